@@ -0,0 +1,9 @@
+--
+-- 为已部署的数据库增加待启用的 TOTP 密钥列
+-- 生成的 TOTP 密钥先保存为待启用密钥，用户提交正确的一次性密码后才启用
+--
+
+USE `questionnaire`;
+
+ALTER TABLE `user`
+  ADD COLUMN `otp_pending_secret` varchar(128) NOT NULL DEFAULT '' COMMENT '加密后的待启用 TOTP 密钥，校验通过后成为 otp_secret' AFTER `otp_last_step`;
@@ -0,0 +1,9 @@
+--
+-- 为已部署的数据库增加问卷地域限制列
+-- 保存允许访问问卷的国家代码（ISO 3166-1 alpha-2），以逗号分隔，为空表示不限制
+--
+
+USE `questionnaire`;
+
+ALTER TABLE `questionnaires`
+  ADD COLUMN `geo_restriction` varchar(255) NOT NULL DEFAULT '' COMMENT '允许访问的国家代码，以逗号分隔，为空表示不限制' AFTER `status`;
@@ -0,0 +1,27 @@
+--
+-- 为已部署的数据库增加用户问卷范围表，并为已有用户授予全部范围
+-- 引入范围校验前所有用户都可以管理全部问卷，迁移后保持原有权限，再由管理员按需收窄
+--
+
+USE `questionnaire`;
+
+CREATE TABLE IF NOT EXISTS `user_scopes` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) unsigned NOT NULL,
+  `scope` varchar(100) NOT NULL COMMENT '问卷编码或通配模式，如 *、phq*',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  `created_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `updated_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `deleted_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
+
+INSERT INTO `user_scopes` (`user_id`, `scope`)
+SELECT u.`id`, '*'
+FROM `user` u
+WHERE NOT EXISTS (
+  SELECT 1 FROM `user_scopes` s WHERE s.`user_id` = u.`id` AND s.`deleted_at` IS NULL
+);
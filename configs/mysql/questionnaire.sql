@@ -23,8 +23,100 @@ CREATE TABLE `user` (
   `phone` varchar(16) NOT NULL,
   `introduction` varchar(1024) NOT NULL,
   `status` tinyint(4) NOT NULL DEFAULT '1' COMMENT '1: 正常, 2: 禁用',
+  `otp_secret` varchar(128) NOT NULL DEFAULT '' COMMENT '加密后的 TOTP 密钥，为空表示未开启二次验证',
+  `otp_last_step` bigint(20) NOT NULL DEFAULT '0' COMMENT '最近一次使用的 TOTP 时间步，用于防重放',
+  `otp_pending_secret` varchar(128) NOT NULL DEFAULT '' COMMENT '加密后的待启用 TOTP 密钥，校验通过后成为 otp_secret',
   `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
   `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
   PRIMARY KEY (`id`),
   UNIQUE KEY `username` (`username`)
-) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
\ No newline at end of file
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
+
+--
+-- Table structure for table `user_scopes`
+--
+
+DROP TABLE IF EXISTS `user_scopes`;
+CREATE TABLE `user_scopes` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `user_id` bigint(20) unsigned NOT NULL,
+  `scope` varchar(100) NOT NULL COMMENT '问卷编码或通配模式，如 *、phq*',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  `created_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `updated_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `deleted_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `idx_user_id` (`user_id`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
+
+--
+-- Table structure for table `notification_hooks`
+--
+
+DROP TABLE IF EXISTS `notification_hooks`;
+CREATE TABLE `notification_hooks` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `questionnaire_code` varchar(255) NOT NULL,
+  `url` varchar(1024) NOT NULL,
+  `secret` varchar(255) NOT NULL COMMENT '用于计算 X-Hub-Signature-256 签名的密钥',
+  `events` varchar(255) NOT NULL COMMENT '订阅的事件，以逗号分隔，如 sheet.submitted,report.generated',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  `created_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `updated_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `deleted_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `idx_questionnaire_code` (`questionnaire_code`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
+
+--
+-- Table structure for table `notification_hook_deliveries`
+--
+
+DROP TABLE IF EXISTS `notification_hook_deliveries`;
+CREATE TABLE `notification_hook_deliveries` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `hook_id` bigint(20) unsigned NOT NULL,
+  `event` varchar(50) NOT NULL,
+  `questionnaire_code` varchar(255) NOT NULL,
+  `attempt` int(11) NOT NULL COMMENT '第几次尝试，从 1 开始',
+  `status_code` int(11) NOT NULL DEFAULT '0' COMMENT '对方返回的 HTTP 状态码，未收到响应时为 0',
+  `error` varchar(1024) NOT NULL DEFAULT '',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  `created_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `updated_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `deleted_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  KEY `idx_hook_id` (`hook_id`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
+
+--
+-- Table structure for table `assignments`
+--
+
+DROP TABLE IF EXISTS `assignments`;
+CREATE TABLE `assignments` (
+  `id` bigint(20) unsigned NOT NULL AUTO_INCREMENT,
+  `assignment_code` varchar(64) NOT NULL,
+  `respondent_id` bigint(20) unsigned NOT NULL COMMENT '受试者ID',
+  `questionnaire_code` varchar(255) NOT NULL,
+  `assigned_by` bigint(20) unsigned NOT NULL DEFAULT '0' COMMENT '分配人的用户ID',
+  `due_date` timestamp NULL DEFAULT NULL COMMENT '截止日期，为空表示不限期',
+  `completed_at` timestamp NULL DEFAULT NULL COMMENT '完成时间，受试者提交该问卷的答卷后记录',
+  `reminder_sent_at` timestamp NULL DEFAULT NULL COMMENT '逾期提醒的发送时间，每个分配只提醒一次',
+  `created_at` timestamp NOT NULL DEFAULT current_timestamp(),
+  `updated_at` timestamp NOT NULL DEFAULT current_timestamp() ON UPDATE current_timestamp(),
+  `deleted_at` timestamp NULL DEFAULT NULL,
+  `created_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `updated_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  `deleted_by` bigint(20) unsigned NOT NULL DEFAULT '0',
+  PRIMARY KEY (`id`),
+  UNIQUE KEY `uk_assignment_code` (`assignment_code`),
+  KEY `idx_respondent_questionnaire` (`respondent_id`, `questionnaire_code`),
+  KEY `idx_due_date` (`due_date`)
+) ENGINE=InnoDB AUTO_INCREMENT=1 DEFAULT CHARSET=utf8;
@@ -1,6 +1,7 @@
 package apiserver
 
 import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/cmd"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/config"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/options"
 	"github.com/yshujie/questionnaire-scale/pkg/app"
@@ -21,6 +22,7 @@ func NewApp(basename string) *app.App {
 		app.WithDefaultValidArgs(),
 		app.WithOptions(opts),
 		app.WithRunFunc(run(opts)),
+		app.WithCommands(cmd.NewExportCommand(), cmd.NewDoctorCommand()),
 	)
 
 	return application
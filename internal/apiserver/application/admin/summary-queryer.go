@@ -0,0 +1,124 @@
+package admin
+
+import (
+	"context"
+	"time"
+
+	"golang.org/x/sync/errgroup"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// SummaryCacheTTL 系统概览的缓存时间
+	SummaryCacheTTL = 5 * time.Minute
+	// recentSubmissionDays 统计最近提交答卷的天数
+	recentSubmissionDays = 7
+	// topQuestionnaireLimit 提交答卷最多的问卷数量
+	topQuestionnaireLimit = 5
+)
+
+// SummaryQueryer 系统概览查询服务
+type SummaryQueryer struct {
+	statsRepo port.SystemStatsRepository
+	cache     port.SystemSummaryCache
+	now       func() time.Time
+}
+
+// NewSummaryQueryer 创建系统概览查询服务，cache 为空时每次都重新统计
+func NewSummaryQueryer(statsRepo port.SystemStatsRepository, cache port.SystemSummaryCache) port.SystemSummaryQueryer {
+	return &SummaryQueryer{
+		statsRepo: statsRepo,
+		cache:     cache,
+		now:       time.Now,
+	}
+}
+
+// GetSystemSummary 获取系统概览，优先使用缓存，缓存读写失败不影响统计结果
+func (q *SummaryQueryer) GetSystemSummary(ctx context.Context) (*admin.SystemSummary, error) {
+	if q.cache != nil {
+		cached, err := q.cache.Get(ctx)
+		if err != nil {
+			log.Warnf("Get cached system summary failed: %v", err)
+		} else if cached != nil {
+			return cached, nil
+		}
+	}
+
+	summary, err := q.collect(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if q.cache != nil {
+		if err := q.cache.Set(ctx, summary, SummaryCacheTTL); err != nil {
+			log.Warnf("Cache system summary failed: %v", err)
+		}
+	}
+	return summary, nil
+}
+
+// collect 并发执行各项统计
+func (q *SummaryQueryer) collect(ctx context.Context) (*admin.SystemSummary, error) {
+	now := q.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	since := today.AddDate(0, 0, -(recentSubmissionDays - 1))
+
+	summary := &admin.SystemSummary{GeneratedAt: now}
+	var daily []admin.DailyCount
+
+	g, ctx := errgroup.WithContext(ctx)
+	counts := []struct {
+		target *int64
+		count  func(context.Context) (int64, error)
+	}{
+		{&summary.TotalQuestionnaires, q.statsRepo.CountQuestionnaires},
+		{&summary.ActiveQuestionnaires, q.statsRepo.CountActiveQuestionnaires},
+		{&summary.TotalAnswersheets, q.statsRepo.CountAnswerSheets},
+		{&summary.TotalRespondents, q.statsRepo.CountRespondents},
+		{&summary.TotalMedicalScales, q.statsRepo.CountMedicalScales},
+	}
+	for _, c := range counts {
+		c := c
+		g.Go(func() error {
+			n, err := c.count(ctx)
+			*c.target = n
+			return err
+		})
+	}
+	g.Go(func() error {
+		var err error
+		daily, err = q.statsRepo.CountDailySubmissions(ctx, since)
+		return err
+	})
+	g.Go(func() error {
+		var err error
+		summary.TopQuestionnaires, err = q.statsRepo.FindTopQuestionnaires(ctx, topQuestionnaireLimit)
+		return err
+	})
+	if err := g.Wait(); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "collect system summary failed")
+	}
+
+	summary.RecentSubmissions = fillDailyCounts(daily, since, recentSubmissionDays)
+	return summary, nil
+}
+
+// fillDailyCounts 补齐从 since 开始连续 days 天的每日数量，没有记录的日期数量为 0
+func fillDailyCounts(counts []admin.DailyCount, since time.Time, days int) []admin.DailyCount {
+	byDate := make(map[string]int64, len(counts))
+	for _, c := range counts {
+		byDate[c.Date] = c.Count
+	}
+
+	result := make([]admin.DailyCount, 0, days)
+	for i := 0; i < days; i++ {
+		date := since.AddDate(0, 0, i).Format("2006-01-02")
+		result = append(result, admin.DailyCount{Date: date, Count: byDate[date]})
+	}
+	return result
+}
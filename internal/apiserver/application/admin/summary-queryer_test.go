@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// fakeStatsRepo 返回固定统计结果的存储库，calls 记录统计次数
+type fakeStatsRepo struct {
+	calls int32
+	err   error
+}
+
+func (r *fakeStatsRepo) CountQuestionnaires(ctx context.Context) (int64, error) {
+	atomic.AddInt32(&r.calls, 1)
+	return 10, nil
+}
+
+func (r *fakeStatsRepo) CountActiveQuestionnaires(ctx context.Context) (int64, error) {
+	return 4, nil
+}
+
+func (r *fakeStatsRepo) CountAnswerSheets(ctx context.Context) (int64, error) {
+	return 120, r.err
+}
+
+func (r *fakeStatsRepo) CountRespondents(ctx context.Context) (int64, error) {
+	return 80, nil
+}
+
+func (r *fakeStatsRepo) CountMedicalScales(ctx context.Context) (int64, error) {
+	return 3, nil
+}
+
+func (r *fakeStatsRepo) CountDailySubmissions(ctx context.Context, since time.Time) ([]admin.DailyCount, error) {
+	return []admin.DailyCount{
+		{Date: since.Format("2006-01-02"), Count: 2},
+		{Date: since.AddDate(0, 0, 6).Format("2006-01-02"), Count: 5},
+	}, nil
+}
+
+func (r *fakeStatsRepo) FindTopQuestionnaires(ctx context.Context, limit int) ([]admin.QuestionnaireUsage, error) {
+	return []admin.QuestionnaireUsage{{QuestionnaireCode: "phq9", Title: "PHQ-9", SubmissionCount: 60}}, nil
+}
+
+// memorySummaryCache 内存中的系统概览缓存
+type memorySummaryCache struct {
+	summary *admin.SystemSummary
+	ttl     time.Duration
+}
+
+func (c *memorySummaryCache) Get(ctx context.Context) (*admin.SystemSummary, error) {
+	return c.summary, nil
+}
+
+func (c *memorySummaryCache) Set(ctx context.Context, summary *admin.SystemSummary, ttl time.Duration) error {
+	c.summary, c.ttl = summary, ttl
+	return nil
+}
+
+func newTestSummaryQueryer(repo *fakeStatsRepo, cache *memorySummaryCache) *SummaryQueryer {
+	q := NewSummaryQueryer(repo, cache).(*SummaryQueryer)
+	q.now = func() time.Time { return time.Date(2024, 3, 10, 15, 30, 0, 0, time.UTC) }
+	return q
+}
+
+func TestSummaryQueryer_GetSystemSummary(t *testing.T) {
+	repo, cache := &fakeStatsRepo{}, &memorySummaryCache{}
+	q := newTestSummaryQueryer(repo, cache)
+
+	summary, err := q.GetSystemSummary(context.Background())
+	if err != nil {
+		t.Fatalf("GetSystemSummary() error = %v", err)
+	}
+
+	if summary.TotalQuestionnaires != 10 || summary.ActiveQuestionnaires != 4 || summary.TotalAnswersheets != 120 ||
+		summary.TotalRespondents != 80 || summary.TotalMedicalScales != 3 {
+		t.Errorf("totals = %+v", summary)
+	}
+	if len(summary.TopQuestionnaires) != 1 || summary.TopQuestionnaires[0].QuestionnaireCode != "phq9" {
+		t.Errorf("TopQuestionnaires = %+v", summary.TopQuestionnaires)
+	}
+
+	// 最近 7 天连续补齐，没有提交的日期为 0
+	want := []admin.DailyCount{
+		{Date: "2024-03-04", Count: 2},
+		{Date: "2024-03-05"},
+		{Date: "2024-03-06"},
+		{Date: "2024-03-07"},
+		{Date: "2024-03-08"},
+		{Date: "2024-03-09"},
+		{Date: "2024-03-10", Count: 5},
+	}
+	if len(summary.RecentSubmissions) != len(want) {
+		t.Fatalf("RecentSubmissions = %+v, want %+v", summary.RecentSubmissions, want)
+	}
+	for i := range want {
+		if summary.RecentSubmissions[i] != want[i] {
+			t.Errorf("RecentSubmissions[%d] = %+v, want %+v", i, summary.RecentSubmissions[i], want[i])
+		}
+	}
+
+	// 第二次查询命中缓存，不再统计
+	if cache.ttl != SummaryCacheTTL {
+		t.Errorf("cache ttl = %v, want %v", cache.ttl, SummaryCacheTTL)
+	}
+	if _, err := q.GetSystemSummary(context.Background()); err != nil {
+		t.Fatalf("GetSystemSummary() error = %v", err)
+	}
+	if repo.calls != 1 {
+		t.Errorf("stats collected %d times, want 1", repo.calls)
+	}
+}
+
+func TestSummaryQueryer_GetSystemSummaryError(t *testing.T) {
+	repo := &fakeStatsRepo{err: errors.New("connection refused")}
+	cache := &memorySummaryCache{}
+	q := newTestSummaryQueryer(repo, cache)
+
+	if _, err := q.GetSystemSummary(context.Background()); !errors.IsCode(err, code.ErrDatabase) {
+		t.Errorf("GetSystemSummary() error = %v, want ErrDatabase", err)
+	}
+	if cache.summary != nil {
+		t.Error("failed summary was cached")
+	}
+}
@@ -0,0 +1,51 @@
+package answersheet
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// Cleaner 软删除答卷清理任务
+// 物理删除软删除时间超过保留时长的答卷
+type Cleaner struct {
+	aRepoMongo port.AnswerSheetRepositoryMongo
+	schedule   string
+	retention  time.Duration
+}
+
+// NewCleaner 创建软删除答卷清理任务，schedule 为 cron 表达式，retention 为软删除答卷的保留时长
+func NewCleaner(aRepoMongo port.AnswerSheetRepositoryMongo, schedule string, retention time.Duration) *Cleaner {
+	return &Cleaner{
+		aRepoMongo: aRepoMongo,
+		schedule:   schedule,
+		retention:  retention,
+	}
+}
+
+// Name 任务名称
+func (c *Cleaner) Name() string {
+	return "answersheet-soft-delete-cleanup"
+}
+
+// Schedule cron 表达式
+func (c *Cleaner) Schedule() string {
+	return c.schedule
+}
+
+// Run 执行清理
+func (c *Cleaner) Run(ctx context.Context) error {
+	deletedBefore := time.Now().Add(-c.retention)
+
+	count, err := c.aRepoMongo.PurgeDeleted(ctx, deletedBefore)
+	if err != nil {
+		return errors.WrapC(err, errCode.ErrDatabase, "清理软删除答卷失败")
+	}
+
+	log.Infof("清理软删除答卷完成，删除数量: %d, 删除时间早于: %s", count, deletedBefore.Format(time.RFC3339))
+	return nil
+}
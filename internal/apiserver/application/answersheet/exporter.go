@@ -0,0 +1,68 @@
+package answersheet
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Exporter 答卷导出器
+type Exporter struct {
+	aRepoMongo port.AnswerSheetRepositoryMongo
+	mapper     mapper.AnswerMapper
+}
+
+// NewExporter 创建答卷导出器
+func NewExporter(aRepoMongo port.AnswerSheetRepositoryMongo) *Exporter {
+	return &Exporter{
+		aRepoMongo: aRepoMongo,
+		mapper:     mapper.NewAnswerMapper(),
+	}
+}
+
+// Export 按问卷和创建时间范围逐条导出答卷
+func (e *Exporter) Export(ctx context.Context, filter dto.AnswerSheetExportFilterDTO, handle func(record dto.AnswerSheetExportDTO) error) error {
+	if filter.QuestionnaireCode == "" {
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "问卷代码不能为空")
+	}
+	if !filter.From.IsZero() && !filter.To.IsZero() && !filter.From.Before(filter.To) {
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "起始时间必须早于截止时间")
+	}
+
+	// 区分写出失败与查询失败，写出错误原样返回
+	var handleErr error
+	err := e.aRepoMongo.IterateByQuestionnaire(ctx, filter.QuestionnaireCode, filter.From, filter.To, func(sheet *answersheet.AnswerSheet) error {
+		handleErr = handle(e.toExportDTO(sheet))
+		return handleErr
+	})
+	if handleErr != nil {
+		return handleErr
+	}
+	if err != nil {
+		return errors.WrapC(err, errCode.ErrDatabase, "查询导出答卷失败")
+	}
+
+	return nil
+}
+
+// toExportDTO 将答卷领域对象转换为导出记录
+func (e *Exporter) toExportDTO(sheet *answersheet.AnswerSheet) dto.AnswerSheetExportDTO {
+	return dto.AnswerSheetExportDTO{
+		AnswerSheet: dto.AnswerSheetDTO{
+			ID:                   sheet.GetID(),
+			QuestionnaireCode:    sheet.GetQuestionnaireCode(),
+			QuestionnaireVersion: sheet.GetQuestionnaireVersion(),
+			Title:                sheet.GetTitle(),
+			Score:                sheet.GetScore(),
+			WriterID:             getWriterID(sheet.GetWriter()),
+			TesteeID:             getTesteeID(sheet.GetTestee()),
+			Answers:              e.mapper.ToDTOs(sheet.GetAnswers()),
+		},
+		CreatedAt: sheet.GetCreatedAt(),
+	}
+}
@@ -0,0 +1,112 @@
+package answersheet
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/fhir"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// FHIRConverter 答卷 FHIR 导入导出器
+type FHIRConverter struct {
+	aRepoMongo port.AnswerSheetRepositoryMongo
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
+	saver      port.AnswerSheetSaver
+	mapper     mapper.AnswerMapper
+}
+
+// NewFHIRConverter 创建答卷 FHIR 导入导出器
+// 导入的答卷通过 saver 保存，与在线提交的答卷经过相同的校验、通知与事件发布
+func NewFHIRConverter(
+	aRepoMongo port.AnswerSheetRepositoryMongo,
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo,
+	saver port.AnswerSheetSaver,
+) *FHIRConverter {
+	return &FHIRConverter{
+		aRepoMongo: aRepoMongo,
+		qRepoMongo: qRepoMongo,
+		saver:      saver,
+		mapper:     mapper.NewAnswerMapper(),
+	}
+}
+
+// ImportFHIRResponse 导入 FHIR QuestionnaireResponse 答卷
+// 答案按引用的问卷定义校验，校验规则与在线提交的答卷一致，保存的答卷来源为 fhir
+func (f *FHIRConverter) ImportFHIRResponse(ctx context.Context, fhirJSON []byte) (*answersheet.AnswerSheet, error) {
+	resp, err := fhir.ParseResponse(fhirJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	questionnaireCode, version := resp.QuestionnaireRef()
+	qDomain, err := f.findQuestionnaire(ctx, questionnaireCode, version)
+	if err != nil {
+		return nil, err
+	}
+
+	sheet, err := fhir.ToAnswerSheet(resp, qDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := f.saver.SaveOriginalAnswerSheet(ctx, dto.AnswerSheetDTO{
+		QuestionnaireCode:    sheet.GetQuestionnaireCode(),
+		QuestionnaireVersion: sheet.GetQuestionnaireVersion(),
+		Title:                sheet.GetTitle(),
+		WriterID:             sheet.GetWriter().GetUserID().Value(),
+		TesteeID:             sheet.GetTestee().GetUserID().Value(),
+		Answers:              f.mapper.ToDTOs(sheet.GetAnswers()),
+		Source:               sheet.GetSource(),
+		CreatedAt:            sheet.GetCreatedAt(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	sheet.SetID(saved.ID)
+	return sheet, nil
+}
+
+// ExportFHIRResponse 将答卷导出为 FHIR QuestionnaireResponse
+func (f *FHIRConverter) ExportFHIRResponse(ctx context.Context, id uint64) ([]byte, error) {
+	sheet, err := f.aRepoMongo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+	if sheet == nil {
+		return nil, errors.WithCode(errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+
+	qDomain, err := f.findQuestionnaire(ctx, sheet.GetQuestionnaireCode(), sheet.GetQuestionnaireVersion())
+	if err != nil {
+		return nil, err
+	}
+
+	return fhir.FromAnswerSheet(sheet, qDomain)
+}
+
+// findQuestionnaire 查找问卷，未指定版本时使用问卷的当前版本
+func (f *FHIRConverter) findQuestionnaire(ctx context.Context, code, version string) (*questionnaire.Questionnaire, error) {
+	var (
+		qDomain *questionnaire.Questionnaire
+		err     error
+	)
+	if version == "" {
+		qDomain, err = f.qRepoMongo.FindByCode(ctx, code)
+	} else {
+		qDomain, err = f.qRepoMongo.FindByCodeVersion(ctx, code, version)
+	}
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+	if qDomain == nil {
+		return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s", code)
+	}
+	return qDomain, nil
+}
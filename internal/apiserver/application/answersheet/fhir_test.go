@@ -0,0 +1,161 @@
+package answersheet
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/fhir"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newPHQ2Questionnaire 与 testdata 中 FHIR 答卷对应的 PHQ-2 问卷
+func newPHQ2Questionnaire() *questionnaire.Questionnaire {
+	newFrequencyQuestion := func(code, title string) question.Question {
+		return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+			question.WithCode(question.NewQuestionCode(code)),
+			question.WithTitle(title),
+			question.WithQuestionType(question.QuestionTypeRadio),
+			question.WithOption("LA6568-5", "Not at all", 0),
+			question.WithOption("LA6569-3", "Several days", 1),
+			question.WithOption("LA6570-1", "More than half the days", 2),
+			question.WithOption("LA6571-9", "Nearly every day", 3),
+		))
+	}
+
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode("phq-2"),
+		"PHQ-2",
+		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		questionnaire.WithQuestions([]question.Question{
+			newFrequencyQuestion("44250-9", "Little interest or pleasure in doing things"),
+			newFrequencyQuestion("44255-8", "Feeling down, depressed, or hopeless"),
+			question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+				question.WithCode(question.NewQuestionCode("comment")),
+				question.WithTitle("Comment"),
+				question.WithQuestionType(question.QuestionTypeText),
+			)),
+			question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+				question.WithCode(question.NewQuestionCode("55758-7")),
+				question.WithTitle("PHQ-2 total score"),
+				question.WithQuestionType(question.QuestionTypeNumber),
+			)),
+		}),
+	)
+}
+
+func TestFHIRConverter_ImportAndExport(t *testing.T) {
+	data, err := os.ReadFile("testdata/questionnaireresponse-phq.json")
+	if err != nil {
+		t.Fatalf("read testdata: %v", err)
+	}
+
+	aRepo := newFakeAnswerSheetRepo()
+	qRepo := &fakeQuestionnaireRepo{qDomain: newPHQ2Questionnaire()}
+	converter := NewFHIRConverter(aRepo, qRepo, NewSaver(aRepo, qRepo, nil, nil, nil, nil, nil))
+
+	sheet, err := converter.ImportFHIRResponse(context.Background(), data)
+	if err != nil {
+		t.Fatalf("ImportFHIRResponse() error = %v", err)
+	}
+
+	saved := aRepo.sheets[sheet.GetID().Value()]
+	if saved == nil || saved.GetSource() != answersheet.SourceFHIR {
+		t.Fatalf("saved sheet = %+v, want source %q", saved, answersheet.SourceFHIR)
+	}
+	if saved.GetQuestionnaireCode() != "phq-2" || saved.GetQuestionnaireVersion() != "1.0" {
+		t.Errorf("questionnaire = %s@%s, want phq-2@1.0", saved.GetQuestionnaireCode(), saved.GetQuestionnaireVersion())
+	}
+	if saved.GetTestee().GetUserID().Value() != 1001 || saved.GetWriter().GetUserID().Value() != 2002 {
+		t.Errorf("testee/writer = %d/%d, want 1001/2002",
+			saved.GetTestee().GetUserID().Value(), saved.GetWriter().GetUserID().Value())
+	}
+	if want := time.Date(2019, 3, 15, 1, 30, 0, 0, time.UTC); !saved.GetCreatedAt().Equal(want) {
+		t.Errorf("created at = %v, want %v", saved.GetCreatedAt(), want)
+	}
+
+	wantValues := map[string]interface{}{
+		"44250-9": "LA6569-3",
+		"44255-8": "LA6570-1",
+		"comment": "Mostly in the mornings",
+		"55758-7": float64(3),
+	}
+	if len(saved.GetAnswers()) != len(wantValues) {
+		t.Fatalf("answers = %d, want %d", len(saved.GetAnswers()), len(wantValues))
+	}
+	for _, ans := range saved.GetAnswers() {
+		if got := ans.GetValue().Raw(); got != wantValues[ans.GetQuestionCode()] {
+			t.Errorf("answer %s = %v, want %v", ans.GetQuestionCode(), got, wantValues[ans.GetQuestionCode()])
+		}
+	}
+
+	exported, err := converter.ExportFHIRResponse(context.Background(), sheet.GetID().Value())
+	if err != nil {
+		t.Fatalf("ExportFHIRResponse() error = %v", err)
+	}
+	resp, err := fhir.ParseResponse(exported)
+	if err != nil {
+		t.Fatalf("ParseResponse(exported) error = %v", err)
+	}
+	if code, version := resp.QuestionnaireRef(); code != "phq-2" || version != "1.0" {
+		t.Errorf("exported questionnaire = %s|%s, want phq-2|1.0", code, version)
+	}
+	if resp.Subject == nil || resp.Subject.Reference != "Patient/1001" {
+		t.Errorf("exported subject = %+v, want Patient/1001", resp.Subject)
+	}
+	if len(resp.Item) != len(wantValues) {
+		t.Fatalf("exported items = %d, want %d", len(resp.Item), len(wantValues))
+	}
+	if coding := resp.Item[1].Answer[0].ValueCoding; coding == nil || coding.Code != "LA6570-1" || coding.Display != "More than half the days" {
+		t.Errorf("exported answer of %s = %+v, want LA6570-1 coding", resp.Item[1].LinkID, coding)
+	}
+
+	// 导出的答卷可以再次导入
+	if _, err := converter.ImportFHIRResponse(context.Background(), exported); err != nil {
+		t.Errorf("ImportFHIRResponse(exported) error = %v", err)
+	}
+}
+
+func TestFHIRConverter_ImportRejectsInvalidAnswers(t *testing.T) {
+	data, err := os.ReadFile("testdata/questionnaireresponse-phq.json")
+	if err != nil {
+		t.Fatalf("read testdata: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		replace [2]string
+	}{
+		{name: "undefined option", replace: [2]string{`"LA6569-3"`, `"LA9999-9"`}},
+		{name: "undefined question", replace: [2]string{`"linkId": "comment"`, `"linkId": "unknown"`}},
+		{name: "answer type mismatch", replace: [2]string{`"valueInteger": 3`, `"valueString": "3"`}},
+		{name: "subject without user id", replace: [2]string{`"Patient/1001"`, `"Patient/example"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aRepo := newFakeAnswerSheetRepo()
+			qRepo := &fakeQuestionnaireRepo{qDomain: newPHQ2Questionnaire()}
+			converter := NewFHIRConverter(aRepo, qRepo, NewSaver(aRepo, qRepo, nil, nil, nil, nil, nil))
+
+			invalid := strings.Replace(string(data), tt.replace[0], tt.replace[1], 1)
+			if !json.Valid([]byte(invalid)) {
+				t.Fatalf("replacement produced invalid json")
+			}
+
+			_, err := converter.ImportFHIRResponse(context.Background(), []byte(invalid))
+			if !errors.IsCode(err, errCode.ErrAnswerSheetInvalid) {
+				t.Errorf("ImportFHIRResponse() error = %v, want ErrAnswerSheetInvalid", err)
+			}
+			if len(aRepo.sheets) != 0 {
+				t.Errorf("saved %d sheets, want none", len(aRepo.sheets))
+			}
+		})
+	}
+}
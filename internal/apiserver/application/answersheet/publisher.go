@@ -0,0 +1,21 @@
+package answersheet
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+)
+
+// SubmissionPublishers 依次向多个发布者发布答卷提交事件，忽略为空的发布者
+type SubmissionPublishers []port.SubmissionPublisher
+
+// 确保实现了接口
+var _ port.SubmissionPublisher = SubmissionPublishers(nil)
+
+// PublishSubmitted 发布答卷提交事件
+func (p SubmissionPublishers) PublishSubmitted(event answersheet.AnswerSheetSubmitted) {
+	for _, publisher := range p {
+		if publisher != nil {
+			publisher.PublishSubmitted(event)
+		}
+	}
+}
@@ -62,12 +62,6 @@ func (q *Queryer) GetAnswerSheetByID(ctx context.Context, id uint64) (*dto.Answe
 		return nil, errors.WrapC(err, errCode.ErrQuestionnaireNotFound, "问卷不存在")
 	}
 
-	// 检查问卷是否存在
-	if qDomain == nil {
-		log.Warnf("Questionnaire with code %s not found", aDomain.GetQuestionnaireCode())
-		return nil, errors.WrapC(nil, errCode.ErrQuestionnaireNotFound, "问卷不存在")
-	}
-
 	// 3. 转换为 DTO
 	answerSheetDTO := &dto.AnswerSheetDTO{
 		ID:                   aDomain.GetID(),
@@ -148,6 +142,46 @@ func (q *Queryer) GetAnswerSheetList(ctx context.Context, filter dto.AnswerSheet
 	return answerSheets, total, nil
 }
 
+// GetAnswerSheetProgress 获取答卷作答进度
+func (q *Queryer) GetAnswerSheetProgress(ctx context.Context, id uint64) (*dto.ProgressReportDTO, error) {
+	// 1. 获取答卷领域对象
+	aDomain, err := q.aRepoMongo.FindByID(ctx, id)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+	if aDomain == nil {
+		return nil, errors.WithCode(errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+
+	// 2. 获取答卷对应版本的问卷
+	qDomain, err := q.qRepoMongo.FindByCodeVersion(ctx, aDomain.GetQuestionnaireCode(), aDomain.GetQuestionnaireVersion())
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+
+	// 3. 计算作答进度
+	report := answersheet.NewProgressReport(qDomain, aDomain)
+
+	sections := make([]dto.SectionProgressItemDTO, 0, len(report.SectionProgress))
+	for _, item := range report.SectionProgress {
+		sections = append(sections, dto.SectionProgressItemDTO{
+			SectionCode:       item.SectionCode.Value(),
+			SectionTitle:      item.SectionTitle,
+			TotalQuestions:    item.TotalQuestions,
+			AnsweredQuestions: item.AnsweredQuestions,
+			IsComplete:        item.IsComplete,
+		})
+	}
+
+	return &dto.ProgressReportDTO{
+		TotalQuestions:    report.TotalQuestions,
+		AnsweredQuestions: report.AnsweredQuestions,
+		IsComplete:        report.IsComplete,
+		SectionProgress:   sections,
+		CurrentSection:    report.CurrentSection.Value(),
+	}, nil
+}
+
 // convertDomainsToAnswerSheetDTOs 将领域对象列表转换为 DTO 列表
 func (q *Queryer) convertDomainsToAnswerSheetDTOs(domains []*answersheet.AnswerSheet) []dto.AnswerSheetDTO {
 	dtos := make([]dto.AnswerSheetDTO, len(domains))
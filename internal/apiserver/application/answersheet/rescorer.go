@@ -0,0 +1,204 @@
+package answersheet
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	msPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// rescoreBatchSize 每批读取和更新的答卷数
+	rescoreBatchSize = 500
+	// maxRescoreReportItems 结果中保留的得分变化和错误的最大条数
+	maxRescoreReportItems = 100
+)
+
+// Rescorer 答卷重新计分器
+type Rescorer struct {
+	aRepoMongo port.AnswerSheetRepositoryMongo
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
+	msRepo     msPort.MedicalScaleRepositoryMongo
+	publisher  port.RescorePublisher
+	now        func() time.Time
+}
+
+// NewRescorer 创建答卷重新计分器，保存后通过 publisher 发布答卷重新计分事件（可为空）
+func NewRescorer(
+	aRepoMongo port.AnswerSheetRepositoryMongo,
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo,
+	msRepo msPort.MedicalScaleRepositoryMongo,
+	publisher port.RescorePublisher,
+) *Rescorer {
+	return &Rescorer{
+		aRepoMongo: aRepoMongo,
+		qRepoMongo: qRepoMongo,
+		msRepo:     msRepo,
+		publisher:  publisher,
+		now:        time.Now,
+	}
+}
+
+// 确保实现了接口
+var _ port.AnswerSheetRescorer = (*Rescorer)(nil)
+
+// RescoreByScale 按量表最新的计分规则重新计算其关联问卷的全部答卷得分
+// 每批读取 500 份答卷，只保存得分有变化的答卷；单份答卷无法计分时记录错误并继续
+func (r *Rescorer) RescoreByScale(ctx context.Context, scaleCode string, dryRun bool) (*dto.RescoreResultDTO, error) {
+	// 1. 获取医学量表
+	if scaleCode == "" {
+		return nil, errors.WithCode(errCode.ErrMedicalScaleInvalidInput, "医学量表编码不能为空")
+	}
+	ms, err := r.msRepo.FindByCode(ctx, scaleCode)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "获取医学量表失败")
+	}
+	if ms == nil {
+		return nil, errors.WithCode(errCode.ErrMedicalScaleNotFound, "医学量表不存在: %s", scaleCode)
+	}
+	questionnaireCode := ms.GetQuestionnaireCode()
+	if questionnaireCode == "" {
+		return nil, errors.WithCode(errCode.ErrMedicalScaleInvalid, "医学量表 %s 未关联问卷", scaleCode)
+	}
+
+	// 2. 分批重新计分
+	result := &dto.RescoreResultDTO{ScaleCode: scaleCode, DryRun: dryRun}
+	engine := ms.NewScoringRuleEngine()
+	versions := make(map[string]*questionnaire.Questionnaire)
+	var afterID uint64
+	for {
+		batch, err := r.aRepoMongo.FindBatchByQuestionnaire(ctx, questionnaireCode, afterID, rescoreBatchSize)
+		if err != nil {
+			return nil, errors.WrapC(err, errCode.ErrDatabase, "查询答卷失败")
+		}
+		if len(batch) == 0 {
+			break
+		}
+		afterID = batch[len(batch)-1].GetID().Value()
+
+		var changed, original []*answersheet.AnswerSheet
+		for _, sheet := range batch {
+			result.TotalProcessed++
+			scored, err := r.rescore(ctx, sheet, engine, versions)
+			if err != nil {
+				addRescoreError(result, fmt.Sprintf("答卷 %d: %v", sheet.GetID().Value(), err))
+				continue
+			}
+			if !scoresChanged(sheet, scored) {
+				continue
+			}
+			changed = append(changed, scored)
+			original = append(original, sheet)
+		}
+
+		// 3. 保存得分变化的答卷并发布事件
+		if !dryRun && len(changed) > 0 {
+			if err := r.aRepoMongo.UpdateScores(ctx, changed); err != nil {
+				addRescoreError(result, fmt.Sprintf("批量更新 %d 份答卷得分失败: %v", len(changed), err))
+				continue
+			}
+			r.publishRescored(ms.GetCode(), original, changed)
+		}
+		result.TotalUpdated += len(changed)
+		for i, scored := range changed {
+			if len(result.Changes) >= maxRescoreReportItems {
+				break
+			}
+			result.Changes = append(result.Changes, dto.ScoreChangeDTO{
+				AnswerSheetID: scored.GetID().Value(),
+				OldScore:      original[i].GetScore(),
+				NewScore:      scored.GetScore(),
+			})
+		}
+
+		if len(batch) < rescoreBatchSize {
+			break
+		}
+	}
+
+	log.Infof("答卷重新计分完成，量表编码: %s, 预览: %t, 处理: %d, 更新: %d, 错误: %d",
+		scaleCode, dryRun, result.TotalProcessed, result.TotalUpdated, len(result.Errors))
+	return result, nil
+}
+
+// rescore 按答卷所答问卷版本和量表计分规则重新计分，同一版本的问卷只查询一次
+func (r *Rescorer) rescore(
+	ctx context.Context,
+	sheet *answersheet.AnswerSheet,
+	engine *scoring.ScoringRuleEngine,
+	versions map[string]*questionnaire.Questionnaire,
+) (*answersheet.AnswerSheet, error) {
+	version := sheet.GetQuestionnaireVersion()
+	qDomain, ok := versions[version]
+	if !ok {
+		var err error
+		qDomain, err = r.qRepoMongo.FindByCodeVersion(ctx, sheet.GetQuestionnaireCode(), version)
+		if err != nil {
+			// 问卷版本不存在时缓存结果，同一版本的其余答卷不再查询
+			if errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+				versions[version] = nil
+			}
+			return nil, err
+		}
+		versions[version] = qDomain
+	}
+	if qDomain == nil {
+		return nil, fmt.Errorf("问卷 %s@%s 不存在", sheet.GetQuestionnaireCode(), version)
+	}
+
+	scored, _ := answersheet.ScoreAnswerSheetWithEngine(qDomain, sheet, engine)
+	return scored, nil
+}
+
+// publishRescored 发布答卷重新计分事件
+func (r *Rescorer) publishRescored(scaleCode string, original, changed []*answersheet.AnswerSheet) {
+	if r.publisher == nil {
+		return
+	}
+	rescoredAt := r.now()
+	for i, scored := range changed {
+		r.publisher.PublishRescored(answersheet.AnswerSheetRescored{
+			AnswerSheetID:     scored.GetID().Value(),
+			QuestionnaireCode: scored.GetQuestionnaireCode(),
+			ScaleCode:         scaleCode,
+			OldScore:          original[i].GetScore(),
+			NewScore:          scored.GetScore(),
+			RescoredAt:        rescoredAt,
+		})
+	}
+}
+
+// addRescoreError 记录重新计分错误，超过 maxRescoreReportItems 条后只写日志
+func addRescoreError(result *dto.RescoreResultDTO, message string) {
+	if len(result.Errors) < maxRescoreReportItems {
+		result.Errors = append(result.Errors, message)
+	}
+	log.Warnf("答卷重新计分失败，量表编码: %s, %s", result.ScaleCode, message)
+}
+
+// scoresChanged 判断重新计分后答卷总分或任一题目得分是否变化
+func scoresChanged(before, after *answersheet.AnswerSheet) bool {
+	if before.GetScore() != after.GetScore() {
+		return true
+	}
+	beforeAnswers, afterAnswers := before.GetAnswers(), after.GetAnswers()
+	if len(beforeAnswers) != len(afterAnswers) {
+		return true
+	}
+	for i := range beforeAnswers {
+		if beforeAnswers[i].GetScore() != afterAnswers[i].GetScore() {
+			return true
+		}
+	}
+	return false
+}
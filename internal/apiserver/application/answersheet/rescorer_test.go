@@ -0,0 +1,122 @@
+package answersheet
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
+
+// recordingRescorePublisher 记录发布的答卷重新计分事件
+type recordingRescorePublisher struct {
+	events []answersheet.AnswerSheetRescored
+}
+
+func (p *recordingRescorePublisher) PublishRescored(event answersheet.AnswerSheetRescored) {
+	p.events = append(p.events, event)
+}
+
+// newScoredAnswerSheet 创建两道单选题的答卷，各题得分和总分按给定值保存
+func newScoredAnswerSheet(t *testing.T, id uint64, q1Score, q2Score, total float64) *answersheet.AnswerSheet {
+	t.Helper()
+	q1, err := answer.NewAnswer(question.NewQuestionCode("Q1"), question.QuestionTypeRadio, q1Score, "B")
+	if err != nil {
+		t.Fatalf("NewAnswer() error = %v", err)
+	}
+	q2, err := answer.NewAnswer(question.NewQuestionCode("Q2"), question.QuestionTypeRadio, q2Score, "C")
+	if err != nil {
+		t.Fatalf("NewAnswer() error = %v", err)
+	}
+	return answersheet.NewAnswerSheet("QN1", "1.0",
+		answersheet.WithID(v1.NewID(id)),
+		answersheet.WithScore(total),
+		answersheet.WithAnswers([]answer.Answer{q1, q2}),
+	)
+}
+
+func newRescorerFixture(t *testing.T) (*fakeAnswerSheetRepo, *fakeQuestionnaireRepo, *fakeMedicalScaleRepo) {
+	aRepo := newFakeAnswerSheetRepo()
+	// 答卷 1 按旧的选项分值计分，答卷 2 的得分与最新计分规则一致
+	aRepo.sheets[1] = newScoredAnswerSheet(t, 1, 0, 1, 1)
+	aRepo.sheets[2] = newScoredAnswerSheet(t, 2, 1, 2, 3)
+
+	qRepo := &fakeQuestionnaireRepo{
+		qDomain: questionnaire.NewQuestionnaire(
+			questionnaire.NewQuestionnaireCode("QN1"),
+			"焦虑自评",
+			questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+			questionnaire.WithQuestions([]question.Question{newRadioQuestion("Q1"), newRadioQuestion("Q2")}),
+		),
+	}
+	msRepo := &fakeMedicalScaleRepo{
+		ms: medicalscale.NewMedicalScale("MS1", "焦虑量表",
+			medicalscale.WithQuestionnaireCode("QN1"),
+			medicalscale.WithFactors([]factor.Factor{newTotalScoreFactor()}),
+		),
+	}
+	return aRepo, qRepo, msRepo
+}
+
+func TestRescorer_RescoreByScale(t *testing.T) {
+	aRepo, qRepo, msRepo := newRescorerFixture(t)
+	publisher := &recordingRescorePublisher{}
+	rescoredAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	rescorer := NewRescorer(aRepo, qRepo, msRepo, publisher)
+	rescorer.now = func() time.Time { return rescoredAt }
+
+	// 预览时只报告得分变化
+	preview, err := rescorer.RescoreByScale(context.Background(), "MS1", true)
+	if err != nil {
+		t.Fatalf("RescoreByScale(dryRun) error = %v", err)
+	}
+	if preview.TotalProcessed != 2 || preview.TotalUpdated != 1 || len(preview.Changes) != 1 {
+		t.Fatalf("dry run result = %+v, want 2 processed and 1 changed", preview)
+	}
+	if change := preview.Changes[0]; change.AnswerSheetID != 1 || change.OldScore != 1 || change.NewScore != 3 {
+		t.Errorf("dry run change = %+v, want answer sheet 1 from 1 to 3", change)
+	}
+	if aRepo.sheets[1].GetScore() != 1 || len(publisher.events) != 0 {
+		t.Fatalf("dry run saved score %v and published %d events, want nothing written", aRepo.sheets[1].GetScore(), len(publisher.events))
+	}
+
+	result, err := rescorer.RescoreByScale(context.Background(), "MS1", false)
+	if err != nil {
+		t.Fatalf("RescoreByScale() error = %v", err)
+	}
+	if result.TotalProcessed != 2 || result.TotalUpdated != 1 || len(result.Errors) != 0 {
+		t.Errorf("result = %+v, want 2 processed and 1 updated", result)
+	}
+	saved := aRepo.sheets[1]
+	if saved.GetScore() != 3 || saved.GetAnswers()[0].GetScore() != 1 {
+		t.Errorf("saved total = %v, Q1 = %v, want 3 and 1", saved.GetScore(), saved.GetAnswers()[0].GetScore())
+	}
+	want := answersheet.AnswerSheetRescored{
+		AnswerSheetID:     1,
+		QuestionnaireCode: "QN1",
+		ScaleCode:         "MS1",
+		OldScore:          1,
+		NewScore:          3,
+		RescoredAt:        rescoredAt,
+	}
+	if len(publisher.events) != 1 || publisher.events[0] != want {
+		t.Errorf("published events = %+v, want [%+v]", publisher.events, want)
+	}
+}
+
+func TestRescorer_RescoreByScale_ScaleNotFound(t *testing.T) {
+	aRepo, qRepo, _ := newRescorerFixture(t)
+	rescorer := NewRescorer(aRepo, qRepo, &fakeMedicalScaleRepo{}, nil)
+
+	if _, err := rescorer.RescoreByScale(context.Background(), "MS404", false); !errors.IsCode(err, errCode.ErrMedicalScaleNotFound) {
+		t.Errorf("RescoreByScale() error = %v, want ErrMedicalScaleNotFound", err)
+	}
+}
@@ -2,11 +2,21 @@ package answersheet
 
 import (
 	"context"
+	"strings"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	hookPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/ability"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
@@ -16,22 +26,50 @@ import (
 // Saver 答卷保存器
 type Saver struct {
 	aRepoMongo port.AnswerSheetRepositoryMongo
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
+	notifier   hookPort.EventNotifier
+	publisher  port.SubmissionPublisher
+	fileRepo   port.FileStorageRepository
+	uploadRepo port.PendingUploadRepository
+	objects    port.ObjectStorageInspector
 	mapper     mapper.AnswerMapper
 }
 
-// NewSaver 创建答卷保存器
-func NewSaver(aRepoMongo port.AnswerSheetRepositoryMongo) *Saver {
+// NewSaver 创建答卷保存器，notifier 不为空时在答卷保存后通知 sheet.submitted 事件，
+// publisher 不为空时在答卷保存后发布答卷提交事件
+// fileRepo 为空时不支持以存储键引用预上传的文件，uploadRepo 或 objects 为空时不支持以 upload_id 引用签名上传的文件
+func NewSaver(
+	aRepoMongo port.AnswerSheetRepositoryMongo,
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo,
+	notifier hookPort.EventNotifier,
+	publisher port.SubmissionPublisher,
+	fileRepo port.FileStorageRepository,
+	uploadRepo port.PendingUploadRepository,
+	objects port.ObjectStorageInspector,
+) *Saver {
 	return &Saver{
 		aRepoMongo: aRepoMongo,
+		qRepoMongo: qRepoMongo,
+		notifier:   notifier,
+		publisher:  publisher,
+		fileRepo:   fileRepo,
+		uploadRepo: uploadRepo,
+		objects:    objects,
 		mapper:     mapper.NewAnswerMapper(),
 	}
 }
 
 // SaveOriginalAnswerSheet 保存原始答卷
 func (s *Saver) SaveOriginalAnswerSheet(ctx context.Context, answerSheetDTO dto.AnswerSheetDTO) (*dto.AnswerSheetDTO, error) {
+	saved, _, err := s.saveOriginal(ctx, answerSheetDTO)
+	return saved, err
+}
+
+// saveOriginal 保存原始答卷，同时返回答卷消费的签名上传，供调用方回滚答卷时恢复
+func (s *Saver) saveOriginal(ctx context.Context, answerSheetDTO dto.AnswerSheetDTO) (*dto.AnswerSheetDTO, []*answersheet.PendingUpload, error) {
 	// 1. 参数校验
 	if err := s.validateAnswerSheet(answerSheetDTO); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// 2. 转换为领域对象
@@ -39,18 +77,68 @@ func (s *Saver) SaveOriginalAnswerSheet(ctx context.Context, answerSheetDTO dto.
 	testee := user.NewTestee(user.NewUserID(answerSheetDTO.TesteeID), "")
 	answers := s.mapper.ToBOs(answerSheetDTO.Answers)
 
+	qDomain, err := s.qRepoMongo.FindByCodeVersion(ctx, answerSheetDTO.QuestionnaireCode, answerSheetDTO.QuestionnaireVersion)
+	if err != nil {
+		return nil, nil, errors.WrapC(err, errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+
+	// 以存储记录中的文件元信息替换客户端提交的文件引用，签名上传的 upload_id 替换为对应的存储键
+	answers, uploads, err := s.resolveUploads(ctx, answerSheetDTO.QuestionnaireCode, answers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 校验文件上传题的答案是否满足题目约束
+	if err := s.validateFileAnswers(qDomain, answers); err != nil {
+		return nil, nil, err
+	}
+
+	// 校验选择题的答案只引用题目声明的选项
+	if err := s.validateOptionAnswers(qDomain, answers); err != nil {
+		return nil, nil, err
+	}
+
+	// 校验条件必填规则
+	if err := s.validateConditionalRequired(qDomain, answers); err != nil {
+		return nil, nil, err
+	}
+
 	asBO := answersheet.NewAnswerSheet(
 		answerSheetDTO.QuestionnaireCode,
 		answerSheetDTO.QuestionnaireVersion,
 		answersheet.WithTitle(answerSheetDTO.Title),
 		answersheet.WithWriter(writer),
 		answersheet.WithTestee(testee),
-		answersheet.WithAnswers(answers),
+		answersheet.WithSource(answerSheetDTO.Source),
+		answersheet.WithCreatedAt(answerSheetDTO.CreatedAt),
 	)
 
-	// 3. 保存到 MongoDB
+	// 逐题作答，记录答案事件
+	if err := s.applyAnswers(asBO, answers, answerSheetDTO.CreatedAt); err != nil {
+		return nil, nil, err
+	}
+
+	// 消费签名上传，保证每个上传只能被一份答卷引用
+	if err := s.consumeUploads(ctx, uploads); err != nil {
+		return nil, nil, err
+	}
+
+	// 3. 保存到 MongoDB，保存失败时恢复已消费的上传，客户端可以重新提交
 	if err := s.aRepoMongo.Create(ctx, asBO); err != nil {
-		return nil, errors.WrapC(err, errCode.ErrDatabase, "保存答卷失败")
+		s.restoreUploads(ctx, uploads)
+		return nil, nil, errors.WrapC(err, errCode.ErrDatabase, "保存答卷失败")
+	}
+	if s.notifier != nil {
+		s.notifier.Notify(notificationhook.EventSheetSubmitted, asBO.GetQuestionnaireCode())
+	}
+	if s.publisher != nil {
+		s.publisher.PublishSubmitted(answersheet.AnswerSheetSubmitted{
+			AnswerSheetID:     asBO.GetID().Value(),
+			QuestionnaireCode: asBO.GetQuestionnaireCode(),
+			TesteeID:          answerSheetDTO.TesteeID,
+			TotalScore:        asBO.GetScore(),
+			SubmittedAt:       time.Now(),
+		})
 	}
 
 	// 4. 转换为 DTO 并返回
@@ -63,7 +151,7 @@ func (s *Saver) SaveOriginalAnswerSheet(ctx context.Context, answerSheetDTO dto.
 		WriterID:             asBO.GetWriter().GetUserID().Value(),
 		TesteeID:             asBO.GetTestee().GetUserID().Value(),
 		Answers:              s.mapper.ToDTOs(asBO.GetAnswers()),
-	}, nil
+	}, uploads, nil
 }
 
 // SaveAnswerSheetScores 保存答卷得分
@@ -98,6 +186,8 @@ func (s *Saver) SaveAnswerSheetScores(ctx context.Context, id uint64, totalScore
 		answersheet.WithWriter(aDomain.GetWriter()),
 		answersheet.WithTestee(aDomain.GetTestee()),
 		answersheet.WithAnswers(answerBOs),
+		answersheet.WithAnswerEvents(aDomain.GetAnswerEvents()),
+		answersheet.WithSource(aDomain.GetSource()),
 		answersheet.WithCreatedAt(aDomain.GetCreatedAt()),
 	)
 
@@ -149,3 +239,249 @@ func (s *Saver) validateAnswerSheet(answerSheet dto.AnswerSheetDTO) error {
 	}
 	return nil
 }
+
+// applyAnswers 以答卷提交时间逐题作答，记录答案事件
+// 重放事件得到的答案状态必须与答卷的答案一致，否则事件日志无法还原这份答卷
+func (s *Saver) applyAnswers(asBO *answersheet.AnswerSheet, answers []answer.Answer, submittedAt time.Time) error {
+	if submittedAt.IsZero() {
+		submittedAt = time.Now()
+	}
+	for _, a := range answers {
+		if err := asBO.SetAnswerAt(question.NewQuestionCode(a.GetQuestionCode()), a, submittedAt); err != nil {
+			return err
+		}
+	}
+
+	state := asBO.ReplayToState(submittedAt)
+	if len(state) != len(asBO.GetAnswers()) {
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "答案事件只能还原 %d 个答案，答卷共 %d 个答案", len(state), len(asBO.GetAnswers()))
+	}
+	return nil
+}
+
+// validateConditionalRequired 校验条件必填规则，汇总所有字段错误
+func (s *Saver) validateConditionalRequired(qDomain *questionnaire.Questionnaire, answers []answer.Answer) error {
+	fieldErrors := answersheet.ValidateConditionalRequired(qDomain, answers)
+	if len(fieldErrors) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(fieldErrors))
+	for _, fieldErr := range fieldErrors {
+		messages = append(messages, fieldErr.Error())
+	}
+	return errors.WithCode(errCode.ErrAnswerSheetInvalid, "%s", strings.Join(messages, "; "))
+}
+
+// validateOptionAnswers 校验答案内容，由题目自带的答案校验器完成
+// 单选、多选题会校验答案引用的选项是否为题目声明的选项
+func (s *Saver) validateOptionAnswers(qDomain *questionnaire.Questionnaire, answers []answer.Answer) error {
+	questions := make(map[string]question.Question, len(qDomain.GetQuestions()))
+	for _, q := range qDomain.GetQuestions() {
+		questions[q.GetCode().Value()] = q
+	}
+
+	for _, ans := range answers {
+		validator, ok := questions[ans.GetQuestionCode()].(ability.AnswerValidator)
+		if !ok {
+			continue
+		}
+		if err := validator.ValidateAnswer(ans); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolveUploads 以存储记录中的元信息替换文件上传题答案中的文件引用，不信任客户端提交的文件名、类型与大小
+// 以存储键引用的预上传文件，元信息取自文件存储；以 upload_id 引用的签名上传替换为对应的存储键，
+// 上传必须签发给本问卷的同一题目，且文件已上传到对象存储，文件名、类型取自上传记录，大小取自对象存储
+// 返回替换后的答案和需要消费的上传
+func (s *Saver) resolveUploads(ctx context.Context, questionnaireCode string, answers []answer.Answer) ([]answer.Answer, []*answersheet.PendingUpload, error) {
+	var uploads []*answersheet.PendingUpload
+	resolved := make([]answer.Answer, 0, len(answers))
+
+	for _, ans := range answers {
+		files, ok := ans.GetValue().Raw().([]values.FileReference)
+		if !ok || question.QuestionType(ans.GetQuestionType()) != question.QuestionTypeFileUpload {
+			resolved = append(resolved, ans)
+			continue
+		}
+
+		refs := make([]values.FileReference, 0, len(files))
+		for _, file := range files {
+			if file.UploadID == "" {
+				ref, err := s.resolveStoredFile(ctx, ans.GetQuestionCode(), file)
+				if err != nil {
+					return nil, nil, err
+				}
+				refs = append(refs, ref)
+				continue
+			}
+			if s.uploadRepo == nil || s.objects == nil {
+				return nil, nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "未启用签名上传，问题 %s 不能引用上传 %s", ans.GetQuestionCode(), file.UploadID)
+			}
+
+			upload, err := s.uploadRepo.FindByID(ctx, file.UploadID)
+			if err != nil {
+				if errors.IsCode(err, errCode.ErrUploadNotFound) {
+					return nil, nil, errors.WrapC(err, errCode.ErrAnswerFileInvalid, "问题 %s 的上传 %s 不存在或已失效", ans.GetQuestionCode(), file.UploadID)
+				}
+				return nil, nil, errors.WrapC(err, errCode.ErrDatabase, "查询上传记录失败")
+			}
+			if !upload.Matches(questionnaireCode, ans.GetQuestionCode()) || upload.IsExpired(time.Now()) {
+				return nil, nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "上传 %s 不能用于问题 %s", file.UploadID, ans.GetQuestionCode())
+			}
+
+			object, err := s.objects.StatObject(ctx, upload.ObjectKey)
+			if err != nil {
+				if errors.IsCode(err, errCode.ErrUploadNotFound) {
+					return nil, nil, errors.WrapC(err, errCode.ErrAnswerFileInvalid, "问题 %s 的上传 %s 尚未完成", ans.GetQuestionCode(), file.UploadID)
+				}
+				return nil, nil, errors.WrapC(err, errCode.ErrFileStorage, "查询上传文件失败")
+			}
+			if upload.MaxSizeBytes > 0 && object.SizeBytes > upload.MaxSizeBytes {
+				return nil, nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "问题 %s 的上传 %s 超过大小上限 %d 字节", ans.GetQuestionCode(), file.UploadID, upload.MaxSizeBytes)
+			}
+
+			refs = append(refs, values.FileReference{
+				FileName:   upload.FileName,
+				MimeType:   upload.MimeType,
+				StorageKey: upload.ObjectKey,
+				SizeBytes:  object.SizeBytes,
+			})
+			uploads = append(uploads, upload)
+		}
+
+		resolvedAns, err := answer.NewAnswer(question.NewQuestionCode(ans.GetQuestionCode()), question.QuestionTypeFileUpload, ans.GetScore(), refs)
+		if err != nil {
+			return nil, nil, errors.WrapC(err, errCode.ErrAnswerFileInvalid, "问题 %s 的答案无效", ans.GetQuestionCode())
+		}
+		resolved = append(resolved, resolvedAns)
+	}
+
+	return resolved, uploads, nil
+}
+
+// resolveStoredFile 查询以存储键引用的预上传文件，返回以存储记录元信息构造的文件引用
+// 未填写存储键的文件引用原样返回，由 validateFileReferences 拒绝
+func (s *Saver) resolveStoredFile(ctx context.Context, questionCode string, file values.FileReference) (values.FileReference, error) {
+	if file.StorageKey == "" {
+		return file, nil
+	}
+	if s.fileRepo == nil {
+		return values.FileReference{}, errors.WithCode(errCode.ErrAnswerFileInvalid, "未启用文件存储，问题 %s 不能引用文件 %s", questionCode, file.StorageKey)
+	}
+
+	meta, err := s.fileRepo.Stat(ctx, file.StorageKey)
+	if err != nil {
+		if errors.IsCode(err, errCode.ErrUploadNotFound) {
+			return values.FileReference{}, errors.WrapC(err, errCode.ErrAnswerFileInvalid, "问题 %s 的文件 %s 不存在", questionCode, file.StorageKey)
+		}
+		return values.FileReference{}, errors.WrapC(err, errCode.ErrFileStorage, "查询文件失败")
+	}
+
+	return values.FileReference{
+		FileName:   meta.FileName,
+		MimeType:   meta.MimeType,
+		StorageKey: file.StorageKey,
+		SizeBytes:  meta.SizeBytes,
+	}, nil
+}
+
+// consumeUploads 消费签名上传，任一上传消费失败时恢复已消费的上传
+func (s *Saver) consumeUploads(ctx context.Context, uploads []*answersheet.PendingUpload) error {
+	for i, upload := range uploads {
+		if err := s.uploadRepo.Consume(ctx, upload.ID); err != nil {
+			s.restoreUploads(ctx, uploads[:i])
+			if errors.IsCode(err, errCode.ErrUploadNotFound) {
+				return errors.WrapC(err, errCode.ErrAnswerFileInvalid, "上传 %s 已被使用", upload.ID)
+			}
+			return errors.WrapC(err, errCode.ErrDatabase, "消费上传记录失败")
+		}
+	}
+	return nil
+}
+
+// restoreUploads 恢复已消费的签名上传，已过期的上传不再恢复
+func (s *Saver) restoreUploads(ctx context.Context, uploads []*answersheet.PendingUpload) {
+	for _, upload := range uploads {
+		if upload.IsExpired(time.Now()) {
+			continue
+		}
+		if err := s.uploadRepo.Save(ctx, upload); err != nil {
+			log.Warnf("恢复上传 %s 失败: %v", upload.ID, err)
+		}
+	}
+}
+
+// validateFileAnswers 校验文件上传题的答案
+// 每个文件引用的类型必须在题目允许的类型内，大小不超过上限，文件数量不超过上限
+func (s *Saver) validateFileAnswers(qDomain *questionnaire.Questionnaire, answers []answer.Answer) error {
+	questions := make(map[string]question.Question, len(qDomain.GetQuestions()))
+	for _, q := range qDomain.GetQuestions() {
+		questions[q.GetCode().Value()] = q
+	}
+
+	for _, ans := range answers {
+		if question.QuestionType(ans.GetQuestionType()) != question.QuestionTypeFileUpload {
+			continue
+		}
+
+		q, ok := questions[ans.GetQuestionCode()]
+		if !ok {
+			return errors.WithCode(errCode.ErrAnswerFileInvalid, "问题 %s 不存在", ans.GetQuestionCode())
+		}
+
+		files, ok := ans.GetValue().Raw().([]values.FileReference)
+		if !ok {
+			return errors.WithCode(errCode.ErrAnswerFileInvalid, "问题 %s 的答案不是有效的文件列表", ans.GetQuestionCode())
+		}
+
+		if err := validateFileReferences(q, files); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateFileReferences 校验文件引用是否满足题目约束
+// 文件引用的元信息须已由 resolveUploads 替换为存储记录中的元信息
+func validateFileReferences(q question.Question, files []values.FileReference) error {
+	if q.GetMaxFiles() > 0 && len(files) > q.GetMaxFiles() {
+		return errors.WithCode(errCode.ErrAnswerFileInvalid,
+			"问题 %s 最多上传 %d 个文件", q.GetCode().Value(), q.GetMaxFiles())
+	}
+
+	for _, file := range files {
+		if file.StorageKey == "" {
+			return errors.WithCode(errCode.ErrAnswerFileInvalid,
+				"问题 %s 的文件 %s 未上传", q.GetCode().Value(), file.FileName)
+		}
+		if !isMIMETypeAllowed(q.GetAllowedMIMETypes(), file.MimeType) {
+			return errors.WithCode(errCode.ErrAnswerFileInvalid,
+				"问题 %s 不允许上传 %s 类型的文件", q.GetCode().Value(), file.MimeType)
+		}
+		if q.GetMaxFileSizeBytes() > 0 && file.SizeBytes > q.GetMaxFileSizeBytes() {
+			return errors.WithCode(errCode.ErrAnswerFileInvalid,
+				"问题 %s 的文件 %s 超过大小上限 %d 字节", q.GetCode().Value(), file.FileName, q.GetMaxFileSizeBytes())
+		}
+	}
+
+	return nil
+}
+
+// isMIMETypeAllowed 判断文件类型是否被允许，未配置允许类型时不限制
+func isMIMETypeAllowed(allowed []string, mimeType string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+	for _, t := range allowed {
+		if t == mimeType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,142 @@
+package answersheet
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report"
+	msPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Scorer 答卷计分器
+type Scorer struct {
+	qRepoMongo   qnPort.QuestionnaireRepositoryMongo
+	msRepo       msPort.MedicalScaleRepositoryMongo
+	answerMapper mapper.AnswerMapper
+	irMapper     *mapper.InterpretReportMapper
+}
+
+// NewScorer 创建答卷计分器
+func NewScorer(
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo,
+	msRepo msPort.MedicalScaleRepositoryMongo,
+) *Scorer {
+	return &Scorer{
+		qRepoMongo:   qRepoMongo,
+		msRepo:       msRepo,
+		answerMapper: mapper.NewAnswerMapper(),
+		irMapper:     mapper.NewInterpretReportMapper(),
+	}
+}
+
+// 确保实现了接口
+var _ port.AnswerSheetScorer = (*Scorer)(nil)
+
+// ScoreAnswerSheet 计算答卷各题得分、总分及医学量表因子得分
+// 问卷未关联医学量表时仅返回题目得分
+func (s *Scorer) ScoreAnswerSheet(ctx context.Context, answerSheetDTO dto.AnswerSheetDTO) (*dto.AnswerSheetScoreDTO, error) {
+	if answerSheetDTO.QuestionnaireCode == "" {
+		return nil, errors.WithCode(errCode.ErrValidation, "问卷代码不能为空")
+	}
+
+	qDomain, err := s.findQuestionnaire(ctx, answerSheetDTO.QuestionnaireCode, answerSheetDTO.QuestionnaireVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	ms, err := s.msRepo.FindByQuestionnaireCode(ctx, qDomain.GetCode().Value())
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "获取医学量表失败")
+	}
+
+	// 关联了医学量表时按量表的计分策略计分，否则总分为各题得分之和
+	engine := scoring.NewScoringRuleEngineBuilder().Build()
+	if ms != nil {
+		engine = ms.NewScoringRuleEngine()
+	}
+
+	aDomain := answersheet.NewAnswerSheet(
+		qDomain.GetCode().Value(),
+		qDomain.GetVersion().Value(),
+		answersheet.WithAnswers(s.answerMapper.ToBOs(answerSheetDTO.Answers)),
+	)
+	scored, ruleScores := answersheet.ScoreAnswerSheetWithEngine(qDomain, aDomain, engine)
+
+	result := &dto.AnswerSheetScoreDTO{
+		QuestionnaireCode:    scored.GetQuestionnaireCode(),
+		QuestionnaireVersion: scored.GetQuestionnaireVersion(),
+		TotalScore:           scored.GetScore(),
+		RuleScores:           ruleScores.GetScores(),
+		DimensionScores:      toDimensionScoreDTOs(ruleScores.GetDimensionScores()),
+		Answers:              s.answerMapper.ToDTOs(scored.GetAnswers()),
+		Factors:              []dto.InterpretItemDTO{},
+	}
+	if ms == nil {
+		return result, nil
+	}
+
+	if ms.GetScoringConfig().NormalizeToPercentage {
+		if result.PercentageScore, err = answersheet.PercentageScore(qDomain, scored.GetScore()); err != nil {
+			return nil, err
+		}
+	}
+
+	report, err := interpretreport.GenerateInterpretReport(0, ms, collectAnswerScores(scored))
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range report.GetInterpretItems() {
+		result.Factors = append(result.Factors, s.irMapper.InterpretItemToDTO(item))
+	}
+
+	return result, nil
+}
+
+// findQuestionnaire 查找问卷，未指定版本时使用当前版本
+func (s *Scorer) findQuestionnaire(ctx context.Context, code, version string) (*questionnaire.Questionnaire, error) {
+	var (
+		qDomain *questionnaire.Questionnaire
+		err     error
+	)
+	if version == "" {
+		qDomain, err = s.qRepoMongo.FindByCode(ctx, code)
+	} else {
+		qDomain, err = s.qRepoMongo.FindByCodeVersion(ctx, code, version)
+	}
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+	return qDomain, nil
+}
+
+// toDimensionScoreDTOs 将维度得分转换为 DTO，以维度编码为键
+func toDimensionScoreDTOs(scores map[string]scoring.DimensionScore) map[string]dto.DimensionScoreDTO {
+	dtos := make(map[string]dto.DimensionScoreDTO, len(scores))
+	for code, score := range scores {
+		dtos[code] = dto.DimensionScoreDTO{
+			Label:      score.Label,
+			Raw:        score.Raw,
+			Normed:     score.Normed,
+			TScore:     score.TScore,
+			Percentile: score.Percentile,
+		}
+	}
+	return dtos
+}
+
+// collectAnswerScores 收集答卷中各题的得分，以题目编码为键
+func collectAnswerScores(aDomain *answersheet.AnswerSheet) map[string]float64 {
+	scores := make(map[string]float64, len(aDomain.GetAnswers()))
+	for _, ans := range aDomain.GetAnswers() {
+		scores[ans.GetQuestionCode()] = ans.GetScore()
+	}
+	return scores
+}
@@ -0,0 +1,38 @@
+package answersheet
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestScorer_ScoreAnswerSheet_QuestionnaireNotFound(t *testing.T) {
+	scorer := NewScorer(&fakeQuestionnaireRepo{}, &fakeMedicalScaleRepo{})
+
+	tests := []struct {
+		name    string
+		version string
+	}{
+		{name: "current version", version: ""},
+		{name: "specific version", version: "2.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := scorer.ScoreAnswerSheet(context.Background(), dto.AnswerSheetDTO{
+				QuestionnaireCode:    "QN404",
+				QuestionnaireVersion: tt.version,
+				Answers: []dto.AnswerDTO{
+					{QuestionCode: "Q1", QuestionType: string(question.QuestionTypeRadio), Value: "A"},
+				},
+			})
+			if !errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+				t.Errorf("ScoreAnswerSheet() error = %v, want ErrQuestionnaireNotFound", err)
+			}
+		})
+	}
+}
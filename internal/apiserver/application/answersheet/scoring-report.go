@@ -0,0 +1,302 @@
+package answersheet
+
+import (
+	"context"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// 计分报表的工作表名称
+const (
+	SheetSummary          = "Summary"
+	SheetRawScores        = "Raw Scores"
+	SheetQuestionAnalysis = "Question Analysis"
+)
+
+// 计分报表的单元格格式
+const (
+	scoreNumFmt = 2 // 内置格式 0.00
+	dateNumFmt  = "yyyy-mm-dd"
+)
+
+// ScoringReporter 答卷计分报表导出器
+// 汇总问卷在时间范围内的答卷得分，生成供医院统计系统使用的 Excel 报表
+type ScoringReporter struct {
+	aRepoMongo port.AnswerSheetRepositoryMongo
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
+}
+
+// NewScoringReporter 创建答卷计分报表导出器
+func NewScoringReporter(aRepoMongo port.AnswerSheetRepositoryMongo, qRepoMongo qnPort.QuestionnaireRepositoryMongo) *ScoringReporter {
+	return &ScoringReporter{
+		aRepoMongo: aRepoMongo,
+		qRepoMongo: qRepoMongo,
+	}
+}
+
+// 确保实现了接口
+var _ port.AnswerSheetScoringReporter = (*ScoringReporter)(nil)
+
+// scoreStats 得分统计
+type scoreStats struct {
+	count  int
+	mean   float64
+	stddev float64 // 样本标准差，少于两个样本时为 0
+	min    float64
+	max    float64
+}
+
+// newScoreStats 计算得分统计
+func newScoreStats(scores []float64) scoreStats {
+	stats := scoreStats{count: len(scores)}
+	if stats.count == 0 {
+		return stats
+	}
+
+	stats.min, stats.max = scores[0], scores[0]
+	var sum float64
+	for _, score := range scores {
+		sum += score
+		stats.min = math.Min(stats.min, score)
+		stats.max = math.Max(stats.max, score)
+	}
+	stats.mean = sum / float64(stats.count)
+
+	if stats.count > 1 {
+		var squares float64
+		for _, score := range scores {
+			squares += (score - stats.mean) * (score - stats.mean)
+		}
+		stats.stddev = math.Sqrt(squares / float64(stats.count-1))
+	}
+	return stats
+}
+
+// ExportScoringReportXLSX 导出问卷在 [from, to) 内答卷的计分报表，时间为零值时不限制
+// 报表包含 Summary（总分汇总）、Raw Scores（每份答卷一行）和 Question Analysis（各题得分均值与标准差）三个工作表
+func (r *ScoringReporter) ExportScoringReportXLSX(ctx context.Context, questionnaireCode string, from, to time.Time) ([]byte, error) {
+	if questionnaireCode == "" {
+		return nil, errors.WithCode(errCode.ErrAnswerSheetInvalid, "问卷代码不能为空")
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, errors.WithCode(errCode.ErrAnswerSheetInvalid, "起始时间必须早于截止时间")
+	}
+
+	var sheets []*answersheet.AnswerSheet
+	err := r.aRepoMongo.IterateByQuestionnaire(ctx, questionnaireCode, from, to, func(sheet *answersheet.AnswerSheet) error {
+		sheets = append(sheets, sheet)
+		return nil
+	})
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "查询报表答卷失败")
+	}
+	sort.SliceStable(sheets, func(i, j int) bool {
+		if !sheets[i].GetCreatedAt().Equal(sheets[j].GetCreatedAt()) {
+			return sheets[i].GetCreatedAt().Before(sheets[j].GetCreatedAt())
+		}
+		return sheets[i].GetID().Value() < sheets[j].GetID().Value()
+	})
+
+	// 题目标题与顺序取自问卷当前版本，问卷不存在时按题目编码排序
+	var questions []question.Question
+	qDomain, err := r.qRepoMongo.FindByCodeOrNil(ctx, questionnaireCode)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "查询问卷失败")
+	}
+	if qDomain != nil {
+		questions = qDomain.GetQuestions()
+	}
+
+	report := newScoringReport()
+	defer report.file.Close()
+
+	if err := report.writeSummary(questionnaireCode, from, to, sheets); err != nil {
+		return nil, err
+	}
+	if err := report.writeRawScores(sheets); err != nil {
+		return nil, err
+	}
+	if err := report.writeQuestionAnalysis(questions, sheets); err != nil {
+		return nil, err
+	}
+
+	buf, err := report.file.WriteToBuffer()
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrEncodingFailed, "生成计分报表失败")
+	}
+	return buf.Bytes(), nil
+}
+
+// scoringReport 计分报表工作簿
+type scoringReport struct {
+	file   *excelize.File
+	styles map[string]int
+	err    error
+}
+
+// newScoringReport 创建包含三个工作表和单元格样式的工作簿
+func newScoringReport() *scoringReport {
+	r := &scoringReport{file: excelize.NewFile(), styles: make(map[string]int)}
+
+	r.do(r.file.SetSheetName(r.file.GetSheetName(0), SheetSummary))
+	for _, sheet := range []string{SheetRawScores, SheetQuestionAnalysis} {
+		_, err := r.file.NewSheet(sheet)
+		r.do(err)
+	}
+
+	dateFmt := dateNumFmt
+	for name, style := range map[string]*excelize.Style{
+		"header": {Font: &excelize.Font{Bold: true}},
+		"score":  {NumFmt: scoreNumFmt},
+		"date":   {CustomNumFmt: &dateFmt},
+	} {
+		id, err := r.file.NewStyle(style)
+		r.do(err)
+		r.styles[name] = id
+	}
+	return r
+}
+
+// do 记录第一个错误，之后的写入不再执行
+func (r *scoringReport) do(err error) {
+	if r.err == nil && err != nil {
+		r.err = err
+	}
+}
+
+// setCell 写入单元格并设置样式，style 为空时使用默认样式；零值时间写为空单元格
+func (r *scoringReport) setCell(sheet string, col, row int, value interface{}, style string) {
+	if r.err != nil {
+		return
+	}
+	cell, err := excelize.CoordinatesToCellName(col, row)
+	if err != nil {
+		r.do(err)
+		return
+	}
+	if t, ok := value.(time.Time); ok && t.IsZero() {
+		return
+	}
+
+	r.do(r.file.SetCellValue(sheet, cell, value))
+	if style != "" {
+		r.do(r.file.SetCellStyle(sheet, cell, cell, r.styles[style]))
+	}
+}
+
+// writeHeader 写入加粗的表头行
+func (r *scoringReport) writeHeader(sheet string, headers ...string) {
+	for i, header := range headers {
+		r.setCell(sheet, i+1, 1, header, "header")
+	}
+}
+
+// result 返回写入过程中的第一个错误
+func (r *scoringReport) result(sheet string) error {
+	if r.err != nil {
+		return errors.WrapC(r.err, errCode.ErrEncodingFailed, "写入工作表 %s 失败", sheet)
+	}
+	return nil
+}
+
+// writeSummary 写入总分汇总
+func (r *scoringReport) writeSummary(questionnaireCode string, from, to time.Time, sheets []*answersheet.AnswerSheet) error {
+	scores := make([]float64, 0, len(sheets))
+	for _, sheet := range sheets {
+		scores = append(scores, sheet.GetScore())
+	}
+	stats := newScoreStats(scores)
+
+	rows := []struct {
+		metric string
+		value  interface{}
+		style  string
+	}{
+		{"Questionnaire Code", questionnaireCode, ""},
+		{"From", from, "date"},
+		{"To (exclusive)", to, "date"},
+		{"Answer Sheets", stats.count, ""},
+		{"Mean Total Score", stats.mean, "score"},
+		{"Std Dev Total Score", stats.stddev, "score"},
+		{"Min Total Score", stats.min, "score"},
+		{"Max Total Score", stats.max, "score"},
+	}
+
+	r.writeHeader(SheetSummary, "Metric", "Value")
+	for i, row := range rows {
+		r.setCell(SheetSummary, 1, i+2, row.metric, "")
+		r.setCell(SheetSummary, 2, i+2, row.value, row.style)
+	}
+	return r.result(SheetSummary)
+}
+
+// writeRawScores 写入每份答卷的被试者信息与总分
+func (r *scoringReport) writeRawScores(sheets []*answersheet.AnswerSheet) error {
+	r.writeHeader(SheetRawScores, "Answer Sheet ID", "Submitted At", "Testee ID", "Testee Name", "Sex", "Birthday", "Total Score")
+	for i, sheet := range sheets {
+		row := i + 2
+		r.setCell(SheetRawScores, 1, row, sheet.GetID().Value(), "")
+		r.setCell(SheetRawScores, 2, row, sheet.GetCreatedAt(), "date")
+		if testee := sheet.GetTestee(); testee != nil {
+			r.setCell(SheetRawScores, 3, row, testee.GetUserID().Value(), "")
+			r.setCell(SheetRawScores, 4, row, testee.Name, "")
+			if testee.Sex != 0 {
+				r.setCell(SheetRawScores, 5, row, testee.Sex, "")
+			}
+			r.setCell(SheetRawScores, 6, row, testee.Birthday, "date")
+		}
+		r.setCell(SheetRawScores, 7, row, sheet.GetScore(), "score")
+	}
+	return r.result(SheetRawScores)
+}
+
+// writeQuestionAnalysis 写入各题得分的均值与标准差，段落题不参与统计
+func (r *scoringReport) writeQuestionAnalysis(questions []question.Question, sheets []*answersheet.AnswerSheet) error {
+	scores := make(map[string][]float64)
+	for _, sheet := range sheets {
+		for _, ans := range sheet.GetAnswers() {
+			scores[ans.GetQuestionCode()] = append(scores[ans.GetQuestionCode()], ans.GetScore())
+		}
+	}
+
+	// 先按问卷题目顺序，再按编码顺序列出问卷中已不存在的题目
+	var codes []string
+	titles := make(map[string]string)
+	for _, q := range questions {
+		if q.GetType() == question.QuestionTypeSection {
+			continue
+		}
+		codes = append(codes, q.GetCode().Value())
+		titles[q.GetCode().Value()] = q.GetTitle()
+	}
+	var removed []string
+	for code := range scores {
+		if _, ok := titles[code]; !ok {
+			removed = append(removed, code)
+		}
+	}
+	sort.Strings(removed)
+	codes = append(codes, removed...)
+
+	r.writeHeader(SheetQuestionAnalysis, "Question Code", "Question Title", "Responses", "Mean Score", "Std Dev")
+	for i, code := range codes {
+		row := i + 2
+		stats := newScoreStats(scores[code])
+		r.setCell(SheetQuestionAnalysis, 1, row, code, "")
+		r.setCell(SheetQuestionAnalysis, 2, row, titles[code], "")
+		r.setCell(SheetQuestionAnalysis, 3, row, stats.count, "")
+		r.setCell(SheetQuestionAnalysis, 4, row, stats.mean, "score")
+		r.setCell(SheetQuestionAnalysis, 5, row, stats.stddev, "score")
+	}
+	return r.result(SheetQuestionAnalysis)
+}
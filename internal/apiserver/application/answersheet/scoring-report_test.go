@@ -0,0 +1,125 @@
+package answersheet
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
+
+// newScoredSheet 创建已计分的单选题答卷，scores 为题目编码到得分的映射
+func newScoredSheet(t *testing.T, id uint64, createdAt time.Time, testee *user.Testee, total float64, scores map[string]float64) *answersheet.AnswerSheet {
+	t.Helper()
+	var answers []answer.Answer
+	for code, score := range scores {
+		ans, err := answer.NewAnswer(question.NewQuestionCode(code), question.QuestionTypeRadio, score, "A")
+		if err != nil {
+			t.Fatalf("NewAnswer(%s) error = %v", code, err)
+		}
+		answers = append(answers, ans)
+	}
+	return answersheet.NewAnswerSheet("QN1", "1.0",
+		answersheet.WithID(v1.NewID(id)),
+		answersheet.WithTestee(testee),
+		answersheet.WithScore(total),
+		answersheet.WithAnswers(answers),
+		answersheet.WithCreatedAt(createdAt),
+	)
+}
+
+func TestScoringReporter_ExportScoringReportXLSX(t *testing.T) {
+	section := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("S1")),
+		question.WithTitle("第一部分"),
+		question.WithQuestionType(question.QuestionTypeSection),
+	))
+	qRepo := &fakeQuestionnaireRepo{
+		qDomain: questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("QN1"), "焦虑自评",
+			questionnaire.WithQuestions([]question.Question{section, newRadioQuestion("Q1"), newRadioQuestion("Q2")}),
+		),
+	}
+
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 10, 0, 0, 0, time.UTC) }
+	zhang := user.NewTestee(user.NewUserID(2), "张三")
+	zhang.Birthday = time.Date(1990, 5, 6, 0, 0, 0, 0, time.UTC)
+
+	aRepo := newFakeAnswerSheetRepo()
+	for _, sheet := range []*answersheet.AnswerSheet{
+		newScoredSheet(t, 1, day(2), zhang, 3, map[string]float64{"Q1": 1, "Q2": 2}),
+		newScoredSheet(t, 2, day(3), user.NewTestee(user.NewUserID(3), ""), 4, map[string]float64{"Q1": 2, "Q2": 2}),
+		// Q9 已从问卷中删除
+		newScoredSheet(t, 3, day(1), user.NewTestee(user.NewUserID(4), ""), 2.5, map[string]float64{"Q1": 0, "Q9": 2.5}),
+	} {
+		aRepo.sheets[sheet.GetID().Value()] = sheet
+	}
+
+	data, err := NewScoringReporter(aRepo, qRepo).ExportScoringReportXLSX(context.Background(), "QN1", day(1), day(4))
+	if err != nil {
+		t.Fatalf("ExportScoringReportXLSX() error = %v", err)
+	}
+
+	f, err := excelize.OpenReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("OpenReader() error = %v", err)
+	}
+	defer f.Close()
+
+	if got := f.GetSheetList(); len(got) != 3 || got[0] != SheetSummary || got[1] != SheetRawScores || got[2] != SheetQuestionAnalysis {
+		t.Errorf("GetSheetList() = %v, want [Summary Raw Scores Question Analysis]", got)
+	}
+
+	want := map[string]map[string]string{
+		SheetSummary: {
+			"A1": "Metric", "B2": "QN1", "B3": "2025-01-01", "B4": "2025-01-04",
+			"B5": "3", "B6": "3.17", "B7": "0.76", "B8": "2.50", "B9": "4.00",
+		},
+		SheetRawScores: {
+			"A1": "Answer Sheet ID",
+			// 按提交时间排序
+			"A2": "3", "B2": "2025-01-01", "C2": "4", "G2": "2.50",
+			"A3": "1", "B3": "2025-01-02", "D3": "张三", "F3": "1990-05-06", "G3": "3.00",
+			"A4": "2", "F4": "", "G4": "4.00",
+		},
+		SheetQuestionAnalysis: {
+			"A1": "Question Code",
+			// 段落题不参与统计
+			"A2": "Q1", "B2": "Q1", "C2": "3", "D2": "1.00", "E2": "1.00",
+			"A3": "Q2", "C3": "2", "D3": "2.00", "E3": "0.00",
+			"A4": "Q9", "B4": "", "C4": "1", "D4": "2.50", "E4": "0.00",
+		},
+	}
+	for sheet, cells := range want {
+		for cell, value := range cells {
+			got, err := f.GetCellValue(sheet, cell)
+			if err != nil {
+				t.Fatalf("GetCellValue(%s, %s) error = %v", sheet, cell, err)
+			}
+			if got != value {
+				t.Errorf("%s!%s = %q, want %q", sheet, cell, got, value)
+			}
+		}
+	}
+
+	// 表头加粗
+	styleID, err := f.GetCellStyle(SheetRawScores, "G1")
+	if err != nil {
+		t.Fatalf("GetCellStyle() error = %v", err)
+	}
+	style, err := f.GetStyle(styleID)
+	if err != nil {
+		t.Fatalf("GetStyle() error = %v", err)
+	}
+	if style.Font == nil || !style.Font.Bold {
+		t.Errorf("header style = %+v, want bold font", style.Font)
+	}
+}
@@ -0,0 +1,113 @@
+package answersheet
+
+import (
+	"context"
+	"path"
+	"time"
+
+	uuid "github.com/satori/go.uuid"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// DefaultUploadURLExpiry 签名上传链接的默认有效期
+const DefaultUploadURLExpiry = 15 * time.Minute
+
+// SignedUploader 签名上传器
+// 文件由客户端直传到对象存储，服务端只签发链接并在 Redis 中记录待提交的上传
+type SignedUploader struct {
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
+	uploadRepo port.PendingUploadRepository
+	presigner  port.ObjectStoragePresigner
+	expiry     time.Duration
+	now        func() time.Time
+}
+
+// NewSignedUploader 创建签名上传器，expiry 不大于 0 时使用 DefaultUploadURLExpiry
+func NewSignedUploader(
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo,
+	uploadRepo port.PendingUploadRepository,
+	presigner port.ObjectStoragePresigner,
+	expiry time.Duration,
+) *SignedUploader {
+	if expiry <= 0 {
+		expiry = DefaultUploadURLExpiry
+	}
+	return &SignedUploader{
+		qRepoMongo: qRepoMongo,
+		uploadRepo: uploadRepo,
+		presigner:  presigner,
+		expiry:     expiry,
+		now:        time.Now,
+	}
+}
+
+// 确保实现了接口
+var _ port.SignedUploader = (*SignedUploader)(nil)
+
+// GenerateUploadURL 为文件上传题签发上传链接
+// 上传策略限制文件类型与题目的大小上限，对象键按 问卷/题目/upload_id/文件名 组织
+func (u *SignedUploader) GenerateUploadURL(ctx context.Context, questionnaireCode, questionCode, fileName, mimeType string) (*answersheet.UploadLink, error) {
+	if questionnaireCode == "" || questionCode == "" {
+		return nil, errors.WithCode(errCode.ErrValidation, "问卷代码和问题编码不能为空")
+	}
+	if fileName == "" || mimeType == "" {
+		return nil, errors.WithCode(errCode.ErrValidation, "文件名和文件类型不能为空")
+	}
+
+	qDomain, err := u.qRepoMongo.FindByCodeOrNil(ctx, questionnaireCode)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "查询问卷失败")
+	}
+	if qDomain == nil {
+		return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+
+	var q question.Question
+	for _, candidate := range qDomain.GetQuestions() {
+		if candidate.GetCode().Value() == questionCode {
+			q = candidate
+			break
+		}
+	}
+	if q == nil {
+		return nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "问题 %s 不存在", questionCode)
+	}
+	if q.GetType() != question.QuestionTypeFileUpload {
+		return nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "问题 %s 不是文件上传题", questionCode)
+	}
+	if !isMIMETypeAllowed(q.GetAllowedMIMETypes(), mimeType) {
+		return nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "问题 %s 不允许上传 %s 类型的文件", questionCode, mimeType)
+	}
+
+	upload := &answersheet.PendingUpload{
+		ID:                uuid.NewV4().String(),
+		QuestionnaireCode: questionnaireCode,
+		QuestionCode:      questionCode,
+		FileName:          path.Base(fileName),
+		MimeType:          mimeType,
+		MaxSizeBytes:      q.GetMaxFileSizeBytes(),
+		ExpiresAt:         u.now().Add(u.expiry),
+	}
+	upload.ObjectKey = path.Join("answersheet", questionnaireCode, questionCode, upload.ID, upload.FileName)
+
+	url, fields, err := u.presigner.PresignPostUpload(ctx, upload.ObjectKey, upload.MimeType, upload.MaxSizeBytes, upload.ExpiresAt)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrFileStorage, "签发上传链接失败")
+	}
+	if err := u.uploadRepo.Save(ctx, upload); err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "保存上传记录失败")
+	}
+
+	return &answersheet.UploadLink{
+		UploadID:  upload.ID,
+		URL:       url,
+		Fields:    fields,
+		ExpiresAt: upload.ExpiresAt,
+	}, nil
+}
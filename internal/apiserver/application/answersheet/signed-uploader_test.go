@@ -0,0 +1,203 @@
+package answersheet
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// fakePendingUploadRepo 内存待提交上传存储库
+type fakePendingUploadRepo struct {
+	uploads map[string]*answersheet.PendingUpload
+}
+
+func (r *fakePendingUploadRepo) Save(ctx context.Context, upload *answersheet.PendingUpload) error {
+	r.uploads[upload.ID] = upload
+	return nil
+}
+
+func (r *fakePendingUploadRepo) FindByID(ctx context.Context, id string) (*answersheet.PendingUpload, error) {
+	upload, ok := r.uploads[id]
+	if !ok {
+		return nil, errors.WithCode(errCode.ErrUploadNotFound, "上传 %s 不存在", id)
+	}
+	return upload, nil
+}
+
+func (r *fakePendingUploadRepo) Consume(ctx context.Context, id string) error {
+	if _, ok := r.uploads[id]; !ok {
+		return errors.WithCode(errCode.ErrUploadNotFound, "上传 %s 不存在", id)
+	}
+	delete(r.uploads, id)
+	return nil
+}
+
+// fakePresigner 记录签名参数的对象存储签名器
+type fakePresigner struct {
+	objectKey    string
+	maxSizeBytes int64
+}
+
+func (p *fakePresigner) PresignPostUpload(ctx context.Context, objectKey, mimeType string, maxSizeBytes int64, expiresAt time.Time) (string, map[string]string, error) {
+	p.objectKey, p.maxSizeBytes = objectKey, maxSizeBytes
+	return "https://minio.example.com/uploads", map[string]string{"key": objectKey, "Content-Type": mimeType}, nil
+}
+
+// fakeObjectStorage 内存对象存储，只记录已上传对象的大小
+type fakeObjectStorage struct {
+	sizes map[string]int64
+}
+
+func (o *fakeObjectStorage) StatObject(ctx context.Context, objectKey string) (*port.ObjectInfo, error) {
+	size, ok := o.sizes[objectKey]
+	if !ok {
+		return nil, errors.WithCode(errCode.ErrUploadNotFound, "对象 %s 不存在", objectKey)
+	}
+	return &port.ObjectInfo{SizeBytes: size}, nil
+}
+
+// failingAnswerSheetRepo 保存答卷总是失败的存储库
+type failingAnswerSheetRepo struct {
+	*fakeAnswerSheetRepo
+}
+
+func (r *failingAnswerSheetRepo) Create(ctx context.Context, aDomain *answersheet.AnswerSheet) error {
+	return fmt.Errorf("mongo unavailable")
+}
+
+// newFileUploadFixture 创建包含一道 PNG 文件上传题（上限 1MB、2 个文件）的问卷及签名上传器
+func newFileUploadFixture() (*fakeQuestionnaireRepo, *fakePendingUploadRepo, *fakePresigner, *SignedUploader) {
+	fileQuestion := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("F1")),
+		question.WithTitle("化验单"),
+		question.WithQuestionType(question.QuestionTypeFileUpload),
+		question.WithFileConstraints([]string{"image/png"}, 1<<20, 2),
+	))
+	qRepo := &fakeQuestionnaireRepo{
+		qDomain: questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("QN1"), "体检问卷",
+			questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+			questionnaire.WithQuestions([]question.Question{fileQuestion}),
+		),
+	}
+	uploadRepo := &fakePendingUploadRepo{uploads: make(map[string]*answersheet.PendingUpload)}
+	presigner := &fakePresigner{}
+	return qRepo, uploadRepo, presigner, NewSignedUploader(qRepo, uploadRepo, presigner, 0)
+}
+
+// newFileUploadSheet 创建以 uploadID 回答文件上传题的答卷
+func newFileUploadSheet(uploadID string) dto.AnswerSheetDTO {
+	return dto.AnswerSheetDTO{
+		QuestionnaireCode:    "QN1",
+		QuestionnaireVersion: "1.0",
+		Title:                "体检问卷",
+		WriterID:             1,
+		TesteeID:             2,
+		Answers: []dto.AnswerDTO{
+			{QuestionCode: "F1", QuestionType: string(question.QuestionTypeFileUpload), Value: uploadID},
+		},
+	}
+}
+
+func TestSignedUploader_GenerateUploadURLAndSubmit(t *testing.T) {
+	qRepo, uploadRepo, presigner, uploader := newFileUploadFixture()
+	ctx := context.Background()
+
+	if _, err := uploader.GenerateUploadURL(ctx, "QN1", "F1", "report.pdf", "application/pdf"); !errors.IsCode(err, errCode.ErrAnswerFileInvalid) {
+		t.Errorf("GenerateUploadURL(application/pdf) error = %v, want ErrAnswerFileInvalid", err)
+	}
+
+	link, err := uploader.GenerateUploadURL(ctx, "QN1", "F1", "../report.png", "image/png")
+	if err != nil {
+		t.Fatalf("GenerateUploadURL() error = %v", err)
+	}
+	wantKey := "answersheet/QN1/F1/" + link.UploadID + "/report.png"
+	if presigner.objectKey != wantKey || presigner.maxSizeBytes != 1<<20 || link.Fields["key"] != wantKey {
+		t.Errorf("presigned key = %q (max %d), want %q (max %d)", presigner.objectKey, presigner.maxSizeBytes, wantKey, 1<<20)
+	}
+	if until := time.Until(link.ExpiresAt); until <= 0 || until > DefaultUploadURLExpiry {
+		t.Errorf("ExpiresAt in %s, want within %s", until, DefaultUploadURLExpiry)
+	}
+	if _, ok := uploadRepo.uploads[link.UploadID]; !ok {
+		t.Fatalf("pending upload %s not saved", link.UploadID)
+	}
+
+	aRepo := newFakeAnswerSheetRepo()
+	objects := &fakeObjectStorage{sizes: make(map[string]int64)}
+	saver := NewSaver(aRepo, qRepo, nil, nil, nil, uploadRepo, objects)
+	sheet := newFileUploadSheet(link.UploadID)
+
+	// 文件尚未上传到对象存储
+	if _, err := saver.SaveOriginalAnswerSheet(ctx, sheet); !errors.IsCode(err, errCode.ErrAnswerFileInvalid) {
+		t.Errorf("SaveOriginalAnswerSheet() before upload error = %v, want ErrAnswerFileInvalid", err)
+	}
+
+	// 客户端声明的大小不被信任，以对象存储中的大小为准
+	objects.sizes[wantKey] = 2048
+	sheet.Answers[0].Value = []any{map[string]any{"upload_id": link.UploadID, "size_bytes": float64(1)}}
+	if _, err := saver.SaveOriginalAnswerSheet(ctx, sheet); err != nil {
+		t.Fatalf("SaveOriginalAnswerSheet() error = %v", err)
+	}
+
+	saved, _ := aRepo.FindByID(ctx, 1)
+	files, _ := saved.GetAnswers()[0].GetValue().Raw().([]values.FileReference)
+	if len(files) != 1 || files[0].StorageKey != wantKey || files[0].MimeType != "image/png" || !strings.HasSuffix(files[0].FileName, "report.png") || files[0].SizeBytes != 2048 {
+		t.Errorf("saved files = %+v, want storage key %q with 2048 bytes", files, wantKey)
+	}
+
+	// 上传只能被引用一次
+	if _, err := saver.SaveOriginalAnswerSheet(ctx, sheet); !errors.IsCode(err, errCode.ErrAnswerFileInvalid) {
+		t.Errorf("SaveOriginalAnswerSheet() reusing upload error = %v, want ErrAnswerFileInvalid", err)
+	}
+}
+
+func TestSaver_SaveOriginalAnswerSheet_RejectsOversizedUpload(t *testing.T) {
+	qRepo, uploadRepo, _, uploader := newFileUploadFixture()
+	ctx := context.Background()
+
+	link, err := uploader.GenerateUploadURL(ctx, "QN1", "F1", "report.png", "image/png")
+	if err != nil {
+		t.Fatalf("GenerateUploadURL() error = %v", err)
+	}
+	objects := &fakeObjectStorage{sizes: map[string]int64{uploadRepo.uploads[link.UploadID].ObjectKey: 2 << 20}}
+	saver := NewSaver(newFakeAnswerSheetRepo(), qRepo, nil, nil, nil, uploadRepo, objects)
+
+	if _, err := saver.SaveOriginalAnswerSheet(ctx, newFileUploadSheet(link.UploadID)); !errors.IsCode(err, errCode.ErrAnswerFileInvalid) {
+		t.Errorf("SaveOriginalAnswerSheet() error = %v, want ErrAnswerFileInvalid", err)
+	}
+}
+
+func TestSaver_SaveOriginalAnswerSheet_RestoresUploadsWhenCreateFails(t *testing.T) {
+	qRepo, uploadRepo, _, uploader := newFileUploadFixture()
+	ctx := context.Background()
+
+	link, err := uploader.GenerateUploadURL(ctx, "QN1", "F1", "report.png", "image/png")
+	if err != nil {
+		t.Fatalf("GenerateUploadURL() error = %v", err)
+	}
+	objects := &fakeObjectStorage{sizes: map[string]int64{uploadRepo.uploads[link.UploadID].ObjectKey: 1024}}
+	sheet := newFileUploadSheet(link.UploadID)
+
+	failing := NewSaver(&failingAnswerSheetRepo{newFakeAnswerSheetRepo()}, qRepo, nil, nil, nil, uploadRepo, objects)
+	if _, err := failing.SaveOriginalAnswerSheet(ctx, sheet); !errors.IsCode(err, errCode.ErrDatabase) {
+		t.Fatalf("SaveOriginalAnswerSheet() error = %v, want ErrDatabase", err)
+	}
+	if _, ok := uploadRepo.uploads[link.UploadID]; !ok {
+		t.Fatalf("upload %s not restored after failed save", link.UploadID)
+	}
+
+	saver := NewSaver(newFakeAnswerSheetRepo(), qRepo, nil, nil, nil, uploadRepo, objects)
+	if _, err := saver.SaveOriginalAnswerSheet(ctx, sheet); err != nil {
+		t.Errorf("SaveOriginalAnswerSheet() retry error = %v", err)
+	}
+}
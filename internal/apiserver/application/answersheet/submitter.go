@@ -0,0 +1,156 @@
+package answersheet
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report"
+	irPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/port"
+	msPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	hookPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// Submitter 答卷提交器
+// 编排答卷的校验、保存、计分与解读报告生成；任一后续步骤失败时回滚已保存的答卷
+// 全部步骤成功后才通知 sheet.submitted 与 report.generated 事件并发布答卷提交事件，回滚的答卷不会触发通知
+type Submitter struct {
+	saver      *Saver
+	aRepoMongo port.AnswerSheetRepositoryMongo
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
+	msRepo     msPort.MedicalScaleRepositoryMongo
+	irRepo     irPort.InterpretReportRepositoryMongo
+	notifier   hookPort.EventNotifier
+	publisher  port.SubmissionPublisher
+	irMapper   *mapper.InterpretReportMapper
+}
+
+// NewSubmitter 创建答卷提交器，saver 不应再通知和发布事件，notifier、publisher 为空时不通知、不发布
+func NewSubmitter(
+	saver *Saver,
+	aRepoMongo port.AnswerSheetRepositoryMongo,
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo,
+	msRepo msPort.MedicalScaleRepositoryMongo,
+	irRepo irPort.InterpretReportRepositoryMongo,
+	notifier hookPort.EventNotifier,
+	publisher port.SubmissionPublisher,
+) *Submitter {
+	return &Submitter{
+		saver:      saver,
+		aRepoMongo: aRepoMongo,
+		qRepoMongo: qRepoMongo,
+		msRepo:     msRepo,
+		irRepo:     irRepo,
+		notifier:   notifier,
+		publisher:  publisher,
+		irMapper:   mapper.NewInterpretReportMapper(),
+	}
+}
+
+// 确保实现了接口
+var _ port.AnswerSheetSubmitter = (*Submitter)(nil)
+
+// SubmitAndInterpret 提交答卷并立即生成解读报告
+func (s *Submitter) SubmitAndInterpret(ctx context.Context, answerSheetDTO dto.AnswerSheetDTO) (*dto.InterpretReportDTO, error) {
+	// 1. 校验并保存原始答卷
+	saved, uploads, err := s.saver.saveOriginal(ctx, answerSheetDTO)
+	if err != nil {
+		return nil, err
+	}
+	answerSheetID := saved.ID.Value()
+
+	// 2. 计分并生成解读报告，失败时回滚答卷并恢复其消费的签名上传
+	report, err := s.scoreAndInterpret(ctx, answerSheetID)
+	if err != nil {
+		s.rollback(ctx, answerSheetID, uploads)
+		return nil, err
+	}
+
+	if s.notifier != nil {
+		s.notifier.Notify(notificationhook.EventSheetSubmitted, saved.QuestionnaireCode)
+		s.notifier.Notify(notificationhook.EventReportGenerated, saved.QuestionnaireCode)
+	}
+	if s.publisher != nil {
+		s.publisher.PublishSubmitted(answersheet.AnswerSheetSubmitted{
+			AnswerSheetID:     answerSheetID,
+			QuestionnaireCode: saved.QuestionnaireCode,
+			TesteeID:          saved.TesteeID,
+			TotalScore:        report.GetTotalScore(),
+			SubmittedAt:       time.Now(),
+		})
+	}
+
+	return s.irMapper.ToDTO(report), nil
+}
+
+// scoreAndInterpret 计算答卷得分并生成、保存解读报告
+func (s *Submitter) scoreAndInterpret(ctx context.Context, answerSheetID uint64) (*interpretreport.InterpretReport, error) {
+	aDomain, err := s.aRepoMongo.FindByID(ctx, answerSheetID)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+	if aDomain == nil {
+		return nil, errors.WithCode(errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+
+	qDomain, err := s.qRepoMongo.FindByCodeVersion(ctx, aDomain.GetQuestionnaireCode(), aDomain.GetQuestionnaireVersion())
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+
+	ms, err := s.msRepo.FindByQuestionnaireCode(ctx, aDomain.GetQuestionnaireCode())
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrMedicalScaleNotFound, "医学量表不存在")
+	}
+	if ms == nil {
+		return nil, errors.WithCode(errCode.ErrMedicalScaleNotFound, "医学量表不存在")
+	}
+
+	// 计算答案得分并保存
+	scored, _ := answersheet.ScoreAnswerSheetWithEngine(qDomain, aDomain, ms.NewScoringRuleEngine())
+	if err := s.aRepoMongo.Update(ctx, scored); err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "保存答卷分数失败")
+	}
+
+	// 生成解读报告并保存
+	var opts []interpretreport.InterpretReportOption
+	if testee := scored.GetTestee(); testee != nil {
+		opts = append(opts, interpretreport.WithTestee(*testee))
+	}
+	if ms.GetScoringConfig().NormalizeToPercentage {
+		percentage, err := answersheet.PercentageScore(qDomain, scored.GetScore())
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, interpretreport.WithPercentageScore(percentage))
+	}
+	report, err := interpretreport.GenerateInterpretReport(answerSheetID, ms, collectAnswerScores(scored), opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.irRepo.Create(ctx, report); err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "保存解读报告失败")
+	}
+
+	return report, nil
+}
+
+// rollback 回滚已保存的答卷，答卷删除后恢复其消费的签名上传，客户端可以用相同的 upload_id 重新提交
+// 答卷删除失败时不恢复上传，避免同一上传被两份答卷引用
+func (s *Submitter) rollback(ctx context.Context, answerSheetID uint64, uploads []*answersheet.PendingUpload) {
+	if err := s.aRepoMongo.HardDelete(ctx, answerSheetID); err != nil {
+		log.Errorf("回滚答卷失败，答卷ID: %d, 错误: %v", answerSheetID, err)
+		return
+	}
+	s.saver.restoreUploads(ctx, uploads)
+	log.Warnf("提交答卷失败，已回滚答卷，答卷ID: %d", answerSheetID)
+}
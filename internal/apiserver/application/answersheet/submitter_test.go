@@ -0,0 +1,400 @@
+package answersheet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report"
+	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor/ability"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
+
+// fakeAnswerSheetRepo 内存答卷存储库
+type fakeAnswerSheetRepo struct {
+	sheets map[uint64]*answersheet.AnswerSheet
+	nextID uint64
+}
+
+func newFakeAnswerSheetRepo() *fakeAnswerSheetRepo {
+	return &fakeAnswerSheetRepo{sheets: make(map[uint64]*answersheet.AnswerSheet), nextID: 1}
+}
+
+func (r *fakeAnswerSheetRepo) Create(ctx context.Context, aDomain *answersheet.AnswerSheet) error {
+	aDomain.SetID(v1.NewID(r.nextID))
+	r.sheets[r.nextID] = aDomain
+	r.nextID++
+	return nil
+}
+
+func (r *fakeAnswerSheetRepo) Update(ctx context.Context, aDomain *answersheet.AnswerSheet) error {
+	r.sheets[aDomain.GetID().Value()] = aDomain
+	return nil
+}
+
+func (r *fakeAnswerSheetRepo) HardDelete(ctx context.Context, id uint64) error {
+	delete(r.sheets, id)
+	return nil
+}
+
+func (r *fakeAnswerSheetRepo) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeAnswerSheetRepo) IterateByQuestionnaire(ctx context.Context, questionnaireCode string, from, to time.Time, fn func(*answersheet.AnswerSheet) error) error {
+	for _, sheet := range r.sheets {
+		if sheet.GetQuestionnaireCode() != questionnaireCode {
+			continue
+		}
+		if err := fn(sheet); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *fakeAnswerSheetRepo) FindLatestByQuestionnaire(ctx context.Context, questionnaireCode string, limit int) ([]*answersheet.AnswerSheet, error) {
+	var sheets []*answersheet.AnswerSheet
+	for _, sheet := range r.sheets {
+		if sheet.GetQuestionnaireCode() == questionnaireCode {
+			sheets = append(sheets, sheet)
+		}
+	}
+	sort.Slice(sheets, func(i, j int) bool { return sheets[i].GetCreatedAt().After(sheets[j].GetCreatedAt()) })
+	if len(sheets) > limit {
+		sheets = sheets[:limit]
+	}
+	return sheets, nil
+}
+
+func (r *fakeAnswerSheetRepo) FindBatchByQuestionnaire(ctx context.Context, questionnaireCode string, afterID uint64, limit int) ([]*answersheet.AnswerSheet, error) {
+	var sheets []*answersheet.AnswerSheet
+	for id, sheet := range r.sheets {
+		if id > afterID && sheet.GetQuestionnaireCode() == questionnaireCode {
+			sheets = append(sheets, sheet)
+		}
+	}
+	sort.Slice(sheets, func(i, j int) bool { return sheets[i].GetID().Value() < sheets[j].GetID().Value() })
+	if len(sheets) > limit {
+		sheets = sheets[:limit]
+	}
+	return sheets, nil
+}
+
+func (r *fakeAnswerSheetRepo) UpdateScores(ctx context.Context, sheets []*answersheet.AnswerSheet) error {
+	for _, sheet := range sheets {
+		r.sheets[sheet.GetID().Value()] = sheet
+	}
+	return nil
+}
+
+func (r *fakeAnswerSheetRepo) FindByID(ctx context.Context, id uint64) (*answersheet.AnswerSheet, error) {
+	return r.sheets[id], nil
+}
+
+func (r *fakeAnswerSheetRepo) FindListByWriter(ctx context.Context, writerID uint64, page, pageSize int) ([]*answersheet.AnswerSheet, error) {
+	return nil, nil
+}
+
+func (r *fakeAnswerSheetRepo) FindListByTestee(ctx context.Context, testeeID uint64, page, pageSize int) ([]*answersheet.AnswerSheet, error) {
+	return nil, nil
+}
+
+func (r *fakeAnswerSheetRepo) CountWithConditions(ctx context.Context, conditions map[string]interface{}) (int64, error) {
+	return int64(len(r.sheets)), nil
+}
+
+// fakeQuestionnaireRepo 内存问卷存储库
+type fakeQuestionnaireRepo struct {
+	qDomain *questionnaire.Questionnaire
+}
+
+func (r *fakeQuestionnaireRepo) Create(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	return nil
+}
+
+func (r *fakeQuestionnaireRepo) FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	if r.qDomain == nil {
+		return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s", code)
+	}
+	return r.qDomain, nil
+}
+
+func (r *fakeQuestionnaireRepo) FindByCodeOrNil(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	return r.qDomain, nil
+}
+
+func (r *fakeQuestionnaireRepo) FindByCodeVersion(ctx context.Context, code, version string) (*questionnaire.Questionnaire, error) {
+	if r.qDomain == nil {
+		return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s@%s", code, version)
+	}
+	return r.qDomain, nil
+}
+
+func (r *fakeQuestionnaireRepo) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	return nil
+}
+
+func (r *fakeQuestionnaireRepo) Remove(ctx context.Context, code string) error {
+	return nil
+}
+
+func (r *fakeQuestionnaireRepo) RemoveByFilter(ctx context.Context, conditions map[string]interface{}) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeQuestionnaireRepo) HardDelete(ctx context.Context, code string) error {
+	return nil
+}
+
+func (r *fakeQuestionnaireRepo) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	return r.qDomain != nil, nil
+}
+
+func (r *fakeQuestionnaireRepo) FindActiveQuestionnaires(ctx context.Context) ([]*questionnaire.Questionnaire, error) {
+	return nil, nil
+}
+
+func (r *fakeQuestionnaireRepo) FindByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*questionnaire.Questionnaire, int64, error) {
+	return nil, 0, nil
+}
+
+// fakeMedicalScaleRepo 内存医学量表存储库
+type fakeMedicalScaleRepo struct {
+	ms *medicalscale.MedicalScale
+}
+
+func (r *fakeMedicalScaleRepo) Create(ctx context.Context, ms *medicalscale.MedicalScale) error {
+	return nil
+}
+
+func (r *fakeMedicalScaleRepo) FindByCode(ctx context.Context, code string) (*medicalscale.MedicalScale, error) {
+	return r.ms, nil
+}
+
+func (r *fakeMedicalScaleRepo) FindByQuestionnaireCode(ctx context.Context, questionnaireCode string) (*medicalscale.MedicalScale, error) {
+	return r.ms, nil
+}
+
+func (r *fakeMedicalScaleRepo) FindList(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*medicalscale.MedicalScale, error) {
+	return nil, nil
+}
+
+func (r *fakeMedicalScaleRepo) FindListAfterCode(ctx context.Context, lastCode string, limit int) ([]*medicalscale.MedicalScale, error) {
+	return nil, nil
+}
+
+func (r *fakeMedicalScaleRepo) CountWithConditions(ctx context.Context, conditions map[string]string) (int64, error) {
+	return 0, nil
+}
+
+func (r *fakeMedicalScaleRepo) Update(ctx context.Context, ms *medicalscale.MedicalScale) error {
+	return nil
+}
+
+func (r *fakeMedicalScaleRepo) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	return r.ms != nil, nil
+}
+
+// fakeInterpretReportRepo 内存解读报告存储库
+type fakeInterpretReportRepo struct {
+	reports []*interpretreport.InterpretReport
+}
+
+func (r *fakeInterpretReportRepo) Create(ctx context.Context, report *interpretreport.InterpretReport) error {
+	report.SetID(v1.NewID(uint64(len(r.reports) + 1)))
+	r.reports = append(r.reports, report)
+	return nil
+}
+
+func (r *fakeInterpretReportRepo) FindByAnswerSheetId(ctx context.Context, answerSheetId uint64) (*interpretreport.InterpretReport, error) {
+	return nil, nil
+}
+
+func (r *fakeInterpretReportRepo) FindList(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*interpretreport.InterpretReport, error) {
+	return nil, nil
+}
+
+func (r *fakeInterpretReportRepo) CountWithConditions(ctx context.Context, conditions map[string]string) (int64, error) {
+	return int64(len(r.reports)), nil
+}
+
+func (r *fakeInterpretReportRepo) Update(ctx context.Context, report *interpretreport.InterpretReport) error {
+	return nil
+}
+
+func (r *fakeInterpretReportRepo) ExistsByAnswerSheetId(ctx context.Context, answerSheetId uint64) (bool, error) {
+	return false, nil
+}
+
+// failingInterpretReportRepo 保存解读报告总是失败的存储库
+type failingInterpretReportRepo struct {
+	fakeInterpretReportRepo
+}
+
+func (r *failingInterpretReportRepo) Create(ctx context.Context, report *interpretreport.InterpretReport) error {
+	return fmt.Errorf("mongo unavailable")
+}
+
+func newRadioQuestion(code string) question.Question {
+	return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode(code)),
+		question.WithTitle(code),
+		question.WithQuestionType(question.QuestionTypeRadio),
+		question.WithOption("A", "从不", 0),
+		question.WithOption("B", "有时", 1),
+		question.WithOption("C", "经常", 2),
+	))
+}
+
+func newTotalScoreFactor() factor.Factor {
+	calc := &ability.CalculationAbility{}
+	calc.SetCalculationRule(calculation.NewCalculationRule(calculation.FormulaTypeSum, []string{"Q1", "Q2"}))
+
+	interp := &ability.InterpretationAbility{}
+	interp.SetInterpretationRules([]interpretation.InterpretRule{
+		interpretation.NewInterpretRule(interpretation.NewScoreRange(0, 2), "正常"),
+		interpretation.NewInterpretRule(interpretation.NewScoreRange(2, 5), "偏高"),
+	})
+
+	return factor.NewFactor("total", "总分", factor.PrimaryFactor,
+		factor.WithCalculation(calc),
+		factor.WithInterpretation(interp),
+		factor.WithIsTotalScore(true),
+	)
+}
+
+func TestSubmitter_SubmitAndInterpret(t *testing.T) {
+	aRepo := newFakeAnswerSheetRepo()
+	qRepo := &fakeQuestionnaireRepo{
+		qDomain: questionnaire.NewQuestionnaire(
+			questionnaire.NewQuestionnaireCode("QN1"),
+			"焦虑自评",
+			questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+			questionnaire.WithQuestions([]question.Question{newRadioQuestion("Q1"), newRadioQuestion("Q2")}),
+		),
+	}
+	msRepo := &fakeMedicalScaleRepo{
+		ms: medicalscale.NewMedicalScale("MS1", "焦虑量表",
+			medicalscale.WithQuestionnaireCode("QN1"),
+			medicalscale.WithFactors([]factor.Factor{newTotalScoreFactor()}),
+		),
+	}
+	irRepo := &fakeInterpretReportRepo{}
+
+	submitter := NewSubmitter(NewSaver(aRepo, qRepo, nil, nil, nil, nil, nil), aRepo, qRepo, msRepo, irRepo, nil, nil)
+
+	report, err := submitter.SubmitAndInterpret(context.Background(), dto.AnswerSheetDTO{
+		QuestionnaireCode:    "QN1",
+		QuestionnaireVersion: "1.0",
+		Title:                "焦虑自评",
+		WriterID:             1,
+		TesteeID:             2,
+		Answers: []dto.AnswerDTO{
+			{QuestionCode: "Q1", QuestionType: string(question.QuestionTypeRadio), Value: "B"},
+			{QuestionCode: "Q2", QuestionType: string(question.QuestionTypeRadio), Value: "C"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SubmitAndInterpret() error = %v", err)
+	}
+
+	if report.AnswerSheetId != 1 || report.MedicalScaleCode != "MS1" {
+		t.Errorf("report header mismatch, got answer_sheet_id=%d medical_scale_code=%s", report.AnswerSheetId, report.MedicalScaleCode)
+	}
+	if len(report.InterpretItems) != 1 {
+		t.Fatalf("len(InterpretItems) = %d, want 1", len(report.InterpretItems))
+	}
+	item := report.InterpretItems[0]
+	if item.FactorCode != "total" || item.Score != 3 || item.Content != "偏高" {
+		t.Errorf("interpret item = %+v, want total/3/偏高", item)
+	}
+
+	saved, _ := aRepo.FindByID(context.Background(), 1)
+	if saved == nil || saved.GetScore() != 3 {
+		t.Errorf("saved answer sheet score mismatch, got %+v", saved)
+	}
+	// 保存答卷时记录每题的作答事件，保存得分后事件日志仍然保留
+	if history := saved.GetAnswerHistory(question.NewQuestionCode("Q2")); len(history) != 1 || history[0].EventType != answersheet.AnswerEventTypeSet {
+		t.Errorf("Q2 history = %+v, want one AnswerSet event", history)
+	}
+	if state := saved.ReplayToState(time.Now()); len(state) != 2 {
+		t.Errorf("replayed %d answers, want 2", len(state))
+	}
+	if len(irRepo.reports) != 1 {
+		t.Errorf("len(reports) = %d, want 1", len(irRepo.reports))
+	}
+}
+
+func TestSubmitter_SubmitAndInterpret_QuestionnaireNotFound(t *testing.T) {
+	aRepo := newFakeAnswerSheetRepo()
+	qRepo := &fakeQuestionnaireRepo{}
+	submitter := NewSubmitter(NewSaver(aRepo, qRepo, nil, nil, nil, nil, nil), aRepo, qRepo, &fakeMedicalScaleRepo{}, &fakeInterpretReportRepo{}, nil, nil)
+
+	_, err := submitter.SubmitAndInterpret(context.Background(), dto.AnswerSheetDTO{
+		QuestionnaireCode:    "QN404",
+		QuestionnaireVersion: "1.0",
+		Title:                "不存在的问卷",
+		WriterID:             1,
+		TesteeID:             2,
+		Answers: []dto.AnswerDTO{
+			{QuestionCode: "Q1", QuestionType: string(question.QuestionTypeRadio), Value: "A"},
+		},
+	})
+	if !errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+		t.Fatalf("SubmitAndInterpret() error = %v, want ErrQuestionnaireNotFound", err)
+	}
+	if len(aRepo.sheets) != 0 {
+		t.Errorf("len(sheets) = %d, want 0", len(aRepo.sheets))
+	}
+}
+
+func TestSubmitter_SubmitAndInterpret_RestoresUploadsWhenReportFails(t *testing.T) {
+	qRepo, uploadRepo, _, uploader := newFileUploadFixture()
+	ctx := context.Background()
+
+	link, err := uploader.GenerateUploadURL(ctx, "QN1", "F1", "report.png", "image/png")
+	if err != nil {
+		t.Fatalf("GenerateUploadURL() error = %v", err)
+	}
+	objects := &fakeObjectStorage{sizes: map[string]int64{uploadRepo.uploads[link.UploadID].ObjectKey: 1024}}
+	msRepo := &fakeMedicalScaleRepo{
+		ms: medicalscale.NewMedicalScale("MS1", "体检量表",
+			medicalscale.WithQuestionnaireCode("QN1"),
+			medicalscale.WithFactors([]factor.Factor{newTotalScoreFactor()}),
+		),
+	}
+	sheet := newFileUploadSheet(link.UploadID)
+
+	aRepo := newFakeAnswerSheetRepo()
+	saver := NewSaver(aRepo, qRepo, nil, nil, nil, uploadRepo, objects)
+	failing := NewSubmitter(saver, aRepo, qRepo, msRepo, &failingInterpretReportRepo{}, nil, nil)
+	if _, err := failing.SubmitAndInterpret(ctx, sheet); !errors.IsCode(err, errCode.ErrDatabase) {
+		t.Fatalf("SubmitAndInterpret() error = %v, want ErrDatabase", err)
+	}
+	if len(aRepo.sheets) != 0 {
+		t.Errorf("len(sheets) = %d, want 0 after rollback", len(aRepo.sheets))
+	}
+	if _, ok := uploadRepo.uploads[link.UploadID]; !ok {
+		t.Fatalf("upload %s not restored after rollback", link.UploadID)
+	}
+
+	// 恢复的上传可以重新提交
+	submitter := NewSubmitter(saver, aRepo, qRepo, msRepo, &fakeInterpretReportRepo{}, nil, nil)
+	if _, err := submitter.SubmitAndInterpret(ctx, sheet); err != nil {
+		t.Errorf("SubmitAndInterpret() retry error = %v", err)
+	}
+}
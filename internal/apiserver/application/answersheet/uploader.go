@@ -0,0 +1,85 @@
+package answersheet
+
+import (
+	"context"
+	"io"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// DefaultMaxUploadSizeBytes 预上传文件的默认大小上限
+const DefaultMaxUploadSizeBytes int64 = 20 << 20
+
+// errFileTooLarge 读取的文件内容超过大小上限
+var errFileTooLarge = errors.New("file exceeds the upload size limit")
+
+// Uploader 文件上传器
+// 文件在提交答卷前预上传，答卷中只保存文件引用
+type Uploader struct {
+	fileRepo     port.FileStorageRepository
+	maxSizeBytes int64
+}
+
+// NewUploader 创建文件上传器，maxSizeBytes 不大于 0 时使用 DefaultMaxUploadSizeBytes
+func NewUploader(fileRepo port.FileStorageRepository, maxSizeBytes int64) *Uploader {
+	if maxSizeBytes <= 0 {
+		maxSizeBytes = DefaultMaxUploadSizeBytes
+	}
+	return &Uploader{
+		fileRepo:     fileRepo,
+		maxSizeBytes: maxSizeBytes,
+	}
+}
+
+// UploadFile 上传文件
+// 文件大小以实际读取的字节数为准，超过上限时中止存储
+func (u *Uploader) UploadFile(ctx context.Context, r io.Reader, meta port.FileMeta) (*dto.FileReferenceDTO, error) {
+	if meta.FileName == "" {
+		return nil, errors.WithCode(errCode.ErrValidation, "文件名不能为空")
+	}
+	if meta.SizeBytes <= 0 {
+		return nil, errors.WithCode(errCode.ErrValidation, "文件不能为空")
+	}
+	if meta.SizeBytes > u.maxSizeBytes {
+		return nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "文件超过大小上限 %d 字节", u.maxSizeBytes)
+	}
+
+	key, err := u.fileRepo.Store(ctx, &limitedReader{r: r, remaining: u.maxSizeBytes}, meta)
+	if err != nil {
+		if errors.Is(err, errFileTooLarge) {
+			return nil, errors.WithCode(errCode.ErrAnswerFileInvalid, "文件超过大小上限 %d 字节", u.maxSizeBytes)
+		}
+		return nil, errors.WrapC(err, errCode.ErrFileStorage, "存储文件失败")
+	}
+
+	stored, err := u.fileRepo.Stat(ctx, key)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrFileStorage, "查询文件失败")
+	}
+
+	return &dto.FileReferenceDTO{
+		FileName:   stored.FileName,
+		MimeType:   stored.MimeType,
+		StorageKey: key,
+		SizeBytes:  stored.SizeBytes,
+	}, nil
+}
+
+// limitedReader 读取超过 remaining 字节时返回 errFileTooLarge 的读取器
+type limitedReader struct {
+	r         io.Reader
+	remaining int64
+}
+
+// Read 读取数据，累计读取的字节数超过上限时返回错误
+func (l *limitedReader) Read(p []byte) (int, error) {
+	n, err := l.r.Read(p)
+	l.remaining -= int64(n)
+	if l.remaining < 0 {
+		return n, errFileTooLarge
+	}
+	return n, err
+}
@@ -0,0 +1,139 @@
+package answersheet
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// fakeFileStorageRepo 内存文件存储库，记录实际写入的字节数
+type fakeFileStorageRepo struct {
+	files map[string]*port.FileMeta
+}
+
+func newFakeFileStorageRepo() *fakeFileStorageRepo {
+	return &fakeFileStorageRepo{files: make(map[string]*port.FileMeta)}
+}
+
+func (r *fakeFileStorageRepo) Store(ctx context.Context, reader io.Reader, meta port.FileMeta) (string, error) {
+	n, err := io.Copy(io.Discard, reader)
+	if err != nil {
+		return "", err
+	}
+	key := fmt.Sprintf("file-%d", len(r.files)+1)
+	r.files[key] = &port.FileMeta{FileName: meta.FileName, MimeType: meta.MimeType, SizeBytes: n}
+	return key, nil
+}
+
+func (r *fakeFileStorageRepo) Retrieve(ctx context.Context, key string) (io.ReadCloser, error) {
+	return io.NopCloser(strings.NewReader("")), nil
+}
+
+func (r *fakeFileStorageRepo) Stat(ctx context.Context, key string) (*port.FileMeta, error) {
+	meta, ok := r.files[key]
+	if !ok {
+		return nil, errors.WithCode(errCode.ErrUploadNotFound, "文件 %s 不存在", key)
+	}
+	return meta, nil
+}
+
+func TestUploader_UploadFile_CapsSize(t *testing.T) {
+	fileRepo := newFakeFileStorageRepo()
+	uploader := NewUploader(fileRepo, 1024)
+	ctx := context.Background()
+
+	// 声明的大小超过上限
+	if _, err := uploader.UploadFile(ctx, bytes.NewReader(make([]byte, 10)), port.FileMeta{FileName: "a.png", MimeType: "image/png", SizeBytes: 2048}); !errors.IsCode(err, errCode.ErrAnswerFileInvalid) {
+		t.Errorf("UploadFile(declared 2048) error = %v, want ErrAnswerFileInvalid", err)
+	}
+
+	// 声明的大小在上限内，实际内容超过上限
+	if _, err := uploader.UploadFile(ctx, bytes.NewReader(make([]byte, 2048)), port.FileMeta{FileName: "a.png", MimeType: "image/png", SizeBytes: 10}); !errors.IsCode(err, errCode.ErrAnswerFileInvalid) {
+		t.Errorf("UploadFile(actual 2048) error = %v, want ErrAnswerFileInvalid", err)
+	}
+	if len(fileRepo.files) != 0 {
+		t.Errorf("stored %d files, want 0", len(fileRepo.files))
+	}
+
+	ref, err := uploader.UploadFile(ctx, bytes.NewReader(make([]byte, 512)), port.FileMeta{FileName: "a.png", MimeType: "image/png", SizeBytes: 1})
+	if err != nil {
+		t.Fatalf("UploadFile() error = %v", err)
+	}
+	if ref.SizeBytes != 512 {
+		t.Errorf("SizeBytes = %d, want stored size 512", ref.SizeBytes)
+	}
+}
+
+func TestSaver_SaveOriginalAnswerSheet_UsesStoredFileMeta(t *testing.T) {
+	qRepo, _, _, _ := newFileUploadFixture()
+	fileRepo := newFakeFileStorageRepo()
+	fileRepo.files["large"] = &port.FileMeta{FileName: "scan.png", MimeType: "image/png", SizeBytes: 2 << 20}
+	fileRepo.files["pdf"] = &port.FileMeta{FileName: "report.pdf", MimeType: "application/pdf", SizeBytes: 1024}
+	fileRepo.files["ok"] = &port.FileMeta{FileName: "scan.png", MimeType: "image/png", SizeBytes: 1024}
+	aRepo := newFakeAnswerSheetRepo()
+	saver := NewSaver(aRepo, qRepo, nil, nil, fileRepo, nil, nil)
+	ctx := context.Background()
+
+	// 客户端声明的文件名、类型与大小都满足约束，但存储记录不满足
+	sheetWithFile := func(key string) dto.AnswerSheetDTO {
+		sheet := newFileUploadSheet("")
+		sheet.Answers[0].Value = []any{map[string]any{
+			"file_name": "claimed.png", "mime_type": "image/png", "storage_key": key, "size_bytes": float64(1),
+		}}
+		return sheet
+	}
+	for _, key := range []string{"large", "pdf", "missing"} {
+		if _, err := saver.SaveOriginalAnswerSheet(ctx, sheetWithFile(key)); !errors.IsCode(err, errCode.ErrAnswerFileInvalid) {
+			t.Errorf("SaveOriginalAnswerSheet(%s) error = %v, want ErrAnswerFileInvalid", key, err)
+		}
+	}
+
+	if _, err := saver.SaveOriginalAnswerSheet(ctx, sheetWithFile("ok")); err != nil {
+		t.Fatalf("SaveOriginalAnswerSheet(ok) error = %v", err)
+	}
+	saved, _ := aRepo.FindByID(ctx, 1)
+	files, _ := saved.GetAnswers()[0].GetValue().Raw().([]values.FileReference)
+	if len(files) != 1 || files[0].FileName != "scan.png" || files[0].SizeBytes != 1024 {
+		t.Errorf("saved files = %+v, want stored metadata", files)
+	}
+}
+
+func TestValidateFileReferences(t *testing.T) {
+	q := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("F1")),
+		question.WithTitle("化验单"),
+		question.WithQuestionType(question.QuestionTypeFileUpload),
+		question.WithFileConstraints([]string{"image/png"}, 1024, 1),
+	))
+	png := values.FileReference{FileName: "a.png", MimeType: "image/png", StorageKey: "k1", SizeBytes: 512}
+
+	tests := []struct {
+		name    string
+		files   []values.FileReference
+		wantErr bool
+	}{
+		{name: "valid", files: []values.FileReference{png}},
+		{name: "too many files", files: []values.FileReference{png, png}, wantErr: true},
+		{name: "not uploaded", files: []values.FileReference{{FileName: "a.png", MimeType: "image/png"}}, wantErr: true},
+		{name: "mime type not allowed", files: []values.FileReference{{FileName: "a.pdf", MimeType: "application/pdf", StorageKey: "k2", SizeBytes: 512}}, wantErr: true},
+		{name: "too large", files: []values.FileReference{{FileName: "a.png", MimeType: "image/png", StorageKey: "k3", SizeBytes: 2048}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateFileReferences(q, tt.files)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("validateFileReferences() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package assignment
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	asPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+	"github.com/yshujie/questionnaire-scale/pkg/util/codeutil"
+)
+
+// completeTimeout 答卷提交后完成分配的超时时间
+const completeTimeout = 10 * time.Second
+
+// Assigner 问卷分配服务
+// 创建问卷分配，并在受试者提交问卷的答卷后完成其最早到期的未完成分配
+type Assigner struct {
+	repo       port.AssignmentRepository
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
+	now        func() time.Time
+}
+
+// NewAssigner 创建问卷分配服务
+func NewAssigner(repo port.AssignmentRepository, qRepoMongo qnPort.QuestionnaireRepositoryMongo) *Assigner {
+	return &Assigner{
+		repo:       repo,
+		qRepoMongo: qRepoMongo,
+		now:        time.Now,
+	}
+}
+
+// 确保实现了接口
+var (
+	_ port.AssignmentCreator     = (*Assigner)(nil)
+	_ port.AssignmentCompleter   = (*Assigner)(nil)
+	_ asPort.SubmissionPublisher = (*Assigner)(nil)
+)
+
+// CreateAssignment 为受试者分配问卷，问卷必须存在，截止日期必须晚于当前时间
+func (s *Assigner) CreateAssignment(
+	ctx context.Context,
+	respondentID uint64,
+	questionnaireCode string,
+	assignedBy uint64,
+	dueDate *time.Time,
+) (*assignment.Assignment, error) {
+	// 1. 校验参数
+	if respondentID == 0 || questionnaireCode == "" {
+		return nil, errors.WithCode(errCode.ErrAssignmentInvalid, "受试者ID和问卷编码不能为空")
+	}
+	if dueDate != nil && !dueDate.After(s.now()) {
+		return nil, errors.WithCode(errCode.ErrAssignmentInvalid, "截止日期必须晚于当前时间")
+	}
+	exists, err := s.qRepoMongo.ExistsByCode(ctx, questionnaireCode)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "查询问卷失败")
+	}
+	if !exists {
+		return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s", questionnaireCode)
+	}
+
+	// 2. 生成分配编码并保存
+	code, err := codeutil.GenerateCode()
+	if err != nil {
+		return nil, err
+	}
+	a := assignment.NewAssignment(code, respondentID, questionnaireCode, assignedBy, dueDate)
+	if err := s.repo.Create(ctx, a); err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "保存问卷分配失败")
+	}
+
+	return a, nil
+}
+
+// CompleteAssignment 将分配标记为已完成，分配不存在时返回 ErrAssignmentNotFound，已完成时返回 ErrAssignmentAlreadyCompleted
+func (s *Assigner) CompleteAssignment(ctx context.Context, assignmentCode string) error {
+	a, err := s.repo.FindByCode(ctx, assignmentCode)
+	if err != nil {
+		return err
+	}
+	if err := a.Complete(s.now()); err != nil {
+		return err
+	}
+	if err := s.repo.Update(ctx, a); err != nil {
+		return errors.WrapC(err, errCode.ErrDatabase, "保存问卷分配失败")
+	}
+	return nil
+}
+
+// PublishSubmitted 答卷提交后在后台完成受试者在该问卷下最早到期的未完成分配，失败只记录日志
+func (s *Assigner) PublishSubmitted(event answersheet.AnswerSheetSubmitted) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), completeTimeout)
+		defer cancel()
+
+		if err := s.completeForSubmission(ctx, event); err != nil {
+			log.Errorf("完成问卷分配失败，受试者ID: %d, 问卷编码: %s, 错误: %v", event.TesteeID, event.QuestionnaireCode, err)
+		}
+	}()
+}
+
+// completeForSubmission 完成答卷对应的问卷分配，受试者没有未完成的分配时不做处理
+func (s *Assigner) completeForSubmission(ctx context.Context, event answersheet.AnswerSheetSubmitted) error {
+	if event.TesteeID == 0 {
+		return nil
+	}
+	pending, err := s.repo.FindPending(ctx, event.TesteeID, event.QuestionnaireCode)
+	if err != nil {
+		return errors.WrapC(err, errCode.ErrDatabase, "查询未完成的问卷分配失败")
+	}
+	if len(pending) == 0 {
+		return nil
+	}
+	return s.CompleteAssignment(ctx, pending[0].AssignmentCode)
+}
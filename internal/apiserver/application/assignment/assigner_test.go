@@ -0,0 +1,149 @@
+package assignment
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// fakeAssignmentRepo 内存问卷分配存储库
+type fakeAssignmentRepo struct {
+	port.AssignmentRepository
+	assignments map[string]*assignment.Assignment
+	updates     int
+}
+
+func newFakeAssignmentRepo(assignments ...*assignment.Assignment) *fakeAssignmentRepo {
+	r := &fakeAssignmentRepo{assignments: make(map[string]*assignment.Assignment)}
+	for _, a := range assignments {
+		r.assignments[a.AssignmentCode] = a
+	}
+	return r
+}
+
+func (r *fakeAssignmentRepo) Create(ctx context.Context, a *assignment.Assignment) error {
+	a.ID = uint64(len(r.assignments) + 1)
+	r.assignments[a.AssignmentCode] = a
+	return nil
+}
+
+func (r *fakeAssignmentRepo) FindByCode(ctx context.Context, assignmentCode string) (*assignment.Assignment, error) {
+	a, ok := r.assignments[assignmentCode]
+	if !ok {
+		return nil, errors.WithCode(errCode.ErrAssignmentNotFound, "assignment not found: %s", assignmentCode)
+	}
+	return a, nil
+}
+
+func (r *fakeAssignmentRepo) FindPending(ctx context.Context, respondentID uint64, questionnaireCode string) ([]*assignment.Assignment, error) {
+	var pending []*assignment.Assignment
+	for _, a := range r.assignments {
+		if a.RespondentID == respondentID && a.QuestionnaireCode == questionnaireCode && !a.IsCompleted() {
+			pending = append(pending, a)
+		}
+	}
+	sort.Slice(pending, func(i, j int) bool {
+		if pending[i].DueDate == nil || pending[j].DueDate == nil {
+			return pending[j].DueDate == nil && pending[i].DueDate != nil
+		}
+		return pending[i].DueDate.Before(*pending[j].DueDate)
+	})
+	return pending, nil
+}
+
+func (r *fakeAssignmentRepo) FindOverdue(ctx context.Context, now time.Time, limit int) ([]*assignment.Assignment, error) {
+	var overdue []*assignment.Assignment
+	for _, a := range r.assignments {
+		if a.IsOverdue(now) && a.ReminderSentAt == nil {
+			overdue = append(overdue, a)
+		}
+	}
+	return overdue, nil
+}
+
+func (r *fakeAssignmentRepo) Update(ctx context.Context, a *assignment.Assignment) error {
+	r.updates++
+	r.assignments[a.AssignmentCode] = a
+	return nil
+}
+
+// fakeQuestionnaireRepo 只实现按编码判断问卷是否存在
+type fakeQuestionnaireRepo struct {
+	qnPort.QuestionnaireRepositoryMongo
+	codes []string
+}
+
+func (r *fakeQuestionnaireRepo) ExistsByCode(ctx context.Context, code string) (bool, error) {
+	for _, c := range r.codes {
+		if c == code {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+var testNow = time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
+
+func TestAssigner_CreateAssignmentValidation(t *testing.T) {
+	repo := newFakeAssignmentRepo()
+	assigner := NewAssigner(repo, &fakeQuestionnaireRepo{codes: []string{"PHQ9"}})
+	assigner.now = func() time.Time { return testNow }
+
+	tests := []struct {
+		name              string
+		questionnaireCode string
+		dueDate           *time.Time
+		wantCode          int
+	}{
+		{"missing questionnaire code", "", nil, errCode.ErrAssignmentInvalid},
+		{"due date in the past", "PHQ9", timePtr(testNow.Add(-time.Hour)), errCode.ErrAssignmentInvalid},
+		{"unknown questionnaire", "GAD7", nil, errCode.ErrQuestionnaireNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := assigner.CreateAssignment(context.Background(), 42, tt.questionnaireCode, 7, tt.dueDate)
+			if !errors.IsCode(err, tt.wantCode) {
+				t.Errorf("CreateAssignment() error = %v, want code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestAssigner_CompletesEarliestPendingAssignmentOnSubmission(t *testing.T) {
+	later := assignment.NewAssignment("A-LATER", 42, "PHQ9", 7, timePtr(testNow.Add(48*time.Hour)))
+	earlier := assignment.NewAssignment("A-EARLIER", 42, "PHQ9", 7, timePtr(testNow.Add(24*time.Hour)))
+	other := assignment.NewAssignment("A-OTHER", 42, "GAD7", 7, nil)
+	repo := newFakeAssignmentRepo(later, earlier, other)
+	assigner := NewAssigner(repo, &fakeQuestionnaireRepo{})
+	assigner.now = func() time.Time { return testNow }
+
+	event := answersheet.AnswerSheetSubmitted{AnswerSheetID: 1, QuestionnaireCode: "PHQ9", TesteeID: 42}
+	if err := assigner.completeForSubmission(context.Background(), event); err != nil {
+		t.Fatalf("completeForSubmission() error = %v", err)
+	}
+	if earlier.CompletedAt == nil || !earlier.CompletedAt.Equal(testNow) {
+		t.Errorf("earliest assignment CompletedAt = %v, want %v", earlier.CompletedAt, testNow)
+	}
+	if later.IsCompleted() || other.IsCompleted() {
+		t.Error("other assignments were completed, want only the earliest due assignment of PHQ9")
+	}
+
+	if err := assigner.CompleteAssignment(context.Background(), "A-EARLIER"); !errors.IsCode(err, errCode.ErrAssignmentAlreadyCompleted) {
+		t.Errorf("CompleteAssignment() on completed assignment error = %v, want ErrAssignmentAlreadyCompleted", err)
+	}
+	if err := assigner.CompleteAssignment(context.Background(), "A-MISSING"); !errors.IsCode(err, errCode.ErrAssignmentNotFound) {
+		t.Errorf("CompleteAssignment() on missing assignment error = %v, want ErrAssignmentNotFound", err)
+	}
+}
@@ -0,0 +1,77 @@
+package assignment
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	hookPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// DefaultOverdueSchedule 默认每小时检查一次逾期分配
+	DefaultOverdueSchedule = "0 * * * *"
+	// overdueBatchSize 每次检查处理的逾期分配数量上限，剩余的在下次检查时处理
+	overdueBatchSize = 500
+)
+
+// OverdueChecker 逾期问卷分配检查任务
+// 对截止日期已过仍未完成的分配记录警告日志，并向问卷的通知钩子推送 assignment.overdue 事件；
+// 每个分配只提醒一次，提醒后记录提醒时间
+type OverdueChecker struct {
+	repo     port.AssignmentRepository
+	notifier hookPort.EventNotifier
+	schedule string
+	now      func() time.Time
+}
+
+// NewOverdueChecker 创建逾期问卷分配检查任务，notifier 为空时只记录日志
+func NewOverdueChecker(repo port.AssignmentRepository, notifier hookPort.EventNotifier, schedule string) *OverdueChecker {
+	return &OverdueChecker{
+		repo:     repo,
+		notifier: notifier,
+		schedule: schedule,
+		now:      time.Now,
+	}
+}
+
+// Name 任务名称
+func (c *OverdueChecker) Name() string {
+	return "assignment-overdue-check"
+}
+
+// Schedule cron 表达式
+func (c *OverdueChecker) Schedule() string {
+	return c.schedule
+}
+
+// Run 检查逾期分配
+func (c *OverdueChecker) Run(ctx context.Context) error {
+	now := c.now()
+	overdue, err := c.repo.FindOverdue(ctx, now, overdueBatchSize)
+	if err != nil {
+		return errors.WrapC(err, errCode.ErrDatabase, "查询逾期问卷分配失败")
+	}
+
+	for _, a := range overdue {
+		log.Warnf("问卷分配已逾期，分配编码: %s, 受试者ID: %d, 问卷编码: %s, 截止日期: %s",
+			a.AssignmentCode, a.RespondentID, a.QuestionnaireCode, a.DueDate.Format(time.RFC3339))
+		if c.notifier != nil {
+			c.notifier.Notify(notificationhook.EventAssignmentOverdue, a.QuestionnaireCode)
+		}
+
+		a.MarkReminderSent(now)
+		if err := c.repo.Update(ctx, a); err != nil {
+			return errors.WrapC(err, errCode.ErrDatabase, "保存问卷分配提醒时间失败")
+		}
+	}
+
+	if len(overdue) > 0 {
+		log.Infof("逾期问卷分配检查完成，提醒数量: %d", len(overdue))
+	}
+	return nil
+}
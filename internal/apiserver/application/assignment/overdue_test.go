@@ -0,0 +1,50 @@
+package assignment
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+)
+
+// recordingNotifier 记录通知的事件和问卷编码
+type recordingNotifier struct {
+	events []string
+}
+
+func (n *recordingNotifier) Notify(event, questionnaireCode string) {
+	n.events = append(n.events, event+":"+questionnaireCode)
+}
+
+func TestOverdueChecker_Run(t *testing.T) {
+	overdue := assignment.NewAssignment("A-OVERDUE", 42, "PHQ9", 7, timePtr(testNow.Add(-time.Hour)))
+	completed := assignment.NewAssignment("A-DONE", 42, "GAD7", 7, timePtr(testNow.Add(-time.Hour)))
+	completed.CompletedAt = timePtr(testNow.Add(-2 * time.Hour))
+	upcoming := assignment.NewAssignment("A-UPCOMING", 43, "PHQ9", 7, timePtr(testNow.Add(time.Hour)))
+	repo := newFakeAssignmentRepo(overdue, completed, upcoming)
+
+	notifier := &recordingNotifier{}
+	checker := NewOverdueChecker(repo, notifier, DefaultOverdueSchedule)
+	checker.now = func() time.Time { return testNow }
+
+	if err := checker.Run(context.Background()); err != nil {
+		t.Fatalf("Run() error = %v", err)
+	}
+	want := notificationhook.EventAssignmentOverdue + ":PHQ9"
+	if len(notifier.events) != 1 || notifier.events[0] != want {
+		t.Fatalf("notified events = %v, want [%s]", notifier.events, want)
+	}
+	if overdue.ReminderSentAt == nil || !overdue.ReminderSentAt.Equal(testNow) {
+		t.Errorf("ReminderSentAt = %v, want %v", overdue.ReminderSentAt, testNow)
+	}
+
+	// 已提醒的分配不会重复提醒
+	if err := checker.Run(context.Background()); err != nil {
+		t.Fatalf("second Run() error = %v", err)
+	}
+	if len(notifier.events) != 1 {
+		t.Errorf("notified events after second run = %v, want a single reminder", notifier.events)
+	}
+}
@@ -0,0 +1,32 @@
+package assignment
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Queryer 问卷分配查询器
+type Queryer struct {
+	repo port.AssignmentRepository
+}
+
+// NewQueryer 创建问卷分配查询器
+func NewQueryer(repo port.AssignmentRepository) *Queryer {
+	return &Queryer{repo: repo}
+}
+
+// 确保实现了接口
+var _ port.AssignmentQueryer = (*Queryer)(nil)
+
+// ListAssignments 分页查询问卷分配，按创建时间倒序
+func (q *Queryer) ListAssignments(ctx context.Context, filter port.AssignmentFilter, page, pageSize int) ([]*assignment.Assignment, int64, error) {
+	assignments, total, err := q.repo.FindList(ctx, filter, page, pageSize)
+	if err != nil {
+		return nil, 0, errors.WrapC(err, errCode.ErrDatabase, "查询问卷分配失败")
+	}
+	return assignments, total, nil
+}
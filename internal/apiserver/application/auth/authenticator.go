@@ -12,13 +12,15 @@ import (
 
 // Authenticator 认证器
 type Authenticator struct {
-	userRepo port.UserRepository
+	userRepo  port.UserRepository
+	scopeRepo port.UserScopeRepository
 }
 
 // NewAuthenticator 创建认证器
-func NewAuthenticator(userRepo port.UserRepository) port.Authenticator {
+func NewAuthenticator(userRepo port.UserRepository, scopeRepo port.UserScopeRepository) port.Authenticator {
 	return &Authenticator{
-		userRepo: userRepo,
+		userRepo:  userRepo,
+		scopeRepo: scopeRepo,
 	}
 }
 
@@ -35,7 +37,35 @@ func (a *Authenticator) Authenticate(ctx context.Context, username, password str
 		return nil, errors.WithCode(code.ErrPasswordIncorrect, "password incorrect")
 	}
 
-	// 3. 返回用户对象，token由gin-jwt中间件生成
+	// 3. 加载用户可管理的问卷范围，写入 JWT 负载
+	if err := a.loadScopes(ctx, userObj); err != nil {
+		return nil, err
+	}
+
+	// 4. 返回用户对象，token由gin-jwt中间件生成
 	// 这里不再生成token，因为gin-jwt会用正确的密钥重新生成
 	return userObj, nil // 空字符串表示不生成token
 }
+
+// LoadUser 按用户ID重新加载用户及其可管理的问卷范围
+func (a *Authenticator) LoadUser(ctx context.Context, userID uint64) (*user.User, error) {
+	userObj, err := a.userRepo.FindByID(ctx, user.NewUserID(userID))
+	if err != nil || userObj == nil {
+		return nil, errors.WithCode(code.ErrUserNotFound, "user not found")
+	}
+
+	if err := a.loadScopes(ctx, userObj); err != nil {
+		return nil, err
+	}
+	return userObj, nil
+}
+
+// loadScopes 加载用户可管理的问卷范围
+func (a *Authenticator) loadScopes(ctx context.Context, userObj *user.User) error {
+	scopes, err := a.scopeRepo.FindScopesByUserID(ctx, userObj.ID().Value())
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "load user scopes failed")
+	}
+	userObj.SetScopes(scopes)
+	return nil
+}
@@ -0,0 +1,145 @@
+package auth
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+const (
+	// DefaultCaptchaFailureThreshold 默认需要验证码的连续登录失败次数
+	DefaultCaptchaFailureThreshold = 5
+	// DefaultCaptchaFailureWindow 默认登录失败次数的统计窗口
+	DefaultCaptchaFailureWindow = 15 * time.Minute
+)
+
+// unconfiguredCaptchaVerifier 未配置验证码校验器时使用，拒绝所有验证码令牌
+// 需要验证码的 IP 在统计窗口结束前无法登录，避免未接入人机验证服务时验证码形同虚设
+type unconfiguredCaptchaVerifier struct{}
+
+// Verify 校验验证码令牌
+func (unconfiguredCaptchaVerifier) Verify(ctx context.Context, token, clientIP string) (bool, error) {
+	return false, errors.New("captcha verifier is not configured")
+}
+
+// loginFailures 同一 IP 在统计窗口内的登录失败记录
+type loginFailures struct {
+	count int
+	since time.Time
+}
+
+// LoginCaptchaGuard 登录验证码守卫
+// 在内存中按 IP 统计登录失败次数，超过统计窗口的失败记录重新计数
+type LoginCaptchaGuard struct {
+	verifier  port.CaptchaVerifier
+	threshold int
+	window    time.Duration
+	now       func() time.Time
+
+	mu        sync.Mutex
+	failures  map[string]*loginFailures
+	lastSweep time.Time
+}
+
+// NewLoginCaptchaGuard 创建登录验证码守卫
+// threshold 为需要验证码的失败次数，小于等于 0 时不启用验证码；window 为失败次数的统计窗口
+// verifier 为空时拒绝所有验证码令牌
+func NewLoginCaptchaGuard(verifier port.CaptchaVerifier, threshold int, window time.Duration) port.LoginCaptchaGuard {
+	if verifier == nil {
+		verifier = unconfiguredCaptchaVerifier{}
+	}
+	if window <= 0 {
+		window = DefaultCaptchaFailureWindow
+	}
+	return &LoginCaptchaGuard{
+		verifier:  verifier,
+		threshold: threshold,
+		window:    window,
+		now:       time.Now,
+		failures:  make(map[string]*loginFailures),
+	}
+}
+
+// Check 校验登录请求，失败次数未达到阈值时直接通过
+func (g *LoginCaptchaGuard) Check(ctx context.Context, clientIP, token string) error {
+	if !g.required(clientIP) {
+		return nil
+	}
+	if token == "" {
+		return errors.WithCode(code.ErrCaptchaRequired, "captcha is required after repeated failed logins")
+	}
+
+	ok, err := g.verifier.Verify(ctx, token, clientIP)
+	if err != nil {
+		return errors.WrapC(err, code.ErrCaptchaInvalid, "verify captcha failed")
+	}
+	if !ok {
+		return errors.WithCode(code.ErrCaptchaInvalid, "captcha is invalid")
+	}
+	return nil
+}
+
+// RecordFailure 记录一次登录失败
+func (g *LoginCaptchaGuard) RecordFailure(clientIP string) {
+	if g.threshold <= 0 {
+		return
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	now := g.now()
+	g.sweep(now)
+	f, ok := g.failures[clientIP]
+	if !ok || now.Sub(f.since) > g.window {
+		g.failures[clientIP] = &loginFailures{count: 1, since: now}
+		return
+	}
+	f.count++
+}
+
+// RecordSuccess 登录成功后清除失败记录
+func (g *LoginCaptchaGuard) RecordSuccess(clientIP string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	delete(g.failures, clientIP)
+}
+
+// required 判断该 IP 的登录是否需要验证码
+func (g *LoginCaptchaGuard) required(clientIP string) bool {
+	if g.threshold <= 0 {
+		return false
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	f, ok := g.failures[clientIP]
+	if !ok {
+		return false
+	}
+	if g.now().Sub(f.since) > g.window {
+		delete(g.failures, clientIP)
+		return false
+	}
+	return f.count >= g.threshold
+}
+
+// sweep 每个统计窗口清理一次已过期的失败记录，避免失败记录随来访 IP 无限增长，调用方需持有锁
+func (g *LoginCaptchaGuard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < g.window {
+		return
+	}
+	g.lastSweep = now
+
+	for ip, f := range g.failures {
+		if now.Sub(f.since) > g.window {
+			delete(g.failures, ip)
+		}
+	}
+}
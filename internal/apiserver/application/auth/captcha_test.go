@@ -0,0 +1,41 @@
+package auth
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestLoginCaptchaGuard_FailsClosedWithoutVerifier(t *testing.T) {
+	guard := NewLoginCaptchaGuard(nil, 1, time.Minute)
+	guard.RecordFailure("10.0.0.1")
+
+	err := guard.Check(context.Background(), "10.0.0.1", "any-token")
+	if !errors.IsCode(err, code.ErrCaptchaInvalid) {
+		t.Errorf("Check() error = %v, want ErrCaptchaInvalid", err)
+	}
+}
+
+func TestLoginCaptchaGuard_EvictsExpiredFailures(t *testing.T) {
+	now := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	guard := NewLoginCaptchaGuard(nil, 3, time.Minute).(*LoginCaptchaGuard)
+	guard.now = func() time.Time { return now }
+
+	for _, ip := range []string{"10.0.0.1", "10.0.0.2", "10.0.0.3"} {
+		guard.RecordFailure(ip)
+	}
+
+	// 统计窗口过后的下一次失败记录会清理过期的记录
+	now = now.Add(2 * time.Minute)
+	guard.RecordFailure("10.0.0.4")
+
+	if len(guard.failures) != 1 {
+		t.Errorf("tracked IPs = %d, want 1", len(guard.failures))
+	}
+	if _, ok := guard.failures["10.0.0.4"]; !ok {
+		t.Error("failure of 10.0.0.4 was evicted")
+	}
+}
@@ -0,0 +1,42 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// LoginAuditor 登录审计服务
+type LoginAuditor struct {
+	auditRepo port.LoginAuditRepository
+}
+
+// NewLoginAuditor 创建登录审计服务
+func NewLoginAuditor(auditRepo port.LoginAuditRepository) port.LoginAuditor {
+	return &LoginAuditor{auditRepo: auditRepo}
+}
+
+// Record 记录一次认证尝试
+func (a *LoginAuditor) Record(ctx context.Context, audit *user.LoginAudit) error {
+	if err := a.auditRepo.Create(ctx, audit); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "保存登录审计记录失败")
+	}
+	return nil
+}
+
+// ListByUsername 分页查询用户的登录记录，按时间倒序
+func (a *LoginAuditor) ListByUsername(ctx context.Context, username string, page, pageSize int) ([]*user.LoginAudit, int64, error) {
+	audits, err := a.auditRepo.FindByUsername(ctx, username, page, pageSize)
+	if err != nil {
+		return nil, 0, errors.WrapC(err, code.ErrDatabase, "查询登录审计记录失败")
+	}
+
+	total, err := a.auditRepo.CountByUsername(ctx, username)
+	if err != nil {
+		return nil, 0, errors.WrapC(err, code.ErrDatabase, "统计登录审计记录失败")
+	}
+	return audits, total, nil
+}
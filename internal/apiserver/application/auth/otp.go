@@ -0,0 +1,179 @@
+package auth
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/auth"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// OTPIssuer 一次性密码在验证器 App 中显示的签发方
+const OTPIssuer = "questionnaire-scale"
+
+const (
+	// otpPeriod TOTP 时间步长（秒）
+	otpPeriod = 30
+	// otpSkew 允许前后偏差的时间步数
+	otpSkew = 1
+	// otpExpiredLookback 判定为过期而非错误时向前检查的时间步数
+	otpExpiredLookback = 10
+)
+
+// otpCodeOpts TOTP 生成参数，与验证器 App 的默认参数一致
+var otpCodeOpts = totp.ValidateOpts{
+	Period:    otpPeriod,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// OTPManager 一次性密码管理器
+// TOTP 密钥加密后保存，每个时间步的一次性密码只能使用一次
+type OTPManager struct {
+	userRepo  port.UserRepository
+	scopeRepo port.UserScopeRepository
+	secretKey string
+	now       func() time.Time
+}
+
+// NewOTPManager 创建一次性密码管理器，secretKey 用于加密保存 TOTP 密钥
+func NewOTPManager(userRepo port.UserRepository, scopeRepo port.UserScopeRepository, secretKey string) port.OTPManager {
+	return &OTPManager{
+		userRepo:  userRepo,
+		scopeRepo: scopeRepo,
+		secretKey: secretKey,
+		now:       time.Now,
+	}
+}
+
+// Setup 为用户生成 TOTP 密钥，加密后保存为待启用密钥，返回用于生成二维码的 otpauth URL
+// 待启用密钥通过 Enable 校验后才会启用，在此之前登录仍使用原有密钥（或不要求二次验证）
+func (m *OTPManager) Setup(ctx context.Context, username string) (string, error) {
+	userObj, err := m.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return "", err
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      OTPIssuer,
+		AccountName: userObj.Username(),
+		Period:      otpPeriod,
+		Digits:      otpCodeOpts.Digits,
+		Algorithm:   otpCodeOpts.Algorithm,
+	})
+	if err != nil {
+		return "", errors.WrapC(err, code.ErrInternalServerError, "generate otp secret failed")
+	}
+
+	encrypted, err := auth.EncryptSecret(m.secretKey, key.Secret())
+	if err != nil {
+		return "", errors.WrapC(err, code.ErrEncrypt, "encrypt otp secret failed")
+	}
+
+	userObj.SetOTPPendingSecret(encrypted)
+	if err := m.userRepo.UpdateOTP(ctx, userObj); err != nil {
+		return "", errors.WrapC(err, code.ErrDatabase, "save otp secret failed")
+	}
+
+	return key.URL(), nil
+}
+
+// Enable 校验用待启用密钥生成的一次性密码，通过后启用该密钥
+// 没有待启用密钥或一次性密码错误时返回 ErrOTPInvalid，超出允许偏差时返回 ErrOTPExpired
+func (m *OTPManager) Enable(ctx context.Context, username, passcode string) error {
+	userObj, err := m.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return err
+	}
+	if userObj.OTPPendingSecret() == "" {
+		return errors.WithCode(code.ErrOTPInvalid, "otp setup is not pending")
+	}
+
+	secret, err := auth.DecryptSecret(m.secretKey, userObj.OTPPendingSecret())
+	if err != nil {
+		return errors.WrapC(err, code.ErrEncrypt, "decrypt otp secret failed")
+	}
+
+	step, err := m.matchStep(secret, passcode)
+	if err != nil {
+		return err
+	}
+	userObj.ActivateOTPSecret(step)
+	if err := m.userRepo.UpdateOTP(ctx, userObj); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "enable otp secret failed")
+	}
+
+	return nil
+}
+
+// Verify 校验用户提交的一次性密码，通过后返回用户（含可管理的问卷范围）
+// 已使用过的一次性密码返回 ErrOTPInvalid，超出允许偏差的一次性密码返回 ErrOTPExpired
+func (m *OTPManager) Verify(ctx context.Context, username, passcode string) (*user.User, error) {
+	userObj, err := m.userRepo.FindByUsername(ctx, username)
+	if err != nil {
+		return nil, err
+	}
+	if !userObj.OTPEnabled() {
+		return nil, errors.WithCode(code.ErrOTPInvalid, "otp is not configured")
+	}
+
+	secret, err := auth.DecryptSecret(m.secretKey, userObj.OTPSecret())
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrEncrypt, "decrypt otp secret failed")
+	}
+
+	step, err := m.matchStep(secret, passcode)
+	if err != nil {
+		return nil, err
+	}
+	consumed, err := m.userRepo.ConsumeOTPStep(ctx, userObj.ID(), step)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "save otp step failed")
+	}
+	if !consumed {
+		return nil, errors.WithCode(code.ErrOTPInvalid, "otp code has already been used")
+	}
+
+	scopes, err := m.scopeRepo.FindScopesByUserID(ctx, userObj.ID().Value())
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "load user scopes failed")
+	}
+	userObj.SetScopes(scopes)
+
+	return userObj, nil
+}
+
+// matchStep 查找一次性密码对应的时间步，错误时返回 ErrOTPInvalid，超出允许偏差时返回 ErrOTPExpired
+func (m *OTPManager) matchStep(secret, passcode string) (int64, error) {
+	now := m.now()
+	step, ok := matchOTPStep(secret, passcode, now)
+	if !ok {
+		return 0, errors.WithCode(code.ErrOTPInvalid, "otp code is invalid")
+	}
+	if step < now.Unix()/otpPeriod-otpSkew {
+		return 0, errors.WithCode(code.ErrOTPExpired, "otp code is expired")
+	}
+	return step, nil
+}
+
+// matchOTPStep 查找一次性密码对应的时间步，从允许偏差的最新时间步向前检查到过期回看范围
+func matchOTPStep(secret, passcode string, now time.Time) (int64, bool) {
+	current := now.Unix() / otpPeriod
+	for step := current + otpSkew; step >= current-otpExpiredLookback; step-- {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*otpPeriod, 0), otpCodeOpts)
+		if err != nil {
+			return 0, false
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(passcode)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
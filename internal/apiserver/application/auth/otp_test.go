@@ -0,0 +1,210 @@
+package auth
+
+import (
+	"context"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// fakeUserRepo 只保存一个用户的存储库，未用到的方法由嵌入的接口提供
+type fakeUserRepo struct {
+	port.UserRepository
+	mu      sync.Mutex
+	user    *user.User
+	updates int
+}
+
+func (r *fakeUserRepo) FindByUsername(ctx context.Context, username string) (*user.User, error) {
+	if username != r.user.Username() {
+		return nil, errors.WithCode(code.ErrUserNotFound, "user not found: %s", username)
+	}
+	return r.user, nil
+}
+
+func (r *fakeUserRepo) Update(ctx context.Context, u *user.User) error {
+	r.user = u
+	r.updates++
+	return nil
+}
+
+func (r *fakeUserRepo) UpdateOTP(ctx context.Context, u *user.User) error {
+	return r.Update(ctx, u)
+}
+
+// ConsumeOTPStep 与数据库的条件更新一样，在锁内比较并记录时间步
+func (r *fakeUserRepo) ConsumeOTPStep(ctx context.Context, id user.UserID, step int64) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.user.ConsumeOTPStep(step), nil
+}
+
+// fakeScopeRepo 返回固定问卷范围的存储库
+type fakeScopeRepo struct{}
+
+func (fakeScopeRepo) FindScopesByUserID(ctx context.Context, userID uint64) ([]string, error) {
+	return []string{"phq*"}, nil
+}
+
+// setup 为 alice 生成待启用的 TOTP 密钥，返回明文密钥
+func setup(t *testing.T, m *OTPManager) string {
+	t.Helper()
+	provisioningURL, err := m.Setup(context.Background(), "alice")
+	if err != nil {
+		t.Fatalf("Setup() error = %v", err)
+	}
+
+	u, err := url.Parse(provisioningURL)
+	if err != nil {
+		t.Fatalf("parse provisioning url %q: %v", provisioningURL, err)
+	}
+	if u.Scheme != "otpauth" || u.Query().Get("issuer") != OTPIssuer {
+		t.Fatalf("provisioning url = %q, want otpauth url issued by %s", provisioningURL, OTPIssuer)
+	}
+	return u.Query().Get("secret")
+}
+
+// enroll 为 alice 绑定并启用 TOTP，返回明文密钥，启用使用的是上一个时间步的一次性密码
+func enroll(t *testing.T, m *OTPManager) string {
+	t.Helper()
+	secret := setup(t, m)
+	passcode, err := totp.GenerateCodeCustom(secret, m.now().Add(-otpPeriod*time.Second), otpCodeOpts)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if err := m.Enable(context.Background(), "alice", passcode); err != nil {
+		t.Fatalf("Enable() error = %v", err)
+	}
+	return secret
+}
+
+func newTestOTPManager(now time.Time) (*OTPManager, *fakeUserRepo) {
+	repo := &fakeUserRepo{
+		user: user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build(),
+	}
+	m := NewOTPManager(repo, fakeScopeRepo{}, "test-secret-key").(*OTPManager)
+	m.now = func() time.Time { return now }
+	return m, repo
+}
+
+func TestOTPManager_SetupRequiresVerifiedCode(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	m, repo := newTestOTPManager(now)
+
+	secret := setup(t, m)
+	if secret == "" {
+		t.Fatal("provisioning url has no secret")
+	}
+	if repo.user.OTPEnabled() {
+		t.Fatal("OTPEnabled() = true before the secret is verified")
+	}
+	if repo.user.OTPPendingSecret() == secret {
+		t.Error("OTP secret stored in plain text")
+	}
+
+	// 错误的一次性密码不会启用密钥
+	if err := m.Enable(context.Background(), "alice", "000000"); !errors.IsCode(err, code.ErrOTPInvalid) {
+		t.Fatalf("Enable(wrong) error = %v, want ErrOTPInvalid", err)
+	}
+	if repo.user.OTPEnabled() {
+		t.Fatal("OTPEnabled() = true after a wrong code")
+	}
+
+	valid, err := totp.GenerateCodeCustom(secret, now, otpCodeOpts)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	if err := m.Enable(context.Background(), "alice", valid); err != nil {
+		t.Fatalf("Enable(valid) error = %v", err)
+	}
+	if !repo.user.OTPEnabled() || repo.user.OTPPendingSecret() != "" {
+		t.Fatalf("OTPEnabled() = %v, pending = %q, want the pending secret enabled", repo.user.OTPEnabled(), repo.user.OTPPendingSecret())
+	}
+
+	// 启用用过的一次性密码不能再用于登录
+	if _, err := m.Verify(context.Background(), "alice", valid); !errors.IsCode(err, code.ErrOTPInvalid) {
+		t.Errorf("Verify(code used to enable) error = %v, want ErrOTPInvalid", err)
+	}
+}
+
+func TestOTPManager_Verify(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	m, _ := newTestOTPManager(now)
+	secret := enroll(t, m)
+
+	valid, err := totp.GenerateCodeCustom(secret, now, otpCodeOpts)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+	expired, err := totp.GenerateCodeCustom(secret, now.Add(-5*time.Minute), otpCodeOpts)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	// 有效的一次性密码
+	got, err := m.Verify(context.Background(), "alice", valid)
+	if err != nil {
+		t.Fatalf("Verify(valid) error = %v", err)
+	}
+	if len(got.Scopes()) != 1 || got.Scopes()[0] != "phq*" {
+		t.Errorf("Scopes() = %v, want [phq*]", got.Scopes())
+	}
+
+	// 重放同一个一次性密码
+	if _, err := m.Verify(context.Background(), "alice", valid); !errors.IsCode(err, code.ErrOTPInvalid) {
+		t.Errorf("Verify(replayed) error = %v, want ErrOTPInvalid", err)
+	}
+
+	// 过期的一次性密码
+	if _, err := m.Verify(context.Background(), "alice", expired); !errors.IsCode(err, code.ErrOTPExpired) {
+		t.Errorf("Verify(expired) error = %v, want ErrOTPExpired", err)
+	}
+
+	// 错误的一次性密码
+	if _, err := m.Verify(context.Background(), "alice", "abcdef"); !errors.IsCode(err, code.ErrOTPInvalid) {
+		t.Errorf("Verify(wrong) error = %v, want ErrOTPInvalid", err)
+	}
+}
+
+func TestOTPManager_VerifyConcurrentReplay(t *testing.T) {
+	now := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	m, _ := newTestOTPManager(now)
+	secret := enroll(t, m)
+
+	valid, err := totp.GenerateCodeCustom(secret, now, otpCodeOpts)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	const attempts = 8
+	errs := make([]error, attempts)
+	var wg sync.WaitGroup
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			_, errs[i] = m.Verify(context.Background(), "alice", valid)
+		}(i)
+	}
+	wg.Wait()
+
+	var succeeded int
+	for _, err := range errs {
+		if err == nil {
+			succeeded++
+		} else if !errors.IsCode(err, code.ErrOTPInvalid) {
+			t.Errorf("Verify() error = %v, want ErrOTPInvalid", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Errorf("succeeded = %d, want the code accepted exactly once", succeeded)
+	}
+}
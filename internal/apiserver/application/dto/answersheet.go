@@ -1,6 +1,10 @@
 package dto
 
-import v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+import (
+	"time"
+
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
 
 // AnswerSheetDTO 表示答卷数据传输对象
 // 用于应用层和领域层之间的数据传输
@@ -13,6 +17,8 @@ type AnswerSheetDTO struct {
 	WriterID             uint64      // 填写人ID
 	TesteeID             uint64      // 被测试者ID
 	Answers              []AnswerDTO // 答案列表
+	Source               string      // 答卷来源，在线填写的答卷为空
+	CreatedAt            time.Time   // 答卷填写时间，为空时取保存时间
 }
 
 // AnswerDTO 表示答案数据传输对象
@@ -24,6 +30,66 @@ type AnswerDTO struct {
 	Value        any     // 答案值，可以是字符串、数字或选项数组等
 }
 
+// AnswerSheetScoreDTO 答卷计分结果数据传输对象
+type AnswerSheetScoreDTO struct {
+	QuestionnaireCode    string                       // 问卷代码
+	QuestionnaireVersion string                       // 问卷版本
+	TotalScore           float64                      // 总分
+	PercentageScore      float64                      // 百分制得分，医学量表开启百分制换算时有效
+	RuleScores           map[string]float64           // 计分规则引擎计算的各项得分，如总分和分量表得分
+	DimensionScores      map[string]DimensionScoreDTO // 医学量表各计分维度的得分，以维度编码为键
+	Answers              []AnswerDTO                  // 含得分的答案列表
+	Factors              []InterpretItemDTO           // 因子得分明细
+}
+
+// DimensionScoreDTO 维度得分数据传输对象
+type DimensionScoreDTO struct {
+	Label      string  // 维度名称
+	Raw        float64 // 原始分
+	Normed     bool    // 是否按常模换算了 T 分数和百分位
+	TScore     float64 // T 分数
+	Percentile float64 // 百分位
+}
+
+// ProgressReportDTO 答卷作答进度数据传输对象
+type ProgressReportDTO struct {
+	TotalQuestions    int                      // 题目总数
+	AnsweredQuestions int                      // 已作答题目数
+	IsComplete        bool                     // 是否已全部作答
+	SectionProgress   []SectionProgressItemDTO // 段落进度
+	CurrentSection    string                   // 当前段落（最后作答题目所属段落）
+}
+
+// SectionProgressItemDTO 段落作答进度数据传输对象
+type SectionProgressItemDTO struct {
+	SectionCode       string // 段落编码
+	SectionTitle      string // 段落标题
+	TotalQuestions    int    // 题目总数
+	AnsweredQuestions int    // 已作答题目数
+	IsComplete        bool   // 是否已全部作答
+}
+
+// FileReferenceDTO 文件引用数据传输对象
+type FileReferenceDTO struct {
+	FileName   string // 文件名
+	MimeType   string // 文件类型
+	StorageKey string // 存储键
+	SizeBytes  int64  // 文件大小（字节）
+}
+
+// AnswerSheetExportFilterDTO 答卷导出过滤条件
+type AnswerSheetExportFilterDTO struct {
+	QuestionnaireCode string    // 问卷代码
+	From              time.Time // 起始创建时间（含），零值表示不限制
+	To                time.Time // 截止创建时间（不含），零值表示不限制
+}
+
+// AnswerSheetExportDTO 答卷导出记录
+type AnswerSheetExportDTO struct {
+	AnswerSheet AnswerSheetDTO // 答卷信息
+	CreatedAt   time.Time      // 创建时间
+}
+
 // AnswerSheetDetailDTO 用于返回答卷详细信息的数据传输对象
 type AnswerSheetDetailDTO struct {
 	AnswerSheet   AnswerSheetDTO   // 答卷基本信息
@@ -50,3 +116,20 @@ type QuestionStatisticsDTO struct {
 	AverageScore       float64          // 平均分
 	AnswerDistribution map[string]int64 // 答案分布（选项代码 -> 选择次数）
 }
+
+// RescoreResultDTO 答卷重新计分结果
+type RescoreResultDTO struct {
+	ScaleCode      string           // 医学量表编码
+	DryRun         bool             // 是否只预览不保存
+	TotalProcessed int              // 处理的答卷数
+	TotalUpdated   int              // 得分变化并已保存（预览时为将要保存）的答卷数
+	Changes        []ScoreChangeDTO // 得分变化的答卷，最多保留前 100 条
+	Errors         []string         // 无法重新计分的答卷及原因，最多保留前 100 条
+}
+
+// ScoreChangeDTO 答卷得分变化
+type ScoreChangeDTO struct {
+	AnswerSheetID uint64  // 答卷ID
+	OldScore      float64 // 原总分
+	NewScore      float64 // 重新计分后的总分
+}
@@ -4,4 +4,17 @@ package dto
 type CalculationRuleDTO struct {
 	FormulaType string   `json:"formula_type"`
 	SourceCodes []string `json:"source_codes"`
+
+	// DependsOnCode 条件计分引用的题目编码
+	DependsOnCode string `json:"depends_on_code,omitempty"`
+	// ScoreMappings 条件计分映射
+	ScoreMappings []ScoreMappingDTO `json:"score_mappings,omitempty"`
+	// Threshold 计数规则的阈值
+	Threshold float64 `json:"threshold,omitempty"`
+}
+
+// ScoreMappingDTO 条件计分映射数据传输对象
+type ScoreMappingDTO struct {
+	WhenValue string             `json:"when_value"`
+	Scores    map[string]float64 `json:"scores"`
 }
@@ -12,6 +12,8 @@ type InterpretReportDTO struct {
 	Title            string             `json:"title"`
 	Description      string             `json:"description"`
 	Testee           *user.Testee       `json:"testee,omitempty"`
+	Score            float64            `json:"score"`      // 报告展示的分数
+	ScoreType        string             `json:"score_type"` // 分数类型：raw 原始总分，percentage 百分制得分
 	InterpretItems   []InterpretItemDTO `json:"interpret_items"`
 }
 
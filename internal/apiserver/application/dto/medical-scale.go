@@ -1,5 +1,7 @@
 package dto
 
+import "encoding/json"
+
 // MedicalScaleDTO 医学量表数据传输对象
 type MedicalScaleDTO struct {
 	ID                uint64      `json:"id"`
@@ -8,6 +10,37 @@ type MedicalScaleDTO struct {
 	Title             string      `json:"title"`
 	Description       string      `json:"description"`
 	Factors           []FactorDTO `json:"factors"`
+
+	ScoringConfig      ScoringConfigDTO `json:"scoring_config"`
+	RuleStrategyConfig json.RawMessage  `json:"rule_strategy_config,omitempty"`
+}
+
+// ScoringConfigDTO 计分配置数据传输对象
+type ScoringConfigDTO struct {
+	NormalizeToPercentage bool           `json:"normalize_to_percentage"`
+	ScoreBands            []ScoreBandDTO `json:"score_bands"`
+	Dimensions            []DimensionDTO `json:"dimensions"`
+}
+
+// DimensionDTO 计分维度数据传输对象
+type DimensionDTO struct {
+	Code          string        `json:"code"`
+	Label         string        `json:"label"`
+	QuestionCodes []string      `json:"question_codes"`
+	NormGroup     *NormGroupDTO `json:"norm_group,omitempty"`
+}
+
+// NormGroupDTO 常模组数据传输对象
+type NormGroupDTO struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+}
+
+// ScoreBandDTO 分数段数据传输对象
+type ScoreBandDTO struct {
+	ScoreRange  ScoreRangeDTO `json:"score_range"`
+	Label       string        `json:"label"`
+	Description string        `json:"description"`
 }
 
 // FactorDTO 因子数据传输对象
@@ -10,6 +10,30 @@ type QuestionnaireDTO struct {
 	Version     string        `json:"version"`
 	Status      string        `json:"status"`
 	Questions   []QuestionDTO `json:"questions"`
+
+	// Pages 按段落分页标记划分的页面，至少包含一页
+	Pages []PageDTO `json:"pages"`
+
+	// GeoRestriction 允许访问的国家（ISO 3166-1 alpha-2 代码），为空表示不限制
+	GeoRestriction []string `json:"geo_restriction"`
+
+	// Tags 问卷标签
+	Tags []string `json:"tags"`
+
+	// Revision 修订号，每次保存递增，用于并发更新时的乐观锁
+	Revision int64 `json:"revision"`
+}
+
+// PageDTO 问卷页面
+type PageDTO struct {
+	Sections []SectionDTO `json:"sections"`
+}
+
+// SectionDTO 问卷段落，第一个段落题之前的题目归入编码为空的段落
+type SectionDTO struct {
+	Code          string   `json:"code"`
+	Title         string   `json:"title"`
+	QuestionCodes []string `json:"question_codes"`
 }
 
 // QuestionnaireListDTO 问卷列表数据传输对象
@@ -27,8 +51,17 @@ type QuestionDTO struct {
 	Placeholder string      // 占位符（用于文本类型问题）
 	Options     []OptionDTO // 选项列表
 
+	// 文件上传约束
+	AllowedMIMETypes []string // 允许上传的文件类型
+	MaxFileSizeBytes int64    // 单个文件大小上限（字节）
+	MaxFiles         int      // 文件数量上限
+
+	// 段落属性
+	PageBreakBefore bool // 段落前是否分页（仅段落题）
+
 	// 验证规则
-	ValidationRules []ValidationRuleDTO // 验证规则列表
+	ValidationRules     []ValidationRuleDTO      // 验证规则列表
+	ConditionalRequired []ConditionalRequiredDTO // 条件必填规则列表
 
 	// 计算规则
 	CalculationRule *CalculationRuleDTO // 计算规则
@@ -45,4 +78,11 @@ type OptionDTO struct {
 type ValidationRuleDTO struct {
 	RuleType    string // 规则类型
 	TargetValue string // 目标值
+	Severity    string // 严重级别：error（默认）或 warning
+}
+
+// ConditionalRequiredDTO 条件必填规则 DTO
+type ConditionalRequiredDTO struct {
+	DependsOnCode     string      // 依赖的题目编码
+	RequiredWhenValue interface{} // 依赖题目答案等于该值时本题必填
 }
@@ -5,7 +5,14 @@ import (
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	asPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report"
 	interpretport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/port"
+	msPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	hookPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
 	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
@@ -13,16 +20,31 @@ import (
 )
 
 // Creator 解读报告创建器
+// 报告保存成功后通知 report.generated 事件，REST 与 gRPC 创建报告都经过这里
 type Creator struct {
-	repo   interpretport.InterpretReportRepositoryMongo
-	mapper *mapper.InterpretReportMapper
+	repo     interpretport.InterpretReportRepositoryMongo
+	aRepo    asPort.AnswerSheetRepositoryMongo
+	qRepo    qnPort.QuestionnaireRepositoryMongo
+	msRepo   msPort.MedicalScaleRepositoryMongo
+	notifier hookPort.EventNotifier
+	mapper   *mapper.InterpretReportMapper
 }
 
-// NewCreator 创建解读报告创建器
-func NewCreator(repo interpretport.InterpretReportRepositoryMongo) *Creator {
+// NewCreator 创建解读报告创建器，notifier 为空时不通知
+func NewCreator(
+	repo interpretport.InterpretReportRepositoryMongo,
+	aRepo asPort.AnswerSheetRepositoryMongo,
+	qRepo qnPort.QuestionnaireRepositoryMongo,
+	msRepo msPort.MedicalScaleRepositoryMongo,
+	notifier hookPort.EventNotifier,
+) *Creator {
 	return &Creator{
-		repo:   repo,
-		mapper: mapper.NewInterpretReportMapper(),
+		repo:     repo,
+		aRepo:    aRepo,
+		qRepo:    qRepo,
+		msRepo:   msRepo,
+		notifier: notifier,
+		mapper:   mapper.NewInterpretReportMapper(),
 	}
 }
 
@@ -50,6 +72,12 @@ func (c *Creator) CreateInterpretReport(ctx context.Context, reportDTO *dto.Inte
 		return nil, errors.WithCode(errCode.ErrInterpretReportAlreadyExists, "该答卷的解读报告已存在")
 	}
 
+	// 医学量表开启百分制换算时，报告展示答卷总分换算后的百分制得分
+	reportDTO, err = c.withPercentageScore(ctx, reportDTO)
+	if err != nil {
+		return nil, err
+	}
+
 	log.Infof("转换DTO为领域对象，解读项数量: %d", len(reportDTO.InterpretItems))
 
 	// 转换DTO为领域对象
@@ -85,9 +113,61 @@ func (c *Creator) CreateInterpretReport(ctx context.Context, reportDTO *dto.Inte
 	}
 
 	log.Infof("解读报告创建成功，ID: %d", resultDTO.ID)
+	c.notifyReportGenerated(ctx, reportDTO.AnswerSheetId)
 	return resultDTO, nil
 }
 
+// notifyReportGenerated 通知答卷所属问卷的 report.generated 事件，报告已保存，查询答卷失败只记录日志
+func (c *Creator) notifyReportGenerated(ctx context.Context, answerSheetID uint64) {
+	if c.notifier == nil {
+		return
+	}
+	aDomain, err := c.aRepo.FindByID(ctx, answerSheetID)
+	if err != nil || aDomain == nil {
+		log.Warnf("查询答卷失败，未通知报告生成事件，答卷ID: %d, 错误: %v", answerSheetID, err)
+		return
+	}
+	c.notifier.Notify(notificationhook.EventReportGenerated, aDomain.GetQuestionnaireCode())
+}
+
+// withPercentageScore 医学量表开启百分制换算时，返回设置了百分制得分的报告副本，否则原样返回
+// 百分制得分由已保存的答卷总分和问卷最高可得分换算
+func (c *Creator) withPercentageScore(ctx context.Context, reportDTO *dto.InterpretReportDTO) (*dto.InterpretReportDTO, error) {
+	if reportDTO.ScoreType == interpretreport.ScoreTypePercentage {
+		return reportDTO, nil
+	}
+
+	ms, err := c.msRepo.FindByCode(ctx, reportDTO.MedicalScaleCode)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrMedicalScaleNotFound, "医学量表不存在")
+	}
+	if ms == nil || !ms.GetScoringConfig().NormalizeToPercentage {
+		return reportDTO, nil
+	}
+
+	aDomain, err := c.aRepo.FindByID(ctx, reportDTO.AnswerSheetId)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+	if aDomain == nil {
+		return nil, errors.WithCode(errCode.ErrAnswerSheetNotFound, "答卷不存在")
+	}
+	qDomain, err := c.qRepo.FindByCodeVersion(ctx, aDomain.GetQuestionnaireCode(), aDomain.GetQuestionnaireVersion())
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+
+	percentage, err := answersheet.PercentageScore(qDomain, aDomain.GetScore())
+	if err != nil {
+		return nil, err
+	}
+
+	withScore := *reportDTO
+	withScore.Score = percentage
+	withScore.ScoreType = interpretreport.ScoreTypePercentage
+	return &withScore, nil
+}
+
 // validateCreateInput 验证创建输入参数
 func (c *Creator) validateCreateInput(reportDTO *dto.InterpretReportDTO) error {
 	log.Infof("开始验证解读报告输入参数")
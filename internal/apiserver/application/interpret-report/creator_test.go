@@ -0,0 +1,157 @@
+package interpretreport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	asPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report"
+	interpretport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/port"
+	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	msPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types"
+)
+
+// fakeReportRepo 内存解读报告存储库，只实现创建器用到的方法
+type fakeReportRepo struct {
+	interpretport.InterpretReportRepositoryMongo
+	created *interpretreport.InterpretReport
+}
+
+func (r *fakeReportRepo) ExistsByAnswerSheetId(ctx context.Context, answerSheetId uint64) (bool, error) {
+	return r.created != nil, nil
+}
+
+func (r *fakeReportRepo) Create(ctx context.Context, report *interpretreport.InterpretReport) error {
+	r.created = report
+	return nil
+}
+
+// fakeAnswerSheetRepo 按 ID 返回固定答卷
+type fakeAnswerSheetRepo struct {
+	asPort.AnswerSheetRepositoryMongo
+	sheet *answersheet.AnswerSheet
+}
+
+func (r *fakeAnswerSheetRepo) FindByID(ctx context.Context, id uint64) (*answersheet.AnswerSheet, error) {
+	return r.sheet, nil
+}
+
+// fakeQuestionnaireRepo 按编码和版本返回固定问卷
+type fakeQuestionnaireRepo struct {
+	qnPort.QuestionnaireRepositoryMongo
+	questionnaire *questionnaire.Questionnaire
+}
+
+func (r *fakeQuestionnaireRepo) FindByCodeVersion(ctx context.Context, code, version string) (*questionnaire.Questionnaire, error) {
+	return r.questionnaire, nil
+}
+
+// fakeMedicalScaleRepo 按编码返回固定医学量表
+type fakeMedicalScaleRepo struct {
+	msPort.MedicalScaleRepositoryMongo
+	scale *medicalscale.MedicalScale
+}
+
+func (r *fakeMedicalScaleRepo) FindByCode(ctx context.Context, code string) (*medicalscale.MedicalScale, error) {
+	return r.scale, nil
+}
+
+// newCreatorForScale 创建医学量表按 config 计分、答卷总分为 totalScore 的创建器
+// 问卷包含 4 道 0~3 分的单选题，最高可得 12 分
+func newCreatorForScale(config medicalscale.ScoringConfig, totalScore float64) (*Creator, *fakeReportRepo) {
+	questions := make([]question.Question, 0, 4)
+	for _, code := range []string{"q1", "q2", "q3", "q4"} {
+		questions = append(questions, question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+			question.WithCode(question.NewQuestionCode(code)),
+			question.WithTitle(code),
+			question.WithQuestionType(question.QuestionTypeRadio),
+			question.WithOption("0", "没有", 0),
+			question.WithOption("3", "重度", 3),
+		)))
+	}
+
+	repo := &fakeReportRepo{}
+	creator := NewCreator(
+		repo,
+		&fakeAnswerSheetRepo{sheet: answersheet.NewAnswerSheet("RATING", "1.0", answersheet.WithScore(totalScore))},
+		&fakeQuestionnaireRepo{questionnaire: questionnaire.NewQuestionnaire(
+			questionnaire.NewQuestionnaireCode("RATING"), "评分量表", questionnaire.WithQuestions(questions),
+		)},
+		&fakeMedicalScaleRepo{scale: medicalscale.NewMedicalScale("RATING_SCALE", "评分量表", medicalscale.WithScoringConfig(config))},
+		nil,
+	)
+	return creator, repo
+}
+
+// fakeNotifier 记录通知的事件
+type fakeNotifier struct {
+	events []string
+}
+
+func (n *fakeNotifier) Notify(event, questionnaireCode string) {
+	n.events = append(n.events, event+":"+questionnaireCode)
+}
+
+func newReportDTO() *dto.InterpretReportDTO {
+	return &dto.InterpretReportDTO{
+		AnswerSheetId:    1,
+		MedicalScaleCode: "RATING_SCALE",
+		Title:            "评分量表解读报告",
+		InterpretItems: []dto.InterpretItemDTO{
+			{FactorCode: "total", Title: "总分", Score: 6, Content: "中度"},
+		},
+	}
+}
+
+func TestCreator_CreateInterpretReport_PercentageScore(t *testing.T) {
+	creator, repo := newCreatorForScale(medicalscale.ScoringConfig{NormalizeToPercentage: true}, 6)
+
+	if _, err := creator.CreateInterpretReport(context.Background(), newReportDTO()); err != nil {
+		t.Fatalf("CreateInterpretReport() error = %v", err)
+	}
+	if got := repo.created.GetScoreType(); got != interpretreport.ScoreTypePercentage {
+		t.Errorf("score type = %q, want %q", got, interpretreport.ScoreTypePercentage)
+	}
+	if got := repo.created.GetPercentageScore(); got != 50 {
+		t.Errorf("percentage score = %v, want 50", got)
+	}
+}
+
+func TestCreator_CreateInterpretReport_RawScore(t *testing.T) {
+	creator, repo := newCreatorForScale(medicalscale.ScoringConfig{}, 6)
+
+	if _, err := creator.CreateInterpretReport(context.Background(), newReportDTO()); err != nil {
+		t.Fatalf("CreateInterpretReport() error = %v", err)
+	}
+	if got := repo.created.GetScoreType(); got == interpretreport.ScoreTypePercentage {
+		t.Errorf("score type = %q, want raw score", got)
+	}
+}
+
+func TestCreator_CreateInterpretReport_NotifiesReportGenerated(t *testing.T) {
+	creator, _ := newCreatorForScale(medicalscale.ScoringConfig{}, 6)
+	notifier := &fakeNotifier{}
+	creator.notifier = notifier
+
+	if _, err := creator.CreateInterpretReport(context.Background(), newReportDTO()); err != nil {
+		t.Fatalf("CreateInterpretReport() error = %v", err)
+	}
+	if want := notificationhook.EventReportGenerated + ":RATING"; len(notifier.events) != 1 || notifier.events[0] != want {
+		t.Errorf("events = %v, want [%s]", notifier.events, want)
+	}
+
+	// 报告已存在时不再通知
+	if _, err := creator.CreateInterpretReport(context.Background(), newReportDTO()); err == nil {
+		t.Fatal("CreateInterpretReport() error = nil, want already exists")
+	}
+	if len(notifier.events) != 1 {
+		t.Errorf("events = %v, want no new event", notifier.events)
+	}
+}
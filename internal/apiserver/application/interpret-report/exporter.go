@@ -0,0 +1,40 @@
+package interpretreport
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/hl7"
+	interpretport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// HL7Exporter 解读报告 HL7 导出器
+type HL7Exporter struct {
+	repo interpretport.InterpretReportRepositoryMongo
+}
+
+// NewHL7Exporter 创建解读报告 HL7 导出器
+func NewHL7Exporter(repo interpretport.InterpretReportRepositoryMongo) *HL7Exporter {
+	return &HL7Exporter{repo: repo}
+}
+
+// 确保实现了接口
+var _ interpretport.InterpretReportHL7Exporter = (*HL7Exporter)(nil)
+
+// ExportHL7 将答卷对应的解读报告导出为 HL7 v2 ORU^R01 消息
+func (e *HL7Exporter) ExportHL7(ctx context.Context, answerSheetId uint64, patient hl7.PatientInfo) (string, error) {
+	if answerSheetId == 0 {
+		return "", errors.WithCode(errCode.ErrInvalidArgument, "答卷ID不能为空")
+	}
+
+	report, err := e.repo.FindByAnswerSheetId(ctx, answerSheetId)
+	if err != nil {
+		return "", errors.WithCode(errCode.ErrInterpretReportNotFound, "解读报告不存在: %v", err)
+	}
+	if report == nil {
+		return "", errors.WithCode(errCode.ErrInterpretReportNotFound, "解读报告不存在: %d", answerSheetId)
+	}
+
+	return hl7.GenerateORUMessage(report, patient)
+}
@@ -28,6 +28,8 @@ func (m *InterpretReportMapper) ToDTO(report *interpretreport.InterpretReport) *
 		Title:            report.GetTitle(),
 		Description:      report.GetDescription(),
 		Testee:           &testee,
+		Score:            report.GetDisplayScore(),
+		ScoreType:        report.GetScoreType(),
 	}
 
 	// 转换解读项
@@ -68,29 +70,25 @@ func (m *InterpretReportMapper) ToDomain(reportDTO *dto.InterpretReportDTO) *int
 		items[i] = m.InterpretItemToDomain(itemDTO)
 	}
 
+	options := []interpretreport.InterpretReportOption{
+		interpretreport.WithID(v1.NewID(reportDTO.ID)),
+		interpretreport.WithDescription(reportDTO.Description),
+		interpretreport.WithInterpretItems(items),
+	}
+	if reportDTO.Testee != nil {
+		options = append(options, interpretreport.WithTestee(*reportDTO.Testee))
+	}
+	if reportDTO.ScoreType == interpretreport.ScoreTypePercentage {
+		options = append(options, interpretreport.WithPercentageScore(reportDTO.Score))
+	}
+
 	// 创建解读报告
-	report := interpretreport.NewInterpretReport(
+	return interpretreport.NewInterpretReport(
 		reportDTO.AnswerSheetId,
 		reportDTO.MedicalScaleCode,
 		reportDTO.Title,
-		interpretreport.WithID(v1.NewID(reportDTO.ID)),
-		interpretreport.WithDescription(reportDTO.Description),
-		interpretreport.WithInterpretItems(items),
+		options...,
 	)
-
-	if reportDTO.Testee != nil {
-		report = interpretreport.NewInterpretReport(
-			reportDTO.AnswerSheetId,
-			reportDTO.MedicalScaleCode,
-			reportDTO.Title,
-			interpretreport.WithID(v1.NewID(reportDTO.ID)),
-			interpretreport.WithDescription(reportDTO.Description),
-			interpretreport.WithTestee(*reportDTO.Testee),
-			interpretreport.WithInterpretItems(items),
-		)
-	}
-
-	return report
 }
 
 // InterpretItemToDTO 将解读项领域对象转换为DTO
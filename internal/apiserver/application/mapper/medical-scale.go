@@ -4,6 +4,8 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	medicalScale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
 )
@@ -24,9 +26,97 @@ func (m *MedicalScaleMapper) ToDTO(bo *medicalScale.MedicalScale) *dto.MedicalSc
 		Title:             bo.GetTitle(),
 		Description:       bo.GetDescription(),
 		Factors:           m.toFactorDTOs(bo.GetFactors()),
+		ScoringConfig: dto.ScoringConfigDTO{
+			NormalizeToPercentage: bo.GetScoringConfig().NormalizeToPercentage,
+			ScoreBands:            m.toScoreBandDTOs(bo.GetScoringConfig().ScoreBands),
+			Dimensions:            m.toDimensionDTOs(bo.GetScoringDimensions()),
+		},
+		RuleStrategyConfig: bo.GetRuleStrategyConfig(),
 	}
 }
 
+// ToScoringConfig 将计分配置 DTO 转换为领域对象
+func (m *MedicalScaleMapper) ToScoringConfig(config dto.ScoringConfigDTO) medicalScale.ScoringConfig {
+	var bands []medicalScale.ScoreBand
+	for _, band := range config.ScoreBands {
+		bands = append(bands, medicalScale.NewScoreBand(
+			interpretation.NewScoreRange(band.ScoreRange.MinScore, band.ScoreRange.MaxScore),
+			band.Label,
+			band.Description,
+		))
+	}
+	return medicalScale.ScoringConfig{
+		NormalizeToPercentage: config.NormalizeToPercentage,
+		ScoreBands:            bands,
+		Dimensions:            m.toDimensions(config.Dimensions),
+	}
+}
+
+// toDimensions 将计分维度 DTO 转换为领域对象
+func (m *MedicalScaleMapper) toDimensions(dtos []dto.DimensionDTO) []scoring.ScoringDimension {
+	var dimensions []scoring.ScoringDimension
+	for _, d := range dtos {
+		questionCodes := make([]question.QuestionCode, len(d.QuestionCodes))
+		for i, questionCode := range d.QuestionCodes {
+			questionCodes[i] = question.NewQuestionCode(questionCode)
+		}
+		dimension := scoring.ScoringDimension{
+			Code:          d.Code,
+			Label:         d.Label,
+			QuestionCodes: questionCodes,
+		}
+		if d.NormGroup != nil {
+			dimension.NormGroup = &scoring.NormGroup{Mean: d.NormGroup.Mean, StdDev: d.NormGroup.StdDev}
+		}
+		dimensions = append(dimensions, dimension)
+	}
+	return dimensions
+}
+
+// toDimensionDTOs 将计分维度领域对象转换为 DTO 数组
+func (m *MedicalScaleMapper) toDimensionDTOs(dimensions []scoring.ScoringDimension) []dto.DimensionDTO {
+	if len(dimensions) == 0 {
+		return nil
+	}
+
+	dtos := make([]dto.DimensionDTO, len(dimensions))
+	for i, d := range dimensions {
+		questionCodes := make([]string, len(d.QuestionCodes))
+		for j, questionCode := range d.QuestionCodes {
+			questionCodes[j] = questionCode.Value()
+		}
+		dtos[i] = dto.DimensionDTO{
+			Code:          d.Code,
+			Label:         d.Label,
+			QuestionCodes: questionCodes,
+		}
+		if d.NormGroup != nil {
+			dtos[i].NormGroup = &dto.NormGroupDTO{Mean: d.NormGroup.Mean, StdDev: d.NormGroup.StdDev}
+		}
+	}
+	return dtos
+}
+
+// toScoreBandDTOs 将分数段领域对象转换为 DTO 数组
+func (m *MedicalScaleMapper) toScoreBandDTOs(bands []medicalScale.ScoreBand) []dto.ScoreBandDTO {
+	if len(bands) == 0 {
+		return nil
+	}
+
+	dtos := make([]dto.ScoreBandDTO, len(bands))
+	for i, band := range bands {
+		dtos[i] = dto.ScoreBandDTO{
+			ScoreRange: dto.ScoreRangeDTO{
+				MinScore: band.GetScoreRange().MinScore(),
+				MaxScore: band.GetScoreRange().MaxScore(),
+			},
+			Label:       band.GetLabel(),
+			Description: band.GetDescription(),
+		}
+	}
+	return dtos
+}
+
 // toFactorDTOs 将因子领域对象转换为 DTO
 func (m *MedicalScaleMapper) toFactorDTOs(factors []factor.Factor) []dto.FactorDTO {
 	dtos := make([]dto.FactorDTO, len(factors))
@@ -61,6 +151,7 @@ func (m *MedicalScaleMapper) toCalculationRuleDTO(rule *calculation.CalculationR
 	return &dto.CalculationRuleDTO{
 		FormulaType: rule.GetFormula().String(),
 		SourceCodes: rule.GetSourceCodes(),
+		Threshold:   rule.GetThreshold(),
 	}
 }
 
@@ -33,6 +33,11 @@ func (m *QuestionnaireMapper) ToDTO(bo *questionnaire.Questionnaire) *dto.Questi
 		ImgUrl:      bo.GetImgUrl(),
 		Status:      bo.GetStatus().String(),
 		Questions:   m.toQuestionDTOs(bo.GetQuestions()),
+		Pages:       m.toPageDTOs(bo.GetPages()),
+
+		GeoRestriction: bo.GetGeoRestriction(),
+		Tags:           bo.GetTags(),
+		Revision:       bo.GetRevision(),
 	}
 }
 
@@ -45,19 +50,46 @@ func (m *QuestionnaireMapper) toQuestionDTOs(questions []question.Question) []dt
 	dtos := make([]dto.QuestionDTO, 0, len(questions))
 	for _, q := range questions {
 		dtos = append(dtos, dto.QuestionDTO{
-			Code:            string(q.GetCode()),
-			Title:           q.GetTitle(),
-			Type:            string(q.GetType()),
-			Tips:            q.GetTips(),
-			Options:         m.toOptionDTOs(q.GetOptions()),
-			Placeholder:     q.GetPlaceholder(),
-			ValidationRules: m.toValidationRuleDTOs(q.GetValidationRules()),
-			CalculationRule: m.toCalculationRuleDTO(q.GetCalculationRule()),
+			Code:             string(q.GetCode()),
+			Title:            q.GetTitle(),
+			Type:             string(q.GetType()),
+			Tips:             q.GetTips(),
+			Options:          m.toOptionDTOs(q.GetOptions()),
+			Placeholder:      q.GetPlaceholder(),
+			AllowedMIMETypes: q.GetAllowedMIMETypes(),
+			MaxFileSizeBytes: q.GetMaxFileSizeBytes(),
+			MaxFiles:         q.GetMaxFiles(),
+			PageBreakBefore:  q.GetPageBreakBefore(),
+			ValidationRules:  m.toValidationRuleDTOs(q.GetValidationRules()),
+			CalculationRule:  m.toCalculationRuleDTO(q.GetCalculationRule()),
+
+			ConditionalRequired: m.toConditionalRequiredDTOs(q.GetConditionalRequired()),
 		})
 	}
 	return dtos
 }
 
+// toPageDTOs 将问卷页面转换为 DTO，段落内的题目只保留编码
+func (m *QuestionnaireMapper) toPageDTOs(pages [][]questionnaire.Section) []dto.PageDTO {
+	dtos := make([]dto.PageDTO, 0, len(pages))
+	for _, page := range pages {
+		pageDTO := dto.PageDTO{Sections: make([]dto.SectionDTO, 0, len(page))}
+		for _, section := range page {
+			sectionDTO := dto.SectionDTO{
+				Code:          section.GetCode().Value(),
+				Title:         section.GetTitle(),
+				QuestionCodes: make([]string, 0, len(section.GetQuestions())),
+			}
+			for _, q := range section.GetQuestions() {
+				sectionDTO.QuestionCodes = append(sectionDTO.QuestionCodes, q.GetCode().Value())
+			}
+			pageDTO.Sections = append(pageDTO.Sections, sectionDTO)
+		}
+		dtos = append(dtos, pageDTO)
+	}
+	return dtos
+}
+
 // toOptionDTOs 将选项领域对象转换为 DTO
 func (m *QuestionnaireMapper) toOptionDTOs(options []question.Option) []dto.OptionDTO {
 	if len(options) == 0 {
@@ -86,6 +118,23 @@ func (m *QuestionnaireMapper) toValidationRuleDTOs(rules []validation.Validation
 		dtos = append(dtos, dto.ValidationRuleDTO{
 			RuleType:    string(r.GetRuleType()),
 			TargetValue: r.GetTargetValue(),
+			Severity:    string(r.GetSeverity()),
+		})
+	}
+	return dtos
+}
+
+// toConditionalRequiredDTOs 将条件必填规则领域对象转换为 DTO
+func (m *QuestionnaireMapper) toConditionalRequiredDTOs(deps []question.ConditionalRequired) []dto.ConditionalRequiredDTO {
+	if len(deps) == 0 {
+		return nil
+	}
+
+	dtos := make([]dto.ConditionalRequiredDTO, 0, len(deps))
+	for _, dep := range deps {
+		dtos = append(dtos, dto.ConditionalRequiredDTO{
+			DependsOnCode:     dep.DependsOnCode.Value(),
+			RequiredWhenValue: dep.RequiredWhenValue,
 		})
 	}
 	return dtos
@@ -97,17 +146,44 @@ func (m *QuestionnaireMapper) toCalculationRuleDTO(rule *calculation.Calculation
 		return nil
 	}
 
-	return &dto.CalculationRuleDTO{
-		FormulaType: string(rule.GetFormula()),
+	ruleDTO := &dto.CalculationRuleDTO{
+		FormulaType:   string(rule.GetFormula()),
+		SourceCodes:   rule.GetSourceCodes(),
+		DependsOnCode: rule.GetDependsOnCode(),
+	}
+	for _, mapping := range rule.GetScoreMappings() {
+		ruleDTO.ScoreMappings = append(ruleDTO.ScoreMappings, dto.ScoreMappingDTO{
+			WhenValue: mapping.WhenValue,
+			Scores:    mapping.Scores,
+		})
 	}
+	return ruleDTO
 }
 
-// QuestionFromDTO 将问题 DTO 转换为领域对象
+// QuestionFromDTO 将问题 DTO 转换为领域对象，不校验题目配置，用于映射已保存的问题
+// 已保存的问题可能早于现行校验规则，如计算规则引用了之后删除的选项，映射时不应因此失败
 func (m *QuestionnaireMapper) QuestionFromDTO(dto *dto.QuestionDTO) (question.Question, error) {
 	if dto == nil {
 		return nil, errors.New("问题 DTO 不能为空")
 	}
+	return createQuestion(m.questionBuilderFromDTO(dto))
+}
+
+// ValidatedQuestionFromDTO 校验问题 DTO 的题目配置后转换为领域对象，用于创建和更新问题
+func (m *QuestionnaireMapper) ValidatedQuestionFromDTO(dto *dto.QuestionDTO) (question.Question, error) {
+	if dto == nil {
+		return nil, errors.New("问题 DTO 不能为空")
+	}
+
+	builder := m.questionBuilderFromDTO(dto)
+	if err := builder.Validate(); err != nil {
+		return nil, err
+	}
+	return createQuestion(builder)
+}
 
+// questionBuilderFromDTO 根据问题 DTO 创建问题构建器
+func (m *QuestionnaireMapper) questionBuilderFromDTO(dto *dto.QuestionDTO) *question.QuestionBuilder {
 	// 创建问题构建器
 	builder := question.NewQuestionBuilder()
 
@@ -125,24 +201,48 @@ func (m *QuestionnaireMapper) QuestionFromDTO(dto *dto.QuestionDTO) (question.Qu
 		}
 	}
 
+	// 设置文件上传约束
+	builder.SetFileConstraints(dto.AllowedMIMETypes, dto.MaxFileSizeBytes, dto.MaxFiles)
+
+	// 设置段落分页
+	builder.SetPageBreakBefore(dto.PageBreakBefore)
+
 	// 设置验证规则
 	if len(dto.ValidationRules) > 0 {
 		for _, ruleDTO := range dto.ValidationRules {
-			builder.AddValidationRule(validation.RuleType(ruleDTO.RuleType), ruleDTO.TargetValue)
+			rule := validation.NewValidationRule(validation.RuleType(ruleDTO.RuleType), ruleDTO.TargetValue).
+				WithSeverity(validation.Severity(ruleDTO.Severity))
+			builder.AddValidationRule(rule)
 		}
 	}
 
+	// 设置条件必填规则
+	for _, depDTO := range dto.ConditionalRequired {
+		builder.AddConditionalRequired(question.NewQuestionCode(depDTO.DependsOnCode), depDTO.RequiredWhenValue)
+	}
+
 	// 设置计算规则
 	if dto.CalculationRule != nil {
-		builder.SetCalculationRule(calculation.FormulaType(dto.CalculationRule.FormulaType))
+		if formula := calculation.FormulaType(dto.CalculationRule.FormulaType); formula == calculation.FormulaTypeConditional {
+			mappings := make([]calculation.ScoreMapping, 0, len(dto.CalculationRule.ScoreMappings))
+			for _, mappingDTO := range dto.CalculationRule.ScoreMappings {
+				mappings = append(mappings, calculation.NewScoreMapping(mappingDTO.WhenValue, mappingDTO.Scores))
+			}
+			builder.SetConditionalCalculationRule(question.NewQuestionCode(dto.CalculationRule.DependsOnCode), mappings...)
+		} else {
+			builder.SetCalculationRule(formula, dto.CalculationRule.SourceCodes...)
+		}
 	}
 
-	// 使用工厂函数创建问题
+	return builder
+}
+
+// createQuestion 使用工厂函数创建问题
+func createQuestion(builder *question.QuestionBuilder) (question.Question, error) {
 	q := question.CreateQuestionFromBuilder(builder)
 	if q == nil {
 		return nil, errors.New("创建问题失败")
 	}
-
 	return q, nil
 }
 
@@ -158,6 +258,9 @@ func (m *QuestionnaireMapper) FromDTO(dto *dto.QuestionnaireDTO) (*questionnaire
 		questionnaire.WithDescription(dto.Description),
 		questionnaire.WithImgUrl(dto.ImgUrl),
 		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion(dto.Version)),
+		questionnaire.WithGeoRestriction(dto.GeoRestriction),
+		questionnaire.WithTags(dto.Tags),
+		questionnaire.WithRevision(dto.Revision),
 	}
 
 	// 设置状态
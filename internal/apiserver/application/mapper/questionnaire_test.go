@@ -0,0 +1,42 @@
+package mapper
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestQuestionnaireMapper_QuestionFromDTO_StaleCalculationRule(t *testing.T) {
+	// 计算规则引用的选项 D 已被删除，已保存的问题仍能映射，作者提交时校验失败
+	questionDTO := &dto.QuestionDTO{
+		Code:  "Q1",
+		Title: "食欲",
+		Type:  string(question.QuestionTypeRadio),
+		Options: []dto.OptionDTO{
+			{Code: "A", Content: "正常", Score: 0},
+			{Code: "B", Content: "减退", Score: 1},
+		},
+		CalculationRule: &dto.CalculationRuleDTO{
+			FormulaType: string(calculation.FormulaTypeScore),
+			SourceCodes: []string{"A", "D"},
+		},
+	}
+	m := NewQuestionnaireMapper()
+
+	q, err := m.QuestionFromDTO(questionDTO)
+	if err != nil {
+		t.Fatalf("QuestionFromDTO() error = %v", err)
+	}
+	if q.GetCode().Value() != "Q1" || len(q.GetCalculationRule().GetSourceCodes()) != 2 {
+		t.Errorf("QuestionFromDTO() = %q with rule %+v, want Q1 with the stored rule", q.GetCode().Value(), q.GetCalculationRule())
+	}
+
+	if _, err := m.ValidatedQuestionFromDTO(questionDTO); !errors.IsCode(err, code.ErrQuestionnaireQuestionInvalid) {
+		t.Errorf("ValidatedQuestionFromDTO() error = %v, want ErrQuestionnaireQuestionInvalid", err)
+	}
+}
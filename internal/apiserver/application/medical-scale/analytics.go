@@ -0,0 +1,155 @@
+package medicalscale
+
+import (
+	"context"
+	"time"
+
+	asPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	medicalScale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// ReliabilityCacheTTL 量表信度的缓存时间
+	ReliabilityCacheTTL = 24 * time.Hour
+	// MaxReliabilityResponses 计算信度时最多使用的最近答卷数
+	MaxReliabilityResponses = 1000
+)
+
+// Analytics 医学量表统计分析服务
+type Analytics struct {
+	msRepo port.MedicalScaleRepositoryMongo
+	qRepo  qnPort.QuestionnaireRepositoryMongo
+	aRepo  asPort.AnswerSheetRepositoryMongo
+	cache  port.ReliabilityCache
+	now    func() time.Time
+}
+
+// NewAnalytics 创建医学量表统计分析服务，cache 为空时每次都重新计算
+func NewAnalytics(
+	msRepo port.MedicalScaleRepositoryMongo,
+	qRepo qnPort.QuestionnaireRepositoryMongo,
+	aRepo asPort.AnswerSheetRepositoryMongo,
+	cache port.ReliabilityCache,
+) *Analytics {
+	return &Analytics{
+		msRepo: msRepo,
+		qRepo:  qRepo,
+		aRepo:  aRepo,
+		cache:  cache,
+		now:    time.Now,
+	}
+}
+
+// 确保 Analytics 实现了 MedicalScaleAnalytics 接口
+var _ port.MedicalScaleAnalytics = (*Analytics)(nil)
+
+// ComputeCronbachAlpha 根据量表最近的答卷计算克朗巴赫 α 系数
+// 条目为量表问卷中的选择题，缺答任一条目的答卷不参与计算；结果缓存 24 小时，缓存读写失败不影响计算
+func (a *Analytics) ComputeCronbachAlpha(ctx context.Context, scaleCode string, minResponses int) (float64, error) {
+	if scaleCode == "" {
+		return 0, errors.WithCode(errorCode.ErrMedicalScaleInvalidInput, "医学量表编码不能为空")
+	}
+	if minResponses < 2 || minResponses > MaxReliabilityResponses {
+		return 0, errors.WithCode(errorCode.ErrMedicalScaleInvalidInput, "最少答卷数必须在 2 到 %d 之间", MaxReliabilityResponses)
+	}
+
+	if a.cache != nil {
+		cached, err := a.cache.Get(ctx, scaleCode)
+		if err != nil {
+			log.Warnf("Get cached reliability of medical scale %s failed: %v", scaleCode, err)
+		} else if cached != nil {
+			if cached.Responses < minResponses {
+				return 0, insufficientResponses(cached.Responses, minResponses)
+			}
+			return cached.CronbachAlpha, nil
+		}
+	}
+
+	reliability, err := a.computeReliability(ctx, scaleCode, minResponses)
+	if err != nil {
+		return 0, err
+	}
+
+	if a.cache != nil {
+		if err := a.cache.Set(ctx, reliability, ReliabilityCacheTTL); err != nil {
+			log.Warnf("Cache reliability of medical scale %s failed: %v", scaleCode, err)
+		}
+	}
+	return reliability.CronbachAlpha, nil
+}
+
+// computeReliability 构建答卷-条目得分矩阵并计算信度
+func (a *Analytics) computeReliability(ctx context.Context, scaleCode string, minResponses int) (*medicalScale.Reliability, error) {
+	scale, err := a.msRepo.FindByCode(ctx, scaleCode)
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "获取医学量表失败")
+	}
+	if scale == nil {
+		return nil, errors.WithCode(errorCode.ErrMedicalScaleNotFound, "医学量表 %s 不存在", scaleCode)
+	}
+
+	qDomain, err := a.qRepo.FindByCodeOrNil(ctx, scale.GetQuestionnaireCode())
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "查询问卷失败")
+	}
+	if qDomain == nil {
+		return nil, errors.WithCode(errorCode.ErrQuestionnaireNotFound, "量表问卷 %s 不存在", scale.GetQuestionnaireCode())
+	}
+
+	var items []string
+	for _, q := range qDomain.GetQuestions() {
+		if q.GetType().HasOptions() {
+			items = append(items, q.GetCode().Value())
+		}
+	}
+
+	sheets, err := a.aRepo.FindLatestByQuestionnaire(ctx, scale.GetQuestionnaireCode(), MaxReliabilityResponses)
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "查询量表答卷失败")
+	}
+
+	var matrix [][]float64
+	for _, sheet := range sheets {
+		scores := make(map[string]float64)
+		for _, ans := range sheet.GetAnswers() {
+			scores[ans.GetQuestionCode()] = ans.GetScore()
+		}
+
+		row := make([]float64, 0, len(items))
+		for _, item := range items {
+			score, ok := scores[item]
+			if !ok {
+				break
+			}
+			row = append(row, score)
+		}
+		if len(row) == len(items) {
+			matrix = append(matrix, row)
+		}
+	}
+	if len(matrix) < minResponses {
+		return nil, insufficientResponses(len(matrix), minResponses)
+	}
+
+	alpha, err := medicalScale.CronbachAlpha(matrix)
+	if err != nil {
+		return nil, err
+	}
+	return &medicalScale.Reliability{
+		ScaleCode:     scaleCode,
+		CronbachAlpha: alpha,
+		Responses:     len(matrix),
+		Items:         len(items),
+		ComputedAt:    a.now(),
+	}, nil
+}
+
+// insufficientResponses 有效答卷不足的错误
+func insufficientResponses(responses, minResponses int) error {
+	return errors.WithCode(errorCode.ErrInsufficientData, "有效答卷 %d 份，少于计算信度所需的 %d 份", responses, minResponses)
+}
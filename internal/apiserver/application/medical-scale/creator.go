@@ -7,20 +7,27 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
 	medicalScale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
 	"github.com/yshujie/questionnaire-scale/pkg/util/codeutil"
 )
 
 // Creator 医学量表创建器
 type Creator struct {
 	mRepoMongo port.MedicalScaleRepositoryMongo
+	qRepoMongo qnPort.QuestionnaireRepositoryMongo
 	mapper     mapper.MedicalScaleMapper
+	flags      featureflag.Checker
 }
 
-// NewCreator 创建医学量表创建器
-func NewCreator(mRepoMongo port.MedicalScaleRepositoryMongo) *Creator {
+// NewCreator 创建医学量表创建器，qRepoMongo 用于校验关联的问卷
+// flags 为功能开关，加权计分按量表编码灰度开放，为空时不允许使用加权计分
+func NewCreator(mRepoMongo port.MedicalScaleRepositoryMongo, qRepoMongo qnPort.QuestionnaireRepositoryMongo, flags featureflag.Checker) *Creator {
 	return &Creator{
 		mRepoMongo: mRepoMongo,
+		qRepoMongo: qRepoMongo,
 		mapper:     mapper.NewMedicalScaleMapper(),
+		flags:      flags,
 	}
 }
 
@@ -40,19 +47,37 @@ func (c *Creator) Create(ctx context.Context, dto *dto.MedicalScaleDTO) (*dto.Me
 		return nil, err
 	}
 
-	// 2. 创建医学量表领域模型
+	// 2. 校验计分配置
+	scoringConfig := c.mapper.ToScoringConfig(dto.ScoringConfig)
+	if err := scoringConfig.Validate(); err != nil {
+		return nil, err
+	}
+
+	// 3. 创建医学量表领域模型
 	msBO := medicalScale.NewMedicalScale(
 		code,
 		dto.Title,
 		medicalScale.WithDescription(dto.Description),
 		medicalScale.WithQuestionnaireCode(dto.QuestionnaireCode),
+		medicalScale.WithScoringConfig(scoringConfig),
 	)
+	if err := msBO.SetRuleStrategyConfig(dto.RuleStrategyConfig); err != nil {
+		return nil, err
+	}
+	if err := checkScoringRollout(c.flags, msBO); err != nil {
+		return nil, err
+	}
+
+	// 4. 校验关联的问卷
+	if err := validateQuestionnaireLink(ctx, c.qRepoMongo, msBO); err != nil {
+		return nil, err
+	}
 
-	// 4. 保存到 mongodb
+	// 5. 保存到 mongodb
 	if err := c.mRepoMongo.Create(ctx, msBO); err != nil {
 		return nil, err
 	}
 
-	// 5. 转换为 DTO 并返回
+	// 6. 转换为 DTO 并返回
 	return c.mapper.ToDTO(msBO), nil
 }
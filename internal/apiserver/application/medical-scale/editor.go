@@ -9,8 +9,10 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor/ability"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
 	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
@@ -18,14 +20,19 @@ import (
 // Editor 医学量表编辑器
 type Editor struct {
 	repo   port.MedicalScaleRepositoryMongo
+	qRepo  qnPort.QuestionnaireRepositoryMongo
 	mapper mapper.MedicalScaleMapper
+	flags  featureflag.Checker
 }
 
-// NewEditor 创建医学量表编辑器
-func NewEditor(repo port.MedicalScaleRepositoryMongo) *Editor {
+// NewEditor 创建医学量表编辑器，qRepo 用于校验关联的问卷
+// flags 为功能开关，加权计分按量表编码灰度开放，为空时不允许使用加权计分
+func NewEditor(repo port.MedicalScaleRepositoryMongo, qRepo qnPort.QuestionnaireRepositoryMongo, flags featureflag.Checker) *Editor {
 	return &Editor{
 		repo:   repo,
+		qRepo:  qRepo,
 		mapper: mapper.NewMedicalScaleMapper(),
+		flags:  flags,
 	}
 }
 
@@ -73,6 +80,18 @@ func (e *Editor) EditBasicInfo(
 	if err := baseInfoService.UpdateDescription(msBO, medicalScaleDTO.Description); err != nil {
 		return nil, err
 	}
+	if err := baseInfoService.UpdateScoringConfig(msBO, e.mapper.ToScoringConfig(medicalScaleDTO.ScoringConfig)); err != nil {
+		return nil, err
+	}
+	if err := msBO.SetRuleStrategyConfig(medicalScaleDTO.RuleStrategyConfig); err != nil {
+		return nil, err
+	}
+	if err := checkScoringRollout(e.flags, msBO); err != nil {
+		return nil, err
+	}
+	if err := validateQuestionnaireLink(ctx, e.qRepo, msBO); err != nil {
+		return nil, err
+	}
 
 	// 4. 保存到数据库
 	if err := e.repo.Update(ctx, msBO); err != nil {
@@ -152,10 +171,7 @@ func (e *Editor) UpdateFactors(
 		// 创建计算规则
 		var calculationRule *calculation.CalculationRule
 		if fDTO.CalculationRule != nil {
-			calculationRule = calculation.NewCalculationRule(
-				calculation.FormulaType(fDTO.CalculationRule.FormulaType),
-				fDTO.CalculationRule.SourceCodes,
-			)
+			calculationRule = newFactorCalculationRule(fDTO.CalculationRule)
 		}
 
 		// 创建计算能力
@@ -214,6 +230,9 @@ func (e *Editor) UpdateFactors(
 
 	// 5. 更新医学量表的因子
 	msBO.SetFactors(factors)
+	if err := validateQuestionnaireLink(ctx, e.qRepo, msBO); err != nil {
+		return nil, err
+	}
 
 	// 6. 保存到数据库
 	if err := e.repo.Update(ctx, msBO); err != nil {
@@ -223,3 +242,12 @@ func (e *Editor) UpdateFactors(
 	// 7. 转换为 DTO 并返回
 	return e.mapper.ToDTO(msBO), nil
 }
+
+// newFactorCalculationRule 根据 DTO 创建因子计算规则，计数规则需要携带阈值
+func newFactorCalculationRule(ruleDTO *dto.CalculationRuleDTO) *calculation.CalculationRule {
+	formula := calculation.FormulaType(ruleDTO.FormulaType)
+	if formula == calculation.FormulaTypeCountAboveThreshold {
+		return calculation.NewCountAboveThresholdCalculationRule(ruleDTO.SourceCodes, ruleDTO.Threshold)
+	}
+	return calculation.NewCalculationRule(formula, ruleDTO.SourceCodes)
+}
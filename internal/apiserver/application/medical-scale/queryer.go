@@ -120,3 +120,35 @@ func (q *Queryer) ListMedicalScales(
 
 	return dtos, total, nil
 }
+
+// ListMedicalScalesAfterCode 按编码升序列出编码大于 lastCode 的医学量表
+// 多取一条用于判断是否还有下一页，避免大页码下的跳过扫描
+func (q *Queryer) ListMedicalScalesAfterCode(
+	ctx context.Context,
+	lastCode string,
+	pageSize int,
+) ([]*dto.MedicalScaleDTO, bool, error) {
+	// 1. 验证分页参数
+	if err := q.validatePagination(1, pageSize); err != nil {
+		return nil, false, err
+	}
+
+	// 2. 获取医学量表列表
+	medicalScales, err := q.repo.FindListAfterCode(ctx, lastCode, pageSize+1)
+	if err != nil {
+		return nil, false, errors.WrapC(err, errorCode.ErrDatabase, "获取医学量表列表失败")
+	}
+
+	hasMore := len(medicalScales) > pageSize
+	if hasMore {
+		medicalScales = medicalScales[:pageSize]
+	}
+
+	// 3. 转换为 DTO 列表
+	dtos := make([]*dto.MedicalScaleDTO, 0, len(medicalScales))
+	for _, medicalScale := range medicalScales {
+		dtos = append(dtos, q.mapper.ToDTO(medicalScale))
+	}
+
+	return dtos, hasMore, nil
+}
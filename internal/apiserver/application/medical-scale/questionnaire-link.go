@@ -0,0 +1,55 @@
+package medicalscale
+
+import (
+	"context"
+	"strings"
+
+	medicalScale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// validateQuestionnaireLink 校验医学量表关联的问卷存在，计分引用的题目都在问卷中，
+// 且总分的解读规则和分数段完整覆盖问卷的可得分范围；未关联问卷的量表不做校验
+// 加权计分的总分不在问卷得分范围内，不校验解读覆盖
+func validateQuestionnaireLink(ctx context.Context, qRepo qnPort.QuestionnaireRepositoryMongo, msBO *medicalScale.MedicalScale) error {
+	questionnaireCode := msBO.GetQuestionnaireCode()
+	if questionnaireCode == "" {
+		return nil
+	}
+
+	// 1. 校验问卷存在
+	qBO, err := qRepo.FindByCodeOrNil(ctx, questionnaireCode)
+	if err != nil {
+		return errors.WrapC(err, errorCode.ErrDatabase, "获取问卷失败")
+	}
+	if qBO == nil {
+		return errors.WithCode(errorCode.ErrMedicalScaleInvalidInput, "医学量表关联的问卷不存在: %s", questionnaireCode)
+	}
+
+	// 2. 校验计分引用的题目都在问卷中
+	questionCodes := make(map[string]bool, len(qBO.GetQuestions()))
+	for _, q := range qBO.GetQuestions() {
+		questionCodes[q.GetCode().Value()] = true
+	}
+	var missing []string
+	for _, code := range msBO.ScoredQuestionCodes() {
+		if !questionCodes[code] {
+			missing = append(missing, code)
+		}
+	}
+	if len(missing) > 0 {
+		return errors.WithCode(errorCode.ErrMedicalScaleInvalidInput,
+			"问卷 %s 中不存在医学量表计分引用的题目: %s", questionnaireCode, strings.Join(missing, ", "))
+	}
+
+	// 3. 校验解读覆盖问卷的可得分范围
+	if !msBO.UsesWeightedScoring() {
+		if err := msBO.ValidateInterpretationCoverage(qBO.ComputeMinScore(), qBO.ComputeMaxScore()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
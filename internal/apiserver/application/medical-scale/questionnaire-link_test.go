@@ -0,0 +1,168 @@
+package medicalscale
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	medicalScale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	qnPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// linkedMedicalScaleRepo 返回关联问卷 questionnaireCode 的医学量表，记录保存次数
+type linkedMedicalScaleRepo struct {
+	port.MedicalScaleRepositoryMongo
+	questionnaireCode string
+	updates           int
+}
+
+func (f *linkedMedicalScaleRepo) FindByCode(ctx context.Context, code string) (*medicalScale.MedicalScale, error) {
+	return medicalScale.NewMedicalScale(code, "PHQ-9 抑郁量表", medicalScale.WithQuestionnaireCode(f.questionnaireCode)), nil
+}
+
+func (f *linkedMedicalScaleRepo) Update(ctx context.Context, msBO *medicalScale.MedicalScale) error {
+	f.updates++
+	return nil
+}
+
+// singleQuestionnaireRepo 只包含问卷 PHQ9，题目为 Q1、Q2，各题选项分值为 0~3，可得分范围为 [0, 6]
+type singleQuestionnaireRepo struct {
+	qnPort.QuestionnaireRepositoryMongo
+}
+
+func (f *singleQuestionnaireRepo) FindByCodeOrNil(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	if code != "PHQ9" {
+		return nil, nil
+	}
+	questions := make([]question.Question, 0, 2)
+	for _, questionCode := range []string{"Q1", "Q2"} {
+		questions = append(questions, question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+			question.WithCode(question.NewQuestionCode(questionCode)),
+			question.WithTitle("最近两周的情绪"),
+			question.WithQuestionType(question.QuestionTypeRadio),
+			question.WithOption("A", "完全不会", 0),
+			question.WithOption("D", "几乎每天", 3),
+		)))
+	}
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode(code),
+		"PHQ-9",
+		questionnaire.WithQuestions(questions),
+	), nil
+}
+
+func TestEditor_UpdateFactorsValidatesQuestionnaireLink(t *testing.T) {
+	sumFactor := func(sourceCodes ...string) dto.FactorDTO {
+		return dto.FactorDTO{
+			Code:            "F1",
+			Title:           "躯体症状",
+			FactorType:      "primary",
+			CalculationRule: &dto.CalculationRuleDTO{FormulaType: "sum", SourceCodes: sourceCodes},
+		}
+	}
+	// 多级因子引用因子 F1，不应按题目校验
+	totalFactor := dto.FactorDTO{
+		Code:            "TOTAL",
+		Title:           "总分",
+		FactorType:      "multilevel",
+		IsTotalScore:    true,
+		CalculationRule: &dto.CalculationRuleDTO{FormulaType: "sum", SourceCodes: []string{"F1"}},
+	}
+
+	tests := []struct {
+		name              string
+		questionnaireCode string
+		factors           []dto.FactorDTO
+		wantErr           bool
+	}{
+		{
+			name:              "valid link",
+			questionnaireCode: "PHQ9",
+			factors:           []dto.FactorDTO{sumFactor("Q1", "Q2"), totalFactor},
+		},
+		{
+			name:              "dangling question reference",
+			questionnaireCode: "PHQ9",
+			factors:           []dto.FactorDTO{sumFactor("Q1", "Q9"), totalFactor},
+			wantErr:           true,
+		},
+		{
+			name:              "dangling questionnaire reference",
+			questionnaireCode: "GAD7",
+			factors:           []dto.FactorDTO{sumFactor("Q1"), totalFactor},
+			wantErr:           true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msRepo := &linkedMedicalScaleRepo{questionnaireCode: tt.questionnaireCode}
+			editor := NewEditor(msRepo, &singleQuestionnaireRepo{}, nil)
+
+			_, err := editor.UpdateFactors(context.Background(), "MS1", tt.factors)
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("UpdateFactors() error = %v", err)
+				}
+				if msRepo.updates != 1 {
+					t.Errorf("updates = %d, want 1", msRepo.updates)
+				}
+				return
+			}
+			if !errors.IsCode(err, errorCode.ErrMedicalScaleInvalidInput) {
+				t.Fatalf("UpdateFactors() error = %v, want ErrMedicalScaleInvalidInput", err)
+			}
+			if msRepo.updates != 0 {
+				t.Errorf("updates = %d, want the medical scale left unsaved", msRepo.updates)
+			}
+		})
+	}
+}
+
+func TestEditor_EditBasicInfoValidatesInterpretationCoverage(t *testing.T) {
+	band := func(min, max float64, label string) dto.ScoreBandDTO {
+		return dto.ScoreBandDTO{ScoreRange: dto.ScoreRangeDTO{MinScore: min, MaxScore: max}, Label: label}
+	}
+
+	tests := []struct {
+		name    string
+		bands   []dto.ScoreBandDTO
+		wantErr bool
+	}{
+		{name: "no score bands"},
+		{name: "complete covering", bands: []dto.ScoreBandDTO{band(0, 3, "轻度"), band(3, 7, "中重度")}},
+		{name: "min score not covered", bands: []dto.ScoreBandDTO{band(1, 3, "轻度"), band(3, 7, "中重度")}, wantErr: true},
+		{name: "max score not covered", bands: []dto.ScoreBandDTO{band(0, 3, "轻度"), band(3, 6, "中重度")}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msRepo := &linkedMedicalScaleRepo{questionnaireCode: "PHQ9"}
+			editor := NewEditor(msRepo, &singleQuestionnaireRepo{}, nil)
+
+			_, err := editor.EditBasicInfo(context.Background(), &dto.MedicalScaleDTO{
+				Code:          "MS1",
+				Title:         "PHQ-9 抑郁量表",
+				ScoringConfig: dto.ScoringConfigDTO{ScoreBands: tt.bands},
+			})
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("EditBasicInfo() error = %v", err)
+				}
+				return
+			}
+			if !errors.IsCode(err, errorCode.ErrMedicalScaleInvalidInput) {
+				t.Fatalf("EditBasicInfo() error = %v, want ErrMedicalScaleInvalidInput", err)
+			}
+			if msRepo.updates != 0 {
+				t.Errorf("updates = %d, want the medical scale left unsaved", msRepo.updates)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package medicalscale
+
+import (
+	medicalScale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// checkScoringRollout 校验加权计分是否已对医学量表开放，其余计分策略不受功能开关限制
+func checkScoringRollout(flags featureflag.Checker, msBO *medicalScale.MedicalScale) error {
+	if !msBO.UsesWeightedScoring() {
+		return nil
+	}
+	if flags == nil || !flags.Enabled(featureflag.WeightedScoring, msBO.GetCode()) {
+		return errors.WithCode(errorCode.ErrMedicalScaleInvalidInput, "加权计分尚未对医学量表 %s 开放", msBO.GetCode())
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package medicalscale
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// keyFlags 只对指定 key 开启指定功能的功能开关
+type keyFlags struct {
+	name, key string
+}
+
+func (f keyFlags) Enabled(name, key string) bool {
+	return name == f.name && key == f.key
+}
+
+func TestEditor_EditBasicInfoGatesWeightedScoring(t *testing.T) {
+	weighted := json.RawMessage(`{"type": "composite", "strategies": [
+		{"type": "simple_sum"},
+		{"type": "weighted_sum", "weights": {"Q1": 2}}
+	]}`)
+
+	tests := []struct {
+		name    string
+		config  json.RawMessage
+		flags   featureflag.Checker
+		wantErr bool
+	}{
+		{name: "simple sum without flags", config: json.RawMessage(`{"type": "simple_sum"}`)},
+		{name: "weighted sum without flags", config: weighted, wantErr: true},
+		{
+			name:    "weighted sum rolled out to another scale",
+			config:  weighted,
+			flags:   keyFlags{name: featureflag.WeightedScoring, key: "MS2"},
+			wantErr: true,
+		},
+		{
+			name:   "weighted sum rolled out to this scale",
+			config: weighted,
+			flags:  keyFlags{name: featureflag.WeightedScoring, key: "MS1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			msRepo := &linkedMedicalScaleRepo{questionnaireCode: "PHQ9"}
+			editor := NewEditor(msRepo, &singleQuestionnaireRepo{}, tt.flags)
+
+			_, err := editor.EditBasicInfo(context.Background(), &dto.MedicalScaleDTO{
+				Code:               "MS1",
+				Title:              "PHQ-9 抑郁量表",
+				RuleStrategyConfig: tt.config,
+			})
+			if !tt.wantErr {
+				if err != nil {
+					t.Fatalf("EditBasicInfo() error = %v", err)
+				}
+				return
+			}
+			if !errors.IsCode(err, errorCode.ErrMedicalScaleInvalidInput) {
+				t.Fatalf("EditBasicInfo() error = %v, want ErrMedicalScaleInvalidInput", err)
+			}
+			if msRepo.updates != 0 {
+				t.Errorf("updates = %d, want the medical scale left unsaved", msRepo.updates)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package notificationhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"sync"
+	"syscall"
+	"time"
+
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// SignatureHeader 通知请求的签名头，值为 sha256=<hex(HMAC-SHA256(secret, body))>
+const SignatureHeader = "X-Hub-Signature-256"
+
+const (
+	// DefaultMaxAttempts 每个钩子的最大投递次数（含首次投递）
+	DefaultMaxAttempts = 3
+	// DefaultRetryBackoff 首次重试前的等待时间，之后每次重试翻倍
+	DefaultRetryBackoff = time.Second
+	// DefaultRequestTimeout 单次投递请求的超时时间
+	DefaultRequestTimeout = 10 * time.Second
+	// DefaultWorkers 后台投递的并发数
+	DefaultWorkers = 4
+	// DefaultQueueSize 待投递事件队列的容量，队列满时丢弃新事件
+	DefaultQueueSize = 1000
+)
+
+// notification 待投递的事件
+type notification struct {
+	event             string
+	questionnaireCode string
+}
+
+// hookPayload 通知请求体
+type hookPayload struct {
+	Event             string `json:"event"`
+	QuestionnaireCode string `json:"questionnaire_code"`
+	Timestamp         string `json:"timestamp"`
+}
+
+// HookDispatcher 通知钩子投递器
+// 以 POST 请求将事件推送给问卷下订阅了该事件的钩子，对方未返回 2xx 时按指数退避重试，每次尝试都记录投递结果
+type HookDispatcher struct {
+	hookRepo     port.NotificationHookRepository
+	deliveryRepo port.DeliveryRepository
+	client       *http.Client
+	maxAttempts  int
+	backoff      time.Duration
+	now          func() time.Time
+	sleep        func(time.Duration)
+
+	queue   chan notification
+	mu      sync.RWMutex
+	closed  bool
+	workers sync.WaitGroup
+}
+
+// NewHookDispatcher 创建通知钩子投递器并启动 DefaultWorkers 个后台投递协程
+// client 为空时使用 newDefaultClient 创建的客户端，该客户端拒绝连接非公网地址
+func NewHookDispatcher(
+	hookRepo port.NotificationHookRepository,
+	deliveryRepo port.DeliveryRepository,
+	client *http.Client,
+) *HookDispatcher {
+	if client == nil {
+		client = newDefaultClient()
+	}
+	d := &HookDispatcher{
+		hookRepo:     hookRepo,
+		deliveryRepo: deliveryRepo,
+		client:       client,
+		maxAttempts:  DefaultMaxAttempts,
+		backoff:      DefaultRetryBackoff,
+		now:          time.Now,
+		sleep:        time.Sleep,
+		queue:        make(chan notification, DefaultQueueSize),
+	}
+	for i := 0; i < DefaultWorkers; i++ {
+		d.workers.Add(1)
+		go d.work()
+	}
+	return d
+}
+
+// newDefaultClient 创建默认的投递客户端
+// 在建立连接时校验解析后的 IP，拒绝回环、私有等非公网地址，防止钩子被用于访问内网服务（包括 DNS 重绑定）
+func newDefaultClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: DefaultRequestTimeout,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || !notificationhook.IsPublicIP(ip) {
+				return errors.WithCode(errCode.ErrNotificationHookURLInvalid, "通知钩子地址不能指向非公网地址 %s", host)
+			}
+			return nil
+		},
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+	return &http.Client{
+		Timeout:   DefaultRequestTimeout,
+		Transport: transport,
+		// 不跟随重定向，以钩子地址首个响应的状态码作为投递结果
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// 确保实现了接口
+var _ port.EventNotifier = (*HookDispatcher)(nil)
+
+// Notify 将事件放入后台队列投递，队列已满或投递器已关闭时丢弃事件并记录日志
+func (d *HookDispatcher) Notify(event, questionnaireCode string) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		log.Warnf("通知投递器已关闭，丢弃事件 %s，问卷编码: %s", event, questionnaireCode)
+		return
+	}
+	select {
+	case d.queue <- notification{event: event, questionnaireCode: questionnaireCode}:
+	default:
+		log.Warnf("通知队列已满，丢弃事件 %s，问卷编码: %s", event, questionnaireCode)
+	}
+}
+
+// Close 停止接收新事件，并等待队列中已有的事件投递完成，ctx 结束时不再等待
+func (d *HookDispatcher) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		d.workers.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return errors.WrapC(ctx.Err(), errCode.ErrInternalServerError, "等待通知投递完成超时，剩余 %d 个事件未投递", len(d.queue))
+	}
+}
+
+// work 后台投递协程，队列关闭且取空后退出
+func (d *HookDispatcher) work() {
+	defer d.workers.Done()
+	for n := range d.queue {
+		if err := d.Dispatch(context.Background(), n.event, n.questionnaireCode); err != nil {
+			log.Errorf("投递事件 %s 失败，问卷编码: %s, 错误: %v", n.event, n.questionnaireCode, err)
+		}
+	}
+}
+
+// Dispatch 向问卷下订阅了事件的钩子依次投递事件，返回时所有投递（含重试）均已完成
+func (d *HookDispatcher) Dispatch(ctx context.Context, event, questionnaireCode string) error {
+	hooks, err := d.hookRepo.FindByQuestionnaireCode(ctx, questionnaireCode)
+	if err != nil {
+		return errors.WrapC(err, errCode.ErrDatabase, "查询通知钩子失败")
+	}
+
+	body, err := json.Marshal(hookPayload{
+		Event:             event,
+		QuestionnaireCode: questionnaireCode,
+		Timestamp:         d.now().UTC().Format(time.RFC3339),
+	})
+	if err != nil {
+		return errors.WrapC(err, errCode.ErrEncodingFailed, "编码通知请求失败")
+	}
+
+	for _, hook := range hooks {
+		if hook.Subscribes(event) {
+			d.deliver(ctx, hook, event, body)
+		}
+	}
+	return nil
+}
+
+// deliver 向单个钩子投递事件，最多尝试 maxAttempts 次，地址无效时只记录一次失败且不重试
+func (d *HookDispatcher) deliver(ctx context.Context, hook *notificationhook.NotificationHook, event string, body []byte) {
+	if err := hook.ValidateURL(); err != nil {
+		delivery := notificationhook.NewDelivery(hook.ID, event, hook.QuestionnaireCode, 1, 0, err.Error(), d.now())
+		if err := d.deliveryRepo.Create(ctx, delivery); err != nil {
+			log.Errorf("保存通知投递记录失败，钩子ID: %d, 错误: %v", hook.ID, err)
+		}
+		log.Warnf("通知钩子 %d 地址无效，跳过投递: %v", hook.ID, err)
+		return
+	}
+
+	backoff := d.backoff
+	for attempt := 1; attempt <= d.maxAttempts; attempt++ {
+		if attempt > 1 {
+			d.sleep(backoff)
+			backoff *= 2
+		}
+
+		var errMsg string
+		statusCode, err := d.post(ctx, hook, body)
+		if err != nil {
+			errMsg = err.Error()
+		}
+
+		delivery := notificationhook.NewDelivery(hook.ID, event, hook.QuestionnaireCode, attempt, statusCode, errMsg, d.now())
+		if err := d.deliveryRepo.Create(ctx, delivery); err != nil {
+			log.Errorf("保存通知投递记录失败，钩子ID: %d, 错误: %v", hook.ID, err)
+		}
+		if delivery.Succeeded() {
+			return
+		}
+	}
+
+	log.Warnf("通知钩子 %d 投递事件 %s 失败，已尝试 %d 次", hook.ID, event, d.maxAttempts)
+}
+
+// post 发送签名后的通知请求，返回对方的 HTTP 状态码
+func (d *HookDispatcher) post(ctx context.Context, hook *notificationhook.NotificationHook, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, hook.Sign(body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// 读完响应体以复用连接
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	return resp.StatusCode, nil
+}
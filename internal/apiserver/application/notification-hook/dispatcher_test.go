@@ -0,0 +1,220 @@
+package notificationhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+)
+
+// fakeHookRepo 内存通知钩子存储库
+type fakeHookRepo struct {
+	hooks []*notificationhook.NotificationHook
+}
+
+func (r *fakeHookRepo) FindByID(ctx context.Context, id uint64) (*notificationhook.NotificationHook, error) {
+	for _, hook := range r.hooks {
+		if hook.ID == id {
+			return hook, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeHookRepo) FindByQuestionnaireCode(ctx context.Context, questionnaireCode string) ([]*notificationhook.NotificationHook, error) {
+	var hooks []*notificationhook.NotificationHook
+	for _, hook := range r.hooks {
+		if hook.QuestionnaireCode == questionnaireCode {
+			hooks = append(hooks, hook)
+		}
+	}
+	return hooks, nil
+}
+
+// fakeDeliveryRepo 内存投递记录存储库
+type fakeDeliveryRepo struct {
+	mu         sync.Mutex
+	deliveries []*notificationhook.Delivery
+}
+
+func (r *fakeDeliveryRepo) Create(ctx context.Context, delivery *notificationhook.Delivery) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.deliveries = append(r.deliveries, delivery)
+	return nil
+}
+
+func (r *fakeDeliveryRepo) FindByHookID(ctx context.Context, hookID uint64, limit int) ([]*notificationhook.Delivery, error) {
+	return r.deliveries, nil
+}
+
+// newTestDispatcher 创建不实际等待的投递器，sleeps 记录每次重试前的等待时间
+// 测试服务监听在回环地址上，因此使用不限制目标地址的客户端
+func newTestDispatcher(hooks []*notificationhook.NotificationHook, sleeps *[]time.Duration) (*HookDispatcher, *fakeDeliveryRepo) {
+	deliveryRepo := &fakeDeliveryRepo{}
+	d := NewHookDispatcher(&fakeHookRepo{hooks: hooks}, deliveryRepo, &http.Client{Timeout: DefaultRequestTimeout})
+	d.now = func() time.Time { return time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC) }
+	d.sleep = func(backoff time.Duration) { *sleeps = append(*sleeps, backoff) }
+	return d, deliveryRepo
+}
+
+func TestHookDispatcher_Dispatch_SignsAndRetries(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		hook := &notificationhook.NotificationHook{Secret: "s3cret"}
+		if got := r.Header.Get(SignatureHeader); got != hook.Sign(body) {
+			t.Errorf("%s = %q, want %q", SignatureHeader, got, hook.Sign(body))
+		}
+
+		var payload hookPayload
+		if err := json.Unmarshal(body, &payload); err != nil {
+			t.Errorf("unmarshal payload error = %v", err)
+		}
+		want := hookPayload{Event: notificationhook.EventSheetSubmitted, QuestionnaireCode: "PHQ9", Timestamp: "2025-01-02T03:04:05Z"}
+		if payload != want {
+			t.Errorf("payload = %+v, want %+v", payload, want)
+		}
+
+		// 前两次失败，第三次成功
+		if atomic.AddInt32(&calls, 1) < 3 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	d, deliveryRepo := newTestDispatcher([]*notificationhook.NotificationHook{
+		{ID: 1, QuestionnaireCode: "PHQ9", URL: server.URL, Secret: "s3cret", Events: []string{notificationhook.EventSheetSubmitted}},
+		{ID: 2, QuestionnaireCode: "PHQ9", URL: server.URL, Secret: "other", Events: []string{notificationhook.EventReportGenerated}},
+	}, &sleeps)
+
+	if err := d.Dispatch(context.Background(), notificationhook.EventSheetSubmitted, "PHQ9"); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	if calls != 3 {
+		t.Errorf("server calls = %d, want 3", calls)
+	}
+	if len(sleeps) != 2 || sleeps[0] != DefaultRetryBackoff || sleeps[1] != 2*DefaultRetryBackoff {
+		t.Errorf("backoffs = %v, want [%v %v]", sleeps, DefaultRetryBackoff, 2*DefaultRetryBackoff)
+	}
+
+	wantStatus := []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusNoContent}
+	if len(deliveryRepo.deliveries) != len(wantStatus) {
+		t.Fatalf("deliveries = %d, want %d", len(deliveryRepo.deliveries), len(wantStatus))
+	}
+	for i, delivery := range deliveryRepo.deliveries {
+		if delivery.HookID != 1 || delivery.Attempt != i+1 || delivery.StatusCode != wantStatus[i] {
+			t.Errorf("delivery[%d] = hook %d attempt %d status %d, want hook 1 attempt %d status %d",
+				i, delivery.HookID, delivery.Attempt, delivery.StatusCode, i+1, wantStatus[i])
+		}
+	}
+}
+
+func TestHookDispatcher_Dispatch_GivesUpAfterMaxAttempts(t *testing.T) {
+	var sleeps []time.Duration
+	d, deliveryRepo := newTestDispatcher([]*notificationhook.NotificationHook{
+		{ID: 1, QuestionnaireCode: "PHQ9", URL: "http://127.0.0.1:0", Events: []string{notificationhook.EventReportGenerated}},
+	}, &sleeps)
+
+	if err := d.Dispatch(context.Background(), notificationhook.EventReportGenerated, "PHQ9"); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	if len(deliveryRepo.deliveries) != DefaultMaxAttempts {
+		t.Fatalf("deliveries = %d, want %d", len(deliveryRepo.deliveries), DefaultMaxAttempts)
+	}
+	for _, delivery := range deliveryRepo.deliveries {
+		if delivery.StatusCode != 0 || delivery.Error == "" || delivery.Succeeded() {
+			t.Errorf("delivery = status %d error %q, want status 0 with error", delivery.StatusCode, delivery.Error)
+		}
+	}
+}
+
+func TestHookDispatcher_Dispatch_RejectsInvalidURL(t *testing.T) {
+	var sleeps []time.Duration
+	d, deliveryRepo := newTestDispatcher([]*notificationhook.NotificationHook{
+		{ID: 1, QuestionnaireCode: "PHQ9", URL: "file:///etc/passwd", Events: []string{notificationhook.EventReportGenerated}},
+		{ID: 2, QuestionnaireCode: "PHQ9", URL: "http:///no-host", Events: []string{notificationhook.EventReportGenerated}},
+	}, &sleeps)
+
+	if err := d.Dispatch(context.Background(), notificationhook.EventReportGenerated, "PHQ9"); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	if len(deliveryRepo.deliveries) != 2 || len(sleeps) != 0 {
+		t.Fatalf("deliveries = %d, sleeps = %d, want 2 deliveries without retry", len(deliveryRepo.deliveries), len(sleeps))
+	}
+	for _, delivery := range deliveryRepo.deliveries {
+		if delivery.Succeeded() || delivery.Error == "" {
+			t.Errorf("delivery for hook %d = status %d error %q, want failure", delivery.HookID, delivery.StatusCode, delivery.Error)
+		}
+	}
+}
+
+func TestHookDispatcher_DefaultClient_RejectsPrivateAddress(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+
+	deliveryRepo := &fakeDeliveryRepo{}
+	d := NewHookDispatcher(&fakeHookRepo{hooks: []*notificationhook.NotificationHook{
+		{ID: 1, QuestionnaireCode: "PHQ9", URL: server.URL, Events: []string{notificationhook.EventReportGenerated}},
+	}}, deliveryRepo, nil)
+	d.sleep = func(time.Duration) {}
+
+	if err := d.Dispatch(context.Background(), notificationhook.EventReportGenerated, "PHQ9"); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+	if atomic.LoadInt32(&calls) != 0 {
+		t.Errorf("server calls = %d, want 0", calls)
+	}
+	if len(deliveryRepo.deliveries) == 0 || deliveryRepo.deliveries[0].Succeeded() {
+		t.Errorf("deliveries = %+v, want failed delivery", deliveryRepo.deliveries)
+	}
+}
+
+func TestHookDispatcher_Close_DrainsQueue(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var sleeps []time.Duration
+	d, _ := newTestDispatcher([]*notificationhook.NotificationHook{
+		{ID: 1, QuestionnaireCode: "PHQ9", URL: server.URL, Events: []string{notificationhook.EventSheetSubmitted}},
+	}, &sleeps)
+
+	const events = 10
+	for i := 0; i < events; i++ {
+		d.Notify(notificationhook.EventSheetSubmitted, "PHQ9")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := d.Close(ctx); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	if atomic.LoadInt32(&calls) != events {
+		t.Errorf("server calls = %d, want %d", calls, events)
+	}
+
+	// 关闭后的事件直接丢弃
+	d.Notify(notificationhook.EventSheetSubmitted, "PHQ9")
+	if atomic.LoadInt32(&calls) != events {
+		t.Errorf("server calls after Close = %d, want %d", calls, events)
+	}
+}
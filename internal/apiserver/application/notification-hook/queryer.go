@@ -0,0 +1,40 @@
+package notificationhook
+
+import (
+	"context"
+
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// MaxListedDeliveries 查询投递记录时最多返回的条数
+const MaxListedDeliveries = 100
+
+// DeliveryQueryer 通知投递记录查询器
+type DeliveryQueryer struct {
+	hookRepo     port.NotificationHookRepository
+	deliveryRepo port.DeliveryRepository
+}
+
+// NewDeliveryQueryer 创建通知投递记录查询器
+func NewDeliveryQueryer(hookRepo port.NotificationHookRepository, deliveryRepo port.DeliveryRepository) port.DeliveryQueryer {
+	return &DeliveryQueryer{
+		hookRepo:     hookRepo,
+		deliveryRepo: deliveryRepo,
+	}
+}
+
+// ListDeliveries 查询通知钩子最近的投递记录，按时间倒序
+func (q *DeliveryQueryer) ListDeliveries(ctx context.Context, hookID uint64) ([]*notificationhook.Delivery, error) {
+	if _, err := q.hookRepo.FindByID(ctx, hookID); err != nil {
+		return nil, err
+	}
+
+	deliveries, err := q.deliveryRepo.FindByHookID(ctx, hookID, MaxListedDeliveries)
+	if err != nil {
+		return nil, errors.WrapC(err, errCode.ErrDatabase, "查询通知投递记录失败")
+	}
+	return deliveries, nil
+}
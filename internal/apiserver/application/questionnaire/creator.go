@@ -7,7 +7,6 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
-	"github.com/yshujie/questionnaire-scale/pkg/util/codeutil"
 )
 
 // Creator 问卷创建器
@@ -31,32 +30,33 @@ func NewCreator(
 
 // CreateQuestionnaire 创建问卷
 func (c *Creator) CreateQuestionnaire(ctx context.Context, questionnaireDTO *dto.QuestionnaireDTO) (*dto.QuestionnaireDTO, error) {
-	// 1. 生成问卷编码
-	code, err := codeutil.GenerateCode()
-	if err != nil {
-		return nil, err
-	}
-
-	// 2. 创建问卷领域模型
+	// 1. 创建问卷领域模型，未提供问卷编码时自动生成
 	qBo := questionnaire.NewQuestionnaire(
-		questionnaire.NewQuestionnaireCode(code),
+		questionnaire.NewQuestionnaireCode(questionnaireDTO.Code),
 		questionnaireDTO.Title,
+		questionnaire.WithAutoGenerateCode(),
 		questionnaire.WithDescription(questionnaireDTO.Description),
 		questionnaire.WithImgUrl(questionnaireDTO.ImgUrl),
 		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
 		questionnaire.WithStatus(questionnaire.STATUS_DRAFT),
 	)
+	if err := (questionnaire.BaseInfoService{}).UpdateGeoRestriction(qBo, questionnaireDTO.GeoRestriction); err != nil {
+		return nil, err
+	}
+	if err := (questionnaire.BaseInfoService{}).UpdateTags(qBo, questionnaireDTO.Tags); err != nil {
+		return nil, err
+	}
 
-	// 3. 保存到 mysql
+	// 2. 保存到 mysql
 	if err := c.qRepoMySQL.Create(ctx, qBo); err != nil {
 		return nil, err
 	}
 
-	// 4. 保存到 mongodb
+	// 3. 保存到 mongodb
 	if err := c.qRepoMongo.Create(ctx, qBo); err != nil {
 		return nil, err
 	}
 
-	// 5. 转换为 DTO 并返回
+	// 4. 转换为 DTO 并返回
 	return c.mapper.ToDTO(qBo), nil
 }
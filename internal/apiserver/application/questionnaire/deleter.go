@@ -0,0 +1,95 @@
+package questionnaire
+
+import (
+	"context"
+
+	asPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	assignmentPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// Deleter 问卷删除器
+// 删除前检查问卷是否仍被答卷或未完成的分配引用，避免产生孤立数据
+type Deleter struct {
+	qRepoMySQL     port.QuestionnaireRepositoryMySQL
+	qRepoMongo     port.QuestionnaireRepositoryMongo
+	aRepoMongo     asPort.AnswerSheetRepositoryMongo
+	assignmentRepo assignmentPort.AssignmentRepository
+}
+
+// NewDeleter 创建问卷删除器
+func NewDeleter(
+	qRepoMySQL port.QuestionnaireRepositoryMySQL,
+	qRepoMongo port.QuestionnaireRepositoryMongo,
+	aRepoMongo asPort.AnswerSheetRepositoryMongo,
+	assignmentRepo assignmentPort.AssignmentRepository,
+) *Deleter {
+	return &Deleter{
+		qRepoMySQL:     qRepoMySQL,
+		qRepoMongo:     qRepoMongo,
+		aRepoMongo:     aRepoMongo,
+		assignmentRepo: assignmentRepo,
+	}
+}
+
+// 确保实现了接口
+var _ port.QuestionnaireDeleter = (*Deleter)(nil)
+
+// DeleteQuestionnaire 软删除问卷的全部版本
+// 问卷存在答卷或未完成的分配时返回 ErrQuestionnaireInUse；force 为 true 时跳过检查，答卷和分配保持不变
+func (d *Deleter) DeleteQuestionnaire(ctx context.Context, code string, force bool) error {
+	// 1. 验证输入参数
+	if code == "" {
+		return errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "问卷编码不能为空")
+	}
+
+	// 2. 获取问卷
+	qBo, err := d.qRepoMySQL.FindByCode(ctx, code)
+	if err != nil {
+		return errors.WrapC(err, errorCode.ErrQuestionnaireNotFound, "获取问卷失败")
+	}
+
+	// 3. 检查引用
+	answerSheets, pendingAssignments, err := d.countReferences(ctx, code)
+	if err != nil {
+		return err
+	}
+	if answerSheets > 0 || pendingAssignments > 0 {
+		if !force {
+			return errors.WithCode(errorCode.ErrQuestionnaireInUse,
+				"问卷 %s 存在 %d 份答卷和 %d 个未完成的分配，不能删除", code, answerSheets, pendingAssignments)
+		}
+		log.Warnf("强制删除被引用的问卷，问卷编码: %s, 答卷数: %d, 未完成分配数: %d", code, answerSheets, pendingAssignments)
+	}
+
+	// 4. 先软删除文档数据库中的全部版本，再删除关系数据库记录
+	if err := d.qRepoMongo.Remove(ctx, code); err != nil {
+		return errors.WrapC(err, errorCode.ErrDatabase, "删除问卷文档失败")
+	}
+	if err := d.qRepoMySQL.Remove(ctx, qBo.GetID().Value()); err != nil {
+		return errors.WrapC(err, errorCode.ErrDatabase, "删除问卷失败")
+	}
+
+	return nil
+}
+
+// countReferences 统计引用问卷的答卷数和未完成的分配数
+func (d *Deleter) countReferences(ctx context.Context, code string) (int64, int64, error) {
+	answerSheets, err := d.aRepoMongo.CountWithConditions(ctx, map[string]interface{}{"questionnaire_code": code})
+	if err != nil {
+		return 0, 0, errors.WrapC(err, errorCode.ErrDatabase, "统计问卷答卷失败")
+	}
+
+	_, pendingAssignments, err := d.assignmentRepo.FindList(ctx, assignmentPort.AssignmentFilter{
+		QuestionnaireCode: code,
+		PendingOnly:       true,
+	}, 1, 1)
+	if err != nil {
+		return 0, 0, errors.WrapC(err, errorCode.ErrDatabase, "统计问卷分配失败")
+	}
+
+	return answerSheets, pendingAssignments, nil
+}
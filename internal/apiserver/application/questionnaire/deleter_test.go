@@ -0,0 +1,103 @@
+package questionnaire
+
+import (
+	"context"
+	"testing"
+
+	asPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+	assignmentPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// removableQuestionnaireRepoMySQL 记录删除的问卷ID
+type removableQuestionnaireRepoMySQL struct {
+	port.QuestionnaireRepositoryMySQL
+	removed []uint64
+}
+
+func (f *removableQuestionnaireRepoMySQL) FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	return questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode(code), "PHQ-9",
+		questionnaire.WithID(questionnaire.NewQuestionnaireID(7))), nil
+}
+
+func (f *removableQuestionnaireRepoMySQL) Remove(ctx context.Context, id uint64) error {
+	f.removed = append(f.removed, id)
+	return nil
+}
+
+// removableQuestionnaireRepoMongo 记录软删除的问卷编码
+type removableQuestionnaireRepoMongo struct {
+	port.QuestionnaireRepositoryMongo
+	removed []string
+}
+
+func (f *removableQuestionnaireRepoMongo) Remove(ctx context.Context, code string) error {
+	f.removed = append(f.removed, code)
+	return nil
+}
+
+// countingAnswerSheetRepo 返回固定的答卷数
+type countingAnswerSheetRepo struct {
+	asPort.AnswerSheetRepositoryMongo
+	count int64
+}
+
+func (f *countingAnswerSheetRepo) CountWithConditions(ctx context.Context, conditions map[string]interface{}) (int64, error) {
+	return f.count, nil
+}
+
+// countingAssignmentRepo 返回固定的未完成分配数
+type countingAssignmentRepo struct {
+	assignmentPort.AssignmentRepository
+	pending int64
+}
+
+func (f *countingAssignmentRepo) FindList(ctx context.Context, filter assignmentPort.AssignmentFilter, page, pageSize int) ([]*assignment.Assignment, int64, error) {
+	if !filter.PendingOnly {
+		return nil, 0, errors.New("want a pending-only filter")
+	}
+	return nil, f.pending, nil
+}
+
+func TestDeleter_DeleteQuestionnaire(t *testing.T) {
+	tests := []struct {
+		name        string
+		answers     int64
+		pending     int64
+		force       bool
+		wantCode    int
+		wantRemoved bool
+	}{
+		{name: "unreferenced", wantRemoved: true},
+		{name: "blocked by answer sheets", answers: 3, wantCode: errorCode.ErrQuestionnaireInUse},
+		{name: "blocked by pending assignments", pending: 1, wantCode: errorCode.ErrQuestionnaireInUse},
+		{name: "forced", answers: 3, pending: 1, force: true, wantRemoved: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mysqlRepo := &removableQuestionnaireRepoMySQL{}
+			mongoRepo := &removableQuestionnaireRepoMongo{}
+			deleter := NewDeleter(mysqlRepo, mongoRepo,
+				&countingAnswerSheetRepo{count: tt.answers}, &countingAssignmentRepo{pending: tt.pending})
+
+			err := deleter.DeleteQuestionnaire(context.Background(), "PHQ9", tt.force)
+			if tt.wantCode != 0 {
+				if !errors.IsCode(err, tt.wantCode) {
+					t.Fatalf("DeleteQuestionnaire() error = %v, want code %d", err, tt.wantCode)
+				}
+			} else if err != nil {
+				t.Fatalf("DeleteQuestionnaire() error = %v", err)
+			}
+
+			removed := len(mysqlRepo.removed) == 1 && mysqlRepo.removed[0] == 7 &&
+				len(mongoRepo.removed) == 1 && mongoRepo.removed[0] == "PHQ9"
+			if removed != tt.wantRemoved {
+				t.Errorf("removed mysql %v, mongo %v, want removed = %t", mysqlRepo.removed, mongoRepo.removed, tt.wantRemoved)
+			}
+		})
+	}
+}
@@ -9,25 +9,37 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
 	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // Editor 问卷编辑器
 type Editor struct {
-	qRepoMySQL port.QuestionnaireRepositoryMySQL
-	qRepoMongo port.QuestionnaireRepositoryMongo
-	mapper     mapper.QuestionnaireMapper
+	qRepoMySQL      port.QuestionnaireRepositoryMySQL
+	qRepoMongo      port.QuestionnaireRepositoryMongo
+	mapper          mapper.QuestionnaireMapper
+	questionService questionnaire.QuestionService
+	thumbnails      port.ThumbnailCache
+	flags           featureflag.Checker
 }
 
-// NewEditor 创建问卷编辑器
+// NewEditor 创建问卷编辑器，limits 限制问卷和段落的问题数量
+// thumbnails 为问卷缩略图缓存，编辑后删除对应版本的缩略图，为空时不处理
+// flags 为功能开关，自定义题型按问卷编码灰度开放，为空时不允许使用自定义题型
 func NewEditor(
 	qRepoMySQL port.QuestionnaireRepositoryMySQL,
 	qRepoMongo port.QuestionnaireRepositoryMongo,
+	limits questionnaire.QuestionLimits,
+	thumbnails port.ThumbnailCache,
+	flags featureflag.Checker,
 ) *Editor {
 	return &Editor{
-		qRepoMySQL: qRepoMySQL,
-		qRepoMongo: qRepoMongo,
-		mapper:     mapper.NewQuestionnaireMapper(),
+		qRepoMySQL:      qRepoMySQL,
+		qRepoMongo:      qRepoMongo,
+		mapper:          mapper.NewQuestionnaireMapper(),
+		questionService: questionnaire.NewQuestionService(limits),
+		thumbnails:      thumbnails,
+		flags:           flags,
 	}
 }
 
@@ -65,22 +77,34 @@ func (e *Editor) EditBasicInfo(
 	if qBo.IsArchived() {
 		return nil, errors.WithCode(errorCode.ErrQuestionnaireArchived, "问卷已归档，不能编辑")
 	}
+	if err := qBo.EnsureImmutability(); err != nil {
+		return nil, err
+	}
+	// 以客户端加载问卷时的修订号作为乐观锁条件，期间有其他人保存时返回冲突
+	qBo.SetRevision(questionnaireDTO.Revision)
 
 	// 4. 更新基本信息
 	baseInfoService := questionnaire.BaseInfoService{}
 	baseInfoService.UpdateTitle(qBo, questionnaireDTO.Title)
 	baseInfoService.UpdateDescription(qBo, questionnaireDTO.Description)
 	baseInfoService.UpdateCoverImage(qBo, questionnaireDTO.ImgUrl)
+	if err := baseInfoService.UpdateGeoRestriction(qBo, questionnaireDTO.GeoRestriction); err != nil {
+		return nil, err
+	}
+	if err := baseInfoService.UpdateTags(qBo, questionnaireDTO.Tags); err != nil {
+		return nil, err
+	}
 
-	// 5. 保存到数据库
-	if err := e.qRepoMySQL.Update(ctx, qBo); err != nil {
-		return nil, errors.WrapC(err, errorCode.ErrDatabase, "保存问卷基本信息失败")
+	// 5. 先按修订号保存到文档数据库，并发修改时不会覆盖关系数据库
+	if err := updateMongo(ctx, e.qRepoMongo, qBo, "同步问卷基本信息失败"); err != nil {
+		return nil, err
 	}
 
-	// 6. 同步到文档数据库
-	if err := e.qRepoMongo.Update(ctx, qBo); err != nil {
-		return nil, errors.WrapC(err, errorCode.ErrDatabase, "同步问卷基本信息失败")
+	// 6. 保存到数据库
+	if err := e.qRepoMySQL.Update(ctx, qBo); err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "保存问卷基本信息失败")
 	}
+	invalidateThumbnail(ctx, e.thumbnails, qBo.GetCode().Value(), qBo.GetVersion().Value())
 
 	// 7. 转换为 DTO 并返回
 	return e.mapper.ToDTO(qBo), nil
@@ -106,10 +130,24 @@ func (e *Editor) validateQuestions(questions []dto.QuestionDTO) error {
 	return nil
 }
 
-// UpdateQuestions 更新问题
+// checkQuestionTypeRollout 校验自定义题型是否已对问卷开放，内置题型不受功能开关限制
+func (e *Editor) checkQuestionTypeRollout(code string, questions []dto.QuestionDTO) error {
+	for _, q := range questions {
+		if question.IsBuiltinQuestionType(question.QuestionType(q.Type)) {
+			continue
+		}
+		if e.flags == nil || !e.flags.Enabled(featureflag.NewQuestionTypes, code) {
+			return errors.WithCode(errorCode.ErrQuestionnaireInvalidQuestion, "题型 %s 尚未对问卷 %s 开放", q.Type, code)
+		}
+	}
+	return nil
+}
+
+// UpdateQuestions 更新问题，revision 为客户端加载问卷时的修订号，期间有其他人保存时返回冲突
 func (e *Editor) UpdateQuestions(
 	ctx context.Context,
 	code string,
+	revision int64,
 	questionDTOs []dto.QuestionDTO,
 ) (*dto.QuestionnaireDTO, error) {
 	// 1. 验证输入参数
@@ -119,6 +157,9 @@ func (e *Editor) UpdateQuestions(
 	if err := e.validateQuestions(questionDTOs); err != nil {
 		return nil, err
 	}
+	if err := e.checkQuestionTypeRollout(code, questionDTOs); err != nil {
+		return nil, err
+	}
 
 	// 2. 获取现有问卷
 	qBo, err := e.qRepoMySQL.FindByCode(ctx, code)
@@ -130,11 +171,15 @@ func (e *Editor) UpdateQuestions(
 	if qBo.IsArchived() {
 		return nil, errors.WithCode(errorCode.ErrQuestionnaireArchived, "问卷已归档，不能编辑")
 	}
+	if err := qBo.EnsureImmutability(); err != nil {
+		return nil, err
+	}
+	qBo.SetRevision(revision)
 
 	// 4. 转换 DTO 到领域对象
 	questions := make([]question.Question, 0, len(questionDTOs))
 	for _, qDTO := range questionDTOs {
-		q, err := e.mapper.QuestionFromDTO(&qDTO)
+		q, err := e.mapper.ValidatedQuestionFromDTO(&qDTO)
 		if err != nil {
 			return nil, errors.WrapC(err, errorCode.ErrQuestionnaireInvalidQuestion, "转换问题失败: %s", qDTO.Code)
 		}
@@ -142,19 +187,111 @@ func (e *Editor) UpdateQuestions(
 	}
 
 	// 5. 更新问题
-	questionService := questionnaire.QuestionService{}
 	// 5.1 清除现有问题
-	questionService.RemoveAllQuestions(qBo)
+	e.questionService.RemoveAllQuestions(qBo)
 	// 5.2 按顺序添加新问题
-	for _, q := range questions {
-		questionService.AddQuestion(qBo, q)
+	if err := e.questionService.BulkAddQuestions(qBo, questions); err != nil {
+		return nil, err
 	}
 
 	// 6. 保存到数据库
-	if err := e.qRepoMongo.Update(ctx, qBo); err != nil {
-		return nil, errors.WrapC(err, errorCode.ErrDatabase, "保存问卷问题失败")
+	if err := updateMongo(ctx, e.qRepoMongo, qBo, "保存问卷问题失败"); err != nil {
+		return nil, err
 	}
+	invalidateThumbnail(ctx, e.thumbnails, qBo.GetCode().Value(), qBo.GetVersion().Value())
 
 	// 7. 转换为 DTO 并返回
 	return e.mapper.ToDTO(qBo), nil
 }
+
+// AmendBasicInfo 为已发布的问卷创建修订版本并更新其基本信息，如修正标题中的错别字
+func (e *Editor) AmendBasicInfo(
+	ctx context.Context,
+	questionnaireDTO *dto.QuestionnaireDTO,
+) (*dto.QuestionnaireDTO, error) {
+	if err := e.validateQuestionnaireDTO(questionnaireDTO); err != nil {
+		return nil, err
+	}
+
+	amended, err := e.CreateAmendedVersion(ctx, questionnaireDTO.Code, func(q *questionnaire.Questionnaire) error {
+		baseInfoService := questionnaire.BaseInfoService{}
+		if err := baseInfoService.UpdateTitle(q, questionnaireDTO.Title); err != nil {
+			return err
+		}
+		if err := baseInfoService.UpdateDescription(q, questionnaireDTO.Description); err != nil {
+			return err
+		}
+		if err := baseInfoService.UpdateCoverImage(q, questionnaireDTO.ImgUrl); err != nil {
+			return err
+		}
+		if err := baseInfoService.UpdateGeoRestriction(q, questionnaireDTO.GeoRestriction); err != nil {
+			return err
+		}
+		return baseInfoService.UpdateTags(q, questionnaireDTO.Tags)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e.mapper.ToDTO(amended), nil
+}
+
+// CreateAmendedVersion 为已发布的问卷创建修订版本
+// 克隆当前版本得到下一版本的草稿，对草稿应用 amendments 后保存，原版本保持不变；amendments 返回错误时不保存；
+// 草稿成为问卷的当前版本，可继续编辑，重新发布后生效
+func (e *Editor) CreateAmendedVersion(
+	ctx context.Context,
+	code string,
+	amendments func(*questionnaire.Questionnaire) error,
+) (*questionnaire.Questionnaire, error) {
+	// 1. 验证输入参数
+	if code == "" {
+		return nil, errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "问卷编码不能为空")
+	}
+
+	// 2. 获取当前版本
+	head, err := e.qRepoMySQL.FindByCode(ctx, code)
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrQuestionnaireNotFound, "获取问卷失败")
+	}
+	if !head.IsPublished() {
+		return nil, errors.WithCode(errorCode.ErrQuestionnaireInvalidStatus, "只有已发布的问卷需要创建修订版本，未发布的问卷可直接编辑")
+	}
+	current, err := e.qRepoMongo.FindByCodeVersion(ctx, code, head.GetVersion().Value())
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrQuestionnaireNotFound, "获取问卷失败")
+	}
+	current.SetID(head.GetID())
+
+	// 3. 克隆为下一版本的草稿并应用修订
+	amended := questionnaire.VersionService{}.Clone(current)
+	if amended.GetVersion() == current.GetVersion() {
+		return nil, errors.WithCode(errorCode.ErrQuestionnaireInvalidStatus, "问卷版本 %s 无法递增", current.GetVersion().Value())
+	}
+	if amendments != nil {
+		if err := amendments(amended); err != nil {
+			return nil, err
+		}
+	}
+
+	// 4. 保存修订版本，原版本的文档保持不变
+	if err := e.qRepoMongo.Create(ctx, amended); err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "保存问卷修订版本失败")
+	}
+	if err := e.qRepoMySQL.Update(ctx, amended); err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "更新问卷当前版本失败")
+	}
+
+	return amended, nil
+}
+
+// updateMongo 保存问卷到文档数据库，修订号冲突的错误原样返回，以便客户端重新加载后重试
+func updateMongo(ctx context.Context, qRepoMongo port.QuestionnaireRepositoryMongo, qBo *questionnaire.Questionnaire, message string) error {
+	if err := qRepoMongo.Update(ctx, qBo); err != nil {
+		if errors.IsCode(err, errorCode.ErrQuestionnaireVersionConflict) {
+			return err
+		}
+		return errors.WrapC(err, errorCode.ErrDatabase, "%s", message)
+	}
+	return nil
+}
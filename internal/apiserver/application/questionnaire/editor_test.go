@@ -0,0 +1,183 @@
+package questionnaire
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// casQuestionnaireRepoMongo 按修订号比较并更新的内存文档库
+type casQuestionnaireRepoMongo struct {
+	port.QuestionnaireRepositoryMongo
+	mu       sync.Mutex
+	revision int64
+}
+
+func (f *casQuestionnaireRepoMongo) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if qDomain.GetRevision() != f.revision {
+		return questionnaire.NewRevisionConflictError(qDomain.GetCode().Value(), f.revision)
+	}
+	f.revision++
+	qDomain.SetRevision(f.revision)
+	return nil
+}
+
+func TestEditor_UpdateQuestions_StaleRevisionConflict(t *testing.T) {
+	// 两位编辑者加载了同一修订号的问卷，第一位保存后，第二位仍以加载时的修订号保存
+	const loadedRevision = 3
+	repo := &casQuestionnaireRepoMongo{revision: loadedRevision}
+	editor := NewEditor(&fakeQuestionnaireRepoMySQL{}, repo, questionnaire.QuestionLimits{}, nil, nil)
+	questions := []dto.QuestionDTO{{Code: "q1", Title: "最近两周的睡眠情况", Type: "Text"}}
+
+	saved, err := editor.UpdateQuestions(context.Background(), "PHQ9", loadedRevision, questions)
+	if err != nil {
+		t.Fatalf("first UpdateQuestions() error = %v", err)
+	}
+	if saved.Revision != loadedRevision+1 {
+		t.Errorf("saved revision = %d, want %d", saved.Revision, loadedRevision+1)
+	}
+
+	_, err = editor.UpdateQuestions(context.Background(), "PHQ9", loadedRevision, questions)
+	if !errors.IsCode(err, errorCode.ErrQuestionnaireVersionConflict) {
+		t.Fatalf("second UpdateQuestions() error = %v, want ErrQuestionnaireVersionConflict", err)
+	}
+	var conflict *questionnaire.RevisionConflictError
+	if !errors.As(err, &conflict) || conflict.CurrentRevision != loadedRevision+1 {
+		t.Errorf("conflict = %+v, want current revision %d", conflict, loadedRevision+1)
+	}
+	if repo.revision != loadedRevision+1 {
+		t.Errorf("revision = %d, want %d", repo.revision, loadedRevision+1)
+	}
+}
+
+// questionnaireFlags 只对指定问卷开启指定功能的功能开关
+type questionnaireFlags struct {
+	name, code string
+}
+
+func (f questionnaireFlags) Enabled(name, key string) bool {
+	return name == f.name && key == f.code
+}
+
+func TestEditor_UpdateQuestions_CustomTypeRollout(t *testing.T) {
+	questions := []dto.QuestionDTO{{Code: "q1", Title: "疼痛程度", Type: "PainScale"}}
+
+	editor := NewEditor(&fakeQuestionnaireRepoMySQL{}, &casQuestionnaireRepoMongo{}, questionnaire.QuestionLimits{}, nil,
+		questionnaireFlags{name: featureflag.NewQuestionTypes, code: "GAD7"})
+	_, err := editor.UpdateQuestions(context.Background(), "PHQ9", 0, questions)
+	if !errors.IsCode(err, errorCode.ErrQuestionnaireInvalidQuestion) {
+		t.Fatalf("UpdateQuestions() error = %v, want ErrQuestionnaireInvalidQuestion before rollout", err)
+	}
+
+	// 内置题型不受功能开关限制
+	builtin := []dto.QuestionDTO{{Code: "q1", Title: "最近两周的睡眠情况", Type: "Text"}}
+	if _, err := editor.UpdateQuestions(context.Background(), "PHQ9", 0, builtin); err != nil {
+		t.Fatalf("UpdateQuestions() with builtin type error = %v", err)
+	}
+}
+
+// publishedQuestionnaireRepoMySQL 返回已发布的问卷，并记录当前版本的更新次数
+type publishedQuestionnaireRepoMySQL struct {
+	port.QuestionnaireRepositoryMySQL
+	updates int
+}
+
+func (f *publishedQuestionnaireRepoMySQL) FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	return newPublishedQuestionnaire(code), nil
+}
+
+func (f *publishedQuestionnaireRepoMySQL) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	f.updates++
+	return nil
+}
+
+// versionedQuestionnaireRepoMongo 返回指定版本的问卷，并记录创建的修订版本数
+type versionedQuestionnaireRepoMongo struct {
+	port.QuestionnaireRepositoryMongo
+	creates int
+}
+
+func (f *versionedQuestionnaireRepoMongo) FindByCodeVersion(ctx context.Context, code, version string) (*questionnaire.Questionnaire, error) {
+	return newPublishedQuestionnaire(code), nil
+}
+
+func (f *versionedQuestionnaireRepoMongo) Create(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	f.creates++
+	return nil
+}
+
+// newPublishedQuestionnaire 创建版本 1.0 的已发布问卷
+func newPublishedQuestionnaire(code string) *questionnaire.Questionnaire {
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode(code),
+		"PHQ-9",
+		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		questionnaire.WithStatus(questionnaire.STATUS_PUBLISHED),
+	)
+}
+
+func TestEditor_AmendBasicInfo_InvalidAmendmentNotSaved(t *testing.T) {
+	mysqlRepo := &publishedQuestionnaireRepoMySQL{}
+	mongoRepo := &versionedQuestionnaireRepoMongo{}
+	editor := NewEditor(mysqlRepo, mongoRepo, questionnaire.QuestionLimits{}, nil, nil)
+
+	_, err := editor.AmendBasicInfo(context.Background(), &dto.QuestionnaireDTO{
+		Code:           "PHQ9",
+		Title:          "PHQ-9 抑郁症筛查量表",
+		ImgUrl:         "https://cdn.example.com/phq9.png",
+		GeoRestriction: []string{"CN", "China"},
+	})
+	if !errors.IsCode(err, errorCode.ErrInvalidArgument) {
+		t.Fatalf("AmendBasicInfo() error = %v, want ErrInvalidArgument", err)
+	}
+	if mongoRepo.creates != 0 || mysqlRepo.updates != 0 {
+		t.Errorf("creates = %d, updates = %d, want the amended version not saved", mongoRepo.creates, mysqlRepo.updates)
+	}
+
+	amended, err := editor.AmendBasicInfo(context.Background(), &dto.QuestionnaireDTO{
+		Code:           "PHQ9",
+		Title:          "PHQ-9 抑郁症筛查量表",
+		ImgUrl:         "https://cdn.example.com/phq9.png",
+		GeoRestriction: []string{"cn"},
+	})
+	if err != nil {
+		t.Fatalf("AmendBasicInfo() with valid country error = %v", err)
+	}
+	if amended.Title != "PHQ-9 抑郁症筛查量表" || len(amended.GeoRestriction) != 1 || amended.GeoRestriction[0] != "CN" {
+		t.Errorf("amended = %+v, want new title and geo restriction [CN]", amended)
+	}
+	if mongoRepo.creates != 1 || mysqlRepo.updates != 1 {
+		t.Errorf("creates = %d, updates = %d, want 1, 1", mongoRepo.creates, mysqlRepo.updates)
+	}
+}
+
+func TestEditor_AmendBasicInfo_InvalidTagsNotSaved(t *testing.T) {
+	mysqlRepo := &publishedQuestionnaireRepoMySQL{}
+	mongoRepo := &versionedQuestionnaireRepoMongo{}
+	editor := NewEditor(mysqlRepo, mongoRepo, questionnaire.QuestionLimits{}, nil, nil)
+
+	_, err := editor.AmendBasicInfo(context.Background(), &dto.QuestionnaireDTO{
+		Code:   "PHQ9",
+		Title:  "PHQ-9 抑郁症筛查量表",
+		ImgUrl: "https://cdn.example.com/phq9.png",
+		Tags:   []string{"抑郁", strings.Repeat("t", questionnaire.MaxTagLength+1)},
+	})
+	if !errors.IsCode(err, errorCode.ErrInvalidArgument) {
+		t.Fatalf("AmendBasicInfo() error = %v, want ErrInvalidArgument", err)
+	}
+	if mongoRepo.creates != 0 || mysqlRepo.updates != 0 {
+		t.Errorf("creates = %d, updates = %d, want the amended version not saved", mongoRepo.creates, mysqlRepo.updates)
+	}
+}
@@ -0,0 +1,120 @@
+package questionnaire
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// previewClaims 预览令牌的签名内容
+type previewClaims struct {
+	Code string `json:"code"`
+	Exp  int64  `json:"exp"`
+}
+
+// Previewer 问卷预览器
+// 预览令牌为 base64url(claims).base64url(HMAC-SHA256(claims))，无需登录即可查看问卷（含草稿）的完整结构
+type Previewer struct {
+	qRepoMySQL port.QuestionnaireRepositoryMySQL
+	queryer    *Queryer
+	secretKey  []byte
+	now        func() time.Time
+}
+
+// NewPreviewer 创建问卷预览器，secretKey 用于签名预览令牌，为空时拒绝签发和校验任何令牌
+func NewPreviewer(
+	qRepoMySQL port.QuestionnaireRepositoryMySQL,
+	qRepoMongo port.QuestionnaireRepositoryMongo,
+	secretKey string,
+) *Previewer {
+	return &Previewer{
+		qRepoMySQL: qRepoMySQL,
+		queryer:    NewQueryer(qRepoMySQL, qRepoMongo),
+		secretKey:  []byte(secretKey),
+		now:        time.Now,
+	}
+}
+
+// CreatePreviewToken 为问卷签发预览令牌，expiresIn 不大于 0 时使用默认有效期
+func (p *Previewer) CreatePreviewToken(ctx context.Context, questionnaireCode string, expiresIn time.Duration) (string, error) {
+	if len(p.secretKey) == 0 {
+		return "", errors.WithCode(errorCode.ErrInternalServerError, "未配置预览令牌签名密钥")
+	}
+	if questionnaireCode == "" {
+		return "", errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "问卷编码不能为空")
+	}
+	if expiresIn <= 0 {
+		expiresIn = questionnaire.DefaultPreviewTokenTTL
+	}
+	if expiresIn > questionnaire.MaxPreviewTokenTTL {
+		return "", errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "预览令牌有效期不能超过 %s", questionnaire.MaxPreviewTokenTTL)
+	}
+
+	if _, err := p.qRepoMySQL.FindByCode(ctx, questionnaireCode); err != nil {
+		return "", errors.WrapC(err, errorCode.ErrQuestionnaireNotFound, "获取问卷失败")
+	}
+
+	payload, err := json.Marshal(previewClaims{
+		Code: questionnaireCode,
+		Exp:  p.now().Add(expiresIn).Unix(),
+	})
+	if err != nil {
+		return "", errors.WrapC(err, errorCode.ErrEncodingFailed, "编码预览令牌失败")
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + p.sign(encoded), nil
+}
+
+// VerifyPreviewToken 校验预览令牌的签名和有效期，返回令牌对应的问卷编码
+func (p *Previewer) VerifyPreviewToken(token string) (string, error) {
+	// 未配置密钥时任何人都能算出签名，不接受任何令牌
+	if len(p.secretKey) == 0 {
+		return "", errors.WithCode(errorCode.ErrPreviewTokenInvalid, "未配置预览令牌签名密钥")
+	}
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok || !hmac.Equal([]byte(signature), []byte(p.sign(encoded))) {
+		return "", errors.WithCode(errorCode.ErrPreviewTokenInvalid, "预览令牌签名无效")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", errors.WrapC(err, errorCode.ErrPreviewTokenInvalid, "预览令牌格式无效")
+	}
+	var claims previewClaims
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Code == "" {
+		return "", errors.WithCode(errorCode.ErrPreviewTokenInvalid, "预览令牌格式无效")
+	}
+
+	if !p.now().Before(time.Unix(claims.Exp, 0)) {
+		return "", errors.WithCode(errorCode.ErrPreviewTokenExpired, "预览令牌已于 %s 过期", time.Unix(claims.Exp, 0).Format(time.RFC3339))
+	}
+
+	return claims.Code, nil
+}
+
+// GetPreviewQuestionnaire 根据预览令牌获取问卷的完整结构，包括尚未发布的草稿问题
+func (p *Previewer) GetPreviewQuestionnaire(ctx context.Context, token string) (*dto.QuestionnaireDTO, error) {
+	code, err := p.VerifyPreviewToken(token)
+	if err != nil {
+		return nil, err
+	}
+	return p.queryer.GetQuestionnaireByCode(ctx, code)
+}
+
+// sign 计算令牌内容的 HMAC-SHA256 签名
+func (p *Previewer) sign(encoded string) string {
+	mac := hmac.New(sha256.New, p.secretKey)
+	mac.Write([]byte(encoded))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
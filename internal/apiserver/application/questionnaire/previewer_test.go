@@ -0,0 +1,80 @@
+package questionnaire
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// fakeQuestionnaireRepoMySQL 只实现按编码查询草稿问卷的存储库
+type fakeQuestionnaireRepoMySQL struct {
+	port.QuestionnaireRepositoryMySQL
+}
+
+func (f *fakeQuestionnaireRepoMySQL) FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	return questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode(code), "PHQ-9"), nil
+}
+
+func newTestPreviewer(secretKey string, now time.Time) *Previewer {
+	p := NewPreviewer(&fakeQuestionnaireRepoMySQL{}, nil, secretKey)
+	p.now = func() time.Time { return now }
+	return p
+}
+
+func TestPreviewer_PreviewToken(t *testing.T) {
+	issuedAt := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+	p := newTestPreviewer("preview-secret", issuedAt)
+
+	token, err := p.CreatePreviewToken(context.Background(), "PHQ9", time.Hour)
+	if err != nil {
+		t.Fatalf("CreatePreviewToken() error = %v", err)
+	}
+	if code, err := p.VerifyPreviewToken(token); err != nil || code != "PHQ9" {
+		t.Fatalf("VerifyPreviewToken() = %q, %v, want PHQ9", code, err)
+	}
+
+	tests := []struct {
+		name     string
+		verifier *Previewer
+		token    string
+		wantCode int
+	}{
+		{"expired", newTestPreviewer("preview-secret", issuedAt.Add(time.Hour)), token, errorCode.ErrPreviewTokenExpired},
+		{"signed with another key", newTestPreviewer("other-secret", issuedAt), token, errorCode.ErrPreviewTokenInvalid},
+		{"tampered signature", p, token[:len(token)-2] + "xx", errorCode.ErrPreviewTokenInvalid},
+		{"tampered claims", p, "e30" + token[3:], errorCode.ErrPreviewTokenInvalid},
+		{"malformed", p, "not-a-token", errorCode.ErrPreviewTokenInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := tt.verifier.VerifyPreviewToken(tt.token); !errors.IsCode(err, tt.wantCode) {
+				t.Errorf("VerifyPreviewToken() error = %v, want code %d", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestPreviewer_CreatePreviewToken_RejectsTooLongTTL(t *testing.T) {
+	p := newTestPreviewer("preview-secret", time.Now())
+	if _, err := p.CreatePreviewToken(context.Background(), "PHQ9", questionnaire.MaxPreviewTokenTTL+time.Hour); !errors.IsCode(err, errorCode.ErrQuestionnaireInvalidInput) {
+		t.Errorf("CreatePreviewToken() error = %v, want ErrQuestionnaireInvalidInput", err)
+	}
+}
+
+func TestPreviewer_EmptySecretKeyFailsClosed(t *testing.T) {
+	p := newTestPreviewer("", time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC))
+
+	if _, err := p.CreatePreviewToken(context.Background(), "PHQ9", time.Hour); err == nil {
+		t.Error("CreatePreviewToken() with empty secret key succeeded, want error")
+	}
+	// 任何人都能用空密钥算出签名，这样伪造的令牌不能通过校验
+	forged := "e30." + p.sign("e30")
+	if _, err := p.VerifyPreviewToken(forged); !errors.IsCode(err, errorCode.ErrPreviewTokenInvalid) {
+		t.Errorf("VerifyPreviewToken() error = %v, want ErrPreviewTokenInvalid", err)
+	}
+}
@@ -2,6 +2,7 @@ package questionnaire
 
 import (
 	"context"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
@@ -13,20 +14,28 @@ import (
 
 // Publisher 问卷发布器
 type Publisher struct {
-	qRepoMySQL port.QuestionnaireRepositoryMySQL
-	qRepoMongo port.QuestionnaireRepositoryMongo
-	mapper     mapper.QuestionnaireMapper
+	qRepoMySQL      port.QuestionnaireRepositoryMySQL
+	qRepoMongo      port.QuestionnaireRepositoryMongo
+	mapper          mapper.QuestionnaireMapper
+	questionService questionnaire.QuestionService
+	events          port.PublicationPublisher
+	now             func() time.Time
 }
 
-// NewPublisher 创建问卷发布器
+// NewPublisher 创建问卷发布器，发布前按 limits 复核问题数量，发布成功后通过 events 发布问卷版本发布事件（可为空）
 func NewPublisher(
 	qRepoMySQL port.QuestionnaireRepositoryMySQL,
 	qRepoMongo port.QuestionnaireRepositoryMongo,
+	limits questionnaire.QuestionLimits,
+	events port.PublicationPublisher,
 ) *Publisher {
 	return &Publisher{
-		qRepoMySQL: qRepoMySQL,
-		qRepoMongo: qRepoMongo,
-		mapper:     mapper.NewQuestionnaireMapper(),
+		qRepoMySQL:      qRepoMySQL,
+		qRepoMongo:      qRepoMongo,
+		mapper:          mapper.NewQuestionnaireMapper(),
+		questionService: questionnaire.NewQuestionService(limits),
+		events:          events,
+		now:             time.Now,
 	}
 }
 
@@ -38,10 +47,11 @@ func (p *Publisher) validateCode(code string) error {
 	return nil
 }
 
-// Publish 发布问卷
+// Publish 发布问卷，revision 为客户端加载问卷时的修订号，期间有其他人保存时返回冲突
 func (p *Publisher) Publish(
 	ctx context.Context,
 	code string,
+	revision int64,
 ) (*dto.QuestionnaireDTO, error) {
 	// 1. 验证输入参数
 	if err := p.validateCode(code); err != nil {
@@ -66,29 +76,44 @@ func (p *Publisher) Publish(
 	if len(qBo.GetQuestions()) == 0 {
 		return nil, errors.WithCode(errorCode.ErrQuestionnaireInvalidQuestion, "问卷没有问题，不能发布")
 	}
+	// 问题数量上限可能在编辑后调整，发布前重新校验
+	if err := p.questionService.ValidateLimits(qBo); err != nil {
+		return nil, err
+	}
+	qBo.SetRevision(revision)
 
 	// 5. 更新状态为已发布
 	versionService := questionnaire.VersionService{}
 	versionService.Publish(qBo)
 
-	// 6. 更新到数据库
+	// 6. 先按修订号同步到文档数据库，并发修改时不会覆盖关系数据库
+	if err := updateMongo(ctx, p.qRepoMongo, qBo, "同步问卷状态失败"); err != nil {
+		return nil, err
+	}
+
+	// 7. 更新到数据库
 	if err := p.qRepoMySQL.Update(ctx, qBo); err != nil {
 		return nil, errors.WrapC(err, errorCode.ErrDatabase, "保存问卷状态失败")
 	}
 
-	// 7. 同步到文档数据库
-	if err := p.qRepoMongo.Update(ctx, qBo); err != nil {
-		return nil, errors.WrapC(err, errorCode.ErrDatabase, "同步问卷状态失败")
+	// 8. 发布问卷版本发布事件
+	if p.events != nil {
+		p.events.PublishPublished(questionnaire.QuestionnairePublished{
+			Code:        qBo.GetCode().Value(),
+			Version:     qBo.GetVersion().Value(),
+			PublishedAt: p.now(),
+		})
 	}
 
-	// 8. 转换为 DTO 并返回
+	// 9. 转换为 DTO 并返回
 	return p.mapper.ToDTO(qBo), nil
 }
 
-// Unpublish 下架问卷
+// Unpublish 下架问卷，revision 为客户端加载问卷时的修订号，期间有其他人保存时返回冲突
 func (p *Publisher) Unpublish(
 	ctx context.Context,
 	code string,
+	revision int64,
 ) (*dto.QuestionnaireDTO, error) {
 	// 1. 验证输入参数
 	if err := p.validateCode(code); err != nil {
@@ -108,19 +133,20 @@ func (p *Publisher) Unpublish(
 	if !qBo.IsPublished() {
 		return nil, errors.WithCode(errorCode.ErrQuestionnaireInvalidStatus, "问卷未发布，不能下架")
 	}
+	qBo.SetRevision(revision)
 
 	// 4. 更新状态为未发布
 	versionService := questionnaire.VersionService{}
 	versionService.Unpublish(qBo)
 
-	// 5. 更新到数据库
-	if err := p.qRepoMySQL.Update(ctx, qBo); err != nil {
-		return nil, errors.WrapC(err, errorCode.ErrDatabase, "保存问卷状态失败")
+	// 5. 先按修订号同步到文档数据库，并发修改时不会覆盖关系数据库
+	if err := updateMongo(ctx, p.qRepoMongo, qBo, "同步问卷状态失败"); err != nil {
+		return nil, err
 	}
 
-	// 6. 同步到文档数据库
-	if err := p.qRepoMongo.Update(ctx, qBo); err != nil {
-		return nil, errors.WrapC(err, errorCode.ErrDatabase, "同步问卷状态失败")
+	// 6. 更新到数据库
+	if err := p.qRepoMySQL.Update(ctx, qBo); err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "保存问卷状态失败")
 	}
 
 	// 7. 转换为 DTO 并返回
@@ -0,0 +1,67 @@
+package questionnaire
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+)
+
+// draftQuestionnaireRepoMySQL 返回一份含问题的草稿问卷
+type draftQuestionnaireRepoMySQL struct {
+	port.QuestionnaireRepositoryMySQL
+}
+
+func (f *draftQuestionnaireRepoMySQL) FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	text := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("Q1")),
+		question.WithTitle("最近两周的睡眠情况"),
+		question.WithQuestionType(question.QuestionTypeText),
+	))
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode(code),
+		"PHQ-9",
+		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0.2")),
+		questionnaire.WithQuestions([]question.Question{text}),
+	), nil
+}
+
+func (f *draftQuestionnaireRepoMySQL) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	return nil
+}
+
+// memoryQuestionnaireRepoMongo 不检查修订号的文档库
+type memoryQuestionnaireRepoMongo struct {
+	port.QuestionnaireRepositoryMongo
+}
+
+func (f *memoryQuestionnaireRepoMongo) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	return nil
+}
+
+// recordingPublicationPublisher 记录发布的问卷版本发布事件
+type recordingPublicationPublisher struct {
+	events []questionnaire.QuestionnairePublished
+}
+
+func (r *recordingPublicationPublisher) PublishPublished(event questionnaire.QuestionnairePublished) {
+	r.events = append(r.events, event)
+}
+
+func TestPublisher_PublishEmitsQuestionnairePublished(t *testing.T) {
+	publishedAt := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	events := &recordingPublicationPublisher{}
+	publisher := NewPublisher(&draftQuestionnaireRepoMySQL{}, &memoryQuestionnaireRepoMongo{}, questionnaire.QuestionLimits{}, events)
+	publisher.now = func() time.Time { return publishedAt }
+
+	if _, err := publisher.Publish(context.Background(), "PHQ9", 0); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	want := questionnaire.QuestionnairePublished{Code: "PHQ9", Version: "1.0.2", PublishedAt: publishedAt}
+	if len(events.events) != 1 || events.events[0] != want {
+		t.Errorf("published events = %+v, want [%+v]", events.events, want)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
 	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
 )
 
 // Queryer 问卷查询器
@@ -68,8 +69,8 @@ func (q *Queryer) GetQuestionnaireByCode(
 		return nil, errors.WrapC(err, errorCode.ErrQuestionnaireNotFound, "获取问卷失败")
 	}
 
-	// 3. 从 MongoDB 获取问题列表
-	qBOFromMongo, err := q.qRepoMongo.FindByCode(ctx, code)
+	// 3. 从 MongoDB 获取问题列表（问卷尚未编辑问题时文档可能不存在）
+	qBOFromMongo, err := q.qRepoMongo.FindByCodeOrNil(ctx, code)
 	if err != nil {
 		return nil, errors.WrapC(err, errorCode.ErrDatabase, "获取问题列表失败")
 	}
@@ -81,6 +82,73 @@ func (q *Queryer) GetQuestionnaireByCode(
 	return q.mapper.ToDTO(qBo), nil
 }
 
+// GetQuestions 获取问卷的问题列表
+// withAutoCodes 为 true 时为缺少编码的问题生成编码并保存，之后的查询返回相同的编码
+func (q *Queryer) GetQuestions(
+	ctx context.Context,
+	code string,
+	withAutoCodes bool,
+) ([]dto.QuestionDTO, error) {
+	if withAutoCodes {
+		if err := q.assignMissingQuestionCodes(ctx, code); err != nil {
+			return nil, err
+		}
+	}
+
+	qDTO, err := q.GetQuestionnaireByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return qDTO.Questions, nil
+}
+
+// assignMissingQuestionCodes 为缺少编码的问题生成编码并保存到 MongoDB
+func (q *Queryer) assignMissingQuestionCodes(ctx context.Context, code string) error {
+	if err := q.validateCode(code); err != nil {
+		return err
+	}
+
+	qBo, err := q.qRepoMongo.FindByCodeOrNil(ctx, code)
+	if err != nil {
+		return errors.WrapC(err, errorCode.ErrDatabase, "获取问题列表失败")
+	}
+	if qBo == nil || !(questionnaire.QuestionService{}).AssignMissingCodes(qBo) {
+		return nil
+	}
+	return updateMongo(ctx, q.qRepoMongo, qBo, "保存自动生成的问题编码失败")
+}
+
+// ListQuestionTypes 列出所有已注册题型（内置题型和自定义题型）及其声明的配置能力，供前端动态构建表单
+func (q *Queryer) ListQuestionTypes(ctx context.Context) []questiontype.Info {
+	return questiontype.List()
+}
+
+// GetQuestionnaireByCodeVersion 根据编码和版本获取问卷
+func (q *Queryer) GetQuestionnaireByCodeVersion(
+	ctx context.Context,
+	code, version string,
+) (*dto.QuestionnaireDTO, error) {
+	// 1. 验证输入参数
+	if err := q.validateCode(code); err != nil {
+		return nil, err
+	}
+	if version == "" {
+		return nil, errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "问卷版本不能为空")
+	}
+
+	// 2. 从 MongoDB 获取指定版本的问卷
+	qBo, err := q.qRepoMongo.FindByCodeVersion(ctx, code, version)
+	if err != nil {
+		if errors.IsCode(err, errorCode.ErrQuestionnaireNotFound) {
+			return nil, err
+		}
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "获取问卷失败")
+	}
+
+	// 3. 转换为 DTO 并返回
+	return q.mapper.ToDTO(qBo), nil
+}
+
 // ListQuestionnaires 获取问卷列表
 func (q *Queryer) ListQuestionnaires(
 	ctx context.Context,
@@ -113,6 +181,40 @@ func (q *Queryer) ListQuestionnaires(
 	return dtos, total, nil
 }
 
+// ListQuestionnairesByTags 按标签获取问卷列表，标签按问卷保存时的规则规范化后再匹配
+func (q *Queryer) ListQuestionnairesByTags(
+	ctx context.Context,
+	tags []string,
+	matchAll bool,
+	page, pageSize int,
+) ([]*dto.QuestionnaireDTO, int64, error) {
+	// 1. 验证分页参数和标签
+	if err := q.validatePagination(page, pageSize); err != nil {
+		return nil, 0, err
+	}
+	tags, err := questionnaire.NormalizeTags(tags)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(tags) == 0 {
+		return nil, 0, errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "标签不能为空")
+	}
+
+	// 2. 获取问卷列表和总数
+	questionnaires, total, err := q.qRepoMySQL.FindByTags(ctx, tags, matchAll, page, pageSize)
+	if err != nil {
+		return nil, 0, errors.WrapC(err, errorCode.ErrDatabase, "按标签获取问卷列表失败")
+	}
+
+	// 3. 转换为 DTO 列表
+	dtos := make([]*dto.QuestionnaireDTO, 0, len(questionnaires))
+	for _, questionnaire := range questionnaires {
+		dtos = append(dtos, q.mapper.ToDTO(questionnaire))
+	}
+
+	return dtos, total, nil
+}
+
 // mergeQuestionnaireData 合并问卷数据
 func (q *Queryer) mergeQuestionnaireData(
 	mysqlData *questionnaire.Questionnaire,
@@ -125,6 +227,13 @@ func (q *Queryer) mergeQuestionnaireData(
 		questionnaire.WithImgUrl(mysqlData.GetImgUrl()),
 		questionnaire.WithVersion(mysqlData.GetVersion()),
 		questionnaire.WithStatus(mysqlData.GetStatus()),
+		questionnaire.WithGeoRestriction(mysqlData.GetGeoRestriction()),
+		questionnaire.WithTags(mysqlData.GetTags()),
+	}
+
+	// 如果 MongoDB 中有问卷数据，则带上修订号，保存时据此检测并发修改
+	if mongoData != nil {
+		opts = append(opts, questionnaire.WithRevision(mongoData.GetRevision()))
 	}
 
 	// 如果 MongoDB 中有问卷数据且有问题列表，则添加问题
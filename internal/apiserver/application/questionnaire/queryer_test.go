@@ -0,0 +1,103 @@
+package questionnaire
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+)
+
+// storedQuestionnaireRepoMongo 保存问题列表和修订号的内存文档库，按修订号比较并更新
+type storedQuestionnaireRepoMongo struct {
+	port.QuestionnaireRepositoryMongo
+	mu        sync.Mutex
+	questions []question.Question
+	revision  int64
+	updates   int
+}
+
+func (f *storedQuestionnaireRepoMongo) FindByCodeOrNil(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	questions := append([]question.Question(nil), f.questions...)
+	return questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode(code), "",
+		questionnaire.WithQuestions(questions),
+		questionnaire.WithRevision(f.revision),
+	), nil
+}
+
+func (f *storedQuestionnaireRepoMongo) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if qDomain.GetRevision() != f.revision {
+		return questionnaire.NewRevisionConflictError(qDomain.GetCode().Value(), f.revision)
+	}
+	f.questions = append([]question.Question(nil), qDomain.GetQuestions()...)
+	f.revision++
+	f.updates++
+	qDomain.SetRevision(f.revision)
+	return nil
+}
+
+func TestQueryer_GetQuestions_WithAutoCodesPersistsCodes(t *testing.T) {
+	stored := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithAutoGenerateCode(false),
+		question.WithTitle("最近两周的睡眠情况"),
+		question.WithQuestionType(question.QuestionTypeText),
+	))
+	repo := &storedQuestionnaireRepoMongo{questions: []question.Question{stored}}
+	queryer := NewQueryer(&fakeQuestionnaireRepoMySQL{}, repo)
+
+	first, err := queryer.GetQuestions(context.Background(), "PHQ9", true)
+	if err != nil {
+		t.Fatalf("first GetQuestions() error = %v", err)
+	}
+	if len(first) != 1 || first[0].Code == "" {
+		t.Fatalf("first GetQuestions() = %+v, want 1 question with a generated code", first)
+	}
+
+	// 再次查询返回已保存的编码，不再重新生成
+	second, err := queryer.GetQuestions(context.Background(), "PHQ9", true)
+	if err != nil {
+		t.Fatalf("second GetQuestions() error = %v", err)
+	}
+	if len(second) != 1 || second[0].Code != first[0].Code {
+		t.Errorf("second GetQuestions() = %+v, want code %q", second, first[0].Code)
+	}
+	if repo.updates != 1 {
+		t.Errorf("updates = %d, want 1", repo.updates)
+	}
+}
+
+func TestQueryer_GetQuestionnaireByCode_ReturnsRevisionForNextSave(t *testing.T) {
+	repo := &storedQuestionnaireRepoMongo{}
+	editor := NewEditor(&fakeQuestionnaireRepoMySQL{}, repo, questionnaire.QuestionLimits{}, nil, nil)
+	queryer := NewQueryer(&fakeQuestionnaireRepoMySQL{}, repo)
+	questions := []dto.QuestionDTO{{Code: "q1", Title: "最近两周的睡眠情况", Type: "Text"}}
+
+	if _, err := editor.UpdateQuestions(context.Background(), "PHQ9", 0, questions); err != nil {
+		t.Fatalf("first UpdateQuestions() error = %v", err)
+	}
+
+	// 加载后以返回的修订号再次保存
+	loaded, err := queryer.GetQuestionnaireByCode(context.Background(), "PHQ9")
+	if err != nil {
+		t.Fatalf("GetQuestionnaireByCode() error = %v", err)
+	}
+	if loaded.Revision != 1 {
+		t.Errorf("loaded revision = %d, want 1", loaded.Revision)
+	}
+	saved, err := editor.UpdateQuestions(context.Background(), "PHQ9", loaded.Revision, questions)
+	if err != nil {
+		t.Fatalf("second UpdateQuestions() error = %v", err)
+	}
+	if saved.Revision != 2 {
+		t.Errorf("saved revision = %d, want 2", saved.Revision)
+	}
+}
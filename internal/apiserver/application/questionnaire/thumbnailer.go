@@ -0,0 +1,98 @@
+package questionnaire
+
+import (
+	"bytes"
+	"context"
+	"io"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// 缩略图尺寸（像素），与 A4 纸接近的 3:4 竖版
+const (
+	ThumbnailWidth  = 300
+	ThumbnailHeight = 400
+)
+
+// PrintPageRenderer 将问卷渲染为打印版式 HTML 页面
+type PrintPageRenderer func(w io.Writer, q *dto.QuestionnaireDTO) error
+
+// thumbnailKey 问卷缩略图的缓存键
+func thumbnailKey(code, version string) string {
+	return code + "-" + version + "-thumbnail"
+}
+
+// Thumbnailer 问卷缩略图生成器
+type Thumbnailer struct {
+	queryer    port.QuestionnaireQueryer
+	renderPage PrintPageRenderer
+	renderer   port.ThumbnailRenderer
+	cache      port.ThumbnailCache
+}
+
+// NewThumbnailer 创建问卷缩略图生成器
+func NewThumbnailer(
+	queryer port.QuestionnaireQueryer,
+	renderPage PrintPageRenderer,
+	renderer port.ThumbnailRenderer,
+	cache port.ThumbnailCache,
+) *Thumbnailer {
+	return &Thumbnailer{
+		queryer:    queryer,
+		renderPage: renderPage,
+		renderer:   renderer,
+		cache:      cache,
+	}
+}
+
+// 确保实现了接口
+var _ port.QuestionnaireThumbnailer = (*Thumbnailer)(nil)
+
+// GenerateThumbnail 生成问卷当前版本打印版式首页的 JPEG 缩略图
+// 缩略图按问卷编码和版本缓存，缓存读写失败只记录日志，不影响生成
+func (t *Thumbnailer) GenerateThumbnail(ctx context.Context, questionnaireCode string) ([]byte, error) {
+	if questionnaireCode == "" {
+		return nil, errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "问卷编码不能为空")
+	}
+
+	q, err := t.queryer.GetQuestionnaireByCode(ctx, questionnaireCode)
+	if err != nil {
+		return nil, err
+	}
+
+	key := thumbnailKey(q.Code, q.Version)
+	if cached, err := t.cache.Get(ctx, key); err != nil {
+		log.Warnf("读取问卷缩略图缓存失败，键: %s, 错误: %v", key, err)
+	} else if cached != nil {
+		return cached, nil
+	}
+
+	var page bytes.Buffer
+	if err := t.renderPage(&page, q); err != nil {
+		return nil, err
+	}
+	thumbnail, err := t.renderer.Render(ctx, page.Bytes(), ThumbnailWidth, ThumbnailHeight)
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrQuestionnaireThumbnailFailed, "生成问卷 %s 的缩略图失败", questionnaireCode)
+	}
+
+	if err := t.cache.Save(ctx, key, thumbnail); err != nil {
+		log.Warnf("保存问卷缩略图缓存失败，键: %s, 错误: %v", key, err)
+	}
+	return thumbnail, nil
+}
+
+// invalidateThumbnail 删除问卷版本的缩略图缓存，cache 为空时不处理，删除失败只记录日志
+func invalidateThumbnail(ctx context.Context, cache port.ThumbnailCache, code, version string) {
+	if cache == nil {
+		return
+	}
+	key := thumbnailKey(code, version)
+	if err := cache.Delete(ctx, key); err != nil {
+		log.Warnf("删除问卷缩略图缓存失败，键: %s, 错误: %v", key, err)
+	}
+}
@@ -0,0 +1,93 @@
+package questionnaire
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/jpeg"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+)
+
+// fakeQuestionnaireQueryer 只实现按编码查询问卷
+type fakeQuestionnaireQueryer struct {
+	port.QuestionnaireQueryer
+}
+
+func (f *fakeQuestionnaireQueryer) GetQuestionnaireByCode(ctx context.Context, code string) (*dto.QuestionnaireDTO, error) {
+	return &dto.QuestionnaireDTO{Code: code, Version: "1.0.1", Title: "PHQ-9"}, nil
+}
+
+// jpegRenderer 按要求的尺寸编码空白 JPEG 图片，并记录渲染次数和收到的页面
+type jpegRenderer struct {
+	calls int
+	html  string
+}
+
+func (r *jpegRenderer) Render(ctx context.Context, html []byte, width, height int) ([]byte, error) {
+	r.calls++
+	r.html = string(html)
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, image.NewGray(image.Rect(0, 0, width, height)), nil); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// memoryThumbnailCache 内存缩略图缓存
+type memoryThumbnailCache map[string][]byte
+
+func (c memoryThumbnailCache) Get(ctx context.Context, key string) ([]byte, error) {
+	return c[key], nil
+}
+
+func (c memoryThumbnailCache) Save(ctx context.Context, key string, data []byte) error {
+	c[key] = data
+	return nil
+}
+
+func (c memoryThumbnailCache) Delete(ctx context.Context, key string) error {
+	delete(c, key)
+	return nil
+}
+
+func TestThumbnailer_GenerateThumbnail(t *testing.T) {
+	renderer := &jpegRenderer{}
+	cache := memoryThumbnailCache{}
+	renderPage := func(w io.Writer, q *dto.QuestionnaireDTO) error {
+		_, err := io.WriteString(w, "<h1>"+q.Title+"</h1>")
+		return err
+	}
+	thumbnailer := NewThumbnailer(&fakeQuestionnaireQueryer{}, renderPage, renderer, cache)
+
+	thumbnail, err := thumbnailer.GenerateThumbnail(context.Background(), "PHQ9")
+	if err != nil {
+		t.Fatalf("GenerateThumbnail() error = %v", err)
+	}
+	if !bytes.HasPrefix(thumbnail, []byte{0xFF, 0xD8, 0xFF}) {
+		t.Fatalf("GenerateThumbnail() = % x..., want JPEG magic number", thumbnail[:min(len(thumbnail), 3)])
+	}
+	config, err := jpeg.DecodeConfig(bytes.NewReader(thumbnail))
+	if err != nil || config.Width != ThumbnailWidth || config.Height != ThumbnailHeight {
+		t.Errorf("thumbnail = %dx%d (err %v), want %dx%d", config.Width, config.Height, err, ThumbnailWidth, ThumbnailHeight)
+	}
+	if !strings.Contains(renderer.html, "PHQ-9") {
+		t.Errorf("rendered page = %q, want the print page of the questionnaire", renderer.html)
+	}
+
+	// 第二次从缓存读取，编辑后缓存失效需重新渲染
+	if _, err := thumbnailer.GenerateThumbnail(context.Background(), "PHQ9"); err != nil || renderer.calls != 1 {
+		t.Fatalf("cached GenerateThumbnail() error = %v, render calls = %d, want 1", err, renderer.calls)
+	}
+	if _, ok := cache["PHQ9-1.0.1-thumbnail"]; !ok {
+		t.Fatalf("cache keys = %v, want PHQ9-1.0.1-thumbnail", cache)
+	}
+	invalidateThumbnail(context.Background(), cache, "PHQ9", "1.0.1")
+	if _, err := thumbnailer.GenerateThumbnail(context.Background(), "PHQ9"); err != nil || renderer.calls != 2 {
+		t.Errorf("GenerateThumbnail() after invalidation error = %v, render calls = %d, want 2", err, renderer.calls)
+	}
+}
@@ -0,0 +1,93 @@
+package questionnaire
+
+import (
+	"context"
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Translator 问卷翻译服务
+// 译文按 问卷 + 语言 + 字段路径 保存，经审核通过后才会覆盖到编译后的问卷上
+type Translator struct {
+	qRepoMongo      port.QuestionnaireRepositoryMongo
+	translationRepo port.TranslationRepository
+}
+
+// NewTranslator 创建问卷翻译服务
+func NewTranslator(qRepoMongo port.QuestionnaireRepositoryMongo, translationRepo port.TranslationRepository) *Translator {
+	return &Translator{
+		qRepoMongo:      qRepoMongo,
+		translationRepo: translationRepo,
+	}
+}
+
+// 确保实现了接口
+var _ port.QuestionnaireTranslator = (*Translator)(nil)
+
+// SubmitTranslation 提交译文，字段路径必须指向问卷中存在的字段
+// 提交后译文为草稿状态并清除审核人，已审核的译文重新提交后需再次审核
+func (t *Translator) SubmitTranslation(ctx context.Context, code string, translation questionnaire.Translation) error {
+	if code == "" {
+		return errors.WithCode(errorCode.ErrQuestionnaireInvalidInput, "问卷编码不能为空")
+	}
+	if translation.Language == "" {
+		return errors.WithCode(errorCode.ErrTranslationInvalid, "译文语言不能为空")
+	}
+	if strings.TrimSpace(translation.TranslatedText) == "" {
+		return errors.WithCode(errorCode.ErrTranslationInvalid, "译文内容不能为空")
+	}
+
+	qDomain, err := t.qRepoMongo.FindByCode(ctx, code)
+	if err != nil {
+		return err
+	}
+	if err := qDomain.ValidateTranslationFieldPath(translation.FieldPath); err != nil {
+		return err
+	}
+
+	translation.Status = questionnaire.TranslationStatusDraft
+	translation.ApprovedBy = nil
+	if err := t.translationRepo.Save(ctx, code, &translation); err != nil {
+		return errors.WrapC(err, errorCode.ErrDatabase, "保存译文失败")
+	}
+	return nil
+}
+
+// ApproveTranslation 审核通过译文
+func (t *Translator) ApproveTranslation(ctx context.Context, code, language, fieldPath string, approverID uint64) error {
+	translation, err := t.translationRepo.FindOne(ctx, code, language, fieldPath)
+	if err != nil {
+		return errors.WrapC(err, errorCode.ErrDatabase, "查询译文失败")
+	}
+	if translation == nil {
+		return errors.WithCode(errorCode.ErrTranslationNotFound, "问卷 %s 不存在 %s 语言的 %s 译文", code, language, fieldPath)
+	}
+
+	translation.Approve(approverID)
+	if err := t.translationRepo.Save(ctx, code, translation); err != nil {
+		return errors.WrapC(err, errorCode.ErrDatabase, "保存译文失败")
+	}
+	return nil
+}
+
+// CompileTranslations 将指定语言已审核通过的译文覆盖到问卷上，返回翻译后的问卷（不保存）
+func (t *Translator) CompileTranslations(ctx context.Context, code, language string) (*questionnaire.Questionnaire, error) {
+	if language == "" {
+		return nil, errors.WithCode(errorCode.ErrTranslationInvalid, "译文语言不能为空")
+	}
+
+	qDomain, err := t.qRepoMongo.FindByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := t.translationRepo.FindByLanguage(ctx, code, language)
+	if err != nil {
+		return nil, errors.WrapC(err, errorCode.ErrDatabase, "查询译文失败")
+	}
+	return qDomain.Translate(translations), nil
+}
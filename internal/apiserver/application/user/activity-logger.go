@@ -0,0 +1,114 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// DefaultActivityQueueSize 活动记录队列的默认容量
+	DefaultActivityQueueSize = 1024
+	// DefaultActivityWriteTimeout 单条活动记录的写入超时时间
+	DefaultActivityWriteTimeout = 5 * time.Second
+)
+
+// ActivityLogger 用户活动记录器
+// 活动记录先放入带缓冲的队列，由后台协程写入存储，记录活动不阻塞业务请求；
+// 队列已满时丢弃该条记录并返回 ErrUserActivityQueueFull
+type ActivityLogger struct {
+	repo         port.UserActivityLogRepository
+	queue        chan *user.UserActivityLog
+	writeTimeout time.Duration
+	now          func() time.Time
+
+	mu     sync.RWMutex
+	closed bool
+	done   chan struct{}
+}
+
+// NewActivityLogger 创建用户活动记录器并启动后台写入协程，queueSize 不大于 0 时使用 DefaultActivityQueueSize
+func NewActivityLogger(repo port.UserActivityLogRepository, queueSize int) *ActivityLogger {
+	if queueSize <= 0 {
+		queueSize = DefaultActivityQueueSize
+	}
+	l := &ActivityLogger{
+		repo:         repo,
+		queue:        make(chan *user.UserActivityLog, queueSize),
+		writeTimeout: DefaultActivityWriteTimeout,
+		now:          time.Now,
+		done:         make(chan struct{}),
+	}
+	go l.run()
+	return l
+}
+
+// 确保实现了接口
+var _ port.ActivityLogger = (*ActivityLogger)(nil)
+
+// LogActivity 异步记录一次用户活动，客户端 User-Agent 从上下文读取
+func (l *ActivityLogger) LogActivity(ctx context.Context, userID uint64, action, detail, ip string) error {
+	if action == "" {
+		return errors.WithCode(code.ErrUserActivityInvalid, "活动类型不能为空")
+	}
+	activity := user.NewUserActivityLog(userID, action, detail, ip, user.UserAgentFromContext(ctx), l.now())
+
+	l.mu.RLock()
+	defer l.mu.RUnlock()
+	if l.closed {
+		return errors.WithCode(code.ErrUserActivityQueueFull, "活动记录器已关闭")
+	}
+	select {
+	case l.queue <- activity:
+		return nil
+	default:
+		return errors.WithCode(code.ErrUserActivityQueueFull, "活动记录队列已满，丢弃活动 %s，用户ID: %d", action, userID)
+	}
+}
+
+// ListByUserID 分页查询用户在时间范围内的活动记录
+func (l *ActivityLogger) ListByUserID(ctx context.Context, userID uint64, from, to time.Time, page, pageSize int) ([]*user.UserActivityLog, int64, error) {
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return nil, 0, errors.WithCode(code.ErrUserActivityInvalid, "开始时间必须早于结束时间")
+	}
+	logs, err := l.repo.FindByUserID(ctx, userID, from, to, page, pageSize)
+	if err != nil {
+		return nil, 0, errors.WrapC(err, code.ErrDatabase, "获取用户活动记录失败")
+	}
+	total, err := l.repo.CountByUserID(ctx, userID, from, to)
+	if err != nil {
+		return nil, 0, errors.WrapC(err, code.ErrDatabase, "获取用户活动记录总数失败")
+	}
+	return logs, total, nil
+}
+
+// Close 停止接收新的活动记录，并等待队列中已有的记录写入完成
+func (l *ActivityLogger) Close() {
+	l.mu.Lock()
+	if l.closed {
+		l.mu.Unlock()
+		return
+	}
+	l.closed = true
+	close(l.queue)
+	l.mu.Unlock()
+	<-l.done
+}
+
+// run 后台写入协程，写入失败只记录日志
+func (l *ActivityLogger) run() {
+	defer close(l.done)
+	for activity := range l.queue {
+		ctx, cancel := context.WithTimeout(context.Background(), l.writeTimeout)
+		if err := l.repo.Create(ctx, activity); err != nil {
+			log.Errorf("保存用户活动记录失败，用户ID: %d, 活动: %s, 错误: %v", activity.UserID, activity.Action, err)
+		}
+		cancel()
+	}
+}
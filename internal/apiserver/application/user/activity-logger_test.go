@@ -0,0 +1,72 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// blockingActivityRepo 写入前等待 release 关闭，用于让队列堆积
+type blockingActivityRepo struct {
+	release chan struct{}
+
+	mu   sync.Mutex
+	logs []*user.UserActivityLog
+}
+
+func (r *blockingActivityRepo) Create(_ context.Context, log *user.UserActivityLog) error {
+	<-r.release
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.logs = append(r.logs, log)
+	return nil
+}
+
+func (r *blockingActivityRepo) FindByUserID(context.Context, uint64, time.Time, time.Time, int, int) ([]*user.UserActivityLog, error) {
+	return nil, nil
+}
+
+func (r *blockingActivityRepo) CountByUserID(context.Context, uint64, time.Time, time.Time) (int64, error) {
+	return 0, nil
+}
+
+func TestActivityLogger_QueueFullAndDrainOnClose(t *testing.T) {
+	repo := &blockingActivityRepo{release: make(chan struct{})}
+	logger := NewActivityLogger(repo, 2)
+
+	ctx := user.WithUserAgent(context.Background(), "test-agent")
+	// 后台协程取出第一条后阻塞在写入，队列还能容纳两条
+	var accepted int
+	for i := 0; i < 10; i++ {
+		err := logger.LogActivity(ctx, 7, user.ActivityLoginFailed, "wrong password", "10.0.0.1")
+		if err == nil {
+			accepted++
+			continue
+		}
+		if !errors.IsCode(err, code.ErrUserActivityQueueFull) {
+			t.Fatalf("LogActivity() error = %v, want ErrUserActivityQueueFull", err)
+		}
+	}
+	if accepted < 2 || accepted > 3 {
+		t.Fatalf("accepted = %d, want the queue capacity plus at most one in flight", accepted)
+	}
+
+	close(repo.release)
+	logger.Close()
+
+	if len(repo.logs) != accepted {
+		t.Fatalf("written = %d, want all %d accepted activities after Close", len(repo.logs), accepted)
+	}
+	got := repo.logs[0]
+	if got.UserID != 7 || got.UserAgent != "test-agent" || got.IPAddress != "10.0.0.1" || got.Severity != user.SeverityWarning {
+		t.Errorf("written activity = %+v", got)
+	}
+	if err := logger.LogActivity(ctx, 7, user.ActivityLogout, "", ""); err == nil {
+		t.Error("LogActivity() after Close error = nil, want error")
+	}
+}
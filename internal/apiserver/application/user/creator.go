@@ -20,8 +20,12 @@ func NewUserCreator(userRepo port.UserRepository) port.UserCreator {
 	return &UserCreator{userRepo: userRepo}
 }
 
-// CreateUser 创建用户
+// CreateUser 创建用户，密码须满足密码强度要求
 func (c *UserCreator) CreateUser(ctx context.Context, username, password, nickname, email, phone, introduction string) (*user.User, error) {
+	if err := user.ValidatePasswordStrength(password); err != nil {
+		return nil, err
+	}
+
 	// 唯一性检查
 	if c.usernameUnique(ctx, username) {
 		return nil, errors.WithCode(code.ErrUserAlreadyExists, "username already exists")
@@ -5,17 +5,22 @@ import (
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 type PasswordChanger struct {
-	userRepo port.UserRepository
+	userRepo       port.UserRepository
+	sessionManager port.SessionManager
+	activityLogger port.ActivityLogger
 }
 
-func NewPasswordChanger(userRepo port.UserRepository) port.PasswordChanger {
-	return &PasswordChanger{userRepo: userRepo}
+// NewPasswordChanger 创建密码管理器
+// sessionManager 为空时修改密码不撤销登录会话，activityLogger 为空时不记录修改密码的用户活动
+func NewPasswordChanger(userRepo port.UserRepository, sessionManager port.SessionManager, activityLogger port.ActivityLogger) port.PasswordChanger {
+	return &PasswordChanger{userRepo: userRepo, sessionManager: sessionManager, activityLogger: activityLogger}
 }
 
-// ChangePassword 修改密码
+// ChangePassword 修改密码，新密码强度不足时返回 ErrUserPasswordWeak，成功后撤销用户的全部登录会话
 func (p *PasswordChanger) ChangePassword(ctx context.Context, id uint64, oldPassword, newPassword string) error {
 	userObj, err := p.userRepo.FindByID(ctx, user.NewUserID(id))
 	if err != nil {
@@ -27,7 +32,22 @@ func (p *PasswordChanger) ChangePassword(ctx context.Context, id uint64, oldPass
 	//     return errors.New("old password is incorrect")
 	// }
 
-	userObj.ChangePassword(newPassword)
+	if err := userObj.ChangePassword(newPassword); err != nil {
+		return err
+	}
+
+	if err := p.userRepo.Update(ctx, userObj); err != nil {
+		return err
+	}
+
+	if p.activityLogger != nil {
+		if err := p.activityLogger.LogActivity(ctx, id, user.ActivityPasswordChange, "", ""); err != nil {
+			log.Warnf("记录修改密码活动失败，用户ID: %d, 错误: %v", id, err)
+		}
+	}
 
-	return p.userRepo.Update(ctx, userObj)
+	if p.sessionManager != nil {
+		return p.sessionManager.RevokeAllSessions(ctx, id)
+	}
+	return nil
 }
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// memUserRepo 只保存一个用户的内存用户仓储，记录更新次数
+type memUserRepo struct {
+	port.UserRepository
+	user    *user.User
+	updates int
+}
+
+func (r *memUserRepo) FindByID(ctx context.Context, id user.UserID) (*user.User, error) {
+	return r.user, nil
+}
+
+func (r *memUserRepo) Update(ctx context.Context, u *user.User) error {
+	r.updates++
+	return nil
+}
+
+func TestPasswordChanger_RejectsWeakPassword(t *testing.T) {
+	tests := []struct {
+		name        string
+		newPassword string
+		wantErr     bool
+	}{
+		{name: "too short", newPassword: "abc123", wantErr: true},
+		{name: "letters only", newPassword: "abcdefgh", wantErr: true},
+		{name: "digits only", newPassword: "12345678", wantErr: true},
+		{name: "strong", newPassword: "secret123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &memUserRepo{user: user.NewUserBuilder().WithID(user.NewUserID(7)).WithUsername("alice").Build()}
+			changer := NewPasswordChanger(repo, nil, nil)
+
+			err := changer.ChangePassword(context.Background(), 7, "", tt.newPassword)
+			if !tt.wantErr {
+				if err != nil || repo.updates != 1 {
+					t.Fatalf("ChangePassword() error = %v, updates = %d, want success", err, repo.updates)
+				}
+				if !repo.user.ValidatePassword(tt.newPassword) {
+					t.Error("stored password does not match the new password")
+				}
+				return
+			}
+			if !errors.IsCode(err, code.ErrUserPasswordWeak) {
+				t.Errorf("ChangePassword() error = %v, want ErrUserPasswordWeak", err)
+			}
+			if repo.updates != 0 {
+				t.Errorf("updates = %d, want weak password not saved", repo.updates)
+			}
+		})
+	}
+}
+
+func TestUserCreator_RejectsWeakPassword(t *testing.T) {
+	creator := NewUserCreator(&memUserRepo{})
+
+	_, err := creator.CreateUser(context.Background(), "alice", "123456", "Alice", "alice@example.com", "13800000000", "")
+	if !errors.IsCode(err, code.ErrUserPasswordWeak) {
+		t.Errorf("CreateUser() error = %v, want ErrUserPasswordWeak", err)
+	}
+}
@@ -0,0 +1,45 @@
+package user
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// PreferenceManager 用户偏好设置管理器
+type PreferenceManager struct {
+	preferenceRepo port.UserPreferenceRepository
+}
+
+// NewPreferenceManager 创建用户偏好设置管理器
+func NewPreferenceManager(preferenceRepo port.UserPreferenceRepository) port.PreferenceManager {
+	return &PreferenceManager{preferenceRepo: preferenceRepo}
+}
+
+// GetPreferences 获取用户偏好设置，用户尚未保存过时返回默认设置
+func (m *PreferenceManager) GetPreferences(ctx context.Context, userID uint64) (*user.UserPreference, error) {
+	pref, err := m.preferenceRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "获取用户偏好设置失败")
+	}
+	if pref == nil {
+		return user.NewDefaultUserPreference(userID), nil
+	}
+	return pref, nil
+}
+
+// UpdatePreferences 更新用户偏好设置，补全缺省值并截断最近访问记录后校验，主题无效时返回 ErrUserPreferenceInvalid
+func (m *PreferenceManager) UpdatePreferences(ctx context.Context, pref *user.UserPreference) error {
+	pref.Normalize()
+	if err := pref.Validate(); err != nil {
+		return err
+	}
+
+	if err := m.preferenceRepo.Upsert(ctx, pref); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "保存用户偏好设置失败")
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// memPreferenceRepo 以用户ID为键的内存偏好设置仓储
+type memPreferenceRepo struct {
+	port.UserPreferenceRepository
+	prefs map[uint64]*user.UserPreference
+}
+
+func (r *memPreferenceRepo) FindByUserID(ctx context.Context, userID uint64) (*user.UserPreference, error) {
+	return r.prefs[userID], nil
+}
+
+func (r *memPreferenceRepo) Upsert(ctx context.Context, pref *user.UserPreference) error {
+	r.prefs[pref.UserID] = pref
+	return nil
+}
+
+func TestPreferenceManager_DefaultsForNewUser(t *testing.T) {
+	manager := NewPreferenceManager(&memPreferenceRepo{prefs: map[uint64]*user.UserPreference{}})
+
+	pref, err := manager.GetPreferences(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetPreferences() error = %v", err)
+	}
+	if want := user.NewDefaultUserPreference(7); !reflect.DeepEqual(pref, want) {
+		t.Errorf("GetPreferences() = %+v, want defaults %+v", pref, want)
+	}
+}
+
+func TestPreferenceManager_UpdatePreferences(t *testing.T) {
+	repo := &memPreferenceRepo{prefs: map[uint64]*user.UserPreference{}}
+	manager := NewPreferenceManager(repo)
+
+	visited := make([]string, 0, user.MaxLastVisitedQuestionnaires+2)
+	for i := 1; i <= user.MaxLastVisitedQuestionnaires+2; i++ {
+		visited = append(visited, fmt.Sprintf("Q%d", i))
+	}
+	if err := manager.UpdatePreferences(context.Background(), &user.UserPreference{
+		UserID:                    7,
+		Theme:                     user.ThemeDark,
+		LastVisitedQuestionnaires: visited,
+	}); err != nil {
+		t.Fatalf("UpdatePreferences() error = %v", err)
+	}
+
+	pref, err := manager.GetPreferences(context.Background(), 7)
+	if err != nil {
+		t.Fatalf("GetPreferences() error = %v", err)
+	}
+	// 缺省的语言补全为默认值，最近访问记录只保留最近的 10 条
+	if pref.Language != user.DefaultLanguage || pref.Theme != user.ThemeDark {
+		t.Errorf("preference = %+v, want default language and dark theme", pref)
+	}
+	if got := pref.LastVisitedQuestionnaires; !reflect.DeepEqual(got, visited[2:]) {
+		t.Errorf("LastVisitedQuestionnaires = %v, want the latest %d: %v", got, user.MaxLastVisitedQuestionnaires, visited[2:])
+	}
+
+	err = manager.UpdatePreferences(context.Background(), &user.UserPreference{UserID: 7, Theme: "blue"})
+	if !errors.IsCode(err, code.ErrUserPreferenceInvalid) {
+		t.Fatalf("UpdatePreferences() with unknown theme error = %v, want ErrUserPreferenceInvalid", err)
+	}
+	if repo.prefs[7].Theme != user.ThemeDark {
+		t.Errorf("stored theme = %q, want the invalid update left unsaved", repo.prefs[7].Theme)
+	}
+}
@@ -0,0 +1,151 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// SessionManager 用户登录会话管理器
+type SessionManager struct {
+	sessionRepo  port.UserSessionRepository
+	maxSessions  int
+	roleSessions map[string]int
+	now          func() time.Time
+}
+
+// NewSessionManager 创建用户登录会话管理器
+// maxSessions 为每个用户的并发会话上限，roleSessions 按角色覆盖该上限，上限小于等于 0 表示不限制
+func NewSessionManager(sessionRepo port.UserSessionRepository, maxSessions int, roleSessions map[string]int) port.SessionManager {
+	return &SessionManager{
+		sessionRepo:  sessionRepo,
+		maxSessions:  maxSessions,
+		roleSessions: roleSessions,
+		now:          time.Now,
+	}
+}
+
+// CreateSession 登录成功后创建会话，达到并发会话上限时撤销最早的会话，rememberFor 大于 0 时开启“记住我”
+func (m *SessionManager) CreateSession(ctx context.Context, userID uint64, roles []string, device, userAgent, ip string, rememberFor time.Duration) (*user.Session, error) {
+	if limit := m.sessionLimit(roles); limit > 0 {
+		if err := m.evictOldestSessions(ctx, userID, limit-1); err != nil {
+			return nil, err
+		}
+	}
+
+	now := m.now()
+	session := user.NewSession(userID, device, userAgent, ip, now)
+	if rememberFor > 0 {
+		session.RememberUntil(now.Add(rememberFor))
+	}
+	if err := m.sessionRepo.Create(ctx, session); err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "保存登录会话失败")
+	}
+	return session, nil
+}
+
+// sessionLimit 返回用户的并发会话上限，用户的角色有单独配置时取其中最大的上限
+func (m *SessionManager) sessionLimit(roles []string) int {
+	limit, matched := 0, false
+	for _, role := range roles {
+		roleLimit, ok := m.roleSessions[role]
+		if !ok {
+			continue
+		}
+		if roleLimit <= 0 {
+			return 0
+		}
+		limit = max(limit, roleLimit)
+		matched = true
+	}
+	if matched {
+		return limit
+	}
+	return m.maxSessions
+}
+
+// evictOldestSessions 撤销最早创建的会话，使用户保留的会话数不超过 keep
+func (m *SessionManager) evictOldestSessions(ctx context.Context, userID uint64, keep int) error {
+	sessions, err := m.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "获取登录会话失败")
+	}
+
+	// 会话按创建时间倒序，超出部分即为最早的会话
+	for i := keep; i < len(sessions); i++ {
+		if err := m.sessionRepo.Delete(ctx, sessions[i]); err != nil {
+			return errors.WrapC(err, code.ErrDatabase, "撤销登录会话失败")
+		}
+	}
+	return nil
+}
+
+// ListSessions 列出用户的有效会话
+func (m *SessionManager) ListSessions(ctx context.Context, userID uint64) ([]*user.Session, error) {
+	sessions, err := m.sessionRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrDatabase, "获取登录会话失败")
+	}
+	return sessions, nil
+}
+
+// RevokeSession 撤销用户的指定会话，会话关联的令牌随之失效
+func (m *SessionManager) RevokeSession(ctx context.Context, userID uint64, sessionID string) error {
+	session, err := m.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "获取登录会话失败")
+	}
+	// 不允许撤销其他用户的会话
+	if session == nil || session.UserID != userID {
+		return errors.WithCode(code.ErrUserSessionNotFound, "登录会话不存在: %s", sessionID)
+	}
+
+	if err := m.sessionRepo.Delete(ctx, session); err != nil {
+		return errors.WrapC(err, code.ErrDatabase, "撤销登录会话失败")
+	}
+	return nil
+}
+
+// RevokeAllSessions 撤销用户的全部会话
+func (m *SessionManager) RevokeAllSessions(ctx context.Context, userID uint64) error {
+	return m.evictOldestSessions(ctx, userID, 0)
+}
+
+// IsSessionActive 判断会话是否有效，不存在或已过期的会话视为无效
+func (m *SessionManager) IsSessionActive(ctx context.Context, sessionID string) (bool, error) {
+	session, err := m.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return false, errors.WrapC(err, code.ErrDatabase, "获取登录会话失败")
+	}
+	return session != nil, nil
+}
+
+// TouchSession 记录会话活跃并延长有效期，返回会话是否有效
+func (m *SessionManager) TouchSession(ctx context.Context, sessionID string) (bool, error) {
+	session, err := m.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return false, errors.WrapC(err, code.ErrDatabase, "获取登录会话失败")
+	}
+	if session == nil {
+		return false, nil
+	}
+
+	session.Touch(m.now())
+	if err := m.sessionRepo.Update(ctx, session); err != nil {
+		return false, errors.WrapC(err, code.ErrDatabase, "更新登录会话失败")
+	}
+	return true, nil
+}
+
+// CanRefresh 判断会话的长期刷新令牌是否可用，会话撤销或过期后不可用
+func (m *SessionManager) CanRefresh(ctx context.Context, sessionID string) (bool, error) {
+	session, err := m.sessionRepo.FindByID(ctx, sessionID)
+	if err != nil {
+		return false, errors.WrapC(err, code.ErrDatabase, "获取登录会话失败")
+	}
+	return session != nil && session.CanRefresh(m.now()), nil
+}
@@ -1,7 +1,6 @@
 package apiserver
 
 import (
-	"context"
 	"encoding/base64"
 	"net/http"
 	"strings"
@@ -9,68 +8,120 @@ import (
 
 	jwt "github.com/appleboy/gin-jwt/v2"
 	"github.com/gin-gonic/gin"
-	"github.com/spf13/viper"
+	gojwt "github.com/golang-jwt/jwt/v4"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/container"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
 	authMiddleware "github.com/yshujie/questionnaire-scale/internal/pkg/middleware/auth"
 	authStrategys "github.com/yshujie/questionnaire-scale/internal/pkg/middleware/auth/strategys"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 // 使用已存在的常量 APIServerAudience 和 APIServerIssuer
 
+const (
+	// loginValidationErrorKey 登录参数校验错误在上下文中的键
+	loginValidationErrorKey = "login_validation_error"
+	// jwtErrorKey gin-jwt 认证失败的原始错误在上下文中的键
+	jwtErrorKey = "jwt_error"
+)
+
 // LoginInfo 登录信息
 type LoginInfo struct {
-	Username string `form:"username" json:"username" binding:"required"`
-	Password string `form:"password" json:"password" binding:"required"`
+	Username   string `form:"username" json:"username" binding:"required"`
+	Password   string `form:"password" json:"password" binding:"required"`
+	Device     string `form:"device" json:"device"`
+	RememberMe bool   `form:"remember_me" json:"remember_me"`
+	// CaptchaToken 同一 IP 连续登录失败达到阈值后需要提交的验证码令牌
+	CaptchaToken string `form:"captcha_token" json:"captcha_token"`
 }
 
 // Auth 认证
 type Auth struct {
-	container     *container.Container
-	authenticator port.Authenticator
+	container      *container.Container
+	authenticator  port.Authenticator
+	otpManager     port.OTPManager
+	sessionManager port.SessionManager
+	loginAuditor   port.LoginAuditor
+	captchaGuard   port.LoginCaptchaGuard
+	userQueryer    port.UserQueryer
+	activityLogger port.ActivityLogger
+	jwtOptions     *genericoptions.JwtOptions
 }
 
 // NewAuth 创建认证
-func NewAuth(container *container.Container) *Auth {
+func NewAuth(container *container.Container, jwtOptions *genericoptions.JwtOptions) *Auth {
 	authenticator := container.AuthModule.Authenticator
 	return &Auth{
-		container:     container,
-		authenticator: authenticator,
+		container:      container,
+		authenticator:  authenticator,
+		otpManager:     container.AuthModule.OTPManager,
+		sessionManager: container.UserModule.SessionManager,
+		loginAuditor:   container.AuthModule.LoginAuditor,
+		captchaGuard:   container.AuthModule.CaptchaGuard,
+		userQueryer:    container.UserModule.UserQueryer,
+		activityLogger: container.UserModule.ActivityLogger,
+		jwtOptions:     jwtOptions,
 	}
 }
 
 // NewBasicAuth 创建Basic认证策略
+// Basic 认证与 JWT 登录共用验证码守卫，同一 IP 连续认证失败后需要在 X-Captcha-Token 头中携带验证码令牌
 func (cfg *Auth) NewBasicAuth() authStrategys.BasicStrategy {
-	return authStrategys.NewBasicStrategy(func(username string, password string) bool {
-		ctx := context.Background()
+	return authStrategys.NewBasicStrategy(func(c *gin.Context, username string, password string) error {
+		if err := cfg.checkCaptcha(c, ""); err != nil {
+			cfg.recordLogin(c, username, user.LoginMethodBasic, err)
+			return err
+		}
+
 		// 调用身份认证起验证身份
-		_, err := cfg.authenticator.Authenticate(ctx, username, password)
+		userObj, err := cfg.authenticator.Authenticate(c.Request.Context(), username, password)
+		cfg.recordCaptchaResult(c, err)
 		if err != nil {
 			log.Errorf("Basic auth failed for user %s: %v", username, err)
-			return false
+			cfg.recordLogin(c, username, user.LoginMethodBasic, err)
+			// 不区分用户不存在和密码错误，避免泄露用户是否存在
+			return errors.WithCode(code.ErrSignatureInvalid, "basic auth failed")
+		}
+
+		// 开启二次验证的用户必须通过 JWT 登录流程
+		if userObj.OTPEnabled() {
+			log.Errorf("Basic auth rejected for user %s: OTP is enabled", username)
+			cfg.recordLogin(c, username, user.LoginMethodBasic, errors.New("otp is enabled, basic auth is not allowed"))
+			return errors.WithCode(code.ErrSignatureInvalid, "basic auth failed")
 		}
 
 		log.Infof("Basic auth successful for user: %s", username)
-		return true
+		cfg.recordLogin(c, username, user.LoginMethodBasic, nil)
+
+		// 将可管理的问卷范围设置到上下文中，供 ScopeGuard 使用
+		c.Set(middleware.ScopeKey, userObj.Scopes())
+		return nil
 	})
 }
 
 // NewJWTAuth 创建JWT认证策略
 func (cfg *Auth) NewJWTAuth() authStrategys.JWTStrategy {
-	ginjwt, _ := jwt.New(&jwt.GinJWTMiddleware{
-		Realm:            viper.GetString("jwt.realm"),
+	var ginjwt *jwt.GinJWTMiddleware
+	ginjwt, _ = jwt.New(&jwt.GinJWTMiddleware{
+		Realm:            cfg.jwtOptions.Realm,
 		SigningAlgorithm: "HS256",
-		Key:              []byte(viper.GetString("jwt.key")),
-		Timeout:          viper.GetDuration("jwt.timeout"),
-		MaxRefresh:       viper.GetDuration("jwt.max-refresh"),
+		Key:              []byte(cfg.jwtOptions.Key),
+		Timeout:          cfg.jwtOptions.Timeout,
+		TimeoutFunc:      cfg.createTimeoutFunc(cfg.jwtOptions.Timeout),
+		MaxRefresh:       cfg.jwtOptions.MaxRefresh,
 		Authenticator:    cfg.createAuthenticator(),
 		LoginResponse:    cfg.createLoginResponse(),
 		LogoutResponse: func(c *gin.Context, code int) {
-			c.JSON(http.StatusOK, gin.H{"message": "Successfully logged out"})
+			cfg.recordLogout(c, ginjwt)
+			envelope.WriteSuccess(c, "Successfully logged out", nil)
 		},
 		RefreshResponse: cfg.createRefreshResponse(),
 		PayloadFunc:     cfg.createPayloadFunc(),
@@ -80,11 +131,28 @@ func (cfg *Auth) NewJWTAuth() authStrategys.JWTStrategy {
 		},
 		IdentityKey:  middleware.UsernameKey,
 		Authorizator: cfg.createAuthorizator(),
-		Unauthorized: func(c *gin.Context, code int, message string) {
-			c.JSON(code, gin.H{
-				"code":    code,
-				"message": message,
-			})
+		Unauthorized: func(c *gin.Context, status int, message string) {
+			// 登录参数校验失败时返回字段级错误
+			if v, ok := c.Get(loginValidationErrorKey); ok {
+				resp := v.(*validation.ErrorResponse)
+				envelope.WriteErrorWithData(c, errors.WithCode(resp.Code, "%s", resp.Message), gin.H{"errors": resp.Errors})
+				return
+			}
+			// 连续登录失败后需要验证码
+			if err, ok := c.Get(captchaErrorKey); ok {
+				writeCaptchaError(c, err.(error))
+				return
+			}
+			// 令牌关联的会话已被撤销
+			if c.GetBool(sessionRevokedKey) {
+				envelope.WriteError(c, errors.WithCode(code.ErrTokenInvalid, "session has been revoked"))
+				return
+			}
+			envelope.WriteError(c, jwtError(c, status, message))
+		},
+		HTTPStatusMessageFunc: func(e error, c *gin.Context) string {
+			c.Set(jwtErrorKey, e)
+			return e.Error()
 		},
 		TokenLookup:   "header: Authorization, query: token, cookie: jwt",
 		TokenHeadName: "Bearer",
@@ -116,23 +184,46 @@ func (cfg *Auth) createAuthenticator() func(c *gin.Context) (interface{}, error)
 			login, err = cfg.parseWithBody(c)
 		}
 		if err != nil {
+			cfg.recordLogin(c, login.Username, user.LoginMethodJWT, err)
+			return "", jwt.ErrFailedAuthentication
+		}
+
+		// 同一 IP 连续登录失败达到阈值后需要验证码
+		if err := cfg.checkCaptcha(c, login.CaptchaToken); err != nil {
+			cfg.recordLogin(c, login.Username, user.LoginMethodJWT, err)
 			return "", jwt.ErrFailedAuthentication
 		}
 
 		// 使用AuthService进行认证 - 只验证用户名密码，不生成token
 		ctx := c.Request.Context()
 		userObj, err := cfg.authenticator.Authenticate(ctx, login.Username, login.Password)
+		cfg.recordCaptchaResult(c, err)
 		if err != nil {
 			log.Errorf("Authentication failed for user %s: %v", login.Username, err)
+			cfg.recordLogin(c, login.Username, user.LoginMethodJWT, err)
 			return "", jwt.ErrFailedAuthentication
 		}
 
 		log.Infof("Authentication successful for user: %s", userObj.Username())
 
+		// 开启二次验证的用户先签发中间令牌，通过二次验证后再创建会话并记录登录
+		if userObj.OTPEnabled() {
+			c.Set("user", userObj)
+			return userObj, nil
+		}
+
+		identity, err := cfg.newLoginIdentity(c, userObj, login.Device, login.RememberMe)
+		if err != nil {
+			log.Errorf("Create session failed for user %s: %v", userObj.Username(), err)
+			cfg.recordLogin(c, login.Username, user.LoginMethodJWT, err)
+			return "", jwt.ErrFailedAuthentication
+		}
+		cfg.recordLogin(c, login.Username, user.LoginMethodJWT, nil)
+
 		// 将用户信息设置到context中，供LoginResponse使用
-		c.Set("user", userObj)
+		c.Set("user", identity)
 
-		return userObj, nil
+		return identity, nil
 	}
 }
 
@@ -167,6 +258,7 @@ func (cfg *Auth) parseWithBody(c *gin.Context) (LoginInfo, error) {
 	var login LoginInfo
 	if err := c.ShouldBindJSON(&login); err != nil {
 		log.Errorf("Failed to parse login parameters: %v", err)
+		c.Set(loginValidationErrorKey, validation.TranslateBindingError(err))
 		return LoginInfo{}, jwt.ErrFailedAuthentication
 	}
 
@@ -179,8 +271,18 @@ func (cfg *Auth) createLoginResponse() func(c *gin.Context, code int, token stri
 		// 从context中获取用户信息
 		userInterface, exists := c.Get("user")
 		var userData interface{}
+		var otpPending bool
+		var identity loginIdentity
 		if exists {
-			if userObj, ok := userInterface.(*user.User); ok {
+			var userObj *user.User
+			switch v := userInterface.(type) {
+			case *user.User:
+				// 开启二次验证的用户此时拿到的是中间令牌
+				userObj, otpPending = v, v.OTPEnabled()
+			case loginIdentity:
+				userObj, identity = v.User, v
+			}
+			if userObj != nil {
 				// 转换领域对象为响应格式
 				userData = gin.H{
 					"id":           userObj.ID().Value(),
@@ -197,21 +299,66 @@ func (cfg *Auth) createLoginResponse() func(c *gin.Context, code int, token stri
 			}
 		}
 
-		c.JSON(http.StatusOK, gin.H{
-			"code":    code,
-			"token":   token,
-			"expire":  expire.Format(time.RFC3339),
-			"user":    userData,
-			"message": "Login successful",
-		})
+		if otpPending {
+			envelope.WriteSuccess(c, "OTP code required", gin.H{
+				"token":        token,
+				"expire":       expire.Format(time.RFC3339),
+				"otp_required": true,
+			})
+			return
+		}
+
+		data := gin.H{
+			"token":  token,
+			"expire": expire.Format(time.RFC3339),
+			"user":   userData,
+		}
+		// 开启“记住我”时返回长期刷新令牌
+		if identity.refreshToken != "" {
+			data["refresh_token"] = identity.refreshToken
+			data["refresh_expire"] = identity.refreshExpire.Format(time.RFC3339)
+		}
+		envelope.WriteSuccess(c, "Login successful", data)
+	}
+}
+
+// jwtError 将 gin-jwt 认证失败的原因转换为带错误码的错误
+// 认证器返回的错误已带错误码时原样返回，gin-jwt 内置错误按类型映射，其余按 HTTP 状态码映射
+func jwtError(c *gin.Context, status int, message string) error {
+	var err error
+	if v, ok := c.Get(jwtErrorKey); ok {
+		err, _ = v.(error)
+	}
+	if envelope.HasCode(err) {
+		return err
+	}
+
+	switch {
+	case errors.Is(err, jwt.ErrExpiredToken):
+		return errors.WithCode(code.ErrExpired, "%s", message)
+	case errors.Is(err, jwt.ErrFailedAuthentication):
+		return errors.WithCode(code.ErrPasswordIncorrect, "%s", message)
+	case errors.Is(err, jwt.ErrEmptyAuthHeader), errors.Is(err, jwt.ErrEmptyQueryToken),
+		errors.Is(err, jwt.ErrEmptyCookieToken), errors.Is(err, jwt.ErrEmptyParamToken):
+		return errors.WithCode(code.ErrMissingHeader, "%s", message)
+	case errors.Is(err, jwt.ErrInvalidAuthHeader):
+		return errors.WithCode(code.ErrInvalidAuthHeader, "%s", message)
+	}
+
+	switch status {
+	case http.StatusForbidden:
+		return errors.WithCode(code.ErrPermissionDenied, "%s", message)
+	case http.StatusUnauthorized, http.StatusBadRequest:
+		return errors.WithCode(code.ErrTokenInvalid, "%s", message)
+	default:
+		return errors.WithCode(code.ErrInternalServerError, "%s", message)
 	}
 }
 
 // createRefreshResponse 创建刷新响应
 func (cfg *Auth) createRefreshResponse() func(c *gin.Context, code int, token string, expire time.Time) {
 	return func(c *gin.Context, code int, token string, expire time.Time) {
-		c.JSON(http.StatusOK, gin.H{
-			"code":   code,
+		envelope.WriteSuccess(c, "Token refreshed", gin.H{
 			"token":  token,
 			"expire": expire.Format(time.RFC3339),
 		})
@@ -228,26 +375,89 @@ func (cfg *Auth) createPayloadFunc() func(data interface{}) jwt.MapClaims {
 			"aud": APIServerAudience,
 		}
 
-		if userObj, ok := data.(*user.User); ok {
+		var userObj *user.User
+		switch v := data.(type) {
+		case *user.User:
+			userObj = v
+			// 开启二次验证的用户先签发中间令牌
+			if userObj.OTPEnabled() {
+				claims[otpRequiredClaim] = true
+			}
+		case loginIdentity:
+			userObj = v.User
+			if v.sessionID != "" {
+				claims[sessionIDClaim] = v.sessionID
+			}
+		case refreshedIdentity:
+			// 长期刷新令牌换取访问令牌，沿用其中的用户身份
+			for key, value := range v {
+				claims[key] = value
+			}
+		}
+
+		if userObj != nil {
 			claims[jwt.IdentityKey] = userObj.Username()
 			claims["sub"] = userObj.Username()
 			claims["user_id"] = userObj.ID().Value()
 			claims["nickname"] = userObj.Nickname()
+			claims[middleware.ScopeKey] = userObj.Scopes()
 		}
 
 		return claims
 	}
 }
 
+// createTimeoutFunc 创建令牌有效期函数，二次验证中间令牌只有短暂的有效期
+func (cfg *Auth) createTimeoutFunc(timeout time.Duration) func(data interface{}) time.Duration {
+	if timeout == 0 {
+		timeout = time.Hour
+	}
+	return func(data interface{}) time.Duration {
+		if claims, ok := data.(gojwt.MapClaims); ok && otpRequired(claims) {
+			return otpChallengeTimeout
+		}
+		return timeout
+	}
+}
+
 // createAuthorizator 创建授权器
 func (cfg *Auth) createAuthorizator() func(data interface{}, c *gin.Context) bool {
 	return func(data interface{}, c *gin.Context) bool {
 		if username, ok := data.(string); ok {
+			claims := jwt.ExtractClaims(c)
+
+			// 二次验证中间令牌不能访问业务接口
+			if otpRequired(claims) {
+				log.L(c).Warnf("User `%s` has not passed OTP validation.", username)
+				return false
+			}
+
+			// 长期刷新令牌不能访问业务接口
+			if isRefreshToken(claims) {
+				log.L(c).Warnf("User `%s` used a refresh token as access token.", username)
+				return false
+			}
+
+			// 会话已撤销的令牌不再有效
+			if !cfg.sessionActive(c, claims) {
+				log.L(c).Warnf("Session of user `%s` has been revoked.", username)
+				c.Set(sessionRevokedKey, true)
+				return false
+			}
+
 			log.L(c).Infof("User `%s` is authorized.", username)
 
 			// 将用户名设置到上下文中
 			c.Set(middleware.UsernameKey, username)
 
+			// 将可管理的问卷范围设置到上下文中，供 ScopeGuard 使用
+			c.Set(middleware.ScopeKey, claims[middleware.ScopeKey])
+
+			// 将当前会话ID设置到上下文中，供会话列表标记当前设备
+			if sessionID, ok := claims[sessionIDClaim].(string); ok {
+				c.Set(middleware.SessionIDKey, sessionID)
+			}
+
 			// 可以在这里添加更多的授权逻辑
 			// 例如：检查用户权限、角色等
 
@@ -0,0 +1,72 @@
+package apiserver
+
+import (
+	"time"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// recordLogin 记录一次认证尝试，authErr 为空表示认证成功
+// 审计记录写入失败只记录日志，不影响认证结果；
+// Basic 认证每个请求都会认证一次，只记录审计，不记录为用户活动
+func (cfg *Auth) recordLogin(c *gin.Context, username string, method user.LoginMethod, authErr error) {
+	outcome, reason := user.LoginOutcomeSuccess, ""
+	if authErr != nil {
+		outcome, reason = user.LoginOutcomeFailure, authErr.Error()
+	}
+
+	if cfg.loginAuditor != nil {
+		audit := user.NewLoginAudit(username, c.ClientIP(), c.Request.UserAgent(), method, outcome, reason, time.Now())
+		if err := cfg.loginAuditor.Record(c.Request.Context(), audit); err != nil {
+			log.Errorf("Record login audit failed for user %s: %v", username, err)
+		}
+	}
+
+	if method == user.LoginMethodJWT {
+		action, detail := user.ActivityLogin, ""
+		if authErr != nil {
+			// 用户名不存在时用户ID记为 0，在详情中保留尝试登录的用户名
+			action, detail = user.ActivityLoginFailed, "username "+username+": "+reason
+		}
+		cfg.recordActivity(c, username, action, detail)
+	}
+}
+
+// recordLogout 记录退出登录，令牌无效时无法确定用户，不记录
+func (cfg *Auth) recordLogout(c *gin.Context, ginjwt *jwt.GinJWTMiddleware) {
+	if ginjwt == nil {
+		return
+	}
+	claims, err := ginjwt.GetClaimsFromJWT(c)
+	if err != nil {
+		return
+	}
+	username, _ := claims[jwt.IdentityKey].(string)
+	if username == "" {
+		return
+	}
+	cfg.recordActivity(c, username, user.ActivityLogout, "")
+}
+
+// recordActivity 异步记录用户活动，用户不存在时用户ID记为 0
+func (cfg *Auth) recordActivity(c *gin.Context, username, action, detail string) {
+	if cfg.activityLogger == nil {
+		return
+	}
+
+	var userID uint64
+	if username != "" && cfg.userQueryer != nil {
+		if u, err := cfg.userQueryer.GetUserByUsername(c.Request.Context(), username); err == nil && u != nil {
+			userID = u.ID().Value()
+		}
+	}
+
+	ctx := user.WithUserAgent(c.Request.Context(), c.Request.UserAgent())
+	if err := cfg.activityLogger.LogActivity(ctx, userID, action, detail, c.ClientIP()); err != nil {
+		log.Errorf("Record activity %s failed for user %s: %v", action, username, err)
+	}
+}
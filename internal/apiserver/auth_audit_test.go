@@ -0,0 +1,90 @@
+package apiserver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+)
+
+// fakeLoginAuditor 在内存中保存登录审计记录
+type fakeLoginAuditor struct {
+	audits []*user.LoginAudit
+}
+
+func (a *fakeLoginAuditor) Record(ctx context.Context, audit *user.LoginAudit) error {
+	a.audits = append(a.audits, audit)
+	return nil
+}
+
+func (a *fakeLoginAuditor) ListByUsername(ctx context.Context, username string, page, pageSize int) ([]*user.LoginAudit, int64, error) {
+	return a.audits, int64(len(a.audits)), nil
+}
+
+func TestLoginAudit_RecordsSuccessAndFailure(t *testing.T) {
+	if err := validation.RegisterBindingValidators(); err != nil {
+		t.Fatalf("RegisterBindingValidators() error = %v", err)
+	}
+	auditor := &fakeLoginAuditor{}
+	auth := &Auth{
+		authenticator: &fakeAuthenticator{
+			user:     user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build(),
+			password: "secret123",
+		},
+		loginAuditor: auditor,
+		jwtOptions:   newTestJwtOptions(time.Hour, 0),
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	jwtStrategy := auth.NewJWTAuth()
+	engine.POST("/auth/login", jwtStrategy.LoginHandler)
+	engine.GET("/basic", auth.NewBasicAuth().AuthFunc(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	login := func(password string) {
+		postJSON(engine, "/auth/login", "", `{"username":"alice","password":"`+password+`"}`)
+	}
+	login("secret123")
+	login("wrong1234")
+
+	basic := func(password string) {
+		req := httptest.NewRequest(http.MethodGet, "/basic", nil)
+		req.SetBasicAuth("alice", password)
+		req.Header.Set("User-Agent", "audit-test")
+		req.RemoteAddr = "10.0.0.8:40000"
+		engine.ServeHTTP(httptest.NewRecorder(), req)
+	}
+	basic("secret123")
+	basic("wrong1234")
+
+	want := []struct {
+		method  user.LoginMethod
+		outcome user.LoginOutcome
+	}{
+		{user.LoginMethodJWT, user.LoginOutcomeSuccess},
+		{user.LoginMethodJWT, user.LoginOutcomeFailure},
+		{user.LoginMethodBasic, user.LoginOutcomeSuccess},
+		{user.LoginMethodBasic, user.LoginOutcomeFailure},
+	}
+	if len(auditor.audits) != len(want) {
+		t.Fatalf("recorded %d audits, want %d", len(auditor.audits), len(want))
+	}
+	for i, w := range want {
+		got := auditor.audits[i]
+		if got.Username != "alice" || got.Method != w.method || got.Outcome != w.outcome {
+			t.Errorf("audit[%d] = {%s %s %s}, want {alice %s %s}", i, got.Username, got.Method, got.Outcome, w.method, w.outcome)
+		}
+		if (got.Outcome == user.LoginOutcomeFailure) != (got.Reason != "") {
+			t.Errorf("audit[%d] reason = %q, want reason only on failure", i, got.Reason)
+		}
+	}
+	if basicAudit := auditor.audits[2]; basicAudit.IP != "10.0.0.8" || basicAudit.UserAgent != "audit-test" {
+		t.Errorf("basic audit client = {%s %s}, want {10.0.0.8 audit-test}", basicAudit.IP, basicAudit.UserAgent)
+	}
+}
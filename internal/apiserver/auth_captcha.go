@@ -0,0 +1,57 @@
+package apiserver
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// captchaTokenHeader 通过 Authorization 头登录时携带验证码令牌的请求头
+	captchaTokenHeader = "X-Captcha-Token"
+
+	// captchaErrorKey 验证码校验错误在上下文中的键
+	captchaErrorKey = "captcha_error"
+)
+
+// checkCaptcha 同一 IP 连续登录失败达到阈值后校验验证码令牌
+// 校验失败时将错误写入上下文，由 Unauthorized 返回需要验证码的响应
+func (cfg *Auth) checkCaptcha(c *gin.Context, token string) error {
+	if cfg.captchaGuard == nil {
+		return nil
+	}
+	if token == "" {
+		token = c.GetHeader(captchaTokenHeader)
+	}
+
+	if err := cfg.captchaGuard.Check(c.Request.Context(), c.ClientIP(), token); err != nil {
+		log.Warnf("Captcha check failed for %s: %v", c.ClientIP(), err)
+		c.Set(captchaErrorKey, err)
+		return err
+	}
+	return nil
+}
+
+// recordCaptchaResult 记录登录结果，认证失败累计失败次数，认证成功清除失败记录
+func (cfg *Auth) recordCaptchaResult(c *gin.Context, authErr error) {
+	if cfg.captchaGuard == nil {
+		return
+	}
+	if authErr != nil {
+		cfg.captchaGuard.RecordFailure(c.ClientIP())
+		return
+	}
+	cfg.captchaGuard.RecordSuccess(c.ClientIP())
+}
+
+// writeCaptchaError 写入需要验证码的错误响应，响应数据中的 captcha_required 提示客户端展示验证码
+func writeCaptchaError(c *gin.Context, err error) {
+	errCode := code.ErrCaptchaRequired
+	if errors.IsCode(err, code.ErrCaptchaInvalid) {
+		errCode = code.ErrCaptchaInvalid
+	}
+	envelope.WriteErrorWithData(c, errors.WrapC(err, errCode, "captcha check failed"), gin.H{"captcha_required": true})
+}
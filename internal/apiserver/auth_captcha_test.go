@@ -0,0 +1,135 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	authApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/auth"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+)
+
+// fakeCaptchaVerifier 只接受指定令牌的验证码校验器
+type fakeCaptchaVerifier struct {
+	token string
+}
+
+func (v fakeCaptchaVerifier) Verify(ctx context.Context, token, clientIP string) (bool, error) {
+	return token == v.token, nil
+}
+
+func TestLogin_CaptchaAfterRepeatedFailures(t *testing.T) {
+	if err := validation.RegisterBindingValidators(); err != nil {
+		t.Fatalf("RegisterBindingValidators() error = %v", err)
+	}
+	auth := &Auth{
+		authenticator: &fakeAuthenticator{
+			user:     user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build(),
+			password: "secret123",
+		},
+		captchaGuard: authApp.NewLoginCaptchaGuard(fakeCaptchaVerifier{token: "valid-token"}, 3, time.Minute),
+		jwtOptions:   newTestJwtOptions(time.Hour, 0),
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	jwtStrategy := auth.NewJWTAuth()
+	engine.POST("/auth/login", jwtStrategy.LoginHandler)
+
+	type loginResponse struct {
+		Code int `json:"code"`
+		Data struct {
+			Token           string `json:"token"`
+			CaptchaRequired bool   `json:"captcha_required"`
+		} `json:"data"`
+	}
+	login := func(password, captchaToken string) (int, loginResponse) {
+		rec := postJSON(engine, "/auth/login", "",
+			`{"username":"alice","password":"`+password+`","captcha_token":"`+captchaToken+`"}`)
+		var body loginResponse
+		if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+			t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+		}
+		return rec.Code, body
+	}
+
+	// 未达到阈值前的失败登录不要求验证码
+	for i := 0; i < 3; i++ {
+		status, body := login("wrong1234", "")
+		if status != http.StatusUnauthorized || body.Data.CaptchaRequired {
+			t.Fatalf("failed login #%d = %d %v, want 401 without captcha", i+1, status, body)
+		}
+	}
+
+	// 达到阈值后，即使密码正确也需要验证码
+	status, body := login("secret123", "")
+	if status != http.StatusUnauthorized || !body.Data.CaptchaRequired ||
+		body.Code != code.ErrCaptchaRequired {
+		t.Fatalf("login without captcha = %d %v, want 401 ErrCaptchaRequired", status, body)
+	}
+
+	// 错误的验证码令牌
+	status, body = login("secret123", "bad-token")
+	if status != http.StatusUnauthorized || body.Code != code.ErrCaptchaInvalid {
+		t.Fatalf("login with invalid captcha = %d %v, want 401 ErrCaptchaInvalid", status, body)
+	}
+
+	// 有效的验证码令牌允许登录，并清除失败记录
+	if status, body = login("secret123", "valid-token"); status != http.StatusOK || body.Data.Token == "" {
+		t.Fatalf("login with valid captcha = %d %v, want 200 with token", status, body)
+	}
+	if status, body = login("secret123", ""); status != http.StatusOK {
+		t.Fatalf("login after success = %d %v, want 200 without captcha", status, body)
+	}
+}
+
+func TestBasicAuth_CaptchaAfterRepeatedFailures(t *testing.T) {
+	auth := &Auth{
+		authenticator: &fakeAuthenticator{
+			user:     user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build(),
+			password: "secret123",
+		},
+		captchaGuard: authApp.NewLoginCaptchaGuard(fakeCaptchaVerifier{token: "valid-token"}, 3, time.Minute),
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/profile", auth.NewBasicAuth().AuthFunc(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	request := func(password, captchaToken string) (int, int) {
+		req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+		req.SetBasicAuth("alice", password)
+		if captchaToken != "" {
+			req.Header.Set(captchaTokenHeader, captchaToken)
+		}
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		var body struct {
+			Code int `json:"code"`
+		}
+		_ = json.Unmarshal(rec.Body.Bytes(), &body)
+		return rec.Code, body.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if status, errCode := request("wrong1234", ""); status != http.StatusUnauthorized || errCode != code.ErrSignatureInvalid {
+			t.Fatalf("failed basic auth #%d = %d %d, want 401 ErrSignatureInvalid", i+1, status, errCode)
+		}
+	}
+
+	// 达到阈值后，即使密码正确也需要验证码
+	if status, errCode := request("secret123", ""); status != http.StatusUnauthorized || errCode != code.ErrCaptchaRequired {
+		t.Fatalf("basic auth without captcha = %d %d, want 401 ErrCaptchaRequired", status, errCode)
+	}
+	if status, _ := request("secret123", "valid-token"); status != http.StatusOK {
+		t.Fatalf("basic auth with valid captcha = %d, want 200", status)
+	}
+}
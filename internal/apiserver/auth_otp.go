@@ -0,0 +1,124 @@
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt/v4"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	authStrategys "github.com/yshujie/questionnaire-scale/internal/pkg/middleware/auth/strategys"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// otpRequiredClaim 标记令牌为二次验证中间令牌，只能用于 /auth/otp/validate
+	otpRequiredClaim = "otp_required"
+
+	// otpChallengeTimeout 二次验证中间令牌的有效期
+	otpChallengeTimeout = 2 * time.Minute
+)
+
+// OTPCodeRequest 一次性密码请求
+type OTPCodeRequest struct {
+	Code       string `json:"code" binding:"required"`
+	Device     string `json:"device"`
+	RememberMe bool   `json:"remember_me"`
+}
+
+// otpRequired 判断令牌负载是否为二次验证中间令牌
+func otpRequired(claims map[string]interface{}) bool {
+	required, _ := claims[otpRequiredClaim].(bool)
+	return required
+}
+
+// SetupOTP 为当前用户生成待启用的 TOTP 密钥，返回用于生成二维码的 otpauth URL
+func (cfg *Auth) SetupOTP(c *gin.Context) {
+	url, err := cfg.otpManager.Setup(c.Request.Context(), c.GetString(middleware.UsernameKey))
+	if err != nil {
+		writeOTPError(c, err)
+		return
+	}
+
+	envelope.WriteSuccess(c, "OTP secret generated", gin.H{"url": url})
+}
+
+// VerifyOTP 校验当前用户用待启用密钥生成的一次性密码，通过后开启二次验证
+func (cfg *Auth) VerifyOTP(c *gin.Context) {
+	var req OTPCodeRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		writeOTPError(c, errors.WithCode(code.ErrBind, "%s", err.Error()))
+		return
+	}
+
+	if err := cfg.otpManager.Enable(c.Request.Context(), c.GetString(middleware.UsernameKey), req.Code); err != nil {
+		writeOTPError(c, err)
+		return
+	}
+
+	envelope.WriteSuccess(c, "OTP enabled", nil)
+}
+
+// NewOTPValidateHandler 创建二次验证处理器
+// 客户端携带登录得到的中间令牌和一次性密码，校验通过后签发正式令牌
+func (cfg *Auth) NewOTPValidateHandler(jwtStrategy *authStrategys.JWTStrategy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		claims, err := jwtStrategy.GetClaimsFromJWT(c)
+		if err != nil {
+			if errors.Is(err, gojwt.ErrTokenExpired) {
+				writeOTPError(c, errors.WithCode(code.ErrOTPExpired, "otp challenge expired"))
+				return
+			}
+			writeOTPError(c, errors.WithCode(code.ErrTokenInvalid, "%s", err.Error()))
+			return
+		}
+		if !otpRequired(claims) {
+			writeOTPError(c, errors.WithCode(code.ErrTokenInvalid, "token is not an otp challenge"))
+			return
+		}
+
+		var req OTPCodeRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			writeOTPError(c, errors.WithCode(code.ErrBind, "%s", err.Error()))
+			return
+		}
+
+		username, _ := claims[jwt.IdentityKey].(string)
+		userObj, err := cfg.otpManager.Verify(c.Request.Context(), username, req.Code)
+		if err != nil {
+			cfg.recordLogin(c, username, user.LoginMethodJWT, err)
+			writeOTPError(c, err)
+			return
+		}
+
+		identity, err := cfg.newLoginIdentity(c, userObj, req.Device, req.RememberMe)
+		if err != nil {
+			cfg.recordLogin(c, username, user.LoginMethodJWT, err)
+			writeOTPError(c, err)
+			return
+		}
+		cfg.recordLogin(c, username, user.LoginMethodJWT, nil)
+
+		token, expire, err := jwtStrategy.TokenGenerator(identity)
+		if err != nil {
+			writeOTPError(c, errors.WrapC(err, code.ErrTokenGeneration, "generate token failed"))
+			return
+		}
+
+		c.Set("user", identity)
+		jwtStrategy.SetCookie(c, token)
+		jwtStrategy.LoginResponse(c, http.StatusOK, token, expire)
+	}
+}
+
+// writeOTPError 写入二次验证相关的错误响应
+func writeOTPError(c *gin.Context, err error) {
+	log.Errorf("OTP request failed: %v", err)
+	envelope.AbortWithError(c, err)
+}
@@ -0,0 +1,178 @@
+package apiserver
+
+import (
+	"net/http"
+	"time"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	"github.com/gin-gonic/gin"
+	gojwt "github.com/golang-jwt/jwt/v4"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	authStrategys "github.com/yshujie/questionnaire-scale/internal/pkg/middleware/auth/strategys"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// tokenTypeClaim 令牌类型，长期刷新令牌只能用于 /auth/refresh
+	tokenTypeClaim = "typ"
+
+	// refreshTokenType 长期刷新令牌的类型
+	refreshTokenType = "refresh"
+)
+
+// refreshIdentityClaims 使用长期刷新令牌换取访问令牌时沿用的负载字段
+var refreshIdentityClaims = []string{
+	jwt.IdentityKey,
+	"sub",
+	"user_id",
+	"nickname",
+	middleware.ScopeKey,
+	sessionIDClaim,
+}
+
+// RefreshTokenRequest 长期刷新令牌请求
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// refreshedIdentity 长期刷新令牌中的用户身份，用于签发新的访问令牌
+type refreshedIdentity map[string]interface{}
+
+// isRefreshToken 判断令牌负载是否为长期刷新令牌
+func isRefreshToken(claims map[string]interface{}) bool {
+	typ, _ := claims[tokenTypeClaim].(string)
+	return typ == refreshTokenType
+}
+
+// signRefreshToken 为开启“记住我”的登录签发长期刷新令牌，有效期与会话记录一致
+func (cfg *Auth) signRefreshToken(identity loginIdentity, expire time.Time) (string, error) {
+	claims := gojwt.MapClaims(cfg.createPayloadFunc()(identity))
+	claims[tokenTypeClaim] = refreshTokenType
+	claims["iat"] = time.Now().Unix()
+	claims["exp"] = expire.Unix()
+
+	token := gojwt.NewWithClaims(gojwt.SigningMethodHS256, claims)
+	return token.SignedString([]byte(cfg.jwtOptions.Key))
+}
+
+// NewRefreshHandler 创建令牌刷新处理器
+// 请求体携带长期刷新令牌时按“记住我”会话刷新，否则沿用访问令牌的刷新窗口
+// 两种方式都会重新加载用户可管理的问卷范围，范围变更在刷新后生效
+func (cfg *Auth) NewRefreshHandler(jwtStrategy *authStrategys.JWTStrategy) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		var req RefreshTokenRequest
+		_ = c.ShouldBindJSON(&req)
+
+		var identity refreshedIdentity
+		if req.RefreshToken == "" {
+			claims, err := jwtStrategy.CheckIfTokenExpire(c)
+			if err != nil {
+				jwtStrategy.Unauthorized(c, http.StatusUnauthorized, jwtStrategy.HTTPStatusMessageFunc(err, c))
+				return
+			}
+			// 长期刷新令牌不能当作访问令牌刷新
+			if isRefreshToken(claims) {
+				writeRefreshError(c, errors.WithCode(code.ErrTokenInvalid, "refresh token must be sent in body"))
+				return
+			}
+			identity = refreshedIdentity{}
+			for key, value := range claims {
+				if key != jwtStrategy.ExpField && key != "orig_iat" {
+					identity[key] = value
+				}
+			}
+		} else {
+			var err error
+			if identity, err = cfg.rememberedIdentity(c, jwtStrategy, req.RefreshToken); err != nil {
+				writeRefreshError(c, err)
+				return
+			}
+		}
+
+		if err := cfg.reloadScopes(c, identity); err != nil {
+			writeRefreshError(c, err)
+			return
+		}
+
+		accessToken, expire, err := jwtStrategy.TokenGenerator(identity)
+		if err != nil {
+			writeRefreshError(c, errors.WrapC(err, code.ErrTokenGeneration, "generate token failed"))
+			return
+		}
+
+		jwtStrategy.SetCookie(c, accessToken)
+		jwtStrategy.RefreshResponse(c, http.StatusOK, accessToken, expire)
+	}
+}
+
+// rememberedIdentity 校验长期刷新令牌及其会话，返回其中的用户身份
+func (cfg *Auth) rememberedIdentity(c *gin.Context, jwtStrategy *authStrategys.JWTStrategy, refreshToken string) (refreshedIdentity, error) {
+	token, err := jwtStrategy.ParseTokenString(refreshToken)
+	if err != nil {
+		if errors.Is(err, gojwt.ErrTokenExpired) {
+			return nil, errors.WithCode(code.ErrExpired, "refresh token expired")
+		}
+		return nil, errors.WithCode(code.ErrTokenInvalid, "%s", err.Error())
+	}
+
+	claims, _ := token.Claims.(gojwt.MapClaims)
+	if !isRefreshToken(claims) {
+		return nil, errors.WithCode(code.ErrTokenInvalid, "token is not a refresh token")
+	}
+
+	// 会话撤销后长期刷新令牌随之失效
+	sessionID, _ := claims[sessionIDClaim].(string)
+	if sessionID == "" || cfg.sessionManager == nil {
+		return nil, errors.WithCode(code.ErrTokenInvalid, "refresh token has no session")
+	}
+	ok, err := cfg.sessionManager.CanRefresh(c.Request.Context(), sessionID)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, errors.WithCode(code.ErrTokenInvalid, "session `%s` can not be refreshed", sessionID)
+	}
+
+	identity := refreshedIdentity{}
+	for _, key := range refreshIdentityClaims {
+		if value, exists := claims[key]; exists {
+			identity[key] = value
+		}
+	}
+	return identity, nil
+}
+
+// reloadScopes 按负载中的用户ID重新加载用户状态和可管理的问卷范围
+// 用户不存在、被封禁或非活跃时令牌不能再刷新
+func (cfg *Auth) reloadScopes(c *gin.Context, identity refreshedIdentity) error {
+	userID, ok := identity["user_id"].(float64)
+	if !ok {
+		return errors.WithCode(code.ErrTokenInvalid, "token has no user id")
+	}
+
+	userObj, err := cfg.authenticator.LoadUser(c.Request.Context(), uint64(userID))
+	if err != nil {
+		if errors.IsCode(err, code.ErrUserNotFound) {
+			return errors.WrapC(err, code.ErrTokenInvalid, "user of token not found")
+		}
+		return err
+	}
+	if userObj.IsBlocked() {
+		return errors.WithCode(code.ErrUserBlocked, "user `%d` is blocked", userObj.ID().Value())
+	}
+	if userObj.IsInactive() {
+		return errors.WithCode(code.ErrUserInactive, "user `%d` is inactive", userObj.ID().Value())
+	}
+	identity[middleware.ScopeKey] = userObj.Scopes()
+	return nil
+}
+
+// writeRefreshError 写入令牌刷新相关的错误响应
+func writeRefreshError(c *gin.Context, err error) {
+	log.Errorf("Refresh request failed: %v", err)
+	envelope.AbortWithError(c, err)
+}
@@ -0,0 +1,102 @@
+package apiserver
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// sessionIDClaim 令牌关联的登录会话ID，会话撤销后令牌失效
+	sessionIDClaim = "sid"
+
+	// sessionRevokedKey 令牌关联的会话已撤销时在上下文中的标记
+	sessionRevokedKey = "session_revoked"
+
+	// adminSessionScope 全部问卷范围
+	adminSessionScope = "*"
+	// adminSessionRole 拥有全部问卷范围的用户在 session.role-max-concurrent 中对应的角色名
+	adminSessionRole = "admin"
+)
+
+// loginIdentity 已完成登录（含二次验证）的用户身份，签发正式令牌时使用
+type loginIdentity struct {
+	*user.User
+	sessionID     string
+	refreshToken  string
+	refreshExpire time.Time
+}
+
+// newLoginIdentity 为登录成功的用户创建会话，未配置会话管理时令牌不关联会话
+// rememberMe 为 true 时会话开启“记住我”，并签发长期刷新令牌
+func (cfg *Auth) newLoginIdentity(c *gin.Context, userObj *user.User, device string, rememberMe bool) (loginIdentity, error) {
+	identity := loginIdentity{User: userObj}
+	if cfg.sessionManager == nil {
+		return identity, nil
+	}
+
+	var rememberFor time.Duration
+	if rememberMe {
+		rememberFor = cfg.jwtOptions.RememberRefresh
+	}
+
+	session, err := cfg.sessionManager.CreateSession(
+		c.Request.Context(),
+		userObj.ID().Value(),
+		sessionRoles(userObj.Scopes()),
+		device,
+		c.Request.UserAgent(),
+		c.ClientIP(),
+		rememberFor,
+	)
+	if err != nil {
+		return identity, err
+	}
+	identity.sessionID = session.ID
+
+	if session.Remembered() {
+		identity.refreshExpire = *session.RefreshExpiresAt
+		identity.refreshToken, err = cfg.signRefreshToken(identity, identity.refreshExpire)
+		if err != nil {
+			return identity, errors.WrapC(err, code.ErrTokenGeneration, "generate refresh token failed")
+		}
+	}
+
+	return identity, nil
+}
+
+// sessionRoles 返回用于确定并发会话上限的角色，拥有全部问卷范围的用户视为 admin 角色，
+// 角色范围授予的角色同样参与计算
+func sessionRoles(scopes []string) []string {
+	var roles []string
+	for _, scope := range scopes {
+		if scope == adminSessionScope {
+			roles = append(roles, adminSessionRole)
+		} else if role, ok := strings.CutPrefix(scope, middleware.RoleScopePrefix); ok && role != "" {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// sessionActive 判断令牌关联的会话是否有效并记录会话活跃，未关联会话的令牌视为有效
+func (cfg *Auth) sessionActive(c *gin.Context, claims map[string]interface{}) bool {
+	sessionID, _ := claims[sessionIDClaim].(string)
+	if sessionID == "" || cfg.sessionManager == nil {
+		return true
+	}
+
+	active, err := cfg.sessionManager.TouchSession(c.Request.Context(), sessionID)
+	if err != nil {
+		log.L(c).Errorf("Check session `%s` failed: %v", sessionID, err)
+		return false
+	}
+	return active
+}
@@ -0,0 +1,330 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	userApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/response"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+)
+
+// memSessionRepo 内存中的登录会话存储库，按创建顺序保存
+type memSessionRepo struct {
+	sessions []*user.Session
+}
+
+func (r *memSessionRepo) Create(ctx context.Context, session *user.Session) error {
+	r.sessions = append(r.sessions, session)
+	return nil
+}
+
+func (r *memSessionRepo) FindByID(ctx context.Context, id string) (*user.Session, error) {
+	for _, s := range r.sessions {
+		if s.ID == id {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *memSessionRepo) FindByUserID(ctx context.Context, userID uint64) ([]*user.Session, error) {
+	var sessions []*user.Session
+	for i := len(r.sessions) - 1; i >= 0; i-- {
+		if r.sessions[i].UserID == userID {
+			sessions = append(sessions, r.sessions[i])
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memSessionRepo) Update(ctx context.Context, session *user.Session) error {
+	return nil
+}
+
+func (r *memSessionRepo) Delete(ctx context.Context, session *user.Session) error {
+	for i, s := range r.sessions {
+		if s.ID == session.ID {
+			r.sessions = append(r.sessions[:i], r.sessions[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// fakeUserQueryer 只支持按用户名查询固定用户的查询器
+type fakeUserQueryer struct {
+	port.UserQueryer
+	user *user.User
+}
+
+func (q *fakeUserQueryer) GetUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	return q.user, nil
+}
+
+// sessionList 会话列表响应
+type sessionList struct {
+	Data []response.UserSessionResponse `json:"data"`
+}
+
+// doRequest 携带 Bearer 令牌发送请求
+func doRequest(engine *gin.Engine, method, path, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+// newSessionEngine 创建带登录和会话路由的测试引擎，会话上限为 maxSessions
+func newSessionEngine(t *testing.T, userObj *user.User, maxSessions int, roleSessions map[string]int) (*gin.Engine, port.SessionManager) {
+	t.Helper()
+	if err := validation.RegisterBindingValidators(); err != nil {
+		t.Fatalf("RegisterBindingValidators() error = %v", err)
+	}
+	sessionManager := userApp.NewSessionManager(&memSessionRepo{}, maxSessions, roleSessions)
+	auth := &Auth{
+		authenticator:  &fakeAuthenticator{user: userObj},
+		sessionManager: sessionManager,
+		jwtOptions:     newTestJwtOptions(time.Hour, 0),
+	}
+	userHandler := handler.NewUserHandler(nil, &fakeUserQueryer{user: userObj}, nil, nil, nil, nil, sessionManager)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	jwtStrategy := auth.NewJWTAuth()
+	engine.POST("/auth/login", jwtStrategy.LoginHandler)
+	sessions := engine.Group("/auth/sessions", jwtStrategy.MiddlewareFunc())
+	sessions.GET("", userHandler.ListSessions)
+	sessions.DELETE("/:sessionID", userHandler.RevokeSession)
+	return engine, sessionManager
+}
+
+// sessionLogin 以指定设备登录并返回令牌
+func sessionLogin(t *testing.T, engine *gin.Engine, device string) string {
+	t.Helper()
+	rec := postJSON(engine, "/auth/login", "", `{"username":"alice","password":"secret123","device":"`+device+`"}`)
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := decodeData(rec, &resp); err != nil || resp.Token == "" {
+		t.Fatalf("login from %s = %d %s", device, rec.Code, rec.Body.String())
+	}
+	return resp.Token
+}
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	userObj := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	engine, _ := newSessionEngine(t, userObj, 5, nil)
+	laptopToken := sessionLogin(t, engine, "laptop")
+	phoneToken := sessionLogin(t, engine, "phone")
+
+	rec := doRequest(engine, http.MethodGet, "/auth/sessions", laptopToken)
+	var list sessionList
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal sessions %q: %v", rec.Body.String(), err)
+	}
+	if len(list.Data) != 2 {
+		t.Fatalf("sessions = %+v, want 2", list.Data)
+	}
+	var phoneID string
+	for _, s := range list.Data {
+		if s.Device == "phone" {
+			phoneID = s.ID
+		}
+		if s.Current != (s.Device == "laptop") {
+			t.Errorf("session %s current = %v", s.Device, s.Current)
+		}
+	}
+
+	// 在笔记本上撤销手机的会话
+	if rec := doRequest(engine, http.MethodDelete, "/auth/sessions/"+phoneID, laptopToken); rec.Code != http.StatusOK {
+		t.Fatalf("revoke = %d %s", rec.Code, rec.Body.String())
+	}
+
+	if rec := doRequest(engine, http.MethodGet, "/auth/sessions", phoneToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("revoked token status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+
+	rec = doRequest(engine, http.MethodGet, "/auth/sessions", laptopToken)
+	list = sessionList{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal sessions %q: %v", rec.Body.String(), err)
+	}
+	if rec.Code != http.StatusOK || len(list.Data) != 1 || list.Data[0].Device != "laptop" {
+		t.Errorf("sessions after revoke = %d %s, want only laptop", rec.Code, rec.Body.String())
+	}
+}
+
+func TestSessions_ConcurrentLimitRevokesOldest(t *testing.T) {
+	userObj := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	engine, _ := newSessionEngine(t, userObj, 2, nil)
+
+	laptopToken := sessionLogin(t, engine, "laptop")
+	phoneToken := sessionLogin(t, engine, "phone")
+	tabletToken := sessionLogin(t, engine, "tablet")
+
+	// 达到上限后最早登录的笔记本会话被撤销
+	if rec := doRequest(engine, http.MethodGet, "/auth/sessions", laptopToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("oldest session status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := doRequest(engine, http.MethodGet, "/auth/sessions", phoneToken); rec.Code != http.StatusOK {
+		t.Errorf("phone session status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	rec := doRequest(engine, http.MethodGet, "/auth/sessions", tabletToken)
+	var list sessionList
+	if err := json.Unmarshal(rec.Body.Bytes(), &list); err != nil {
+		t.Fatalf("unmarshal sessions %q: %v", rec.Body.String(), err)
+	}
+	if len(list.Data) != 2 {
+		t.Errorf("sessions = %+v, want 2", list.Data)
+	}
+}
+
+func TestSessions_RoleLimitOverridesDefault(t *testing.T) {
+	admin := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	admin.SetScopes([]string{adminSessionScope})
+	engine, _ := newSessionEngine(t, admin, 5, map[string]int{adminSessionRole: 1})
+
+	firstToken := sessionLogin(t, engine, "laptop")
+	secondToken := sessionLogin(t, engine, "phone")
+
+	if rec := doRequest(engine, http.MethodGet, "/auth/sessions", firstToken); rec.Code != http.StatusUnauthorized {
+		t.Errorf("first admin session status = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+	if rec := doRequest(engine, http.MethodGet, "/auth/sessions", secondToken); rec.Code != http.StatusOK {
+		t.Errorf("second admin session status = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
+
+// memUserRepo 只保存单个用户的用户存储库
+type memUserRepo struct {
+	port.UserRepository
+	user *user.User
+}
+
+func (r *memUserRepo) FindByID(ctx context.Context, id user.UserID) (*user.User, error) {
+	return r.user, nil
+}
+
+func (r *memUserRepo) Update(ctx context.Context, u *user.User) error {
+	return nil
+}
+
+func TestSessions_PasswordChangeRevokesAll(t *testing.T) {
+	userObj := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	engine, sessionManager := newSessionEngine(t, userObj, 5, nil)
+
+	laptopToken := sessionLogin(t, engine, "laptop")
+	phoneToken := sessionLogin(t, engine, "phone")
+
+	changer := userApp.NewPasswordChanger(&memUserRepo{user: userObj}, sessionManager, nil)
+	if err := changer.ChangePassword(context.Background(), 1, "secret123", "newSecret456"); err != nil {
+		t.Fatalf("ChangePassword() error = %v", err)
+	}
+
+	for device, token := range map[string]string{"laptop": laptopToken, "phone": phoneToken} {
+		if rec := doRequest(engine, http.MethodGet, "/auth/sessions", token); rec.Code != http.StatusUnauthorized {
+			t.Errorf("%s session after password change = %d, want %d", device, rec.Code, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestSessions_RememberMeRefresh(t *testing.T) {
+	if err := validation.RegisterBindingValidators(); err != nil {
+		t.Fatalf("RegisterBindingValidators() error = %v", err)
+	}
+	userObj := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	sessionManager := userApp.NewSessionManager(&memSessionRepo{}, 5, nil)
+	auth := &Auth{
+		authenticator:  &fakeAuthenticator{user: userObj},
+		sessionManager: sessionManager,
+		jwtOptions:     newTestJwtOptions(15*time.Minute, time.Hour),
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	jwtStrategy := auth.NewJWTAuth()
+	engine.POST("/auth/login", jwtStrategy.LoginHandler)
+	engine.POST("/auth/refresh", auth.NewRefreshHandler(&jwtStrategy))
+	engine.GET("/profile", jwtStrategy.MiddlewareFunc(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	type loginResponse struct {
+		Token         string    `json:"token"`
+		Expire        time.Time `json:"expire"`
+		RefreshToken  string    `json:"refresh_token"`
+		RefreshExpire time.Time `json:"refresh_expire"`
+	}
+	login := func(body string) loginResponse {
+		rec := postJSON(engine, "/auth/login", "", body)
+		var resp loginResponse
+		if err := decodeData(rec, &resp); err != nil || resp.Token == "" {
+			t.Fatalf("login = %d %s", rec.Code, rec.Body.String())
+		}
+		return resp
+	}
+
+	// 未勾选“记住我”时不签发长期刷新令牌
+	if plain := login(`{"username":"alice","password":"secret123"}`); plain.RefreshToken != "" {
+		t.Errorf("login without remember_me returned refresh token")
+	}
+
+	// 勾选“记住我”时访问令牌仍然短期有效，刷新窗口长于默认刷新窗口
+	remembered := login(`{"username":"alice","password":"secret123","remember_me":true}`)
+	if remembered.RefreshToken == "" {
+		t.Fatal("login with remember_me returned no refresh token")
+	}
+	if ttl := time.Until(remembered.Expire); ttl > 15*time.Minute {
+		t.Errorf("access token ttl = %s, want at most 15m", ttl)
+	}
+	if window := time.Until(remembered.RefreshExpire); window <= auth.jwtOptions.MaxRefresh {
+		t.Errorf("remember-me refresh window = %s, want longer than %s", window, auth.jwtOptions.MaxRefresh)
+	}
+
+	// 长期刷新令牌不能当作访问令牌使用
+	if rec := doRequest(engine, http.MethodGet, "/profile", remembered.RefreshToken); rec.Code != http.StatusForbidden {
+		t.Errorf("profile with refresh token status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	// 长期刷新令牌换取新的访问令牌
+	refreshBody := `{"refresh_token":"` + remembered.RefreshToken + `"}`
+	rec := postJSON(engine, "/auth/refresh", "", refreshBody)
+	var refreshed struct {
+		Token string `json:"token"`
+	}
+	if err := decodeData(rec, &refreshed); err != nil || rec.Code != http.StatusOK || refreshed.Token == "" {
+		t.Fatalf("refresh = %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := doRequest(engine, http.MethodGet, "/profile", refreshed.Token); rec.Code != http.StatusOK {
+		t.Errorf("profile with refreshed token status = %d, want %d", rec.Code, http.StatusOK)
+	}
+
+	// 撤销会话后长期刷新令牌失效
+	sessions, err := sessionManager.ListSessions(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	for _, s := range sessions {
+		if s.Remembered() {
+			if err := sessionManager.RevokeSession(context.Background(), 1, s.ID); err != nil {
+				t.Fatalf("RevokeSession() error = %v", err)
+			}
+		}
+	}
+	if rec := postJSON(engine, "/auth/refresh", "", refreshBody); rec.Code != http.StatusUnauthorized {
+		t.Errorf("refresh after revoke = %d %s, want %d", rec.Code, rec.Body.String(), http.StatusUnauthorized)
+	}
+	if rec := doRequest(engine, http.MethodGet, "/profile", refreshed.Token); rec.Code != http.StatusUnauthorized {
+		t.Errorf("profile with refreshed token after revoke = %d, want %d", rec.Code, http.StatusUnauthorized)
+	}
+}
@@ -0,0 +1,327 @@
+package apiserver
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newTestJwtOptions 创建测试用的 JWT 配置
+func newTestJwtOptions(timeout, maxRefresh time.Duration) *genericoptions.JwtOptions {
+	opts := genericoptions.NewJwtOptions()
+	opts.Key = "test-secret"
+	opts.Timeout = timeout
+	opts.MaxRefresh = maxRefresh
+	return opts
+}
+
+// newLoginEngine 创建仅包含登录路由的测试引擎
+func newLoginEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+	if err := validation.RegisterBindingValidators(); err != nil {
+		t.Fatalf("RegisterBindingValidators() error = %v", err)
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	jwtStrategy := (&Auth{jwtOptions: newTestJwtOptions(time.Hour, 0)}).NewJWTAuth()
+	engine.POST("/auth/login", jwtStrategy.LoginHandler)
+	return engine
+}
+
+func TestLogin_ValidationErrors(t *testing.T) {
+	tests := []struct {
+		name        string
+		body        string
+		wantField   string
+		wantCode    int
+		wantMessage string
+	}{
+		{
+			name:        "missing username",
+			body:        `{"password":"secret123"}`,
+			wantField:   "username",
+			wantCode:    code.ErrFieldRequired,
+			wantMessage: "username不能为空",
+		},
+		{
+			name:        "missing password",
+			body:        `{"username":"alice"}`,
+			wantField:   "password",
+			wantCode:    code.ErrFieldRequired,
+			wantMessage: "password不能为空",
+		},
+	}
+
+	engine := newLoginEngine(t)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want %d, body = %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+			}
+
+			var resp struct {
+				Code int `json:"code"`
+				Data struct {
+					Errors []validation.FieldError `json:"errors"`
+				} `json:"data"`
+			}
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("unmarshal response %q: %v", rec.Body.String(), err)
+			}
+			if resp.Code != code.ErrValidation {
+				t.Errorf("code = %d, want %d", resp.Code, code.ErrValidation)
+			}
+			if len(resp.Data.Errors) != 1 {
+				t.Fatalf("errors = %+v, want exactly one field error", resp.Data.Errors)
+			}
+
+			got := resp.Data.Errors[0]
+			if got.Field != tt.wantField || got.Code != tt.wantCode || got.Message != tt.wantMessage {
+				t.Errorf("field error = %+v, want {Field:%s Code:%d Message:%s}", got, tt.wantField, tt.wantCode, tt.wantMessage)
+			}
+		})
+	}
+}
+
+// fakeAuthenticator 固定返回指定用户的认证器，password 不为空时校验密码
+type fakeAuthenticator struct {
+	user     *user.User
+	password string
+}
+
+func (a *fakeAuthenticator) Authenticate(ctx context.Context, username, password string) (*user.User, error) {
+	if a.password != "" && password != a.password {
+		return nil, errors.WithCode(code.ErrPasswordIncorrect, "password incorrect")
+	}
+	return a.user, nil
+}
+
+func (a *fakeAuthenticator) LoadUser(ctx context.Context, userID uint64) (*user.User, error) {
+	return a.user, nil
+}
+
+// fakeOTPManager 只接受指定一次性密码的管理器
+type fakeOTPManager struct {
+	user     *user.User
+	passcode string
+}
+
+func (m *fakeOTPManager) Setup(ctx context.Context, username string) (string, error) {
+	return "otpauth://totp/test", nil
+}
+
+func (m *fakeOTPManager) Enable(ctx context.Context, username, passcode string) error {
+	_, err := m.Verify(ctx, username, passcode)
+	return err
+}
+
+func (m *fakeOTPManager) Verify(ctx context.Context, username, passcode string) (*user.User, error) {
+	if username != m.user.Username() || passcode != m.passcode {
+		return nil, errors.WithCode(code.ErrOTPInvalid, "otp code is invalid")
+	}
+	return m.user, nil
+}
+
+// decodeData 解析统一响应结构中的 data 字段
+func decodeData(rec *httptest.ResponseRecorder, v interface{}) error {
+	var resp struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		return err
+	}
+	return json.Unmarshal(resp.Data, v)
+}
+
+// postJSON 发送 JSON 请求，token 不为空时携带 Bearer 令牌
+func postJSON(engine *gin.Engine, path, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLogin_OTPChallenge(t *testing.T) {
+	if err := validation.RegisterBindingValidators(); err != nil {
+		t.Fatalf("RegisterBindingValidators() error = %v", err)
+	}
+	userObj := user.NewUserBuilder().
+		WithID(user.NewUserID(1)).
+		WithUsername("alice").
+		WithOTPSecret("JBSWY3DPEHPK3PXP").
+		Build()
+	auth := &Auth{
+		authenticator: &fakeAuthenticator{user: userObj},
+		otpManager:    &fakeOTPManager{user: userObj, passcode: "123456"},
+		jwtOptions:    newTestJwtOptions(time.Hour, 0),
+	}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	jwtStrategy := auth.NewJWTAuth()
+	engine.POST("/auth/login", jwtStrategy.LoginHandler)
+	engine.POST("/auth/otp/validate", auth.NewOTPValidateHandler(&jwtStrategy))
+	engine.GET("/profile", jwtStrategy.MiddlewareFunc(), func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	// 1. 密码校验通过后只拿到短期的中间令牌
+	rec := postJSON(engine, "/auth/login", "", `{"username":"alice","password":"secret123"}`)
+	var login struct {
+		Token       string    `json:"token"`
+		Expire      time.Time `json:"expire"`
+		OTPRequired bool      `json:"otp_required"`
+	}
+	if err := decodeData(rec, &login); err != nil {
+		t.Fatalf("unmarshal login response %q: %v", rec.Body.String(), err)
+	}
+	if !login.OTPRequired {
+		t.Fatalf("login response = %s, want otp_required", rec.Body.String())
+	}
+	if ttl := time.Until(login.Expire); ttl > otpChallengeTimeout {
+		t.Errorf("challenge token ttl = %s, want at most %s", ttl, otpChallengeTimeout)
+	}
+
+	// 2. 中间令牌不能访问业务接口
+	req := httptest.NewRequest(http.MethodGet, "/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+login.Token)
+	profile := httptest.NewRecorder()
+	engine.ServeHTTP(profile, req)
+	if profile.Code != http.StatusForbidden {
+		t.Errorf("profile with challenge token status = %d, want %d", profile.Code, http.StatusForbidden)
+	}
+
+	// 3. 错误的一次性密码被拒绝
+	rec = postJSON(engine, "/auth/otp/validate", login.Token, `{"code":"000000"}`)
+	if rec.Code != http.StatusUnauthorized || !strings.Contains(rec.Body.String(), strconv.Itoa(code.ErrOTPInvalid)) {
+		t.Errorf("validate with wrong code = %d %s, want 401 ErrOTPInvalid", rec.Code, rec.Body.String())
+	}
+
+	// 4. 正确的一次性密码换取正式令牌
+	rec = postJSON(engine, "/auth/otp/validate", login.Token, `{"code":"123456"}`)
+	var final struct {
+		Token       string    `json:"token"`
+		Expire      time.Time `json:"expire"`
+		OTPRequired bool      `json:"otp_required"`
+	}
+	if err := decodeData(rec, &final); err != nil {
+		t.Fatalf("unmarshal validate response %q: %v", rec.Body.String(), err)
+	}
+	if rec.Code != http.StatusOK || final.OTPRequired || time.Until(final.Expire) <= otpChallengeTimeout {
+		t.Fatalf("validate response = %d %s, want long-lived token", rec.Code, rec.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, "/profile", nil)
+	req.Header.Set("Authorization", "Bearer "+final.Token)
+	profile = httptest.NewRecorder()
+	engine.ServeHTTP(profile, req)
+	if profile.Code != http.StatusOK {
+		t.Errorf("profile with final token status = %d, want %d", profile.Code, http.StatusOK)
+	}
+}
+
+func TestBasicAuth_SetsScopes(t *testing.T) {
+	userObj := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	userObj.SetScopes([]string{"phq*"})
+	auth := &Auth{authenticator: &fakeAuthenticator{user: userObj, password: "secret123"}}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.PUT("/questionnaires/:code", auth.NewBasicAuth().AuthFunc(), middleware.ScopeGuard("code"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	tests := []struct {
+		code       string
+		wantStatus int
+	}{
+		{code: "phq9", wantStatus: http.StatusOK},
+		{code: "gad7", wantStatus: http.StatusForbidden},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(http.MethodPut, "/questionnaires/"+tt.code, nil)
+		req.SetBasicAuth("alice", "secret123")
+		rec := httptest.NewRecorder()
+		engine.ServeHTTP(rec, req)
+
+		if rec.Code != tt.wantStatus {
+			t.Errorf("PUT %s status = %d, want %d", tt.code, rec.Code, tt.wantStatus)
+		}
+	}
+}
+
+func TestRefresh_ReloadsScopes(t *testing.T) {
+	if err := validation.RegisterBindingValidators(); err != nil {
+		t.Fatalf("RegisterBindingValidators() error = %v", err)
+	}
+
+	userObj := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	userObj.SetScopes([]string{"phq*"})
+	auth := &Auth{authenticator: &fakeAuthenticator{user: userObj}, jwtOptions: newTestJwtOptions(time.Hour, time.Hour)}
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	jwtStrategy := auth.NewJWTAuth()
+	engine.POST("/auth/login", jwtStrategy.LoginHandler)
+	engine.POST("/auth/refresh", auth.NewRefreshHandler(&jwtStrategy))
+	engine.PUT("/questionnaires/:code", jwtStrategy.MiddlewareFunc(), middleware.ScopeGuard("code"), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	var login struct {
+		Token string `json:"token"`
+	}
+	rec := postJSON(engine, "/auth/login", "", `{"username":"alice","password":"secret123"}`)
+	if err := decodeData(rec, &login); err != nil || login.Token == "" {
+		t.Fatalf("login = %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := doRequest(engine, http.MethodPut, "/questionnaires/gad7", login.Token); rec.Code != http.StatusForbidden {
+		t.Fatalf("PUT gad7 before refresh status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	// 管理员调整范围后，刷新得到的令牌使用新范围
+	userObj.SetScopes([]string{"gad*"})
+	var refreshed struct {
+		Token string `json:"token"`
+	}
+	rec = postJSON(engine, "/auth/refresh", login.Token, "")
+	if err := decodeData(rec, &refreshed); err != nil || refreshed.Token == "" {
+		t.Fatalf("refresh = %d %s", rec.Code, rec.Body.String())
+	}
+	if rec := doRequest(engine, http.MethodPut, "/questionnaires/gad7", refreshed.Token); rec.Code != http.StatusOK {
+		t.Errorf("PUT gad7 after refresh status = %d, want %d", rec.Code, http.StatusOK)
+	}
+	if rec := doRequest(engine, http.MethodPut, "/questionnaires/phq9", refreshed.Token); rec.Code != http.StatusForbidden {
+		t.Errorf("PUT phq9 after refresh status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+
+	// 用户被封禁后令牌不能再刷新
+	if err := userObj.Block(); err != nil {
+		t.Fatalf("Block() error = %v", err)
+	}
+	if rec := postJSON(engine, "/auth/refresh", refreshed.Token, ""); rec.Code != http.StatusForbidden {
+		t.Errorf("refresh after block status = %d, want %d", rec.Code, http.StatusForbidden)
+	}
+}
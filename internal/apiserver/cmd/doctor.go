@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+)
+
+// doctorReportBatchSize 每批核对是否存在的答卷 ID 数
+const doctorReportBatchSize = 1000
+
+// 一致性检查项
+const (
+	CheckScaleQuestionnaire       = "scale-questionnaire"
+	CheckAnswerSheetQuestionnaire = "answersheet-questionnaire"
+	CheckReportAnswerSheet        = "report-answersheet"
+)
+
+// doctorStore 一致性检查读取的数据，只读不写
+type doctorStore interface {
+	// ActiveQuestionnaireCodes 获取未删除的问卷编码
+	ActiveQuestionnaireCodes(ctx context.Context) (map[string]bool, error)
+	// ScaleQuestionnaireCodes 获取未删除的医学量表编码及其关联的问卷编码
+	ScaleQuestionnaireCodes(ctx context.Context) (map[string]string, error)
+	// AnswerSheetCountsByQuestionnaire 按问卷编码统计未删除的答卷数
+	AnswerSheetCountsByQuestionnaire(ctx context.Context) (map[string]int64, error)
+	// ReportAnswerSheetIDs 获取未删除的解读报告引用的答卷 ID
+	ReportAnswerSheetIDs(ctx context.Context) ([]uint64, error)
+	// ExistingAnswerSheetIDs 返回 ids 中存在且未删除的答卷 ID
+	ExistingAnswerSheetIDs(ctx context.Context, ids []uint64) (map[uint64]bool, error)
+}
+
+// doctorFinding 一条不一致数据
+type doctorFinding struct {
+	Check   string
+	Subject string
+	Detail  string
+}
+
+// NewDoctorCommand 创建数据一致性检查命令
+// 直接连接 MongoDB 扫描不一致的数据并输出，不修改任何数据；发现问题时以非零状态退出
+func NewDoctorCommand() *app.Command {
+	return app.NewCommand("doctor",
+		"Scan the data store for inconsistencies without modifying any data",
+		app.WithCommandRunFunc(runDoctor),
+	)
+}
+
+// runDoctor 执行一致性检查
+func runDoctor(args []string) error {
+	mongoDB, closeMongo, err := connectMongoDB()
+	if err != nil {
+		return err
+	}
+	defer closeMongo()
+
+	findings, err := diagnose(context.Background(), newMongoDoctorStore(mongoDB))
+	if err != nil {
+		return err
+	}
+	printFindings(os.Stdout, findings)
+	if len(findings) > 0 {
+		return fmt.Errorf("found %d inconsistencies", len(findings))
+	}
+	return nil
+}
+
+// diagnose 依次执行全部检查
+func diagnose(ctx context.Context, store doctorStore) ([]doctorFinding, error) {
+	questionnaires, err := store.ActiveQuestionnaireCodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load questionnaires: %w", err)
+	}
+
+	var findings []doctorFinding
+	for _, check := range []func(context.Context, doctorStore, map[string]bool) ([]doctorFinding, error){
+		checkScaleQuestionnaires,
+		checkAnswerSheetQuestionnaires,
+		checkReportAnswerSheets,
+	} {
+		found, err := check(ctx, store, questionnaires)
+		if err != nil {
+			return nil, err
+		}
+		findings = append(findings, found...)
+	}
+	return findings, nil
+}
+
+// checkScaleQuestionnaires 检查医学量表关联的问卷是否存在
+func checkScaleQuestionnaires(ctx context.Context, store doctorStore, questionnaires map[string]bool) ([]doctorFinding, error) {
+	scales, err := store.ScaleQuestionnaireCodes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load medical scales: %w", err)
+	}
+
+	var findings []doctorFinding
+	for _, scaleCode := range sortedKeys(scales) {
+		questionnaireCode := scales[scaleCode]
+		if questionnaireCode == "" || questionnaires[questionnaireCode] {
+			continue
+		}
+		findings = append(findings, doctorFinding{
+			Check:   CheckScaleQuestionnaire,
+			Subject: "medical scale " + scaleCode,
+			Detail:  fmt.Sprintf("references missing or deleted questionnaire %s", questionnaireCode),
+		})
+	}
+	return findings, nil
+}
+
+// checkAnswerSheetQuestionnaires 检查答卷所答问卷是否存在，按问卷汇总
+func checkAnswerSheetQuestionnaires(ctx context.Context, store doctorStore, questionnaires map[string]bool) ([]doctorFinding, error) {
+	counts, err := store.AnswerSheetCountsByQuestionnaire(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count answer sheets: %w", err)
+	}
+
+	var findings []doctorFinding
+	for _, questionnaireCode := range sortedKeys(counts) {
+		if questionnaires[questionnaireCode] {
+			continue
+		}
+		findings = append(findings, doctorFinding{
+			Check:   CheckAnswerSheetQuestionnaire,
+			Subject: "questionnaire " + questionnaireCode,
+			Detail:  fmt.Sprintf("is missing or deleted but still has %d answer sheets", counts[questionnaireCode]),
+		})
+	}
+	return findings, nil
+}
+
+// checkReportAnswerSheets 检查解读报告引用的答卷是否存在，每批核对 doctorReportBatchSize 个答卷
+func checkReportAnswerSheets(ctx context.Context, store doctorStore, _ map[string]bool) ([]doctorFinding, error) {
+	ids, err := store.ReportAnswerSheetIDs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load interpret reports: %w", err)
+	}
+
+	var findings []doctorFinding
+	for start := 0; start < len(ids); start += doctorReportBatchSize {
+		batch := ids[start:min(start+doctorReportBatchSize, len(ids))]
+		existing, err := store.ExistingAnswerSheetIDs(ctx, batch)
+		if err != nil {
+			return nil, fmt.Errorf("failed to look up answer sheets: %w", err)
+		}
+		for _, id := range batch {
+			if existing[id] {
+				continue
+			}
+			findings = append(findings, doctorFinding{
+				Check:   CheckReportAnswerSheet,
+				Subject: fmt.Sprintf("interpret report of answer sheet %d", id),
+				Detail:  "is orphaned, the answer sheet is missing or deleted",
+			})
+		}
+	}
+	return findings, nil
+}
+
+// printFindings 输出检查结果
+func printFindings(out io.Writer, findings []doctorFinding) {
+	if len(findings) == 0 {
+		fmt.Fprintln(out, "No inconsistencies found")
+		return
+	}
+	for _, f := range findings {
+		fmt.Fprintf(out, "[%s] %s %s\n", f.Check, f.Subject, f.Detail)
+	}
+	fmt.Fprintf(out, "Found %d inconsistencies\n", len(findings))
+}
+
+// sortedKeys 返回按字典序排列的键，保证输出稳定
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
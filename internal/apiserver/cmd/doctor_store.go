@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	answersheetInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/answersheet"
+	interpretReportInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/interpret-report"
+	medicalScaleInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/medical-scale"
+	questionnaireInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
+)
+
+// notDeleted 匹配未软删除的文档，deleted_at 为 null 或不存在
+var notDeleted = bson.M{"deleted_at": nil}
+
+// mongoDoctorStore 从 MongoDB 读取一致性检查所需的数据，只做查询和聚合
+type mongoDoctorStore struct {
+	questionnaires   *mongo.Collection
+	medicalScales    *mongo.Collection
+	answerSheets     *mongo.Collection
+	interpretReports *mongo.Collection
+}
+
+// newMongoDoctorStore 创建 MongoDB 一致性检查数据源
+func newMongoDoctorStore(db *mongo.Database) *mongoDoctorStore {
+	return &mongoDoctorStore{
+		questionnaires:   db.Collection(questionnaireInfra.QuestionnairePO{}.CollectionName()),
+		medicalScales:    db.Collection(medicalScaleInfra.MedicalScalePO{}.CollectionName()),
+		answerSheets:     db.Collection(answersheetInfra.AnswerSheetPO{}.CollectionName()),
+		interpretReports: db.Collection(interpretReportInfra.InterpretReportPO{}.CollectionName()),
+	}
+}
+
+// ActiveQuestionnaireCodes 获取未删除的问卷编码
+func (s *mongoDoctorStore) ActiveQuestionnaireCodes(ctx context.Context) (map[string]bool, error) {
+	values, err := s.questionnaires.Distinct(ctx, "code", notDeleted)
+	if err != nil {
+		return nil, err
+	}
+	codes := make(map[string]bool, len(values))
+	for _, v := range values {
+		if code, ok := v.(string); ok {
+			codes[code] = true
+		}
+	}
+	return codes, nil
+}
+
+// ScaleQuestionnaireCodes 获取未删除的医学量表编码及其关联的问卷编码
+func (s *mongoDoctorStore) ScaleQuestionnaireCodes(ctx context.Context) (map[string]string, error) {
+	opts := options.Find().SetProjection(bson.M{"code": 1, "questionnaire_code": 1})
+	cursor, err := s.medicalScales.Find(ctx, notDeleted, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	scales := make(map[string]string)
+	for cursor.Next(ctx) {
+		var doc struct {
+			Code              string `bson:"code"`
+			QuestionnaireCode string `bson:"questionnaire_code"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		scales[doc.Code] = doc.QuestionnaireCode
+	}
+	return scales, cursor.Err()
+}
+
+// AnswerSheetCountsByQuestionnaire 按问卷编码统计未删除的答卷数
+func (s *mongoDoctorStore) AnswerSheetCountsByQuestionnaire(ctx context.Context) (map[string]int64, error) {
+	cursor, err := s.answerSheets.Aggregate(ctx, mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted}},
+		{{Key: "$group", Value: bson.M{"_id": "$questionnaire_code", "count": bson.M{"$sum": 1}}}},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[string]int64)
+	for cursor.Next(ctx) {
+		var doc struct {
+			QuestionnaireCode string `bson:"_id"`
+			Count             int64  `bson:"count"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		counts[doc.QuestionnaireCode] = doc.Count
+	}
+	return counts, cursor.Err()
+}
+
+// ReportAnswerSheetIDs 获取未删除的解读报告引用的答卷 ID
+func (s *mongoDoctorStore) ReportAnswerSheetIDs(ctx context.Context) ([]uint64, error) {
+	return distinctIDs(ctx, s.interpretReports, "answer_sheet_id", notDeleted)
+}
+
+// ExistingAnswerSheetIDs 返回 ids 中存在且未删除的答卷 ID
+func (s *mongoDoctorStore) ExistingAnswerSheetIDs(ctx context.Context, ids []uint64) (map[uint64]bool, error) {
+	found, err := distinctIDs(ctx, s.answerSheets, "domain_id", bson.M{
+		"domain_id":  bson.M{"$in": ids},
+		"deleted_at": nil,
+	})
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[uint64]bool, len(found))
+	for _, id := range found {
+		existing[id] = true
+	}
+	return existing, nil
+}
+
+// distinctIDs 查询字段的不同取值，数值按 uint64 返回
+func distinctIDs(ctx context.Context, collection *mongo.Collection, field string, filter bson.M) ([]uint64, error) {
+	values, err := collection.Distinct(ctx, field, filter)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]uint64, 0, len(values))
+	for _, v := range values {
+		switch id := v.(type) {
+		case int64:
+			ids = append(ids, uint64(id))
+		case int32:
+			ids = append(ids, uint64(id))
+		}
+	}
+	return ids, nil
+}
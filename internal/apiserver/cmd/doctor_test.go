@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+)
+
+// seededDoctorStore 内存中的一致性检查数据
+type seededDoctorStore struct {
+	questionnaires map[string]bool
+	scales         map[string]string
+	answerSheets   map[string]int64
+	reports        []uint64
+	answerSheetIDs map[uint64]bool
+}
+
+func (s *seededDoctorStore) ActiveQuestionnaireCodes(ctx context.Context) (map[string]bool, error) {
+	return s.questionnaires, nil
+}
+
+func (s *seededDoctorStore) ScaleQuestionnaireCodes(ctx context.Context) (map[string]string, error) {
+	return s.scales, nil
+}
+
+func (s *seededDoctorStore) AnswerSheetCountsByQuestionnaire(ctx context.Context) (map[string]int64, error) {
+	return s.answerSheets, nil
+}
+
+func (s *seededDoctorStore) ReportAnswerSheetIDs(ctx context.Context) ([]uint64, error) {
+	return s.reports, nil
+}
+
+func (s *seededDoctorStore) ExistingAnswerSheetIDs(ctx context.Context, ids []uint64) (map[uint64]bool, error) {
+	existing := make(map[uint64]bool)
+	for _, id := range ids {
+		if s.answerSheetIDs[id] {
+			existing[id] = true
+		}
+	}
+	return existing, nil
+}
+
+// newConsistentDoctorStore 创建一份一致的数据：量表 MS1 关联问卷 PHQ9，答卷 1、2 及其解读报告
+func newConsistentDoctorStore() *seededDoctorStore {
+	return &seededDoctorStore{
+		questionnaires: map[string]bool{"PHQ9": true},
+		scales:         map[string]string{"MS1": "PHQ9", "MS2": ""},
+		answerSheets:   map[string]int64{"PHQ9": 2},
+		reports:        []uint64{1, 2},
+		answerSheetIDs: map[uint64]bool{1: true, 2: true},
+	}
+}
+
+func TestDiagnose_ConsistentData(t *testing.T) {
+	findings, err := diagnose(context.Background(), newConsistentDoctorStore())
+	if err != nil {
+		t.Fatalf("diagnose() error = %v", err)
+	}
+	if len(findings) != 0 {
+		t.Errorf("findings = %v, want none", findings)
+	}
+}
+
+func TestDiagnose_ReportsSeededInconsistencies(t *testing.T) {
+	store := newConsistentDoctorStore()
+	// 问卷 GAD7 已删除，但仍被量表 MS3 和 4 份答卷引用；答卷 3 已删除，其解读报告成为孤立数据
+	store.scales["MS3"] = "GAD7"
+	store.answerSheets["GAD7"] = 4
+	store.reports = append(store.reports, 3)
+
+	findings, err := diagnose(context.Background(), store)
+	if err != nil {
+		t.Fatalf("diagnose() error = %v", err)
+	}
+
+	var out bytes.Buffer
+	printFindings(&out, findings)
+	for _, want := range []string{
+		"[scale-questionnaire] medical scale MS3 references missing or deleted questionnaire GAD7",
+		"[answersheet-questionnaire] questionnaire GAD7 is missing or deleted but still has 4 answer sheets",
+		"[report-answersheet] interpret report of answer sheet 3 is orphaned",
+		"Found 3 inconsistencies",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("output does not contain %q:\n%s", want, out.String())
+		}
+	}
+}
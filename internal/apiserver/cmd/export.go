@@ -0,0 +1,253 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	answersheetApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/spss"
+	answersheetInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/answersheet"
+	questionnaireInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+	"github.com/yshujie/questionnaire-scale/pkg/database/databases"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	cliflag "github.com/yshujie/questionnaire-scale/pkg/flag"
+)
+
+// exportDateLayout 导出时间范围的日期格式
+const exportDateLayout = "2006-01-02"
+
+// 导出格式
+const (
+	ExportFormatJSON = "json"
+	ExportFormatCSV  = "csv"
+)
+
+// ExportOptions 导出命令选项
+type ExportOptions struct {
+	QuestionnaireCode string
+	Format            string
+	OutputFile        string
+	FromDate          string
+	ToDate            string
+	// SPSSSyntaxFile SPSS 语法文件路径，为空时不生成
+	SPSSSyntaxFile string
+}
+
+// NewExportOptions 创建导出命令选项
+func NewExportOptions() *ExportOptions {
+	return &ExportOptions{
+		Format: ExportFormatJSON,
+	}
+}
+
+// Flags 返回导出命令的命令行参数
+func (o *ExportOptions) Flags() (fss cliflag.NamedFlagSets) {
+	fs := fss.FlagSet("export")
+	fs.StringVar(&o.QuestionnaireCode, "questionnaire-code", o.QuestionnaireCode, "Code of the questionnaire whose answer sheets are exported.")
+	fs.StringVar(&o.Format, "format", o.Format, "Output format, one of json|csv.")
+	fs.StringVar(&o.OutputFile, "output-file", o.OutputFile, "Path of the file the answer sheets are written to.")
+	fs.StringVar(&o.FromDate, "from-date", o.FromDate, "Only export answer sheets created on or after this date (YYYY-MM-DD).")
+	fs.StringVar(&o.ToDate, "to-date", o.ToDate, "Only export answer sheets created on or before this date (YYYY-MM-DD).")
+	fs.StringVar(&o.SPSSSyntaxFile, "spss-syntax-file", o.SPSSSyntaxFile, "Path of the SPSS syntax file with variable and value labels for the exported CSV. "+
+		"When set, the CSV gets one column per question (one 0/1 column per option for checkbox questions).")
+	return fss
+}
+
+// Validate 校验导出命令选项
+func (o *ExportOptions) Validate() []error {
+	var errs []error
+
+	if o.QuestionnaireCode == "" {
+		errs = append(errs, app.NewOptionsValidationError("questionnaire-code", "is required"))
+	}
+	if o.Format != ExportFormatJSON && o.Format != ExportFormatCSV {
+		errs = append(errs, app.NewOptionsValidationError("format", "must be one of json|csv, got %q", o.Format))
+	}
+	if o.OutputFile == "" {
+		errs = append(errs, app.NewOptionsValidationError("output-file", "is required"))
+	}
+	if o.SPSSSyntaxFile != "" && o.Format != ExportFormatCSV {
+		errs = append(errs, app.NewOptionsValidationError("spss-syntax-file", "is only supported with --format=csv"))
+	}
+	if _, _, err := o.dateRange(); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
+}
+
+// dateRange 解析导出时间范围，截止日期包含当天
+func (o *ExportOptions) dateRange() (from, to time.Time, err error) {
+	if o.FromDate != "" {
+		if from, err = time.ParseInLocation(exportDateLayout, o.FromDate, time.Local); err != nil {
+			return from, to, fmt.Errorf("invalid --from-date %q: %w", o.FromDate, err)
+		}
+	}
+	if o.ToDate != "" {
+		if to, err = time.ParseInLocation(exportDateLayout, o.ToDate, time.Local); err != nil {
+			return from, to, fmt.Errorf("invalid --to-date %q: %w", o.ToDate, err)
+		}
+		to = to.AddDate(0, 0, 1)
+	}
+	if !from.IsZero() && !to.IsZero() && !from.Before(to) {
+		return from, to, fmt.Errorf("--from-date must not be after --to-date")
+	}
+	return from, to, nil
+}
+
+// NewExportCommand 创建答卷导出命令
+// 直接连接 MongoDB 导出答卷，不经过 HTTP 接口
+func NewExportCommand() *app.Command {
+	opts := NewExportOptions()
+	return app.NewCommand("export",
+		"Export answer sheets of a questionnaire to a JSON or CSV file",
+		app.WithCommandOptions(opts),
+		app.WithCommandRunFunc(runExport(opts)),
+	)
+}
+
+// runExport 执行导出
+func runExport(opts *ExportOptions) app.RunCommandFunc {
+	return func(args []string) error {
+		if errs := opts.Validate(); len(errs) != 0 {
+			app.PrintOptionsValidationErrors(os.Stderr, errs)
+			return errors.NewAggregate(errs)
+		}
+
+		ctx := context.Background()
+
+		mongoDB, closeMongo, err := connectMongoDB()
+		if err != nil {
+			return err
+		}
+		defer closeMongo()
+
+		var variables []spss.Variable
+		if opts.SPSSSyntaxFile != "" {
+			qDomain, err := questionnaireInfra.NewRepository(mongoDB).FindByCode(ctx, opts.QuestionnaireCode)
+			if err != nil {
+				return fmt.Errorf("failed to load questionnaire %s: %w", opts.QuestionnaireCode, err)
+			}
+			if variables, err = writeSPSSSyntax(opts.SPSSSyntaxFile, qDomain); err != nil {
+				return err
+			}
+		}
+
+		file, err := os.Create(opts.OutputFile)
+		if err != nil {
+			return fmt.Errorf("failed to create output file: %w", err)
+		}
+		defer file.Close()
+
+		exporter := answersheetApp.NewExporter(answersheetInfra.NewRepository(mongoDB))
+		count, err := exportAnswerSheets(ctx, exporter, opts, file, newProgressCounter(os.Stderr), variables)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("Exported %d answer sheets to %s\n", count, opts.OutputFile)
+		if opts.SPSSSyntaxFile != "" {
+			fmt.Printf("Wrote SPSS variable and value labels to %s\n", opts.SPSSSyntaxFile)
+		}
+		return nil
+	}
+}
+
+// writeSPSSSyntax 按问卷生成 SPSS 语法文件，返回需要在 CSV 中追加的问题变量
+func writeSPSSSyntax(path string, qDomain *questionnaire.Questionnaire) ([]spss.Variable, error) {
+	variables, err := spss.BuildVariables(qDomain)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create SPSS syntax file: %w", err)
+	}
+	defer file.Close()
+
+	title := fmt.Sprintf("Labels for answer sheets of questionnaire %s@%s %s",
+		qDomain.GetCode().Value(), qDomain.GetVersion().Value(), qDomain.GetTitle())
+	if err := spss.WriteSyntax(file, title, append(append([]spss.Variable(nil), csvHeaderVariables...), variables...)); err != nil {
+		return nil, fmt.Errorf("failed to write SPSS syntax file: %w", err)
+	}
+	return variables, nil
+}
+
+// exportAnswerSheets 将答卷逐条写入输出，返回导出数量
+// variables 为 CSV 中追加的问题变量，为空时只导出固定列
+func exportAnswerSheets(ctx context.Context, exporter port.AnswerSheetExporter, opts *ExportOptions, out io.Writer, progress progressTicker, variables []spss.Variable) (int, error) {
+	from, to, err := opts.dateRange()
+	if err != nil {
+		return 0, err
+	}
+
+	writer, err := newExportWriter(opts.Format, out, variables)
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	filter := dto.AnswerSheetExportFilterDTO{
+		QuestionnaireCode: opts.QuestionnaireCode,
+		From:              from,
+		To:                to,
+	}
+	err = exporter.Export(ctx, filter, func(record dto.AnswerSheetExportDTO) error {
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("failed to write answer sheet %s: %w", record.AnswerSheet.ID.String(), err)
+		}
+		count++
+		return progress.Add(1)
+	})
+	if err != nil {
+		return count, err
+	}
+
+	if err := writer.Close(); err != nil {
+		return count, fmt.Errorf("failed to flush output file: %w", err)
+	}
+	return count, progress.Finish()
+}
+
+// connectMongoDB 使用 apiserver 配置文件中的 mongodb 配置建立连接
+func connectMongoDB() (*mongo.Database, func(), error) {
+	mongoOpts := genericoptions.NewMongoDBOptions()
+	if err := viper.UnmarshalKey("mongodb", mongoOpts); err != nil {
+		return nil, nil, fmt.Errorf("failed to read mongodb config: %w", err)
+	}
+
+	conn := databases.NewMongoDBConnection(&databases.MongoConfig{
+		URL:                      mongoOpts.URL,
+		UseSSL:                   mongoOpts.UseSSL,
+		SSLInsecureSkipVerify:    mongoOpts.SSLInsecureSkipVerify,
+		SSLAllowInvalidHostnames: mongoOpts.SSLAllowInvalidHostnames,
+		SSLCAFile:                mongoOpts.SSLCAFile,
+		SSLPEMKeyfile:            mongoOpts.SSLPEMKeyfile,
+		MaxPoolSize:              mongoOpts.MaxPoolSize,
+		MinPoolSize:              mongoOpts.MinPoolSize,
+		MaxConnIdleTime:          mongoOpts.MaxConnIdleTime,
+	})
+	if err := conn.Connect(); err != nil {
+		return nil, nil, err
+	}
+
+	client, ok := conn.GetClient().(*mongo.Client)
+	if !ok {
+		_ = conn.Close()
+		return nil, nil, fmt.Errorf("failed to cast client to *mongo.Client")
+	}
+
+	return client.Database(viper.GetString("mongodb.database")), func() { _ = conn.Close() }, nil
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/spss"
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
+
+// fakeExporter 返回固定答卷并记录收到的过滤条件
+type fakeExporter struct {
+	records []dto.AnswerSheetExportDTO
+	filter  dto.AnswerSheetExportFilterDTO
+}
+
+func (f *fakeExporter) Export(ctx context.Context, filter dto.AnswerSheetExportFilterDTO, handle func(record dto.AnswerSheetExportDTO) error) error {
+	f.filter = filter
+	for _, record := range f.records {
+		if err := handle(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// nopProgress 不输出的导出进度
+type nopProgress struct{ ticks int }
+
+func (p *nopProgress) Add(n int) error { p.ticks += n; return nil }
+func (p *nopProgress) Finish() error   { return nil }
+
+func newFakeExporter() *fakeExporter {
+	createdAt := time.Date(2024, 3, 1, 8, 30, 0, 0, time.UTC)
+	return &fakeExporter{
+		records: []dto.AnswerSheetExportDTO{
+			{
+				AnswerSheet: dto.AnswerSheetDTO{
+					ID:                v1.NewID(1),
+					QuestionnaireCode: "QN1",
+					Title:             "睡眠质量问卷",
+					Score:             3,
+					WriterID:          10,
+					TesteeID:          20,
+					Answers: []dto.AnswerDTO{
+						{QuestionCode: "Q1", QuestionType: "Radio", Score: 1, Value: "B"},
+						{QuestionCode: "Q2", QuestionType: "Radio", Score: 2, Value: "C"},
+					},
+				},
+				CreatedAt: createdAt,
+			},
+			{
+				AnswerSheet: dto.AnswerSheetDTO{ID: v1.NewID(2), QuestionnaireCode: "QN1", Title: "睡眠质量问卷"},
+				CreatedAt:   createdAt.Add(time.Hour),
+			},
+		},
+	}
+}
+
+func TestExportAnswerSheets_JSON(t *testing.T) {
+	exporter := newFakeExporter()
+	progress := &nopProgress{}
+	opts := &ExportOptions{
+		QuestionnaireCode: "QN1",
+		Format:            ExportFormatJSON,
+		FromDate:          "2024-03-01",
+		ToDate:            "2024-03-31",
+	}
+
+	var out bytes.Buffer
+	count, err := exportAnswerSheets(context.Background(), exporter, opts, &out, progress, nil)
+	if err != nil {
+		t.Fatalf("exportAnswerSheets() error = %v", err)
+	}
+	if count != 2 || progress.ticks != 2 {
+		t.Errorf("count = %d, ticks = %d, want 2", count, progress.ticks)
+	}
+
+	// 截止日期包含当天
+	wantTo := time.Date(2024, 4, 1, 0, 0, 0, 0, time.Local)
+	if exporter.filter.QuestionnaireCode != "QN1" || !exporter.filter.To.Equal(wantTo) {
+		t.Errorf("filter = %+v, want code QN1 and to %s", exporter.filter, wantTo)
+	}
+
+	var records []exportRecord
+	if err := json.Unmarshal(out.Bytes(), &records); err != nil {
+		t.Fatalf("output is not a JSON array: %v\n%s", err, out.String())
+	}
+	if len(records) != 2 {
+		t.Fatalf("records = %d, want 2", len(records))
+	}
+	if records[0].ID != 1 || len(records[0].Answers) != 2 || records[0].Answers[1].Value != "C" {
+		t.Errorf("first record = %+v", records[0])
+	}
+	if records[0].CreatedAt != "2024-03-01T08:30:00Z" {
+		t.Errorf("created_at = %s, want 2024-03-01T08:30:00Z", records[0].CreatedAt)
+	}
+}
+
+func TestExportAnswerSheets_CSV(t *testing.T) {
+	opts := &ExportOptions{QuestionnaireCode: "QN1", Format: ExportFormatCSV}
+
+	var out bytes.Buffer
+	if _, err := exportAnswerSheets(context.Background(), newFakeExporter(), opts, &out, &nopProgress{}, nil); err != nil {
+		t.Fatalf("exportAnswerSheets() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if len(rows) != 3 {
+		t.Fatalf("rows = %d, want header + 2", len(rows))
+	}
+	if rows[0][0] != "id" || rows[1][0] != "1" || rows[1][4] != "3" {
+		t.Errorf("unexpected rows: %v", rows[:2])
+	}
+
+	var answers []exportAnswer
+	if err := json.Unmarshal([]byte(rows[1][8]), &answers); err != nil || len(answers) != 2 {
+		t.Errorf("answers column = %q, err = %v", rows[1][8], err)
+	}
+}
+
+func TestExportAnswerSheets_CSVWithSPSSVariables(t *testing.T) {
+	opts := &ExportOptions{QuestionnaireCode: "QN1", Format: ExportFormatCSV}
+	variables := []spss.Variable{
+		{Name: "q1", QuestionCode: "Q1"},
+		{Name: "q2", QuestionCode: "Q2"},
+		{Name: "q3", QuestionCode: "Q3"},
+	}
+
+	var out bytes.Buffer
+	if _, err := exportAnswerSheets(context.Background(), newFakeExporter(), opts, &out, &nopProgress{}, variables); err != nil {
+		t.Fatalf("exportAnswerSheets() error = %v", err)
+	}
+
+	rows, err := csv.NewReader(&out).ReadAll()
+	if err != nil {
+		t.Fatalf("output is not valid CSV: %v", err)
+	}
+	if got := rows[0][len(csvHeader):]; len(got) != 3 || got[0] != "q1" || got[2] != "q3" {
+		t.Errorf("variable columns = %v, want q1 q2 q3", got)
+	}
+	if got := rows[1][len(csvHeader):]; got[0] != "B" || got[1] != "C" || got[2] != "" {
+		t.Errorf("variable values = %v, want B C and empty", got)
+	}
+}
+
+func TestExportOptions_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    ExportOptions
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			opts: ExportOptions{QuestionnaireCode: "QN1", Format: ExportFormatCSV, OutputFile: "out.csv", FromDate: "2024-01-01", ToDate: "2024-01-01"},
+		},
+		{
+			name:    "unsupported format",
+			opts:    ExportOptions{QuestionnaireCode: "QN1", Format: "xml", OutputFile: "out.xml"},
+			wantErr: true,
+		},
+		{
+			name:    "from after to",
+			opts:    ExportOptions{QuestionnaireCode: "QN1", Format: ExportFormatJSON, OutputFile: "out.json", FromDate: "2024-02-01", ToDate: "2024-01-01"},
+			wantErr: true,
+		},
+		{
+			name:    "spss syntax requires csv",
+			opts:    ExportOptions{QuestionnaireCode: "QN1", Format: ExportFormatJSON, OutputFile: "out.json", SPSSSyntaxFile: "labels.sps"},
+			wantErr: true,
+		},
+		{
+			name:    "missing questionnaire code",
+			opts:    ExportOptions{Format: ExportFormatJSON, OutputFile: "out.json"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if errs := tt.opts.Validate(); (len(errs) != 0) != tt.wantErr {
+				t.Errorf("Validate() errors = %v, wantErr %v", errs, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/spss"
+)
+
+// exportRecord 导出文件中的答卷记录
+type exportRecord struct {
+	ID                   uint64         `json:"id"`
+	QuestionnaireCode    string         `json:"questionnaire_code"`
+	QuestionnaireVersion string         `json:"questionnaire_version"`
+	Title                string         `json:"title"`
+	Score                float64        `json:"score"`
+	WriterID             uint64         `json:"writer_id"`
+	TesteeID             uint64         `json:"testee_id"`
+	CreatedAt            string         `json:"created_at"`
+	Answers              []exportAnswer `json:"answers"`
+}
+
+// exportAnswer 导出文件中的答案
+type exportAnswer struct {
+	QuestionCode string  `json:"question_code"`
+	QuestionType string  `json:"question_type"`
+	Score        float64 `json:"score"`
+	Value        any     `json:"value"`
+}
+
+// csvHeader CSV 导出表头，answers 列为答案列表的 JSON
+var csvHeader = []string{
+	"id", "questionnaire_code", "questionnaire_version", "title", "score",
+	"writer_id", "testee_id", "created_at", "answers",
+}
+
+// csvHeaderVariables CSV 表头各列在 SPSS 语法文件中的变量标签
+var csvHeaderVariables = []spss.Variable{
+	{Name: "id", Label: "答卷ID"},
+	{Name: "questionnaire_code", Label: "问卷编码"},
+	{Name: "questionnaire_version", Label: "问卷版本"},
+	{Name: "title", Label: "答卷标题"},
+	{Name: "score", Label: "总分"},
+	{Name: "writer_id", Label: "填写人ID"},
+	{Name: "testee_id", Label: "被测试者ID"},
+	{Name: "created_at", Label: "提交时间"},
+	{Name: "answers", Label: "答案（JSON）"},
+}
+
+// newExportRecord 将导出 DTO 转换为导出记录
+func newExportRecord(record dto.AnswerSheetExportDTO) exportRecord {
+	sheet := record.AnswerSheet
+	answers := make([]exportAnswer, 0, len(sheet.Answers))
+	for _, a := range sheet.Answers {
+		answers = append(answers, exportAnswer{
+			QuestionCode: a.QuestionCode,
+			QuestionType: a.QuestionType,
+			Score:        a.Score,
+			Value:        a.Value,
+		})
+	}
+
+	return exportRecord{
+		ID:                   sheet.ID.Value(),
+		QuestionnaireCode:    sheet.QuestionnaireCode,
+		QuestionnaireVersion: sheet.QuestionnaireVersion,
+		Title:                sheet.Title,
+		Score:                sheet.Score,
+		WriterID:             sheet.WriterID,
+		TesteeID:             sheet.TesteeID,
+		CreatedAt:            record.CreatedAt.Format(time.RFC3339),
+		Answers:              answers,
+	}
+}
+
+// exportWriter 导出写入器
+type exportWriter interface {
+	// Write 写入一条答卷
+	Write(record dto.AnswerSheetExportDTO) error
+	// Close 结束写入并刷新缓冲
+	Close() error
+}
+
+// newExportWriter 根据格式创建导出写入器
+// variables 不为空时 CSV 在固定列之后为每个变量追加一列答案，与 SPSS 语法文件中的变量对应
+func newExportWriter(format string, w io.Writer, variables []spss.Variable) (exportWriter, error) {
+	switch format {
+	case ExportFormatJSON:
+		return &jsonExportWriter{w: w}, nil
+	case ExportFormatCSV:
+		return &csvExportWriter{w: csv.NewWriter(w), variables: variables}, nil
+	default:
+		return nil, fmt.Errorf("unsupported export format: %s", format)
+	}
+}
+
+// jsonExportWriter 以 JSON 数组格式逐条写入，避免一次性加载全部答卷
+type jsonExportWriter struct {
+	w     io.Writer
+	count int
+}
+
+// Write 写入一条答卷
+func (j *jsonExportWriter) Write(record dto.AnswerSheetExportDTO) error {
+	data, err := json.Marshal(newExportRecord(record))
+	if err != nil {
+		return err
+	}
+
+	prefix := ",\n  "
+	if j.count == 0 {
+		prefix = "[\n  "
+	}
+	if _, err := io.WriteString(j.w, prefix); err != nil {
+		return err
+	}
+	if _, err := j.w.Write(data); err != nil {
+		return err
+	}
+	j.count++
+	return nil
+}
+
+// Close 结束 JSON 数组
+func (j *jsonExportWriter) Close() error {
+	suffix := "\n]\n"
+	if j.count == 0 {
+		suffix = "[]\n"
+	}
+	_, err := io.WriteString(j.w, suffix)
+	return err
+}
+
+// csvExportWriter 以 CSV 格式逐条写入
+type csvExportWriter struct {
+	w             *csv.Writer
+	variables     []spss.Variable
+	headerWritten bool
+}
+
+// Write 写入一条答卷
+func (c *csvExportWriter) Write(record dto.AnswerSheetExportDTO) error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+
+	r := newExportRecord(record)
+	answers, err := json.Marshal(r.Answers)
+	if err != nil {
+		return err
+	}
+
+	row := []string{
+		strconv.FormatUint(r.ID, 10),
+		r.QuestionnaireCode,
+		r.QuestionnaireVersion,
+		r.Title,
+		strconv.FormatFloat(r.Score, 'f', -1, 64),
+		strconv.FormatUint(r.WriterID, 10),
+		strconv.FormatUint(r.TesteeID, 10),
+		r.CreatedAt,
+		string(answers),
+	}
+	if len(c.variables) > 0 {
+		values := make(map[string]any, len(r.Answers))
+		for _, a := range r.Answers {
+			values[a.QuestionCode] = a.Value
+		}
+		for _, v := range c.variables {
+			row = append(row, v.Value(values[v.QuestionCode]))
+		}
+	}
+	return c.w.Write(row)
+}
+
+// Close 刷新缓冲，无数据时仍输出表头
+func (c *csvExportWriter) Close() error {
+	if err := c.writeHeader(); err != nil {
+		return err
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
+
+// writeHeader 写入表头
+func (c *csvExportWriter) writeHeader() error {
+	if c.headerWritten {
+		return nil
+	}
+	c.headerWritten = true
+
+	header := append([]string(nil), csvHeader...)
+	for _, v := range c.variables {
+		header = append(header, v.Name)
+	}
+	return c.w.Write(header)
+}
+
+// progressTicker 导出进度
+type progressTicker interface {
+	// Add 增加已导出数量
+	Add(n int) error
+	// Finish 结束进度显示
+	Finish() error
+}
+
+// progressCounter 在终端同一行刷新已导出数量
+type progressCounter struct {
+	w     io.Writer
+	count int
+}
+
+// newProgressCounter 创建导出进度计数器
+func newProgressCounter(w io.Writer) *progressCounter {
+	return &progressCounter{w: w}
+}
+
+// Add 增加已导出数量
+func (p *progressCounter) Add(n int) error {
+	p.count += n
+	_, err := fmt.Fprintf(p.w, "\rexporting answer sheets... %d", p.count)
+	return err
+}
+
+// Finish 结束进度显示
+func (p *progressCounter) Finish() error {
+	_, err := fmt.Fprintf(p.w, "\rexporting answer sheets... %d done\n", p.count)
+	return err
+}
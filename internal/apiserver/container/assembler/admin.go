@@ -0,0 +1,76 @@
+package assembler
+
+import (
+	redis "github.com/go-redis/redis/v7"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	adminApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/admin"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin/port"
+	systemStatsInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/system-stats"
+	cacheInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/redis/cache"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// AdminModule 管理员模块
+type AdminModule struct {
+	// repository 层
+	StatsRepo    port.SystemStatsRepository
+	SummaryCache port.SystemSummaryCache
+
+	// service 层
+	SummaryQueryer port.SystemSummaryQueryer
+
+	// handler 层
+	AdminHandler *handler.AdminHandler
+}
+
+// NewAdminModule 创建管理员模块
+func NewAdminModule() *AdminModule {
+	return &AdminModule{}
+}
+
+// Initialize 初始化模块
+// params[0] 为 MongoDB 数据库，params[1] 为 Redis 客户端（可为空，为空时系统概览不缓存）
+func (m *AdminModule) Initialize(params ...interface{}) error {
+	mongoDB := params[0].(*mongo.Database)
+	if mongoDB == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "database connection is nil")
+	}
+
+	// 初始化 repository 层
+	m.StatsRepo = systemStatsInfra.NewRepository(mongoDB)
+	if len(params) > 1 {
+		if redisClient, ok := params[1].(redis.UniversalClient); ok && redisClient != nil {
+			m.SummaryCache = cacheInfra.NewSystemSummaryCache(redisClient)
+		}
+	}
+
+	// 初始化 service 层
+	m.SummaryQueryer = adminApp.NewSummaryQueryer(m.StatsRepo, m.SummaryCache)
+
+	// 初始化 handler 层
+	m.AdminHandler = handler.NewAdminHandler(m.SummaryQueryer)
+
+	return nil
+}
+
+// Cleanup 清理模块资源
+func (m *AdminModule) Cleanup() error {
+	return nil
+}
+
+// CheckHealth 检查模块健康状态
+func (m *AdminModule) CheckHealth() error {
+	return nil
+}
+
+// ModuleInfo 返回模块信息
+func (m *AdminModule) ModuleInfo() ModuleInfo {
+	return ModuleInfo{
+		Name:        "admin",
+		Version:     "1.0.0",
+		Description: "管理员模块",
+	}
+}
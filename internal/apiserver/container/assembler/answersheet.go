@@ -1,29 +1,59 @@
 package assembler
 
 import (
+	"time"
+
+	redis "github.com/go-redis/redis/v7"
+	"github.com/spf13/viper"
+
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	hookPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 	"go.mongodb.org/mongo-driver/mongo"
 
+	irMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/interpret-report"
+	msMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/medical-scale"
 	qnMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
 
 	asApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/answersheet"
 	asMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/answersheet"
+	fileMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/file"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/objectstorage"
+	uploadRedisInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/redis/upload"
 	asHandler "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/scheduler"
 )
 
 // AnswersheetModule 答卷模块
 type AnswersheetModule struct {
 	// repository 层
-	AnswersheetRepo port.AnswerSheetRepositoryMongo
+	AnswersheetRepo   port.AnswerSheetRepositoryMongo
+	FileStorageRepo   port.FileStorageRepository
+	PendingUploadRepo port.PendingUploadRepository
 
 	// handler 层
-	AnswersheetHandler *asHandler.AnswerSheetHandler
+	AnswersheetHandler   *asHandler.AnswerSheetHandler
+	ExportHandler        *asHandler.AnswerSheetExportHandler
+	RescoreHandler       *asHandler.RescoreHandler
+	FileHandler          *asHandler.FileHandler
+	ScoringReportHandler *asHandler.ScoringReportHandler
+	SignedUploadHandler  *asHandler.SignedUploadHandler
+	SubmissionHandler    *asHandler.SubmissionStreamHandler
 
 	// service 层
-	AnswersheetSaver   port.AnswerSheetSaver
-	AnswersheetQueryer port.AnswerSheetQueryer
+	AnswersheetSaver     port.AnswerSheetSaver
+	AnswersheetQueryer   port.AnswerSheetQueryer
+	AnswersheetSubmitter port.AnswerSheetSubmitter
+	AnswersheetScorer    port.AnswerSheetScorer
+	AnswersheetFHIR      port.AnswerSheetFHIRConverter
+	AnswersheetExporter  port.AnswerSheetExporter
+	AnswersheetRescorer  port.AnswerSheetRescorer
+	ScoringReporter      port.AnswerSheetScoringReporter
+	FileUploader         port.FileUploader
+	SignedUploader       port.SignedUploader
+	SubmissionHub        *asHandler.SubmissionHub
 }
 
 // NewAnswersheetModule 创建答卷模块
@@ -32,25 +62,144 @@ func NewAnswersheetModule() *AnswersheetModule {
 }
 
 // Initialize 初始化模块
+// params[0] 为 MongoDB 数据库，params[1] 为定时任务调度器，params[2] 为事件通知器（可省略，省略时不通知），
+// params[3] 为 Redis 客户端（可省略，省略或未配置 MinIO/S3 时不启用签名上传），
+// params[4] 为用户活动记录器（可省略，省略时不记录提交答卷的操作），
+// params[5] 为额外的答卷提交事件发布者（可省略），如完成问卷分配，
+// params[6] 为软删除答卷清理任务配置（可省略，省略或未启用时不注册清理任务）
 func (m *AnswersheetModule) Initialize(params ...interface{}) error {
 	mongoDB := params[0].(*mongo.Database)
 	if mongoDB == nil {
 		return errors.WithCode(code.ErrModuleInitializationFailed, "database connection is nil")
 	}
+	cronScheduler, ok := params[1].(*scheduler.CronScheduler)
+	if !ok || cronScheduler == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "cron scheduler is nil")
+	}
+	var notifier hookPort.EventNotifier
+	if len(params) > 2 {
+		notifier, _ = params[2].(hookPort.EventNotifier)
+	}
+	var redisClient redis.UniversalClient
+	if len(params) > 3 {
+		redisClient, _ = params[3].(redis.UniversalClient)
+	}
+	var activityRecorder *asHandler.ActivityRecorder
+	if len(params) > 4 {
+		activityRecorder, _ = params[4].(*asHandler.ActivityRecorder)
+	}
+	var extraPublisher port.SubmissionPublisher
+	if len(params) > 5 {
+		extraPublisher, _ = params[5].(port.SubmissionPublisher)
+	}
+	var purgeOptions *genericoptions.AnswerSheetPurgeOptions
+	if len(params) > 6 {
+		purgeOptions, _ = params[6].(*genericoptions.AnswerSheetPurgeOptions)
+	}
 
 	// 初始化 repository 层
 	m.AnswersheetRepo = asMongoInfra.NewRepository(mongoDB)
+	m.FileStorageRepo = fileMongoInfra.NewRepository(mongoDB)
+	questionnaireRepo := qnMongoInfra.NewRepository(mongoDB)
+	medicalScaleRepo := msMongoInfra.NewRepository(mongoDB)
+	presigner, err := newObjectStoragePresigner()
+	if err != nil {
+		return errors.WrapC(err, code.ErrModuleInitializationFailed, "failed to create object storage presigner")
+	}
+	// 签名上传同时需要对象存储与 Redis，提交答卷时通过对象存储确认文件已上传
+	var objectInspector port.ObjectStorageInspector
+	if presigner != nil && redisClient != nil {
+		m.PendingUploadRepo = uploadRedisInfra.NewPendingUploadStore(redisClient)
+		objectInspector = presigner
+	}
 
 	// 初始化 service 层
-	m.AnswersheetSaver = asApp.NewSaver(m.AnswersheetRepo)
-	m.AnswersheetQueryer = asApp.NewQueryer(m.AnswersheetRepo, qnMongoInfra.NewRepository(mongoDB))
+	// 答卷提交事件通过 WebSocket 实时推送给订阅问卷的客户端
+	m.SubmissionHub = asHandler.NewSubmissionHub(asHandler.DefaultMaxSubmissionSubscribers)
+	publisher := asApp.SubmissionPublishers{m.SubmissionHub, extraPublisher}
+	m.AnswersheetSaver = asApp.NewSaver(m.AnswersheetRepo, questionnaireRepo, notifier, publisher, m.FileStorageRepo, m.PendingUploadRepo, objectInspector)
+	m.AnswersheetQueryer = asApp.NewQueryer(m.AnswersheetRepo, questionnaireRepo)
+	// 提交器在全部步骤成功后自行通知和发布事件，内部使用不通知、不发布的保存器
+	m.AnswersheetSubmitter = asApp.NewSubmitter(
+		asApp.NewSaver(m.AnswersheetRepo, questionnaireRepo, nil, nil, m.FileStorageRepo, m.PendingUploadRepo, objectInspector),
+		m.AnswersheetRepo,
+		questionnaireRepo,
+		medicalScaleRepo,
+		irMongoInfra.NewRepository(mongoDB),
+		notifier,
+		publisher,
+	)
+	m.AnswersheetScorer = asApp.NewScorer(questionnaireRepo, medicalScaleRepo)
+	// 答卷重新计分事件暂无订阅者
+	m.AnswersheetRescorer = asApp.NewRescorer(m.AnswersheetRepo, questionnaireRepo, medicalScaleRepo, nil)
+	m.AnswersheetFHIR = asApp.NewFHIRConverter(m.AnswersheetRepo, questionnaireRepo, m.AnswersheetSaver)
+	m.AnswersheetExporter = asApp.NewExporter(m.AnswersheetRepo)
+	m.ScoringReporter = asApp.NewScoringReporter(m.AnswersheetRepo, questionnaireRepo)
+	m.FileUploader = asApp.NewUploader(m.FileStorageRepo, 0)
+	if m.PendingUploadRepo != nil {
+		m.SignedUploader = asApp.NewSignedUploader(questionnaireRepo, m.PendingUploadRepo, presigner, objectStoragePresignExpiry())
+	}
 
 	// 初始化 handler 层
-	m.AnswersheetHandler = asHandler.NewAnswerSheetHandler(m.AnswersheetSaver, m.AnswersheetQueryer)
+	m.AnswersheetHandler = asHandler.NewAnswerSheetHandler(m.AnswersheetSaver, m.AnswersheetQueryer, m.AnswersheetSubmitter, m.AnswersheetFHIR)
+	m.AnswersheetHandler.SetActivityRecorder(activityRecorder)
+	m.ExportHandler = asHandler.NewAnswerSheetExportHandler(m.AnswersheetExporter)
+	m.RescoreHandler = asHandler.NewRescoreHandler(m.AnswersheetRescorer)
+	m.FileHandler = asHandler.NewFileHandler(m.FileUploader)
+	m.ScoringReportHandler = asHandler.NewScoringReportHandler(m.ScoringReporter)
+	m.SubmissionHandler = asHandler.NewSubmissionStreamHandler(m.SubmissionHub)
+	if m.SignedUploader != nil {
+		m.SignedUploadHandler = asHandler.NewSignedUploadHandler(m.SignedUploader)
+	}
+
+	// 注册定时任务，清理会物理删除答卷，需在配置中显式启用
+	if purgeOptions != nil && purgeOptions.Enabled {
+		cronScheduler.Register(asApp.NewCleaner(m.AnswersheetRepo, purgeOptions.Schedule, purgeOptions.Retention))
+	}
 
 	return nil
 }
 
+// objectStorageSection 返回签名上传使用的对象存储配置节，优先使用 MinIO，均未配置时返回空
+func objectStorageSection() string {
+	if viper.GetString("minio.endpoint") != "" {
+		return "minio"
+	}
+	if viper.GetString("s3.bucket") != "" {
+		return "s3"
+	}
+	return ""
+}
+
+// newObjectStoragePresigner 根据 minio.* 或 s3.* 配置创建对象存储签名器，均未配置时返回 nil
+func newObjectStoragePresigner() (*objectstorage.Presigner, error) {
+	section := objectStorageSection()
+	if section == "" {
+		return nil, nil
+	}
+
+	cfg := objectstorage.Config{
+		Endpoint:  viper.GetString(section + ".endpoint"),
+		Region:    viper.GetString(section + ".region"),
+		AccessKey: viper.GetString(section + ".access-key"),
+		SecretKey: viper.GetString(section + ".secret-key"),
+		Bucket:    viper.GetString(section + ".bucket"),
+		UseSSL:    viper.GetBool(section + ".use-ssl"),
+	}
+	if section == "s3" {
+		if cfg.Endpoint == "" {
+			cfg.Endpoint = "s3.amazonaws.com"
+		}
+		cfg.UseSSL = true
+	}
+	return objectstorage.NewPresigner(cfg)
+}
+
+// objectStoragePresignExpiry 签名上传链接的有效期，未配置时使用默认有效期
+func objectStoragePresignExpiry() time.Duration {
+	return viper.GetDuration(objectStorageSection() + ".presign-expiry")
+}
+
 // Cleanup 清理模块资源
 func (m *AnswersheetModule) Cleanup() error {
 	// 如果有需要清理的资源，在这里进行清理
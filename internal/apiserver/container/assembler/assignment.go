@@ -0,0 +1,92 @@
+package assembler
+
+import (
+	"go.mongodb.org/mongo-driver/mongo"
+	"gorm.io/gorm"
+
+	assignmentApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/assignment"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	hookPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	userPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	qnMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
+	assignmentInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql/assignment"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/scheduler"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// AssignmentModule 问卷分配模块
+type AssignmentModule struct {
+	// repository 层
+	AssignmentRepo port.AssignmentRepository
+
+	// service 层
+	Assigner *assignmentApp.Assigner
+	Queryer  port.AssignmentQueryer
+
+	// handler 层
+	AssignmentHandler *handler.AssignmentHandler
+}
+
+// NewAssignmentModule 创建问卷分配模块
+func NewAssignmentModule() *AssignmentModule {
+	return &AssignmentModule{}
+}
+
+// Initialize 初始化模块
+// params[0] 为 MySQL 数据库，params[1] 为 MongoDB 数据库，params[2] 为定时任务调度器，
+// params[3] 为用户查询器，params[4] 为事件通知器（可省略，省略时逾期提醒只记录日志）
+func (m *AssignmentModule) Initialize(params ...interface{}) error {
+	mysqlDB := params[0].(*gorm.DB)
+	if mysqlDB == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "database connection is nil")
+	}
+	mongoDB := params[1].(*mongo.Database)
+	if mongoDB == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "mongodb connection is nil")
+	}
+	cronScheduler, ok := params[2].(*scheduler.CronScheduler)
+	if !ok || cronScheduler == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "cron scheduler is nil")
+	}
+	userQueryer, _ := params[3].(userPort.UserQueryer)
+	var notifier hookPort.EventNotifier
+	if len(params) > 4 {
+		notifier, _ = params[4].(hookPort.EventNotifier)
+	}
+
+	// 初始化 repository 层
+	m.AssignmentRepo = assignmentInfra.NewRepository(mysqlDB)
+
+	// 初始化 service 层
+	m.Assigner = assignmentApp.NewAssigner(m.AssignmentRepo, qnMongoInfra.NewRepository(mongoDB))
+	m.Queryer = assignmentApp.NewQueryer(m.AssignmentRepo)
+
+	// 初始化 handler 层
+	m.AssignmentHandler = handler.NewAssignmentHandler(m.Assigner, m.Queryer, userQueryer)
+
+	// 注册定时任务
+	cronScheduler.Register(assignmentApp.NewOverdueChecker(m.AssignmentRepo, notifier, assignmentApp.DefaultOverdueSchedule))
+
+	return nil
+}
+
+// Cleanup 清理模块资源
+func (m *AssignmentModule) Cleanup() error {
+	return nil
+}
+
+// CheckHealth 检查模块健康状态
+func (m *AssignmentModule) CheckHealth() error {
+	return nil
+}
+
+// ModuleInfo 返回模块信息
+func (m *AssignmentModule) ModuleInfo() ModuleInfo {
+	return ModuleInfo{
+		Name:        "assignment",
+		Version:     "1.0.0",
+		Description: "问卷分配模块",
+	}
+}
@@ -1,23 +1,39 @@
 package assembler
 
 import (
+	"github.com/spf13/viper"
+	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 
 	authApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/auth"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	loginAuditInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/login-audit"
 	userInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 // Module 认证模块
 // 负责组装用户相关的所有组件
 type AuthModule struct {
 	// repository 层
-	UserRepo port.UserRepository
+	UserRepo       port.UserRepository
+	ScopeRepo      port.UserScopeRepository
+	LoginAuditRepo port.LoginAuditRepository
+
+	// handler 层
+	LoginAuditHandler *handler.LoginAuditHandler
 
 	// service 层 - 使用接口类型而非具体类型
 	Authenticator port.Authenticator
+	OTPManager    port.OTPManager
+	LoginAuditor  port.LoginAuditor
+	CaptchaGuard  port.LoginCaptchaGuard
+
+	// CaptchaVerifier 验证码校验器，为空时拒绝所有验证码令牌
+	CaptchaVerifier port.CaptchaVerifier
 }
 
 // NewModule 创建认证模块
@@ -31,16 +47,56 @@ func (m *AuthModule) Initialize(params ...interface{}) error {
 	if db == nil {
 		return errors.WithCode(code.ErrModuleInitializationFailed, "database connection is nil")
 	}
+	mongoDB := params[1].(*mongo.Database)
+	if mongoDB == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "mongodb connection is nil")
+	}
 
 	// 初始化 repository 层
 	m.UserRepo = userInfra.NewRepository(db)
+	m.ScopeRepo = userInfra.NewScopeRepository(db)
+	m.LoginAuditRepo = loginAuditInfra.NewRepository(mongoDB)
 
 	// 初始化 service 层
-	m.Authenticator = authApp.NewAuthenticator(m.UserRepo)
+	m.Authenticator = authApp.NewAuthenticator(m.UserRepo, m.ScopeRepo)
+	otpKey, err := otpSecretKey()
+	if err != nil {
+		return err
+	}
+	m.OTPManager = authApp.NewOTPManager(m.UserRepo, m.ScopeRepo, otpKey)
+	m.LoginAuditor = authApp.NewLoginAuditor(m.LoginAuditRepo)
+	if m.CaptchaVerifier == nil && captchaFailureThreshold() > 0 {
+		log.Warn("captcha verifier is not configured, clients reaching captcha.failure-threshold are locked out until captcha.failure-window passes")
+	}
+	m.CaptchaGuard = authApp.NewLoginCaptchaGuard(m.CaptchaVerifier, captchaFailureThreshold(), viper.GetDuration("captcha.failure-window"))
+
+	// 初始化 handler 层
+	m.LoginAuditHandler = handler.NewLoginAuditHandler(m.LoginAuditor)
 
 	return nil
 }
 
+// otpSecretKey 获取 TOTP 密钥的加密密钥
+// 必须单独配置 otp.secret-key，且不能与 JWT 签名密钥相同，避免一个密钥泄露同时危及两者
+func otpSecretKey() (string, error) {
+	key := viper.GetString("otp.secret-key")
+	if key == "" {
+		return "", errors.WithCode(code.ErrModuleInitializationFailed, "otp.secret-key is required")
+	}
+	if key == viper.GetString("jwt.key") {
+		return "", errors.WithCode(code.ErrModuleInitializationFailed, "otp.secret-key must differ from jwt.key")
+	}
+	return key, nil
+}
+
+// captchaFailureThreshold 获取需要验证码的连续登录失败次数，未配置时使用默认值
+func captchaFailureThreshold() int {
+	if !viper.IsSet("captcha.failure-threshold") {
+		return authApp.DefaultCaptchaFailureThreshold
+	}
+	return viper.GetInt("captcha.failure-threshold")
+}
+
 // CheckHealth 检查模块健康状态
 func (m *AuthModule) CheckHealth() error {
 	return nil
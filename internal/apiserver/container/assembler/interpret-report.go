@@ -5,7 +5,12 @@ import (
 
 	interpretreportapp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/interpret-report"
 	interpretreportport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/port"
+	hookPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	asMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/answersheet"
 	interpretreportmongo "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/interpret-report"
+	msInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/medical-scale"
+	qnMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
 )
 
 // InterpretReportModule 解读报告模块
@@ -13,22 +18,34 @@ type InterpretReportModule struct {
 	IRCreator interpretreportport.InterpretReportCreator
 	IREditor  interpretreportport.InterpretReportEditor
 	IRQueryer interpretreportport.InterpretReportQueryer
+	IRHL7     interpretreportport.InterpretReportHL7Exporter
+
+	IRHandler *handler.InterpretReportHandler
 }
 
-// NewInterpretReportModule 创建解读报告模块
-func NewInterpretReportModule(mongoDB *mongo.Database) *InterpretReportModule {
+// NewInterpretReportModule 创建解读报告模块，notifier 用于通知报告生成事件
+func NewInterpretReportModule(mongoDB *mongo.Database, notifier hookPort.EventNotifier) *InterpretReportModule {
 	// 创建仓储
 	repo := interpretreportmongo.NewRepository(mongoDB)
 
 	// 创建应用服务
-	creator := interpretreportapp.NewCreator(repo)
+	creator := interpretreportapp.NewCreator(
+		repo,
+		asMongoInfra.NewRepository(mongoDB),
+		qnMongoInfra.NewRepository(mongoDB),
+		msInfra.NewRepository(mongoDB),
+		notifier,
+	)
 	editor := interpretreportapp.NewEditor(repo)
 	queryer := interpretreportapp.NewQueryer(repo)
+	hl7Exporter := interpretreportapp.NewHL7Exporter(repo)
 
 	return &InterpretReportModule{
 		IRCreator: creator,
 		IREditor:  editor,
 		IRQueryer: queryer,
+		IRHL7:     hl7Exporter,
+		IRHandler: handler.NewInterpretReportHandler(hl7Exporter),
 	}
 }
 
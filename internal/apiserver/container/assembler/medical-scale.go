@@ -1,28 +1,35 @@
 package assembler
 
 import (
+	redis "github.com/go-redis/redis/v7"
 	"go.mongodb.org/mongo-driver/mongo"
 
 	msApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/medical-scale"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	asMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/answersheet"
 	msInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/medical-scale"
+	qnMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
+	cacheInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/redis/cache"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // MedicalScaleModule 医学量表模块
 type MedicalScaleModule struct {
 	// repository 层
-	MSRepo port.MedicalScaleRepositoryMongo
+	MSRepo           port.MedicalScaleRepositoryMongo
+	ReliabilityCache port.ReliabilityCache
 
 	// handler 层
 	MSHandler *handler.MedicalScaleHandler
 
 	// service 层
-	MSCreator port.MedicalScaleCreator
-	MSEditor  port.MedicalScaleEditor
-	MSQueryer port.MedicalScaleQueryer
+	MSCreator   port.MedicalScaleCreator
+	MSEditor    port.MedicalScaleEditor
+	MSQueryer   port.MedicalScaleQueryer
+	MSAnalytics port.MedicalScaleAnalytics
 }
 
 // NewMedicalScaleModule 创建医学量表模块
@@ -31,6 +38,8 @@ func NewMedicalScaleModule() *MedicalScaleModule {
 }
 
 // Initialize 初始化模块
+// params[0] 为 MongoDB 数据库，params[1] 为 Redis 客户端（可为空，为空时量表信度不缓存），
+// params[2] 为功能开关（可省略，省略时不允许使用加权计分）
 func (m *MedicalScaleModule) Initialize(params ...interface{}) error {
 	mongoDB := params[0].(*mongo.Database)
 	if mongoDB == nil {
@@ -39,17 +48,37 @@ func (m *MedicalScaleModule) Initialize(params ...interface{}) error {
 
 	// 初始化 repository 层
 	m.MSRepo = msInfra.NewRepository(mongoDB)
+	if len(params) > 1 {
+		if redisClient, ok := params[1].(redis.UniversalClient); ok && redisClient != nil {
+			m.ReliabilityCache = cacheInfra.NewReliabilityCache(redisClient)
+		}
+	}
+
+	var flags featureflag.Checker
+	if len(params) > 2 {
+		if f, ok := params[2].(*featureflag.Flags); ok && f != nil {
+			flags = f
+		}
+	}
 
 	// 初始化 service 层
-	m.MSCreator = msApp.NewCreator(m.MSRepo)
-	m.MSEditor = msApp.NewEditor(m.MSRepo)
+	qnRepo := qnMongoInfra.NewRepository(mongoDB)
+	m.MSCreator = msApp.NewCreator(m.MSRepo, qnRepo, flags)
+	m.MSEditor = msApp.NewEditor(m.MSRepo, qnRepo, flags)
 	m.MSQueryer = msApp.NewQueryer(m.MSRepo)
+	m.MSAnalytics = msApp.NewAnalytics(
+		m.MSRepo,
+		qnRepo,
+		asMongoInfra.NewRepository(mongoDB),
+		m.ReliabilityCache,
+	)
 
 	// 初始化 handler 层
 	m.MSHandler = handler.NewMedicalScaleHandler(
 		m.MSCreator,
 		m.MSQueryer,
 		m.MSEditor,
+		m.MSAnalytics,
 	)
 
 	return nil
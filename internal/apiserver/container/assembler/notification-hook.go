@@ -0,0 +1,85 @@
+package assembler
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	hookApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/notification-hook"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	hookInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql/notification-hook"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// dispatcherDrainTimeout 关闭模块时等待未投递事件完成的最长时间
+const dispatcherDrainTimeout = 30 * time.Second
+
+// NotificationHookModule 通知钩子模块
+type NotificationHookModule struct {
+	// repository 层
+	HookRepo     port.NotificationHookRepository
+	DeliveryRepo port.DeliveryRepository
+
+	// service 层
+	Dispatcher      port.EventNotifier
+	dispatcher      *hookApp.HookDispatcher
+	DeliveryQueryer port.DeliveryQueryer
+
+	// handler 层
+	NotificationHookHandler *handler.NotificationHookHandler
+}
+
+// NewNotificationHookModule 创建通知钩子模块
+func NewNotificationHookModule() *NotificationHookModule {
+	return &NotificationHookModule{}
+}
+
+// Initialize 初始化模块
+func (m *NotificationHookModule) Initialize(params ...interface{}) error {
+	db := params[0].(*gorm.DB)
+	if db == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "database connection is nil")
+	}
+
+	// 初始化 repository 层
+	m.HookRepo = hookInfra.NewRepository(db)
+	m.DeliveryRepo = hookInfra.NewDeliveryRepository(db)
+
+	// 初始化 service 层
+	m.dispatcher = hookApp.NewHookDispatcher(m.HookRepo, m.DeliveryRepo, nil)
+	m.Dispatcher = m.dispatcher
+	m.DeliveryQueryer = hookApp.NewDeliveryQueryer(m.HookRepo, m.DeliveryRepo)
+
+	// 初始化 handler 层
+	m.NotificationHookHandler = handler.NewNotificationHookHandler(m.DeliveryQueryer)
+
+	return nil
+}
+
+// Cleanup 清理模块资源
+// 停止接收新事件，并等待已入队的事件投递完成
+func (m *NotificationHookModule) Cleanup() error {
+	if m.dispatcher == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), dispatcherDrainTimeout)
+	defer cancel()
+	return m.dispatcher.Close(ctx)
+}
+
+// CheckHealth 检查模块健康状态
+func (m *NotificationHookModule) CheckHealth() error {
+	return nil
+}
+
+// ModuleInfo 返回模块信息
+func (m *NotificationHookModule) ModuleInfo() ModuleInfo {
+	return ModuleInfo{
+		Name:        "notificationhook",
+		Version:     "1.0.0",
+		Description: "通知钩子模块",
+	}
+}
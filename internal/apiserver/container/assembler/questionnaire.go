@@ -1,40 +1,118 @@
 package assembler
 
 import (
+	"github.com/spf13/viper"
 	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 
 	quesApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	userPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/chrome"
+	asMongoInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/answersheet"
 	quesDocInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
+	thumbnailInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire-thumbnail"
+	translationInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire-translation"
+	assignmentInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql/assignment"
 	quesInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/printview"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
+// QuestionnaireModuleConfig 问卷模块配置
+type QuestionnaireModuleConfig struct {
+	// MaxQuestionsPerQuestionnaire 每份问卷最多包含的问题数（含段落）
+	MaxQuestionsPerQuestionnaire int
+	// MaxQuestionsPerSection 每个段落最多包含的问题数
+	MaxQuestionsPerSection int
+	// ThumbnailEnabled 是否提供问卷缩略图，需要运行环境安装 Chrome
+	ThumbnailEnabled bool
+	// Chrome 生成缩略图使用的无头浏览器配置
+	Chrome chrome.Config
+	// PreviewSecretKey 问卷预览令牌的签名密钥，必须单独配置
+	PreviewSecretKey string
+}
+
+// NewQuestionnaireModuleConfig 从配置文件读取问卷模块配置，未配置时使用默认值
+func NewQuestionnaireModuleConfig() QuestionnaireModuleConfig {
+	cfg := QuestionnaireModuleConfig{
+		MaxQuestionsPerQuestionnaire: questionnaire.DefaultMaxQuestionsPerQuestionnaire,
+		MaxQuestionsPerSection:       questionnaire.DefaultMaxQuestionsPerSection,
+	}
+	if v := viper.GetInt("questionnaire.max-questions-per-questionnaire"); v > 0 {
+		cfg.MaxQuestionsPerQuestionnaire = v
+	}
+	if v := viper.GetInt("questionnaire.max-questions-per-section"); v > 0 {
+		cfg.MaxQuestionsPerSection = v
+	}
+	cfg.PreviewSecretKey = viper.GetString("questionnaire.preview-secret-key")
+	cfg.ThumbnailEnabled = viper.GetBool("thumbnail.enabled")
+	cfg.Chrome = chrome.Config{
+		ExecPath: viper.GetString("thumbnail.chrome-path"),
+		Timeout:  viper.GetDuration("thumbnail.timeout"),
+	}
+	return cfg
+}
+
+// questionLimits 转换为问题数量上限
+func (c QuestionnaireModuleConfig) questionLimits() questionnaire.QuestionLimits {
+	return questionnaire.QuestionLimits{
+		MaxQuestionsPerQuestionnaire: c.MaxQuestionsPerQuestionnaire,
+		MaxQuestionsPerSection:       c.MaxQuestionsPerSection,
+	}
+}
+
+// validatePreviewSecretKey 校验预览令牌的签名密钥已单独配置，不能与 JWT 签名密钥相同
+func (c QuestionnaireModuleConfig) validatePreviewSecretKey() error {
+	if c.PreviewSecretKey == "" {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "questionnaire.preview-secret-key is required")
+	}
+	if c.PreviewSecretKey == viper.GetString("jwt.key") {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "questionnaire.preview-secret-key must differ from jwt.key")
+	}
+	return nil
+}
+
 // Module 问卷模块
 type QuestionnaireModule struct {
+	// 模块配置
+	Config QuestionnaireModuleConfig
+
 	// repository 层
-	QuesRepo port.QuestionnaireRepositoryMySQL
-	QuesDoc  port.QuestionnaireRepositoryMongo
+	QuesRepo        port.QuestionnaireRepositoryMySQL
+	QuesDoc         port.QuestionnaireRepositoryMongo
+	TranslationRepo port.TranslationRepository
+	ThumbnailCache  port.ThumbnailCache
 
 	// handler 层
-	QuesHandler *handler.QuestionnaireHandler
+	QuesHandler        *handler.QuestionnaireHandler
+	TranslationHandler *handler.TranslationHandler
 
 	// service 层
-	QuesCreator   port.QuestionnaireCreator
-	QuesEditor    port.QuestionnaireEditor
-	QuesPublisher port.QuestionnairePublisher
-	QuesQueryer   port.QuestionnaireQueryer
+	QuesCreator    port.QuestionnaireCreator
+	QuesEditor     port.QuestionnaireEditor
+	QuesPublisher  port.QuestionnairePublisher
+	QuesQueryer    port.QuestionnaireQueryer
+	QuesPreviewer  port.QuestionnairePreviewer
+	QuesTranslator port.QuestionnaireTranslator
+	QuesDeleter    port.QuestionnaireDeleter
+	// QuesThumbnailer 未开启 thumbnail.enabled 时为空
+	QuesThumbnailer port.QuestionnaireThumbnailer
 }
 
 // NewModule 创建用户模块
 func NewQuestionnaireModule() *QuestionnaireModule {
-	return &QuestionnaireModule{}
+	return &QuestionnaireModule{Config: NewQuestionnaireModuleConfig()}
 }
 
 // Initialize 初始化模块
+// params[0] 为 MySQL 数据库，params[1] 为 MongoDB 数据库，params[2] 为用户查询服务（可为空，为空时不提供翻译接口），
+// params[3] 为用户活动记录器（可省略，省略时不记录发布问卷的操作），
+// params[4] 为功能开关（可省略，省略时不允许使用自定义题型）
 func (m *QuestionnaireModule) Initialize(params ...interface{}) error {
 	mysqlDB := params[0].(*gorm.DB)
 	mongoDB := params[1].(*mongo.Database)
@@ -42,18 +120,43 @@ func (m *QuestionnaireModule) Initialize(params ...interface{}) error {
 		return errors.WithCode(code.ErrModuleInitializationFailed, "database connection is nil")
 	}
 
+	if err := m.Config.validatePreviewSecretKey(); err != nil {
+		return err
+	}
+
 	// 初始化 repository 层
 	m.QuesRepo = quesInfra.NewRepository(mysqlDB)
 
 	// 安全的类型断言
 	mongoRepo := quesDocInfra.NewRepository(mongoDB)
 	m.QuesDoc = mongoRepo
+	m.TranslationRepo = translationInfra.NewRepository(mongoDB)
+	m.ThumbnailCache = thumbnailInfra.NewCache(mongoDB)
+
+	var flags featureflag.Checker
+	if len(params) > 4 {
+		if f, ok := params[4].(*featureflag.Flags); ok && f != nil {
+			flags = f
+		}
+	}
 
 	// 初始化 service 层
 	m.QuesCreator = quesApp.NewCreator(m.QuesRepo, m.QuesDoc)
-	m.QuesEditor = quesApp.NewEditor(m.QuesRepo, m.QuesDoc)
-	m.QuesPublisher = quesApp.NewPublisher(m.QuesRepo, m.QuesDoc)
+	m.QuesEditor = quesApp.NewEditor(m.QuesRepo, m.QuesDoc, m.Config.questionLimits(), m.ThumbnailCache, flags)
+	// 问卷版本发布事件暂无订阅者
+	m.QuesPublisher = quesApp.NewPublisher(m.QuesRepo, m.QuesDoc, m.Config.questionLimits(), nil)
 	m.QuesQueryer = quesApp.NewQueryer(m.QuesRepo, m.QuesDoc)
+	m.QuesPreviewer = quesApp.NewPreviewer(m.QuesRepo, m.QuesDoc, m.Config.PreviewSecretKey)
+	m.QuesTranslator = quesApp.NewTranslator(m.QuesDoc, m.TranslationRepo)
+	m.QuesDeleter = quesApp.NewDeleter(m.QuesRepo, m.QuesDoc, asMongoInfra.NewRepository(mongoDB), assignmentInfra.NewRepository(mysqlDB))
+	if m.Config.ThumbnailEnabled {
+		m.QuesThumbnailer = quesApp.NewThumbnailer(
+			m.QuesQueryer,
+			printview.RenderQuestionnaire,
+			chrome.NewThumbnailRenderer(m.Config.Chrome),
+			m.ThumbnailCache,
+		)
+	}
 
 	// 初始化 handler 层
 	m.QuesHandler = handler.NewQuestionnaireHandler(
@@ -61,7 +164,20 @@ func (m *QuestionnaireModule) Initialize(params ...interface{}) error {
 		m.QuesEditor,
 		m.QuesPublisher,
 		m.QuesQueryer,
+		m.QuesPreviewer,
 	)
+	m.QuesHandler.SetThumbnailer(m.QuesThumbnailer)
+	m.QuesHandler.SetDeleter(m.QuesDeleter)
+	if len(params) > 2 {
+		if userQueryer, ok := params[2].(userPort.UserQueryer); ok && userQueryer != nil {
+			m.TranslationHandler = handler.NewTranslationHandler(m.QuesTranslator, userQueryer)
+		}
+	}
+	if len(params) > 3 {
+		if recorder, ok := params[3].(*handler.ActivityRecorder); ok {
+			m.QuesHandler.SetActivityRecorder(recorder)
+		}
+	}
 
 	return nil
 }
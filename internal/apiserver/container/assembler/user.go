@@ -1,13 +1,19 @@
 package assembler
 
 import (
+	redis "github.com/go-redis/redis/v7"
+	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 
 	userApp "github.com/yshujie/questionnaire-scale/internal/apiserver/application/user"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	activityInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/user-activity-log"
+	preferenceInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/user-preference"
 	userInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql/user"
+	sessionInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/redis/user-session"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
@@ -15,10 +21,16 @@ import (
 // 负责组装用户相关的所有组件
 type UserModule struct {
 	// repository 层
-	UserRepo port.UserRepository
+	UserRepo           port.UserRepository
+	UserPreferenceRepo port.UserPreferenceRepository
+	UserSessionRepo    port.UserSessionRepository
+	ActivityLogRepo    port.UserActivityLogRepository
 
 	// handler 层
-	UserHandler *handler.UserHandler
+	UserHandler         *handler.UserHandler
+	UserActivityHandler *handler.UserActivityHandler
+	// ActivityRecorder 供其他模块的处理器记录用户的关键操作
+	ActivityRecorder *handler.ActivityRecorder
 
 	// service 层
 	UserCreator         port.UserCreator
@@ -26,6 +38,9 @@ type UserModule struct {
 	UserEditor          port.UserEditor
 	UserActivator       port.UserActivator
 	UserPasswordChanger port.PasswordChanger
+	PreferenceManager   port.PreferenceManager
+	SessionManager      port.SessionManager
+	ActivityLogger      *userApp.ActivityLogger
 }
 
 // NewModule 创建用户模块
@@ -39,16 +54,41 @@ func (m *UserModule) Initialize(params ...interface{}) error {
 	if db == nil {
 		return errors.WithCode(code.ErrModuleInitializationFailed, "database connection is nil")
 	}
+	mongoDB := params[1].(*mongo.Database)
+	if mongoDB == nil {
+		return errors.WithCode(code.ErrModuleInitializationFailed, "mongodb connection is nil")
+	}
+	// Redis 与会话配置可选，未配置 Redis 时不记录登录会话
+	var redisClient redis.UniversalClient
+	if len(params) > 2 {
+		redisClient, _ = params[2].(redis.UniversalClient)
+	}
+	sessionOpts := genericoptions.NewSessionOptions()
+	if len(params) > 3 {
+		if opts, ok := params[3].(*genericoptions.SessionOptions); ok && opts != nil {
+			sessionOpts = opts
+		}
+	}
 
 	// 初始化 repository 层
 	m.UserRepo = userInfra.NewRepository(db)
+	m.UserPreferenceRepo = preferenceInfra.NewRepository(mongoDB)
+	if redisClient != nil {
+		m.UserSessionRepo = sessionInfra.NewRepository(redisClient, sessionOpts.Timeout)
+	}
+	m.ActivityLogRepo = activityInfra.NewRepository(mongoDB)
 
 	// 初始化 service 层
 	m.UserCreator = userApp.NewUserCreator(m.UserRepo)
 	m.UserQueryer = userApp.NewUserQueryer(m.UserRepo)
 	m.UserEditor = userApp.NewUserEditor(m.UserRepo)
 	m.UserActivator = userApp.NewUserActivator(m.UserRepo)
-	m.UserPasswordChanger = userApp.NewPasswordChanger(m.UserRepo)
+	m.PreferenceManager = userApp.NewPreferenceManager(m.UserPreferenceRepo)
+	if m.UserSessionRepo != nil {
+		m.SessionManager = userApp.NewSessionManager(m.UserSessionRepo, sessionOpts.MaxConcurrent, sessionOpts.RoleMaxConcurrent)
+	}
+	m.ActivityLogger = userApp.NewActivityLogger(m.ActivityLogRepo, userApp.DefaultActivityQueueSize)
+	m.UserPasswordChanger = userApp.NewPasswordChanger(m.UserRepo, m.SessionManager, m.ActivityLogger)
 
 	// 初始化 handler 层
 	m.UserHandler = handler.NewUserHandler(
@@ -57,13 +97,21 @@ func (m *UserModule) Initialize(params ...interface{}) error {
 		m.UserEditor,
 		m.UserActivator,
 		m.UserPasswordChanger,
+		m.PreferenceManager,
+		m.SessionManager,
 	)
+	m.UserActivityHandler = handler.NewUserActivityHandler(m.ActivityLogger)
+	m.ActivityRecorder = handler.NewActivityRecorder(m.ActivityLogger, m.UserQueryer)
 
 	return nil
 }
 
 // Cleanup 清理模块资源
 func (m *UserModule) Cleanup() error {
+	// 等待队列中的用户活动记录写入完成
+	if m.ActivityLogger != nil {
+		m.ActivityLogger.Close()
+	}
 	// 如果有需要清理的资源，在这里进行清理
 	// 比如关闭数据库连接、释放缓存等
 	return nil
@@ -3,167 +3,303 @@ package container
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"sync"
 
+	redis "github.com/go-redis/redis/v7"
+	"github.com/spf13/viper"
 	"go.mongodb.org/mongo-driver/mongo"
 	"gorm.io/gorm"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/container/assembler"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/redis/lock"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/scheduler"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
-// modulePool 模块池
-var modulePool = make(map[string]assembler.Module)
-
 // Container 主容器
 // 组合所有业务模块和基础设施组件
 type Container struct {
 	// 基础设施
-	mysqlDB *gorm.DB
-	mongoDB *mongo.Database
+	mysqlDB     *gorm.DB
+	mongoDB     *mongo.Database
+	redisClient redis.UniversalClient
+
+	// 登录会话配置
+	sessionOptions *genericoptions.SessionOptions
+	purgeOptions   *genericoptions.AnswerSheetPurgeOptions
+
+	// 定时任务调度器
+	Scheduler *scheduler.CronScheduler
+
+	// 功能开关，业务模块通过它判断灰度中的功能是否开启
+	FeatureFlags *featureflag.Flags
 
 	// 业务模块
-	AuthModule            *assembler.AuthModule
-	UserModule            *assembler.UserModule
-	QuestionnaireModule   *assembler.QuestionnaireModule
-	AnswersheetModule     *assembler.AnswersheetModule
-	MedicalScaleModule    *assembler.MedicalScaleModule
-	InterpretReportModule *assembler.InterpretReportModule
-
-	// 容器状态
-	initialized bool
+	AuthModule             *assembler.AuthModule
+	UserModule             *assembler.UserModule
+	QuestionnaireModule    *assembler.QuestionnaireModule
+	NotificationHookModule *assembler.NotificationHookModule
+	AssignmentModule       *assembler.AssignmentModule
+	AnswersheetModule      *assembler.AnswersheetModule
+	MedicalScaleModule     *assembler.MedicalScaleModule
+	InterpretReportModule  *assembler.InterpretReportModule
+	AdminModule            *assembler.AdminModule
+
+	// 容器状态，mu 保护 initialized 与 modulePool
+	mu           sync.RWMutex
+	initialized  bool
+	modulePool   map[string]assembler.Module
+	initializers []moduleInitializer
+	startupTimer *StartupTimer
 }
 
-// NewContainer 创建容器
-func NewContainer(mysqlDB *gorm.DB, mongoDB *mongo.Database) *Container {
-	return &Container{
-		mysqlDB:     mysqlDB,
-		mongoDB:     mongoDB,
-		initialized: false,
+// Option 容器选项
+type Option func(*Container)
+
+// WithSessionOptions 设置登录会话配置
+func WithSessionOptions(opts *genericoptions.SessionOptions) Option {
+	return func(c *Container) {
+		c.sessionOptions = opts
 	}
 }
 
-// Initialize 初始化容器
-func (c *Container) Initialize() error {
-	if c.initialized {
-		return nil
+// WithAnswerSheetPurgeOptions 设置软删除答卷清理任务配置
+func WithAnswerSheetPurgeOptions(opts *genericoptions.AnswerSheetPurgeOptions) Option {
+	return func(c *Container) {
+		c.purgeOptions = opts
 	}
+}
 
-	// 初始化用户模块
-	if err := c.initUserModule(); err != nil {
-		return fmt.Errorf("failed to initialize user module: %w", err)
+// moduleInitializer 业务模块初始化步骤
+type moduleInitializer struct {
+	name string
+	init func() error
+}
+
+// NewContainer 创建容器
+// redisClient 为空时定时任务不加分布式锁，且不记录登录会话，仅适用于单实例部署
+func NewContainer(mysqlDB *gorm.DB, mongoDB *mongo.Database, redisClient redis.UniversalClient, opts ...Option) *Container {
+	var schedulerOpts []scheduler.Option
+	if redisClient != nil {
+		schedulerOpts = append(schedulerOpts, scheduler.WithDistributedLock(
+			lock.NewRedisLock(redisClient, scheduler.DefaultLockOwner()),
+			scheduler.DefaultLockTTL,
+		))
 	}
 
-	// 初始化认证模块
-	if err := c.initAuthModule(); err != nil {
-		return fmt.Errorf("failed to initialize auth module: %w", err)
+	c := &Container{
+		mysqlDB:        mysqlDB,
+		mongoDB:        mongoDB,
+		redisClient:    redisClient,
+		sessionOptions: genericoptions.NewSessionOptions(),
+		purgeOptions:   genericoptions.NewAnswerSheetPurgeOptions(),
+		Scheduler:      scheduler.NewCronScheduler(schedulerOpts...),
+		FeatureFlags:   loadFeatureFlags(),
+		initialized:    false,
+		modulePool:     make(map[string]assembler.Module),
 	}
 
-	// 初始化问卷模块
-	if err := c.initQuestionnaireModule(); err != nil {
-		return fmt.Errorf("failed to initialize questionnaire module: %w", err)
+	// 按依赖顺序初始化各业务模块
+	c.initializers = []moduleInitializer{
+		{"user", c.initUserModule},
+		{"auth", c.initAuthModule},
+		{"questionnaire", c.initQuestionnaireModule},
+		{"notificationhook", c.initNotificationHookModule},
+		{"assignment", c.initAssignmentModule},
+		{"answersheet", c.initAnswersheetModule},
+		{"medicalscale", c.initMedicalScaleModule},
+		{"interpretreport", c.initInterpretReportModule},
+		{"admin", c.initAdminModule},
+	}
+	for _, opt := range opts {
+		opt(c)
 	}
 
-	// 初始化答卷模块
-	if err := c.initAnswersheetModule(); err != nil {
-		return fmt.Errorf("failed to initialize answersheet module: %w", err)
+	return c
+}
+
+// loadFeatureFlags 从配置文件的 feature-flags 段加载功能开关，配置无效时所有开关视为关闭
+func loadFeatureFlags() *featureflag.Flags {
+	flags := make(map[string]featureflag.Flag)
+	if err := viper.UnmarshalKey("feature-flags", &flags); err != nil {
+		log.Errorf("Failed to load feature flags: %v", err)
 	}
+	return featureflag.NewFlags(flags)
+}
 
-	// 初始化医学量表模块
-	if err := c.initMedicalScaleModule(); err != nil {
-		return fmt.Errorf("failed to initialize medical scale module: %w", err)
+// Initialize 初始化容器
+// 并发调用时只有一个调用者执行初始化，其余调用者等待其完成；初始化失败时下次调用会重新初始化
+func (c *Container) Initialize() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.initialized {
+		return nil
 	}
 
-	// 初始化解读报告模块
-	if err := c.initInterpretReportModule(); err != nil {
-		return fmt.Errorf("failed to initialize interpret report module: %w", err)
+	c.startupTimer = NewStartupTimer()
+
+	for _, m := range c.initializers {
+		if err := c.startupTimer.Track(m.name, m.init); err != nil {
+			return err
+		}
 	}
 
+	// 所有模块就绪后启动定时任务
+	c.Scheduler.Start()
+
 	c.initialized = true
-	fmt.Printf("🏗️  Container initialized with modules: user\n")
 
 	return nil
 }
 
+// PrintStartupSummary 输出容器启动耗时汇总
+func (c *Container) PrintStartupSummary() {
+	if c.startupTimer == nil {
+		return
+	}
+
+	log.Info(c.startupTimer.Summary(),
+		log.Int64("init_duration_ms", c.startupTimer.Total().Milliseconds()),
+	)
+}
+
 // initUserModule 初始化用户模块
 func (c *Container) initUserModule() error {
 	userModule := assembler.NewUserModule()
-	if err := userModule.Initialize(c.mysqlDB); err != nil {
+	if err := userModule.Initialize(c.mysqlDB, c.mongoDB, c.redisClient, c.sessionOptions); err != nil {
 		return fmt.Errorf("failed to initialize user module: %w", err)
 	}
 
 	c.UserModule = userModule
-	modulePool["user"] = userModule
+	c.registerModule("user", userModule)
 
-	fmt.Printf("📦 User module initialized\n")
 	return nil
 }
 
 // initAuthModule 初始化认证模块
 func (c *Container) initAuthModule() error {
 	authModule := assembler.NewAuthModule()
-	if err := authModule.Initialize(c.mysqlDB); err != nil {
+	if err := authModule.Initialize(c.mysqlDB, c.mongoDB); err != nil {
 		return fmt.Errorf("failed to initialize auth module: %w", err)
 	}
 
 	c.AuthModule = authModule
-	modulePool["auth"] = authModule
+	c.registerModule("auth", authModule)
 
-	fmt.Printf("📦 Auth module initialized\n")
 	return nil
 }
 
 // initQuestionnaireModule 初始化问卷模块
 func (c *Container) initQuestionnaireModule() error {
 	quesModule := assembler.NewQuestionnaireModule()
-	if err := quesModule.Initialize(c.mysqlDB, c.mongoDB); err != nil {
+	if err := quesModule.Initialize(c.mysqlDB, c.mongoDB, c.UserModule.UserQueryer, c.UserModule.ActivityRecorder, c.FeatureFlags); err != nil {
 		return fmt.Errorf("failed to initialize questionnaire module: %w", err)
 	}
 
 	c.QuestionnaireModule = quesModule
-	modulePool["questionnaire"] = quesModule
+	c.registerModule("questionnaire", quesModule)
+
+	return nil
+}
+
+// initNotificationHookModule 初始化通知钩子模块
+func (c *Container) initNotificationHookModule() error {
+	notificationHookModule := assembler.NewNotificationHookModule()
+	if err := notificationHookModule.Initialize(c.mysqlDB); err != nil {
+		return fmt.Errorf("failed to initialize notification hook module: %w", err)
+	}
+
+	c.NotificationHookModule = notificationHookModule
+	c.registerModule("notificationhook", notificationHookModule)
+
+	return nil
+}
+
+// initAssignmentModule 初始化问卷分配模块
+func (c *Container) initAssignmentModule() error {
+	assignmentModule := assembler.NewAssignmentModule()
+	if err := assignmentModule.Initialize(c.mysqlDB, c.mongoDB, c.Scheduler, c.UserModule.UserQueryer, c.NotificationHookModule.Dispatcher); err != nil {
+		return fmt.Errorf("failed to initialize assignment module: %w", err)
+	}
+
+	c.AssignmentModule = assignmentModule
+	c.registerModule("assignment", assignmentModule)
 
-	fmt.Printf("📦 Questionnaire module initialized\n")
 	return nil
 }
 
 // initAnswersheetModule 初始化答卷模块
 func (c *Container) initAnswersheetModule() error {
 	answersheetModule := assembler.NewAnswersheetModule()
-	if err := answersheetModule.Initialize(c.mongoDB); err != nil {
+	if err := answersheetModule.Initialize(c.mongoDB, c.Scheduler, c.NotificationHookModule.Dispatcher, c.redisClient, c.UserModule.ActivityRecorder, c.AssignmentModule.Assigner, c.purgeOptions); err != nil {
 		return fmt.Errorf("failed to initialize answersheet module: %w", err)
 	}
 
 	c.AnswersheetModule = answersheetModule
-	modulePool["answersheet"] = answersheetModule
+	c.registerModule("answersheet", answersheetModule)
 
-	fmt.Printf("📦 Answersheet module initialized\n")
 	return nil
 }
 
 // initMedicalScaleModule 初始化医学量表模块
 func (c *Container) initMedicalScaleModule() error {
 	medicalScaleModule := assembler.NewMedicalScaleModule()
-	if err := medicalScaleModule.Initialize(c.mongoDB); err != nil {
+	if err := medicalScaleModule.Initialize(c.mongoDB, c.redisClient, c.FeatureFlags); err != nil {
 		return fmt.Errorf("failed to initialize medical scale module: %w", err)
 	}
 
 	c.MedicalScaleModule = medicalScaleModule
-	modulePool["medicalscale"] = medicalScaleModule
+	c.registerModule("medicalscale", medicalScaleModule)
 
-	fmt.Printf("📦 Medical scale module initialized\n")
 	return nil
 }
 
 // initInterpretReportModule 初始化解读报告模块
 func (c *Container) initInterpretReportModule() error {
-	interpretReportModule := assembler.NewInterpretReportModule(c.mongoDB)
+	interpretReportModule := assembler.NewInterpretReportModule(c.mongoDB, c.NotificationHookModule.Dispatcher)
 
 	c.InterpretReportModule = interpretReportModule
-	modulePool["interpretreport"] = interpretReportModule
+	c.registerModule("interpretreport", interpretReportModule)
 
-	fmt.Printf("📦 Interpret report module initialized\n")
 	return nil
 }
 
+// initAdminModule 初始化管理员模块
+func (c *Container) initAdminModule() error {
+	adminModule := assembler.NewAdminModule()
+	if err := adminModule.Initialize(c.mongoDB, c.redisClient); err != nil {
+		return fmt.Errorf("failed to initialize admin module: %w", err)
+	}
+
+	c.AdminModule = adminModule
+	c.registerModule("admin", adminModule)
+
+	return nil
+}
+
+// registerModule 将模块加入模块池，调用方需持有 c.mu
+func (c *Container) registerModule(name string, module assembler.Module) {
+	c.modulePool[name] = module
+}
+
+// modules 返回模块池的快照，避免在持锁期间调用模块方法
+func (c *Container) modules() []assembler.Module {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	modules := make([]assembler.Module, 0, len(c.modulePool))
+	for _, module := range c.modulePool {
+		modules = append(modules, module)
+	}
+	return modules
+}
+
 // HealthCheck 健康检查
 func (c *Container) HealthCheck(ctx context.Context) error {
 	// 检查MySQL连接
@@ -194,7 +330,7 @@ func (c *Container) HealthCheck(ctx context.Context) error {
 
 // checkModulesHealth 检查模块健康状态
 func (c *Container) checkModulesHealth(ctx context.Context) error {
-	for _, module := range modulePool {
+	for _, module := range c.modules() {
 		if err := module.CheckHealth(); err != nil {
 			return fmt.Errorf("module health check failed: %w", err)
 		}
@@ -206,41 +342,68 @@ func (c *Container) checkModulesHealth(ctx context.Context) error {
 func (c *Container) Cleanup() error {
 	fmt.Printf("🧹 Cleaning up container resources...\n")
 
-	for _, module := range modulePool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.Scheduler.Stop()
+
+	for _, module := range c.modulePool {
 		if err := module.Cleanup(); err != nil {
 			return fmt.Errorf("failed to cleanup module: %w", err)
 		}
 		fmt.Printf("   ✅ %s module cleaned up\n", module.ModuleInfo().Name)
 	}
 
+	// 清空模块池，重新初始化时各模块重新注册
+	c.modulePool = make(map[string]assembler.Module)
 	c.initialized = false
 	fmt.Printf("🏁 Container cleanup completed\n")
 
 	return nil
 }
 
+// ContainerInfo 容器信息
+type ContainerInfo struct {
+	Name           string                          `json:"name"`
+	Version        string                          `json:"version"`
+	Architecture   string                          `json:"architecture"`
+	Initialized    bool                            `json:"initialized"`
+	Modules        map[string]assembler.ModuleInfo `json:"modules"`
+	Infrastructure InfrastructureInfo              `json:"infrastructure"`
+}
+
+// InfrastructureInfo 基础设施连接情况
+type InfrastructureInfo struct {
+	MySQL   bool `json:"mysql"`
+	MongoDB bool `json:"mongodb"`
+}
+
 // GetContainerInfo 获取容器信息
-func (c *Container) GetContainerInfo() map[string]interface{} {
-	modules := make(map[string]interface{})
-	for _, module := range modulePool {
-		modules[module.ModuleInfo().Name] = module.ModuleInfo()
+func (c *Container) GetContainerInfo() ContainerInfo {
+	modules := make(map[string]assembler.ModuleInfo)
+	for _, module := range c.modules() {
+		info := module.ModuleInfo()
+		modules[info.Name] = info
 	}
 
-	return map[string]interface{}{
-		"name":         "apiserver-container",
-		"version":      "2.0.0",
-		"architecture": "hexagonal",
-		"initialized":  c.initialized,
-		"modules":      modules,
-		"infrastructure": map[string]bool{
-			"mysql":   c.mysqlDB != nil,
-			"mongodb": c.mongoDB != nil,
+	return ContainerInfo{
+		Name:         "apiserver-container",
+		Version:      "2.0.0",
+		Architecture: "hexagonal",
+		Initialized:  c.IsInitialized(),
+		Modules:      modules,
+		Infrastructure: InfrastructureInfo{
+			MySQL:   c.mysqlDB != nil,
+			MongoDB: c.mongoDB != nil,
 		},
 	}
 }
 
 // IsInitialized 检查容器是否已初始化
 func (c *Container) IsInitialized() bool {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
 	return c.initialized
 }
 
@@ -248,7 +411,7 @@ func (c *Container) IsInitialized() bool {
 func (c *Container) GetLoadedModules() []string {
 	modules := make([]string, 0)
 
-	for _, module := range modulePool {
+	for _, module := range c.modules() {
 		modules = append(modules, module.ModuleInfo().Name)
 	}
 
@@ -257,29 +420,37 @@ func (c *Container) GetLoadedModules() []string {
 
 // PrintContainerInfo 打印容器信息
 func (c *Container) PrintContainerInfo() {
-	info := c.GetContainerInfo()
-
-	fmt.Printf("🏗️  Container Information:\n")
-	fmt.Printf("   Name: %s\n", info["name"].(string))
-	fmt.Printf("   Version: %s\n", info["version"].(string))
-	fmt.Printf("   Architecture: %s\n", info["architecture"].(string))
-	fmt.Printf("   Initialized: %v\n", info["initialized"].(bool))
-
-	infra := info["infrastructure"].(map[string]bool)
-	fmt.Printf("   Infrastructure:\n")
-	if infra["mysql"] {
-		fmt.Printf("     • MySQL: ✅\n")
-	} else {
-		fmt.Printf("     • MySQL: ❌\n")
+	writeContainerInfo(os.Stdout, c.GetContainerInfo())
+}
+
+// writeContainerInfo 输出容器信息，模块按名称排序
+func writeContainerInfo(w io.Writer, info ContainerInfo) {
+	fmt.Fprintf(w, "🏗️  Container Information:\n")
+	fmt.Fprintf(w, "   Name: %s\n", info.Name)
+	fmt.Fprintf(w, "   Version: %s\n", info.Version)
+	fmt.Fprintf(w, "   Architecture: %s\n", info.Architecture)
+	fmt.Fprintf(w, "   Initialized: %v\n", info.Initialized)
+
+	fmt.Fprintf(w, "   Infrastructure:\n")
+	fmt.Fprintf(w, "     • MySQL: %s\n", statusMark(info.Infrastructure.MySQL))
+	fmt.Fprintf(w, "     • MongoDB: %s\n", statusMark(info.Infrastructure.MongoDB))
+
+	names := make([]string, 0, len(info.Modules))
+	for name := range info.Modules {
+		names = append(names, name)
 	}
-	if infra["mongodb"] {
-		fmt.Printf("     • MongoDB: ✅\n")
-	} else {
-		fmt.Printf("     • MongoDB: ❌\n")
+	sort.Strings(names)
+
+	fmt.Fprintf(w, "   Loaded Modules:\n")
+	for _, name := range names {
+		fmt.Fprintf(w, "     • %s\n", name)
 	}
+}
 
-	fmt.Printf("   Loaded Modules:\n")
-	for _, module := range c.GetLoadedModules() {
-		fmt.Printf("     • %s\n", module)
+// statusMark 返回连接状态标记
+func statusMark(ok bool) string {
+	if ok {
+		return "✅"
 	}
+	return "❌"
 }
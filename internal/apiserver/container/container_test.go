@@ -0,0 +1,133 @@
+package container
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/container/assembler"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/scheduler"
+)
+
+// fakeModule 仅提供模块信息的测试模块
+type fakeModule struct {
+	name string
+}
+
+func (m *fakeModule) Initialize(params ...interface{}) error { return nil }
+func (m *fakeModule) CheckHealth() error                     { return nil }
+func (m *fakeModule) Cleanup() error                         { return nil }
+func (m *fakeModule) ModuleInfo() assembler.ModuleInfo {
+	return assembler.ModuleInfo{Name: m.name}
+}
+
+// newTestContainer 创建只包含一个计数初始化步骤的容器
+func newTestContainer(name string, calls *int32) *Container {
+	c := &Container{
+		Scheduler:  scheduler.NewCronScheduler(),
+		modulePool: make(map[string]assembler.Module),
+	}
+	c.initializers = []moduleInitializer{
+		{name, func() error {
+			atomic.AddInt32(calls, 1)
+			c.registerModule(name, &fakeModule{name: name})
+			return nil
+		}},
+	}
+	return c
+}
+
+func TestContainer_Initialize_Concurrent(t *testing.T) {
+	var calls int32
+	c := newTestContainer("user", &calls)
+	defer c.Scheduler.Stop()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := c.Initialize(); err != nil {
+				t.Errorf("Initialize() error = %v", err)
+			}
+			_ = c.GetLoadedModules()
+		}()
+	}
+	wg.Wait()
+
+	if calls != 1 {
+		t.Errorf("module initialized %d times, want 1", calls)
+	}
+	if !c.IsInitialized() {
+		t.Error("IsInitialized() = false after Initialize()")
+	}
+}
+
+func TestContainer_ModulePoolPerInstance(t *testing.T) {
+	var userCalls, authCalls int32
+	first := newTestContainer("user", &userCalls)
+	second := newTestContainer("auth", &authCalls)
+	defer second.Scheduler.Stop()
+
+	if err := first.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	if err := second.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+
+	if got := first.GetLoadedModules(); len(got) != 1 || got[0] != "user" {
+		t.Errorf("first.GetLoadedModules() = %v, want [user]", got)
+	}
+	if got := second.GetLoadedModules(); len(got) != 1 || got[0] != "auth" {
+		t.Errorf("second.GetLoadedModules() = %v, want [auth]", got)
+	}
+
+	// 清理一个容器不影响另一个容器的模块
+	if err := first.Cleanup(); err != nil {
+		t.Fatalf("Cleanup() error = %v", err)
+	}
+	if got := first.GetLoadedModules(); len(got) != 0 {
+		t.Errorf("first.GetLoadedModules() after Cleanup() = %v, want none", got)
+	}
+	if got := second.GetContainerInfo().Modules; len(got) != 1 || got["auth"].Name != "auth" {
+		t.Errorf("second modules after first.Cleanup() = %v, want only auth", got)
+	}
+}
+
+func TestContainer_GetContainerInfo(t *testing.T) {
+	var calls int32
+	c := newTestContainer("user", &calls)
+	defer c.Scheduler.Stop()
+
+	// 未初始化的容器也能输出信息
+	info := c.GetContainerInfo()
+	if info.Initialized || len(info.Modules) != 0 || info.Infrastructure.MySQL || info.Infrastructure.MongoDB {
+		t.Errorf("GetContainerInfo() before Initialize() = %+v, want empty", info)
+	}
+	writeContainerInfo(io.Discard, info)
+
+	if err := c.Initialize(); err != nil {
+		t.Fatalf("Initialize() error = %v", err)
+	}
+	info = c.GetContainerInfo()
+	if info.Name != "apiserver-container" || !info.Initialized {
+		t.Errorf("GetContainerInfo() = %+v, want initialized apiserver-container", info)
+	}
+	if len(info.Modules) != 1 || info.Modules["user"].Name != "user" {
+		t.Errorf("GetContainerInfo().Modules = %v, want only user", info.Modules)
+	}
+
+	c.PrintContainerInfo()
+
+	var buf bytes.Buffer
+	writeContainerInfo(&buf, info)
+	for _, want := range []string{"Initialized: true", "MySQL: ❌", "• user"} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("writeContainerInfo() output missing %q:\n%s", want, buf.String())
+		}
+	}
+}
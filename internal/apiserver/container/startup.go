@@ -0,0 +1,91 @@
+package container
+
+import (
+	"strings"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// 模块初始化状态
+const (
+	startupStatusOK     = "ok"
+	startupStatusFailed = "failed"
+)
+
+// moduleTiming 单个模块的初始化耗时
+type moduleTiming struct {
+	name     string
+	duration time.Duration
+	status   string
+}
+
+// StartupTimer 记录容器启动过程中各模块的初始化耗时
+type StartupTimer struct {
+	start   time.Time
+	end     time.Time
+	modules []moduleTiming
+	now     func() time.Time
+}
+
+// NewStartupTimer 创建启动计时器，从创建时刻开始计时
+func NewStartupTimer() *StartupTimer {
+	return newStartupTimer(time.Now)
+}
+
+// newStartupTimer 使用指定时钟创建启动计时器
+func newStartupTimer(now func() time.Time) *StartupTimer {
+	return &StartupTimer{
+		start: now(),
+		now:   now,
+	}
+}
+
+// Track 执行模块初始化并记录耗时与结果
+func (t *StartupTimer) Track(name string, init func() error) error {
+	start := t.now()
+	err := init()
+	duration := t.now().Sub(start)
+
+	status := startupStatusOK
+	if err != nil {
+		status = startupStatusFailed
+	}
+	t.modules = append(t.modules, moduleTiming{name: name, duration: duration, status: status})
+	t.end = t.now()
+
+	log.Info("container module initialized",
+		log.String("module_name", name),
+		log.Int64("init_duration_ms", duration.Milliseconds()),
+		log.String("status", status),
+	)
+	return err
+}
+
+// Total 获取从开始计时到最后一个模块完成的总耗时
+func (t *StartupTimer) Total() time.Duration {
+	if t.end.IsZero() {
+		return 0
+	}
+	return t.end.Sub(t.start)
+}
+
+// Summary 生成启动耗时汇总，例如 "container ready in 1.23s: user=120ms auth=50ms"
+func (t *StartupTimer) Summary() string {
+	var b strings.Builder
+	b.WriteString("container ready in ")
+	b.WriteString(t.Total().Round(10 * time.Millisecond).String())
+	b.WriteString(":")
+	for _, m := range t.modules {
+		b.WriteString(" ")
+		b.WriteString(m.name)
+		b.WriteString("=")
+		b.WriteString(m.duration.Round(time.Millisecond).String())
+		if m.status != startupStatusOK {
+			b.WriteString("(")
+			b.WriteString(m.status)
+			b.WriteString(")")
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,45 @@
+package container
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// fakeClock 每次调用按给定步长推进的时钟
+type fakeClock struct {
+	now   time.Time
+	steps []time.Duration
+}
+
+func (c *fakeClock) Now() time.Time {
+	if len(c.steps) > 0 {
+		c.now = c.now.Add(c.steps[0])
+		c.steps = c.steps[1:]
+	}
+	return c.now
+}
+
+func TestStartupTimer_Summary(t *testing.T) {
+	clock := &fakeClock{
+		now: time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC),
+		// 创建、user 开始/结束/完成、auth 开始/结束/完成
+		steps: []time.Duration{0, 0, 120 * time.Millisecond, 0, 0, 1110 * time.Millisecond, 0},
+	}
+	timer := newStartupTimer(clock.Now)
+
+	if err := timer.Track("user", func() error { return nil }); err != nil {
+		t.Fatalf("Track(user) error = %v", err)
+	}
+	wantErr := errors.New("boom")
+	if err := timer.Track("auth", func() error { return wantErr }); err != wantErr {
+		t.Fatalf("Track(auth) error = %v, want %v", err, wantErr)
+	}
+
+	if got, want := timer.Total(), 1230*time.Millisecond; got != want {
+		t.Errorf("Total() = %s, want %s", got, want)
+	}
+	if got, want := timer.Summary(), "container ready in 1.23s: user=120ms auth=1.11s(failed)"; got != want {
+		t.Errorf("Summary() = %q, want %q", got, want)
+	}
+}
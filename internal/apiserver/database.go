@@ -69,6 +69,7 @@ func (dm *DatabaseManager) initMySQL() error {
 		MaxIdleConnections:    dm.config.MySQLOptions.MaxIdleConnections,
 		MaxOpenConnections:    dm.config.MySQLOptions.MaxOpenConnections,
 		MaxConnectionLifeTime: dm.config.MySQLOptions.MaxConnectionLifeTime,
+		MaxConnectionIdleTime: dm.config.MySQLOptions.MaxConnectionIdleTime,
 		LogLevel:              dm.config.MySQLOptions.LogLevel,
 		Logger:                logger.New(dm.config.MySQLOptions.LogLevel),
 	}
@@ -116,6 +117,9 @@ func (dm *DatabaseManager) initMongoDB() error {
 		SSLAllowInvalidHostnames: dm.config.MongoDBOptions.SSLAllowInvalidHostnames,
 		SSLCAFile:                dm.config.MongoDBOptions.SSLCAFile,
 		SSLPEMKeyfile:            dm.config.MongoDBOptions.SSLPEMKeyfile,
+		MaxPoolSize:              dm.config.MongoDBOptions.MaxPoolSize,
+		MinPoolSize:              dm.config.MongoDBOptions.MinPoolSize,
+		MaxConnIdleTime:          dm.config.MongoDBOptions.MaxConnIdleTime,
 	}
 
 	if mongoConfig.URL == "" {
@@ -0,0 +1,34 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin"
+)
+
+// SystemStatsRepository 系统统计存储库接口（出站端口）
+type SystemStatsRepository interface {
+	// CountQuestionnaires 统计问卷总数
+	CountQuestionnaires(ctx context.Context) (int64, error)
+	// CountActiveQuestionnaires 统计已发布的问卷数
+	CountActiveQuestionnaires(ctx context.Context) (int64, error)
+	// CountAnswerSheets 统计答卷总数
+	CountAnswerSheets(ctx context.Context) (int64, error)
+	// CountRespondents 统计提交过答卷的受试者人数
+	CountRespondents(ctx context.Context) (int64, error)
+	// CountMedicalScales 统计医学量表总数
+	CountMedicalScales(ctx context.Context) (int64, error)
+	// CountDailySubmissions 按天统计 since 之后提交的答卷数，日期按 since 所在时区划分，没有提交的日期不返回
+	CountDailySubmissions(ctx context.Context, since time.Time) ([]admin.DailyCount, error)
+	// FindTopQuestionnaires 查询提交答卷最多的 limit 个问卷
+	FindTopQuestionnaires(ctx context.Context, limit int) ([]admin.QuestionnaireUsage, error)
+}
+
+// SystemSummaryCache 系统概览缓存接口（出站端口）
+type SystemSummaryCache interface {
+	// Get 获取缓存的系统概览，未缓存时返回 nil
+	Get(ctx context.Context) (*admin.SystemSummary, error)
+	// Set 缓存系统概览
+	Set(ctx context.Context, summary *admin.SystemSummary, ttl time.Duration) error
+}
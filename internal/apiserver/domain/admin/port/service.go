@@ -0,0 +1,13 @@
+package port
+
+import (
+	"context"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin"
+)
+
+// SystemSummaryQueryer 系统概览查询接口
+type SystemSummaryQueryer interface {
+	// GetSystemSummary 获取系统概览
+	GetSystemSummary(ctx context.Context) (*admin.SystemSummary, error)
+}
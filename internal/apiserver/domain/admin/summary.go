@@ -0,0 +1,32 @@
+package admin
+
+import "time"
+
+// SystemSummary 系统概览，供管理员查看系统整体运行情况
+type SystemSummary struct {
+	TotalQuestionnaires  int64
+	ActiveQuestionnaires int64
+	TotalAnswersheets    int64
+	TotalRespondents     int64
+	TotalMedicalScales   int64
+	// RecentSubmissions 最近 7 天每日提交的答卷数，按日期升序，没有提交的日期数量为 0
+	RecentSubmissions []DailyCount
+	// TopQuestionnaires 提交答卷最多的问卷，按提交数降序
+	TopQuestionnaires []QuestionnaireUsage
+	// GeneratedAt 概览的统计时间
+	GeneratedAt time.Time
+}
+
+// DailyCount 某一天的数量
+type DailyCount struct {
+	// Date 日期，格式为 2006-01-02
+	Date  string
+	Count int64
+}
+
+// QuestionnaireUsage 问卷的答卷提交数
+type QuestionnaireUsage struct {
+	QuestionnaireCode string
+	Title             string
+	SubmissionCount   int64
+}
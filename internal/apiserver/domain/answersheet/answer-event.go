@@ -0,0 +1,115 @@
+package answersheet
+
+import (
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// 答案事件类型
+const (
+	// AnswerEventTypeSet 首次作答
+	AnswerEventTypeSet = "AnswerSet"
+	// AnswerEventTypeRevised 修改答案
+	AnswerEventTypeRevised = "AnswerRevised"
+)
+
+// MaxAnswerEvents 每份答卷保留的答案事件上限，超出时丢弃最早的事件
+const MaxAnswerEvents = 1000
+
+// AnswerEvent 答案变更事件
+type AnswerEvent struct {
+	EventType    string
+	QuestionCode question.QuestionCode
+	OldValue     *answer.Answer // 首次作答时为 nil
+	NewValue     answer.Answer
+	OccurredAt   time.Time
+}
+
+// WithAnswerEvents 设置答案事件日志
+func WithAnswerEvents(events []AnswerEvent) AnswerSheetOption {
+	return func(a *AnswerSheet) {
+		a.answerEvents = events
+	}
+}
+
+// GetAnswerEvents 获取答案事件日志，按发生顺序排列
+func (a *AnswerSheet) GetAnswerEvents() []AnswerEvent {
+	return a.answerEvents
+}
+
+// SetAnswer 作答或修改答案，并记录答案事件
+func (a *AnswerSheet) SetAnswer(qCode question.QuestionCode, value answer.Answer) error {
+	return a.SetAnswerAt(qCode, value, time.Now())
+}
+
+// SetAnswerAt 在指定时间作答或修改答案，并记录答案事件
+func (a *AnswerSheet) SetAnswerAt(qCode question.QuestionCode, value answer.Answer, occurredAt time.Time) error {
+	if qCode.Value() == "" {
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "问题编码不能为空")
+	}
+	if value.GetQuestionCode() != qCode.Value() {
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "答案的问题编码 %s 与目标问题 %s 不一致", value.GetQuestionCode(), qCode.Value())
+	}
+
+	event := AnswerEvent{
+		EventType:    AnswerEventTypeSet,
+		QuestionCode: qCode,
+		NewValue:     value,
+		OccurredAt:   occurredAt,
+	}
+
+	replaced := false
+	for i, existing := range a.answers {
+		if existing.GetQuestionCode() == qCode.Value() {
+			old := existing
+			event.EventType = AnswerEventTypeRevised
+			event.OldValue = &old
+			a.answers[i] = value
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		a.answers = append(a.answers, value)
+	}
+
+	a.appendAnswerEvent(event)
+	a.updatedAt = occurredAt
+	return nil
+}
+
+// appendAnswerEvent 追加答案事件，超出上限时丢弃最早的事件
+func (a *AnswerSheet) appendAnswerEvent(event AnswerEvent) {
+	a.answerEvents = append(a.answerEvents, event)
+	if overflow := len(a.answerEvents) - MaxAnswerEvents; overflow > 0 {
+		a.answerEvents = append([]AnswerEvent(nil), a.answerEvents[overflow:]...)
+	}
+}
+
+// GetAnswerHistory 获取指定问题的答案事件，按发生顺序排列
+func (a *AnswerSheet) GetAnswerHistory(qCode question.QuestionCode) []AnswerEvent {
+	history := make([]AnswerEvent, 0)
+	for _, event := range a.answerEvents {
+		if event.QuestionCode.Value() == qCode.Value() {
+			history = append(history, event)
+		}
+	}
+	return history
+}
+
+// ReplayToState 重放答案事件，得到截止指定时间（含）的答案状态
+// 事件日志超出上限被截断时，只能重放仍保留的事件
+func (a *AnswerSheet) ReplayToState(upToTime time.Time) map[question.QuestionCode]answer.Answer {
+	state := make(map[question.QuestionCode]answer.Answer)
+	for _, event := range a.answerEvents {
+		if event.OccurredAt.After(upToTime) {
+			continue
+		}
+		state[event.QuestionCode] = event.NewValue
+	}
+	return state
+}
@@ -20,6 +20,7 @@ const (
 	NumberValueType  AnswerValueType = "Number"
 	OptionValueType  AnswerValueType = "Option"
 	OptionsValueType AnswerValueType = "Options"
+	FilesValueType   AnswerValueType = "Files"
 )
 
 // 注册函数签名
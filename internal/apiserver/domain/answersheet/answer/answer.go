@@ -39,6 +39,8 @@ func transforAnswerValueType(qType question.QuestionType) (AnswerValueType, erro
 		return StringValueType, nil
 	case question.QuestionTypeNumber:
 		return NumberValueType, nil
+	case question.QuestionTypeFileUpload:
+		return FilesValueType, nil
 	default:
 		return "", errors.New("no AnswerValueType")
 	}
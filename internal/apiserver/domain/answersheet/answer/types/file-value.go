@@ -0,0 +1,81 @@
+package values
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+)
+
+// 注册文件值工厂
+func init() {
+	answer.RegisterAnswerValueFactory(answer.FilesValueType, func(value any) answer.AnswerValue {
+		switch v := value.(type) {
+		case []FileReference:
+			return FilesValue{V: v}
+		case string:
+			// 签名上传的 upload_id
+			return FilesValue{V: []FileReference{{UploadID: v}}}
+		case []map[string]any:
+			// 处理结构化的文件引用列表
+			files := make([]FileReference, 0, len(v))
+			for _, item := range v {
+				files = append(files, fileReferenceFromMap(item))
+			}
+			return FilesValue{V: files}
+		case []any:
+			// 处理 JSON 反序列化得到的文件引用列表
+			files := make([]FileReference, 0, len(v))
+			for _, item := range v {
+				switch item := item.(type) {
+				case map[string]any:
+					files = append(files, fileReferenceFromMap(item))
+				case string:
+					files = append(files, FileReference{UploadID: item})
+				default:
+					return nil
+				}
+			}
+			return FilesValue{V: files}
+		default:
+			return nil
+		}
+	})
+}
+
+// FileReference 文件引用，指向已上传到文件存储中的文件
+// 通过签名链接直传的文件在提交时只有 UploadID，保存答卷前替换为对应的存储键
+type FileReference struct {
+	FileName   string `json:"file_name"`
+	MimeType   string `json:"mime_type"`
+	StorageKey string `json:"storage_key"`
+	SizeBytes  int64  `json:"size_bytes"`
+	UploadID   string `json:"upload_id,omitempty"`
+}
+
+// FilesValue 文件值
+type FilesValue struct {
+	V []FileReference
+}
+
+// Raw 原始值
+func (v FilesValue) Raw() any { return v.V }
+
+// fileReferenceFromMap 从键值对中解析文件引用
+func fileReferenceFromMap(m map[string]any) FileReference {
+	ref := FileReference{}
+	ref.FileName, _ = m["file_name"].(string)
+	ref.MimeType, _ = m["mime_type"].(string)
+	ref.StorageKey, _ = m["storage_key"].(string)
+	ref.UploadID, _ = m["upload_id"].(string)
+
+	switch size := m["size_bytes"].(type) {
+	case int:
+		ref.SizeBytes = int64(size)
+	case int32:
+		ref.SizeBytes = int64(size)
+	case int64:
+		ref.SizeBytes = size
+	case float64:
+		ref.SizeBytes = int64(size)
+	}
+
+	return ref
+}
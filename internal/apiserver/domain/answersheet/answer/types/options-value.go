@@ -13,6 +13,17 @@ func init() {
 				options[i] = OptionValue{Code: str}
 			}
 			return OptionsValue{V: options}
+		case []any:
+			// 处理 JSON 反序列化得到的选项列表
+			options := make([]OptionValue, 0, len(v))
+			for _, item := range v {
+				str, ok := item.(string)
+				if !ok {
+					return nil
+				}
+				options = append(options, OptionValue{Code: str})
+			}
+			return OptionsValue{V: options}
 		default:
 			return nil
 		}
@@ -0,0 +1,106 @@
+package answersheet
+
+import (
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+)
+
+func TestSetAnswer_RecordsSetAndRevised(t *testing.T) {
+	sheet := NewAnswerSheet("QN1", "1.0")
+	q1 := question.NewQuestionCode("Q1")
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	if err := sheet.SetAnswerAt(q1, newTestAnswer(t, "Q1", question.QuestionTypeRadio, "A"), start); err != nil {
+		t.Fatalf("SetAnswerAt() error = %v", err)
+	}
+	if err := sheet.SetAnswerAt(q1, newTestAnswer(t, "Q1", question.QuestionTypeRadio, "B"), start.Add(time.Minute)); err != nil {
+		t.Fatalf("SetAnswerAt() error = %v", err)
+	}
+
+	if len(sheet.GetAnswers()) != 1 {
+		t.Fatalf("answers = %d, want 1", len(sheet.GetAnswers()))
+	}
+	current, _ := sheet.GetAnswer("Q1")
+	if current.GetValue().Raw() != "B" {
+		t.Errorf("current answer = %v, want B", current.GetValue().Raw())
+	}
+
+	history := sheet.GetAnswerHistory(q1)
+	if len(history) != 2 {
+		t.Fatalf("history = %d, want 2", len(history))
+	}
+	if history[0].EventType != AnswerEventTypeSet || history[0].OldValue != nil {
+		t.Errorf("first event = %+v, want AnswerSet without old value", history[0])
+	}
+	if history[1].EventType != AnswerEventTypeRevised || history[1].OldValue == nil || history[1].OldValue.GetValue().Raw() != "A" {
+		t.Errorf("second event = %+v, want AnswerRevised from A", history[1])
+	}
+}
+
+func TestSetAnswer_RejectsMismatchedQuestionCode(t *testing.T) {
+	sheet := NewAnswerSheet("QN1", "1.0")
+	err := sheet.SetAnswer(question.NewQuestionCode("Q2"), newTestAnswer(t, "Q1", question.QuestionTypeRadio, "A"))
+	if err == nil {
+		t.Fatal("SetAnswer() error = nil, want mismatch error")
+	}
+	if len(sheet.GetAnswerEvents()) != 0 {
+		t.Errorf("events = %d, want 0", len(sheet.GetAnswerEvents()))
+	}
+}
+
+func TestSetAnswer_CapsEventLog(t *testing.T) {
+	sheet := NewAnswerSheet("QN1", "1.0")
+	q1 := question.NewQuestionCode("Q1")
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	for i := 0; i < MaxAnswerEvents+5; i++ {
+		if err := sheet.SetAnswerAt(q1, newTestAnswer(t, "Q1", question.QuestionTypeNumber, i), start.Add(time.Duration(i)*time.Second)); err != nil {
+			t.Fatalf("SetAnswerAt() error = %v", err)
+		}
+	}
+
+	events := sheet.GetAnswerEvents()
+	if len(events) != MaxAnswerEvents {
+		t.Fatalf("events = %d, want %d", len(events), MaxAnswerEvents)
+	}
+	if !events[0].OccurredAt.Equal(start.Add(5 * time.Second)) {
+		t.Errorf("oldest event at %s, want the first 5 events dropped", events[0].OccurredAt)
+	}
+}
+
+func TestReplayToState(t *testing.T) {
+	sheet := NewAnswerSheet("QN1", "1.0")
+	q1 := question.NewQuestionCode("Q1")
+	q2 := question.NewQuestionCode("Q2")
+	start := time.Date(2024, 3, 1, 8, 0, 0, 0, time.UTC)
+
+	steps := []struct {
+		code  question.QuestionCode
+		value string
+		at    time.Time
+	}{
+		{q1, "A", start},
+		{q2, "X", start.Add(time.Minute)},
+		{q1, "B", start.Add(2 * time.Minute)},
+	}
+	for _, step := range steps {
+		if err := sheet.SetAnswerAt(step.code, newTestAnswer(t, step.code.Value(), question.QuestionTypeRadio, step.value), step.at); err != nil {
+			t.Fatalf("SetAnswerAt() error = %v", err)
+		}
+	}
+
+	state := sheet.ReplayToState(start.Add(time.Minute))
+	if len(state) != 2 {
+		t.Fatalf("state = %d answers, want 2", len(state))
+	}
+	a1, a2 := state[q1], state[q2]
+	if a1.GetValue().Raw() != "A" || a2.GetValue().Raw() != "X" {
+		t.Errorf("state = Q1:%v Q2:%v, want Q1:A Q2:X", a1.GetValue().Raw(), a2.GetValue().Raw())
+	}
+
+	if state := sheet.ReplayToState(start.Add(-time.Second)); len(state) != 0 {
+		t.Errorf("state before first event = %d answers, want 0", len(state))
+	}
+}
@@ -11,6 +11,12 @@ import (
 	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
 )
 
+// 答卷来源
+const (
+	// SourceFHIR 从 FHIR QuestionnaireResponse 导入的答卷
+	SourceFHIR = "fhir"
+)
+
 // AnswerSheet 答卷
 type AnswerSheet struct {
 	id                   v1.ID
@@ -19,8 +25,10 @@ type AnswerSheet struct {
 	title                string
 	score                float64
 	answers              []answer.Answer
+	answerEvents         []AnswerEvent
 	writer               *user.Writer
 	testee               *user.Testee
+	source               string
 	createdAt            time.Time
 	updatedAt            time.Time
 }
@@ -87,6 +95,13 @@ func WithTestee(testee *user.Testee) AnswerSheetOption {
 	}
 }
 
+// WithSource 设置答卷来源，在线填写的答卷来源为空
+func WithSource(source string) AnswerSheetOption {
+	return func(a *AnswerSheet) {
+		a.source = source
+	}
+}
+
 func WithCreatedAt(createdAt time.Time) AnswerSheetOption {
 	return func(a *AnswerSheet) {
 		a.createdAt = createdAt
@@ -139,6 +154,11 @@ func (a *AnswerSheet) GetTestee() *user.Testee {
 	return a.testee
 }
 
+// GetSource 获取答卷来源
+func (a *AnswerSheet) GetSource() string {
+	return a.source
+}
+
 func (a *AnswerSheet) GetCreatedAt() time.Time {
 	return a.createdAt
 }
@@ -0,0 +1,113 @@
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// FromAnswerSheet 将答卷导出为 FHIR R4 QuestionnaireResponse 资源，与 ToAnswerSheet 互逆
+// 问题按问卷中的顺序导出，被试者导出为 Patient 引用，填写人导出为 RelatedPerson 引用
+func FromAnswerSheet(sheet *answersheet.AnswerSheet, q *questionnaire.Questionnaire) ([]byte, error) {
+	if sheet == nil || q == nil {
+		return nil, errors.WithCode(code.ErrAnswerSheetInvalid, "answer sheet and questionnaire are required")
+	}
+
+	resource := QuestionnaireResponse{
+		ResourceType:  ResourceTypeQuestionnaireResponse,
+		ID:            strconv.FormatUint(sheet.GetID().Value(), 10),
+		Questionnaire: fmt.Sprintf("%s/%s|%s", referenceTypeQuestionnaire, sheet.GetQuestionnaireCode(), sheet.GetQuestionnaireVersion()),
+		Status:        StatusCompleted,
+	}
+	if testee := sheet.GetTestee(); testee != nil {
+		resource.Subject = &Reference{Reference: fmt.Sprintf("%s/%d", referenceTypePatient, testee.GetUserID().Value())}
+	}
+	if writer := sheet.GetWriter(); writer != nil {
+		resource.Author = &Reference{Reference: fmt.Sprintf("%s/%d", referenceTypeRelatedPerson, writer.GetUserID().Value())}
+	}
+	if !sheet.GetCreatedAt().IsZero() {
+		resource.Authored = sheet.GetCreatedAt().Format(time.RFC3339)
+	}
+
+	answers := make(map[string]answer.Answer, len(sheet.GetAnswers()))
+	for _, ans := range sheet.GetAnswers() {
+		answers[ans.GetQuestionCode()] = ans
+	}
+	for _, qu := range q.GetQuestions() {
+		if qu == nil {
+			continue
+		}
+		ans, ok := answers[qu.GetCode().Value()]
+		if !ok {
+			continue
+		}
+		fhirAnswers, err := exportAnswer(qu, ans)
+		if err != nil {
+			return nil, err
+		}
+		resource.Item = append(resource.Item, ResponseItem{
+			LinkID: qu.GetCode().Value(),
+			Text:   qu.GetTitle(),
+			Answer: fhirAnswers,
+		})
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrEncodingJSON, "encode FHIR QuestionnaireResponse failed")
+	}
+	return data, nil
+}
+
+// exportAnswer 将答案值对象转换为 FHIR 答案，选择题导出为带选项内容的 coding
+func exportAnswer(q question.Question, ans answer.Answer) ([]Answer, error) {
+	switch v := ans.GetValue().Raw().(type) {
+	case []values.OptionValue:
+		fhirAnswers := make([]Answer, 0, len(v))
+		for _, option := range v {
+			fhirAnswers = append(fhirAnswers, Answer{ValueCoding: exportCoding(q, option.Code)})
+		}
+		return fhirAnswers, nil
+	case []values.FileReference:
+		fhirAnswers := make([]Answer, 0, len(v))
+		for _, file := range v {
+			fhirAnswers = append(fhirAnswers, Answer{ValueAttachment: &Attachment{
+				ContentType: file.MimeType,
+				URL:         file.StorageKey,
+				Size:        file.SizeBytes,
+				Title:       file.FileName,
+			}})
+		}
+		return fhirAnswers, nil
+	case float64:
+		return []Answer{{ValueDecimal: &v}}, nil
+	case string:
+		if q.GetType() == question.QuestionTypeRadio {
+			return []Answer{{ValueCoding: exportCoding(q, v)}}, nil
+		}
+		return []Answer{{ValueString: &v}}, nil
+	default:
+		return nil, errors.WithCode(code.ErrAnswerSheetInvalid, "answer of question %s cannot be exported to FHIR", q.GetCode().Value())
+	}
+}
+
+// exportCoding 将选项编码转换为 FHIR 编码，display 取选项内容
+func exportCoding(q question.Question, optionCode string) *Coding {
+	coding := &Coding{Code: optionCode}
+	for _, option := range q.GetOptions() {
+		if option.GetCode() == optionCode {
+			coding.Display = option.GetContent()
+			break
+		}
+	}
+	return coding
+}
@@ -0,0 +1,109 @@
+package fhir
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
+
+// expectedSleepResponse 睡眠问卷答卷对应的 FHIR QuestionnaireResponse
+const expectedSleepResponse = `{
+	"resourceType": "QuestionnaireResponse",
+	"id": "42",
+	"questionnaire": "Questionnaire/sleep|1.0",
+	"status": "completed",
+	"subject": {"reference": "Patient/1001"},
+	"authored": "2025-03-01T08:30:00Z",
+	"author": {"reference": "RelatedPerson/2002"},
+	"item": [
+		{"linkId": "Q1", "text": "睡眠质量", "answer": [{"valueCoding": {"code": "B", "display": "一般"}}]},
+		{"linkId": "Q2", "text": "影响睡眠的因素", "answer": [
+			{"valueCoding": {"code": "A", "display": "噪音"}},
+			{"valueCoding": {"code": "C", "display": "咖啡"}}
+		]},
+		{"linkId": "Q3", "text": "平均睡眠时长", "answer": [{"valueDecimal": 6.5}]},
+		{"linkId": "Q4", "text": "补充说明", "answer": [{"valueString": "经常早醒"}]},
+		{"linkId": "Q5", "text": "睡眠监测报告", "answer": [
+			{"valueAttachment": {"contentType": "application/pdf", "url": "files/abc", "size": 2048, "title": "monitor.pdf"}}
+		]}
+	]
+}`
+
+func TestFromAnswerSheet_Structure(t *testing.T) {
+	newQuestion := func(code, title string, typ question.QuestionType, opts ...question.BuilderOption) question.Question {
+		opts = append(opts,
+			question.WithCode(question.NewQuestionCode(code)),
+			question.WithTitle(title),
+			question.WithQuestionType(typ),
+		)
+		return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(opts...))
+	}
+	q := questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("sleep"), "睡眠问卷",
+		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		questionnaire.WithQuestions([]question.Question{
+			newQuestion("S1", "基本情况", question.QuestionTypeSection),
+			newQuestion("Q1", "睡眠质量", question.QuestionTypeRadio,
+				question.WithOption("A", "好", 0), question.WithOption("B", "一般", 1)),
+			newQuestion("Q2", "影响睡眠的因素", question.QuestionTypeCheckbox,
+				question.WithOption("A", "噪音", 1), question.WithOption("B", "光线", 1), question.WithOption("C", "咖啡", 1)),
+			newQuestion("Q3", "平均睡眠时长", question.QuestionTypeNumber),
+			newQuestion("Q4", "补充说明", question.QuestionTypeText),
+			newQuestion("Q5", "睡眠监测报告", question.QuestionTypeFileUpload),
+			// 未作答的问题不导出
+			newQuestion("Q6", "夜间醒来次数", question.QuestionTypeNumber),
+		}),
+	)
+
+	var answers []answer.Answer
+	for _, a := range []struct {
+		code  string
+		typ   question.QuestionType
+		value any
+	}{
+		{"Q5", question.QuestionTypeFileUpload, []values.FileReference{{FileName: "monitor.pdf", MimeType: "application/pdf", StorageKey: "files/abc", SizeBytes: 2048}}},
+		{"Q4", question.QuestionTypeText, "经常早醒"},
+		{"Q3", question.QuestionTypeNumber, 6.5},
+		{"Q2", question.QuestionTypeCheckbox, []string{"A", "C"}},
+		{"Q1", question.QuestionTypeRadio, "B"},
+	} {
+		ans, err := answer.NewAnswer(question.NewQuestionCode(a.code), a.typ, 0, a.value)
+		if err != nil {
+			t.Fatalf("NewAnswer(%s) error = %v", a.code, err)
+		}
+		answers = append(answers, ans)
+	}
+
+	sheet := answersheet.NewAnswerSheet("sleep", "1.0",
+		answersheet.WithID(v1.NewID(42)),
+		answersheet.WithTestee(user.NewTestee(user.NewUserID(1001), "")),
+		answersheet.WithWriter(user.NewWriter(user.NewUserID(2002), "")),
+		answersheet.WithCreatedAt(time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)),
+		answersheet.WithAnswers(answers),
+	)
+
+	data, err := FromAnswerSheet(sheet, q)
+	if err != nil {
+		t.Fatalf("FromAnswerSheet() error = %v", err)
+	}
+
+	var got, want map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal exported resource: %v", err)
+	}
+	if err := json.Unmarshal([]byte(expectedSleepResponse), &want); err != nil {
+		t.Fatalf("unmarshal expected resource: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("FromAnswerSheet() =\n%s\nwant\n%s", data, expectedSleepResponse)
+	}
+}
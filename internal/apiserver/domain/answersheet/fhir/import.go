@@ -0,0 +1,232 @@
+package fhir
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// ParseResponse 解析 FHIR R4 QuestionnaireResponse 资源
+func ParseResponse(data []byte) (*QuestionnaireResponse, error) {
+	var resource QuestionnaireResponse
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, errors.WrapC(err, code.ErrAnswerSheetInvalid, "invalid FHIR QuestionnaireResponse json")
+	}
+	if resource.ResourceType != ResourceTypeQuestionnaireResponse {
+		return nil, errors.WithCode(code.ErrAnswerSheetInvalid, "unexpected FHIR resourceType %q", resource.ResourceType)
+	}
+	if resource.Questionnaire == "" {
+		return nil, errors.WithCode(code.ErrAnswerSheetInvalid, "FHIR QuestionnaireResponse has no questionnaire")
+	}
+	return &resource, nil
+}
+
+// QuestionnaireRef 返回答卷引用的问卷编码和版本
+// questionnaire 为 canonical 引用，如 http://example.org/Questionnaire/phq9|1.0，
+// 取最后一段路径作为问卷编码，"|" 之后的部分作为版本，未指定版本时版本为空
+func (r *QuestionnaireResponse) QuestionnaireRef() (questionnaireCode, version string) {
+	ref := r.Questionnaire
+	if i := strings.Index(ref, "|"); i >= 0 {
+		ref, version = ref[:i], ref[i+1:]
+	}
+	return ref[strings.LastIndex(ref, "/")+1:], version
+}
+
+// ToAnswerSheet 按问卷定义将 FHIR 答卷转换为答卷，答卷来源为 fhir
+// item 和 answer 下嵌套的问题递归展开，只有答案的问题必须在问卷中存在
+// subject 作为被试者，author 作为填写人，未指定 author 时填写人与被试者相同
+func ToAnswerSheet(resp *QuestionnaireResponse, q *questionnaire.Questionnaire) (*answersheet.AnswerSheet, error) {
+	testeeID, err := referenceID(resp.Subject)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrAnswerSheetInvalid, "invalid FHIR QuestionnaireResponse subject")
+	}
+	writerID := testeeID
+	if resp.Author != nil {
+		if writerID, err = referenceID(resp.Author); err != nil {
+			return nil, errors.WrapC(err, code.ErrAnswerSheetInvalid, "invalid FHIR QuestionnaireResponse author")
+		}
+	}
+
+	authored, err := parseAuthored(resp.Authored)
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make(map[string]question.Question, len(q.GetQuestions()))
+	for _, qu := range q.GetQuestions() {
+		if qu != nil {
+			questions[qu.GetCode().Value()] = qu
+		}
+	}
+
+	var answers []answer.Answer
+	seen := make(map[string]bool)
+	if err := collectAnswers(resp.Item, questions, seen, &answers); err != nil {
+		return nil, err
+	}
+	if len(answers) == 0 {
+		return nil, errors.WithCode(code.ErrAnswerSheetInvalid, "FHIR QuestionnaireResponse has no answers")
+	}
+
+	return answersheet.NewAnswerSheet(
+		q.GetCode().Value(),
+		q.GetVersion().Value(),
+		answersheet.WithTitle(q.GetTitle()),
+		answersheet.WithWriter(user.NewWriter(user.NewUserID(writerID), "")),
+		answersheet.WithTestee(user.NewTestee(user.NewUserID(testeeID), "")),
+		answersheet.WithAnswers(answers),
+		answersheet.WithSource(answersheet.SourceFHIR),
+		answersheet.WithCreatedAt(authored),
+	), nil
+}
+
+// collectAnswers 递归收集问题的答案，同一个问题只能作答一次
+func collectAnswers(items []ResponseItem, questions map[string]question.Question, seen map[string]bool, answers *[]answer.Answer) error {
+	for _, item := range items {
+		if len(item.Answer) > 0 {
+			q, ok := questions[item.LinkID]
+			if !ok {
+				return errors.WithCode(code.ErrAnswerSheetInvalid, "question %s is not defined in questionnaire", item.LinkID)
+			}
+			if seen[item.LinkID] {
+				return errors.WithCode(code.ErrAnswerSheetInvalid, "question %s is answered more than once", item.LinkID)
+			}
+			seen[item.LinkID] = true
+
+			ans, err := importAnswer(q, item.Answer)
+			if err != nil {
+				return err
+			}
+			*answers = append(*answers, ans)
+		}
+
+		if err := collectAnswers(item.Item, questions, seen, answers); err != nil {
+			return err
+		}
+		for _, a := range item.Answer {
+			if err := collectAnswers(a.Item, questions, seen, answers); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// importAnswer 按题型将 FHIR 答案转换为答案值对象，答案类型与题型不匹配时返回错误
+func importAnswer(q question.Question, fhirAnswers []Answer) (answer.Answer, error) {
+	linkID := q.GetCode().Value()
+	if q.GetType() != question.QuestionTypeCheckbox && q.GetType() != question.QuestionTypeFileUpload && len(fhirAnswers) > 1 {
+		return answer.Answer{}, errors.WithCode(code.ErrAnswerSheetInvalid, "question %s accepts a single answer", linkID)
+	}
+
+	var value any
+	switch q.GetType() {
+	case question.QuestionTypeRadio:
+		optionCode, ok := optionCodeOf(fhirAnswers[0])
+		if !ok {
+			return answer.Answer{}, answerTypeMismatch(linkID, "coding")
+		}
+		value = optionCode
+	case question.QuestionTypeCheckbox:
+		optionCodes := make([]string, 0, len(fhirAnswers))
+		for _, a := range fhirAnswers {
+			optionCode, ok := optionCodeOf(a)
+			if !ok {
+				return answer.Answer{}, answerTypeMismatch(linkID, "coding")
+			}
+			optionCodes = append(optionCodes, optionCode)
+		}
+		value = optionCodes
+	case question.QuestionTypeText, question.QuestionTypeTextarea:
+		if fhirAnswers[0].ValueString == nil {
+			return answer.Answer{}, answerTypeMismatch(linkID, "string")
+		}
+		value = *fhirAnswers[0].ValueString
+	case question.QuestionTypeNumber:
+		switch a := fhirAnswers[0]; {
+		case a.ValueDecimal != nil:
+			value = *a.ValueDecimal
+		case a.ValueInteger != nil:
+			value = float64(*a.ValueInteger)
+		default:
+			return answer.Answer{}, answerTypeMismatch(linkID, "decimal or integer")
+		}
+	case question.QuestionTypeFileUpload:
+		files := make([]values.FileReference, 0, len(fhirAnswers))
+		for _, a := range fhirAnswers {
+			if a.ValueAttachment == nil {
+				return answer.Answer{}, answerTypeMismatch(linkID, "attachment")
+			}
+			files = append(files, values.FileReference{
+				FileName:   a.ValueAttachment.Title,
+				MimeType:   a.ValueAttachment.ContentType,
+				StorageKey: a.ValueAttachment.URL,
+				SizeBytes:  a.ValueAttachment.Size,
+			})
+		}
+		value = files
+	default:
+		return answer.Answer{}, errors.WithCode(code.ErrAnswerSheetInvalid, "question %s of type %s cannot be answered", linkID, q.GetType())
+	}
+
+	ans, err := answer.NewAnswer(q.GetCode(), q.GetType(), 0, value)
+	if err != nil {
+		return answer.Answer{}, errors.WrapC(err, code.ErrAnswerSheetInvalid, "invalid answer of question %s", linkID)
+	}
+	return ans, nil
+}
+
+// optionCodeOf 获取选择题答案对应的选项编码，支持 coding、string 和 integer 答案
+func optionCodeOf(a Answer) (string, bool) {
+	switch {
+	case a.ValueCoding != nil:
+		return a.ValueCoding.Code, true
+	case a.ValueString != nil:
+		return *a.ValueString, true
+	case a.ValueInteger != nil:
+		return strconv.Itoa(*a.ValueInteger), true
+	default:
+		return "", false
+	}
+}
+
+// answerTypeMismatch 答案类型与题型不匹配的错误
+func answerTypeMismatch(linkID, want string) error {
+	return errors.WithCode(code.ErrAnswerSheetInvalid, "answer of question %s must be %s", linkID, want)
+}
+
+// referenceID 解析 Patient/1001 形式的引用，返回数字用户ID
+func referenceID(ref *Reference) (uint64, error) {
+	if ref == nil || ref.Reference == "" {
+		return 0, errors.New("reference is required")
+	}
+	raw := ref.Reference[strings.LastIndex(ref.Reference, "/")+1:]
+	id, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil || id == 0 {
+		return 0, errors.Errorf("reference %q does not point to a user id", ref.Reference)
+	}
+	return id, nil
+}
+
+// parseAuthored 解析作答时间，FHIR dateTime 允许只精确到日期，为空时返回零值
+func parseAuthored(authored string) (time.Time, error) {
+	if authored == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if t, err := time.Parse(layout, authored); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, errors.WithCode(code.ErrAnswerSheetInvalid, "invalid FHIR authored time %q", authored)
+}
@@ -0,0 +1,71 @@
+// Package fhir 在答卷与 FHIR R4 QuestionnaireResponse 资源之间相互转换
+// 只解析转换所需的字段，不依赖完整的 FHIR 库
+package fhir
+
+// ResourceTypeQuestionnaireResponse FHIR QuestionnaireResponse 资源类型
+const ResourceTypeQuestionnaireResponse = "QuestionnaireResponse"
+
+// FHIR 答卷状态
+const (
+	StatusInProgress = "in-progress"
+	StatusCompleted  = "completed"
+)
+
+// 导出时使用的引用资源类型
+const (
+	referenceTypePatient       = "Patient"
+	referenceTypeRelatedPerson = "RelatedPerson"
+	referenceTypeQuestionnaire = "Questionnaire"
+)
+
+// QuestionnaireResponse FHIR R4 QuestionnaireResponse 资源
+type QuestionnaireResponse struct {
+	ResourceType  string         `json:"resourceType"`
+	ID            string         `json:"id,omitempty"`
+	Questionnaire string         `json:"questionnaire,omitempty"`
+	Status        string         `json:"status"`
+	Subject       *Reference     `json:"subject,omitempty"`
+	Authored      string         `json:"authored,omitempty"`
+	Author        *Reference     `json:"author,omitempty"`
+	Item          []ResponseItem `json:"item,omitempty"`
+}
+
+// ResponseItem 答卷中的问题，分组问题通过 item 嵌套
+type ResponseItem struct {
+	LinkID string         `json:"linkId"`
+	Text   string         `json:"text,omitempty"`
+	Answer []Answer       `json:"answer,omitempty"`
+	Item   []ResponseItem `json:"item,omitempty"`
+}
+
+// Answer 问题的答案，一个问题允许多个答案，答案下可以嵌套子问题
+type Answer struct {
+	ValueBoolean    *bool          `json:"valueBoolean,omitempty"`
+	ValueDecimal    *float64       `json:"valueDecimal,omitempty"`
+	ValueInteger    *int           `json:"valueInteger,omitempty"`
+	ValueString     *string        `json:"valueString,omitempty"`
+	ValueCoding     *Coding        `json:"valueCoding,omitempty"`
+	ValueAttachment *Attachment    `json:"valueAttachment,omitempty"`
+	Item            []ResponseItem `json:"item,omitempty"`
+}
+
+// Reference 资源引用，如 Patient/1001
+type Reference struct {
+	Reference string `json:"reference,omitempty"`
+	Display   string `json:"display,omitempty"`
+}
+
+// Coding 编码值
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+// Attachment 附件，url 保存文件存储键
+type Attachment struct {
+	ContentType string `json:"contentType,omitempty"`
+	URL         string `json:"url,omitempty"`
+	Size        int64  `json:"size,omitempty"`
+	Title       string `json:"title,omitempty"`
+}
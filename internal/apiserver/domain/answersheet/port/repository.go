@@ -2,6 +2,8 @@ package port
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
 )
@@ -11,8 +13,63 @@ import (
 type AnswerSheetRepositoryMongo interface {
 	Create(ctx context.Context, aDomain *answersheet.AnswerSheet) error
 	Update(ctx context.Context, aDomain *answersheet.AnswerSheet) error
+	HardDelete(ctx context.Context, id uint64) error
+	PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error)
 	FindByID(ctx context.Context, id uint64) (*answersheet.AnswerSheet, error)
 	FindListByWriter(ctx context.Context, writerID uint64, page, pageSize int) ([]*answersheet.AnswerSheet, error)
 	FindListByTestee(ctx context.Context, testeeID uint64, page, pageSize int) ([]*answersheet.AnswerSheet, error)
 	CountWithConditions(ctx context.Context, conditions map[string]interface{}) (int64, error)
+	// IterateByQuestionnaire 按创建时间顺序遍历问卷在 [from, to) 内的答卷，时间为零值时不限制
+	IterateByQuestionnaire(ctx context.Context, questionnaireCode string, from, to time.Time, fn func(*answersheet.AnswerSheet) error) error
+	// FindLatestByQuestionnaire 按创建时间倒序查询问卷最近的 limit 份答卷
+	FindLatestByQuestionnaire(ctx context.Context, questionnaireCode string, limit int) ([]*answersheet.AnswerSheet, error)
+	// FindBatchByQuestionnaire 按答卷ID升序查询问卷中ID大于 afterID 的至多 limit 份答卷
+	FindBatchByQuestionnaire(ctx context.Context, questionnaireCode string, afterID uint64, limit int) ([]*answersheet.AnswerSheet, error)
+	// UpdateScores 批量更新答卷的总分和各题得分
+	UpdateScores(ctx context.Context, sheets []*answersheet.AnswerSheet) error
+}
+
+// FileMeta 文件元信息
+type FileMeta struct {
+	FileName  string
+	MimeType  string
+	SizeBytes int64
+}
+
+// FileStorageRepository 文件存储库接口（出站端口）
+// 用于存储文件上传题的附件，返回的存储键写入答案的文件引用中
+type FileStorageRepository interface {
+	Store(ctx context.Context, r io.Reader, meta FileMeta) (string, error)
+	Retrieve(ctx context.Context, key string) (io.ReadCloser, error)
+	// Stat 查询存储时记录的文件元信息，大小为实际存储的字节数，文件不存在时返回 ErrUploadNotFound
+	Stat(ctx context.Context, key string) (*FileMeta, error)
+}
+
+// PendingUploadRepository 待提交签名上传存储库接口（出站端口）
+// 记录在过期时间后自动失效
+type PendingUploadRepository interface {
+	Save(ctx context.Context, upload *answersheet.PendingUpload) error
+	// FindByID 获取待提交的上传，不存在、已过期或已消费时返回 ErrUploadNotFound
+	FindByID(ctx context.Context, id string) (*answersheet.PendingUpload, error)
+	// Consume 将上传标记为已消费，不存在或已消费时返回 ErrUploadNotFound
+	Consume(ctx context.Context, id string) error
+}
+
+// ObjectStoragePresigner 对象存储签名器接口（出站端口）
+type ObjectStoragePresigner interface {
+	// PresignPostUpload 签发 POST 表单上传链接，限制对象键、文件类型与大小，maxSizeBytes 为 0 时不限制大小
+	PresignPostUpload(ctx context.Context, objectKey, mimeType string, maxSizeBytes int64, expiresAt time.Time) (url string, fields map[string]string, err error)
+}
+
+// ObjectInfo 对象存储中对象的元信息
+type ObjectInfo struct {
+	SizeBytes int64
+	MimeType  string
+}
+
+// ObjectStorageInspector 对象存储查询接口（出站端口）
+// 用于在提交答卷时确认客户端已将文件上传到对象存储
+type ObjectStorageInspector interface {
+	// StatObject 查询对象元信息，对象不存在时返回 ErrUploadNotFound
+	StatObject(ctx context.Context, objectKey string) (*ObjectInfo, error)
 }
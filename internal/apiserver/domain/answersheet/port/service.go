@@ -2,8 +2,11 @@ package port
 
 import (
 	"context"
+	"io"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
 )
 
 // AnswerSheetSaver 答卷保存器
@@ -16,6 +19,34 @@ type AnswerSheetSaver interface {
 	SaveAnswerSheetScores(ctx context.Context, id uint64, totalScore float64, answers []dto.AnswerDTO) (*dto.AnswerSheetDTO, error)
 }
 
+// AnswerSheetSubmitter 答卷提交器
+// 在一次调用中完成答卷的校验、保存、计分与解读报告生成
+type AnswerSheetSubmitter interface {
+	// SubmitAndInterpret 提交答卷并立即生成解读报告
+	SubmitAndInterpret(ctx context.Context, answerSheet dto.AnswerSheetDTO) (*dto.InterpretReportDTO, error)
+}
+
+// SubmissionPublisher 答卷提交事件发布器
+// 实现不应阻塞调用方，发布失败不影响答卷提交
+type SubmissionPublisher interface {
+	// PublishSubmitted 发布答卷提交事件
+	PublishSubmitted(event answersheet.AnswerSheetSubmitted)
+}
+
+// RescorePublisher 答卷重新计分事件发布器
+// 实现不应阻塞调用方，发布失败不影响重新计分
+type RescorePublisher interface {
+	// PublishRescored 发布答卷重新计分事件
+	PublishRescored(event answersheet.AnswerSheetRescored)
+}
+
+// AnswerSheetScorer 答卷计分器
+// 仅计算答卷得分与因子得分，不保存答卷
+type AnswerSheetScorer interface {
+	// ScoreAnswerSheet 计算答卷各题得分、总分及医学量表因子得分
+	ScoreAnswerSheet(ctx context.Context, answerSheet dto.AnswerSheetDTO) (*dto.AnswerSheetScoreDTO, error)
+}
+
 // AnswerSheetQueryer 答卷查询器
 // 专注于答卷的查询操作
 type AnswerSheetQueryer interface {
@@ -24,4 +55,52 @@ type AnswerSheetQueryer interface {
 
 	// GetAnswerSheetList 获取答卷列表
 	GetAnswerSheetList(ctx context.Context, filter dto.AnswerSheetDTO, page, pageSize int) ([]dto.AnswerSheetDTO, int64, error)
+
+	// GetAnswerSheetProgress 获取答卷作答进度（含段落进度）
+	GetAnswerSheetProgress(ctx context.Context, id uint64) (*dto.ProgressReportDTO, error)
+}
+
+// AnswerSheetExporter 答卷导出器
+// 专注于按问卷批量导出答卷
+type AnswerSheetExporter interface {
+	// Export 按问卷和创建时间范围逐条导出答卷，每条记录回调一次 handle
+	Export(ctx context.Context, filter dto.AnswerSheetExportFilterDTO, handle func(record dto.AnswerSheetExportDTO) error) error
+}
+
+// AnswerSheetScoringReporter 答卷计分报表导出器
+// 按问卷汇总答卷得分，生成 Excel 报表
+type AnswerSheetScoringReporter interface {
+	// ExportScoringReportXLSX 导出问卷在 [from, to) 内答卷的计分报表 XLSX 文件，时间为零值时不限制
+	ExportScoringReportXLSX(ctx context.Context, questionnaireCode string, from, to time.Time) ([]byte, error)
+}
+
+// AnswerSheetRescorer 答卷重新计分器
+// 医学量表的常模、计分权重等更新后，按量表最新定义重新计算历史答卷得分
+type AnswerSheetRescorer interface {
+	// RescoreByScale 重新计算量表关联问卷的全部答卷得分，dryRun 为 true 时只报告得分变化，不保存
+	RescoreByScale(ctx context.Context, scaleCode string, dryRun bool) (*dto.RescoreResultDTO, error)
+}
+
+// AnswerSheetFHIRConverter 答卷 FHIR 导入导出器
+// 在答卷与 FHIR R4 QuestionnaireResponse 资源之间相互转换
+type AnswerSheetFHIRConverter interface {
+	// ImportFHIRResponse 按问卷定义校验并保存 FHIR 答卷，返回保存后的答卷
+	ImportFHIRResponse(ctx context.Context, fhirJSON []byte) (*answersheet.AnswerSheet, error)
+
+	// ExportFHIRResponse 将答卷导出为 FHIR QuestionnaireResponse
+	ExportFHIRResponse(ctx context.Context, id uint64) ([]byte, error)
+}
+
+// SignedUploader 签名上传器
+// 为文件上传题签发对象存储的直传链接，文件不经过服务端
+type SignedUploader interface {
+	// GenerateUploadURL 签发上传链接，返回的 UploadID 在提交答卷时作为文件上传题的答案
+	GenerateUploadURL(ctx context.Context, questionnaireCode, questionCode, fileName, mimeType string) (*answersheet.UploadLink, error)
+}
+
+// FileUploader 文件上传器
+// 专注于文件上传题附件的预上传
+type FileUploader interface {
+	// UploadFile 上传文件，返回可在答卷中引用的文件信息
+	UploadFile(ctx context.Context, r io.Reader, meta FileMeta) (*dto.FileReferenceDTO, error)
 }
@@ -0,0 +1,92 @@
+package answersheet
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+)
+
+// SectionCode 段落编码
+type SectionCode string
+
+// Value 获取段落编码
+func (c SectionCode) Value() string {
+	return string(c)
+}
+
+// SectionProgressItem 段落作答进度
+type SectionProgressItem struct {
+	SectionCode       SectionCode
+	SectionTitle      string
+	TotalQuestions    int
+	AnsweredQuestions int
+	IsComplete        bool
+}
+
+// ProgressReport 答卷作答进度
+type ProgressReport struct {
+	TotalQuestions    int
+	AnsweredQuestions int
+	IsComplete        bool
+	SectionProgress   []SectionProgressItem
+	CurrentSection    SectionCode
+}
+
+// NewProgressReport 根据问卷定义计算答卷的作答进度
+// 段落题之后、下一个段落题之前的题目均属于该段落；段落题本身不计入题目数量
+func NewProgressReport(qDomain *questionnaire.Questionnaire, aDomain *AnswerSheet) ProgressReport {
+	answered := make(map[string]struct{}, len(aDomain.GetAnswers()))
+	for _, ans := range aDomain.GetAnswers() {
+		answered[ans.GetQuestionCode()] = struct{}{}
+	}
+
+	report := ProgressReport{
+		SectionProgress: make([]SectionProgressItem, 0),
+	}
+
+	// 题目编码 -> 所属段落在 SectionProgress 中的下标
+	sectionIndexes := make(map[string]int)
+	currentIndex := -1
+
+	for _, q := range qDomain.GetQuestions() {
+		if q.GetType() == question.QuestionTypeSection {
+			report.SectionProgress = append(report.SectionProgress, SectionProgressItem{
+				SectionCode:  SectionCode(q.GetCode().Value()),
+				SectionTitle: q.GetTitle(),
+			})
+			currentIndex = len(report.SectionProgress) - 1
+			continue
+		}
+
+		_, isAnswered := answered[q.GetCode().Value()]
+		report.TotalQuestions++
+		if isAnswered {
+			report.AnsweredQuestions++
+		}
+
+		if currentIndex < 0 {
+			continue
+		}
+		sectionIndexes[q.GetCode().Value()] = currentIndex
+		report.SectionProgress[currentIndex].TotalQuestions++
+		if isAnswered {
+			report.SectionProgress[currentIndex].AnsweredQuestions++
+		}
+	}
+
+	for i := range report.SectionProgress {
+		item := &report.SectionProgress[i]
+		item.IsComplete = item.AnsweredQuestions == item.TotalQuestions
+	}
+	report.IsComplete = report.AnsweredQuestions == report.TotalQuestions
+
+	// 当前段落取最后一个已作答题目所属的段落
+	answers := aDomain.GetAnswers()
+	for i := len(answers) - 1; i >= 0; i-- {
+		if idx, ok := sectionIndexes[answers[i].GetQuestionCode()]; ok {
+			report.CurrentSection = report.SectionProgress[idx].SectionCode
+			break
+		}
+	}
+
+	return report
+}
@@ -0,0 +1,109 @@
+package answersheet
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+)
+
+// newSectionedQuestionnaire 题目 intro 不属于任何段落，段落 S1 包含 q1、q2，段落 S2 包含 q3
+func newSectionedQuestionnaire() *questionnaire.Questionnaire {
+	newQuestion := func(code, title string, typ question.QuestionType) question.Question {
+		return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+			question.WithCode(question.NewQuestionCode(code)),
+			question.WithTitle(title),
+			question.WithQuestionType(typ),
+		))
+	}
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode("SLEEP"),
+		"睡眠质量评估",
+		questionnaire.WithQuestions([]question.Question{
+			newQuestion("intro", "您的年龄", question.QuestionTypeText),
+			newQuestion("S1", "入睡情况", question.QuestionTypeSection),
+			newQuestion("q1", "通常几点上床", question.QuestionTypeText),
+			newQuestion("q2", "入睡需要多久", question.QuestionTypeText),
+			newQuestion("S2", "白天状态", question.QuestionTypeSection),
+			newQuestion("q3", "白天是否犯困", question.QuestionTypeText),
+		}),
+	)
+}
+
+func TestNewProgressReport(t *testing.T) {
+	qDomain := newSectionedQuestionnaire()
+
+	tests := []struct {
+		name         string
+		answered     []string
+		wantAnswered int
+		wantComplete bool
+		wantSections []SectionProgressItem
+		wantCurrent  SectionCode
+	}{
+		{
+			name: "not started",
+			wantSections: []SectionProgressItem{
+				{SectionCode: "S1", SectionTitle: "入睡情况", TotalQuestions: 2},
+				{SectionCode: "S2", SectionTitle: "白天状态", TotalQuestions: 1},
+			},
+		},
+		{
+			// 当前段落取最后作答的题目所属的段落，而不是问卷中靠后的段落
+			name:         "sections complete",
+			answered:     []string{"q1", "q3", "q2"},
+			wantAnswered: 3,
+			wantSections: []SectionProgressItem{
+				{SectionCode: "S1", SectionTitle: "入睡情况", TotalQuestions: 2, AnsweredQuestions: 2, IsComplete: true},
+				{SectionCode: "S2", SectionTitle: "白天状态", TotalQuestions: 1, AnsweredQuestions: 1, IsComplete: true},
+			},
+			wantCurrent: "S1",
+		},
+		{
+			// 不属于任何段落的题目只计入总进度，不改变当前段落
+			name:         "complete",
+			answered:     []string{"q3", "q1", "q2", "intro"},
+			wantAnswered: 4,
+			wantComplete: true,
+			wantSections: []SectionProgressItem{
+				{SectionCode: "S1", SectionTitle: "入睡情况", TotalQuestions: 2, AnsweredQuestions: 2, IsComplete: true},
+				{SectionCode: "S2", SectionTitle: "白天状态", TotalQuestions: 1, AnsweredQuestions: 1, IsComplete: true},
+			},
+			wantCurrent: "S1",
+		},
+		{
+			name:         "one section incomplete",
+			answered:     []string{"q1"},
+			wantAnswered: 1,
+			wantSections: []SectionProgressItem{
+				{SectionCode: "S1", SectionTitle: "入睡情况", TotalQuestions: 2, AnsweredQuestions: 1},
+				{SectionCode: "S2", SectionTitle: "白天状态", TotalQuestions: 1},
+			},
+			wantCurrent: "S1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := make([]answer.Answer, 0, len(tt.answered))
+			for _, code := range tt.answered {
+				answers = append(answers, newTestAnswer(t, code, question.QuestionTypeText, "answer"))
+			}
+
+			report := NewProgressReport(qDomain, NewAnswerSheet("SLEEP", "1.0", WithAnswers(answers)))
+
+			if report.TotalQuestions != 4 || report.AnsweredQuestions != tt.wantAnswered || report.IsComplete != tt.wantComplete {
+				t.Errorf("progress = %d/%d complete=%v, want %d/4 complete=%v",
+					report.AnsweredQuestions, report.TotalQuestions, report.IsComplete, tt.wantAnswered, tt.wantComplete)
+			}
+			if !reflect.DeepEqual(report.SectionProgress, tt.wantSections) {
+				t.Errorf("SectionProgress = %+v, want %+v", report.SectionProgress, tt.wantSections)
+			}
+			if report.CurrentSection != tt.wantCurrent {
+				t.Errorf("CurrentSection = %q, want %q", report.CurrentSection, tt.wantCurrent)
+			}
+		})
+	}
+}
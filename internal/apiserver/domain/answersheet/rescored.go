@@ -0,0 +1,13 @@
+package answersheet
+
+import "time"
+
+// AnswerSheetRescored 答卷重新计分事件，在医学量表更新后按新的计分规则重新计分并保存答卷后发布
+type AnswerSheetRescored struct {
+	AnswerSheetID     uint64
+	QuestionnaireCode string
+	ScaleCode         string
+	OldScore          float64
+	NewScore          float64
+	RescoredAt        time.Time
+}
@@ -0,0 +1,91 @@
+package answersheet
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	scoringsdk "github.com/yshujie/questionnaire-scale/pkg/scoring"
+)
+
+// ScoreAnswerSheet 按问卷选项分值计算答案得分及答卷总分，返回计分后的答卷
+// 题目得分由计分 SDK 计算：单选题取所选选项的分值，多选题取所选选项分值之和，其余题型不计分；
+// 设置了条件计分规则的题目，按引用题目的答案选择选项分值映射；答卷总分为各题得分之和
+func ScoreAnswerSheet(qDomain *questionnaire.Questionnaire, aDomain *AnswerSheet) *AnswerSheet {
+	scored, _ := ScoreAnswerSheetWithEngine(qDomain, aDomain, scoring.NewScoringRuleEngineBuilder().Build())
+	return scored
+}
+
+// ScoreAnswerSheetWithEngine 计算答案得分后由计分规则引擎计算各项得分，返回计分后的答卷及计分结果
+// 引擎计算出总分时以其作为答卷总分，否则答卷总分为各题得分之和
+func ScoreAnswerSheetWithEngine(qDomain *questionnaire.Questionnaire, aDomain *AnswerSheet, engine *scoring.ScoringRuleEngine) (*AnswerSheet, *scoring.ScoreResult) {
+	questionScores := qDomain.ScoringDefinition().ScoreQuestions(toScoringAnswers(aDomain.GetAnswers()))
+
+	var totalScore float64
+	scored := make([]answer.Answer, 0, len(aDomain.GetAnswers()))
+	for _, ans := range aDomain.GetAnswers() {
+		score := questionScores[ans.GetQuestionCode()]
+		totalScore += score
+
+		scoredAnswer, err := answer.NewAnswer(
+			question.NewQuestionCode(ans.GetQuestionCode()),
+			question.QuestionType(ans.GetQuestionType()),
+			score,
+			ans.GetValue().Raw(),
+		)
+		if err != nil {
+			scoredAnswer = ans
+		}
+		scored = append(scored, scoredAnswer)
+	}
+
+	result := engine.Score(scoring.NewScoredQuestions(qDomain.GetQuestions()), scoring.NewAnswerMap(scored))
+	if score, ok := result.GetTotalScore(); ok {
+		totalScore = score
+	}
+
+	return NewAnswerSheet(
+		aDomain.GetQuestionnaireCode(),
+		aDomain.GetQuestionnaireVersion(),
+		WithID(aDomain.GetID()),
+		WithTitle(aDomain.GetTitle()),
+		WithScore(totalScore),
+		WithAnswers(scored),
+		WithAnswerEvents(aDomain.answerEvents),
+		WithWriter(aDomain.writer),
+		WithTestee(aDomain.testee),
+		WithCreatedAt(aDomain.GetCreatedAt()),
+		WithUpdatedAt(aDomain.GetUpdatedAt()),
+	), result
+}
+
+// PercentageScore 将答卷总分换算为百分制得分：总分 / 问卷最高可得分 * 100
+// 问卷最高可得分为 0 时无法换算，返回计分配置无效错误
+func PercentageScore(qDomain *questionnaire.Questionnaire, totalScore float64) (float64, error) {
+	maxScore := qDomain.ComputeMaxScore()
+	if maxScore == 0 {
+		return 0, errors.WithCode(errCode.ErrScoringConfigInvalid, "问卷 %s 的最高可得分为 0，无法换算百分制得分", qDomain.GetCode().Value())
+	}
+	return totalScore / maxScore * 100, nil
+}
+
+// toScoringAnswers 将答案转换为计分 SDK 的答案，多选题的值转换为选项编码列表
+func toScoringAnswers(answers []answer.Answer) scoringsdk.Answers {
+	result := make(scoringsdk.Answers, len(answers))
+	for _, ans := range answers {
+		switch v := ans.GetValue().Raw().(type) {
+		case []values.OptionValue:
+			codes := make([]string, 0, len(v))
+			for _, opt := range v {
+				codes = append(codes, opt.Code)
+			}
+			result[ans.GetQuestionCode()] = codes
+		default:
+			result[ans.GetQuestionCode()] = v
+		}
+	}
+	return result
+}
@@ -0,0 +1,156 @@
+package answersheet
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newFrequencySeverityQuestionnaire 严重程度的得分取决于发生频率：
+// 从不发生时不计分，经常发生时加倍计分，偶尔发生时按选项分值计分
+func newFrequencySeverityQuestionnaire() *questionnaire.Questionnaire {
+	frequency := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("frequency")),
+		question.WithTitle("头痛发生的频率"),
+		question.WithQuestionType(question.QuestionTypeRadio),
+		question.WithOption("never", "从不", 0),
+		question.WithOption("sometimes", "偶尔", 1),
+		question.WithOption("often", "经常", 2),
+	))
+	severity := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("severity")),
+		question.WithTitle("头痛的严重程度"),
+		question.WithQuestionType(question.QuestionTypeRadio),
+		question.WithOption("mild", "轻度", 1),
+		question.WithOption("moderate", "中度", 2),
+		question.WithOption("severe", "重度", 3),
+		question.WithConditionalCalculationRule(question.NewQuestionCode("frequency"),
+			calculation.NewScoreMapping("never", map[string]float64{"mild": 0, "moderate": 0, "severe": 0}),
+			calculation.NewScoreMapping("often", map[string]float64{"mild": 2, "moderate": 4, "severe": 6}),
+		),
+	))
+
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode("HEADACHE"),
+		"头痛评估",
+		questionnaire.WithQuestions([]question.Question{frequency, severity}),
+	)
+}
+
+func TestScoreAnswerSheet_ConditionalCalculation(t *testing.T) {
+	qDomain := newFrequencySeverityQuestionnaire()
+
+	tests := []struct {
+		frequency     string
+		wantSeverity  float64
+		wantTotal     float64
+		skipFrequency bool
+	}{
+		{frequency: "never", wantSeverity: 0, wantTotal: 0},
+		{frequency: "sometimes", wantSeverity: 2, wantTotal: 3},
+		{frequency: "often", wantSeverity: 4, wantTotal: 6},
+		{frequency: "unanswered", wantSeverity: 2, wantTotal: 2, skipFrequency: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.frequency, func(t *testing.T) {
+			answers := []answer.Answer{newTestAnswer(t, "severity", question.QuestionTypeRadio, "moderate")}
+			if !tt.skipFrequency {
+				answers = append(answers, newTestAnswer(t, "frequency", question.QuestionTypeRadio, tt.frequency))
+			}
+
+			scored := ScoreAnswerSheet(qDomain, NewAnswerSheet("HEADACHE", "1.0", WithAnswers(answers)))
+
+			for _, ans := range scored.GetAnswers() {
+				if ans.GetQuestionCode() == "severity" && ans.GetScore() != tt.wantSeverity {
+					t.Errorf("severity score = %v, want %v", ans.GetScore(), tt.wantSeverity)
+				}
+			}
+			if scored.GetScore() != tt.wantTotal {
+				t.Errorf("total score = %v, want %v", scored.GetScore(), tt.wantTotal)
+			}
+		})
+	}
+}
+
+// newRatingScaleQuestionnaire 4 道 0-3 分的评分题，最高可得分为 12
+func newRatingScaleQuestionnaire() *questionnaire.Questionnaire {
+	questions := make([]question.Question, 0, 4)
+	for _, code := range []string{"q1", "q2", "q3", "q4"} {
+		questions = append(questions, question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+			question.WithCode(question.NewQuestionCode(code)),
+			question.WithTitle(code),
+			question.WithQuestionType(question.QuestionTypeRadio),
+			question.WithOption("0", "没有", 0),
+			question.WithOption("1", "轻度", 1),
+			question.WithOption("2", "中度", 2),
+			question.WithOption("3", "重度", 3),
+		)))
+	}
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode("RATING"),
+		"评分量表",
+		questionnaire.WithQuestions(questions),
+	)
+}
+
+func TestPercentageScore(t *testing.T) {
+	qDomain := newRatingScaleQuestionnaire()
+	if got := qDomain.ComputeMaxScore(); got != 12 {
+		t.Fatalf("ComputeMaxScore() = %v, want 12", got)
+	}
+
+	tests := []struct {
+		name    string
+		options [4]string
+		want    float64
+	}{
+		{name: "minimum", options: [4]string{"0", "0", "0", "0"}, want: 0},
+		{name: "lowest non-zero", options: [4]string{"1", "0", "0", "0"}, want: 1.0 / 12 * 100},
+		{name: "half", options: [4]string{"3", "3", "0", "0"}, want: 50},
+		{name: "one below maximum", options: [4]string{"3", "3", "3", "2"}, want: 11.0 / 12 * 100},
+		{name: "maximum", options: [4]string{"3", "3", "3", "3"}, want: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			answers := make([]answer.Answer, 0, len(tt.options))
+			for i, option := range tt.options {
+				answers = append(answers, newTestAnswer(t, qDomain.GetQuestions()[i].GetCode().Value(), question.QuestionTypeRadio, option))
+			}
+			scored := ScoreAnswerSheet(qDomain, NewAnswerSheet("RATING", "1.0", WithAnswers(answers)))
+
+			got, err := PercentageScore(qDomain, scored.GetScore())
+			if err != nil {
+				t.Fatalf("PercentageScore() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("PercentageScore(%v) = %v, want %v", scored.GetScore(), got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercentageScore_ZeroMaxScore(t *testing.T) {
+	qDomain := questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode("TEXT_ONLY"),
+		"无计分题目",
+		questionnaire.WithQuestions([]question.Question{
+			question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+				question.WithCode(question.NewQuestionCode("comment")),
+				question.WithTitle("备注"),
+				question.WithQuestionType(question.QuestionTypeText),
+			)),
+		}),
+	)
+
+	if _, err := PercentageScore(qDomain, 0); !errors.IsCode(err, errCode.ErrScoringConfigInvalid) {
+		t.Errorf("PercentageScore() error = %v, want ErrScoringConfigInvalid", err)
+	}
+}
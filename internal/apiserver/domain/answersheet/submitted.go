@@ -0,0 +1,12 @@
+package answersheet
+
+import "time"
+
+// AnswerSheetSubmitted 答卷提交事件，在答卷保存（或提交并解读）成功后发布
+type AnswerSheetSubmitted struct {
+	AnswerSheetID     uint64
+	QuestionnaireCode string
+	TesteeID          uint64
+	TotalScore        float64 // 计分前保存的答卷为 0
+	SubmittedAt       time.Time
+}
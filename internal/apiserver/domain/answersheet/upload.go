@@ -0,0 +1,34 @@
+package answersheet
+
+import "time"
+
+// PendingUpload 待提交的签名上传
+// 签发上传链接时创建，客户端上传文件后在答卷中以 upload_id 引用，提交答卷时消费
+type PendingUpload struct {
+	ID                string
+	QuestionnaireCode string
+	QuestionCode      string
+	ObjectKey         string // 对象存储中的键，消费后写入答案的文件引用
+	FileName          string
+	MimeType          string
+	MaxSizeBytes      int64 // 上传策略允许的最大字节数，0 表示不限制
+	ExpiresAt         time.Time
+}
+
+// IsExpired 判断上传链接是否已过期
+func (u *PendingUpload) IsExpired(now time.Time) bool {
+	return !now.Before(u.ExpiresAt)
+}
+
+// Matches 判断上传是否签发给指定问卷的指定题目
+func (u *PendingUpload) Matches(questionnaireCode, questionCode string) bool {
+	return u.QuestionnaireCode == questionnaireCode && u.QuestionCode == questionCode
+}
+
+// UploadLink 签名上传链接，客户端将 Fields 作为表单字段、文件作为 file 字段 POST 到 URL
+type UploadLink struct {
+	UploadID  string
+	URL       string
+	Fields    map[string]string
+	ExpiresAt time.Time
+}
@@ -0,0 +1,82 @@
+package answersheet
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+)
+
+// FieldError 答卷字段校验错误
+type FieldError struct {
+	QuestionCode string
+	Message      string
+}
+
+// Error 实现 error 接口
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.QuestionCode, e.Message)
+}
+
+// ValidateConditionalRequired 校验题目的条件必填规则
+// 依赖题目的答案等于规则指定的值而本题未作答时，返回对应的字段错误
+func ValidateConditionalRequired(qDomain *questionnaire.Questionnaire, answers []answer.Answer) []FieldError {
+	answerMap := make(map[string]answer.Answer, len(answers))
+	for _, ans := range answers {
+		answerMap[ans.GetQuestionCode()] = ans
+	}
+
+	var fieldErrors []FieldError
+	for _, q := range qDomain.GetQuestions() {
+		code := q.GetCode().Value()
+		if ans, ok := answerMap[code]; ok && !isAnswerEmpty(ans) {
+			continue
+		}
+
+		for _, dep := range q.GetConditionalRequired() {
+			depAnswer, ok := answerMap[dep.DependsOnCode.Value()]
+			if !ok || !answerMatches(depAnswer, dep.RequiredWhenValue) {
+				continue
+			}
+			fieldErrors = append(fieldErrors, FieldError{
+				QuestionCode: code,
+				Message:      fmt.Sprintf("题目 %s 的答案为 %v 时必须作答", dep.DependsOnCode.Value(), dep.RequiredWhenValue),
+			})
+			break
+		}
+	}
+	return fieldErrors
+}
+
+// isAnswerEmpty 判断答案是否为空
+func isAnswerEmpty(ans answer.Answer) bool {
+	switch v := ans.GetValue().Raw().(type) {
+	case nil:
+		return true
+	case string:
+		return strings.TrimSpace(v) == ""
+	case []values.OptionValue:
+		return len(v) == 0
+	case []values.FileReference:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
+// answerMatches 判断答案是否等于期望值，多选题只要任一选项等于期望值即视为匹配
+func answerMatches(ans answer.Answer, want interface{}) bool {
+	switch v := ans.GetValue().Raw().(type) {
+	case []values.OptionValue:
+		for _, opt := range v {
+			if opt.Code == fmt.Sprint(want) {
+				return true
+			}
+		}
+		return false
+	default:
+		return fmt.Sprint(v) == fmt.Sprint(want)
+	}
+}
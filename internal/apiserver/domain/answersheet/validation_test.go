@@ -0,0 +1,95 @@
+package answersheet
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types"
+)
+
+func newConditionalQuestionnaire() *questionnaire.Questionnaire {
+	q1 := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("Q1")),
+		question.WithTitle("是否服用过药物"),
+		question.WithQuestionType(question.QuestionTypeRadio),
+		question.WithOption("yes", "是", 0),
+		question.WithOption("no", "否", 0),
+	))
+	q2 := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("Q2")),
+		question.WithTitle("请填写药物名称"),
+		question.WithQuestionType(question.QuestionTypeText),
+		question.WithConditionalRequired(question.NewConditionalRequired(question.NewQuestionCode("Q1"), "yes")),
+	))
+
+	return questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode("QN1"),
+		"用药情况",
+		questionnaire.WithQuestions([]question.Question{q1, q2}),
+	)
+}
+
+func newTestAnswer(t *testing.T, code string, qType question.QuestionType, value any) answer.Answer {
+	t.Helper()
+	ans, err := answer.NewAnswer(question.NewQuestionCode(code), qType, 0, value)
+	if err != nil {
+		t.Fatalf("NewAnswer(%s) error = %v", code, err)
+	}
+	return ans
+}
+
+func TestValidateConditionalRequired(t *testing.T) {
+	qDomain := newConditionalQuestionnaire()
+
+	tests := []struct {
+		name    string
+		answers []answer.Answer
+		wantErr bool
+	}{
+		{
+			name: "Q1 is yes and Q2 is missing",
+			answers: []answer.Answer{
+				newTestAnswer(t, "Q1", question.QuestionTypeRadio, "yes"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Q1 is yes and Q2 is blank",
+			answers: []answer.Answer{
+				newTestAnswer(t, "Q1", question.QuestionTypeRadio, "yes"),
+				newTestAnswer(t, "Q2", question.QuestionTypeText, "  "),
+			},
+			wantErr: true,
+		},
+		{
+			name: "Q1 is yes and Q2 is answered",
+			answers: []answer.Answer{
+				newTestAnswer(t, "Q1", question.QuestionTypeRadio, "yes"),
+				newTestAnswer(t, "Q2", question.QuestionTypeText, "阿司匹林"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "Q1 is no and Q2 is missing",
+			answers: []answer.Answer{
+				newTestAnswer(t, "Q1", question.QuestionTypeRadio, "no"),
+			},
+			wantErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fieldErrors := ValidateConditionalRequired(qDomain, tt.answers)
+			if (len(fieldErrors) > 0) != tt.wantErr {
+				t.Fatalf("ValidateConditionalRequired() = %v, wantErr %v", fieldErrors, tt.wantErr)
+			}
+			if tt.wantErr && fieldErrors[0].QuestionCode != "Q2" {
+				t.Errorf("FieldError.QuestionCode = %s, want Q2", fieldErrors[0].QuestionCode)
+			}
+		})
+	}
+}
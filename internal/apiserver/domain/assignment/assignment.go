@@ -0,0 +1,57 @@
+package assignment
+
+import (
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Assignment 问卷分配
+// 管理员为受试者分配需要在截止日期前完成的问卷，受试者提交该问卷的答卷后分配即完成
+type Assignment struct {
+	ID                uint64
+	AssignmentCode    string
+	RespondentID      uint64
+	QuestionnaireCode string
+	AssignedBy        uint64
+	DueDate           *time.Time // 截止日期，为空表示不限期
+	CompletedAt       *time.Time // 完成时间，为空表示未完成
+	ReminderSentAt    *time.Time // 逾期提醒的发送时间，为空表示未提醒
+	CreatedAt         time.Time
+}
+
+// NewAssignment 创建问卷分配
+func NewAssignment(assignmentCode string, respondentID uint64, questionnaireCode string, assignedBy uint64, dueDate *time.Time) *Assignment {
+	return &Assignment{
+		AssignmentCode:    assignmentCode,
+		RespondentID:      respondentID,
+		QuestionnaireCode: questionnaireCode,
+		AssignedBy:        assignedBy,
+		DueDate:           dueDate,
+	}
+}
+
+// IsCompleted 判断分配是否已完成
+func (a *Assignment) IsCompleted() bool {
+	return a.CompletedAt != nil
+}
+
+// IsOverdue 判断分配在 now 时是否已逾期，已完成或不限期的分配不会逾期
+func (a *Assignment) IsOverdue(now time.Time) bool {
+	return !a.IsCompleted() && a.DueDate != nil && a.DueDate.Before(now)
+}
+
+// Complete 将分配标记为在 now 完成，已完成时返回 ErrAssignmentAlreadyCompleted
+func (a *Assignment) Complete(now time.Time) error {
+	if a.IsCompleted() {
+		return errors.WithCode(code.ErrAssignmentAlreadyCompleted, "问卷分配 %s 已完成", a.AssignmentCode)
+	}
+	a.CompletedAt = &now
+	return nil
+}
+
+// MarkReminderSent 记录逾期提醒的发送时间
+func (a *Assignment) MarkReminderSent(now time.Time) {
+	a.ReminderSentAt = &now
+}
@@ -0,0 +1,30 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+)
+
+// AssignmentFilter 问卷分配查询条件，零值字段不参与过滤
+type AssignmentFilter struct {
+	RespondentID      uint64
+	QuestionnaireCode string
+	PendingOnly       bool // 只查询未完成的分配
+}
+
+// AssignmentRepository 问卷分配存储库接口（出站端口）
+type AssignmentRepository interface {
+	Create(ctx context.Context, a *assignment.Assignment) error
+	// FindByCode 根据分配编码查询，不存在时返回 ErrAssignmentNotFound
+	FindByCode(ctx context.Context, assignmentCode string) (*assignment.Assignment, error)
+	// FindList 分页查询问卷分配，按创建时间倒序，返回当前页和总数
+	FindList(ctx context.Context, filter AssignmentFilter, page, pageSize int) ([]*assignment.Assignment, int64, error)
+	// FindPending 查询受试者在问卷下未完成的分配，按截止日期升序，不限期的排在最后
+	FindPending(ctx context.Context, respondentID uint64, questionnaireCode string) ([]*assignment.Assignment, error)
+	// FindOverdue 查询截止日期早于 now、未完成且未发送逾期提醒的分配，最多返回 limit 条
+	FindOverdue(ctx context.Context, now time.Time, limit int) ([]*assignment.Assignment, error)
+	Update(ctx context.Context, a *assignment.Assignment) error
+	Remove(ctx context.Context, assignmentCode string) error
+}
@@ -0,0 +1,25 @@
+package port
+
+import (
+	"context"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+)
+
+// AssignmentCreator 问卷分配创建器
+type AssignmentCreator interface {
+	// CreateAssignment 为受试者分配问卷，dueDate 为空表示不限期
+	CreateAssignment(ctx context.Context, respondentID uint64, questionnaireCode string, assignedBy uint64, dueDate *time.Time) (*assignment.Assignment, error)
+}
+
+// AssignmentCompleter 问卷分配完成器
+type AssignmentCompleter interface {
+	// CompleteAssignment 将分配标记为已完成
+	CompleteAssignment(ctx context.Context, assignmentCode string) error
+}
+
+// AssignmentQueryer 问卷分配查询器
+type AssignmentQueryer interface {
+	ListAssignments(ctx context.Context, filter AssignmentFilter, page, pageSize int) ([]*assignment.Assignment, int64, error)
+}
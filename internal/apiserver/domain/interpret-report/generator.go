@@ -0,0 +1,81 @@
+package interpretationreport
+
+import (
+	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// GenerateInterpretReport 根据医学量表的因子计算规则与解读规则生成解读报告
+// answerScores 为题目编码到答案得分的映射；一级因子引用题目得分，多级因子引用其他因子得分
+func GenerateInterpretReport(answerSheetID uint64, ms *medicalscale.MedicalScale, answerScores map[string]float64, opts ...InterpretReportOption) (*InterpretReport, error) {
+	factorScores := make(map[string]float64, len(ms.GetFactors()))
+
+	// 先计算一级因子，再计算多级因子
+	for _, factorType := range []factor.FactorType{factor.PrimaryFactor, factor.MultilevelFactor} {
+		for _, f := range ms.GetFactors() {
+			if f.GetFactorType() != factorType || f.GetCalculationAbility() == nil {
+				continue
+			}
+			rule := f.GetCalculationAbility().GetCalculationRule()
+			if rule == nil {
+				continue
+			}
+
+			sourceScores := answerScores
+			if factorType == factor.MultilevelFactor {
+				sourceScores = factorScores
+			}
+
+			operands := make([]float64, 0, len(rule.GetSourceCodes()))
+			for _, sourceCode := range rule.GetSourceCodes() {
+				if score, ok := sourceScores[sourceCode]; ok {
+					operands = append(operands, score)
+				}
+			}
+
+			score, err := calculateFactorScore(rule, operands)
+			if err != nil {
+				return nil, errors.WrapC(err, errCode.ErrInterpretReportGenerationFailed, "因子 %s 计算失败", f.GetCode())
+			}
+			factorScores[f.GetCode()] = score
+		}
+	}
+
+	items := make([]InterpretItem, 0, len(factorScores))
+	for _, f := range ms.GetFactors() {
+		score, ok := factorScores[f.GetCode()]
+		if !ok {
+			continue
+		}
+		items = append(items, NewInterpretItem(f.GetCode(), f.GetTitle(), score, interpretFactorScore(f, score)))
+	}
+
+	opts = append(opts, WithInterpretItems(items))
+	return NewInterpretReport(answerSheetID, ms.GetCode(), ms.GetTitle(), opts...), nil
+}
+
+// calculateFactorScore 按计算规则的公式计算因子得分
+func calculateFactorScore(rule *calculation.CalculationRule, operands []float64) (float64, error) {
+	score, err := rule.Calculate(operands)
+	if err != nil {
+		return 0, errors.WrapC(err, errCode.ErrInterpretReportGenerationFailed, "不支持的计算公式: %s", rule.GetFormula())
+	}
+	return score, nil
+}
+
+// interpretFactorScore 获取因子得分命中的解读内容
+func interpretFactorScore(f factor.Factor, score float64) string {
+	if f.GetInterpretationAbility() == nil {
+		return ""
+	}
+
+	for _, rule := range f.GetInterpretationAbility().GetInterpretationRules() {
+		if rule.GetScoreRange().Contains(score) {
+			return rule.GetContent()
+		}
+	}
+	return ""
+}
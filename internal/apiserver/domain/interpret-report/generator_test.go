@@ -0,0 +1,36 @@
+package interpretationreport
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+)
+
+func TestCalculateFactorScore_AggregationModes(t *testing.T) {
+	items := []float64{0, 2, 1, 3, 2}
+
+	tests := []struct {
+		name string
+		rule *calculation.CalculationRule
+		want float64
+	}{
+		{name: "sum", rule: calculation.NewCalculationRule(calculation.FormulaTypeSum, nil), want: 8},
+		{name: "mean", rule: calculation.NewCalculationRule(calculation.FormulaTypeAvg, nil), want: 1.6},
+		{name: "max", rule: calculation.NewCalculationRule(calculation.FormulaTypeMax, nil), want: 3},
+		{name: "min", rule: calculation.NewCalculationRule(calculation.FormulaTypeMin, nil), want: 0},
+		{name: "count above threshold", rule: calculation.NewCountAboveThresholdCalculationRule(nil, 1), want: 3},
+		{name: "count above threshold excludes equal scores", rule: calculation.NewCountAboveThresholdCalculationRule(nil, 2), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := calculateFactorScore(tt.rule, items)
+			if err != nil {
+				t.Fatalf("calculateFactorScore() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("calculateFactorScore() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
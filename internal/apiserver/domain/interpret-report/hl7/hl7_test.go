@@ -0,0 +1,109 @@
+package hl7
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
+
+func newTestReport() *interpretreport.InterpretReport {
+	return interpretreport.NewInterpretReport(9001, "PHQ9", "PHQ-9 抑郁筛查",
+		interpretreport.WithID(v1.NewID(77)),
+		interpretreport.WithTestee(*user.NewTestee(user.NewUserID(1001), "张三")),
+		interpretreport.WithInterpretItems([]interpretreport.InterpretItem{
+			interpretreport.NewInterpretItem("total", "总分", 12, "中度抑郁|建议复诊"),
+			interpretreport.NewInterpretItem("sleep", "睡眠^质量", 2.5, ""),
+		}),
+	)
+}
+
+func TestGenerateORUMessage_Reparse(t *testing.T) {
+	now = func() time.Time { return time.Date(2024, 5, 6, 7, 8, 9, 0, time.UTC) }
+	t.Cleanup(func() { now = time.Now })
+
+	message, err := GenerateORUMessage(newTestReport(), PatientInfo{
+		FamilyName: `O'Brien&Sons`,
+		GivenName:  `Mary~Ann\Jr`,
+		BirthDate:  time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC),
+		Gender:     "f",
+	})
+	if err != nil {
+		t.Fatalf("GenerateORUMessage() error = %v", err)
+	}
+	if !strings.HasPrefix(message, "MSH|^~\\&|QUESTIONNAIRE-SCALE|") {
+		t.Errorf("message does not start with MSH header: %q", message)
+	}
+
+	msg, err := Parse(message)
+	if err != nil {
+		t.Fatalf("Parse() error = %v\n%s", err, message)
+	}
+
+	var names []string
+	for _, seg := range msg.Segments {
+		names = append(names, seg.Name)
+	}
+	if got := strings.Join(names, ","); got != "MSH,PID,OBR,OBX,NTE,OBX" {
+		t.Fatalf("segments = %s, want MSH,PID,OBR,OBX,NTE,OBX", got)
+	}
+
+	msh := msg.Segments[0]
+	if msh.Field(2) != encodingCharacters || msh.Component(9, 1) != "ORU" || msh.Component(9, 2) != "R01" {
+		t.Errorf("MSH = %v, want ORU^R01 with default encoding characters", msh.Fields)
+	}
+	if msh.Field(7) != "20240506070809" || msh.Field(12) != Version {
+		t.Errorf("MSH-7/MSH-12 = %q/%q", msh.Field(7), msh.Field(12))
+	}
+
+	pid := msg.SegmentsByName("PID")[0]
+	if pid.Field(3) != "1001" || pid.Component(3, 5) != "MR" {
+		t.Errorf("PID-3 = %v, want testee id 1001 as MR", pid.Fields[3])
+	}
+	if pid.Component(5, 1) != `O'Brien&Sons` || pid.Component(5, 2) != `Mary~Ann\Jr` {
+		t.Errorf("PID-5 = %v, want escaped delimiters restored", pid.Fields[5])
+	}
+	if pid.Field(7) != "19900102" || pid.Field(8) != "F" {
+		t.Errorf("PID-7/PID-8 = %q/%q", pid.Field(7), pid.Field(8))
+	}
+
+	obr := msg.SegmentsByName("OBR")[0]
+	if obr.Field(3) != "9001" || obr.Component(4, 1) != "PHQ9" || obr.Field(25) != "F" {
+		t.Errorf("OBR = %v", obr.Fields)
+	}
+
+	obx := msg.SegmentsByName("OBX")
+	if obx[0].Field(2) != "NM" || obx[0].Field(3) != "total" || obx[0].Field(5) != "12" || obx[0].Field(11) != "F" {
+		t.Errorf("OBX[0] = %v", obx[0].Fields)
+	}
+	if obx[1].Component(3, 2) != "睡眠^质量" || obx[1].Field(5) != "2.5" {
+		t.Errorf("OBX[1] = %v", obx[1].Fields)
+	}
+	if nte := msg.SegmentsByName("NTE")[0]; nte.Field(3) != "中度抑郁|建议复诊" {
+		t.Errorf("NTE-3 = %q", nte.Field(3))
+	}
+}
+
+func TestEscape(t *testing.T) {
+	raw := "a|b^c~d\\e&f\r\ng"
+	escaped := Escape(raw)
+	if strings.ContainsAny(escaped, "|^~&\r\n") {
+		t.Errorf("Escape(%q) = %q still contains delimiters", raw, escaped)
+	}
+	if escaped != `a\F\b\S\c\R\d\E\e\T\f\X0D\\X0A\g` {
+		t.Errorf("Escape(%q) = %q", raw, escaped)
+	}
+	if got := Unescape(escaped); got != raw {
+		t.Errorf("Unescape(Escape(%q)) = %q", raw, got)
+	}
+}
+
+func TestGenerateORUMessage_RequiresScores(t *testing.T) {
+	report := interpretreport.NewInterpretReport(1, "PHQ9", "PHQ-9")
+	if _, err := GenerateORUMessage(report, PatientInfo{ID: "MRN-1"}); err == nil {
+		t.Error("GenerateORUMessage() error = nil for report without scores")
+	}
+}
@@ -0,0 +1,76 @@
+// Package hl7 生成与解析 HL7 v2 消息
+// 只实现导出解读报告所需的最小子集，不依赖第三方 HL7 库
+package hl7
+
+import (
+	"strings"
+)
+
+// HL7 v2 默认分隔符
+const (
+	SegmentSeparator      = "\r"
+	FieldSeparator        = '|'
+	ComponentSeparator    = '^'
+	RepetitionSeparator   = '~'
+	EscapeCharacter       = '\\'
+	SubcomponentSeparator = '&'
+)
+
+// encodingCharacters MSH-2 中声明的编码字符
+const encodingCharacters = "^~\\&"
+
+// escaper 将字段值中的分隔符替换为 HL7 转义序列，换行符使用十六进制转义
+var escaper = strings.NewReplacer(
+	`\`, `\E\`,
+	`|`, `\F\`,
+	`^`, `\S\`,
+	`~`, `\R\`,
+	`&`, `\T\`,
+	"\r", `\X0D\`,
+	"\n", `\X0A\`,
+)
+
+// unescaper 还原 escaper 生成的转义序列
+var unescaper = strings.NewReplacer(
+	`\E\`, `\`,
+	`\F\`, `|`,
+	`\S\`, `^`,
+	`\R\`, `~`,
+	`\T\`, `&`,
+	`\X0D\`, "\r",
+	`\X0A\`, "\n",
+)
+
+// Escape 转义字段值中的 HL7 分隔符
+func Escape(value string) string {
+	return escaper.Replace(value)
+}
+
+// Unescape 还原字段值中的 HL7 转义序列
+func Unescape(value string) string {
+	return unescaper.Replace(value)
+}
+
+// Field 由多个组件组成的字段，组件值在写出时转义
+type Field []string
+
+// segment 构建一个段，fields[0] 为 HL7 字段序号 1 的值
+func segment(name string, fields ...Field) string {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, field := range fields {
+		components := make([]string, len(field))
+		for i, component := range field {
+			components[i] = Escape(component)
+		}
+		b.WriteByte(FieldSeparator)
+		b.WriteString(strings.TrimRight(strings.Join(components, string(ComponentSeparator)), string(ComponentSeparator)))
+	}
+	return strings.TrimRight(b.String(), string(FieldSeparator))
+}
+
+// mshSegment 构建 MSH 段，fields[0] 为 MSH-3 的值
+// MSH-1、MSH-2 为分隔符定义，不能转义
+func mshSegment(fields ...Field) string {
+	return "MSH" + string(FieldSeparator) + encodingCharacters + segment("", fields...)
+}
@@ -0,0 +1,134 @@
+package hl7
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// 消息头固定值
+const (
+	// SendingApplication 发送应用（MSH-3）
+	SendingApplication = "QUESTIONNAIRE-SCALE"
+	// Version HL7 版本（MSH-12）
+	Version = "2.5.1"
+
+	messageType       = "ORU"
+	triggerEvent      = "R01"
+	messageStructure  = "ORU_R01"
+	processingID      = "P"
+	resultStatusFinal = "F"
+	valueTypeNumeric  = "NM"
+	codingSystemLocal = "L"
+	identifierTypeMR  = "MR"
+	timestampLayout   = "20060102150405"
+	dateLayout        = "20060102"
+)
+
+// now 当前时间，测试时可替换
+var now = time.Now
+
+// PatientInfo 患者信息，写入 PID 段
+// ID 为患者标识（如病历号），为空时使用报告中被试者的用户ID
+type PatientInfo struct {
+	ID                 string
+	AssigningAuthority string
+	FamilyName         string
+	GivenName          string
+	BirthDate          time.Time
+	Gender             string // HL7 性别编码：M、F、O、U
+}
+
+// GenerateORUMessage 将解读报告生成为 HL7 v2 ORU^R01 消息，段之间以 \r 分隔
+// 每个解读项生成一个 NM 类型的 OBX 段，解读内容写入紧随其后的 NTE 段
+func GenerateORUMessage(report *interpretreport.InterpretReport, patient PatientInfo) (string, error) {
+	if report == nil {
+		return "", errors.WithCode(code.ErrInterpretReportInvalid, "interpret report is required")
+	}
+	if report.IsEmpty() {
+		return "", errors.WithCode(code.ErrInterpretReportInvalid, "interpret report %d has no scores", report.GetAnswerSheetId())
+	}
+
+	patientID := patient.ID
+	if patientID == "" {
+		testee := report.GetTestee()
+		if testee.GetUserID().Value() == 0 {
+			return "", errors.WithCode(code.ErrInterpretReportInvalid, "patient id is required")
+		}
+		patientID = strconv.FormatUint(testee.GetUserID().Value(), 10)
+	}
+
+	timestamp := now()
+	reportID := strconv.FormatUint(report.GetAnswerSheetId(), 10)
+
+	segments := []string{
+		mshSegment(mshFields(reportID, timestamp)...),
+		segment("PID", pidFields(patient, patientID)...),
+		segment("OBR", obrFields(report, reportID, timestamp)...),
+	}
+	for i, item := range report.GetInterpretItems() {
+		segments = append(segments, segment("OBX", obxFields(i+1, item)...))
+		if item.GetContent() != "" {
+			segments = append(segments, segment("NTE", Field{"1"}, Field{}, Field{item.GetContent()}))
+		}
+	}
+
+	return strings.Join(segments, SegmentSeparator) + SegmentSeparator, nil
+}
+
+// mshFields 构建 MSH 段从 MSH-3 开始的字段，消息控制ID由报告编号和时间组成
+func mshFields(reportID string, timestamp time.Time) []Field {
+	fields := make([]Field, 10)
+	fields[0] = Field{SendingApplication}                           // MSH-3
+	fields[4] = Field{timestamp.Format(timestampLayout)}            // MSH-7
+	fields[6] = Field{messageType, triggerEvent, messageStructure}  // MSH-9
+	fields[7] = Field{reportID + timestamp.Format(timestampLayout)} // MSH-10
+	fields[8] = Field{processingID}                                 // MSH-11
+	fields[9] = Field{Version}                                      // MSH-12
+	return fields
+}
+
+// pidFields 构建 PID 段字段
+func pidFields(patient PatientInfo, patientID string) []Field {
+	fields := make([]Field, 8)
+	fields[0] = Field{"1"}                                                             // PID-1
+	fields[2] = Field{patientID, "", "", patient.AssigningAuthority, identifierTypeMR} // PID-3
+	fields[4] = Field{patient.FamilyName, patient.GivenName}                           // PID-5
+	fields[6] = Field{formatDate(patient.BirthDate)}                                   // PID-7
+	fields[7] = Field{strings.ToUpper(patient.Gender)}                                 // PID-8
+	return fields
+}
+
+// obrFields 构建 OBR 段字段，量表编码作为检查项目，结果状态为最终结果
+func obrFields(report *interpretreport.InterpretReport, reportID string, timestamp time.Time) []Field {
+	fields := make([]Field, 25)
+	fields[0] = Field{"1"}                                                                // OBR-1
+	fields[2] = Field{reportID}                                                           // OBR-3 报告编号
+	fields[3] = Field{report.GetMedicalScaleCode(), report.GetTitle(), codingSystemLocal} // OBR-4
+	fields[6] = Field{timestamp.Format(timestampLayout)}                                  // OBR-7
+	fields[24] = Field{resultStatusFinal}                                                 // OBR-25
+	return fields
+}
+
+// obxFields 构建 OBX 段字段，因子得分作为数值型观察结果
+func obxFields(setID int, item interpretreport.InterpretItem) []Field {
+	fields := make([]Field, 11)
+	fields[0] = Field{strconv.Itoa(setID)}                                      // OBX-1
+	fields[1] = Field{valueTypeNumeric}                                         // OBX-2
+	fields[2] = Field{item.GetFactorCode(), item.GetTitle(), codingSystemLocal} // OBX-3
+	fields[4] = Field{strconv.FormatFloat(item.GetScore(), 'f', -1, 64)}        // OBX-5
+	fields[10] = Field{resultStatusFinal}                                       // OBX-11
+	return fields
+}
+
+// formatDate 格式化日期，零值返回空字符串
+func formatDate(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Format(dateLayout)
+}
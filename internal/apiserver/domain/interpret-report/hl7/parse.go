@@ -0,0 +1,98 @@
+package hl7
+
+import (
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Segment 解析后的段
+// Fields[n] 为 HL7 字段序号 n 的各个组件（已还原转义），Fields[0] 为段名称
+// MSH 段的字段序号 1 为字段分隔符本身，与 HL7 规范的编号一致
+type Segment struct {
+	Name   string
+	Fields [][]string
+}
+
+// Field 返回字段序号 n 的第一个组件，字段不存在时返回空字符串
+func (s Segment) Field(n int) string {
+	return s.Component(n, 1)
+}
+
+// Component 返回字段序号 n 的第 c 个组件（从 1 开始），不存在时返回空字符串
+func (s Segment) Component(n, c int) string {
+	if n < 0 || n >= len(s.Fields) || c < 1 || c > len(s.Fields[n]) {
+		return ""
+	}
+	return s.Fields[n][c-1]
+}
+
+// Message 解析后的消息
+type Message struct {
+	Segments []Segment
+}
+
+// SegmentsByName 返回指定名称的所有段
+func (m *Message) SegmentsByName(name string) []Segment {
+	var segments []Segment
+	for _, s := range m.Segments {
+		if s.Name == name {
+			segments = append(segments, s)
+		}
+	}
+	return segments
+}
+
+// Parse 解析使用默认分隔符的 HL7 v2 消息，段之间允许使用 \r、\n 或 \r\n 分隔
+func Parse(message string) (*Message, error) {
+	message = strings.ReplaceAll(message, "\r\n", SegmentSeparator)
+	message = strings.ReplaceAll(message, "\n", SegmentSeparator)
+
+	var msg Message
+	for _, line := range strings.Split(message, SegmentSeparator) {
+		if line == "" {
+			continue
+		}
+		seg, err := parseSegment(line)
+		if err != nil {
+			return nil, err
+		}
+		msg.Segments = append(msg.Segments, seg)
+	}
+
+	if len(msg.Segments) == 0 || msg.Segments[0].Name != "MSH" {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "HL7 message must start with MSH segment")
+	}
+	return &msg, nil
+}
+
+// parseSegment 解析一个段
+func parseSegment(line string) (Segment, error) {
+	raw := strings.Split(line, string(FieldSeparator))
+	name := raw[0]
+	if len(name) != 3 {
+		return Segment{}, errors.WithCode(code.ErrInvalidArgument, "invalid HL7 segment %q", line)
+	}
+
+	seg := Segment{Name: name, Fields: [][]string{{name}}}
+	if name == "MSH" {
+		// MSH-1 为字段分隔符，MSH-2 为编码字符，均不按组件拆分
+		if len(raw) < 2 || raw[1] != encodingCharacters {
+			return Segment{}, errors.WithCode(code.ErrInvalidArgument, "unsupported HL7 encoding characters in %q", line)
+		}
+		seg.Fields = append(seg.Fields, []string{string(FieldSeparator)}, []string{raw[1]})
+		raw = raw[2:]
+	} else {
+		raw = raw[1:]
+	}
+
+	for _, field := range raw {
+		components := strings.Split(field, string(ComponentSeparator))
+		for i, component := range components {
+			components[i] = Unescape(component)
+		}
+		seg.Fields = append(seg.Fields, components)
+	}
+	return seg, nil
+}
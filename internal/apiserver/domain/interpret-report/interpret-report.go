@@ -14,8 +14,17 @@ type InterpretReport struct {
 	description      string
 	testee           user.Testee
 	interpretItems   []InterpretItem
+
+	normalizeToPercentage bool
+	percentageScore       float64
 }
 
+// 报告展示的分数类型
+const (
+	ScoreTypeRaw        = "raw"        // 原始总分
+	ScoreTypePercentage = "percentage" // 百分制得分
+)
+
 // InterpretReportOption 解读报告选项
 type InterpretReportOption func(*InterpretReport)
 
@@ -62,6 +71,14 @@ func WithInterpretItems(items []InterpretItem) InterpretReportOption {
 	}
 }
 
+// WithPercentageScore 设置百分制得分，报告改为展示百分制得分
+func WithPercentageScore(score float64) InterpretReportOption {
+	return func(r *InterpretReport) {
+		r.normalizeToPercentage = true
+		r.percentageScore = score
+	}
+}
+
 // Getter 方法
 
 // GetID 获取ID
@@ -170,6 +187,32 @@ func (r *InterpretReport) GetTotalScore() float64 {
 	return total
 }
 
+// IsNormalizedToPercentage 判断报告是否展示百分制得分
+func (r *InterpretReport) IsNormalizedToPercentage() bool {
+	return r.normalizeToPercentage
+}
+
+// GetPercentageScore 获取百分制得分
+func (r *InterpretReport) GetPercentageScore() float64 {
+	return r.percentageScore
+}
+
+// GetScoreType 获取报告展示的分数类型
+func (r *InterpretReport) GetScoreType() string {
+	if r.normalizeToPercentage {
+		return ScoreTypePercentage
+	}
+	return ScoreTypeRaw
+}
+
+// GetDisplayScore 获取报告展示的分数，按医学量表计分配置为百分制得分或原始总分
+func (r *InterpretReport) GetDisplayScore() float64 {
+	if r.normalizeToPercentage {
+		return r.percentageScore
+	}
+	return r.GetTotalScore()
+}
+
 // HasFactorCode 判断是否包含指定因子代码的解读项
 func (r *InterpretReport) HasFactorCode(factorCode string) bool {
 	return r.GetInterpretItemByFactorCode(factorCode) != nil
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/hl7"
 )
 
 // InterpretReportCreator
@@ -23,3 +24,9 @@ type InterpretReportQueryer interface {
 	// GetInterpretReportByAnswerSheetId 根据答卷ID获取解读报告
 	GetInterpretReportByAnswerSheetId(ctx context.Context, answerSheetId uint64) (*dto.InterpretReportDTO, error)
 }
+
+// InterpretReportHL7Exporter 解读报告 HL7 导出器接口
+type InterpretReportHL7Exporter interface {
+	// ExportHL7 将答卷对应的解读报告导出为 HL7 v2 ORU^R01 消息
+	ExportHL7(ctx context.Context, answerSheetId uint64, patient hl7.PatientInfo) (string, error)
+}
@@ -23,6 +23,16 @@ func (BaseInfoService) UpdateTitle(m *MedicalScale, newTitle string) error {
 	return nil
 }
 
+// UpdateScoringConfig 设置医学量表计分配置，分数段按最低分升序保存
+func (BaseInfoService) UpdateScoringConfig(m *MedicalScale, config ScoringConfig) error {
+	if err := config.Validate(); err != nil {
+		return err
+	}
+	config.ScoreBands = sortScoreBands(config.ScoreBands)
+	m.scoringConfig = config
+	return nil
+}
+
 // UpdateDescription 设置医学量表描述
 func (BaseInfoService) UpdateDescription(m *MedicalScale, newDescription string) error {
 	if len(newDescription) > 500 {
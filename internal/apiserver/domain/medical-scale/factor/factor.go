@@ -2,6 +2,9 @@ package factor
 
 import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor/ability"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // Factor 因子实体
@@ -87,3 +90,21 @@ func (f Factor) GetCalculationAbility() *ability.CalculationAbility {
 func (f Factor) GetInterpretationAbility() *ability.InterpretationAbility {
 	return f.interpretationAbility
 }
+
+// ValidateInterpretationCoverage 校验解读规则是否完整覆盖因子的可能得分范围 [minScore, maxScore] 且互不重叠
+// 未设置解读规则的因子不做校验
+func (f Factor) ValidateInterpretationCoverage(minScore, maxScore float64) error {
+	if f.interpretationAbility == nil || len(f.interpretationAbility.GetInterpretationRules()) == 0 {
+		return nil
+	}
+
+	rules := f.interpretationAbility.GetInterpretationRules()
+	ranges := make([]interpretation.ScoreRange, len(rules))
+	for i, rule := range rules {
+		ranges[i] = rule.GetScoreRange()
+	}
+	if err := interpretation.ValidateCoverage(ranges, minScore, maxScore); err != nil {
+		return errors.WrapC(err, code.ErrMedicalScaleInvalidInput, "因子 %s 的解读规则未完整覆盖得分范围", f.code)
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+
+	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// ExportToFHIR 将医学量表及其关联的问卷导出为 FHIR R4 Questionnaire 资源，与 ImportFromFHIR 互逆
+// 数字题统一导出为 decimal 类型，文件上传题无对应的 FHIR 类型，导出时返回错误
+func ExportToFHIR(scale *medicalscale.MedicalScale, q *questionnaire.Questionnaire) ([]byte, error) {
+	if scale == nil || q == nil {
+		return nil, errors.WithCode(code.ErrMedicalScaleInvalidInput, "medical scale and questionnaire are required")
+	}
+
+	resource := Questionnaire{
+		ResourceType: ResourceTypeQuestionnaire,
+		ID:           scale.GetQuestionnaireCode(),
+		Name:         scale.GetCode(),
+		Title:        scale.GetTitle(),
+		Status:       exportStatus(q.GetStatus()),
+		Description:  scale.GetDescription(),
+	}
+	for _, qu := range q.GetQuestions() {
+		item, err := exportQuestion(qu)
+		if err != nil {
+			return nil, err
+		}
+		resource.Item = append(resource.Item, item)
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrEncodingJSON, "encode FHIR Questionnaire failed")
+	}
+	return data, nil
+}
+
+// exportQuestion 将问卷问题转换为 FHIR 问题
+func exportQuestion(q question.Question) (Item, error) {
+	item := Item{
+		LinkID: q.GetCode().Value(),
+		Text:   q.GetTitle(),
+	}
+
+	switch q.GetType() {
+	case question.QuestionTypeRadio:
+		item.Type = ItemTypeChoice
+	case question.QuestionTypeCheckbox:
+		item.Type, item.Repeats = ItemTypeChoice, true
+	case question.QuestionTypeText:
+		item.Type = ItemTypeString
+	case question.QuestionTypeTextarea:
+		item.Type = ItemTypeText
+	case question.QuestionTypeNumber:
+		item.Type = ItemTypeDecimal
+	case question.QuestionTypeSection:
+		item.Type = ItemTypeDisplay
+	default:
+		return Item{}, errors.WithCode(code.ErrMedicalScaleInvalidInput, "question type %s of %s cannot be exported to FHIR", q.GetType(), item.LinkID)
+	}
+
+	for _, rule := range q.GetValidationRules() {
+		if rule.GetRuleType() == validation.RuleTypeRequired {
+			item.Required = true
+		}
+	}
+	for _, option := range q.GetOptions() {
+		item.AnswerOption = append(item.AnswerOption, exportOption(option))
+	}
+	for _, dep := range q.GetConditionalRequired() {
+		item.EnableWhen = append(item.EnableWhen, exportConditionalRequired(dep))
+	}
+	return item, nil
+}
+
+// exportOption 将选项转换为 FHIR 可选答案，分值写入 ordinalValue 扩展
+func exportOption(option question.Option) AnswerOption {
+	score := float64(option.GetScore())
+	return AnswerOption{
+		Extension:   []Extension{{URL: OrdinalValueExtension, ValueDecimal: &score}},
+		ValueCoding: &Coding{Code: option.GetCode(), Display: option.GetContent()},
+	}
+}
+
+// exportConditionalRequired 将条件必填规则转换为 FHIR 启用条件
+func exportConditionalRequired(dep question.ConditionalRequired) EnableWhen {
+	cond := EnableWhen{
+		Question: dep.DependsOnCode.Value(),
+		Operator: enableWhenOperatorEqual,
+	}
+
+	switch v := dep.RequiredWhenValue.(type) {
+	case bool:
+		cond.AnswerBoolean = &v
+	case int:
+		cond.AnswerInteger = &v
+	case float64:
+		cond.AnswerDecimal = &v
+	case string:
+		cond.AnswerCoding = &Coding{Code: v}
+	default:
+		s := fmt.Sprint(v)
+		cond.AnswerString = &s
+	}
+	return cond
+}
+
+// exportStatus 将问卷状态映射为 FHIR 问卷状态
+func exportStatus(status questionnaire.QuestionnaireStatus) string {
+	switch status {
+	case questionnaire.STATUS_PUBLISHED:
+		return StatusActive
+	case questionnaire.STATUS_ARCHIVED:
+		return StatusRetired
+	default:
+		return StatusDraft
+	}
+}
@@ -0,0 +1,150 @@
+package fhir
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+const phq2JSON = `{
+  "resourceType": "Questionnaire",
+  "id": "phq2-questionnaire",
+  "name": "phq2",
+  "title": "PHQ-2",
+  "status": "active",
+  "description": "Patient Health Questionnaire-2",
+  "item": [
+    {
+      "linkId": "q1",
+      "text": "Little interest or pleasure in doing things",
+      "type": "choice",
+      "required": true,
+      "answerOption": [
+        {"valueCoding": {"code": "a0", "display": "Not at all"}, "extension": [{"url": "http://hl7.org/fhir/StructureDefinition/ordinalValue", "valueDecimal": 0}]},
+        {"valueCoding": {"code": "a1", "display": "Several days"}, "extension": [{"url": "http://hl7.org/fhir/StructureDefinition/ordinalValue", "valueDecimal": 1}]}
+      ]
+    },
+    {
+      "linkId": "q2",
+      "text": "Which symptoms",
+      "type": "choice",
+      "repeats": true,
+      "answerOption": [{"valueString": "sleep"}, {"valueString": "appetite"}]
+    },
+    {
+      "linkId": "q3",
+      "text": "Please describe",
+      "type": "string",
+      "enableWhen": [{"question": "q1", "operator": "=", "answerCoding": {"code": "a1"}}]
+    },
+    {"linkId": "q4", "text": "Age", "type": "integer"},
+    {"linkId": "q5", "text": "Weight", "type": "decimal"}
+  ]
+}`
+
+func TestImportFromFHIR(t *testing.T) {
+	scale, q, err := ImportFromFHIR([]byte(phq2JSON))
+	if err != nil {
+		t.Fatalf("ImportFromFHIR() error = %v", err)
+	}
+
+	if scale.GetCode() != "phq2" || scale.GetQuestionnaireCode() != "phq2-questionnaire" || scale.GetTitle() != "PHQ-2" {
+		t.Errorf("scale = %s/%s/%s", scale.GetCode(), scale.GetQuestionnaireCode(), scale.GetTitle())
+	}
+	if q.GetStatus() != questionnaire.STATUS_PUBLISHED {
+		t.Errorf("status = %v, want published", q.GetStatus())
+	}
+
+	questions := q.GetQuestions()
+	wantTypes := []question.QuestionType{
+		question.QuestionTypeRadio,
+		question.QuestionTypeCheckbox,
+		question.QuestionTypeText,
+		question.QuestionTypeNumber,
+		question.QuestionTypeNumber,
+	}
+	if len(questions) != len(wantTypes) {
+		t.Fatalf("got %d questions, want %d", len(questions), len(wantTypes))
+	}
+	for i, want := range wantTypes {
+		if questions[i].GetType() != want {
+			t.Errorf("question %d type = %s, want %s", i, questions[i].GetType(), want)
+		}
+	}
+
+	options := questions[0].GetOptions()
+	if len(options) != 2 || options[1].GetCode() != "a1" || options[1].GetContent() != "Several days" || options[1].GetScore() != 1 {
+		t.Errorf("q1 options = %+v", options)
+	}
+	if options := questions[1].GetOptions(); len(options) != 2 || options[0].GetCode() != "sleep" {
+		t.Errorf("q2 options = %+v", options)
+	}
+
+	deps := questions[2].GetConditionalRequired()
+	if len(deps) != 1 || deps[0].DependsOnCode != "q1" || deps[0].RequiredWhenValue != "a1" {
+		t.Errorf("q3 conditional required = %+v", deps)
+	}
+}
+
+func TestImportFromFHIR_Unsupported(t *testing.T) {
+	tests := map[string]string{
+		"item type": `{"resourceType": "Questionnaire", "id": "x", "status": "draft",
+			"item": [{"linkId": "q1", "text": "Birthday", "type": "date"}]}`,
+		"enableWhen operator": `{"resourceType": "Questionnaire", "id": "x", "status": "draft",
+			"item": [{"linkId": "q1", "text": "Age", "type": "integer"},
+			{"linkId": "q2", "text": "Note", "type": "string", "enableWhen": [{"question": "q1", "operator": ">", "answerInteger": 18}]}]}`,
+		"resource type": `{"resourceType": "Patient", "id": "x"}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := ImportFromFHIR([]byte(data)); !errors.IsCode(err, code.ErrMedicalScaleInvalidInput) {
+				t.Errorf("ImportFromFHIR() error = %v, want ErrMedicalScaleInvalidInput", err)
+			}
+		})
+	}
+}
+
+func TestExportToFHIR_RoundTrip(t *testing.T) {
+	scale, q, err := ImportFromFHIR([]byte(phq2JSON))
+	if err != nil {
+		t.Fatalf("ImportFromFHIR() error = %v", err)
+	}
+
+	data, err := ExportToFHIR(scale, q)
+	if err != nil {
+		t.Fatalf("ExportToFHIR() error = %v", err)
+	}
+
+	scale2, q2, err := ImportFromFHIR(data)
+	if err != nil {
+		t.Fatalf("ImportFromFHIR(exported) error = %v", err)
+	}
+	if scale2.GetCode() != scale.GetCode() || scale2.GetQuestionnaireCode() != scale.GetQuestionnaireCode() {
+		t.Errorf("round trip scale = %s/%s", scale2.GetCode(), scale2.GetQuestionnaireCode())
+	}
+
+	before, after := q.GetQuestions(), q2.GetQuestions()
+	if len(after) != len(before) {
+		t.Fatalf("round trip got %d questions, want %d", len(after), len(before))
+	}
+	for i := range before {
+		if after[i].GetCode() != before[i].GetCode() || after[i].GetType() != before[i].GetType() {
+			t.Errorf("question %d = %s/%s, want %s/%s", i, after[i].GetCode(), after[i].GetType(), before[i].GetCode(), before[i].GetType())
+		}
+		if len(after[i].GetOptions()) != len(before[i].GetOptions()) {
+			t.Errorf("question %d has %d options, want %d", i, len(after[i].GetOptions()), len(before[i].GetOptions()))
+		}
+		if len(after[i].GetValidationRules()) != len(before[i].GetValidationRules()) {
+			t.Errorf("question %d has %d validation rules, want %d", i, len(after[i].GetValidationRules()), len(before[i].GetValidationRules()))
+		}
+	}
+	if score := after[0].GetOptions()[1].GetScore(); score != 1 {
+		t.Errorf("round trip score = %d, want 1", score)
+	}
+	if deps := after[2].GetConditionalRequired(); len(deps) != 1 || deps[0].RequiredWhenValue != "a1" {
+		t.Errorf("round trip conditional required = %+v", deps)
+	}
+}
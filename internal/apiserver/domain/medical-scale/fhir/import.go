@@ -0,0 +1,196 @@
+package fhir
+
+import (
+	"encoding/json"
+	"strconv"
+
+	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// ImportFromFHIR 解析 FHIR R4 Questionnaire 资源，返回医学量表及其关联的问卷
+// 医学量表本身不包含问题，问题导入到关联的问卷中：
+// name 作为量表编码，id 作为问卷编码，缺少其一时使用另一个
+// enableWhen 只支持 "=" 运算符，映射为条件必填规则
+func ImportFromFHIR(data []byte) (*medicalscale.MedicalScale, *questionnaire.Questionnaire, error) {
+	var resource Questionnaire
+	if err := json.Unmarshal(data, &resource); err != nil {
+		return nil, nil, errors.WrapC(err, code.ErrMedicalScaleInvalidInput, "invalid FHIR Questionnaire json")
+	}
+	if resource.ResourceType != ResourceTypeQuestionnaire {
+		return nil, nil, errors.WithCode(code.ErrMedicalScaleInvalidInput, "unexpected FHIR resourceType %q", resource.ResourceType)
+	}
+
+	scaleCode, questionnaireCode := resource.Name, resource.ID
+	if scaleCode == "" {
+		scaleCode = questionnaireCode
+	}
+	if questionnaireCode == "" {
+		questionnaireCode = scaleCode
+	}
+	if scaleCode == "" {
+		return nil, nil, errors.WithCode(code.ErrMedicalScaleInvalidInput, "FHIR Questionnaire has neither id nor name")
+	}
+
+	questions := make([]question.Question, 0, len(resource.Item))
+	for _, item := range resource.Item {
+		q, err := importItem(item)
+		if err != nil {
+			return nil, nil, err
+		}
+		questions = append(questions, q)
+	}
+
+	scale := medicalscale.NewMedicalScale(scaleCode, resource.Title,
+		medicalscale.WithQuestionnaireCode(questionnaireCode),
+		medicalscale.WithDescription(resource.Description),
+	)
+	q := questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode(questionnaireCode), resource.Title,
+		questionnaire.WithDescription(resource.Description),
+		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		questionnaire.WithStatus(importStatus(resource.Status)),
+		questionnaire.WithQuestions(questions),
+	)
+	return scale, q, nil
+}
+
+// importItem 将 FHIR 问题转换为问卷问题
+func importItem(item Item) (question.Question, error) {
+	if item.LinkID == "" {
+		return nil, errors.WithCode(code.ErrMedicalScaleInvalidInput, "FHIR item without linkId")
+	}
+
+	questionType, err := importItemType(item)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := []question.BuilderOption{
+		question.WithCode(question.NewQuestionCode(item.LinkID)),
+		question.WithTitle(item.Text),
+		question.WithQuestionType(questionType),
+	}
+	if item.Required {
+		opts = append(opts, question.WithRequired())
+	}
+	if len(item.AnswerOption) > 0 {
+		options := make([]question.Option, 0, len(item.AnswerOption))
+		for _, answer := range item.AnswerOption {
+			option, err := importAnswerOption(item.LinkID, answer)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, option)
+		}
+		opts = append(opts, question.WithOptions(options))
+	}
+	for _, cond := range item.EnableWhen {
+		dep, err := importEnableWhen(item.LinkID, cond)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, question.WithConditionalRequired(dep))
+	}
+
+	builder := question.BuildQuestionConfig(opts...)
+	if err := builder.Validate(); err != nil {
+		return nil, errors.WrapC(err, code.ErrMedicalScaleInvalidInput, "invalid FHIR item %s", item.LinkID)
+	}
+	return question.CreateQuestionFromBuilder(builder), nil
+}
+
+// importItemType 将 FHIR 问题类型映射为问卷题型，不支持的类型返回错误
+func importItemType(item Item) (question.QuestionType, error) {
+	switch item.Type {
+	case ItemTypeChoice:
+		if item.Repeats {
+			return question.QuestionTypeCheckbox, nil
+		}
+		return question.QuestionTypeRadio, nil
+	case ItemTypeString:
+		return question.QuestionTypeText, nil
+	case ItemTypeText:
+		return question.QuestionTypeTextarea, nil
+	case ItemTypeInteger, ItemTypeDecimal:
+		return question.QuestionTypeNumber, nil
+	case ItemTypeDisplay:
+		return question.QuestionTypeSection, nil
+	default:
+		return "", errors.WithCode(code.ErrMedicalScaleInvalidInput, "unsupported FHIR item type %q of item %s", item.Type, item.LinkID)
+	}
+}
+
+// importAnswerOption 将 FHIR 可选答案转换为选项，分值取自 ordinalValue 扩展
+func importAnswerOption(linkID string, answer AnswerOption) (question.Option, error) {
+	var optionCode, content string
+	switch {
+	case answer.ValueCoding != nil:
+		optionCode, content = answer.ValueCoding.Code, answer.ValueCoding.Display
+	case answer.ValueString != nil:
+		optionCode, content = *answer.ValueString, *answer.ValueString
+	case answer.ValueInteger != nil:
+		optionCode = strconv.Itoa(*answer.ValueInteger)
+		content = optionCode
+	default:
+		return question.Option{}, errors.WithCode(code.ErrMedicalScaleInvalidInput, "unsupported answerOption value of item %s", linkID)
+	}
+	if content == "" {
+		content = optionCode
+	}
+
+	score := 0
+	for _, ext := range answer.Extension {
+		if ext.URL != OrdinalValueExtension {
+			continue
+		}
+		switch {
+		case ext.ValueDecimal != nil:
+			score = int(*ext.ValueDecimal)
+		case ext.ValueInteger != nil:
+			score = *ext.ValueInteger
+		}
+	}
+	return question.NewOption(optionCode, content, score), nil
+}
+
+// importEnableWhen 将 FHIR 启用条件转换为条件必填规则
+func importEnableWhen(linkID string, cond EnableWhen) (question.ConditionalRequired, error) {
+	if cond.Operator != enableWhenOperatorEqual {
+		return question.ConditionalRequired{}, errors.WithCode(code.ErrMedicalScaleInvalidInput,
+			"unsupported enableWhen operator %q of item %s", cond.Operator, linkID)
+	}
+
+	var value interface{}
+	switch {
+	case cond.AnswerCoding != nil:
+		value = cond.AnswerCoding.Code
+	case cond.AnswerString != nil:
+		value = *cond.AnswerString
+	case cond.AnswerInteger != nil:
+		value = *cond.AnswerInteger
+	case cond.AnswerDecimal != nil:
+		value = *cond.AnswerDecimal
+	case cond.AnswerBoolean != nil:
+		value = *cond.AnswerBoolean
+	default:
+		return question.ConditionalRequired{}, errors.WithCode(code.ErrMedicalScaleInvalidInput,
+			"enableWhen of item %s has no answer", linkID)
+	}
+	return question.NewConditionalRequired(question.NewQuestionCode(cond.Question), value), nil
+}
+
+// importStatus 将 FHIR 问卷状态映射为问卷状态
+func importStatus(status string) questionnaire.QuestionnaireStatus {
+	switch status {
+	case StatusActive:
+		return questionnaire.STATUS_PUBLISHED
+	case StatusRetired:
+		return questionnaire.STATUS_ARCHIVED
+	default:
+		return questionnaire.STATUS_DRAFT
+	}
+}
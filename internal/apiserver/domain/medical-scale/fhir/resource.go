@@ -0,0 +1,85 @@
+// Package fhir 在医学量表与 FHIR R4 Questionnaire 资源之间相互转换
+// 只解析转换所需的字段，不依赖完整的 FHIR 库
+package fhir
+
+// ResourceTypeQuestionnaire FHIR Questionnaire 资源类型
+const ResourceTypeQuestionnaire = "Questionnaire"
+
+// OrdinalValueExtension 选项分值扩展，FHIR R4 使用 ordinalValue 表示选项的计分
+const OrdinalValueExtension = "http://hl7.org/fhir/StructureDefinition/ordinalValue"
+
+// FHIR 问题类型
+const (
+	ItemTypeDisplay = "display"
+	ItemTypeChoice  = "choice"
+	ItemTypeString  = "string"
+	ItemTypeText    = "text"
+	ItemTypeInteger = "integer"
+	ItemTypeDecimal = "decimal"
+)
+
+// FHIR 问卷状态
+const (
+	StatusDraft   = "draft"
+	StatusActive  = "active"
+	StatusRetired = "retired"
+)
+
+// enableWhenOperatorEqual 答案等于指定值时启用
+const enableWhenOperatorEqual = "="
+
+// Questionnaire FHIR R4 Questionnaire 资源
+type Questionnaire struct {
+	ResourceType string `json:"resourceType"`
+	ID           string `json:"id,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Title        string `json:"title,omitempty"`
+	Status       string `json:"status"`
+	Description  string `json:"description,omitempty"`
+	Item         []Item `json:"item,omitempty"`
+}
+
+// Item 问卷中的问题
+type Item struct {
+	LinkID       string         `json:"linkId"`
+	Text         string         `json:"text,omitempty"`
+	Type         string         `json:"type"`
+	Required     bool           `json:"required,omitempty"`
+	Repeats      bool           `json:"repeats,omitempty"`
+	EnableWhen   []EnableWhen   `json:"enableWhen,omitempty"`
+	AnswerOption []AnswerOption `json:"answerOption,omitempty"`
+	Item         []Item         `json:"item,omitempty"`
+}
+
+// EnableWhen 问题的启用条件
+type EnableWhen struct {
+	Question      string   `json:"question"`
+	Operator      string   `json:"operator"`
+	AnswerBoolean *bool    `json:"answerBoolean,omitempty"`
+	AnswerDecimal *float64 `json:"answerDecimal,omitempty"`
+	AnswerInteger *int     `json:"answerInteger,omitempty"`
+	AnswerString  *string  `json:"answerString,omitempty"`
+	AnswerCoding  *Coding  `json:"answerCoding,omitempty"`
+}
+
+// AnswerOption 可选答案
+type AnswerOption struct {
+	Extension    []Extension `json:"extension,omitempty"`
+	ValueInteger *int        `json:"valueInteger,omitempty"`
+	ValueString  *string     `json:"valueString,omitempty"`
+	ValueCoding  *Coding     `json:"valueCoding,omitempty"`
+}
+
+// Coding 编码值
+type Coding struct {
+	System  string `json:"system,omitempty"`
+	Code    string `json:"code"`
+	Display string `json:"display,omitempty"`
+}
+
+// Extension 扩展
+type Extension struct {
+	URL          string   `json:"url"`
+	ValueDecimal *float64 `json:"valueDecimal,omitempty"`
+	ValueInteger *int     `json:"valueInteger,omitempty"`
+}
@@ -1,7 +1,13 @@
 package medicalscale
 
 import (
+	"encoding/json"
+
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
 )
 
@@ -13,6 +19,20 @@ type MedicalScale struct {
 	title             string
 	description       string
 	factors           []factor.Factor
+	scoringConfig     ScoringConfig
+
+	ruleStrategyConfig json.RawMessage
+	ruleStrategy       scoring.RuleStrategy
+}
+
+// ScoringConfig 医学量表计分配置
+type ScoringConfig struct {
+	// NormalizeToPercentage 是否将总分换算为占问卷最高可得分的百分比 [0, 100]
+	NormalizeToPercentage bool
+	// ScoreBands 总分的临床切分分数段，按最低分升序排列
+	ScoreBands []ScoreBand
+	// Dimensions 计分维度，如 SF-36 的各健康维度，每个维度在总分之外独立计分
+	Dimensions []scoring.ScoringDimension
 }
 
 // NewMedicalScale 创建医学量表
@@ -72,6 +92,14 @@ func WithFactors(factors []factor.Factor) MedicalScaleOption {
 	}
 }
 
+// WithScoringConfig 设置计分配置
+func WithScoringConfig(config ScoringConfig) MedicalScaleOption {
+	return func(s *MedicalScale) {
+		config.ScoreBands = sortScoreBands(config.ScoreBands)
+		s.scoringConfig = config
+	}
+}
+
 // SetID 设置ID
 func (s *MedicalScale) SetID(id v1.ID) {
 	s.id = id
@@ -111,3 +139,102 @@ func (s *MedicalScale) GetFactors() []factor.Factor {
 func (s *MedicalScale) SetFactors(factors []factor.Factor) {
 	s.factors = factors
 }
+
+// GetScoringConfig 获取计分配置
+func (s *MedicalScale) GetScoringConfig() ScoringConfig {
+	return s.scoringConfig
+}
+
+// SetRuleStrategyConfig 设置计分策略配置，并将其解析为对应的计分策略
+// 配置为空时使用默认的简单求和策略
+func (s *MedicalScale) SetRuleStrategyConfig(config json.RawMessage) error {
+	strategy, err := scoring.NewRuleStrategy(config)
+	if err != nil {
+		return err
+	}
+	s.ruleStrategyConfig = config
+	s.ruleStrategy = strategy
+	return nil
+}
+
+// GetRuleStrategyConfig 获取计分策略配置
+func (s *MedicalScale) GetRuleStrategyConfig() json.RawMessage {
+	return s.ruleStrategyConfig
+}
+
+// UsesWeightedScoring 判断计分策略是否使用加权求和
+func (s *MedicalScale) UsesWeightedScoring() bool {
+	return scoring.UsesWeightedSum(s.ruleStrategy)
+}
+
+// GetScoringDimensions 获取计分维度
+func (s *MedicalScale) GetScoringDimensions() []scoring.ScoringDimension {
+	return s.scoringConfig.Dimensions
+}
+
+// NewScoringRuleEngine 按计分策略配置和计分维度创建计分规则引擎
+func (s *MedicalScale) NewScoringRuleEngine() *scoring.ScoringRuleEngine {
+	return scoring.NewScoringRuleEngineBuilder().
+		WithStrategy(s.ruleStrategy).
+		WithDimensions(s.scoringConfig.Dimensions).
+		Build()
+}
+
+// ScoredQuestionCodes 获取量表计分引用的题目编码（去重，保持首次出现顺序）
+// 包括一级因子计算规则的源题目和条件依赖题目、计分维度的题目；多级因子引用的是其他因子，不在其中
+func (s *MedicalScale) ScoredQuestionCodes() []string {
+	seen := make(map[string]bool)
+	var codes []string
+	add := func(code string) {
+		if code == "" || seen[code] {
+			return
+		}
+		seen[code] = true
+		codes = append(codes, code)
+	}
+
+	for _, f := range s.factors {
+		if f.GetFactorType() != factor.PrimaryFactor || f.GetCalculationAbility() == nil {
+			continue
+		}
+		rule := f.GetCalculationAbility().GetCalculationRule()
+		if rule == nil {
+			continue
+		}
+		for _, sourceCode := range rule.GetSourceCodes() {
+			add(sourceCode)
+		}
+		add(rule.GetDependsOnCode())
+	}
+	for _, dimension := range s.scoringConfig.Dimensions {
+		for _, questionCode := range dimension.QuestionCodes {
+			add(questionCode.Value())
+		}
+	}
+	return codes
+}
+
+// ValidateInterpretationCoverage 校验量表在可能得分范围 [minScore, maxScore] 内的解读是否完整
+// 总分因子的解读规则和总分分数段（如有配置）都必须连续覆盖整个得分范围且互不重叠
+func (s *MedicalScale) ValidateInterpretationCoverage(minScore, maxScore float64) error {
+	for _, f := range s.factors {
+		if !f.IsTotalScore() {
+			continue
+		}
+		if err := f.ValidateInterpretationCoverage(minScore, maxScore); err != nil {
+			return err
+		}
+	}
+
+	if len(s.scoringConfig.ScoreBands) == 0 {
+		return nil
+	}
+	ranges := make([]interpretation.ScoreRange, len(s.scoringConfig.ScoreBands))
+	for i, band := range s.scoringConfig.ScoreBands {
+		ranges[i] = band.GetScoreRange()
+	}
+	if err := interpretation.ValidateCoverage(ranges, minScore, maxScore); err != nil {
+		return errors.WrapC(err, code.ErrMedicalScaleInvalidInput, "医学量表 %s 的分数段未完整覆盖得分范围", s.code)
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package medicalscale
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor/ability"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newTotalScoreFactor 创建带解读规则的总分因子
+func newTotalScoreFactor(ranges ...interpretation.ScoreRange) factor.Factor {
+	rules := make([]interpretation.InterpretRule, len(ranges))
+	for i, r := range ranges {
+		rules[i] = interpretation.NewInterpretRule(r, r.String())
+	}
+	interpretationAbility := &ability.InterpretationAbility{}
+	interpretationAbility.SetInterpretationRules(rules)
+	return factor.NewFactor("total", "总分", factor.PrimaryFactor,
+		factor.WithIsTotalScore(true),
+		factor.WithInterpretation(interpretationAbility),
+	)
+}
+
+func TestMedicalScale_ValidateInterpretationCoverage(t *testing.T) {
+	tests := map[string]struct {
+		factor  factor.Factor
+		bands   []ScoreBand
+		wantErr bool
+	}{
+		"complete covering": {
+			factor: newTotalScoreFactor(interpretation.NewScoreRange(0, 10), interpretation.NewScoreRange(10, 28)),
+			bands:  phq9ScoreBands(),
+		},
+		"interpret rules gap": {
+			factor:  newTotalScoreFactor(interpretation.NewScoreRange(0, 5), interpretation.NewScoreRange(10, 28)),
+			wantErr: true,
+		},
+		"interpret rules overlap": {
+			factor:  newTotalScoreFactor(interpretation.NewScoreRange(0, 12), interpretation.NewScoreRange(10, 28)),
+			wantErr: true,
+		},
+		"score bands do not cover max score": {
+			factor: newTotalScoreFactor(interpretation.NewScoreRange(0, 28)),
+			bands: []ScoreBand{
+				NewScoreBand(interpretation.NewScoreRange(0, 10), "无或轻度抑郁", ""),
+				NewScoreBand(interpretation.NewScoreRange(10, 27), "中度及以上抑郁", ""),
+			},
+			wantErr: true,
+		},
+	}
+
+	for name, tt := range tests {
+		scale := NewMedicalScale("PHQ9", "PHQ-9",
+			WithFactors([]factor.Factor{tt.factor}),
+			WithScoringConfig(ScoringConfig{ScoreBands: tt.bands}),
+		)
+		err := scale.ValidateInterpretationCoverage(0, 27)
+		if tt.wantErr && !errors.IsCode(err, code.ErrMedicalScaleInvalidInput) {
+			t.Errorf("%s: ValidateInterpretationCoverage() error = %v, want ErrMedicalScaleInvalidInput", name, err)
+		}
+		if !tt.wantErr && err != nil {
+			t.Errorf("%s: ValidateInterpretationCoverage() error = %v", name, err)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	medicalScale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
 )
@@ -12,7 +13,16 @@ type MedicalScaleRepositoryMongo interface {
 	FindByCode(ctx context.Context, code string) (*medicalScale.MedicalScale, error)
 	FindByQuestionnaireCode(ctx context.Context, questionnaireCode string) (*medicalScale.MedicalScale, error)
 	FindList(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*medicalScale.MedicalScale, error)
+	FindListAfterCode(ctx context.Context, lastCode string, limit int) ([]*medicalScale.MedicalScale, error)
 	CountWithConditions(ctx context.Context, conditions map[string]string) (int64, error)
 	Update(ctx context.Context, qDomain *medicalScale.MedicalScale) error
 	ExistsByCode(ctx context.Context, code string) (bool, error)
 }
+
+// ReliabilityCache 量表信度缓存接口（出站端口）
+type ReliabilityCache interface {
+	// Get 获取缓存的量表信度，未缓存时返回 nil
+	Get(ctx context.Context, scaleCode string) (*medicalScale.Reliability, error)
+	// Set 缓存量表信度
+	Set(ctx context.Context, reliability *medicalScale.Reliability, ttl time.Duration) error
+}
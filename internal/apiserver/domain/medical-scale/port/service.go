@@ -20,6 +20,8 @@ type MedicalScaleQueryer interface {
 	GetMedicalScaleByQuestionnaireCode(ctx context.Context, questionnaireCode string) (*dto.MedicalScaleDTO, error)
 	// ListMedicalScales 列出医学量表列表
 	ListMedicalScales(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*dto.MedicalScaleDTO, int64, error)
+	// ListMedicalScalesAfterCode 按编码升序列出编码大于 lastCode 的医学量表，hasMore 表示是否还有下一页
+	ListMedicalScalesAfterCode(ctx context.Context, lastCode string, pageSize int) (scales []*dto.MedicalScaleDTO, hasMore bool, err error)
 }
 
 // MedicalScaleEditor 医学量表编辑接口
@@ -29,3 +31,9 @@ type MedicalScaleEditor interface {
 	// UpdateFactors 更新医学量表因子
 	UpdateFactors(ctx context.Context, code string, factors []dto.FactorDTO) (*dto.MedicalScaleDTO, error)
 }
+
+// MedicalScaleAnalytics 医学量表统计分析接口
+type MedicalScaleAnalytics interface {
+	// ComputeCronbachAlpha 根据量表最近的答卷计算克朗巴赫 α 系数，有效答卷少于 minResponses 份时返回错误
+	ComputeCronbachAlpha(ctx context.Context, scaleCode string, minResponses int) (float64, error)
+}
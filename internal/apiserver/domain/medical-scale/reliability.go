@@ -0,0 +1,64 @@
+package medicalscale
+
+import (
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Reliability 量表信度分析结果
+type Reliability struct {
+	ScaleCode     string    `json:"scale_code"`
+	CronbachAlpha float64   `json:"cronbach_alpha"`
+	Responses     int       `json:"responses"` // 参与计算的答卷数
+	Items         int       `json:"items"`     // 参与计算的条目数
+	ComputedAt    time.Time `json:"computed_at"`
+}
+
+// CronbachAlpha 计算克朗巴赫 α 系数，scores 每行为一份答卷，每列为一个条目的得分
+// α = k/(k-1) × (1 − Σ条目方差 / 总分方差)，方差均为样本方差
+func CronbachAlpha(scores [][]float64) (float64, error) {
+	if len(scores) < 2 {
+		return 0, errors.WithCode(code.ErrInsufficientData, "至少需要两份答卷才能计算信度")
+	}
+	k := len(scores[0])
+	if k < 2 {
+		return 0, errors.WithCode(code.ErrMedicalScaleInvalid, "至少需要两个计分条目才能计算信度")
+	}
+
+	totals := make([]float64, len(scores))
+	var itemVariance float64
+	for i := 0; i < k; i++ {
+		column := make([]float64, len(scores))
+		for j, row := range scores {
+			if len(row) != k {
+				return 0, errors.WithCode(code.ErrMedicalScaleInvalidInput, "第 %d 份答卷的条目数与其他答卷不一致", j+1)
+			}
+			column[j] = row[i]
+			totals[j] += row[i]
+		}
+		itemVariance += sampleVariance(column)
+	}
+
+	totalVariance := sampleVariance(totals)
+	if totalVariance == 0 {
+		return 0, errors.WithCode(code.ErrInsufficientData, "答卷总分没有差异，无法计算信度")
+	}
+	return float64(k) / float64(k-1) * (1 - itemVariance/totalVariance), nil
+}
+
+// sampleVariance 计算样本方差
+func sampleVariance(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var squares float64
+	for _, v := range values {
+		squares += (v - mean) * (v - mean)
+	}
+	return squares / float64(len(values)-1)
+}
@@ -0,0 +1,100 @@
+package medicalscale
+
+import (
+	"math"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestCronbachAlpha(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores [][]float64
+		want   float64
+	}{
+		{
+			name:   "条目完全一致",
+			scores: [][]float64{{1, 1, 1}, {2, 2, 2}, {3, 3, 3}, {0, 0, 0}},
+			want:   1,
+		},
+		{
+			name:   "三条目六份答卷",
+			scores: [][]float64{{2, 3, 3}, {3, 3, 4}, {1, 2, 2}, {4, 4, 5}, {2, 2, 3}, {3, 4, 4}},
+			want:   0.9642857142857142,
+		},
+		{
+			name:   "条目互不相关",
+			scores: [][]float64{{1, 0}, {0, 1}, {1, 1}, {0, 0}},
+			want:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := CronbachAlpha(tt.scores)
+			if err != nil {
+				t.Fatalf("CronbachAlpha() error = %v", err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("CronbachAlpha() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestCronbachAlpha_LSAT 使用公开的参考数据集校验：Bock & Lieberman (1970) 的 LSAT 第 6 部分，
+// 1000 名考生 5 道 0/1 计分题，文献（如 R 包 ltm 的 LSAT 数据集）报告的 α 为 0.295
+func TestCronbachAlpha_LSAT(t *testing.T) {
+	// 作答模式（第 1~5 题）及人数
+	patterns := []struct {
+		items [5]float64
+		count int
+	}{
+		{[5]float64{0, 0, 0, 0, 0}, 3}, {[5]float64{0, 0, 0, 0, 1}, 6}, {[5]float64{0, 0, 0, 1, 0}, 2}, {[5]float64{0, 0, 0, 1, 1}, 11},
+		{[5]float64{0, 0, 1, 0, 0}, 1}, {[5]float64{0, 0, 1, 0, 1}, 1}, {[5]float64{0, 0, 1, 1, 0}, 3}, {[5]float64{0, 0, 1, 1, 1}, 4},
+		{[5]float64{0, 1, 0, 0, 0}, 1}, {[5]float64{0, 1, 0, 0, 1}, 8}, {[5]float64{0, 1, 0, 1, 1}, 16}, {[5]float64{0, 1, 1, 0, 1}, 3},
+		{[5]float64{0, 1, 1, 1, 0}, 2}, {[5]float64{0, 1, 1, 1, 1}, 15}, {[5]float64{1, 0, 0, 0, 0}, 10}, {[5]float64{1, 0, 0, 0, 1}, 29},
+		{[5]float64{1, 0, 0, 1, 0}, 14}, {[5]float64{1, 0, 0, 1, 1}, 81}, {[5]float64{1, 0, 1, 0, 0}, 3}, {[5]float64{1, 0, 1, 0, 1}, 28},
+		{[5]float64{1, 0, 1, 1, 0}, 15}, {[5]float64{1, 0, 1, 1, 1}, 80}, {[5]float64{1, 1, 0, 0, 0}, 16}, {[5]float64{1, 1, 0, 0, 1}, 56},
+		{[5]float64{1, 1, 0, 1, 0}, 21}, {[5]float64{1, 1, 0, 1, 1}, 173}, {[5]float64{1, 1, 1, 0, 0}, 11}, {[5]float64{1, 1, 1, 0, 1}, 61},
+		{[5]float64{1, 1, 1, 1, 0}, 28}, {[5]float64{1, 1, 1, 1, 1}, 298},
+	}
+	var scores [][]float64
+	for _, p := range patterns {
+		for i := 0; i < p.count; i++ {
+			scores = append(scores, p.items[:])
+		}
+	}
+	if len(scores) != 1000 {
+		t.Fatalf("len(scores) = %d, want 1000 examinees", len(scores))
+	}
+
+	got, err := CronbachAlpha(scores)
+	if err != nil {
+		t.Fatalf("CronbachAlpha() error = %v", err)
+	}
+	if math.Abs(got-0.295) > 5e-4 {
+		t.Errorf("CronbachAlpha() = %v, want the published 0.295", got)
+	}
+}
+
+func TestCronbachAlpha_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		scores [][]float64
+		want   int
+	}{
+		{"答卷不足", [][]float64{{1, 2}}, code.ErrInsufficientData},
+		{"总分无差异", [][]float64{{1, 2}, {2, 1}}, code.ErrInsufficientData},
+		{"单个条目", [][]float64{{1}, {2}}, code.ErrMedicalScaleInvalid},
+		{"条目数不一致", [][]float64{{1, 2}, {2}}, code.ErrMedicalScaleInvalidInput},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CronbachAlpha(tt.scores); !errors.IsCode(err, tt.want) {
+				t.Errorf("CronbachAlpha() error = %v, want code %d", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package medicalscale
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// ScoreBand 分数段值对象，表示量表总分的临床切分区间
+// 例如 PHQ-9 总分 [10, 15) 提示中度抑郁
+type ScoreBand struct {
+	scoreRange  interpretation.ScoreRange
+	label       string
+	description string
+}
+
+// NewScoreBand 创建分数段
+func NewScoreBand(scoreRange interpretation.ScoreRange, label, description string) ScoreBand {
+	return ScoreBand{
+		scoreRange:  scoreRange,
+		label:       label,
+		description: description,
+	}
+}
+
+// GetScoreRange 获取分数范围，采用左闭右开区间 [min, max)
+func (b ScoreBand) GetScoreRange() interpretation.ScoreRange {
+	return b.scoreRange
+}
+
+// GetLabel 获取分数段名称，如 "中度抑郁"
+func (b ScoreBand) GetLabel() string {
+	return b.label
+}
+
+// GetDescription 获取分数段说明
+func (b ScoreBand) GetDescription() string {
+	return b.description
+}
+
+// ResolveScoreBand 获取总分所在的分数段，没有分数段包含该分数时返回 false
+func (c ScoringConfig) ResolveScoreBand(score float64) (ScoreBand, bool) {
+	for _, band := range c.ScoreBands {
+		if band.scoreRange.Contains(score) {
+			return band, true
+		}
+	}
+	return ScoreBand{}, false
+}
+
+// Validate 校验计分配置，分数段名称不能为空，分数范围必须连续且不重叠；计分维度须有效
+func (c ScoringConfig) Validate() error {
+	if err := scoring.ValidateDimensions(c.Dimensions); err != nil {
+		return err
+	}
+	if len(c.ScoreBands) == 0 {
+		return nil
+	}
+
+	ranges := make([]interpretation.ScoreRange, len(c.ScoreBands))
+	for i, band := range c.ScoreBands {
+		if strings.TrimSpace(band.label) == "" {
+			return errors.WithCode(code.ErrInvalidArgument, "第 %d 个分数段的名称不能为空", i+1)
+		}
+		ranges[i] = band.scoreRange
+	}
+	if err := interpretation.ValidateRanges(ranges); err != nil {
+		return errors.WrapC(err, code.ErrInvalidArgument, "分数段范围无效")
+	}
+	return nil
+}
+
+// sortScoreBands 按最低分升序排列分数段
+func sortScoreBands(bands []ScoreBand) []ScoreBand {
+	sorted := append([]ScoreBand(nil), bands...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].scoreRange.MinScore() < sorted[j].scoreRange.MinScore()
+	})
+	return sorted
+}
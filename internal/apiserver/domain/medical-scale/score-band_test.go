@@ -0,0 +1,76 @@
+package medicalscale
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// phq9ScoreBands PHQ-9 总分的临床切分分数段，故意打乱顺序
+func phq9ScoreBands() []ScoreBand {
+	return []ScoreBand{
+		NewScoreBand(interpretation.NewScoreRange(10, 15), "中度抑郁", "建议心理咨询"),
+		NewScoreBand(interpretation.NewScoreRange(0, 5), "无抑郁", ""),
+		NewScoreBand(interpretation.NewScoreRange(20, 28), "重度抑郁", "建议立即就医"),
+		NewScoreBand(interpretation.NewScoreRange(5, 10), "轻度抑郁", ""),
+		NewScoreBand(interpretation.NewScoreRange(15, 20), "中重度抑郁", ""),
+	}
+}
+
+func TestScoringConfig_ResolveScoreBand(t *testing.T) {
+	scale := NewMedicalScale("PHQ9", "PHQ-9", WithScoringConfig(ScoringConfig{ScoreBands: phq9ScoreBands()}))
+	config := scale.GetScoringConfig()
+
+	if got := config.ScoreBands[0].GetLabel(); got != "无抑郁" {
+		t.Errorf("ScoreBands[0] = %s, want bands sorted by min score", got)
+	}
+
+	tests := map[float64]string{0: "无抑郁", 9.5: "轻度抑郁", 10: "中度抑郁", 14.99: "中度抑郁", 27: "重度抑郁"}
+	for score, want := range tests {
+		band, ok := config.ResolveScoreBand(score)
+		if !ok || band.GetLabel() != want {
+			t.Errorf("ResolveScoreBand(%v) = %q, %v, want %q", score, band.GetLabel(), ok, want)
+		}
+	}
+
+	for _, score := range []float64{-1, 28} {
+		if band, ok := config.ResolveScoreBand(score); ok {
+			t.Errorf("ResolveScoreBand(%v) = %q, want no band", score, band.GetLabel())
+		}
+	}
+}
+
+func TestBaseInfoService_UpdateScoringConfig_ValidatesScoreBands(t *testing.T) {
+	scale := NewMedicalScale("PHQ9", "PHQ-9")
+
+	invalid := map[string][]ScoreBand{
+		"empty label": {NewScoreBand(interpretation.NewScoreRange(0, 5), " ", "")},
+		"gap": {
+			NewScoreBand(interpretation.NewScoreRange(0, 5), "无抑郁", ""),
+			NewScoreBand(interpretation.NewScoreRange(10, 15), "中度抑郁", ""),
+		},
+		"overlap": {
+			NewScoreBand(interpretation.NewScoreRange(0, 10), "无抑郁", ""),
+			NewScoreBand(interpretation.NewScoreRange(5, 15), "轻度抑郁", ""),
+		},
+		"empty range": {NewScoreBand(interpretation.NewScoreRange(5, 5), "轻度抑郁", "")},
+	}
+	for name, bands := range invalid {
+		err := (BaseInfoService{}).UpdateScoringConfig(scale, ScoringConfig{ScoreBands: bands})
+		if !errors.IsCode(err, code.ErrInvalidArgument) {
+			t.Errorf("%s: UpdateScoringConfig() error = %v, want ErrInvalidArgument", name, err)
+		}
+	}
+	if len(scale.GetScoringConfig().ScoreBands) != 0 {
+		t.Errorf("invalid score bands were saved: %v", scale.GetScoringConfig().ScoreBands)
+	}
+
+	if err := (BaseInfoService{}).UpdateScoringConfig(scale, ScoringConfig{ScoreBands: phq9ScoreBands()}); err != nil {
+		t.Fatalf("UpdateScoringConfig() error = %v", err)
+	}
+	if band, ok := scale.GetScoringConfig().ResolveScoreBand(12); !ok || band.GetDescription() != "建议心理咨询" {
+		t.Errorf("ResolveScoreBand(12) = %+v, %v", band, ok)
+	}
+}
@@ -0,0 +1,100 @@
+package scoring
+
+import (
+	"math"
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// NormGroup 常模组，以常模样本的均值和标准差将原始分换算为 T 分数和百分位
+type NormGroup struct {
+	// Mean 常模均值
+	Mean float64
+	// StdDev 常模标准差，必须大于 0
+	StdDev float64
+}
+
+// TScore 计算 T 分数：50 + 10 * (原始分 - 均值) / 标准差
+func (n NormGroup) TScore(raw float64) float64 {
+	return 50 + 10*n.zScore(raw)
+}
+
+// Percentile 按正态分布计算原始分在常模中的百分位 [0, 100]
+func (n NormGroup) Percentile(raw float64) float64 {
+	return 50 * (1 + math.Erf(n.zScore(raw)/math.Sqrt2))
+}
+
+// zScore 计算标准分
+func (n NormGroup) zScore(raw float64) float64 {
+	return (raw - n.Mean) / n.StdDev
+}
+
+// ScoringDimension 计分维度，如 SF-36 的生理功能、躯体疼痛等，各维度独立计分
+type ScoringDimension struct {
+	// Code 维度编码，在量表内唯一
+	Code string
+	// Label 维度名称
+	Label string
+	// QuestionCodes 参与该维度计分的题目编码
+	QuestionCodes []question.QuestionCode
+	// NormGroup 维度常模，为空时只计算原始分
+	NormGroup *NormGroup
+}
+
+// Score 计算维度得分：原始分为维度内已作答题目得分之和，配置了常模时换算 T 分数和百分位
+func (d ScoringDimension) Score(answers map[question.QuestionCode]answer.Answer) DimensionScore {
+	var raw float64
+	for _, questionCode := range d.QuestionCodes {
+		if score, ok := answerScore(answers, questionCode); ok {
+			raw += score
+		}
+	}
+
+	result := DimensionScore{Label: d.Label, Raw: raw}
+	if d.NormGroup != nil {
+		result.Normed = true
+		result.TScore = d.NormGroup.TScore(raw)
+		result.Percentile = d.NormGroup.Percentile(raw)
+	}
+	return result
+}
+
+// DimensionScore 维度得分
+type DimensionScore struct {
+	// Label 维度名称
+	Label string
+	// Raw 原始分
+	Raw float64
+	// Normed 是否按常模换算了 T 分数和百分位
+	Normed bool
+	// TScore T 分数，未配置常模时为 0
+	TScore float64
+	// Percentile 百分位 [0, 100]，未配置常模时为 0
+	Percentile float64
+}
+
+// ValidateDimensions 校验计分维度，编码不能为空且不能重复，每个维度至少包含一道题目，常模标准差必须大于 0
+func ValidateDimensions(dimensions []ScoringDimension) error {
+	codes := make(map[string]struct{}, len(dimensions))
+	for i, d := range dimensions {
+		if strings.TrimSpace(d.Code) == "" {
+			return errors.WithCode(code.ErrScoringConfigInvalid, "第 %d 个计分维度的编码不能为空", i+1)
+		}
+		if _, exists := codes[d.Code]; exists {
+			return errors.WithCode(code.ErrScoringConfigInvalid, "计分维度编码 %s 重复", d.Code)
+		}
+		codes[d.Code] = struct{}{}
+
+		if len(d.QuestionCodes) == 0 {
+			return errors.WithCode(code.ErrScoringConfigInvalid, "计分维度 %s 至少需要包含一道题目", d.Code)
+		}
+		if d.NormGroup != nil && !(d.NormGroup.StdDev > 0) {
+			return errors.WithCode(code.ErrScoringConfigInvalid, "计分维度 %s 的常模标准差必须大于 0", d.Code)
+		}
+	}
+	return nil
+}
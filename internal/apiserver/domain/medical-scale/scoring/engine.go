@@ -0,0 +1,60 @@
+package scoring
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+)
+
+// ScoringRuleEngine 计分规则引擎，按配置的策略计算答卷得分
+type ScoringRuleEngine struct {
+	strategy   RuleStrategy
+	dimensions []ScoringDimension
+}
+
+// Score 计算得分，按策略计算总分等各项得分，并为每个计分维度计算维度得分
+func (e *ScoringRuleEngine) Score(questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult {
+	result := e.strategy.Apply(questions, answers)
+	for _, d := range e.dimensions {
+		result.SetDimensionScore(d.Code, d.Score(answers))
+	}
+	return result
+}
+
+// ScoringRuleEngineBuilder 计分规则引擎构建器
+type ScoringRuleEngineBuilder struct {
+	strategies []RuleStrategy
+	dimensions []ScoringDimension
+}
+
+// NewScoringRuleEngineBuilder 创建计分规则引擎构建器
+func NewScoringRuleEngineBuilder() *ScoringRuleEngineBuilder {
+	return &ScoringRuleEngineBuilder{}
+}
+
+// WithStrategy 添加计分策略，多个策略按添加顺序执行
+func (b *ScoringRuleEngineBuilder) WithStrategy(strategy RuleStrategy) *ScoringRuleEngineBuilder {
+	if strategy != nil {
+		b.strategies = append(b.strategies, strategy)
+	}
+	return b
+}
+
+// WithDimensions 设置计分维度，各维度独立计算原始分、T 分数和百分位
+func (b *ScoringRuleEngineBuilder) WithDimensions(dimensions []ScoringDimension) *ScoringRuleEngineBuilder {
+	b.dimensions = append(b.dimensions, dimensions...)
+	return b
+}
+
+// Build 构建计分规则引擎，未添加策略时使用简单求和策略，添加多个策略时组合执行
+func (b *ScoringRuleEngineBuilder) Build() *ScoringRuleEngine {
+	engine := &ScoringRuleEngine{dimensions: b.dimensions}
+	switch len(b.strategies) {
+	case 0:
+		engine.strategy = NewSimpleSumStrategy()
+	case 1:
+		engine.strategy = b.strategies[0]
+	default:
+		engine.strategy = NewCompositeStrategy(b.strategies...)
+	}
+	return engine
+}
@@ -0,0 +1,173 @@
+package scoring
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newTestAnswers 创建题目 q1..qn 的单选题已计分答案，得分依次为 scores
+func newTestAnswers(t *testing.T, scores ...float64) ([]ScoredQuestion, map[question.QuestionCode]answer.Answer) {
+	t.Helper()
+	var (
+		questions []ScoredQuestion
+		answers   []answer.Answer
+	)
+	for i, score := range scores {
+		questionCode := question.NewQuestionCode("q" + string(rune('1'+i)))
+		ans, err := answer.NewAnswer(questionCode, question.QuestionTypeRadio, score, "a")
+		if err != nil {
+			t.Fatalf("NewAnswer(%s) error = %v", questionCode, err)
+		}
+		questions = append(questions, ScoredQuestion{Code: questionCode, Type: question.QuestionTypeRadio})
+		answers = append(answers, ans)
+	}
+	return questions, NewAnswerMap(answers)
+}
+
+func TestScoringRuleEngine_Strategies(t *testing.T) {
+	questions, answers := newTestAnswers(t, 3, 1, 2, 0)
+
+	subscales := NewSubscaleSumStrategy(map[string][]question.QuestionCode{
+		"anxiety":    {"q1", "q2"},
+		"depression": {"q3", "q4", "q9"},
+	})
+	weighted := NewWeightedSumStrategy(map[question.QuestionCode]float64{"q1": 2, "q4": 0.5})
+
+	tests := []struct {
+		name   string
+		engine *ScoringRuleEngine
+		want   map[string]float64
+	}{
+		{
+			name:   "default simple sum",
+			engine: NewScoringRuleEngineBuilder().Build(),
+			want:   map[string]float64{TotalScoreCode: 6},
+		},
+		{
+			name:   "subscale sum",
+			engine: NewScoringRuleEngineBuilder().WithStrategy(subscales).Build(),
+			want:   map[string]float64{"anxiety": 4, "depression": 2},
+		},
+		{
+			name:   "weighted sum uses weight 1 for unlisted questions",
+			engine: NewScoringRuleEngineBuilder().WithStrategy(weighted).Build(),
+			want:   map[string]float64{TotalScoreCode: 9},
+		},
+		{
+			name: "composite merges results, later strategies win",
+			engine: NewScoringRuleEngineBuilder().
+				WithStrategy(NewSimpleSumStrategy()).
+				WithStrategy(subscales).
+				WithStrategy(weighted).
+				Build(),
+			want: map[string]float64{TotalScoreCode: 9, "anxiety": 4, "depression": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.engine.Score(questions, answers).GetScores()
+			if len(got) != len(tt.want) {
+				t.Fatalf("Score() = %v, want %v", got, tt.want)
+			}
+			for scoreCode, want := range tt.want {
+				if got[scoreCode] != want {
+					t.Errorf("Score()[%s] = %v, want %v", scoreCode, got[scoreCode], want)
+				}
+			}
+		})
+	}
+}
+
+func TestNewRuleStrategy_FromConfig(t *testing.T) {
+	questions, answers := newTestAnswers(t, 3, 1, 2, 0)
+
+	strategy, err := NewRuleStrategy(json.RawMessage(`{"type": "composite", "strategies": [
+		{"type": "simple_sum"},
+		{"type": "subscale_sum", "subscales": {"anxiety": ["q1", "q2"]}},
+		{"type": "formula", "code": "severe_items", "formula": "count_above_threshold", "source_codes": ["q1", "q2", "q3", "q4"], "threshold": 1},
+		{"type": "formula", "code": "mean", "formula": "avg", "source_codes": ["q1", "q2", "q3", "q4"]}
+	]}`))
+	if err != nil {
+		t.Fatalf("NewRuleStrategy() error = %v", err)
+	}
+	if _, ok := strategy.(*CompositeStrategy); !ok {
+		t.Fatalf("NewRuleStrategy() = %T, want *CompositeStrategy", strategy)
+	}
+
+	got := NewScoringRuleEngineBuilder().WithStrategy(strategy).Build().Score(questions, answers).GetScores()
+	want := map[string]float64{TotalScoreCode: 6, "anxiety": 4, "severe_items": 2, "mean": 1.5}
+	for scoreCode, score := range want {
+		if got[scoreCode] != score {
+			t.Errorf("Score()[%s] = %v, want %v", scoreCode, got[scoreCode], score)
+		}
+	}
+
+	if strategy, err := NewRuleStrategy(nil); strategy != nil || err != nil {
+		t.Errorf("NewRuleStrategy(nil) = %v, %v, want nil, nil", strategy, err)
+	}
+}
+
+func TestNewRuleStrategy_InvalidConfig(t *testing.T) {
+	for _, config := range []string{
+		`{"type": "unknown"}`,
+		`{"type": "subscale_sum"}`,
+		`{"type": "formula", "code": "x", "formula": "conditional"}`,
+		`{"type": "composite", "strategies": [{"type": "formula", "formula": "sum"}]}`,
+		`not json`,
+	} {
+		if _, err := NewRuleStrategy(json.RawMessage(config)); !errors.IsCode(err, code.ErrScoringConfigInvalid) {
+			t.Errorf("NewRuleStrategy(%s) error = %v, want ErrScoringConfigInvalid", config, err)
+		}
+	}
+}
+
+func TestScoringRuleEngine_Dimensions(t *testing.T) {
+	questions, answers := newTestAnswers(t, 3, 1, 2, 0)
+
+	engine := NewScoringRuleEngineBuilder().WithDimensions([]ScoringDimension{
+		{Code: "physical", Label: "生理功能", QuestionCodes: []question.QuestionCode{"q1", "q2"}, NormGroup: &NormGroup{Mean: 2, StdDev: 2}},
+		{Code: "pain", Label: "躯体疼痛", QuestionCodes: []question.QuestionCode{"q3", "q9"}},
+	}).Build()
+	result := engine.Score(questions, answers)
+
+	if total, ok := result.GetTotalScore(); !ok || total != 6 {
+		t.Errorf("GetTotalScore() = %v, %v, want 6, true", total, ok)
+	}
+
+	dimensions := result.GetDimensionScores()
+	physical := dimensions["physical"]
+	if physical.Raw != 4 || !physical.Normed || physical.TScore != 60 {
+		t.Errorf("physical = %+v, want raw 4 and T score 60", physical)
+	}
+	if physical.Percentile < 84.1 || physical.Percentile > 84.2 {
+		t.Errorf("physical percentile = %v, want about 84.13", physical.Percentile)
+	}
+	if pain := dimensions["pain"]; pain.Raw != 2 || pain.Normed || pain.TScore != 0 || pain.Label != "躯体疼痛" {
+		t.Errorf("pain = %+v, want raw 2 without norm scores", pain)
+	}
+}
+
+func TestValidateDimensions(t *testing.T) {
+	valid := ScoringDimension{Code: "pain", QuestionCodes: []question.QuestionCode{"q1"}}
+	if err := ValidateDimensions([]ScoringDimension{valid}); err != nil {
+		t.Fatalf("ValidateDimensions() error = %v", err)
+	}
+
+	for name, dimensions := range map[string][]ScoringDimension{
+		"empty code":        {{QuestionCodes: []question.QuestionCode{"q1"}}},
+		"duplicate code":    {valid, valid},
+		"no questions":      {{Code: "pain"}},
+		"non-positive norm": {{Code: "pain", QuestionCodes: []question.QuestionCode{"q1"}, NormGroup: &NormGroup{Mean: 10}}},
+	} {
+		if err := ValidateDimensions(dimensions); !errors.IsCode(err, code.ErrScoringConfigInvalid) {
+			t.Errorf("%s: ValidateDimensions() error = %v, want ErrScoringConfigInvalid", name, err)
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package scoring
+
+import (
+	"bytes"
+	"encoding/json"
+	"sync"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	scoringsdk "github.com/yshujie/questionnaire-scale/pkg/scoring"
+)
+
+// 计分策略类型，与计分 SDK 的策略类型一致
+const (
+	StrategyTypeSimpleSum   = scoringsdk.StrategyTypeSimpleSum
+	StrategyTypeSubscaleSum = scoringsdk.StrategyTypeSubscaleSum
+	StrategyTypeWeightedSum = scoringsdk.StrategyTypeWeightedSum
+	StrategyTypeFormula     = scoringsdk.StrategyTypeFormula
+	StrategyTypeComposite   = scoringsdk.StrategyTypeComposite
+)
+
+// RuleStrategyFactory 计分策略工厂，由策略配置创建计分策略
+type RuleStrategyFactory func(config json.RawMessage) (RuleStrategy, error)
+
+// registry 计分策略类型到工厂的注册表
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]RuleStrategyFactory)
+)
+
+// RegisterRuleStrategyFactory 注册计分策略工厂，策略类型为空、工厂为空或类型已注册时返回错误
+func RegisterRuleStrategyFactory(strategyType string, factory RuleStrategyFactory) error {
+	if strategyType == "" || factory == nil {
+		return errors.WithCode(code.ErrScoringConfigInvalid, "计分策略工厂缺少策略类型或工厂函数")
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[strategyType]; exists {
+		return errors.WithCode(code.ErrScoringConfigInvalid, "计分策略类型已注册: %s", strategyType)
+	}
+	registry[strategyType] = factory
+	return nil
+}
+
+// mustRegisterRuleStrategyFactory 注册内置计分策略工厂，注册失败时 panic
+func mustRegisterRuleStrategyFactory(strategyType string, factory RuleStrategyFactory) {
+	if err := RegisterRuleStrategyFactory(strategyType, factory); err != nil {
+		panic(err)
+	}
+}
+
+func init() {
+	mustRegisterRuleStrategyFactory(StrategyTypeSimpleSum, newSimpleSumStrategyFromConfig)
+	mustRegisterRuleStrategyFactory(StrategyTypeSubscaleSum, newSubscaleSumStrategyFromConfig)
+	mustRegisterRuleStrategyFactory(StrategyTypeWeightedSum, newWeightedSumStrategyFromConfig)
+	mustRegisterRuleStrategyFactory(StrategyTypeFormula, newFormulaStrategyFromConfig)
+	mustRegisterRuleStrategyFactory(StrategyTypeComposite, newCompositeStrategyFromConfig)
+}
+
+// NewRuleStrategy 按策略配置中的 type 字段选择已注册的工厂创建计分策略
+// 配置为空时返回 nil，表示使用默认的简单求和策略，例如：
+//
+//	{"type": "composite", "strategies": [
+//	    {"type": "simple_sum"},
+//	    {"type": "subscale_sum", "subscales": {"anxiety": ["q1", "q2"]}},
+//	    {"type": "formula", "code": "severe_items", "formula": "count_above_threshold", "source_codes": ["q1", "q2"], "threshold": 2}
+//	]}
+func NewRuleStrategy(config json.RawMessage) (RuleStrategy, error) {
+	if len(bytes.TrimSpace(config)) == 0 || bytes.Equal(bytes.TrimSpace(config), []byte("null")) {
+		return nil, nil
+	}
+
+	var header struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(config, &header); err != nil {
+		return nil, errors.WrapC(err, code.ErrScoringConfigInvalid, "计分策略配置格式错误")
+	}
+	registryMu.RLock()
+	factory, ok := registry[header.Type]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, errors.WithCode(code.ErrScoringConfigInvalid, "未知的计分策略类型: %q", header.Type)
+	}
+	return factory(config)
+}
+
+// decodeStrategyConfig 解析具体策略的配置
+func decodeStrategyConfig(config json.RawMessage, v any) error {
+	if err := json.Unmarshal(config, v); err != nil {
+		return errors.WrapC(err, code.ErrScoringConfigInvalid, "计分策略配置格式错误")
+	}
+	return nil
+}
+
+// newSimpleSumStrategyFromConfig 创建简单求和策略，无额外配置
+func newSimpleSumStrategyFromConfig(config json.RawMessage) (RuleStrategy, error) {
+	return NewSimpleSumStrategy(), nil
+}
+
+// newSubscaleSumStrategyFromConfig 创建分量表求和策略，配置 subscales 为分量表编码到题目编码列表的映射
+func newSubscaleSumStrategyFromConfig(config json.RawMessage) (RuleStrategy, error) {
+	var c struct {
+		Subscales map[string][]string `json:"subscales"`
+	}
+	if err := decodeStrategyConfig(config, &c); err != nil {
+		return nil, err
+	}
+	if len(c.Subscales) == 0 {
+		return nil, errors.WithCode(code.ErrScoringConfigInvalid, "分量表求和策略未配置分量表")
+	}
+
+	subscales := make(map[string][]question.QuestionCode, len(c.Subscales))
+	for subscale, codes := range c.Subscales {
+		for _, questionCode := range codes {
+			subscales[subscale] = append(subscales[subscale], question.NewQuestionCode(questionCode))
+		}
+	}
+	return NewSubscaleSumStrategy(subscales), nil
+}
+
+// newWeightedSumStrategyFromConfig 创建加权求和策略，配置 weights 为题目编码到权重的映射
+func newWeightedSumStrategyFromConfig(config json.RawMessage) (RuleStrategy, error) {
+	var c struct {
+		Weights map[string]float64 `json:"weights"`
+	}
+	if err := decodeStrategyConfig(config, &c); err != nil {
+		return nil, err
+	}
+
+	weights := make(map[question.QuestionCode]float64, len(c.Weights))
+	for questionCode, weight := range c.Weights {
+		weights[question.NewQuestionCode(questionCode)] = weight
+	}
+	return NewWeightedSumStrategy(weights), nil
+}
+
+// newFormulaStrategyFromConfig 创建公式策略
+// 配置 code 为得分编码，formula 为聚合公式，source_codes 为源题目编码，threshold 为计数规则的阈值
+func newFormulaStrategyFromConfig(config json.RawMessage) (RuleStrategy, error) {
+	var c struct {
+		Code        string   `json:"code"`
+		Formula     string   `json:"formula"`
+		SourceCodes []string `json:"source_codes"`
+		Threshold   float64  `json:"threshold"`
+	}
+	if err := decodeStrategyConfig(config, &c); err != nil {
+		return nil, err
+	}
+
+	rule := calculation.NewCalculationRule(calculation.FormulaType(c.Formula), c.SourceCodes)
+	if calculation.FormulaType(c.Formula) == calculation.FormulaTypeCountAboveThreshold {
+		rule = calculation.NewCountAboveThresholdCalculationRule(c.SourceCodes, c.Threshold)
+	}
+	return NewFormulaStrategy(c.Code, rule)
+}
+
+// newCompositeStrategyFromConfig 创建组合策略，配置 strategies 为子策略配置列表
+func newCompositeStrategyFromConfig(config json.RawMessage) (RuleStrategy, error) {
+	var c struct {
+		Strategies []json.RawMessage `json:"strategies"`
+	}
+	if err := decodeStrategyConfig(config, &c); err != nil {
+		return nil, err
+	}
+	if len(c.Strategies) == 0 {
+		return nil, errors.WithCode(code.ErrScoringConfigInvalid, "组合策略未配置子策略")
+	}
+
+	strategies := make([]RuleStrategy, 0, len(c.Strategies))
+	for i, strategyConfig := range c.Strategies {
+		strategy, err := NewRuleStrategy(strategyConfig)
+		if err != nil {
+			return nil, errors.WrapC(err, code.ErrScoringConfigInvalid, "第 %d 个子策略配置无效", i+1)
+		}
+		if strategy == nil {
+			return nil, errors.WithCode(code.ErrScoringConfigInvalid, "第 %d 个子策略配置为空", i+1)
+		}
+		strategies = append(strategies, strategy)
+	}
+	return NewCompositeStrategy(strategies...), nil
+}
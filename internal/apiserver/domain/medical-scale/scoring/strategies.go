@@ -0,0 +1,144 @@
+package scoring
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	scoringsdk "github.com/yshujie/questionnaire-scale/pkg/scoring"
+)
+
+// SimpleSumStrategy 简单求和策略，总分为各题得分之和
+type SimpleSumStrategy struct{}
+
+// NewSimpleSumStrategy 创建简单求和策略
+func NewSimpleSumStrategy() *SimpleSumStrategy {
+	return &SimpleSumStrategy{}
+}
+
+// Apply 计算总分
+func (s *SimpleSumStrategy) Apply(questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult {
+	return applySDKStrategy(scoringsdk.Strategy{Type: StrategyTypeSimpleSum}, questions, answers)
+}
+
+// SubscaleSumStrategy 分量表求和策略，各分量表得分为其题目得分之和
+type SubscaleSumStrategy struct {
+	config scoringsdk.Strategy
+}
+
+// NewSubscaleSumStrategy 创建分量表求和策略，subscales 为分量表编码到题目编码的映射
+func NewSubscaleSumStrategy(subscales map[string][]question.QuestionCode) *SubscaleSumStrategy {
+	config := scoringsdk.Strategy{Type: StrategyTypeSubscaleSum, Subscales: make(map[string][]string, len(subscales))}
+	for subscale, codes := range subscales {
+		for _, c := range codes {
+			config.Subscales[subscale] = append(config.Subscales[subscale], c.Value())
+		}
+	}
+	return &SubscaleSumStrategy{config: config}
+}
+
+// Apply 计算各分量表得分，不计算总分
+func (s *SubscaleSumStrategy) Apply(questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult {
+	return applySDKStrategy(s.config, questions, answers)
+}
+
+// WeightedSumStrategy 加权求和策略，总分为各题得分乘以权重之和，未配置权重的题目权重为 1
+type WeightedSumStrategy struct {
+	config scoringsdk.Strategy
+}
+
+// NewWeightedSumStrategy 创建加权求和策略
+func NewWeightedSumStrategy(weights map[question.QuestionCode]float64) *WeightedSumStrategy {
+	config := scoringsdk.Strategy{Type: StrategyTypeWeightedSum, Weights: make(map[string]float64, len(weights))}
+	for c, weight := range weights {
+		config.Weights[c.Value()] = weight
+	}
+	return &WeightedSumStrategy{config: config}
+}
+
+// Apply 计算加权总分
+func (s *WeightedSumStrategy) Apply(questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult {
+	return applySDKStrategy(s.config, questions, answers)
+}
+
+// FormulaStrategy 公式策略，按聚合公式由源题目得分计算一项得分，如“得分超过 1 分的条目数”
+type FormulaStrategy struct {
+	config scoringsdk.Strategy
+}
+
+// NewFormulaStrategy 创建公式策略，得分写入 scoreCode，只支持聚合公式
+func NewFormulaStrategy(scoreCode string, rule *calculation.CalculationRule) (*FormulaStrategy, error) {
+	if scoreCode == "" {
+		return nil, errors.WithCode(code.ErrScoringConfigInvalid, "公式策略的得分编码不能为空")
+	}
+	if rule == nil || !rule.GetFormula().IsAggregation() {
+		return nil, errors.WithCode(code.ErrScoringConfigInvalid, "公式策略 %s 的计算公式不是聚合公式", scoreCode)
+	}
+	return &FormulaStrategy{config: scoringsdk.Strategy{
+		Type:        StrategyTypeFormula,
+		Code:        scoreCode,
+		Formula:     rule.GetFormula().String(),
+		SourceCodes: rule.GetSourceCodes(),
+		Threshold:   rule.GetThreshold(),
+	}}, nil
+}
+
+// Apply 按公式计算得分，未作答的源题目不参与计算
+func (s *FormulaStrategy) Apply(questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult {
+	return applySDKStrategy(s.config, questions, answers)
+}
+
+// CompositeStrategy 组合策略，依次执行多个策略并合并结果，编码相同的得分以后执行的策略为准
+type CompositeStrategy struct {
+	strategies []RuleStrategy
+}
+
+// NewCompositeStrategy 创建组合策略
+func NewCompositeStrategy(strategies ...RuleStrategy) *CompositeStrategy {
+	return &CompositeStrategy{strategies: strategies}
+}
+
+// Apply 依次执行各策略并合并结果
+func (s *CompositeStrategy) Apply(questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult {
+	result := NewScoreResult()
+	for _, strategy := range s.strategies {
+		result.Merge(strategy.Apply(questions, answers))
+	}
+	return result
+}
+
+// UsesWeightedSum 判断计分策略是否使用加权求和，组合策略检查其各子策略
+func UsesWeightedSum(strategy RuleStrategy) bool {
+	switch s := strategy.(type) {
+	case *WeightedSumStrategy:
+		return true
+	case *CompositeStrategy:
+		for _, sub := range s.strategies {
+			if UsesWeightedSum(sub) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// applySDKStrategy 由计分 SDK 按策略配置计算得分，服务端与第三方共用同一套策略实现
+// 各策略构造时已校验配置，计算不会失败
+func applySDKStrategy(config scoringsdk.Strategy, questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult {
+	codes := make([]string, 0, len(questions))
+	for _, q := range questions {
+		codes = append(codes, q.Code.Value())
+	}
+	questionScores := make(map[string]float64, len(answers))
+	for c, ans := range answers {
+		questionScores[c.Value()] = ans.GetScore()
+	}
+
+	result := NewScoreResult()
+	scores, _ := config.Apply(codes, questionScores)
+	for c, score := range scores {
+		result.SetScore(c, score)
+	}
+	return result
+}
@@ -0,0 +1,119 @@
+package scoring
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	scoringsdk "github.com/yshujie/questionnaire-scale/pkg/scoring"
+)
+
+// TotalScoreCode 总分的得分编码
+const TotalScoreCode = scoringsdk.TotalScoreCode
+
+// RuleStrategy 计分规则策略
+// 根据参与计分的题目和已计分的答案计算得分，得分按编码写入计分结果
+type RuleStrategy interface {
+	Apply(questions []ScoredQuestion, answers map[question.QuestionCode]answer.Answer) *ScoreResult
+}
+
+// ScoredQuestion 参与计分的题目
+type ScoredQuestion struct {
+	Code question.QuestionCode
+	Type question.QuestionType
+}
+
+// NewScoredQuestions 按问卷题目顺序创建参与计分的题目列表，段落题不参与计分
+func NewScoredQuestions(questions []question.Question) []ScoredQuestion {
+	scored := make([]ScoredQuestion, 0, len(questions))
+	for _, q := range questions {
+		if q == nil || q.GetType() == question.QuestionTypeSection {
+			continue
+		}
+		scored = append(scored, ScoredQuestion{Code: q.GetCode(), Type: q.GetType()})
+	}
+	return scored
+}
+
+// NewAnswerMap 以题目编码为键建立答案索引
+func NewAnswerMap(answers []answer.Answer) map[question.QuestionCode]answer.Answer {
+	m := make(map[question.QuestionCode]answer.Answer, len(answers))
+	for _, ans := range answers {
+		m[question.NewQuestionCode(ans.GetQuestionCode())] = ans
+	}
+	return m
+}
+
+// ScoreResult 计分结果，以得分编码为键保存各项得分，总分的编码为 TotalScoreCode
+// 量表配置了计分维度时，另以维度编码为键保存各维度得分
+type ScoreResult struct {
+	scores     map[string]float64
+	dimensions map[string]DimensionScore
+}
+
+// NewScoreResult 创建计分结果
+func NewScoreResult() *ScoreResult {
+	return &ScoreResult{
+		scores:     make(map[string]float64),
+		dimensions: make(map[string]DimensionScore),
+	}
+}
+
+// SetScore 设置得分
+func (r *ScoreResult) SetScore(code string, score float64) {
+	r.scores[code] = score
+}
+
+// GetScore 获取得分
+func (r *ScoreResult) GetScore(code string) (float64, bool) {
+	score, ok := r.scores[code]
+	return score, ok
+}
+
+// GetTotalScore 获取总分，没有策略计算总分时返回 false
+func (r *ScoreResult) GetTotalScore() (float64, bool) {
+	return r.GetScore(TotalScoreCode)
+}
+
+// GetScores 获取全部得分
+func (r *ScoreResult) GetScores() map[string]float64 {
+	scores := make(map[string]float64, len(r.scores))
+	for code, score := range r.scores {
+		scores[code] = score
+	}
+	return scores
+}
+
+// SetDimensionScore 设置维度得分
+func (r *ScoreResult) SetDimensionScore(code string, score DimensionScore) {
+	r.dimensions[code] = score
+}
+
+// GetDimensionScores 获取全部维度得分，未配置计分维度时为空
+func (r *ScoreResult) GetDimensionScores() map[string]DimensionScore {
+	dimensions := make(map[string]DimensionScore, len(r.dimensions))
+	for code, score := range r.dimensions {
+		dimensions[code] = score
+	}
+	return dimensions
+}
+
+// Merge 合并另一个计分结果，编码相同的得分以 other 为准
+func (r *ScoreResult) Merge(other *ScoreResult) {
+	if other == nil {
+		return
+	}
+	for code, score := range other.scores {
+		r.scores[code] = score
+	}
+	for code, score := range other.dimensions {
+		r.dimensions[code] = score
+	}
+}
+
+// answerScore 获取题目答案的得分，未作答时返回 false
+func answerScore(answers map[question.QuestionCode]answer.Answer, code question.QuestionCode) (float64, bool) {
+	ans, ok := answers[code]
+	if !ok {
+		return 0, false
+	}
+	return ans.GetScore(), true
+}
@@ -0,0 +1,33 @@
+package notificationhook
+
+import "time"
+
+// Delivery 通知投递记录
+// 每次投递尝试（包括重试）记录一条
+type Delivery struct {
+	HookID            uint64
+	Event             string
+	QuestionnaireCode string
+	Attempt           int
+	StatusCode        int // 对方返回的 HTTP 状态码，未收到响应时为 0
+	Error             string
+	CreatedAt         time.Time
+}
+
+// NewDelivery 创建投递记录，请求失败时 errMsg 记录失败原因
+func NewDelivery(hookID uint64, event, questionnaireCode string, attempt, statusCode int, errMsg string, now time.Time) *Delivery {
+	return &Delivery{
+		HookID:            hookID,
+		Event:             event,
+		QuestionnaireCode: questionnaireCode,
+		Attempt:           attempt,
+		StatusCode:        statusCode,
+		Error:             errMsg,
+		CreatedAt:         now,
+	}
+}
+
+// Succeeded 判断投递是否成功，对方返回 2xx 视为成功
+func (d *Delivery) Succeeded() bool {
+	return d.StatusCode >= 200 && d.StatusCode < 300
+}
@@ -0,0 +1,70 @@
+package notificationhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net"
+	"net/url"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// 通知事件
+const (
+	// EventSheetSubmitted 答卷已提交
+	EventSheetSubmitted = "sheet.submitted"
+	// EventReportGenerated 解读报告已生成
+	EventReportGenerated = "report.generated"
+	// EventAssignmentOverdue 问卷分配已逾期
+	EventAssignmentOverdue = "assignment.overdue"
+)
+
+// NotificationHook 通知钩子
+// 问卷的答卷提交、解读报告生成后向 URL 推送事件，供外部系统集成
+type NotificationHook struct {
+	ID                uint64
+	QuestionnaireCode string
+	URL               string
+	Secret            string
+	Events            []string
+}
+
+// Subscribes 判断钩子是否订阅了事件
+func (h *NotificationHook) Subscribes(event string) bool {
+	for _, e := range h.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Sign 使用钩子密钥计算请求体的 HMAC-SHA256 签名，格式为 sha256=<hex>
+func (h *NotificationHook) Sign(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(h.Secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// ValidateURL 校验钩子地址，只允许带主机的 http、https 绝对地址
+func (h *NotificationHook) ValidateURL() error {
+	u, err := url.Parse(h.URL)
+	if err != nil {
+		return errors.WrapC(err, code.ErrNotificationHookURLInvalid, "通知钩子地址无效")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.WithCode(code.ErrNotificationHookURLInvalid, "通知钩子地址只支持 http、https 协议")
+	}
+	if u.Hostname() == "" {
+		return errors.WithCode(code.ErrNotificationHookURLInvalid, "通知钩子地址缺少主机")
+	}
+	return nil
+}
+
+// IsPublicIP 判断 IP 是否为公网地址，回环、私有、链路本地、组播和未指定地址均不是公网地址
+func IsPublicIP(ip net.IP) bool {
+	return !ip.IsLoopback() && !ip.IsPrivate() && !ip.IsLinkLocalUnicast() && !ip.IsLinkLocalMulticast() &&
+		!ip.IsInterfaceLocalMulticast() && !ip.IsMulticast() && !ip.IsUnspecified()
+}
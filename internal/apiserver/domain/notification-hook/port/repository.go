@@ -0,0 +1,19 @@
+package port
+
+import (
+	"context"
+
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+)
+
+// NotificationHookRepository 通知钩子存储库接口（出站端口）
+type NotificationHookRepository interface {
+	FindByID(ctx context.Context, id uint64) (*notificationhook.NotificationHook, error)
+	FindByQuestionnaireCode(ctx context.Context, questionnaireCode string) ([]*notificationhook.NotificationHook, error)
+}
+
+// DeliveryRepository 通知投递记录存储库接口（出站端口）
+type DeliveryRepository interface {
+	Create(ctx context.Context, delivery *notificationhook.Delivery) error
+	FindByHookID(ctx context.Context, hookID uint64, limit int) ([]*notificationhook.Delivery, error)
+}
@@ -0,0 +1,18 @@
+package port
+
+import (
+	"context"
+
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+)
+
+// EventNotifier 事件通知器
+// 向问卷下订阅了事件的通知钩子推送事件，异步投递，不阻塞调用方
+type EventNotifier interface {
+	Notify(event, questionnaireCode string)
+}
+
+// DeliveryQueryer 通知投递记录查询器
+type DeliveryQueryer interface {
+	ListDeliveries(ctx context.Context, hookID uint64) ([]*notificationhook.Delivery, error)
+}
@@ -2,6 +2,7 @@ package questionnaire
 
 import (
 	"strings"
+	"unicode/utf8"
 
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
@@ -44,3 +45,70 @@ func (BaseInfoService) UpdateCoverImage(q *Questionnaire, imageURL string) error
 	q.imgUrl = imageURL
 	return nil
 }
+
+// UpdateGeoRestriction 设置允许访问的国家（ISO 3166-1 alpha-2 代码），为空表示不限制
+func (BaseInfoService) UpdateGeoRestriction(q *Questionnaire, countries []string) error {
+	normalized := make([]string, 0, len(countries))
+	for _, country := range countries {
+		country = strings.ToUpper(strings.TrimSpace(country))
+		if !isCountryCode(country) {
+			return errors.WithCode(code.ErrInvalidArgument, "国家代码 %q 不是合法的 ISO 3166-1 alpha-2 代码", country)
+		}
+		normalized = append(normalized, country)
+	}
+	q.geoRestriction = normalized
+	return nil
+}
+
+// 问卷标签的数量和长度上限
+const (
+	MaxTags      = 20
+	MaxTagLength = 50
+)
+
+// UpdateTags 设置问卷标签，标签按 NormalizeTags 规范化
+func (BaseInfoService) UpdateTags(q *Questionnaire, tags []string) error {
+	normalized, err := NormalizeTags(tags)
+	if err != nil {
+		return err
+	}
+	q.tags = normalized
+	return nil
+}
+
+// NormalizeTags 规范化标签，标签去除首尾空白并转为小写，忽略空标签和重复标签
+func NormalizeTags(tags []string) ([]string, error) {
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if tag == "" {
+			continue
+		}
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		if utf8.RuneCountInString(tag) > MaxTagLength {
+			return nil, errors.WithCode(code.ErrInvalidArgument, "标签 %q 长度不能超过 %d 字符", tag, MaxTagLength)
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > MaxTags {
+		return nil, errors.WithCode(code.ErrInvalidArgument, "标签数量不能超过 %d 个", MaxTags)
+	}
+	return normalized, nil
+}
+
+// isCountryCode 判断是否为两位大写字母的国家代码
+func isCountryCode(country string) bool {
+	if len(country) != 2 {
+		return false
+	}
+	for _, r := range country {
+		if r < 'A' || r > 'Z' {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package questionnaire
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestBaseInfoService_UpdateGeoRestriction(t *testing.T) {
+	q := NewQuestionnaire(NewQuestionnaireCode("phq9"), "PHQ-9")
+
+	if err := (BaseInfoService{}).UpdateGeoRestriction(q, []string{" cn", "SG"}); err != nil {
+		t.Fatalf("UpdateGeoRestriction() error = %v", err)
+	}
+	if got := q.GetGeoRestriction(); len(got) != 2 || got[0] != "CN" || got[1] != "SG" {
+		t.Errorf("GetGeoRestriction() = %v, want [CN SG]", got)
+	}
+	if !q.AllowsCountry("cn") || q.AllowsCountry("US") {
+		t.Errorf("AllowsCountry() does not match restriction %v", q.GetGeoRestriction())
+	}
+
+	for _, invalid := range []string{"CHN", "C1", ""} {
+		if err := (BaseInfoService{}).UpdateGeoRestriction(q, []string{invalid}); !errors.IsCode(err, code.ErrInvalidArgument) {
+			t.Errorf("UpdateGeoRestriction(%q) error = %v, want ErrInvalidArgument", invalid, err)
+		}
+	}
+
+	// 清空地域限制后允许所有国家
+	if err := (BaseInfoService{}).UpdateGeoRestriction(q, nil); err != nil {
+		t.Fatalf("UpdateGeoRestriction(nil) error = %v", err)
+	}
+	if !q.AllowsCountry("US") {
+		t.Error("AllowsCountry(US) = false without restriction")
+	}
+}
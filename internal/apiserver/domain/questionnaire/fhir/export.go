@@ -0,0 +1,131 @@
+package fhir
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// ExportQuestionnaire 将问卷定义导出为 FHIR R4 Questionnaire 资源
+// attachments 为 true 时文件上传题导出为 attachment 类型，允许多个文件时可重复作答；
+// 为 false 时问卷包含文件上传题返回错误，供不支持附件的接收方使用
+func ExportQuestionnaire(q *questionnaire.Questionnaire, attachments bool) ([]byte, error) {
+	if q == nil {
+		return nil, errors.WithCode(code.ErrQuestionnaireInvalidInput, "questionnaire is required")
+	}
+
+	resource := Questionnaire{
+		ResourceType: ResourceTypeQuestionnaire,
+		ID:           q.GetCode().Value(),
+		Version:      q.GetVersion().Value(),
+		Name:         q.GetCode().Value(),
+		Title:        q.GetTitle(),
+		Status:       exportStatus(q.GetStatus()),
+		Description:  q.GetDescription(),
+	}
+	for _, qu := range q.GetQuestions() {
+		item, err := exportQuestion(qu, attachments)
+		if err != nil {
+			return nil, err
+		}
+		resource.Item = append(resource.Item, item)
+	}
+
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return nil, errors.WrapC(err, code.ErrEncodingJSON, "encode FHIR Questionnaire failed")
+	}
+	return data, nil
+}
+
+// exportQuestion 将问卷问题转换为 FHIR 问题
+func exportQuestion(q question.Question, attachments bool) (Item, error) {
+	item := Item{
+		LinkID: q.GetCode().Value(),
+		Text:   q.GetTitle(),
+	}
+
+	switch q.GetType() {
+	case question.QuestionTypeRadio:
+		item.Type = ItemTypeChoice
+	case question.QuestionTypeCheckbox:
+		item.Type, item.Repeats = ItemTypeChoice, true
+	case question.QuestionTypeText:
+		item.Type = ItemTypeString
+	case question.QuestionTypeTextarea:
+		item.Type = ItemTypeText
+	case question.QuestionTypeNumber:
+		item.Type = ItemTypeDecimal
+	case question.QuestionTypeSection:
+		item.Type = ItemTypeDisplay
+	case question.QuestionTypeFileUpload:
+		if !attachments {
+			return Item{}, errors.WithCode(code.ErrQuestionnaireInvalidInput, "question type %s of %s cannot be exported to FHIR without attachments", q.GetType(), item.LinkID)
+		}
+		item.Type, item.Repeats = ItemTypeAttachment, q.GetMaxFiles() > 1
+	default:
+		return Item{}, errors.WithCode(code.ErrQuestionnaireInvalidInput, "question type %s of %s cannot be exported to FHIR", q.GetType(), item.LinkID)
+	}
+
+	for _, rule := range q.GetValidationRules() {
+		if rule.GetRuleType() == validation.RuleTypeRequired {
+			item.Required = true
+		}
+	}
+	for _, option := range q.GetOptions() {
+		item.AnswerOption = append(item.AnswerOption, exportOption(option))
+	}
+	for _, dep := range q.GetConditionalRequired() {
+		item.EnableWhen = append(item.EnableWhen, exportConditionalRequired(dep))
+	}
+	return item, nil
+}
+
+// exportOption 将选项转换为 FHIR 可选答案，分值写入 ordinalValue 扩展
+func exportOption(option question.Option) AnswerOption {
+	score := float64(option.GetScore())
+	return AnswerOption{
+		Extension:   []Extension{{URL: OrdinalValueExtension, ValueDecimal: &score}},
+		ValueCoding: &Coding{Code: option.GetCode(), Display: option.GetContent()},
+	}
+}
+
+// exportConditionalRequired 将条件必填规则转换为 FHIR 启用条件
+func exportConditionalRequired(dep question.ConditionalRequired) EnableWhen {
+	cond := EnableWhen{
+		Question: dep.DependsOnCode.Value(),
+		Operator: enableWhenOperatorEqual,
+	}
+
+	switch v := dep.RequiredWhenValue.(type) {
+	case bool:
+		cond.AnswerBoolean = &v
+	case int:
+		cond.AnswerInteger = &v
+	case float64:
+		cond.AnswerDecimal = &v
+	case string:
+		cond.AnswerCoding = &Coding{Code: v}
+	default:
+		s := fmt.Sprint(v)
+		cond.AnswerString = &s
+	}
+	return cond
+}
+
+// exportStatus 将问卷状态映射为 FHIR 问卷状态
+func exportStatus(status questionnaire.QuestionnaireStatus) string {
+	switch status {
+	case questionnaire.STATUS_PUBLISHED:
+		return StatusActive
+	case questionnaire.STATUS_ARCHIVED:
+		return StatusRetired
+	default:
+		return StatusDraft
+	}
+}
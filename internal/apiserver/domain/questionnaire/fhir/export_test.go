@@ -0,0 +1,85 @@
+package fhir
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// expectedSleepQuestionnaire 睡眠问卷对应的 FHIR Questionnaire
+const expectedSleepQuestionnaire = `{
+	"resourceType": "Questionnaire",
+	"id": "sleep",
+	"version": "1.0",
+	"name": "sleep",
+	"title": "睡眠问卷",
+	"status": "active",
+	"description": "成人睡眠状况调查",
+	"item": [
+		{
+			"linkId": "Q1",
+			"text": "睡眠质量",
+			"type": "choice",
+			"required": true,
+			"answerOption": [
+				{"valueCoding": {"code": "A", "display": "好"}, "extension": [{"url": "http://hl7.org/fhir/StructureDefinition/ordinalValue", "valueDecimal": 0}]},
+				{"valueCoding": {"code": "B", "display": "差"}, "extension": [{"url": "http://hl7.org/fhir/StructureDefinition/ordinalValue", "valueDecimal": 2}]}
+			]
+		},
+		{"linkId": "Q2", "text": "平均睡眠时长", "type": "decimal"},
+		{"linkId": "Q3", "text": "睡眠监测报告", "type": "attachment", "repeats": true}
+	]
+}`
+
+func TestExportQuestionnaire(t *testing.T) {
+	newQuestion := func(code, title string, typ question.QuestionType, opts ...question.BuilderOption) question.Question {
+		opts = append(opts,
+			question.WithCode(question.NewQuestionCode(code)),
+			question.WithTitle(title),
+			question.WithQuestionType(typ),
+		)
+		return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(opts...))
+	}
+
+	q := questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("sleep"), "睡眠问卷",
+		questionnaire.WithDescription("成人睡眠状况调查"),
+		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		questionnaire.WithStatus(questionnaire.STATUS_PUBLISHED),
+		questionnaire.WithQuestions([]question.Question{
+			newQuestion("Q1", "睡眠质量", question.QuestionTypeRadio,
+				question.WithOption("A", "好", 0), question.WithOption("B", "差", 2),
+				question.WithValidationRule(validation.RuleTypeRequired, "true")),
+			newQuestion("Q2", "平均睡眠时长", question.QuestionTypeNumber),
+			newQuestion("Q3", "睡眠监测报告", question.QuestionTypeFileUpload,
+				question.WithFileConstraints([]string{"application/pdf"}, 1<<20, 3)),
+		}),
+	)
+
+	data, err := ExportQuestionnaire(q, true)
+	if err != nil {
+		t.Fatalf("ExportQuestionnaire() error = %v", err)
+	}
+
+	var got, want map[string]any
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal exported resource: %v", err)
+	}
+	if err := json.Unmarshal([]byte(expectedSleepQuestionnaire), &want); err != nil {
+		t.Fatalf("unmarshal expected resource: %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ExportQuestionnaire() =\n%s\nwant\n%s", data, expectedSleepQuestionnaire)
+	}
+
+	// 接收方不支持附件时，文件上传题无法导出
+	if _, err := ExportQuestionnaire(q, false); !errors.IsCode(err, code.ErrQuestionnaireInvalidInput) {
+		t.Errorf("ExportQuestionnaire(attachments=false) error = %v, want ErrQuestionnaireInvalidInput", err)
+	}
+}
@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
 )
@@ -15,6 +16,9 @@ type QuestionnaireRepositoryMySQL interface {
 	FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error)
 	FindList(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*questionnaire.Questionnaire, error)
 	CountWithConditions(ctx context.Context, conditions map[string]string) (int64, error)
+	// FindByTags 按标签分页查询问卷，返回当前页问卷和总数
+	// matchAll 为 true 时要求包含全部标签，否则包含任一标签即可
+	FindByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*questionnaire.Questionnaire, int64, error)
 	Update(ctx context.Context, questionnaire *questionnaire.Questionnaire) error
 	Remove(ctx context.Context, id uint64) error
 }
@@ -23,11 +27,72 @@ type QuestionnaireRepositoryMySQL interface {
 // 定义了与存储相关的所有操作契约
 type QuestionnaireRepositoryMongo interface {
 	Create(ctx context.Context, qDomain *questionnaire.Questionnaire) error
+	// FindByCode 根据编码查询问卷的当前（版本号最大的）版本，不存在时返回 ErrQuestionnaireNotFound
 	FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error)
+	// FindByCodeOrNil 根据编码查询问卷的当前（版本号最大的）版本，不存在时返回 nil
+	FindByCodeOrNil(ctx context.Context, code string) (*questionnaire.Questionnaire, error)
+	// FindByCodeVersion 根据编码和版本查询问卷，不存在时返回 ErrQuestionnaireNotFound
 	FindByCodeVersion(ctx context.Context, code, version string) (*questionnaire.Questionnaire, error)
 	Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error
 	Remove(ctx context.Context, code string) error
+	// RemoveByFilter 批量软删除符合条件的问卷，返回受影响的数量，条件为空时返回 ErrQuestionnaireInvalidInput
+	RemoveByFilter(ctx context.Context, conditions map[string]interface{}) (int64, error)
 	HardDelete(ctx context.Context, code string) error
 	ExistsByCode(ctx context.Context, code string) (bool, error)
 	FindActiveQuestionnaires(ctx context.Context) ([]*questionnaire.Questionnaire, error)
+	// FindByTags 按标签分页查询未删除的问卷，返回当前页问卷和总数，问卷的每个版本分别匹配
+	// matchAll 为 true 时要求包含全部标签，否则包含任一标签即可
+	FindByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*questionnaire.Questionnaire, int64, error)
+}
+
+// ChangeEventType 问卷变更类型
+type ChangeEventType string
+
+const (
+	// ChangeEventCreated 问卷创建
+	ChangeEventCreated ChangeEventType = "create"
+	// ChangeEventUpdated 问卷更新
+	ChangeEventUpdated ChangeEventType = "update"
+	// ChangeEventDeleted 问卷删除（包括软删除）
+	ChangeEventDeleted ChangeEventType = "delete"
+)
+
+// ChangeEvent 问卷变更事件
+type ChangeEvent struct {
+	Type ChangeEventType
+	// Code 问卷编码，物理删除且数据库未开启变更前镜像时为空
+	Code       string
+	OccurredAt time.Time
+}
+
+// QuestionnaireChangeWatcher 问卷变更监听接口（出站端口）
+type QuestionnaireChangeWatcher interface {
+	// Watch 监听问卷变更，ctx 取消后关闭返回的通道
+	Watch(ctx context.Context) (<-chan ChangeEvent, error)
+}
+
+// ThumbnailCache 问卷缩略图缓存接口（出站端口）
+type ThumbnailCache interface {
+	// Get 读取缩略图，不存在时返回 nil
+	Get(ctx context.Context, key string) ([]byte, error)
+	// Save 保存缩略图，覆盖同键的缩略图
+	Save(ctx context.Context, key string, data []byte) error
+	// Delete 删除缩略图，不存在时不报错
+	Delete(ctx context.Context, key string) error
+}
+
+// ThumbnailRenderer 问卷缩略图渲染接口（出站端口）
+type ThumbnailRenderer interface {
+	// Render 渲染 HTML 页面的首屏，返回 width × height 像素的 JPEG 图片
+	Render(ctx context.Context, html []byte, width, height int) ([]byte, error)
+}
+
+// TranslationRepository 问卷译文存储库接口（出站端口）
+type TranslationRepository interface {
+	// Save 保存译文，同一问卷、语言和字段路径的译文会被覆盖
+	Save(ctx context.Context, questionnaireCode string, translation *questionnaire.Translation) error
+	// FindOne 查询问卷指定语言和字段路径的译文，不存在时返回 nil
+	FindOne(ctx context.Context, questionnaireCode, language, fieldPath string) (*questionnaire.Translation, error)
+	// FindByLanguage 查询问卷指定语言的全部译文
+	FindByLanguage(ctx context.Context, questionnaireCode, language string) ([]*questionnaire.Translation, error)
 }
@@ -2,8 +2,11 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
 )
 
 // QuestionnaireCreator 问卷创建接口
@@ -16,22 +19,73 @@ type QuestionnaireCreator interface {
 type QuestionnaireQueryer interface {
 	// GetQuestionnaireByCode 根据问卷代码获取问卷
 	GetQuestionnaireByCode(ctx context.Context, code string) (*dto.QuestionnaireDTO, error)
+	// GetQuestionnaireByCodeVersion 根据问卷代码和版本获取问卷
+	GetQuestionnaireByCodeVersion(ctx context.Context, code, version string) (*dto.QuestionnaireDTO, error)
 	// ListQuestionnaires 列出问卷列表
 	ListQuestionnaires(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*dto.QuestionnaireDTO, int64, error)
+	// ListQuestionnairesByTags 按标签列出问卷，matchAll 为 true 时要求包含全部标签，否则包含任一标签即可
+	ListQuestionnairesByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*dto.QuestionnaireDTO, int64, error)
+	// GetQuestions 获取问卷的问题列表，withAutoCodes 为 true 时为缺少编码的问题生成编码并保存
+	GetQuestions(ctx context.Context, code string, withAutoCodes bool) ([]dto.QuestionDTO, error)
+	// ListQuestionTypes 列出所有已注册题型及其支持的配置能力
+	ListQuestionTypes(ctx context.Context) []questiontype.Info
 }
 
 // QuestionnaireEditor 问卷编辑接口
 type QuestionnaireEditor interface {
-	// EditBasicInfo 编辑问卷基本信息
+	// EditBasicInfo 编辑问卷基本信息，以 questionnaireDTO.Revision 作为乐观锁条件
 	EditBasicInfo(ctx context.Context, questionnaireDTO *dto.QuestionnaireDTO) (*dto.QuestionnaireDTO, error)
-	// UpdateQuestions 更新问卷问题
-	UpdateQuestions(ctx context.Context, code string, questions []dto.QuestionDTO) (*dto.QuestionnaireDTO, error)
+	// UpdateQuestions 更新问卷问题，revision 为客户端加载问卷时的修订号，不一致时返回 ErrQuestionnaireVersionConflict
+	UpdateQuestions(ctx context.Context, code string, revision int64, questions []dto.QuestionDTO) (*dto.QuestionnaireDTO, error)
+	// AmendBasicInfo 为已发布的问卷创建修订版本并更新其基本信息
+	AmendBasicInfo(ctx context.Context, questionnaireDTO *dto.QuestionnaireDTO) (*dto.QuestionnaireDTO, error)
+	// CreateAmendedVersion 克隆已发布的问卷为下一版本的草稿并应用修订，修订返回错误时不保存，原版本保持不变
+	CreateAmendedVersion(ctx context.Context, code string, amendments func(*questionnaire.Questionnaire) error) (*questionnaire.Questionnaire, error)
 }
 
 // QuestionnairePublisher 问卷发布接口
 type QuestionnairePublisher interface {
-	// Publish 发布问卷
-	Publish(ctx context.Context, code string) (*dto.QuestionnaireDTO, error)
-	// Unpublish 取消发布问卷
-	Unpublish(ctx context.Context, code string) (*dto.QuestionnaireDTO, error)
+	// Publish 发布问卷，revision 为客户端加载问卷时的修订号，不一致时返回 ErrQuestionnaireVersionConflict
+	Publish(ctx context.Context, code string, revision int64) (*dto.QuestionnaireDTO, error)
+	// Unpublish 取消发布问卷，revision 为客户端加载问卷时的修订号，不一致时返回 ErrQuestionnaireVersionConflict
+	Unpublish(ctx context.Context, code string, revision int64) (*dto.QuestionnaireDTO, error)
+}
+
+// QuestionnaireDeleter 问卷删除接口
+type QuestionnaireDeleter interface {
+	// DeleteQuestionnaire 软删除问卷，问卷存在答卷或未完成的分配时返回 ErrQuestionnaireInUse，force 为 true 时仍然删除
+	DeleteQuestionnaire(ctx context.Context, code string, force bool) error
+}
+
+// PublicationPublisher 问卷版本发布事件发布器
+// 实现不应阻塞调用方，发布失败不影响问卷发布
+type PublicationPublisher interface {
+	// PublishPublished 发布问卷版本发布事件
+	PublishPublished(event questionnaire.QuestionnairePublished)
+}
+
+// QuestionnairePreviewer 问卷预览接口，通过签名令牌向无账号的评审者分享问卷
+type QuestionnairePreviewer interface {
+	// CreatePreviewToken 为问卷签发预览令牌
+	CreatePreviewToken(ctx context.Context, questionnaireCode string, expiresIn time.Duration) (string, error)
+	// VerifyPreviewToken 校验预览令牌，返回令牌对应的问卷编码
+	VerifyPreviewToken(token string) (string, error)
+	// GetPreviewQuestionnaire 根据预览令牌获取问卷（含草稿问题）
+	GetPreviewQuestionnaire(ctx context.Context, token string) (*dto.QuestionnaireDTO, error)
+}
+
+// QuestionnaireTranslator 问卷翻译接口，译文需审核通过后才会出现在编译后的问卷中
+type QuestionnaireTranslator interface {
+	// SubmitTranslation 提交译文，提交后为草稿状态，已审核的译文重新提交后需再次审核
+	SubmitTranslation(ctx context.Context, code string, t questionnaire.Translation) error
+	// ApproveTranslation 审核通过译文
+	ApproveTranslation(ctx context.Context, code, language, fieldPath string, approverID uint64) error
+	// CompileTranslations 将指定语言已审核通过的译文覆盖到问卷上，返回翻译后的问卷（不保存）
+	CompileTranslations(ctx context.Context, code, language string) (*questionnaire.Questionnaire, error)
+}
+
+// QuestionnaireThumbnailer 问卷缩略图接口，用于管理后台展示问卷打印版式的预览图
+type QuestionnaireThumbnailer interface {
+	// GenerateThumbnail 生成问卷当前版本打印版式首页的 JPEG 缩略图
+	GenerateThumbnail(ctx context.Context, questionnaireCode string) ([]byte, error)
 }
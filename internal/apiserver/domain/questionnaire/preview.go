@@ -0,0 +1,10 @@
+package questionnaire
+
+import "time"
+
+const (
+	// DefaultPreviewTokenTTL 未指定有效期时问卷预览令牌的有效期
+	DefaultPreviewTokenTTL = 7 * 24 * time.Hour
+	// MaxPreviewTokenTTL 问卷预览令牌的最长有效期
+	MaxPreviewTokenTTL = 30 * 24 * time.Hour
+)
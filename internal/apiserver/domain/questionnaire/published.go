@@ -0,0 +1,11 @@
+package questionnaire
+
+import "time"
+
+// QuestionnairePublished 问卷版本发布事件，在问卷发布成功并保存后发布
+// 引用问卷最新版本的缓存和分配可据此刷新
+type QuestionnairePublished struct {
+	Code        string
+	Version     string
+	PublishedAt time.Time
+}
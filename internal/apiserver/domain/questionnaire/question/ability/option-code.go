@@ -0,0 +1,58 @@
+package ability
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// AnswerValidator 答案校验器，由需要校验答案内容的题型实现
+type AnswerValidator interface {
+	ValidateAnswer(ans answer.Answer) error
+}
+
+// OptionCodeValidationRule 选项编码校验规则，答案引用的选项必须是题目声明的选项
+// 由题型工厂根据题目选项创建，不随问卷持久化
+type OptionCodeValidationRule struct {
+	codes map[string]struct{}
+}
+
+// NewOptionCodeValidationRule 根据题目选项创建选项编码校验规则
+func NewOptionCodeValidationRule(options []question.Option) *OptionCodeValidationRule {
+	codes := make(map[string]struct{}, len(options))
+	for i := range options {
+		codes[options[i].GetCode()] = struct{}{}
+	}
+	return &OptionCodeValidationRule{codes: codes}
+}
+
+// Validate 校验答案中的选项编码，单选校验所选选项，多选逐个校验所选选项
+func (r *OptionCodeValidationRule) Validate(ans answer.Answer) error {
+	if ans.GetValue() == nil {
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "问题 %s 的答案不是有效的选项", ans.GetQuestionCode())
+	}
+
+	switch raw := ans.GetValue().Raw().(type) {
+	case string:
+		return r.validateCode(ans.GetQuestionCode(), raw)
+	case []values.OptionValue:
+		for _, opt := range raw {
+			if err := r.validateCode(ans.GetQuestionCode(), opt.Code); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "问题 %s 的答案不是有效的选项", ans.GetQuestionCode())
+	}
+}
+
+// validateCode 校验单个选项编码是否在题目选项中
+func (r *OptionCodeValidationRule) validateCode(questionCode, code string) error {
+	if _, ok := r.codes[code]; !ok {
+		return errors.WithCode(errCode.ErrAnswerSheetInvalid, "问题 %s 不存在选项 %s", questionCode, code)
+	}
+	return nil
+}
@@ -1,10 +1,16 @@
 package ability
 
-import "github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+)
 
 // ValidationAbility 校验能力
 type ValidationAbility struct {
-	validationRules []validation.ValidationRule
+	validationRules     []validation.ValidationRule
+	conditionalRequired []question.ConditionalRequired
+	optionCodeRule      *OptionCodeValidationRule
 }
 
 // GetValidationRules 获取校验规则
@@ -34,3 +40,26 @@ func (v *ValidationAbility) ClearValidationRules() {
 func (v *ValidationAbility) SetValidationRules(rules []validation.ValidationRule) {
 	v.validationRules = rules
 }
+
+// GetConditionalRequired 获取条件必填规则
+func (v *ValidationAbility) GetConditionalRequired() []question.ConditionalRequired {
+	return v.conditionalRequired
+}
+
+// SetConditionalRequired 设置条件必填规则
+func (v *ValidationAbility) SetConditionalRequired(deps []question.ConditionalRequired) {
+	v.conditionalRequired = deps
+}
+
+// SetOptionCodeValidationRule 设置选项编码校验规则
+func (v *ValidationAbility) SetOptionCodeValidationRule(rule *OptionCodeValidationRule) {
+	v.optionCodeRule = rule
+}
+
+// ValidateAnswer 校验答案内容，未设置选项编码校验规则时不做校验
+func (v *ValidationAbility) ValidateAnswer(ans answer.Answer) error {
+	if v.optionCodeRule == nil {
+		return nil
+	}
+	return v.optionCodeRule.Validate(ans)
+}
@@ -1,10 +1,17 @@
 package question
 
 import (
+	uuid "github.com/satori/go.uuid"
+
 	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
+// autoCodePrefix 自动生成的问题编码前缀
+const autoCodePrefix = "q-"
+
 // BuilderOption 构建器选项函数类型
 type BuilderOption func(*QuestionBuilder)
 
@@ -21,19 +28,56 @@ type QuestionBuilder struct {
 	placeholder string
 	options     []Option
 
+	// 文件上传属性
+	allowedMIMETypes []string
+	maxFileSizeBytes int64
+	maxFiles         int
+
+	// 段落属性
+	pageBreakBefore bool
+
 	// 能力配置
-	validationRules []validation.ValidationRule
-	calculationRule *calculation.CalculationRule
+	validationRules     []validation.ValidationRule
+	conditionalRequired []ConditionalRequired
+	calculationRule     *calculation.CalculationRule
+
+	// 未设置编码时是否自动生成
+	autoGenerateCode bool
 }
 
 // NewQuestionBuilder 创建新的问题构建器
 func NewQuestionBuilder() *QuestionBuilder {
 	return &QuestionBuilder{
-		options:         make([]Option, 0),
-		validationRules: make([]validation.ValidationRule, 0),
+		options:          make([]Option, 0),
+		validationRules:  make([]validation.ValidationRule, 0),
+		autoGenerateCode: true,
 	}
 }
 
+// NewQuestionBuilderFrom 创建包含已有题目全部配置的构建器，用于在不修改原题的前提下派生新题目
+func NewQuestionBuilderFrom(q Question) *QuestionBuilder {
+	return &QuestionBuilder{
+		code:                q.GetCode(),
+		title:               q.GetTitle(),
+		tips:                q.GetTips(),
+		questionType:        q.GetType(),
+		placeholder:         q.GetPlaceholder(),
+		options:             append([]Option(nil), q.GetOptions()...),
+		allowedMIMETypes:    q.GetAllowedMIMETypes(),
+		maxFileSizeBytes:    q.GetMaxFileSizeBytes(),
+		maxFiles:            q.GetMaxFiles(),
+		pageBreakBefore:     q.GetPageBreakBefore(),
+		validationRules:     q.GetValidationRules(),
+		conditionalRequired: q.GetConditionalRequired(),
+		calculationRule:     q.GetCalculationRule(),
+	}
+}
+
+// GenerateQuestionCode 生成问题编码，格式为 q- 加 8 位十六进制随机串
+func GenerateQuestionCode() QuestionCode {
+	return NewQuestionCode(autoCodePrefix + uuid.NewV4().String()[:8])
+}
+
 // ================================
 // With函数式选项模式
 // ================================
@@ -45,6 +89,13 @@ func WithCode(code QuestionCode) BuilderOption {
 	}
 }
 
+// WithAutoGenerateCode 设置未提供编码时是否自动生成，默认开启
+func WithAutoGenerateCode(enabled bool) BuilderOption {
+	return func(b *QuestionBuilder) {
+		b.autoGenerateCode = enabled
+	}
+}
+
 // WithTitle 设置问题标题
 func WithTitle(title string) BuilderOption {
 	return func(b *QuestionBuilder) {
@@ -88,6 +139,22 @@ func WithOption(code, content string, score int) BuilderOption {
 	}
 }
 
+// WithFileConstraints 设置文件上传约束
+func WithFileConstraints(allowedMIMETypes []string, maxFileSizeBytes int64, maxFiles int) BuilderOption {
+	return func(b *QuestionBuilder) {
+		b.allowedMIMETypes = allowedMIMETypes
+		b.maxFileSizeBytes = maxFileSizeBytes
+		b.maxFiles = maxFiles
+	}
+}
+
+// WithPageBreakBefore 设置段落前是否分页，仅对段落题有效
+func WithPageBreakBefore(pageBreakBefore bool) BuilderOption {
+	return func(b *QuestionBuilder) {
+		b.pageBreakBefore = pageBreakBefore
+	}
+}
+
 // WithValidationRules 设置校验规则列表
 func WithValidationRules(rules []validation.ValidationRule) BuilderOption {
 	return func(b *QuestionBuilder) {
@@ -103,10 +170,24 @@ func WithValidationRule(ruleType validation.RuleType, targetValue string) Builde
 	}
 }
 
-// WithCalculationRule 设置计算规则
-func WithCalculationRule(formula calculation.FormulaType) BuilderOption {
+// WithConditionalRequired 添加条件必填规则
+func WithConditionalRequired(deps ...ConditionalRequired) BuilderOption {
+	return func(b *QuestionBuilder) {
+		b.conditionalRequired = append(b.conditionalRequired, deps...)
+	}
+}
+
+// WithCalculationRule 设置计算规则，sourceCodes 为参与计算的选项编码
+func WithCalculationRule(formula calculation.FormulaType, sourceCodes ...string) BuilderOption {
 	return func(b *QuestionBuilder) {
-		b.calculationRule = calculation.NewCalculationRule(formula, []string{})
+		b.calculationRule = calculation.NewCalculationRule(formula, normalizeSourceCodes(sourceCodes))
+	}
+}
+
+// WithConditionalCalculationRule 设置条件计分规则，按引用题目的答案选择选项分值映射
+func WithConditionalCalculationRule(dependsOnCode QuestionCode, mappings ...calculation.ScoreMapping) BuilderOption {
+	return func(b *QuestionBuilder) {
+		b.calculationRule = calculation.NewConditionalCalculationRule(dependsOnCode.Value(), mappings)
 	}
 }
 
@@ -148,6 +229,11 @@ func (b *QuestionBuilder) SetCode(code QuestionCode) *QuestionBuilder {
 	return b
 }
 
+func (b *QuestionBuilder) SetAutoGenerateCode(enabled bool) *QuestionBuilder {
+	b.autoGenerateCode = enabled
+	return b
+}
+
 func (b *QuestionBuilder) SetTitle(title string) *QuestionBuilder {
 	b.title = title
 	return b
@@ -174,17 +260,46 @@ func (b *QuestionBuilder) AddOption(code, content string, score int) *QuestionBu
 	return b
 }
 
-func (b *QuestionBuilder) AddValidationRule(ruleType validation.RuleType, targetValue string) *QuestionBuilder {
-	rule := validation.NewValidationRule(ruleType, targetValue)
+func (b *QuestionBuilder) SetFileConstraints(allowedMIMETypes []string, maxFileSizeBytes int64, maxFiles int) *QuestionBuilder {
+	b.allowedMIMETypes = allowedMIMETypes
+	b.maxFileSizeBytes = maxFileSizeBytes
+	b.maxFiles = maxFiles
+	return b
+}
+
+func (b *QuestionBuilder) SetPageBreakBefore(pageBreakBefore bool) *QuestionBuilder {
+	b.pageBreakBefore = pageBreakBefore
+	return b
+}
+
+func (b *QuestionBuilder) AddValidationRule(rule validation.ValidationRule) *QuestionBuilder {
 	b.validationRules = append(b.validationRules, rule)
 	return b
 }
 
-func (b *QuestionBuilder) SetCalculationRule(formula calculation.FormulaType) *QuestionBuilder {
-	b.calculationRule = calculation.NewCalculationRule(formula, []string{})
+func (b *QuestionBuilder) AddConditionalRequired(dependsOnCode QuestionCode, requiredWhenValue interface{}) *QuestionBuilder {
+	b.conditionalRequired = append(b.conditionalRequired, NewConditionalRequired(dependsOnCode, requiredWhenValue))
+	return b
+}
+
+func (b *QuestionBuilder) SetCalculationRule(formula calculation.FormulaType, sourceCodes ...string) *QuestionBuilder {
+	b.calculationRule = calculation.NewCalculationRule(formula, normalizeSourceCodes(sourceCodes))
+	return b
+}
+
+func (b *QuestionBuilder) SetConditionalCalculationRule(dependsOnCode QuestionCode, mappings ...calculation.ScoreMapping) *QuestionBuilder {
+	b.calculationRule = calculation.NewConditionalCalculationRule(dependsOnCode.Value(), mappings)
 	return b
 }
 
+// normalizeSourceCodes 保证源编码列表不为 nil
+func normalizeSourceCodes(sourceCodes []string) []string {
+	if sourceCodes == nil {
+		return []string{}
+	}
+	return sourceCodes
+}
+
 // ================================
 // 配置信息访问方法（只读）
 // ================================
@@ -193,6 +308,10 @@ func (b *QuestionBuilder) GetCode() QuestionCode {
 	return b.code
 }
 
+func (b *QuestionBuilder) GetAutoGenerateCode() bool {
+	return b.autoGenerateCode
+}
+
 func (b *QuestionBuilder) GetTitle() string {
 	return b.title
 }
@@ -213,10 +332,30 @@ func (b *QuestionBuilder) GetOptions() []Option {
 	return b.options
 }
 
+func (b *QuestionBuilder) GetAllowedMIMETypes() []string {
+	return b.allowedMIMETypes
+}
+
+func (b *QuestionBuilder) GetMaxFileSizeBytes() int64 {
+	return b.maxFileSizeBytes
+}
+
+func (b *QuestionBuilder) GetMaxFiles() int {
+	return b.maxFiles
+}
+
+func (b *QuestionBuilder) GetPageBreakBefore() bool {
+	return b.pageBreakBefore
+}
+
 func (b *QuestionBuilder) GetValidationRules() []validation.ValidationRule {
 	return b.validationRules
 }
 
+func (b *QuestionBuilder) GetConditionalRequired() []ConditionalRequired {
+	return b.conditionalRequired
+}
+
 func (b *QuestionBuilder) GetCalculationRule() *calculation.CalculationRule {
 	return b.calculationRule
 }
@@ -227,14 +366,14 @@ func (b *QuestionBuilder) GetCalculationRule() *calculation.CalculationRule {
 
 // IsValid 验证配置是否有效
 func (b *QuestionBuilder) IsValid() bool {
-	return b.code.Value() != "" && b.title != "" && b.questionType != ""
+	return (b.code.Value() != "" || b.autoGenerateCode) && b.title != "" && b.questionType != ""
 }
 
 // GetValidationErrors 获取配置验证错误
 func (b *QuestionBuilder) GetValidationErrors() []string {
 	var errors []string
 
-	if b.code.Value() == "" {
+	if b.code.Value() == "" && !b.autoGenerateCode {
 		errors = append(errors, "问题编码不能为空")
 	}
 	if b.title == "" {
@@ -247,6 +386,121 @@ func (b *QuestionBuilder) GetValidationErrors() []string {
 	return errors
 }
 
+// Validate 校验配置，包括文件上传约束以及计算规则引用的选项编码是否均已定义
+func (b *QuestionBuilder) Validate() error {
+	b.ensureCode()
+	if errs := b.GetValidationErrors(); len(errs) > 0 {
+		return errors.WithCode(code.ErrQuestionnaireQuestionBasicInfoInvalid, "%s", errs[0])
+	}
+
+	if err := b.validateFileConstraints(); err != nil {
+		return err
+	}
+
+	if err := b.validateConditionalRequired(); err != nil {
+		return err
+	}
+
+	if err := b.validateConditionalCalculation(); err != nil {
+		return err
+	}
+
+	return b.validateCalculationSourceCodes()
+}
+
+// ensureCode 未设置编码且开启自动生成时生成编码
+func (b *QuestionBuilder) ensureCode() {
+	if b.code.Value() == "" && b.autoGenerateCode {
+		b.code = GenerateQuestionCode()
+	}
+}
+
+// validateConditionalCalculation 校验条件计分规则，引用题目不能为空且不能是本题，至少包含一个映射
+func (b *QuestionBuilder) validateConditionalCalculation() error {
+	if b.calculationRule == nil || !b.calculationRule.IsConditional() {
+		return nil
+	}
+
+	dependsOnCode := NewQuestionCode(b.calculationRule.GetDependsOnCode())
+	if dependsOnCode.Value() == "" {
+		return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+			"问题 %s 的条件计分规则缺少引用题目", b.code.Value())
+	}
+	if dependsOnCode.Equals(b.code) {
+		return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+			"问题 %s 的条件计分规则不能引用自身", b.code.Value())
+	}
+	if len(b.calculationRule.GetScoreMappings()) == 0 {
+		return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+			"问题 %s 的条件计分规则缺少分值映射", b.code.Value())
+	}
+	return nil
+}
+
+// validateConditionalRequired 校验条件必填规则，依赖题目不能为空且不能是本题
+func (b *QuestionBuilder) validateConditionalRequired() error {
+	for _, dep := range b.conditionalRequired {
+		if dep.DependsOnCode.Value() == "" {
+			return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+				"问题 %s 的条件必填规则缺少依赖题目", b.code.Value())
+		}
+		if dep.DependsOnCode.Equals(b.code) {
+			return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+				"问题 %s 的条件必填规则不能依赖自身", b.code.Value())
+		}
+	}
+	return nil
+}
+
+// validateFileConstraints 校验文件上传题的约束配置
+func (b *QuestionBuilder) validateFileConstraints() error {
+	if b.questionType != QuestionTypeFileUpload {
+		return nil
+	}
+
+	if b.maxFileSizeBytes < 0 {
+		return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+			"问题 %s 的文件大小上限不能为负数", b.code.Value())
+	}
+	if b.maxFiles < 0 {
+		return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+			"问题 %s 的文件数量上限不能为负数", b.code.Value())
+	}
+
+	return nil
+}
+
+// validateCalculationSourceCodes 校验计算规则引用的选项编码
+// 选择类题型的计算规则及条件计分映射只能引用本题已定义的选项，否则会产生静默的 0 分
+func (b *QuestionBuilder) validateCalculationSourceCodes() error {
+	if b.calculationRule == nil || !b.questionType.HasOptions() {
+		return nil
+	}
+
+	optionCodes := make(map[string]struct{}, len(b.options))
+	for _, opt := range b.options {
+		optionCodes[opt.GetCode()] = struct{}{}
+	}
+
+	for _, sourceCode := range b.calculationRule.GetSourceCodes() {
+		if _, ok := optionCodes[sourceCode]; !ok {
+			return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+				"问题 %s 的计算规则引用了不存在的选项: %s", b.code.Value(), sourceCode)
+		}
+	}
+
+	for _, mapping := range b.calculationRule.GetScoreMappings() {
+		for optionCode := range mapping.Scores {
+			if _, ok := optionCodes[optionCode]; !ok {
+				return errors.WithCode(code.ErrQuestionnaireQuestionInvalid,
+					"问题 %s 的条件计分规则引用了不存在的选项: %s", b.code.Value(), optionCode)
+			}
+		}
+	}
+
+	return nil
+}
+
 // ================================
 // 便捷构建函数（仅创建Builder）
 // ================================
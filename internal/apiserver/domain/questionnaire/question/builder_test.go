@@ -0,0 +1,125 @@
+package question
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestQuestionBuilder_Validate_CalculationSourceCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []BuilderOption
+		wantErr bool
+	}{
+		{
+			name: "source codes reference defined options",
+			opts: []BuilderOption{
+				WithCode(NewQuestionCode("Q1")),
+				WithTitle("睡眠质量"),
+				WithQuestionType(QuestionTypeRadio),
+				WithOption("A", "好", 0),
+				WithOption("B", "一般", 1),
+				WithOption("C", "差", 2),
+				WithCalculationRule(calculation.FormulaTypeScore, "A", "B", "C"),
+			},
+			wantErr: false,
+		},
+		{
+			name: "source code references missing option",
+			opts: []BuilderOption{
+				WithCode(NewQuestionCode("Q2")),
+				WithTitle("食欲"),
+				WithQuestionType(QuestionTypeRadio),
+				WithOption("A", "正常", 0),
+				WithOption("B", "减退", 1),
+				WithCalculationRule(calculation.FormulaTypeScore, "A", "D"),
+			},
+			wantErr: true,
+		},
+		{
+			name: "calculation rule without source codes",
+			opts: []BuilderOption{
+				WithCode(NewQuestionCode("Q3")),
+				WithTitle("情绪"),
+				WithQuestionType(QuestionTypeCheckbox),
+				WithOption("A", "平静", 0),
+				WithCalculationRule(calculation.FormulaTypeSum),
+			},
+			wantErr: false,
+		},
+		{
+			name: "conditional mapping references missing option",
+			opts: []BuilderOption{
+				WithCode(NewQuestionCode("Q4")),
+				WithTitle("严重程度"),
+				WithQuestionType(QuestionTypeRadio),
+				WithOption("A", "轻", 1),
+				WithConditionalCalculationRule(NewQuestionCode("Q1"),
+					calculation.NewScoreMapping("C", map[string]float64{"A": 2, "D": 3})),
+			},
+			wantErr: true,
+		},
+		{
+			name: "conditional rule depends on itself",
+			opts: []BuilderOption{
+				WithCode(NewQuestionCode("Q5")),
+				WithTitle("严重程度"),
+				WithQuestionType(QuestionTypeRadio),
+				WithOption("A", "轻", 1),
+				WithConditionalCalculationRule(NewQuestionCode("Q5"),
+					calculation.NewScoreMapping("A", map[string]float64{"A": 2})),
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := BuildQuestionConfig(tt.opts...).Validate()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.IsCode(err, code.ErrQuestionnaireQuestionInvalid) {
+				t.Errorf("Validate() error code mismatch, got %v", err)
+			}
+		})
+	}
+}
+
+func TestQuestionBuilder_AutoGenerateCode(t *testing.T) {
+	builder := BuildQuestionConfig(WithTitle("睡眠质量"), WithQuestionType(QuestionTypeText))
+	if !builder.GetAutoGenerateCode() {
+		t.Fatal("GetAutoGenerateCode() = false, want true by default")
+	}
+	if err := builder.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if got := builder.GetCode().Value(); !regexp.MustCompile(`^q-[0-9a-f]{8}$`).MatchString(got) {
+		t.Errorf("GetCode() = %q, want q- followed by 8 hex characters", got)
+	}
+
+	// 相同题型和标题的问题生成不同的编码
+	again := BuildQuestionConfig(WithTitle("睡眠质量"), WithQuestionType(QuestionTypeText))
+	if err := again.Validate(); err != nil || again.GetCode() == builder.GetCode() {
+		t.Errorf("second Validate() = %v, code %q, want nil and a code different from %q", err, again.GetCode().Value(), builder.GetCode().Value())
+	}
+
+	// 已设置的编码保持不变
+	builder = BuildQuestionConfig(WithCode(NewQuestionCode("Q1")), WithTitle("睡眠质量"), WithQuestionType(QuestionTypeText))
+	if err := builder.Validate(); err != nil || builder.GetCode().Value() != "Q1" {
+		t.Errorf("Validate() = %v, code %q, want nil, Q1", err, builder.GetCode().Value())
+	}
+
+	// 关闭自动生成时缺少编码校验失败
+	builder = BuildQuestionConfig(WithAutoGenerateCode(false), WithTitle("睡眠质量"), WithQuestionType(QuestionTypeText))
+	if err := builder.Validate(); !errors.IsCode(err, code.ErrQuestionnaireQuestionBasicInfoInvalid) {
+		t.Errorf("Validate() error = %v, want ErrQuestionnaireQuestionBasicInfoInvalid", err)
+	}
+	if builder.GetCode().Value() != "" {
+		t.Errorf("GetCode() = %q, want empty", builder.GetCode().Value())
+	}
+}
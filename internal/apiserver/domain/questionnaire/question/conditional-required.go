@@ -0,0 +1,16 @@
+package question
+
+// ConditionalRequired 条件必填规则
+// 当 DependsOnCode 对应题目的答案等于 RequiredWhenValue 时，本题变为必填
+type ConditionalRequired struct {
+	DependsOnCode     QuestionCode
+	RequiredWhenValue interface{}
+}
+
+// NewConditionalRequired 创建条件必填规则
+func NewConditionalRequired(dependsOnCode QuestionCode, requiredWhenValue interface{}) ConditionalRequired {
+	return ConditionalRequired{
+		DependsOnCode:     dependsOnCode,
+		RequiredWhenValue: requiredWhenValue,
+	}
+}
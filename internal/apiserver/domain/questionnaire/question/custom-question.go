@@ -0,0 +1,86 @@
+package question
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
+)
+
+// customQuestion 通过 pkg/questiontype 注册的自定义题型题目
+type customQuestion struct {
+	code         QuestionCode
+	title        string
+	tips         string
+	questionType QuestionType
+
+	placeholder string
+	options     []Option
+
+	allowedMIMETypes []string
+	maxFileSizeBytes int64
+	maxFiles         int
+
+	pageBreakBefore bool
+
+	validationRules     []validation.ValidationRule
+	conditionalRequired []ConditionalRequired
+	calculationRule     *calculation.CalculationRule
+}
+
+// newCustomQuestion 根据构建器配置创建自定义题型题目，题型未声明支持的配置被忽略
+func newCustomQuestion(builder *QuestionBuilder, capabilities questiontype.Capabilities) *customQuestion {
+	q := &customQuestion{
+		code:            builder.GetCode(),
+		title:           builder.GetTitle(),
+		tips:            builder.GetTips(),
+		questionType:    builder.GetQuestionType(),
+		pageBreakBefore: builder.GetPageBreakBefore(),
+	}
+	if capabilities.Placeholder {
+		q.placeholder = builder.GetPlaceholder()
+	}
+	if capabilities.Options {
+		q.options = builder.GetOptions()
+	}
+	if capabilities.FileUpload {
+		q.allowedMIMETypes = builder.GetAllowedMIMETypes()
+		q.maxFileSizeBytes = builder.GetMaxFileSizeBytes()
+		q.maxFiles = builder.GetMaxFiles()
+	}
+	if capabilities.Validation {
+		q.validationRules = builder.GetValidationRules()
+		q.conditionalRequired = builder.GetConditionalRequired()
+	}
+	if capabilities.Scoring {
+		q.calculationRule = builder.GetCalculationRule()
+	}
+	return q
+}
+
+func (q *customQuestion) GetCode() QuestionCode { return q.code }
+
+func (q *customQuestion) GetTitle() string { return q.title }
+
+func (q *customQuestion) GetType() QuestionType { return q.questionType }
+
+func (q *customQuestion) GetTips() string { return q.tips }
+
+func (q *customQuestion) GetPlaceholder() string { return q.placeholder }
+
+func (q *customQuestion) GetOptions() []Option { return q.options }
+
+func (q *customQuestion) GetAllowedMIMETypes() []string { return q.allowedMIMETypes }
+
+func (q *customQuestion) GetMaxFileSizeBytes() int64 { return q.maxFileSizeBytes }
+
+func (q *customQuestion) GetMaxFiles() int { return q.maxFiles }
+
+func (q *customQuestion) GetPageBreakBefore() bool { return q.pageBreakBefore }
+
+func (q *customQuestion) GetValidationRules() []validation.ValidationRule { return q.validationRules }
+
+func (q *customQuestion) GetConditionalRequired() []ConditionalRequired {
+	return q.conditionalRequired
+}
+
+func (q *customQuestion) GetCalculationRule() *calculation.CalculationRule { return q.calculationRule }
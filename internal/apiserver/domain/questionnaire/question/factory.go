@@ -1,29 +1,110 @@
 package question
 
 import (
+	"sync"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
 )
 
 // 注册函数签名
 type QuestionFactory func(builder *QuestionBuilder) Question
 
-// 注册表本体
-var registry = make(map[QuestionType]QuestionFactory)
+// builtinType 内置题型在公开题型注册表中的插件，声明内置题型的能力，并保证自定义题型不能与内置题型重名
+type builtinType struct {
+	typ          QuestionType
+	capabilities questiontype.Capabilities
+}
+
+func (t builtinType) Type() string { return string(t.typ) }
+
+func (t builtinType) Capabilities() questiontype.Capabilities { return t.capabilities }
+
+func (t builtinType) Validate(questiontype.Definition) error { return nil }
+
+// factories 内置题型到工厂函数的注册表
+var (
+	factoriesMu sync.RWMutex
+	factories   = make(map[QuestionType]QuestionFactory)
+)
+
+// RegisterQuestionFactory 注册内置题型的能力和工厂函数，注册失败时 panic
+// 供各题型在 init 中调用，重复注册属于编程错误；自定义题型通过 pkg/questiontype 注册
+func RegisterQuestionFactory(typ QuestionType, capabilities questiontype.Capabilities, factory QuestionFactory) {
+	if err := questiontype.RegisterQuestionType(builtinType{typ: typ, capabilities: capabilities}); err != nil {
+		panic(toRegisterError(err))
+	}
+
+	factoriesMu.Lock()
+	factories[typ] = factory
+	factoriesMu.Unlock()
+}
+
+// IsBuiltinQuestionType 判断题型是否为内置题型，通过 pkg/questiontype 注册的自定义题型返回 false
+func IsBuiltinQuestionType(typ QuestionType) bool {
+	factoriesMu.RLock()
+	defer factoriesMu.RUnlock()
 
-// 注册函数
-func RegisterQuestionFactory(typ QuestionType, factory QuestionFactory) {
-	if _, exists := registry[typ]; exists {
-		log.Errorf("question type already registered: %s", typ)
+	_, ok := factories[typ]
+	return ok
+}
+
+// toRegisterError 将公开注册表的错误转换为带错误码的错误
+func toRegisterError(err error) error {
+	if errors.Is(err, questiontype.ErrTypeAlreadyRegistered) {
+		return errors.WrapC(err, code.ErrQuestionTypeAlreadyRegistered, "题型注册失败")
 	}
-	registry[typ] = factory
+	return errors.WrapC(err, code.ErrQuestionnaireQuestionInvalid, "题型注册失败")
 }
 
-// 创建统一入口
+// CreateQuestionFromBuilder 创建题目的统一入口，未设置编码时按构建器配置自动生成
+// 内置题型由工厂创建，通过 pkg/questiontype 注册的自定义题型经插件校验后创建为通用题目，只保留插件声明支持的配置
 func CreateQuestionFromBuilder(builder *QuestionBuilder) Question {
-	factory, ok := registry[builder.GetQuestionType()]
+	builder.ensureCode()
+
+	factoriesMu.RLock()
+	factory, ok := factories[builder.GetQuestionType()]
+	factoriesMu.RUnlock()
+	if ok {
+		return factory(builder)
+	}
+
+	plugin, ok := questiontype.Lookup(builder.GetQuestionType().Value())
 	if !ok {
 		log.Errorf("unknown question type: %s", builder.GetQuestionType())
 		return nil
 	}
-	return factory(builder)
+	if err := plugin.Validate(toDefinition(builder)); err != nil {
+		log.Errorf("invalid %s question %s: %v", builder.GetQuestionType(), builder.GetCode(), err)
+		return nil
+	}
+	return newCustomQuestion(builder, plugin.Capabilities())
+}
+
+// toDefinition 将构建器配置转换为公开的题目配置
+func toDefinition(builder *QuestionBuilder) questiontype.Definition {
+	def := questiontype.Definition{
+		Code:        builder.GetCode().Value(),
+		Title:       builder.GetTitle(),
+		Type:        builder.GetQuestionType().Value(),
+		Tips:        builder.GetTips(),
+		Placeholder: builder.GetPlaceholder(),
+	}
+	for _, opt := range builder.GetOptions() {
+		def.Options = append(def.Options, questiontype.Option{
+			Code:    opt.GetCode(),
+			Content: opt.GetContent(),
+			Score:   opt.GetScore(),
+		})
+	}
+	for _, rule := range builder.GetValidationRules() {
+		def.ValidationRules = append(def.ValidationRules, questiontype.ValidationRule{
+			RuleType:    string(rule.GetRuleType()),
+			TargetValue: rule.GetTargetValue(),
+			Severity:    string(rule.GetSeverity()),
+		})
+	}
+	return def
 }
@@ -17,8 +17,15 @@ type Question interface {
 	GetPlaceholder() string
 	// 选项相关方法
 	GetOptions() []Option
+	// 文件上传相关方法
+	GetAllowedMIMETypes() []string
+	GetMaxFileSizeBytes() int64
+	GetMaxFiles() int
+	// 段落相关方法
+	GetPageBreakBefore() bool
 	// 校验相关方法
 	GetValidationRules() []validation.ValidationRule
+	GetConditionalRequired() []ConditionalRequired
 	// 计算相关方法
 	GetCalculationRule() *calculation.CalculationRule
 }
@@ -48,11 +55,17 @@ func (t QuestionType) Value() string {
 	return string(t)
 }
 
+// HasOptions 判断题型是否为带选项的选择类题型
+func (t QuestionType) HasOptions() bool {
+	return t == QuestionTypeRadio || t == QuestionTypeCheckbox
+}
+
 const (
-	QuestionTypeSection  QuestionType = "Section"  // 段落
-	QuestionTypeRadio    QuestionType = "Radio"    // 单选
-	QuestionTypeCheckbox QuestionType = "Checkbox" // 多选
-	QuestionTypeText     QuestionType = "Text"     // 文本
-	QuestionTypeTextarea QuestionType = "Textarea" // 文本域
-	QuestionTypeNumber   QuestionType = "Number"   // 数字
+	QuestionTypeSection    QuestionType = "Section"    // 段落
+	QuestionTypeRadio      QuestionType = "Radio"      // 单选
+	QuestionTypeCheckbox   QuestionType = "Checkbox"   // 多选
+	QuestionTypeText       QuestionType = "Text"       // 文本
+	QuestionTypeTextarea   QuestionType = "Textarea"   // 文本域
+	QuestionTypeNumber     QuestionType = "Number"     // 数字
+	QuestionTypeFileUpload QuestionType = "FileUpload" // 文件上传
 )
@@ -53,11 +53,36 @@ func (q *BaseQuestion) GetOptions() []question.Option {
 	return nil
 }
 
+// GetAllowedMIMETypes 获取允许上传的文件类型
+func (q *BaseQuestion) GetAllowedMIMETypes() []string {
+	return nil
+}
+
+// GetMaxFileSizeBytes 获取单个文件大小上限
+func (q *BaseQuestion) GetMaxFileSizeBytes() int64 {
+	return 0
+}
+
+// GetMaxFiles 获取文件数量上限
+func (q *BaseQuestion) GetMaxFiles() int {
+	return 0
+}
+
+// GetPageBreakBefore 获取是否在段落前分页
+func (q *BaseQuestion) GetPageBreakBefore() bool {
+	return false
+}
+
 // GetValidationRules 获取校验规则
 func (q *BaseQuestion) GetValidationRules() []validation.ValidationRule {
 	return nil
 }
 
+// GetConditionalRequired 获取条件必填规则
+func (q *BaseQuestion) GetConditionalRequired() []question.ConditionalRequired {
+	return nil
+}
+
 // GetCalculationRule 获取计算规则
 func (q *BaseQuestion) GetCalculationRule() *calculation.CalculationRule {
 	return nil
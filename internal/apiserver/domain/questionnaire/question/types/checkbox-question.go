@@ -5,6 +5,7 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/ability"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
 )
 
 // CheckboxQuestion 多选问题
@@ -18,18 +19,24 @@ type CheckboxQuestion struct {
 
 // 注册多选问题
 func init() {
-	question.RegisterQuestionFactory(question.QuestionTypeCheckbox, func(builder *question.QuestionBuilder) question.Question {
+	question.RegisterQuestionFactory(question.QuestionTypeCheckbox, questiontype.Capabilities{Options: true, Scoring: true, Validation: true}, func(builder *question.QuestionBuilder) question.Question {
 		// 创建多选问题
 		q := newCheckboxQuestion(builder.GetCode(), builder.GetTitle())
 
 		// 设置选项
 		q.setOptions(builder.GetOptions())
 
+		// 设置选项编码校验规则，答案只能引用题目声明的选项
+		q.SetOptionCodeValidationRule(ability.NewOptionCodeValidationRule(q.GetOptions()))
+
 		// 设置校验规则
 		for _, rule := range builder.GetValidationRules() {
 			q.addValidationRule(rule)
 		}
 
+		// 设置条件必填规则
+		q.SetConditionalRequired(builder.GetConditionalRequired())
+
 		// 设置计算规则
 		if builder.GetCalculationRule() != nil {
 			q.setCalculationRule(builder.GetCalculationRule())
@@ -71,6 +78,11 @@ func (q *CheckboxQuestion) GetValidationRules() []validation.ValidationRule {
 	return q.ValidationAbility.GetValidationRules()
 }
 
+// GetConditionalRequired 获取条件必填规则 - 重写BaseQuestion的默认实现
+func (q *CheckboxQuestion) GetConditionalRequired() []question.ConditionalRequired {
+	return q.ValidationAbility.GetConditionalRequired()
+}
+
 // GetCalculationRule 获取计算规则 - 重写BaseQuestion的默认实现
 func (q *CheckboxQuestion) GetCalculationRule() *calculation.CalculationRule {
 	return q.CalculationAbility.GetCalculationRule()
@@ -0,0 +1,83 @@
+package types
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/ability"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
+)
+
+// 注册文件上传问题
+func init() {
+	question.RegisterQuestionFactory(question.QuestionTypeFileUpload, questiontype.Capabilities{Validation: true, FileUpload: true}, func(builder *question.QuestionBuilder) question.Question {
+		// 创建文件上传问题
+		q := newFileUploadQuestion(builder.GetCode(), builder.GetTitle())
+
+		// 设置文件约束
+		q.setFileConstraints(builder.GetAllowedMIMETypes(), builder.GetMaxFileSizeBytes(), builder.GetMaxFiles())
+
+		// 设置校验规则
+		for _, rule := range builder.GetValidationRules() {
+			q.addValidationRule(rule)
+		}
+
+		// 设置条件必填规则
+		q.SetConditionalRequired(builder.GetConditionalRequired())
+		return q
+	})
+}
+
+// FileUploadQuestion 文件上传问题
+// 用于需要附带照片、PDF 等文件的题目（如知情同意书）
+type FileUploadQuestion struct {
+	BaseQuestion
+	ability.ValidationAbility
+
+	allowedMIMETypes []string
+	maxFileSizeBytes int64
+	maxFiles         int
+}
+
+// newFileUploadQuestion 创建文件上传问题
+func newFileUploadQuestion(code question.QuestionCode, title string) *FileUploadQuestion {
+	return &FileUploadQuestion{
+		BaseQuestion: NewBaseQuestion(code, title, question.QuestionTypeFileUpload),
+	}
+}
+
+// setFileConstraints 设置文件约束
+func (q *FileUploadQuestion) setFileConstraints(allowedMIMETypes []string, maxFileSizeBytes int64, maxFiles int) {
+	q.allowedMIMETypes = allowedMIMETypes
+	q.maxFileSizeBytes = maxFileSizeBytes
+	q.maxFiles = maxFiles
+}
+
+// addValidationRule 添加校验规则
+func (q *FileUploadQuestion) addValidationRule(rule validation.ValidationRule) {
+	q.ValidationAbility.AddValidationRule(rule)
+}
+
+// GetAllowedMIMETypes 获取允许上传的文件类型，为空表示不限制
+func (q *FileUploadQuestion) GetAllowedMIMETypes() []string {
+	return q.allowedMIMETypes
+}
+
+// GetMaxFileSizeBytes 获取单个文件大小上限（字节），0 表示不限制
+func (q *FileUploadQuestion) GetMaxFileSizeBytes() int64 {
+	return q.maxFileSizeBytes
+}
+
+// GetMaxFiles 获取文件数量上限，0 表示不限制
+func (q *FileUploadQuestion) GetMaxFiles() int {
+	return q.maxFiles
+}
+
+// GetValidationRules 获取校验规则 - 重写BaseQuestion的默认实现
+func (q *FileUploadQuestion) GetValidationRules() []validation.ValidationRule {
+	return q.ValidationAbility.GetValidationRules()
+}
+
+// GetConditionalRequired 获取条件必填规则 - 重写BaseQuestion的默认实现
+func (q *FileUploadQuestion) GetConditionalRequired() []question.ConditionalRequired {
+	return q.ValidationAbility.GetConditionalRequired()
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/ability"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
 )
 
 // NumberQuestion 数字问题
@@ -16,7 +17,7 @@ type NumberQuestion struct {
 
 // 注册数字问题
 func init() {
-	question.RegisterQuestionFactory(question.QuestionTypeNumber, func(builder *question.QuestionBuilder) question.Question {
+	question.RegisterQuestionFactory(question.QuestionTypeNumber, questiontype.Capabilities{Validation: true, Placeholder: true}, func(builder *question.QuestionBuilder) question.Question {
 		// 创建数字问题
 		q := newNumberQuestion(builder.GetCode(), builder.GetTitle())
 
@@ -27,6 +28,9 @@ func init() {
 		for _, rule := range builder.GetValidationRules() {
 			q.addValidationRule(rule)
 		}
+
+		// 设置条件必填规则
+		q.SetConditionalRequired(builder.GetConditionalRequired())
 		return q
 	})
 }
@@ -57,3 +61,8 @@ func (q *NumberQuestion) GetPlaceholder() string {
 func (q *NumberQuestion) GetValidationRules() []validation.ValidationRule {
 	return q.ValidationAbility.GetValidationRules()
 }
+
+// GetConditionalRequired 获取条件必填规则 - 重写BaseQuestion的默认实现
+func (q *NumberQuestion) GetConditionalRequired() []question.ConditionalRequired {
+	return q.ValidationAbility.GetConditionalRequired()
+}
@@ -0,0 +1,56 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/ability"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestChoiceQuestion_ValidateAnswer_OptionCodes(t *testing.T) {
+	tests := []struct {
+		name    string
+		qType   question.QuestionType
+		value   any
+		wantErr bool
+	}{
+		{name: "radio declared option", qType: question.QuestionTypeRadio, value: "A", wantErr: false},
+		{name: "radio undeclared option", qType: question.QuestionTypeRadio, value: "D", wantErr: true},
+		{name: "checkbox declared options", qType: question.QuestionTypeCheckbox, value: []string{"A", "B"}, wantErr: false},
+		{name: "checkbox one undeclared option", qType: question.QuestionTypeCheckbox, value: []string{"A", "D"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+				question.WithCode(question.NewQuestionCode("Q1")),
+				question.WithTitle("睡眠质量"),
+				question.WithQuestionType(tt.qType),
+				question.WithOption("A", "好", 0),
+				question.WithOption("B", "一般", 1),
+				question.WithOption("C", "差", 2),
+			))
+			validator, ok := q.(ability.AnswerValidator)
+			if !ok {
+				t.Fatalf("%T does not implement ability.AnswerValidator", q)
+			}
+
+			ans, err := answer.NewAnswer(question.NewQuestionCode("Q1"), tt.qType, 0, tt.value)
+			if err != nil {
+				t.Fatalf("NewAnswer() error = %v", err)
+			}
+
+			err = validator.ValidateAnswer(ans)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ValidateAnswer() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.IsCode(err, code.ErrAnswerSheetInvalid) {
+				t.Errorf("ValidateAnswer() error code = %v, want ErrAnswerSheetInvalid", err)
+			}
+		})
+	}
+}
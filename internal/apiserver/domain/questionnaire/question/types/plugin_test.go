@@ -0,0 +1,101 @@
+package types
+
+import (
+	stderrors "errors"
+	"fmt"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
+)
+
+// questionTypeRating 测试用的自定义题型
+const questionTypeRating question.QuestionType = "Rating"
+
+// ratingPlugin 评分题插件，要求提供 1-5 分的选项
+type ratingPlugin struct{}
+
+func (ratingPlugin) Type() string { return questionTypeRating.Value() }
+
+func (ratingPlugin) Capabilities() questiontype.Capabilities {
+	return questiontype.Capabilities{Options: true, Scoring: true}
+}
+
+func (ratingPlugin) Validate(def questiontype.Definition) error {
+	if len(def.Options) != 5 {
+		return fmt.Errorf("rating question needs 5 options, got %d", len(def.Options))
+	}
+	return nil
+}
+
+func TestRegisterQuestionType_CustomType(t *testing.T) {
+	if err := questiontype.RegisterQuestionType(ratingPlugin{}); err != nil {
+		t.Fatalf("RegisterQuestionType() error = %v", err)
+	}
+
+	opts := []question.BuilderOption{
+		question.WithCode(question.NewQuestionCode("R1")),
+		question.WithTitle("整体满意度"),
+		question.WithTips("1-5 分"),
+		question.WithQuestionType(questionTypeRating),
+		question.WithPlaceholder("请选择"),
+	}
+	for i := 1; i <= 5; i++ {
+		opts = append(opts, question.WithOption(fmt.Sprint(i), fmt.Sprintf("%d 分", i), i))
+	}
+	q := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(opts...))
+	if q == nil {
+		t.Fatal("CreateQuestionFromBuilder() returned nil")
+	}
+	if q.GetCode().Value() != "R1" || q.GetType() != questionTypeRating || q.GetTips() != "1-5 分" || len(q.GetOptions()) != 5 {
+		t.Errorf("question = %s/%s/%q/%d options, want R1/Rating/\"1-5 分\"/5 options",
+			q.GetCode().Value(), q.GetType(), q.GetTips(), len(q.GetOptions()))
+	}
+	// 插件未声明支持占位符，配置被忽略
+	if q.GetPlaceholder() != "" {
+		t.Errorf("placeholder = %q, want empty for a type without placeholder capability", q.GetPlaceholder())
+	}
+
+	// 插件校验未通过时不创建题目
+	invalid := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("R2")),
+		question.WithTitle("整体满意度"),
+		question.WithQuestionType(questionTypeRating),
+	))
+	if invalid != nil {
+		t.Errorf("CreateQuestionFromBuilder() = %T, want nil for invalid rating question", invalid)
+	}
+
+	// 自定义题型和内置题型都不能重复注册
+	for _, plugin := range []questiontype.QuestionTypePlugin{ratingPlugin{}, namedPlugin(question.QuestionTypeRadio)} {
+		if err := questiontype.RegisterQuestionType(plugin); !stderrors.Is(err, questiontype.ErrTypeAlreadyRegistered) {
+			t.Errorf("RegisterQuestionType(%s) error = %v, want ErrTypeAlreadyRegistered", plugin.Type(), err)
+		}
+	}
+	if err := questiontype.RegisterQuestionType(namedPlugin("")); !stderrors.Is(err, questiontype.ErrTypeRequired) {
+		t.Errorf("RegisterQuestionType(\"\") error = %v, want ErrTypeRequired", err)
+	}
+}
+
+// namedPlugin 只声明题型的插件，用于校验注册冲突
+type namedPlugin question.QuestionType
+
+func (p namedPlugin) Type() string { return string(p) }
+
+func (p namedPlugin) Capabilities() questiontype.Capabilities { return questiontype.Capabilities{} }
+
+func (p namedPlugin) Validate(questiontype.Definition) error { return nil }
+
+func TestRegisterQuestionFactory_DuplicatePanics(t *testing.T) {
+	defer func() {
+		err, _ := recover().(error)
+		if !errors.IsCode(err, code.ErrQuestionTypeAlreadyRegistered) {
+			t.Errorf("RegisterQuestionFactory(Radio) panic = %v, want ErrQuestionTypeAlreadyRegistered", err)
+		}
+	}()
+
+	// 单选题已在 init 中注册，重复注册应在启动时暴露
+	question.RegisterQuestionFactory(question.QuestionTypeRadio, questiontype.Capabilities{}, func(*question.QuestionBuilder) question.Question { return nil })
+}
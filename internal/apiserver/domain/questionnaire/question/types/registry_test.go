@@ -0,0 +1,29 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
+)
+
+func TestQuestionTypeList_BuiltinCapabilities(t *testing.T) {
+	want := map[question.QuestionType]questiontype.Capabilities{
+		question.QuestionTypeSection:    {},
+		question.QuestionTypeRadio:      {Options: true, Scoring: true, Validation: true},
+		question.QuestionTypeCheckbox:   {Options: true, Scoring: true, Validation: true},
+		question.QuestionTypeText:       {Validation: true, Placeholder: true},
+		question.QuestionTypeNumber:     {Validation: true, Placeholder: true},
+		question.QuestionTypeFileUpload: {Validation: true, FileUpload: true},
+	}
+
+	got := make(map[question.QuestionType]questiontype.Capabilities)
+	for _, info := range questiontype.List() {
+		got[question.QuestionType(info.Type)] = info.Capabilities
+	}
+	for typ, capabilities := range want {
+		if c, ok := got[typ]; !ok || c != capabilities {
+			t.Errorf("List()[%s] = %+v, want %+v", typ, c, capabilities)
+		}
+	}
+}
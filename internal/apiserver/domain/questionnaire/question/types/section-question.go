@@ -2,23 +2,31 @@ package types
 
 import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
 )
 
 // SectionQuestion 段落问题
 type SectionQuestion struct {
 	BaseQuestion
+	pageBreakBefore bool
 }
 
 // 注册段落问题
 func init() {
-	question.RegisterQuestionFactory(question.QuestionTypeSection, func(builder *question.QuestionBuilder) question.Question {
-		return newSectionQuestion(builder.GetCode(), builder.GetTitle())
+	question.RegisterQuestionFactory(question.QuestionTypeSection, questiontype.Capabilities{}, func(builder *question.QuestionBuilder) question.Question {
+		return newSectionQuestion(builder.GetCode(), builder.GetTitle(), builder.GetPageBreakBefore())
 	})
 }
 
 // newSectionQuestion 创建段落问题
-func newSectionQuestion(code question.QuestionCode, title string) *SectionQuestion {
+func newSectionQuestion(code question.QuestionCode, title string, pageBreakBefore bool) *SectionQuestion {
 	return &SectionQuestion{
-		BaseQuestion: NewBaseQuestion(code, title, question.QuestionTypeSection),
+		BaseQuestion:    NewBaseQuestion(code, title, question.QuestionTypeSection),
+		pageBreakBefore: pageBreakBefore,
 	}
 }
+
+// GetPageBreakBefore 获取是否在段落前分页
+func (q *SectionQuestion) GetPageBreakBefore() bool {
+	return q.pageBreakBefore
+}
@@ -4,11 +4,12 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/ability"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
 )
 
 // 注册文本问题
 func init() {
-	question.RegisterQuestionFactory(question.QuestionTypeText, func(builder *question.QuestionBuilder) question.Question {
+	question.RegisterQuestionFactory(question.QuestionTypeText, questiontype.Capabilities{Validation: true, Placeholder: true}, func(builder *question.QuestionBuilder) question.Question {
 		// 创建文本问题
 		q := newTextQuestion(builder.GetCode(), builder.GetTitle())
 
@@ -19,6 +20,9 @@ func init() {
 		for _, rule := range builder.GetValidationRules() {
 			q.addValidationRule(rule)
 		}
+
+		// 设置条件必填规则
+		q.SetConditionalRequired(builder.GetConditionalRequired())
 		return q
 	})
 }
@@ -57,3 +61,8 @@ func (q *TextQuestion) GetPlaceholder() string {
 func (q *TextQuestion) GetValidationRules() []validation.ValidationRule {
 	return q.ValidationAbility.GetValidationRules()
 }
+
+// GetConditionalRequired 获取条件必填规则 - 重写BaseQuestion的默认实现
+func (q *TextQuestion) GetConditionalRequired() []question.ConditionalRequired {
+	return q.ValidationAbility.GetConditionalRequired()
+}
@@ -7,11 +7,56 @@ import (
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
+const (
+	// DefaultMaxQuestionsPerQuestionnaire 每份问卷默认最多包含的问题数（含段落）
+	DefaultMaxQuestionsPerQuestionnaire = 500
+	// DefaultMaxQuestionsPerSection 每个段落默认最多包含的问题数
+	DefaultMaxQuestionsPerSection = 100
+)
+
+// QuestionLimits 问题数量上限，用于拦截失控导入产生的超大问卷
+// 段落内的问题指段落题之后、下一个段落题之前的题目
+type QuestionLimits struct {
+	MaxQuestionsPerQuestionnaire int
+	MaxQuestionsPerSection       int
+}
+
+// DefaultQuestionLimits 默认问题数量上限
+func DefaultQuestionLimits() QuestionLimits {
+	return QuestionLimits{
+		MaxQuestionsPerQuestionnaire: DefaultMaxQuestionsPerQuestionnaire,
+		MaxQuestionsPerSection:       DefaultMaxQuestionsPerSection,
+	}
+}
+
 // QuestionService 问题服务
-type QuestionService struct{}
+// 零值使用默认问题数量上限
+type QuestionService struct {
+	limits QuestionLimits
+}
+
+// NewQuestionService 创建问题服务，未设置的上限使用默认值
+func NewQuestionService(limits QuestionLimits) QuestionService {
+	defaults := DefaultQuestionLimits()
+	if limits.MaxQuestionsPerQuestionnaire <= 0 {
+		limits.MaxQuestionsPerQuestionnaire = defaults.MaxQuestionsPerQuestionnaire
+	}
+	if limits.MaxQuestionsPerSection <= 0 {
+		limits.MaxQuestionsPerSection = defaults.MaxQuestionsPerSection
+	}
+	return QuestionService{limits: limits}
+}
+
+// Limits 返回问题数量上限
+func (s QuestionService) Limits() QuestionLimits {
+	if s.limits == (QuestionLimits{}) {
+		return DefaultQuestionLimits()
+	}
+	return s.limits
+}
 
 // AddQuestion 添加问题
-func (QuestionService) AddQuestion(q *Questionnaire, newQuestion question.Question) error {
+func (s QuestionService) AddQuestion(q *Questionnaire, newQuestion question.Question) error {
 	log.Infow("---- in QuestionService AddQuestion: ")
 
 	// 检查问题对象是否为 nil
@@ -28,11 +73,90 @@ func (QuestionService) AddQuestion(q *Questionnaire, newQuestion question.Questi
 			return errors.WithCode(code.ErrQuestionnaireQuestionAlreadyExists, "code 重复，不能添加")
 		}
 	}
+
+	limits := s.Limits()
+	if len(q.questions) >= limits.MaxQuestionsPerQuestionnaire {
+		return errors.WithCode(code.ErrMaxQuestionsExceeded, "问卷最多包含 %d 个问题", limits.MaxQuestionsPerQuestionnaire)
+	}
+	if newQuestion.GetType() != question.QuestionTypeSection {
+		if section, count := lastSection(q.questions); section != nil && count >= limits.MaxQuestionsPerSection {
+			return errors.WithCode(code.ErrMaxQuestionsExceeded, "段落 %s 最多包含 %d 个问题", section.GetCode().Value(), limits.MaxQuestionsPerSection)
+		}
+	}
+
 	q.questions = append(q.questions, newQuestion)
 	log.Infow("---- q.questions: ", "q.questions", q.questions)
 	return nil
 }
 
+// BulkAddQuestions 按顺序批量添加问题，总数超出上限时不添加任何问题
+func (s QuestionService) BulkAddQuestions(q *Questionnaire, questions []question.Question) error {
+	limits := s.Limits()
+	if total := len(q.questions) + len(questions); total > limits.MaxQuestionsPerQuestionnaire {
+		return errors.WithCode(code.ErrMaxQuestionsExceeded, "问卷最多包含 %d 个问题，本次添加后共 %d 个", limits.MaxQuestionsPerQuestionnaire, total)
+	}
+
+	for _, newQuestion := range questions {
+		if err := s.AddQuestion(q, newQuestion); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// AssignMissingCodes 为缺少编码的问题生成随机编码，返回是否有问题被分配了编码
+// 调用方需保存问卷，之后的查询才能得到相同的编码
+func (s QuestionService) AssignMissingCodes(q *Questionnaire) bool {
+	assigned := false
+	for i, existing := range q.questions {
+		if existing == nil || existing.GetCode().Value() != "" {
+			continue
+		}
+		builder := question.NewQuestionBuilderFrom(existing).SetAutoGenerateCode(true)
+		if rebuilt := question.CreateQuestionFromBuilder(builder); rebuilt != nil {
+			q.questions[i] = rebuilt
+			assigned = true
+		}
+	}
+	return assigned
+}
+
+// ValidateLimits 校验问卷的问题数量是否在上限之内，用于发布前复核（上限配置可能已调整）
+func (s QuestionService) ValidateLimits(q *Questionnaire) error {
+	limits := s.Limits()
+	if len(q.questions) > limits.MaxQuestionsPerQuestionnaire {
+		return errors.WithCode(code.ErrMaxQuestionsExceeded, "问卷包含 %d 个问题，超过上限 %d", len(q.questions), limits.MaxQuestionsPerQuestionnaire)
+	}
+
+	var section question.Question
+	count := 0
+	for _, existing := range q.questions {
+		if existing.GetType() == question.QuestionTypeSection {
+			section, count = existing, 0
+			continue
+		}
+		if section == nil {
+			continue
+		}
+		if count++; count > limits.MaxQuestionsPerSection {
+			return errors.WithCode(code.ErrMaxQuestionsExceeded, "段落 %s 超过 %d 个问题", section.GetCode().Value(), limits.MaxQuestionsPerSection)
+		}
+	}
+	return nil
+}
+
+// lastSection 返回最后一个段落及其包含的问题数，问题列表中没有段落时返回 nil
+func lastSection(questions []question.Question) (question.Question, int) {
+	count := 0
+	for i := len(questions) - 1; i >= 0; i-- {
+		if questions[i].GetType() == question.QuestionTypeSection {
+			return questions[i], count
+		}
+		count++
+	}
+	return nil, 0
+}
+
 // UpdateQuestion 更新问题
 func (QuestionService) UpdateQuestion(q *Questionnaire, updated question.Question) error {
 	for i := range q.GetQuestions() {
@@ -0,0 +1,117 @@
+package questionnaire
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newTestQuestion 创建指定编码和题型的问题
+func newTestQuestion(questionCode string, typ question.QuestionType) question.Question {
+	return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode(questionCode)),
+		question.WithTitle(questionCode),
+		question.WithQuestionType(typ),
+	))
+}
+
+func TestQuestionService_AddQuestion_MaxQuestionsPerQuestionnaire(t *testing.T) {
+	s := NewQuestionService(QuestionLimits{MaxQuestionsPerQuestionnaire: 3})
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9")
+
+	for i := 1; i <= 3; i++ {
+		if err := s.AddQuestion(q, newTestQuestion(fmt.Sprintf("Q%d", i), question.QuestionTypeText)); err != nil {
+			t.Fatalf("AddQuestion(Q%d) error = %v", i, err)
+		}
+	}
+
+	err := s.AddQuestion(q, newTestQuestion("Q4", question.QuestionTypeText))
+	if !errors.IsCode(err, code.ErrMaxQuestionsExceeded) {
+		t.Fatalf("AddQuestion(Q4) error = %v, want ErrMaxQuestionsExceeded", err)
+	}
+	if len(q.GetQuestions()) != 3 {
+		t.Errorf("len(GetQuestions()) = %d, want 3", len(q.GetQuestions()))
+	}
+}
+
+func TestQuestionService_AddQuestion_AutoGeneratedCodes(t *testing.T) {
+	s := NewQuestionService(QuestionLimits{})
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9")
+
+	// 未提供编码、题型和标题相同的两个问题（如重复的李克特题）都能添加
+	for i := 0; i < 2; i++ {
+		likert := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+			question.WithTitle("我感到紧张"),
+			question.WithQuestionType(question.QuestionTypeText),
+		))
+		if err := s.AddQuestion(q, likert); err != nil {
+			t.Fatalf("AddQuestion(#%d) error = %v", i+1, err)
+		}
+	}
+	questions := q.GetQuestions()
+	if len(questions) != 2 || questions[0].GetCode() == questions[1].GetCode() {
+		t.Errorf("questions = %v, want 2 questions with different codes", questions)
+	}
+}
+
+func TestQuestionService_AssignMissingCodes(t *testing.T) {
+	stored := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithAutoGenerateCode(false),
+		question.WithTitle("我感到紧张"),
+		question.WithQuestionType(question.QuestionTypeText),
+	))
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9",
+		WithQuestions([]question.Question{newTestQuestion("Q1", question.QuestionTypeText), stored}))
+
+	s := QuestionService{}
+	if !s.AssignMissingCodes(q) {
+		t.Fatal("AssignMissingCodes() = false, want true")
+	}
+	questions := q.GetQuestions()
+	if questions[0].GetCode().Value() != "Q1" {
+		t.Errorf("questions[0] code = %q, want Q1", questions[0].GetCode().Value())
+	}
+	assigned := questions[1].GetCode()
+	if assigned.Value() == "" || questions[1].GetTitle() != "我感到紧张" {
+		t.Errorf("questions[1] = %q %q, want a generated code and the original title", assigned.Value(), questions[1].GetTitle())
+	}
+
+	// 编码已全部存在时不再生成
+	if s.AssignMissingCodes(q) || q.GetQuestions()[1].GetCode() != assigned {
+		t.Errorf("second AssignMissingCodes() changed codes, want %q kept", assigned.Value())
+	}
+}
+
+func TestQuestionService_BulkAddQuestions(t *testing.T) {
+	s := NewQuestionService(QuestionLimits{MaxQuestionsPerQuestionnaire: 3, MaxQuestionsPerSection: 1})
+
+	// 总数超出上限时不添加任何问题
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9")
+	questions := []question.Question{
+		newTestQuestion("Q1", question.QuestionTypeText),
+		newTestQuestion("Q2", question.QuestionTypeText),
+		newTestQuestion("Q3", question.QuestionTypeText),
+		newTestQuestion("Q4", question.QuestionTypeText),
+	}
+	if err := s.BulkAddQuestions(q, questions); !errors.IsCode(err, code.ErrMaxQuestionsExceeded) {
+		t.Fatalf("BulkAddQuestions() error = %v, want ErrMaxQuestionsExceeded", err)
+	}
+	if len(q.GetQuestions()) != 0 {
+		t.Errorf("len(GetQuestions()) = %d, want 0", len(q.GetQuestions()))
+	}
+
+	// 段落内问题超出上限
+	q = NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9")
+	questions = []question.Question{
+		newTestQuestion("S1", question.QuestionTypeSection),
+		newTestQuestion("Q1", question.QuestionTypeText),
+		newTestQuestion("Q2", question.QuestionTypeText),
+	}
+	if err := s.BulkAddQuestions(q, questions); !errors.IsCode(err, code.ErrMaxQuestionsExceeded) {
+		t.Fatalf("BulkAddQuestions(section) error = %v, want ErrMaxQuestionsExceeded", err)
+	}
+}
@@ -1,7 +1,11 @@
 package questionnaire
 
 import (
+	"strings"
+
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // Questionnaire 问卷
@@ -14,6 +18,12 @@ type Questionnaire struct {
 	version     QuestionnaireVersion
 	status      QuestionnaireStatus
 	questions   []question.Question
+	// geoRestriction 允许访问的国家（ISO 3166-1 alpha-2 代码），为空表示不限制
+	geoRestriction []string
+	// tags 问卷标签，用于按主题发现问卷，已去重并转为小写
+	tags []string
+	// revision 修订号，每次保存递增，用于并发更新时的乐观锁
+	revision int64
 }
 
 type QuestionnaireOption func(*Questionnaire)
@@ -34,6 +44,15 @@ func WithID(id QuestionnaireID) QuestionnaireOption {
 	}
 }
 
+// WithAutoGenerateCode 未提供问卷编码时自动生成
+func WithAutoGenerateCode() QuestionnaireOption {
+	return func(q *Questionnaire) {
+		if q.code == "" {
+			q.code = GenerateQuestionnaireCode()
+		}
+	}
+}
+
 // WithTitle 设置问卷标题
 func WithTitle(title string) QuestionnaireOption {
 	return func(q *Questionnaire) {
@@ -76,6 +95,27 @@ func WithQuestions(questions []question.Question) QuestionnaireOption {
 	}
 }
 
+// WithGeoRestriction 设置允许访问问卷的国家
+func WithGeoRestriction(countries []string) QuestionnaireOption {
+	return func(q *Questionnaire) {
+		q.geoRestriction = countries
+	}
+}
+
+// WithTags 设置问卷标签
+func WithTags(tags []string) QuestionnaireOption {
+	return func(q *Questionnaire) {
+		q.tags = tags
+	}
+}
+
+// WithRevision 设置问卷修订号
+func WithRevision(revision int64) QuestionnaireOption {
+	return func(q *Questionnaire) {
+		q.revision = revision
+	}
+}
+
 // SetID 设置问卷ID
 func (q *Questionnaire) SetID(id QuestionnaireID) {
 	q.id = id
@@ -121,6 +161,39 @@ func (q *Questionnaire) GetQuestions() []question.Question {
 	return q.questions
 }
 
+// GetGeoRestriction 获取允许访问问卷的国家
+func (q *Questionnaire) GetGeoRestriction() []string {
+	return q.geoRestriction
+}
+
+// GetTags 获取问卷标签
+func (q *Questionnaire) GetTags() []string {
+	return q.tags
+}
+
+// GetRevision 获取问卷修订号
+func (q *Questionnaire) GetRevision() int64 {
+	return q.revision
+}
+
+// SetRevision 设置问卷修订号
+func (q *Questionnaire) SetRevision(revision int64) {
+	q.revision = revision
+}
+
+// AllowsCountry 判断问卷是否允许该国家访问，未设置地域限制时允许所有国家
+func (q *Questionnaire) AllowsCountry(country string) bool {
+	if len(q.geoRestriction) == 0 {
+		return true
+	}
+	for _, allowed := range q.geoRestriction {
+		if strings.EqualFold(allowed, country) {
+			return true
+		}
+	}
+	return false
+}
+
 // IsPublished 判断问卷是否已发布
 func (q *Questionnaire) IsPublished() bool {
 	return q.status == STATUS_PUBLISHED
@@ -130,3 +203,26 @@ func (q *Questionnaire) IsPublished() bool {
 func (q *Questionnaire) IsArchived() bool {
 	return q.status == STATUS_ARCHIVED
 }
+
+// EnsureImmutability 校验问卷内容是否允许修改，已发布的问卷不可修改
+// 需要修改已发布的问卷时，应创建修订版本（草稿），修改后重新发布
+func (q *Questionnaire) EnsureImmutability() error {
+	if q.IsPublished() {
+		return errors.WithCode(code.ErrPublishedQuestionnaireImmutable,
+			"问卷 %s@%s 已发布，不能修改，请创建修订版本", q.code.Value(), q.version.Value())
+	}
+	return nil
+}
+
+// ComputeMaxScore 计算问卷的最高可得分，由计分 SDK 按与答卷计分相同的规则计算
+// 单选题取选项最高分，多选题取正分选项之和，其余题型不计分；
+// 设置了条件计分规则的题目取各分值映射下的最高分
+func (q *Questionnaire) ComputeMaxScore() float64 {
+	return q.ScoringDefinition().MaxScore()
+}
+
+// ComputeMinScore 计算问卷的最低可得分，由计分 SDK 按与答卷计分相同的规则计算
+// 单选题取选项最低分，多选题取负分选项之和，未作答的题目得 0 分
+func (q *Questionnaire) ComputeMinScore() float64 {
+	return q.ScoringDefinition().MinScore()
+}
@@ -0,0 +1,219 @@
+// Package redcap 将问卷导出为 REDCap 数据字典（Data Dictionary），供科研团队导入 REDCap 项目
+package redcap
+
+import (
+	"bytes"
+	"encoding/csv"
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// REDCap 字段类型
+const (
+	FieldTypeText        = "text"
+	FieldTypeNotes       = "notes"
+	FieldTypeRadio       = "radio"
+	FieldTypeCheckbox    = "checkbox"
+	FieldTypeFile        = "file"
+	FieldTypeDescriptive = "descriptive"
+)
+
+// ValidationNumber REDCap 文本字段的数字校验类型
+const ValidationNumber = "number"
+
+// Header REDCap 数据字典的表头，列顺序与 REDCap 导入模板一致
+var Header = []string{
+	"Variable / Field Name",
+	"Form Name",
+	"Section Header",
+	"Field Type",
+	"Field Label",
+	"Choices, Calculations, OR Slider Labels",
+	"Field Note",
+	"Text Validation Type OR Show Slider Number",
+	"Text Validation Min",
+	"Text Validation Max",
+	"Identifier?",
+	"Branching Logic (Show field only if...)",
+	"Required Field?",
+	"Custom Alignment",
+	"Question Number (surveys only)",
+	"Matrix Group Name",
+	"Matrix Ranking?",
+	"Field Annotation",
+}
+
+// dictionaryField 数据字典中的一行，未列出的列导出为空
+type dictionaryField struct {
+	VariableName   string
+	FormName       string
+	SectionHeader  string
+	FieldType      string
+	FieldLabel     string
+	Choices        string
+	FieldNote      string
+	ValidationType string
+	ValidationMin  string
+	ValidationMax  string
+	Required       bool
+}
+
+// record 按表头顺序输出字段
+func (f dictionaryField) record() []string {
+	record := make([]string, len(Header))
+	record[0] = f.VariableName
+	record[1] = f.FormName
+	record[2] = f.SectionHeader
+	record[3] = f.FieldType
+	record[4] = f.FieldLabel
+	record[5] = f.Choices
+	record[6] = f.FieldNote
+	record[7] = f.ValidationType
+	record[8] = f.ValidationMin
+	record[9] = f.ValidationMax
+	if f.Required {
+		record[12] = "y"
+	}
+	return record
+}
+
+// ExportDataDictionary 将问卷导出为 REDCap 数据字典 CSV，问卷对应一个表单（instrument）
+// 段落导出为下一个字段的 Section Header，后面没有字段的段落导出为 descriptive 字段；
+// 问题编码和问卷编码转换为 REDCap 允许的小写变量名，转换后重名时返回错误
+func ExportDataDictionary(q *questionnaire.Questionnaire) ([]byte, error) {
+	fields, err := buildFields(q)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(Header); err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		if err := w.Write(f.record()); err != nil {
+			return nil, err
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// buildFields 将问卷转换为数据字典中的字段
+func buildFields(q *questionnaire.Questionnaire) ([]dictionaryField, error) {
+	if q == nil {
+		return nil, errors.WithCode(code.ErrQuestionnaireInvalidInput, "questionnaire is required")
+	}
+
+	formName := variableName(q.GetCode().Value())
+	names := make(map[string]string)
+	var fields []dictionaryField
+	var pending *dictionaryField // 尚未挂到字段上的段落
+
+	for _, qu := range q.GetQuestions() {
+		name := variableName(qu.GetCode().Value())
+		if other, ok := names[name]; ok {
+			return nil, errors.WithCode(code.ErrQuestionnaireInvalidInput,
+				"questions %s and %s map to the same REDCap variable %s", other, qu.GetCode().Value(), name)
+		}
+		names[name] = qu.GetCode().Value()
+
+		if qu.GetType() == question.QuestionTypeSection {
+			if pending != nil {
+				fields = append(fields, *pending)
+			}
+			pending = &dictionaryField{VariableName: name, FormName: formName, FieldType: FieldTypeDescriptive, FieldLabel: qu.GetTitle()}
+			continue
+		}
+
+		field, err := exportQuestion(qu)
+		if err != nil {
+			return nil, err
+		}
+		field.VariableName, field.FormName = name, formName
+		if pending != nil {
+			field.SectionHeader = pending.FieldLabel
+			pending = nil
+		}
+		fields = append(fields, field)
+	}
+	if pending != nil {
+		fields = append(fields, *pending)
+	}
+	return fields, nil
+}
+
+// exportQuestion 将问题转换为 REDCap 字段
+// 数字题导出为带 number 校验的 text 字段，最小值、最大值规则导出为校验范围
+func exportQuestion(q question.Question) (dictionaryField, error) {
+	field := dictionaryField{
+		FieldLabel: q.GetTitle(),
+		FieldNote:  q.GetTips(),
+	}
+
+	switch q.GetType() {
+	case question.QuestionTypeRadio:
+		field.FieldType = FieldTypeRadio
+	case question.QuestionTypeCheckbox:
+		field.FieldType = FieldTypeCheckbox
+	case question.QuestionTypeText:
+		field.FieldType = FieldTypeText
+	case question.QuestionTypeTextarea:
+		field.FieldType = FieldTypeNotes
+	case question.QuestionTypeNumber:
+		field.FieldType, field.ValidationType = FieldTypeText, ValidationNumber
+	case question.QuestionTypeFileUpload:
+		field.FieldType = FieldTypeFile
+	default:
+		return dictionaryField{}, errors.WithCode(code.ErrQuestionnaireInvalidInput,
+			"question type %s of %s cannot be exported to REDCap", q.GetType(), q.GetCode().Value())
+	}
+
+	for _, rule := range q.GetValidationRules() {
+		switch rule.GetRuleType() {
+		case validation.RuleTypeRequired:
+			field.Required = true
+		case validation.RuleTypeMinValue:
+			if field.ValidationType == ValidationNumber {
+				field.ValidationMin = rule.GetTargetValue()
+			}
+		case validation.RuleTypeMaxValue:
+			if field.ValidationType == ValidationNumber {
+				field.ValidationMax = rule.GetTargetValue()
+			}
+		}
+	}
+
+	choices := make([]string, 0, len(q.GetOptions()))
+	for _, option := range q.GetOptions() {
+		choices = append(choices, option.GetCode()+", "+option.GetContent())
+	}
+	field.Choices = strings.Join(choices, " | ")
+	return field, nil
+}
+
+// variableName 将编码转换为 REDCap 变量名：小写字母、数字和下划线，以字母开头
+func variableName(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if name == "" || name[0] < 'a' || name[0] > 'z' {
+		name = "v" + name
+	}
+	return name
+}
@@ -0,0 +1,81 @@
+package redcap
+
+import (
+	"encoding/csv"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+)
+
+func newQuestion(code, title string, typ question.QuestionType, opts ...question.BuilderOption) question.Question {
+	opts = append(opts,
+		question.WithCode(question.NewQuestionCode(code)),
+		question.WithTitle(title),
+		question.WithQuestionType(typ),
+	)
+	return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(opts...))
+}
+
+func TestExportDataDictionary(t *testing.T) {
+	q := questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("Sleep-1"), "睡眠问卷",
+		questionnaire.WithQuestions([]question.Question{
+			newQuestion("S1", "基本情况", question.QuestionTypeSection),
+			newQuestion("Q1", "睡眠质量", question.QuestionTypeRadio,
+				question.WithOption("A", "好", 0), question.WithOption("B", "一般, 偶尔失眠", 1),
+				question.WithValidationRule(validation.RuleTypeRequired, "true")),
+			newQuestion("Q2", "平均睡眠时长", question.QuestionTypeNumber,
+				question.WithValidationRule(validation.RuleTypeMinValue, "0"),
+				question.WithValidationRule(validation.RuleTypeMaxValue, "24")),
+			newQuestion("Q3", "补充说明", question.QuestionTypeText),
+		}),
+	)
+
+	data, err := ExportDataDictionary(q)
+	if err != nil {
+		t.Fatalf("ExportDataDictionary() error = %v", err)
+	}
+	records, err := csv.NewReader(strings.NewReader(string(data))).ReadAll()
+	if err != nil {
+		t.Fatalf("parse exported CSV: %v", err)
+	}
+
+	if !reflect.DeepEqual(records[0], Header) || len(Header) != 18 {
+		t.Fatalf("header = %q, want the 18 REDCap data dictionary columns", records[0])
+	}
+	if len(records) != 4 {
+		t.Fatalf("records = %d, want header and 3 fields", len(records))
+	}
+
+	radio := records[1]
+	if radio[0] != "q1" || radio[1] != "sleep_1" || radio[2] != "基本情况" || radio[3] != FieldTypeRadio || radio[12] != "y" {
+		t.Errorf("radio field = %q", radio)
+	}
+	if want := "A, 好 | B, 一般, 偶尔失眠"; radio[5] != want {
+		t.Errorf("radio choices = %q, want %q", radio[5], want)
+	}
+
+	number := records[2]
+	if number[3] != FieldTypeText || number[7] != ValidationNumber || number[8] != "0" || number[9] != "24" {
+		t.Errorf("number field = %q", number)
+	}
+	if text := records[3]; text[3] != FieldTypeText || text[5] != "" || text[7] != "" {
+		t.Errorf("text field = %q", text)
+	}
+}
+
+func TestExportDataDictionary_DuplicateVariable(t *testing.T) {
+	q := questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("sleep"), "睡眠问卷",
+		questionnaire.WithQuestions([]question.Question{
+			newQuestion("Q1", "睡眠质量", question.QuestionTypeText),
+			newQuestion("q1", "睡眠时长", question.QuestionTypeText),
+		}),
+	)
+	if _, err := ExportDataDictionary(q); err == nil {
+		t.Error("ExportDataDictionary() error = nil, want duplicate variable error")
+	}
+}
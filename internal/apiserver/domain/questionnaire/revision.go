@@ -0,0 +1,25 @@
+package questionnaire
+
+import (
+	"fmt"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// RevisionConflictError 保存问卷时修订号与服务端不一致，说明问卷已被其他请求修改
+type RevisionConflictError struct {
+	Code            string
+	CurrentRevision int64 // 服务端当前的修订号
+}
+
+// Error 实现 error 接口
+func (e *RevisionConflictError) Error() string {
+	return fmt.Sprintf("questionnaire %s revision conflict, current revision is %d", e.Code, e.CurrentRevision)
+}
+
+// NewRevisionConflictError 创建带 ErrQuestionnaireVersionConflict 错误码的修订号冲突错误
+func NewRevisionConflictError(qnCode string, currentRevision int64) error {
+	return errors.WrapC(&RevisionConflictError{Code: qnCode, CurrentRevision: currentRevision},
+		code.ErrQuestionnaireVersionConflict, "问卷 %s 已被其他请求修改，当前修订号为 %d", qnCode, currentRevision)
+}
@@ -0,0 +1,39 @@
+package questionnaire
+
+import (
+	"github.com/yshujie/questionnaire-scale/pkg/scoring"
+)
+
+// ScoringDefinition 将问卷转换为计分 SDK 的问卷定义，服务端与第三方共用同一套计分规则
+func (q *Questionnaire) ScoringDefinition() *scoring.Questionnaire {
+	def := &scoring.Questionnaire{
+		Code:      q.code.Value(),
+		Version:   q.version.Value(),
+		Questions: make([]scoring.Question, 0, len(q.questions)),
+	}
+	for _, qu := range q.questions {
+		if qu == nil {
+			continue
+		}
+
+		sq := scoring.Question{
+			Code:    qu.GetCode().Value(),
+			Type:    qu.GetType().Value(),
+			Options: make([]scoring.Option, 0, len(qu.GetOptions())),
+		}
+		for _, opt := range qu.GetOptions() {
+			sq.Options = append(sq.Options, scoring.Option{Code: opt.GetCode(), Score: float64(opt.GetScore())})
+		}
+		if rule := qu.GetCalculationRule(); rule != nil && rule.IsConditional() {
+			sq.Conditional = &scoring.Conditional{DependsOn: rule.GetDependsOnCode()}
+			for _, mapping := range rule.GetScoreMappings() {
+				sq.Conditional.Mappings = append(sq.Conditional.Mappings, scoring.ScoreMapping{
+					WhenValue: mapping.WhenValue,
+					Scores:    mapping.Scores,
+				})
+			}
+		}
+		def.Questions = append(def.Questions, sq)
+	}
+	return def
+}
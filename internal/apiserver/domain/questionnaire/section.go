@@ -0,0 +1,102 @@
+package questionnaire
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Section 段落：段落题及其后直到下一个段落题之前的题目
+// 第一个段落题之前的题目归入没有段落题的首个段落
+type Section struct {
+	header    question.Question
+	questions []question.Question
+}
+
+// GetCode 获取段落编码，首个无标题段落返回空编码
+func (s Section) GetCode() question.QuestionCode {
+	if s.header == nil {
+		return ""
+	}
+	return s.header.GetCode()
+}
+
+// GetTitle 获取段落标题，首个无标题段落返回空字符串
+func (s Section) GetTitle() string {
+	if s.header == nil {
+		return ""
+	}
+	return s.header.GetTitle()
+}
+
+// GetQuestions 获取段落内的题目，不含段落题本身
+func (s Section) GetQuestions() []question.Question {
+	return s.questions
+}
+
+// PageBreakBefore 判断段落前是否分页
+func (s Section) PageBreakBefore() bool {
+	return s.header != nil && s.header.GetPageBreakBefore()
+}
+
+// GetSections 将问卷题目按段落题分组
+func (q *Questionnaire) GetSections() []Section {
+	var sections []Section
+	for _, qu := range q.questions {
+		if qu.GetType() == question.QuestionTypeSection {
+			sections = append(sections, Section{header: qu})
+			continue
+		}
+		if len(sections) == 0 {
+			sections = append(sections, Section{})
+		}
+		last := &sections[len(sections)-1]
+		last.questions = append(last.questions, qu)
+	}
+	return sections
+}
+
+// GetPages 按段落的分页标记将段落分组为页面，没有分页标记时整份问卷为一页
+// 返回值至少包含一页；第一个段落上的分页标记不会产生空白页
+func (q *Questionnaire) GetPages() [][]Section {
+	pages := [][]Section{{}}
+	for _, section := range q.GetSections() {
+		current := len(pages) - 1
+		if section.PageBreakBefore() && len(pages[current]) > 0 {
+			pages = append(pages, []Section{})
+			current++
+		}
+		pages[current] = append(pages[current], section)
+	}
+	return pages
+}
+
+// SetPageBreak 设置段落前是否分页，sectionCode 必须是段落题
+func (q *Questionnaire) SetPageBreak(sectionCode string, breakBefore bool) error {
+	if err := q.EnsureImmutability(); err != nil {
+		return err
+	}
+
+	for i, qu := range q.questions {
+		if qu.GetCode().Value() != sectionCode {
+			continue
+		}
+		if qu.GetType() != question.QuestionTypeSection {
+			return errors.WithCode(code.ErrQuestionnaireQuestionInvalid, "题目 %s 不是段落，不能设置分页", sectionCode)
+		}
+
+		section := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+			question.WithCode(qu.GetCode()),
+			question.WithTitle(qu.GetTitle()),
+			question.WithQuestionType(question.QuestionTypeSection),
+			question.WithPageBreakBefore(breakBefore),
+		))
+		if section == nil {
+			return errors.WithCode(code.ErrQuestionnaireQuestionInvalid, "重建段落 %s 失败", sectionCode)
+		}
+		q.questions[i] = section
+		return nil
+	}
+
+	return errors.WithCode(code.ErrQuestionnaireQuestionNotFound, "找不到段落 %s", sectionCode)
+}
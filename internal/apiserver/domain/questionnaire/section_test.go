@@ -0,0 +1,97 @@
+package questionnaire
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// pageSectionCodes 返回各页面的段落编码
+func pageSectionCodes(pages [][]Section) [][]string {
+	var codes [][]string
+	for _, page := range pages {
+		var pageCodes []string
+		for _, section := range page {
+			pageCodes = append(pageCodes, section.GetCode().Value())
+		}
+		codes = append(codes, pageCodes)
+	}
+	return codes
+}
+
+func TestQuestionnaire_GetPages(t *testing.T) {
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9", WithQuestions([]question.Question{
+		newTestQuestion("Q0", question.QuestionTypeText),
+		newTestQuestion("S1", question.QuestionTypeSection),
+		newTestQuestion("Q1", question.QuestionTypeText),
+		newTestQuestion("S2", question.QuestionTypeSection),
+		newTestQuestion("Q2", question.QuestionTypeText),
+		newTestQuestion("S3", question.QuestionTypeSection),
+		newTestQuestion("Q3", question.QuestionTypeText),
+	}))
+
+	pages := q.GetPages()
+	if len(pages) != 1 || len(pages[0]) != 4 {
+		t.Fatalf("GetPages() without page breaks = %v, want 1 page with 4 sections", pageSectionCodes(pages))
+	}
+	if got := pages[0][0]; got.GetCode() != "" || len(got.GetQuestions()) != 1 {
+		t.Errorf("first section = %q with %d questions, want untitled section with Q0", got.GetCode(), len(got.GetQuestions()))
+	}
+
+	for _, sectionCode := range []string{"S1", "S3"} {
+		if err := q.SetPageBreak(sectionCode, true); err != nil {
+			t.Fatalf("SetPageBreak(%s) error = %v", sectionCode, err)
+		}
+	}
+	got := pageSectionCodes(q.GetPages())
+	want := [][]string{{""}, {"S1", "S2"}, {"S3"}}
+	if len(got) != len(want) {
+		t.Fatalf("GetPages() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if len(got[i]) != len(want[i]) || got[i][0] != want[i][0] {
+			t.Errorf("page %d = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestQuestionnaire_GetPages_SinglePage(t *testing.T) {
+	empty := NewQuestionnaire(NewQuestionnaireCode("EMPTY"), "Empty")
+	if pages := empty.GetPages(); len(pages) != 1 {
+		t.Errorf("GetPages() on empty questionnaire = %d pages, want 1", len(pages))
+	}
+
+	// 第一个段落上的分页标记不产生空白页
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9", WithQuestions([]question.Question{
+		newTestQuestion("S1", question.QuestionTypeSection),
+		newTestQuestion("Q1", question.QuestionTypeText),
+	}))
+	if err := q.SetPageBreak("S1", true); err != nil {
+		t.Fatalf("SetPageBreak() error = %v", err)
+	}
+	if pages := q.GetPages(); len(pages) != 1 || len(pages[0]) != 1 {
+		t.Errorf("GetPages() = %v, want a single page", pageSectionCodes(pages))
+	}
+}
+
+func TestQuestionnaire_SetPageBreak_Errors(t *testing.T) {
+	questions := []question.Question{
+		newTestQuestion("S1", question.QuestionTypeSection),
+		newTestQuestion("Q1", question.QuestionTypeText),
+	}
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9", WithQuestions(questions))
+
+	if err := q.SetPageBreak("Q1", true); !errors.IsCode(err, code.ErrQuestionnaireQuestionInvalid) {
+		t.Errorf("SetPageBreak(non-section) error = %v, want ErrQuestionnaireQuestionInvalid", err)
+	}
+	if err := q.SetPageBreak("S9", true); !errors.IsCode(err, code.ErrQuestionnaireQuestionNotFound) {
+		t.Errorf("SetPageBreak(missing) error = %v, want ErrQuestionnaireQuestionNotFound", err)
+	}
+
+	published := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9", WithQuestions(questions), WithStatus(STATUS_PUBLISHED))
+	if err := published.SetPageBreak("S1", true); !errors.IsCode(err, code.ErrPublishedQuestionnaireImmutable) {
+		t.Errorf("SetPageBreak(published) error = %v, want ErrPublishedQuestionnaireImmutable", err)
+	}
+}
@@ -0,0 +1,221 @@
+// Package spss 为答卷导出生成 SPSS 语法文件（变量标签和值标签），使编码后的答案在 SPSS 中显示为可读的标签
+package spss
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// maxVariableNameLength SPSS 变量名的最大长度（字节）
+const maxVariableNameLength = 64
+
+// reservedWords SPSS 保留字，不能作为变量名
+var reservedWords = map[string]bool{
+	"all": true, "and": true, "by": true, "eq": true, "ge": true, "gt": true, "le": true,
+	"lt": true, "ne": true, "not": true, "or": true, "to": true, "with": true,
+}
+
+// 多选题拆分出的二分变量的取值
+const (
+	Unselected = "0"
+	Selected   = "1"
+)
+
+// ValueLabel 值标签
+type ValueLabel struct {
+	Value string
+	Label string
+}
+
+// Variable 导出文件中的一列及其标签
+// 单选、填空、数字题各对应一个变量；多选题按选项拆分为取值 0/1 的二分变量，OptionCode 为对应选项
+type Variable struct {
+	Name         string
+	Label        string
+	Numeric      bool // 数值型变量的值标签不加引号
+	QuestionCode string
+	OptionCode   string
+	ValueLabels  []ValueLabel
+}
+
+// Value 将答案值转换为该变量在导出文件中的取值，未作答时为空（SPSS 系统缺失值）
+func (v Variable) Value(answer any) string {
+	if answer == nil {
+		return ""
+	}
+	values := answerValues(answer)
+	if v.OptionCode != "" {
+		for _, value := range values {
+			if value == v.OptionCode {
+				return Selected
+			}
+		}
+		return Unselected
+	}
+	return strings.Join(values, ";")
+}
+
+// answerValues 将答案值转换为字符串列表，多选题的答案为选项编码数组
+func answerValues(answer any) []string {
+	rv := reflect.ValueOf(answer)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return []string{formatValue(answer)}
+	}
+	values := make([]string, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		values = append(values, formatValue(rv.Index(i).Interface()))
+	}
+	return values
+}
+
+// formatValue 格式化单个答案值，数字不使用科学计数法
+func formatValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case float32:
+		return strconv.FormatFloat(float64(v), 'f', -1, 32)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+// BuildVariables 按问卷问题生成导出变量，段落和文件上传题不导出
+func BuildVariables(q *questionnaire.Questionnaire) ([]Variable, error) {
+	if q == nil {
+		return nil, errors.WithCode(code.ErrQuestionnaireInvalidInput, "questionnaire is required")
+	}
+
+	var variables []Variable
+	for _, qu := range q.GetQuestions() {
+		name := VariableName(qu.GetCode().Value())
+		switch qu.GetType() {
+		case question.QuestionTypeSection, question.QuestionTypeFileUpload:
+			continue
+		case question.QuestionTypeCheckbox:
+			for _, option := range qu.GetOptions() {
+				variables = append(variables, Variable{
+					Name:         VariableName(qu.GetCode().Value() + "_" + option.GetCode()),
+					Label:        qu.GetTitle() + ": " + option.GetContent(),
+					Numeric:      true,
+					QuestionCode: qu.GetCode().Value(),
+					OptionCode:   option.GetCode(),
+					ValueLabels:  []ValueLabel{{Value: Unselected, Label: "未选"}, {Value: Selected, Label: "已选"}},
+				})
+			}
+		case question.QuestionTypeRadio:
+			v := Variable{Name: name, Label: qu.GetTitle(), Numeric: true, QuestionCode: qu.GetCode().Value()}
+			for _, option := range qu.GetOptions() {
+				if _, err := strconv.ParseFloat(option.GetCode(), 64); err != nil {
+					v.Numeric = false
+				}
+				v.ValueLabels = append(v.ValueLabels, ValueLabel{Value: option.GetCode(), Label: option.GetContent()})
+			}
+			variables = append(variables, v)
+		case question.QuestionTypeNumber:
+			variables = append(variables, Variable{Name: name, Label: qu.GetTitle(), Numeric: true, QuestionCode: qu.GetCode().Value()})
+		default:
+			variables = append(variables, Variable{Name: name, Label: qu.GetTitle(), QuestionCode: qu.GetCode().Value()})
+		}
+	}
+	return variables, nil
+}
+
+// WriteSyntax 写入 SPSS 语法文件，包含所有变量的变量标签和有值标签的变量的值标签
+// 变量名重复时返回错误，通常是不同的问题编码转换为了相同的变量名
+func WriteSyntax(w io.Writer, title string, variables []Variable) error {
+	names := make(map[string]bool, len(variables))
+	for _, v := range variables {
+		if names[v.Name] {
+			return errors.WithCode(code.ErrQuestionnaireInvalidInput, "duplicate SPSS variable name %s", v.Name)
+		}
+		names[v.Name] = true
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "* %s.\n", strings.ReplaceAll(title, "\n", " "))
+
+	if len(variables) > 0 {
+		b.WriteString("VARIABLE LABELS\n")
+		for i, v := range variables {
+			fmt.Fprintf(&b, "  %s%s %s", separator(i), v.Name, quote(v.Label))
+			b.WriteString(terminator(i, len(variables)))
+		}
+	}
+
+	var labelled []Variable
+	for _, v := range variables {
+		if len(v.ValueLabels) > 0 {
+			labelled = append(labelled, v)
+		}
+	}
+	if len(labelled) > 0 {
+		b.WriteString("VALUE LABELS\n")
+		for i, v := range labelled {
+			fmt.Fprintf(&b, "  %s%s", separator(i), v.Name)
+			for _, label := range v.ValueLabels {
+				value := label.Value
+				if !v.Numeric {
+					value = quote(value)
+				}
+				fmt.Fprintf(&b, " %s %s", value, quote(label.Label))
+			}
+			b.WriteString(terminator(i, len(labelled)))
+		}
+	}
+	b.WriteString("EXECUTE.\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// separator 命令中第二个及之后的变量以斜杠分隔
+func separator(i int) string {
+	if i == 0 {
+		return ""
+	}
+	return "/"
+}
+
+// terminator 命令的最后一个变量以句点结束
+func terminator(i, n int) string {
+	if i == n-1 {
+		return ".\n"
+	}
+	return "\n"
+}
+
+// quote 以单引号包裹字符串，内部的单引号写两次
+func quote(s string) string {
+	return "'" + strings.ReplaceAll(strings.ReplaceAll(s, "\n", " "), "'", "''") + "'"
+}
+
+// VariableName 将编码转换为 SPSS 变量名：小写字母、数字和下划线，以字母开头，不超过 64 字节且不是保留字
+func VariableName(value string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(value) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	name := b.String()
+	if name == "" || name[0] < 'a' || name[0] > 'z' || reservedWords[name] {
+		name = "v" + name
+	}
+	if len(name) > maxVariableNameLength {
+		name = name[:maxVariableNameLength]
+	}
+	return name
+}
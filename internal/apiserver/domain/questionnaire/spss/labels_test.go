@@ -0,0 +1,103 @@
+package spss
+
+import (
+	"strings"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
+)
+
+func newQuestion(code, title string, typ question.QuestionType, opts ...question.BuilderOption) question.Question {
+	opts = append(opts,
+		question.WithCode(question.NewQuestionCode(code)),
+		question.WithTitle(title),
+		question.WithQuestionType(typ),
+	)
+	return question.CreateQuestionFromBuilder(question.BuildQuestionConfig(opts...))
+}
+
+func newSleepQuestionnaire() *questionnaire.Questionnaire {
+	return questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("sleep"), "睡眠问卷",
+		questionnaire.WithQuestions([]question.Question{
+			newQuestion("S1", "基本情况", question.QuestionTypeSection),
+			newQuestion("Q1", "睡眠质量", question.QuestionTypeRadio,
+				question.WithOption("A", "好", 0), question.WithOption("B", "医生's 建议", 2)),
+			newQuestion("Q2", "入睡时间", question.QuestionTypeRadio,
+				question.WithOption("1", "30 分钟内", 0), question.WithOption("2", "超过 30 分钟", 1)),
+			newQuestion("Q3", "影响睡眠的因素", question.QuestionTypeCheckbox,
+				question.WithOption("a", "噪音", 0), question.WithOption("b", "疼痛", 0)),
+			newQuestion("Q4", "平均睡眠时长", question.QuestionTypeNumber),
+		}),
+	)
+}
+
+func TestWriteSyntax(t *testing.T) {
+	variables, err := BuildVariables(newSleepQuestionnaire())
+	if err != nil {
+		t.Fatalf("BuildVariables() error = %v", err)
+	}
+
+	var b strings.Builder
+	if err := WriteSyntax(&b, "睡眠问卷", variables); err != nil {
+		t.Fatalf("WriteSyntax() error = %v", err)
+	}
+
+	want := `* 睡眠问卷.
+VARIABLE LABELS
+  q1 '睡眠质量'
+  /q2 '入睡时间'
+  /q3_a '影响睡眠的因素: 噪音'
+  /q3_b '影响睡眠的因素: 疼痛'
+  /q4 '平均睡眠时长'.
+VALUE LABELS
+  q1 'A' '好' 'B' '医生''s 建议'
+  /q2 1 '30 分钟内' 2 '超过 30 分钟'
+  /q3_a 0 '未选' 1 '已选'
+  /q3_b 0 '未选' 1 '已选'.
+EXECUTE.
+`
+	if got := b.String(); got != want {
+		t.Errorf("WriteSyntax() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestVariable_Value(t *testing.T) {
+	variables, err := BuildVariables(newSleepQuestionnaire())
+	if err != nil {
+		t.Fatalf("BuildVariables() error = %v", err)
+	}
+	byName := make(map[string]Variable)
+	for _, v := range variables {
+		byName[v.Name] = v
+	}
+
+	tests := []struct {
+		variable string
+		answer   any
+		want     string
+	}{
+		{"q1", "B", "B"},
+		{"q1", nil, ""},
+		{"q3_a", primitive.A{"a"}, Selected},
+		{"q3_b", []string{"a"}, Unselected},
+		{"q3_b", nil, ""},
+		{"q4", 7.5, "7.5"},
+	}
+	for _, tt := range tests {
+		if got := byName[tt.variable].Value(tt.answer); got != tt.want {
+			t.Errorf("%s.Value(%v) = %q, want %q", tt.variable, tt.answer, got, tt.want)
+		}
+	}
+}
+
+func TestVariableName(t *testing.T) {
+	for code, want := range map[string]string{"Q1": "q1", "1a": "v1a", "q-1.2": "q_1_2", "BY": "vby"} {
+		if got := VariableName(code); got != want {
+			t.Errorf("VariableName(%q) = %q, want %q", code, got, want)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package questionnaire
+
+import (
+	"strings"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// 翻译审核状态
+const (
+	TranslationStatusDraft    = "draft"
+	TranslationStatusApproved = "approved"
+	TranslationStatusRejected = "rejected"
+)
+
+// 可翻译的字段
+// 问卷字段为 title、description，问题字段为 question.{问题编码}.title/tips/placeholder，
+// 选项内容为 question.{问题编码}.option.{选项编码}
+const (
+	translationFieldTitle       = "title"
+	translationFieldDescription = "description"
+	translationFieldTips        = "tips"
+	translationFieldPlaceholder = "placeholder"
+	translationFieldOption      = "option"
+	translationPathQuestion     = "question"
+)
+
+// Translation 问卷字段的译文，同一问卷的同一语言和字段只有一份译文
+type Translation struct {
+	Language       string
+	FieldPath      string // 字段路径，如 "question.q1.title"
+	TranslatedText string
+	Status         string
+	TranslatedBy   uint64
+	ApprovedBy     *uint64
+}
+
+// IsApproved 判断译文是否已审核通过
+func (t *Translation) IsApproved() bool {
+	return t.Status == TranslationStatusApproved
+}
+
+// Approve 审核通过译文
+func (t *Translation) Approve(approverID uint64) {
+	t.Status = TranslationStatusApproved
+	t.ApprovedBy = &approverID
+}
+
+// translationPath 解析后的字段路径
+type translationPath struct {
+	questionCode string // 为空时表示问卷字段
+	field        string
+	optionCode   string
+}
+
+// parseTranslationPath 解析字段路径，路径格式不正确时返回错误
+func parseTranslationPath(fieldPath string) (translationPath, error) {
+	parts := strings.Split(fieldPath, ".")
+	switch {
+	case len(parts) == 1 && (parts[0] == translationFieldTitle || parts[0] == translationFieldDescription):
+		return translationPath{field: parts[0]}, nil
+	case len(parts) == 3 && parts[0] == translationPathQuestion && parts[1] != "":
+		switch parts[2] {
+		case translationFieldTitle, translationFieldTips, translationFieldPlaceholder:
+			return translationPath{questionCode: parts[1], field: parts[2]}, nil
+		}
+	case len(parts) == 4 && parts[0] == translationPathQuestion && parts[1] != "" &&
+		parts[2] == translationFieldOption && parts[3] != "":
+		return translationPath{questionCode: parts[1], field: translationFieldOption, optionCode: parts[3]}, nil
+	}
+	return translationPath{}, errors.WithCode(code.ErrTranslationInvalid, "不支持翻译的字段路径: %s", fieldPath)
+}
+
+// ValidateTranslationFieldPath 校验字段路径格式正确且指向问卷中存在的问题和选项
+func (q *Questionnaire) ValidateTranslationFieldPath(fieldPath string) error {
+	path, err := parseTranslationPath(fieldPath)
+	if err != nil {
+		return err
+	}
+	if path.questionCode == "" {
+		return nil
+	}
+
+	for _, qu := range q.questions {
+		if qu.GetCode().Value() != path.questionCode {
+			continue
+		}
+		if path.field != translationFieldOption {
+			return nil
+		}
+		for _, option := range qu.GetOptions() {
+			if option.GetCode() == path.optionCode {
+				return nil
+			}
+		}
+		return errors.WithCode(code.ErrTranslationInvalid, "问题 %s 不存在选项 %s", path.questionCode, path.optionCode)
+	}
+	return errors.WithCode(code.ErrTranslationInvalid, "问卷 %s 不存在问题 %s", q.code.Value(), path.questionCode)
+}
+
+// Translate 将已审核通过的译文覆盖到问卷副本上，原问卷不变
+// 未审核的译文以及指向已删除问题或选项的译文会被忽略
+func (q *Questionnaire) Translate(translations []*Translation) *Questionnaire {
+	translated := *q
+	questionTexts := make(map[string]map[string]string)
+	optionTexts := make(map[string]map[string]string)
+
+	for _, t := range translations {
+		if t == nil || !t.IsApproved() {
+			continue
+		}
+		path, err := parseTranslationPath(t.FieldPath)
+		if err != nil {
+			continue
+		}
+
+		switch {
+		case path.questionCode == "" && path.field == translationFieldTitle:
+			translated.title = t.TranslatedText
+		case path.questionCode == "" && path.field == translationFieldDescription:
+			translated.description = t.TranslatedText
+		case path.field == translationFieldOption:
+			if optionTexts[path.questionCode] == nil {
+				optionTexts[path.questionCode] = make(map[string]string)
+			}
+			optionTexts[path.questionCode][path.optionCode] = t.TranslatedText
+		default:
+			if questionTexts[path.questionCode] == nil {
+				questionTexts[path.questionCode] = make(map[string]string)
+			}
+			questionTexts[path.questionCode][path.field] = t.TranslatedText
+		}
+	}
+
+	translated.questions = make([]question.Question, 0, len(q.questions))
+	for _, qu := range q.questions {
+		texts, options := questionTexts[qu.GetCode().Value()], optionTexts[qu.GetCode().Value()]
+		if len(texts) == 0 && len(options) == 0 {
+			translated.questions = append(translated.questions, qu)
+			continue
+		}
+		translated.questions = append(translated.questions, translateQuestion(qu, texts, options))
+	}
+	return &translated
+}
+
+// translateQuestion 以原题配置创建替换了译文的新题目
+func translateQuestion(qu question.Question, texts, optionTexts map[string]string) question.Question {
+	builder := question.NewQuestionBuilderFrom(qu)
+	if text, ok := texts[translationFieldTitle]; ok {
+		builder.SetTitle(text)
+	}
+	if text, ok := texts[translationFieldTips]; ok {
+		builder.SetTips(text)
+	}
+	if text, ok := texts[translationFieldPlaceholder]; ok {
+		builder.SetPlaceholder(text)
+	}
+
+	options := make([]question.Option, 0, len(builder.GetOptions()))
+	for _, option := range builder.GetOptions() {
+		content := option.GetContent()
+		if text, ok := optionTexts[option.GetCode()]; ok {
+			content = text
+		}
+		options = append(options, question.NewOption(option.GetCode(), content, option.GetScore()))
+	}
+	question.WithOptions(options)(builder)
+
+	if translated := question.CreateQuestionFromBuilder(builder); translated != nil {
+		return translated
+	}
+	return qu
+}
@@ -0,0 +1,81 @@
+package questionnaire
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newTranslatableQuestionnaire 创建包含单选题和文本题的问卷
+func newTranslatableQuestionnaire() *Questionnaire {
+	radio := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("Q1")),
+		question.WithTitle("睡眠质量"),
+		question.WithQuestionType(question.QuestionTypeRadio),
+		question.WithOption("A", "好", 0),
+		question.WithOption("B", "差", 2),
+		question.WithCalculationRule(calculation.FormulaTypeScore, "A", "B"),
+	))
+	return NewQuestionnaire(NewQuestionnaireCode("sleep"), "睡眠问卷",
+		WithDescription("成人睡眠状况调查"),
+		WithQuestions([]question.Question{radio, newTestQuestion("Q2", question.QuestionTypeText)}),
+	)
+}
+
+func TestQuestionnaire_ValidateTranslationFieldPath(t *testing.T) {
+	q := newTranslatableQuestionnaire()
+
+	for _, path := range []string{"title", "description", "question.Q1.title", "question.Q2.placeholder", "question.Q1.option.B"} {
+		if err := q.ValidateTranslationFieldPath(path); err != nil {
+			t.Errorf("ValidateTranslationFieldPath(%q) error = %v", path, err)
+		}
+	}
+	for _, path := range []string{"", "version", "question.Q1", "question.Q1.code", "question.Q9.title", "question.Q1.option.C", "question.Q1.option"} {
+		if err := q.ValidateTranslationFieldPath(path); !errors.IsCode(err, code.ErrTranslationInvalid) {
+			t.Errorf("ValidateTranslationFieldPath(%q) error = %v, want ErrTranslationInvalid", path, err)
+		}
+	}
+}
+
+func TestQuestionnaire_Translate(t *testing.T) {
+	q := newTranslatableQuestionnaire()
+	approved := func(path, text string) *Translation {
+		t := &Translation{Language: "en-US", FieldPath: path, TranslatedText: text}
+		t.Approve(1)
+		return t
+	}
+
+	translated := q.Translate([]*Translation{
+		approved("title", "Sleep Questionnaire"),
+		approved("question.Q1.title", "Sleep quality"),
+		approved("question.Q1.option.B", "Poor"),
+		// 草稿译文和指向已删除问题的译文不生效
+		{Language: "en-US", FieldPath: "description", TranslatedText: "Adult sleep survey", Status: TranslationStatusDraft},
+		approved("question.Q9.title", "Removed"),
+	})
+
+	if translated.GetTitle() != "Sleep Questionnaire" || translated.GetDescription() != "成人睡眠状况调查" {
+		t.Errorf("translated title/description = %q/%q", translated.GetTitle(), translated.GetDescription())
+	}
+	q1 := translated.GetQuestions()[0]
+	if q1.GetTitle() != "Sleep quality" {
+		t.Errorf("Q1 title = %q, want Sleep quality", q1.GetTitle())
+	}
+	if options := q1.GetOptions(); options[0].GetContent() != "好" || options[1].GetContent() != "Poor" || options[1].GetScore() != 2 {
+		t.Errorf("Q1 options = %+v, want B translated with score kept", options)
+	}
+	if q1.GetCalculationRule() == nil {
+		t.Error("Q1 calculation rule lost after translation")
+	}
+	if translated.GetQuestions()[1] != q.GetQuestions()[1] {
+		t.Error("untranslated question Q2 should be reused")
+	}
+
+	// 原问卷不变
+	if q.GetTitle() != "睡眠问卷" || q.GetQuestions()[0].GetTitle() != "睡眠质量" {
+		t.Errorf("original questionnaire modified: %q/%q", q.GetTitle(), q.GetQuestions()[0].GetTitle())
+	}
+}
@@ -1,6 +1,7 @@
 package questionnaire
 
 import (
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
@@ -38,10 +39,15 @@ func (VersionService) Archive(q *Questionnaire) error {
 	return nil
 }
 
-// Clone 克隆问卷
+// Clone 克隆问卷，克隆出的问卷为下一版本的草稿
+// 题目列表、地域限制和标签会被复制，修改克隆出的问卷不影响原问卷；新版本的修订号从 0 开始
 func (VersionService) Clone(q *Questionnaire) *Questionnaire {
-	copy := *q
-	copy.status = STATUS_DRAFT
-	copy.version = copy.version.Increment()
-	return &copy
+	clone := *q
+	clone.status = STATUS_DRAFT
+	clone.version = clone.version.Increment()
+	clone.revision = 0
+	clone.questions = append([]question.Question(nil), q.questions...)
+	clone.geoRestriction = append([]string(nil), q.geoRestriction...)
+	clone.tags = append([]string(nil), q.tags...)
+	return &clone
 }
@@ -0,0 +1,58 @@
+package questionnaire
+
+import (
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestQuestionnaire_EnsureImmutability(t *testing.T) {
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9")
+	if err := q.EnsureImmutability(); err != nil {
+		t.Fatalf("EnsureImmutability() on draft error = %v", err)
+	}
+
+	q = NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9", WithStatus(STATUS_PUBLISHED))
+	if err := q.EnsureImmutability(); !errors.IsCode(err, code.ErrPublishedQuestionnaireImmutable) {
+		t.Errorf("EnsureImmutability() on published error = %v, want ErrPublishedQuestionnaireImmutable", err)
+	}
+}
+
+func TestVersionService_Clone_CreatesIndependentDraft(t *testing.T) {
+	q := NewQuestionnaire(NewQuestionnaireCode("PHQ9"), "PHQ-9",
+		WithVersion(NewQuestionnaireVersion("1.0")),
+		WithStatus(STATUS_PUBLISHED),
+		WithQuestions([]question.Question{newTestQuestion("Q1", question.QuestionTypeText)}),
+		WithGeoRestriction([]string{"CN"}),
+	)
+
+	clone := (VersionService{}).Clone(q)
+	if clone.GetStatus() != STATUS_DRAFT || clone.GetVersion().Value() != "1.1" {
+		t.Errorf("Clone() status/version = %v/%s, want draft/1.1", clone.GetStatus(), clone.GetVersion().Value())
+	}
+	if err := clone.EnsureImmutability(); err != nil {
+		t.Errorf("EnsureImmutability() on clone error = %v", err)
+	}
+
+	// 修改克隆出的问卷不影响原问卷
+	if err := NewQuestionService(QuestionLimits{}).AddQuestion(clone, newTestQuestion("Q2", question.QuestionTypeText)); err != nil {
+		t.Fatalf("AddQuestion() error = %v", err)
+	}
+	clone.geoRestriction[0] = "SG"
+	if len(q.GetQuestions()) != 1 || q.GetGeoRestriction()[0] != "CN" {
+		t.Errorf("original questionnaire changed: questions = %d, geo = %v", len(q.GetQuestions()), q.GetGeoRestriction())
+	}
+	if !q.IsPublished() || q.GetVersion().Value() != "1.0" {
+		t.Errorf("original status/version = %v/%s, want published/1.0", q.GetStatus(), q.GetVersion().Value())
+	}
+}
+
+func TestQuestionnaireVersion_Increment(t *testing.T) {
+	for version, want := range map[string]string{"1.0": "1.1", "2.9": "2.10", "3": "4", "v1": "v1"} {
+		if got := NewQuestionnaireVersion(version).Increment().Value(); got != want {
+			t.Errorf("Increment(%q) = %q, want %q", version, got, want)
+		}
+	}
+}
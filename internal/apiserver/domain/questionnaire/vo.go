@@ -1,6 +1,11 @@
 package questionnaire
 
-import "strconv"
+import (
+	"strconv"
+	"strings"
+
+	uuid "github.com/satori/go.uuid"
+)
 
 // QuestionnaireID 问卷唯一标识
 type QuestionnaireID struct {
@@ -71,11 +76,20 @@ func (v QuestionnaireVersion) Value() string {
 	return string(v)
 }
 
-// Increment 增加版本号
+// Increment 增加版本号，点分版本号递增最后一段，如 1.0 -> 1.1；最后一段不是数字时保持不变
 func (v QuestionnaireVersion) Increment() QuestionnaireVersion {
-	version, err := strconv.Atoi(v.Value())
+	prefix, last := "", v.Value()
+	if i := strings.LastIndex(last, "."); i >= 0 {
+		prefix, last = last[:i+1], last[i+1:]
+	}
+	version, err := strconv.Atoi(last)
 	if err != nil {
 		return v
 	}
-	return QuestionnaireVersion(strconv.Itoa(version + 1))
+	return QuestionnaireVersion(prefix + strconv.Itoa(version+1))
+}
+
+// GenerateQuestionnaireCode 生成问卷编码，格式为 qn- 加 8 位十六进制随机串
+func GenerateQuestionnaireCode() QuestionnaireCode {
+	return NewQuestionnaireCode("qn-" + uuid.NewV4().String()[:8])
 }
@@ -0,0 +1,75 @@
+package user
+
+import (
+	"context"
+	"time"
+)
+
+// 用户活动类型
+const (
+	ActivityLogin                = "login"
+	ActivityLoginFailed          = "login_failed"
+	ActivityLogout               = "logout"
+	ActivityPasswordChange       = "password_change"
+	ActivityRoleChange           = "role_change"
+	ActivityQuestionnairePublish = "questionnaire_publish"
+	ActivityAnswerSheetSubmit    = "answersheet_submit"
+)
+
+// 用户活动的严重级别，用于安全审计时优先关注高级别事件
+const (
+	SeverityInfo     = "info"
+	SeverityWarning  = "warning"
+	SeverityCritical = "critical"
+)
+
+// UserActivityLog 用户活动记录，按时间排列构成用户操作的时间线，用于安全审计
+type UserActivityLog struct {
+	UserID     uint64
+	Action     string
+	Detail     string
+	IPAddress  string
+	UserAgent  string
+	Severity   string
+	OccurredAt time.Time
+}
+
+// NewUserActivityLog 创建用户活动记录，严重级别由活动类型决定
+func NewUserActivityLog(userID uint64, action, detail, ip, userAgent string, now time.Time) *UserActivityLog {
+	return &UserActivityLog{
+		UserID:     userID,
+		Action:     action,
+		Detail:     detail,
+		IPAddress:  ip,
+		UserAgent:  userAgent,
+		Severity:   ActivitySeverity(action),
+		OccurredAt: now,
+	}
+}
+
+// ActivitySeverity 活动类型对应的严重级别
+// 登录失败为 warning，修改密码和变更角色为 critical，其他活动为 info
+func ActivitySeverity(action string) string {
+	switch action {
+	case ActivityLoginFailed:
+		return SeverityWarning
+	case ActivityPasswordChange, ActivityRoleChange:
+		return SeverityCritical
+	default:
+		return SeverityInfo
+	}
+}
+
+// userAgentKey 上下文中客户端 User-Agent 的键
+type userAgentKey struct{}
+
+// WithUserAgent 在上下文中携带客户端 User-Agent，记录用户活动时读取
+func WithUserAgent(ctx context.Context, userAgent string) context.Context {
+	return context.WithValue(ctx, userAgentKey{}, userAgent)
+}
+
+// UserAgentFromContext 读取上下文中的客户端 User-Agent，未携带时返回空字符串
+func UserAgentFromContext(ctx context.Context) string {
+	userAgent, _ := ctx.Value(userAgentKey{}).(string)
+	return userAgent
+}
@@ -54,6 +54,18 @@ func (b *UserBuilder) WithUpdatedAt(t time.Time) *UserBuilder {
 	b.u.updatedAt = t
 	return b
 }
+func (b *UserBuilder) WithOTPSecret(secret string) *UserBuilder {
+	b.u.otpSecret = secret
+	return b
+}
+func (b *UserBuilder) WithOTPLastStep(step int64) *UserBuilder {
+	b.u.otpLastStep = step
+	return b
+}
+func (b *UserBuilder) WithOTPPendingSecret(secret string) *UserBuilder {
+	b.u.otpPendingSecret = secret
+	return b
+}
 
 // WithPassword 设置密码（自动加密）
 func (b *UserBuilder) WithPassword(password string) *UserBuilder {
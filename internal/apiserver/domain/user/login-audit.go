@@ -0,0 +1,48 @@
+package user
+
+import "time"
+
+// LoginOutcome 登录结果
+type LoginOutcome string
+
+const (
+	// LoginOutcomeSuccess 认证成功
+	LoginOutcomeSuccess LoginOutcome = "success"
+	// LoginOutcomeFailure 认证失败
+	LoginOutcomeFailure LoginOutcome = "failure"
+)
+
+// LoginMethod 登录方式
+type LoginMethod string
+
+const (
+	// LoginMethodJWT 通过 /auth/login 登录
+	LoginMethodJWT LoginMethod = "jwt"
+	// LoginMethodBasic 通过 Basic 认证访问接口
+	LoginMethodBasic LoginMethod = "basic"
+)
+
+// LoginAudit 登录审计记录
+// 每次认证尝试（无论成功与否）记录一条，用于合规审计
+type LoginAudit struct {
+	Username  string
+	IP        string
+	UserAgent string
+	Method    LoginMethod
+	Outcome   LoginOutcome
+	Reason    string
+	CreatedAt time.Time
+}
+
+// NewLoginAudit 创建登录审计记录，认证失败时 reason 记录失败原因
+func NewLoginAudit(username, ip, userAgent string, method LoginMethod, outcome LoginOutcome, reason string, now time.Time) *LoginAudit {
+	return &LoginAudit{
+		Username:  username,
+		IP:        ip,
+		UserAgent: userAgent,
+		Method:    method,
+		Outcome:   outcome,
+		Reason:    reason,
+		CreatedAt: now,
+	}
+}
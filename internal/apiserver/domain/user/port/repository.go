@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 )
@@ -14,6 +15,10 @@ type UserRepository interface {
 	FindByID(ctx context.Context, id user.UserID) (*user.User, error)
 	Update(ctx context.Context, user *user.User) error
 	Remove(ctx context.Context, id user.UserID) error
+	// UpdateOTP 更新用户的 TOTP 密钥、待启用密钥和已使用的时间步，空值同样写入
+	UpdateOTP(ctx context.Context, user *user.User) error
+	// ConsumeOTPStep 记录已使用的一次性密码时间步，时间步不晚于已记录的时间步时返回 false，用于防止重放
+	ConsumeOTPStep(ctx context.Context, id user.UserID, step int64) (bool, error)
 
 	// 查询操作
 	FindByUsername(ctx context.Context, username string) (*user.User, error)
@@ -35,3 +40,51 @@ type UserRepository interface {
 	FindByIDs(ctx context.Context, ids []user.UserID) ([]*user.User, error)
 	FindByStatus(ctx context.Context, status user.Status, limit, offset int) ([]*user.User, error)
 }
+
+// UserPreferenceRepository 用户偏好设置存储库接口（出站端口）
+type UserPreferenceRepository interface {
+	// FindByUserID 根据用户ID查找偏好设置，不存在时返回 nil
+	FindByUserID(ctx context.Context, userID uint64) (*user.UserPreference, error)
+	// Upsert 保存偏好设置，不存在时创建
+	Upsert(ctx context.Context, pref *user.UserPreference) error
+}
+
+// UserScopeRepository 用户问卷范围存储库接口（出站端口）
+type UserScopeRepository interface {
+	// FindScopesByUserID 查找用户可管理的问卷范围，未配置时返回空列表
+	FindScopesByUserID(ctx context.Context, userID uint64) ([]string, error)
+}
+
+// UserSessionRepository 用户登录会话存储库接口（出站端口）
+type UserSessionRepository interface {
+	// Create 保存新会话
+	Create(ctx context.Context, session *user.Session) error
+	// FindByID 根据会话ID查找会话，不存在时返回 nil
+	FindByID(ctx context.Context, id string) (*user.Session, error)
+	// FindByUserID 查找用户所有未过期的会话，按创建时间倒序
+	FindByUserID(ctx context.Context, userID uint64) ([]*user.Session, error)
+	// Update 更新会话并重新计算过期时间
+	Update(ctx context.Context, session *user.Session) error
+	// Delete 删除会话
+	Delete(ctx context.Context, session *user.Session) error
+}
+
+// LoginAuditRepository 登录审计记录存储库接口（出站端口）
+type LoginAuditRepository interface {
+	// Create 保存登录审计记录
+	Create(ctx context.Context, audit *user.LoginAudit) error
+	// FindByUsername 分页查找用户的登录审计记录，按时间倒序
+	FindByUsername(ctx context.Context, username string, page, pageSize int) ([]*user.LoginAudit, error)
+	// CountByUsername 统计用户的登录审计记录数量
+	CountByUsername(ctx context.Context, username string) (int64, error)
+}
+
+// UserActivityLogRepository 用户活动记录存储库接口（出站端口）
+type UserActivityLogRepository interface {
+	// Create 保存用户活动记录
+	Create(ctx context.Context, log *user.UserActivityLog) error
+	// FindByUserID 分页查找用户在时间范围内的活动记录，按时间倒序，from、to 为零值时不限制
+	FindByUserID(ctx context.Context, userID uint64, from, to time.Time, page, pageSize int) ([]*user.UserActivityLog, error)
+	// CountByUserID 统计用户在时间范围内的活动记录数量
+	CountByUserID(ctx context.Context, userID uint64, from, to time.Time) (int64, error)
+}
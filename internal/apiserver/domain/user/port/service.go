@@ -2,6 +2,7 @@ package port
 
 import (
 	"context"
+	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 )
@@ -38,4 +39,74 @@ type UserActivator interface {
 // Authenticator 认证接口
 type Authenticator interface {
 	Authenticate(ctx context.Context, username, password string) (*user.User, error)
+	// LoadUser 按用户ID重新加载用户及其可管理的问卷范围，用于刷新令牌时更新负载
+	LoadUser(ctx context.Context, userID uint64) (*user.User, error)
+}
+
+// OTPManager 一次性密码（TOTP）二次验证接口
+type OTPManager interface {
+	// Setup 生成 TOTP 密钥并保存为待启用密钥，返回用于生成二维码的 otpauth URL
+	Setup(ctx context.Context, username string) (string, error)
+	// Enable 校验用待启用密钥生成的一次性密码，通过后启用该密钥
+	Enable(ctx context.Context, username, code string) error
+	// Verify 校验一次性密码，通过后返回用户（含可管理的问卷范围）
+	Verify(ctx context.Context, username, code string) (*user.User, error)
+}
+
+// LoginAuditor 登录审计接口
+type LoginAuditor interface {
+	// Record 记录一次认证尝试
+	Record(ctx context.Context, audit *user.LoginAudit) error
+	// ListByUsername 分页查询用户的登录记录，按时间倒序
+	ListByUsername(ctx context.Context, username string, page, pageSize int) ([]*user.LoginAudit, int64, error)
+}
+
+// CaptchaVerifier 验证码校验接口，可接入第三方人机验证服务
+type CaptchaVerifier interface {
+	// Verify 校验客户端提交的验证码令牌
+	Verify(ctx context.Context, token, clientIP string) (bool, error)
+}
+
+// LoginCaptchaGuard 登录验证码守卫接口，同一 IP 连续登录失败达到阈值后要求验证码
+type LoginCaptchaGuard interface {
+	// Check 校验登录请求，需要验证码时令牌缺失返回 ErrCaptchaRequired，校验失败返回 ErrCaptchaInvalid
+	Check(ctx context.Context, clientIP, token string) error
+	// RecordFailure 记录一次登录失败
+	RecordFailure(clientIP string)
+	// RecordSuccess 登录成功后清除失败记录
+	RecordSuccess(clientIP string)
+}
+
+// SessionManager 用户登录会话管理接口
+type SessionManager interface {
+	// CreateSession 登录成功后创建会话，达到并发会话上限时撤销最早的会话，rememberFor 大于 0 时开启“记住我”
+	CreateSession(ctx context.Context, userID uint64, roles []string, device, userAgent, ip string, rememberFor time.Duration) (*user.Session, error)
+	// ListSessions 列出用户的有效会话
+	ListSessions(ctx context.Context, userID uint64) ([]*user.Session, error)
+	// RevokeSession 撤销用户的指定会话
+	RevokeSession(ctx context.Context, userID uint64, sessionID string) error
+	// RevokeAllSessions 撤销用户的全部会话
+	RevokeAllSessions(ctx context.Context, userID uint64) error
+	// IsSessionActive 判断会话是否有效
+	IsSessionActive(ctx context.Context, sessionID string) (bool, error)
+	// TouchSession 记录会话活跃并延长有效期，返回会话是否有效
+	TouchSession(ctx context.Context, sessionID string) (bool, error)
+	// CanRefresh 判断会话的长期刷新令牌是否可用
+	CanRefresh(ctx context.Context, sessionID string) (bool, error)
+}
+
+// PreferenceManager 用户偏好设置管理接口
+type PreferenceManager interface {
+	// GetPreferences 获取用户偏好设置，用户尚未保存过时返回默认设置
+	GetPreferences(ctx context.Context, userID uint64) (*user.UserPreference, error)
+	// UpdatePreferences 校验并保存用户偏好设置，最近访问的问卷只保留最近的记录
+	UpdatePreferences(ctx context.Context, pref *user.UserPreference) error
+}
+
+// ActivityLogger 用户活动记录接口
+type ActivityLogger interface {
+	// LogActivity 异步记录一次用户活动，不阻塞调用方，客户端 User-Agent 从上下文读取
+	LogActivity(ctx context.Context, userID uint64, action, detail, ip string) error
+	// ListByUserID 分页查询用户在时间范围内的活动记录，按时间倒序
+	ListByUserID(ctx context.Context, userID uint64, from, to time.Time, page, pageSize int) ([]*user.UserActivityLog, int64, error)
 }
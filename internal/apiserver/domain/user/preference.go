@@ -0,0 +1,70 @@
+package user
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+const (
+	// ThemeLight 浅色主题
+	ThemeLight = "light"
+	// ThemeDark 深色主题
+	ThemeDark = "dark"
+
+	// DefaultLanguage 默认界面语言
+	DefaultLanguage = "zh-CN"
+	// MaxLastVisitedQuestionnaires 最近访问问卷的最大记录数
+	MaxLastVisitedQuestionnaires = 10
+)
+
+// UserPreference 用户偏好设置
+type UserPreference struct {
+	UserID                    uint64
+	Language                  string
+	Theme                     string
+	LastVisitedQuestionnaires []string
+	NotificationEnabled       bool
+}
+
+// NewDefaultUserPreference 创建默认偏好设置
+func NewDefaultUserPreference(userID uint64) *UserPreference {
+	return &UserPreference{
+		UserID:                    userID,
+		Language:                  DefaultLanguage,
+		Theme:                     ThemeLight,
+		LastVisitedQuestionnaires: []string{},
+		NotificationEnabled:       true,
+	}
+}
+
+// Normalize 规范化偏好设置，补全缺省值并截断最近访问记录
+func (p *UserPreference) Normalize() {
+	if p.Language == "" {
+		p.Language = DefaultLanguage
+	}
+	if p.Theme == "" {
+		p.Theme = ThemeLight
+	}
+	if p.LastVisitedQuestionnaires == nil {
+		p.LastVisitedQuestionnaires = []string{}
+	}
+	p.trimLastVisited()
+}
+
+// Validate 校验偏好设置
+func (p *UserPreference) Validate() error {
+	if p.UserID == 0 {
+		return errors.WithCode(code.ErrUserPreferenceInvalid, "用户ID不能为空")
+	}
+	if p.Theme != ThemeLight && p.Theme != ThemeDark {
+		return errors.WithCode(code.ErrUserPreferenceInvalid, "不支持的主题: %s", p.Theme)
+	}
+	return nil
+}
+
+// trimLastVisited 仅保留最近的 MaxLastVisitedQuestionnaires 条访问记录
+func (p *UserPreference) trimLastVisited() {
+	if overflow := len(p.LastVisitedQuestionnaires) - MaxLastVisitedQuestionnaires; overflow > 0 {
+		p.LastVisitedQuestionnaires = p.LastVisitedQuestionnaires[overflow:]
+	}
+}
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/pkg/util/idutil"
+)
+
+// sessionIDPrefix 会话ID前缀
+const sessionIDPrefix = "sess-"
+
+// Session 用户登录会话
+// 每次登录创建一条会话，JWT 通过 sid 关联会话，会话撤销或过期后关联的令牌随之失效
+// 勾选“记住我”的会话额外签发长期刷新令牌，有效期截止到 RefreshExpiresAt
+type Session struct {
+	ID               string
+	UserID           uint64
+	Device           string
+	UserAgent        string
+	IP               string
+	CreatedAt        time.Time
+	LastActiveAt     time.Time
+	RefreshExpiresAt *time.Time
+}
+
+// NewSession 创建登录会话
+func NewSession(userID uint64, device, userAgent, ip string, now time.Time) *Session {
+	return &Session{
+		ID:           idutil.GetUUID36(sessionIDPrefix),
+		UserID:       userID,
+		Device:       device,
+		UserAgent:    userAgent,
+		IP:           ip,
+		CreatedAt:    now,
+		LastActiveAt: now,
+	}
+}
+
+// Touch 记录会话的最近活跃时间
+func (s *Session) Touch(now time.Time) {
+	s.LastActiveAt = now
+}
+
+// RememberUntil 开启“记住我”，长期刷新令牌有效期截止到 expiresAt
+func (s *Session) RememberUntil(expiresAt time.Time) {
+	s.RefreshExpiresAt = &expiresAt
+}
+
+// Remembered 会话是否开启了“记住我”
+func (s *Session) Remembered() bool {
+	return s.RefreshExpiresAt != nil
+}
+
+// CanRefresh 长期刷新令牌在 now 时是否仍可用于换取访问令牌
+func (s *Session) CanRefresh(now time.Time) bool {
+	return s.Remembered() && now.Before(*s.RefreshExpiresAt)
+}
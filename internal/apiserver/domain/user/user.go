@@ -4,23 +4,28 @@ import (
 	"time"
 
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
 	"github.com/yshujie/questionnaire-scale/pkg/auth"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // User 用户聚合根
 type User struct {
-	id           UserID
-	username     string
-	password     string
-	nickname     string
-	avatar       string
-	email        string
-	phone        string
-	introduction string
-	status       Status
-	createdAt    time.Time
-	updatedAt    time.Time
+	id               UserID
+	username         string
+	password         string
+	nickname         string
+	avatar           string
+	email            string
+	phone            string
+	introduction     string
+	status           Status
+	scopes           []string
+	otpSecret        string
+	otpLastStep      int64
+	otpPendingSecret string
+	createdAt        time.Time
+	updatedAt        time.Time
 }
 
 // ID 获取用户ID
@@ -93,6 +98,61 @@ func (u *User) SetUpdatedAt(updatedAt time.Time) {
 	u.updatedAt = updatedAt
 }
 
+// Scopes 获取可管理的问卷范围，元素为问卷编码或通配模式
+func (u *User) Scopes() []string {
+	return u.scopes
+}
+
+// SetScopes 设置可管理的问卷范围（用于登录时从数据库读取）
+func (u *User) SetScopes(scopes []string) {
+	u.scopes = scopes
+}
+
+// OTPSecret 获取加密后的一次性密码（TOTP）密钥
+func (u *User) OTPSecret() string {
+	return u.otpSecret
+}
+
+// OTPLastStep 获取最近一次使用的一次性密码时间步
+func (u *User) OTPLastStep() int64 {
+	return u.otpLastStep
+}
+
+// ConsumeOTPStep 记录一次性密码的时间步，时间步不晚于上次使用时返回 false，防止重放
+func (u *User) ConsumeOTPStep(step int64) bool {
+	if step <= u.otpLastStep {
+		return false
+	}
+	u.otpLastStep = step
+	return true
+}
+
+// OTPEnabled 是否已开启一次性密码二次验证
+func (u *User) OTPEnabled() bool {
+	return u.otpSecret != ""
+}
+
+// OTPPendingSecret 获取加密后的待启用一次性密码（TOTP）密钥
+func (u *User) OTPPendingSecret() string {
+	return u.otpPendingSecret
+}
+
+// SetOTPPendingSecret 设置加密后的待启用 TOTP 密钥，校验通过前不影响当前的二次验证
+func (u *User) SetOTPPendingSecret(secret string) {
+	u.otpPendingSecret = secret
+}
+
+// ActivateOTPSecret 启用待启用的 TOTP 密钥，step 为校验通过的一次性密码的时间步，没有待启用的密钥时返回 false
+func (u *User) ActivateOTPSecret(step int64) bool {
+	if u.otpPendingSecret == "" {
+		return false
+	}
+	u.otpSecret = u.otpPendingSecret
+	u.otpPendingSecret = ""
+	u.otpLastStep = step
+	return true
+}
+
 // SetPassword 设置已加密的密码（用于从数据库读取）
 func (u *User) SetPassword(hashedPassword string) {
 	u.password = hashedPassword
@@ -134,10 +194,18 @@ func (u *User) ChangePhone(newPhone string) error {
 	return nil
 }
 
-// ChangePassword 修改密码
+// ValidatePasswordStrength 校验密码强度，长度至少 8 位且同时包含字母和数字，不满足时返回 ErrUserPasswordWeak
+func ValidatePasswordStrength(password string) error {
+	if !validation.IsStrongPassword(password) {
+		return errors.WithCode(code.ErrUserPasswordWeak, "%s", validation.PasswordWeakMessage)
+	}
+	return nil
+}
+
+// ChangePassword 修改密码，新密码须满足密码强度要求
 func (u *User) ChangePassword(newPassword string) error {
-	if len(newPassword) < 6 {
-		return errors.WithCode(code.ErrUserBasicInfoInvalid, "password must be at least 6 characters")
+	if err := ValidatePasswordStrength(newPassword); err != nil {
+		return err
 	}
 
 	// 使用 bcrypt 加密密码
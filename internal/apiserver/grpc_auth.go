@@ -0,0 +1,83 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	jwt "github.com/appleboy/gin-jwt/v2"
+	gojwt "github.com/golang-jwt/jwt/v4"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+)
+
+// grpcAuthConfig gRPC 认证配置，Bearer 令牌使用与 HTTP 接口相同的 JWT
+func grpcAuthConfig(opts *genericoptions.GRPCOptions, validator *grpcTokenValidator) middleware.GRPCAuthConfig {
+	return middleware.GRPCAuthConfig{
+		APIKeys:       opts.APIKeys,
+		ValidateToken: validator.Validate,
+		PublicMethods: opts.PublicMethods,
+	}
+}
+
+// grpcTokenValidator gRPC Bearer 令牌校验器
+// gRPC 服务器先于容器创建，会话管理在容器初始化后通过 UseSessionManager 注入
+type grpcTokenValidator struct {
+	key            []byte
+	mu             sync.RWMutex
+	sessionManager port.SessionManager
+}
+
+// newGRPCTokenValidator 创建 gRPC Bearer 令牌校验器，使用与 HTTP 接口相同的 JWT 签名密钥
+func newGRPCTokenValidator(jwtOptions *genericoptions.JwtOptions) *grpcTokenValidator {
+	return &grpcTokenValidator{key: []byte(jwtOptions.Key)}
+}
+
+// UseSessionManager 设置会话管理，为空时令牌不校验关联会话
+func (v *grpcTokenValidator) UseSessionManager(sessionManager port.SessionManager) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	v.sessionManager = sessionManager
+}
+
+// Validate 校验 JWT 访问令牌的签名、有效期和关联会话，返回令牌中的用户名
+// 长期刷新令牌、二次验证中间令牌和会话已撤销的令牌不能用于调用接口
+func (v *grpcTokenValidator) Validate(ctx context.Context, tokenString string) (string, error) {
+	token, err := gojwt.Parse(tokenString, func(token *gojwt.Token) (interface{}, error) {
+		if token.Method != gojwt.SigningMethodHS256 {
+			return nil, fmt.Errorf("unexpected signing method %s", token.Header["alg"])
+		}
+		return v.key, nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	claims, ok := token.Claims.(gojwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", fmt.Errorf("invalid token")
+	}
+	if isRefreshToken(claims) || otpRequired(claims) {
+		return "", fmt.Errorf("token cannot be used to call services")
+	}
+	username, _ := claims[jwt.IdentityKey].(string)
+	if username == "" {
+		return "", fmt.Errorf("token has no identity")
+	}
+
+	v.mu.RLock()
+	sessionManager := v.sessionManager
+	v.mu.RUnlock()
+	if sessionID, _ := claims[sessionIDClaim].(string); sessionID != "" && sessionManager != nil {
+		active, err := sessionManager.TouchSession(ctx, sessionID)
+		if err != nil {
+			return "", err
+		}
+		if !active {
+			return "", fmt.Errorf("session has been revoked")
+		}
+	}
+	return username, nil
+}
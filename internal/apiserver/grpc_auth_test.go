@@ -0,0 +1,43 @@
+package apiserver
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+)
+
+func TestGRPCTokenValidator_RejectsRevokedSession(t *testing.T) {
+	userObj := user.NewUserBuilder().WithID(user.NewUserID(1)).WithUsername("alice").Build()
+	engine, sessionManager := newSessionEngine(t, userObj, 5, nil)
+	laptopToken := sessionLogin(t, engine, "laptop")
+	phoneToken := sessionLogin(t, engine, "phone")
+
+	validator := newGRPCTokenValidator(newTestJwtOptions(time.Hour, 0))
+	validator.UseSessionManager(sessionManager)
+
+	ctx := context.Background()
+	if username, err := validator.Validate(ctx, phoneToken); err != nil || username != "alice" {
+		t.Fatalf("Validate() = %q, %v, want alice", username, err)
+	}
+
+	sessions, err := sessionManager.ListSessions(ctx, 1)
+	if err != nil {
+		t.Fatalf("ListSessions() error = %v", err)
+	}
+	for _, s := range sessions {
+		if s.Device == "phone" {
+			if err := sessionManager.RevokeSession(ctx, 1, s.ID); err != nil {
+				t.Fatalf("RevokeSession() error = %v", err)
+			}
+		}
+	}
+
+	if _, err := validator.Validate(ctx, phoneToken); err == nil {
+		t.Error("Validate() with revoked session succeeded, want error")
+	}
+	if _, err := validator.Validate(ctx, laptopToken); err != nil {
+		t.Errorf("Validate() with active session error = %v", err)
+	}
+}
@@ -0,0 +1,56 @@
+package apiserver
+
+import (
+	"context"
+	"sync"
+
+	answersheetpb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/answersheet"
+	medicalscalepb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+)
+
+// grpcRateLimits 内置单独限流的 gRPC 方法，其余方法使用 middleware.DefaultGRPCRateLimit
+var grpcRateLimits = map[string]middleware.RateLimitConfig{
+	medicalscalepb.MedicalScaleService_GetMedicalScaleByCode_FullMethodName: {Rate: 100, Burst: 100},
+	answersheetpb.AnswerSheetService_ListAnswerSheets_FullMethodName:        {Rate: 10, Burst: 10},
+}
+
+// grpcRateLimitConfig 合并内置方法限流与配置中的方法限流，同一方法以配置为准
+func grpcRateLimitConfig(opts *genericoptions.GRPCOptions) map[string]middleware.RateLimitConfig {
+	limits := make(map[string]middleware.RateLimitConfig, len(grpcRateLimits)+len(opts.RateLimits))
+	for method, limit := range grpcRateLimits {
+		limits[method] = limit
+	}
+	for _, limit := range opts.RateLimits {
+		limits[limit.Method] = middleware.RateLimitConfig{Rate: limit.Rate, Burst: limit.Burst}
+	}
+	return limits
+}
+
+// grpcRateLimitStore gRPC 限流使用的令牌桶存储
+// gRPC 服务器在连接 Redis 之前创建，先使用进程内存储，Redis 就绪后切换为共享存储
+type grpcRateLimitStore struct {
+	mu    sync.RWMutex
+	store middleware.RateLimitStore
+}
+
+// newGRPCRateLimitStore 创建 gRPC 限流存储
+func newGRPCRateLimitStore() *grpcRateLimitStore {
+	return &grpcRateLimitStore{store: middleware.NewMemoryRateLimitStore()}
+}
+
+// Use 切换底层令牌桶存储
+func (s *grpcRateLimitStore) Use(store middleware.RateLimitStore) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.store = store
+}
+
+// Allow 从 key 对应的令牌桶中取出一个令牌
+func (s *grpcRateLimitStore) Allow(ctx context.Context, key string, config middleware.RateLimitConfig) (bool, error) {
+	s.mu.RLock()
+	store := s.store
+	s.mu.RUnlock()
+	return store.Allow(ctx, key, config)
+}
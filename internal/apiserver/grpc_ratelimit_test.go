@@ -0,0 +1,53 @@
+package apiserver
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	answersheetpb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/answersheet"
+	medicalscalepb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+)
+
+func TestGRPCRateLimitConfig_ConfiguredMethodLimit(t *testing.T) {
+	const limited = "/questionnaire.QuestionnaireService/ListQuestionnaires"
+	opts := genericoptions.NewGRPCOptions()
+	opts.RateLimits = []genericoptions.GRPCRateLimitOptions{
+		{Method: limited, Rate: 0.001, Burst: 1},
+		{Method: answersheetpb.AnswerSheetService_ListAnswerSheets_FullMethodName, Rate: 1, Burst: 3},
+	}
+
+	limits := grpcRateLimitConfig(opts)
+	if got := limits[answersheetpb.AnswerSheetService_ListAnswerSheets_FullMethodName]; got.Burst != 3 {
+		t.Errorf("ListAnswerSheets limit = %+v, want the configured burst 3", got)
+	}
+	if got := limits[medicalscalepb.MedicalScaleService_GetMedicalScaleByCode_FullMethodName]; got.Burst != 100 {
+		t.Errorf("GetMedicalScaleByCode limit = %+v, want the built-in limit", got)
+	}
+
+	interceptor := middleware.GRPCRateLimitInterceptor(middleware.NewMemoryRateLimitStore(), limits)
+	call := func(method string) error {
+		_, err := interceptor(context.Background(), nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return "ok", nil
+		})
+		return err
+	}
+
+	if err := call(limited); err != nil {
+		t.Fatalf("first call error = %v", err)
+	}
+	if err := call(limited); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("call over the configured limit error = %v, want ResourceExhausted", err)
+	}
+	// 其他方法不受该方法限流影响
+	for i := 0; i < 3; i++ {
+		if err := call(medicalscalepb.MedicalScaleService_GetMedicalScaleByCode_FullMethodName); err != nil {
+			t.Fatalf("other method call %d error = %v", i+1, err)
+		}
+	}
+}
@@ -45,6 +45,11 @@ func (r *GRPCRegistry) RegisterServices() error {
 		return err
 	}
 
+	// 注册答卷计分服务
+	if err := r.registerScoringService(); err != nil {
+		return err
+	}
+
 	log.Info("✅ All GRPC services registered successfully")
 	return nil
 }
@@ -114,6 +119,20 @@ func (r *GRPCRegistry) registerInterpretReportService() error {
 	return nil
 }
 
+// registerScoringService 注册答卷计分服务
+func (r *GRPCRegistry) registerScoringService() error {
+	if r.container.AnswersheetModule == nil {
+		log.Warn("AnswersheetModule is not initialized, skipping scoring service registration")
+		return nil
+	}
+
+	// 创建并注册答卷计分服务
+	scoringService := service.NewScoringService(r.container.AnswersheetModule.AnswersheetScorer)
+	r.server.RegisterService(scoringService)
+	log.Info("   🧮 Scoring service registered")
+	return nil
+}
+
 // GetRegisteredServices 获取已注册的服务列表
 func (r *GRPCRegistry) GetRegisteredServices() []string {
 	services := make([]string, 0)
@@ -134,6 +153,10 @@ func (r *GRPCRegistry) GetRegisteredServices() []string {
 		services = append(services, "InterpretReportService")
 	}
 
+	if r.container.AnswersheetModule != nil {
+		services = append(services, "ScoringService")
+	}
+
 	// TODO: 添加其他服务
 	// if r.container.UserModule != nil {
 	//     services = append(services, "UserService")
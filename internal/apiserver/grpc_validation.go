@@ -0,0 +1,31 @@
+package apiserver
+
+import (
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	// 注册请求消息的 proto 描述，校验拦截器按字段全名解析必填字段
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/answersheet"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/interpret-report"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scale"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaire"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/scoring"
+)
+
+// grpcRequiredFields gRPC 请求的必填字段，缺少时由校验拦截器返回 codes.InvalidArgument
+var grpcRequiredFields = []protoreflect.FullName{
+	"answersheet.SaveAnswerSheetRequest.questionnaire_code",
+	"answersheet.GetAnswerSheetRequest.id",
+	"answersheet.SaveAnswerSheetScoresRequest.answer_sheet_id",
+	"interpret_report.SaveInterpretReportRequest.answer_sheet_id",
+	"interpret_report.SaveInterpretReportRequest.medical_scale_code",
+	"interpret_report.SaveInterpretReportRequest.title",
+	"interpret_report.SaveInterpretReportRequest.interpret_items",
+	"interpret_report.GetInterpretReportByAnswerSheetIDRequest.answer_sheet_id",
+	"medical_scale.GetMedicalScaleByCodeRequest.code",
+	"medical_scale.GetMedicalScaleByQuestionnaireCodeRequest.questionnaire_code",
+	"questionnaire.GetQuestionnaireRequest.code",
+	"questionnaire.GetQuestionnaireByCodeRequest.code",
+	"questionnaire.GetQuestionnaireByCodeVersionRequest.code",
+	"questionnaire.GetQuestionnaireByCodeVersionRequest.version",
+	"scoring.ScoreAnswersheetRequest.questionnaire_code",
+}
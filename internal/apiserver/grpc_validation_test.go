@@ -0,0 +1,50 @@
+package apiserver
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	medicalscalepb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scale"
+	questionnairepb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+)
+
+func TestGRPCValidationInterceptor_RequiredFields(t *testing.T) {
+	interceptor := middleware.GRPCValidationInterceptor(grpcRequiredFields...)
+
+	tests := []struct {
+		name        string
+		req         interface{}
+		wantCode    codes.Code
+		wantMessage string
+	}{
+		{"empty code", &medicalscalepb.GetMedicalScaleByCodeRequest{}, codes.InvalidArgument, "code must not be empty"},
+		{"blank code", &medicalscalepb.GetMedicalScaleByCodeRequest{Code: "  "}, codes.InvalidArgument, "code must not be empty"},
+		{"all missing fields listed", &questionnairepb.GetQuestionnaireByCodeVersionRequest{}, codes.InvalidArgument, "code, version must not be empty"},
+		{"valid request", &medicalscalepb.GetMedicalScaleByCodeRequest{Code: "PHQ9"}, codes.OK, ""},
+		{"request without required fields", &medicalscalepb.ListMedicalScalesRequest{}, codes.OK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			_, err := interceptor(context.Background(), tt.req, &grpc.UnaryServerInfo{FullMethod: "/test.Service/Method"}, func(ctx context.Context, req interface{}) (interface{}, error) {
+				called = true
+				return nil, nil
+			})
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %s, want %s (err = %v)", code, tt.wantCode, err)
+			}
+			if called != (tt.wantCode == codes.OK) {
+				t.Errorf("handler called = %v, want %v", called, tt.wantCode == codes.OK)
+			}
+			if !strings.Contains(status.Convert(err).Message(), tt.wantMessage) {
+				t.Errorf("message = %q, want it to contain %q", status.Convert(err).Message(), tt.wantMessage)
+			}
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package apiserver
+
+import (
+	medicalscalepb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+)
+
+// medicalScaleVersionNegotiation 医学量表服务的 API 版本协商规则
+// v2 新增游标分页，v1 客户端的响应保留分页令牌，否则 v1 客户端无法获取第一页之后的数据
+var medicalScaleVersionNegotiation = middleware.VersionNegotiationConfig{
+	ServicePrefix:     "/" + medicalscalepb.MedicalScaleService_ServiceDesc.ServiceName + "/",
+	SupportedVersions: []string{"v1", "v2"},
+}
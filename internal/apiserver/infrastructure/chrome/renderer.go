@@ -0,0 +1,84 @@
+package chrome
+
+import (
+	"context"
+	"time"
+
+	"github.com/chromedp/cdproto/page"
+	"github.com/chromedp/chromedp"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+)
+
+// DefaultTimeout 单次渲染（含启动浏览器）的默认超时时间
+const DefaultTimeout = 30 * time.Second
+
+// pageScale 页面按缩略图尺寸的倍数排版后再缩小截图，避免打印版式在过窄的视口中换行
+const pageScale = 3
+
+// jpegQuality 缩略图的 JPEG 质量
+const jpegQuality = 85
+
+// Config 无头浏览器配置
+type Config struct {
+	// ExecPath Chrome 可执行文件路径，为空时在 PATH 中查找
+	ExecPath string
+	// Timeout 单次渲染的超时时间，为 0 时使用 DefaultTimeout
+	Timeout time.Duration
+}
+
+// ThumbnailRenderer 基于无头 Chrome 的缩略图渲染器，每次渲染启动一个独立的浏览器进程
+type ThumbnailRenderer struct {
+	config Config
+}
+
+// NewThumbnailRenderer 创建缩略图渲染器
+func NewThumbnailRenderer(config Config) port.ThumbnailRenderer {
+	if config.Timeout <= 0 {
+		config.Timeout = DefaultTimeout
+	}
+	return &ThumbnailRenderer{config: config}
+}
+
+// Render 在 (width × pageScale) × (height × pageScale) 的视口中加载 HTML，截取首屏并缩小为 width × height 的 JPEG 图片
+func (r *ThumbnailRenderer) Render(ctx context.Context, html []byte, width, height int) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.config.Timeout)
+	defer cancel()
+
+	opts := chromedp.DefaultExecAllocatorOptions[:]
+	if r.config.ExecPath != "" {
+		opts = append(opts, chromedp.ExecPath(r.config.ExecPath))
+	}
+	allocCtx, cancelAlloc := chromedp.NewExecAllocator(ctx, opts...)
+	defer cancelAlloc()
+	browserCtx, cancelBrowser := chromedp.NewContext(allocCtx)
+	defer cancelBrowser()
+
+	viewportWidth, viewportHeight := float64(width*pageScale), float64(height*pageScale)
+	var thumbnail []byte
+	err := chromedp.Run(browserCtx,
+		chromedp.EmulateViewport(int64(viewportWidth), int64(viewportHeight)),
+		chromedp.Navigate("about:blank"),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			tree, err := page.GetFrameTree().Do(ctx)
+			if err != nil {
+				return err
+			}
+			return page.SetDocumentContent(tree.Frame.ID, string(html)).Do(ctx)
+		}),
+		chromedp.WaitReady("body", chromedp.ByQuery),
+		chromedp.ActionFunc(func(ctx context.Context) error {
+			var err error
+			thumbnail, err = page.CaptureScreenshot().
+				WithFormat(page.CaptureScreenshotFormatJpeg).
+				WithQuality(jpegQuality).
+				WithClip(&page.Viewport{Width: viewportWidth, Height: viewportHeight, Scale: 1.0 / pageScale}).
+				Do(ctx)
+			return err
+		}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return thumbnail, nil
+}
@@ -1,8 +1,11 @@
 package answersheet
 
 import (
+	"go.mongodb.org/mongo-driver/bson"
+
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer"
+	values "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/answer/types"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
@@ -30,6 +33,12 @@ func (m *AnswerSheetMapper) ToPO(bo *answersheet.AnswerSheet) *AnswerSheetPO {
 		}
 	}
 
+	// 转换答案事件
+	var events []AnswerEventPO
+	for _, event := range bo.GetAnswerEvents() {
+		events = append(events, m.mapAnswerEventToPO(event))
+	}
+
 	// 转换答卷者 - 只存储 userID
 	var writer *WriterPO
 	if bo.GetWriter() != nil {
@@ -53,8 +62,10 @@ func (m *AnswerSheetMapper) ToPO(bo *answersheet.AnswerSheet) *AnswerSheetPO {
 		Title:                bo.GetTitle(),
 		Score:                bo.GetScore(),
 		Answers:              answers,
+		AnswerEvents:         events,
 		Writer:               writer,
 		Testee:               testee,
+		Source:               bo.GetSource(),
 	}
 
 	// 设置时间字段
@@ -81,6 +92,12 @@ func (m *AnswerSheetMapper) ToBO(po *AnswerSheetPO) *answersheet.AnswerSheet {
 		answers = append(answers, m.mapAnswerToBO(answerPO))
 	}
 
+	// 转换答案事件
+	events := make([]answersheet.AnswerEvent, 0, len(po.AnswerEvents))
+	for _, eventPO := range po.AnswerEvents {
+		events = append(events, m.mapAnswerEventToBO(eventPO))
+	}
+
 	// 转换答卷者 - 只使用 userID 创建 Writer
 	var writer *user.Writer
 	if po.Writer != nil {
@@ -100,8 +117,10 @@ func (m *AnswerSheetMapper) ToBO(po *AnswerSheetPO) *answersheet.AnswerSheet {
 		answersheet.WithTitle(po.Title),
 		answersheet.WithScore(po.Score),
 		answersheet.WithAnswers(answers),
+		answersheet.WithAnswerEvents(events),
 		answersheet.WithWriter(writer),
 		answersheet.WithTestee(testee),
+		answersheet.WithSource(po.Source),
 		answersheet.WithCreatedAt(po.CreatedAt),
 		answersheet.WithUpdatedAt(po.UpdatedAt),
 	)
@@ -114,18 +133,98 @@ func (m *AnswerSheetMapper) mapAnswerToPO(answerBO answer.Answer) *AnswerPO {
 		QuestionType: answerBO.GetQuestionType(),
 		Score:        answerBO.GetScore(),
 		Value: AnswerValuePO{
-			Value: answerBO.GetValue().Raw(),
+			Value: m.mapAnswerValueToPO(answerBO),
 		},
 	}
 }
 
+// mapAnswerEventToPO 将答案事件转换为 AnswerEventPO
+func (m *AnswerSheetMapper) mapAnswerEventToPO(event answersheet.AnswerEvent) AnswerEventPO {
+	po := AnswerEventPO{
+		EventType:    event.EventType,
+		QuestionCode: event.QuestionCode.Value(),
+		NewValue:     *m.mapAnswerToPO(event.NewValue),
+		OccurredAt:   event.OccurredAt,
+	}
+	if event.OldValue != nil {
+		po.OldValue = m.mapAnswerToPO(*event.OldValue)
+	}
+	return po
+}
+
+// mapAnswerEventToBO 将 AnswerEventPO 转换为答案事件
+func (m *AnswerSheetMapper) mapAnswerEventToBO(eventPO AnswerEventPO) answersheet.AnswerEvent {
+	event := answersheet.AnswerEvent{
+		EventType:    eventPO.EventType,
+		QuestionCode: question.QuestionCode(eventPO.QuestionCode),
+		NewValue:     m.mapAnswerToBO(eventPO.NewValue),
+		OccurredAt:   eventPO.OccurredAt,
+	}
+	if eventPO.OldValue != nil {
+		old := m.mapAnswerToBO(*eventPO.OldValue)
+		event.OldValue = &old
+	}
+	return event
+}
+
+// mapAnswerValueToPO 转换答案值，文件引用使用带 bson 标签的持久化对象存储
+func (m *AnswerSheetMapper) mapAnswerValueToPO(answerBO answer.Answer) any {
+	raw := answerBO.GetValue().Raw()
+
+	files, ok := raw.([]values.FileReference)
+	if !ok {
+		return raw
+	}
+
+	filePOs := make([]FileReferencePO, 0, len(files))
+	for _, file := range files {
+		filePOs = append(filePOs, FileReferencePO{
+			FileName:   file.FileName,
+			MimeType:   file.MimeType,
+			StorageKey: file.StorageKey,
+			SizeBytes:  file.SizeBytes,
+		})
+	}
+	return filePOs
+}
+
 // mapAnswerToBO 将 AnswerPO 转换为答案领域对象
 func (m *AnswerSheetMapper) mapAnswerToBO(answerPO AnswerPO) answer.Answer {
 	ans, _ := answer.NewAnswer(
 		question.QuestionCode(answerPO.QuestionCode),
 		question.QuestionType(answerPO.QuestionType),
 		answerPO.Score,
-		answerPO.Value.Value,
+		m.mapAnswerValueToBO(answerPO),
 	)
 	return ans
 }
+
+// mapAnswerValueToBO 转换答案值，文件引用需从 bson 文档中解码
+func (m *AnswerSheetMapper) mapAnswerValueToBO(answerPO AnswerPO) any {
+	if question.QuestionType(answerPO.QuestionType) != question.QuestionTypeFileUpload {
+		return answerPO.Value.Value
+	}
+
+	data, err := bson.Marshal(bson.M{"files": answerPO.Value.Value})
+	if err != nil {
+		return nil
+	}
+
+	var holder struct {
+		Files []FileReferencePO `bson:"files"`
+	}
+	if err := bson.Unmarshal(data, &holder); err != nil {
+		return nil
+	}
+
+	files := make([]values.FileReference, 0, len(holder.Files))
+	for _, filePO := range holder.Files {
+		files = append(files, values.FileReference{
+			FileName:   filePO.FileName,
+			MimeType:   filePO.MimeType,
+			StorageKey: filePO.StorageKey,
+			SizeBytes:  filePO.SizeBytes,
+		})
+	}
+	return files
+}
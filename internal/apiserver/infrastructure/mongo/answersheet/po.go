@@ -14,13 +14,15 @@ import (
 // 对应MongoDB集合结构
 type AnswerSheetPO struct {
 	base.BaseDocument    `bson:",inline"`
-	QuestionnaireCode    string     `bson:"questionnaire_code" json:"questionnaire_code"`
-	QuestionnaireVersion string     `bson:"questionnaire_version" json:"questionnaire_version"`
-	Title                string     `bson:"title" json:"title"`
-	Score                float64    `bson:"score" json:"score"`
-	Answers              []AnswerPO `bson:"answers" json:"answers"`
-	Writer               *WriterPO  `bson:"writer" json:"writer"`
-	Testee               *TesteePO  `bson:"testee" json:"testee"`
+	QuestionnaireCode    string          `bson:"questionnaire_code" json:"questionnaire_code"`
+	QuestionnaireVersion string          `bson:"questionnaire_version" json:"questionnaire_version"`
+	Title                string          `bson:"title" json:"title"`
+	Score                float64         `bson:"score" json:"score"`
+	Answers              []AnswerPO      `bson:"answers" json:"answers"`
+	AnswerEvents         []AnswerEventPO `bson:"answer_events,omitempty" json:"answer_events,omitempty"`
+	Writer               *WriterPO       `bson:"writer" json:"writer"`
+	Testee               *TesteePO       `bson:"testee" json:"testee"`
+	Source               string          `bson:"source,omitempty" json:"source,omitempty"`
 }
 
 // CollectionName 集合名称
@@ -41,7 +43,10 @@ func (p *AnswerSheetPO) BeforeInsert() {
 	// 添加调试日志
 	log.Infof("生成答卷DomainID: %d", domainID)
 
-	p.CreatedAt = time.Now()
+	// 导入的历史答卷保留原始作答时间
+	if p.CreatedAt.IsZero() {
+		p.CreatedAt = time.Now()
+	}
 	p.UpdatedAt = time.Now()
 	p.DeletedAt = nil
 
@@ -118,6 +123,15 @@ func (p *AnswerPO) ToBsonM() (bson.M, error) {
 	return result, nil
 }
 
+// AnswerEventPO 答案事件持久化对象
+type AnswerEventPO struct {
+	EventType    string    `bson:"event_type" json:"event_type"`
+	QuestionCode string    `bson:"question_code" json:"question_code"`
+	OldValue     *AnswerPO `bson:"old_value,omitempty" json:"old_value,omitempty"`
+	NewValue     AnswerPO  `bson:"new_value" json:"new_value"`
+	OccurredAt   time.Time `bson:"occurred_at" json:"occurred_at"`
+}
+
 // AnswerValuePO 答案值持久化对象
 type AnswerValuePO struct {
 	Value interface{} `bson:"value" json:"value"`
@@ -139,6 +153,14 @@ func (p *AnswerValuePO) ToBsonM() (bson.M, error) {
 	return result, nil
 }
 
+// FileReferencePO 文件引用持久化对象
+type FileReferencePO struct {
+	FileName   string `bson:"file_name" json:"file_name"`
+	MimeType   string `bson:"mime_type" json:"mime_type"`
+	StorageKey string `bson:"storage_key" json:"storage_key"`
+	SizeBytes  int64  `bson:"size_bytes" json:"size_bytes"`
+}
+
 // WriterPO 答卷者持久化对象
 type WriterPO struct {
 	UserID uint64 `bson:"id" json:"id"`
@@ -24,7 +24,7 @@ type Repository struct {
 func NewRepository(db *mongo.Database) port.AnswerSheetRepositoryMongo {
 	po := &AnswerSheetPO{}
 	return &Repository{
-		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName()),
+		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName(), mongoBase.WithSchema(mongoBase.CollectionSchema(po.CollectionName()))),
 		mapper:         NewAnswerSheetMapper(),
 	}
 }
@@ -153,7 +153,8 @@ func (r *Repository) CountWithConditions(ctx context.Context, conditions map[str
 		"$exists": false,
 	}
 
-	return r.CountDocuments(ctx, filter)
+	// 统计查询可容忍复制延迟，优先读从节点
+	return r.ReadFrom(mongoBase.ReadPreferenceSecondaryPreferred).CountDocuments(ctx, filter)
 }
 
 // FindByQuestionnaireCode 根据问卷代码查找答卷列表
@@ -170,7 +171,8 @@ func (r *Repository) FindByQuestionnaireCode(ctx context.Context, questionnaireC
 		SetLimit(limit).
 		SetSort(bson.M{"created_at": -1}) // 按创建时间倒序
 
-	cursor, err := r.Find(ctx, filter, opts)
+	// 按问卷查询答卷列表属于后台报表查询，优先读从节点
+	cursor, err := r.ReadFrom(mongoBase.ReadPreferenceSecondaryPreferred).Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -207,7 +209,8 @@ func (r *Repository) FindByQuestionnaireCodeAndVersion(ctx context.Context, ques
 		SetLimit(limit).
 		SetSort(bson.M{"created_at": -1}) // 按创建时间倒序
 
-	cursor, err := r.Find(ctx, filter, opts)
+	// 按问卷查询答卷列表属于后台报表查询，优先读从节点
+	cursor, err := r.ReadFrom(mongoBase.ReadPreferenceSecondaryPreferred).Find(ctx, filter, opts)
 	if err != nil {
 		return nil, err
 	}
@@ -310,6 +313,96 @@ func (r *Repository) HardDelete(ctx context.Context, id uint64) error {
 	return nil
 }
 
+// PurgeDeleted 物理删除在指定时间之前软删除的答卷，返回删除数量
+func (r *Repository) PurgeDeleted(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	filter := bson.M{
+		"deleted_at": bson.M{
+			"$ne": nil,
+			"$lt": deletedBefore,
+		},
+	}
+
+	result, err := r.Collection().DeleteMany(ctx, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.DeletedCount, nil
+}
+
+// IterateByQuestionnaire 按创建时间顺序遍历问卷在 [from, to) 内的答卷
+func (r *Repository) IterateByQuestionnaire(ctx context.Context, questionnaireCode string, from, to time.Time, fn func(*answersheet.AnswerSheet) error) error {
+	filter := bson.M{
+		"questionnaire_code": questionnaireCode,
+		"deleted_at":         nil,
+	}
+
+	createdAt := bson.M{}
+	if !from.IsZero() {
+		createdAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		createdAt["$lt"] = to
+	}
+	if len(createdAt) > 0 {
+		filter["created_at"] = createdAt
+	}
+
+	opts := options.Find().SetSort(bson.M{"created_at": 1})
+
+	// 导出为批量读取，优先读从节点以减轻主节点压力
+	cursor, err := r.ReadFrom(mongoBase.ReadPreferenceSecondaryPreferred).Find(ctx, filter, opts)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var po AnswerSheetPO
+		if err := cursor.Decode(&po); err != nil {
+			return err
+		}
+		if err := fn(r.mapper.ToBO(&po)); err != nil {
+			return err
+		}
+	}
+
+	return cursor.Err()
+}
+
+// FindLatestByQuestionnaire 按创建时间倒序查询问卷最近的 limit 份答卷
+func (r *Repository) FindLatestByQuestionnaire(ctx context.Context, questionnaireCode string, limit int) ([]*answersheet.AnswerSheet, error) {
+	filter := bson.M{
+		"questionnaire_code": questionnaireCode,
+		"deleted_at":         nil,
+	}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"created_at": -1})
+
+	// 统计分析为批量读取，优先读从节点以减轻主节点压力
+	cursor, err := r.ReadFrom(mongoBase.ReadPreferenceSecondaryPreferred).Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var answerSheets []*answersheet.AnswerSheet
+	for cursor.Next(ctx) {
+		var po AnswerSheetPO
+		if err := cursor.Decode(&po); err != nil {
+			return nil, err
+		}
+		answerSheets = append(answerSheets, r.mapper.ToBO(&po))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return answerSheets, nil
+}
+
 // ExistsByID 检查ID是否存在
 func (r *Repository) ExistsByID(ctx context.Context, id uint64) (bool, error) {
 	filter := bson.M{
@@ -318,3 +411,62 @@ func (r *Repository) ExistsByID(ctx context.Context, id uint64) (bool, error) {
 
 	return r.ExistsByFilter(ctx, filter)
 }
+
+// FindBatchByQuestionnaire 按答卷ID升序查询问卷中ID大于 afterID 的至多 limit 份答卷
+func (r *Repository) FindBatchByQuestionnaire(ctx context.Context, questionnaireCode string, afterID uint64, limit int) ([]*answersheet.AnswerSheet, error) {
+	filter := bson.M{
+		"questionnaire_code": questionnaireCode,
+		"domain_id":          bson.M{"$gt": afterID},
+		"deleted_at":         nil,
+	}
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"domain_id": 1})
+
+	cursor, err := r.Collection().Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var answerSheets []*answersheet.AnswerSheet
+	for cursor.Next(ctx) {
+		var po AnswerSheetPO
+		if err := cursor.Decode(&po); err != nil {
+			return nil, err
+		}
+		answerSheets = append(answerSheets, r.mapper.ToBO(&po))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return answerSheets, nil
+}
+
+// UpdateScores 使用 bulkWrite 批量更新答卷的总分和各题得分，不修改答卷的其他字段
+func (r *Repository) UpdateScores(ctx context.Context, sheets []*answersheet.AnswerSheet) error {
+	if len(sheets) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]mongo.WriteModel, 0, len(sheets))
+	for _, sheet := range sheets {
+		po := r.mapper.ToPO(sheet)
+		if po == nil {
+			continue
+		}
+		models = append(models, mongo.NewUpdateOneModel().
+			SetFilter(bson.M{"domain_id": sheet.GetID().Value()}).
+			SetUpdate(bson.M{"$set": bson.M{
+				"score":      po.Score,
+				"answers":    po.Answers,
+				"updated_at": now,
+			}}))
+	}
+
+	_, err := r.Collection().BulkWrite(ctx, models, options.BulkWrite().SetOrdered(false))
+	return err
+}
@@ -5,24 +5,84 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/santhosh-tekuri/jsonschema/v5"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
 )
 
+// ReadPreference 读偏好
+type ReadPreference string
+
+const (
+	// ReadPreferencePrimary 只从主节点读取
+	ReadPreferencePrimary ReadPreference = "primary"
+	// ReadPreferenceSecondary 只从从节点读取
+	ReadPreferenceSecondary ReadPreference = "secondary"
+	// ReadPreferenceSecondaryPreferred 优先从从节点读取，无可用从节点时读主节点
+	ReadPreferenceSecondaryPreferred ReadPreference = "secondaryPreferred"
+	// ReadPreferenceNearest 从网络延迟最低的节点读取
+	ReadPreferenceNearest ReadPreference = "nearest"
+)
+
+// readPref 转换为驱动的读偏好，未知取值按主节点处理
+func (p ReadPreference) readPref() *readpref.ReadPref {
+	switch p {
+	case ReadPreferenceSecondary:
+		return readpref.Secondary()
+	case ReadPreferenceSecondaryPreferred:
+		return readpref.SecondaryPreferred()
+	case ReadPreferenceNearest:
+		return readpref.Nearest()
+	default:
+		return readpref.Primary()
+	}
+}
+
+// collectionOptions 创建带读偏好的集合选项
+func collectionOptions(pref ReadPreference) *options.CollectionOptions {
+	return options.Collection().SetReadPreference(pref.readPref())
+}
+
 // BaseRepository MongoDB基础存储库
 type BaseRepository struct {
-	db         *mongo.Database
-	collection *mongo.Collection
+	db             *mongo.Database
+	collection     *mongo.Collection
+	collectionName string
+	readPreference ReadPreference
+	schema         *jsonschema.Schema // 插入前校验文档的 JSON Schema，为空时不校验
 }
 
-// NewBaseRepository 创建基础存储库
-func NewBaseRepository(db *mongo.Database, collectionName string) BaseRepository {
-	return BaseRepository{
-		db:         db,
-		collection: db.Collection(collectionName),
+// BaseRepositoryOption 基础存储库选项
+type BaseRepositoryOption func(*BaseRepository)
+
+// WithReadPreference 设置存储库默认读偏好
+func WithReadPreference(pref ReadPreference) BaseRepositoryOption {
+	return func(r *BaseRepository) {
+		r.readPreference = pref
+	}
+}
+
+// NewBaseRepository 创建基础存储库，默认从主节点读取
+func NewBaseRepository(db *mongo.Database, collectionName string, opts ...BaseRepositoryOption) BaseRepository {
+	r := BaseRepository{
+		db:             db,
+		collectionName: collectionName,
+		readPreference: ReadPreferencePrimary,
 	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+
+	// 主节点读取时沿用数据库连接的读偏好配置
+	if r.readPreference == ReadPreferencePrimary {
+		r.collection = db.Collection(collectionName)
+	} else {
+		r.collection = db.Collection(collectionName, collectionOptions(r.readPreference))
+	}
+	return r
 }
 
 // DB 获取数据库连接
@@ -35,14 +95,44 @@ func (r *BaseRepository) Collection() *mongo.Collection {
 	return r.collection
 }
 
-// InsertOne 插入一条文档
+// ReadPreference 获取读偏好
+func (r *BaseRepository) ReadPreference() ReadPreference {
+	return r.readPreference
+}
+
+// ReadFrom 返回使用指定读偏好的存储库副本，用于单次查询
+// 报表、统计等可容忍延迟的查询可以读从节点以减轻主节点压力
+func (r *BaseRepository) ReadFrom(pref ReadPreference) *BaseRepository {
+	if pref == r.readPreference {
+		return r
+	}
+
+	collection, err := r.collection.Clone(collectionOptions(pref))
+	if err != nil {
+		// 仅在集合选项非法时失败，此时沿用原读偏好
+		return r
+	}
+
+	return &BaseRepository{
+		db:             r.db,
+		collection:     collection,
+		collectionName: r.collectionName,
+		readPreference: pref,
+		schema:         r.schema,
+	}
+}
+
+// InsertOne 插入一条文档，配置了 JSON Schema 时先校验文档，不符合时返回 ErrDocumentSchemaValidation
 func (r *BaseRepository) InsertOne(ctx context.Context, document interface{}) (*mongo.InsertOneResult, error) {
+	if err := r.validateDocument(document); err != nil {
+		return nil, err
+	}
 	return r.collection.InsertOne(ctx, document)
 }
 
 // FindOne 查找一条文档
-func (r *BaseRepository) FindOne(ctx context.Context, filter bson.M, result interface{}) error {
-	return r.collection.FindOne(ctx, filter).Decode(result)
+func (r *BaseRepository) FindOne(ctx context.Context, filter bson.M, result interface{}, opts ...*options.FindOneOptions) error {
+	return r.collection.FindOne(ctx, filter, opts...).Decode(result)
 }
 
 // FindByID 根据ObjectID查找文档
@@ -56,6 +146,11 @@ func (r *BaseRepository) UpdateOne(ctx context.Context, filter bson.M, update bs
 	return r.collection.UpdateOne(ctx, filter, update)
 }
 
+// UpdateMany 更新多条文档
+func (r *BaseRepository) UpdateMany(ctx context.Context, filter bson.M, update bson.M) (*mongo.UpdateResult, error) {
+	return r.collection.UpdateMany(ctx, filter, update)
+}
+
 // UpdateByID 根据ObjectID更新文档
 func (r *BaseRepository) UpdateByID(ctx context.Context, id primitive.ObjectID, update bson.M) (*mongo.UpdateResult, error) {
 	filter := bson.M{"_id": id}
@@ -67,6 +162,11 @@ func (r *BaseRepository) DeleteOne(ctx context.Context, filter bson.M) (*mongo.D
 	return r.collection.DeleteOne(ctx, filter)
 }
 
+// DeleteMany 删除多条文档
+func (r *BaseRepository) DeleteMany(ctx context.Context, filter bson.M) (*mongo.DeleteResult, error) {
+	return r.collection.DeleteMany(ctx, filter)
+}
+
 // DeleteByID 根据ObjectID删除文档
 func (r *BaseRepository) DeleteByID(ctx context.Context, id primitive.ObjectID) (*mongo.DeleteResult, error) {
 	filter := bson.M{"_id": id}
@@ -0,0 +1,121 @@
+package mongo
+
+import (
+	"context"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+	"go.mongodb.org/mongo-driver/mongo/options"
+	"go.mongodb.org/mongo-driver/mongo/readpref"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// newTestDatabase 创建不连接服务端的数据库句柄
+func newTestDatabase(t *testing.T) *mongo.Database {
+	t.Helper()
+	client, err := mongo.Connect(context.Background(), options.Client().ApplyURI("mongodb://127.0.0.1:27017"))
+	if err != nil {
+		t.Fatalf("mongo.Connect() error = %v", err)
+	}
+	t.Cleanup(func() { _ = client.Disconnect(context.Background()) })
+	return client.Database("test")
+}
+
+func TestCollectionOptions_SetsReadPreference(t *testing.T) {
+	tests := []struct {
+		pref ReadPreference
+		want readpref.Mode
+	}{
+		{ReadPreferencePrimary, readpref.PrimaryMode},
+		{ReadPreferenceSecondary, readpref.SecondaryMode},
+		{ReadPreferenceSecondaryPreferred, readpref.SecondaryPreferredMode},
+		{ReadPreferenceNearest, readpref.NearestMode},
+		{ReadPreference("unknown"), readpref.PrimaryMode},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.pref), func(t *testing.T) {
+			opts := collectionOptions(tt.pref)
+			if opts.ReadPreference == nil || opts.ReadPreference.Mode() != tt.want {
+				t.Errorf("ReadPreference = %v, want mode %v", opts.ReadPreference, tt.want)
+			}
+		})
+	}
+}
+
+func TestBaseRepository_ReadPreference(t *testing.T) {
+	db := newTestDatabase(t)
+
+	r := NewBaseRepository(db, "answersheets")
+	if r.ReadPreference() != ReadPreferencePrimary {
+		t.Errorf("default ReadPreference = %s, want primary", r.ReadPreference())
+	}
+
+	secondary := r.ReadFrom(ReadPreferenceSecondary)
+	if secondary.ReadPreference() != ReadPreferenceSecondary {
+		t.Errorf("ReadFrom(secondary).ReadPreference() = %s, want secondary", secondary.ReadPreference())
+	}
+	if secondary.Collection() == r.Collection() {
+		t.Error("ReadFrom(secondary) reused the primary collection")
+	}
+	if r.ReadPreference() != ReadPreferencePrimary {
+		t.Errorf("ReadFrom changed the repository read preference to %s", r.ReadPreference())
+	}
+
+	nearest := NewBaseRepository(db, "answersheets", WithReadPreference(ReadPreferenceNearest))
+	if nearest.ReadPreference() != ReadPreferenceNearest {
+		t.Errorf("WithReadPreference(nearest) = %s, want nearest", nearest.ReadPreference())
+	}
+}
+
+func TestBaseRepository_InsertOneSchemaValidation(t *testing.T) {
+	for _, name := range []string{"questionnaires", "answersheets", "medical_scales"} {
+		if CollectionSchema(name) == nil {
+			t.Fatalf("CollectionSchema(%q) = nil, want embedded schema", name)
+		}
+	}
+
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	newRepo := func(mt *mtest.T) BaseRepository {
+		return NewBaseRepository(mt.DB, "answersheets", WithSchema(CollectionSchema("answersheets")))
+	}
+	valid := func() bson.M {
+		return bson.M{
+			"_id":                   primitive.NewObjectID(),
+			"domain_id":             int64(1001),
+			"questionnaire_code":    "QN1",
+			"questionnaire_version": "1.0",
+			"answers":               bson.A{bson.M{"question_code": "Q1", "question_type": "Radio", "score": 1.5}},
+			"writer":                bson.M{"id": int64(1)},
+			"testee":                bson.M{"id": int64(2)},
+		}
+	}
+
+	mt.Run("valid document is inserted", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+		r := newRepo(mt)
+		if _, err := r.InsertOne(context.Background(), valid()); err != nil {
+			t.Fatalf("InsertOne() error = %v", err)
+		}
+	})
+
+	mt.Run("missing required field is rejected before the write", func(mt *mtest.T) {
+		doc := valid()
+		delete(doc, "questionnaire_code")
+
+		r := newRepo(mt)
+		_, err := r.InsertOne(context.Background(), doc)
+		if !errors.IsCode(err, code.ErrDocumentSchemaValidation) {
+			t.Fatalf("InsertOne() error = %v, want ErrDocumentSchemaValidation", err)
+		}
+		if started := mt.GetStartedEvent(); started != nil {
+			t.Errorf("InsertOne() sent %s to MongoDB, want no write", started.CommandName)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package file
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// bucketName GridFS 存储桶名称
+const bucketName = "answer_files"
+
+// filePO GridFS 文件文档，length 为实际写入的字节数
+type filePO struct {
+	Name     string `bson:"filename"`
+	Length   int64  `bson:"length"`
+	Metadata struct {
+		MimeType string `bson:"mime_type"`
+	} `bson:"metadata"`
+}
+
+// Repository 文件存储库（基于 GridFS）
+type Repository struct {
+	db *mongo.Database
+}
+
+// NewRepository 创建文件存储库
+func NewRepository(db *mongo.Database) port.FileStorageRepository {
+	return &Repository{db: db}
+}
+
+// bucket 获取 GridFS 存储桶
+func (r *Repository) bucket() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(r.db, options.GridFSBucket().SetName(bucketName))
+}
+
+// Store 存储文件，返回存储键
+func (r *Repository) Store(ctx context.Context, reader io.Reader, meta port.FileMeta) (string, error) {
+	bucket, err := r.bucket()
+	if err != nil {
+		return "", err
+	}
+
+	uploadOpts := options.GridFSUpload().SetMetadata(bson.M{
+		"mime_type":  meta.MimeType,
+		"size_bytes": meta.SizeBytes,
+	})
+
+	stream, err := bucket.OpenUploadStream(meta.FileName, uploadOpts)
+	if err != nil {
+		return "", err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := stream.SetWriteDeadline(deadline); err != nil {
+			_ = stream.Abort()
+			return "", err
+		}
+	}
+
+	if _, err := io.Copy(stream, reader); err != nil {
+		_ = stream.Abort()
+		return "", err
+	}
+
+	// 关闭上传流时才会写入文件元数据
+	if err := stream.Close(); err != nil {
+		return "", err
+	}
+
+	fileID, ok := stream.FileID.(primitive.ObjectID)
+	if !ok {
+		return "", fmt.Errorf("unexpected gridfs file id type: %T", stream.FileID)
+	}
+
+	return fileID.Hex(), nil
+}
+
+// Retrieve 根据存储键读取文件
+func (r *Repository) Retrieve(ctx context.Context, key string) (io.ReadCloser, error) {
+	fileID, err := primitive.ObjectIDFromHex(key)
+	if err != nil {
+		return nil, err
+	}
+
+	bucket, err := r.bucket()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := bucket.OpenDownloadStream(fileID)
+	if err != nil {
+		return nil, err
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := stream.SetReadDeadline(deadline); err != nil {
+			stream.Close()
+			return nil, err
+		}
+	}
+
+	return stream, nil
+}
+
+// Stat 根据存储键查询文件元信息
+func (r *Repository) Stat(ctx context.Context, key string) (*port.FileMeta, error) {
+	fileID, err := primitive.ObjectIDFromHex(key)
+	if err != nil {
+		return nil, errors.WithCode(code.ErrUploadNotFound, "文件 %s 不存在", key)
+	}
+
+	var po filePO
+	err = r.db.Collection(bucketName+".files").FindOne(ctx, bson.M{"_id": fileID}).Decode(&po)
+	if err == mongo.ErrNoDocuments {
+		return nil, errors.WithCode(code.ErrUploadNotFound, "文件 %s 不存在", key)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &port.FileMeta{
+		FileName:  po.Name,
+		MimeType:  po.Metadata.MimeType,
+		SizeBytes: po.Length,
+	}, nil
+}
@@ -35,6 +35,11 @@ func (m *Mapper) ToEntity(po *InterpretReportPO) (*interpretreport.InterpretRepo
 	options = append(options, interpretreport.WithDescription(po.Description))
 	options = append(options, interpretreport.WithInterpretItems(items))
 
+	// 如果报告展示百分制得分
+	if po.NormalizeToPercentage {
+		options = append(options, interpretreport.WithPercentageScore(po.PercentageScore))
+	}
+
 	// 如果有被试者信息
 	if po.Testee != nil {
 		testee := user.Testee{
@@ -96,6 +101,9 @@ func (m *Mapper) ToPO(entity *interpretreport.InterpretReport) (*InterpretReport
 		Description:      entity.GetDescription(),
 		Testee:           testeePO,
 		InterpretItems:   items,
+
+		NormalizeToPercentage: entity.IsNormalizedToPercentage(),
+		PercentageScore:       entity.GetPercentageScore(),
 	}
 
 	return po, nil
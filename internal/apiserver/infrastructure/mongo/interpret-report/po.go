@@ -19,6 +19,9 @@ type InterpretReportPO struct {
 	Description       string            `bson:"description" json:"description"`
 	Testee            *TesteePO         `bson:"testee" json:"testee"`
 	InterpretItems    []InterpretItemPO `bson:"interpret_items" json:"interpret_items"`
+
+	NormalizeToPercentage bool    `bson:"normalize_to_percentage,omitempty" json:"normalize_to_percentage,omitempty"`
+	PercentageScore       float64 `bson:"percentage_score,omitempty" json:"percentage_score,omitempty"`
 }
 
 // TesteePO 被试者持久化对象
@@ -135,8 +135,8 @@ func (r *Repository) FindList(ctx context.Context, page, pageSize int, condition
 	findOptions.SetLimit(int64(pageSize))
 	findOptions.SetSort(bson.M{"created_at": -1}) // 按创建时间倒序
 
-	// 查询数据
-	cursor, err := r.Find(ctx, filter, findOptions)
+	// 查询数据，列表查询可容忍复制延迟，优先读从节点
+	cursor, err := r.ReadFrom(base.ReadPreferenceSecondaryPreferred).Find(ctx, filter, findOptions)
 	if err != nil {
 		return nil, fmt.Errorf("查询解读报告列表失败: %v", err)
 	}
@@ -200,8 +200,8 @@ func (r *Repository) CountWithConditions(ctx context.Context, conditions map[str
 		}
 	}
 
-	// 统计数量
-	count, err := r.CountDocuments(ctx, filter)
+	// 统计数量，优先读从节点
+	count, err := r.ReadFrom(base.ReadPreferenceSecondaryPreferred).CountDocuments(ctx, filter)
 	if err != nil {
 		return 0, fmt.Errorf("统计解读报告数量失败: %v", err)
 	}
@@ -0,0 +1,37 @@
+package loginaudit
+
+import "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+
+// LoginAuditMapper 登录审计记录映射器
+type LoginAuditMapper struct{}
+
+// NewLoginAuditMapper 创建登录审计记录映射器
+func NewLoginAuditMapper() *LoginAuditMapper {
+	return &LoginAuditMapper{}
+}
+
+// ToPO 将领域对象转换为持久化对象
+func (m *LoginAuditMapper) ToPO(audit *user.LoginAudit) *LoginAuditPO {
+	return &LoginAuditPO{
+		Username:  audit.Username,
+		IP:        audit.IP,
+		UserAgent: audit.UserAgent,
+		Method:    string(audit.Method),
+		Outcome:   string(audit.Outcome),
+		Reason:    audit.Reason,
+		CreatedAt: audit.CreatedAt,
+	}
+}
+
+// ToBO 将持久化对象转换为领域对象
+func (m *LoginAuditMapper) ToBO(po *LoginAuditPO) *user.LoginAudit {
+	return &user.LoginAudit{
+		Username:  po.Username,
+		IP:        po.IP,
+		UserAgent: po.UserAgent,
+		Method:    user.LoginMethod(po.Method),
+		Outcome:   user.LoginOutcome(po.Outcome),
+		Reason:    po.Reason,
+		CreatedAt: po.CreatedAt,
+	}
+}
@@ -0,0 +1,19 @@
+package loginaudit
+
+import "time"
+
+// LoginAuditPO 登录审计记录MongoDB持久化对象
+type LoginAuditPO struct {
+	Username  string    `bson:"username" json:"username"`
+	IP        string    `bson:"ip" json:"ip"`
+	UserAgent string    `bson:"user_agent" json:"user_agent"`
+	Method    string    `bson:"method" json:"method"`
+	Outcome   string    `bson:"outcome" json:"outcome"`
+	Reason    string    `bson:"reason,omitempty" json:"reason,omitempty"`
+	CreatedAt time.Time `bson:"created_at" json:"created_at"`
+}
+
+// CollectionName 集合名称
+func (LoginAuditPO) CollectionName() string {
+	return "login_audits"
+}
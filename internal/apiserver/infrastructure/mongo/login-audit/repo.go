@@ -0,0 +1,64 @@
+package loginaudit
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	mongoBase "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo"
+)
+
+// Repository 登录审计记录MongoDB存储库
+type Repository struct {
+	mongoBase.BaseRepository
+	mapper *LoginAuditMapper
+}
+
+// NewRepository 创建登录审计记录MongoDB存储库
+func NewRepository(db *mongo.Database) port.LoginAuditRepository {
+	po := &LoginAuditPO{}
+	return &Repository{
+		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName()),
+		mapper:         NewLoginAuditMapper(),
+	}
+}
+
+// Create 保存登录审计记录
+func (r *Repository) Create(ctx context.Context, audit *user.LoginAudit) error {
+	_, err := r.InsertOne(ctx, r.mapper.ToPO(audit))
+	return err
+}
+
+// FindByUsername 分页查找用户的登录审计记录
+func (r *Repository) FindByUsername(ctx context.Context, username string, page, pageSize int) ([]*user.LoginAudit, error) {
+	opts := options.Find().
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.M{"created_at": -1}) // 按时间倒序
+
+	cursor, err := r.Find(ctx, bson.M{"username": username}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []LoginAuditPO
+	if err := cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	audits := make([]*user.LoginAudit, 0, len(pos))
+	for i := range pos {
+		audits = append(audits, r.mapper.ToBO(&pos[i]))
+	}
+	return audits, nil
+}
+
+// CountByUsername 统计用户的登录审计记录数量
+func (r *Repository) CountByUsername(ctx context.Context, username string) (int64, error) {
+	return r.CountDocuments(ctx, bson.M{"username": username})
+}
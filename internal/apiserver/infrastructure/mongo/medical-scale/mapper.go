@@ -1,12 +1,18 @@
 package medicalscale
 
 import (
+	"encoding/json"
+
 	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/factor/ability"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
 	base "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/interpretation"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
@@ -37,17 +43,19 @@ func (m *MedicalScaleMapper) ToPO(bo *medicalscale.MedicalScale) *MedicalScalePO
 		BaseDocument: base.BaseDocument{
 			ID: primitive.NewObjectID(),
 		},
-		Code:              bo.GetCode(),
-		Title:             bo.GetTitle(),
-		QuestionnaireCode: bo.GetQuestionnaireCode(),
-		Factors:           factors,
+		Code:               bo.GetCode(),
+		Title:              bo.GetTitle(),
+		QuestionnaireCode:  bo.GetQuestionnaireCode(),
+		Factors:            factors,
+		ScoringConfig:      m.mapScoringConfigToPO(bo.GetScoringConfig()),
+		RuleStrategyConfig: string(bo.GetRuleStrategyConfig()),
 	}
 }
 
-// ToBO 将MongoDB持久化对象转换为领域对象
-func (m *MedicalScaleMapper) ToBO(po *MedicalScalePO) *medicalscale.MedicalScale {
+// ToBO 将MongoDB持久化对象转换为领域对象，计分策略配置无效时返回错误
+func (m *MedicalScaleMapper) ToBO(po *MedicalScalePO) (*medicalscale.MedicalScale, error) {
 	if po == nil {
-		return nil
+		return nil, nil
 	}
 
 	// 转换因子列表
@@ -58,13 +66,99 @@ func (m *MedicalScaleMapper) ToBO(po *MedicalScalePO) *medicalscale.MedicalScale
 		}
 	}
 
-	return medicalscale.NewMedicalScale(
+	bo := medicalscale.NewMedicalScale(
 		po.Code,
 		po.Title,
 		medicalscale.WithID(v1.NewID(po.DomainID)),
 		medicalscale.WithQuestionnaireCode(po.QuestionnaireCode),
 		medicalscale.WithFactors(factors),
+		medicalscale.WithScoringConfig(m.mapScoringConfigToBO(po.ScoringConfig)),
 	)
+
+	// 加载时将计分策略配置解析为计分策略，配置无效时不能按默认策略计分，直接返回错误
+	if err := bo.SetRuleStrategyConfig(json.RawMessage(po.RuleStrategyConfig)); err != nil {
+		return nil, errors.WrapC(err, code.ErrScoringConfigInvalid, "医学量表 %s 的计分策略配置无效", po.Code)
+	}
+	return bo, nil
+}
+
+// mapScoringConfigToPO 将计分配置转换为持久化对象
+func (m *MedicalScaleMapper) mapScoringConfigToPO(config medicalscale.ScoringConfig) ScoringConfigPO {
+	var bands []ScoreBandPO
+	for _, band := range config.ScoreBands {
+		bands = append(bands, ScoreBandPO{
+			ScoreRange: ScoreRangePO{
+				MinScore: band.GetScoreRange().MinScore(),
+				MaxScore: band.GetScoreRange().MaxScore(),
+			},
+			Label:       band.GetLabel(),
+			Description: band.GetDescription(),
+		})
+	}
+	return ScoringConfigPO{
+		NormalizeToPercentage: config.NormalizeToPercentage,
+		ScoreBands:            bands,
+		Dimensions:            m.mapDimensionsToPO(config.Dimensions),
+	}
+}
+
+// mapDimensionsToPO 将计分维度转换为持久化对象
+func (m *MedicalScaleMapper) mapDimensionsToPO(dimensions []scoring.ScoringDimension) []DimensionPO {
+	var pos []DimensionPO
+	for _, d := range dimensions {
+		questionCodes := make([]string, len(d.QuestionCodes))
+		for i, questionCode := range d.QuestionCodes {
+			questionCodes[i] = questionCode.Value()
+		}
+		po := DimensionPO{
+			Code:          d.Code,
+			Label:         d.Label,
+			QuestionCodes: questionCodes,
+		}
+		if d.NormGroup != nil {
+			po.NormGroup = &NormGroupPO{Mean: d.NormGroup.Mean, StdDev: d.NormGroup.StdDev}
+		}
+		pos = append(pos, po)
+	}
+	return pos
+}
+
+// mapDimensionsToBO 将计分维度持久化对象转换为领域对象
+func (m *MedicalScaleMapper) mapDimensionsToBO(pos []DimensionPO) []scoring.ScoringDimension {
+	var dimensions []scoring.ScoringDimension
+	for _, po := range pos {
+		questionCodes := make([]question.QuestionCode, len(po.QuestionCodes))
+		for i, questionCode := range po.QuestionCodes {
+			questionCodes[i] = question.NewQuestionCode(questionCode)
+		}
+		d := scoring.ScoringDimension{
+			Code:          po.Code,
+			Label:         po.Label,
+			QuestionCodes: questionCodes,
+		}
+		if po.NormGroup != nil {
+			d.NormGroup = &scoring.NormGroup{Mean: po.NormGroup.Mean, StdDev: po.NormGroup.StdDev}
+		}
+		dimensions = append(dimensions, d)
+	}
+	return dimensions
+}
+
+// mapScoringConfigToBO 将计分配置持久化对象转换为领域对象
+func (m *MedicalScaleMapper) mapScoringConfigToBO(po ScoringConfigPO) medicalscale.ScoringConfig {
+	var bands []medicalscale.ScoreBand
+	for _, band := range po.ScoreBands {
+		bands = append(bands, medicalscale.NewScoreBand(
+			interpretation.NewScoreRange(band.ScoreRange.MinScore, band.ScoreRange.MaxScore),
+			band.Label,
+			band.Description,
+		))
+	}
+	return medicalscale.ScoringConfig{
+		NormalizeToPercentage: po.NormalizeToPercentage,
+		ScoreBands:            bands,
+		Dimensions:            m.mapDimensionsToBO(po.Dimensions),
+	}
 }
 
 // mapFactorToPO 将因子领域对象转换为持久化对象
@@ -80,6 +174,7 @@ func (m *MedicalScaleMapper) mapFactorToPO(bo *factor.Factor) *FactorPO {
 		calculationRule = CalculationRulePO{
 			FormulaType: rule.GetFormula().String(),
 			SourceCodes: rule.GetSourceCodes(),
+			Threshold:   rule.GetThreshold(),
 		}
 	}
 
@@ -117,10 +212,11 @@ func (m *MedicalScaleMapper) mapFactorToBO(po *FactorPO) *factor.Factor {
 	// 转换计算规则
 	var calculationAbility *ability.CalculationAbility
 	if po.CalculationRule.FormulaType != "" {
-		rule := calculation.NewCalculationRule(
-			calculation.FormulaType(po.CalculationRule.FormulaType),
-			po.CalculationRule.SourceCodes,
-		)
+		formula := calculation.FormulaType(po.CalculationRule.FormulaType)
+		rule := calculation.NewCalculationRule(formula, po.CalculationRule.SourceCodes)
+		if formula == calculation.FormulaTypeCountAboveThreshold {
+			rule = calculation.NewCountAboveThresholdCalculationRule(po.CalculationRule.SourceCodes, po.CalculationRule.Threshold)
+		}
 		calculationAbility = &ability.CalculationAbility{}
 		calculationAbility.SetCalculationRule(rule)
 	}
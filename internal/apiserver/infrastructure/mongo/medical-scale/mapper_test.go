@@ -0,0 +1,24 @@
+package medicalscale
+
+import (
+	"testing"
+
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestMedicalScaleMapper_ToBO_RuleStrategyConfig(t *testing.T) {
+	mapper := NewMedicalScaleMapper()
+
+	scale, err := mapper.ToBO(&MedicalScalePO{Code: "SDS", RuleStrategyConfig: `{"type": "simple_sum"}`})
+	if err != nil {
+		t.Fatalf("ToBO() error = %v", err)
+	}
+	if string(scale.GetRuleStrategyConfig()) != `{"type": "simple_sum"}` {
+		t.Errorf("rule strategy config = %s", scale.GetRuleStrategyConfig())
+	}
+
+	if _, err := mapper.ToBO(&MedicalScalePO{Code: "SDS", RuleStrategyConfig: `{"type": "unknown"}`}); !errors.IsCode(err, errCode.ErrScoringConfigInvalid) {
+		t.Errorf("ToBO() with invalid config error = %v, want ErrScoringConfigInvalid", err)
+	}
+}
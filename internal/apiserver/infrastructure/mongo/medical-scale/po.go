@@ -13,11 +13,41 @@ import (
 // MedicalScalePO 医学量表MongoDB持久化对象
 type MedicalScalePO struct {
 	base.BaseDocument    `bson:",inline"`
-	Code                 string     `bson:"code" json:"code"`
-	Title                string     `bson:"title" json:"title"`
-	QuestionnaireCode    string     `bson:"questionnaire_code" json:"questionnaire_code"`
-	QuestionnaireVersion string     `bson:"questionnaire_version" json:"questionnaire_version"`
-	Factors              []FactorPO `bson:"factors" json:"factors"`
+	Code                 string          `bson:"code" json:"code"`
+	Title                string          `bson:"title" json:"title"`
+	QuestionnaireCode    string          `bson:"questionnaire_code" json:"questionnaire_code"`
+	QuestionnaireVersion string          `bson:"questionnaire_version" json:"questionnaire_version"`
+	Factors              []FactorPO      `bson:"factors" json:"factors"`
+	ScoringConfig        ScoringConfigPO `bson:"scoring_config" json:"scoring_config"`
+	RuleStrategyConfig   string          `bson:"rule_strategy_config,omitempty" json:"rule_strategy_config,omitempty"`
+}
+
+// ScoringConfigPO 计分配置持久化对象
+type ScoringConfigPO struct {
+	NormalizeToPercentage bool          `bson:"normalize_to_percentage" json:"normalize_to_percentage"`
+	ScoreBands            []ScoreBandPO `bson:"score_bands,omitempty" json:"score_bands,omitempty"`
+	Dimensions            []DimensionPO `bson:"dimensions,omitempty" json:"dimensions,omitempty"`
+}
+
+// DimensionPO 计分维度持久化对象
+type DimensionPO struct {
+	Code          string       `bson:"code" json:"code"`
+	Label         string       `bson:"label" json:"label"`
+	QuestionCodes []string     `bson:"question_codes" json:"question_codes"`
+	NormGroup     *NormGroupPO `bson:"norm_group,omitempty" json:"norm_group,omitempty"`
+}
+
+// NormGroupPO 常模组持久化对象
+type NormGroupPO struct {
+	Mean   float64 `bson:"mean" json:"mean"`
+	StdDev float64 `bson:"std_dev" json:"std_dev"`
+}
+
+// ScoreBandPO 分数段持久化对象
+type ScoreBandPO struct {
+	ScoreRange  ScoreRangePO `bson:"score_range" json:"score_range"`
+	Label       string       `bson:"label" json:"label"`
+	Description string       `bson:"description,omitempty" json:"description,omitempty"`
 }
 
 // CollectionName 集合名称
@@ -139,6 +169,7 @@ func (p *FactorPO) ToBsonM() (bson.M, error) {
 type CalculationRulePO struct {
 	FormulaType string   `bson:"formula_type" json:"formula_type"`
 	SourceCodes []string `bson:"source_codes" json:"source_codes"`
+	Threshold   float64  `bson:"threshold,omitempty" json:"threshold,omitempty"`
 }
 
 // ToBsonM 将 CalculationRulePO 转换为 bson.M
@@ -24,7 +24,7 @@ type Repository struct {
 func NewRepository(db *mongo.Database) port.MedicalScaleRepositoryMongo {
 	po := &MedicalScalePO{}
 	return &Repository{
-		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName()),
+		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName(), mongoBase.WithSchema(mongoBase.CollectionSchema(po.CollectionName()))),
 		mapper:         NewMedicalScaleMapper(),
 	}
 }
@@ -71,7 +71,7 @@ func (r *Repository) FindByID(ctx context.Context, id v1.ID) (*medicalScale.Medi
 		return nil, err
 	}
 
-	return r.mapper.ToBO(&po), nil
+	return r.mapper.ToBO(&po)
 }
 
 // FindByCode 根据代码查找医学量表
@@ -89,7 +89,7 @@ func (r *Repository) FindByCode(ctx context.Context, code string) (*medicalScale
 		return nil, err
 	}
 
-	return r.mapper.ToBO(&po), nil
+	return r.mapper.ToBO(&po)
 }
 
 // FindByQuestionnaireCode 根据问卷代码查找医学量表列表
@@ -110,7 +110,11 @@ func (r *Repository) FindByQuestionnaireCode(ctx context.Context, questionnaireC
 		if err := cursor.Decode(&po); err != nil {
 			return nil, err
 		}
-		scales = append(scales, r.mapper.ToBO(&po))
+		scale, err := r.mapper.ToBO(&po)
+		if err != nil {
+			return nil, err
+		}
+		scales = append(scales, scale)
 	}
 
 	if err := cursor.Err(); err != nil {
@@ -262,7 +266,50 @@ func (r *Repository) FindList(ctx context.Context, page, pageSize int, condition
 		if err := cursor.Decode(&po); err != nil {
 			return nil, err
 		}
-		scales = append(scales, r.mapper.ToBO(&po))
+		scale, err := r.mapper.ToBO(&po)
+		if err != nil {
+			return nil, err
+		}
+		scales = append(scales, scale)
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return scales, nil
+}
+
+// FindListAfterCode 按编码升序获取编码大于 lastCode 的医学量表，lastCode 为空时从第一条开始
+func (r *Repository) FindListAfterCode(ctx context.Context, lastCode string, limit int) ([]*medicalScale.MedicalScale, error) {
+	filter := bson.M{
+		"deleted_at": bson.M{"$exists": false},
+	}
+	if lastCode != "" {
+		filter["code"] = bson.M{"$gt": lastCode}
+	}
+
+	opts := options.Find().
+		SetLimit(int64(limit)).
+		SetSort(bson.M{"code": 1})
+
+	cursor, err := r.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var scales []*medicalScale.MedicalScale
+	for cursor.Next(ctx) {
+		var po MedicalScalePO
+		if err := cursor.Decode(&po); err != nil {
+			return nil, err
+		}
+		scale, err := r.mapper.ToBO(&po)
+		if err != nil {
+			return nil, err
+		}
+		scales = append(scales, scale)
 	}
 
 	if err := cursor.Err(); err != nil {
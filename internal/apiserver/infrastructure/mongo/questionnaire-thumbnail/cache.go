@@ -0,0 +1,108 @@
+package questionnairethumbnail
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/gridfs"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+)
+
+// bucketName GridFS 存储桶名称
+const bucketName = "questionnaire_thumbnails"
+
+// Cache 问卷缩略图缓存（基于 GridFS），以缓存键作为文件名
+type Cache struct {
+	db *mongo.Database
+}
+
+// NewCache 创建问卷缩略图缓存
+func NewCache(db *mongo.Database) port.ThumbnailCache {
+	return &Cache{db: db}
+}
+
+// bucket 获取 GridFS 存储桶
+func (c *Cache) bucket() (*gridfs.Bucket, error) {
+	return gridfs.NewBucket(c.db, options.GridFSBucket().SetName(bucketName))
+}
+
+// Get 读取缩略图，同名文件有多个时取最新上传的版本，不存在时返回 nil
+func (c *Cache) Get(ctx context.Context, key string) ([]byte, error) {
+	bucket, err := c.bucket()
+	if err != nil {
+		return nil, err
+	}
+
+	stream, err := bucket.OpenDownloadStreamByName(key)
+	if errors.Is(err, gridfs.ErrFileNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer stream.Close()
+
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := stream.SetReadDeadline(deadline); err != nil {
+			return nil, err
+		}
+	}
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(stream); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// Save 保存缩略图，上传成功后删除同名的旧文件
+func (c *Cache) Save(ctx context.Context, key string, data []byte) error {
+	bucket, err := c.bucket()
+	if err != nil {
+		return err
+	}
+
+	uploadOpts := options.GridFSUpload().SetMetadata(bson.M{"mime_type": "image/jpeg"})
+	fileID, err := bucket.UploadFromStream(key, bytes.NewReader(data), uploadOpts)
+	if err != nil {
+		return err
+	}
+
+	return c.deleteFiles(ctx, bucket, bson.M{"filename": key, "_id": bson.M{"$ne": fileID}})
+}
+
+// Delete 删除缩略图，不存在时不报错
+func (c *Cache) Delete(ctx context.Context, key string) error {
+	bucket, err := c.bucket()
+	if err != nil {
+		return err
+	}
+	return c.deleteFiles(ctx, bucket, bson.M{"filename": key})
+}
+
+// deleteFiles 删除符合条件的文件及其数据块
+func (c *Cache) deleteFiles(ctx context.Context, bucket *gridfs.Bucket, filter bson.M) error {
+	cursor, err := bucket.FindContext(ctx, filter)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	var files []struct {
+		ID interface{} `bson:"_id"`
+	}
+	if err := cursor.All(ctx, &files); err != nil {
+		return err
+	}
+	for _, file := range files {
+		if err := bucket.DeleteContext(ctx, file.ID); err != nil && !errors.Is(err, gridfs.ErrFileNotFound) {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package questionnairetranslation
+
+import "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+
+// TranslationMapper 问卷译文映射器
+type TranslationMapper struct{}
+
+// NewTranslationMapper 创建问卷译文映射器
+func NewTranslationMapper() *TranslationMapper {
+	return &TranslationMapper{}
+}
+
+// ToPO 将领域对象转换为持久化对象
+func (m *TranslationMapper) ToPO(questionnaireCode string, t *questionnaire.Translation) *TranslationPO {
+	return &TranslationPO{
+		QuestionnaireCode: questionnaireCode,
+		Language:          t.Language,
+		FieldPath:         t.FieldPath,
+		TranslatedText:    t.TranslatedText,
+		Status:            t.Status,
+		TranslatedBy:      t.TranslatedBy,
+		ApprovedBy:        t.ApprovedBy,
+	}
+}
+
+// ToBO 将持久化对象转换为领域对象
+func (m *TranslationMapper) ToBO(po *TranslationPO) *questionnaire.Translation {
+	return &questionnaire.Translation{
+		Language:       po.Language,
+		FieldPath:      po.FieldPath,
+		TranslatedText: po.TranslatedText,
+		Status:         po.Status,
+		TranslatedBy:   po.TranslatedBy,
+		ApprovedBy:     po.ApprovedBy,
+	}
+}
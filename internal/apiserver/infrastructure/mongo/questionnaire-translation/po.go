@@ -0,0 +1,21 @@
+package questionnairetranslation
+
+import "time"
+
+// TranslationPO 问卷译文MongoDB持久化对象
+// 以 questionnaire_code、language 和 field_path 作为唯一键
+type TranslationPO struct {
+	QuestionnaireCode string    `bson:"questionnaire_code" json:"questionnaire_code"`
+	Language          string    `bson:"language" json:"language"`
+	FieldPath         string    `bson:"field_path" json:"field_path"`
+	TranslatedText    string    `bson:"translated_text" json:"translated_text"`
+	Status            string    `bson:"status" json:"status"`
+	TranslatedBy      uint64    `bson:"translated_by" json:"translated_by"`
+	ApprovedBy        *uint64   `bson:"approved_by,omitempty" json:"approved_by,omitempty"`
+	UpdatedAt         time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CollectionName 集合名称
+func (TranslationPO) CollectionName() string {
+	return "questionnaire_translations"
+}
@@ -0,0 +1,96 @@
+package questionnairetranslation
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	mongoBase "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo"
+)
+
+// Repository 问卷译文MongoDB存储库
+type Repository struct {
+	mongoBase.BaseRepository
+	mapper *TranslationMapper
+}
+
+// NewRepository 创建问卷译文MongoDB存储库
+func NewRepository(db *mongo.Database) port.TranslationRepository {
+	po := &TranslationPO{}
+	return &Repository{
+		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName()),
+		mapper:         NewTranslationMapper(),
+	}
+}
+
+// Save 保存译文，文档不存在时创建
+func (r *Repository) Save(ctx context.Context, questionnaireCode string, translation *questionnaire.Translation) error {
+	po := r.mapper.ToPO(questionnaireCode, translation)
+	po.UpdatedAt = time.Now()
+
+	_, err := r.Collection().ReplaceOne(
+		ctx,
+		translationFilter(questionnaireCode, po.Language, po.FieldPath),
+		po,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
+
+// FindOne 查询问卷指定语言和字段路径的译文，不存在时返回 nil
+func (r *Repository) FindOne(ctx context.Context, questionnaireCode, language, fieldPath string) (*questionnaire.Translation, error) {
+	var po TranslationPO
+	err := r.BaseRepository.FindOne(ctx, translationFilter(questionnaireCode, language, fieldPath), &po)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.mapper.ToBO(&po), nil
+}
+
+// FindByLanguage 查询问卷指定语言的全部译文，按字段路径排序
+func (r *Repository) FindByLanguage(ctx context.Context, questionnaireCode, language string) ([]*questionnaire.Translation, error) {
+	filter := bson.M{
+		"questionnaire_code": questionnaireCode,
+		"language":           language,
+	}
+	opts := options.Find().SetSort(bson.M{"field_path": 1})
+
+	cursor, err := r.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var translations []*questionnaire.Translation
+	for cursor.Next(ctx) {
+		var po TranslationPO
+		if err := cursor.Decode(&po); err != nil {
+			return nil, err
+		}
+		translations = append(translations, r.mapper.ToBO(&po))
+	}
+
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	return translations, nil
+}
+
+// translationFilter 译文唯一键的查询条件
+func translationFilter(questionnaireCode, language, fieldPath string) bson.M {
+	return bson.M{
+		"questionnaire_code": questionnaireCode,
+		"language":           language,
+		"field_path":         fieldPath,
+	}
+}
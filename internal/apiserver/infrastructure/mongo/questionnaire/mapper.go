@@ -3,6 +3,7 @@ package questionnaire
 import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	_ "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question/types" // 注册题型工厂
 	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
 )
@@ -25,25 +26,28 @@ func (m *QuestionnaireMapper) ToPO(bo *questionnaire.Questionnaire) *Questionnai
 		ImgUrl:      bo.GetImgUrl(),
 		Version:     bo.GetVersion().Value(),
 		Status:      bo.GetStatus().Value(),
+
+		GeoRestriction: bo.GetGeoRestriction(),
+		Tags:           bo.GetTags(),
+		Revision:       bo.GetRevision(),
 	}
 
 	for _, questionBO := range bo.GetQuestions() {
 		questionPO := QuestionPO{
-			Code:            questionBO.GetCode().Value(),
-			Title:           questionBO.GetTitle(),
-			QuestionType:    string(questionBO.GetType()),
-			Tips:            questionBO.GetTips(),
-			Placeholder:     questionBO.GetPlaceholder(),
-			Options:         m.mapOptions(questionBO.GetOptions()),
-			ValidationRules: m.mapValidationRules(questionBO.GetValidationRules()),
-			CalculationRule: m.mapCalculationRule(questionBO.GetCalculationRule()),
-		}
+			Code:             questionBO.GetCode().Value(),
+			Title:            questionBO.GetTitle(),
+			QuestionType:     string(questionBO.GetType()),
+			Tips:             questionBO.GetTips(),
+			Placeholder:      questionBO.GetPlaceholder(),
+			Options:          m.mapOptions(questionBO.GetOptions()),
+			AllowedMIMETypes: questionBO.GetAllowedMIMETypes(),
+			MaxFileSizeBytes: questionBO.GetMaxFileSizeBytes(),
+			MaxFiles:         questionBO.GetMaxFiles(),
+			PageBreakBefore:  questionBO.GetPageBreakBefore(),
+			ValidationRules:  m.mapValidationRules(questionBO.GetValidationRules()),
+			CalculationRule:  m.mapCalculationRule(questionBO.GetCalculationRule()),
 
-		// 处理计算规则（可能为nil）
-		if rule := questionBO.GetCalculationRule(); rule != nil {
-			questionPO.CalculationRule = CalculationRulePO{
-				Formula: string(rule.GetFormula()),
-			}
+			ConditionalRequired: m.mapConditionalRequired(questionBO.GetConditionalRequired()),
 		}
 
 		po.Questions = append(po.Questions, questionPO)
@@ -80,19 +84,45 @@ func (m *QuestionnaireMapper) mapValidationRules(rules []validation.ValidationRu
 		rulesPO = append(rulesPO, ValidationRulePO{
 			RuleType:    string(rule.GetRuleType()),
 			TargetValue: rule.GetTargetValue(),
+			Severity:    string(rule.GetSeverity()),
 		})
 	}
 	return rulesPO
 }
 
+// mapConditionalRequired 转换条件必填规则
+func (m *QuestionnaireMapper) mapConditionalRequired(deps []question.ConditionalRequired) []ConditionalRequiredPO {
+	if len(deps) == 0 {
+		return nil
+	}
+
+	depsPO := make([]ConditionalRequiredPO, 0, len(deps))
+	for _, dep := range deps {
+		depsPO = append(depsPO, ConditionalRequiredPO{
+			DependsOnCode:     dep.DependsOnCode.Value(),
+			RequiredWhenValue: dep.RequiredWhenValue,
+		})
+	}
+	return depsPO
+}
+
 // mapCalculationRule 转换计算规则
 func (m *QuestionnaireMapper) mapCalculationRule(rule *calculation.CalculationRule) CalculationRulePO {
 	if rule == nil {
 		return CalculationRulePO{}
 	}
-	return CalculationRulePO{
-		Formula: string(rule.GetFormula()),
+	rulePO := CalculationRulePO{
+		Formula:       string(rule.GetFormula()),
+		SourceCodes:   rule.GetSourceCodes(),
+		DependsOnCode: rule.GetDependsOnCode(),
 	}
+	for _, mapping := range rule.GetScoreMappings() {
+		rulePO.ScoreMappings = append(rulePO.ScoreMappings, ScoreMappingPO{
+			WhenValue: mapping.WhenValue,
+			Scores:    mapping.Scores,
+		})
+	}
+	return rulePO
 }
 
 // ToBO 将MongoDB持久化对象转换为业务对象
@@ -107,6 +137,9 @@ func (m *QuestionnaireMapper) ToBO(po *QuestionnairePO) *questionnaire.Questionn
 		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion(po.Version)),
 		questionnaire.WithStatus(questionnaire.QuestionnaireStatus(po.Status)),
 		questionnaire.WithQuestions(m.mapQuestions(po.Questions)),
+		questionnaire.WithGeoRestriction(po.GeoRestriction),
+		questionnaire.WithTags(po.Tags),
+		questionnaire.WithRevision(po.Revision),
 	)
 
 	return q
@@ -129,12 +162,25 @@ func (m *QuestionnaireMapper) mapQuestions(questionsPO []QuestionPO) []question.
 			question.WithQuestionType(question.QuestionType(questionPO.QuestionType)),
 			question.WithPlaceholder(questionPO.Placeholder),
 			question.WithOptions(m.mapOptionsPOToBO(questionPO.Options)),
+			question.WithFileConstraints(questionPO.AllowedMIMETypes, questionPO.MaxFileSizeBytes, questionPO.MaxFiles),
+			question.WithPageBreakBefore(questionPO.PageBreakBefore),
 			question.WithValidationRules(m.mapValidationRulesPOToBO(questionPO.ValidationRules)),
+			question.WithConditionalRequired(m.mapConditionalRequiredPOToBO(questionPO.ConditionalRequired)...),
+			// 已持久化的题目保留原编码，不在读取时生成
+			question.WithAutoGenerateCode(false),
 		}
 
 		// 添加计算规则（如果有的话）
-		if questionPO.CalculationRule.Formula != "" {
-			opts = append(opts, question.WithCalculationRule(calculation.FormulaType(questionPO.CalculationRule.Formula)))
+		if questionPO.CalculationRule.Formula == string(calculation.FormulaTypeConditional) {
+			opts = append(opts, question.WithConditionalCalculationRule(
+				question.NewQuestionCode(questionPO.CalculationRule.DependsOnCode),
+				m.mapScoreMappingsPOToBO(questionPO.CalculationRule.ScoreMappings)...,
+			))
+		} else if questionPO.CalculationRule.Formula != "" {
+			opts = append(opts, question.WithCalculationRule(
+				calculation.FormulaType(questionPO.CalculationRule.Formula),
+				questionPO.CalculationRule.SourceCodes...,
+			))
 		}
 
 		// 1. 创建配置
@@ -173,12 +219,31 @@ func (m *QuestionnaireMapper) mapValidationRulesPOToBO(rulesPO []ValidationRuleP
 	var rules []validation.ValidationRule
 	for _, rulePO := range rulesPO {
 		ruleType := validation.RuleType(rulePO.RuleType)
-		rule := validation.NewValidationRule(ruleType, rulePO.TargetValue)
+		rule := validation.NewValidationRule(ruleType, rulePO.TargetValue).
+			WithSeverity(validation.Severity(rulePO.Severity))
 		rules = append(rules, rule)
 	}
 	return rules
 }
 
+// mapConditionalRequiredPOToBO 将条件必填规则PO转换为BO
+func (m *QuestionnaireMapper) mapConditionalRequiredPOToBO(depsPO []ConditionalRequiredPO) []question.ConditionalRequired {
+	deps := make([]question.ConditionalRequired, 0, len(depsPO))
+	for _, depPO := range depsPO {
+		deps = append(deps, question.NewConditionalRequired(question.NewQuestionCode(depPO.DependsOnCode), depPO.RequiredWhenValue))
+	}
+	return deps
+}
+
+// mapScoreMappingsPOToBO 将条件计分映射PO转换为BO
+func (m *QuestionnaireMapper) mapScoreMappingsPOToBO(mappingsPO []ScoreMappingPO) []calculation.ScoreMapping {
+	mappings := make([]calculation.ScoreMapping, 0, len(mappingsPO))
+	for _, mappingPO := range mappingsPO {
+		mappings = append(mappings, calculation.NewScoreMapping(mappingPO.WhenValue, mappingPO.Scores))
+	}
+	return mappings
+}
+
 // mapCalculationRulePOToBO 将计算规则PO转换为计算规则BO
 func (m *QuestionnaireMapper) mapCalculationRulePOToBO(rulePO CalculationRulePO) *calculation.CalculationRule {
 	if rulePO.Formula == "" {
@@ -186,5 +251,8 @@ func (m *QuestionnaireMapper) mapCalculationRulePOToBO(rulePO CalculationRulePO)
 	}
 
 	formulaType := calculation.FormulaType(rulePO.Formula)
-	return calculation.NewCalculationRule(formulaType, []string{})
+	if formulaType == calculation.FormulaTypeConditional {
+		return calculation.NewConditionalCalculationRule(rulePO.DependsOnCode, m.mapScoreMappingsPOToBO(rulePO.ScoreMappings))
+	}
+	return calculation.NewCalculationRule(formulaType, rulePO.SourceCodes)
 }
@@ -0,0 +1,113 @@
+package questionnaire
+
+import (
+	"reflect"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+)
+
+func TestQuestionnaireMapper_RoundTripRadioQuestionAbilities(t *testing.T) {
+	radio := question.CreateQuestionFromBuilder(question.BuildQuestionConfig(
+		question.WithCode(question.NewQuestionCode("Q1")),
+		question.WithTitle("最近一周的睡眠质量"),
+		question.WithQuestionType(question.QuestionTypeRadio),
+		question.WithOption("A", "好", 0),
+		question.WithOption("B", "一般", 1),
+		question.WithOption("C", "差", 2),
+		question.WithValidationRule(validation.RuleTypeRequired, "true"),
+		question.WithValidationRule(validation.RuleTypeMaxSelections, "1"),
+		question.WithCalculationRule(calculation.FormulaTypeScore, "A", "B", "C"),
+	))
+	if radio == nil {
+		t.Fatal("CreateQuestionFromBuilder() returned nil")
+	}
+
+	bo := questionnaire.NewQuestionnaire(
+		questionnaire.NewQuestionnaireCode("QN1"),
+		"睡眠质量问卷",
+		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		questionnaire.WithQuestions([]question.Question{radio}),
+	)
+
+	mapper := NewQuestionnaireMapper()
+
+	// 经过 BSON 编解码，确保嵌套能力字段能够持久化
+	data, err := bson.Marshal(mapper.ToPO(bo))
+	if err != nil {
+		t.Fatalf("bson.Marshal() error = %v", err)
+	}
+	var po QuestionnairePO
+	if err := bson.Unmarshal(data, &po); err != nil {
+		t.Fatalf("bson.Unmarshal() error = %v", err)
+	}
+
+	questions := mapper.ToBO(&po).GetQuestions()
+	if len(questions) != 1 {
+		t.Fatalf("questions = %d, want 1", len(questions))
+	}
+	got := questions[0]
+
+	if got.GetType() != question.QuestionTypeRadio {
+		t.Errorf("type = %s, want %s", got.GetType(), question.QuestionTypeRadio)
+	}
+
+	wantOptions := map[string]int{"A": 0, "B": 1, "C": 2}
+	if len(got.GetOptions()) != len(wantOptions) {
+		t.Fatalf("options = %d, want %d", len(got.GetOptions()), len(wantOptions))
+	}
+	for _, opt := range got.GetOptions() {
+		if score, ok := wantOptions[opt.GetCode()]; !ok || score != opt.GetScore() {
+			t.Errorf("option %s score = %d, want %d", opt.GetCode(), opt.GetScore(), score)
+		}
+	}
+
+	wantRules := map[validation.RuleType]string{
+		validation.RuleTypeRequired:      "true",
+		validation.RuleTypeMaxSelections: "1",
+	}
+	rules := got.GetValidationRules()
+	if len(rules) != len(wantRules) {
+		t.Fatalf("validation rules = %d, want %d", len(rules), len(wantRules))
+	}
+	for _, rule := range rules {
+		if target, ok := wantRules[rule.GetRuleType()]; !ok || target != rule.GetTargetValue() {
+			t.Errorf("validation rule %s = %q, want %q", rule.GetRuleType(), rule.GetTargetValue(), target)
+		}
+	}
+
+	calcRule := got.GetCalculationRule()
+	if calcRule == nil {
+		t.Fatal("calculation rule lost after round trip")
+	}
+	if calcRule.GetFormula() != calculation.FormulaTypeScore {
+		t.Errorf("formula = %s, want %s", calcRule.GetFormula(), calculation.FormulaTypeScore)
+	}
+	if !reflect.DeepEqual(calcRule.GetSourceCodes(), []string{"A", "B", "C"}) {
+		t.Errorf("source codes = %v, want [A B C]", calcRule.GetSourceCodes())
+	}
+}
+
+func TestQuestionnaireMapper_RoundTripValidationRuleSeverity(t *testing.T) {
+	mapper := NewQuestionnaireMapper()
+	rules := []validation.ValidationRule{
+		validation.NewValidationRule(validation.RuleTypeMaxValue, "12").WithSeverity(validation.SeverityWarning),
+		validation.NewValidationRule(validation.RuleTypeRequired, "true"),
+	}
+
+	got := mapper.mapValidationRulesPOToBO(mapper.mapValidationRules(rules))
+	if len(got) != 2 {
+		t.Fatalf("validation rules = %d, want 2", len(got))
+	}
+	if got[0].GetSeverity() != validation.SeverityWarning {
+		t.Errorf("severity = %s, want %s", got[0].GetSeverity(), validation.SeverityWarning)
+	}
+	if got[1].GetSeverity() != validation.SeverityError {
+		t.Errorf("default severity = %s, want %s", got[1].GetSeverity(), validation.SeverityError)
+	}
+}
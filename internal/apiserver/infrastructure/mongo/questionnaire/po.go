@@ -21,6 +21,10 @@ type QuestionnairePO struct {
 	Version           string       `bson:"version" json:"version"`
 	Status            uint8        `bson:"status" json:"status"`
 	Questions         []QuestionPO `bson:"questions,omitempty" json:"questions,omitempty"`
+	GeoRestriction    []string     `bson:"geo_restriction,omitempty" json:"geo_restriction,omitempty"`
+	Tags              []string     `bson:"tags,omitempty" json:"tags,omitempty"`
+	// Revision 修订号，每次更新递增，用于乐观锁；旧文档没有该字段时视为 0
+	Revision int64 `bson:"revision" json:"revision"`
 }
 
 // CollectionName 集合名称
@@ -113,14 +117,27 @@ func (p *QuestionnairePO) ToBsonMWithFilter(includeFields []string) (bson.M, err
 
 // QuestionPO 问题
 type QuestionPO struct {
-	Code            string             `bson:"code" json:"code"`
-	Title           string             `bson:"title" json:"title"`
-	QuestionType    string             `bson:"question_type" json:"question_type"`
-	Tips            string             `bson:"tips" json:"tip"`
-	Placeholder     string             `bson:"placeholder" json:"placeholder"`
-	Options         []OptionPO         `bson:"options" json:"options"`
-	ValidationRules []ValidationRulePO `bson:"validation_rules" json:"validation_rules"`
-	CalculationRule CalculationRulePO  `bson:"calculation_rule" json:"calculation_rule"`
+	Code         string     `bson:"code" json:"code"`
+	Title        string     `bson:"title" json:"title"`
+	QuestionType string     `bson:"question_type" json:"question_type"`
+	Tips         string     `bson:"tips" json:"tip"`
+	Placeholder  string     `bson:"placeholder" json:"placeholder"`
+	Options      []OptionPO `bson:"options" json:"options"`
+	// 文件上传约束
+	AllowedMIMETypes []string           `bson:"allowed_mime_types,omitempty" json:"allowed_mime_types,omitempty"`
+	MaxFileSizeBytes int64              `bson:"max_file_size_bytes,omitempty" json:"max_file_size_bytes,omitempty"`
+	MaxFiles         int                `bson:"max_files,omitempty" json:"max_files,omitempty"`
+	PageBreakBefore  bool               `bson:"page_break_before,omitempty" json:"page_break_before,omitempty"` // 段落前是否分页（仅段落题）
+	ValidationRules  []ValidationRulePO `bson:"validation_rules" json:"validation_rules"`
+	// 条件必填规则
+	ConditionalRequired []ConditionalRequiredPO `bson:"conditional_required,omitempty" json:"conditional_required,omitempty"`
+	CalculationRule     CalculationRulePO       `bson:"calculation_rule" json:"calculation_rule"`
+}
+
+// ConditionalRequiredPO 条件必填规则持久化对象
+type ConditionalRequiredPO struct {
+	DependsOnCode     string      `bson:"depends_on_code" json:"depends_on_code"`
+	RequiredWhenValue interface{} `bson:"required_when_value" json:"required_when_value"`
 }
 
 // ToBsonM 将 QuestionPO 转换为 bson.M
@@ -166,6 +183,7 @@ func (p *OptionPO) ToBsonM() (bson.M, error) {
 type ValidationRulePO struct {
 	RuleType    string `bson:"rule_type" json:"rule_type"`
 	TargetValue string `bson:"target_value" json:"target_value"`
+	Severity    string `bson:"severity,omitempty" json:"severity,omitempty"`
 }
 
 // ToBsonM 将 ValidationRulePO 转换为 bson.M
@@ -186,7 +204,17 @@ func (p *ValidationRulePO) ToBsonM() (bson.M, error) {
 
 // CalculationRulePO 计算规则
 type CalculationRulePO struct {
-	Formula string `bson:"formula" json:"formula"`
+	Formula     string   `bson:"formula" json:"formula"`
+	SourceCodes []string `bson:"source_codes,omitempty" json:"source_codes,omitempty"`
+
+	DependsOnCode string           `bson:"depends_on_code,omitempty" json:"depends_on_code,omitempty"`
+	ScoreMappings []ScoreMappingPO `bson:"score_mappings,omitempty" json:"score_mappings,omitempty"`
+}
+
+// ScoreMappingPO 条件计分映射
+type ScoreMappingPO struct {
+	WhenValue string             `bson:"when_value" json:"when_value"`
+	Scores    map[string]float64 `bson:"scores" json:"scores"`
 }
 
 // ToBsonM 将 CalculationRulePO 转换为 bson.M
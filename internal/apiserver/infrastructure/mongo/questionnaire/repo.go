@@ -6,10 +6,13 @@ import (
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
 	mongoBase "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // Repository 问卷MongoDB存储库
@@ -22,7 +25,7 @@ type Repository struct {
 func NewRepository(db *mongo.Database) port.QuestionnaireRepositoryMongo {
 	po := &QuestionnairePO{}
 	return &Repository{
-		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName()),
+		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName(), mongoBase.WithSchema(mongoBase.CollectionSchema(po.CollectionName()))),
 		mapper:         NewQuestionnaireMapper(),
 	}
 }
@@ -45,17 +48,31 @@ func (r *Repository) Create(ctx context.Context, qDomain *questionnaire.Question
 	return nil
 }
 
-// FindByCode 根据编码查询问卷
+// FindByCode 根据编码查询问卷的当前版本，不存在时返回 ErrQuestionnaireNotFound
 func (r *Repository) FindByCode(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
+	qDomain, err := r.FindByCodeOrNil(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	if qDomain == nil {
+		return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s", code)
+	}
+
+	return qDomain, nil
+}
+
+// FindByCodeOrNil 根据编码查询问卷的当前版本，不存在时返回 nil
+// 修订版本的版本号总是递增的，版本号最大的文档即当前版本
+func (r *Repository) FindByCodeOrNil(ctx context.Context, code string) (*questionnaire.Questionnaire, error) {
 	filter := bson.M{
 		"code": code,
 	}
 
 	var po QuestionnairePO
-	err := r.FindOne(ctx, filter, &po)
+	err := r.FindOne(ctx, filter, &po, headVersionOptions())
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil // 或者返回自定义的NotFound错误
+			return nil, nil
 		}
 		return nil, err
 	}
@@ -63,7 +80,14 @@ func (r *Repository) FindByCode(ctx context.Context, code string) (*questionnair
 	return r.mapper.ToBO(&po), nil
 }
 
-// FindByCodeVersion 根据编码和版本查询问卷
+// headVersionOptions 按版本号降序取第一个文档，版本号按数字比较，如 2.10 排在 2.9 之前
+func headVersionOptions() *options.FindOneOptions {
+	return options.FindOne().
+		SetSort(bson.D{{Key: "version", Value: -1}}).
+		SetCollation(&options.Collation{Locale: "en", NumericOrdering: true})
+}
+
+// FindByCodeVersion 根据编码和版本查询问卷，不存在时返回 ErrQuestionnaireNotFound
 func (r *Repository) FindByCodeVersion(ctx context.Context, code, version string) (*questionnaire.Questionnaire, error) {
 	filter := bson.M{
 		"code":    code,
@@ -74,7 +98,7 @@ func (r *Repository) FindByCodeVersion(ctx context.Context, code, version string
 	err := r.FindOne(ctx, filter, &po)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
-			return nil, nil // 或者返回自定义的NotFound错误
+			return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s@%s", code, version)
 		}
 		return nil, err
 	}
@@ -82,32 +106,83 @@ func (r *Repository) FindByCodeVersion(ctx context.Context, code, version string
 	return r.mapper.ToBO(&po), nil
 }
 
-// Update 更新问卷
+// Update 更新问卷，按编码和版本定位文档，其他版本的文档不受影响
+// 已发布的问卷只允许变更状态，以发布状态再次保存时返回 ErrPublishedQuestionnaireImmutable；
+// 以问卷的修订号作为乐观锁条件，文档已被其他请求修改时返回 ErrQuestionnaireVersionConflict，
+// 保存成功后问卷的修订号加一
 func (r *Repository) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	locator := bson.M{
+		"code":    qDomain.GetCode().Value(),
+		"version": qDomain.GetVersion().Value(),
+	}
+
+	if qDomain.IsPublished() {
+		var stored QuestionnairePO
+		if err := r.FindOne(ctx, locator, &stored); err != nil && err != mongo.ErrNoDocuments {
+			return err
+		} else if err == nil {
+			if err := r.mapper.ToBO(&stored).EnsureImmutability(); err != nil {
+				return err
+			}
+		}
+	}
+
 	po := r.mapper.ToPO(qDomain)
 	po.BeforeUpdate()
 
-	// 根据领域ID查找文档
-	filter := bson.M{"code": qDomain.GetCode().Value()}
-
 	// 将领域模型转换为BSON M
 	updateData, err := po.ToBsonM()
 	if err != nil {
 		return err
 	}
+	// 修订号由 $inc 递增，不随其他字段一起覆盖
+	delete(updateData, "revision")
 
 	// 使用 $set 操作符包装更新数据，避免覆盖其他字段
-	update := bson.M{"$set": updateData}
+	update := bson.M{
+		"$set": updateData,
+		"$inc": bson.M{"revision": 1},
+	}
 
-	_, err = r.UpdateOne(ctx, filter, update)
+	result, err := r.UpdateOne(ctx, revisionFilter(locator, qDomain.GetRevision()), update)
 	if err != nil {
 		return err
 	}
+	if result.MatchedCount == 0 {
+		return r.revisionConflict(ctx, locator)
+	}
 
+	qDomain.SetRevision(qDomain.GetRevision() + 1)
 	return nil
 }
 
-// Remove 删除问卷（软删除）
+// revisionFilter 在定位条件上追加修订号条件，修订号为 0 时同时匹配没有修订号字段的旧文档
+func revisionFilter(locator bson.M, revision int64) bson.M {
+	filter := bson.M{}
+	for key, value := range locator {
+		filter[key] = value
+	}
+	if revision == 0 {
+		filter["revision"] = bson.M{"$in": bson.A{0, nil}}
+	} else {
+		filter["revision"] = revision
+	}
+	return filter
+}
+
+// revisionConflict 更新未匹配到文档时区分问卷不存在和修订号冲突
+func (r *Repository) revisionConflict(ctx context.Context, locator bson.M) error {
+	var stored QuestionnairePO
+	if err := r.FindOne(ctx, locator, &stored); err != nil {
+		if err == mongo.ErrNoDocuments {
+			return errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s@%s", locator["code"], locator["version"])
+		}
+		return err
+	}
+	return questionnaire.NewRevisionConflictError(stored.Code, stored.Revision)
+}
+
+// Remove 删除问卷的所有版本（软删除）
 func (r *Repository) Remove(ctx context.Context, code string) error {
 	filter := bson.M{"code": code}
 
@@ -120,7 +195,7 @@ func (r *Repository) Remove(ctx context.Context, code string) error {
 		},
 	}
 
-	result, err := r.UpdateOne(ctx, filter, update)
+	result, err := r.UpdateMany(ctx, filter, update)
 	if err != nil {
 		return err
 	}
@@ -132,11 +207,41 @@ func (r *Repository) Remove(ctx context.Context, code string) error {
 	return nil
 }
 
-// HardDelete 物理删除问卷
+// RemoveByFilter 批量软删除符合条件的问卷，返回受影响的数量
+// 条件为空时拒绝执行，避免误删全部问卷；已删除的问卷不会重复标记
+func (r *Repository) RemoveByFilter(ctx context.Context, conditions map[string]interface{}) (int64, error) {
+	if len(conditions) == 0 {
+		return 0, errors.WithCode(errCode.ErrQuestionnaireInvalidInput, "批量删除问卷的条件不能为空")
+	}
+
+	filter := bson.M{}
+	for key, value := range conditions {
+		filter[key] = value
+	}
+	filter["deleted_at"] = nil
+
+	now := time.Now()
+	update := bson.M{
+		"$set": bson.M{
+			"deleted_at": now,
+			"deleted_by": 0, // 这里应该从上下文中获取当前用户ID
+			"updated_at": now,
+		},
+	}
+
+	result, err := r.UpdateMany(ctx, filter, update)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.ModifiedCount, nil
+}
+
+// HardDelete 物理删除问卷的所有版本
 func (r *Repository) HardDelete(ctx context.Context, code string) error {
 	filter := bson.M{"code": code}
 
-	result, err := r.DeleteOne(ctx, filter)
+	result, err := r.DeleteMany(ctx, filter)
 	if err != nil {
 		return err
 	}
@@ -169,10 +274,70 @@ func (r *Repository) FindActiveQuestionnaires(ctx context.Context) ([]*questionn
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
+	// 使用独立上下文关闭游标，确保请求取消后仍能释放服务端游标
+	defer cursor.Close(context.Background())
+
+	return r.decodeQuestionnaires(ctx, cursor)
+}
 
+// FindByTags 按标签分页查询未删除的问卷，按创建时间倒序
+func (r *Repository) FindByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*questionnaire.Questionnaire, int64, error) {
+	filter := tagsFilter(tags, matchAll)
+
+	total, err := r.CountDocuments(ctx, filter)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: -1}})
+	if page > 0 && pageSize > 0 {
+		opts.SetSkip(int64((page - 1) * pageSize))
+	}
+	if pageSize > 0 {
+		opts.SetLimit(int64(pageSize))
+	}
+
+	cursor, err := r.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer cursor.Close(context.Background())
+
+	questionnaires, err := r.decodeQuestionnaires(ctx, cursor)
+	if err != nil {
+		return nil, 0, err
+	}
+	return questionnaires, total, nil
+}
+
+// tagsFilter 构造按标签查询的过滤条件，matchAll 为 true 时使用 $all，否则使用 $in
+func tagsFilter(tags []string, matchAll bool) bson.M {
+	operator := "$in"
+	if matchAll {
+		operator = "$all"
+	}
+	return bson.M{
+		"tags":       bson.M{operator: tags},
+		"deleted_at": bson.M{"$exists": false},
+	}
+}
+
+// questionnaireCursor 问卷查询游标
+type questionnaireCursor interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	Err() error
+}
+
+// decodeQuestionnaires 逐条解码游标中的问卷，上下文取消时立即中止
+func (r *Repository) decodeQuestionnaires(ctx context.Context, cursor questionnaireCursor) ([]*questionnaire.Questionnaire, error) {
 	var questionnaires []*questionnaire.Questionnaire
 	for cursor.Next(ctx) {
+		// 已缓存在当前批次中的文档不会触发网络请求，需主动检查上下文
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
 		var po QuestionnairePO
 		if err := cursor.Decode(&po); err != nil {
 			return nil, err
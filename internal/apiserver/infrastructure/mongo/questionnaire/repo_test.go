@@ -0,0 +1,376 @@
+package questionnaire
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/integration/mtest"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestRepository_FindNotFound(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	emptyCursor := mtest.CreateCursorResponse(0, "questionnaire.questionnaires", mtest.FirstBatch)
+
+	mt.Run("FindByCode returns typed not found error", func(mt *mtest.T) {
+		mt.AddMockResponses(emptyCursor)
+
+		qDomain, err := NewRepository(mt.DB).FindByCode(context.Background(), "QN404")
+		if qDomain != nil {
+			t.Errorf("FindByCode() = %v, want nil", qDomain)
+		}
+		if !errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+			t.Errorf("FindByCode() error = %v, want ErrQuestionnaireNotFound", err)
+		}
+	})
+
+	mt.Run("FindByCodeVersion returns typed not found error", func(mt *mtest.T) {
+		mt.AddMockResponses(emptyCursor)
+
+		_, err := NewRepository(mt.DB).FindByCodeVersion(context.Background(), "QN1", "9.9")
+		if !errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+			t.Errorf("FindByCodeVersion() error = %v, want ErrQuestionnaireNotFound", err)
+		}
+	})
+
+	mt.Run("FindByCodeOrNil returns nil without error", func(mt *mtest.T) {
+		mt.AddMockResponses(emptyCursor)
+
+		qDomain, err := NewRepository(mt.DB).(*Repository).FindByCodeOrNil(context.Background(), "QN404")
+		if err != nil || qDomain != nil {
+			t.Errorf("FindByCodeOrNil() = %v, %v, want nil, nil", qDomain, err)
+		}
+	})
+
+	mt.Run("FindByCode returns document", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "questionnaire.questionnaires", mtest.FirstBatch, bson.D{
+			{Key: "code", Value: "QN1"},
+			{Key: "title", Value: "睡眠质量问卷"},
+			{Key: "version", Value: "1.0"},
+		}))
+
+		qDomain, err := NewRepository(mt.DB).FindByCode(context.Background(), "QN1")
+		if err != nil {
+			t.Fatalf("FindByCode() error = %v", err)
+		}
+		if qDomain.GetCode().Value() != "QN1" || qDomain.GetVersion().Value() != "1.0" {
+			t.Errorf("FindByCode() = %s@%s, want QN1@1.0", qDomain.GetCode().Value(), qDomain.GetVersion().Value())
+		}
+	})
+}
+
+// cancellingCursor 在解码指定数量的文档后取消上下文
+type cancellingCursor struct {
+	docs        []bson.D
+	pos         int
+	decoded     int
+	cancelAfter int
+	cancel      context.CancelFunc
+}
+
+func (c *cancellingCursor) Next(ctx context.Context) bool {
+	if c.pos >= len(c.docs) {
+		return false
+	}
+	c.pos++
+	return true
+}
+
+func (c *cancellingCursor) Decode(val interface{}) error {
+	data, err := bson.Marshal(c.docs[c.pos-1])
+	if err != nil {
+		return err
+	}
+	c.decoded++
+	if c.decoded == c.cancelAfter {
+		c.cancel()
+	}
+	return bson.Unmarshal(data, val)
+}
+
+func (c *cancellingCursor) Err() error { return nil }
+
+func TestRepository_DecodeQuestionnaires_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	docs := make([]bson.D, 1000)
+	for i := range docs {
+		docs[i] = bson.D{{Key: "code", Value: "QN1"}, {Key: "title", Value: "睡眠质量问卷"}}
+	}
+	cursor := &cancellingCursor{docs: docs, cancelAfter: 3, cancel: cancel}
+
+	repo := &Repository{mapper: NewQuestionnaireMapper()}
+	questionnaires, err := repo.decodeQuestionnaires(ctx, cursor)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("decodeQuestionnaires() error = %v, want context.Canceled", err)
+	}
+	if questionnaires != nil {
+		t.Errorf("decodeQuestionnaires() returned %d questionnaires, want nil", len(questionnaires))
+	}
+	if cursor.decoded != 3 {
+		t.Errorf("decoded = %d, want 3 (iteration should stop right after cancellation)", cursor.decoded)
+	}
+}
+
+func TestRepository_ReadAfterAmendment(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("FindByCode selects the highest version", func(mt *mtest.T) {
+		// 1.9 已发布后修订出 1.10，按编码读取应得到修订版本
+		mt.AddMockResponses(mtest.CreateCursorResponse(1, "questionnaire.questionnaires", mtest.FirstBatch, bson.D{
+			{Key: "code", Value: "QN1"},
+			{Key: "title", Value: "睡眠质量问卷（修订）"},
+			{Key: "version", Value: "1.10"},
+		}))
+
+		qDomain, err := NewRepository(mt.DB).FindByCode(context.Background(), "QN1")
+		if err != nil {
+			t.Fatalf("FindByCode() error = %v", err)
+		}
+		if qDomain.GetVersion().Value() != "1.10" {
+			t.Errorf("FindByCode() version = %s, want 1.10", qDomain.GetVersion().Value())
+		}
+
+		find := mt.GetStartedEvent().Command
+		if find.Lookup("sort", "version").AsInt64() != -1 {
+			t.Errorf("sort = %s, want version descending", find.Lookup("sort"))
+		}
+		if !find.Lookup("collation", "numericOrdering").Boolean() {
+			t.Errorf("collation = %s, want numeric ordering so that 1.10 sorts after 1.9", find.Lookup("collation"))
+		}
+	})
+
+	mt.Run("Remove soft deletes every version", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 2},
+			bson.E{Key: "nModified", Value: 2},
+		))
+
+		if err := NewRepository(mt.DB).Remove(context.Background(), "QN1"); err != nil {
+			t.Fatalf("Remove() error = %v", err)
+		}
+		stmt := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		if !stmt.Lookup("multi").Boolean() {
+			t.Error("update statement is not multi")
+		}
+	})
+
+	mt.Run("HardDelete deletes every version", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 2}))
+
+		if err := NewRepository(mt.DB).HardDelete(context.Background(), "QN1"); err != nil {
+			t.Fatalf("HardDelete() error = %v", err)
+		}
+		stmt := mt.GetStartedEvent().Command.Lookup("deletes").Array().Index(0).Value().Document()
+		if stmt.Lookup("limit").AsInt64() != 0 {
+			t.Errorf("delete limit = %s, want 0 (all matching documents)", stmt.Lookup("limit"))
+		}
+	})
+}
+
+func TestRepository_RemoveByFilter(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("soft deletes matching batch", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 3},
+			bson.E{Key: "nModified", Value: 3},
+		))
+
+		cutoff := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		count, err := NewRepository(mt.DB).RemoveByFilter(context.Background(), map[string]interface{}{
+			"status":     0, // 草稿
+			"created_at": bson.M{"$lt": cutoff},
+		})
+		if err != nil {
+			t.Fatalf("RemoveByFilter() error = %v", err)
+		}
+		if count != 3 {
+			t.Errorf("RemoveByFilter() = %d, want 3", count)
+		}
+
+		started := mt.GetStartedEvent()
+		if started == nil || started.CommandName != "update" {
+			t.Fatalf("started event = %v, want update command", started)
+		}
+		stmt := started.Command.Lookup("updates").Array().Index(0).Value().Document()
+		if !stmt.Lookup("multi").Boolean() {
+			t.Error("update statement is not multi")
+		}
+		filter := stmt.Lookup("q").Document()
+		if filter.Lookup("status").AsInt64() != 0 || filter.Lookup("deleted_at").Type != bson.TypeNull {
+			t.Errorf("filter = %s, want status 0 and deleted_at null", filter)
+		}
+		if stmt.Lookup("u", "$set", "deleted_at").Type != bson.TypeDateTime {
+			t.Errorf("update = %s, want deleted_at set", stmt.Lookup("u"))
+		}
+	})
+
+	mt.Run("rejects empty filter", func(mt *mtest.T) {
+		count, err := NewRepository(mt.DB).RemoveByFilter(context.Background(), map[string]interface{}{})
+		if !errors.IsCode(err, errCode.ErrQuestionnaireInvalidInput) {
+			t.Errorf("RemoveByFilter() error = %v, want ErrQuestionnaireInvalidInput", err)
+		}
+		if count != 0 {
+			t.Errorf("RemoveByFilter() = %d, want 0", count)
+		}
+		if started := mt.GetStartedEvent(); started != nil {
+			t.Errorf("unexpected %s command sent for empty filter", started.CommandName)
+		}
+	})
+}
+
+func TestRepository_UpdateRevision(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	newDraft := func(revision int64) *questionnaire.Questionnaire {
+		return questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("QN1"), "睡眠质量问卷",
+			questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+			questionnaire.WithRevision(revision),
+		)
+	}
+
+	mt.Run("increments revision on match", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse(
+			bson.E{Key: "n", Value: 1},
+			bson.E{Key: "nModified", Value: 1},
+		))
+
+		qDomain := newDraft(2)
+		if err := NewRepository(mt.DB).Update(context.Background(), qDomain); err != nil {
+			t.Fatalf("Update() error = %v", err)
+		}
+		if qDomain.GetRevision() != 3 {
+			t.Errorf("revision = %d, want 3", qDomain.GetRevision())
+		}
+
+		stmt := mt.GetStartedEvent().Command.Lookup("updates").Array().Index(0).Value().Document()
+		if stmt.Lookup("q", "revision").AsInt64() != 2 {
+			t.Errorf("filter = %s, want revision 2", stmt.Lookup("q"))
+		}
+		if stmt.Lookup("u", "$inc", "revision").AsInt64() != 1 {
+			t.Errorf("update = %s, want revision incremented", stmt.Lookup("u"))
+		}
+		if _, err := stmt.Lookup("u", "$set").Document().LookupErr("revision"); err == nil {
+			t.Errorf("update = %s, revision should not be set", stmt.Lookup("u"))
+		}
+	})
+
+	mt.Run("returns conflict with current revision", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+			mtest.CreateCursorResponse(1, "questionnaire.questionnaires", mtest.FirstBatch, bson.D{
+				{Key: "code", Value: "QN1"},
+				{Key: "version", Value: "1.0"},
+				{Key: "revision", Value: int64(5)},
+			}),
+		)
+
+		err := NewRepository(mt.DB).Update(context.Background(), newDraft(4))
+		if !errors.IsCode(err, errCode.ErrQuestionnaireVersionConflict) {
+			t.Fatalf("Update() error = %v, want ErrQuestionnaireVersionConflict", err)
+		}
+		var conflict *questionnaire.RevisionConflictError
+		if !errors.As(err, &conflict) || conflict.CurrentRevision != 5 {
+			t.Errorf("conflict = %+v, want current revision 5", conflict)
+		}
+	})
+
+	mt.Run("returns not found when document is missing", func(mt *mtest.T) {
+		mt.AddMockResponses(
+			mtest.CreateSuccessResponse(bson.E{Key: "n", Value: 0}, bson.E{Key: "nModified", Value: 0}),
+			mtest.CreateCursorResponse(0, "questionnaire.questionnaires", mtest.FirstBatch),
+		)
+
+		err := NewRepository(mt.DB).Update(context.Background(), newDraft(0))
+		if !errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+			t.Errorf("Update() error = %v, want ErrQuestionnaireNotFound", err)
+		}
+	})
+}
+
+func TestRepository_CreateSchemaValidation(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	mt.Run("valid questionnaire passes the collection schema", func(mt *mtest.T) {
+		mt.AddMockResponses(mtest.CreateSuccessResponse())
+
+		qDomain := questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode("QN1"), "睡眠质量问卷",
+			questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		)
+		if err := NewRepository(mt.DB).Create(context.Background(), qDomain); err != nil {
+			t.Fatalf("Create() error = %v", err)
+		}
+	})
+
+	mt.Run("questionnaire without code is rejected", func(mt *mtest.T) {
+		qDomain := questionnaire.NewQuestionnaire(questionnaire.NewQuestionnaireCode(""), "睡眠质量问卷",
+			questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion("1.0")),
+		)
+		err := NewRepository(mt.DB).Create(context.Background(), qDomain)
+		if !errors.IsCode(err, errCode.ErrDocumentSchemaValidation) {
+			t.Errorf("Create() error = %v, want ErrDocumentSchemaValidation", err)
+		}
+	})
+}
+
+func TestRepository_FindByTags(t *testing.T) {
+	mt := mtest.New(t, mtest.NewOptions().ClientType(mtest.Mock))
+
+	tests := []struct {
+		name     string
+		matchAll bool
+		operator string
+	}{
+		{name: "match all tags", matchAll: true, operator: "$all"},
+		{name: "match any tag", matchAll: false, operator: "$in"},
+	}
+	for _, tt := range tests {
+		mt.Run(tt.name, func(mt *mtest.T) {
+			mt.AddMockResponses(
+				mtest.CreateCursorResponse(0, "questionnaire.questionnaires", mtest.FirstBatch, bson.D{{Key: "n", Value: int32(3)}}),
+				mtest.CreateCursorResponse(0, "questionnaire.questionnaires", mtest.FirstBatch, bson.D{
+					{Key: "code", Value: "PHQ9"},
+					{Key: "version", Value: "1.0"},
+					{Key: "tags", Value: bson.A{"depression", "anxiety"}},
+				}),
+			)
+
+			qs, total, err := NewRepository(mt.DB).FindByTags(context.Background(), []string{"depression", "anxiety"}, tt.matchAll, 2, 2)
+			if err != nil {
+				mt.Fatalf("FindByTags() error = %v", err)
+			}
+			if total != 3 || len(qs) != 1 || qs[0].GetCode().Value() != "PHQ9" {
+				mt.Fatalf("FindByTags() = %d questionnaires, total %d, want PHQ9 and total 3", len(qs), total)
+			}
+			if got := qs[0].GetTags(); len(got) != 2 || got[0] != "depression" {
+				mt.Errorf("tags = %v, want [depression anxiety]", got)
+			}
+
+			find := mt.GetStartedEvent()
+			for find != nil && find.CommandName != "find" {
+				find = mt.GetStartedEvent()
+			}
+			if find == nil {
+				mt.Fatal("find command was not sent")
+			}
+			filter := find.Command.Lookup("filter").Document()
+			if _, err := filter.LookupErr("tags", tt.operator); err != nil {
+				mt.Errorf("filter = %v, want tags.%s", filter, tt.operator)
+			}
+			if _, err := filter.LookupErr("deleted_at", "$exists"); err != nil {
+				mt.Errorf("filter = %v, want deleted questionnaires excluded", filter)
+			}
+			if skip := find.Command.Lookup("skip").AsInt64(); skip != 2 {
+				mt.Errorf("skip = %d, want 2", skip)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package questionnaire
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// watchRetryInterval 变更流中断后重新连接的间隔
+const watchRetryInterval = time.Second
+
+// changeStream 变更流，抽象 *mongo.ChangeStream 便于测试
+type changeStream interface {
+	Next(ctx context.Context) bool
+	Decode(val interface{}) error
+	ResumeToken() bson.Raw
+	Err() error
+	Close(ctx context.Context) error
+}
+
+// changeStreamOpener 打开变更流，resumeToken 不为空时从该位置之后继续
+type changeStreamOpener func(ctx context.Context, resumeToken bson.Raw) (changeStream, error)
+
+// changeEventDocument 变更流事件文档
+type changeEventDocument struct {
+	OperationType            string              `bson:"operationType"`
+	ClusterTime              primitive.Timestamp `bson:"clusterTime"`
+	FullDocument             *QuestionnairePO    `bson:"fullDocument"`
+	FullDocumentBeforeChange *QuestionnairePO    `bson:"fullDocumentBeforeChange"`
+}
+
+// toChangeEvent 转换为问卷变更事件，不关心的操作返回 false
+func (d *changeEventDocument) toChangeEvent() (port.ChangeEvent, bool) {
+	event := port.ChangeEvent{
+		OccurredAt: time.Unix(int64(d.ClusterTime.T), 0),
+	}
+
+	switch d.OperationType {
+	case "insert":
+		event.Type = port.ChangeEventCreated
+	case "update", "replace":
+		event.Type = port.ChangeEventUpdated
+		// 软删除表现为设置 deleted_at 的更新
+		if d.FullDocument != nil && d.FullDocument.DeletedAt != nil {
+			event.Type = port.ChangeEventDeleted
+		}
+	case "delete":
+		event.Type = port.ChangeEventDeleted
+	default:
+		return event, false
+	}
+
+	if d.FullDocument != nil {
+		event.Code = d.FullDocument.Code
+	} else if d.FullDocumentBeforeChange != nil {
+		event.Code = d.FullDocumentBeforeChange.Code
+	}
+
+	return event, true
+}
+
+var _ port.QuestionnaireChangeWatcher = (*Repository)(nil)
+
+// Watch 通过 MongoDB 变更流监听问卷变更
+// 变更流中断时使用最后处理事件的恢复令牌重新连接，保证不丢失事件
+// 变更流要求 MongoDB 以副本集或分片集群方式部署
+func (r *Repository) Watch(ctx context.Context) (<-chan port.ChangeEvent, error) {
+	w := &changeWatcher{
+		open:          r.openChangeStream,
+		retryInterval: watchRetryInterval,
+	}
+	return w.watch(ctx)
+}
+
+// openChangeStream 打开问卷集合的变更流
+func (r *Repository) openChangeStream(ctx context.Context, resumeToken bson.Raw) (changeStream, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{
+			"operationType": bson.M{"$in": []string{"insert", "update", "replace", "delete"}},
+		}}},
+	}
+
+	opts := options.ChangeStream().
+		SetFullDocument(options.UpdateLookup).
+		SetFullDocumentBeforeChange(options.WhenAvailable)
+	if resumeToken != nil {
+		opts.SetResumeAfter(resumeToken)
+	}
+
+	return r.Collection().Watch(ctx, pipeline, opts)
+}
+
+// changeWatcher 变更流监听器，负责断线重连
+type changeWatcher struct {
+	open          changeStreamOpener
+	retryInterval time.Duration
+}
+
+// watch 打开变更流并在后台转发事件，首次打开失败时直接返回错误
+func (w *changeWatcher) watch(ctx context.Context) (<-chan port.ChangeEvent, error) {
+	stream, err := w.open(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	events := make(chan port.ChangeEvent)
+	go func() {
+		defer close(events)
+
+		var resumeToken bson.Raw
+		for {
+			resumeToken = w.consume(ctx, stream, events, resumeToken)
+			if ctx.Err() != nil {
+				return
+			}
+
+			if stream = w.reopen(ctx, resumeToken); stream == nil {
+				return
+			}
+		}
+	}()
+
+	return events, nil
+}
+
+// consume 转发变更流中的事件直到变更流中断或 ctx 取消，返回最后处理事件的恢复令牌
+func (w *changeWatcher) consume(ctx context.Context, stream changeStream, events chan<- port.ChangeEvent, resumeToken bson.Raw) bson.Raw {
+	defer stream.Close(context.Background())
+
+	for stream.Next(ctx) {
+		var doc changeEventDocument
+		if err := stream.Decode(&doc); err != nil {
+			log.Warnf("failed to decode questionnaire change event: %v", err)
+		} else if event, ok := doc.toChangeEvent(); ok {
+			select {
+			case events <- event:
+			case <-ctx.Done():
+				return resumeToken
+			}
+		}
+		resumeToken = append(bson.Raw(nil), stream.ResumeToken()...)
+	}
+
+	if err := stream.Err(); err != nil && ctx.Err() == nil {
+		log.Warnf("questionnaire change stream interrupted, resuming: %v", err)
+	}
+	return resumeToken
+}
+
+// reopen 按重试间隔重新打开变更流，ctx 取消时返回 nil
+func (w *changeWatcher) reopen(ctx context.Context, resumeToken bson.Raw) changeStream {
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(w.retryInterval):
+		}
+
+		stream, err := w.open(ctx, resumeToken)
+		if err == nil {
+			return stream
+		}
+		log.Warnf("failed to reopen questionnaire change stream: %v", err)
+	}
+}
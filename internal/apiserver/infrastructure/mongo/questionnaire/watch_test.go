@@ -0,0 +1,157 @@
+package questionnaire
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+)
+
+// fakeChangeStream 依次返回预置事件，事件耗尽后返回 err，err 为空时阻塞到 ctx 取消
+type fakeChangeStream struct {
+	docs   []bson.D
+	pos    int
+	offset int
+	err    error
+}
+
+func (s *fakeChangeStream) Next(ctx context.Context) bool {
+	if s.pos < len(s.docs) {
+		s.pos++
+		return true
+	}
+	if s.err == nil {
+		<-ctx.Done()
+	}
+	return false
+}
+
+func (s *fakeChangeStream) Decode(val interface{}) error {
+	data, err := bson.Marshal(s.docs[s.pos-1])
+	if err != nil {
+		return err
+	}
+	return bson.Unmarshal(data, val)
+}
+
+func (s *fakeChangeStream) ResumeToken() bson.Raw {
+	token, _ := bson.Marshal(bson.D{{Key: "_data", Value: strconv.Itoa(s.offset + s.pos)}})
+	return token
+}
+
+func (s *fakeChangeStream) Err() error {
+	if s.pos < len(s.docs) {
+		return nil
+	}
+	return s.err
+}
+
+func (s *fakeChangeStream) Close(ctx context.Context) error { return nil }
+
+func changeDoc(op, code string, deleted bool) bson.D {
+	doc := bson.D{{Key: "code", Value: code}, {Key: "deleted_at", Value: nil}}
+	if deleted {
+		doc[1].Value = time.Now()
+	}
+	return bson.D{{Key: "operationType", Value: op}, {Key: "fullDocument", Value: doc}}
+}
+
+func receive(t *testing.T, events <-chan port.ChangeEvent) port.ChangeEvent {
+	t.Helper()
+	select {
+	case event := <-events:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for change event")
+		return port.ChangeEvent{}
+	}
+}
+
+func TestChangeWatcher_UpdateProducesEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	stream := &fakeChangeStream{docs: []bson.D{
+		changeDoc("update", "QN1", false),
+		changeDoc("update", "QN2", true),
+	}}
+	w := &changeWatcher{
+		open: func(ctx context.Context, resumeToken bson.Raw) (changeStream, error) {
+			return stream, nil
+		},
+	}
+
+	events, err := w.watch(ctx)
+	if err != nil {
+		t.Fatalf("watch() error = %v", err)
+	}
+
+	if event := receive(t, events); event.Type != port.ChangeEventUpdated || event.Code != "QN1" {
+		t.Errorf("first event = %+v, want update of QN1", event)
+	}
+	if event := receive(t, events); event.Type != port.ChangeEventDeleted || event.Code != "QN2" {
+		t.Errorf("second event = %+v, want soft delete of QN2", event)
+	}
+
+	cancel()
+	if _, ok := <-events; ok {
+		t.Error("events channel still open after ctx cancelled")
+	}
+}
+
+func TestChangeWatcher_ResumesAfterInterruption(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	streams := []*fakeChangeStream{
+		{docs: []bson.D{changeDoc("insert", "QN1", false)}, err: errors.New("connection reset")},
+		{docs: []bson.D{changeDoc("update", "QN1", false)}, offset: 1},
+	}
+	var tokens []bson.Raw
+	w := &changeWatcher{
+		open: func(ctx context.Context, resumeToken bson.Raw) (changeStream, error) {
+			tokens = append(tokens, resumeToken)
+			stream := streams[0]
+			streams = streams[1:]
+			return stream, nil
+		},
+		retryInterval: time.Millisecond,
+	}
+
+	events, err := w.watch(ctx)
+	if err != nil {
+		t.Fatalf("watch() error = %v", err)
+	}
+
+	if event := receive(t, events); event.Type != port.ChangeEventCreated {
+		t.Errorf("first event = %+v, want create", event)
+	}
+	if event := receive(t, events); event.Type != port.ChangeEventUpdated {
+		t.Errorf("second event = %+v, want update", event)
+	}
+
+	if len(tokens) != 2 || tokens[0] != nil {
+		t.Fatalf("resume tokens = %v, want nil then the last processed token", tokens)
+	}
+	if got := tokens[1].Lookup("_data").StringValue(); got != "1" {
+		t.Errorf("reopened after token %q, want %q", got, "1")
+	}
+}
+
+func TestChangeWatcher_OpenError(t *testing.T) {
+	wantErr := errors.New("change streams require a replica set")
+	w := &changeWatcher{
+		open: func(ctx context.Context, resumeToken bson.Raw) (changeStream, error) {
+			return nil, wantErr
+		},
+	}
+
+	if _, err := w.watch(context.Background()); !errors.Is(err, wantErr) {
+		t.Errorf("watch() error = %v, want %v", err, wantErr)
+	}
+}
@@ -0,0 +1,62 @@
+package mongo
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"go.mongodb.org/mongo-driver/bson"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// schemaFS 集合的 JSON Schema 定义，文件名为集合名称
+//
+//go:embed schemas/*.json
+var schemaFS embed.FS
+
+// CollectionSchema 获取集合的 JSON Schema 定义，未定义时返回 nil
+func CollectionSchema(collectionName string) []byte {
+	data, err := schemaFS.ReadFile("schemas/" + collectionName + ".json")
+	if err != nil {
+		return nil
+	}
+	return data
+}
+
+// WithSchema 插入文档前按 JSON Schema 校验文档，schema 为空时不校验
+// 文档先转换为 Relaxed Extended JSON 再校验，ObjectID、日期等类型在 Schema 中表示为 {"$oid": ...}、{"$date": ...}；
+// schema 在创建存储库时编译，内容无效属于编程错误，直接 panic
+func WithSchema(schema []byte) BaseRepositoryOption {
+	return func(r *BaseRepository) {
+		if len(schema) == 0 {
+			return
+		}
+		r.schema = jsonschema.MustCompileString(r.collectionName+".json", string(schema))
+	}
+}
+
+// validateDocument 按集合的 JSON Schema 校验文档，未配置 Schema 时不校验
+func (r *BaseRepository) validateDocument(document interface{}) error {
+	if r.schema == nil {
+		return nil
+	}
+
+	data, err := bson.MarshalExtJSON(document, false, false)
+	if err != nil {
+		return errors.WrapC(err, code.ErrDocumentSchemaValidation, "集合 %s 的文档无法转换为 JSON", r.collectionName)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return errors.WrapC(err, code.ErrDocumentSchemaValidation, "集合 %s 的文档无法转换为 JSON", r.collectionName)
+	}
+
+	if err := r.schema.Validate(value); err != nil {
+		return errors.WrapC(err, code.ErrDocumentSchemaValidation, "集合 %s 的文档不符合 Schema", r.collectionName)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package systemstats
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin/port"
+	questionnaireDomain "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	mongoBase "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo"
+	answersheetInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/answersheet"
+	medicalScaleInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/medical-scale"
+	questionnaireInfra "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo/questionnaire"
+)
+
+// notDeleted 未软删除的文档（deleted_at 为 null 或不存在）
+var notDeleted = bson.M{"deleted_at": nil}
+
+// Repository 系统统计MongoDB存储库
+// 统计查询可容忍复制延迟，各集合优先读从节点
+type Repository struct {
+	questionnaires mongoBase.BaseRepository
+	answersheets   mongoBase.BaseRepository
+	medicalScales  mongoBase.BaseRepository
+}
+
+// NewRepository 创建系统统计MongoDB存储库
+func NewRepository(db *mongo.Database) port.SystemStatsRepository {
+	readSecondary := mongoBase.WithReadPreference(mongoBase.ReadPreferenceSecondaryPreferred)
+	return &Repository{
+		questionnaires: mongoBase.NewBaseRepository(db, questionnaireInfra.QuestionnairePO{}.CollectionName(), readSecondary),
+		answersheets:   mongoBase.NewBaseRepository(db, answersheetInfra.AnswerSheetPO{}.CollectionName(), readSecondary),
+		medicalScales:  mongoBase.NewBaseRepository(db, medicalScaleInfra.MedicalScalePO{}.CollectionName(), readSecondary),
+	}
+}
+
+// CountQuestionnaires 统计问卷总数
+func (r *Repository) CountQuestionnaires(ctx context.Context) (int64, error) {
+	return r.questionnaires.CountDocuments(ctx, notDeleted)
+}
+
+// CountActiveQuestionnaires 统计已发布的问卷数
+func (r *Repository) CountActiveQuestionnaires(ctx context.Context) (int64, error) {
+	return r.questionnaires.CountDocuments(ctx, bson.M{
+		"deleted_at": nil,
+		"status":     questionnaireDomain.STATUS_PUBLISHED.Value(),
+	})
+}
+
+// CountAnswerSheets 统计答卷总数
+func (r *Repository) CountAnswerSheets(ctx context.Context) (int64, error) {
+	return r.answersheets.CountDocuments(ctx, notDeleted)
+}
+
+// CountRespondents 统计提交过答卷的受试者人数
+func (r *Repository) CountRespondents(ctx context.Context) (int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deleted_at": nil, "testee.id": bson.M{"$gt": 0}}}},
+		{{Key: "$group", Value: bson.M{"_id": "$testee.id"}}},
+		{{Key: "$count", Value: "total"}},
+	}
+
+	var result []struct {
+		Total int64 `bson:"total"`
+	}
+	if err := aggregate(ctx, r.answersheets.Collection(), pipeline, &result); err != nil {
+		return 0, err
+	}
+	if len(result) == 0 {
+		return 0, nil
+	}
+	return result[0].Total, nil
+}
+
+// CountMedicalScales 统计医学量表总数
+func (r *Repository) CountMedicalScales(ctx context.Context) (int64, error) {
+	return r.medicalScales.CountDocuments(ctx, notDeleted)
+}
+
+// CountDailySubmissions 按天统计 since 之后提交的答卷数，日期按 since 所在时区划分
+func (r *Repository) CountDailySubmissions(ctx context.Context, since time.Time) ([]admin.DailyCount, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"deleted_at": nil, "created_at": bson.M{"$gte": since}}}},
+		{{Key: "$group", Value: bson.M{
+			"_id": bson.M{"$dateToString": bson.M{
+				"format":   "%Y-%m-%d",
+				"date":     "$created_at",
+				"timezone": since.Format("-07:00"),
+			}},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	var result []struct {
+		Date  string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err := aggregate(ctx, r.answersheets.Collection(), pipeline, &result); err != nil {
+		return nil, err
+	}
+
+	counts := make([]admin.DailyCount, 0, len(result))
+	for _, item := range result {
+		counts = append(counts, admin.DailyCount{Date: item.Date, Count: item.Count})
+	}
+	return counts, nil
+}
+
+// FindTopQuestionnaires 查询提交答卷最多的 limit 个问卷
+func (r *Repository) FindTopQuestionnaires(ctx context.Context, limit int) ([]admin.QuestionnaireUsage, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: notDeleted}},
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$questionnaire_code",
+			"title": bson.M{"$last": "$title"},
+			"count": bson.M{"$sum": 1},
+		}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: limit}},
+	}
+
+	var result []struct {
+		Code  string `bson:"_id"`
+		Title string `bson:"title"`
+		Count int64  `bson:"count"`
+	}
+	if err := aggregate(ctx, r.answersheets.Collection(), pipeline, &result); err != nil {
+		return nil, err
+	}
+
+	usages := make([]admin.QuestionnaireUsage, 0, len(result))
+	for _, item := range result {
+		usages = append(usages, admin.QuestionnaireUsage{
+			QuestionnaireCode: item.Code,
+			Title:             item.Title,
+			SubmissionCount:   item.Count,
+		})
+	}
+	return usages, nil
+}
+
+// aggregate 执行聚合查询并解码全部结果
+func aggregate(ctx context.Context, collection *mongo.Collection, pipeline mongo.Pipeline, results interface{}) error {
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	return cursor.All(ctx, results)
+}
@@ -0,0 +1,37 @@
+package useractivitylog
+
+import "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+
+// UserActivityLogMapper 用户活动记录映射器
+type UserActivityLogMapper struct{}
+
+// NewUserActivityLogMapper 创建用户活动记录映射器
+func NewUserActivityLogMapper() *UserActivityLogMapper {
+	return &UserActivityLogMapper{}
+}
+
+// ToPO 将领域对象转换为持久化对象
+func (m *UserActivityLogMapper) ToPO(log *user.UserActivityLog) *UserActivityLogPO {
+	return &UserActivityLogPO{
+		UserID:     log.UserID,
+		Action:     log.Action,
+		Detail:     log.Detail,
+		IPAddress:  log.IPAddress,
+		UserAgent:  log.UserAgent,
+		Severity:   log.Severity,
+		OccurredAt: log.OccurredAt,
+	}
+}
+
+// ToBO 将持久化对象转换为领域对象
+func (m *UserActivityLogMapper) ToBO(po *UserActivityLogPO) *user.UserActivityLog {
+	return &user.UserActivityLog{
+		UserID:     po.UserID,
+		Action:     po.Action,
+		Detail:     po.Detail,
+		IPAddress:  po.IPAddress,
+		UserAgent:  po.UserAgent,
+		Severity:   po.Severity,
+		OccurredAt: po.OccurredAt,
+	}
+}
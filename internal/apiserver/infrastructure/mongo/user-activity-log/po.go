@@ -0,0 +1,20 @@
+package useractivitylog
+
+import "time"
+
+// UserActivityLogPO 用户活动记录MongoDB持久化对象
+type UserActivityLogPO struct {
+	UserID     uint64    `bson:"user_id" json:"user_id"`
+	Action     string    `bson:"action" json:"action"`
+	Detail     string    `bson:"detail,omitempty" json:"detail,omitempty"`
+	IPAddress  string    `bson:"ip_address" json:"ip_address"`
+	UserAgent  string    `bson:"user_agent" json:"user_agent"`
+	Severity   string    `bson:"severity" json:"severity"`
+	OccurredAt time.Time `bson:"occurred_at" json:"occurred_at"`
+}
+
+// CollectionName 集合名称
+// occurred_at 上建有 90 天过期的 TTL 索引，见 scripts/mongodb/create-indexes.js
+func (UserActivityLogPO) CollectionName() string {
+	return "user_activity_logs"
+}
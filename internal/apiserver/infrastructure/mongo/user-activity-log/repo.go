@@ -0,0 +1,81 @@
+package useractivitylog
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	mongoBase "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo"
+)
+
+// Repository 用户活动记录MongoDB存储库
+type Repository struct {
+	mongoBase.BaseRepository
+	mapper *UserActivityLogMapper
+}
+
+// NewRepository 创建用户活动记录MongoDB存储库
+func NewRepository(db *mongo.Database) port.UserActivityLogRepository {
+	po := &UserActivityLogPO{}
+	return &Repository{
+		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName()),
+		mapper:         NewUserActivityLogMapper(),
+	}
+}
+
+// Create 保存用户活动记录
+func (r *Repository) Create(ctx context.Context, log *user.UserActivityLog) error {
+	_, err := r.InsertOne(ctx, r.mapper.ToPO(log))
+	return err
+}
+
+// FindByUserID 分页查找用户在时间范围内的活动记录
+func (r *Repository) FindByUserID(ctx context.Context, userID uint64, from, to time.Time, page, pageSize int) ([]*user.UserActivityLog, error) {
+	opts := options.Find().
+		SetSkip(int64((page - 1) * pageSize)).
+		SetLimit(int64(pageSize)).
+		SetSort(bson.M{"occurred_at": -1}) // 按时间倒序
+
+	cursor, err := r.Find(ctx, userFilter(userID, from, to), opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var pos []UserActivityLogPO
+	if err := cursor.All(ctx, &pos); err != nil {
+		return nil, err
+	}
+
+	logs := make([]*user.UserActivityLog, 0, len(pos))
+	for i := range pos {
+		logs = append(logs, r.mapper.ToBO(&pos[i]))
+	}
+	return logs, nil
+}
+
+// CountByUserID 统计用户在时间范围内的活动记录数量
+func (r *Repository) CountByUserID(ctx context.Context, userID uint64, from, to time.Time) (int64, error) {
+	return r.CountDocuments(ctx, userFilter(userID, from, to))
+}
+
+// userFilter 按用户和时间范围过滤，时间范围为左闭右开区间
+func userFilter(userID uint64, from, to time.Time) bson.M {
+	filter := bson.M{"user_id": userID}
+	occurredAt := bson.M{}
+	if !from.IsZero() {
+		occurredAt["$gte"] = from
+	}
+	if !to.IsZero() {
+		occurredAt["$lt"] = to
+	}
+	if len(occurredAt) > 0 {
+		filter["occurred_at"] = occurredAt
+	}
+	return filter
+}
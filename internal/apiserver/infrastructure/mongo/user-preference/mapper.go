@@ -0,0 +1,35 @@
+package userpreference
+
+import "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+
+// UserPreferenceMapper 用户偏好设置映射器
+type UserPreferenceMapper struct{}
+
+// NewUserPreferenceMapper 创建用户偏好设置映射器
+func NewUserPreferenceMapper() *UserPreferenceMapper {
+	return &UserPreferenceMapper{}
+}
+
+// ToPO 将领域对象转换为持久化对象
+func (m *UserPreferenceMapper) ToPO(pref *user.UserPreference) *UserPreferencePO {
+	return &UserPreferencePO{
+		UserID:                    pref.UserID,
+		Language:                  pref.Language,
+		Theme:                     pref.Theme,
+		LastVisitedQuestionnaires: pref.LastVisitedQuestionnaires,
+		NotificationEnabled:       pref.NotificationEnabled,
+	}
+}
+
+// ToBO 将持久化对象转换为领域对象
+func (m *UserPreferenceMapper) ToBO(po *UserPreferencePO) *user.UserPreference {
+	pref := &user.UserPreference{
+		UserID:                    po.UserID,
+		Language:                  po.Language,
+		Theme:                     po.Theme,
+		LastVisitedQuestionnaires: po.LastVisitedQuestionnaires,
+		NotificationEnabled:       po.NotificationEnabled,
+	}
+	pref.Normalize()
+	return pref
+}
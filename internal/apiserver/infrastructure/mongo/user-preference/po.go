@@ -0,0 +1,19 @@
+package userpreference
+
+import "time"
+
+// UserPreferencePO 用户偏好设置MongoDB持久化对象
+// 每个用户一条文档，以 user_id 作为唯一键
+type UserPreferencePO struct {
+	UserID                    uint64    `bson:"user_id" json:"user_id"`
+	Language                  string    `bson:"language" json:"language"`
+	Theme                     string    `bson:"theme" json:"theme"`
+	LastVisitedQuestionnaires []string  `bson:"last_visited_questionnaires" json:"last_visited_questionnaires"`
+	NotificationEnabled       bool      `bson:"notification_enabled" json:"notification_enabled"`
+	UpdatedAt                 time.Time `bson:"updated_at" json:"updated_at"`
+}
+
+// CollectionName 集合名称
+func (UserPreferencePO) CollectionName() string {
+	return "user_preferences"
+}
@@ -0,0 +1,57 @@
+package userpreference
+
+import (
+	"context"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	mongoBase "github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mongo"
+)
+
+// Repository 用户偏好设置MongoDB存储库
+type Repository struct {
+	mongoBase.BaseRepository
+	mapper *UserPreferenceMapper
+}
+
+// NewRepository 创建用户偏好设置MongoDB存储库
+func NewRepository(db *mongo.Database) port.UserPreferenceRepository {
+	po := &UserPreferencePO{}
+	return &Repository{
+		BaseRepository: mongoBase.NewBaseRepository(db, po.CollectionName()),
+		mapper:         NewUserPreferenceMapper(),
+	}
+}
+
+// FindByUserID 根据用户ID查找偏好设置
+func (r *Repository) FindByUserID(ctx context.Context, userID uint64) (*user.UserPreference, error) {
+	var po UserPreferencePO
+	err := r.FindOne(ctx, bson.M{"user_id": userID}, &po)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return r.mapper.ToBO(&po), nil
+}
+
+// Upsert 保存偏好设置，文档不存在时创建
+func (r *Repository) Upsert(ctx context.Context, pref *user.UserPreference) error {
+	po := r.mapper.ToPO(pref)
+	po.UpdatedAt = time.Now()
+
+	_, err := r.Collection().ReplaceOne(
+		ctx,
+		bson.M{"user_id": po.UserID},
+		po,
+		options.Replace().SetUpsert(true),
+	)
+	return err
+}
@@ -0,0 +1,52 @@
+package assignment
+
+import (
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql"
+)
+
+// AssignmentPO 问卷分配持久化对象
+type AssignmentPO struct {
+	mysql.AuditFields
+	AssignmentCode    string     `gorm:"uniqueIndex;column:assignment_code;type:varchar(64)" json:"assignment_code"`
+	RespondentID      uint64     `gorm:"index:idx_respondent_questionnaire;column:respondent_id" json:"respondent_id"`
+	QuestionnaireCode string     `gorm:"index:idx_respondent_questionnaire;column:questionnaire_code;type:varchar(255)" json:"questionnaire_code"`
+	AssignedBy        uint64     `gorm:"column:assigned_by" json:"assigned_by"`
+	DueDate           *time.Time `gorm:"index;column:due_date" json:"due_date"`
+	CompletedAt       *time.Time `gorm:"column:completed_at" json:"completed_at"`
+	ReminderSentAt    *time.Time `gorm:"column:reminder_sent_at" json:"reminder_sent_at"`
+}
+
+// TableName 指定表名
+func (AssignmentPO) TableName() string {
+	return "assignments"
+}
+
+// newAssignmentPO 将问卷分配转换为持久化对象
+func newAssignmentPO(a *assignment.Assignment) *AssignmentPO {
+	po := &AssignmentPO{
+		AssignmentCode:    a.AssignmentCode,
+		RespondentID:      a.RespondentID,
+		QuestionnaireCode: a.QuestionnaireCode,
+		AssignedBy:        a.AssignedBy,
+		DueDate:           a.DueDate,
+		CompletedAt:       a.CompletedAt,
+		ReminderSentAt:    a.ReminderSentAt,
+	}
+	po.ID = a.ID
+	po.CreatedAt = a.CreatedAt
+	po.CreatedBy = a.AssignedBy
+	return po
+}
+
+// ToBO 转换为领域对象
+func (p *AssignmentPO) ToBO() *assignment.Assignment {
+	a := assignment.NewAssignment(p.AssignmentCode, p.RespondentID, p.QuestionnaireCode, p.AssignedBy, p.DueDate)
+	a.ID = p.ID
+	a.CompletedAt = p.CompletedAt
+	a.ReminderSentAt = p.ReminderSentAt
+	a.CreatedAt = p.CreatedAt
+	return a
+}
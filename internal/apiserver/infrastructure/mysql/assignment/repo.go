@@ -0,0 +1,129 @@
+package assignment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/assignment/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	pkgerrors "github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Repository 问卷分配存储库实现
+type Repository struct {
+	mysql.BaseRepository[*AssignmentPO]
+}
+
+// NewRepository 创建问卷分配存储库
+func NewRepository(db *gorm.DB) port.AssignmentRepository {
+	return &Repository{
+		BaseRepository: mysql.NewBaseRepository[*AssignmentPO](db),
+	}
+}
+
+// Create 保存问卷分配，回填ID和创建时间
+func (r *Repository) Create(ctx context.Context, a *assignment.Assignment) error {
+	return r.CreateAndSync(ctx, newAssignmentPO(a), func(po *AssignmentPO) {
+		a.ID = po.ID
+		a.CreatedAt = po.CreatedAt
+	})
+}
+
+// FindByCode 根据分配编码查询
+func (r *Repository) FindByCode(ctx context.Context, assignmentCode string) (*assignment.Assignment, error) {
+	var po AssignmentPO
+	if err := r.FindByField(ctx, &po, "assignment_code", assignmentCode); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pkgerrors.WithCode(code.ErrAssignmentNotFound, "assignment not found: %s", assignmentCode)
+		}
+		return nil, err
+	}
+	return po.ToBO(), nil
+}
+
+// FindList 分页查询问卷分配
+func (r *Repository) FindList(ctx context.Context, filter port.AssignmentFilter, page, pageSize int) ([]*assignment.Assignment, int64, error) {
+	db := r.WithContext(ctx).Model(&AssignmentPO{})
+	if filter.RespondentID != 0 {
+		db = db.Where("respondent_id = ?", filter.RespondentID)
+	}
+	if filter.QuestionnaireCode != "" {
+		db = db.Where("questionnaire_code = ?", filter.QuestionnaireCode)
+	}
+	if filter.PendingOnly {
+		db = db.Where("completed_at IS NULL")
+	}
+
+	var total int64
+	if err := db.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var pos []*AssignmentPO
+	err := db.Order("created_at DESC, id DESC").
+		Offset((page - 1) * pageSize).
+		Limit(pageSize).
+		Find(&pos).Error
+	if err != nil {
+		return nil, 0, err
+	}
+	return toBOList(pos), total, nil
+}
+
+// FindPending 查询受试者在问卷下未完成的分配
+func (r *Repository) FindPending(ctx context.Context, respondentID uint64, questionnaireCode string) ([]*assignment.Assignment, error) {
+	var pos []*AssignmentPO
+	err := r.WithContext(ctx).
+		Where("respondent_id = ? AND questionnaire_code = ? AND completed_at IS NULL", respondentID, questionnaireCode).
+		Order("due_date IS NULL, due_date ASC, id ASC").
+		Find(&pos).Error
+	if err != nil {
+		return nil, err
+	}
+	return toBOList(pos), nil
+}
+
+// FindOverdue 查询逾期且未提醒的分配
+func (r *Repository) FindOverdue(ctx context.Context, now time.Time, limit int) ([]*assignment.Assignment, error) {
+	var pos []*AssignmentPO
+	err := r.WithContext(ctx).
+		Where("due_date < ? AND completed_at IS NULL AND reminder_sent_at IS NULL", now).
+		Order("due_date ASC, id ASC").
+		Limit(limit).
+		Find(&pos).Error
+	if err != nil {
+		return nil, err
+	}
+	return toBOList(pos), nil
+}
+
+// Update 更新问卷分配
+func (r *Repository) Update(ctx context.Context, a *assignment.Assignment) error {
+	return r.UpdateAndSync(ctx, newAssignmentPO(a), func(*AssignmentPO) {})
+}
+
+// Remove 删除问卷分配
+func (r *Repository) Remove(ctx context.Context, assignmentCode string) error {
+	result := r.WithContext(ctx).Where("assignment_code = ?", assignmentCode).Delete(&AssignmentPO{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return pkgerrors.WithCode(code.ErrAssignmentNotFound, "assignment not found: %s", assignmentCode)
+	}
+	return nil
+}
+
+// toBOList 批量转换为领域对象
+func toBOList(pos []*AssignmentPO) []*assignment.Assignment {
+	assignments := make([]*assignment.Assignment, 0, len(pos))
+	for _, po := range pos {
+		assignments = append(assignments, po.ToBO())
+	}
+	return assignments
+}
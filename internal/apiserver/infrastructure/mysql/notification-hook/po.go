@@ -0,0 +1,75 @@
+package notificationhook
+
+import (
+	"strings"
+
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql"
+)
+
+// NotificationHookPO 通知钩子持久化对象
+type NotificationHookPO struct {
+	mysql.AuditFields
+	QuestionnaireCode string `gorm:"index;column:questionnaire_code;type:varchar(255)" json:"questionnaire_code"`
+	URL               string `gorm:"column:url;type:varchar(1024)" json:"url"`
+	Secret            string `gorm:"column:secret;type:varchar(255)" json:"-"`
+	Events            string `gorm:"column:events;type:varchar(255)" json:"events"` // 订阅的事件，以逗号分隔
+}
+
+// TableName 指定表名
+func (NotificationHookPO) TableName() string {
+	return "notification_hooks"
+}
+
+// ToBO 转换为领域对象
+func (p *NotificationHookPO) ToBO() *notificationhook.NotificationHook {
+	var events []string
+	for _, event := range strings.Split(p.Events, ",") {
+		if event = strings.TrimSpace(event); event != "" {
+			events = append(events, event)
+		}
+	}
+
+	return &notificationhook.NotificationHook{
+		ID:                p.ID,
+		QuestionnaireCode: p.QuestionnaireCode,
+		URL:               p.URL,
+		Secret:            p.Secret,
+		Events:            events,
+	}
+}
+
+// DeliveryPO 通知投递记录持久化对象
+type DeliveryPO struct {
+	mysql.AuditFields
+	HookID            uint64 `gorm:"index;column:hook_id" json:"hook_id"`
+	Event             string `gorm:"column:event;type:varchar(50)" json:"event"`
+	QuestionnaireCode string `gorm:"column:questionnaire_code;type:varchar(255)" json:"questionnaire_code"`
+	Attempt           int    `gorm:"column:attempt" json:"attempt"`
+	StatusCode        int    `gorm:"column:status_code" json:"status_code"`
+	Error             string `gorm:"column:error;type:varchar(1024)" json:"error"`
+}
+
+// TableName 指定表名
+func (DeliveryPO) TableName() string {
+	return "notification_hook_deliveries"
+}
+
+// newDeliveryPO 将投递记录转换为持久化对象
+func newDeliveryPO(delivery *notificationhook.Delivery) *DeliveryPO {
+	po := &DeliveryPO{
+		HookID:            delivery.HookID,
+		Event:             delivery.Event,
+		QuestionnaireCode: delivery.QuestionnaireCode,
+		Attempt:           delivery.Attempt,
+		StatusCode:        delivery.StatusCode,
+		Error:             delivery.Error,
+	}
+	po.CreatedAt = delivery.CreatedAt
+	return po
+}
+
+// ToBO 转换为领域对象
+func (p *DeliveryPO) ToBO() *notificationhook.Delivery {
+	return notificationhook.NewDelivery(p.HookID, p.Event, p.QuestionnaireCode, p.Attempt, p.StatusCode, p.Error, p.CreatedAt)
+}
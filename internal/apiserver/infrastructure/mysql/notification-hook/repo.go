@@ -0,0 +1,88 @@
+package notificationhook
+
+import (
+	"context"
+	"errors"
+
+	"gorm.io/gorm"
+
+	notificationhook "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	pkgerrors "github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// Repository 通知钩子存储库实现
+type Repository struct {
+	mysql.BaseRepository[*NotificationHookPO]
+}
+
+// NewRepository 创建通知钩子存储库
+func NewRepository(db *gorm.DB) port.NotificationHookRepository {
+	return &Repository{
+		BaseRepository: mysql.NewBaseRepository[*NotificationHookPO](db),
+	}
+}
+
+// FindByID 根据ID查询通知钩子
+func (r *Repository) FindByID(ctx context.Context, id uint64) (*notificationhook.NotificationHook, error) {
+	var po NotificationHookPO
+	if err := r.BaseRepository.FindByField(ctx, &po, "id", id); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, pkgerrors.WithCode(code.ErrNotificationHookNotFound, "notification hook not found: %d", id)
+		}
+		return nil, err
+	}
+	return po.ToBO(), nil
+}
+
+// FindByQuestionnaireCode 查询问卷下的所有通知钩子
+func (r *Repository) FindByQuestionnaireCode(ctx context.Context, questionnaireCode string) ([]*notificationhook.NotificationHook, error) {
+	pos, err := r.FindWithConditions(ctx, &NotificationHookPO{}, map[string]interface{}{"questionnaire_code": questionnaireCode})
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := make([]*notificationhook.NotificationHook, 0, len(pos))
+	for _, po := range pos {
+		hooks = append(hooks, po.ToBO())
+	}
+	return hooks, nil
+}
+
+// DeliveryRepository 通知投递记录存储库实现
+type DeliveryRepository struct {
+	mysql.BaseRepository[*DeliveryPO]
+}
+
+// NewDeliveryRepository 创建通知投递记录存储库
+func NewDeliveryRepository(db *gorm.DB) port.DeliveryRepository {
+	return &DeliveryRepository{
+		BaseRepository: mysql.NewBaseRepository[*DeliveryPO](db),
+	}
+}
+
+// Create 保存投递记录
+func (r *DeliveryRepository) Create(ctx context.Context, delivery *notificationhook.Delivery) error {
+	return r.CreateAndSync(ctx, newDeliveryPO(delivery), func(*DeliveryPO) {})
+}
+
+// FindByHookID 查询通知钩子最近的投递记录，按时间倒序
+func (r *DeliveryRepository) FindByHookID(ctx context.Context, hookID uint64, limit int) ([]*notificationhook.Delivery, error) {
+	var pos []*DeliveryPO
+	err := r.WithContext(ctx).
+		Where("hook_id = ?", hookID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&pos).Error
+	if err != nil {
+		return nil, err
+	}
+
+	deliveries := make([]*notificationhook.Delivery, 0, len(pos))
+	for _, po := range pos {
+		deliveries = append(deliveries, po.ToBO())
+	}
+	return deliveries, nil
+}
@@ -1,6 +1,8 @@
 package questionnaire
 
 import (
+	"strings"
+
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
 )
 
@@ -19,6 +21,9 @@ func (m *QuestionnaireMapper) ToPO(bo *questionnaire.Questionnaire) *Questionnai
 		ImgUrl:      bo.GetImgUrl(),
 		Version:     bo.GetVersion().Value(),
 		Status:      bo.GetStatus().Value(),
+
+		GeoRestriction: strings.Join(bo.GetGeoRestriction(), ","),
+		Tags:           bo.GetTags(),
 	}
 
 	// 设置 AuditFields 中的 ID
@@ -37,6 +42,8 @@ func (m *QuestionnaireMapper) ToBO(po *QuestionnairePO) *questionnaire.Questionn
 		questionnaire.WithImgUrl(po.ImgUrl),
 		questionnaire.WithVersion(questionnaire.NewQuestionnaireVersion(po.Version)),
 		questionnaire.WithStatus(questionnaire.QuestionnaireStatus(po.Status)),
+		questionnaire.WithGeoRestriction(splitGeoRestriction(po.GeoRestriction)),
+		questionnaire.WithTags(po.Tags),
 	)
 
 	return qBO
@@ -50,3 +57,11 @@ func (m *QuestionnaireMapper) ToBOList(pos []*QuestionnairePO) []*questionnaire.
 	}
 	return bos
 }
+
+// splitGeoRestriction 拆分以逗号分隔的国家代码
+func splitGeoRestriction(value string) []string {
+	if value == "" {
+		return nil
+	}
+	return strings.Split(value, ",")
+}
@@ -19,6 +19,10 @@ type QuestionnairePO struct {
 	ImgUrl      string `gorm:"column:img_url;type:varchar(255)" json:"img_url"`
 	Version     string `gorm:"column:version;type:varchar(255);" json:"version"`
 	Status      uint8  `gorm:"column:status;type:tinyint;" json:"status"`
+	// GeoRestriction 允许访问的国家代码，以逗号分隔
+	GeoRestriction string `gorm:"column:geo_restriction;type:varchar(255)" json:"geo_restriction"`
+	// Tags 问卷标签，以 JSON 数组存储，按标签查询时使用 JSON_CONTAINS
+	Tags []string `gorm:"column:tags;type:json;serializer:json" json:"tags"`
 }
 
 // TableName 指定表名
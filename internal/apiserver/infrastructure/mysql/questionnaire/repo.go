@@ -2,6 +2,8 @@ package questionnaire
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
 	"gorm.io/gorm"
 
@@ -33,7 +35,18 @@ func (r *Repository) Create(ctx context.Context, qDomain *questionnaire.Question
 }
 
 // Update 更新问卷
+// 已发布的问卷只允许变更状态，以发布状态再次保存时返回 ErrPublishedQuestionnaireImmutable
 func (r *Repository) Update(ctx context.Context, qDomain *questionnaire.Questionnaire) error {
+	if qDomain.IsPublished() {
+		stored, err := r.FindByID(ctx, qDomain.GetID().Value())
+		if err != nil {
+			return err
+		}
+		if err := stored.EnsureImmutability(); err != nil {
+			return err
+		}
+	}
+
 	return r.BaseRepository.UpdateAndSync(ctx, r.mapper.ToPO(qDomain), func(qPO *QuestionnairePO) {
 		qDomain.SetID(questionnaire.NewQuestionnaireID(qPO.ID))
 	})
@@ -77,3 +90,48 @@ func (r *Repository) FindList(ctx context.Context, page, pageSize int, condition
 func (r *Repository) CountWithConditions(ctx context.Context, conditions map[string]string) (int64, error) {
 	return r.BaseRepository.CountWithConditions(ctx, &QuestionnairePO{}, conditions)
 }
+
+// FindByTags 按标签分页查询问卷，标签以 JSON 数组存储在 tags 列中
+func (r *Repository) FindByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*questionnaire.Questionnaire, int64, error) {
+	condition, args := tagsCondition(tags, matchAll)
+
+	var total int64
+	if err := r.WithContext(ctx).Model(&QuestionnairePO{}).Where(condition, args...).Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	db := r.WithContext(ctx).Where(condition, args...).Order("id DESC")
+	if page > 0 {
+		db = db.Offset((page - 1) * pageSize)
+	}
+	if pageSize > 0 {
+		db = db.Limit(pageSize)
+	}
+	pos := make([]*QuestionnairePO, 0)
+	if err := db.Find(&pos).Error; err != nil {
+		return nil, 0, err
+	}
+	return r.mapper.ToBOList(pos), total, nil
+}
+
+// tagsCondition 构造按标签查询的 JSON_CONTAINS 条件
+// matchAll 为 true 时要求 tags 包含整个标签数组，否则以 OR 连接每个标签的条件
+func tagsCondition(tags []string, matchAll bool) (string, []interface{}) {
+	if matchAll {
+		return "JSON_CONTAINS(tags, ?)", []interface{}{jsonString(tags)}
+	}
+
+	clauses := make([]string, 0, len(tags))
+	args := make([]interface{}, 0, len(tags))
+	for _, tag := range tags {
+		clauses = append(clauses, "JSON_CONTAINS(tags, ?)")
+		args = append(args, jsonString(tag))
+	}
+	return "(" + strings.Join(clauses, " OR ") + ")", args
+}
+
+// jsonString 将标签编码为 JSON_CONTAINS 的候选值，字符串和字符串切片的编码不会失败
+func jsonString(v interface{}) string {
+	data, _ := json.Marshal(v)
+	return string(data)
+}
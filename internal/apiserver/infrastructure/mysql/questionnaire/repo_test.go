@@ -0,0 +1,39 @@
+package questionnaire
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTagsCondition(t *testing.T) {
+	tests := []struct {
+		name      string
+		matchAll  bool
+		wantQuery string
+		wantArgs  []interface{}
+	}{
+		{
+			name:      "match all tags",
+			matchAll:  true,
+			wantQuery: "JSON_CONTAINS(tags, ?)",
+			wantArgs:  []interface{}{`["depression","anxiety"]`},
+		},
+		{
+			name:      "match any tag",
+			matchAll:  false,
+			wantQuery: "(JSON_CONTAINS(tags, ?) OR JSON_CONTAINS(tags, ?))",
+			wantArgs:  []interface{}{`"depression"`, `"anxiety"`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := tagsCondition([]string{"depression", "anxiety"}, tt.matchAll)
+			if query != tt.wantQuery {
+				t.Errorf("tagsCondition() query = %q, want %q", query, tt.wantQuery)
+			}
+			if !reflect.DeepEqual(args, tt.wantArgs) {
+				t.Errorf("tagsCondition() args = %v, want %v", args, tt.wantArgs)
+			}
+		})
+	}
+}
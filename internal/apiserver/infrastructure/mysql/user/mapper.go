@@ -21,14 +21,17 @@ func (m *UserMapper) ToPO(domainUser *user.User) *UserPO {
 
 	// 先创建持久化对象（不包含嵌入字段的成员）
 	po := &UserPO{
-		Username:     domainUser.Username(),
-		Nickname:     domainUser.Nickname(),
-		Avatar:       domainUser.Avatar(),
-		Phone:        domainUser.Phone(),
-		Introduction: domainUser.Introduction(),
-		Email:        domainUser.Email(),
-		Password:     domainUser.Password(),
-		Status:       domainUser.Status().Value(),
+		Username:         domainUser.Username(),
+		Nickname:         domainUser.Nickname(),
+		Avatar:           domainUser.Avatar(),
+		Phone:            domainUser.Phone(),
+		Introduction:     domainUser.Introduction(),
+		Email:            domainUser.Email(),
+		Password:         domainUser.Password(),
+		Status:           domainUser.Status().Value(),
+		OTPSecret:        domainUser.OTPSecret(),
+		OTPLastStep:      domainUser.OTPLastStep(),
+		OTPPendingSecret: domainUser.OTPPendingSecret(),
 	}
 
 	// 然后设置嵌入字段的成员
@@ -56,6 +59,9 @@ func (m *UserMapper) ToBO(po *UserPO) *user.User {
 		WithStatus(user.Status(po.Status)).
 		WithCreatedAt(po.CreatedAt).
 		WithUpdatedAt(po.UpdatedAt).
+		WithOTPSecret(po.OTPSecret).
+		WithOTPLastStep(po.OTPLastStep).
+		WithOTPPendingSecret(po.OTPPendingSecret).
 		Build()
 
 	// 直接设置已加密的密码，不需要重新加密
@@ -13,14 +13,17 @@ import (
 // 对应数据库表结构
 type UserPO struct {
 	base.AuditFields
-	Username     string `gorm:"uniqueIndex;column:username;type:varchar(50)" json:"username"`
-	Nickname     string `gorm:"column:nickname;type:varchar(50)" json:"nickname"`
-	Avatar       string `gorm:"column:avatar;type:varchar(255)" json:"avatar"`
-	Phone        string `gorm:"column:phone;type:varchar(20)" json:"phone"`
-	Introduction string `gorm:"column:introduction;type:varchar(255)" json:"introduction"`
-	Email        string `gorm:"uniqueIndex;column:email;type:varchar(100)" json:"email"`
-	Password     string `gorm:"column:password;type:varchar(255)" json:"-"`
-	Status       uint8  `gorm:"column:status;type:tinyint;default:0" json:"status"`
+	Username         string `gorm:"uniqueIndex;column:username;type:varchar(50)" json:"username"`
+	Nickname         string `gorm:"column:nickname;type:varchar(50)" json:"nickname"`
+	Avatar           string `gorm:"column:avatar;type:varchar(255)" json:"avatar"`
+	Phone            string `gorm:"column:phone;type:varchar(20)" json:"phone"`
+	Introduction     string `gorm:"column:introduction;type:varchar(255)" json:"introduction"`
+	Email            string `gorm:"uniqueIndex;column:email;type:varchar(100)" json:"email"`
+	Password         string `gorm:"column:password;type:varchar(255)" json:"-"`
+	Status           uint8  `gorm:"column:status;type:tinyint;default:0" json:"status"`
+	OTPSecret        string `gorm:"column:otp_secret;type:varchar(128)" json:"-"`
+	OTPLastStep      int64  `gorm:"column:otp_last_step;default:0" json:"-"`
+	OTPPendingSecret string `gorm:"column:otp_pending_secret;type:varchar(128)" json:"-"`
 }
 
 // TableName 指定表名
@@ -64,6 +64,29 @@ func (r *Repository) Update(ctx context.Context, userDomain *user.User) error {
 	})
 }
 
+// UpdateOTP 更新用户的 TOTP 密钥、待启用密钥和已使用的时间步，空值同样写入
+func (r *Repository) UpdateOTP(ctx context.Context, userDomain *user.User) error {
+	return r.WithContext(ctx).Model(&UserPO{}).
+		Where("id = ?", userDomain.ID().Value()).
+		Updates(map[string]interface{}{
+			"otp_secret":         userDomain.OTPSecret(),
+			"otp_pending_secret": userDomain.OTPPendingSecret(),
+			"otp_last_step":      userDomain.OTPLastStep(),
+		}).Error
+}
+
+// ConsumeOTPStep 以条件更新记录已使用的一次性密码时间步，时间步不晚于已记录的时间步时返回 false
+// 并发提交同一个一次性密码时只有一个请求能更新成功
+func (r *Repository) ConsumeOTPStep(ctx context.Context, id user.UserID, step int64) (bool, error) {
+	result := r.WithContext(ctx).Model(&UserPO{}).
+		Where("id = ? AND otp_last_step < ?", id.Value(), step).
+		Update("otp_last_step", step)
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
 // 查询操作
 func (r *Repository) FindByUsername(ctx context.Context, username string) (*user.User, error) {
 	var po UserPO
@@ -0,0 +1,49 @@
+package user
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/mysql"
+)
+
+// UserScopePO 用户问卷范围持久化对象
+// 每行记录一个问卷编码或通配模式（如 "*"、"phq*"）
+type UserScopePO struct {
+	mysql.AuditFields
+	UserID uint64 `gorm:"index;column:user_id" json:"user_id"`
+	Scope  string `gorm:"column:scope;type:varchar(100)" json:"scope"`
+}
+
+// TableName 指定表名
+func (UserScopePO) TableName() string {
+	return "user_scopes"
+}
+
+// ScopeRepository 用户问卷范围存储库实现
+type ScopeRepository struct {
+	mysql.BaseRepository[*UserScopePO]
+}
+
+// NewScopeRepository 创建用户问卷范围存储库
+func NewScopeRepository(db *gorm.DB) port.UserScopeRepository {
+	return &ScopeRepository{
+		BaseRepository: mysql.NewBaseRepository[*UserScopePO](db),
+	}
+}
+
+// FindScopesByUserID 查找用户可管理的问卷范围
+func (r *ScopeRepository) FindScopesByUserID(ctx context.Context, userID uint64) ([]string, error) {
+	pos, err := r.FindWithConditions(ctx, &UserScopePO{}, map[string]interface{}{"user_id": userID})
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := make([]string, 0, len(pos))
+	for _, po := range pos {
+		scopes = append(scopes, po.Scope)
+	}
+	return scopes, nil
+}
@@ -0,0 +1,96 @@
+package objectstorage
+
+import (
+	"context"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// DefaultRegion 未配置区域时使用的区域，MinIO 默认即为该区域
+const DefaultRegion = "us-east-1"
+
+// Config S3 兼容对象存储的连接配置，MinIO 与 AWS S3 共用
+type Config struct {
+	Endpoint  string // 服务地址，如 s3.amazonaws.com 或 minio.example.com:9000
+	Region    string
+	AccessKey string
+	SecretKey string
+	Bucket    string
+	UseSSL    bool
+}
+
+// Presigner 基于 MinIO 客户端的对象存储签名器，同时支持 MinIO 与 AWS S3
+type Presigner struct {
+	client *minio.Client
+	bucket string
+}
+
+// 确保实现了接口
+var (
+	_ port.ObjectStoragePresigner = (*Presigner)(nil)
+	_ port.ObjectStorageInspector = (*Presigner)(nil)
+)
+
+// NewPresigner 创建对象存储签名器，签名在本地完成，不访问对象存储
+func NewPresigner(cfg Config) (*Presigner, error) {
+	region := cfg.Region
+	if region == "" {
+		region = DefaultRegion
+	}
+
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+		Region: region,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Presigner{client: client, bucket: cfg.Bucket}, nil
+}
+
+// PresignPostUpload 签发 POST 表单上传链接
+func (p *Presigner) PresignPostUpload(ctx context.Context, objectKey, mimeType string, maxSizeBytes int64, expiresAt time.Time) (string, map[string]string, error) {
+	policy := minio.NewPostPolicy()
+	if err := policy.SetBucket(p.bucket); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetKey(objectKey); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetExpires(expiresAt); err != nil {
+		return "", nil, err
+	}
+	if err := policy.SetContentType(mimeType); err != nil {
+		return "", nil, err
+	}
+	if maxSizeBytes > 0 {
+		if err := policy.SetContentLengthRange(1, maxSizeBytes); err != nil {
+			return "", nil, err
+		}
+	}
+
+	u, fields, err := p.client.PresignedPostPolicy(ctx, policy)
+	if err != nil {
+		return "", nil, err
+	}
+	return u.String(), fields, nil
+}
+
+// StatObject 查询对象元信息
+func (p *Presigner) StatObject(ctx context.Context, objectKey string) (*port.ObjectInfo, error) {
+	info, err := p.client.StatObject(ctx, p.bucket, objectKey, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == minio.NoSuchKey {
+			return nil, errors.WithCode(code.ErrUploadNotFound, "对象 %s 不存在", objectKey)
+		}
+		return nil, err
+	}
+	return &port.ObjectInfo{SizeBytes: info.Size, MimeType: info.ContentType}, nil
+}
@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	redis "github.com/go-redis/redis/v7"
+
+	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+)
+
+// reliabilityKeyPrefix 量表信度的缓存键前缀
+const reliabilityKeyPrefix = "medical-scale:reliability:"
+
+// ReliabilityCache 基于 Redis 的量表信度缓存
+type ReliabilityCache struct {
+	client redis.UniversalClient
+}
+
+// NewReliabilityCache 创建量表信度缓存
+func NewReliabilityCache(client redis.UniversalClient) port.ReliabilityCache {
+	return &ReliabilityCache{client: client}
+}
+
+// Get 获取缓存的量表信度，未缓存时返回 nil
+func (c *ReliabilityCache) Get(ctx context.Context, scaleCode string) (*medicalscale.Reliability, error) {
+	data, err := c.client.DoContext(ctx, "GET", reliabilityKeyPrefix+scaleCode).Text()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var reliability medicalscale.Reliability
+	if err := json.Unmarshal([]byte(data), &reliability); err != nil {
+		return nil, err
+	}
+	return &reliability, nil
+}
+
+// Set 缓存量表信度
+func (c *ReliabilityCache) Set(ctx context.Context, reliability *medicalscale.Reliability, ttl time.Duration) error {
+	data, err := json.Marshal(reliability)
+	if err != nil {
+		return err
+	}
+	return c.client.DoContext(ctx, "SET", reliabilityKeyPrefix+reliability.ScaleCode, data, "PX", ttl.Milliseconds()).Err()
+}
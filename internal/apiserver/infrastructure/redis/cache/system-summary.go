@@ -0,0 +1,51 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	redis "github.com/go-redis/redis/v7"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin/port"
+)
+
+// systemSummaryKey 系统概览的缓存键
+const systemSummaryKey = "admin:system-summary"
+
+// SystemSummaryCache 基于 Redis 的系统概览缓存，多实例共享同一份统计结果
+type SystemSummaryCache struct {
+	client redis.UniversalClient
+}
+
+// NewSystemSummaryCache 创建系统概览缓存
+func NewSystemSummaryCache(client redis.UniversalClient) port.SystemSummaryCache {
+	return &SystemSummaryCache{client: client}
+}
+
+// Get 获取缓存的系统概览，未缓存时返回 nil
+func (c *SystemSummaryCache) Get(ctx context.Context) (*admin.SystemSummary, error) {
+	data, err := c.client.DoContext(ctx, "GET", systemSummaryKey).Text()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var summary admin.SystemSummary
+	if err := json.Unmarshal([]byte(data), &summary); err != nil {
+		return nil, err
+	}
+	return &summary, nil
+}
+
+// Set 缓存系统概览
+func (c *SystemSummaryCache) Set(ctx context.Context, summary *admin.SystemSummary, ttl time.Duration) error {
+	data, err := json.Marshal(summary)
+	if err != nil {
+		return err
+	}
+	return c.client.DoContext(ctx, "SET", systemSummaryKey, data, "PX", ttl.Milliseconds()).Err()
+}
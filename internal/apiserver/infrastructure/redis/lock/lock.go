@@ -0,0 +1,104 @@
+package lock
+
+import (
+	"context"
+	"time"
+
+	redis "github.com/go-redis/redis/v7"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/scheduler"
+)
+
+// releaseScript 仅当锁仍由当前持有者持有时删除，避免误删其他实例在过期后获取的锁
+const releaseScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`
+
+// refreshScript 仅当锁仍由当前持有者持有时重置过期时间（毫秒）
+const refreshScript = `
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("PEXPIRE", KEYS[1], ARGV[2])
+end
+return 0
+`
+
+// RedisLock 基于 Redis SET NX EX 的分布式锁
+type RedisLock struct {
+	client redis.UniversalClient
+	owner  string
+}
+
+// NewRedisLock 创建 Redis 分布式锁，owner 为空时使用主机名和进程号
+func NewRedisLock(client redis.UniversalClient, owner string) *RedisLock {
+	if owner == "" {
+		owner = scheduler.DefaultLockOwner()
+	}
+	return &RedisLock{
+		client: client,
+		owner:  owner,
+	}
+}
+
+// Acquire 尝试获取锁
+func (l *RedisLock) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	seconds := int64(ttl / time.Second)
+	if seconds <= 0 {
+		seconds = 1
+	}
+
+	err := l.client.DoContext(ctx, "SET", key, l.owner, "NX", "EX", seconds).Err()
+	if err == redis.Nil {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Refresh 续期锁，锁已不属于当前持有者时返回 false
+func (l *RedisLock) Refresh(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	millis := int64(ttl / time.Millisecond)
+	if millis <= 0 {
+		millis = 1
+	}
+
+	renewed, err := l.client.DoContext(ctx, "EVAL", refreshScript, 1, key, l.owner, millis).Int64()
+	if err != nil {
+		return false, err
+	}
+	return renewed == 1, nil
+}
+
+// Release 释放锁
+func (l *RedisLock) Release(ctx context.Context, key string) error {
+	return l.client.DoContext(ctx, "EVAL", releaseScript, 1, key, l.owner).Err()
+}
+
+// Status 查询锁的持有者及剩余过期时间
+func (l *RedisLock) Status(ctx context.Context, key string) (*scheduler.LockStatus, error) {
+	status := &scheduler.LockStatus{Key: key}
+
+	owner, err := l.client.DoContext(ctx, "GET", key).Text()
+	if err == redis.Nil {
+		return status, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	ttl, err := l.client.DoContext(ctx, "PTTL", key).Int64()
+	if err != nil {
+		return nil, err
+	}
+
+	status.Locked = true
+	status.LockOwner = owner
+	if ttl > 0 {
+		status.TTL = time.Duration(ttl) * time.Millisecond
+	}
+	return status, nil
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+
+	redis "github.com/go-redis/redis/v7"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+)
+
+// tokenBucketScript 原子地补充令牌并尝试取出一个令牌
+// KEYS[1] 令牌桶键；ARGV[1] 每秒补充的令牌数；ARGV[2] 桶容量；ARGV[3] 当前毫秒时间戳；ARGV[4] 键过期毫秒数
+const tokenBucketScript = `
+local rate = tonumber(ARGV[1])
+local burst = tonumber(ARGV[2])
+local now = tonumber(ARGV[3])
+
+local state = redis.call("HMGET", KEYS[1], "tokens", "ts")
+local tokens = tonumber(state[1])
+local ts = tonumber(state[2])
+if tokens == nil or ts == nil then
+	tokens = burst
+	ts = now
+end
+
+tokens = math.min(burst, tokens + math.max(0, now - ts) * rate / 1000)
+
+local allowed = 0
+if tokens >= 1 then
+	tokens = tokens - 1
+	allowed = 1
+end
+
+redis.call("HMSET", KEYS[1], "tokens", tokens, "ts", now)
+redis.call("PEXPIRE", KEYS[1], ARGV[4])
+return allowed
+`
+
+// Store 基于 Redis 的令牌桶存储，多实例共享同一个令牌桶
+type Store struct {
+	client redis.UniversalClient
+	now    func() time.Time
+}
+
+// NewStore 创建基于 Redis 的令牌桶存储
+func NewStore(client redis.UniversalClient) middleware.RateLimitStore {
+	return &Store{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Allow 从 key 对应的令牌桶中取出一个令牌
+func (s *Store) Allow(ctx context.Context, key string, config middleware.RateLimitConfig) (bool, error) {
+	burst := config.BurstSize()
+
+	// 令牌桶从空补满后即与新建无异，过期时间取补满所需时间并留出余量
+	ttl := int64(1000)
+	if config.Rate > 0 {
+		ttl += int64(math.Ceil(float64(burst) / config.Rate * 1000))
+	}
+
+	allowed, err := s.client.DoContext(ctx, "EVAL", tokenBucketScript, 1, key,
+		config.Rate, burst, s.now().UnixMilli(), ttl).Int64()
+	if err != nil {
+		return false, err
+	}
+	return allowed == 1, nil
+}
@@ -0,0 +1,98 @@
+package upload
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	redis "github.com/go-redis/redis/v7"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// pendingUploadKeyPrefix 待提交签名上传的缓存键前缀，完整键为 前缀 + upload_id
+const pendingUploadKeyPrefix = "answersheet:pending-upload:"
+
+// pendingUploadPO 待提交签名上传的缓存对象
+type pendingUploadPO struct {
+	QuestionnaireCode string    `json:"questionnaire_code"`
+	QuestionCode      string    `json:"question_code"`
+	ObjectKey         string    `json:"object_key"`
+	FileName          string    `json:"file_name"`
+	MimeType          string    `json:"mime_type"`
+	MaxSizeBytes      int64     `json:"max_size_bytes"`
+	ExpiresAt         time.Time `json:"expires_at"`
+}
+
+// PendingUploadStore 基于 Redis 的待提交签名上传存储，记录在链接过期时自动删除
+type PendingUploadStore struct {
+	client redis.UniversalClient
+}
+
+// NewPendingUploadStore 创建待提交签名上传存储
+func NewPendingUploadStore(client redis.UniversalClient) port.PendingUploadRepository {
+	return &PendingUploadStore{client: client}
+}
+
+// Save 保存待提交的上传，过期时间取上传链接的过期时间
+func (s *PendingUploadStore) Save(ctx context.Context, upload *answersheet.PendingUpload) error {
+	ttl := time.Until(upload.ExpiresAt)
+	if ttl <= 0 {
+		return errors.WithCode(code.ErrValidation, "上传链接已过期")
+	}
+
+	data, err := json.Marshal(pendingUploadPO{
+		QuestionnaireCode: upload.QuestionnaireCode,
+		QuestionCode:      upload.QuestionCode,
+		ObjectKey:         upload.ObjectKey,
+		FileName:          upload.FileName,
+		MimeType:          upload.MimeType,
+		MaxSizeBytes:      upload.MaxSizeBytes,
+		ExpiresAt:         upload.ExpiresAt,
+	})
+	if err != nil {
+		return err
+	}
+	return s.client.DoContext(ctx, "SET", pendingUploadKeyPrefix+upload.ID, data, "PX", ttl.Milliseconds()).Err()
+}
+
+// FindByID 获取待提交的上传
+func (s *PendingUploadStore) FindByID(ctx context.Context, id string) (*answersheet.PendingUpload, error) {
+	data, err := s.client.DoContext(ctx, "GET", pendingUploadKeyPrefix+id).Text()
+	if err == redis.Nil {
+		return nil, errors.WithCode(code.ErrUploadNotFound, "上传 %s 不存在或已失效", id)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var po pendingUploadPO
+	if err := json.Unmarshal([]byte(data), &po); err != nil {
+		return nil, err
+	}
+	return &answersheet.PendingUpload{
+		ID:                id,
+		QuestionnaireCode: po.QuestionnaireCode,
+		QuestionCode:      po.QuestionCode,
+		ObjectKey:         po.ObjectKey,
+		FileName:          po.FileName,
+		MimeType:          po.MimeType,
+		MaxSizeBytes:      po.MaxSizeBytes,
+		ExpiresAt:         po.ExpiresAt,
+	}, nil
+}
+
+// Consume 删除待提交的上传，并发提交时只有一个请求能删除成功
+func (s *PendingUploadStore) Consume(ctx context.Context, id string) error {
+	deleted, err := s.client.DoContext(ctx, "DEL", pendingUploadKeyPrefix+id).Int64()
+	if err != nil {
+		return err
+	}
+	if deleted == 0 {
+		return errors.WithCode(code.ErrUploadNotFound, "上传 %s 不存在或已使用", id)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package usersession
+
+import "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+
+// UserSessionMapper 用户登录会话映射器
+type UserSessionMapper struct{}
+
+// NewUserSessionMapper 创建用户登录会话映射器
+func NewUserSessionMapper() *UserSessionMapper {
+	return &UserSessionMapper{}
+}
+
+// ToPO 将领域对象转换为持久化对象
+func (m *UserSessionMapper) ToPO(session *user.Session) *UserSessionPO {
+	return &UserSessionPO{
+		SessionID:        session.ID,
+		UserID:           session.UserID,
+		Device:           session.Device,
+		UserAgent:        session.UserAgent,
+		IP:               session.IP,
+		CreatedAt:        session.CreatedAt,
+		LastActiveAt:     session.LastActiveAt,
+		RefreshExpiresAt: session.RefreshExpiresAt,
+	}
+}
+
+// ToBO 将持久化对象转换为领域对象
+func (m *UserSessionMapper) ToBO(po *UserSessionPO) *user.Session {
+	return &user.Session{
+		ID:               po.SessionID,
+		UserID:           po.UserID,
+		Device:           po.Device,
+		UserAgent:        po.UserAgent,
+		IP:               po.IP,
+		CreatedAt:        po.CreatedAt,
+		LastActiveAt:     po.LastActiveAt,
+		RefreshExpiresAt: po.RefreshExpiresAt,
+	}
+}
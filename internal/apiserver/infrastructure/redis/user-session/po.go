@@ -0,0 +1,35 @@
+package usersession
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// sessionKeyPrefix 会话键前缀，键为 session:{sessionID}
+	sessionKeyPrefix = "session:"
+	// userSessionsKeyPrefix 用户会话索引键前缀，键为 user_sessions:{userID}，按创建时间排序
+	userSessionsKeyPrefix = "user_sessions:"
+)
+
+// UserSessionPO 用户登录会话Redis持久化对象，以 JSON 存储
+type UserSessionPO struct {
+	SessionID        string     `json:"session_id"`
+	UserID           uint64     `json:"user_id"`
+	Device           string     `json:"device"`
+	UserAgent        string     `json:"user_agent"`
+	IP               string     `json:"ip"`
+	CreatedAt        time.Time  `json:"created_at"`
+	LastActiveAt     time.Time  `json:"last_active_at"`
+	RefreshExpiresAt *time.Time `json:"refresh_expires_at,omitempty"`
+}
+
+// sessionKey 会话键
+func sessionKey(sessionID string) string {
+	return sessionKeyPrefix + sessionID
+}
+
+// userSessionsKey 用户会话索引键
+func userSessionsKey(userID uint64) string {
+	return fmt.Sprintf("%s%d", userSessionsKeyPrefix, userID)
+}
@@ -0,0 +1,155 @@
+package usersession
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	redis "github.com/go-redis/redis/v7"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+)
+
+// Repository 用户登录会话Redis存储库
+// 会话以 session:{sessionID} 存储并在闲置 ttl 后过期，开启“记住我”的会话至少保留到长期刷新令牌过期
+// user_sessions:{userID} 有序集合记录用户的会话ID，已过期的会话ID在查询时清理
+type Repository struct {
+	client redis.UniversalClient
+	ttl    time.Duration
+	mapper *UserSessionMapper
+}
+
+// NewRepository 创建用户登录会话Redis存储库，ttl 为会话闲置后的过期时间
+func NewRepository(client redis.UniversalClient, ttl time.Duration) port.UserSessionRepository {
+	return &Repository{
+		client: client,
+		ttl:    ttl,
+		mapper: NewUserSessionMapper(),
+	}
+}
+
+// Create 保存新会话
+func (r *Repository) Create(ctx context.Context, session *user.Session) error {
+	data, err := json.Marshal(r.mapper.ToPO(session))
+	if err != nil {
+		return err
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.Do("SET", sessionKey(session.ID), data, "PX", r.expiration(session).Milliseconds())
+	pipe.Do("ZADD", userSessionsKey(session.UserID), session.CreatedAt.UnixNano(), session.ID)
+	_, err = pipe.ExecContext(ctx)
+	return err
+}
+
+// FindByID 根据会话ID查找会话，不存在或已过期时返回 nil
+func (r *Repository) FindByID(ctx context.Context, id string) (*user.Session, error) {
+	data, err := r.client.DoContext(ctx, "GET", sessionKey(id)).Text()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.decode(data)
+}
+
+// FindByUserID 查找用户所有未过期的会话，按创建时间倒序，顺带清理索引中已过期的会话ID
+func (r *Repository) FindByUserID(ctx context.Context, userID uint64) ([]*user.Session, error) {
+	indexKey := userSessionsKey(userID)
+	members, err := r.client.DoContext(ctx, "ZREVRANGE", indexKey, 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+	ids := toStrings(members)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	args := make([]interface{}, 0, len(ids)+1)
+	args = append(args, "MGET")
+	for _, id := range ids {
+		args = append(args, sessionKey(id))
+	}
+	result, err := r.client.DoContext(ctx, args...).Result()
+	if err != nil {
+		return nil, err
+	}
+	values, _ := result.([]interface{})
+
+	sessions := make([]*user.Session, 0, len(ids))
+	expired := []interface{}{"ZREM", indexKey}
+	for i, value := range values {
+		data, ok := value.(string)
+		if !ok {
+			expired = append(expired, ids[i])
+			continue
+		}
+		session, err := r.decode(data)
+		if err != nil {
+			return nil, err
+		}
+		sessions = append(sessions, session)
+	}
+
+	if len(expired) > 2 {
+		if err := r.client.DoContext(ctx, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+	return sessions, nil
+}
+
+// Update 更新会话并重新计算过期时间，会话已被删除或过期时不做处理
+func (r *Repository) Update(ctx context.Context, session *user.Session) error {
+	data, err := json.Marshal(r.mapper.ToPO(session))
+	if err != nil {
+		return err
+	}
+
+	err = r.client.DoContext(ctx, "SET", sessionKey(session.ID), data, "PX", r.expiration(session).Milliseconds(), "XX").Err()
+	if err == redis.Nil {
+		return nil
+	}
+	return err
+}
+
+// Delete 删除会话
+func (r *Repository) Delete(ctx context.Context, session *user.Session) error {
+	pipe := r.client.TxPipeline()
+	pipe.Do("DEL", sessionKey(session.ID))
+	pipe.Do("ZREM", userSessionsKey(session.UserID), session.ID)
+	_, err := pipe.ExecContext(ctx)
+	return err
+}
+
+// expiration 会话从现在起的有效期，开启“记住我”的会话不早于长期刷新令牌过期
+func (r *Repository) expiration(session *user.Session) time.Duration {
+	ttl := r.ttl
+	if session.Remembered() {
+		ttl = max(ttl, time.Until(*session.RefreshExpiresAt))
+	}
+	return ttl
+}
+
+// decode 将存储的 JSON 转换为领域对象
+func (r *Repository) decode(data string) (*user.Session, error) {
+	var po UserSessionPO
+	if err := json.Unmarshal([]byte(data), &po); err != nil {
+		return nil, err
+	}
+	return r.mapper.ToBO(&po), nil
+}
+
+// toStrings 将 Redis 多条回复转换为字符串列表
+func toStrings(reply interface{}) []string {
+	items, _ := reply.([]interface{})
+	values := make([]string, 0, len(items))
+	for _, item := range items {
+		if value, ok := item.(string); ok {
+			values = append(values, value)
+		}
+	}
+	return values
+}
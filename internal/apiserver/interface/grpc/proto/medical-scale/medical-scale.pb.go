@@ -201,6 +201,112 @@ func (x *GetMedicalScaleByQuestionnaireCodeResponse) GetMedicalScale() *MedicalS
 	return nil
 }
 
+// 获取医学量表列表请求
+type ListMedicalScalesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	PageToken     string                 `protobuf:"bytes,1,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // 分页令牌，为空表示第一页
+	PageSize      int32                  `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`   // 每页数量
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMedicalScalesRequest) Reset() {
+	*x = ListMedicalScalesRequest{}
+	mi := &file_medical_scale_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMedicalScalesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMedicalScalesRequest) ProtoMessage() {}
+
+func (x *ListMedicalScalesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_medical_scale_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMedicalScalesRequest.ProtoReflect.Descriptor instead.
+func (*ListMedicalScalesRequest) Descriptor() ([]byte, []int) {
+	return file_medical_scale_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListMedicalScalesRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListMedicalScalesRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+// 获取医学量表列表响应
+type ListMedicalScalesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	MedicalScales []*MedicalScale        `protobuf:"bytes,1,rep,name=medical_scales,json=medicalScales,proto3" json:"medical_scales,omitempty"`   // 医学量表列表
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // 下一页的分页令牌，为空表示已是最后一页
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMedicalScalesResponse) Reset() {
+	*x = ListMedicalScalesResponse{}
+	mi := &file_medical_scale_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMedicalScalesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMedicalScalesResponse) ProtoMessage() {}
+
+func (x *ListMedicalScalesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_medical_scale_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMedicalScalesResponse.ProtoReflect.Descriptor instead.
+func (*ListMedicalScalesResponse) Descriptor() ([]byte, []int) {
+	return file_medical_scale_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ListMedicalScalesResponse) GetMedicalScales() []*MedicalScale {
+	if x != nil {
+		return x.MedicalScales
+	}
+	return nil
+}
+
+func (x *ListMedicalScalesResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
 // 解读报告
 type InterpretReport struct {
 	state            protoimpl.MessageState `protogen:"open.v1"`
@@ -218,7 +324,7 @@ type InterpretReport struct {
 
 func (x *InterpretReport) Reset() {
 	*x = InterpretReport{}
-	mi := &file_medical_scale_proto_msgTypes[4]
+	mi := &file_medical_scale_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -230,7 +336,7 @@ func (x *InterpretReport) String() string {
 func (*InterpretReport) ProtoMessage() {}
 
 func (x *InterpretReport) ProtoReflect() protoreflect.Message {
-	mi := &file_medical_scale_proto_msgTypes[4]
+	mi := &file_medical_scale_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -243,7 +349,7 @@ func (x *InterpretReport) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InterpretReport.ProtoReflect.Descriptor instead.
 func (*InterpretReport) Descriptor() ([]byte, []int) {
-	return file_medical_scale_proto_rawDescGZIP(), []int{4}
+	return file_medical_scale_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *InterpretReport) GetId() uint64 {
@@ -315,7 +421,7 @@ type InterpretItem struct {
 
 func (x *InterpretItem) Reset() {
 	*x = InterpretItem{}
-	mi := &file_medical_scale_proto_msgTypes[5]
+	mi := &file_medical_scale_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -327,7 +433,7 @@ func (x *InterpretItem) String() string {
 func (*InterpretItem) ProtoMessage() {}
 
 func (x *InterpretItem) ProtoReflect() protoreflect.Message {
-	mi := &file_medical_scale_proto_msgTypes[5]
+	mi := &file_medical_scale_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -340,7 +446,7 @@ func (x *InterpretItem) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InterpretItem.ProtoReflect.Descriptor instead.
 func (*InterpretItem) Descriptor() ([]byte, []int) {
-	return file_medical_scale_proto_rawDescGZIP(), []int{5}
+	return file_medical_scale_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *InterpretItem) GetFactorCode() string {
@@ -382,13 +488,14 @@ type MedicalScale struct {
 	Factors           []*Factor              `protobuf:"bytes,6,rep,name=factors,proto3" json:"factors,omitempty"`                                              // 因子列表
 	CreatedAt         string                 `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`                         // 创建时间
 	UpdatedAt         string                 `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`                         // 更新时间
+	ScoreBands        []*ScoreBand           `protobuf:"bytes,9,rep,name=score_bands,json=scoreBands,proto3" json:"score_bands,omitempty"`                      // 总分的临床切分分数段，按最低分升序排列
 	unknownFields     protoimpl.UnknownFields
 	sizeCache         protoimpl.SizeCache
 }
 
 func (x *MedicalScale) Reset() {
 	*x = MedicalScale{}
-	mi := &file_medical_scale_proto_msgTypes[6]
+	mi := &file_medical_scale_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -400,7 +507,7 @@ func (x *MedicalScale) String() string {
 func (*MedicalScale) ProtoMessage() {}
 
 func (x *MedicalScale) ProtoReflect() protoreflect.Message {
-	mi := &file_medical_scale_proto_msgTypes[6]
+	mi := &file_medical_scale_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -413,7 +520,7 @@ func (x *MedicalScale) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MedicalScale.ProtoReflect.Descriptor instead.
 func (*MedicalScale) Descriptor() ([]byte, []int) {
-	return file_medical_scale_proto_rawDescGZIP(), []int{6}
+	return file_medical_scale_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *MedicalScale) GetId() uint64 {
@@ -472,6 +579,74 @@ func (x *MedicalScale) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *MedicalScale) GetScoreBands() []*ScoreBand {
+	if x != nil {
+		return x.ScoreBands
+	}
+	return nil
+}
+
+// 分数段
+type ScoreBand struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ScoreRange    *ScoreRange            `protobuf:"bytes,1,opt,name=score_range,json=scoreRange,proto3" json:"score_range,omitempty"` // 分数范围，左闭右开区间 [min, max)
+	Label         string                 `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`                             // 分数段名称，如 "中度抑郁"
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`                 // 分数段说明
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreBand) Reset() {
+	*x = ScoreBand{}
+	mi := &file_medical_scale_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreBand) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreBand) ProtoMessage() {}
+
+func (x *ScoreBand) ProtoReflect() protoreflect.Message {
+	mi := &file_medical_scale_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreBand.ProtoReflect.Descriptor instead.
+func (*ScoreBand) Descriptor() ([]byte, []int) {
+	return file_medical_scale_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *ScoreBand) GetScoreRange() *ScoreRange {
+	if x != nil {
+		return x.ScoreRange
+	}
+	return nil
+}
+
+func (x *ScoreBand) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *ScoreBand) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
 // 因子
 type Factor struct {
 	state               protoimpl.MessageState `protogen:"open.v1"`
@@ -487,7 +662,7 @@ type Factor struct {
 
 func (x *Factor) Reset() {
 	*x = Factor{}
-	mi := &file_medical_scale_proto_msgTypes[7]
+	mi := &file_medical_scale_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -499,7 +674,7 @@ func (x *Factor) String() string {
 func (*Factor) ProtoMessage() {}
 
 func (x *Factor) ProtoReflect() protoreflect.Message {
-	mi := &file_medical_scale_proto_msgTypes[7]
+	mi := &file_medical_scale_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -512,7 +687,7 @@ func (x *Factor) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Factor.ProtoReflect.Descriptor instead.
 func (*Factor) Descriptor() ([]byte, []int) {
-	return file_medical_scale_proto_rawDescGZIP(), []int{7}
+	return file_medical_scale_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *Factor) GetCode() string {
@@ -562,13 +737,14 @@ type CalculationRule struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FormulaType   string                 `protobuf:"bytes,1,opt,name=formula_type,json=formulaType,proto3" json:"formula_type,omitempty"` // 公式类型
 	SourceCodes   []string               `protobuf:"bytes,2,rep,name=source_codes,json=sourceCodes,proto3" json:"source_codes,omitempty"` // 源代码列表
+	Threshold     float64                `protobuf:"fixed64,3,opt,name=threshold,proto3" json:"threshold,omitempty"`                      // 计数规则（count_above_threshold）的阈值
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *CalculationRule) Reset() {
 	*x = CalculationRule{}
-	mi := &file_medical_scale_proto_msgTypes[8]
+	mi := &file_medical_scale_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -580,7 +756,7 @@ func (x *CalculationRule) String() string {
 func (*CalculationRule) ProtoMessage() {}
 
 func (x *CalculationRule) ProtoReflect() protoreflect.Message {
-	mi := &file_medical_scale_proto_msgTypes[8]
+	mi := &file_medical_scale_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -593,7 +769,7 @@ func (x *CalculationRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CalculationRule.ProtoReflect.Descriptor instead.
 func (*CalculationRule) Descriptor() ([]byte, []int) {
-	return file_medical_scale_proto_rawDescGZIP(), []int{8}
+	return file_medical_scale_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CalculationRule) GetFormulaType() string {
@@ -610,6 +786,13 @@ func (x *CalculationRule) GetSourceCodes() []string {
 	return nil
 }
 
+func (x *CalculationRule) GetThreshold() float64 {
+	if x != nil {
+		return x.Threshold
+	}
+	return 0
+}
+
 // 解读规则
 type InterpretationRule struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -621,7 +804,7 @@ type InterpretationRule struct {
 
 func (x *InterpretationRule) Reset() {
 	*x = InterpretationRule{}
-	mi := &file_medical_scale_proto_msgTypes[9]
+	mi := &file_medical_scale_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -633,7 +816,7 @@ func (x *InterpretationRule) String() string {
 func (*InterpretationRule) ProtoMessage() {}
 
 func (x *InterpretationRule) ProtoReflect() protoreflect.Message {
-	mi := &file_medical_scale_proto_msgTypes[9]
+	mi := &file_medical_scale_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -646,7 +829,7 @@ func (x *InterpretationRule) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use InterpretationRule.ProtoReflect.Descriptor instead.
 func (*InterpretationRule) Descriptor() ([]byte, []int) {
-	return file_medical_scale_proto_rawDescGZIP(), []int{9}
+	return file_medical_scale_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *InterpretationRule) GetScoreRange() *ScoreRange {
@@ -674,7 +857,7 @@ type ScoreRange struct {
 
 func (x *ScoreRange) Reset() {
 	*x = ScoreRange{}
-	mi := &file_medical_scale_proto_msgTypes[10]
+	mi := &file_medical_scale_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -686,7 +869,7 @@ func (x *ScoreRange) String() string {
 func (*ScoreRange) ProtoMessage() {}
 
 func (x *ScoreRange) ProtoReflect() protoreflect.Message {
-	mi := &file_medical_scale_proto_msgTypes[10]
+	mi := &file_medical_scale_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -699,7 +882,7 @@ func (x *ScoreRange) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ScoreRange.ProtoReflect.Descriptor instead.
 func (*ScoreRange) Descriptor() ([]byte, []int) {
-	return file_medical_scale_proto_rawDescGZIP(), []int{10}
+	return file_medical_scale_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ScoreRange) GetMinScore() float64 {
@@ -728,7 +911,14 @@ const file_medical_scale_proto_rawDesc = "" +
 	")GetMedicalScaleByQuestionnaireCodeRequest\x12-\n" +
 	"\x12questionnaire_code\x18\x01 \x01(\tR\x11questionnaireCode\"n\n" +
 	"*GetMedicalScaleByQuestionnaireCodeResponse\x12@\n" +
-	"\rmedical_scale\x18\x01 \x01(\v2\x1b.medical_scale.MedicalScaleR\fmedicalScale\"\xb4\x02\n" +
+	"\rmedical_scale\x18\x01 \x01(\v2\x1b.medical_scale.MedicalScaleR\fmedicalScale\"V\n" +
+	"\x18ListMedicalScalesRequest\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x01 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\"\x87\x01\n" +
+	"\x19ListMedicalScalesResponse\x12B\n" +
+	"\x0emedical_scales\x18\x01 \x03(\v2\x1b.medical_scale.MedicalScaleR\rmedicalScales\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xb4\x02\n" +
 	"\x0fInterpretReport\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12&\n" +
 	"\x0fanswer_sheet_id\x18\x02 \x01(\x04R\ranswerSheetId\x12,\n" +
@@ -745,7 +935,7 @@ const file_medical_scale_proto_rawDesc = "" +
 	"factorCode\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x14\n" +
 	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x18\n" +
-	"\acontent\x18\x04 \x01(\tR\acontent\"\x88\x02\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent\"\xc3\x02\n" +
 	"\fMedicalScale\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\x04R\x02id\x12\x12\n" +
 	"\x04code\x18\x02 \x01(\tR\x04code\x12-\n" +
@@ -756,7 +946,14 @@ const file_medical_scale_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\a \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\b \x01(\tR\tupdatedAt\"\x9a\x02\n" +
+	"updated_at\x18\b \x01(\tR\tupdatedAt\x129\n" +
+	"\vscore_bands\x18\t \x03(\v2\x18.medical_scale.ScoreBandR\n" +
+	"scoreBands\"\x7f\n" +
+	"\tScoreBand\x12:\n" +
+	"\vscore_range\x18\x01 \x01(\v2\x19.medical_scale.ScoreRangeR\n" +
+	"scoreRange\x12\x14\n" +
+	"\x05label\x18\x02 \x01(\tR\x05label\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\"\x9a\x02\n" +
 	"\x06Factor\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12\x1f\n" +
@@ -764,10 +961,11 @@ const file_medical_scale_proto_rawDesc = "" +
 	"factorType\x12$\n" +
 	"\x0eis_total_score\x18\x04 \x01(\bR\fisTotalScore\x12I\n" +
 	"\x10calculation_rule\x18\x05 \x01(\v2\x1e.medical_scale.CalculationRuleR\x0fcalculationRule\x12T\n" +
-	"\x14interpretation_rules\x18\x06 \x03(\v2!.medical_scale.InterpretationRuleR\x13interpretationRules\"W\n" +
+	"\x14interpretation_rules\x18\x06 \x03(\v2!.medical_scale.InterpretationRuleR\x13interpretationRules\"u\n" +
 	"\x0fCalculationRule\x12!\n" +
 	"\fformula_type\x18\x01 \x01(\tR\vformulaType\x12!\n" +
-	"\fsource_codes\x18\x02 \x03(\tR\vsourceCodes\"j\n" +
+	"\fsource_codes\x18\x02 \x03(\tR\vsourceCodes\x12\x1c\n" +
+	"\tthreshold\x18\x03 \x01(\x01R\tthreshold\"j\n" +
 	"\x12InterpretationRule\x12:\n" +
 	"\vscore_range\x18\x01 \x01(\v2\x19.medical_scale.ScoreRangeR\n" +
 	"scoreRange\x12\x18\n" +
@@ -775,10 +973,11 @@ const file_medical_scale_proto_rawDesc = "" +
 	"\n" +
 	"ScoreRange\x12\x1b\n" +
 	"\tmin_score\x18\x01 \x01(\x01R\bminScore\x12\x1b\n" +
-	"\tmax_score\x18\x02 \x01(\x01R\bmaxScore2\xa5\x02\n" +
+	"\tmax_score\x18\x02 \x01(\x01R\bmaxScore2\x8d\x03\n" +
 	"\x13MedicalScaleService\x12r\n" +
 	"\x15GetMedicalScaleByCode\x12+.medical_scale.GetMedicalScaleByCodeRequest\x1a,.medical_scale.GetMedicalScaleByCodeResponse\x12\x99\x01\n" +
-	"\"GetMedicalScaleByQuestionnaireCode\x128.medical_scale.GetMedicalScaleByQuestionnaireCodeRequest\x1a9.medical_scale.GetMedicalScaleByQuestionnaireCodeResponseB^Z\\github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scaleb\x06proto3"
+	"\"GetMedicalScaleByQuestionnaireCode\x128.medical_scale.GetMedicalScaleByQuestionnaireCodeRequest\x1a9.medical_scale.GetMedicalScaleByQuestionnaireCodeResponse\x12f\n" +
+	"\x11ListMedicalScales\x12'.medical_scale.ListMedicalScalesRequest\x1a(.medical_scale.ListMedicalScalesResponseB^Z\\github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scaleb\x06proto3"
 
 var (
 	file_medical_scale_proto_rawDescOnce sync.Once
@@ -792,37 +991,45 @@ func file_medical_scale_proto_rawDescGZIP() []byte {
 	return file_medical_scale_proto_rawDescData
 }
 
-var file_medical_scale_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_medical_scale_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
 var file_medical_scale_proto_goTypes = []any{
 	(*GetMedicalScaleByCodeRequest)(nil),               // 0: medical_scale.GetMedicalScaleByCodeRequest
 	(*GetMedicalScaleByCodeResponse)(nil),              // 1: medical_scale.GetMedicalScaleByCodeResponse
 	(*GetMedicalScaleByQuestionnaireCodeRequest)(nil),  // 2: medical_scale.GetMedicalScaleByQuestionnaireCodeRequest
 	(*GetMedicalScaleByQuestionnaireCodeResponse)(nil), // 3: medical_scale.GetMedicalScaleByQuestionnaireCodeResponse
-	(*InterpretReport)(nil),                            // 4: medical_scale.InterpretReport
-	(*InterpretItem)(nil),                              // 5: medical_scale.InterpretItem
-	(*MedicalScale)(nil),                               // 6: medical_scale.MedicalScale
-	(*Factor)(nil),                                     // 7: medical_scale.Factor
-	(*CalculationRule)(nil),                            // 8: medical_scale.CalculationRule
-	(*InterpretationRule)(nil),                         // 9: medical_scale.InterpretationRule
-	(*ScoreRange)(nil),                                 // 10: medical_scale.ScoreRange
+	(*ListMedicalScalesRequest)(nil),                   // 4: medical_scale.ListMedicalScalesRequest
+	(*ListMedicalScalesResponse)(nil),                  // 5: medical_scale.ListMedicalScalesResponse
+	(*InterpretReport)(nil),                            // 6: medical_scale.InterpretReport
+	(*InterpretItem)(nil),                              // 7: medical_scale.InterpretItem
+	(*MedicalScale)(nil),                               // 8: medical_scale.MedicalScale
+	(*ScoreBand)(nil),                                  // 9: medical_scale.ScoreBand
+	(*Factor)(nil),                                     // 10: medical_scale.Factor
+	(*CalculationRule)(nil),                            // 11: medical_scale.CalculationRule
+	(*InterpretationRule)(nil),                         // 12: medical_scale.InterpretationRule
+	(*ScoreRange)(nil),                                 // 13: medical_scale.ScoreRange
 }
 var file_medical_scale_proto_depIdxs = []int32{
-	6,  // 0: medical_scale.GetMedicalScaleByCodeResponse.medical_scale:type_name -> medical_scale.MedicalScale
-	6,  // 1: medical_scale.GetMedicalScaleByQuestionnaireCodeResponse.medical_scale:type_name -> medical_scale.MedicalScale
-	5,  // 2: medical_scale.InterpretReport.interpret_items:type_name -> medical_scale.InterpretItem
-	7,  // 3: medical_scale.MedicalScale.factors:type_name -> medical_scale.Factor
-	8,  // 4: medical_scale.Factor.calculation_rule:type_name -> medical_scale.CalculationRule
-	9,  // 5: medical_scale.Factor.interpretation_rules:type_name -> medical_scale.InterpretationRule
-	10, // 6: medical_scale.InterpretationRule.score_range:type_name -> medical_scale.ScoreRange
-	0,  // 7: medical_scale.MedicalScaleService.GetMedicalScaleByCode:input_type -> medical_scale.GetMedicalScaleByCodeRequest
-	2,  // 8: medical_scale.MedicalScaleService.GetMedicalScaleByQuestionnaireCode:input_type -> medical_scale.GetMedicalScaleByQuestionnaireCodeRequest
-	1,  // 9: medical_scale.MedicalScaleService.GetMedicalScaleByCode:output_type -> medical_scale.GetMedicalScaleByCodeResponse
-	3,  // 10: medical_scale.MedicalScaleService.GetMedicalScaleByQuestionnaireCode:output_type -> medical_scale.GetMedicalScaleByQuestionnaireCodeResponse
-	9,  // [9:11] is the sub-list for method output_type
-	7,  // [7:9] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	8,  // 0: medical_scale.GetMedicalScaleByCodeResponse.medical_scale:type_name -> medical_scale.MedicalScale
+	8,  // 1: medical_scale.GetMedicalScaleByQuestionnaireCodeResponse.medical_scale:type_name -> medical_scale.MedicalScale
+	8,  // 2: medical_scale.ListMedicalScalesResponse.medical_scales:type_name -> medical_scale.MedicalScale
+	7,  // 3: medical_scale.InterpretReport.interpret_items:type_name -> medical_scale.InterpretItem
+	10, // 4: medical_scale.MedicalScale.factors:type_name -> medical_scale.Factor
+	9,  // 5: medical_scale.MedicalScale.score_bands:type_name -> medical_scale.ScoreBand
+	13, // 6: medical_scale.ScoreBand.score_range:type_name -> medical_scale.ScoreRange
+	11, // 7: medical_scale.Factor.calculation_rule:type_name -> medical_scale.CalculationRule
+	12, // 8: medical_scale.Factor.interpretation_rules:type_name -> medical_scale.InterpretationRule
+	13, // 9: medical_scale.InterpretationRule.score_range:type_name -> medical_scale.ScoreRange
+	0,  // 10: medical_scale.MedicalScaleService.GetMedicalScaleByCode:input_type -> medical_scale.GetMedicalScaleByCodeRequest
+	2,  // 11: medical_scale.MedicalScaleService.GetMedicalScaleByQuestionnaireCode:input_type -> medical_scale.GetMedicalScaleByQuestionnaireCodeRequest
+	4,  // 12: medical_scale.MedicalScaleService.ListMedicalScales:input_type -> medical_scale.ListMedicalScalesRequest
+	1,  // 13: medical_scale.MedicalScaleService.GetMedicalScaleByCode:output_type -> medical_scale.GetMedicalScaleByCodeResponse
+	3,  // 14: medical_scale.MedicalScaleService.GetMedicalScaleByQuestionnaireCode:output_type -> medical_scale.GetMedicalScaleByQuestionnaireCodeResponse
+	5,  // 15: medical_scale.MedicalScaleService.ListMedicalScales:output_type -> medical_scale.ListMedicalScalesResponse
+	13, // [13:16] is the sub-list for method output_type
+	10, // [10:13] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_medical_scale_proto_init() }
@@ -836,7 +1043,7 @@ func file_medical_scale_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_medical_scale_proto_rawDesc), len(file_medical_scale_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   14,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
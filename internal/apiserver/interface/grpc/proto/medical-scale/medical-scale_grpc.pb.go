@@ -21,6 +21,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	MedicalScaleService_GetMedicalScaleByCode_FullMethodName              = "/medical_scale.MedicalScaleService/GetMedicalScaleByCode"
 	MedicalScaleService_GetMedicalScaleByQuestionnaireCode_FullMethodName = "/medical_scale.MedicalScaleService/GetMedicalScaleByQuestionnaireCode"
+	MedicalScaleService_ListMedicalScales_FullMethodName                  = "/medical_scale.MedicalScaleService/ListMedicalScales"
 )
 
 // MedicalScaleServiceClient is the client API for MedicalScaleService service.
@@ -33,6 +34,8 @@ type MedicalScaleServiceClient interface {
 	GetMedicalScaleByCode(ctx context.Context, in *GetMedicalScaleByCodeRequest, opts ...grpc.CallOption) (*GetMedicalScaleByCodeResponse, error)
 	// GetMedicalScaleByQuestionnaireCode 根据问卷代码获取医学量表详情
 	GetMedicalScaleByQuestionnaireCode(ctx context.Context, in *GetMedicalScaleByQuestionnaireCodeRequest, opts ...grpc.CallOption) (*GetMedicalScaleByQuestionnaireCodeResponse, error)
+	// ListMedicalScales 按医学量表代码升序分页获取医学量表列表（游标分页）
+	ListMedicalScales(ctx context.Context, in *ListMedicalScalesRequest, opts ...grpc.CallOption) (*ListMedicalScalesResponse, error)
 }
 
 type medicalScaleServiceClient struct {
@@ -63,6 +66,16 @@ func (c *medicalScaleServiceClient) GetMedicalScaleByQuestionnaireCode(ctx conte
 	return out, nil
 }
 
+func (c *medicalScaleServiceClient) ListMedicalScales(ctx context.Context, in *ListMedicalScalesRequest, opts ...grpc.CallOption) (*ListMedicalScalesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMedicalScalesResponse)
+	err := c.cc.Invoke(ctx, MedicalScaleService_ListMedicalScales_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MedicalScaleServiceServer is the server API for MedicalScaleService service.
 // All implementations must embed UnimplementedMedicalScaleServiceServer
 // for forward compatibility.
@@ -73,6 +86,8 @@ type MedicalScaleServiceServer interface {
 	GetMedicalScaleByCode(context.Context, *GetMedicalScaleByCodeRequest) (*GetMedicalScaleByCodeResponse, error)
 	// GetMedicalScaleByQuestionnaireCode 根据问卷代码获取医学量表详情
 	GetMedicalScaleByQuestionnaireCode(context.Context, *GetMedicalScaleByQuestionnaireCodeRequest) (*GetMedicalScaleByQuestionnaireCodeResponse, error)
+	// ListMedicalScales 按医学量表代码升序分页获取医学量表列表（游标分页）
+	ListMedicalScales(context.Context, *ListMedicalScalesRequest) (*ListMedicalScalesResponse, error)
 	mustEmbedUnimplementedMedicalScaleServiceServer()
 }
 
@@ -89,6 +104,9 @@ func (UnimplementedMedicalScaleServiceServer) GetMedicalScaleByCode(context.Cont
 func (UnimplementedMedicalScaleServiceServer) GetMedicalScaleByQuestionnaireCode(context.Context, *GetMedicalScaleByQuestionnaireCodeRequest) (*GetMedicalScaleByQuestionnaireCodeResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetMedicalScaleByQuestionnaireCode not implemented")
 }
+func (UnimplementedMedicalScaleServiceServer) ListMedicalScales(context.Context, *ListMedicalScalesRequest) (*ListMedicalScalesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListMedicalScales not implemented")
+}
 func (UnimplementedMedicalScaleServiceServer) mustEmbedUnimplementedMedicalScaleServiceServer() {}
 func (UnimplementedMedicalScaleServiceServer) testEmbeddedByValue()                             {}
 
@@ -146,6 +164,24 @@ func _MedicalScaleService_GetMedicalScaleByQuestionnaireCode_Handler(srv interfa
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MedicalScaleService_ListMedicalScales_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMedicalScalesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MedicalScaleServiceServer).ListMedicalScales(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MedicalScaleService_ListMedicalScales_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MedicalScaleServiceServer).ListMedicalScales(ctx, req.(*ListMedicalScalesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MedicalScaleService_ServiceDesc is the grpc.ServiceDesc for MedicalScaleService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +197,10 @@ var MedicalScaleService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetMedicalScaleByQuestionnaireCode",
 			Handler:    _MedicalScaleService_GetMedicalScaleByQuestionnaireCode_Handler,
 		},
+		{
+			MethodName: "ListMedicalScales",
+			Handler:    _MedicalScaleService_ListMedicalScales_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "medical-scale.proto",
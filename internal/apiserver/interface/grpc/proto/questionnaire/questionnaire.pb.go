@@ -23,18 +23,19 @@ const (
 
 // 问卷信息
 type Questionnaire struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
-	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	ImgUrl        string                 `protobuf:"bytes,4,opt,name=img_url,json=imgUrl,proto3" json:"img_url,omitempty"`
-	Status        string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
-	Version       string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
-	Questions     []*Question            `protobuf:"bytes,7,rep,name=questions,proto3" json:"questions,omitempty"`
-	CreatedAt     string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt     string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Code           string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Title          string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Description    string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImgUrl         string                 `protobuf:"bytes,4,opt,name=img_url,json=imgUrl,proto3" json:"img_url,omitempty"`
+	Status         string                 `protobuf:"bytes,5,opt,name=status,proto3" json:"status,omitempty"`
+	Version        string                 `protobuf:"bytes,6,opt,name=version,proto3" json:"version,omitempty"`
+	Questions      []*Question            `protobuf:"bytes,7,rep,name=questions,proto3" json:"questions,omitempty"`
+	CreatedAt      string                 `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt      string                 `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	GeoRestriction []string               `protobuf:"bytes,10,rep,name=geo_restriction,json=geoRestriction,proto3" json:"geo_restriction,omitempty"` // 允许访问的国家（ISO 3166-1 alpha-2 代码），为空表示不限制
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Questionnaire) Reset() {
@@ -130,6 +131,13 @@ func (x *Questionnaire) GetUpdatedAt() string {
 	return ""
 }
 
+func (x *Questionnaire) GetGeoRestriction() []string {
+	if x != nil {
+		return x.GeoRestriction
+	}
+	return nil
+}
+
 // 问题信息
 type Question struct {
 	state           protoimpl.MessageState `protogen:"open.v1"`
@@ -297,6 +305,7 @@ type ValidationRule struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RuleType      string                 `protobuf:"bytes,1,opt,name=rule_type,json=ruleType,proto3" json:"rule_type,omitempty"`
 	TargetValue   string                 `protobuf:"bytes,2,opt,name=target_value,json=targetValue,proto3" json:"target_value,omitempty"`
+	Severity      string                 `protobuf:"bytes,3,opt,name=severity,proto3" json:"severity,omitempty"` // 严重级别：error 或 warning，warning 时规则未通过只产生警告
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -345,10 +354,19 @@ func (x *ValidationRule) GetTargetValue() string {
 	return ""
 }
 
+func (x *ValidationRule) GetSeverity() string {
+	if x != nil {
+		return x.Severity
+	}
+	return ""
+}
+
 // 计算规则
 type CalculationRule struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	FormulaType   string                 `protobuf:"bytes,1,opt,name=formula_type,json=formulaType,proto3" json:"formula_type,omitempty"`
+	DependsOnCode string                 `protobuf:"bytes,2,opt,name=depends_on_code,json=dependsOnCode,proto3" json:"depends_on_code,omitempty"` // 条件计分（conditional）引用的题目编码
+	ScoreMappings []*ScoreMapping        `protobuf:"bytes,3,rep,name=score_mappings,json=scoreMappings,proto3" json:"score_mappings,omitempty"`   // 条件计分映射，取第一个条件值匹配的映射
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -390,6 +408,73 @@ func (x *CalculationRule) GetFormulaType() string {
 	return ""
 }
 
+func (x *CalculationRule) GetDependsOnCode() string {
+	if x != nil {
+		return x.DependsOnCode
+	}
+	return ""
+}
+
+func (x *CalculationRule) GetScoreMappings() []*ScoreMapping {
+	if x != nil {
+		return x.ScoreMappings
+	}
+	return nil
+}
+
+// 条件计分映射：引用题目的答案等于 when_value 时，本题选项按 scores 中的分值计分
+type ScoreMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	WhenValue     string                 `protobuf:"bytes,1,opt,name=when_value,json=whenValue,proto3" json:"when_value,omitempty"`
+	Scores        map[string]float64     `protobuf:"bytes,2,rep,name=scores,proto3" json:"scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"` // 选项编码 -> 分值，未列出的选项按选项分值计分
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScoreMapping) Reset() {
+	*x = ScoreMapping{}
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreMapping) ProtoMessage() {}
+
+func (x *ScoreMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreMapping.ProtoReflect.Descriptor instead.
+func (*ScoreMapping) Descriptor() ([]byte, []int) {
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ScoreMapping) GetWhenValue() string {
+	if x != nil {
+		return x.WhenValue
+	}
+	return ""
+}
+
+func (x *ScoreMapping) GetScores() map[string]float64 {
+	if x != nil {
+		return x.Scores
+	}
+	return nil
+}
+
 // 获取问卷请求
 type GetQuestionnaireRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -400,7 +485,7 @@ type GetQuestionnaireRequest struct {
 
 func (x *GetQuestionnaireRequest) Reset() {
 	*x = GetQuestionnaireRequest{}
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[5]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -412,7 +497,7 @@ func (x *GetQuestionnaireRequest) String() string {
 func (*GetQuestionnaireRequest) ProtoMessage() {}
 
 func (x *GetQuestionnaireRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[5]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -425,7 +510,7 @@ func (x *GetQuestionnaireRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetQuestionnaireRequest.ProtoReflect.Descriptor instead.
 func (*GetQuestionnaireRequest) Descriptor() ([]byte, []int) {
-	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{5}
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *GetQuestionnaireRequest) GetCode() string {
@@ -445,7 +530,7 @@ type GetQuestionnaireResponse struct {
 
 func (x *GetQuestionnaireResponse) Reset() {
 	*x = GetQuestionnaireResponse{}
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[6]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -457,7 +542,7 @@ func (x *GetQuestionnaireResponse) String() string {
 func (*GetQuestionnaireResponse) ProtoMessage() {}
 
 func (x *GetQuestionnaireResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[6]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -470,7 +555,7 @@ func (x *GetQuestionnaireResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetQuestionnaireResponse.ProtoReflect.Descriptor instead.
 func (*GetQuestionnaireResponse) Descriptor() ([]byte, []int) {
-	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{6}
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *GetQuestionnaireResponse) GetQuestionnaire() *Questionnaire {
@@ -480,6 +565,194 @@ func (x *GetQuestionnaireResponse) GetQuestionnaire() *Questionnaire {
 	return nil
 }
 
+// 根据问卷代码获取问卷请求
+type GetQuestionnaireByCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionnaireByCodeRequest) Reset() {
+	*x = GetQuestionnaireByCodeRequest{}
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionnaireByCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionnaireByCodeRequest) ProtoMessage() {}
+
+func (x *GetQuestionnaireByCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionnaireByCodeRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionnaireByCodeRequest) Descriptor() ([]byte, []int) {
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *GetQuestionnaireByCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+// 根据问卷代码获取问卷响应
+type GetQuestionnaireByCodeResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questionnaire *Questionnaire         `protobuf:"bytes,1,opt,name=questionnaire,proto3" json:"questionnaire,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionnaireByCodeResponse) Reset() {
+	*x = GetQuestionnaireByCodeResponse{}
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionnaireByCodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionnaireByCodeResponse) ProtoMessage() {}
+
+func (x *GetQuestionnaireByCodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionnaireByCodeResponse.ProtoReflect.Descriptor instead.
+func (*GetQuestionnaireByCodeResponse) Descriptor() ([]byte, []int) {
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *GetQuestionnaireByCodeResponse) GetQuestionnaire() *Questionnaire {
+	if x != nil {
+		return x.Questionnaire
+	}
+	return nil
+}
+
+// 根据问卷代码和版本获取问卷请求
+type GetQuestionnaireByCodeVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	Version       string                 `protobuf:"bytes,2,opt,name=version,proto3" json:"version,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionnaireByCodeVersionRequest) Reset() {
+	*x = GetQuestionnaireByCodeVersionRequest{}
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionnaireByCodeVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionnaireByCodeVersionRequest) ProtoMessage() {}
+
+func (x *GetQuestionnaireByCodeVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionnaireByCodeVersionRequest.ProtoReflect.Descriptor instead.
+func (*GetQuestionnaireByCodeVersionRequest) Descriptor() ([]byte, []int) {
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *GetQuestionnaireByCodeVersionRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *GetQuestionnaireByCodeVersionRequest) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+// 根据问卷代码和版本获取问卷响应
+type GetQuestionnaireByCodeVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Questionnaire *Questionnaire         `protobuf:"bytes,1,opt,name=questionnaire,proto3" json:"questionnaire,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetQuestionnaireByCodeVersionResponse) Reset() {
+	*x = GetQuestionnaireByCodeVersionResponse{}
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetQuestionnaireByCodeVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetQuestionnaireByCodeVersionResponse) ProtoMessage() {}
+
+func (x *GetQuestionnaireByCodeVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetQuestionnaireByCodeVersionResponse.ProtoReflect.Descriptor instead.
+func (*GetQuestionnaireByCodeVersionResponse) Descriptor() ([]byte, []int) {
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *GetQuestionnaireByCodeVersionResponse) GetQuestionnaire() *Questionnaire {
+	if x != nil {
+		return x.Questionnaire
+	}
+	return nil
+}
+
 // 获取问卷列表请求
 type ListQuestionnairesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -493,7 +766,7 @@ type ListQuestionnairesRequest struct {
 
 func (x *ListQuestionnairesRequest) Reset() {
 	*x = ListQuestionnairesRequest{}
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[7]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -505,7 +778,7 @@ func (x *ListQuestionnairesRequest) String() string {
 func (*ListQuestionnairesRequest) ProtoMessage() {}
 
 func (x *ListQuestionnairesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[7]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -518,7 +791,7 @@ func (x *ListQuestionnairesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListQuestionnairesRequest.ProtoReflect.Descriptor instead.
 func (*ListQuestionnairesRequest) Descriptor() ([]byte, []int) {
-	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{7}
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *ListQuestionnairesRequest) GetPage() int32 {
@@ -560,7 +833,7 @@ type ListQuestionnairesResponse struct {
 
 func (x *ListQuestionnairesResponse) Reset() {
 	*x = ListQuestionnairesResponse{}
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[8]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -572,7 +845,7 @@ func (x *ListQuestionnairesResponse) String() string {
 func (*ListQuestionnairesResponse) ProtoMessage() {}
 
 func (x *ListQuestionnairesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_questionnaire_questionnaire_proto_msgTypes[8]
+	mi := &file_questionnaire_questionnaire_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -585,7 +858,7 @@ func (x *ListQuestionnairesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListQuestionnairesResponse.ProtoReflect.Descriptor instead.
 func (*ListQuestionnairesResponse) Descriptor() ([]byte, []int) {
-	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{8}
+	return file_questionnaire_questionnaire_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *ListQuestionnairesResponse) GetQuestionnaires() []*Questionnaire {
@@ -606,7 +879,7 @@ var File_questionnaire_questionnaire_proto protoreflect.FileDescriptor
 
 const file_questionnaire_questionnaire_proto_rawDesc = "" +
 	"\n" +
-	"!questionnaire/questionnaire.proto\x12\rquestionnaire\"\x9b\x02\n" +
+	"!questionnaire/questionnaire.proto\x12\rquestionnaire\"\xc4\x02\n" +
 	"\rQuestionnaire\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x14\n" +
 	"\x05title\x18\x02 \x01(\tR\x05title\x12 \n" +
@@ -618,7 +891,9 @@ const file_questionnaire_questionnaire_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\tR\tcreatedAt\x12\x1d\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\tR\tupdatedAt\"\xc4\x02\n" +
+	"updated_at\x18\t \x01(\tR\tupdatedAt\x12'\n" +
+	"\x0fgeo_restriction\x18\n" +
+	" \x03(\tR\x0egeoRestriction\"\xc4\x02\n" +
 	"\bQuestion\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\x12\x14\n" +
@@ -631,15 +906,34 @@ const file_questionnaire_questionnaire_proto_rawDesc = "" +
 	"\x06Option\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\x12\x14\n" +
-	"\x05score\x18\x03 \x01(\x05R\x05score\"P\n" +
+	"\x05score\x18\x03 \x01(\x05R\x05score\"l\n" +
 	"\x0eValidationRule\x12\x1b\n" +
 	"\trule_type\x18\x01 \x01(\tR\bruleType\x12!\n" +
-	"\ftarget_value\x18\x02 \x01(\tR\vtargetValue\"4\n" +
+	"\ftarget_value\x18\x02 \x01(\tR\vtargetValue\x12\x1a\n" +
+	"\bseverity\x18\x03 \x01(\tR\bseverity\"\xa0\x01\n" +
 	"\x0fCalculationRule\x12!\n" +
-	"\fformula_type\x18\x01 \x01(\tR\vformulaType\"-\n" +
+	"\fformula_type\x18\x01 \x01(\tR\vformulaType\x12&\n" +
+	"\x0fdepends_on_code\x18\x02 \x01(\tR\rdependsOnCode\x12B\n" +
+	"\x0escore_mappings\x18\x03 \x03(\v2\x1b.questionnaire.ScoreMappingR\rscoreMappings\"\xa9\x01\n" +
+	"\fScoreMapping\x12\x1d\n" +
+	"\n" +
+	"when_value\x18\x01 \x01(\tR\twhenValue\x12?\n" +
+	"\x06scores\x18\x02 \x03(\v2'.questionnaire.ScoreMapping.ScoresEntryR\x06scores\x1a9\n" +
+	"\vScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\"-\n" +
 	"\x17GetQuestionnaireRequest\x12\x12\n" +
 	"\x04code\x18\x01 \x01(\tR\x04code\"^\n" +
 	"\x18GetQuestionnaireResponse\x12B\n" +
+	"\rquestionnaire\x18\x01 \x01(\v2\x1c.questionnaire.QuestionnaireR\rquestionnaire\"3\n" +
+	"\x1dGetQuestionnaireByCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"d\n" +
+	"\x1eGetQuestionnaireByCodeResponse\x12B\n" +
+	"\rquestionnaire\x18\x01 \x01(\v2\x1c.questionnaire.QuestionnaireR\rquestionnaire\"T\n" +
+	"$GetQuestionnaireByCodeVersionRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x18\n" +
+	"\aversion\x18\x02 \x01(\tR\aversion\"k\n" +
+	"%GetQuestionnaireByCodeVersionResponse\x12B\n" +
 	"\rquestionnaire\x18\x01 \x01(\v2\x1c.questionnaire.QuestionnaireR\rquestionnaire\"z\n" +
 	"\x19ListQuestionnairesRequest\x12\x12\n" +
 	"\x04page\x18\x01 \x01(\x05R\x04page\x12\x1b\n" +
@@ -648,10 +942,12 @@ const file_questionnaire_questionnaire_proto_rawDesc = "" +
 	"\x05title\x18\x04 \x01(\tR\x05title\"x\n" +
 	"\x1aListQuestionnairesResponse\x12D\n" +
 	"\x0equestionnaires\x18\x01 \x03(\v2\x1c.questionnaire.QuestionnaireR\x0equestionnaires\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total2\xe6\x01\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total2\xea\x03\n" +
 	"\x14QuestionnaireService\x12c\n" +
 	"\x10GetQuestionnaire\x12&.questionnaire.GetQuestionnaireRequest\x1a'.questionnaire.GetQuestionnaireResponse\x12i\n" +
-	"\x12ListQuestionnaires\x12(.questionnaire.ListQuestionnairesRequest\x1a).questionnaire.ListQuestionnairesResponseB^Z\\github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaireb\x06proto3"
+	"\x12ListQuestionnaires\x12(.questionnaire.ListQuestionnairesRequest\x1a).questionnaire.ListQuestionnairesResponse\x12u\n" +
+	"\x16GetQuestionnaireByCode\x12,.questionnaire.GetQuestionnaireByCodeRequest\x1a-.questionnaire.GetQuestionnaireByCodeResponse\x12\x8a\x01\n" +
+	"\x1dGetQuestionnaireByCodeVersion\x123.questionnaire.GetQuestionnaireByCodeVersionRequest\x1a4.questionnaire.GetQuestionnaireByCodeVersionResponseB^Z\\github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaireb\x06proto3"
 
 var (
 	file_questionnaire_questionnaire_proto_rawDescOnce sync.Once
@@ -665,34 +961,48 @@ func file_questionnaire_questionnaire_proto_rawDescGZIP() []byte {
 	return file_questionnaire_questionnaire_proto_rawDescData
 }
 
-var file_questionnaire_questionnaire_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_questionnaire_questionnaire_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
 var file_questionnaire_questionnaire_proto_goTypes = []any{
-	(*Questionnaire)(nil),              // 0: questionnaire.Questionnaire
-	(*Question)(nil),                   // 1: questionnaire.Question
-	(*Option)(nil),                     // 2: questionnaire.Option
-	(*ValidationRule)(nil),             // 3: questionnaire.ValidationRule
-	(*CalculationRule)(nil),            // 4: questionnaire.CalculationRule
-	(*GetQuestionnaireRequest)(nil),    // 5: questionnaire.GetQuestionnaireRequest
-	(*GetQuestionnaireResponse)(nil),   // 6: questionnaire.GetQuestionnaireResponse
-	(*ListQuestionnairesRequest)(nil),  // 7: questionnaire.ListQuestionnairesRequest
-	(*ListQuestionnairesResponse)(nil), // 8: questionnaire.ListQuestionnairesResponse
+	(*Questionnaire)(nil),                         // 0: questionnaire.Questionnaire
+	(*Question)(nil),                              // 1: questionnaire.Question
+	(*Option)(nil),                                // 2: questionnaire.Option
+	(*ValidationRule)(nil),                        // 3: questionnaire.ValidationRule
+	(*CalculationRule)(nil),                       // 4: questionnaire.CalculationRule
+	(*ScoreMapping)(nil),                          // 5: questionnaire.ScoreMapping
+	(*GetQuestionnaireRequest)(nil),               // 6: questionnaire.GetQuestionnaireRequest
+	(*GetQuestionnaireResponse)(nil),              // 7: questionnaire.GetQuestionnaireResponse
+	(*GetQuestionnaireByCodeRequest)(nil),         // 8: questionnaire.GetQuestionnaireByCodeRequest
+	(*GetQuestionnaireByCodeResponse)(nil),        // 9: questionnaire.GetQuestionnaireByCodeResponse
+	(*GetQuestionnaireByCodeVersionRequest)(nil),  // 10: questionnaire.GetQuestionnaireByCodeVersionRequest
+	(*GetQuestionnaireByCodeVersionResponse)(nil), // 11: questionnaire.GetQuestionnaireByCodeVersionResponse
+	(*ListQuestionnairesRequest)(nil),             // 12: questionnaire.ListQuestionnairesRequest
+	(*ListQuestionnairesResponse)(nil),            // 13: questionnaire.ListQuestionnairesResponse
+	nil,                                           // 14: questionnaire.ScoreMapping.ScoresEntry
 }
 var file_questionnaire_questionnaire_proto_depIdxs = []int32{
-	1, // 0: questionnaire.Questionnaire.questions:type_name -> questionnaire.Question
-	2, // 1: questionnaire.Question.options:type_name -> questionnaire.Option
-	3, // 2: questionnaire.Question.validation_rules:type_name -> questionnaire.ValidationRule
-	4, // 3: questionnaire.Question.calculation_rule:type_name -> questionnaire.CalculationRule
-	0, // 4: questionnaire.GetQuestionnaireResponse.questionnaire:type_name -> questionnaire.Questionnaire
-	0, // 5: questionnaire.ListQuestionnairesResponse.questionnaires:type_name -> questionnaire.Questionnaire
-	5, // 6: questionnaire.QuestionnaireService.GetQuestionnaire:input_type -> questionnaire.GetQuestionnaireRequest
-	7, // 7: questionnaire.QuestionnaireService.ListQuestionnaires:input_type -> questionnaire.ListQuestionnairesRequest
-	6, // 8: questionnaire.QuestionnaireService.GetQuestionnaire:output_type -> questionnaire.GetQuestionnaireResponse
-	8, // 9: questionnaire.QuestionnaireService.ListQuestionnaires:output_type -> questionnaire.ListQuestionnairesResponse
-	8, // [8:10] is the sub-list for method output_type
-	6, // [6:8] is the sub-list for method input_type
-	6, // [6:6] is the sub-list for extension type_name
-	6, // [6:6] is the sub-list for extension extendee
-	0, // [0:6] is the sub-list for field type_name
+	1,  // 0: questionnaire.Questionnaire.questions:type_name -> questionnaire.Question
+	2,  // 1: questionnaire.Question.options:type_name -> questionnaire.Option
+	3,  // 2: questionnaire.Question.validation_rules:type_name -> questionnaire.ValidationRule
+	4,  // 3: questionnaire.Question.calculation_rule:type_name -> questionnaire.CalculationRule
+	5,  // 4: questionnaire.CalculationRule.score_mappings:type_name -> questionnaire.ScoreMapping
+	14, // 5: questionnaire.ScoreMapping.scores:type_name -> questionnaire.ScoreMapping.ScoresEntry
+	0,  // 6: questionnaire.GetQuestionnaireResponse.questionnaire:type_name -> questionnaire.Questionnaire
+	0,  // 7: questionnaire.GetQuestionnaireByCodeResponse.questionnaire:type_name -> questionnaire.Questionnaire
+	0,  // 8: questionnaire.GetQuestionnaireByCodeVersionResponse.questionnaire:type_name -> questionnaire.Questionnaire
+	0,  // 9: questionnaire.ListQuestionnairesResponse.questionnaires:type_name -> questionnaire.Questionnaire
+	6,  // 10: questionnaire.QuestionnaireService.GetQuestionnaire:input_type -> questionnaire.GetQuestionnaireRequest
+	12, // 11: questionnaire.QuestionnaireService.ListQuestionnaires:input_type -> questionnaire.ListQuestionnairesRequest
+	8,  // 12: questionnaire.QuestionnaireService.GetQuestionnaireByCode:input_type -> questionnaire.GetQuestionnaireByCodeRequest
+	10, // 13: questionnaire.QuestionnaireService.GetQuestionnaireByCodeVersion:input_type -> questionnaire.GetQuestionnaireByCodeVersionRequest
+	7,  // 14: questionnaire.QuestionnaireService.GetQuestionnaire:output_type -> questionnaire.GetQuestionnaireResponse
+	13, // 15: questionnaire.QuestionnaireService.ListQuestionnaires:output_type -> questionnaire.ListQuestionnairesResponse
+	9,  // 16: questionnaire.QuestionnaireService.GetQuestionnaireByCode:output_type -> questionnaire.GetQuestionnaireByCodeResponse
+	11, // 17: questionnaire.QuestionnaireService.GetQuestionnaireByCodeVersion:output_type -> questionnaire.GetQuestionnaireByCodeVersionResponse
+	14, // [14:18] is the sub-list for method output_type
+	10, // [10:14] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
 }
 
 func init() { file_questionnaire_questionnaire_proto_init() }
@@ -706,7 +1016,7 @@ func file_questionnaire_questionnaire_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_questionnaire_questionnaire_proto_rawDesc), len(file_questionnaire_questionnaire_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   15,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
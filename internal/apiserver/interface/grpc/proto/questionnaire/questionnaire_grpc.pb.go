@@ -19,8 +19,10 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	QuestionnaireService_GetQuestionnaire_FullMethodName   = "/questionnaire.QuestionnaireService/GetQuestionnaire"
-	QuestionnaireService_ListQuestionnaires_FullMethodName = "/questionnaire.QuestionnaireService/ListQuestionnaires"
+	QuestionnaireService_GetQuestionnaire_FullMethodName              = "/questionnaire.QuestionnaireService/GetQuestionnaire"
+	QuestionnaireService_ListQuestionnaires_FullMethodName            = "/questionnaire.QuestionnaireService/ListQuestionnaires"
+	QuestionnaireService_GetQuestionnaireByCode_FullMethodName        = "/questionnaire.QuestionnaireService/GetQuestionnaireByCode"
+	QuestionnaireService_GetQuestionnaireByCodeVersion_FullMethodName = "/questionnaire.QuestionnaireService/GetQuestionnaireByCodeVersion"
 )
 
 // QuestionnaireServiceClient is the client API for QuestionnaireService service.
@@ -33,6 +35,10 @@ type QuestionnaireServiceClient interface {
 	GetQuestionnaire(ctx context.Context, in *GetQuestionnaireRequest, opts ...grpc.CallOption) (*GetQuestionnaireResponse, error)
 	// 获取问卷列表
 	ListQuestionnaires(ctx context.Context, in *ListQuestionnairesRequest, opts ...grpc.CallOption) (*ListQuestionnairesResponse, error)
+	// 根据问卷代码获取问卷
+	GetQuestionnaireByCode(ctx context.Context, in *GetQuestionnaireByCodeRequest, opts ...grpc.CallOption) (*GetQuestionnaireByCodeResponse, error)
+	// 根据问卷代码和版本获取问卷
+	GetQuestionnaireByCodeVersion(ctx context.Context, in *GetQuestionnaireByCodeVersionRequest, opts ...grpc.CallOption) (*GetQuestionnaireByCodeVersionResponse, error)
 }
 
 type questionnaireServiceClient struct {
@@ -63,6 +69,26 @@ func (c *questionnaireServiceClient) ListQuestionnaires(ctx context.Context, in
 	return out, nil
 }
 
+func (c *questionnaireServiceClient) GetQuestionnaireByCode(ctx context.Context, in *GetQuestionnaireByCodeRequest, opts ...grpc.CallOption) (*GetQuestionnaireByCodeResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuestionnaireByCodeResponse)
+	err := c.cc.Invoke(ctx, QuestionnaireService_GetQuestionnaireByCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *questionnaireServiceClient) GetQuestionnaireByCodeVersion(ctx context.Context, in *GetQuestionnaireByCodeVersionRequest, opts ...grpc.CallOption) (*GetQuestionnaireByCodeVersionResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetQuestionnaireByCodeVersionResponse)
+	err := c.cc.Invoke(ctx, QuestionnaireService_GetQuestionnaireByCodeVersion_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // QuestionnaireServiceServer is the server API for QuestionnaireService service.
 // All implementations must embed UnimplementedQuestionnaireServiceServer
 // for forward compatibility.
@@ -73,6 +99,10 @@ type QuestionnaireServiceServer interface {
 	GetQuestionnaire(context.Context, *GetQuestionnaireRequest) (*GetQuestionnaireResponse, error)
 	// 获取问卷列表
 	ListQuestionnaires(context.Context, *ListQuestionnairesRequest) (*ListQuestionnairesResponse, error)
+	// 根据问卷代码获取问卷
+	GetQuestionnaireByCode(context.Context, *GetQuestionnaireByCodeRequest) (*GetQuestionnaireByCodeResponse, error)
+	// 根据问卷代码和版本获取问卷
+	GetQuestionnaireByCodeVersion(context.Context, *GetQuestionnaireByCodeVersionRequest) (*GetQuestionnaireByCodeVersionResponse, error)
 	mustEmbedUnimplementedQuestionnaireServiceServer()
 }
 
@@ -89,6 +119,12 @@ func (UnimplementedQuestionnaireServiceServer) GetQuestionnaire(context.Context,
 func (UnimplementedQuestionnaireServiceServer) ListQuestionnaires(context.Context, *ListQuestionnairesRequest) (*ListQuestionnairesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ListQuestionnaires not implemented")
 }
+func (UnimplementedQuestionnaireServiceServer) GetQuestionnaireByCode(context.Context, *GetQuestionnaireByCodeRequest) (*GetQuestionnaireByCodeResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuestionnaireByCode not implemented")
+}
+func (UnimplementedQuestionnaireServiceServer) GetQuestionnaireByCodeVersion(context.Context, *GetQuestionnaireByCodeVersionRequest) (*GetQuestionnaireByCodeVersionResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuestionnaireByCodeVersion not implemented")
+}
 func (UnimplementedQuestionnaireServiceServer) mustEmbedUnimplementedQuestionnaireServiceServer() {}
 func (UnimplementedQuestionnaireServiceServer) testEmbeddedByValue()                              {}
 
@@ -146,6 +182,42 @@ func _QuestionnaireService_ListQuestionnaires_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _QuestionnaireService_GetQuestionnaireByCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuestionnaireByCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionnaireServiceServer).GetQuestionnaireByCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionnaireService_GetQuestionnaireByCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionnaireServiceServer).GetQuestionnaireByCode(ctx, req.(*GetQuestionnaireByCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _QuestionnaireService_GetQuestionnaireByCodeVersion_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetQuestionnaireByCodeVersionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(QuestionnaireServiceServer).GetQuestionnaireByCodeVersion(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: QuestionnaireService_GetQuestionnaireByCodeVersion_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(QuestionnaireServiceServer).GetQuestionnaireByCodeVersion(ctx, req.(*GetQuestionnaireByCodeVersionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // QuestionnaireService_ServiceDesc is the grpc.ServiceDesc for QuestionnaireService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -161,6 +233,14 @@ var QuestionnaireService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListQuestionnaires",
 			Handler:    _QuestionnaireService_ListQuestionnaires_Handler,
 		},
+		{
+			MethodName: "GetQuestionnaireByCode",
+			Handler:    _QuestionnaireService_GetQuestionnaireByCode_Handler,
+		},
+		{
+			MethodName: "GetQuestionnaireByCodeVersion",
+			Handler:    _QuestionnaireService_GetQuestionnaireByCodeVersion_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "questionnaire/questionnaire.proto",
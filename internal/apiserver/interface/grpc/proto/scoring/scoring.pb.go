@@ -0,0 +1,557 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: scoring.proto
+
+package scoring
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// 答卷计分请求
+type ScoreAnswersheetRequest struct {
+	state                protoimpl.MessageState `protogen:"open.v1"`
+	QuestionnaireCode    string                 `protobuf:"bytes,1,opt,name=questionnaire_code,json=questionnaireCode,proto3" json:"questionnaire_code,omitempty"`          // 问卷代码
+	QuestionnaireVersion string                 `protobuf:"bytes,2,opt,name=questionnaire_version,json=questionnaireVersion,proto3" json:"questionnaire_version,omitempty"` // 问卷版本，为空时使用当前版本
+	Answers              []*Answer              `protobuf:"bytes,3,rep,name=answers,proto3" json:"answers,omitempty"`                                                       // 答案列表
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ScoreAnswersheetRequest) Reset() {
+	*x = ScoreAnswersheetRequest{}
+	mi := &file_scoring_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreAnswersheetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreAnswersheetRequest) ProtoMessage() {}
+
+func (x *ScoreAnswersheetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_scoring_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreAnswersheetRequest.ProtoReflect.Descriptor instead.
+func (*ScoreAnswersheetRequest) Descriptor() ([]byte, []int) {
+	return file_scoring_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ScoreAnswersheetRequest) GetQuestionnaireCode() string {
+	if x != nil {
+		return x.QuestionnaireCode
+	}
+	return ""
+}
+
+func (x *ScoreAnswersheetRequest) GetQuestionnaireVersion() string {
+	if x != nil {
+		return x.QuestionnaireVersion
+	}
+	return ""
+}
+
+func (x *ScoreAnswersheetRequest) GetAnswers() []*Answer {
+	if x != nil {
+		return x.Answers
+	}
+	return nil
+}
+
+// 答卷计分响应
+type ScoreAnswersheetResponse struct {
+	state                protoimpl.MessageState     `protogen:"open.v1"`
+	QuestionnaireCode    string                     `protobuf:"bytes,1,opt,name=questionnaire_code,json=questionnaireCode,proto3" json:"questionnaire_code,omitempty"`                                                                     // 问卷代码
+	QuestionnaireVersion string                     `protobuf:"bytes,2,opt,name=questionnaire_version,json=questionnaireVersion,proto3" json:"questionnaire_version,omitempty"`                                                            // 问卷版本
+	TotalScore           float64                    `protobuf:"fixed64,3,opt,name=total_score,json=totalScore,proto3" json:"total_score,omitempty"`                                                                                        // 答卷总分
+	AnswerScores         []*AnswerScore             `protobuf:"bytes,4,rep,name=answer_scores,json=answerScores,proto3" json:"answer_scores,omitempty"`                                                                                    // 各题得分
+	FactorScores         []*FactorScore             `protobuf:"bytes,5,rep,name=factor_scores,json=factorScores,proto3" json:"factor_scores,omitempty"`                                                                                    // 因子得分明细
+	PercentageScore      float64                    `protobuf:"fixed64,6,opt,name=percentage_score,json=percentageScore,proto3" json:"percentage_score,omitempty"`                                                                         // 百分制得分，医学量表开启百分制换算时有效
+	RuleScores           map[string]float64         `protobuf:"bytes,7,rep,name=rule_scores,json=ruleScores,proto3" json:"rule_scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"fixed64,2,opt,name=value"`              // 医学量表计分策略计算的各项得分，如总分和分量表得分
+	DimensionScores      map[string]*DimensionScore `protobuf:"bytes,8,rep,name=dimension_scores,json=dimensionScores,proto3" json:"dimension_scores,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // 医学量表各计分维度的得分，以维度编码为键
+	unknownFields        protoimpl.UnknownFields
+	sizeCache            protoimpl.SizeCache
+}
+
+func (x *ScoreAnswersheetResponse) Reset() {
+	*x = ScoreAnswersheetResponse{}
+	mi := &file_scoring_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScoreAnswersheetResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScoreAnswersheetResponse) ProtoMessage() {}
+
+func (x *ScoreAnswersheetResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_scoring_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScoreAnswersheetResponse.ProtoReflect.Descriptor instead.
+func (*ScoreAnswersheetResponse) Descriptor() ([]byte, []int) {
+	return file_scoring_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ScoreAnswersheetResponse) GetQuestionnaireCode() string {
+	if x != nil {
+		return x.QuestionnaireCode
+	}
+	return ""
+}
+
+func (x *ScoreAnswersheetResponse) GetQuestionnaireVersion() string {
+	if x != nil {
+		return x.QuestionnaireVersion
+	}
+	return ""
+}
+
+func (x *ScoreAnswersheetResponse) GetTotalScore() float64 {
+	if x != nil {
+		return x.TotalScore
+	}
+	return 0
+}
+
+func (x *ScoreAnswersheetResponse) GetAnswerScores() []*AnswerScore {
+	if x != nil {
+		return x.AnswerScores
+	}
+	return nil
+}
+
+func (x *ScoreAnswersheetResponse) GetFactorScores() []*FactorScore {
+	if x != nil {
+		return x.FactorScores
+	}
+	return nil
+}
+
+func (x *ScoreAnswersheetResponse) GetPercentageScore() float64 {
+	if x != nil {
+		return x.PercentageScore
+	}
+	return 0
+}
+
+func (x *ScoreAnswersheetResponse) GetRuleScores() map[string]float64 {
+	if x != nil {
+		return x.RuleScores
+	}
+	return nil
+}
+
+func (x *ScoreAnswersheetResponse) GetDimensionScores() map[string]*DimensionScore {
+	if x != nil {
+		return x.DimensionScores
+	}
+	return nil
+}
+
+// 答案
+type Answer struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionCode  string                 `protobuf:"bytes,1,opt,name=question_code,json=questionCode,proto3" json:"question_code,omitempty"` // 题目代码
+	QuestionType  string                 `protobuf:"bytes,2,opt,name=question_type,json=questionType,proto3" json:"question_type,omitempty"` // 题目类型
+	Value         string                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`                                   // 答案值，JSON 字符串
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Answer) Reset() {
+	*x = Answer{}
+	mi := &file_scoring_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Answer) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Answer) ProtoMessage() {}
+
+func (x *Answer) ProtoReflect() protoreflect.Message {
+	mi := &file_scoring_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Answer.ProtoReflect.Descriptor instead.
+func (*Answer) Descriptor() ([]byte, []int) {
+	return file_scoring_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Answer) GetQuestionCode() string {
+	if x != nil {
+		return x.QuestionCode
+	}
+	return ""
+}
+
+func (x *Answer) GetQuestionType() string {
+	if x != nil {
+		return x.QuestionType
+	}
+	return ""
+}
+
+func (x *Answer) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+// 题目得分
+type AnswerScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	QuestionCode  string                 `protobuf:"bytes,1,opt,name=question_code,json=questionCode,proto3" json:"question_code,omitempty"` // 题目代码
+	Score         float64                `protobuf:"fixed64,2,opt,name=score,proto3" json:"score,omitempty"`                                 // 得分
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AnswerScore) Reset() {
+	*x = AnswerScore{}
+	mi := &file_scoring_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AnswerScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AnswerScore) ProtoMessage() {}
+
+func (x *AnswerScore) ProtoReflect() protoreflect.Message {
+	mi := &file_scoring_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AnswerScore.ProtoReflect.Descriptor instead.
+func (*AnswerScore) Descriptor() ([]byte, []int) {
+	return file_scoring_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *AnswerScore) GetQuestionCode() string {
+	if x != nil {
+		return x.QuestionCode
+	}
+	return ""
+}
+
+func (x *AnswerScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+// 维度得分
+type DimensionScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Label         string                 `protobuf:"bytes,1,opt,name=label,proto3" json:"label,omitempty"`                   // 维度名称
+	Raw           float64                `protobuf:"fixed64,2,opt,name=raw,proto3" json:"raw,omitempty"`                     // 原始分
+	Normed        bool                   `protobuf:"varint,3,opt,name=normed,proto3" json:"normed,omitempty"`                // 是否按常模换算了 T 分数和百分位
+	TScore        float64                `protobuf:"fixed64,4,opt,name=t_score,json=tScore,proto3" json:"t_score,omitempty"` // T 分数，未配置常模时为 0
+	Percentile    float64                `protobuf:"fixed64,5,opt,name=percentile,proto3" json:"percentile,omitempty"`       // 百分位 [0, 100]，未配置常模时为 0
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DimensionScore) Reset() {
+	*x = DimensionScore{}
+	mi := &file_scoring_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DimensionScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DimensionScore) ProtoMessage() {}
+
+func (x *DimensionScore) ProtoReflect() protoreflect.Message {
+	mi := &file_scoring_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DimensionScore.ProtoReflect.Descriptor instead.
+func (*DimensionScore) Descriptor() ([]byte, []int) {
+	return file_scoring_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *DimensionScore) GetLabel() string {
+	if x != nil {
+		return x.Label
+	}
+	return ""
+}
+
+func (x *DimensionScore) GetRaw() float64 {
+	if x != nil {
+		return x.Raw
+	}
+	return 0
+}
+
+func (x *DimensionScore) GetNormed() bool {
+	if x != nil {
+		return x.Normed
+	}
+	return false
+}
+
+func (x *DimensionScore) GetTScore() float64 {
+	if x != nil {
+		return x.TScore
+	}
+	return 0
+}
+
+func (x *DimensionScore) GetPercentile() float64 {
+	if x != nil {
+		return x.Percentile
+	}
+	return 0
+}
+
+// 因子得分
+type FactorScore struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	FactorCode    string                 `protobuf:"bytes,1,opt,name=factor_code,json=factorCode,proto3" json:"factor_code,omitempty"` // 因子代码
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`                             // 因子标题
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`                           // 因子得分
+	Content       string                 `protobuf:"bytes,4,opt,name=content,proto3" json:"content,omitempty"`                         // 解读内容
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FactorScore) Reset() {
+	*x = FactorScore{}
+	mi := &file_scoring_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FactorScore) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FactorScore) ProtoMessage() {}
+
+func (x *FactorScore) ProtoReflect() protoreflect.Message {
+	mi := &file_scoring_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FactorScore.ProtoReflect.Descriptor instead.
+func (*FactorScore) Descriptor() ([]byte, []int) {
+	return file_scoring_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *FactorScore) GetFactorCode() string {
+	if x != nil {
+		return x.FactorCode
+	}
+	return ""
+}
+
+func (x *FactorScore) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *FactorScore) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *FactorScore) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+var File_scoring_proto protoreflect.FileDescriptor
+
+const file_scoring_proto_rawDesc = "" +
+	"\n" +
+	"\rscoring.proto\x12\ascoring\"\xa8\x01\n" +
+	"\x17ScoreAnswersheetRequest\x12-\n" +
+	"\x12questionnaire_code\x18\x01 \x01(\tR\x11questionnaireCode\x123\n" +
+	"\x15questionnaire_version\x18\x02 \x01(\tR\x14questionnaireVersion\x12)\n" +
+	"\aanswers\x18\x03 \x03(\v2\x0f.scoring.AnswerR\aanswers\"\x93\x05\n" +
+	"\x18ScoreAnswersheetResponse\x12-\n" +
+	"\x12questionnaire_code\x18\x01 \x01(\tR\x11questionnaireCode\x123\n" +
+	"\x15questionnaire_version\x18\x02 \x01(\tR\x14questionnaireVersion\x12\x1f\n" +
+	"\vtotal_score\x18\x03 \x01(\x01R\n" +
+	"totalScore\x129\n" +
+	"\ranswer_scores\x18\x04 \x03(\v2\x14.scoring.AnswerScoreR\fanswerScores\x129\n" +
+	"\rfactor_scores\x18\x05 \x03(\v2\x14.scoring.FactorScoreR\ffactorScores\x12)\n" +
+	"\x10percentage_score\x18\x06 \x01(\x01R\x0fpercentageScore\x12R\n" +
+	"\vrule_scores\x18\a \x03(\v21.scoring.ScoreAnswersheetResponse.RuleScoresEntryR\n" +
+	"ruleScores\x12a\n" +
+	"\x10dimension_scores\x18\b \x03(\v26.scoring.ScoreAnswersheetResponse.DimensionScoresEntryR\x0fdimensionScores\x1a=\n" +
+	"\x0fRuleScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\x01R\x05value:\x028\x01\x1a[\n" +
+	"\x14DimensionScoresEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12-\n" +
+	"\x05value\x18\x02 \x01(\v2\x17.scoring.DimensionScoreR\x05value:\x028\x01\"h\n" +
+	"\x06Answer\x12#\n" +
+	"\rquestion_code\x18\x01 \x01(\tR\fquestionCode\x12#\n" +
+	"\rquestion_type\x18\x02 \x01(\tR\fquestionType\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\"H\n" +
+	"\vAnswerScore\x12#\n" +
+	"\rquestion_code\x18\x01 \x01(\tR\fquestionCode\x12\x14\n" +
+	"\x05score\x18\x02 \x01(\x01R\x05score\"\x89\x01\n" +
+	"\x0eDimensionScore\x12\x14\n" +
+	"\x05label\x18\x01 \x01(\tR\x05label\x12\x10\n" +
+	"\x03raw\x18\x02 \x01(\x01R\x03raw\x12\x16\n" +
+	"\x06normed\x18\x03 \x01(\bR\x06normed\x12\x17\n" +
+	"\at_score\x18\x04 \x01(\x01R\x06tScore\x12\x1e\n" +
+	"\n" +
+	"percentile\x18\x05 \x01(\x01R\n" +
+	"percentile\"t\n" +
+	"\vFactorScore\x12\x1f\n" +
+	"\vfactor_code\x18\x01 \x01(\tR\n" +
+	"factorCode\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x18\n" +
+	"\acontent\x18\x04 \x01(\tR\acontent2i\n" +
+	"\x0eScoringService\x12W\n" +
+	"\x10ScoreAnswersheet\x12 .scoring.ScoreAnswersheetRequest\x1a!.scoring.ScoreAnswersheetResponseBXZVgithub.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/scoringb\x06proto3"
+
+var (
+	file_scoring_proto_rawDescOnce sync.Once
+	file_scoring_proto_rawDescData []byte
+)
+
+func file_scoring_proto_rawDescGZIP() []byte {
+	file_scoring_proto_rawDescOnce.Do(func() {
+		file_scoring_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_scoring_proto_rawDesc), len(file_scoring_proto_rawDesc)))
+	})
+	return file_scoring_proto_rawDescData
+}
+
+var file_scoring_proto_msgTypes = make([]protoimpl.MessageInfo, 8)
+var file_scoring_proto_goTypes = []any{
+	(*ScoreAnswersheetRequest)(nil),  // 0: scoring.ScoreAnswersheetRequest
+	(*ScoreAnswersheetResponse)(nil), // 1: scoring.ScoreAnswersheetResponse
+	(*Answer)(nil),                   // 2: scoring.Answer
+	(*AnswerScore)(nil),              // 3: scoring.AnswerScore
+	(*DimensionScore)(nil),           // 4: scoring.DimensionScore
+	(*FactorScore)(nil),              // 5: scoring.FactorScore
+	nil,                              // 6: scoring.ScoreAnswersheetResponse.RuleScoresEntry
+	nil,                              // 7: scoring.ScoreAnswersheetResponse.DimensionScoresEntry
+}
+var file_scoring_proto_depIdxs = []int32{
+	2, // 0: scoring.ScoreAnswersheetRequest.answers:type_name -> scoring.Answer
+	3, // 1: scoring.ScoreAnswersheetResponse.answer_scores:type_name -> scoring.AnswerScore
+	5, // 2: scoring.ScoreAnswersheetResponse.factor_scores:type_name -> scoring.FactorScore
+	6, // 3: scoring.ScoreAnswersheetResponse.rule_scores:type_name -> scoring.ScoreAnswersheetResponse.RuleScoresEntry
+	7, // 4: scoring.ScoreAnswersheetResponse.dimension_scores:type_name -> scoring.ScoreAnswersheetResponse.DimensionScoresEntry
+	4, // 5: scoring.ScoreAnswersheetResponse.DimensionScoresEntry.value:type_name -> scoring.DimensionScore
+	0, // 6: scoring.ScoringService.ScoreAnswersheet:input_type -> scoring.ScoreAnswersheetRequest
+	1, // 7: scoring.ScoringService.ScoreAnswersheet:output_type -> scoring.ScoreAnswersheetResponse
+	7, // [7:8] is the sub-list for method output_type
+	6, // [6:7] is the sub-list for method input_type
+	6, // [6:6] is the sub-list for extension type_name
+	6, // [6:6] is the sub-list for extension extendee
+	0, // [0:6] is the sub-list for field type_name
+}
+
+func init() { file_scoring_proto_init() }
+func file_scoring_proto_init() {
+	if File_scoring_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_scoring_proto_rawDesc), len(file_scoring_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   8,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_scoring_proto_goTypes,
+		DependencyIndexes: file_scoring_proto_depIdxs,
+		MessageInfos:      file_scoring_proto_msgTypes,
+	}.Build()
+	File_scoring_proto = out.File
+	file_scoring_proto_goTypes = nil
+	file_scoring_proto_depIdxs = nil
+}
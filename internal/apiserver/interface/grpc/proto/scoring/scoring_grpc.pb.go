@@ -0,0 +1,127 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             v5.29.3
+// source: scoring.proto
+
+package scoring
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	ScoringService_ScoreAnswersheet_FullMethodName = "/scoring.ScoringService/ScoreAnswersheet"
+)
+
+// ScoringServiceClient is the client API for ScoringService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// ScoringService 答卷计分服务
+type ScoringServiceClient interface {
+	// ScoreAnswersheet 根据问卷选项分值与医学量表因子规则计算答卷得分，不保存答卷
+	ScoreAnswersheet(ctx context.Context, in *ScoreAnswersheetRequest, opts ...grpc.CallOption) (*ScoreAnswersheetResponse, error)
+}
+
+type scoringServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewScoringServiceClient(cc grpc.ClientConnInterface) ScoringServiceClient {
+	return &scoringServiceClient{cc}
+}
+
+func (c *scoringServiceClient) ScoreAnswersheet(ctx context.Context, in *ScoreAnswersheetRequest, opts ...grpc.CallOption) (*ScoreAnswersheetResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScoreAnswersheetResponse)
+	err := c.cc.Invoke(ctx, ScoringService_ScoreAnswersheet_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ScoringServiceServer is the server API for ScoringService service.
+// All implementations must embed UnimplementedScoringServiceServer
+// for forward compatibility.
+//
+// ScoringService 答卷计分服务
+type ScoringServiceServer interface {
+	// ScoreAnswersheet 根据问卷选项分值与医学量表因子规则计算答卷得分，不保存答卷
+	ScoreAnswersheet(context.Context, *ScoreAnswersheetRequest) (*ScoreAnswersheetResponse, error)
+	mustEmbedUnimplementedScoringServiceServer()
+}
+
+// UnimplementedScoringServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedScoringServiceServer struct{}
+
+func (UnimplementedScoringServiceServer) ScoreAnswersheet(context.Context, *ScoreAnswersheetRequest) (*ScoreAnswersheetResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScoreAnswersheet not implemented")
+}
+func (UnimplementedScoringServiceServer) mustEmbedUnimplementedScoringServiceServer() {}
+func (UnimplementedScoringServiceServer) testEmbeddedByValue()                        {}
+
+// UnsafeScoringServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ScoringServiceServer will
+// result in compilation errors.
+type UnsafeScoringServiceServer interface {
+	mustEmbedUnimplementedScoringServiceServer()
+}
+
+func RegisterScoringServiceServer(s grpc.ServiceRegistrar, srv ScoringServiceServer) {
+	// If the following call pancis, it indicates UnimplementedScoringServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&ScoringService_ServiceDesc, srv)
+}
+
+func _ScoringService_ScoreAnswersheet_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScoreAnswersheetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ScoringServiceServer).ScoreAnswersheet(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ScoringService_ScoreAnswersheet_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ScoringServiceServer).ScoreAnswersheet(ctx, req.(*ScoreAnswersheetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ScoringService_ServiceDesc is the grpc.ServiceDesc for ScoringService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ScoringService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "scoring.ScoringService",
+	HandlerType: (*ScoringServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ScoreAnswersheet",
+			Handler:    _ScoringService_ScoreAnswersheet_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "scoring.proto",
+}
@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
+	"strconv"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
@@ -85,6 +88,91 @@ func (s *MedicalScaleService) GetMedicalScaleByQuestionnaireCode(ctx context.Con
 	return response, nil
 }
 
+// defaultMedicalScalePageSize 未指定每页数量时的默认值
+const defaultMedicalScalePageSize = 20
+
+// medicalScalePageToken 医学量表列表的分页令牌，记录上一页最后一条医学量表
+type medicalScalePageToken struct {
+	LastCode string `json:"last_code"`
+	LastID   string `json:"last_id"`
+}
+
+// encodeMedicalScalePageToken 将分页令牌编码为 base64 JSON 字符串
+func encodeMedicalScalePageToken(token medicalScalePageToken) (string, error) {
+	data, err := json.Marshal(token)
+	if err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(data), nil
+}
+
+// decodeMedicalScalePageToken 解析分页令牌，空字符串表示第一页
+func decodeMedicalScalePageToken(pageToken string) (medicalScalePageToken, error) {
+	var token medicalScalePageToken
+	if pageToken == "" {
+		return token, nil
+	}
+
+	data, err := base64.StdEncoding.DecodeString(pageToken)
+	if err != nil {
+		return token, err
+	}
+	if err := json.Unmarshal(data, &token); err != nil {
+		return token, err
+	}
+	if token.LastCode == "" {
+		return token, fmt.Errorf("last_code is empty")
+	}
+	return token, nil
+}
+
+// ListMedicalScales 按医学量表代码升序分页获取医学量表列表
+// 使用上一页最后一条记录的代码作为游标，避免大页码偏移查询缓慢以及并发写入导致的重复或遗漏
+func (s *MedicalScaleService) ListMedicalScales(ctx context.Context, req *pb.ListMedicalScalesRequest) (*pb.ListMedicalScalesResponse, error) {
+	token, err := decodeMedicalScalePageToken(req.PageToken)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, fmt.Sprintf("分页令牌无效: %v", err))
+	}
+
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultMedicalScalePageSize
+	}
+	if pageSize < 0 || pageSize > 100 {
+		return nil, status.Error(codes.InvalidArgument, "每页数量必须在 1 到 100 之间")
+	}
+
+	log.Infof("获取医学量表列表，起始代码: %s，每页数量: %d", token.LastCode, pageSize)
+
+	medicalScales, hasMore, err := s.medicalScaleQueryer.ListMedicalScalesAfterCode(ctx, token.LastCode, pageSize)
+	if err != nil {
+		log.Errorf("获取医学量表列表失败: %v", err)
+		return nil, status.Error(codes.Internal, fmt.Sprintf("获取医学量表列表失败: %v", err))
+	}
+
+	response := &pb.ListMedicalScalesResponse{
+		MedicalScales: make([]*pb.MedicalScale, 0, len(medicalScales)),
+	}
+	for _, medicalScale := range medicalScales {
+		response.MedicalScales = append(response.MedicalScales, convertMedicalScaleToProto(medicalScale))
+	}
+
+	// 还有下一页时返回以本页最后一条记录为游标的分页令牌
+	if hasMore && len(medicalScales) > 0 {
+		last := medicalScales[len(medicalScales)-1]
+		nextPageToken, err := encodeMedicalScalePageToken(medicalScalePageToken{
+			LastCode: last.Code,
+			LastID:   strconv.FormatUint(last.ID, 10),
+		})
+		if err != nil {
+			return nil, status.Error(codes.Internal, fmt.Sprintf("生成分页令牌失败: %v", err))
+		}
+		response.NextPageToken = nextPageToken
+	}
+
+	return response, nil
+}
+
 // convertMedicalScaleToProto 将 DTO 转换为 Proto 消息
 func convertMedicalScaleToProto(medicalScale *dto.MedicalScaleDTO) *pb.MedicalScale {
 	if medicalScale == nil {
@@ -97,6 +185,19 @@ func convertMedicalScaleToProto(medicalScale *dto.MedicalScaleDTO) *pb.MedicalSc
 		factors = append(factors, convertFactorToProto(factor))
 	}
 
+	// 转换分数段列表
+	scoreBands := make([]*pb.ScoreBand, 0, len(medicalScale.ScoringConfig.ScoreBands))
+	for _, band := range medicalScale.ScoringConfig.ScoreBands {
+		scoreBands = append(scoreBands, &pb.ScoreBand{
+			ScoreRange: &pb.ScoreRange{
+				MinScore: band.ScoreRange.MinScore,
+				MaxScore: band.ScoreRange.MaxScore,
+			},
+			Label:       band.Label,
+			Description: band.Description,
+		})
+	}
+
 	return &pb.MedicalScale{
 		Id:                medicalScale.ID,
 		Code:              medicalScale.Code,
@@ -106,6 +207,7 @@ func convertMedicalScaleToProto(medicalScale *dto.MedicalScaleDTO) *pb.MedicalSc
 		Factors:           factors,
 		CreatedAt:         "", // DTO 中没有时间字段，暂时为空
 		UpdatedAt:         "", // DTO 中没有时间字段，暂时为空
+		ScoreBands:        scoreBands,
 	}
 }
 
@@ -117,6 +219,7 @@ func convertFactorToProto(factor dto.FactorDTO) *pb.Factor {
 		calculationRule = &pb.CalculationRule{
 			FormulaType: factor.CalculationRule.FormulaType,
 			SourceCodes: factor.CalculationRule.SourceCodes,
+			Threshold:   factor.CalculationRule.Threshold,
 		}
 	}
 
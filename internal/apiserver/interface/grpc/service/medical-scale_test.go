@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
+	pb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scale"
+)
+
+// fakeMedicalScaleQueryer 基于内存的医学量表查询器，医学量表按编码升序保存
+type fakeMedicalScaleQueryer struct {
+	port.MedicalScaleQueryer
+	scales []*dto.MedicalScaleDTO
+}
+
+func (f *fakeMedicalScaleQueryer) ListMedicalScalesAfterCode(ctx context.Context, lastCode string, pageSize int) ([]*dto.MedicalScaleDTO, bool, error) {
+	var result []*dto.MedicalScaleDTO
+	for _, scale := range f.scales {
+		if scale.Code > lastCode {
+			result = append(result, scale)
+		}
+	}
+	if len(result) > pageSize {
+		return result[:pageSize], true, nil
+	}
+	return result, false, nil
+}
+
+func (f *fakeMedicalScaleQueryer) GetMedicalScaleByCode(ctx context.Context, code string) (*dto.MedicalScaleDTO, error) {
+	for _, scale := range f.scales {
+		if scale.Code == code {
+			return scale, nil
+		}
+	}
+	return nil, nil
+}
+
+func TestMedicalScaleService_GetMedicalScaleByCode_ScoreBands(t *testing.T) {
+	queryer := &fakeMedicalScaleQueryer{scales: []*dto.MedicalScaleDTO{{
+		ID:   1,
+		Code: "PHQ9",
+		ScoringConfig: dto.ScoringConfigDTO{ScoreBands: []dto.ScoreBandDTO{
+			{ScoreRange: dto.ScoreRangeDTO{MinScore: 0, MaxScore: 10}, Label: "无或轻度抑郁"},
+			{ScoreRange: dto.ScoreRangeDTO{MinScore: 10, MaxScore: 28}, Label: "中度及以上抑郁", Description: "建议复诊"},
+		}},
+	}}}
+	conn := dialBufconn(t, NewMedicalScaleService(queryer).RegisterService)
+	client := pb.NewMedicalScaleServiceClient(conn)
+
+	resp, err := client.GetMedicalScaleByCode(context.Background(), &pb.GetMedicalScaleByCodeRequest{Code: "PHQ9"})
+	if err != nil {
+		t.Fatalf("GetMedicalScaleByCode() error = %v", err)
+	}
+
+	bands := resp.GetMedicalScale().GetScoreBands()
+	if len(bands) != 2 {
+		t.Fatalf("len(ScoreBands) = %d, want 2", len(bands))
+	}
+	got := bands[1]
+	if got.GetScoreRange().GetMinScore() != 10 || got.GetScoreRange().GetMaxScore() != 28 ||
+		got.GetLabel() != "中度及以上抑郁" || got.GetDescription() != "建议复诊" {
+		t.Errorf("ScoreBands[1] = %v", got)
+	}
+}
+
+func TestMedicalScaleService_ListMedicalScales(t *testing.T) {
+	queryer := &fakeMedicalScaleQueryer{}
+	for i := 1; i <= 5; i++ {
+		queryer.scales = append(queryer.scales, &dto.MedicalScaleDTO{ID: uint64(i), Code: fmt.Sprintf("MS%02d", i)})
+	}
+	conn := dialBufconn(t, NewMedicalScaleService(queryer).RegisterService)
+	client := pb.NewMedicalScaleServiceClient(conn)
+
+	// 逐页获取，直到下一页令牌为空
+	var codesSeen []string
+	req := &pb.ListMedicalScalesRequest{PageSize: 2}
+	for pages := 1; ; pages++ {
+		resp, err := client.ListMedicalScales(context.Background(), req)
+		if err != nil {
+			t.Fatalf("ListMedicalScales() page %d error = %v", pages, err)
+		}
+		for _, scale := range resp.GetMedicalScales() {
+			codesSeen = append(codesSeen, scale.GetCode())
+		}
+		if resp.GetNextPageToken() == "" {
+			if pages != 3 {
+				t.Errorf("pages = %d, want 3", pages)
+			}
+			break
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+
+	want := []string{"MS01", "MS02", "MS03", "MS04", "MS05"}
+	if fmt.Sprint(codesSeen) != fmt.Sprint(want) {
+		t.Errorf("codes = %v, want %v", codesSeen, want)
+	}
+
+	// 无效的分页令牌
+	_, err := client.ListMedicalScales(context.Background(), &pb.ListMedicalScalesRequest{PageToken: "not-a-token", PageSize: 2})
+	if got := status.Code(err); got != codes.InvalidArgument {
+		t.Errorf("ListMedicalScales(invalid token) code = %v, want %v", got, codes.InvalidArgument)
+	}
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"fmt"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -10,6 +11,9 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
 	pb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaire"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 // QuestionnaireService 问卷 GRPC 服务 - 对外提供查询功能
@@ -73,6 +77,63 @@ func (s *QuestionnaireService) ListQuestionnaires(ctx context.Context, req *pb.L
 	}, nil
 }
 
+// GetQuestionnaireByCode 根据问卷代码获取问卷
+func (s *QuestionnaireService) GetQuestionnaireByCode(ctx context.Context, req *pb.GetQuestionnaireByCodeRequest) (*pb.GetQuestionnaireByCodeResponse, error) {
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "问卷代码不能为空")
+	}
+
+	log.Infof("获取问卷详情，代码: %s", req.Code)
+
+	result, err := s.queryer.GetQuestionnaireByCode(ctx, req.Code)
+	if err != nil {
+		return nil, s.toStatusError(err)
+	}
+	if result == nil {
+		return nil, status.Error(codes.NotFound, "问卷不存在")
+	}
+
+	return &pb.GetQuestionnaireByCodeResponse{
+		Questionnaire: s.toProtoQuestionnaire(result),
+	}, nil
+}
+
+// GetQuestionnaireByCodeVersion 根据问卷代码和版本获取问卷
+func (s *QuestionnaireService) GetQuestionnaireByCodeVersion(ctx context.Context, req *pb.GetQuestionnaireByCodeVersionRequest) (*pb.GetQuestionnaireByCodeVersionResponse, error) {
+	if req.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "问卷代码不能为空")
+	}
+	if req.Version == "" {
+		return nil, status.Error(codes.InvalidArgument, "问卷版本不能为空")
+	}
+
+	log.Infof("获取问卷详情，代码: %s，版本: %s", req.Code, req.Version)
+
+	result, err := s.queryer.GetQuestionnaireByCodeVersion(ctx, req.Code, req.Version)
+	if err != nil {
+		return nil, s.toStatusError(err)
+	}
+	if result == nil {
+		return nil, status.Error(codes.NotFound, "问卷不存在")
+	}
+
+	return &pb.GetQuestionnaireByCodeVersionResponse{
+		Questionnaire: s.toProtoQuestionnaire(result),
+	}, nil
+}
+
+// toStatusError 将应用层错误转换为 gRPC 状态错误
+func (s *QuestionnaireService) toStatusError(err error) error {
+	if errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+		return status.Error(codes.NotFound, "问卷不存在")
+	}
+	if errors.IsCode(err, errCode.ErrQuestionnaireInvalidInput) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	log.Errorf("获取问卷失败: %v", err)
+	return status.Error(codes.Internal, fmt.Sprintf("获取问卷失败: %v", err))
+}
+
 // toProtoQuestionnaire 转换为 protobuf 问卷
 func (s *QuestionnaireService) toProtoQuestionnaire(dto *dto.QuestionnaireDTO) *pb.Questionnaire {
 	if dto == nil {
@@ -87,6 +148,8 @@ func (s *QuestionnaireService) toProtoQuestionnaire(dto *dto.QuestionnaireDTO) *
 		Status:      dto.Status,
 		Version:     dto.Version,
 		Questions:   s.toProtoQuestions(dto.Questions),
+
+		GeoRestriction: dto.GeoRestriction,
 		// TODO: 添加 CreatedAt 和 UpdatedAt 字段到 DTO
 		CreatedAt: "",
 		UpdatedAt: "",
@@ -140,6 +203,7 @@ func (s *QuestionnaireService) toProtoValidationRules(rules []dto.ValidationRule
 		protoRules[i] = &pb.ValidationRule{
 			RuleType:    r.RuleType,
 			TargetValue: r.TargetValue,
+			Severity:    r.Severity,
 		}
 	}
 	return protoRules
@@ -151,7 +215,17 @@ func (s *QuestionnaireService) toProtoCalculationRule(rule *dto.CalculationRuleD
 		return nil
 	}
 
+	scoreMappings := make([]*pb.ScoreMapping, len(rule.ScoreMappings))
+	for i, mapping := range rule.ScoreMappings {
+		scoreMappings[i] = &pb.ScoreMapping{
+			WhenValue: mapping.WhenValue,
+			Scores:    mapping.Scores,
+		}
+	}
+
 	return &pb.CalculationRule{
-		FormulaType: rule.FormulaType,
+		FormulaType:   rule.FormulaType,
+		DependsOnCode: rule.DependsOnCode,
+		ScoreMappings: scoreMappings,
 	}
 }
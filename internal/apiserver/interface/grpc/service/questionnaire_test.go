@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	pb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaire"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
+)
+
+// fakeQuestionnaireQueryer 基于内存的问卷查询器，按 code@version 存储问卷
+type fakeQuestionnaireQueryer struct {
+	questionnaires map[string]*dto.QuestionnaireDTO
+}
+
+func (f *fakeQuestionnaireQueryer) GetQuestionnaireByCode(ctx context.Context, code string) (*dto.QuestionnaireDTO, error) {
+	for _, q := range f.questionnaires {
+		if q.Code == code {
+			return q, nil
+		}
+	}
+	return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s", code)
+}
+
+func (f *fakeQuestionnaireQueryer) GetQuestionnaireByCodeVersion(ctx context.Context, code, version string) (*dto.QuestionnaireDTO, error) {
+	if q, ok := f.questionnaires[code+"@"+version]; ok {
+		return q, nil
+	}
+	return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在: %s@%s", code, version)
+}
+
+func (f *fakeQuestionnaireQueryer) ListQuestionnaires(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*dto.QuestionnaireDTO, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeQuestionnaireQueryer) ListQuestionnairesByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*dto.QuestionnaireDTO, int64, error) {
+	return nil, 0, nil
+}
+
+func (f *fakeQuestionnaireQueryer) GetQuestions(ctx context.Context, code string, withAutoCodes bool) ([]dto.QuestionDTO, error) {
+	q, err := f.GetQuestionnaireByCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return q.Questions, nil
+}
+
+func (f *fakeQuestionnaireQueryer) ListQuestionTypes(ctx context.Context) []questiontype.Info {
+	return questiontype.List()
+}
+
+func newQuestionnaireClient(t *testing.T) pb.QuestionnaireServiceClient {
+	t.Helper()
+
+	queryer := &fakeQuestionnaireQueryer{
+		questionnaires: map[string]*dto.QuestionnaireDTO{
+			"QN1@1.0": {
+				Code:    "QN1",
+				Title:   "睡眠质量问卷",
+				Version: "1.0",
+				Status:  "published",
+				Questions: []dto.QuestionDTO{
+					{
+						Code:  "Q1",
+						Type:  "Radio",
+						Title: "睡眠质量",
+						Options: []dto.OptionDTO{
+							{Code: "A", Content: "好", Score: 0},
+							{Code: "B", Content: "差", Score: 2},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	conn := dialBufconn(t, NewQuestionnaireService(queryer).RegisterService)
+	return pb.NewQuestionnaireServiceClient(conn)
+}
+
+func TestQuestionnaireService_GetQuestionnaireByCode(t *testing.T) {
+	client := newQuestionnaireClient(t)
+
+	tests := []struct {
+		name     string
+		code     string
+		wantCode codes.Code
+	}{
+		{name: "found", code: "QN1", wantCode: codes.OK},
+		{name: "not found", code: "QN404", wantCode: codes.NotFound},
+		{name: "empty code", code: "", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GetQuestionnaireByCode(context.Background(), &pb.GetQuestionnaireByCodeRequest{Code: tt.code})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("GetQuestionnaireByCode() code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode != codes.OK {
+				return
+			}
+			q := resp.GetQuestionnaire()
+			if q.GetCode() != "QN1" || q.GetVersion() != "1.0" {
+				t.Errorf("questionnaire = %s@%s, want QN1@1.0", q.GetCode(), q.GetVersion())
+			}
+			if len(q.GetQuestions()) != 1 || len(q.GetQuestions()[0].GetOptions()) != 2 {
+				t.Fatalf("questions = %v, want 1 question with 2 options", q.GetQuestions())
+			}
+		})
+	}
+}
+
+func TestQuestionnaireService_GetQuestionnaireByCodeVersion(t *testing.T) {
+	client := newQuestionnaireClient(t)
+
+	tests := []struct {
+		name     string
+		code     string
+		version  string
+		wantCode codes.Code
+	}{
+		{name: "found", code: "QN1", version: "1.0", wantCode: codes.OK},
+		{name: "version not found", code: "QN1", version: "2.0", wantCode: codes.NotFound},
+		{name: "code not found", code: "QN404", version: "1.0", wantCode: codes.NotFound},
+		{name: "empty version", code: "QN1", version: "", wantCode: codes.InvalidArgument},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.GetQuestionnaireByCodeVersion(context.Background(), &pb.GetQuestionnaireByCodeVersionRequest{
+				Code:    tt.code,
+				Version: tt.version,
+			})
+			if got := status.Code(err); got != tt.wantCode {
+				t.Fatalf("GetQuestionnaireByCodeVersion() code = %v, want %v (err: %v)", got, tt.wantCode, err)
+			}
+			if tt.wantCode == codes.OK && resp.GetQuestionnaire().GetTitle() != "睡眠质量问卷" {
+				t.Errorf("title = %q, want %q", resp.GetQuestionnaire().GetTitle(), "睡眠质量问卷")
+			}
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	pb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/scoring"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// ScoringService 答卷计分 gRPC 服务
+type ScoringService struct {
+	pb.UnimplementedScoringServiceServer
+	scorer port.AnswerSheetScorer
+}
+
+// NewScoringService 创建答卷计分服务
+func NewScoringService(scorer port.AnswerSheetScorer) *ScoringService {
+	return &ScoringService{
+		scorer: scorer,
+	}
+}
+
+// RegisterService 注册 GRPC 服务
+func (s *ScoringService) RegisterService(server *grpc.Server) {
+	pb.RegisterScoringServiceServer(server, s)
+}
+
+// ScoreAnswersheet 计算答卷得分及因子得分明细
+func (s *ScoringService) ScoreAnswersheet(ctx context.Context, req *pb.ScoreAnswersheetRequest) (*pb.ScoreAnswersheetResponse, error) {
+	if req.QuestionnaireCode == "" {
+		return nil, status.Error(codes.InvalidArgument, "问卷代码不能为空")
+	}
+	if len(req.Answers) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "答案不能为空")
+	}
+
+	log.Infof("计算答卷得分，问卷代码: %s, 版本: %s", req.QuestionnaireCode, req.QuestionnaireVersion)
+
+	result, err := s.scorer.ScoreAnswerSheet(ctx, dto.AnswerSheetDTO{
+		QuestionnaireCode:    req.QuestionnaireCode,
+		QuestionnaireVersion: req.QuestionnaireVersion,
+		Answers:              convertScoringAnswersFromProto(req.Answers),
+	})
+	if err != nil {
+		log.Errorf("计算答卷得分失败: %v", err)
+		if errors.IsCode(err, errCode.ErrQuestionnaireNotFound) {
+			return nil, status.Error(codes.NotFound, "问卷不存在")
+		}
+		return nil, status.Error(codes.Internal, fmt.Sprintf("计算答卷得分失败: %v", err))
+	}
+
+	// 转换为 gRPC 响应
+	answerScores := make([]*pb.AnswerScore, 0, len(result.Answers))
+	for _, answer := range result.Answers {
+		answerScores = append(answerScores, &pb.AnswerScore{
+			QuestionCode: answer.QuestionCode,
+			Score:        answer.Score,
+		})
+	}
+
+	factorScores := make([]*pb.FactorScore, 0, len(result.Factors))
+	for _, factor := range result.Factors {
+		factorScores = append(factorScores, &pb.FactorScore{
+			FactorCode: factor.FactorCode,
+			Title:      factor.Title,
+			Score:      factor.Score,
+			Content:    factor.Content,
+		})
+	}
+
+	dimensionScores := make(map[string]*pb.DimensionScore, len(result.DimensionScores))
+	for code, score := range result.DimensionScores {
+		dimensionScores[code] = &pb.DimensionScore{
+			Label:      score.Label,
+			Raw:        score.Raw,
+			Normed:     score.Normed,
+			TScore:     score.TScore,
+			Percentile: score.Percentile,
+		}
+	}
+
+	return &pb.ScoreAnswersheetResponse{
+		QuestionnaireCode:    result.QuestionnaireCode,
+		QuestionnaireVersion: result.QuestionnaireVersion,
+		TotalScore:           result.TotalScore,
+		AnswerScores:         answerScores,
+		FactorScores:         factorScores,
+		PercentageScore:      result.PercentageScore,
+		RuleScores:           result.RuleScores,
+		DimensionScores:      dimensionScores,
+	}, nil
+}
+
+// convertScoringAnswersFromProto 将 Proto 答案转换为 DTO
+// 答案值为 JSON 字符串，无法解析时按原始字符串处理
+func convertScoringAnswersFromProto(protoAnswers []*pb.Answer) []dto.AnswerDTO {
+	answers := make([]dto.AnswerDTO, 0, len(protoAnswers))
+	for _, protoAnswer := range protoAnswers {
+		var value any
+		if err := json.Unmarshal([]byte(protoAnswer.Value), &value); err != nil {
+			value = protoAnswer.Value
+		}
+
+		answers = append(answers, dto.AnswerDTO{
+			QuestionCode: protoAnswer.QuestionCode,
+			QuestionType: protoAnswer.QuestionType,
+			Value:        value,
+		})
+	}
+	return answers
+}
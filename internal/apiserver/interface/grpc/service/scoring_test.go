@@ -0,0 +1,144 @@
+package service
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	pb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/scoring"
+	errCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// fakeScorer 按选项编码计分的答卷计分器
+type fakeScorer struct {
+	received dto.AnswerSheetDTO
+}
+
+func (f *fakeScorer) ScoreAnswerSheet(ctx context.Context, answerSheet dto.AnswerSheetDTO) (*dto.AnswerSheetScoreDTO, error) {
+	f.received = answerSheet
+	if answerSheet.QuestionnaireCode != "QN1" {
+		return nil, errors.WithCode(errCode.ErrQuestionnaireNotFound, "问卷不存在")
+	}
+
+	optionScores := map[string]float64{"A": 0, "B": 1, "C": 2}
+	result := &dto.AnswerSheetScoreDTO{
+		QuestionnaireCode:    answerSheet.QuestionnaireCode,
+		QuestionnaireVersion: "1.0",
+	}
+	for _, answer := range answerSheet.Answers {
+		score := optionScores[answer.Value.(string)]
+		answer.Score = score
+		result.TotalScore += score
+		result.Answers = append(result.Answers, answer)
+	}
+	result.Factors = []dto.InterpretItemDTO{
+		{FactorCode: "total", Title: "总分", Score: result.TotalScore, Content: "偏高"},
+	}
+	return result, nil
+}
+
+// dialBufconn 在内存监听上启动 gRPC 服务并返回客户端连接
+func dialBufconn(t *testing.T, register func(server *grpc.Server)) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer()
+	register(server)
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	return conn
+}
+
+func newScoringClient(t *testing.T, scorer *fakeScorer) pb.ScoringServiceClient {
+	t.Helper()
+
+	conn := dialBufconn(t, NewScoringService(scorer).RegisterService)
+	return pb.NewScoringServiceClient(conn)
+}
+
+func TestScoringService_ScoreAnswersheet(t *testing.T) {
+	scorer := &fakeScorer{}
+	client := newScoringClient(t, scorer)
+
+	resp, err := client.ScoreAnswersheet(context.Background(), &pb.ScoreAnswersheetRequest{
+		QuestionnaireCode: "QN1",
+		Answers: []*pb.Answer{
+			{QuestionCode: "Q1", QuestionType: "Radio", Value: `"B"`},
+			{QuestionCode: "Q2", QuestionType: "Radio", Value: `"C"`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("ScoreAnswersheet() error = %v", err)
+	}
+
+	if resp.TotalScore != 3 {
+		t.Errorf("TotalScore = %v, want 3", resp.TotalScore)
+	}
+	if len(resp.AnswerScores) != 2 || resp.AnswerScores[1].QuestionCode != "Q2" || resp.AnswerScores[1].Score != 2 {
+		t.Errorf("AnswerScores = %v", resp.AnswerScores)
+	}
+	if len(resp.FactorScores) != 1 || resp.FactorScores[0].FactorCode != "total" || resp.FactorScores[0].Score != 3 {
+		t.Errorf("FactorScores = %v", resp.FactorScores)
+	}
+	if scorer.received.Answers[0].Value != "B" {
+		t.Errorf("answer value should be decoded from JSON, got %#v", scorer.received.Answers[0].Value)
+	}
+}
+
+func TestScoringService_ScoreAnswersheet_Errors(t *testing.T) {
+	client := newScoringClient(t, &fakeScorer{})
+
+	tests := []struct {
+		name string
+		req  *pb.ScoreAnswersheetRequest
+		want codes.Code
+	}{
+		{
+			name: "missing questionnaire code",
+			req:  &pb.ScoreAnswersheetRequest{Answers: []*pb.Answer{{QuestionCode: "Q1", Value: `"A"`}}},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "missing answers",
+			req:  &pb.ScoreAnswersheetRequest{QuestionnaireCode: "QN1"},
+			want: codes.InvalidArgument,
+		},
+		{
+			name: "questionnaire not found",
+			req:  &pb.ScoreAnswersheetRequest{QuestionnaireCode: "QN404", Answers: []*pb.Answer{{QuestionCode: "Q1", Value: `"A"`}}},
+			want: codes.NotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.ScoreAnswersheet(context.Background(), tt.req)
+			if got := status.Code(err); got != tt.want {
+				t.Errorf("status code = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
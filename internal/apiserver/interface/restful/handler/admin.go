@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/admin/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+)
+
+// AdminHandler 管理员处理器
+type AdminHandler struct {
+	*BaseHandler
+	summaryQueryer port.SystemSummaryQueryer
+}
+
+// NewAdminHandler 创建管理员处理器
+func NewAdminHandler(summaryQueryer port.SystemSummaryQueryer) *AdminHandler {
+	return &AdminHandler{
+		BaseHandler:    &BaseHandler{},
+		summaryQueryer: summaryQueryer,
+	}
+}
+
+// GetSystemSummary 获取系统概览
+// @Summary 获取系统概览
+// @Description 获取问卷、答卷、受试者、医学量表总数，最近 7 天每日答卷提交数及提交最多的 5 个问卷，结果缓存 5 分钟
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=viewmodel.SystemSummaryViewModel}
+// @Router /v1/admin/summary [get]
+func (h *AdminHandler) GetSystemSummary(c *gin.Context) {
+	summary, err := h.summaryQueryer.GetSystemSummary(c.Request.Context())
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	recent := make([]viewmodel.DailyCountViewModel, 0, len(summary.RecentSubmissions))
+	for _, day := range summary.RecentSubmissions {
+		recent = append(recent, viewmodel.DailyCountViewModel{
+			Date:  day.Date,
+			Count: day.Count,
+		})
+	}
+
+	top := make([]viewmodel.QuestionnaireUsageViewModel, 0, len(summary.TopQuestionnaires))
+	for _, usage := range summary.TopQuestionnaires {
+		top = append(top, viewmodel.QuestionnaireUsageViewModel{
+			QuestionnaireCode: usage.QuestionnaireCode,
+			Title:             usage.Title,
+			SubmissionCount:   usage.SubmissionCount,
+		})
+	}
+
+	h.SuccessResponse(c, viewmodel.SystemSummaryViewModel{
+		TotalQuestionnaires:  summary.TotalQuestionnaires,
+		ActiveQuestionnaires: summary.ActiveQuestionnaires,
+		TotalAnswersheets:    summary.TotalAnswersheets,
+		TotalRespondents:     summary.TotalRespondents,
+		TotalMedicalScales:   summary.TotalMedicalScales,
+		RecentSubmissions:    recent,
+		TopQuestionnaires:    top,
+		GeneratedAt:          summary.GeneratedAt.Format(time.RFC3339),
+	})
+}
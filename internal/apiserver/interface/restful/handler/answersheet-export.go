@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// csvExportBufferSize CSV 写入器与响应之间的缓冲大小
+	csvExportBufferSize = 4096
+	// csvExportFlushRows 每写入多少行向客户端刷新一次
+	csvExportFlushRows = 100
+)
+
+// answerSheetCSVHeader 答卷 CSV 表头，answers 列为答案列表的 JSON，与 export 命令导出的 CSV 一致
+var answerSheetCSVHeader = []string{
+	"id", "questionnaire_code", "questionnaire_version", "title", "score",
+	"writer_id", "testee_id", "created_at", "answers",
+}
+
+// answerSheetCSVAnswer CSV answers 列中的答案
+type answerSheetCSVAnswer struct {
+	QuestionCode string  `json:"question_code"`
+	QuestionType string  `json:"question_type"`
+	Score        float64 `json:"score"`
+	Value        any     `json:"value"`
+}
+
+// AnswerSheetExportHandler 答卷导出处理器
+type AnswerSheetExportHandler struct {
+	*BaseHandler
+	exporter port.AnswerSheetExporter
+}
+
+// NewAnswerSheetExportHandler 创建答卷导出处理器
+func NewAnswerSheetExportHandler(exporter port.AnswerSheetExporter) *AnswerSheetExportHandler {
+	return &AnswerSheetExportHandler{
+		BaseHandler: &BaseHandler{},
+		exporter:    exporter,
+	}
+}
+
+// ExportCSV 以分块传输流式导出问卷的答卷
+// @Summary 导出问卷答卷 CSV
+// @Description 按游标逐条读取答卷并以分块传输编码写出 CSV，每 100 行刷新一次，不在内存中缓存全部答卷；客户端断开后停止查询
+// @Tags questionnaire
+// @Produce text/csv
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param code path string true "问卷编码"
+// @Param from query string false "起始日期（YYYY-MM-DD）"
+// @Param to query string false "截止日期（YYYY-MM-DD，含当天）"
+// @Success 200 {file} file "CSV 文件"
+// @Router /v1/questionnaires/{code}/answersheets.csv [get]
+func (h *AnswerSheetExportHandler) ExportCSV(c *gin.Context) {
+	var req request.ScoringReportRequest
+	if err := h.BindQuery(c, &req); err != nil {
+		return
+	}
+
+	to := req.To
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	code := h.GetPathParam(c, "code")
+	header := c.Writer.Header()
+	header.Set("Content-Type", "text/csv; charset=utf-8")
+	header.Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-answersheets.csv"`, code))
+	header.Set("Transfer-Encoding", "chunked")
+
+	stream := newCSVStream(c.Writer)
+	ctx := c.Request.Context()
+	filter := dto.AnswerSheetExportFilterDTO{QuestionnaireCode: code, From: req.From, To: to}
+	err := h.exporter.Export(ctx, filter, func(record dto.AnswerSheetExportDTO) error {
+		// 客户端断开后停止写出，导出器随即关闭游标
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return stream.write(record)
+	})
+	if err == nil {
+		err = stream.close()
+	}
+	if err == nil {
+		return
+	}
+
+	if !c.Writer.Written() {
+		// 尚未写出任何数据，仍可返回错误响应
+		header.Del("Content-Type")
+		header.Del("Content-Disposition")
+		header.Del("Transfer-Encoding")
+		h.ErrorResponse(c, err)
+		return
+	}
+	// 响应已开始，只能中断传输
+	log.Warnf("流式导出答卷 CSV 中断，问卷编码: %s, 已写出: %d 行, 错误: %v", code, stream.rows, err)
+}
+
+// csvStream 逐行写出 CSV，每 csvExportFlushRows 行刷新到客户端
+type csvStream struct {
+	w             http.ResponseWriter
+	buf           *bufio.Writer
+	csv           *csv.Writer
+	rows          int
+	headerWritten bool
+}
+
+// newCSVStream 创建 CSV 流，CSV 写入器经 csvExportBufferSize 字节的缓冲写入响应
+func newCSVStream(w http.ResponseWriter) *csvStream {
+	buf := bufio.NewWriterSize(w, csvExportBufferSize)
+	return &csvStream{w: w, buf: buf, csv: csv.NewWriter(buf)}
+}
+
+// write 写入一条答卷，首次写入前写出表头
+func (s *csvStream) write(record dto.AnswerSheetExportDTO) error {
+	if err := s.writeHeader(); err != nil {
+		return err
+	}
+
+	row, err := answerSheetCSVRow(record)
+	if err != nil {
+		return err
+	}
+	if err := s.csv.Write(row); err != nil {
+		return err
+	}
+
+	s.rows++
+	if s.rows%csvExportFlushRows == 0 {
+		return s.flush()
+	}
+	return nil
+}
+
+// close 刷新剩余的行，无答卷时仍输出表头
+func (s *csvStream) close() error {
+	if err := s.writeHeader(); err != nil {
+		return err
+	}
+	return s.flush()
+}
+
+// writeHeader 写入表头
+func (s *csvStream) writeHeader() error {
+	if s.headerWritten {
+		return nil
+	}
+	s.headerWritten = true
+	return s.csv.Write(answerSheetCSVHeader)
+}
+
+// flush 依次刷新 CSV 写入器、缓冲和响应
+func (s *csvStream) flush() error {
+	s.csv.Flush()
+	if err := s.csv.Error(); err != nil {
+		return err
+	}
+	if err := s.buf.Flush(); err != nil {
+		return err
+	}
+	if flusher, ok := s.w.(http.Flusher); ok {
+		flusher.Flush()
+	}
+	return nil
+}
+
+// answerSheetCSVRow 将导出记录转换为 CSV 行
+func answerSheetCSVRow(record dto.AnswerSheetExportDTO) ([]string, error) {
+	sheet := record.AnswerSheet
+	answers := make([]answerSheetCSVAnswer, 0, len(sheet.Answers))
+	for _, a := range sheet.Answers {
+		answers = append(answers, answerSheetCSVAnswer{
+			QuestionCode: a.QuestionCode,
+			QuestionType: a.QuestionType,
+			Score:        a.Score,
+			Value:        a.Value,
+		})
+	}
+	answersJSON, err := json.Marshal(answers)
+	if err != nil {
+		return nil, err
+	}
+
+	return []string{
+		strconv.FormatUint(sheet.ID.Value(), 10),
+		sheet.QuestionnaireCode,
+		sheet.QuestionnaireVersion,
+		sheet.Title,
+		strconv.FormatFloat(sheet.Score, 'f', -1, 64),
+		strconv.FormatUint(sheet.WriterID, 10),
+		strconv.FormatUint(sheet.TesteeID, 10),
+		record.CreatedAt.Format(time.RFC3339),
+		string(answersJSON),
+	}, nil
+}
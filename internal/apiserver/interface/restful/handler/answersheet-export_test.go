@@ -0,0 +1,160 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	v1 "github.com/yshujie/questionnaire-scale/pkg/meta/v1"
+)
+
+// generatedExporter 按游标方式逐条生成 total 条答卷，回调返回错误时停止
+type generatedExporter struct {
+	total   int
+	handled int
+	// onRecord 每条答卷回调后执行，可为空
+	onRecord func(n int)
+}
+
+func (e *generatedExporter) Export(ctx context.Context, filter dto.AnswerSheetExportFilterDTO, handle func(record dto.AnswerSheetExportDTO) error) error {
+	createdAt := time.Date(2025, 3, 1, 8, 0, 0, 0, time.UTC)
+	for i := 1; i <= e.total; i++ {
+		record := dto.AnswerSheetExportDTO{
+			AnswerSheet: dto.AnswerSheetDTO{
+				ID:                   v1.NewID(uint64(i)),
+				QuestionnaireCode:    filter.QuestionnaireCode,
+				QuestionnaireVersion: "1.0.1",
+				Title:                "PHQ-9",
+				Score:                12,
+				WriterID:             1,
+				TesteeID:             uint64(i),
+				Answers: []dto.AnswerDTO{
+					{QuestionCode: "Q1", QuestionType: "Radio", Score: 2, Value: "B"},
+					{QuestionCode: "Q2", QuestionType: "Text", Value: "最近两周睡眠不好"},
+				},
+			},
+			CreatedAt: createdAt,
+		}
+		if err := handle(record); err != nil {
+			return err
+		}
+		e.handled++
+		if e.onRecord != nil {
+			e.onRecord(i)
+		}
+	}
+	return nil
+}
+
+// discardResponseWriter 丢弃响应体，只统计行数和刷新次数，避免测试本身缓存导出数据
+type discardResponseWriter struct {
+	header  http.Header
+	status  int
+	lines   int
+	flushes int
+}
+
+func newDiscardResponseWriter() *discardResponseWriter {
+	return &discardResponseWriter{header: make(http.Header)}
+}
+
+func (w *discardResponseWriter) Header() http.Header { return w.header }
+
+func (w *discardResponseWriter) WriteHeader(status int) { w.status = status }
+
+func (w *discardResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	w.lines += bytes.Count(p, []byte("\n"))
+	return len(p), nil
+}
+
+func (w *discardResponseWriter) Flush() { w.flushes++ }
+
+// newExportEngine 创建注册了答卷导出路由的引擎
+func newExportEngine(exporter *generatedExporter) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/questionnaires/:code/answersheets.csv", NewAnswerSheetExportHandler(exporter).ExportCSV)
+	return engine
+}
+
+func TestAnswerSheetExportHandler_ExportCSV_StreamsLargeExport(t *testing.T) {
+	const total = 100000
+	const maxHeapInuse = 50 << 20
+
+	// 进程常驻内存以堆占用近似，导出期间定期采样峰值
+	var peak uint64
+	sample := func() {
+		var stats runtime.MemStats
+		runtime.ReadMemStats(&stats)
+		if stats.HeapInuse > peak {
+			peak = stats.HeapInuse
+		}
+	}
+	exporter := &generatedExporter{total: total, onRecord: func(n int) {
+		if n%5000 == 0 {
+			sample()
+		}
+	}}
+	w := newDiscardResponseWriter()
+	req := httptest.NewRequest(http.MethodGet, "/questionnaires/PHQ9/answersheets.csv", nil)
+
+	runtime.GC()
+	newExportEngine(exporter).ServeHTTP(w, req)
+	sample()
+
+	if w.status != http.StatusOK {
+		t.Fatalf("status = %d, want 200", w.status)
+	}
+	if got := w.header.Get("Transfer-Encoding"); got != "chunked" {
+		t.Errorf("Transfer-Encoding = %q, want chunked", got)
+	}
+	if got := w.header.Get("Content-Type"); got != "text/csv; charset=utf-8" {
+		t.Errorf("Content-Type = %q, want text/csv; charset=utf-8", got)
+	}
+	if w.lines != total+1 {
+		t.Errorf("CSV lines = %d, want header and %d rows", w.lines, total)
+	}
+	if w.flushes < total/csvExportFlushRows {
+		t.Errorf("flushes = %d, want at least one every %d rows", w.flushes, csvExportFlushRows)
+	}
+	if peak >= maxHeapInuse {
+		t.Errorf("peak heap in use = %d MB, want below %d MB", peak>>20, maxHeapInuse>>20)
+	}
+}
+
+func TestAnswerSheetExportHandler_ExportCSV_StopsWhenClientDisconnects(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	exporter := &generatedExporter{total: 1000, onRecord: func(n int) {
+		if n == 250 {
+			cancel()
+		}
+	}}
+	w := newDiscardResponseWriter()
+	req := httptest.NewRequest(http.MethodGet, "/questionnaires/PHQ9/answersheets.csv", nil).WithContext(ctx)
+	newExportEngine(exporter).ServeHTTP(w, req)
+
+	if exporter.handled != 250 {
+		t.Errorf("exported records = %d, want export to stop at 250 after the client disconnected", exporter.handled)
+	}
+}
+
+func BenchmarkAnswerSheetExportHandler_ExportCSV(b *testing.B) {
+	engine := newExportEngine(&generatedExporter{total: 100000})
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		req := httptest.NewRequest(http.MethodGet, "/questionnaires/PHQ9/answersheets.csv", nil)
+		engine.ServeHTTP(newDiscardResponseWriter(), req)
+	}
+}
@@ -1,10 +1,13 @@
 package handler
 
 import (
+	"fmt"
+	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/mapper"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
@@ -14,21 +17,31 @@ import (
 // AnswerSheetHandler 答卷处理器
 type AnswerSheetHandler struct {
 	*BaseHandler
-	saver   port.AnswerSheetSaver
-	queryer port.AnswerSheetQueryer
-	mapper  *mapper.AnswerSheetMapper
+	saver     port.AnswerSheetSaver
+	queryer   port.AnswerSheetQueryer
+	submitter port.AnswerSheetSubmitter
+	fhir      port.AnswerSheetFHIRConverter
+	mapper    *mapper.AnswerSheetMapper
+	activity  *ActivityRecorder
 }
 
 // NewAnswerSheetHandler 创建答卷处理器
-func NewAnswerSheetHandler(saver port.AnswerSheetSaver, queryer port.AnswerSheetQueryer) *AnswerSheetHandler {
+func NewAnswerSheetHandler(saver port.AnswerSheetSaver, queryer port.AnswerSheetQueryer, submitter port.AnswerSheetSubmitter, fhir port.AnswerSheetFHIRConverter) *AnswerSheetHandler {
 	return &AnswerSheetHandler{
 		BaseHandler: &BaseHandler{},
 		saver:       saver,
 		queryer:     queryer,
+		submitter:   submitter,
+		fhir:        fhir,
 		mapper:      mapper.NewAnswerSheetMapper(),
 	}
 }
 
+// SetActivityRecorder 设置用户活动记录器，提交答卷时记录操作用户
+func (h *AnswerSheetHandler) SetActivityRecorder(recorder *ActivityRecorder) {
+	h.activity = recorder
+}
+
 // Save 保存答卷
 // @Summary 保存答卷
 // @Description 保存答卷
@@ -52,12 +65,41 @@ func (h *AnswerSheetHandler) Save(c *gin.Context) {
 		h.ErrorResponse(c, err)
 		return
 	}
+	h.activity.Record(c, user.ActivityAnswerSheetSubmit, fmt.Sprintf("提交问卷 %s 的答卷 %d", req.QuestionnaireCode, savedDTO.ID))
 
 	h.SuccessResponse(c, gin.H{
 		"id": savedDTO.ID,
 	})
 }
 
+// SubmitAndInterpret 提交答卷并立即生成解读报告
+// @Summary 提交答卷并解读
+// @Description 校验并保存答卷，计算得分后立即生成解读报告；任一步骤失败时答卷不会被保留
+// @Tags answersheet
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param request body viewmodel.SaveAnswerSheetRequest true "保存答卷请求"
+// @Success 200 {object} response.Response{data=viewmodel.InterpretReportViewModel}
+// @Router /v1/answersheets/submit-and-interpret [post]
+func (h *AnswerSheetHandler) SubmitAndInterpret(c *gin.Context) {
+	var req viewmodel.SaveAnswerSheetRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.ErrorResponse(c, errors.WrapC(err, code.ErrBind, "参数绑定失败"))
+		return
+	}
+
+	dto := h.mapper.ToAnswerSheetDTO(req)
+	report, err := h.submitter.SubmitAndInterpret(c.Request.Context(), dto)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+	h.activity.Record(c, user.ActivityAnswerSheetSubmit, "提交问卷 "+req.QuestionnaireCode+" 的答卷并解读")
+
+	h.SuccessResponse(c, h.mapper.ToInterpretReportViewModel(*report))
+}
+
 // List 获取答卷列表
 // @Summary 获取答卷列表
 // @Description 获取答卷列表
@@ -124,3 +166,85 @@ func (h *AnswerSheetHandler) Get(c *gin.Context) {
 	vm := h.mapper.ToAnswerSheetDetailViewModel(*detail)
 	h.SuccessResponse(c, vm)
 }
+
+// Progress 获取答卷作答进度
+// @Summary 获取答卷作答进度
+// @Description 获取答卷的整体作答进度及各段落作答进度
+// @Tags answersheet
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path integer true "答卷ID"
+// @Success 200 {object} response.Response{data=viewmodel.ProgressReportViewModel}
+// @Router /v1/answersheets/{id}/progress [get]
+func (h *AnswerSheetHandler) Progress(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.ErrorResponse(c, errors.WithCode(code.ErrValidation, "无效的答卷ID"))
+		return
+	}
+
+	report, err := h.queryer.GetAnswerSheetProgress(c.Request.Context(), id)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, h.mapper.ToProgressReportViewModel(*report))
+}
+
+// ImportFHIR 导入 FHIR 答卷
+// @Summary 导入 FHIR 答卷
+// @Description 导入 FHIR R4 QuestionnaireResponse 资源，按引用的问卷校验后保存为答卷
+// @Tags answersheet
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param request body object true "FHIR QuestionnaireResponse"
+// @Success 200 {object} response.Response
+// @Router /v1/answersheets/fhir [post]
+func (h *AnswerSheetHandler) ImportFHIR(c *gin.Context) {
+	data, err := c.GetRawData()
+	if err != nil {
+		h.ErrorResponse(c, errors.WrapC(err, code.ErrBind, "读取请求体失败"))
+		return
+	}
+
+	sheet, err := h.fhir.ImportFHIRResponse(c.Request.Context(), data)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, gin.H{
+		"id": sheet.GetID(),
+	})
+}
+
+// ExportFHIR 导出 FHIR 答卷
+// @Summary 导出 FHIR 答卷
+// @Description 将答卷导出为 FHIR R4 QuestionnaireResponse 资源
+// @Tags answersheet
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path integer true "答卷ID"
+// @Success 200 {object} object
+// @Router /v1/answersheets/{id}/fhir [get]
+func (h *AnswerSheetHandler) ExportFHIR(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.ErrorResponse(c, errors.WithCode(code.ErrValidation, "无效的答卷ID"))
+		return
+	}
+
+	data, err := h.fhir.ExportFHIRResponse(c.Request.Context(), id)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, fhirContentType, data)
+}
+
+// fhirContentType FHIR JSON 资源的媒体类型
+const fhirContentType = "application/fhir+json"
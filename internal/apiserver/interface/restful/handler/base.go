@@ -1,14 +1,15 @@
 package handler
 
 import (
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
 
+	userPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
-	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 // BaseHandler 基础Handler结构
@@ -20,30 +21,14 @@ func NewBaseHandler() *BaseHandler {
 	return &BaseHandler{}
 }
 
-// Response 统一响应结构
-type Response struct {
-	Code      int         `json:"code"`
-	Message   string      `json:"message"`
-	Data      interface{} `json:"data,omitempty"`
-	Reference string      `json:"reference,omitempty"`
-}
-
 // SuccessResponse 成功响应
 func (h *BaseHandler) SuccessResponse(c *gin.Context, data interface{}) {
-	c.JSON(http.StatusOK, Response{
-		Code:    code.ErrSuccess,
-		Message: "操作成功",
-		Data:    data,
-	})
+	envelope.WriteSuccess(c, "操作成功", data)
 }
 
 // SuccessResponseWithMessage 带消息的成功响应
 func (h *BaseHandler) SuccessResponseWithMessage(c *gin.Context, message string, data interface{}) {
-	c.JSON(http.StatusOK, Response{
-		Code:    code.ErrSuccess,
-		Message: message,
-		Data:    data,
-	})
+	envelope.WriteSuccess(c, message, data)
 }
 
 // ErrorResponse 智能错误响应 - 根据错误类型自动选择合适的HTTP状态码和错误码
@@ -53,33 +38,7 @@ func (h *BaseHandler) ErrorResponse(c *gin.Context, err error) {
 		return
 	}
 
-	// 记录错误日志
-	log.Errorf("HTTP Handler Error: %+v", err)
-
-	var httpStatus int
-	var errorCode int
-	var message string
-	var reference string
-
-	// 尝试解析为内部错误码
-	if coder := errors.ParseCoder(err); coder != nil {
-		httpStatus = coder.HTTPStatus()
-		errorCode = coder.Code()
-		message = coder.String()
-		reference = coder.Reference()
-	} else {
-		// 处理未知错误
-		httpStatus = http.StatusInternalServerError
-		errorCode = code.ErrDatabase
-		message = "内部服务器错误"
-	}
-
-	// 发送响应
-	c.JSON(httpStatus, Response{
-		Code:      errorCode,
-		Message:   message,
-		Reference: reference,
-	})
+	envelope.WriteError(c, err)
 }
 
 // ErrorResponseWithCode 直接使用错误码的错误响应
@@ -203,3 +162,15 @@ func (h *BaseHandler) GetUserID(c *gin.Context) (string, bool) {
 
 	return "", false
 }
+
+// CurrentUserID 按认证中间件设置的用户名查询当前登录用户的ID
+func (h *BaseHandler) CurrentUserID(c *gin.Context, userQueryer userPort.UserQueryer) (uint64, error) {
+	u, err := userQueryer.GetUserByUsername(c.Request.Context(), c.GetString(middleware.UsernameKey))
+	if err != nil {
+		return 0, err
+	}
+	if u == nil {
+		return 0, errors.WithCode(code.ErrUserNotFound, "用户不存在")
+	}
+	return u.ID().Value(), nil
+}
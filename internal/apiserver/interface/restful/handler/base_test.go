@@ -0,0 +1,131 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestBaseHandler_ResponseEnvelope(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.RequestID())
+
+	h := NewBaseHandler()
+	engine.GET("/success", func(c *gin.Context) {
+		h.SuccessResponse(c, gin.H{"id": 1})
+	})
+	engine.GET("/not-found", func(c *gin.Context) {
+		h.ErrorResponse(c, errors.WithCode(code.ErrUserNotFound, "user 1 not found"))
+	})
+	engine.GET("/unknown", func(c *gin.Context) {
+		h.ErrorResponse(c, errors.New("connection reset"))
+	})
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantCode   int
+		wantData   bool
+	}{
+		{path: "/success", wantStatus: http.StatusOK, wantCode: code.ErrSuccess, wantData: true},
+		{path: "/not-found", wantStatus: http.StatusNotFound, wantCode: code.ErrUserNotFound},
+		{path: "/unknown", wantStatus: http.StatusInternalServerError, wantCode: code.ErrUnknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set(middleware.XRequestIDKey, "req-"+tt.path)
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+
+			var body map[string]json.RawMessage
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+			}
+			for _, key := range []string{"code", "message", "data", "request_id"} {
+				if _, ok := body[key]; !ok {
+					t.Errorf("response %s has no %q field", rec.Body.String(), key)
+				}
+			}
+
+			var resp envelope.Response
+			if err := json.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+			}
+			if resp.Code != tt.wantCode {
+				t.Errorf("code = %d, want %d", resp.Code, tt.wantCode)
+			}
+			if resp.RequestID != "req-"+tt.path {
+				t.Errorf("request_id = %q, want %q", resp.RequestID, "req-"+tt.path)
+			}
+			if (resp.Data != nil) != tt.wantData {
+				t.Errorf("data = %v, want data present = %v", resp.Data, tt.wantData)
+			}
+		})
+	}
+}
+
+// fakeUserQueryer 按用户名查找固定用户的用户查询服务
+type fakeUserQueryer struct {
+	user *user.User
+}
+
+func (q *fakeUserQueryer) GetUser(ctx context.Context, id uint64) (*user.User, error) {
+	return q.user, nil
+}
+
+func (q *fakeUserQueryer) GetUserByUsername(ctx context.Context, username string) (*user.User, error) {
+	if q.user == nil || q.user.Username() != username {
+		return nil, nil
+	}
+	return q.user, nil
+}
+
+func (q *fakeUserQueryer) ListUsers(ctx context.Context, page, pageSize int) ([]*user.User, int64, error) {
+	return nil, 0, nil
+}
+
+func TestBaseHandler_CurrentUserID(t *testing.T) {
+	queryer := &fakeUserQueryer{user: user.NewUserBuilder().WithID(user.NewUserID(7)).WithUsername("alice").Build()}
+	h := NewBaseHandler()
+
+	for _, tt := range []struct {
+		username string
+		wantID   uint64
+		wantCode int
+	}{
+		{username: "alice", wantID: 7},
+		{username: "bob", wantCode: code.ErrUserNotFound},
+	} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+		c.Set(middleware.UsernameKey, tt.username)
+
+		id, err := h.CurrentUserID(c, queryer)
+		if tt.wantCode != 0 {
+			if !errors.IsCode(err, tt.wantCode) {
+				t.Errorf("CurrentUserID(%s) error = %v, want code %d", tt.username, err, tt.wantCode)
+			}
+			continue
+		}
+		if err != nil || id != tt.wantID {
+			t.Errorf("CurrentUserID(%s) = %d, %v, want %d", tt.username, id, err, tt.wantID)
+		}
+	}
+}
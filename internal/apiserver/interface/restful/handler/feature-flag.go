@@ -0,0 +1,44 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/featureflag"
+)
+
+// FeatureFlagHandler 功能开关处理器
+type FeatureFlagHandler struct {
+	*BaseHandler
+	flags *featureflag.Flags
+}
+
+// NewFeatureFlagHandler 创建功能开关处理器
+func NewFeatureFlagHandler(flags *featureflag.Flags) *FeatureFlagHandler {
+	return &FeatureFlagHandler{
+		BaseHandler: &BaseHandler{},
+		flags:       flags,
+	}
+}
+
+// List 获取功能开关
+// @Summary 获取功能开关
+// @Description 获取当前实例加载的所有功能开关及其灰度百分比
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=[]viewmodel.FeatureFlagViewModel}
+// @Router /v1/admin/feature-flags [get]
+func (h *FeatureFlagHandler) List(c *gin.Context) {
+	flags := h.flags.List()
+	vms := make([]viewmodel.FeatureFlagViewModel, 0, len(flags))
+	for _, flag := range flags {
+		vms = append(vms, viewmodel.FeatureFlagViewModel{
+			Name:       flag.Name,
+			Enabled:    flag.Enabled,
+			Percentage: flag.Percentage,
+		})
+	}
+
+	h.SuccessResponse(c, vms)
+}
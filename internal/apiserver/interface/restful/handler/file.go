@@ -0,0 +1,67 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// FileHandler 文件处理器
+type FileHandler struct {
+	*BaseHandler
+	uploader port.FileUploader
+}
+
+// NewFileHandler 创建文件处理器
+func NewFileHandler(uploader port.FileUploader) *FileHandler {
+	return &FileHandler{
+		BaseHandler: &BaseHandler{},
+		uploader:    uploader,
+	}
+}
+
+// Upload 上传文件
+// @Summary 上传文件
+// @Description 提交答卷前预上传文件上传题的附件，返回的文件引用用于答卷提交
+// @Tags file
+// @Accept multipart/form-data
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param file formData file true "文件"
+// @Success 200 {object} response.Response{data=viewmodel.FileReferenceDTO}
+// @Router /v1/files [post]
+func (h *FileHandler) Upload(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		h.ErrorResponse(c, errors.WrapC(err, code.ErrBind, "参数绑定失败"))
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		h.ErrorResponse(c, errors.WrapC(err, code.ErrBind, "读取文件失败"))
+		return
+	}
+	defer file.Close()
+
+	meta := port.FileMeta{
+		FileName:  fileHeader.Filename,
+		MimeType:  fileHeader.Header.Get("Content-Type"),
+		SizeBytes: fileHeader.Size,
+	}
+
+	ref, err := h.uploader.UploadFile(c.Request.Context(), file, meta)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, viewmodel.FileReferenceDTO{
+		FileName:   ref.FileName,
+		MimeType:   ref.MimeType,
+		StorageKey: ref.StorageKey,
+		SizeBytes:  ref.SizeBytes,
+	})
+}
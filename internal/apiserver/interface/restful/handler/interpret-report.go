@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/hl7"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/interpret-report/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// InterpretReportHandler 解读报告处理器
+type InterpretReportHandler struct {
+	*BaseHandler
+	hl7Exporter port.InterpretReportHL7Exporter
+}
+
+// NewInterpretReportHandler 创建解读报告处理器
+func NewInterpretReportHandler(hl7Exporter port.InterpretReportHL7Exporter) *InterpretReportHandler {
+	return &InterpretReportHandler{
+		BaseHandler: &BaseHandler{},
+		hl7Exporter: hl7Exporter,
+	}
+}
+
+// ExportHL7 导出解读报告为 HL7 消息
+// @Summary 导出 HL7 ORU 消息
+// @Description 将答卷对应的解读报告导出为 HL7 v2 ORU^R01 消息，各因子得分作为 NM 类型的 OBX 段
+// @Tags admin
+// @Accept json
+// @Produce plain
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path integer true "答卷ID"
+// @Param body body request.ExportHL7Request false "患者信息"
+// @Success 200 {string} string "HL7 v2 消息"
+// @Router /v1/admin/reports/{id}/hl7 [post]
+func (h *InterpretReportHandler) ExportHL7(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.ErrorResponse(c, errors.WithCode(code.ErrValidation, "无效的答卷ID"))
+		return
+	}
+
+	var req request.ExportHL7Request
+	if c.Request.ContentLength != 0 {
+		if err := h.BindJSON(c, &req); err != nil {
+			return
+		}
+	}
+
+	patient := hl7.PatientInfo{
+		ID:                 req.PatientID,
+		AssigningAuthority: req.AssigningAuthority,
+		FamilyName:         req.FamilyName,
+		GivenName:          req.GivenName,
+		Gender:             req.Gender,
+	}
+	if req.BirthDate != "" {
+		patient.BirthDate, _ = time.Parse("2006-01-02", req.BirthDate)
+	}
+
+	message, err := h.hl7Exporter.ExportHL7(c.Request.Context(), id, patient)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "text/plain; charset=utf-8", []byte(message))
+}
@@ -0,0 +1,91 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/scheduler"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// JobHandler 定时任务处理器
+type JobHandler struct {
+	*BaseHandler
+	scheduler *scheduler.CronScheduler
+}
+
+// NewJobHandler 创建定时任务处理器
+func NewJobHandler(scheduler *scheduler.CronScheduler) *JobHandler {
+	return &JobHandler{
+		BaseHandler: &BaseHandler{},
+		scheduler:   scheduler,
+	}
+}
+
+// List 获取定时任务列表
+// @Summary 获取定时任务列表
+// @Description 获取所有已注册的定时任务及其最近一次运行时间和错误
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=[]viewmodel.JobViewModel}
+// @Router /v1/admin/jobs [get]
+func (h *JobHandler) List(c *gin.Context) {
+	jobs := h.scheduler.Jobs()
+
+	vms := make([]viewmodel.JobViewModel, 0, len(jobs))
+	for _, job := range jobs {
+		vm := viewmodel.JobViewModel{
+			Name:      job.Name,
+			Schedule:  job.Schedule,
+			LastError: job.LastError,
+		}
+		if job.LastRunAt != nil {
+			vm.LastRunAt = job.LastRunAt.Format(time.RFC3339)
+		}
+		if job.NextRunAt != nil {
+			vm.NextRunAt = job.NextRunAt.Format(time.RFC3339)
+		}
+		vms = append(vms, vm)
+	}
+
+	h.SuccessResponse(c, vms)
+}
+
+// LockStatus 获取定时任务的分布式锁状态
+// @Summary 获取定时任务锁状态
+// @Description 查询定时任务当前是否被某个实例持有锁，以及持有者和剩余过期时间
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param name path string true "任务名称"
+// @Success 200 {object} response.Response{data=viewmodel.JobLockStatusViewModel}
+// @Router /v1/admin/jobs/{name}/lock-status [get]
+func (h *JobHandler) LockStatus(c *gin.Context) {
+	name := h.GetPathParam(c, "name")
+	if !h.scheduler.HasJob(name) {
+		h.ErrorResponse(c, errors.WithCode(code.ErrPageNotFound, "定时任务不存在: %s", name))
+		return
+	}
+
+	status, err := h.scheduler.LockStatus(c.Request.Context(), name)
+	if err != nil {
+		h.ErrorResponse(c, errors.WrapC(err, code.ErrDatabase, "查询任务锁状态失败"))
+		return
+	}
+
+	vm := viewmodel.JobLockStatusViewModel{
+		Name:        name,
+		LockEnabled: status != nil,
+	}
+	if status != nil {
+		vm.Key = status.Key
+		vm.Locked = status.Locked
+		vm.LockOwner = status.LockOwner
+		vm.TTLSeconds = int64(status.TTL / time.Second)
+	}
+
+	h.SuccessResponse(c, vm)
+}
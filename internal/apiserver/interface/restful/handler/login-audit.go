@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+)
+
+// LoginAuditHandler 登录审计处理器
+type LoginAuditHandler struct {
+	*BaseHandler
+	loginAuditor port.LoginAuditor
+}
+
+// NewLoginAuditHandler 创建登录审计处理器
+func NewLoginAuditHandler(loginAuditor port.LoginAuditor) *LoginAuditHandler {
+	return &LoginAuditHandler{
+		BaseHandler:  &BaseHandler{},
+		loginAuditor: loginAuditor,
+	}
+}
+
+// ListByUsername 查询用户的登录记录
+// @Summary 查询用户登录记录
+// @Description 分页查询用户的认证尝试（含成功与失败），按时间倒序
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param user path string true "用户名"
+// @Param page query int true "页码"
+// @Param page_size query int true "每页数量"
+// @Success 200 {object} response.Response{data=viewmodel.LoginAuditListViewModel}
+// @Router /v1/admin/users/{user}/login-audits [get]
+func (h *LoginAuditHandler) ListByUsername(c *gin.Context) {
+	var req request.ListLoginAuditsRequest
+	if err := h.BindQuery(c, &req); err != nil {
+		return
+	}
+
+	audits, total, err := h.loginAuditor.ListByUsername(c.Request.Context(), h.GetPathParam(c, "user"), req.Page, req.PageSize)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	items := make([]viewmodel.LoginAuditViewModel, 0, len(audits))
+	for _, audit := range audits {
+		items = append(items, viewmodel.LoginAuditViewModel{
+			Username:  audit.Username,
+			IP:        audit.IP,
+			UserAgent: audit.UserAgent,
+			Method:    string(audit.Method),
+			Outcome:   string(audit.Outcome),
+			Reason:    audit.Reason,
+			CreatedAt: audit.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	h.SuccessResponse(c, viewmodel.LoginAuditListViewModel{
+		Items:      items,
+		TotalCount: total,
+		Page:       req.Page,
+		PageSize:   req.PageSize,
+	})
+}
@@ -0,0 +1,66 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// MaintenanceHandler 维护模式处理器
+type MaintenanceHandler struct {
+	*BaseHandler
+	mode *middleware.MaintenanceMode
+}
+
+// NewMaintenanceHandler 创建维护模式处理器
+func NewMaintenanceHandler(mode *middleware.MaintenanceMode) *MaintenanceHandler {
+	return &MaintenanceHandler{
+		BaseHandler: &BaseHandler{},
+		mode:        mode,
+	}
+}
+
+// Get 获取维护模式状态
+// @Summary 获取维护模式状态
+// @Description 获取当前实例是否处于维护模式
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Success 200 {object} response.Response{data=viewmodel.MaintenanceViewModel}
+// @Router /v1/admin/maintenance [get]
+func (h *MaintenanceHandler) Get(c *gin.Context) {
+	h.SuccessResponse(c, h.viewModel())
+}
+
+// Update 开启或关闭维护模式
+// @Summary 切换维护模式
+// @Description 开启后当前实例的修改类请求返回 503，读请求不受影响
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param body body request.UpdateMaintenanceRequest true "维护模式开关"
+// @Success 200 {object} response.Response{data=viewmodel.MaintenanceViewModel}
+// @Router /v1/admin/maintenance [put]
+func (h *MaintenanceHandler) Update(c *gin.Context) {
+	var req request.UpdateMaintenanceRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+
+	h.mode.SetEnabled(*req.Enabled)
+	log.Infof("Maintenance mode set to %t by %s", *req.Enabled, c.GetString(middleware.UsernameKey))
+
+	h.SuccessResponse(c, h.viewModel())
+}
+
+// viewModel 转换维护模式状态
+func (h *MaintenanceHandler) viewModel() viewmodel.MaintenanceViewModel {
+	return viewmodel.MaintenanceViewModel{
+		Enabled:           h.mode.Enabled(),
+		RetryAfterSeconds: int64(h.mode.RetryAfter().Seconds()),
+	}
+}
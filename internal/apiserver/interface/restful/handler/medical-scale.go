@@ -1,14 +1,13 @@
 package handler
 
 import (
-	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/medical-scale/port"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
-	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/response"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
 	errorCode "github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
@@ -17,9 +16,10 @@ import (
 // MedicalScaleHandler 医学量表处理器
 type MedicalScaleHandler struct {
 	BaseHandler
-	creator port.MedicalScaleCreator
-	queryer port.MedicalScaleQueryer
-	editor  port.MedicalScaleEditor
+	creator   port.MedicalScaleCreator
+	queryer   port.MedicalScaleQueryer
+	editor    port.MedicalScaleEditor
+	analytics port.MedicalScaleAnalytics
 }
 
 // NewMedicalScaleHandler 创建医学量表处理器
@@ -27,11 +27,13 @@ func NewMedicalScaleHandler(
 	creator port.MedicalScaleCreator,
 	queryer port.MedicalScaleQueryer,
 	editor port.MedicalScaleEditor,
+	analytics port.MedicalScaleAnalytics,
 ) *MedicalScaleHandler {
 	return &MedicalScaleHandler{
-		creator: creator,
-		queryer: queryer,
-		editor:  editor,
+		creator:   creator,
+		queryer:   queryer,
+		editor:    editor,
+		analytics: analytics,
 	}
 }
 
@@ -42,7 +44,7 @@ func NewMedicalScaleHandler(
 // @Accept json
 // @Produce json
 // @Param request body request.CreateMedicalScaleRequest true "创建医学量表请求"
-// @Success 200 {object} response.MedicalScaleResponse
+// @Success 200 {object} response.Response{data=viewmodel.MedicalScaleVM}
 // @Router /api/v1/medical-scales [post]
 func (h *MedicalScaleHandler) Create(c *gin.Context) {
 	var req request.CreateMedicalScaleRequest
@@ -53,9 +55,11 @@ func (h *MedicalScaleHandler) Create(c *gin.Context) {
 
 	// 创建医学量表DTO
 	medicalScaleDTO := &dto.MedicalScaleDTO{
-		Code:              req.Code,
-		Title:             req.Title,
-		QuestionnaireCode: req.QuestionnaireCode,
+		Code:               req.Code,
+		Title:              req.Title,
+		QuestionnaireCode:  req.QuestionnaireCode,
+		ScoringConfig:      h.convertScoringConfigRequestToDTO(req.ScoringConfig),
+		RuleStrategyConfig: req.RuleStrategyConfig,
 	}
 
 	// 创建医学量表
@@ -65,9 +69,7 @@ func (h *MedicalScaleHandler) Create(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, &response.MedicalScaleResponse{
-		Data: h.convertDTOToVM(scale),
-	})
+	h.SuccessResponse(c, h.convertDTOToVM(scale))
 }
 
 // UpdateBaseInfo 更新医学量表基础信息
@@ -78,7 +80,7 @@ func (h *MedicalScaleHandler) Create(c *gin.Context) {
 // @Produce json
 // @Param code path string true "医学量表代码"
 // @Param request body request.UpdateMedicalScaleRequest true "更新医学量表请求"
-// @Success 200 {object} response.MedicalScaleResponse
+// @Success 200 {object} response.Response{data=viewmodel.MedicalScaleVM}
 // @Router /api/v1/medical-scales/{code} [put]
 func (h *MedicalScaleHandler) UpdateBaseInfo(c *gin.Context) {
 	code := c.Param("code")
@@ -95,9 +97,11 @@ func (h *MedicalScaleHandler) UpdateBaseInfo(c *gin.Context) {
 
 	// 创建医学量表DTO
 	medicalScaleDTO := &dto.MedicalScaleDTO{
-		Code:              code,
-		Title:             req.Title,
-		QuestionnaireCode: req.QuestionnaireCode,
+		Code:               code,
+		Title:              req.Title,
+		QuestionnaireCode:  req.QuestionnaireCode,
+		ScoringConfig:      h.convertScoringConfigRequestToDTO(req.ScoringConfig),
+		RuleStrategyConfig: req.RuleStrategyConfig,
 	}
 
 	// 更新医学量表
@@ -107,9 +111,7 @@ func (h *MedicalScaleHandler) UpdateBaseInfo(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, &response.MedicalScaleResponse{
-		Data: h.convertDTOToVM(scale),
-	})
+	h.SuccessResponse(c, h.convertDTOToVM(scale))
 }
 
 // UpdateFactor 更新医学量表因子
@@ -120,7 +122,7 @@ func (h *MedicalScaleHandler) UpdateBaseInfo(c *gin.Context) {
 // @Produce json
 // @Param code path string true "医学量表代码"
 // @Param request body request.UpdateMedicalScaleFactorRequest true "更新因子请求"
-// @Success 200 {object} response.MedicalScaleResponse
+// @Success 200 {object} response.Response{data=viewmodel.MedicalScaleVM}
 // @Router /api/v1/medical-scales/{code}/factors [put]
 func (h *MedicalScaleHandler) UpdateFactor(c *gin.Context) {
 	code := c.Param("code")
@@ -148,6 +150,7 @@ func (h *MedicalScaleHandler) UpdateFactor(c *gin.Context) {
 		factorDTO.CalculationRule = &dto.CalculationRuleDTO{
 			FormulaType: factor.CalculationRule.FormulaType,
 			SourceCodes: factor.CalculationRule.SourceCodes,
+			Threshold:   factor.CalculationRule.Threshold,
 		}
 
 		// 处理解读规则（支持多个解读规则）
@@ -178,9 +181,7 @@ func (h *MedicalScaleHandler) UpdateFactor(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, &response.MedicalScaleResponse{
-		Data: h.convertDTOToVM(scale),
-	})
+	h.SuccessResponse(c, h.convertDTOToVM(scale))
 }
 
 // Get 获取医学量表详情
@@ -190,7 +191,7 @@ func (h *MedicalScaleHandler) UpdateFactor(c *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param code path string true "医学量表代码"
-// @Success 200 {object} response.MedicalScaleResponse
+// @Success 200 {object} response.Response{data=viewmodel.MedicalScaleVM}
 // @Router /api/v1/medical-scales/{code} [get]
 func (h *MedicalScaleHandler) Get(c *gin.Context) {
 	code := c.Param("code")
@@ -211,8 +212,41 @@ func (h *MedicalScaleHandler) Get(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, &response.MedicalScaleResponse{
-		Data: h.convertDTOToVM(scale),
+	h.SuccessResponse(c, h.convertDTOToVM(scale))
+}
+
+// GetReliability 获取医学量表信度
+// @Summary 获取医学量表信度
+// @Description 根据量表最近的答卷计算克朗巴赫 α 系数，结果缓存 24 小时
+// @Tags MedicalScale
+// @Produce json
+// @Param code path string true "医学量表代码"
+// @Param min_responses query int false "计算所需的最少有效答卷数" default(30)
+// @Success 200 {object} response.Response{data=map[string]interface{}}
+// @Router /api/v1/medical-scales/{code}/reliability [get]
+func (h *MedicalScaleHandler) GetReliability(c *gin.Context) {
+	code := c.Param("code")
+	if code == "" {
+		h.ErrorResponse(c, errors.WithCode(errorCode.ErrValidation, "医学量表代码不能为空"))
+		return
+	}
+
+	minResponses, err := strconv.Atoi(c.DefaultQuery("min_responses", "30"))
+	if err != nil {
+		h.ErrorResponse(c, errors.WithCode(errorCode.ErrMedicalScaleInvalidInput, "最少答卷数必须为整数"))
+		return
+	}
+
+	alpha, err := h.analytics.ComputeCronbachAlpha(c.Request.Context(), code, minResponses)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, gin.H{
+		"code":           code,
+		"cronbach_alpha": alpha,
+		"min_responses":  minResponses,
 	})
 }
 
@@ -223,11 +257,13 @@ func (h *MedicalScaleHandler) convertDTOToVM(dto *dto.MedicalScaleDTO) *viewmode
 	}
 
 	vm := &viewmodel.MedicalScaleVM{
-		ID:                dto.ID,
-		Code:              dto.Code,
-		Title:             dto.Title,
-		QuestionnaireCode: dto.QuestionnaireCode,
-		Factors:           make([]viewmodel.FactorVM, 0, len(dto.Factors)),
+		ID:                 dto.ID,
+		Code:               dto.Code,
+		Title:              dto.Title,
+		QuestionnaireCode:  dto.QuestionnaireCode,
+		Factors:            make([]viewmodel.FactorVM, 0, len(dto.Factors)),
+		ScoringConfig:      h.convertScoringConfigDTOToVM(dto.ScoringConfig),
+		RuleStrategyConfig: dto.RuleStrategyConfig,
 	}
 
 	for _, factor := range dto.Factors {
@@ -242,6 +278,7 @@ func (h *MedicalScaleHandler) convertDTOToVM(dto *dto.MedicalScaleDTO) *viewmode
 			factorVM.CalculationRule = viewmodel.CalculationRuleVM{
 				FormulaType: factor.CalculationRule.FormulaType,
 				SourceCodes: factor.CalculationRule.SourceCodes,
+				Threshold:   factor.CalculationRule.Threshold,
 			}
 		}
 
@@ -262,3 +299,62 @@ func (h *MedicalScaleHandler) convertDTOToVM(dto *dto.MedicalScaleDTO) *viewmode
 
 	return vm
 }
+
+// convertScoringConfigRequestToDTO 将计分配置请求转换为 DTO
+func (h *MedicalScaleHandler) convertScoringConfigRequestToDTO(req request.ScoringConfigRequest) dto.ScoringConfigDTO {
+	config := dto.ScoringConfigDTO{
+		NormalizeToPercentage: req.NormalizeToPercentage,
+	}
+	for _, band := range req.ScoreBands {
+		config.ScoreBands = append(config.ScoreBands, dto.ScoreBandDTO{
+			ScoreRange: dto.ScoreRangeDTO{
+				MinScore: band.ScoreRange.MinScore,
+				MaxScore: band.ScoreRange.MaxScore,
+			},
+			Label:       band.Label,
+			Description: band.Description,
+		})
+	}
+	for _, d := range req.Dimensions {
+		dimension := dto.DimensionDTO{
+			Code:          d.Code,
+			Label:         d.Label,
+			QuestionCodes: d.QuestionCodes,
+		}
+		if d.NormGroup != nil {
+			dimension.NormGroup = &dto.NormGroupDTO{Mean: d.NormGroup.Mean, StdDev: d.NormGroup.StdDev}
+		}
+		config.Dimensions = append(config.Dimensions, dimension)
+	}
+	return config
+}
+
+// convertScoringConfigDTOToVM 将计分配置 DTO 转换为视图模型
+func (h *MedicalScaleHandler) convertScoringConfigDTOToVM(config dto.ScoringConfigDTO) viewmodel.ScoringConfigVM {
+	vm := viewmodel.ScoringConfigVM{
+		NormalizeToPercentage: config.NormalizeToPercentage,
+		ScoreBands:            make([]viewmodel.ScoreBandVM, len(config.ScoreBands)),
+		Dimensions:            make([]viewmodel.DimensionVM, len(config.Dimensions)),
+	}
+	for i, band := range config.ScoreBands {
+		vm.ScoreBands[i] = viewmodel.ScoreBandVM{
+			ScoreRange: viewmodel.ScoreRangeVM{
+				MinScore: band.ScoreRange.MinScore,
+				MaxScore: band.ScoreRange.MaxScore,
+			},
+			Label:       band.Label,
+			Description: band.Description,
+		}
+	}
+	for i, d := range config.Dimensions {
+		vm.Dimensions[i] = viewmodel.DimensionVM{
+			Code:          d.Code,
+			Label:         d.Label,
+			QuestionCodes: d.QuestionCodes,
+		}
+		if d.NormGroup != nil {
+			vm.Dimensions[i].NormGroup = &viewmodel.NormGroupVM{Mean: d.NormGroup.Mean, StdDev: d.NormGroup.StdDev}
+		}
+	}
+	return vm
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/notification-hook/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// NotificationHookHandler 通知钩子处理器
+type NotificationHookHandler struct {
+	*BaseHandler
+	deliveryQueryer port.DeliveryQueryer
+}
+
+// NewNotificationHookHandler 创建通知钩子处理器
+func NewNotificationHookHandler(deliveryQueryer port.DeliveryQueryer) *NotificationHookHandler {
+	return &NotificationHookHandler{
+		BaseHandler:     &BaseHandler{},
+		deliveryQueryer: deliveryQueryer,
+	}
+}
+
+// ListDeliveries 查询通知钩子的投递记录
+// @Summary 查询通知钩子投递记录
+// @Description 查询通知钩子最近的投递尝试（含重试）及对方返回的 HTTP 状态码，按时间倒序
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param id path integer true "通知钩子ID"
+// @Success 200 {object} response.Response{data=[]viewmodel.HookDeliveryViewModel}
+// @Router /v1/admin/hooks/{id}/deliveries [get]
+func (h *NotificationHookHandler) ListDeliveries(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("id"), 10, 64)
+	if err != nil {
+		h.ErrorResponse(c, errors.WithCode(code.ErrValidation, "无效的通知钩子ID"))
+		return
+	}
+
+	deliveries, err := h.deliveryQueryer.ListDeliveries(c.Request.Context(), id)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	vms := make([]viewmodel.HookDeliveryViewModel, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		vms = append(vms, viewmodel.HookDeliveryViewModel{
+			Event:             delivery.Event,
+			QuestionnaireCode: delivery.QuestionnaireCode,
+			Attempt:           delivery.Attempt,
+			StatusCode:        delivery.StatusCode,
+			Succeeded:         delivery.Succeeded(),
+			Error:             delivery.Error,
+			CreatedAt:         delivery.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	h.SuccessResponse(c, vms)
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/mapper"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	userPort "github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/response"
+)
+
+// TranslationHandler 问卷翻译处理器
+type TranslationHandler struct {
+	*BaseHandler
+	translator  port.QuestionnaireTranslator
+	userQueryer userPort.UserQueryer
+	mapper      mapper.QuestionnaireMapper
+}
+
+// NewTranslationHandler 创建问卷翻译处理器
+func NewTranslationHandler(translator port.QuestionnaireTranslator, userQueryer userPort.UserQueryer) *TranslationHandler {
+	return &TranslationHandler{
+		BaseHandler: &BaseHandler{},
+		translator:  translator,
+		userQueryer: userQueryer,
+		mapper:      mapper.NewQuestionnaireMapper(),
+	}
+}
+
+// Submit 提交问卷译文，提交人为当前用户
+// @Summary 提交问卷译文
+// @Description 提交问卷字段的译文，提交后为草稿状态，审核通过后才会出现在翻译后的问卷中
+// @Tags questionnaire
+// @Accept json
+// @Produce json
+// @Param code path string true "问卷编码"
+// @Param request body request.SubmitTranslationRequest true "提交译文请求"
+// @Success 200 {object} response.Response
+// @Router /v1/questionnaires/{code}/translations [post]
+func (h *TranslationHandler) Submit(c *gin.Context) {
+	var req request.SubmitTranslationRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+
+	userID, err := h.CurrentUserID(c, h.userQueryer)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	err = h.translator.SubmitTranslation(c.Request.Context(), c.Param("code"), questionnaire.Translation{
+		Language:       req.Language,
+		FieldPath:      req.FieldPath,
+		TranslatedText: req.TranslatedText,
+		TranslatedBy:   userID,
+	})
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponseWithMessage(c, "译文已提交", nil)
+}
+
+// Approve 审核通过问卷译文，审核人为当前用户
+// @Summary 审核通过问卷译文
+// @Description 审核通过问卷字段的译文，需要 translation-admin 角色
+// @Tags questionnaire
+// @Accept json
+// @Produce json
+// @Param code path string true "问卷编码"
+// @Param request body request.ApproveTranslationRequest true "审核译文请求"
+// @Success 200 {object} response.Response
+// @Router /v1/questionnaires/{code}/translations/approve [post]
+func (h *TranslationHandler) Approve(c *gin.Context) {
+	var req request.ApproveTranslationRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+
+	userID, err := h.CurrentUserID(c, h.userQueryer)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	if err := h.translator.ApproveTranslation(c.Request.Context(), c.Param("code"), req.Language, req.FieldPath, userID); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponseWithMessage(c, "译文已审核通过", nil)
+}
+
+// Compile 获取翻译后的问卷
+// @Summary 获取翻译后的问卷
+// @Description 返回覆盖了指定语言已审核通过译文的问卷，未翻译的字段保留原文
+// @Tags questionnaire
+// @Produce json
+// @Param code path string true "问卷编码"
+// @Param language path string true "语言，如 en-US"
+// @Success 200 {object} response.Response{data=response.QuestionnaireResponse}
+// @Router /v1/questionnaires/{code}/translations/{language} [get]
+func (h *TranslationHandler) Compile(c *gin.Context) {
+	qDomain, err := h.translator.CompileTranslations(c.Request.Context(), c.Param("code"), c.Param("language"))
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, response.NewQuestionnaireResponse(h.mapper.ToDTO(qDomain)))
+}
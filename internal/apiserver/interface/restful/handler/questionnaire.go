@@ -1,19 +1,30 @@
 package handler
 
 import (
+	"bytes"
+	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/mapper"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/printview"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/response"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
+// PreviewTokenHeader 携带问卷预览令牌的请求头
+const PreviewTokenHeader = "X-Preview-Token"
+
 // QuestionnaireHandler 问卷处理器
 type QuestionnaireHandler struct {
 	BaseHandler
@@ -21,6 +32,10 @@ type QuestionnaireHandler struct {
 	questionnaireEditor    port.QuestionnaireEditor
 	questionnairePublisher port.QuestionnairePublisher
 	questionnaireQueryer   port.QuestionnaireQueryer
+	questionnairePreviewer port.QuestionnairePreviewer
+	activityRecorder       *ActivityRecorder
+	thumbnailer            port.QuestionnaireThumbnailer
+	deleter                port.QuestionnaireDeleter
 }
 
 // NewQuestionnaireHandler 创建问卷处理器
@@ -29,15 +44,32 @@ func NewQuestionnaireHandler(
 	questionnaireEditor port.QuestionnaireEditor,
 	questionnairePublisher port.QuestionnairePublisher,
 	questionnaireQueryer port.QuestionnaireQueryer,
+	questionnairePreviewer port.QuestionnairePreviewer,
 ) *QuestionnaireHandler {
 	return &QuestionnaireHandler{
 		questionnaireCreator:   questionnaireCreator,
 		questionnaireEditor:    questionnaireEditor,
 		questionnairePublisher: questionnairePublisher,
 		questionnaireQueryer:   questionnaireQueryer,
+		questionnairePreviewer: questionnairePreviewer,
 	}
 }
 
+// SetActivityRecorder 设置用户活动记录器，发布问卷时记录操作用户
+func (h *QuestionnaireHandler) SetActivityRecorder(recorder *ActivityRecorder) {
+	h.activityRecorder = recorder
+}
+
+// SetThumbnailer 设置问卷缩略图生成器，为空时缩略图接口返回未开启
+func (h *QuestionnaireHandler) SetThumbnailer(thumbnailer port.QuestionnaireThumbnailer) {
+	h.thumbnailer = thumbnailer
+}
+
+// SetDeleter 设置问卷删除器
+func (h *QuestionnaireHandler) SetDeleter(deleter port.QuestionnaireDeleter) {
+	h.deleter = deleter
+}
+
 // CreateQuestionnaire 创建问卷
 func (h *QuestionnaireHandler) CreateQuestionnaire(c *gin.Context) {
 	var req request.CreateQuestionnaireRequest
@@ -55,6 +87,9 @@ func (h *QuestionnaireHandler) CreateQuestionnaire(c *gin.Context) {
 		Title:       req.Title,
 		Description: req.Description,
 		ImgUrl:      req.ImgUrl,
+
+		GeoRestriction: req.GeoRestriction,
+		Tags:           req.Tags,
 	}
 
 	// 调用领域服务
@@ -81,6 +116,11 @@ func (h *QuestionnaireHandler) EditBasicInfo(c *gin.Context) {
 		h.ErrorResponse(c, err)
 		return
 	}
+	revision, err := requireRevision(req.Revision)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
 
 	// 转换为 DTO
 	questionnaireDTO := &dto.QuestionnaireDTO{
@@ -88,10 +128,65 @@ func (h *QuestionnaireHandler) EditBasicInfo(c *gin.Context) {
 		Title:       req.Title,
 		Description: req.Description,
 		ImgUrl:      req.ImgUrl,
+
+		GeoRestriction: req.GeoRestriction,
+		Tags:           req.Tags,
+		Revision:       revision,
 	}
 
 	// 调用领域服务
 	result, err := h.questionnaireEditor.EditBasicInfo(c, questionnaireDTO)
+	if err != nil {
+		h.saveErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, response.NewQuestionnaireResponse(result))
+}
+
+// requireRevision 校验请求携带了加载问卷时的修订号
+// 修订号 0 是未保存过的问卷的合法值，因此以字段缺失判断
+func requireRevision(revision *int64) (int64, error) {
+	if revision == nil {
+		return 0, errors.WithCode(code.ErrQuestionnaireInvalidInput, "修订号不能为空")
+	}
+	return *revision, nil
+}
+
+// saveErrorResponse 保存问卷失败的响应，修订号冲突时在响应数据中返回服务端当前的修订号
+func (h *QuestionnaireHandler) saveErrorResponse(c *gin.Context, err error) {
+	var conflict *questionnaire.RevisionConflictError
+	if errors.As(err, &conflict) {
+		envelope.WriteErrorWithData(c, err, gin.H{"current_revision": conflict.CurrentRevision})
+		return
+	}
+	h.ErrorResponse(c, err)
+}
+
+// AmendQuestionnaire 为已发布的问卷创建修订版本
+// 已发布的问卷不可修改，修订版本为下一版本的草稿，可继续编辑问题，重新发布后生效
+func (h *QuestionnaireHandler) AmendQuestionnaire(c *gin.Context) {
+	qCode := c.Param("code")
+	if qCode == "" {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "问卷代码不能为空"))
+		return
+	}
+
+	var req request.EditQuestionnaireBasicInfoRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	result, err := h.questionnaireEditor.AmendBasicInfo(c, &dto.QuestionnaireDTO{
+		Code:        qCode,
+		Title:       req.Title,
+		Description: req.Description,
+		ImgUrl:      req.ImgUrl,
+
+		GeoRestriction: req.GeoRestriction,
+		Tags:           req.Tags,
+	})
 	if err != nil {
 		h.ErrorResponse(c, err)
 		return
@@ -114,14 +209,19 @@ func (h *QuestionnaireHandler) UpdateQuestions(c *gin.Context) {
 		h.ErrorResponse(c, err)
 		return
 	}
+	revision, err := requireRevision(req.Revision)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
 
 	// 转换为 DTO
 	questions := mapper.NewQuestionMapper().ToDTOs(req.Questions)
 
 	// 调用领域服务
-	result, err := h.questionnaireEditor.UpdateQuestions(c, qCode, questions)
+	result, err := h.questionnaireEditor.UpdateQuestions(c, qCode, revision, questions)
 	if err != nil {
-		h.ErrorResponse(c, err)
+		h.saveErrorResponse(c, err)
 		return
 	}
 
@@ -137,13 +237,25 @@ func (h *QuestionnaireHandler) PublishQuestionnaire(c *gin.Context) {
 		return
 	}
 
-	// 调用领域服务
-	result, err := h.questionnairePublisher.Publish(c, qCode)
+	var req request.PublishQuestionnaireRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+	revision, err := requireRevision(req.Revision)
 	if err != nil {
 		h.ErrorResponse(c, err)
 		return
 	}
 
+	// 调用领域服务
+	result, err := h.questionnairePublisher.Publish(c, qCode, revision)
+	if err != nil {
+		h.saveErrorResponse(c, err)
+		return
+	}
+	h.activityRecorder.Record(c, user.ActivityQuestionnairePublish, "发布问卷 "+qCode)
+
 	h.SuccessResponse(c, response.NewQuestionnaireResponse(result))
 }
 
@@ -156,16 +268,56 @@ func (h *QuestionnaireHandler) UnpublishQuestionnaire(c *gin.Context) {
 		return
 	}
 
-	// 调用领域服务
-	result, err := h.questionnairePublisher.Unpublish(c, qCode)
+	var req request.PublishQuestionnaireRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+	revision, err := requireRevision(req.Revision)
 	if err != nil {
 		h.ErrorResponse(c, err)
 		return
 	}
 
+	// 调用领域服务
+	result, err := h.questionnairePublisher.Unpublish(c, qCode, revision)
+	if err != nil {
+		h.saveErrorResponse(c, err)
+		return
+	}
+
 	h.SuccessResponse(c, response.NewQuestionnaireResponse(result))
 }
 
+// DeleteQuestionnaire 删除问卷
+// 问卷存在答卷或未完成的分配时返回 409，查询参数 force=true 时仍然删除
+func (h *QuestionnaireHandler) DeleteQuestionnaire(c *gin.Context) {
+	// 从路径参数获取code
+	qCode := c.Param("code")
+	if qCode == "" {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "问卷代码不能为空"))
+		return
+	}
+
+	force := false
+	if v := c.Query("force"); v != "" {
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "force 参数无效: %s", v))
+			return
+		}
+		force = parsed
+	}
+
+	// 调用领域服务
+	if err := h.deleter.DeleteQuestionnaire(c, qCode, force); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, nil)
+}
+
 // QueryOne 查询单个问卷
 func (h *QuestionnaireHandler) QueryOne(c *gin.Context) {
 	// 从路径参数获取code
@@ -185,7 +337,143 @@ func (h *QuestionnaireHandler) QueryOne(c *gin.Context) {
 	h.SuccessResponse(c, response.NewQuestionnaireResponse(result))
 }
 
+// QueryQuestions 查询问卷的问题列表
+// with_auto_codes=true 时为缺少编码的问题自动生成编码并保存，再次查询返回相同的编码
+func (h *QuestionnaireHandler) QueryQuestions(c *gin.Context) {
+	qCode := c.Param("code")
+	if qCode == "" {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "问卷代码不能为空"))
+		return
+	}
+
+	withAutoCodes, err := strconv.ParseBool(c.DefaultQuery("with_auto_codes", "false"))
+	if err != nil {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "with_auto_codes 必须为布尔值"))
+		return
+	}
+
+	questions, err := h.questionnaireQueryer.GetQuestions(c, qCode, withAutoCodes)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, response.NewQuestionListResponse(qCode, questions))
+}
+
+// ListQuestionTypes 列出所有已注册题型及其支持的配置能力，供前端表单构建器使用
+func (h *QuestionnaireHandler) ListQuestionTypes(c *gin.Context) {
+	h.SuccessResponse(c, h.questionnaireQueryer.ListQuestionTypes(c))
+}
+
+// Print 获取问卷的打印版式，返回适合 A4 纸打印的 HTML 页面，用于纸质施测
+func (h *QuestionnaireHandler) Print(c *gin.Context) {
+	qCode := c.Param("code")
+	if qCode == "" {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "问卷代码不能为空"))
+		return
+	}
+
+	result, err := h.questionnaireQueryer.GetQuestionnaireByCode(c, qCode)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	var page bytes.Buffer
+	if err := printview.RenderQuestionnaire(&page, result); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, printview.ContentType, page.Bytes())
+}
+
+// Thumbnail 获取问卷当前版本打印版式首页的 300×400 JPEG 缩略图，用于管理后台预览，需开启 thumbnail.enabled
+func (h *QuestionnaireHandler) Thumbnail(c *gin.Context) {
+	if h.thumbnailer == nil {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireThumbnailDisabled, "问卷缩略图未开启"))
+		return
+	}
+
+	qCode := c.Param("code")
+	if qCode == "" {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "问卷代码不能为空"))
+		return
+	}
+
+	thumbnail, err := h.thumbnailer.GenerateThumbnail(c.Request.Context(), qCode)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	c.Data(http.StatusOK, "image/jpeg", thumbnail)
+}
+
+// CreatePreviewToken 为问卷创建预览令牌，用于向没有账号的评审者分享尚未发布的问卷
+func (h *QuestionnaireHandler) CreatePreviewToken(c *gin.Context) {
+	qCode := c.Param("code")
+	if qCode == "" {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "问卷代码不能为空"))
+		return
+	}
+
+	var req request.CreatePreviewTokenRequest
+	if c.Request.ContentLength > 0 {
+		if err := h.BindJSON(c, &req); err != nil {
+			h.ErrorResponse(c, err)
+			return
+		}
+	}
+
+	expiresIn := time.Duration(req.ExpiresIn) * time.Second
+	if expiresIn <= 0 {
+		expiresIn = questionnaire.DefaultPreviewTokenTTL
+	}
+	token, err := h.questionnairePreviewer.CreatePreviewToken(c, qCode, expiresIn)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, &response.PreviewTokenResponse{
+		Token:     token,
+		ExpiresAt: time.Now().Add(expiresIn),
+	})
+}
+
+// Preview 根据预览令牌获取问卷的完整结构（含草稿问题），无需登录
+func (h *QuestionnaireHandler) Preview(c *gin.Context) {
+	result, err := h.questionnairePreviewer.GetPreviewQuestionnaire(c, c.Param("token"))
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, response.NewPreviewQuestionnaireResponse(result))
+}
+
+// RejectPreviewToken 拒绝以预览令牌作为凭证的请求，预览令牌只能用于查看问卷，不能提交答卷
+func (h *QuestionnaireHandler) RejectPreviewToken(c *gin.Context) {
+	token := c.GetHeader(PreviewTokenHeader)
+	if bearer := c.GetHeader("Authorization"); token == "" && strings.HasPrefix(bearer, "Bearer ") {
+		token = strings.TrimPrefix(bearer, "Bearer ")
+	}
+
+	if token != "" {
+		if _, err := h.questionnairePreviewer.VerifyPreviewToken(token); err == nil || errors.IsCode(err, code.ErrPreviewTokenExpired) {
+			h.ErrorResponse(c, errors.WithCode(code.ErrPreviewOnlyToken, "预览令牌不能用于访问问卷预览以外的接口"))
+			c.Abort()
+			return
+		}
+	}
+
+	c.Next()
+}
+
 // QueryList 查询问卷列表
+// 指定 tags（逗号分隔）时按标签查询，match_all=true 要求包含全部标签，此时忽略 status 和 title 条件
 func (h *QuestionnaireHandler) QueryList(c *gin.Context) {
 	// 获取分页参数
 	page, err := strconv.Atoi(c.DefaultQuery("page", "1"))
@@ -200,6 +488,22 @@ func (h *QuestionnaireHandler) QueryList(c *gin.Context) {
 		return
 	}
 
+	// 按标签查询
+	if tagsParam := c.Query("tags"); tagsParam != "" {
+		matchAll, err := strconv.ParseBool(c.DefaultQuery("match_all", "false"))
+		if err != nil {
+			h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "match_all 必须为布尔值"))
+			return
+		}
+		questionnaires, total, err := h.questionnaireQueryer.ListQuestionnairesByTags(c, strings.Split(tagsParam, ","), matchAll, page, pageSize)
+		if err != nil {
+			h.ErrorResponse(c, err)
+			return
+		}
+		h.SuccessResponse(c, response.NewQuestionnaireListResponse(questionnaires, total, page, pageSize))
+		return
+	}
+
 	// 获取查询条件
 	conditions := make(map[string]string)
 	if status := c.Query("status"); status != "" {
@@ -0,0 +1,218 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/questiontype"
+)
+
+// fakeQuestionnaireQueryer 返回固定问卷的查询服务
+type fakeQuestionnaireQueryer struct {
+	questionnaire *dto.QuestionnaireDTO
+}
+
+func (f *fakeQuestionnaireQueryer) GetQuestionnaireByCode(ctx context.Context, code string) (*dto.QuestionnaireDTO, error) {
+	return f.questionnaire, nil
+}
+
+func (f *fakeQuestionnaireQueryer) GetQuestionnaireByCodeVersion(ctx context.Context, code, version string) (*dto.QuestionnaireDTO, error) {
+	return f.questionnaire, nil
+}
+
+func (f *fakeQuestionnaireQueryer) ListQuestionnaires(ctx context.Context, page, pageSize int, conditions map[string]string) ([]*dto.QuestionnaireDTO, int64, error) {
+	return []*dto.QuestionnaireDTO{f.questionnaire}, 1, nil
+}
+
+func (f *fakeQuestionnaireQueryer) ListQuestionnairesByTags(ctx context.Context, tags []string, matchAll bool, page, pageSize int) ([]*dto.QuestionnaireDTO, int64, error) {
+	return []*dto.QuestionnaireDTO{f.questionnaire}, 1, nil
+}
+
+func (f *fakeQuestionnaireQueryer) GetQuestions(ctx context.Context, code string, withAutoCodes bool) ([]dto.QuestionDTO, error) {
+	return f.questionnaire.Questions, nil
+}
+
+func (f *fakeQuestionnaireQueryer) ListQuestionTypes(ctx context.Context) []questiontype.Info {
+	return questiontype.List()
+}
+
+func TestQuestionnaireHandler_Print(t *testing.T) {
+	frequency := []dto.OptionDTO{{Code: "0", Content: "完全不会"}, {Code: "1", Content: "好几天"}, {Code: "2", Content: "一半以上的天数"}}
+	queryer := &fakeQuestionnaireQueryer{questionnaire: &dto.QuestionnaireDTO{
+		Code:        "phq9",
+		Title:       "PHQ-9 抑郁筛查量表",
+		Description: "在过去两周里，你有多少时间受到以下问题困扰？",
+		Version:     "1.0.2",
+		Questions: []dto.QuestionDTO{
+			{Code: "s1", Title: "情绪", Type: "Section"},
+			{Code: "q1", Title: "做事时提不起劲或没有兴趣", Type: "Radio", Options: frequency},
+			{Code: "q2", Title: "感到心情低落、沮丧或绝望", Type: "Radio", Options: frequency},
+			{Code: "s2", Title: "补充信息", Type: "Section"},
+			{Code: "q3", Title: "以下哪些情况适用于你", Type: "Checkbox", Options: []dto.OptionDTO{{Code: "a", Content: "失眠"}, {Code: "b", Content: "食欲下降"}}},
+			{Code: "q4", Title: "其他需要说明的情况", Type: "Textarea"},
+		},
+	}}
+	h := NewQuestionnaireHandler(nil, nil, nil, queryer, nil)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/questionnaires/:code/print", func(c *gin.Context) {
+		c.Set(middleware.ScopeKey, strings.Split(c.GetHeader("X-Test-Scope"), ","))
+	}, middleware.ScopeGuard("code"), h.Print)
+
+	for _, tt := range []struct {
+		name       string
+		scope      string
+		wantStatus int
+	}{
+		{name: "admin", scope: middleware.AdminScope, wantStatus: http.StatusOK},
+		{name: "clinician managing the questionnaire", scope: "phq*", wantStatus: http.StatusOK},
+		{name: "out of scope", scope: "gad*", wantStatus: http.StatusForbidden},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/questionnaires/phq9/print", nil)
+			req.Header.Set("X-Test-Scope", tt.scope)
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			if got := rec.Header().Get("Content-Type"); got != "text/html; charset=utf-8" {
+				t.Errorf("Content-Type = %q, want text/html; charset=utf-8", got)
+			}
+			body := rec.Body.String()
+			if got := strings.Count(body, `<li class="question">`); got != 4 {
+				t.Errorf("rendered %d questions, want 4", got)
+			}
+			for _, want := range []string{"@media print", "size: A4", "break-before: page", `class="marker circle"`, `class="marker square"`, "4. 其他需要说明的情况", "phq9 · 版本 1.0.2"} {
+				if !strings.Contains(body, want) {
+					t.Errorf("body does not contain %q", want)
+				}
+			}
+		})
+	}
+}
+
+// fakeQuestionnairePreviewer 只接受令牌 "preview-token" 的预览服务
+type fakeQuestionnairePreviewer struct{}
+
+func (f *fakeQuestionnairePreviewer) CreatePreviewToken(ctx context.Context, questionnaireCode string, expiresIn time.Duration) (string, error) {
+	return "preview-token", nil
+}
+
+func (f *fakeQuestionnairePreviewer) VerifyPreviewToken(token string) (string, error) {
+	if token != "preview-token" {
+		return "", errors.WithCode(code.ErrPreviewTokenInvalid, "invalid")
+	}
+	return "phq9", nil
+}
+
+func (f *fakeQuestionnairePreviewer) GetPreviewQuestionnaire(ctx context.Context, token string) (*dto.QuestionnaireDTO, error) {
+	if _, err := f.VerifyPreviewToken(token); err != nil {
+		return nil, err
+	}
+	return &dto.QuestionnaireDTO{Code: "phq9", Status: "draft"}, nil
+}
+
+func TestQuestionnaireHandler_Preview(t *testing.T) {
+	h := NewQuestionnaireHandler(nil, nil, nil, nil, &fakeQuestionnairePreviewer{})
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/questionnaires/preview/:token", h.Preview)
+	engine.POST("/answersheets", h.RejectPreviewToken, func(c *gin.Context) { c.Status(http.StatusCreated) })
+
+	for _, tt := range []struct {
+		name       string
+		method     string
+		path       string
+		header     string
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "preview", method: http.MethodGet, path: "/questionnaires/preview/preview-token", wantStatus: http.StatusOK, wantBody: `"preview":true`},
+		{name: "invalid token", method: http.MethodGet, path: "/questionnaires/preview/forged", wantStatus: http.StatusUnauthorized},
+		{name: "submit with bearer preview token", method: http.MethodPost, path: "/answersheets", header: "Bearer preview-token", wantStatus: http.StatusForbidden},
+		{name: "submit with other credentials", method: http.MethodPost, path: "/answersheets", header: "Bearer a.b.c", wantStatus: http.StatusCreated},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d: %s", rec.Code, tt.wantStatus, rec.Body.String())
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body.String(), tt.wantBody)
+			}
+		})
+	}
+}
+
+// conflictingQuestionnairePublisher 发布时总是遇到修订号冲突的发布服务
+type conflictingQuestionnairePublisher struct{}
+
+func (f *conflictingQuestionnairePublisher) Publish(ctx context.Context, code string, revision int64) (*dto.QuestionnaireDTO, error) {
+	return nil, questionnaire.NewRevisionConflictError(code, 7)
+}
+
+func (f *conflictingQuestionnairePublisher) Unpublish(ctx context.Context, code string, revision int64) (*dto.QuestionnaireDTO, error) {
+	return nil, questionnaire.NewRevisionConflictError(code, 7)
+}
+
+func TestQuestionnaireHandler_PublishRevisionConflict(t *testing.T) {
+	h := NewQuestionnaireHandler(nil, nil, &conflictingQuestionnairePublisher{}, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/questionnaires/:code/publish", h.PublishQuestionnaire)
+
+	req := httptest.NewRequest(http.MethodPost, "/questionnaires/phq9/publish", strings.NewReader(`{"revision":6}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusConflict {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusConflict, rec.Body.String())
+	}
+	if !strings.Contains(rec.Body.String(), `"current_revision":7`) {
+		t.Errorf("body = %s, want it to contain the current revision", rec.Body.String())
+	}
+}
+
+func TestQuestionnaireHandler_PublishRequiresRevision(t *testing.T) {
+	h := NewQuestionnaireHandler(nil, nil, &conflictingQuestionnairePublisher{}, nil, nil)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.POST("/questionnaires/:code/publish", h.PublishQuestionnaire)
+
+	req := httptest.NewRequest(http.MethodPost, "/questionnaires/phq9/publish", strings.NewReader(`{}`))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusBadRequest {
+		t.Fatalf("status = %d, want %d: %s", rec.Code, http.StatusBadRequest, rec.Body.String())
+	}
+}
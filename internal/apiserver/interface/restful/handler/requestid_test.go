@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/grpcserver"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// lookupMethod 测试用的下游 gRPC 方法
+const lookupMethod = "/test.Lookup/Find"
+
+// lookupServiceDesc 总是返回 NotFound 的测试服务，服务端日志通过 log.L(ctx) 输出
+var lookupServiceDesc = &grpc.ServiceDesc{
+	ServiceName: "test.Lookup",
+	HandlerType: (*interface{})(nil),
+	Methods: []grpc.MethodDesc{{
+		MethodName: "Find",
+		Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+			in := new(emptypb.Empty)
+			if err := dec(in); err != nil {
+				return nil, err
+			}
+			handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+				log.L(ctx).Info("downstream lookup")
+				return nil, status.Error(codes.NotFound, "questionnaire not found")
+			}
+			return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: lookupMethod}, handler)
+		},
+	}},
+}
+
+func TestRequestID_PropagatesToResponseLogsAndGRPC(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "requestid.log")
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{logFile}
+	opts.Format = "json"
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	// 下游 gRPC 服务
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpc.NewServer(grpc.ChainUnaryInterceptor(
+		grpcserver.RequestIDInterceptor(),
+		grpcserver.LoggingInterceptor(),
+	))
+	server.RegisterService(lookupServiceDesc, struct{}{})
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithUnaryInterceptor(middleware.UnaryClientRequestIDInterceptor()),
+	)
+	if err != nil {
+		t.Fatalf("NewClient() error = %v", err)
+	}
+	t.Cleanup(func() { _ = conn.Close() })
+
+	// 边缘 HTTP 服务
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.RequestID())
+	engine.GET("/questionnaires/:code", func(c *gin.Context) {
+		log.L(c).Infof("loading questionnaire %s", c.Param("code"))
+
+		err := conn.Invoke(c.Request.Context(), lookupMethod, &emptypb.Empty{}, &emptypb.Empty{})
+		envelope.WriteError(c, errors.WrapC(err, code.ErrQuestionnaireNotFound, "questionnaire %s not found", c.Param("code")))
+	})
+
+	for _, tt := range []struct {
+		name     string
+		incoming string
+	}{
+		{name: "generated at edge"},
+		{name: "read from header", incoming: "req-from-edge"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/questionnaires/phq9", nil)
+			if tt.incoming != "" {
+				req.Header.Set(middleware.XRequestIDKey, tt.incoming)
+			}
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, req)
+
+			rid := rec.Header().Get(middleware.XRequestIDKey)
+			if rid == "" || (tt.incoming != "" && rid != tt.incoming) {
+				t.Fatalf("%s header = %q, want %q", middleware.XRequestIDKey, rid, tt.incoming)
+			}
+
+			var body envelope.Response
+			if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+				t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+			}
+			if body.Code != code.ErrQuestionnaireNotFound || body.RequestID != rid {
+				t.Errorf("response = %+v, want ErrQuestionnaireNotFound with request_id %q", body, rid)
+			}
+
+			log.Flush()
+			logged, err := os.ReadFile(logFile)
+			if err != nil {
+				t.Fatalf("read log: %v", err)
+			}
+			for _, want := range []string{
+				"loading questionnaire phq9",
+				"gRPC Request Started",
+				"downstream lookup",
+				"gRPC Request Failed",
+				"HTTP Handler Error",
+			} {
+				if got := logRequestID(t, logged, want); got != rid {
+					t.Errorf("log line %q has requestID %q, want %q", want, got, rid)
+				}
+			}
+		})
+	}
+}
+
+// logRequestID 返回最后一条包含 msg 的 JSON 日志的 requestID 字段
+func logRequestID(t *testing.T, logged []byte, msg string) string {
+	t.Helper()
+	var requestID string
+	for _, line := range strings.Split(strings.TrimSpace(string(logged)), "\n") {
+		if !strings.Contains(line, msg) {
+			continue
+		}
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("decode log line %q: %v", line, err)
+		}
+		requestID, _ = entry[log.KeyRequestID].(string)
+	}
+	return requestID
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+)
+
+// RescoreHandler 答卷重新计分处理器
+type RescoreHandler struct {
+	*BaseHandler
+	rescorer port.AnswerSheetRescorer
+}
+
+// NewRescoreHandler 创建答卷重新计分处理器
+func NewRescoreHandler(rescorer port.AnswerSheetRescorer) *RescoreHandler {
+	return &RescoreHandler{
+		BaseHandler: &BaseHandler{},
+		rescorer:    rescorer,
+	}
+}
+
+// Rescore 按医学量表重新计算历史答卷得分
+// @Summary 重新计算答卷得分
+// @Description 医学量表的常模、计分权重更新后，按量表最新定义重新计算其关联问卷的全部答卷得分，只保存得分有变化的答卷；dry_run 为 true 时只报告得分变化
+// @Tags admin
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param scale_code query string true "医学量表编码"
+// @Param dry_run query bool false "只预览得分变化，不保存"
+// @Success 200 {object} response.Response{data=viewmodel.RescoreResultViewModel}
+// @Router /v1/admin/rescoring [post]
+func (h *RescoreHandler) Rescore(c *gin.Context) {
+	var req request.RescoreRequest
+	if err := h.BindQuery(c, &req); err != nil {
+		return
+	}
+
+	result, err := h.rescorer.RescoreByScale(c.Request.Context(), req.ScaleCode, req.DryRun)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	changes := make([]viewmodel.ScoreChangeViewModel, 0, len(result.Changes))
+	for _, change := range result.Changes {
+		changes = append(changes, viewmodel.ScoreChangeViewModel{
+			AnswerSheetID: change.AnswerSheetID,
+			OldScore:      change.OldScore,
+			NewScore:      change.NewScore,
+		})
+	}
+	errs := result.Errors
+	if errs == nil {
+		errs = []string{}
+	}
+
+	h.SuccessResponse(c, viewmodel.RescoreResultViewModel{
+		ScaleCode:      result.ScaleCode,
+		DryRun:         result.DryRun,
+		TotalProcessed: result.TotalProcessed,
+		TotalUpdated:   result.TotalUpdated,
+		Changes:        changes,
+		Errors:         errs,
+	})
+}
@@ -0,0 +1,61 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+)
+
+// xlsxContentType Excel 工作簿的媒体类型
+const xlsxContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// ScoringReportHandler 计分报表处理器
+type ScoringReportHandler struct {
+	*BaseHandler
+	reporter port.AnswerSheetScoringReporter
+}
+
+// NewScoringReportHandler 创建计分报表处理器
+func NewScoringReportHandler(reporter port.AnswerSheetScoringReporter) *ScoringReportHandler {
+	return &ScoringReportHandler{
+		BaseHandler: &BaseHandler{},
+		reporter:    reporter,
+	}
+}
+
+// ExportXLSX 导出问卷计分报表
+// @Summary 导出问卷计分报表
+// @Description 导出问卷在日期范围内答卷的计分报表 XLSX 文件，包含 Summary、Raw Scores 和 Question Analysis 三个工作表
+// @Tags questionnaire
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param code path string true "问卷编码"
+// @Param from query string false "起始日期（YYYY-MM-DD）"
+// @Param to query string false "截止日期（YYYY-MM-DD，含当天）"
+// @Success 200 {file} file "XLSX 文件"
+// @Router /v1/questionnaires/{code}/report.xlsx [get]
+func (h *ScoringReportHandler) ExportXLSX(c *gin.Context) {
+	var req request.ScoringReportRequest
+	if err := h.BindQuery(c, &req); err != nil {
+		return
+	}
+
+	to := req.To
+	if !to.IsZero() {
+		to = to.AddDate(0, 0, 1)
+	}
+
+	code := h.GetPathParam(c, "code")
+	data, err := h.reporter.ExportScoringReportXLSX(c.Request.Context(), code, req.From, to)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s-report.xlsx"`, code))
+	c.Data(http.StatusOK, xlsxContentType, data)
+}
@@ -0,0 +1,53 @@
+package handler
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+)
+
+// SignedUploadHandler 签名上传处理器
+type SignedUploadHandler struct {
+	*BaseHandler
+	uploader port.SignedUploader
+}
+
+// NewSignedUploadHandler 创建签名上传处理器
+func NewSignedUploadHandler(uploader port.SignedUploader) *SignedUploadHandler {
+	return &SignedUploadHandler{
+		BaseHandler: &BaseHandler{},
+		uploader:    uploader,
+	}
+}
+
+// CreateUploadURL 签发文件上传链接
+// @Summary 签发文件上传链接
+// @Description 为文件上传题签发 MinIO/S3 的表单直传链接，客户端将 fields 与文件一起 POST 到 url，上传完成后以 upload_id 作为答案提交答卷
+// @Tags file
+// @Accept json
+// @Produce json
+// @Param Authorization header string true "Bearer 用户令牌"
+// @Param request body request.CreateUploadURLRequest true "上传文件信息"
+// @Success 200 {object} response.Response{data=viewmodel.UploadLinkDTO}
+// @Router /v1/files/upload-urls [post]
+func (h *SignedUploadHandler) CreateUploadURL(c *gin.Context) {
+	var req request.CreateUploadURLRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+
+	link, err := h.uploader.GenerateUploadURL(c.Request.Context(), req.QuestionnaireCode, req.QuestionCode, req.FileName, req.MimeType)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, viewmodel.UploadLinkDTO{
+		UploadID:  link.UploadID,
+		URL:       link.URL,
+		Fields:    link.Fields,
+		ExpiresAt: link.ExpiresAt,
+	})
+}
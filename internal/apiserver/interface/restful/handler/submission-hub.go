@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"encoding/json"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet/port"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// DefaultMaxSubmissionSubscribers 每份问卷允许的实时提交通知连接数上限
+const DefaultMaxSubmissionSubscribers = 100
+
+// submissionSendBuffer 每个连接待发送通知的缓冲数量，缓冲满时丢弃该连接的新通知
+const submissionSendBuffer = 16
+
+// SubmissionNotification 推送给客户端的答卷提交通知
+type SubmissionNotification struct {
+	AnswersheetCode string    `json:"answersheet_code"`
+	RespondentID    uint64    `json:"respondent_id"`
+	SubmittedAt     time.Time `json:"submitted_at"`
+	TotalScore      float64   `json:"total_score"`
+}
+
+// submissionSubscriber 订阅问卷提交通知的连接
+type submissionSubscriber struct {
+	send chan []byte
+}
+
+// SubmissionHub 答卷提交通知中心
+// 按问卷编码维护 WebSocket 订阅者，收到答卷提交事件时向该问卷的全部订阅者广播通知
+// 订阅者只保存在当前进程内，多实例部署时客户端只能收到所连接实例上提交的答卷
+type SubmissionHub struct {
+	mu             sync.Mutex
+	subscribers    map[string]map[*submissionSubscriber]struct{}
+	maxSubscribers int
+}
+
+// NewSubmissionHub 创建答卷提交通知中心，maxSubscribers 不大于 0 时使用 DefaultMaxSubmissionSubscribers
+func NewSubmissionHub(maxSubscribers int) *SubmissionHub {
+	if maxSubscribers <= 0 {
+		maxSubscribers = DefaultMaxSubmissionSubscribers
+	}
+	return &SubmissionHub{
+		subscribers:    make(map[string]map[*submissionSubscriber]struct{}),
+		maxSubscribers: maxSubscribers,
+	}
+}
+
+// 确保实现了接口
+var _ port.SubmissionPublisher = (*SubmissionHub)(nil)
+
+// subscribe 订阅问卷的提交通知，问卷的订阅者已达上限时返回 nil
+func (h *SubmissionHub) subscribe(questionnaireCode string) *submissionSubscriber {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribers := h.subscribers[questionnaireCode]
+	if len(subscribers) >= h.maxSubscribers {
+		return nil
+	}
+	if subscribers == nil {
+		subscribers = make(map[*submissionSubscriber]struct{})
+		h.subscribers[questionnaireCode] = subscribers
+	}
+
+	sub := &submissionSubscriber{send: make(chan []byte, submissionSendBuffer)}
+	subscribers[sub] = struct{}{}
+	return sub
+}
+
+// unsubscribe 取消订阅
+func (h *SubmissionHub) unsubscribe(questionnaireCode string, sub *submissionSubscriber) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	subscribers := h.subscribers[questionnaireCode]
+	delete(subscribers, sub)
+	if len(subscribers) == 0 {
+		delete(h.subscribers, questionnaireCode)
+	}
+}
+
+// PublishSubmitted 向问卷的全部订阅者广播答卷提交通知，不等待发送完成
+func (h *SubmissionHub) PublishSubmitted(event answersheet.AnswerSheetSubmitted) {
+	message, err := json.Marshal(SubmissionNotification{
+		AnswersheetCode: strconv.FormatUint(event.AnswerSheetID, 10),
+		RespondentID:    event.TesteeID,
+		SubmittedAt:     event.SubmittedAt,
+		TotalScore:      event.TotalScore,
+	})
+	if err != nil {
+		log.Errorf("序列化答卷提交通知失败，答卷ID: %d, 错误: %v", event.AnswerSheetID, err)
+		return
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for sub := range h.subscribers[event.QuestionnaireCode] {
+		select {
+		case sub.send <- message:
+		default:
+			log.Warnf("答卷提交通知连接发送缓冲已满，丢弃通知，问卷编码: %s, 答卷ID: %d", event.QuestionnaireCode, event.AnswerSheetID)
+		}
+	}
+}
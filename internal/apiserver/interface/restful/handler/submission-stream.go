@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// submissionPingInterval 向客户端发送 ping 的心跳间隔
+	submissionPingInterval = 60 * time.Second
+	// submissionPongWait 等待客户端 pong 的最长时间，超时后断开连接
+	submissionPongWait = submissionPingInterval + 10*time.Second
+	// submissionWriteWait 单次写消息的超时时间
+	submissionWriteWait = 10 * time.Second
+	// submissionMaxMessageSize 客户端消息的最大字节数，客户端只需回应 pong 和关闭帧
+	submissionMaxMessageSize = 512
+)
+
+// SubmissionStreamHandler 答卷提交实时通知处理器
+// 将请求升级为 WebSocket 连接，推送问卷的新提交答卷；升级前由路由上的 JWT 认证校验 ?token= 参数
+type SubmissionStreamHandler struct {
+	BaseHandler
+	hub      *SubmissionHub
+	upgrader websocket.Upgrader
+}
+
+// NewSubmissionStreamHandler 创建答卷提交实时通知处理器，只接受与服务同源的 WebSocket 请求
+func NewSubmissionStreamHandler(hub *SubmissionHub) *SubmissionStreamHandler {
+	return &SubmissionStreamHandler{hub: hub}
+}
+
+// Subscribe 订阅问卷的答卷提交通知，连接期间每提交一份答卷推送一条 JSON 通知
+func (h *SubmissionStreamHandler) Subscribe(c *gin.Context) {
+	qCode := c.Param("code")
+	if qCode == "" {
+		h.ErrorResponse(c, errors.WithCode(code.ErrQuestionnaireInvalidInput, "问卷代码不能为空"))
+		return
+	}
+
+	sub := h.hub.subscribe(qCode)
+	if sub == nil {
+		h.ErrorResponse(c, errors.WithCode(code.ErrSubmissionStreamLimit,
+			"问卷 %s 的实时提交通知连接数已达上限 %d", qCode, h.hub.maxSubscribers))
+		return
+	}
+
+	conn, err := h.upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		// Upgrade 失败时已向客户端返回错误响应
+		h.hub.unsubscribe(qCode, sub)
+		log.Warnf("升级答卷提交通知连接失败，问卷编码: %s, 错误: %v", qCode, err)
+		return
+	}
+
+	go writeSubmissions(conn, sub)
+	readUntilClosed(conn)
+
+	// 取消订阅后不会再有通知写入 send，可以安全关闭
+	h.hub.unsubscribe(qCode, sub)
+	close(sub.send)
+}
+
+// readUntilClosed 读取客户端消息直到连接关闭或心跳超时，收到 pong 时延长读超时
+func readUntilClosed(conn *websocket.Conn) {
+	conn.SetReadLimit(submissionMaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(submissionPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(submissionPongWait))
+	})
+
+	for {
+		if _, _, err := conn.ReadMessage(); err != nil {
+			return
+		}
+	}
+}
+
+// writeSubmissions 向客户端写入通知并定时发送 ping，send 关闭或写入失败时关闭连接
+func writeSubmissions(conn *websocket.Conn, sub *submissionSubscriber) {
+	ticker := time.NewTicker(submissionPingInterval)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-sub.send:
+			_ = conn.SetWriteDeadline(time.Now().Add(submissionWriteWait))
+			if !ok {
+				_ = conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(websocket.CloseNormalClosure, ""))
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(submissionWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
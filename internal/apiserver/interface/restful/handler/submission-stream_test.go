@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/answersheet"
+)
+
+func TestSubmissionStreamHandler_Subscribe(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	hub := NewSubmissionHub(1)
+	engine := gin.New()
+	engine.GET("/ws/questionnaires/:code/submissions", NewSubmissionStreamHandler(hub).Subscribe)
+	server := httptest.NewServer(engine)
+	defer server.Close()
+
+	url := "ws" + strings.TrimPrefix(server.URL, "http") + "/ws/questionnaires/PHQ9/submissions"
+	conn, _, err := websocket.DefaultDialer.Dial(url, nil)
+	if err != nil {
+		t.Fatalf("Dial() error = %v", err)
+	}
+	defer conn.Close()
+
+	// 超过连接数上限时拒绝升级
+	if _, resp, err := websocket.DefaultDialer.Dial(url, nil); err == nil || resp == nil || resp.StatusCode != http.StatusConflict {
+		t.Errorf("second Dial() response = %v, error = %v, want 409 Conflict", resp, err)
+	}
+
+	submittedAt := time.Date(2025, 3, 1, 8, 30, 0, 0, time.UTC)
+	hub.PublishSubmitted(answersheet.AnswerSheetSubmitted{AnswerSheetID: 7, QuestionnaireCode: "GAD7", TesteeID: 1})
+	hub.PublishSubmitted(answersheet.AnswerSheetSubmitted{
+		AnswerSheetID:     42,
+		QuestionnaireCode: "PHQ9",
+		TesteeID:          1001,
+		TotalScore:        12,
+		SubmittedAt:       submittedAt,
+	})
+
+	_ = conn.SetReadDeadline(time.Now().Add(5 * time.Second))
+	var notification SubmissionNotification
+	if err := conn.ReadJSON(&notification); err != nil {
+		t.Fatalf("ReadJSON() error = %v", err)
+	}
+	want := SubmissionNotification{AnswersheetCode: "42", RespondentID: 1001, SubmittedAt: submittedAt, TotalScore: 12}
+	if notification != want {
+		t.Errorf("notification = %+v, want %+v", notification, want)
+	}
+}
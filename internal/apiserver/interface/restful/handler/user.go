@@ -6,10 +6,13 @@ import (
 	"github.com/asaskevich/govalidator"
 	"github.com/gin-gonic/gin"
 
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/user/port"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/request"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/response"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // UserHandler 用户HTTP处理器
@@ -20,16 +23,20 @@ type UserHandler struct {
 	userEditor          port.UserEditor
 	userActivator       port.UserActivator
 	userPasswordChanger port.PasswordChanger
+	preferenceManager   port.PreferenceManager
+	sessionManager      port.SessionManager
 }
 
 // NewUserHandler 创建用户处理器
-func NewUserHandler(userCreator port.UserCreator, userQueryer port.UserQueryer, userEditor port.UserEditor, userActivator port.UserActivator, userPasswordChanger port.PasswordChanger) *UserHandler {
+func NewUserHandler(userCreator port.UserCreator, userQueryer port.UserQueryer, userEditor port.UserEditor, userActivator port.UserActivator, userPasswordChanger port.PasswordChanger, preferenceManager port.PreferenceManager, sessionManager port.SessionManager) *UserHandler {
 	return &UserHandler{
 		userCreator:         userCreator,
 		userQueryer:         userQueryer,
 		userEditor:          userEditor,
 		userActivator:       userActivator,
 		userPasswordChanger: userPasswordChanger,
+		preferenceManager:   preferenceManager,
+		sessionManager:      sessionManager,
 	}
 }
 
@@ -98,3 +105,115 @@ func (h *UserHandler) GetUserProfile(c *gin.Context) {
 
 	h.SuccessResponse(c, response)
 }
+
+// GetPreferences 获取当前用户偏好设置
+// GET /api/v1/users/me/preferences
+func (h *UserHandler) GetPreferences(c *gin.Context) {
+	userID, err := h.CurrentUserID(c, h.userQueryer)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	pref, err := h.preferenceManager.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, toUserPreferenceResponse(pref))
+}
+
+// UpdatePreferences 更新当前用户偏好设置
+// PUT /api/v1/users/me/preferences
+func (h *UserHandler) UpdatePreferences(c *gin.Context) {
+	var req request.UpdatePreferencesRequest
+	if err := h.BindJSON(c, &req); err != nil {
+		return
+	}
+	if ok, err := govalidator.ValidateStruct(req); !ok {
+		h.ErrorResponse(c, errors.WrapC(err, code.ErrUserPreferenceInvalid, "偏好设置参数无效"))
+		return
+	}
+
+	userID, err := h.CurrentUserID(c, h.userQueryer)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	pref := &user.UserPreference{
+		UserID:                    userID,
+		Language:                  req.Language,
+		Theme:                     req.Theme,
+		LastVisitedQuestionnaires: req.LastVisitedQuestionnaires,
+		NotificationEnabled:       req.NotificationEnabled,
+	}
+	if err := h.preferenceManager.UpdatePreferences(c.Request.Context(), pref); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponse(c, toUserPreferenceResponse(pref))
+}
+
+// ListSessions 获取当前用户的登录会话
+// GET /auth/sessions
+func (h *UserHandler) ListSessions(c *gin.Context) {
+	userID, err := h.CurrentUserID(c, h.userQueryer)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	sessions, err := h.sessionManager.ListSessions(c.Request.Context(), userID)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	currentID := c.GetString(middleware.SessionIDKey)
+	items := make([]response.UserSessionResponse, 0, len(sessions))
+	for _, session := range sessions {
+		items = append(items, response.UserSessionResponse{
+			ID:           session.ID,
+			Device:       session.Device,
+			UserAgent:    session.UserAgent,
+			IP:           session.IP,
+			CreatedAt:    session.CreatedAt.Format(time.RFC3339),
+			LastActiveAt: session.LastActiveAt.Format(time.RFC3339),
+			RememberMe:   session.Remembered(),
+			Current:      session.ID == currentID,
+		})
+	}
+
+	h.SuccessResponse(c, items)
+}
+
+// RevokeSession 撤销当前用户的指定登录会话，会话关联的令牌随之失效
+// DELETE /auth/sessions/:sessionID
+func (h *UserHandler) RevokeSession(c *gin.Context) {
+	userID, err := h.CurrentUserID(c, h.userQueryer)
+	if err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	if err := h.sessionManager.RevokeSession(c.Request.Context(), userID, c.Param("sessionID")); err != nil {
+		h.ErrorResponse(c, err)
+		return
+	}
+
+	h.SuccessResponseWithMessage(c, "登录会话已撤销", nil)
+}
+
+// toUserPreferenceResponse 将用户偏好设置转换为响应
+func toUserPreferenceResponse(pref *user.UserPreference) response.UserPreferenceResponse {
+	return response.UserPreferenceResponse{
+		UserID:                    pref.UserID,
+		Language:                  pref.Language,
+		Theme:                     pref.Theme,
+		LastVisitedQuestionnaires: pref.LastVisitedQuestionnaires,
+		NotificationEnabled:       pref.NotificationEnabled,
+	}
+}
@@ -97,3 +97,49 @@ func (m *AnswerSheetMapper) ToAnswerSheetDetailViewModel(dto dto.AnswerSheetDeta
 		UpdatedAt:     dto.UpdatedAt,
 	}
 }
+
+// ToProgressReportViewModel 将作答进度 DTO 转换为视图模型
+func (m *AnswerSheetMapper) ToProgressReportViewModel(report dto.ProgressReportDTO) viewmodel.ProgressReportViewModel {
+	sections := make([]viewmodel.SectionProgressItemViewModel, 0, len(report.SectionProgress))
+	for _, item := range report.SectionProgress {
+		sections = append(sections, viewmodel.SectionProgressItemViewModel{
+			SectionCode:       item.SectionCode,
+			SectionTitle:      item.SectionTitle,
+			TotalQuestions:    item.TotalQuestions,
+			AnsweredQuestions: item.AnsweredQuestions,
+			IsComplete:        item.IsComplete,
+		})
+	}
+
+	return viewmodel.ProgressReportViewModel{
+		TotalQuestions:    report.TotalQuestions,
+		AnsweredQuestions: report.AnsweredQuestions,
+		IsComplete:        report.IsComplete,
+		SectionProgress:   sections,
+		CurrentSection:    report.CurrentSection,
+	}
+}
+
+// ToInterpretReportViewModel 将解读报告 DTO 转换为视图模型
+func (m *AnswerSheetMapper) ToInterpretReportViewModel(report dto.InterpretReportDTO) viewmodel.InterpretReportViewModel {
+	items := make([]viewmodel.InterpretItemViewModel, 0, len(report.InterpretItems))
+	for _, item := range report.InterpretItems {
+		items = append(items, viewmodel.InterpretItemViewModel{
+			FactorCode: item.FactorCode,
+			Title:      item.Title,
+			Score:      item.Score,
+			Content:    item.Content,
+		})
+	}
+
+	return viewmodel.InterpretReportViewModel{
+		ID:               report.ID,
+		AnswerSheetID:    report.AnswerSheetId,
+		MedicalScaleCode: report.MedicalScaleCode,
+		Title:            report.Title,
+		Description:      report.Description,
+		Score:            report.Score,
+		ScoreType:        report.ScoreType,
+		InterpretItems:   items,
+	}
+}
@@ -20,10 +20,14 @@ func (m *QuestionMapper) ToDTO(vm *viewmodel.QuestionDTO) *dto.QuestionDTO {
 	}
 
 	questionDTO := &dto.QuestionDTO{
-		Code:  vm.Code,
-		Type:  vm.Type,
-		Title: vm.Title,
-		Tips:  vm.Tips,
+		Code:             vm.Code,
+		Type:             vm.Type,
+		Title:            vm.Title,
+		Tips:             vm.Tips,
+		AllowedMIMETypes: vm.AllowedMIMETypes,
+		MaxFileSizeBytes: vm.MaxFileSizeBytes,
+		MaxFiles:         vm.MaxFiles,
+		PageBreakBefore:  vm.PageBreakBefore,
 	}
 
 	if vm.Options != nil {
@@ -43,13 +47,32 @@ func (m *QuestionMapper) ToDTO(vm *viewmodel.QuestionDTO) *dto.QuestionDTO {
 			questionDTO.ValidationRules[i] = dto.ValidationRuleDTO{
 				RuleType:    rule.RuleType,
 				TargetValue: rule.TargetValue,
+				Severity:    rule.Severity,
+			}
+		}
+	}
+
+	if vm.ConditionalRequired != nil {
+		questionDTO.ConditionalRequired = make([]dto.ConditionalRequiredDTO, len(vm.ConditionalRequired))
+		for i, dep := range vm.ConditionalRequired {
+			questionDTO.ConditionalRequired[i] = dto.ConditionalRequiredDTO{
+				DependsOnCode:     dep.DependsOnCode,
+				RequiredWhenValue: dep.RequiredWhenValue,
 			}
 		}
 	}
 
 	if vm.CalculationRule != nil {
 		questionDTO.CalculationRule = &dto.CalculationRuleDTO{
-			FormulaType: vm.CalculationRule.FormulaType,
+			FormulaType:   vm.CalculationRule.FormulaType,
+			SourceCodes:   vm.CalculationRule.SourceCodes,
+			DependsOnCode: vm.CalculationRule.DependsOnCode,
+		}
+		for _, mapping := range vm.CalculationRule.ScoreMappings {
+			questionDTO.CalculationRule.ScoreMappings = append(questionDTO.CalculationRule.ScoreMappings, dto.ScoreMappingDTO{
+				WhenValue: mapping.WhenValue,
+				Scores:    mapping.Scores,
+			})
 		}
 	}
 
@@ -63,10 +86,14 @@ func (m *QuestionMapper) ToViewModel(dto *dto.QuestionDTO) *viewmodel.QuestionDT
 	}
 
 	vm := &viewmodel.QuestionDTO{
-		Code:  dto.Code,
-		Type:  dto.Type,
-		Title: dto.Title,
-		Tips:  dto.Tips,
+		Code:             dto.Code,
+		Type:             dto.Type,
+		Title:            dto.Title,
+		Tips:             dto.Tips,
+		AllowedMIMETypes: dto.AllowedMIMETypes,
+		MaxFileSizeBytes: dto.MaxFileSizeBytes,
+		MaxFiles:         dto.MaxFiles,
+		PageBreakBefore:  dto.PageBreakBefore,
 	}
 
 	if dto.Options != nil {
@@ -86,13 +113,32 @@ func (m *QuestionMapper) ToViewModel(dto *dto.QuestionDTO) *viewmodel.QuestionDT
 			vm.ValidationRules[i] = viewmodel.ValidationRuleDTO{
 				RuleType:    rule.RuleType,
 				TargetValue: rule.TargetValue,
+				Severity:    rule.Severity,
+			}
+		}
+	}
+
+	if dto.ConditionalRequired != nil {
+		vm.ConditionalRequired = make([]viewmodel.ConditionalRequiredDTO, len(dto.ConditionalRequired))
+		for i, dep := range dto.ConditionalRequired {
+			vm.ConditionalRequired[i] = viewmodel.ConditionalRequiredDTO{
+				DependsOnCode:     dep.DependsOnCode,
+				RequiredWhenValue: dep.RequiredWhenValue,
 			}
 		}
 	}
 
 	if dto.CalculationRule != nil {
 		vm.CalculationRule = &viewmodel.CalculationRuleDTO{
-			FormulaType: dto.CalculationRule.FormulaType,
+			FormulaType:   dto.CalculationRule.FormulaType,
+			SourceCodes:   dto.CalculationRule.SourceCodes,
+			DependsOnCode: dto.CalculationRule.DependsOnCode,
+		}
+		for _, mapping := range dto.CalculationRule.ScoreMappings {
+			vm.CalculationRule.ScoreMappings = append(vm.CalculationRule.ScoreMappings, viewmodel.ScoreMappingDTO{
+				WhenValue: mapping.WhenValue,
+				Scores:    mapping.Scores,
+			})
 		}
 	}
 
@@ -0,0 +1,118 @@
+package printview
+
+import (
+	_ "embed"
+	"html/template"
+	"io"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/domain/questionnaire/question"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// ContentType 打印页面的媒体类型
+const ContentType = "text/html; charset=utf-8"
+
+// 选项前的填涂标记
+const (
+	markerCircle = "circle" // 单选题，圆圈
+	markerSquare = "square" // 多选题，方框
+)
+
+// answerLines 填空类题目预留的作答行数，未列出的题型不预留
+var answerLines = map[string]int{
+	question.QuestionTypeText.Value():     1,
+	question.QuestionTypeNumber.Value():   1,
+	question.QuestionTypeTextarea.Value(): 4,
+}
+
+//go:embed questionnaire.html
+var questionnaireHTML string
+
+var questionnaireTemplate = template.Must(template.New("questionnaire").Parse(questionnaireHTML))
+
+// questionnairePage 问卷打印页面
+type questionnairePage struct {
+	Code         string
+	Version      string
+	Title        string
+	Instructions string
+	Sections     []printSection
+}
+
+// printSection 打印段落，每个段落从新的一页开始
+type printSection struct {
+	Title     string
+	Questions []printQuestion
+}
+
+// printQuestion 打印题目
+type printQuestion struct {
+	Number  int
+	Title   string
+	Tips    string
+	Marker  string
+	Options []string
+	Lines   []struct{}
+}
+
+// RenderQuestionnaire 将问卷渲染为适合 A4 纸打印的 HTML 页面
+// 段落题作为段落标题，段落之间分页；段落题之前的题目归入无标题的首个段落；其余题目连续编号
+func RenderQuestionnaire(w io.Writer, q *dto.QuestionnaireDTO) error {
+	if err := questionnaireTemplate.Execute(w, newQuestionnairePage(q)); err != nil {
+		return errors.WrapC(err, code.ErrEncodingFailed, "渲染问卷 %s 打印页面失败", q.Code)
+	}
+	return nil
+}
+
+// newQuestionnairePage 将问卷 DTO 转换为打印页面
+func newQuestionnairePage(q *dto.QuestionnaireDTO) questionnairePage {
+	page := questionnairePage{
+		Code:         q.Code,
+		Version:      q.Version,
+		Title:        q.Title,
+		Instructions: q.Description,
+	}
+
+	number := 0
+	for _, qu := range q.Questions {
+		if qu.Type == question.QuestionTypeSection.Value() {
+			page.Sections = append(page.Sections, printSection{Title: qu.Title})
+			continue
+		}
+		if len(page.Sections) == 0 {
+			page.Sections = append(page.Sections, printSection{})
+		}
+
+		number++
+		section := &page.Sections[len(page.Sections)-1]
+		section.Questions = append(section.Questions, newPrintQuestion(number, qu))
+	}
+
+	return page
+}
+
+// newPrintQuestion 将题目 DTO 转换为打印题目，选择题按题型选择填涂标记，填空题预留作答行
+func newPrintQuestion(number int, qu dto.QuestionDTO) printQuestion {
+	pq := printQuestion{
+		Number: number,
+		Title:  qu.Title,
+		Tips:   qu.Tips,
+	}
+
+	switch qu.Type {
+	case question.QuestionTypeRadio.Value():
+		pq.Marker = markerCircle
+	case question.QuestionTypeCheckbox.Value():
+		pq.Marker = markerSquare
+	default:
+		pq.Lines = make([]struct{}, answerLines[qu.Type])
+		return pq
+	}
+
+	for _, option := range qu.Options {
+		pq.Options = append(pq.Options, option.Content)
+	}
+	return pq
+}
@@ -1,6 +1,10 @@
 package request
 
-import "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+import (
+	"time"
+
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
+)
 
 // SaveAnswerSheetRequest 保存答卷请求
 type SaveAnswerSheetRequest struct {
@@ -21,3 +25,23 @@ type ListAnswerSheetsRequest struct {
 	Page                 int    `form:"page" binding:"required,min=1"`
 	PageSize             int    `form:"page_size" binding:"required,min=1,max=100"`
 }
+
+// ScoringReportRequest 导出计分报表请求，日期格式为 YYYY-MM-DD，to 当天的答卷包含在内
+type ScoringReportRequest struct {
+	From time.Time `form:"from" time_format:"2006-01-02"`
+	To   time.Time `form:"to" time_format:"2006-01-02"`
+}
+
+// CreateUploadURLRequest 签发文件上传链接请求
+type CreateUploadURLRequest struct {
+	QuestionnaireCode string `json:"questionnaire_code" valid:"required"`
+	QuestionCode      string `json:"question_code" valid:"required"`
+	FileName          string `json:"file_name" valid:"required"`
+	MimeType          string `json:"mime_type" valid:"required"`
+}
+
+// RescoreRequest 答卷重新计分请求
+type RescoreRequest struct {
+	ScaleCode string `form:"scale_code" binding:"required"`
+	DryRun    bool   `form:"dry_run"`
+}
@@ -0,0 +1,18 @@
+package request
+
+import "time"
+
+// CreateAssignmentRequest 分配问卷请求，due_date 为 RFC3339 时间，省略时不限期
+type CreateAssignmentRequest struct {
+	RespondentID      uint64     `json:"respondent_id" binding:"required"`
+	QuestionnaireCode string     `json:"questionnaire_code" binding:"required"`
+	DueDate           *time.Time `json:"due_date"`
+}
+
+// ListAssignmentsRequest 查询问卷分配请求
+type ListAssignmentsRequest struct {
+	Page              int    `form:"page" binding:"required,min=1"`
+	PageSize          int    `form:"page_size" binding:"required,min=1,max=100"`
+	RespondentID      uint64 `form:"respondent_id"`
+	QuestionnaireCode string `form:"questionnaire_code"`
+}
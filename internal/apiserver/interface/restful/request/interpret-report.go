@@ -0,0 +1,11 @@
+package request
+
+// ExportHL7Request 导出 HL7 消息请求，患者信息写入 PID 段
+type ExportHL7Request struct {
+	PatientID          string `json:"patient_id"`
+	AssigningAuthority string `json:"assigning_authority"`
+	FamilyName         string `json:"family_name"`
+	GivenName          string `json:"given_name"`
+	BirthDate          string `json:"birth_date" binding:"omitempty,datetime=2006-01-02"`
+	Gender             string `json:"gender" binding:"omitempty,oneof=M F O U m f o u"`
+}
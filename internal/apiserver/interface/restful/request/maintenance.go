@@ -0,0 +1,6 @@
+package request
+
+// UpdateMaintenanceRequest 切换维护模式请求
+type UpdateMaintenanceRequest struct {
+	Enabled *bool `json:"enabled" binding:"required"`
+}
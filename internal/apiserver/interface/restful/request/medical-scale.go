@@ -1,18 +1,55 @@
 package request
 
+import "encoding/json"
+
 // CreateMedicalScaleRequest 创建医学量表请求
 type CreateMedicalScaleRequest struct {
-	Code                 string `json:"code" binding:"required"`
-	Title                string `json:"title" binding:"required"`
-	QuestionnaireCode    string `json:"questionnaire_code" binding:"required"`
-	QuestionnaireVersion string `json:"questionnaire_version" binding:"required"`
+	Code                 string               `json:"code" binding:"required"`
+	Title                string               `json:"title" binding:"required"`
+	QuestionnaireCode    string               `json:"questionnaire_code" binding:"required"`
+	QuestionnaireVersion string               `json:"questionnaire_version" binding:"required"`
+	ScoringConfig        ScoringConfigRequest `json:"scoring_config"`
+	RuleStrategyConfig   json.RawMessage      `json:"rule_strategy_config"` // 计分策略配置，为空时总分为各题得分之和
 }
 
 // UpdateMedicalScaleRequest 更新医学量表基础信息请求
 type UpdateMedicalScaleRequest struct {
-	Title                string `json:"title" binding:"required"`
-	QuestionnaireCode    string `json:"questionnaire_code" binding:"required"`
-	QuestionnaireVersion string `json:"questionnaire_version" binding:"required"`
+	Title                string               `json:"title" binding:"required"`
+	QuestionnaireCode    string               `json:"questionnaire_code" binding:"required"`
+	QuestionnaireVersion string               `json:"questionnaire_version" binding:"required"`
+	ScoringConfig        ScoringConfigRequest `json:"scoring_config"`
+	RuleStrategyConfig   json.RawMessage      `json:"rule_strategy_config"` // 计分策略配置，为空时总分为各题得分之和
+}
+
+// ScoringConfigRequest 计分配置请求
+type ScoringConfigRequest struct {
+	// NormalizeToPercentage 是否将总分换算为百分制
+	NormalizeToPercentage bool `json:"normalize_to_percentage"`
+	// ScoreBands 总分的临床切分分数段，分数范围采用左闭右开区间 [min, max)，必须连续且不重叠
+	ScoreBands []ScoreBandRequest `json:"score_bands"`
+	// Dimensions 计分维度，各维度在总分之外独立计算原始分，配置常模时换算 T 分数和百分位
+	Dimensions []DimensionRequest `json:"dimensions"`
+}
+
+// DimensionRequest 计分维度请求
+type DimensionRequest struct {
+	Code          string            `json:"code" binding:"required"`
+	Label         string            `json:"label" binding:"required"`
+	QuestionCodes []string          `json:"question_codes" binding:"required,min=1"`
+	NormGroup     *NormGroupRequest `json:"norm_group"`
+}
+
+// NormGroupRequest 常模组请求
+type NormGroupRequest struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev" binding:"gt=0"`
+}
+
+// ScoreBandRequest 分数段请求
+type ScoreBandRequest struct {
+	ScoreRange  ScoreRangeRequest `json:"score_range" binding:"required"`
+	Label       string            `json:"label" binding:"required"`
+	Description string            `json:"description"`
 }
 
 // UpdateMedicalScaleFactorRequest 更新医学量表因子请求
@@ -35,6 +72,7 @@ type FactorDTO struct {
 type CalculationRuleRequest struct {
 	FormulaType string   `json:"formula_type" binding:"required"`
 	SourceCodes []string `json:"source_codes" binding:"required,min=1"`
+	Threshold   float64  `json:"threshold"` // 计数规则（count_above_threshold）的阈值
 }
 
 // InterpretRuleRequest 解读规则请求
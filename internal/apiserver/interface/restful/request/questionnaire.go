@@ -9,6 +9,10 @@ type CreateQuestionnaireRequest struct {
 	Title       string `json:"title" valid:"required~标题不能为空"`
 	Description string `json:"description"`
 	ImgUrl      string `json:"img_url"`
+	// GeoRestriction 允许访问的国家（ISO 3166-1 alpha-2 代码），为空表示不限制
+	GeoRestriction []string `json:"geo_restriction"`
+	// Tags 问卷标签，如 ["depression", "anxiety"]
+	Tags []string `json:"tags"`
 }
 
 // EditQuestionnaireBasicInfoRequest 编辑问卷基本信息请求
@@ -16,11 +20,25 @@ type EditQuestionnaireBasicInfoRequest struct {
 	Title       string `json:"title" valid:"required~标题不能为空"`
 	Description string `json:"description"`
 	ImgUrl      string `json:"img_url"`
+	// GeoRestriction 允许访问的国家（ISO 3166-1 alpha-2 代码），为空表示不限制
+	GeoRestriction []string `json:"geo_restriction"`
+	// Tags 问卷标签，如 ["depression", "anxiety"]
+	Tags []string `json:"tags"`
+	// Revision 加载问卷时的修订号，必填，保存时据此检测其他人的并发修改
+	Revision *int64 `json:"revision"`
 }
 
 // EditQuestionnaireQuestionsRequest 编辑问卷问题请求
 type EditQuestionnaireQuestionsRequest struct {
 	Questions []viewmodel.QuestionDTO `json:"questions" valid:"required~问题列表不能为空"`
+	// Revision 加载问卷时的修订号，必填，保存时据此检测其他人的并发修改
+	Revision *int64 `json:"revision"`
+}
+
+// PublishQuestionnaireRequest 发布或下架问卷请求
+type PublishQuestionnaireRequest struct {
+	// Revision 加载问卷时的修订号，必填，保存时据此检测其他人的并发修改
+	Revision *int64 `json:"revision"`
 }
 
 // QueryQuestionnaireRequest 问卷ID请求
@@ -34,3 +52,23 @@ type QueryQuestionnaireListRequest struct {
 	PageSize   int               `json:"page_size" valid:"required"`
 	Conditions map[string]string `json:"conditions"`
 }
+
+// CreatePreviewTokenRequest 创建问卷预览令牌请求
+type CreatePreviewTokenRequest struct {
+	// ExpiresIn 令牌有效期（秒），为空时使用默认有效期
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// SubmitTranslationRequest 提交问卷译文请求
+type SubmitTranslationRequest struct {
+	Language string `json:"language" binding:"required"`
+	// FieldPath 字段路径，如 title、question.q1.title、question.q1.option.A
+	FieldPath      string `json:"field_path" binding:"required"`
+	TranslatedText string `json:"translated_text" binding:"required"`
+}
+
+// ApproveTranslationRequest 审核通过问卷译文请求
+type ApproveTranslationRequest struct {
+	Language  string `json:"language" binding:"required"`
+	FieldPath string `json:"field_path" binding:"required"`
+}
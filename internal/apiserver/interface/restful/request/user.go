@@ -4,3 +4,25 @@ package request
 type UserIDRequest struct {
 	ID uint64 `json:"id" valid:"required"`
 }
+
+// UpdatePreferencesRequest 更新用户偏好设置请求
+type UpdatePreferencesRequest struct {
+	Language                  string   `json:"language"`
+	Theme                     string   `json:"theme" valid:"in(light|dark)"`
+	LastVisitedQuestionnaires []string `json:"last_visited_questionnaires"`
+	NotificationEnabled       bool     `json:"notification_enabled"`
+}
+
+// ListLoginAuditsRequest 查询登录审计记录请求
+type ListLoginAuditsRequest struct {
+	Page     int `form:"page" binding:"required,min=1"`
+	PageSize int `form:"page_size" binding:"required,min=1,max=100"`
+}
+
+// ListUserActivityRequest 查询用户活动记录请求，from、to 为 RFC3339 时间或 2006-01-02 格式的日期
+type ListUserActivityRequest struct {
+	Page     int    `form:"page" binding:"required,min=1"`
+	PageSize int    `form:"page_size" binding:"required,min=1,max=100"`
+	From     string `form:"from"`
+	To       string `form:"to"`
+}
@@ -21,15 +21,51 @@ func NewMedicalScaleResponse(scale *medicalScale.MedicalScale) *MedicalScaleResp
 
 	return &MedicalScaleResponse{
 		Data: &viewmodel.MedicalScaleVM{
-			ID:                scale.GetID().Value(),
-			Code:              scale.GetCode(),
-			Title:             scale.GetTitle(),
-			QuestionnaireCode: scale.GetQuestionnaireCode(),
-			Factors:           mapFactorsToVM(scale.GetFactors()),
+			ID:                 scale.GetID().Value(),
+			Code:               scale.GetCode(),
+			Title:              scale.GetTitle(),
+			QuestionnaireCode:  scale.GetQuestionnaireCode(),
+			Factors:            mapFactorsToVM(scale.GetFactors()),
+			ScoringConfig:      mapScoringConfigToVM(scale.GetScoringConfig()),
+			RuleStrategyConfig: scale.GetRuleStrategyConfig(),
 		},
 	}
 }
 
+// mapScoringConfigToVM 将计分配置转换为视图模型
+func mapScoringConfigToVM(config medicalScale.ScoringConfig) viewmodel.ScoringConfigVM {
+	vm := viewmodel.ScoringConfigVM{
+		NormalizeToPercentage: config.NormalizeToPercentage,
+		ScoreBands:            make([]viewmodel.ScoreBandVM, len(config.ScoreBands)),
+		Dimensions:            make([]viewmodel.DimensionVM, len(config.Dimensions)),
+	}
+	for i, band := range config.ScoreBands {
+		vm.ScoreBands[i] = viewmodel.ScoreBandVM{
+			ScoreRange: viewmodel.ScoreRangeVM{
+				MinScore: band.GetScoreRange().MinScore(),
+				MaxScore: band.GetScoreRange().MaxScore(),
+			},
+			Label:       band.GetLabel(),
+			Description: band.GetDescription(),
+		}
+	}
+	for i, d := range config.Dimensions {
+		questionCodes := make([]string, len(d.QuestionCodes))
+		for j, questionCode := range d.QuestionCodes {
+			questionCodes[j] = questionCode.Value()
+		}
+		vm.Dimensions[i] = viewmodel.DimensionVM{
+			Code:          d.Code,
+			Label:         d.Label,
+			QuestionCodes: questionCodes,
+		}
+		if d.NormGroup != nil {
+			vm.Dimensions[i].NormGroup = &viewmodel.NormGroupVM{Mean: d.NormGroup.Mean, StdDev: d.NormGroup.StdDev}
+		}
+	}
+	return vm
+}
+
 // mapFactorsToVM 将因子领域对象转换为视图模型
 func mapFactorsToVM(factors []factor.Factor) []viewmodel.FactorVM {
 	if factors == nil {
@@ -50,6 +86,7 @@ func mapFactorsToVM(factors []factor.Factor) []viewmodel.FactorVM {
 				factorVM.CalculationRule = viewmodel.CalculationRuleVM{
 					FormulaType: calcRule.GetFormula().String(),
 					SourceCodes: calcRule.GetSourceCodes(),
+					Threshold:   calcRule.GetThreshold(),
 				}
 			}
 		}
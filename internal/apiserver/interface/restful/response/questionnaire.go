@@ -1,6 +1,8 @@
 package response
 
 import (
+	"time"
+
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/application/dto"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/mapper"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/viewmodel"
@@ -15,6 +17,11 @@ type QuestionnaireResponse struct {
 	Version     string                  `json:"version"`
 	Status      string                  `json:"status"`
 	Questions   []viewmodel.QuestionDTO `json:"questions,omitempty"`
+	Pages       []viewmodel.PageVM      `json:"pages,omitempty"`
+
+	GeoRestriction []string `json:"geo_restriction,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	Revision       int64    `json:"revision"`
 }
 
 // QuestionnaireListResponse 问卷列表响应
@@ -25,6 +32,24 @@ type QuestionnaireListResponse struct {
 	PageSize       int                     `json:"page_size"`
 }
 
+// QuestionListResponse 问卷问题列表响应
+type QuestionListResponse struct {
+	Code      string                  `json:"code"`
+	Questions []viewmodel.QuestionDTO `json:"questions"`
+}
+
+// PreviewQuestionnaireResponse 问卷预览响应，Preview 恒为 true，提示调用方该问卷仅供预览、不能作答
+type PreviewQuestionnaireResponse struct {
+	*QuestionnaireResponse
+	Preview bool `json:"preview"`
+}
+
+// PreviewTokenResponse 问卷预览令牌响应
+type PreviewTokenResponse struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
 // NewQuestionnaireResponse 创建问卷响应
 func NewQuestionnaireResponse(dto *dto.QuestionnaireDTO) *QuestionnaireResponse {
 	if dto == nil {
@@ -39,11 +64,24 @@ func NewQuestionnaireResponse(dto *dto.QuestionnaireDTO) *QuestionnaireResponse
 		Version:     dto.Version,
 		Status:      dto.Status,
 		Questions:   mapper.NewQuestionMapper().ToViewModels(dto.Questions),
+		Pages:       mapPagesToVM(dto.Pages),
+
+		GeoRestriction: dto.GeoRestriction,
+		Tags:           dto.Tags,
+		Revision:       dto.Revision,
 	}
 
 	return response
 }
 
+// NewQuestionListResponse 创建问卷问题列表响应
+func NewQuestionListResponse(code string, questions []dto.QuestionDTO) *QuestionListResponse {
+	return &QuestionListResponse{
+		Code:      code,
+		Questions: mapper.NewQuestionMapper().ToViewModels(questions),
+	}
+}
+
 // NewQuestionnaireListResponse 创建问卷列表响应
 func NewQuestionnaireListResponse(dtos []*dto.QuestionnaireDTO, total int64, page, pageSize int) *QuestionnaireListResponse {
 	if dtos == nil {
@@ -62,3 +100,32 @@ func NewQuestionnaireListResponse(dtos []*dto.QuestionnaireDTO, total int64, pag
 		PageSize:       pageSize,
 	}
 }
+
+// NewPreviewQuestionnaireResponse 创建问卷预览响应
+func NewPreviewQuestionnaireResponse(dto *dto.QuestionnaireDTO) *PreviewQuestionnaireResponse {
+	return &PreviewQuestionnaireResponse{
+		QuestionnaireResponse: NewQuestionnaireResponse(dto),
+		Preview:               true,
+	}
+}
+
+// mapPagesToVM 将问卷页面转换为视图模型
+func mapPagesToVM(pages []dto.PageDTO) []viewmodel.PageVM {
+	if len(pages) == 0 {
+		return nil
+	}
+
+	vms := make([]viewmodel.PageVM, 0, len(pages))
+	for _, page := range pages {
+		vm := viewmodel.PageVM{Sections: make([]viewmodel.SectionVM, 0, len(page.Sections))}
+		for _, section := range page.Sections {
+			vm.Sections = append(vm.Sections, viewmodel.SectionVM{
+				Code:          section.Code,
+				Title:         section.Title,
+				QuestionCodes: section.QuestionCodes,
+			})
+		}
+		vms = append(vms, vm)
+	}
+	return vms
+}
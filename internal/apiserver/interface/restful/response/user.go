@@ -16,6 +16,27 @@ type UserResponse struct {
 	UpdatedAt    string `json:"updated_at"`
 }
 
+// UserPreferenceResponse 用户偏好设置响应
+type UserPreferenceResponse struct {
+	UserID                    uint64   `json:"user_id"`
+	Language                  string   `json:"language"`
+	Theme                     string   `json:"theme"`
+	LastVisitedQuestionnaires []string `json:"last_visited_questionnaires"`
+	NotificationEnabled       bool     `json:"notification_enabled"`
+}
+
+// UserSessionResponse 用户登录会话响应
+type UserSessionResponse struct {
+	ID           string `json:"id"`
+	Device       string `json:"device"`
+	UserAgent    string `json:"user_agent"`
+	IP           string `json:"ip"`
+	CreatedAt    string `json:"created_at"`
+	LastActiveAt string `json:"last_active_at"`
+	RememberMe   bool   `json:"remember_me"`
+	Current      bool   `json:"current"`
+}
+
 // UserListResponse 用户列表响应
 type UserListResponse struct {
 	Users      []*UserResponse `json:"users"`
@@ -1,5 +1,7 @@
 package viewmodel
 
+import "time"
+
 // AnswerDTO 答案
 type AnswerDTO struct {
 	QuestionCode string  `json:"question_code" valid:"required"`
@@ -7,3 +9,19 @@ type AnswerDTO struct {
 	Value        any     `json:"value"`
 	Score        float64 `json:"score"`
 }
+
+// FileReferenceDTO 文件引用
+type FileReferenceDTO struct {
+	FileName   string `json:"file_name"`   // 文件名
+	MimeType   string `json:"mime_type"`   // 文件类型
+	StorageKey string `json:"storage_key"` // 存储键，提交答卷时引用
+	SizeBytes  int64  `json:"size_bytes"`  // 文件大小（字节）
+}
+
+// UploadLinkDTO 签名上传链接
+type UploadLinkDTO struct {
+	UploadID  string            `json:"upload_id"`  // 上传ID，提交答卷时作为文件上传题的答案
+	URL       string            `json:"url"`        // 表单上传地址
+	Fields    map[string]string `json:"fields"`     // 表单上传需携带的字段
+	ExpiresAt time.Time         `json:"expires_at"` // 链接过期时间
+}
@@ -46,3 +46,21 @@ type AnswerSheetDetailViewModel struct {
 	CreatedAt     string               `json:"created_at"`
 	UpdatedAt     string               `json:"updated_at"`
 }
+
+// ProgressReportViewModel 答卷作答进度视图模型
+type ProgressReportViewModel struct {
+	TotalQuestions    int                            `json:"total_questions"`
+	AnsweredQuestions int                            `json:"answered_questions"`
+	IsComplete        bool                           `json:"is_complete"`
+	SectionProgress   []SectionProgressItemViewModel `json:"section_progress"`
+	CurrentSection    string                         `json:"current_section"`
+}
+
+// SectionProgressItemViewModel 段落作答进度视图模型
+type SectionProgressItemViewModel struct {
+	SectionCode       string `json:"section_code"`
+	SectionTitle      string `json:"section_title"`
+	TotalQuestions    int    `json:"total_questions"`
+	AnsweredQuestions int    `json:"answered_questions"`
+	IsComplete        bool   `json:"is_complete"`
+}
@@ -0,0 +1,22 @@
+package viewmodel
+
+// AssignmentViewModel 问卷分配视图模型，时间为 RFC3339 格式
+type AssignmentViewModel struct {
+	AssignmentCode    string `json:"assignment_code"`
+	RespondentID      uint64 `json:"respondent_id"`
+	QuestionnaireCode string `json:"questionnaire_code"`
+	AssignedBy        uint64 `json:"assigned_by"`
+	DueDate           string `json:"due_date,omitempty"`
+	CompletedAt       string `json:"completed_at,omitempty"`
+	ReminderSentAt    string `json:"reminder_sent_at,omitempty"`
+	Overdue           bool   `json:"overdue"`
+	CreatedAt         string `json:"created_at"`
+}
+
+// AssignmentListViewModel 问卷分配列表视图模型
+type AssignmentListViewModel struct {
+	Items      []AssignmentViewModel `json:"items"`
+	TotalCount int64                 `json:"total_count"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+}
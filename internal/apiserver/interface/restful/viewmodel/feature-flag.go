@@ -0,0 +1,8 @@
+package viewmodel
+
+// FeatureFlagViewModel 功能开关视图模型
+type FeatureFlagViewModel struct {
+	Name       string `json:"name"`
+	Enabled    bool   `json:"enabled"`
+	Percentage int    `json:"percentage"`
+}
@@ -0,0 +1,21 @@
+package viewmodel
+
+// InterpretReportViewModel 解读报告视图模型
+type InterpretReportViewModel struct {
+	ID               uint64                   `json:"id"`
+	AnswerSheetID    uint64                   `json:"answer_sheet_id"`
+	MedicalScaleCode string                   `json:"medical_scale_code"`
+	Title            string                   `json:"title"`
+	Description      string                   `json:"description"`
+	Score            float64                  `json:"score"`
+	ScoreType        string                   `json:"score_type"`
+	InterpretItems   []InterpretItemViewModel `json:"interpret_items"`
+}
+
+// InterpretItemViewModel 解读项视图模型
+type InterpretItemViewModel struct {
+	FactorCode string  `json:"factor_code"`
+	Title      string  `json:"title"`
+	Score      float64 `json:"score"`
+	Content    string  `json:"content"`
+}
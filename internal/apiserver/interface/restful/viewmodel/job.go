@@ -0,0 +1,20 @@
+package viewmodel
+
+// JobViewModel 定时任务视图模型
+type JobViewModel struct {
+	Name      string `json:"name"`
+	Schedule  string `json:"schedule"`
+	LastRunAt string `json:"last_run_at,omitempty"`
+	LastError string `json:"last_error,omitempty"`
+	NextRunAt string `json:"next_run_at,omitempty"`
+}
+
+// JobLockStatusViewModel 定时任务分布式锁状态视图模型
+type JobLockStatusViewModel struct {
+	Name        string `json:"name"`
+	LockEnabled bool   `json:"lock_enabled"`
+	Key         string `json:"key,omitempty"`
+	Locked      bool   `json:"locked"`
+	LockOwner   string `json:"lock_owner,omitempty"`
+	TTLSeconds  int64  `json:"ttl_seconds,omitempty"`
+}
@@ -0,0 +1,20 @@
+package viewmodel
+
+// LoginAuditViewModel 登录审计记录视图模型
+type LoginAuditViewModel struct {
+	Username  string `json:"username"`
+	IP        string `json:"ip"`
+	UserAgent string `json:"user_agent"`
+	Method    string `json:"method"`
+	Outcome   string `json:"outcome"`
+	Reason    string `json:"reason,omitempty"`
+	CreatedAt string `json:"created_at"`
+}
+
+// LoginAuditListViewModel 登录审计记录列表视图模型
+type LoginAuditListViewModel struct {
+	Items      []LoginAuditViewModel `json:"items"`
+	TotalCount int64                 `json:"total_count"`
+	Page       int                   `json:"page"`
+	PageSize   int                   `json:"page_size"`
+}
@@ -0,0 +1,7 @@
+package viewmodel
+
+// MaintenanceViewModel 维护模式视图模型
+type MaintenanceViewModel struct {
+	Enabled           bool  `json:"enabled"`
+	RetryAfterSeconds int64 `json:"retry_after_seconds"`
+}
@@ -1,14 +1,46 @@
 package viewmodel
 
+import "encoding/json"
+
 // MedicalScaleVM 医学量表视图模型
 type MedicalScaleVM struct {
-	ID                   uint64     `json:"id"`
-	Code                 string     `json:"code"`
-	Title                string     `json:"title"`
-	Description          string     `json:"description"`
-	QuestionnaireCode    string     `json:"questionnaire_code"`
-	QuestionnaireVersion string     `json:"questionnaire_version"`
-	Factors              []FactorVM `json:"factors"`
+	ID                   uint64          `json:"id"`
+	Code                 string          `json:"code"`
+	Title                string          `json:"title"`
+	Description          string          `json:"description"`
+	QuestionnaireCode    string          `json:"questionnaire_code"`
+	QuestionnaireVersion string          `json:"questionnaire_version"`
+	Factors              []FactorVM      `json:"factors"`
+	ScoringConfig        ScoringConfigVM `json:"scoring_config"`
+	RuleStrategyConfig   json.RawMessage `json:"rule_strategy_config,omitempty"`
+}
+
+// ScoringConfigVM 计分配置视图模型
+type ScoringConfigVM struct {
+	NormalizeToPercentage bool          `json:"normalize_to_percentage"`
+	ScoreBands            []ScoreBandVM `json:"score_bands"`
+	Dimensions            []DimensionVM `json:"dimensions"`
+}
+
+// DimensionVM 计分维度视图模型
+type DimensionVM struct {
+	Code          string       `json:"code"`
+	Label         string       `json:"label"`
+	QuestionCodes []string     `json:"question_codes"`
+	NormGroup     *NormGroupVM `json:"norm_group,omitempty"`
+}
+
+// NormGroupVM 常模组视图模型
+type NormGroupVM struct {
+	Mean   float64 `json:"mean"`
+	StdDev float64 `json:"std_dev"`
+}
+
+// ScoreBandVM 分数段视图模型
+type ScoreBandVM struct {
+	ScoreRange  ScoreRangeVM `json:"score_range"`
+	Label       string       `json:"label"`
+	Description string       `json:"description"`
 }
 
 // FactorVM 因子视图模型
@@ -25,6 +57,7 @@ type FactorVM struct {
 type CalculationRuleVM struct {
 	FormulaType string   `json:"formula_type"`
 	SourceCodes []string `json:"source_codes"`
+	Threshold   float64  `json:"threshold,omitempty"`
 }
 
 // InterpretRuleVM 解读规则视图模型
@@ -0,0 +1,12 @@
+package viewmodel
+
+// HookDeliveryViewModel 通知投递记录视图模型
+type HookDeliveryViewModel struct {
+	Event             string `json:"event"`
+	QuestionnaireCode string `json:"questionnaire_code"`
+	Attempt           int    `json:"attempt"`
+	StatusCode        int    `json:"status_code"`
+	Succeeded         bool   `json:"succeeded"`
+	Error             string `json:"error,omitempty"`
+	CreatedAt         string `json:"created_at"`
+}
@@ -11,9 +11,30 @@ type QuestionDTO struct {
 	Placeholder string      `json:"placeholder"`       // 问题占位符
 	Options     []OptionDTO `json:"options,omitempty"` // 问题选项（可选项，结构化题型）
 
+	// 文件上传约束（仅文件上传题）
+	AllowedMIMETypes []string `json:"allowed_mime_types,omitempty"`  // 允许上传的文件类型
+	MaxFileSizeBytes int64    `json:"max_file_size_bytes,omitempty"` // 单个文件大小上限（字节）
+	MaxFiles         int      `json:"max_files,omitempty"`           // 文件数量上限
+
+	// 段落属性（仅段落题）
+	PageBreakBefore bool `json:"page_break_before,omitempty"` // 段落前是否分页
+
 	// 能力属性
-	ValidationRules []ValidationRuleDTO `json:"validation_rules,omitempty"` // 校验规则（可选项）
-	CalculationRule *CalculationRuleDTO `json:"calculation_rule,omitempty"` // 问题算分规则（可选项，结构化题型）
+	ValidationRules     []ValidationRuleDTO      `json:"validation_rules,omitempty"`     // 校验规则（可选项）
+	ConditionalRequired []ConditionalRequiredDTO `json:"conditional_required,omitempty"` // 条件必填规则（可选项）
+	CalculationRule     *CalculationRuleDTO      `json:"calculation_rule,omitempty"`     // 问题算分规则（可选项，结构化题型）
+}
+
+// PageVM 问卷页面，前端按页面渲染多页表单
+type PageVM struct {
+	Sections []SectionVM `json:"sections"`
+}
+
+// SectionVM 问卷段落，第一个段落题之前的题目归入编码为空的段落
+type SectionVM struct {
+	Code          string   `json:"code"`
+	Title         string   `json:"title"`
+	QuestionCodes []string `json:"question_codes"`
 }
 
 // Option 选项
@@ -25,11 +46,27 @@ type OptionDTO struct {
 
 // ValidationRule 校验规则
 type ValidationRuleDTO struct {
-	RuleType    string `json:"rule_type"`    // 规则类型
-	TargetValue string `json:"target_value"` // 目标值
+	RuleType    string `json:"rule_type"`          // 规则类型
+	TargetValue string `json:"target_value"`       // 目标值
+	Severity    string `json:"severity,omitempty"` // 严重级别：error（默认）或 warning
+}
+
+// ConditionalRequired 条件必填规则
+type ConditionalRequiredDTO struct {
+	DependsOnCode     string      `json:"depends_on_code"`     // 依赖的题目编码
+	RequiredWhenValue interface{} `json:"required_when_value"` // 依赖题目答案等于该值时本题必填
 }
 
 // CalculationRule 算分规则
 type CalculationRuleDTO struct {
-	FormulaType string `json:"formula_type"` // 公式类型
+	FormulaType   string            `json:"formula_type"`              // 公式类型
+	SourceCodes   []string          `json:"source_codes,omitempty"`    // 参与计算的选项编码
+	DependsOnCode string            `json:"depends_on_code,omitempty"` // 条件计分引用的题目编码
+	ScoreMappings []ScoreMappingDTO `json:"score_mappings,omitempty"`  // 条件计分映射
+}
+
+// ScoreMappingDTO 条件计分映射
+type ScoreMappingDTO struct {
+	WhenValue string             `json:"when_value"` // 引用题目的答案等于该值时使用本映射
+	Scores    map[string]float64 `json:"scores"`     // 选项编码到分值的映射
 }
@@ -0,0 +1,18 @@
+package viewmodel
+
+// RescoreResultViewModel 答卷重新计分结果视图模型
+type RescoreResultViewModel struct {
+	ScaleCode      string                 `json:"scale_code"`
+	DryRun         bool                   `json:"dry_run"`
+	TotalProcessed int                    `json:"total_processed"`
+	TotalUpdated   int                    `json:"total_updated"`
+	Changes        []ScoreChangeViewModel `json:"changes"`
+	Errors         []string               `json:"errors"`
+}
+
+// ScoreChangeViewModel 答卷得分变化视图模型
+type ScoreChangeViewModel struct {
+	AnswerSheetID uint64  `json:"answersheet_id"`
+	OldScore      float64 `json:"old_score"`
+	NewScore      float64 `json:"new_score"`
+}
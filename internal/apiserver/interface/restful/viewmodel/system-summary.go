@@ -0,0 +1,26 @@
+package viewmodel
+
+// SystemSummaryViewModel 系统概览视图模型
+type SystemSummaryViewModel struct {
+	TotalQuestionnaires  int64                         `json:"total_questionnaires"`
+	ActiveQuestionnaires int64                         `json:"active_questionnaires"`
+	TotalAnswersheets    int64                         `json:"total_answersheets"`
+	TotalRespondents     int64                         `json:"total_respondents"`
+	TotalMedicalScales   int64                         `json:"total_medical_scales"`
+	RecentSubmissions    []DailyCountViewModel         `json:"recent_submissions"`
+	TopQuestionnaires    []QuestionnaireUsageViewModel `json:"top_questionnaires"`
+	GeneratedAt          string                        `json:"generated_at"`
+}
+
+// DailyCountViewModel 每日数量视图模型
+type DailyCountViewModel struct {
+	Date  string `json:"date"`
+	Count int64  `json:"count"`
+}
+
+// QuestionnaireUsageViewModel 问卷答卷提交数视图模型
+type QuestionnaireUsageViewModel struct {
+	QuestionnaireCode string `json:"questionnaire_code"`
+	Title             string `json:"title"`
+	SubmissionCount   int64  `json:"submission_count"`
+}
@@ -0,0 +1,20 @@
+package viewmodel
+
+// UserActivityViewModel 用户活动记录视图模型
+type UserActivityViewModel struct {
+	UserID     uint64 `json:"user_id"`
+	Action     string `json:"action"`
+	Detail     string `json:"detail,omitempty"`
+	IPAddress  string `json:"ip_address"`
+	UserAgent  string `json:"user_agent"`
+	Severity   string `json:"severity"`
+	OccurredAt string `json:"occurred_at"`
+}
+
+// UserActivityListViewModel 用户活动记录列表视图模型
+type UserActivityListViewModel struct {
+	Items      []UserActivityViewModel `json:"items"`
+	TotalCount int64                   `json:"total_count"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+}
@@ -10,14 +10,17 @@ import (
 
 // Options 包含所有配置项
 type Options struct {
-	Log                     *log.Options                           `json:"log"    mapstructure:"log"`
-	GenericServerRunOptions *genericoptions.ServerRunOptions       `json:"server" mapstructure:"server"`
-	GRPCOptions             *genericoptions.GRPCOptions            `json:"grpc"     mapstructure:"grpc"`
-	InsecureServing         *genericoptions.InsecureServingOptions `json:"insecure" mapstructure:"insecure"`
-	SecureServing           *genericoptions.SecureServingOptions   `json:"secure" mapstructure:"secure"`
-	MySQLOptions            *genericoptions.MySQLOptions           `json:"mysql"    mapstructure:"mysql"`
-	RedisOptions            *genericoptions.RedisOptions           `json:"redis"    mapstructure:"redis"`
-	MongoDBOptions          *genericoptions.MongoDBOptions         `json:"mongodb"  mapstructure:"mongodb"`
+	Log                     *log.Options                            `json:"log"    mapstructure:"log"`
+	GenericServerRunOptions *genericoptions.ServerRunOptions        `json:"server" mapstructure:"server"`
+	GRPCOptions             *genericoptions.GRPCOptions             `json:"grpc"     mapstructure:"grpc"`
+	InsecureServing         *genericoptions.InsecureServingOptions  `json:"insecure" mapstructure:"insecure"`
+	SecureServing           *genericoptions.SecureServingOptions    `json:"secure" mapstructure:"secure"`
+	MySQLOptions            *genericoptions.MySQLOptions            `json:"mysql"    mapstructure:"mysql"`
+	RedisOptions            *genericoptions.RedisOptions            `json:"redis"    mapstructure:"redis"`
+	MongoDBOptions          *genericoptions.MongoDBOptions          `json:"mongodb"  mapstructure:"mongodb"`
+	SessionOptions          *genericoptions.SessionOptions          `json:"session"  mapstructure:"session"`
+	JwtOptions              *genericoptions.JwtOptions              `json:"jwt"      mapstructure:"jwt"`
+	AnswerSheetPurgeOptions *genericoptions.AnswerSheetPurgeOptions `json:"answersheet-purge" mapstructure:"answersheet-purge"`
 }
 
 // NewOptions 创建一个 Options 对象，包含默认参数
@@ -31,6 +34,9 @@ func NewOptions() *Options {
 		MySQLOptions:            genericoptions.NewMySQLOptions(),
 		RedisOptions:            genericoptions.NewRedisOptions(),
 		MongoDBOptions:          genericoptions.NewMongoDBOptions(),
+		SessionOptions:          genericoptions.NewSessionOptions(),
+		JwtOptions:              genericoptions.NewJwtOptions(),
+		AnswerSheetPurgeOptions: genericoptions.NewAnswerSheetPurgeOptions(),
 	}
 }
 
@@ -44,6 +50,9 @@ func (o *Options) Flags() (fss cliflag.NamedFlagSets) {
 	o.MySQLOptions.AddFlags(fss.FlagSet("mysql"))
 	o.RedisOptions.AddFlags(fss.FlagSet("redis"))
 	o.MongoDBOptions.AddFlags(fss.FlagSet("mongodb"))
+	o.SessionOptions.AddFlags(fss.FlagSet("session"))
+	o.JwtOptions.AddFlags(fss.FlagSet("jwt"))
+	o.AnswerSheetPurgeOptions.AddFlags(fss.FlagSet("answersheet-purge"))
 
 	return fss
 }
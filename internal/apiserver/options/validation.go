@@ -6,6 +6,10 @@ func (o *Options) Validate() []error {
 
 	errs = append(errs, o.GenericServerRunOptions.Validate()...)
 	errs = append(errs, o.MySQLOptions.Validate()...)
+	errs = append(errs, o.MongoDBOptions.Validate()...)
+	errs = append(errs, o.SessionOptions.Validate()...)
+	errs = append(errs, o.JwtOptions.Validate()...)
+	errs = append(errs, o.AnswerSheetPurgeOptions.Validate()...)
 	errs = append(errs, o.Log.Validate()...)
 
 	return errs
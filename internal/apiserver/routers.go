@@ -7,30 +7,53 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/container"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/restful/handler"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/validation"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 // Router 集中的路由管理器
 type Router struct {
-	container *container.Container
-	auth      *Auth
+	container   *container.Container
+	auth        *Auth
+	maintenance *middleware.MaintenanceMode
 }
 
 // NewRouter 创建路由管理器
-func NewRouter(c *container.Container) *Router {
+func NewRouter(c *container.Container, jwtOptions *genericoptions.JwtOptions) *Router {
 	return &Router{
-		container: c,
-		auth:      NewAuth(c), // 初始化认证配置
+		container:   c,
+		auth:        NewAuth(c, jwtOptions), // 初始化认证配置
+		maintenance: middleware.NewMaintenanceMode(viper.GetBool("maintenance.enabled"), viper.GetDuration("maintenance.retry-after")),
 	}
 }
 
 // RegisterRoutes 注册所有路由
 func (r *Router) RegisterRoutes(engine *gin.Engine) {
+	// 注册自定义绑定校验标签
+	if err := validation.RegisterBindingValidators(); err != nil {
+		log.Errorf("Failed to register binding validators: %v", err)
+	}
+
+	// 维护模式下拒绝修改类请求，登录和切换维护模式的接口除外
+	engine.Use(middleware.Maintenance(r.maintenance,
+		"/auth/login",
+		"/auth/refresh",
+		"/auth/otp/validate",
+		"/api/v1/admin/maintenance",
+	))
+
 	// 注册公开路由（不需要认证）
 	r.registerPublicRoutes(engine)
 
 	// 注册需要认证的路由
 	r.registerProtectedRoutes(engine)
 
+	// 注册 WebSocket 路由
+	r.registerWebSocketRoutes(engine)
+
 	fmt.Printf("🔗 Registered routes for: public, protected(user, questionnaire)\n")
 }
 
@@ -46,7 +69,19 @@ func (r *Router) registerPublicRoutes(engine *gin.Engine) {
 		jwtStrategy := r.auth.NewJWTAuth()
 		auth.POST("/login", jwtStrategy.LoginHandler)
 		auth.POST("/logout", jwtStrategy.LogoutHandler)
-		auth.POST("/refresh", jwtStrategy.RefreshHandler)
+		auth.POST("/refresh", r.auth.NewRefreshHandler(&jwtStrategy))
+
+		// 一次性密码二次验证
+		auth.POST("/otp/setup", jwtStrategy.MiddlewareFunc(), r.auth.SetupOTP)   // 生成密钥（需登录）
+		auth.POST("/otp/verify", jwtStrategy.MiddlewareFunc(), r.auth.VerifyOTP) // 校验一次性密码并开启二次验证（需登录）
+		auth.POST("/otp/validate", r.auth.NewOTPValidateHandler(&jwtStrategy))   // 中间令牌换取正式令牌
+
+		// 登录会话管理，未配置 Redis 时不记录会话
+		if r.container.UserModule.SessionManager != nil {
+			userHandler := r.container.UserModule.UserHandler
+			auth.GET("/sessions", jwtStrategy.MiddlewareFunc(), userHandler.ListSessions)                // 列出当前用户的会话
+			auth.DELETE("/sessions/:sessionID", jwtStrategy.MiddlewareFunc(), userHandler.RevokeSession) // 撤销指定会话
+		}
 	}
 
 	// 公开的API路由
@@ -59,6 +94,11 @@ func (r *Router) registerPublicRoutes(engine *gin.Engine) {
 				"description": "问卷量表管理系统",
 			})
 		})
+
+		// 凭预览令牌查看问卷（含草稿），供没有账号的评审者使用
+		if quesHandler := r.container.QuestionnaireModule.QuesHandler; quesHandler != nil {
+			publicAPI.GET("/questionnaires/preview/:token", quesHandler.Preview)
+		}
 	}
 }
 
@@ -67,6 +107,11 @@ func (r *Router) registerProtectedRoutes(engine *gin.Engine) {
 	// 创建需要认证的API组
 	apiV1 := engine.Group("/api/v1")
 
+	// 预览令牌只能用于查看问卷，在认证之前拒绝以其作为凭证的请求（如提交答卷）
+	if quesHandler := r.container.QuestionnaireModule.QuesHandler; quesHandler != nil {
+		apiV1.Use(quesHandler.RejectPreviewToken)
+	}
+
 	// 应用认证中间件
 	authMiddleware := r.auth.CreateAuthMiddleware("auto") // 自动选择Basic或JWT
 	apiV1.Use(authMiddleware)
@@ -80,6 +125,9 @@ func (r *Router) registerProtectedRoutes(engine *gin.Engine) {
 	// 注册答卷相关的受保护路由
 	r.registerAnswersheetProtectedRoutes(apiV1)
 
+	// 注册文件相关的受保护路由
+	r.registerFileProtectedRoutes(apiV1)
+
 	// 注册医学量表相关的受保护路由
 	r.registerMedicalScaleProtectedRoutes(apiV1)
 
@@ -87,6 +135,20 @@ func (r *Router) registerProtectedRoutes(engine *gin.Engine) {
 	r.registerAdminRoutes(apiV1)
 }
 
+// registerWebSocketRoutes 注册 WebSocket 路由
+// 浏览器无法为 WebSocket 请求设置 Authorization 头，JWT 通过 ?token= 参数传递，在升级连接前校验
+func (r *Router) registerWebSocketRoutes(engine *gin.Engine) {
+	submissionHandler := r.container.AnswersheetModule.SubmissionHandler
+	if submissionHandler == nil {
+		return
+	}
+
+	ws := engine.Group("/ws", r.auth.CreateAuthMiddleware("jwt"))
+	{
+		ws.GET("/questionnaires/:code/submissions", middleware.ScopeGuard("code"), submissionHandler.Subscribe) // 订阅问卷的答卷提交通知
+	}
+}
+
 // registerUserProtectedRoutes 注册用户相关的受保护路由
 func (r *Router) registerUserProtectedRoutes(apiV1 *gin.RouterGroup) {
 	userHandler := r.container.UserModule.UserHandler
@@ -99,6 +161,12 @@ func (r *Router) registerUserProtectedRoutes(apiV1 *gin.RouterGroup) {
 	{
 		// 获取当前用户资料相关
 		users.GET("/profile", userHandler.GetUserProfile)
+
+		// 当前用户偏好设置
+		users.GET("/me/preferences", userHandler.GetPreferences)
+		users.PUT("/me/preferences", userHandler.UpdatePreferences)
+
+		// 当前用户登录会话
 	}
 }
 
@@ -109,20 +177,53 @@ func (r *Router) registerQuestionnaireProtectedRoutes(apiV1 *gin.RouterGroup) {
 		return
 	}
 
+	// 修改类操作仅允许管理范围内的问卷
+	scopeGuard := middleware.ScopeGuard("code")
+
+	// 题型列表，供前端动态构建表单
+	apiV1.GET("/question-types", quesHandler.ListQuestionTypes)
+
 	questionnaires := apiV1.Group("/questionnaires")
 	{
 		// 问卷CRUD操作
-		questionnaires.POST("", quesHandler.CreateQuestionnaire) // 创建问卷
-		questionnaires.GET("", quesHandler.QueryList)            // 获取问卷列表
-		questionnaires.GET("/:code", quesHandler.QueryOne)       // 获取指定问卷
-		questionnaires.PUT("/:code", quesHandler.EditBasicInfo)  // 更新问卷
+		questionnaires.POST("", quesHandler.CreateQuestionnaire)                      // 创建问卷
+		questionnaires.GET("", quesHandler.QueryList)                                 // 获取问卷列表
+		questionnaires.GET("/:code", quesHandler.QueryOne)                            // 获取指定问卷
+		questionnaires.PUT("/:code", scopeGuard, quesHandler.EditBasicInfo)           // 更新问卷
+		questionnaires.DELETE("/:code", scopeGuard, quesHandler.DeleteQuestionnaire)  // 删除问卷
+		questionnaires.GET("/:code/print", scopeGuard, quesHandler.Print)             // 获取问卷打印版式
+		questionnaires.GET("/:code/thumbnail.jpg", scopeGuard, quesHandler.Thumbnail) // 获取问卷缩略图
 
 		// 问卷状态管理
-		questionnaires.POST("/:code/publish", quesHandler.PublishQuestionnaire)   // 发布问卷
-		questionnaires.POST("/:code/archive", quesHandler.UnpublishQuestionnaire) // 归档问卷
+		questionnaires.POST("/:code/publish", scopeGuard, quesHandler.PublishQuestionnaire)   // 发布问卷
+		questionnaires.POST("/:code/archive", scopeGuard, quesHandler.UnpublishQuestionnaire) // 归档问卷
 
 		// 问卷问题管理
-		questionnaires.PUT("/:code/questions", quesHandler.UpdateQuestions) // 更新问卷问题
+		questionnaires.GET("/:code/questions", scopeGuard, quesHandler.QueryQuestions)  // 获取问卷问题
+		questionnaires.PUT("/:code/questions", scopeGuard, quesHandler.UpdateQuestions) // 更新问卷问题
+
+		// 已发布问卷的修订
+		questionnaires.POST("/:code/amendments", scopeGuard, quesHandler.AmendQuestionnaire) // 创建修订版本
+
+		// 向评审者分享问卷预览
+		questionnaires.POST("/:code/preview-tokens", scopeGuard, quesHandler.CreatePreviewToken) // 创建预览令牌
+
+		// 问卷翻译，审核需要 translation-admin 角色
+		if translationHandler := r.container.QuestionnaireModule.TranslationHandler; translationHandler != nil {
+			questionnaires.POST("/:code/translations", scopeGuard, translationHandler.Submit)                                                     // 提交译文
+			questionnaires.POST("/:code/translations/approve", scopeGuard, middleware.RoleGuard("translation-admin"), translationHandler.Approve) // 审核通过译文
+			questionnaires.GET("/:code/translations/:language", translationHandler.Compile)                                                       // 获取翻译后的问卷
+		}
+
+		// 计分报表
+		if reportHandler := r.container.AnswersheetModule.ScoringReportHandler; reportHandler != nil {
+			questionnaires.GET("/:code/report.xlsx", scopeGuard, reportHandler.ExportXLSX) // 导出计分报表
+		}
+
+		// 答卷导出
+		if exportHandler := r.container.AnswersheetModule.ExportHandler; exportHandler != nil {
+			questionnaires.GET("/:code/answersheets.csv", scopeGuard, exportHandler.ExportCSV) // 流式导出答卷 CSV
+		}
 	}
 }
 
@@ -135,8 +236,30 @@ func (r *Router) registerAnswersheetProtectedRoutes(apiV1 *gin.RouterGroup) {
 
 	answersheets := apiV1.Group("/answersheets")
 	{
-		answersheets.POST("", answersheetHandler.Save)   // 保存答卷
-		answersheets.GET("/:id", answersheetHandler.Get) // 获取答卷
+		answersheets.POST("", answersheetHandler.Save)                                    // 保存答卷
+		answersheets.POST("/submit-and-interpret", answersheetHandler.SubmitAndInterpret) // 提交答卷并解读
+		answersheets.POST("/fhir", answersheetHandler.ImportFHIR)                         // 导入 FHIR 答卷
+		answersheets.GET("/:id", answersheetHandler.Get)                                  // 获取答卷
+		answersheets.GET("/:id/progress", answersheetHandler.Progress)                    // 获取答卷作答进度
+		answersheets.GET("/:id/fhir", answersheetHandler.ExportFHIR)                      // 导出 FHIR 答卷
+	}
+}
+
+// registerFileProtectedRoutes 注册文件相关的受保护路由
+func (r *Router) registerFileProtectedRoutes(apiV1 *gin.RouterGroup) {
+	fileHandler := r.container.AnswersheetModule.FileHandler
+	if fileHandler == nil {
+		return
+	}
+
+	files := apiV1.Group("/files")
+	{
+		files.POST("", fileHandler.Upload) // 预上传文件（文件上传题）
+
+		// 配置了 MinIO/S3 时支持客户端直传
+		if signedUploadHandler := r.container.AnswersheetModule.SignedUploadHandler; signedUploadHandler != nil {
+			files.POST("/upload-urls", signedUploadHandler.CreateUploadURL) // 签发文件上传链接
+		}
 	}
 }
 
@@ -153,17 +276,66 @@ func (r *Router) registerMedicalScaleProtectedRoutes(apiV1 *gin.RouterGroup) {
 		medicalScales.GET("/:code", medicalScaleHandler.Get)
 		medicalScales.PUT("/:code", medicalScaleHandler.UpdateBaseInfo)
 		medicalScales.PUT("/:code/factors", medicalScaleHandler.UpdateFactor)
+		medicalScales.GET("/:code/reliability", medicalScaleHandler.GetReliability)
 	}
 }
 
 // registerAdminRoutes 注册管理员路由
 func (r *Router) registerAdminRoutes(apiV1 *gin.RouterGroup) {
+	jobHandler := handler.NewJobHandler(r.container.Scheduler)
+	maintenanceHandler := handler.NewMaintenanceHandler(r.maintenance)
+	featureFlagHandler := handler.NewFeatureFlagHandler(r.container.FeatureFlags)
+
 	admin := apiV1.Group("/admin")
 	// admin.Use(r.requireAdminRole()) // 需要实现管理员权限检查中间件
 	{
-		admin.GET("/users", r.placeholder)      // 管理员获取所有用户
-		admin.GET("/statistics", r.placeholder) // 系统统计信息
-		admin.GET("/logs", r.placeholder)       // 系统日志
+		admin.GET("/users", r.placeholder)                          // 管理员获取所有用户
+		admin.GET("/statistics", r.placeholder)                     // 系统统计信息
+		admin.GET("/logs", r.placeholder)                           // 系统日志
+		admin.GET("/jobs", jobHandler.List)                         // 定时任务列表
+		admin.GET("/jobs/:name/lock-status", jobHandler.LockStatus) // 定时任务锁状态
+
+		// 维护模式
+		admin.GET("/maintenance", middleware.RequireAdmin(), maintenanceHandler.Get)
+		admin.PUT("/maintenance", middleware.RequireAdmin(), maintenanceHandler.Update)
+
+		// 功能开关
+		admin.GET("/feature-flags", middleware.RequireAdmin(), featureFlagHandler.List)
+
+		// 系统概览
+		if adminHandler := r.container.AdminModule.AdminHandler; adminHandler != nil {
+			admin.GET("/summary", middleware.RequireAdmin(), adminHandler.GetSystemSummary)
+		}
+
+		// 解读报告导出为 HL7 消息
+		if reportHandler := r.container.InterpretReportModule.IRHandler; reportHandler != nil {
+			admin.POST("/reports/:id/hl7", middleware.RequireAdmin(), reportHandler.ExportHL7)
+		}
+
+		// 通知钩子投递记录
+		if hookHandler := r.container.NotificationHookModule.NotificationHookHandler; hookHandler != nil {
+			admin.GET("/hooks/:id/deliveries", middleware.RequireAdmin(), hookHandler.ListDeliveries)
+		}
+
+		// 医学量表更新后重新计算答卷得分
+		if rescoreHandler := r.container.AnswersheetModule.RescoreHandler; rescoreHandler != nil {
+			admin.POST("/rescoring", middleware.RequireAdmin(), rescoreHandler.Rescore)
+		}
+
+		// 问卷分配
+		if assignmentHandler := r.container.AssignmentModule.AssignmentHandler; assignmentHandler != nil {
+			admin.POST("/assignments", middleware.RequireAdmin(), assignmentHandler.Create)
+			admin.GET("/assignments", middleware.RequireAdmin(), assignmentHandler.List)
+		}
+
+		// 用户登录审计记录和活动记录
+		// gin 要求同一位置的路径参数同名，:user 在登录审计中为用户名，在活动记录中为用户ID
+		if loginAuditHandler := r.container.AuthModule.LoginAuditHandler; loginAuditHandler != nil {
+			admin.GET("/users/:user/login-audits", middleware.RequireAdmin(), loginAuditHandler.ListByUsername)
+		}
+		if activityHandler := r.container.UserModule.UserActivityHandler; activityHandler != nil {
+			admin.GET("/users/:user/activity", middleware.RequireAdmin(), activityHandler.ListByUserID)
+		}
 	}
 }
 
@@ -193,10 +365,10 @@ func (r *Router) healthCheck(c *gin.Context) {
 			"application": "questionnaire_service, user_service",
 		},
 		"jwt_config": gin.H{
-			"realm":       viper.GetString("jwt.realm"),
-			"timeout":     viper.GetDuration("jwt.timeout").String(),
-			"max_refresh": viper.GetDuration("jwt.max-refresh").String(),
-			"key_loaded":  viper.GetString("jwt.key") != "", // 不显示实际密钥，只显示是否加载
+			"realm":       r.auth.jwtOptions.Realm,
+			"timeout":     r.auth.jwtOptions.Timeout.String(),
+			"max_refresh": r.auth.jwtOptions.MaxRefresh.String(),
+			"key_loaded":  r.auth.jwtOptions.Key != "", // 不显示实际密钥，只显示是否加载
 		},
 	}
 
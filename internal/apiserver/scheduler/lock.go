@@ -0,0 +1,48 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+)
+
+// DefaultLockTTL 默认任务锁过期时间，任务执行期间定期续期，实例崩溃后由过期自动释放
+const DefaultLockTTL = 10 * time.Minute
+
+// lockKeyPrefix 定时任务锁键前缀
+const lockKeyPrefix = "cron:lock:"
+
+// DistributedLock 分布式锁，保证多副本部署时同一任务同一时刻只在一个实例上执行
+type DistributedLock interface {
+	// Acquire 尝试获取锁，已被其他持有者占用时返回 false
+	Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Refresh 将当前持有者的锁过期时间重置为 ttl，锁已过期或被其他持有者占用时返回 false
+	Refresh(ctx context.Context, key string, ttl time.Duration) (bool, error)
+	// Release 释放当前持有者的锁
+	Release(ctx context.Context, key string) error
+	// Status 查询锁的持有情况
+	Status(ctx context.Context, key string) (*LockStatus, error)
+}
+
+// LockStatus 锁持有状态
+type LockStatus struct {
+	Key       string
+	Locked    bool
+	LockOwner string
+	TTL       time.Duration
+}
+
+// DefaultLockOwner 生成当前实例的锁持有者标识（主机名:进程号），便于排查
+func DefaultLockOwner() string {
+	hostname, err := os.Hostname()
+	if err != nil || hostname == "" {
+		hostname = "unknown"
+	}
+	return fmt.Sprintf("%s:%d", hostname, os.Getpid())
+}
+
+// LockKey 获取任务对应的锁键
+func LockKey(jobName string) string {
+	return lockKeyPrefix + jobName
+}
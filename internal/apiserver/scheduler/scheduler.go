@@ -0,0 +1,241 @@
+package scheduler
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// CronJob 定时任务
+type CronJob interface {
+	// Name 任务名称，在调度器内唯一
+	Name() string
+	// Schedule 标准 cron 表达式（分 时 日 月 周）
+	Schedule() string
+	// Run 执行任务
+	Run(ctx context.Context) error
+}
+
+// JobStatus 定时任务运行状态
+type JobStatus struct {
+	Name      string
+	Schedule  string
+	LastRunAt *time.Time
+	LastError string
+	NextRunAt *time.Time
+}
+
+// jobEntry 已注册的定时任务
+type jobEntry struct {
+	job     CronJob
+	entryID cron.EntryID
+	status  JobStatus
+}
+
+// CronScheduler 基于 cron 表达式的后台任务调度器
+type CronScheduler struct {
+	cron    *cron.Cron
+	ctx     context.Context
+	cancel  context.CancelFunc
+	mu      sync.RWMutex
+	entries map[string]*jobEntry
+	lock    DistributedLock
+	lockTTL time.Duration
+}
+
+// Option 调度器选项
+type Option func(*CronScheduler)
+
+// WithDistributedLock 设置分布式锁，任务执行前需先获取以任务名为键的锁
+func WithDistributedLock(lock DistributedLock, ttl time.Duration) Option {
+	return func(s *CronScheduler) {
+		s.lock = lock
+		if ttl > 0 {
+			s.lockTTL = ttl
+		}
+	}
+}
+
+// NewCronScheduler 创建定时任务调度器
+func NewCronScheduler(opts ...Option) *CronScheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &CronScheduler{
+		cron:    cron.New(),
+		ctx:     ctx,
+		cancel:  cancel,
+		entries: make(map[string]*jobEntry),
+		lockTTL: DefaultLockTTL,
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// Register 注册定时任务
+// cron 表达式无效时任务不会被调度，错误记录在任务状态中
+func (s *CronScheduler) Register(job CronJob) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, exists := s.entries[job.Name()]; exists {
+		log.Errorf("cron job already registered: %s", job.Name())
+		return
+	}
+
+	entry := &jobEntry{
+		job: job,
+		status: JobStatus{
+			Name:     job.Name(),
+			Schedule: job.Schedule(),
+		},
+	}
+	s.entries[job.Name()] = entry
+
+	entryID, err := s.cron.AddFunc(job.Schedule(), func() { s.run(entry) })
+	if err != nil {
+		log.Errorf("invalid cron expression for job %s: %v", job.Name(), err)
+		entry.status.LastError = err.Error()
+		return
+	}
+	entry.entryID = entryID
+}
+
+// Start 启动调度器
+func (s *CronScheduler) Start() {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	s.cron.Start()
+	log.Infof("cron scheduler started with %d jobs", len(s.entries))
+}
+
+// Stop 停止调度器并等待正在执行的任务结束
+func (s *CronScheduler) Stop() {
+	s.cancel()
+	<-s.cron.Stop().Done()
+	log.Info("cron scheduler stopped")
+}
+
+// Jobs 获取所有已注册任务的运行状态，按名称排序
+func (s *CronScheduler) Jobs() []JobStatus {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	jobs := make([]JobStatus, 0, len(s.entries))
+	for _, entry := range s.entries {
+		status := entry.status
+		if entry.entryID != 0 {
+			if next := s.cron.Entry(entry.entryID).Next; !next.IsZero() {
+				status.NextRunAt = &next
+			}
+		}
+		jobs = append(jobs, status)
+	}
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Name < jobs[j].Name })
+	return jobs
+}
+
+// HasJob 判断任务是否已注册
+func (s *CronScheduler) HasJob(name string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	_, exists := s.entries[name]
+	return exists
+}
+
+// LockStatus 查询任务的分布式锁状态，未配置分布式锁时返回 nil
+func (s *CronScheduler) LockStatus(ctx context.Context, name string) (*LockStatus, error) {
+	if s.lock == nil {
+		return nil, nil
+	}
+	return s.lock.Status(ctx, LockKey(name))
+}
+
+// run 获取任务锁后执行任务，锁被其他实例持有时跳过本次执行
+func (s *CronScheduler) run(entry *jobEntry) {
+	if s.lock == nil {
+		s.execute(entry)
+		return
+	}
+
+	key := LockKey(entry.job.Name())
+	acquired, err := s.lock.Acquire(s.ctx, key, s.lockTTL)
+	if err != nil {
+		log.Errorf("failed to acquire lock for cron job %s: %v", entry.job.Name(), err)
+		return
+	}
+	if !acquired {
+		log.Infof("cron job %s is running on another instance, skipped", entry.job.Name())
+		return
+	}
+	stopRenewal := s.renewLock(entry.job.Name(), key)
+	defer func() {
+		stopRenewal()
+		// 使用独立上下文释放锁，避免调度器停止时锁无法释放
+		if err := s.lock.Release(context.Background(), key); err != nil {
+			log.Errorf("failed to release lock for cron job %s: %v", entry.job.Name(), err)
+		}
+	}()
+
+	s.execute(entry)
+}
+
+// renewLock 在任务执行期间每隔 1/3 锁过期时间续期一次，执行时间超过锁过期时间的任务不会被其他实例重复执行
+// 返回的函数停止续期并等待续期协程退出
+func (s *CronScheduler) renewLock(jobName, key string) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(s.lockTTL / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				renewed, err := s.lock.Refresh(context.Background(), key, s.lockTTL)
+				if err != nil {
+					log.Errorf("failed to renew lock for cron job %s: %v", jobName, err)
+					continue
+				}
+				if !renewed {
+					log.Errorf("lock for cron job %s was lost before the job finished", jobName)
+					return
+				}
+			}
+		}
+	}()
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// execute 执行任务并记录运行结果
+func (s *CronScheduler) execute(entry *jobEntry) {
+	startedAt := time.Now()
+	err := entry.job.Run(s.ctx)
+
+	s.mu.Lock()
+	entry.status.LastRunAt = &startedAt
+	entry.status.LastError = ""
+	if err != nil {
+		entry.status.LastError = err.Error()
+	}
+	s.mu.Unlock()
+
+	if err != nil {
+		log.Errorf("cron job %s failed: %v", entry.job.Name(), err)
+		return
+	}
+	log.Infof("cron job %s finished in %s", entry.job.Name(), time.Since(startedAt))
+}
@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// memoryLock 基于内存的分布式锁，模拟多个实例共享的 Redis，锁按 ttl 过期
+type memoryLock struct {
+	mu      sync.Mutex
+	holders map[string]string
+	expires map[string]time.Time
+	owner   string
+}
+
+func newMemoryLock(owner string) *memoryLock {
+	return &memoryLock{holders: make(map[string]string), expires: make(map[string]time.Time), owner: owner}
+}
+
+// held 判断锁是否被持有，调用方需持有 mu
+func (l *memoryLock) held(key string) bool {
+	if _, ok := l.holders[key]; !ok {
+		return false
+	}
+	if time.Now().After(l.expires[key]) {
+		delete(l.holders, key)
+		return false
+	}
+	return true
+}
+
+func (l *memoryLock) Acquire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.held(key) {
+		return false, nil
+	}
+	l.holders[key] = l.owner
+	l.expires[key] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (l *memoryLock) Refresh(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held(key) || l.holders[key] != l.owner {
+		return false, nil
+	}
+	l.expires[key] = time.Now().Add(ttl)
+	return true, nil
+}
+
+func (l *memoryLock) Release(ctx context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.holders, key)
+	return nil
+}
+
+func (l *memoryLock) Status(ctx context.Context, key string) (*LockStatus, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if !l.held(key) {
+		return &LockStatus{Key: key}, nil
+	}
+	return &LockStatus{Key: key, Locked: true, LockOwner: l.holders[key], TTL: time.Until(l.expires[key])}, nil
+}
+
+// blockingJob 执行时阻塞直到 release 关闭，用于制造并发执行窗口
+type blockingJob struct {
+	runs    int32
+	started chan struct{}
+	release chan struct{}
+}
+
+func (j *blockingJob) Name() string     { return "blocking-job" }
+func (j *blockingJob) Schedule() string { return "@every 1h" }
+func (j *blockingJob) Run(ctx context.Context) error {
+	atomic.AddInt32(&j.runs, 1)
+	j.started <- struct{}{}
+	<-j.release
+	return nil
+}
+
+func TestCronScheduler_RunWithLockExecutesOnce(t *testing.T) {
+	lock := newMemoryLock("host-a:1")
+	s := NewCronScheduler(WithDistributedLock(lock, time.Minute))
+	job := &blockingJob{started: make(chan struct{}, 2), release: make(chan struct{})}
+	s.Register(job)
+	entry := s.entries[job.Name()]
+
+	// 第一个实例获取锁并阻塞在任务中
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		s.run(entry)
+	}()
+	<-job.started
+
+	// 第二个实例在锁被持有期间触发同一任务，应直接跳过
+	skipped := make(chan struct{})
+	go func() {
+		defer wg.Done()
+		s.run(entry)
+		close(skipped)
+	}()
+	select {
+	case <-skipped:
+	case <-time.After(time.Second):
+		t.Fatal("second run did not skip while lock was held")
+	}
+
+	status, err := s.LockStatus(context.Background(), job.Name())
+	if err != nil {
+		t.Fatalf("LockStatus() error = %v", err)
+	}
+	if !status.Locked || status.LockOwner != "host-a:1" {
+		t.Errorf("LockStatus() = %+v, want locked by host-a:1", status)
+	}
+
+	close(job.release)
+	wg.Wait()
+
+	if runs := atomic.LoadInt32(&job.runs); runs != 1 {
+		t.Errorf("job runs = %d, want 1", runs)
+	}
+	if status, _ := s.LockStatus(context.Background(), job.Name()); status.Locked {
+		t.Error("lock should be released after job completes")
+	}
+}
+
+func TestCronScheduler_RenewsLockWhileJobRuns(t *testing.T) {
+	const ttl = 60 * time.Millisecond
+	lock := newMemoryLock("host-a:1")
+	s := NewCronScheduler(WithDistributedLock(lock, ttl))
+	job := &blockingJob{started: make(chan struct{}, 2), release: make(chan struct{})}
+	s.Register(job)
+	entry := s.entries[job.Name()]
+
+	done := make(chan struct{})
+	go func() {
+		s.run(entry)
+		close(done)
+	}()
+	<-job.started
+
+	// 任务执行时间超过锁过期时间，续期后其他实例仍无法获取锁
+	time.Sleep(3 * ttl)
+	if acquired, _ := lock.Acquire(context.Background(), LockKey(job.Name()), ttl); acquired {
+		t.Fatal("lock expired while the job was still running")
+	}
+
+	close(job.release)
+	<-done
+	if status, _ := s.LockStatus(context.Background(), job.Name()); status.Locked {
+		t.Error("lock should be released after job completes")
+	}
+}
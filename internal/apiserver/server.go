@@ -3,7 +3,10 @@ package apiserver
 import (
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/config"
 	"github.com/yshujie/questionnaire-scale/internal/apiserver/container"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/infrastructure/redis/ratelimit"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/grpcserver"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
+	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
 	genericapiserver "github.com/yshujie/questionnaire-scale/internal/pkg/server"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 	"github.com/yshujie/questionnaire-scale/pkg/shutdown"
@@ -18,10 +21,20 @@ type apiServer struct {
 	genericAPIServer *genericapiserver.GenericAPIServer
 	// GRPC 服务器
 	grpcServer *grpcserver.Server
+	// GRPC 限流存储
+	grpcRateLimitStore *grpcRateLimitStore
+	// GRPC Bearer 令牌校验器
+	grpcTokenValidator *grpcTokenValidator
 	// 数据库管理器
 	dbManager *DatabaseManager
 	// Container 主容器
 	container *container.Container
+	// 登录会话配置
+	sessionOptions *genericoptions.SessionOptions
+	// 软删除答卷清理任务配置
+	purgeOptions *genericoptions.AnswerSheetPurgeOptions
+	// JWT 令牌配置
+	jwtOptions *genericoptions.JwtOptions
 }
 
 // preparedAPIServer 定义了准备运行的 API 服务器
@@ -43,7 +56,9 @@ func createAPIServer(cfg *config.Config) (*apiServer, error) {
 	}
 
 	// 创建 GRPC 服务器
-	grpcServer, err := buildGRPCServer(cfg)
+	rateLimitStore := newGRPCRateLimitStore()
+	tokenValidator := newGRPCTokenValidator(cfg.JwtOptions)
+	grpcServer, err := buildGRPCServer(cfg, rateLimitStore, tokenValidator)
 	if err != nil {
 		log.Fatalf("Failed to build GRPC server: %v", err)
 		return nil, err
@@ -54,10 +69,15 @@ func createAPIServer(cfg *config.Config) (*apiServer, error) {
 
 	// 创建 API 服务器实例
 	server := &apiServer{
-		gs:               gs,
-		genericAPIServer: genericServer,
-		dbManager:        dbManager,
-		grpcServer:       grpcServer,
+		gs:                 gs,
+		genericAPIServer:   genericServer,
+		dbManager:          dbManager,
+		grpcServer:         grpcServer,
+		grpcRateLimitStore: rateLimitStore,
+		grpcTokenValidator: tokenValidator,
+		sessionOptions:     cfg.SessionOptions,
+		purgeOptions:       cfg.AnswerSheetPurgeOptions,
+		jwtOptions:         cfg.JwtOptions,
 	}
 
 	return server, nil
@@ -82,22 +102,43 @@ func (s *apiServer) PrepareRun() preparedAPIServer {
 		log.Fatalf("Failed to get MongoDB connection: %v", err)
 	}
 
+	// 获取 Redis 客户端（用于定时任务分布式锁和登录会话，未配置时跳过）
+	redisClient, err := s.dbManager.GetRedisClient()
+	if err != nil {
+		log.Warnf("Redis client unavailable, cron jobs run without distributed lock and login sessions are not tracked: %v", err)
+	}
+
+	// gRPC 限流改用 Redis 令牌桶，多实例共享限流计数
+	if redisClient != nil {
+		s.grpcRateLimitStore.Use(ratelimit.NewStore(redisClient))
+	}
+
 	// 创建六边形架构容器（自动发现版本）
-	s.container = container.NewContainer(mysqlDB, mongoDB)
+	s.container = container.NewContainer(mysqlDB, mongoDB, redisClient,
+		container.WithSessionOptions(s.sessionOptions),
+		container.WithAnswerSheetPurgeOptions(s.purgeOptions),
+	)
 
 	// 初始化容器中的所有组件
 	if err := s.container.Initialize(); err != nil {
 		log.Fatalf("Failed to initialize hexagonal architecture container: %v", err)
 	}
+	s.container.PrintStartupSummary()
 
 	// 创建并初始化路由器
-	NewRouter(s.container).RegisterRoutes(s.genericAPIServer.Engine)
+	NewRouter(s.container, s.jwtOptions).RegisterRoutes(s.genericAPIServer.Engine)
+
+	// gRPC 令牌与 HTTP 接口一样校验关联会话是否已撤销
+	s.grpcTokenValidator.UseSessionManager(s.container.UserModule.SessionManager)
 
 	// 注册 GRPC 服务
 	if err := NewGRPCRegistry(s.grpcServer, s.container).RegisterServices(); err != nil {
 		log.Fatalf("Failed to register GRPC services: %v", err)
 	}
 
+	// 模块初始化和服务注册完成后才开始处理 GRPC 业务请求
+	s.grpcServer.SetReady()
+
 	log.Info("🏗️  Hexagonal Architecture initialized successfully!")
 	log.Info("   📦 Domain: questionnaire, user")
 	log.Info("   🔌 Ports: storage, document")
@@ -208,10 +249,28 @@ func buildGenericConfig(cfg *config.Config) (genericConfig *genericapiserver.Con
 }
 
 // buildGRPCServer 构建 GRPC 服务器
-func buildGRPCServer(cfg *config.Config) (*grpcserver.Server, error) {
+func buildGRPCServer(cfg *config.Config, rateLimitStore middleware.RateLimitStore, tokenValidator *grpcTokenValidator) (*grpcserver.Server, error) {
 	// 创建 GRPC 配置
 	grpcConfig := grpcserver.NewConfig()
 
+	// 按方法和客户端IP限流，方法限流可在配置中覆盖
+	grpcConfig.UnaryInterceptors = append(grpcConfig.UnaryInterceptors,
+		middleware.GRPCRateLimitInterceptor(rateLimitStore, grpcRateLimitConfig(cfg.GRPCOptions)))
+
+	// 校验调用方的 API Key 或 JWT，开启前需为内部 gRPC 客户端配置 API Key
+	if cfg.GRPCOptions.RequireAuth {
+		grpcConfig.UnaryInterceptors = append(grpcConfig.UnaryInterceptors,
+			middleware.GRPCAuthInterceptor(grpcAuthConfig(cfg.GRPCOptions, tokenValidator)))
+	}
+
+	// 校验请求的必填字段，缺少时不调用处理器
+	grpcConfig.UnaryInterceptors = append(grpcConfig.UnaryInterceptors,
+		middleware.GRPCValidationInterceptor(grpcRequiredFields...))
+
+	// 按客户端 API 版本协商医学量表服务的响应格式
+	grpcConfig.UnaryInterceptors = append(grpcConfig.UnaryInterceptors,
+		middleware.VersionNegotiationInterceptor(medicalScaleVersionNegotiation))
+
 	// 应用配置选项
 	if err := applyGRPCOptions(cfg, grpcConfig); err != nil {
 		return nil, err
@@ -226,8 +285,23 @@ func applyGRPCOptions(cfg *config.Config, grpcConfig *grpcserver.Config) error {
 	// 应用基本配置
 	grpcConfig.BindAddress = cfg.GRPCOptions.BindAddress
 	grpcConfig.BindPort = cfg.GRPCOptions.BindPort
-
-	// 应用 TLS 配置
+	grpcConfig.ShutdownTimeout = cfg.GRPCOptions.ShutdownTimeout
+	grpcConfig.MaxRecvMsgSize = cfg.GRPCOptions.MaxRecvMsgSize
+	grpcConfig.MaxSendMsgSize = cfg.GRPCOptions.MaxSendMsgSize
+
+	// 应用 keepalive 配置
+	keepalive := cfg.GRPCOptions.Keepalive
+	grpcConfig.MaxConnectionIdle = keepalive.MaxConnectionIdle
+	grpcConfig.MaxConnectionAge = keepalive.MaxConnectionAge
+	grpcConfig.MaxConnectionAgeGrace = keepalive.MaxConnectionAgeGrace
+	grpcConfig.KeepaliveTime = keepalive.Time
+	grpcConfig.KeepaliveTimeout = keepalive.Timeout
+	grpcConfig.KeepaliveMinTime = keepalive.MinTime
+	grpcConfig.KeepalivePermitWithoutStream = keepalive.PermitWithoutStream
+
+	// 应用 TLS 配置，证书复用安全服务的配置，仅在显式配置时使用明文连接
+	grpcConfig.Insecure = cfg.GRPCOptions.Insecure
+	grpcConfig.ClientCAFile = cfg.GRPCOptions.ClientCAFile
 	if cfg.SecureServing != nil {
 		grpcConfig.TLSCertFile = cfg.SecureServing.TLS.CertFile
 		grpcConfig.TLSKeyFile = cfg.SecureServing.TLS.KeyFile
@@ -10,6 +10,8 @@ type SubmitRequest struct {
 	Title             string      `json:"title" validate:"required"`
 	TesteeInfo        *TesteeInfo `json:"testee_info" validate:"required"`
 	Answers           []*Answer   `json:"answers" validate:"required"`
+	// ClientIP 提交者 IP，用于校验问卷地域限制
+	ClientIP string `json:"-"`
 }
 
 // SubmitResponse 提交答卷响应
@@ -18,6 +20,20 @@ type SubmitResponse struct {
 	Status    string    `json:"status"`
 	Message   string    `json:"message"`
 	CreatedAt time.Time `json:"created_at"`
+	// Warnings 不阻断提交的验证警告
+	Warnings []*ValidationWarning `json:"warnings,omitempty"`
+}
+
+// ValidationWarning 验证警告
+type ValidationWarning struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
+// ValidationResponse 验证答卷响应
+type ValidationResponse struct {
+	Warnings []*ValidationWarning `json:"warnings,omitempty"`
 }
 
 // ValidationRequest 验证答卷请求
@@ -3,6 +3,7 @@ package answersheet
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
 	"time"
@@ -10,6 +11,7 @@ import (
 	answersheetpb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/answersheet"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/application/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/domain/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/collection-server/domain/validation"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/infrastructure/grpc"
 	internalpubsub "github.com/yshujie/questionnaire-scale/internal/pkg/pubsub"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
@@ -22,7 +24,16 @@ type Service interface {
 	SubmitAnswersheet(ctx context.Context, req *SubmitRequest) (*SubmitResponse, error)
 
 	// ValidateAnswersheet 验证答卷
-	ValidateAnswersheet(ctx context.Context, req *ValidationRequest) error
+	ValidateAnswersheet(ctx context.Context, req *ValidationRequest) (*ValidationResponse, error)
+}
+
+// ErrGeoRestricted 问卷在提交者所属地区不可用
+var ErrGeoRestricted = errors.New("questionnaire is not available in your region")
+
+// GeoRestrictionChecker 问卷地域限制检查
+type GeoRestrictionChecker interface {
+	// Allow 判断 IP 是否允许访问限定在 allowed 国家内的问卷，allowed 为空时不限制
+	Allow(ip string, allowed []string) bool
 }
 
 // service 答卷应用服务实现
@@ -31,15 +42,17 @@ type service struct {
 	questionnaireService questionnaire.Service
 	validator            *answersheet.Validator
 	publisher            pubsub.Publisher
+	geoChecker           GeoRestrictionChecker
 }
 
 // NewService 创建答卷应用服务
-func NewService(answersheetClient grpc.AnswersheetClient, publisher pubsub.Publisher, questionnaireService questionnaire.Service) Service {
+func NewService(answersheetClient grpc.AnswersheetClient, publisher pubsub.Publisher, questionnaireService questionnaire.Service, geoChecker GeoRestrictionChecker) Service {
 	return &service{
 		answersheetClient:    answersheetClient,
 		questionnaireService: questionnaireService,
 		validator:            answersheet.NewValidator(),
 		publisher:            publisher,
+		geoChecker:           geoChecker,
 	}
 }
 
@@ -69,6 +82,12 @@ func (s *service) SubmitAnswersheet(ctx context.Context, req *SubmitRequest) (*S
 	}
 	log.L(ctx).Infof("Successfully retrieved questionnaire info: %s", req.QuestionnaireCode)
 
+	// 设置了地域限制的问卷仅允许限定国家提交
+	if !s.geoChecker.Allow(req.ClientIP, questionnaireInfo.GetGeoRestriction()) {
+		log.L(ctx).Warnf("Answersheet submission from %s rejected by geo restriction: %s", req.ClientIP, req.QuestionnaireCode)
+		return nil, ErrGeoRestricted
+	}
+
 	log.L(ctx).Info("Converting to domain entity...")
 	// 转换为领域实体
 	answersheetEntity := s.convertToAnswersheet(req)
@@ -76,10 +95,12 @@ func (s *service) SubmitAnswersheet(ctx context.Context, req *SubmitRequest) (*S
 
 	log.L(ctx).Info("Starting domain validation...")
 	// 验证答卷
-	if err := s.validator.ValidateSubmitRequest(ctx, answersheetEntity, questionnaireInfo); err != nil {
+	result := s.validator.CheckSubmitRequest(ctx, answersheetEntity, questionnaireInfo)
+	if err := result.Err(); err != nil {
 		log.L(ctx).Errorf("Domain validation failed: %v", err)
 		return nil, fmt.Errorf("answersheet validation failed: %w", err)
 	}
+	logValidationWarnings(ctx, result)
 	log.L(ctx).Info("Domain validation passed")
 
 	log.L(ctx).Info("Converting to gRPC request...")
@@ -120,6 +141,7 @@ func (s *service) SubmitAnswersheet(ctx context.Context, req *SubmitRequest) (*S
 		Status:    "success",
 		Message:   grpcResp.Message,
 		CreatedAt: time.Now(),
+		Warnings:  toValidationWarnings(result),
 	}
 
 	log.L(ctx).Infof("=== Answersheet submission completed successfully: id=%s ===", response.ID)
@@ -127,14 +149,14 @@ func (s *service) SubmitAnswersheet(ctx context.Context, req *SubmitRequest) (*S
 }
 
 // ValidateAnswersheet 验证答卷
-func (s *service) ValidateAnswersheet(ctx context.Context, req *ValidationRequest) error {
+func (s *service) ValidateAnswersheet(ctx context.Context, req *ValidationRequest) (*ValidationResponse, error) {
 	if req == nil {
-		return fmt.Errorf("validation request cannot be nil")
+		return nil, fmt.Errorf("validation request cannot be nil")
 	}
 
 	// 验证请求
 	if err := s.validateValidationRequest(req); err != nil {
-		return fmt.Errorf("invalid validation request: %w", err)
+		return nil, fmt.Errorf("invalid validation request: %w", err)
 	}
 
 	// 转换为领域实体
@@ -148,15 +170,41 @@ func (s *service) ValidateAnswersheet(ctx context.Context, req *ValidationReques
 	// 获取问卷信息
 	questionnaireInfo, err := s.questionnaireService.GetQuestionnaireForValidation(ctx, req.QuestionnaireCode)
 	if err != nil {
-		return fmt.Errorf("failed to get questionnaire for validation: %w", err)
+		return nil, fmt.Errorf("failed to get questionnaire for validation: %w", err)
 	}
 
 	// 验证答卷
-	if err := s.validator.ValidateSubmitRequest(ctx, answersheetEntity, questionnaireInfo); err != nil {
-		return fmt.Errorf("answersheet validation failed: %w", err)
+	result := s.validator.CheckSubmitRequest(ctx, answersheetEntity, questionnaireInfo)
+	if err := result.Err(); err != nil {
+		return nil, fmt.Errorf("answersheet validation failed: %w", err)
 	}
+	logValidationWarnings(ctx, result)
 
-	return nil
+	return &ValidationResponse{Warnings: toValidationWarnings(result)}, nil
+}
+
+// logValidationWarnings 记录不阻断提交的验证警告
+func logValidationWarnings(ctx context.Context, result *validation.Result) {
+	for _, warning := range result.Warnings {
+		log.L(ctx).Warnf("Answersheet validation warning: field=%s, message=%s", warning.Field, warning.Message)
+	}
+}
+
+// toValidationWarnings 将验证警告转换为 DTO，返回给调用方展示
+func toValidationWarnings(result *validation.Result) []*ValidationWarning {
+	if len(result.Warnings) == 0 {
+		return nil
+	}
+
+	warnings := make([]*ValidationWarning, 0, len(result.Warnings))
+	for _, warning := range result.Warnings {
+		warnings = append(warnings, &ValidationWarning{
+			Field:   warning.Field,
+			Rule:    warning.Rule,
+			Message: warning.Message,
+		})
+	}
+	return warnings
 }
 
 // convertToSaveRequest 将DTO转换为gRPC保存请求
@@ -7,6 +7,7 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/domain/answersheet"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/domain/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/infrastructure/grpc"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 // Service 问卷应用服务接口
@@ -57,10 +58,14 @@ func (s *service) GetQuestionnaire(ctx context.Context, code string) (*questionn
 		return nil, fmt.Errorf("failed to convert questionnaire from proto")
 	}
 
-	// 验证问卷实体
-	if err := s.validator.ValidateQuestionnaire(questionnaireEntity); err != nil {
+	// 验证问卷实体，警告不阻断流程，只记录日志
+	result := s.validator.CheckQuestionnaire(questionnaireEntity)
+	if err := result.Err(); err != nil {
 		return nil, fmt.Errorf("invalid questionnaire: %w", err)
 	}
+	for _, warning := range result.Warnings {
+		log.L(ctx).Warnf("Questionnaire %s validation warning: %s", code, warning.Message)
+	}
 
 	return questionnaireEntity, nil
 }
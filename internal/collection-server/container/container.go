@@ -7,9 +7,11 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/application/answersheet"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/application/questionnaire"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/application/validation"
+	"github.com/yshujie/questionnaire-scale/internal/collection-server/infrastructure/geoip"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/infrastructure/grpc"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/interface/restful/handler"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/options"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 	"github.com/yshujie/questionnaire-scale/pkg/pubsub"
 )
@@ -20,6 +22,11 @@ type Container struct {
 	QuestionnaireClient grpc.QuestionnaireClient
 	AnswersheetClient   grpc.AnswersheetClient
 	Publisher           pubsub.Publisher
+	// GeoIPLookup IP 归属国家查询，未配置数据库时为空
+	GeoIPLookup middleware.GeoIPLookup
+	geoIPCloser func() error
+	// GeoRestrictionGuard 问卷地域限制检查器，未配置 IP 归属地数据库时拒绝访问设置了地域限制的问卷
+	GeoRestrictionGuard *middleware.GeoRestrictionGuard
 
 	// 应用层
 	ValidationService           validation.Service
@@ -35,15 +42,17 @@ type Container struct {
 	grpcClientConfig  *options.GRPCClientOptions
 	pubsubConfig      *pubsub.Config
 	concurrencyConfig *options.ConcurrencyOptions
+	geoIPConfig       *options.GeoIPOptions
 	initialized       bool
 }
 
 // NewContainer 创建新的容器
-func NewContainer(grpcClientConfig *options.GRPCClientOptions, pubsubConfig *pubsub.Config, concurrencyConfig *options.ConcurrencyOptions) *Container {
+func NewContainer(grpcClientConfig *options.GRPCClientOptions, pubsubConfig *pubsub.Config, concurrencyConfig *options.ConcurrencyOptions, geoIPConfig *options.GeoIPOptions) *Container {
 	return &Container{
 		grpcClientConfig:  grpcClientConfig,
 		pubsubConfig:      pubsubConfig,
 		concurrencyConfig: concurrencyConfig,
+		geoIPConfig:       geoIPConfig,
 		initialized:       false,
 	}
 }
@@ -105,6 +114,22 @@ func (c *Container) initializeInfrastructure() error {
 	c.Publisher = publisher
 
 	log.Info("   ✅ Publisher initialized")
+
+	// 打开 IP 归属地数据库
+	if c.geoIPConfig != nil && c.geoIPConfig.DatabasePath != "" {
+		geoIPLookup, err := geoip.NewMaxMindLookup(c.geoIPConfig.DatabasePath)
+		if err != nil {
+			return fmt.Errorf("failed to create geoip lookup: %w", err)
+		}
+		c.GeoIPLookup = geoIPLookup
+		c.geoIPCloser = geoIPLookup.Close
+
+		log.Info("   🌍 GeoIP database loaded")
+	} else {
+		log.Warn("   🌍 GeoIP database not configured, geo-restricted questionnaires will be unavailable")
+	}
+	c.GeoRestrictionGuard = middleware.NewGeoRestrictionGuard(c.GeoIPLookup)
+
 	return nil
 }
 
@@ -134,7 +159,7 @@ func (c *Container) initializeApplication() error {
 	c.QuestionnaireService = questionnaire.NewService(c.QuestionnaireClient)
 
 	// 再创建答卷应用服务
-	c.AnswersheetService = answersheet.NewService(c.AnswersheetClient, c.Publisher, c.QuestionnaireService)
+	c.AnswersheetService = answersheet.NewService(c.AnswersheetClient, c.Publisher, c.QuestionnaireService, c.GeoRestrictionGuard)
 
 	log.Infof("   ✅ Application services initialized (using concurrent validation, max concurrency: %d)", c.concurrencyConfig.MaxConcurrency)
 	return nil
@@ -197,6 +222,13 @@ func (c *Container) Cleanup() error {
 		}
 	}
 
+	// 关闭 IP 归属地数据库
+	if c.geoIPCloser != nil {
+		if err := c.geoIPCloser(); err != nil {
+			log.Errorf("Failed to close geoip database: %v", err)
+		}
+	}
+
 	// 关闭 Watermill 发布者
 	if c.Publisher != nil {
 		if err := c.Publisher.Close(); err != nil {
@@ -223,6 +255,7 @@ func (c *Container) GetContainerInfo() map[string]interface{} {
 			"validation_service":    c.ValidationService != nil,
 			"questionnaire_handler": c.QuestionnaireHandler != nil,
 			"answersheet_handler":   c.AnswersheetHandler != nil,
+			"geoip_lookup":          c.GeoIPLookup != nil,
 		},
 	}
 }
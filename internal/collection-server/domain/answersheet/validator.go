@@ -29,14 +29,21 @@ type QuestionValidationRule interface {
 	GetRuleType() string
 	GetTargetValue() string
 	GetMessage() string
+	// GetSeverity 规则的严重级别，为 warning 时未通过只产生警告
+	GetSeverity() string
 }
 
 // QuestionnaireInfo 问卷信息接口
 type QuestionnaireInfo interface {
 	GetCode() string
 	GetQuestions() []QuestionInfo
+	// GetGeoRestriction 获取允许访问的国家列表，为空表示不限制
+	GetGeoRestriction() []string
 }
 
+// unusualTesteeAge 超过该年龄的测试者信息仍然有效，但会产生警告
+const unusualTesteeAge = 100
+
 // Validator 答卷验证器
 type Validator struct {
 	validationValidator *validation.Validator
@@ -100,29 +107,48 @@ func (v *Validator) ValidateTesteeInfo(info *TesteeInfo) error {
 	return nil
 }
 
-// ValidateAnswer 根据问题验证规则验证单个答案
+// checkTesteeInfo 检查有效但少见的测试者信息，只产生警告
+func (v *Validator) checkTesteeInfo(info *TesteeInfo, result *validation.Result) {
+	if info.Age > unusualTesteeAge {
+		result.Warn("testee_info.age", fmt.Sprintf("testee age %d is unusual", info.Age), info.Age, "unusual_age")
+	}
+}
+
+// ValidateAnswer 根据问题验证规则验证单个答案，警告不影响验证结果
 func (v *Validator) ValidateAnswer(ctx context.Context, answer *Answer, question QuestionInfo) error {
+	return v.CheckAnswer(ctx, answer, question).Err()
+}
+
+// CheckAnswer 根据问题验证规则验证单个答案，返回包含错误和警告的验证结果
+func (v *Validator) CheckAnswer(ctx context.Context, answer *Answer, question QuestionInfo) *validation.Result {
+	result := validation.NewResult()
+
 	if answer == nil {
-		return fmt.Errorf("answer cannot be nil")
+		result.Add(fmt.Errorf("answer cannot be nil"))
+		return result
 	}
 
 	if question == nil {
-		return fmt.Errorf("question cannot be nil")
+		result.Add(fmt.Errorf("question cannot be nil"))
+		return result
 	}
 
 	// 验证问题代码匹配
 	if answer.QuestionCode != question.GetCode() {
-		return fmt.Errorf("question code mismatch: expected %s, got %s", question.GetCode(), answer.QuestionCode)
+		result.Add(fmt.Errorf("question code mismatch: expected %s, got %s", question.GetCode(), answer.QuestionCode))
+		return result
 	}
 
 	// 验证问题类型匹配
 	if answer.QuestionType != question.GetType() {
-		return fmt.Errorf("question type mismatch: expected %s, got %s", question.GetType(), answer.QuestionType)
+		result.Add(fmt.Errorf("question type mismatch: expected %s, got %s", question.GetType(), answer.QuestionType))
+		return result
 	}
 
 	// 验证答案值不为空
 	if answer.Value == nil {
-		return fmt.Errorf("answer value cannot be nil")
+		result.Add(fmt.Errorf("answer value cannot be nil"))
+		return result
 	}
 
 	// 根据问题的验证规则验证答案
@@ -131,20 +157,25 @@ func (v *Validator) ValidateAnswer(ctx context.Context, answer *Answer, question
 		// 将问卷的验证规则转换为验证器的规则
 		rules := v.convertValidationRules(validationRules)
 
-		// 使用验证器验证答案
-		errors := v.validationValidator.ValidateMultiple(answer.Value, rules)
-		if len(errors) > 0 {
+		// 使用验证器验证答案，警告级别的规则未通过时只记录警告
+		checked := v.validationValidator.Check(answer.Value, rules)
+		if err := checked.Err(); err != nil {
 			// 返回第一个错误
-			return fmt.Errorf("answer validation failed for question %s: %w", question.GetCode(), errors[0])
+			result.Add(fmt.Errorf("answer validation failed for question %s: %w", question.GetCode(), err))
+			return result
+		}
+		for _, warning := range checked.Warnings {
+			warning.Field = question.GetCode()
+			result.Add(warning)
 		}
 	}
 
 	// 根据问题类型进行额外验证
 	if err := v.validateAnswerByType(answer, question); err != nil {
-		return fmt.Errorf("type-specific validation failed for question %s: %w", question.GetCode(), err)
+		result.Add(fmt.Errorf("type-specific validation failed for question %s: %w", question.GetCode(), err))
 	}
 
-	return nil
+	return result
 }
 
 // validateAnswerByType 根据问题类型验证答案
@@ -224,6 +255,15 @@ func (v *Validator) convertValidationRules(protoRules []QuestionValidationRule)
 
 // convertValidationRule 转换单个验证规则
 func (v *Validator) convertValidationRule(protoRule QuestionValidationRule) *rules.BaseRule {
+	rule := v.convertValidationRuleType(protoRule)
+	if rule != nil && rules.Severity(protoRule.GetSeverity()) == rules.SeverityWarning {
+		rule.AsWarning()
+	}
+	return rule
+}
+
+// convertValidationRuleType 按规则类型创建验证器的规则
+func (v *Validator) convertValidationRuleType(protoRule QuestionValidationRule) *rules.BaseRule {
 	switch protoRule.GetRuleType() {
 	case "required":
 		return validation.Required("此题为必答题")
@@ -261,14 +301,23 @@ func parseFloat(s string) float64 {
 	return 0.0
 }
 
-// ValidateAnswers 验证答案列表（需要问卷信息）
+// ValidateAnswers 验证答案列表（需要问卷信息），警告不影响验证结果
 func (v *Validator) ValidateAnswers(ctx context.Context, answers []*Answer, questionnaire QuestionnaireInfo) error {
+	return v.CheckAnswers(ctx, answers, questionnaire).Err()
+}
+
+// CheckAnswers 验证答案列表，返回包含错误和警告的验证结果
+func (v *Validator) CheckAnswers(ctx context.Context, answers []*Answer, questionnaire QuestionnaireInfo) *validation.Result {
+	result := validation.NewResult()
+
 	if len(answers) == 0 {
-		return fmt.Errorf("answers cannot be empty")
+		result.Add(fmt.Errorf("answers cannot be empty"))
+		return result
 	}
 
 	if questionnaire == nil {
-		return fmt.Errorf("questionnaire cannot be nil")
+		result.Add(fmt.Errorf("questionnaire cannot be nil"))
+		return result
 	}
 
 	// 创建问题映射，方便查找
@@ -284,52 +333,73 @@ func (v *Validator) ValidateAnswers(ctx context.Context, answers []*Answer, ques
 		// 查找对应的问题
 		question, exists := questionMap[answer.QuestionCode]
 		if !exists {
-			return fmt.Errorf("question not found: %s", answer.QuestionCode)
+			result.Add(fmt.Errorf("question not found: %s", answer.QuestionCode))
+			return result
 		}
 
 		// 检查重复答案
 		if questionCodes[answer.QuestionCode] {
-			return fmt.Errorf("duplicate answer for question: %s", answer.QuestionCode)
+			result.Add(fmt.Errorf("duplicate answer for question: %s", answer.QuestionCode))
+			return result
 		}
 		questionCodes[answer.QuestionCode] = true
 
 		// 验证答案
-		if err := v.ValidateAnswer(ctx, answer, question); err != nil {
-			return fmt.Errorf("invalid answer at index %d: %w", i, err)
+		answerResult := v.CheckAnswer(ctx, answer, question)
+		result.Warnings = append(result.Warnings, answerResult.Warnings...)
+		if err := answerResult.Err(); err != nil {
+			result.Add(fmt.Errorf("invalid answer at index %d: %w", i, err))
+			return result
 		}
 	}
 
-	return nil
+	return result
 }
 
-// ValidateSubmitRequest 验证提交请求
+// ValidateSubmitRequest 验证提交请求，警告不影响验证结果
 func (v *Validator) ValidateSubmitRequest(ctx context.Context, req *SubmitRequest, questionnaire QuestionnaireInfo) error {
+	return v.CheckSubmitRequest(ctx, req, questionnaire).Err()
+}
+
+// CheckSubmitRequest 验证提交请求，返回包含错误和警告的验证结果
+// 遇到第一个错误即停止验证，已经产生的警告仍会返回
+func (v *Validator) CheckSubmitRequest(ctx context.Context, req *SubmitRequest, questionnaire QuestionnaireInfo) *validation.Result {
+	result := validation.NewResult()
+
 	if req == nil {
-		return fmt.Errorf("submit request cannot be nil")
+		result.Add(fmt.Errorf("submit request cannot be nil"))
+		return result
 	}
 
 	// 验证问卷代码
 	if req.QuestionnaireCode == "" {
-		return fmt.Errorf("questionnaire code cannot be empty")
+		result.Add(fmt.Errorf("questionnaire code cannot be empty"))
+		return result
 	}
 
 	// 验证标题
 	if req.Title == "" {
-		return fmt.Errorf("title cannot be empty")
+		result.Add(fmt.Errorf("title cannot be empty"))
+		return result
 	}
 	if len(req.Title) > 200 {
-		return fmt.Errorf("title cannot exceed 200 characters")
+		result.Add(fmt.Errorf("title cannot exceed 200 characters"))
+		return result
 	}
 
 	// 验证测试者信息
 	if err := v.ValidateTesteeInfo(req.TesteeInfo); err != nil {
-		return fmt.Errorf("invalid testee info: %w", err)
+		result.Add(fmt.Errorf("invalid testee info: %w", err))
+		return result
 	}
+	v.checkTesteeInfo(req.TesteeInfo, result)
 
 	// 验证答案（需要问卷信息）
-	if err := v.ValidateAnswers(ctx, req.Answers, questionnaire); err != nil {
-		return fmt.Errorf("invalid answers: %w", err)
+	answersResult := v.CheckAnswers(ctx, req.Answers, questionnaire)
+	result.Warnings = append(result.Warnings, answersResult.Warnings...)
+	if err := answersResult.Err(); err != nil {
+		result.Add(fmt.Errorf("invalid answers: %w", err))
 	}
 
-	return nil
+	return result
 }
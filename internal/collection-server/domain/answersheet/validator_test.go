@@ -0,0 +1,75 @@
+package answersheet
+
+import (
+	"context"
+	"testing"
+)
+
+type fakeRule struct{ ruleType, targetValue, severity string }
+
+func (r fakeRule) GetRuleType() string    { return r.ruleType }
+func (r fakeRule) GetTargetValue() string { return r.targetValue }
+func (r fakeRule) GetMessage() string     { return "" }
+func (r fakeRule) GetSeverity() string    { return r.severity }
+
+type fakeQuestion struct {
+	code  string
+	rules []QuestionValidationRule
+}
+
+func (q fakeQuestion) GetCode() string                              { return q.code }
+func (q fakeQuestion) GetType() string                              { return "number" }
+func (q fakeQuestion) GetOptions() []QuestionOption                 { return nil }
+func (q fakeQuestion) GetValidationRules() []QuestionValidationRule { return q.rules }
+
+type fakeQuestionnaire struct{ questions []QuestionInfo }
+
+func (q fakeQuestionnaire) GetCode() string              { return "QN1" }
+func (q fakeQuestionnaire) GetQuestions() []QuestionInfo { return q.questions }
+func (q fakeQuestionnaire) GetGeoRestriction() []string  { return nil }
+
+func TestValidator_CheckSubmitRequestWarnings(t *testing.T) {
+	// 每晚睡眠时长：超过 16 小时少见但允许，超过 24 小时无效
+	questionnaire := fakeQuestionnaire{questions: []QuestionInfo{
+		fakeQuestion{code: "sleep_hours", rules: []QuestionValidationRule{
+			fakeRule{ruleType: "max_value", targetValue: "24"},
+			fakeRule{ruleType: "max_value", targetValue: "16", severity: "warning"},
+		}},
+	}}
+	newRequest := func(age int, hours float64) *SubmitRequest {
+		return &SubmitRequest{
+			QuestionnaireCode: "QN1",
+			Title:             "睡眠情况",
+			TesteeInfo:        &TesteeInfo{Name: "张三", Age: age, Gender: "male"},
+			Answers:           []*Answer{{QuestionCode: "sleep_hours", QuestionType: "number", Value: hours}},
+		}
+	}
+
+	validator := NewValidator()
+	ctx := context.Background()
+
+	result := validator.CheckSubmitRequest(ctx, newRequest(101, 18), questionnaire)
+	if err := result.Err(); err != nil {
+		t.Fatalf("CheckSubmitRequest() error = %v, want warnings only", err)
+	}
+	fields := make(map[string]bool)
+	for _, warning := range result.Warnings {
+		if !warning.IsWarning() {
+			t.Errorf("warning %+v has severity %q", warning, warning.Severity)
+		}
+		fields[warning.Field] = true
+	}
+	if len(result.Warnings) != 2 || !fields["testee_info.age"] || !fields["sleep_hours"] {
+		t.Errorf("warnings = %+v, want testee_info.age and sleep_hours", result.Warnings)
+	}
+	if err := validator.ValidateSubmitRequest(ctx, newRequest(101, 18), questionnaire); err != nil {
+		t.Errorf("ValidateSubmitRequest() error = %v, warnings must not fail validation", err)
+	}
+
+	if result := validator.CheckSubmitRequest(ctx, newRequest(30, 8), questionnaire); !result.Valid() || len(result.Warnings) != 0 {
+		t.Errorf("CheckSubmitRequest() = %+v, want no errors and no warnings", result)
+	}
+	if err := validator.ValidateSubmitRequest(ctx, newRequest(30, 25), questionnaire); err == nil {
+		t.Error("ValidateSubmitRequest() error = nil, want error for value above hard limit")
+	}
+}
@@ -28,6 +28,11 @@ func (a *QuestionnaireAdapter) GetQuestions() []answersheet.QuestionInfo {
 	return questions
 }
 
+// GetGeoRestriction 获取允许访问的国家列表
+func (a *QuestionnaireAdapter) GetGeoRestriction() []string {
+	return a.questionnaire.GeoRestriction
+}
+
 // QuestionAdapter 问题适配器，实现 answersheet.QuestionInfo 接口
 type QuestionAdapter struct {
 	question *Question
@@ -121,3 +126,8 @@ func (a *ValidationRuleAdapter) GetTargetValue() string {
 func (a *ValidationRuleAdapter) GetMessage() string {
 	return a.rule.Message
 }
+
+// GetSeverity 获取严重级别
+func (a *ValidationRuleAdapter) GetSeverity() string {
+	return a.rule.Severity
+}
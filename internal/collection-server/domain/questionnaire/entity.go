@@ -15,6 +15,8 @@ type Questionnaire struct {
 	Questions   []*Question `json:"questions"`
 	CreatedAt   time.Time   `json:"created_at"`
 	UpdatedAt   time.Time   `json:"updated_at"`
+	// GeoRestriction 允许访问的国家（ISO 3166-1 alpha-2 代码），为空表示不限制
+	GeoRestriction []string `json:"geo_restriction,omitempty"`
 }
 
 // Question 问题实体
@@ -40,6 +42,8 @@ type ValidationRule struct {
 	RuleType    string `json:"rule_type"`
 	TargetValue string `json:"target_value"`
 	Message     string `json:"message"`
+	// Severity 严重级别，为 warning 时规则未通过只产生警告
+	Severity string `json:"severity,omitempty"`
 }
 
 // FromProto 从 protobuf 转换为领域实体
@@ -77,6 +81,7 @@ func FromProto(proto *questionnairepb.Questionnaire) *Questionnaire {
 				question.ValidationRules = append(question.ValidationRules, &ValidationRule{
 					RuleType:    rule.RuleType,
 					TargetValue: rule.TargetValue,
+					Severity:    rule.Severity,
 				})
 			}
 		}
@@ -105,5 +110,7 @@ func FromProto(proto *questionnairepb.Questionnaire) *Questionnaire {
 		Questions:   questions,
 		CreatedAt:   createdAt,
 		UpdatedAt:   updatedAt,
+
+		GeoRestriction: proto.GeoRestriction,
 	}
 }
@@ -3,6 +3,8 @@ package questionnaire
 import (
 	"fmt"
 	"regexp"
+
+	"github.com/yshujie/questionnaire-scale/internal/collection-server/domain/validation"
 )
 
 // Validator 问卷验证器
@@ -36,8 +38,34 @@ func (v *Validator) ValidateCode(code string) error {
 	return nil
 }
 
-// ValidateQuestionnaire 验证问卷实体
+// ValidateQuestionnaire 验证问卷实体，警告不影响验证结果
 func (v *Validator) ValidateQuestionnaire(questionnaire *Questionnaire) error {
+	return v.CheckQuestionnaire(questionnaire).Err()
+}
+
+// CheckQuestionnaire 验证问卷实体，返回包含错误和警告的验证结果
+// 已归档的问卷和没有标题的问题仍然有效，但会产生警告
+func (v *Validator) CheckQuestionnaire(questionnaire *Questionnaire) *validation.Result {
+	result := validation.NewResult()
+	result.Add(v.validateQuestionnaire(questionnaire))
+	if !result.Valid() {
+		return result
+	}
+
+	if questionnaire.Status == "archived" {
+		result.Warn("status", "questionnaire is archived", questionnaire.Status, "archived")
+	}
+	for _, question := range questionnaire.Questions {
+		if question.Title == "" {
+			result.Warn(question.Code, fmt.Sprintf("question %s has no title", question.Code), question.Title, "title")
+		}
+	}
+
+	return result
+}
+
+// validateQuestionnaire 验证问卷实体的必要条件
+func (v *Validator) validateQuestionnaire(questionnaire *Questionnaire) error {
 	if questionnaire == nil {
 		return fmt.Errorf("questionnaire cannot be nil")
 	}
@@ -33,6 +33,12 @@ func (b *ValidationRuleBuilder) WithMessage(message string) *ValidationRuleBuild
 	return b
 }
 
+// WithSeverity 设置严重级别
+func (b *ValidationRuleBuilder) WithSeverity(severity rules.Severity) *ValidationRuleBuilder {
+	b.rule.Severity = severity
+	return b
+}
+
 // WithParam 添加参数
 func (b *ValidationRuleBuilder) WithParam(key string, value interface{}) *ValidationRuleBuilder {
 	b.rule.Params[key] = value
@@ -0,0 +1,47 @@
+package validation
+
+import (
+	"github.com/yshujie/questionnaire-scale/internal/collection-server/domain/validation/rules"
+)
+
+// Result 验证结果
+// Errors 导致验证失败，Warnings 是不阻断流程的提示（如少见但允许的值），由调用方决定如何展示
+type Result struct {
+	Errors   []error
+	Warnings []*rules.ValidationError
+}
+
+// NewResult 创建验证结果
+func NewResult() *Result {
+	return &Result{}
+}
+
+// Add 按严重级别记录验证问题，警告级别的 ValidationError 记为警告，其余记为错误
+func (r *Result) Add(err error) {
+	if err == nil {
+		return
+	}
+	if validationErr, ok := err.(*rules.ValidationError); ok && validationErr.IsWarning() {
+		r.Warnings = append(r.Warnings, validationErr)
+		return
+	}
+	r.Errors = append(r.Errors, err)
+}
+
+// Warn 记录一条验证警告
+func (r *Result) Warn(field, message string, value interface{}, rule string) {
+	r.Warnings = append(r.Warnings, rules.NewValidationWarning(field, message, value, rule))
+}
+
+// Valid 判断验证是否通过，警告不影响验证结果
+func (r *Result) Valid() bool {
+	return len(r.Errors) == 0
+}
+
+// Err 返回第一个错误，验证通过时返回 nil
+func (r *Result) Err() error {
+	if len(r.Errors) == 0 {
+		return nil
+	}
+	return r.Errors[0]
+}
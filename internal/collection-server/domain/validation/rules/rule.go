@@ -10,12 +10,24 @@ type Rule interface {
 	GetRuleName() string
 }
 
+// Severity 验证问题的严重级别
+type Severity string
+
+const (
+	// SeverityError 错误，导致验证失败
+	SeverityError Severity = "error"
+	// SeverityWarning 警告，不影响验证结果，只作为提示返回给调用方
+	SeverityWarning Severity = "warning"
+)
+
 // BaseRule 基础验证规则
 type BaseRule struct {
 	Name    string                 `json:"name"`
 	Value   interface{}            `json:"value"`
 	Message string                 `json:"message"`
 	Params  map[string]interface{} `json:"params"`
+	// Severity 规则未通过时的严重级别，为空时按错误处理
+	Severity Severity `json:"severity,omitempty"`
 }
 
 // NewBaseRule 创建基础验证规则
@@ -33,6 +45,17 @@ func (r *BaseRule) GetRuleName() string {
 	return r.Name
 }
 
+// IsWarning 判断规则是否只产生警告
+func (r *BaseRule) IsWarning() bool {
+	return r.Severity == SeverityWarning
+}
+
+// AsWarning 将规则设置为只产生警告
+func (r *BaseRule) AsWarning() *BaseRule {
+	r.Severity = SeverityWarning
+	return r
+}
+
 // WithParams 添加参数
 func (r *BaseRule) WithParams(params map[string]interface{}) *BaseRule {
 	r.Params = params
@@ -54,6 +77,13 @@ type ValidationError struct {
 	Message string      `json:"message"`
 	Value   interface{} `json:"value"`
 	Rule    string      `json:"rule"`
+	// Severity 严重级别，警告不影响验证结果
+	Severity Severity `json:"severity"`
+}
+
+// IsWarning 判断是否为警告
+func (e *ValidationError) IsWarning() bool {
+	return e.Severity == SeverityWarning
 }
 
 // Error 实现 error 接口
@@ -64,9 +94,17 @@ func (e *ValidationError) Error() string {
 // NewValidationError 创建验证错误
 func NewValidationError(field, message string, value interface{}, rule string) *ValidationError {
 	return &ValidationError{
-		Field:   field,
-		Message: message,
-		Value:   value,
-		Rule:    rule,
+		Field:    field,
+		Message:  message,
+		Value:    value,
+		Rule:     rule,
+		Severity: SeverityError,
 	}
 }
+
+// NewValidationWarning 创建验证警告
+func NewValidationWarning(field, message string, value interface{}, rule string) *ValidationError {
+	warning := NewValidationError(field, message, value, rule)
+	warning.Severity = SeverityWarning
+	return warning
+}
@@ -239,3 +239,33 @@ func TestNumberRules(t *testing.T) {
 		t.Errorf("期望3个规则，但得到: %d", len(rules))
 	}
 }
+
+func TestValidator_CheckWarning(t *testing.T) {
+	validator := NewValidator()
+
+	checkRules := []*rules.BaseRule{
+		rules.NewBaseRule("required", nil, "此字段为必填项"),
+		NewRule("max_value").WithValue(100).WithMessage("数值偏大").WithSeverity(rules.SeverityWarning).Build(),
+	}
+
+	// 警告级别的规则未通过时，验证仍然通过，但会返回警告
+	result := validator.Check(120, checkRules)
+	if !result.Valid() || result.Err() != nil {
+		t.Fatalf("期望验证通过，但得到错误: %v", result.Errors)
+	}
+	if len(result.Warnings) != 1 {
+		t.Fatalf("期望得到1个警告，实际得到 %d 个", len(result.Warnings))
+	}
+	if warning := result.Warnings[0]; !warning.IsWarning() || warning.Message != "数值偏大" || warning.Rule != "max_value" {
+		t.Errorf("警告内容不正确: %+v", warning)
+	}
+	if errs := validator.ValidateMultiple(120, checkRules); len(errs) != 0 {
+		t.Errorf("警告不应导致 ValidateMultiple 失败: %v", errs)
+	}
+
+	// 错误级别的规则未通过时，验证失败
+	result = validator.Check(nil, checkRules)
+	if result.Valid() || len(result.Warnings) != 0 {
+		t.Errorf("期望验证失败且没有警告，实际得到 errors=%v warnings=%v", result.Errors, result.Warnings)
+	}
+}
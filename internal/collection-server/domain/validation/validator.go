@@ -36,7 +36,12 @@ func (v *Validator) Validate(value interface{}, rule *rules.BaseRule) error {
 		return fmt.Errorf("验证策略 '%s' 不存在: %w", rule.Name, err)
 	}
 
-	return strategy.Validate(value, rule)
+	err = strategy.Validate(value, rule)
+	// 策略按规则值重新构建规则，严重级别需要在这里回填
+	if validationErr, ok := err.(*rules.ValidationError); ok && rule.IsWarning() {
+		validationErr.Severity = rules.SeverityWarning
+	}
+	return err
 }
 
 // ValidateField 验证字段
@@ -57,17 +62,18 @@ func (v *Validator) ValidateField(fieldName string, value interface{}, rule *rul
 	return err
 }
 
-// ValidateMultiple 验证多个规则
+// ValidateMultiple 验证多个规则，只返回导致验证失败的错误
 func (v *Validator) ValidateMultiple(value interface{}, rules []*rules.BaseRule) []error {
-	var errors []error
+	return v.Check(value, rules).Errors
+}
 
+// Check 验证多个规则，返回包含错误和警告的验证结果
+func (v *Validator) Check(value interface{}, rules []*rules.BaseRule) *Result {
+	result := NewResult()
 	for _, rule := range rules {
-		if err := v.Validate(value, rule); err != nil {
-			errors = append(errors, err)
-		}
+		result.Add(v.Validate(value, rule))
 	}
-
-	return errors
+	return result
 }
 
 // ValidateMultipleFields 验证多个字段
@@ -0,0 +1,41 @@
+package geoip
+
+import (
+	"fmt"
+	"net"
+
+	"github.com/oschwald/geoip2-golang"
+)
+
+// MaxMindLookup 基于 MaxMind GeoIP2/GeoLite2 国家数据库的 IP 归属查询
+type MaxMindLookup struct {
+	db *geoip2.Reader
+}
+
+// NewMaxMindLookup 打开 MaxMind 数据库文件（.mmdb）
+func NewMaxMindLookup(path string) (*MaxMindLookup, error) {
+	db, err := geoip2.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open geoip database %s: %w", path, err)
+	}
+	return &MaxMindLookup{db: db}, nil
+}
+
+// LookupCountry 返回 IP 所属国家的 ISO 3166-1 alpha-2 代码，数据库中没有记录时返回空字符串
+func (l *MaxMindLookup) LookupCountry(ip string) (string, error) {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return "", fmt.Errorf("invalid ip address: %s", ip)
+	}
+
+	record, err := l.db.Country(parsed)
+	if err != nil {
+		return "", fmt.Errorf("failed to lookup country of %s: %w", ip, err)
+	}
+	return record.Country.IsoCode, nil
+}
+
+// Close 关闭数据库文件
+func (l *MaxMindLookup) Close() error {
+	return l.db.Close()
+}
@@ -48,11 +48,18 @@ func NewAnswersheetClient(config *options.GRPCClientOptions) (AnswersheetClient,
 	opts := []grpc.DialOption{
 		grpc.WithTimeout(time.Duration(config.Timeout) * time.Second),
 		grpc.WithKeepaliveParams(kacp),
-		grpc.WithUnaryInterceptor(middleware.UnaryClientLoggingInterceptor()),
-		grpc.WithStreamInterceptor(middleware.StreamClientLoggingInterceptor()),
+		grpc.WithChainUnaryInterceptor(
+			middleware.UnaryClientRequestIDInterceptor(),
+			middleware.UnaryClientAPIKeyInterceptor(config.APIKey),
+			middleware.UnaryClientLoggingInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			middleware.StreamClientAPIKeyInterceptor(config.APIKey),
+			middleware.StreamClientLoggingInterceptor(),
+		),
 		grpc.WithDefaultCallOptions(
-			grpc.MaxCallRecvMsgSize(20*1024*1024), // 20MB
-			grpc.MaxCallSendMsgSize(20*1024*1024), // 20MB
+			grpc.MaxCallRecvMsgSize(config.MaxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(config.MaxSendMsgSize),
 		),
 	}
 
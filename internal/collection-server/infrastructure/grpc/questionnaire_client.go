@@ -45,8 +45,19 @@ func NewQuestionnaireClient(config *options.GRPCClientOptions) (QuestionnaireCli
 	opts := []grpc.DialOption{
 		grpc.WithTimeout(time.Duration(config.Timeout) * time.Second),
 		grpc.WithKeepaliveParams(kacp),
-		grpc.WithUnaryInterceptor(middleware.UnaryClientLoggingInterceptor()),
-		grpc.WithStreamInterceptor(middleware.StreamClientLoggingInterceptor()),
+		grpc.WithChainUnaryInterceptor(
+			middleware.UnaryClientRequestIDInterceptor(),
+			middleware.UnaryClientAPIKeyInterceptor(config.APIKey),
+			middleware.UnaryClientLoggingInterceptor(),
+		),
+		grpc.WithChainStreamInterceptor(
+			middleware.StreamClientAPIKeyInterceptor(config.APIKey),
+			middleware.StreamClientLoggingInterceptor(),
+		),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(config.MaxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(config.MaxSendMsgSize),
+		),
 	}
 
 	// 根据配置决定是否使用TLS
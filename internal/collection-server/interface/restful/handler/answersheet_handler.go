@@ -1,6 +1,7 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,7 @@ import (
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/interface/restful/mapper"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/interface/restful/request"
 	"github.com/yshujie/questionnaire-scale/internal/collection-server/interface/restful/response"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
@@ -65,11 +67,16 @@ func (h *answersheetHandler) Submit(c *gin.Context) {
 	log.L(ctx).Info("Starting answersheet conversion...")
 	// 直接转换请求（问题类型已在请求中提供）
 	serviceReq := h.mapper.ToServiceRequest(&req)
+	serviceReq.ClientIP = c.ClientIP()
 	log.L(ctx).Info("Answersheet conversion completed")
 
 	log.L(ctx).Info("Calling answersheet application service...")
 	// 调用应用服务
 	serviceResponse, err := h.answersheetService.SubmitAnswersheet(ctx, serviceReq)
+	if errors.Is(err, answersheetapp.ErrGeoRestricted) {
+		middleware.AbortGeoRestricted(c)
+		return
+	}
 	if err != nil {
 		log.L(ctx).Errorf("Failed to submit answersheet: %v", err)
 		c.JSON(http.StatusInternalServerError, response.ErrorResponse{
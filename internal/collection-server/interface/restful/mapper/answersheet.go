@@ -50,7 +50,7 @@ func (m *AnswersheetMapper) ToSubmitResponse(
 	serviceResp *answersheetapp.SubmitResponse,
 	req *request.AnswersheetSubmitRequest,
 ) *response.AnswersheetSubmitResponse {
-	return &response.AnswersheetSubmitResponse{
+	resp := &response.AnswersheetSubmitResponse{
 		ID:                serviceResp.ID,
 		QuestionnaireCode: req.QuestionnaireCode,
 		Status:            serviceResp.Status,
@@ -58,6 +58,15 @@ func (m *AnswersheetMapper) ToSubmitResponse(
 		ValidationStatus:  "valid",
 		Message:           serviceResp.Message,
 	}
+
+	for _, warning := range serviceResp.Warnings {
+		resp.ValidationWarnings = append(resp.ValidationWarnings, response.ValidationWarning{
+			Field:   warning.Field,
+			Rule:    warning.Rule,
+			Message: warning.Message,
+		})
+	}
+	return resp
 }
 
 // ToAnswersheetResponse 将gRPC答卷数据转换为HTTP详情响应
@@ -64,6 +64,13 @@ type ValidationError struct {
 	Message      string `json:"message"`
 }
 
+// ValidationWarning 验证警告
+type ValidationWarning struct {
+	Field   string `json:"field"`
+	Rule    string `json:"rule"`
+	Message string `json:"message"`
+}
+
 // AnswersheetSubmitResponse 提交答卷响应
 type AnswersheetSubmitResponse struct {
 	ID                string            `json:"id"`
@@ -72,9 +79,11 @@ type AnswersheetSubmitResponse struct {
 	SubmissionTime    time.Time         `json:"submission_time"`
 	ValidationStatus  string            `json:"validation_status"`
 	ValidationErrors  []ValidationError `json:"validation_errors,omitempty"`
-	TotalScore        *float64          `json:"total_score,omitempty"`
-	NextSteps         []NextStep        `json:"next_steps,omitempty"`
-	Message           string            `json:"message"`
+	// ValidationWarnings 不阻断提交的验证警告，前端可提示用户核对
+	ValidationWarnings []ValidationWarning `json:"validation_warnings,omitempty"`
+	TotalScore         *float64            `json:"total_score,omitempty"`
+	NextSteps          []NextStep          `json:"next_steps,omitempty"`
+	Message            string              `json:"message"`
 }
 
 // NextStep 下一步操作
@@ -6,6 +6,7 @@ import (
 
 	"github.com/spf13/pflag"
 	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+	"github.com/yshujie/questionnaire-scale/pkg/app"
 	cliflag "github.com/yshujie/questionnaire-scale/pkg/flag"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 	"github.com/yshujie/questionnaire-scale/pkg/pubsub"
@@ -23,13 +24,18 @@ type Options struct {
 	Redis *genericoptions.RedisOptions `json:"redis" mapstructure:"redis"`
 	// 并发处理配置
 	Concurrency *ConcurrencyOptions `json:"concurrency" mapstructure:"concurrency"`
+	// IP 归属地配置，用于问卷的地域限制
+	GeoIP *GeoIPOptions `json:"geoip" mapstructure:"geoip"`
 }
 
 // GRPCClientOptions GRPC 客户端配置
 type GRPCClientOptions struct {
-	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
-	Timeout  int    `json:"timeout"  mapstructure:"timeout"`  // 超时时间（秒）
-	Insecure bool   `json:"insecure" mapstructure:"insecure"` // 是否使用不安全连接
+	Endpoint       string `json:"endpoint"                mapstructure:"endpoint"`
+	Timeout        int    `json:"timeout"                 mapstructure:"timeout"`                 // 超时时间（秒）
+	Insecure       bool   `json:"insecure"                mapstructure:"insecure"`                // 是否使用不安全连接
+	MaxRecvMsgSize int    `json:"max_recv_msg_size_bytes" mapstructure:"max_recv_msg_size_bytes"` // 可接收的最大消息字节数
+	MaxSendMsgSize int    `json:"max_send_msg_size_bytes" mapstructure:"max_send_msg_size_bytes"` // 可发送的最大消息字节数
+	APIKey         string `json:"-"                       mapstructure:"api_key"`                 // 调用 apiserver 时携带的 API Key，为空时不携带
 }
 
 // ConcurrencyOptions 并发处理配置
@@ -37,6 +43,12 @@ type ConcurrencyOptions struct {
 	MaxConcurrency int `json:"max_concurrency" mapstructure:"max_concurrency"` // 最大并发数
 }
 
+// GeoIPOptions IP 归属地配置
+type GeoIPOptions struct {
+	// DatabasePath MaxMind 国家数据库文件路径，为空时无法确定请求所属国家，设置了地域限制的问卷一律不可访问
+	DatabasePath string `json:"database_path" mapstructure:"database_path"`
+}
+
 // LoggingOptions 日志配置选项
 type LoggingOptions struct {
 	// EnableAPILogging 是否启用详细API日志
@@ -74,14 +86,17 @@ func NewOptions() *Options {
 		InsecureServing:         genericoptions.NewInsecureServingOptions(),
 		SecureServing:           genericoptions.NewSecureServingOptions(),
 		GRPCClient: &GRPCClientOptions{
-			Endpoint: "localhost:9090", // apiserver 的 GRPC 端口
-			Timeout:  30,
-			Insecure: true,
+			Endpoint:       "localhost:9090", // apiserver 的 GRPC 端口
+			Timeout:        30,
+			Insecure:       true,
+			MaxRecvMsgSize: genericoptions.DefaultGRPCMaxMsgSize,
+			MaxSendMsgSize: genericoptions.DefaultGRPCMaxMsgSize,
 		},
 		Redis: genericoptions.NewRedisOptions(),
 		Concurrency: &ConcurrencyOptions{
 			MaxConcurrency: 10, // 默认最大并发数
 		},
+		GeoIP: &GeoIPOptions{},
 	}
 }
 
@@ -94,6 +109,7 @@ func (o *Options) Flags() (fss cliflag.NamedFlagSets) {
 	o.GRPCClient.AddFlags(fss.FlagSet("grpc-client"))
 	o.Redis.AddFlags(fss.FlagSet("redis"))
 	o.Concurrency.AddFlags(fss.FlagSet("concurrency"))
+	o.GeoIP.AddFlags(fss.FlagSet("geoip"))
 
 	return fss
 }
@@ -106,6 +122,12 @@ func (g *GRPCClientOptions) AddFlags(fs *pflag.FlagSet) {
 		"The timeout for gRPC client requests in seconds.")
 	fs.BoolVar(&g.Insecure, "grpc-client.insecure", g.Insecure,
 		"Whether to use insecure gRPC connection.")
+	fs.IntVar(&g.MaxRecvMsgSize, "grpc-client.max-recv-msg-size-bytes", g.MaxRecvMsgSize,
+		"Maximum size in bytes of a gRPC response the client can receive, up to 64MB.")
+	fs.IntVar(&g.MaxSendMsgSize, "grpc-client.max-send-msg-size-bytes", g.MaxSendMsgSize,
+		"Maximum size in bytes of a gRPC request the client can send, up to 64MB.")
+	fs.StringVar(&g.APIKey, "grpc-client.api-key", g.APIKey,
+		"API key sent as x-api-key metadata, required when apiserver enables grpc.require-auth.")
 }
 
 // AddFlags 添加并发处理相关的命令行参数
@@ -114,6 +136,12 @@ func (c *ConcurrencyOptions) AddFlags(fs *pflag.FlagSet) {
 		"The maximum number of concurrent goroutines for validation.")
 }
 
+// AddFlags 添加 IP 归属地相关的命令行参数
+func (g *GeoIPOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&g.DatabasePath, "geoip.database-path", g.DatabasePath,
+		"The path of MaxMind GeoIP2/GeoLite2 country database, geo-restricted questionnaires are unavailable when empty.")
+}
+
 // ToPubSubConfig 将RedisOptions转换为pubsub.Config
 func (o *Options) ToPubSubConfig() *pubsub.Config {
 	addr := fmt.Sprintf("%s:%d", o.Redis.Host, o.Redis.Port)
@@ -146,26 +174,35 @@ func (o *Options) Validate() []error {
 
 	// 验证 GRPC 客户端配置
 	if o.GRPCClient.Endpoint == "" {
-		errs = append(errs, fmt.Errorf("grpc-client.endpoint cannot be empty"))
+		errs = append(errs, app.NewOptionsValidationError("grpc-client.endpoint", "cannot be empty"))
 	}
 	if o.GRPCClient.Timeout <= 0 {
-		errs = append(errs, fmt.Errorf("grpc-client.timeout must be greater than 0"))
+		errs = append(errs, app.NewOptionsValidationError("grpc-client.timeout", "must be greater than 0"))
+	}
+	if err := genericoptions.ValidateGRPCMsgSize("grpc-client.max-recv-msg-size-bytes", o.GRPCClient.MaxRecvMsgSize); err != nil {
+		errs = append(errs, err)
+	}
+	if err := genericoptions.ValidateGRPCMsgSize("grpc-client.max-send-msg-size-bytes", o.GRPCClient.MaxSendMsgSize); err != nil {
+		errs = append(errs, err)
+	}
+	if o.GRPCClient.APIKey != "" && len(o.GRPCClient.APIKey) < 16 {
+		errs = append(errs, app.NewOptionsValidationError("grpc-client.api-key", "must be at least 16 characters"))
 	}
 
 	// 验证 Redis 配置
 	if o.Redis.Host == "" {
-		errs = append(errs, fmt.Errorf("redis.host cannot be empty"))
+		errs = append(errs, app.NewOptionsValidationError("redis.host", "cannot be empty"))
 	}
 	if o.Redis.Port <= 0 {
-		errs = append(errs, fmt.Errorf("redis.port must be greater than 0"))
+		errs = append(errs, app.NewOptionsValidationError("redis.port", "must be greater than 0"))
 	}
 
 	// 验证并发配置
 	if o.Concurrency.MaxConcurrency <= 0 {
-		errs = append(errs, fmt.Errorf("concurrency.max-concurrency must be greater than 0"))
+		errs = append(errs, app.NewOptionsValidationError("concurrency.max-concurrency", "must be greater than 0"))
 	}
 	if o.Concurrency.MaxConcurrency > 100 {
-		errs = append(errs, fmt.Errorf("concurrency.max-concurrency cannot be greater than 100"))
+		errs = append(errs, app.NewOptionsValidationError("concurrency.max-concurrency", "cannot be greater than 100"))
 	}
 
 	return errs
@@ -0,0 +1,36 @@
+package options
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+)
+
+func TestOptions_Validate_RequiredFields(t *testing.T) {
+	if errs := NewOptions().Validate(); len(errs) != 0 {
+		t.Fatalf("NewOptions().Validate() = %v, want no errors", errs)
+	}
+
+	tests := []struct {
+		field string
+		unset func(o *Options)
+	}{
+		{"grpc-client.endpoint", func(o *Options) { o.GRPCClient.Endpoint = "" }},
+		{"redis.host", func(o *Options) { o.Redis.Host = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			o := NewOptions()
+			tt.unset(o)
+
+			var buf bytes.Buffer
+			app.PrintOptionsValidationErrors(&buf, o.Validate())
+			if !strings.Contains(buf.String(), tt.field) {
+				t.Errorf("validation output does not name field %s:\n%s", tt.field, buf.String())
+			}
+		})
+	}
+}
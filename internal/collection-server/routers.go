@@ -1,6 +1,7 @@
 package collection
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -95,10 +96,14 @@ func (r *Router) registerQuestionnaireRoutes(apiV1 *gin.RouterGroup) {
 
 	questionnaires := apiV1.Group("/questionnaires")
 	{
-		// 获取问卷列表和详情
-		questionnaires.GET("", questionnaireHandler.List)             // 获取问卷列表
-		questionnaires.GET("/:code", questionnaireHandler.Get)        // 获取问卷详情
-		questionnaires.GET("/:code/raw", questionnaireHandler.GetRaw) // 获取原始问卷
+		// 获取问卷列表
+		questionnaires.GET("", questionnaireHandler.List)
+
+		// 获取问卷详情，设置了地域限制的问卷仅允许限定国家访问
+		questionnaire := questionnaires.Group("/:code")
+		questionnaire.Use(r.container.GeoRestrictionGuard.Middleware(r.loadGeoRestriction))
+		questionnaire.GET("", questionnaireHandler.Get)        // 获取问卷详情
+		questionnaire.GET("/raw", questionnaireHandler.GetRaw) // 获取原始问卷
 
 		// 问卷验证（可选路由，根据需要启用）
 		// questionnaires.POST("/validate", questionnaireHandler.ValidateCode)
@@ -125,6 +130,15 @@ func (r *Router) registerAnswersheetRoutes(apiV1 *gin.RouterGroup) {
 	}
 }
 
+// loadGeoRestriction 查询问卷允许访问的国家列表
+func (r *Router) loadGeoRestriction(ctx context.Context, code string) ([]string, error) {
+	q, err := r.container.QuestionnaireService.GetQuestionnaire(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+	return q.GeoRestriction, nil
+}
+
 // 公共路由处理函数
 
 // getServerInfo 获取服务器信息
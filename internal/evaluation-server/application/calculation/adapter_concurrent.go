@@ -73,11 +73,12 @@ func (a *ConcurrentCalculationAdapter) Calculate(ctx context.Context, request *C
 	}
 
 	return &CalculationResult{
-		ID:       request.ID,
-		Name:     request.Name,
-		Value:    result.Value,
-		Details:  result,
-		Duration: duration,
+		ID:                 request.ID,
+		Name:               request.Name,
+		Value:              result.Value,
+		Details:            result,
+		Duration:           duration,
+		NotApplicableCount: result.NotApplicableCount,
 	}, nil
 }
 
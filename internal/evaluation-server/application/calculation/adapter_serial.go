@@ -55,11 +55,12 @@ func (a *SerialCalculationAdapter) Calculate(ctx context.Context, request *Calcu
 	}
 
 	return &CalculationResult{
-		ID:       request.ID,
-		Name:     request.Name,
-		Value:    result.Value,
-		Details:  result,
-		Duration: duration,
+		ID:                 request.ID,
+		Name:               request.Name,
+		Value:              result.Value,
+		Details:            result,
+		Duration:           duration,
+		NotApplicableCount: result.NotApplicableCount,
 	}, nil
 }
 
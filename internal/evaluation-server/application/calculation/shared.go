@@ -6,6 +6,10 @@ import (
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
+// NotApplicableAnswerValue 答案值为该值时表示受试者将题目标记为"不适用"
+// 不适用的题目不计为 0 分，而是从总分与平均值的分母中剔除
+const NotApplicableAnswerValue = "N/A"
+
 // CalculationRequest 计算请求
 type CalculationRequest struct {
 	ID           string                 `json:"id"`            // 计算任务ID
@@ -15,6 +19,13 @@ type CalculationRequest struct {
 	Parameters   map[string]interface{} `json:"parameters"`    // 额外参数
 	Precision    int                    `json:"precision"`     // 精度要求
 	RoundingMode string                 `json:"rounding_mode"` // 舍入模式
+	Prorate      *ProrateOption         `json:"prorate"`       // 按比例折算（仅对求和生效）
+}
+
+// ProrateOption 按比例折算选项
+type ProrateOption struct {
+	TotalItems int `json:"total_items"` // 条目总数
+	MaxMissing int `json:"max_missing"` // 允许缺失的最大条目数
 }
 
 // CalculationResult 计算结果
@@ -25,6 +36,8 @@ type CalculationResult struct {
 	Details  *strategies.CalculationResult `json:"details"`  // 详细计算信息
 	Error    string                        `json:"error"`    // 错误信息
 	Duration int64                         `json:"duration"` // 计算耗时（纳秒）
+
+	NotApplicableCount int `json:"not_applicable_count"` // 不适用项数量
 }
 
 // createCalculationRule 创建计算规则（共享函数）
@@ -45,6 +58,11 @@ func createCalculationRule(request *CalculationRequest) (*rules.CalculationRule,
 		rule.SetRoundingMode(request.RoundingMode)
 	}
 
+	// 应用折算设置
+	if request.Prorate != nil {
+		rule.SetProrate(request.Prorate.TotalItems, request.Prorate.MaxMissing)
+	}
+
 	// 应用额外参数
 	for key, value := range request.Parameters {
 		rule.AddParam(key, value)
@@ -60,12 +78,16 @@ func mapFormulaTypeToStrategy(formulaType string) string {
 		return "option"
 	case "sum":
 		return "sum"
-	case "average", "avg":
+	case "average", "avg", "mean":
 		return "average"
 	case "max", "maximum":
 		return "max"
 	case "min", "minimum":
 		return "min"
+	case "count_above_threshold":
+		return "count_above_threshold"
+	case "conditional":
+		return "conditional"
 	case "weighted", "weighted_average":
 		return "weighted"
 	default:
@@ -9,6 +9,7 @@ import (
 	answersheetpb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/answersheet"
 	questionnairepb "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaire"
 	calculationapp "github.com/yshujie/questionnaire-scale/internal/evaluation-server/application/calculation"
+	"github.com/yshujie/questionnaire-scale/internal/evaluation-server/domain/calculation/strategies"
 	grpcclient "github.com/yshujie/questionnaire-scale/internal/evaluation-server/infrastructure/grpc"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/pubsub"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
@@ -18,6 +19,7 @@ import (
 type CalcAnswersheetScoreHandler struct {
 	questionnaireClient *grpcclient.QuestionnaireClient
 	answersheetClient   *grpcclient.AnswerSheetClient
+	scoringClient       *grpcclient.ScoringClient
 	calculationPort     calculationapp.CalculationPort
 }
 
@@ -25,10 +27,12 @@ type CalcAnswersheetScoreHandler struct {
 func NewCalcAnswersheetScoreHandler(
 	questionnaireClient *grpcclient.QuestionnaireClient,
 	answersheetClient *grpcclient.AnswerSheetClient,
+	scoringClient *grpcclient.ScoringClient,
 ) *CalcAnswersheetScoreHandler {
 	return &CalcAnswersheetScoreHandler{
 		questionnaireClient: questionnaireClient,
 		answersheetClient:   answersheetClient,
+		scoringClient:       scoringClient,
 		calculationPort:     calculationapp.GetConcurrentCalculationPort(50), // 默认并发50
 	}
 }
@@ -37,11 +41,13 @@ func NewCalcAnswersheetScoreHandler(
 func NewCalcAnswersheetScoreHandlerWithAdapter(
 	questionnaireClient *grpcclient.QuestionnaireClient,
 	answersheetClient *grpcclient.AnswerSheetClient,
+	scoringClient *grpcclient.ScoringClient,
 	calculationPort calculationapp.CalculationPort,
 ) *CalcAnswersheetScoreHandler {
 	return &CalcAnswersheetScoreHandler{
 		questionnaireClient: questionnaireClient,
 		answersheetClient:   answersheetClient,
+		scoringClient:       scoringClient,
 		calculationPort:     calculationPort,
 	}
 }
@@ -73,7 +79,7 @@ func (h *CalcAnswersheetScoreHandler) Handle(ctx context.Context, data pubsub.An
 
 	// 计算答卷总分
 	totalStartTime := time.Now()
-	if err := h.calculateAnswerSheetTotalScore(answersheet); err != nil {
+	if err := h.calculateAnswerSheetTotalScore(ctx, answersheet); err != nil {
 		log.Errorf("计算答卷总分失败: %v", err)
 		return err
 	}
@@ -127,6 +133,7 @@ func (h *CalcAnswersheetScoreHandler) applyAnswerCalculationResults(answersheet
 
 	successCount := 0
 	errorCount := 0
+	notApplicableCount := 0
 
 	for _, result := range results {
 		if result.Error != "" {
@@ -135,6 +142,8 @@ func (h *CalcAnswersheetScoreHandler) applyAnswerCalculationResults(answersheet
 			continue
 		}
 
+		notApplicableCount += result.NotApplicableCount
+
 		// 从结果ID提取问题代码
 		if questionCode := extractQuestionCodeFromResultID(result.ID); questionCode != "" {
 			if answer, exists := answerMap[questionCode]; exists {
@@ -145,7 +154,7 @@ func (h *CalcAnswersheetScoreHandler) applyAnswerCalculationResults(answersheet
 		}
 	}
 
-	log.Infof("答案得分计算完成，成功 %d 个，失败 %d 个", successCount, errorCount)
+	log.Infof("答案得分计算完成，成功 %d 个，失败 %d 个，不适用项 %d 个", successCount, errorCount, notApplicableCount)
 	return nil
 }
 
@@ -181,17 +190,27 @@ func (h *CalcAnswersheetScoreHandler) loadAnswersheet(ctx context.Context, answe
 }
 
 // calculateAnswerSheetTotalScore 计算答卷总分
-func (h *CalcAnswersheetScoreHandler) calculateAnswerSheetTotalScore(answersheet *answersheetpb.AnswerSheet) error {
-	var totalScore float64
-	for _, answer := range answersheet.Answers {
-		totalScore += float64(answer.Score)
+// 总分由 apiserver 的计分引擎按医学量表配置的计分策略计算，与量表的分量表求和、加权求和等策略保持一致
+func (h *CalcAnswersheetScoreHandler) calculateAnswerSheetTotalScore(ctx context.Context, answersheet *answersheetpb.AnswerSheet) error {
+	scored, err := h.scoringClient.ScoreAnswerSheet(ctx, answersheet)
+	if err != nil {
+		return err
 	}
 
-	answersheet.Score = float64(totalScore)
-	log.Debugf("答卷总分计算完成: %d", answersheet.Score)
+	answersheet.Score = scored.TotalScore
+	log.Debugf("答卷总分计算完成: %f", answersheet.Score)
 	return nil
 }
 
+// parseAnswerValue 解析答案值，JSON 字符串解码后返回，否则直接使用原值
+func parseAnswerValue(answer *answersheetpb.Answer) string {
+	var actualValue string
+	if err := json.Unmarshal([]byte(answer.Value), &actualValue); err != nil {
+		actualValue = answer.Value
+	}
+	return actualValue
+}
+
 // saveAnswerSheetScores 保存答卷得分
 func (h *CalcAnswersheetScoreHandler) saveAnswerSheetScores(ctx context.Context, answerSheetID uint64, answersheet *answersheetpb.AnswerSheet) error {
 	// 保存答卷得分
@@ -221,6 +240,12 @@ func (h *CalcAnswersheetScoreHandler) convertAnswerBatchCalculation(answersheet
 		questionMap[question.Code] = question
 	}
 
+	// 创建答案值映射，供条件计分查询引用题目的答案
+	answerValues := make(map[string]string, len(answersheet.Answers))
+	for _, answer := range answersheet.Answers {
+		answerValues[answer.QuestionCode] = parseAnswerValue(answer)
+	}
+
 	var requests []*calculationapp.CalculationRequest
 
 	for _, answer := range answersheet.Answers {
@@ -236,7 +261,7 @@ func (h *CalcAnswersheetScoreHandler) convertAnswerBatchCalculation(answersheet
 			continue
 		}
 
-		request, err := h.convertAnswerCalculation(answer, question)
+		request, err := h.convertAnswerCalculation(answer, question, answerValues)
 		if err != nil {
 			log.Errorf("转换答案计算请求失败，问题: %s, 错误: %v", answer.QuestionCode, err)
 			continue
@@ -250,7 +275,8 @@ func (h *CalcAnswersheetScoreHandler) convertAnswerBatchCalculation(answersheet
 }
 
 // convertAnswerCalculation 转换答案计算请求（私有方法）
-func (h *CalcAnswersheetScoreHandler) convertAnswerCalculation(answer *answersheetpb.Answer, question *questionnairepb.Question) (*calculationapp.CalculationRequest, error) {
+// answerValues 为答卷中各题目的答案值，条件计分规则按其中引用题目的答案选择计分映射
+func (h *CalcAnswersheetScoreHandler) convertAnswerCalculation(answer *answersheetpb.Answer, question *questionnairepb.Question, answerValues map[string]string) (*calculationapp.CalculationRequest, error) {
 	if answer == nil || question == nil {
 		return nil, fmt.Errorf("答案或问题不能为空")
 	}
@@ -265,16 +291,27 @@ func (h *CalcAnswersheetScoreHandler) convertAnswerCalculation(answer *answershe
 		return nil, fmt.Errorf("解析答案操作数失败: %w", err)
 	}
 
+	parameters := map[string]interface{}{
+		"question_code": answer.QuestionCode,
+		"question_type": answer.QuestionType,
+		"answer_value":  answer.Value,
+	}
+	if rule := question.CalculationRule; rule.FormulaType == "conditional" {
+		mappings := make([]strategies.ScoreMapping, len(rule.ScoreMappings))
+		for i, mapping := range rule.ScoreMappings {
+			mappings[i] = strategies.ScoreMapping{WhenValue: mapping.WhenValue, Scores: mapping.Scores}
+		}
+		parameters["option_code"] = parseAnswerValue(answer)
+		parameters["depends_on_value"] = answerValues[rule.DependsOnCode]
+		parameters["score_mappings"] = mappings
+	}
+
 	return &calculationapp.CalculationRequest{
-		ID:          fmt.Sprintf("answer_%s", answer.QuestionCode),
-		Name:        fmt.Sprintf("问题 %s 答案计算", question.Title),
-		FormulaType: question.CalculationRule.FormulaType,
-		Operands:    operands,
-		Parameters: map[string]interface{}{
-			"question_code": answer.QuestionCode,
-			"question_type": answer.QuestionType,
-			"answer_value":  answer.Value,
-		},
+		ID:           fmt.Sprintf("answer_%s", answer.QuestionCode),
+		Name:         fmt.Sprintf("问题 %s 答案计算", question.Title),
+		FormulaType:  question.CalculationRule.FormulaType,
+		Operands:     operands,
+		Parameters:   parameters,
 		Precision:    2,
 		RoundingMode: "round",
 	}, nil
@@ -283,14 +320,15 @@ func (h *CalcAnswersheetScoreHandler) convertAnswerCalculation(answer *answershe
 // extractOperandsFromAnswer 从答案中提取操作数（私有方法）
 func (h *CalcAnswersheetScoreHandler) extractOperandsFromAnswer(answer *answersheetpb.Answer, question *questionnairepb.Question) ([]float64, error) {
 	// 解析答案值
-	var actualValue string
-	if err := json.Unmarshal([]byte(answer.Value), &actualValue); err != nil {
-		// 如果不是JSON格式，直接使用原值
-		actualValue = answer.Value
-	}
+	actualValue := parseAnswerValue(answer)
 
 	log.Debugf("解析答案值: 原始值=%s, 解析后=%s", answer.Value, actualValue)
 
+	// 不适用的答案以哨兵值参与计算，由计算策略从分母中剔除
+	if actualValue == calculationapp.NotApplicableAnswerValue {
+		return []float64{strategies.NotApplicable()}, nil
+	}
+
 	// 遍历问题选项寻找匹配的得分
 	for _, option := range question.Options {
 		if option.Code == actualValue {
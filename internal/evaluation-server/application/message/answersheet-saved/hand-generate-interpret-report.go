@@ -377,6 +377,7 @@ func (h *GenerateInterpretReportHandlerConcurrent) convertFactorCalculation(fact
 			"factor_type":  factor.FactorType,
 			"source_codes": factor.CalculationRule.SourceCodes,
 			"total_score":  factor.IsTotalScore,
+			"threshold":    factor.CalculationRule.Threshold,
 		},
 		Precision:    2,
 		RoundingMode: "round",
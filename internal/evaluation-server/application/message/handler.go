@@ -29,6 +29,7 @@ func NewHandler(
 	questionnaireClient *grpcclient.QuestionnaireClient,
 	medicalScaleClient *grpcclient.MedicalScaleClient,
 	interpretReportClient *grpcclient.InterpretReportClient,
+	scoringClient *grpcclient.ScoringClient,
 ) Handler {
 	// 创建消息分发器
 	dispatcher := NewMessageDispatcher()
@@ -40,6 +41,7 @@ func NewHandler(
 	handlerChain.AddHandler(answersheet_saved.NewCalcAnswersheetScoreHandler(
 		questionnaireClient,
 		answersheetClient,
+		scoringClient,
 	))
 
 	// 添加生成解读报告处理器（使用并发版本）
@@ -109,6 +111,7 @@ func NewHandlerWithConcurrency(
 	questionnaireClient *grpcclient.QuestionnaireClient,
 	medicalScaleClient *grpcclient.MedicalScaleClient,
 	interpretReportClient *grpcclient.InterpretReportClient,
+	scoringClient *grpcclient.ScoringClient,
 	maxConcurrency int,
 ) Handler {
 	// 创建消息分发器
@@ -121,6 +124,7 @@ func NewHandlerWithConcurrency(
 	handlerChain.AddHandler(answersheet_saved.NewCalcAnswersheetScoreHandler(
 		questionnaireClient,
 		answersheetClient,
+		scoringClient,
 	))
 
 	// 添加生成解读报告处理器（使用并发版本，可配置并发数）
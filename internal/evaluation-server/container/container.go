@@ -18,6 +18,7 @@ type Container struct {
 	AnswerSheetClient     *grpcclient.AnswerSheetClient
 	MedicalScaleClient    *grpcclient.MedicalScaleClient
 	InterpretReportClient *grpcclient.InterpretReportClient
+	ScoringClient         *grpcclient.ScoringClient
 
 	// gRPC 客户端工厂
 	grpcClientFactory *grpcclient.ClientFactory
@@ -81,7 +82,12 @@ func (c *Container) initializeGRPCClients() error {
 	log.Info("   🔌 Initializing gRPC clients...")
 
 	// 创建 gRPC 客户端工厂
-	factory, err := grpcclient.NewClientFactory(c.grpcClientConfig.Endpoint)
+	factory, err := grpcclient.NewClientFactory(
+		c.grpcClientConfig.Endpoint,
+		c.grpcClientConfig.MaxRecvMsgSize,
+		c.grpcClientConfig.MaxSendMsgSize,
+		c.grpcClientConfig.APIKey,
+	)
 	if err != nil {
 		return fmt.Errorf("failed to create gRPC client factory: %w", err)
 	}
@@ -92,6 +98,7 @@ func (c *Container) initializeGRPCClients() error {
 	c.AnswerSheetClient = grpcclient.NewAnswerSheetClient(factory)
 	c.MedicalScaleClient = grpcclient.NewMedicalScaleClient(factory)
 	c.InterpretReportClient = grpcclient.NewInterpretReportClient(factory)
+	c.ScoringClient = grpcclient.NewScoringClient(factory)
 
 	log.Info("   ✅ gRPC clients initialized")
 	return nil
@@ -107,6 +114,7 @@ func (c *Container) initializeApplication() error {
 		c.QuestionnaireClient,
 		c.MedicalScaleClient,
 		c.InterpretReportClient,
+		c.ScoringClient,
 		c.concurrencyConfig.MaxConcurrency, // 从配置获取最大并发数
 	)
 
@@ -40,6 +40,12 @@ func (b *CalculationRuleBuilder) WithWeights(weights []float64) *CalculationRule
 	return b
 }
 
+// WithProrate 设置按比例折算
+func (b *CalculationRuleBuilder) WithProrate(totalItems, maxMissing int) *CalculationRuleBuilder {
+	b.rule.SetProrate(totalItems, maxMissing)
+	return b
+}
+
 // WithParam 添加参数
 func (b *CalculationRuleBuilder) WithParam(key string, value interface{}) *CalculationRuleBuilder {
 	b.rule.AddParam(key, value)
@@ -69,6 +75,11 @@ func SumWithPrecision(precision int) *CalculationRuleBuilder {
 	return Sum().WithPrecision(precision)
 }
 
+// ProratedSum 创建按比例折算的求和规则
+func ProratedSum(totalItems, maxMissing int) *CalculationRuleBuilder {
+	return Sum().WithProrate(totalItems, maxMissing)
+}
+
 // Average 创建平均值计算规则
 func Average() *CalculationRuleBuilder {
 	return NewCalculationRule("average")
@@ -89,6 +100,11 @@ func Min() *CalculationRuleBuilder {
 	return NewCalculationRule("min")
 }
 
+// CountAboveThreshold 创建超过阈值条目数计算规则
+func CountAboveThreshold(threshold float64) *CalculationRuleBuilder {
+	return NewCalculationRule("count_above_threshold").WithParam("threshold", threshold)
+}
+
 // Option 创建选项计算规则
 func Option() *CalculationRuleBuilder {
 	return NewCalculationRule("option").WithOperandLimits(1, 1)
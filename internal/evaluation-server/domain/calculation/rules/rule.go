@@ -17,6 +17,15 @@ type CalculationConfig struct {
 	Weights         []float64              `json:"weights"`          // 权重配置
 	ValidationRules []ValidationRule       `json:"validation_rules"` // 验证规则
 	CustomParams    map[string]interface{} `json:"custom_params"`    // 自定义参数
+	Prorate         ProrateConfig          `json:"prorate"`          // 按比例折算配置
+}
+
+// ProrateConfig 按比例折算配置
+// 量表存在少量缺失条目时，按 (条目总数 / 已完成条目数) 对总和进行折算
+type ProrateConfig struct {
+	Enabled    bool `json:"enabled"`     // 是否启用折算
+	TotalItems int  `json:"total_items"` // 条目总数
+	MaxMissing int  `json:"max_missing"` // 允许缺失的最大条目数
 }
 
 // ValidationRule 验证规则
@@ -82,6 +91,16 @@ func (r *CalculationRule) SetWeights(weights []float64) *CalculationRule {
 	return r
 }
 
+// SetProrate 启用按比例折算
+func (r *CalculationRule) SetProrate(totalItems, maxMissing int) *CalculationRule {
+	r.Config.Prorate = ProrateConfig{
+		Enabled:    true,
+		TotalItems: totalItems,
+		MaxMissing: maxMissing,
+	}
+	return r
+}
+
 // AddValidationRule 添加验证规则
 func (r *CalculationRule) AddValidationRule(ruleType string, value interface{}, message string) *CalculationRule {
 	r.Config.ValidationRules = append(r.Config.ValidationRules, ValidationRule{
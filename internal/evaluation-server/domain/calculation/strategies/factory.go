@@ -82,7 +82,9 @@ func (f *StrategyFactory) RegisterDefaultStrategies() {
 	f.RegisterStrategy(NewAverageStrategy())
 	f.RegisterStrategy(NewMaxStrategy())
 	f.RegisterStrategy(NewMinStrategy())
+	f.RegisterStrategy(NewCountAboveThresholdStrategy())
 	f.RegisterStrategy(NewOptionStrategy())
+	f.RegisterStrategy(NewConditionalStrategy())
 	f.RegisterStrategy(NewWeightedStrategy())
 }
 
@@ -25,6 +25,14 @@ func NewSumStrategy() *SumStrategy {
 
 // Calculate 执行求和计算
 func (s *SumStrategy) Calculate(ctx context.Context, operands []float64, rule *rules.CalculationRule) (*CalculationResult, error) {
+	operands, naCount := excludeNotApplicable(operands)
+	if err := s.validateProrate(operands, rule); err != nil {
+		return nil, err
+	}
+	if len(operands) == 0 && naCount > 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
 	if err := s.Validate(operands, rule); err != nil {
 		return nil, err
 	}
@@ -34,9 +42,20 @@ func (s *SumStrategy) Calculate(ctx context.Context, operands []float64, rule *r
 		sum += operand
 	}
 
-	result := NewCalculationResult(s.applyRounding(sum, rule), s.Name)
+	value := sum
+	prorate := rule.Config.Prorate
+	if prorate.Enabled {
+		value = sum * float64(prorate.TotalItems) / float64(len(operands))
+	}
+
+	result := NewCalculationResult(s.applyRounding(value, rule), s.Name)
 	result.SetMetadata("raw_sum", sum)
+	if prorate.Enabled {
+		result.SetMetadata("prorated", true)
+		result.SetMetadata("missing_count", prorate.TotalItems-len(operands))
+	}
 	result.SetMetadata("operand_count", len(operands))
+	result.NotApplicableCount = naCount
 
 	// 记录操作数信息
 	for i, operand := range operands {
@@ -46,6 +65,32 @@ func (s *SumStrategy) Calculate(ctx context.Context, operands []float64, rule *r
 	return result, nil
 }
 
+// validateProrate 验证折算条件，缺失条目数超过允许阈值时拒绝计算
+func (s *SumStrategy) validateProrate(operands []float64, rule *rules.CalculationRule) error {
+	prorate := rule.Config.Prorate
+	if !prorate.Enabled {
+		return nil
+	}
+
+	if prorate.TotalItems <= 0 {
+		return NewCalculationError("", "折算条目总数必须大于 0", operands, s.Name)
+	}
+	if len(operands) > prorate.TotalItems {
+		return NewCalculationError("",
+			fmt.Sprintf("已完成条目数 %d 超过条目总数 %d", len(operands), prorate.TotalItems),
+			operands, s.Name)
+	}
+
+	missing := prorate.TotalItems - len(operands)
+	if missing > prorate.MaxMissing || len(operands) == 0 {
+		return NewCalculationError("",
+			fmt.Sprintf("缺失条目数 %d 超过允许的最大缺失数 %d，无法折算", missing, prorate.MaxMissing),
+			operands, s.Name)
+	}
+
+	return nil
+}
+
 // AverageStrategy 平均值计算策略
 type AverageStrategy struct {
 	BaseStrategy
@@ -63,6 +108,11 @@ func NewAverageStrategy() *AverageStrategy {
 
 // Calculate 执行平均值计算
 func (s *AverageStrategy) Calculate(ctx context.Context, operands []float64, rule *rules.CalculationRule) (*CalculationResult, error) {
+	operands, naCount := excludeNotApplicable(operands)
+	if len(operands) == 0 && naCount > 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
 	if err := s.Validate(operands, rule); err != nil {
 		return nil, err
 	}
@@ -77,6 +127,7 @@ func (s *AverageStrategy) Calculate(ctx context.Context, operands []float64, rul
 	result.SetMetadata("sum", sum)
 	result.SetMetadata("raw_average", average)
 	result.SetMetadata("operand_count", len(operands))
+	result.NotApplicableCount = naCount
 
 	// 记录操作数信息
 	for i, operand := range operands {
@@ -103,6 +154,11 @@ func NewMaxStrategy() *MaxStrategy {
 
 // Calculate 执行最大值计算
 func (s *MaxStrategy) Calculate(ctx context.Context, operands []float64, rule *rules.CalculationRule) (*CalculationResult, error) {
+	operands, naCount := excludeNotApplicable(operands)
+	if len(operands) == 0 && naCount > 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
 	if err := s.Validate(operands, rule); err != nil {
 		return nil, err
 	}
@@ -120,6 +176,7 @@ func (s *MaxStrategy) Calculate(ctx context.Context, operands []float64, rule *r
 	result := NewCalculationResult(s.applyRounding(max, rule), s.Name)
 	result.SetMetadata("max_index", maxIndex)
 	result.SetMetadata("operand_count", len(operands))
+	result.NotApplicableCount = naCount
 
 	// 记录操作数信息
 	for i, operand := range operands {
@@ -146,6 +203,11 @@ func NewMinStrategy() *MinStrategy {
 
 // Calculate 执行最小值计算
 func (s *MinStrategy) Calculate(ctx context.Context, operands []float64, rule *rules.CalculationRule) (*CalculationResult, error) {
+	operands, naCount := excludeNotApplicable(operands)
+	if len(operands) == 0 && naCount > 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
 	if err := s.Validate(operands, rule); err != nil {
 		return nil, err
 	}
@@ -163,6 +225,7 @@ func (s *MinStrategy) Calculate(ctx context.Context, operands []float64, rule *r
 	result := NewCalculationResult(s.applyRounding(min, rule), s.Name)
 	result.SetMetadata("min_index", minIndex)
 	result.SetMetadata("operand_count", len(operands))
+	result.NotApplicableCount = naCount
 
 	// 记录操作数信息
 	for i, operand := range operands {
@@ -172,6 +235,76 @@ func (s *MinStrategy) Calculate(ctx context.Context, operands []float64, rule *r
 	return result, nil
 }
 
+// CountAboveThresholdStrategy 超过阈值条目数计算策略
+type CountAboveThresholdStrategy struct {
+	BaseStrategy
+}
+
+// NewCountAboveThresholdStrategy 创建超过阈值条目数策略
+func NewCountAboveThresholdStrategy() *CountAboveThresholdStrategy {
+	return &CountAboveThresholdStrategy{
+		BaseStrategy: BaseStrategy{
+			Name:        "count_above_threshold",
+			Description: "统计严格大于阈值的操作数个数，阈值由参数 threshold 指定",
+		},
+	}
+}
+
+// Validate 验证操作数及阈值参数
+func (s *CountAboveThresholdStrategy) Validate(operands []float64, rule *rules.CalculationRule) error {
+	if err := s.BaseStrategy.Validate(operands, rule); err != nil {
+		return err
+	}
+	if _, ok := thresholdParam(rule); !ok {
+		return NewCalculationError("", "缺少数值参数 threshold", operands, s.Name)
+	}
+	return nil
+}
+
+// Calculate 执行超过阈值条目数计算
+func (s *CountAboveThresholdStrategy) Calculate(ctx context.Context, operands []float64, rule *rules.CalculationRule) (*CalculationResult, error) {
+	operands, naCount := excludeNotApplicable(operands)
+	if len(operands) == 0 && naCount > 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
+	if err := s.Validate(operands, rule); err != nil {
+		return nil, err
+	}
+
+	threshold, _ := thresholdParam(rule)
+	count := 0
+	for _, operand := range operands {
+		if operand > threshold {
+			count++
+		}
+	}
+
+	result := NewCalculationResult(float64(count), s.Name)
+	result.SetMetadata("threshold", threshold)
+	result.SetMetadata("operand_count", len(operands))
+	result.NotApplicableCount = naCount
+
+	// 记录操作数信息
+	for i, operand := range operands {
+		result.AddOperandInfo(operand, 1.0, "", i)
+	}
+
+	return result, nil
+}
+
+// thresholdParam 读取规则参数中的阈值，兼容 JSON 解码得到的 float64 与代码中传入的 int
+func thresholdParam(rule *rules.CalculationRule) (float64, bool) {
+	switch v := rule.Params["threshold"].(type) {
+	case float64:
+		return v, true
+	case int:
+		return float64(v), true
+	default:
+		return 0, false
+	}
+}
+
 // OptionStrategy 选项计算策略
 type OptionStrategy struct {
 	BaseStrategy
@@ -209,6 +342,11 @@ func (s *OptionStrategy) Validate(operands []float64, rule *rules.CalculationRul
 
 // Calculate 执行选项计算
 func (s *OptionStrategy) Calculate(ctx context.Context, operands []float64, rule *rules.CalculationRule) (*CalculationResult, error) {
+	operands, naCount := excludeNotApplicable(operands)
+	if len(operands) == 0 && naCount > 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
 	if err := s.Validate(operands, rule); err != nil {
 		return nil, err
 	}
@@ -216,6 +354,7 @@ func (s *OptionStrategy) Calculate(ctx context.Context, operands []float64, rule
 	value := operands[0]
 	result := NewCalculationResult(s.applyRounding(value, rule), s.Name)
 	result.SetMetadata("operand_count", len(operands))
+	result.NotApplicableCount = naCount
 
 	// 记录操作数信息
 	for i, operand := range operands {
@@ -225,6 +364,80 @@ func (s *OptionStrategy) Calculate(ctx context.Context, operands []float64, rule
 	return result, nil
 }
 
+// ScoreMapping 条件计分映射
+// 引用题目的答案等于 WhenValue 时，选项按 Scores 中的分值计分
+type ScoreMapping struct {
+	WhenValue string
+	Scores    map[string]float64
+}
+
+// ConditionalStrategy 条件计分策略
+type ConditionalStrategy struct {
+	BaseStrategy
+}
+
+// NewConditionalStrategy 创建条件计分策略
+func NewConditionalStrategy() *ConditionalStrategy {
+	return &ConditionalStrategy{
+		BaseStrategy: BaseStrategy{
+			Name:        "conditional",
+			Description: "按引用题目的答案选择计分映射计算选项得分，如频率 × 严重程度类题目",
+		},
+	}
+}
+
+// Validate 验证条件计分操作数
+func (s *ConditionalStrategy) Validate(operands []float64, rule *rules.CalculationRule) error {
+	if err := s.BaseStrategy.Validate(operands, rule); err != nil {
+		return err
+	}
+
+	if len(operands) != 1 {
+		return NewCalculationError("",
+			fmt.Sprintf("操作数数量 %d 不等于 1", len(operands)),
+			operands, s.Name)
+	}
+
+	return nil
+}
+
+// Calculate 执行条件计分
+// 参数 option_code 为本题所选选项，depends_on_value 为引用题目的答案，score_mappings 为计分映射；
+// 取第一个条件值匹配的映射，没有匹配的映射或映射中未列出该选项时按操作数（选项分值）计分
+func (s *ConditionalStrategy) Calculate(ctx context.Context, operands []float64, rule *rules.CalculationRule) (*CalculationResult, error) {
+	operands, naCount := excludeNotApplicable(operands)
+	if len(operands) == 0 && naCount > 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
+	if err := s.Validate(operands, rule); err != nil {
+		return nil, err
+	}
+
+	value := operands[0]
+	matched := false
+	optionCode, _ := rule.Params["option_code"].(string)
+	dependsOnValue, _ := rule.Params["depends_on_value"].(string)
+	mappings, _ := rule.Params["score_mappings"].([]ScoreMapping)
+	for _, mapping := range mappings {
+		if mapping.WhenValue != dependsOnValue {
+			continue
+		}
+		if score, ok := mapping.Scores[optionCode]; ok {
+			value = score
+			matched = true
+		}
+		break
+	}
+
+	result := NewCalculationResult(s.applyRounding(value, rule), s.Name)
+	result.SetMetadata("mapping_matched", matched)
+	result.NotApplicableCount = naCount
+	result.AddOperandInfo(operands[0], 1.0, optionCode, 0)
+
+	return result, nil
+}
+
 // WeightedStrategy 加权计算策略
 type WeightedStrategy struct {
 	BaseStrategy
@@ -281,6 +494,12 @@ func (s *WeightedStrategy) Calculate(ctx context.Context, operands []float64, ru
 		}
 	}
 
+	// 剔除不适用的操作数及其对应权重
+	operands, weights, naCount := excludeNotApplicableWeighted(operands, weights)
+	if len(operands) == 0 {
+		return newNotApplicableResult(naCount, s.Name), nil
+	}
+
 	weightedSum := 0.0
 	totalWeight := 0.0
 
@@ -313,6 +532,7 @@ func (s *WeightedStrategy) Calculate(ctx context.Context, operands []float64, ru
 	result.SetMetadata("total_weight", totalWeight)
 	result.SetMetadata("weights", weights)
 	result.SetMetadata("operand_count", len(operands))
+	result.NotApplicableCount = naCount
 
 	// 记录操作数信息
 	for i, operand := range operands {
@@ -322,6 +542,20 @@ func (s *WeightedStrategy) Calculate(ctx context.Context, operands []float64, ru
 	return result, nil
 }
 
+// excludeNotApplicableWeighted 剔除不适用的操作数，并同步剔除对应的权重
+func excludeNotApplicableWeighted(operands, weights []float64) ([]float64, []float64, int) {
+	applicableOperands := make([]float64, 0, len(operands))
+	applicableWeights := make([]float64, 0, len(weights))
+	for i, operand := range operands {
+		if IsNotApplicable(operand) {
+			continue
+		}
+		applicableOperands = append(applicableOperands, operand)
+		applicableWeights = append(applicableWeights, weights[i])
+	}
+	return applicableOperands, applicableWeights, len(operands) - len(applicableOperands)
+}
+
 // applyRounding 应用舍入规则（所有策略的公共方法）
 func (s *BaseStrategy) applyRounding(value float64, rule *rules.CalculationRule) float64 {
 	precision := rule.Config.Precision
@@ -0,0 +1,215 @@
+package strategies
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/internal/evaluation-server/domain/calculation/rules"
+)
+
+func TestSumStrategy_NotApplicable(t *testing.T) {
+	strategy := NewSumStrategy()
+	rule := rules.NewCalculationRule("sum")
+
+	without, err := strategy.Calculate(context.Background(), []float64{1, 2, 3}, rule)
+	if err != nil {
+		t.Fatalf("Calculate() without N/A error = %v", err)
+	}
+
+	with, err := strategy.Calculate(context.Background(), []float64{1, NotApplicable(), 2, 3, NotApplicable()}, rule)
+	if err != nil {
+		t.Fatalf("Calculate() with N/A error = %v", err)
+	}
+
+	if with.Value != without.Value {
+		t.Errorf("sum with N/A = %v, want %v", with.Value, without.Value)
+	}
+	if without.NotApplicableCount != 0 {
+		t.Errorf("NotApplicableCount without N/A = %d, want 0", without.NotApplicableCount)
+	}
+	if with.NotApplicableCount != 2 {
+		t.Errorf("NotApplicableCount with N/A = %d, want 2", with.NotApplicableCount)
+	}
+}
+
+func TestStrategies_NotApplicableExcludedFromDenominator(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy CalculationStrategy
+		rule     *rules.CalculationRule
+		operands []float64
+		want     float64
+		wantNA   int
+	}{
+		{
+			name:     "average skips N/A",
+			strategy: NewAverageStrategy(),
+			rule:     rules.NewCalculationRule("average"),
+			operands: []float64{2, NotApplicable(), 4},
+			want:     3,
+			wantNA:   1,
+		},
+		{
+			name:     "weighted average skips N/A and its weight",
+			strategy: NewWeightedStrategy(),
+			rule:     rules.NewCalculationRule("weighted").SetWeights([]float64{1, 5, 3}),
+			operands: []float64{4, NotApplicable(), 8},
+			want:     7,
+			wantNA:   1,
+		},
+		{
+			name:     "all N/A yields zero",
+			strategy: NewOptionStrategy(),
+			rule:     rules.NewCalculationRule("option"),
+			operands: []float64{NotApplicable()},
+			want:     0,
+			wantNA:   1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.strategy.Calculate(context.Background(), tt.operands, tt.rule)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.Value != tt.want {
+				t.Errorf("Calculate() value = %v, want %v", result.Value, tt.want)
+			}
+			if result.NotApplicableCount != tt.wantNA {
+				t.Errorf("Calculate() NotApplicableCount = %d, want %d", result.NotApplicableCount, tt.wantNA)
+			}
+		})
+	}
+}
+
+func TestSumStrategy_Prorate(t *testing.T) {
+	tests := []struct {
+		name     string
+		operands []float64
+		want     float64
+		wantErr  bool
+	}{
+		{
+			name:     "no missing items",
+			operands: []float64{2, 2, 2, 2, 2, 2, 2, 2, 2, 2},
+			want:     20,
+		},
+		{
+			name:     "missing items within threshold",
+			operands: []float64{2, 2, 2, 2, 2, 2, 2, 2},
+			want:     20,
+		},
+		{
+			name:     "N/A items count as missing",
+			operands: []float64{2, 2, 2, 2, 2, 2, 2, 2, NotApplicable()},
+			want:     20,
+		},
+		{
+			name:     "missing items over threshold",
+			operands: []float64{2, 2, 2, 2, 2, 2, 2},
+			wantErr:  true,
+		},
+	}
+
+	strategy := NewSumStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := rules.NewCalculationRule("sum").SetProrate(10, 2)
+
+			result, err := strategy.Calculate(context.Background(), tt.operands, rule)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Calculate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if result.Value != tt.want {
+				t.Errorf("Calculate() value = %v, want %v", result.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrategies_AggregationModes(t *testing.T) {
+	items := []float64{0, 2, 1, 3, 2}
+
+	tests := []struct {
+		name     string
+		strategy CalculationStrategy
+		rule     *rules.CalculationRule
+		want     float64
+	}{
+		{name: "sum", strategy: NewSumStrategy(), rule: rules.NewCalculationRule("sum"), want: 8},
+		{name: "mean", strategy: NewAverageStrategy(), rule: rules.NewCalculationRule("average"), want: 1.6},
+		{name: "max", strategy: NewMaxStrategy(), rule: rules.NewCalculationRule("max"), want: 3},
+		{name: "min", strategy: NewMinStrategy(), rule: rules.NewCalculationRule("min"), want: 0},
+		{
+			name:     "count above threshold",
+			strategy: NewCountAboveThresholdStrategy(),
+			rule:     rules.NewCalculationRule("count_above_threshold").AddParam("threshold", 1.0),
+			want:     3,
+		},
+		{
+			name:     "count above threshold excludes equal scores",
+			strategy: NewCountAboveThresholdStrategy(),
+			rule:     rules.NewCalculationRule("count_above_threshold").AddParam("threshold", 2),
+			want:     1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.strategy.Calculate(context.Background(), items, tt.rule)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.Value != tt.want {
+				t.Errorf("Calculate() value = %v, want %v", result.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountAboveThresholdStrategy_RequiresThreshold(t *testing.T) {
+	strategy := NewCountAboveThresholdStrategy()
+	if _, err := strategy.Calculate(context.Background(), []float64{1, 2}, rules.NewCalculationRule("count_above_threshold")); err == nil {
+		t.Error("Calculate() error = nil without threshold parameter")
+	}
+}
+
+func TestConditionalStrategy(t *testing.T) {
+	mappings := []ScoreMapping{
+		{WhenValue: "daily", Scores: map[string]float64{"severe": 4, "mild": 2}},
+		{WhenValue: "weekly", Scores: map[string]float64{"severe": 2}},
+	}
+	tests := []struct {
+		name           string
+		optionCode     string
+		dependsOnValue string
+		want           float64
+	}{
+		{name: "matched mapping", optionCode: "severe", dependsOnValue: "daily", want: 4},
+		{name: "second mapping", optionCode: "severe", dependsOnValue: "weekly", want: 2},
+		{name: "option missing from mapping", optionCode: "mild", dependsOnValue: "weekly", want: 1},
+		{name: "no matching mapping", optionCode: "severe", dependsOnValue: "never", want: 1},
+	}
+
+	strategy := NewConditionalStrategy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rule := rules.NewCalculationRule("conditional").
+				AddParam("option_code", tt.optionCode).
+				AddParam("depends_on_value", tt.dependsOnValue).
+				AddParam("score_mappings", mappings)
+
+			result, err := strategy.Calculate(context.Background(), []float64{1}, rule)
+			if err != nil {
+				t.Fatalf("Calculate() error = %v", err)
+			}
+			if result.Value != tt.want {
+				t.Errorf("Calculate() = %v, want %v", result.Value, tt.want)
+			}
+		})
+	}
+}
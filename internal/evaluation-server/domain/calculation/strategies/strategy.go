@@ -2,6 +2,7 @@ package strategies
 
 import (
 	"context"
+	"math"
 
 	"github.com/yshujie/questionnaire-scale/internal/evaluation-server/domain/calculation/rules"
 )
@@ -42,13 +43,36 @@ func (s *BaseStrategy) Validate(operands []float64, rule *rules.CalculationRule)
 	return nil
 }
 
+// NotApplicable 返回"不适用"操作数哨兵值
+// 被标记为不适用的题目不计为 0 分，而是从总和、平均值的分母中剔除
+func NotApplicable() float64 {
+	return math.NaN()
+}
+
+// IsNotApplicable 判断操作数是否为"不适用"哨兵值
+func IsNotApplicable(operand float64) bool {
+	return math.IsNaN(operand)
+}
+
+// excludeNotApplicable 剔除不适用的操作数，返回参与计算的操作数及不适用项数量
+func excludeNotApplicable(operands []float64) ([]float64, int) {
+	applicable := make([]float64, 0, len(operands))
+	for _, operand := range operands {
+		if !IsNotApplicable(operand) {
+			applicable = append(applicable, operand)
+		}
+	}
+	return applicable, len(operands) - len(applicable)
+}
+
 // CalculationResult 计算结果
 type CalculationResult struct {
-	Value       float64                `json:"value"`     // 计算结果
-	Precision   int                    `json:"precision"` // 精度
-	Metadata    map[string]interface{} `json:"metadata"`  // 元数据
-	OperandInfo []OperandInfo          `json:"operands"`  // 操作数信息
-	Strategy    string                 `json:"strategy"`  // 使用的策略
+	Value              float64                `json:"value"`                // 计算结果
+	Precision          int                    `json:"precision"`            // 精度
+	Metadata           map[string]interface{} `json:"metadata"`             // 元数据
+	OperandInfo        []OperandInfo          `json:"operands"`             // 操作数信息
+	Strategy           string                 `json:"strategy"`             // 使用的策略
+	NotApplicableCount int                    `json:"not_applicable_count"` // 不适用项数量
 }
 
 // OperandInfo 操作数信息
@@ -70,6 +94,20 @@ func NewCalculationResult(value float64, strategy string) *CalculationResult {
 	}
 }
 
+// newNotApplicableResult 创建全部操作数均不适用时的计算结果
+func newNotApplicableResult(notApplicableCount int, strategy string) *CalculationResult {
+	result := NewCalculationResult(0, strategy)
+	result.NotApplicableCount = notApplicableCount
+	result.SetMetadata("all_not_applicable", true)
+	return result
+}
+
+// IsAllNotApplicable 判断是否全部操作数均不适用
+func (r *CalculationResult) IsAllNotApplicable() bool {
+	all, _ := r.Metadata["all_not_applicable"].(bool)
+	return all
+}
+
 // SetMetadata 设置元数据
 func (r *CalculationResult) SetMetadata(key string, value interface{}) {
 	if r.Metadata == nil {
@@ -7,6 +7,8 @@ import (
 	interpretreport "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/interpret-report"
 	medicalscale "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/medical-scale"
 	questionnaire "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/questionnaire"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/scoring"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
@@ -17,12 +19,21 @@ type ClientFactory struct {
 	conn *grpc.ClientConn
 }
 
-// NewClientFactory 创建 gRPC 客户端工厂
-func NewClientFactory(target string) (*ClientFactory, error) {
+// NewClientFactory 创建 gRPC 客户端工厂，maxRecvMsgSize 和 maxSendMsgSize 为单条消息的收发字节数上限
+// apiKey 为调用 apiserver 时携带的 API Key，为空时不携带
+func NewClientFactory(target string, maxRecvMsgSize, maxSendMsgSize int, apiKey string) (*ClientFactory, error) {
 	// 创建 gRPC 连接
 	conn, err := grpc.Dial(
 		target,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(
+			middleware.UnaryClientRequestIDInterceptor(),
+			middleware.UnaryClientAPIKeyInterceptor(apiKey),
+		),
+		grpc.WithDefaultCallOptions(
+			grpc.MaxCallRecvMsgSize(maxRecvMsgSize),
+			grpc.MaxCallSendMsgSize(maxSendMsgSize),
+		),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("创建 gRPC 连接失败: %v", err)
@@ -59,3 +70,8 @@ func (f *ClientFactory) NewMedicalScaleClient() medicalscale.MedicalScaleService
 func (f *ClientFactory) NewInterpretReportClient() interpretreport.InterpretReportServiceClient {
 	return interpretreport.NewInterpretReportServiceClient(f.conn)
 }
+
+// NewScoringClient 创建答卷计分客户端
+func (f *ClientFactory) NewScoringClient() scoring.ScoringServiceClient {
+	return scoring.NewScoringServiceClient(f.conn)
+}
@@ -34,3 +34,28 @@ func (c *MedicalScaleClient) GetMedicalScaleByQuestionnaireCode(ctx context.Cont
 
 	return resp.MedicalScale, nil
 }
+
+// AutoPaginate 自动翻页获取医学量表列表，对每个医学量表调用 handler
+// req 的分页令牌作为起始位置，翻页过程中会更新 req.PageToken
+func AutoPaginate(
+	ctx context.Context,
+	client medical_scale.MedicalScaleServiceClient,
+	req *medical_scale.ListMedicalScalesRequest,
+	handler func(*medical_scale.MedicalScale),
+) error {
+	for {
+		resp, err := client.ListMedicalScales(ctx, req)
+		if err != nil {
+			return fmt.Errorf("获取医学量表列表失败: %v", err)
+		}
+
+		for _, medicalScale := range resp.GetMedicalScales() {
+			handler(medicalScale)
+		}
+
+		if resp.GetNextPageToken() == "" {
+			return nil
+		}
+		req.PageToken = resp.GetNextPageToken()
+	}
+}
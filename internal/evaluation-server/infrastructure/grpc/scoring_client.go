@@ -0,0 +1,48 @@
+package grpc
+
+import (
+	"context"
+	"fmt"
+
+	answersheet "github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/answersheet"
+	"github.com/yshujie/questionnaire-scale/internal/apiserver/interface/grpc/proto/scoring"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// ScoringClient 答卷计分客户端
+type ScoringClient struct {
+	client scoring.ScoringServiceClient
+}
+
+// NewScoringClient 创建答卷计分客户端
+func NewScoringClient(factory *ClientFactory) *ScoringClient {
+	return &ScoringClient{
+		client: factory.NewScoringClient(),
+	}
+}
+
+// ScoreAnswerSheet 按问卷选项分值和医学量表计分策略计算答卷得分，不保存答卷
+func (c *ScoringClient) ScoreAnswerSheet(ctx context.Context, sheet *answersheet.AnswerSheet) (*scoring.ScoreAnswersheetResponse, error) {
+	log.Infof("计算答卷得分，答卷ID: %d", sheet.Id)
+
+	answers := make([]*scoring.Answer, 0, len(sheet.Answers))
+	for _, answer := range sheet.Answers {
+		answers = append(answers, &scoring.Answer{
+			QuestionCode: answer.QuestionCode,
+			QuestionType: answer.QuestionType,
+			Value:        answer.Value,
+		})
+	}
+
+	// 调用 gRPC 服务
+	resp, err := c.client.ScoreAnswersheet(ctx, &scoring.ScoreAnswersheetRequest{
+		QuestionnaireCode:    sheet.QuestionnaireCode,
+		QuestionnaireVersion: sheet.QuestionnaireVersion,
+		Answers:              answers,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("计算答卷得分失败: %v", err)
+	}
+
+	return resp, nil
+}
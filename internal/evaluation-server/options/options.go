@@ -2,11 +2,11 @@ package options
 
 import (
 	"encoding/json"
-	"fmt"
 	"strings"
 
 	"github.com/spf13/pflag"
 	genericoptions "github.com/yshujie/questionnaire-scale/internal/pkg/options"
+	"github.com/yshujie/questionnaire-scale/pkg/app"
 	cliflag "github.com/yshujie/questionnaire-scale/pkg/flag"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 	"github.com/yshujie/questionnaire-scale/pkg/pubsub"
@@ -29,9 +29,12 @@ type Options struct {
 
 // GRPCClientOptions GRPC 客户端配置
 type GRPCClientOptions struct {
-	Endpoint string `json:"endpoint" mapstructure:"endpoint"`
-	Timeout  int    `json:"timeout"  mapstructure:"timeout"`  // 超时时间（秒）
-	Insecure bool   `json:"insecure" mapstructure:"insecure"` // 是否使用不安全连接
+	Endpoint       string `json:"endpoint"                mapstructure:"endpoint"`
+	Timeout        int    `json:"timeout"                 mapstructure:"timeout"`                 // 超时时间（秒）
+	Insecure       bool   `json:"insecure"                mapstructure:"insecure"`                // 是否使用不安全连接
+	MaxRecvMsgSize int    `json:"max_recv_msg_size_bytes" mapstructure:"max_recv_msg_size_bytes"` // 可接收的最大消息字节数
+	MaxSendMsgSize int    `json:"max_send_msg_size_bytes" mapstructure:"max_send_msg_size_bytes"` // 可发送的最大消息字节数
+	APIKey         string `json:"-"                       mapstructure:"api_key"`                 // 调用 apiserver 时携带的 API Key，为空时不携带
 }
 
 // MessageQueueOptions 消息队列配置
@@ -69,9 +72,11 @@ func NewOptions() *Options {
 		SecureServing:           genericoptions.NewSecureServingOptions(),
 
 		GRPCClient: &GRPCClientOptions{
-			Endpoint: "localhost:9090", // apiserver 的 GRPC 端口
-			Timeout:  30,
-			Insecure: true,
+			Endpoint:       "localhost:9090", // apiserver 的 GRPC 端口
+			Timeout:        30,
+			Insecure:       true,
+			MaxRecvMsgSize: genericoptions.DefaultGRPCMaxMsgSize,
+			MaxSendMsgSize: genericoptions.DefaultGRPCMaxMsgSize,
 		},
 		MessageQueue: &MessageQueueOptions{
 			Type:     "redis",
@@ -109,6 +114,12 @@ func (g *GRPCClientOptions) AddFlags(fs *pflag.FlagSet) {
 		"The timeout for gRPC client requests in seconds.")
 	fs.BoolVar(&g.Insecure, "grpc-client.insecure", g.Insecure,
 		"Whether to use insecure gRPC connection.")
+	fs.IntVar(&g.MaxRecvMsgSize, "grpc-client.max-recv-msg-size-bytes", g.MaxRecvMsgSize,
+		"Maximum size in bytes of a gRPC response the client can receive, up to 64MB.")
+	fs.IntVar(&g.MaxSendMsgSize, "grpc-client.max-send-msg-size-bytes", g.MaxSendMsgSize,
+		"Maximum size in bytes of a gRPC request the client can send, up to 64MB.")
+	fs.StringVar(&g.APIKey, "grpc-client.api-key", g.APIKey,
+		"API key sent as x-api-key metadata, required when apiserver enables grpc.require-auth.")
 }
 
 // AddFlags 添加消息队列相关的命令行参数
@@ -144,38 +155,47 @@ func (o *Options) Validate() []error {
 
 	// 验证 gRPC 客户端配置
 	if o.GRPCClient.Endpoint == "" {
-		errs = append(errs, fmt.Errorf("grpc-client.endpoint cannot be empty"))
+		errs = append(errs, app.NewOptionsValidationError("grpc-client.endpoint", "cannot be empty"))
 	}
 	if o.GRPCClient.Timeout <= 0 {
-		errs = append(errs, fmt.Errorf("grpc-client.timeout must be greater than 0"))
+		errs = append(errs, app.NewOptionsValidationError("grpc-client.timeout", "must be greater than 0"))
+	}
+	if err := genericoptions.ValidateGRPCMsgSize("grpc-client.max-recv-msg-size-bytes", o.GRPCClient.MaxRecvMsgSize); err != nil {
+		errs = append(errs, err)
+	}
+	if err := genericoptions.ValidateGRPCMsgSize("grpc-client.max-send-msg-size-bytes", o.GRPCClient.MaxSendMsgSize); err != nil {
+		errs = append(errs, err)
+	}
+	if o.GRPCClient.APIKey != "" && len(o.GRPCClient.APIKey) < 16 {
+		errs = append(errs, app.NewOptionsValidationError("grpc-client.api-key", "must be at least 16 characters"))
 	}
 
 	// 验证消息队列配置
 	if o.MessageQueue.Type == "" {
-		errs = append(errs, fmt.Errorf("message-queue.type cannot be empty"))
+		errs = append(errs, app.NewOptionsValidationError("message-queue.type", "cannot be empty"))
 	}
 	if o.MessageQueue.Endpoint == "" {
-		errs = append(errs, fmt.Errorf("message-queue.endpoint cannot be empty"))
+		errs = append(errs, app.NewOptionsValidationError("message-queue.endpoint", "cannot be empty"))
 	}
 	if o.MessageQueue.Topic == "" {
-		errs = append(errs, fmt.Errorf("message-queue.topic cannot be empty"))
+		errs = append(errs, app.NewOptionsValidationError("message-queue.topic", "cannot be empty"))
 	}
 	if o.MessageQueue.Group == "" {
-		errs = append(errs, fmt.Errorf("message-queue.group cannot be empty"))
+		errs = append(errs, app.NewOptionsValidationError("message-queue.group", "cannot be empty"))
 	}
 
 	// 验证并发配置
 	if o.Concurrency.MaxConcurrency <= 0 {
-		errs = append(errs, fmt.Errorf("concurrency.max-concurrency must be greater than 0"))
+		errs = append(errs, app.NewOptionsValidationError("concurrency.max-concurrency", "must be greater than 0"))
 	}
 	if o.Concurrency.MaxConcurrency > 100 {
-		errs = append(errs, fmt.Errorf("concurrency.max-concurrency cannot be greater than 100"))
+		errs = append(errs, app.NewOptionsValidationError("concurrency.max-concurrency", "cannot be greater than 100"))
 	}
 
 	// 验证 Redis 特定配置
 	if strings.ToLower(o.MessageQueue.Type) == "redis" {
 		if !strings.Contains(o.MessageQueue.Endpoint, ":") {
-			errs = append(errs, fmt.Errorf("redis endpoint must include port (e.g., localhost:6379)"))
+			errs = append(errs, app.NewOptionsValidationError("message-queue.endpoint", "must include port for redis (e.g., localhost:6379)"))
 		}
 	}
 
@@ -0,0 +1,39 @@
+package options
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+)
+
+func TestOptions_Validate_RequiredFields(t *testing.T) {
+	if errs := NewOptions().Validate(); len(errs) != 0 {
+		t.Fatalf("NewOptions().Validate() = %v, want no errors", errs)
+	}
+
+	tests := []struct {
+		field string
+		unset func(o *Options)
+	}{
+		{"grpc-client.endpoint", func(o *Options) { o.GRPCClient.Endpoint = "" }},
+		{"message-queue.type", func(o *Options) { o.MessageQueue.Type = "" }},
+		{"message-queue.endpoint", func(o *Options) { o.MessageQueue.Endpoint = "" }},
+		{"message-queue.topic", func(o *Options) { o.MessageQueue.Topic = "" }},
+		{"message-queue.group", func(o *Options) { o.MessageQueue.Group = "" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			o := NewOptions()
+			tt.unset(o)
+
+			var buf bytes.Buffer
+			app.PrintOptionsValidationErrors(&buf, o.Validate())
+			if !strings.Contains(buf.String(), tt.field) {
+				t.Errorf("validation output does not name field %s:\n%s", tt.field, buf.String())
+			}
+		})
+	}
+}
@@ -1,5 +1,7 @@
 package calculation
 
+import "github.com/yshujie/questionnaire-scale/pkg/scoring"
+
 // FormulaType 公式类型
 type FormulaType string
 
@@ -9,6 +11,10 @@ const (
 	FormulaTypeAvg   FormulaType = "avg"   // 平均值
 	FormulaTypeMax   FormulaType = "max"   // 最大值
 	FormulaTypeMin   FormulaType = "min"   // 最小值
+
+	FormulaTypeCountAboveThreshold FormulaType = "count_above_threshold" // 超过阈值的条目数
+
+	FormulaTypeConditional FormulaType = "conditional" // 条件计分
 )
 
 // String 实现 Stringer 接口
@@ -16,10 +22,30 @@ func (f FormulaType) String() string {
 	return string(f)
 }
 
+// ScoreMapping 条件计分映射
+// 引用题目的答案等于 WhenValue 时，本题选项按 Scores 中的分值计分
+type ScoreMapping struct {
+	WhenValue string
+	Scores    map[string]float64
+}
+
+// NewScoreMapping 创建条件计分映射
+func NewScoreMapping(whenValue string, scores map[string]float64) ScoreMapping {
+	return ScoreMapping{
+		WhenValue: whenValue,
+		Scores:    scores,
+	}
+}
+
 // CalculationRule 计算规则
 type CalculationRule struct {
 	formula     FormulaType
 	sourceCodes []string
+
+	dependsOnCode string
+	scoreMappings []ScoreMapping
+
+	threshold float64
 }
 
 // NewCalculationRule 创建计算规则
@@ -30,6 +56,27 @@ func NewCalculationRule(formula FormulaType, sourceCodes []string) *CalculationR
 	}
 }
 
+// NewConditionalCalculationRule 创建条件计分规则
+// 按引用题目 dependsOnCode 的答案选择计分映射，如频率 × 严重程度类题目
+func NewConditionalCalculationRule(dependsOnCode string, mappings []ScoreMapping) *CalculationRule {
+	return &CalculationRule{
+		formula:       FormulaTypeConditional,
+		sourceCodes:   []string{},
+		dependsOnCode: dependsOnCode,
+		scoreMappings: mappings,
+	}
+}
+
+// NewCountAboveThresholdCalculationRule 创建计数规则
+// 得分为源得分严格大于 threshold 的条目数，如“得分超过 1 分的条目数”
+func NewCountAboveThresholdCalculationRule(sourceCodes []string, threshold float64) *CalculationRule {
+	return &CalculationRule{
+		formula:     FormulaTypeCountAboveThreshold,
+		sourceCodes: sourceCodes,
+		threshold:   threshold,
+	}
+}
+
 // GetFormulaType 获取公式类型
 func (c *CalculationRule) GetFormula() FormulaType {
 	return c.formula
@@ -39,3 +86,34 @@ func (c *CalculationRule) GetFormula() FormulaType {
 func (c *CalculationRule) GetSourceCodes() []string {
 	return c.sourceCodes
 }
+
+// GetDependsOnCode 获取条件计分引用的题目编码
+func (c *CalculationRule) GetDependsOnCode() string {
+	return c.dependsOnCode
+}
+
+// GetScoreMappings 获取条件计分映射
+func (c *CalculationRule) GetScoreMappings() []ScoreMapping {
+	return c.scoreMappings
+}
+
+// IsConditional 判断是否为条件计分规则
+func (c *CalculationRule) IsConditional() bool {
+	return c.formula == FormulaTypeConditional
+}
+
+// GetThreshold 获取计数规则的阈值
+func (c *CalculationRule) GetThreshold() float64 {
+	return c.threshold
+}
+
+// IsAggregation 判断公式是否为聚合公式，聚合公式可以由一组源得分计算出一个得分
+func (f FormulaType) IsAggregation() bool {
+	return scoring.IsSupportedFormula(f.String())
+}
+
+// Calculate 按公式由源得分计算得分，没有源得分时得分为 0
+// 聚合计算由计分 SDK 实现，与客户端离线计分保持一致
+func (c *CalculationRule) Calculate(operands []float64) (float64, error) {
+	return scoring.Aggregate(c.formula.String(), operands, c.threshold)
+}
@@ -3,11 +3,23 @@ package code
 // answersheet errors.
 const (
 	// ErrAnswerSheetNotFound - 404: Answer sheet not found.
-	ErrAnswerSheetNotFound int = iota + 110001
+	ErrAnswerSheetNotFound int = iota + 110101
 
 	// ErrAnswerNotFound - 404: Answer not found.
 	ErrAnswerNotFound
 
 	// ErrAnswerSheetInvalid - 400: Answer sheet is invalid.
 	ErrAnswerSheetInvalid
+
+	// ErrAnswerFileInvalid - 400: Answer file does not satisfy the question constraints.
+	ErrAnswerFileInvalid
+
+	// ErrFileStorage - 500: File storage error.
+	ErrFileStorage
+
+	// ErrUploadNotFound - 404: Upload does not exist, has expired or was already used.
+	ErrUploadNotFound
+
+	// ErrSubmissionStreamLimit - 409: Too many real-time submission connections for the questionnaire.
+	ErrSubmissionStreamLimit
 )
@@ -5,7 +5,7 @@ const (
 	// ErrUserNotFound - 404: User not found.
 	ErrUserNotFound int = iota + 110001
 
-	// ErrUserAlreadyExists- 400: User already exist.
+	// ErrUserAlreadyExists - 400: User already exist.
 	ErrUserAlreadyExists
 
 	// ErrUserBasicInfoInvalid - 400: User basic info is invalid.
@@ -22,4 +22,19 @@ const (
 
 	// ErrUserInactive - 403: User is inactive.
 	ErrUserInactive
+
+	// ErrUserPreferenceInvalid - 400: User preference is invalid.
+	ErrUserPreferenceInvalid
+
+	// ErrUserPasswordWeak - 400: User password is too weak.
+	ErrUserPasswordWeak
+
+	// ErrUserSessionNotFound - 404: User session not found.
+	ErrUserSessionNotFound
+
+	// ErrUserActivityInvalid - 400: User activity is invalid.
+	ErrUserActivityInvalid
+
+	// ErrUserActivityQueueFull - 500: User activity log queue is full.
+	ErrUserActivityQueueFull
 )
@@ -0,0 +1,13 @@
+package code
+
+// 问卷分配错误码
+const (
+	// ErrAssignmentNotFound - 404: Assignment not found.
+	ErrAssignmentNotFound int = iota + 110601
+
+	// ErrAssignmentInvalid - 400: Assignment is invalid.
+	ErrAssignmentInvalid
+
+	// ErrAssignmentAlreadyCompleted - 409: Assignment is already completed.
+	ErrAssignmentAlreadyCompleted
+)
@@ -26,12 +26,24 @@ const (
 
 	// ErrInvalidMessage - 400: Invalid message.
 	ErrInvalidMessage
+
+	// ErrFieldRequired - 400: Required field is missing.
+	ErrFieldRequired
+
+	// ErrFieldInvalid - 400: Field value is invalid.
+	ErrFieldInvalid
+
+	// ErrMaintenance - 500: Service is under maintenance.
+	ErrMaintenance
 )
 
 // common: database errors.
 const (
 	// ErrDatabase - 500: Database error.
 	ErrDatabase int = iota + 100101
+
+	// ErrDocumentSchemaValidation - 500: Document does not match the collection schema.
+	ErrDocumentSchemaValidation
 )
 
 // common: authorization and authentication errors.
@@ -54,7 +66,7 @@ const (
 	// ErrPasswordIncorrect - 401: Password was incorrect.
 	ErrPasswordIncorrect
 
-	// PermissionDenied - 403: Permission denied.
+	// ErrPermissionDenied - 403: Permission denied.
 	ErrPermissionDenied
 
 	// ErrTokenGeneration - 500: Failed to generate token.
@@ -62,6 +74,18 @@ const (
 
 	// ErrInternalServerError - 500: Internal server error.
 	ErrInternalServerError
+
+	// ErrOTPInvalid - 401: One-time password is invalid.
+	ErrOTPInvalid
+
+	// ErrOTPExpired - 401: One-time password challenge expired.
+	ErrOTPExpired
+
+	// ErrCaptchaRequired - 401: Captcha is required after repeated failed logins.
+	ErrCaptchaRequired
+
+	// ErrCaptchaInvalid - 401: Captcha token is invalid.
+	ErrCaptchaInvalid
 )
 
 // common: encode/decode errors.
@@ -3,7 +3,7 @@ package code
 // calculation errors.
 const (
 	// ErrOperandsEmpty - 400: Operands is empty.
-	ErrOperandsEmpty int = iota + 110001
+	ErrOperandsEmpty int = iota + 110201
 	// ErrOperandsOverside - 400: Operands is overside.
 	ErrOperandsOverside
 	// ErrInvalidCalculaterType - 400: Invalid calculater type.
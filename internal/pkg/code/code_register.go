@@ -0,0 +1,109 @@
+package code
+
+// init registers every error code with the HTTP status and message from its doc comment.
+func init() {
+	register(ErrSuccess, 200, "OK.")
+	register(ErrUnknown, 500, "Internal server error.")
+	register(ErrBind, 400, "Error occurred while binding the request body to the struct.")
+	register(ErrValidation, 400, "Validation failed.")
+	register(ErrTokenInvalid, 401, "Token invalid.")
+	register(ErrPageNotFound, 404, "Page not found.")
+	register(ErrInvalidArgument, 400, "Invalid argument.")
+	register(ErrInvalidMessage, 400, "Invalid message.")
+	register(ErrFieldRequired, 400, "Required field is missing.")
+	register(ErrFieldInvalid, 400, "Field value is invalid.")
+	register(ErrMaintenance, 500, "Service is under maintenance.")
+	register(ErrDatabase, 500, "Database error.")
+	register(ErrDocumentSchemaValidation, 500, "Document does not match the collection schema.")
+	register(ErrEncrypt, 401, "Error occurred while encrypting the user password.")
+	register(ErrSignatureInvalid, 401, "Signature is invalid.")
+	register(ErrExpired, 401, "Token expired.")
+	register(ErrInvalidAuthHeader, 401, "Invalid authorization header.")
+	register(ErrMissingHeader, 401, "The `Authorization` header was empty.")
+	register(ErrPasswordIncorrect, 401, "Password was incorrect.")
+	register(ErrPermissionDenied, 403, "Permission denied.")
+	register(ErrTokenGeneration, 500, "Failed to generate token.")
+	register(ErrInternalServerError, 500, "Internal server error.")
+	register(ErrOTPInvalid, 401, "One-time password is invalid.")
+	register(ErrOTPExpired, 401, "One-time password challenge expired.")
+	register(ErrCaptchaRequired, 401, "Captcha is required after repeated failed logins.")
+	register(ErrCaptchaInvalid, 401, "Captcha token is invalid.")
+	register(ErrEncodingFailed, 500, "Encoding failed due to an error with the data.")
+	register(ErrDecodingFailed, 500, "Decoding failed due to an error with the data.")
+	register(ErrInvalidJSON, 500, "Data is not valid JSON.")
+	register(ErrEncodingJSON, 500, "JSON data could not be encoded.")
+	register(ErrDecodingJSON, 500, "JSON data could not be decoded.")
+	register(ErrInvalidYaml, 500, "Data is not valid Yaml.")
+	register(ErrEncodingYaml, 500, "Yaml data could not be encoded.")
+	register(ErrDecodingYaml, 500, "Yaml data could not be decoded.")
+	register(ErrModuleInitializationFailed, 500, "Module initialization failed.")
+	register(ErrModuleNotFound, 404, "Module not found.")
+	register(ErrUserNotFound, 404, "User not found.")
+	register(ErrUserAlreadyExists, 400, "User already exist.")
+	register(ErrUserBasicInfoInvalid, 400, "User basic info is invalid.")
+	register(ErrUserStatusInvalid, 400, "User status is invalid.")
+	register(ErrUserInvalid, 400, "User is invalid.")
+	register(ErrUserBlocked, 403, "User is blocked.")
+	register(ErrUserInactive, 403, "User is inactive.")
+	register(ErrUserPreferenceInvalid, 400, "User preference is invalid.")
+	register(ErrUserPasswordWeak, 400, "User password is too weak.")
+	register(ErrUserSessionNotFound, 404, "User session not found.")
+	register(ErrUserActivityInvalid, 400, "User activity is invalid.")
+	register(ErrUserActivityQueueFull, 500, "User activity log queue is full.")
+	register(ErrAnswerSheetNotFound, 404, "Answer sheet not found.")
+	register(ErrAnswerNotFound, 404, "Answer not found.")
+	register(ErrAnswerSheetInvalid, 400, "Answer sheet is invalid.")
+	register(ErrAnswerFileInvalid, 400, "Answer file does not satisfy the question constraints.")
+	register(ErrFileStorage, 500, "File storage error.")
+	register(ErrUploadNotFound, 404, "Upload does not exist, has expired or was already used.")
+	register(ErrSubmissionStreamLimit, 409, "Too many real-time submission connections for the questionnaire.")
+	register(ErrOperandsEmpty, 400, "Operands is empty.")
+	register(ErrOperandsOverside, 400, "Operands is overside.")
+	register(ErrInvalidCalculaterType, 400, "Invalid calculater type.")
+	register(ErrCalculaterNotFound, 400, "Calculater not found.")
+	register(ErrInterpretReportNotFound, 404, "Interpret report not found.")
+	register(ErrInterpretReportAlreadyExists, 400, "Interpret report already exists.")
+	register(ErrInterpretReportInvalid, 400, "Interpret report is invalid.")
+	register(ErrInterpretReportGenerationFailed, 500, "Interpret report generation failed.")
+	register(ErrInterpretItemNotFound, 404, "Interpret item not found.")
+	register(ErrInterpretItemInvalid, 400, "Interpret item is invalid.")
+	register(ErrMedicalScaleInvalidInput, 400, "Invalid input for medical scale.")
+	register(ErrMedicalScaleNotFound, 404, "Medical scale not found.")
+	register(ErrMedicalScaleAlreadyExists, 400, "Medical scale already exists.")
+	register(ErrMedicalScaleFactorNotFound, 404, "Medical scale factor not found.")
+	register(ErrMedicalScaleInvalid, 400, "Medical scale is invalid.")
+	register(ErrScoringConfigInvalid, 400, "Scoring configuration is invalid.")
+	register(ErrInsufficientData, 400, "Insufficient data for analysis.")
+	register(ErrNotificationHookNotFound, 404, "Notification hook not found.")
+	register(ErrNotificationHookURLInvalid, 400, "Notification hook URL must be an absolute http or https URL.")
+	register(ErrAssignmentNotFound, 404, "Assignment not found.")
+	register(ErrAssignmentInvalid, 400, "Assignment is invalid.")
+	register(ErrAssignmentAlreadyCompleted, 409, "Assignment is already completed.")
+	register(ErrQuestionnaireNotFound, 404, "Questionnaire not found.")
+	register(ErrQuestionnaireAlreadyExists, 400, "Questionnaire already exists.")
+	register(ErrQuestionnaireArchived, 400, "Questionnaire is archived.")
+	register(ErrQuestionnaireInvalidInput, 400, "Invalid input for questionnaire.")
+	register(ErrQuestionnaireInvalidStatus, 400, "Invalid questionnaire status.")
+	register(ErrQuestionnaireInvalidQuestion, 400, "Invalid question in questionnaire.")
+	register(ErrQuestionnaireQuestionNotFound, 404, "Question not found in questionnaire.")
+	register(ErrQuestionnaireQuestionAlreadyExists, 400, "Question already exists in questionnaire.")
+	register(ErrQuestionnaireQuestionBasicInfoInvalid, 400, "Question basic info is invalid.")
+	register(ErrQuestionnaireQuestionInvalid, 400, "Question is invalid.")
+	register(ErrQuestionnaireStatusInvalid, 400, "Invalid status transition.")
+	register(ErrMaxQuestionsExceeded, 400, "Too many questions in questionnaire or section.")
+	register(ErrQuestionnaireGeoRestricted, 403, "Questionnaire is not available in your region.")
+	register(ErrPublishedQuestionnaireImmutable, 409, "Published questionnaire is immutable.",
+		"Create an amended draft with POST /api/v1/questionnaires/{code}/amendments, edit the draft, then publish it again.")
+	register(ErrPreviewTokenInvalid, 401, "Questionnaire preview token is invalid.")
+	register(ErrPreviewTokenExpired, 401, "Questionnaire preview token has expired.")
+	register(ErrPreviewOnlyToken, 403, "Preview token can only be used to preview the questionnaire.")
+	register(ErrQuestionTypeAlreadyRegistered, 409, "Question type is already registered.")
+	register(ErrTranslationInvalid, 400, "Questionnaire translation is invalid.")
+	register(ErrTranslationNotFound, 404, "Questionnaire translation not found.")
+	register(ErrQuestionnaireVersionConflict, 409, "Questionnaire has been modified by another request.",
+		"Reload the questionnaire to get the current revision, reapply your changes, then retry.")
+	register(ErrQuestionnaireThumbnailDisabled, 404, "Questionnaire thumbnail is disabled.")
+	register(ErrQuestionnaireThumbnailFailed, 500, "Failed to generate questionnaire thumbnail.")
+	register(ErrQuestionnaireInUse, 409, "Questionnaire is referenced by answer sheets or assignments.",
+		"Retry with DELETE /api/v1/questionnaires/{code}?force=true to delete it anyway.")
+}
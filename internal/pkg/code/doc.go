@@ -4,7 +4,19 @@
 // StatusUnauthorized                 = 401 // RFC 7235, 3.1
 // StatusForbidden                    = 403 // RFC 7231, 6.5.3
 // StatusNotFound                     = 404 // RFC 7231, 6.5.4
+// StatusConflict                     = 409 // RFC 7231, 6.5.8
 // StatusInternalServerError          = 500 // RFC 7231, 6.6.1
 
+// Each module owns a block of 100 codes:
+// 1000xx-1004xx base (common, database, auth, encoding, module)
+// 1100xx        user
+// 1101xx        answersheet (moved from 1100xx, which collided with user)
+// 1102xx        calculation (moved from 1100xx, which collided with user)
+// 1103xx        medical scale
+// 1104xx        interpret report
+// 1105xx        notification hook
+// 1106xx        assignment
+// 1200xx        questionnaire
+
 // Package code defines error codes for questionnaire-scale platform.
 package code
@@ -2,14 +2,18 @@ package code
 
 // 医学量表错误码
 const (
-	// ErrMedicalScaleInvalidInput 无效的输入参数
+	// ErrMedicalScaleInvalidInput - 400: Invalid input for medical scale.
 	ErrMedicalScaleInvalidInput int = iota + 110301
-	// ErrMedicalScaleNotFound 医学量表不存在
+	// ErrMedicalScaleNotFound - 404: Medical scale not found.
 	ErrMedicalScaleNotFound
-	// ErrMedicalScaleAlreadyExists 医学量表已存在
+	// ErrMedicalScaleAlreadyExists - 400: Medical scale already exists.
 	ErrMedicalScaleAlreadyExists
-	// ErrMedicalScaleFactorNotFound 因子不存在
+	// ErrMedicalScaleFactorNotFound - 404: Medical scale factor not found.
 	ErrMedicalScaleFactorNotFound
-	// ErrMedicalScaleInvalid 医学量表无效
+	// ErrMedicalScaleInvalid - 400: Medical scale is invalid.
 	ErrMedicalScaleInvalid
+	// ErrScoringConfigInvalid - 400: Scoring configuration is invalid.
+	ErrScoringConfigInvalid
+	// ErrInsufficientData - 400: Insufficient data for analysis.
+	ErrInsufficientData
 )
@@ -0,0 +1,10 @@
+package code
+
+// 通知钩子错误码
+const (
+	// ErrNotificationHookNotFound - 404: Notification hook not found.
+	ErrNotificationHookNotFound int = iota + 110501
+
+	// ErrNotificationHookURLInvalid - 400: Notification hook URL must be an absolute http or https URL.
+	ErrNotificationHookURLInvalid
+)
@@ -34,4 +34,43 @@ const (
 
 	// ErrQuestionnaireStatusInvalid - 400: Invalid status transition.
 	ErrQuestionnaireStatusInvalid
+
+	// ErrMaxQuestionsExceeded - 400: Too many questions in questionnaire or section.
+	ErrMaxQuestionsExceeded
+
+	// ErrQuestionnaireGeoRestricted - 403: Questionnaire is not available in your region.
+	ErrQuestionnaireGeoRestricted
+
+	// ErrPublishedQuestionnaireImmutable - 409: Published questionnaire is immutable.
+	ErrPublishedQuestionnaireImmutable
+
+	// ErrPreviewTokenInvalid - 401: Questionnaire preview token is invalid.
+	ErrPreviewTokenInvalid
+
+	// ErrPreviewTokenExpired - 401: Questionnaire preview token has expired.
+	ErrPreviewTokenExpired
+
+	// ErrPreviewOnlyToken - 403: Preview token can only be used to preview the questionnaire.
+	ErrPreviewOnlyToken
+
+	// ErrQuestionTypeAlreadyRegistered - 409: Question type is already registered.
+	ErrQuestionTypeAlreadyRegistered
+
+	// ErrTranslationInvalid - 400: Questionnaire translation is invalid.
+	ErrTranslationInvalid
+
+	// ErrTranslationNotFound - 404: Questionnaire translation not found.
+	ErrTranslationNotFound
+
+	// ErrQuestionnaireVersionConflict - 409: Questionnaire has been modified by another request.
+	ErrQuestionnaireVersionConflict
+
+	// ErrQuestionnaireThumbnailDisabled - 404: Questionnaire thumbnail is disabled.
+	ErrQuestionnaireThumbnailDisabled
+
+	// ErrQuestionnaireThumbnailFailed - 500: Failed to generate questionnaire thumbnail.
+	ErrQuestionnaireThumbnailFailed
+
+	// ErrQuestionnaireInUse - 409: Questionnaire is referenced by answer sheets or assignments.
+	ErrQuestionnaireInUse
 )
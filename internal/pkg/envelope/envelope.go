@@ -0,0 +1,100 @@
+// Package envelope 定义 HTTP 接口统一的响应结构
+// 处理器、认证和中间件写出的响应都使用该结构：{code, message, data, request_id}
+package envelope
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// requestIDKey 请求 ID 在 gin 上下文和响应头中的键，与 middleware.XRequestIDKey 一致
+const requestIDKey = "X-Request-ID"
+
+// unknownCoderCode errors.ParseCoder 无法识别错误码时返回的错误码
+const unknownCoderCode = 1
+
+// Response 统一响应结构
+type Response struct {
+	Code      int         `json:"code"`
+	Message   string      `json:"message"`
+	Data      interface{} `json:"data"`
+	RequestID string      `json:"request_id"`
+	Reference string      `json:"reference,omitempty"`
+}
+
+// WriteSuccess 写入成功响应
+func WriteSuccess(c *gin.Context, message string, data interface{}) {
+	c.JSON(http.StatusOK, Response{
+		Code:      code.ErrSuccess,
+		Message:   message,
+		Data:      data,
+		RequestID: requestID(c),
+	})
+}
+
+// WriteError 写入错误响应，根据 internal/pkg/code 中注册的错误码选择 HTTP 状态码
+// 不带错误码的错误按 ErrUnknown 处理
+func WriteError(c *gin.Context, err error) {
+	WriteErrorWithData(c, err, nil)
+}
+
+// WriteErrorWithData 写入错误响应，并在响应数据中附带帮助客户端处理错误的信息
+func WriteErrorWithData(c *gin.Context, err error, data interface{}) {
+	httpStatus, resp := errorResponse(c, err, data)
+	c.JSON(httpStatus, resp)
+}
+
+// AbortWithError 写入错误响应并中止后续处理器，用于中间件
+func AbortWithError(c *gin.Context, err error) {
+	httpStatus, resp := errorResponse(c, err, nil)
+	c.AbortWithStatusJSON(httpStatus, resp)
+}
+
+// AbortWithStatus 以指定的 HTTP 状态码写入错误响应并中止后续处理器
+// 用于错误码注册表不支持的状态码，如 451、503
+func AbortWithStatus(c *gin.Context, httpStatus int, err error) {
+	_, resp := errorResponse(c, err, nil)
+	c.AbortWithStatusJSON(httpStatus, resp)
+}
+
+// errorResponse 记录错误日志，返回错误码对应的 HTTP 状态码和响应体
+func errorResponse(c *gin.Context, err error, data interface{}) (int, Response) {
+	log.L(c).Errorf("HTTP Handler Error: %+v", err)
+
+	resp := Response{
+		Code:      code.ErrUnknown,
+		Message:   "内部服务器错误",
+		Data:      data,
+		RequestID: requestID(c),
+	}
+	httpStatus := http.StatusInternalServerError
+	if HasCode(err) {
+		coder := errors.ParseCoder(err)
+		httpStatus = coder.HTTPStatus()
+		resp.Code = coder.Code()
+		resp.Message = coder.String()
+		resp.Reference = coder.Reference()
+	}
+	return httpStatus, resp
+}
+
+// HasCode 判断错误是否带有已注册的错误码
+func HasCode(err error) bool {
+	coder := errors.ParseCoder(err)
+	return coder != nil && coder.Code() != unknownCoderCode
+}
+
+// requestID 获取当前请求的请求 ID
+func requestID(c *gin.Context) string {
+	if v, ok := c.Get(requestIDKey); ok {
+		if rid, ok := v.(string); ok && rid != "" {
+			return rid
+		}
+	}
+	return c.Writer.Header().Get(requestIDKey)
+}
@@ -0,0 +1,100 @@
+// Package featureflag 基于配置的功能开关，用于新题型、计分规则等功能的灰度发布
+package featureflag
+
+import (
+	"hash/fnv"
+	"sort"
+	"strings"
+)
+
+// 功能开关名称
+const (
+	// NewQuestionTypes 通过 pkg/questiontype 注册的自定义题型，按问卷编码灰度
+	NewQuestionTypes = "new-question-types"
+	// WeightedScoring 医学量表的加权求和计分策略，按医学量表编码灰度
+	WeightedScoring = "weighted-scoring"
+)
+
+// Checker 功能开关查询接口，业务服务依赖该接口判断功能是否开启
+type Checker interface {
+	// Enabled 判断功能对 key（如问卷编码、用户ID）是否开启
+	Enabled(name, key string) bool
+}
+
+// Flag 功能开关
+// Enabled 为 true 时对所有 key 开启；否则按 Percentage 对 key 分桶灰度开启
+type Flag struct {
+	Name       string `json:"name" mapstructure:"-"`
+	Enabled    bool   `json:"enabled" mapstructure:"enabled"`
+	Percentage int    `json:"percentage" mapstructure:"percentage"`
+}
+
+// Flags 功能开关集合，创建后只读，可并发查询
+type Flags struct {
+	flags map[string]Flag
+}
+
+// NewFlags 创建功能开关集合，map 的键为开关名称，百分比超出 [0, 100] 时截断
+// viper 读取配置时会将键转为小写，开关名称因此不区分大小写
+func NewFlags(flags map[string]Flag) *Flags {
+	f := &Flags{flags: make(map[string]Flag, len(flags))}
+	for name, flag := range flags {
+		name = normalizeName(name)
+		flag.Name = name
+		if flag.Percentage < 0 {
+			flag.Percentage = 0
+		}
+		if flag.Percentage > 100 {
+			flag.Percentage = 100
+		}
+		f.flags[name] = flag
+	}
+	return f
+}
+
+// Enabled 判断功能对 key 是否开启，未配置的开关视为关闭
+// 灰度开关按开关名称和 key 计算分桶，同一个 key 的结果固定；key 为空时灰度开关视为关闭
+func (f *Flags) Enabled(name, key string) bool {
+	if f == nil {
+		return false
+	}
+	flag, ok := f.flags[normalizeName(name)]
+	if !ok {
+		return false
+	}
+	if flag.Enabled {
+		return true
+	}
+	if flag.Percentage <= 0 || key == "" {
+		return false
+	}
+	return bucket(flag.Name, key) < flag.Percentage
+}
+
+// List 按名称顺序返回所有开关
+func (f *Flags) List() []Flag {
+	if f == nil {
+		return nil
+	}
+	list := make([]Flag, 0, len(f.flags))
+	for _, flag := range f.flags {
+		list = append(list, flag)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// normalizeName 规范化开关名称，与 viper 的键保持一致
+func normalizeName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// bucket 计算 key 在开关下的分桶，取值范围 [0, 100)
+// 分桶包含开关名称，不同开关的灰度用户相互独立
+func bucket(name, key string) int {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,84 @@
+package featureflag
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestFlags_Boolean(t *testing.T) {
+	flags := NewFlags(map[string]Flag{
+		"file-upload-question": {Enabled: true},
+		"weighted-scoring":     {Enabled: false},
+	})
+
+	for _, key := range []string{"", "phq9", "42"} {
+		if !flags.Enabled("file-upload-question", key) {
+			t.Errorf("Enabled(file-upload-question, %q) = false, want true", key)
+		}
+		if flags.Enabled("weighted-scoring", key) {
+			t.Errorf("Enabled(weighted-scoring, %q) = true, want false", key)
+		}
+		if flags.Enabled("unknown", key) {
+			t.Errorf("Enabled(unknown, %q) = true, want false", key)
+		}
+	}
+}
+
+func TestFlags_PercentageRollout(t *testing.T) {
+	flags := NewFlags(map[string]Flag{
+		"new-scoring": {Percentage: 30},
+		"everyone":    {Percentage: 150},
+		"nobody":      {Percentage: 0},
+	})
+
+	enabled := 0
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		got := flags.Enabled("new-scoring", key)
+		// 同一个 key 的结果固定
+		for j := 0; j < 3; j++ {
+			if flags.Enabled("new-scoring", key) != got {
+				t.Fatalf("Enabled(new-scoring, %q) is not deterministic", key)
+			}
+		}
+		if got {
+			enabled++
+		}
+		if !flags.Enabled("everyone", key) || flags.Enabled("nobody", key) {
+			t.Fatalf("Enabled(%q) ignores 0%%/100%% rollout", key)
+		}
+	}
+	if enabled < 2700 || enabled > 3300 {
+		t.Errorf("new-scoring enabled for %d of 10000 keys, want about 3000", enabled)
+	}
+
+	// 重新加载相同配置后结果不变
+	reloaded := NewFlags(map[string]Flag{"new-scoring": {Percentage: 30}})
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		if reloaded.Enabled("new-scoring", key) != flags.Enabled("new-scoring", key) {
+			t.Fatalf("Enabled(new-scoring, %q) changed after reload", key)
+		}
+	}
+
+	if flags.Enabled("new-scoring", "") {
+		t.Error("Enabled(new-scoring, \"\") = true, want false for empty key")
+	}
+}
+
+func TestFlags_NamesAreCaseInsensitive(t *testing.T) {
+	// viper 读取配置时会将键转为小写
+	flags := NewFlags(map[string]Flag{"New-Question-Types": {Enabled: true}, "weightedscoring": {Percentage: 100}})
+
+	for _, name := range []string{NewQuestionTypes, "New-Question-Types", " NEW-QUESTION-TYPES "} {
+		if !flags.Enabled(name, "phq9") {
+			t.Errorf("Enabled(%q) = false, want true", name)
+		}
+	}
+	if !flags.Enabled("WeightedScoring", "phq9") {
+		t.Error("Enabled(WeightedScoring) = false, want true for lowercased viper key")
+	}
+	if got := flags.List(); len(got) != 2 || got[0].Name != "new-question-types" {
+		t.Errorf("List() = %+v, want normalized names", got)
+	}
+}
@@ -2,37 +2,76 @@ package grpcserver
 
 import (
 	"time"
+
+	"google.golang.org/grpc"
 )
 
 // Config GRPC 服务器配置
 type Config struct {
 	BindAddress           string
 	BindPort              int
-	MaxMsgSize            int
+	MaxRecvMsgSize        int // 可接收的最大消息字节数
+	MaxSendMsgSize        int // 可发送的最大消息字节数
 	MaxConnectionAge      time.Duration
 	MaxConnectionAgeGrace time.Duration
 	ReadTimeout           time.Duration
 	WriteTimeout          time.Duration
 	TLSCertFile           string
 	TLSKeyFile            string
+	ClientCAFile          string // 校验客户端证书的 CA 文件，配置后要求客户端提供证书（双向 TLS）
 	EnableReflection      bool
 	EnableHealthCheck     bool
-	Insecure              bool // 是否使用不安全连接
+	Insecure              bool          // 是否使用不安全连接，为 false 时必须配置 TLS 证书
+	ShutdownTimeout       time.Duration // 优雅关闭的最长等待时间，超时后强制停止
+
+	// keepalive 配置，MaxConnectionAge 和 MaxConnectionAgeGrace 同样作用于连接保活
+	MaxConnectionIdle time.Duration // 空闲连接的最长保留时间，0 表示不限制
+	KeepaliveTime     time.Duration // 连接空闲多久后服务端发送 ping 探测客户端
+	KeepaliveTimeout  time.Duration // 等待 ping 响应的时间，超时后关闭连接
+	KeepaliveMinTime  time.Duration // 允许客户端发送 ping 的最小间隔，更频繁的 ping 累计 3 次后以 GOAWAY 关闭连接
+	// KeepalivePermitWithoutStream 是否允许客户端在没有进行中请求时发送 ping
+	KeepalivePermitWithoutStream bool
+
+	// UnaryInterceptors 追加在内置拦截器之后的一元拦截器
+	UnaryInterceptors []grpc.UnaryServerInterceptor
 }
 
+// defaultShutdownTimeout 默认优雅关闭超时时间
+const defaultShutdownTimeout = 5 * time.Second
+
+// defaultMaxMsgSize 默认消息大小限制 4MB
+const defaultMaxMsgSize = 4 * 1024 * 1024
+
+// keepalive 默认值
+// 客户端 ping 的最小间隔取 20 秒：内部客户端有请求时每 30 秒 ping 一次，grpc-go 默认的 5 分钟会断开这些连接；
+// 服务端在连接空闲 1 分钟后 ping 客户端，20 秒无响应即关闭，及时清理断开的连接；
+// 15 分钟没有请求的连接由服务端关闭，客户端下次请求时重新建立
+const (
+	defaultMaxConnectionIdle = 15 * time.Minute
+	defaultKeepaliveTime     = 1 * time.Minute
+	defaultKeepaliveTimeout  = 20 * time.Second
+	defaultKeepaliveMinTime  = 20 * time.Second
+)
+
 // NewConfig 创建默认的 GRPC 服务器配置
 func NewConfig() *Config {
 	return &Config{
 		BindAddress:           "0.0.0.0",
 		BindPort:              9090,
-		MaxMsgSize:            4 * 1024 * 1024,  // 4MB
+		MaxRecvMsgSize:        defaultMaxMsgSize,
+		MaxSendMsgSize:        defaultMaxMsgSize,
 		MaxConnectionAge:      2 * time.Hour,    // 连接最大存活时间
 		MaxConnectionAgeGrace: 10 * time.Second, // 连接优雅终止等待时间
-		ReadTimeout:           5 * time.Second,  // 读取超时时间
-		WriteTimeout:          5 * time.Second,  // 写入超时时间
-		EnableReflection:      true,             // 启用反射
-		EnableHealthCheck:     true,             // 启用健康检查
-		Insecure:              true,             // 默认使用不安全连接
+		MaxConnectionIdle:     defaultMaxConnectionIdle,
+		KeepaliveTime:         defaultKeepaliveTime,
+		KeepaliveTimeout:      defaultKeepaliveTimeout,
+		KeepaliveMinTime:      defaultKeepaliveMinTime,
+		ReadTimeout:           5 * time.Second, // 读取超时时间
+		WriteTimeout:          5 * time.Second, // 写入超时时间
+		EnableReflection:      true,            // 启用反射
+		EnableHealthCheck:     true,            // 启用健康检查
+		Insecure:              false,           // 默认使用 TLS，明文连接需显式开启
+		ShutdownTimeout:       defaultShutdownTimeout,
 	}
 }
 
@@ -50,8 +89,11 @@ func (c *Config) Complete() CompletedConfig {
 	if c.BindPort == 0 {
 		c.BindPort = 8090
 	}
-	if c.MaxMsgSize == 0 {
-		c.MaxMsgSize = 4 * 1024 * 1024
+	if c.MaxRecvMsgSize == 0 {
+		c.MaxRecvMsgSize = defaultMaxMsgSize
+	}
+	if c.MaxSendMsgSize == 0 {
+		c.MaxSendMsgSize = defaultMaxMsgSize
 	}
 	if c.MaxConnectionAge == 0 {
 		c.MaxConnectionAge = 2 * time.Hour
@@ -59,12 +101,24 @@ func (c *Config) Complete() CompletedConfig {
 	if c.MaxConnectionAgeGrace == 0 {
 		c.MaxConnectionAgeGrace = 10 * time.Second
 	}
+	if c.KeepaliveTime == 0 {
+		c.KeepaliveTime = defaultKeepaliveTime
+	}
+	if c.KeepaliveTimeout == 0 {
+		c.KeepaliveTimeout = defaultKeepaliveTimeout
+	}
+	if c.KeepaliveMinTime == 0 {
+		c.KeepaliveMinTime = defaultKeepaliveMinTime
+	}
 	if c.ReadTimeout == 0 {
 		c.ReadTimeout = 5 * time.Second
 	}
 	if c.WriteTimeout == 0 {
 		c.WriteTimeout = 5 * time.Second
 	}
+	if c.ShutdownTimeout <= 0 {
+		c.ShutdownTimeout = defaultShutdownTimeout
+	}
 
 	return CompletedConfig{c}
 }
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
@@ -12,9 +14,23 @@ import (
 	"google.golang.org/grpc/peer"
 	"google.golang.org/grpc/status"
 
+	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
 	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
+// healthServicePrefix 健康检查服务的方法前缀，健康检查不受就绪状态限制
+const healthServicePrefix = "/grpc.health.v1.Health/"
+
+// ReadinessInterceptor 就绪拦截器，服务器就绪前以 codes.Unavailable 拒绝除健康检查外的请求
+func ReadinessInterceptor(ready *atomic.Bool) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !ready.Load() && !strings.HasPrefix(info.FullMethod, healthServicePrefix) {
+			return nil, status.Error(codes.Unavailable, "server is not ready")
+		}
+		return handler(ctx, req)
+	}
+}
+
 // LoggingInterceptor 统一的 gRPC 日志拦截器
 func LoggingInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
@@ -27,7 +43,7 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 		headers := getHeaders(ctx)
 
 		// 记录请求开始（包含请求参数）
-		log.Infof("gRPC Request Started - RequestID: %s, Method: %s, ClientIP: %s, UserAgent: %s, Headers: %v, Request: %+v",
+		log.L(ctx).Infof("gRPC Request Started - RequestID: %s, Method: %s, ClientIP: %s, UserAgent: %s, Headers: %v, Request: %+v",
 			requestID, info.FullMethod, clientIP, userAgent, headers, req)
 
 		// 执行实际的处理器
@@ -51,12 +67,12 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 
 		// 记录请求完成（包含响应数据）
 		if err != nil {
-			log.Errorf("gRPC Request Failed - RequestID: %s, Method: %s, Duration: %v, Status: %s, Error: %s",
+			log.L(ctx).Errorf("gRPC Request Failed - RequestID: %s, Method: %s, Duration: %v, Status: %s, Error: %s",
 				requestID, info.FullMethod, duration, statusCode, errorMsg)
 		} else {
 			// 生成响应摘要，避免日志过长
 			responseSummary := generateResponseSummary(resp)
-			log.Infof("gRPC Request Completed - RequestID: %s, Method: %s, Duration: %v, Status: %s, ResponseSummary: %s",
+			log.L(ctx).Infof("gRPC Request Completed - RequestID: %s, Method: %s, Duration: %v, Status: %s, ResponseSummary: %s",
 				requestID, info.FullMethod, duration, statusCode, responseSummary)
 		}
 
@@ -65,11 +81,17 @@ func LoggingInterceptor() grpc.UnaryServerInterceptor {
 }
 
 // RecoveryInterceptor 恢复拦截器，防止 panic 导致服务崩溃
-func RecoveryInterceptor() grpc.UnaryServerInterceptor {
+// inFlight 不为空时记录正在处理的请求数，用于优雅关闭时统计
+func RecoveryInterceptor(inFlight *atomic.Int64) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		if inFlight != nil {
+			inFlight.Add(1)
+			defer inFlight.Add(-1)
+		}
+
 		defer func() {
 			if r := recover(); r != nil {
-				log.Errorf("gRPC Request Panic Recovered - Method: %s, Panic: %v, Stack: %s", info.FullMethod, r, debug.Stack())
+				log.L(ctx).Errorf("gRPC Request Panic Recovered - Method: %s, Panic: %v, Stack: %s", info.FullMethod, r, debug.Stack())
 				err = status.Error(codes.Internal, fmt.Sprintf("内部服务器错误: %v", r))
 			}
 		}()
@@ -78,19 +100,33 @@ func RecoveryInterceptor() grpc.UnaryServerInterceptor {
 	}
 }
 
-// RequestIDInterceptor 请求ID拦截器，为每个请求生成唯一ID
+// RequestIDInterceptor 请求ID拦截器
+// 优先使用调用方通过 metadata 传递的请求ID，没有时生成新的ID，并通过响应头返回给调用方
 func RequestIDInterceptor() grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
-		// 生成请求ID
-		requestID := generateRequestID()
+		requestID := incomingRequestID(ctx)
+		if requestID == "" {
+			requestID = generateRequestID()
+		}
 
-		// 将请求ID添加到上下文
-		ctx = context.WithValue(ctx, "request_id", requestID)
+		// 将请求ID添加到上下文，log.L(ctx) 输出的日志会带上它
+		ctx = middleware.WithRequestID(ctx, requestID)
+		_ = grpc.SetHeader(ctx, metadata.Pairs(middleware.GRPCRequestIDKey, requestID))
 
 		return handler(ctx, req)
 	}
 }
 
+// incomingRequestID 从传入的 metadata 获取请求ID
+func incomingRequestID(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if requestID := md.Get(middleware.GRPCRequestIDKey); len(requestID) > 0 {
+			return requestID[0]
+		}
+	}
+	return ""
+}
+
 // getClientIP 获取客户端IP地址
 func getClientIP(ctx context.Context) string {
 	if peer, ok := peer.FromContext(ctx); ok {
@@ -127,7 +163,7 @@ func getHeaders(ctx context.Context) map[string][]string {
 
 // getRequestID 从上下文获取请求ID
 func getRequestID(ctx context.Context) string {
-	if requestID, ok := ctx.Value("request_id").(string); ok {
+	if requestID := middleware.RequestIDFrom(ctx); requestID != "" {
 		return requestID
 	}
 	return "unknown"
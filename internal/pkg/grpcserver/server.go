@@ -4,7 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net"
-	"time"
+	"sync/atomic"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -22,6 +22,9 @@ type Server struct {
 	config   *Config
 	services []Service
 	secure   bool
+	inFlight *atomic.Int64 // 正在处理的请求数
+	ready    *atomic.Bool  // 是否已就绪，就绪前拒绝业务请求
+	health   *health.Server
 }
 
 // Service GRPC 服务接口
@@ -35,45 +38,60 @@ func NewServer(config *Config) (*Server, error) {
 	var serverOpts []grpc.ServerOption
 
 	// 添加拦截器链
-	serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(
-		RecoveryInterceptor(),  // 恢复拦截器，防止 panic
-		RequestIDInterceptor(), // 请求ID拦截器
-		LoggingInterceptor(),   // 日志拦截器
-	))
+	inFlight := &atomic.Int64{}
+	ready := &atomic.Bool{}
+	interceptors := []grpc.UnaryServerInterceptor{
+		RecoveryInterceptor(inFlight), // 恢复拦截器，防止 panic，并统计处理中的请求
+		RequestIDInterceptor(),        // 请求ID拦截器
+		LoggingInterceptor(),          // 日志拦截器
+		ReadinessInterceptor(ready),   // 就绪拦截器，就绪前拒绝业务请求
+	}
+	interceptors = append(interceptors, config.UnaryInterceptors...)
+	serverOpts = append(serverOpts, grpc.ChainUnaryInterceptor(interceptors...))
 
 	// 添加消息大小限制
-	if config.MaxMsgSize > 0 {
-		serverOpts = append(serverOpts,
-			grpc.MaxRecvMsgSize(config.MaxMsgSize),
-			grpc.MaxSendMsgSize(config.MaxMsgSize),
-		)
+	if config.MaxRecvMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxRecvMsgSize(config.MaxRecvMsgSize))
+	}
+	if config.MaxSendMsgSize > 0 {
+		serverOpts = append(serverOpts, grpc.MaxSendMsgSize(config.MaxSendMsgSize))
 	}
 
-	// 添加连接管理选项
-	if config.MaxConnectionAge > 0 {
-		serverOpts = append(serverOpts, grpc.KeepaliveParams(keepalive.ServerParameters{
+	// 添加连接管理选项，字段为 0 时使用 grpc-go 的默认值
+	serverOpts = append(serverOpts,
+		grpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle:     config.MaxConnectionIdle,
 			MaxConnectionAge:      config.MaxConnectionAge,
 			MaxConnectionAgeGrace: config.MaxConnectionAgeGrace,
-		}))
-	}
-
-	// 如果配置了 TLS 且不是不安全模式，添加 TLS 选项
+			Time:                  config.KeepaliveTime,
+			Timeout:               config.KeepaliveTimeout,
+		}),
+		// 限制客户端 ping 的频率，避免频繁 ping 占用服务端资源
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             config.KeepaliveMinTime,
+			PermitWithoutStream: config.KeepalivePermitWithoutStream,
+		}),
+	)
+
+	// 除非显式配置为不安全模式，否则必须使用 TLS
 	secure := false
-	if !config.Insecure && config.TLSCertFile != "" && config.TLSKeyFile != "" {
-		creds, err := credentials.NewServerTLSFromFile(config.TLSCertFile, config.TLSKeyFile)
+	if !config.Insecure {
+		tlsConfig, err := loadTLSConfig(config)
 		if err != nil {
-			return nil, fmt.Errorf("failed to load TLS credentials: %v", err)
+			return nil, err
 		}
-		serverOpts = append(serverOpts, grpc.Creds(creds))
+		serverOpts = append(serverOpts, grpc.Creds(credentials.NewTLS(tlsConfig)))
 		secure = true
 	}
 
 	// 创建 GRPC 服务器
 	grpcServer := grpc.NewServer(serverOpts...)
 
-	// 注册健康检查服务
+	// 注册健康检查服务，就绪前报告 NOT_SERVING
+	var healthServer *health.Server
 	if config.EnableHealthCheck {
-		healthServer := health.NewServer()
+		healthServer = health.NewServer()
+		healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
 		healthpb.RegisterHealthServer(grpcServer, healthServer)
 	}
 
@@ -87,6 +105,9 @@ func NewServer(config *Config) (*Server, error) {
 		config:   config,
 		services: make([]Service, 0),
 		secure:   secure,
+		inFlight: inFlight,
+		ready:    ready,
+		health:   healthServer,
 	}, nil
 }
 
@@ -111,7 +132,8 @@ func (s *Server) Run() error {
 	if s.secure {
 		scheme = "https"
 	}
-	log.Infof("Starting GRPC Server on %s://%s (max message size: %d)", scheme, address, s.config.MaxMsgSize)
+	log.Infof("Starting GRPC Server on %s://%s (max recv message size: %d, max send message size: %d)",
+		scheme, address, s.config.MaxRecvMsgSize, s.config.MaxSendMsgSize)
 
 	// 启动服务器
 	return s.Serve(lis)
@@ -133,24 +155,52 @@ func (s *Server) RunWithContext(ctx context.Context) error {
 	}
 }
 
-// Close 优雅关闭 GRPC 服务器
+// SetReady 标记服务器已就绪，健康检查报告 SERVING 并开始处理业务请求
+// 应在所有模块初始化完成、服务注册之后调用
+func (s *Server) SetReady() {
+	s.ready.Store(true)
+	if s.health != nil {
+		s.health.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	}
+	log.Info("GRPC server is ready to serve requests")
+}
+
+// IsReady 返回服务器是否已就绪
+func (s *Server) IsReady() bool {
+	return s.ready.Load()
+}
+
+// InFlightRequests 返回正在处理的请求数
+func (s *Server) InFlightRequests() int64 {
+	return s.inFlight.Load()
+}
+
+// Close 优雅关闭 GRPC 服务器，超过 ShutdownTimeout 仍未完成时强制停止
 func (s *Server) Close() {
-	const timeout = 5 * time.Second
-	ch := make(chan struct{})
+	timeout := s.config.ShutdownTimeout
+	if timeout <= 0 {
+		timeout = defaultShutdownTimeout
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	log.Infof("GRPC server shutting down, in-flight requests: %d", s.InFlightRequests())
 
+	done := make(chan struct{})
 	go func() {
 		// 优雅停止
 		s.GracefulStop()
-		close(ch)
+		close(done)
 	}()
 
 	// 等待优雅停止或超时
 	select {
-	case <-ch:
+	case <-done:
 		log.Info("GRPC server stopped gracefully")
-	case <-time.After(timeout):
-		log.Info("GRPC server forced to stop after timeout")
+	case <-ctx.Done():
+		terminated := s.InFlightRequests()
 		s.Stop()
+		log.Warnf("GRPC server forced to stop after %s, %d in-flight requests terminated", timeout, terminated)
 	}
 }
 
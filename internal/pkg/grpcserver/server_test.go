@@ -0,0 +1,354 @@
+package grpcserver
+
+import (
+	"context"
+	"net"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/http2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// sleepMethod 测试用的慢请求方法
+const sleepMethod = "/test.Sleeper/Sleep"
+
+// newSleepServiceDesc 创建处理耗时为 delay 的测试服务
+func newSleepServiceDesc(delay time.Duration) *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: "test.Sleeper",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Sleep",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+					time.Sleep(delay)
+					return &emptypb.Empty{}, nil
+				}
+				return interceptor(ctx, in, &grpc.UnaryServerInfo{Server: srv, FullMethod: sleepMethod}, handler)
+			},
+		}},
+	}
+}
+
+// largeMethod 测试用的大响应方法
+const largeMethod = "/test.Large/Get"
+
+// newLargeServiceDesc 创建返回 size 字节响应的测试服务
+func newLargeServiceDesc(size int) *grpc.ServiceDesc {
+	return &grpc.ServiceDesc{
+		ServiceName: "test.Large",
+		HandlerType: (*interface{})(nil),
+		Methods: []grpc.MethodDesc{{
+			MethodName: "Get",
+			Handler: func(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+				in := new(emptypb.Empty)
+				if err := dec(in); err != nil {
+					return nil, err
+				}
+				return wrapperspb.Bytes(make([]byte, size)), nil
+			},
+		}},
+	}
+}
+
+func TestServer_MaxSendMsgSize(t *testing.T) {
+	const responseSize = 6 * 1024 * 1024 // 超过默认的 4MB 限制
+
+	tests := []struct {
+		name     string
+		sendSize int
+		wantCode codes.Code
+	}{
+		{"default limit", 0, codes.ResourceExhausted},
+		{"configured limit", 8 * 1024 * 1024, codes.OK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig()
+			config.Insecure = true
+			config.EnableReflection = false
+			config.EnableHealthCheck = false
+			if tt.sendSize > 0 {
+				config.MaxSendMsgSize = tt.sendSize
+			}
+
+			server, err := config.Complete().New()
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+			server.Server.RegisterService(newLargeServiceDesc(responseSize), struct{}{})
+
+			listener := bufconn.Listen(1024 * 1024)
+			go func() { _ = server.Serve(listener) }()
+			defer server.Stop()
+
+			conn, err := grpc.NewClient(
+				"passthrough:///bufnet",
+				grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+					return listener.DialContext(ctx)
+				}),
+				grpc.WithTransportCredentials(insecure.NewCredentials()),
+				grpc.WithDefaultCallOptions(grpc.MaxCallRecvMsgSize(8*1024*1024)),
+			)
+			if err != nil {
+				t.Fatalf("grpc.NewClient() error = %v", err)
+			}
+			defer conn.Close()
+
+			reply := new(wrapperspb.BytesValue)
+			err = conn.Invoke(context.Background(), largeMethod, &emptypb.Empty{}, reply)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("Invoke() error = %v, want code %s", err, tt.wantCode)
+			}
+			if tt.wantCode == codes.OK && len(reply.GetValue()) != responseSize {
+				t.Errorf("response size = %d, want %d", len(reply.GetValue()), responseSize)
+			}
+		})
+	}
+}
+
+func TestServer_MaxRecvMsgSize(t *testing.T) {
+	config := NewConfig()
+	config.Insecure = true
+	config.EnableReflection = false
+	config.EnableHealthCheck = false
+	config.MaxRecvMsgSize = 1024
+
+	server, err := config.Complete().New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	server.Server.RegisterService(newLargeServiceDesc(0), struct{}{})
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+
+	err = conn.Invoke(context.Background(), largeMethod, wrapperspb.Bytes(make([]byte, 2048)), new(wrapperspb.BytesValue))
+	if code := status.Code(err); code != codes.ResourceExhausted {
+		t.Errorf("Invoke() with 2KB request error = %v, want code %s", err, codes.ResourceExhausted)
+	}
+}
+
+func TestServer_CloseForcesStopAfterShutdownTimeout(t *testing.T) {
+	config := NewConfig()
+	config.Insecure = true
+	config.ShutdownTimeout = 50 * time.Millisecond
+	config.EnableReflection = false
+	config.EnableHealthCheck = false
+
+	server, err := config.Complete().New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	server.Server.RegisterService(newSleepServiceDesc(200*time.Millisecond), struct{}{})
+	server.SetReady()
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+
+	callErr := make(chan error, 1)
+	go func() {
+		callErr <- conn.Invoke(context.Background(), sleepMethod, &emptypb.Empty{}, &emptypb.Empty{})
+	}()
+
+	// 等待请求进入处理器
+	deadline := time.Now().Add(time.Second)
+	for server.InFlightRequests() != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("InFlightRequests() = %d, want 1", server.InFlightRequests())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	start := time.Now()
+	server.Close()
+	if elapsed := time.Since(start); elapsed >= 200*time.Millisecond {
+		t.Errorf("Close() took %s, want it to give up after the 50ms shutdown timeout", elapsed)
+	}
+
+	if err := <-callErr; err == nil {
+		t.Error("in-flight request succeeded, want it to be terminated")
+	}
+}
+
+func TestServer_RejectsRequestsUntilReady(t *testing.T) {
+	config := NewConfig()
+	config.Insecure = true
+	config.EnableReflection = false
+
+	server, err := config.Complete().New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	server.Server.RegisterService(newSleepServiceDesc(0), struct{}{})
+
+	listener := bufconn.Listen(1024 * 1024)
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	conn, err := grpc.NewClient(
+		"passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("grpc.NewClient() error = %v", err)
+	}
+	defer conn.Close()
+
+	healthClient := healthpb.NewHealthClient(conn)
+	checkServing := func(want healthpb.HealthCheckResponse_ServingStatus) {
+		t.Helper()
+		resp, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{})
+		if err != nil || resp.GetStatus() != want {
+			t.Fatalf("health Check() = %v, %v, want %s", resp.GetStatus(), err, want)
+		}
+	}
+
+	// 就绪前健康检查报告 NOT_SERVING，业务请求返回 Unavailable
+	checkServing(healthpb.HealthCheckResponse_NOT_SERVING)
+	err = conn.Invoke(context.Background(), sleepMethod, &emptypb.Empty{}, &emptypb.Empty{})
+	if code := status.Code(err); code != codes.Unavailable {
+		t.Fatalf("Invoke() before ready error = %v, want code %s", err, codes.Unavailable)
+	}
+
+	server.SetReady()
+
+	checkServing(healthpb.HealthCheckResponse_SERVING)
+	if err := conn.Invoke(context.Background(), sleepMethod, &emptypb.Empty{}, &emptypb.Empty{}); err != nil {
+		t.Errorf("Invoke() after ready error = %v", err)
+	}
+}
+
+func TestServer_KeepaliveEnforcement(t *testing.T) {
+	const minTime = 100 * time.Millisecond
+
+	tests := []struct {
+		name       string
+		interval   time.Duration
+		wantGoAway bool
+	}{
+		{"client respecting the policy stays connected", 150 * time.Millisecond, false},
+		{"client pinging too often is disconnected", 5 * time.Millisecond, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig()
+			config.Insecure = true
+			config.EnableReflection = false
+			config.EnableHealthCheck = false
+			config.KeepaliveMinTime = minTime
+			config.KeepalivePermitWithoutStream = true
+
+			server, err := config.Complete().New()
+			if err != nil {
+				t.Fatalf("New() error = %v", err)
+			}
+
+			listener := bufconn.Listen(1024 * 1024)
+			go func() { _ = server.Serve(listener) }()
+			defer server.Stop()
+
+			// grpc-go 客户端的 ping 间隔不能小于 10 秒，因此直接以 HTTP/2 帧发送 ping
+			conn, err := listener.Dial()
+			if err != nil {
+				t.Fatalf("Dial() error = %v", err)
+			}
+			defer conn.Close()
+
+			var mu sync.Mutex
+			framer := http2.NewFramer(conn, conn)
+			write := func(f func() error) {
+				mu.Lock()
+				defer mu.Unlock()
+				_ = f()
+			}
+			write(func() error {
+				if _, err := conn.Write([]byte(http2.ClientPreface)); err != nil {
+					return err
+				}
+				return framer.WriteSettings()
+			})
+
+			goAway := make(chan http2.ErrCode, 1)
+			go func() {
+				for {
+					frame, err := framer.ReadFrame()
+					if err != nil {
+						return
+					}
+					switch f := frame.(type) {
+					case *http2.SettingsFrame:
+						if !f.IsAck() {
+							write(framer.WriteSettingsAck)
+						}
+					case *http2.GoAwayFrame:
+						goAway <- f.ErrCode
+						return
+					}
+				}
+			}()
+
+			for i := 0; i < 5; i++ {
+				write(func() error { return framer.WritePing(false, [8]byte{byte(i)}) })
+				time.Sleep(tt.interval)
+			}
+
+			select {
+			case code := <-goAway:
+				if !tt.wantGoAway {
+					t.Fatalf("connection closed with GOAWAY %s, want it to stay connected", code)
+				}
+				if code != http2.ErrCodeEnhanceYourCalm {
+					t.Errorf("GOAWAY code = %s, want %s", code, http2.ErrCodeEnhanceYourCalm)
+				}
+			case <-time.After(200 * time.Millisecond):
+				if tt.wantGoAway {
+					t.Fatal("connection stayed open, want GOAWAY for too many pings")
+				}
+			}
+		})
+	}
+}
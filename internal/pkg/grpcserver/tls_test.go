@@ -0,0 +1,160 @@
+package grpcserver
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// testCert 测试用证书及其 PEM 编码
+type testCert struct {
+	cert    *x509.Certificate
+	key     *ecdsa.PrivateKey
+	certPEM []byte
+	keyPEM  []byte
+}
+
+// newTestCert 生成测试证书，parent 为空时生成自签名 CA 证书
+func newTestCert(t *testing.T, commonName string, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("GenerateKey() error = %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+	}
+	signer, signerKey := template, key
+	if parent == nil {
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+	} else {
+		signer, signerKey = parent.cert, parent.key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("CreateCertificate() error = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("ParseCertificate() error = %v", err)
+	}
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("MarshalECPrivateKey() error = %v", err)
+	}
+	return &testCert{
+		cert:    cert,
+		key:     key,
+		certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM:  pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+// writeFile 将内容写入临时目录下的文件并返回路径
+func writeFile(t *testing.T, dir, name string, data []byte) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+	return path
+}
+
+// startTLSServer 在本地随机端口启动 TLS GRPC 服务器并返回监听地址
+func startTLSServer(t *testing.T, config *Config) string {
+	t.Helper()
+	server, err := config.Complete().New()
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Listen() error = %v", err)
+	}
+	go func() { _ = server.Serve(lis) }()
+	t.Cleanup(server.Stop)
+	return lis.Addr().String()
+}
+
+// checkHealth 使用给定的 TLS 配置连接服务器并调用健康检查
+func checkHealth(addr string, tlsConfig *tls.Config) error {
+	conn, err := grpc.NewClient(addr, grpc.WithTransportCredentials(credentials.NewTLS(tlsConfig)))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = healthpb.NewHealthClient(conn).Check(ctx, &healthpb.HealthCheckRequest{})
+	return err
+}
+
+func TestServer_TLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := newTestCert(t, "test-ca", nil)
+	serverCert := newTestCert(t, "127.0.0.1", ca)
+	clientCert := newTestCert(t, "test-client", ca)
+
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.cert)
+
+	config := NewConfig()
+	config.EnableReflection = false
+	config.TLSCertFile = writeFile(t, dir, "server.crt", serverCert.certPEM)
+	config.TLSKeyFile = writeFile(t, dir, "server.key", serverCert.keyPEM)
+	addr := startTLSServer(t, config)
+
+	if err := checkHealth(addr, &tls.Config{RootCAs: roots}); err != nil {
+		t.Fatalf("TLS health check error = %v", err)
+	}
+
+	t.Run("mutual TLS", func(t *testing.T) {
+		mtlsConfig := *config
+		mtlsConfig.ClientCAFile = writeFile(t, dir, "ca.crt", ca.certPEM)
+		addr := startTLSServer(t, &mtlsConfig)
+
+		if err := checkHealth(addr, &tls.Config{RootCAs: roots}); err == nil {
+			t.Errorf("health check without client certificate succeeded, want handshake failure")
+		}
+
+		pair, err := tls.X509KeyPair(clientCert.certPEM, clientCert.keyPEM)
+		if err != nil {
+			t.Fatalf("X509KeyPair() error = %v", err)
+		}
+		if err := checkHealth(addr, &tls.Config{RootCAs: roots, Certificates: []tls.Certificate{pair}}); err != nil {
+			t.Errorf("health check with client certificate error = %v", err)
+		}
+	})
+
+	t.Run("missing certificate", func(t *testing.T) {
+		noCertConfig := NewConfig()
+		if _, err := noCertConfig.Complete().New(); err == nil {
+			t.Errorf("New() without certificate and insecure disabled succeeded, want error")
+		}
+	})
+}
@@ -2,6 +2,7 @@ package interpretation
 
 import (
 	"fmt"
+	"math"
 	"sort"
 )
 
@@ -127,3 +128,45 @@ func (sr ScoreRange) Validate() error {
 func (sr ScoreRange) String() string {
 	return fmt.Sprintf("[%.2f, %.2f)", sr.minScore, sr.maxScore)
 }
+
+// ValidateCoverage 验证多个分数范围是否完整覆盖 [minScore, maxScore] 且互不重叠
+// 由于区间左闭右开，最后一个区间的 max 必须大于 maxScore 才能包含最高分；
+// 存在空缺或重叠时返回的错误中包含具体的空缺区间或重叠区间
+func ValidateCoverage(ranges []ScoreRange, minScore, maxScore float64) error {
+	if minScore > maxScore {
+		return fmt.Errorf("invalid score bounds: min score %.2f is greater than max score %.2f", minScore, maxScore)
+	}
+	if len(ranges) == 0 {
+		return fmt.Errorf("score ranges cannot be empty")
+	}
+
+	// 按照 minScore 排序，不修改调用方的切片
+	sorted := append([]ScoreRange(nil), ranges...)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].minScore < sorted[j].minScore
+	})
+
+	for i, r := range sorted {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("invalid range at index %d: %w", i, err)
+		}
+	}
+
+	if first := sorted[0]; first.minScore > minScore {
+		return fmt.Errorf("gap in score ranges: %s is not covered", NewScoreRange(minScore, first.minScore))
+	}
+	for i := 1; i < len(sorted); i++ {
+		prev, r := sorted[i-1], sorted[i]
+		if prev.maxScore < r.minScore {
+			return fmt.Errorf("gap in score ranges: %s is not covered", NewScoreRange(prev.maxScore, r.minScore))
+		}
+		if prev.maxScore > r.minScore {
+			return fmt.Errorf("overlapping ranges: %s and %s overlap on %s",
+				prev, r, NewScoreRange(r.minScore, math.Min(prev.maxScore, r.maxScore)))
+		}
+	}
+	if last := sorted[len(sorted)-1]; last.maxScore <= maxScore {
+		return fmt.Errorf("gap in score ranges: [%.2f, %.2f] is not covered", last.maxScore, maxScore)
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+package interpretation
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateCoverage(t *testing.T) {
+	tests := []struct {
+		name    string
+		ranges  []ScoreRange
+		wantErr string
+	}{
+		{
+			name:   "complete covering",
+			ranges: []ScoreRange{NewScoreRange(10, 20), NewScoreRange(0, 5), NewScoreRange(20, 28), NewScoreRange(5, 10)},
+		},
+		{
+			name:    "gap between ranges",
+			ranges:  []ScoreRange{NewScoreRange(0, 5), NewScoreRange(10, 28)},
+			wantErr: "[5.00, 10.00) is not covered",
+		},
+		{
+			name:    "gap below first range",
+			ranges:  []ScoreRange{NewScoreRange(1, 28)},
+			wantErr: "[0.00, 1.00) is not covered",
+		},
+		{
+			name:    "max score not covered",
+			ranges:  []ScoreRange{NewScoreRange(0, 27)},
+			wantErr: "[27.00, 27.00] is not covered",
+		},
+		{
+			name:    "overlap",
+			ranges:  []ScoreRange{NewScoreRange(0, 12), NewScoreRange(10, 28)},
+			wantErr: "[0.00, 12.00) and [10.00, 28.00) overlap on [10.00, 12.00)",
+		},
+		{
+			name:    "empty",
+			wantErr: "cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateCoverage(tt.ranges, 0, 27)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("ValidateCoverage() error = %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ValidateCoverage() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
-	"github.com/yshujie/questionnaire-scale/pkg/core"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
@@ -42,12 +42,7 @@ func (a AutoStrategy) AuthFunc() gin.HandlerFunc {
 
 		// 如果Authorization头格式不正确，返回错误
 		if len(authHeader) != authHeaderCount {
-			core.WriteResponse(
-				c,
-				errors.WithCode(code.ErrInvalidAuthHeader, "Authorization header format is wrong."),
-				nil,
-			)
-			c.Abort()
+			envelope.AbortWithError(c, errors.WithCode(code.ErrInvalidAuthHeader, "Authorization header format is wrong."))
 
 			return
 		}
@@ -61,8 +56,7 @@ func (a AutoStrategy) AuthFunc() gin.HandlerFunc {
 			// 使用 JWT 认证器
 			operator.SetStrategy(a.jwt)
 		default:
-			core.WriteResponse(c, errors.WithCode(code.ErrSignatureInvalid, "unrecognized Authorization header."), nil)
-			c.Abort()
+			envelope.AbortWithError(c, errors.WithCode(code.ErrSignatureInvalid, "unrecognized Authorization header."))
 
 			return
 		}
@@ -7,22 +7,23 @@ import (
 	"github.com/gin-gonic/gin"
 
 	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware"
 	"github.com/yshujie/questionnaire-scale/internal/pkg/middleware/auth"
-	"github.com/yshujie/questionnaire-scale/pkg/core"
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
 )
 
 // BasicStrategy 基础策略认证器
 type BasicStrategy struct {
-	compare func(username string, password string) bool
+	compare func(c *gin.Context, username string, password string) error
 }
 
 // 实现AuthStrategy接口
 var _ auth.AuthStrategy = &BasicStrategy{}
 
-// NewBasicStrategy 创建基础认证策略器
-func NewBasicStrategy(compare func(username string, password string) bool) BasicStrategy {
+// NewBasicStrategy 创建基础认证策略器，compare 可从请求上下文中获取客户端信息
+// compare 返回的错误带错误码时按该错误码响应，否则按 ErrSignatureInvalid 响应
+func NewBasicStrategy(compare func(c *gin.Context, username string, password string) error) BasicStrategy {
 	return BasicStrategy{
 		compare: compare,
 	}
@@ -36,12 +37,7 @@ func (b BasicStrategy) AuthFunc() gin.HandlerFunc {
 
 		// 如果Authorization头格式不正确，返回错误
 		if len(auth) != 2 || auth[0] != "Basic" {
-			core.WriteResponse(
-				c,
-				errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong."),
-				nil,
-			)
-			c.Abort()
+			envelope.AbortWithError(c, errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong."))
 
 			return
 		}
@@ -51,14 +47,18 @@ func (b BasicStrategy) AuthFunc() gin.HandlerFunc {
 		// 分割用户名和密码
 		pair := strings.SplitN(string(payload), ":", 2)
 
+		if len(pair) != 2 {
+			envelope.AbortWithError(c, errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong."))
+
+			return
+		}
+
 		// 如果用户名和密码不匹配，返回错误
-		if len(pair) != 2 || !b.compare(pair[0], pair[1]) {
-			core.WriteResponse(
-				c,
-				errors.WithCode(code.ErrSignatureInvalid, "Authorization header format is wrong."),
-				nil,
-			)
-			c.Abort()
+		if err := b.compare(c, pair[0], pair[1]); err != nil {
+			if !envelope.HasCode(err) {
+				err = errors.WrapC(err, code.ErrSignatureInvalid, "basic auth failed")
+			}
+			envelope.AbortWithError(c, err)
 
 			return
 		}
@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// boundedCache 带过期时间和容量上限的进程内缓存
+// 条目数超过容量上限时淘汰最久未访问的条目，过期条目在访问时删除
+type boundedCache[V any] struct {
+	mu         sync.Mutex
+	ttl        time.Duration
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+// boundedCacheEntry 缓存条目
+type boundedCacheEntry[V any] struct {
+	key       string
+	value     V
+	expiresAt time.Time
+}
+
+// newBoundedCache 创建缓存，ttl 为条目有效期，maxEntries 为条目数上限
+func newBoundedCache[V any](ttl time.Duration, maxEntries int) *boundedCache[V] {
+	return &boundedCache[V]{
+		ttl:        ttl,
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+// Get 获取未过期的缓存值
+func (c *boundedCache[V]) Get(key string) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var zero V
+	elem, ok := c.entries[key]
+	if !ok {
+		return zero, false
+	}
+	entry := elem.Value.(*boundedCacheEntry[V])
+	if time.Now().After(entry.expiresAt) {
+		c.remove(elem)
+		return zero, false
+	}
+	c.order.MoveToFront(elem)
+	return entry.value, true
+}
+
+// Set 写入缓存值并重置有效期，超出容量时淘汰最久未访问的条目
+func (c *boundedCache[V]) Set(key string, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	expiresAt := time.Now().Add(c.ttl)
+	if elem, ok := c.entries[key]; ok {
+		entry := elem.Value.(*boundedCacheEntry[V])
+		entry.value = value
+		entry.expiresAt = expiresAt
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	c.entries[key] = c.order.PushFront(&boundedCacheEntry[V]{key: key, value: value, expiresAt: expiresAt})
+	for c.order.Len() > c.maxEntries {
+		c.remove(c.order.Back())
+	}
+}
+
+// Len 返回缓存条目数
+func (c *boundedCache[V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// remove 删除条目，调用方需持有锁
+func (c *boundedCache[V]) remove(elem *list.Element) {
+	c.order.Remove(elem)
+	delete(c.entries, elem.Value.(*boundedCacheEntry[V]).key)
+}
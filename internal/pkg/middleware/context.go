@@ -9,6 +9,9 @@ import (
 // UsernameKey 定义了在 gin 上下文中表示密钥所有者的键
 const UsernameKey = "username"
 
+// SessionIDKey 定义了在 gin 上下文中表示当前登录会话的键
+const SessionIDKey = "session_id"
+
 // Context 是一个中间件，将公共前缀字段注入到 gin.Context 中
 func Context() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+const (
+	// geoLookupCacheTTL IP 归属国家的缓存时间
+	geoLookupCacheTTL = time.Hour
+	// geoLookupCacheSize 缓存的 IP 归属国家数量上限
+	geoLookupCacheSize = 10000
+	// geoRestrictionCacheTTL 问卷地域限制的缓存时间，问卷地域限制修改后最迟在该时间后生效
+	geoRestrictionCacheTTL = time.Minute
+	// geoRestrictionCacheSize 缓存的问卷地域限制数量上限
+	geoRestrictionCacheSize = 1000
+)
+
+// GeoIPLookup 根据 IP 查询所属国家
+type GeoIPLookup interface {
+	// LookupCountry 返回 IP 所属国家的 ISO 3166-1 alpha-2 代码
+	LookupCountry(ip string) (string, error)
+}
+
+// GeoRestrictionLoader 查询问卷允许访问的国家列表，为空表示不限制
+type GeoRestrictionLoader func(ctx context.Context, questionnaireCode string) ([]string, error)
+
+// GeoRestrictionGuard 问卷地域限制检查器
+// 未配置 IP 归属地数据库时无法确定请求所属国家，设置了地域限制的问卷一律拒绝访问
+type GeoRestrictionGuard struct {
+	geoIPDB      GeoIPLookup
+	countries    *boundedCache[string]
+	restrictions *boundedCache[[]string]
+}
+
+// NewGeoRestrictionGuard 创建问卷地域限制检查器，geoIPDB 可以为空
+func NewGeoRestrictionGuard(geoIPDB GeoIPLookup) *GeoRestrictionGuard {
+	return &GeoRestrictionGuard{
+		geoIPDB:      geoIPDB,
+		countries:    newBoundedCache[string](geoLookupCacheTTL, geoLookupCacheSize),
+		restrictions: newBoundedCache[[]string](geoRestrictionCacheTTL, geoRestrictionCacheSize),
+	}
+}
+
+// Allow 判断 IP 是否允许访问限定在 allowed 国家内的问卷，allowed 为空时不限制
+// IP 所属国家无法确定时不允许访问
+func (g *GeoRestrictionGuard) Allow(ip string, allowed []string) bool {
+	if len(allowed) == 0 {
+		return true
+	}
+
+	country := g.lookupCountry(ip)
+	if country == "" {
+		return false
+	}
+	for _, a := range allowed {
+		if strings.EqualFold(a, country) {
+			return true
+		}
+	}
+	return false
+}
+
+// Middleware 按路由参数 code 指向问卷的地域限制校验请求，不允许访问时返回 451
+// 问卷的地域限制按 code 缓存，避免每次请求都查询问卷；查询失败时不做处理，由后续处理器返回错误
+func (g *GeoRestrictionGuard) Middleware(loader GeoRestrictionLoader) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		questionnaireCode := c.Param("code")
+		allowed, ok := g.restrictions.Get(questionnaireCode)
+		if !ok {
+			var err error
+			allowed, err = loader(c.Request.Context(), questionnaireCode)
+			if err != nil {
+				c.Next()
+
+				return
+			}
+			g.restrictions.Set(questionnaireCode, allowed)
+		}
+
+		if !g.Allow(c.ClientIP(), allowed) {
+			AbortGeoRestricted(c)
+
+			return
+		}
+		c.Next()
+	}
+}
+
+// AbortGeoRestricted 以 451 中止请求，表示问卷在请求所属地区不可用
+func AbortGeoRestricted(c *gin.Context) {
+	envelope.AbortWithStatus(c, http.StatusUnavailableForLegalReasons,
+		errors.WithCode(code.ErrQuestionnaireGeoRestricted, "questionnaire is not available in your region"))
+}
+
+// lookupCountry 查询 IP 所属国家，查询失败或未配置 IP 归属地数据库时返回空
+func (g *GeoRestrictionGuard) lookupCountry(ip string) string {
+	if g.geoIPDB == nil {
+		return ""
+	}
+	if country, ok := g.countries.Get(ip); ok {
+		return country
+	}
+
+	country, err := g.geoIPDB.LookupCountry(ip)
+	if err != nil {
+		log.Warnf("Lookup country of %s failed: %v", ip, err)
+		return ""
+	}
+	g.countries.Set(ip, country)
+	return country
+}
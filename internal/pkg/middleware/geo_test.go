@@ -0,0 +1,119 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+// fakeGeoIPLookup 按固定映射返回国家，calls 记录查询次数
+type fakeGeoIPLookup struct {
+	countries map[string]string
+	calls     int
+}
+
+func (l *fakeGeoIPLookup) LookupCountry(ip string) (string, error) {
+	l.calls++
+	return l.countries[ip], nil
+}
+
+// newGeoTestEngine 创建挂载了地域限制中间件的测试路由
+func newGeoTestEngine(guard *GeoRestrictionGuard, loader GeoRestrictionLoader) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.GET("/questionnaires/:code", guard.Middleware(loader), func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+	return engine
+}
+
+// serveGeoRequest 以 ip 为来源请求问卷，返回响应状态码
+func serveGeoRequest(engine *gin.Engine, ip string) int {
+	req := httptest.NewRequest(http.MethodGet, "/questionnaires/phq9", nil)
+	req.RemoteAddr = ip + ":12345"
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestGeoRestrictionGuard_Middleware(t *testing.T) {
+	tests := []struct {
+		name        string
+		restriction []string
+		ip          string
+		wantStatus  int
+	}{
+		{name: "no restriction", restriction: nil, ip: "203.0.113.1", wantStatus: http.StatusOK},
+		{name: "allowed country", restriction: []string{"CN", "SG"}, ip: "203.0.113.1", wantStatus: http.StatusOK},
+		{name: "lower case restriction", restriction: []string{"cn"}, ip: "203.0.113.1", wantStatus: http.StatusOK},
+		{name: "other country", restriction: []string{"CN"}, ip: "198.51.100.1", wantStatus: http.StatusUnavailableForLegalReasons},
+		{name: "unknown country", restriction: []string{"CN"}, ip: "192.0.2.1", wantStatus: http.StatusUnavailableForLegalReasons},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lookup := &fakeGeoIPLookup{countries: map[string]string{"203.0.113.1": "CN", "198.51.100.1": "US"}}
+			engine := newGeoTestEngine(NewGeoRestrictionGuard(lookup), func(ctx context.Context, code string) ([]string, error) {
+				return tt.restriction, nil
+			})
+
+			if status := serveGeoRequest(engine, tt.ip); status != tt.wantStatus {
+				t.Errorf("status = %d, want %d", status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestGeoRestrictionGuard_WithoutGeoIPDatabaseFailsClosed(t *testing.T) {
+	guard := NewGeoRestrictionGuard(nil)
+
+	if !guard.Allow("203.0.113.1", nil) {
+		t.Error("questionnaire without restriction should be allowed")
+	}
+	if guard.Allow("203.0.113.1", []string{"CN"}) {
+		t.Error("restricted questionnaire should be rejected when the country cannot be determined")
+	}
+}
+
+func TestGeoRestrictionGuard_CachesLookups(t *testing.T) {
+	lookup := &fakeGeoIPLookup{countries: map[string]string{"203.0.113.1": "CN"}}
+	loads := 0
+	engine := newGeoTestEngine(NewGeoRestrictionGuard(lookup), func(ctx context.Context, code string) ([]string, error) {
+		loads++
+		return []string{"CN"}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		if status := serveGeoRequest(engine, "203.0.113.1"); status != http.StatusOK {
+			t.Fatalf("status = %d, want %d", status, http.StatusOK)
+		}
+	}
+
+	if lookup.calls != 1 {
+		t.Errorf("LookupCountry called %d times, want 1", lookup.calls)
+	}
+	if loads != 1 {
+		t.Errorf("restriction loaded %d times, want 1", loads)
+	}
+}
+
+func TestBoundedCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	cache := newBoundedCache[string](geoLookupCacheTTL, 2)
+	cache.Set("a", "1")
+	cache.Set("b", "2")
+	cache.Get("a")
+	cache.Set("c", "3")
+
+	if cache.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", cache.Len())
+	}
+	if _, ok := cache.Get("b"); ok {
+		t.Error("least recently used entry b should be evicted")
+	}
+	if _, ok := cache.Get("a"); !ok {
+		t.Error("recently used entry a should be kept")
+	}
+}
@@ -0,0 +1,138 @@
+package middleware
+
+import (
+	"context"
+	"crypto/subtle"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// GRPCAPIKeyKey 携带 API Key 的 gRPC metadata 键
+	GRPCAPIKeyKey = "x-api-key"
+	// GRPCAuthorizationKey 携带 Bearer 令牌的 gRPC metadata 键
+	GRPCAuthorizationKey = "authorization"
+
+	// GRPCAPIKeyIdentity 通过 API Key 认证的调用方身份
+	GRPCAPIKeyIdentity = "api-key"
+)
+
+// GRPCTokenValidator 校验 Bearer 令牌，返回令牌对应的用户名
+type GRPCTokenValidator func(ctx context.Context, token string) (string, error)
+
+// GRPCAuthConfig gRPC 认证配置
+type GRPCAuthConfig struct {
+	// APIKeys 允许访问的 API Key
+	APIKeys []string
+	// ValidateToken 校验 Bearer 令牌，为空时不接受令牌认证
+	ValidateToken GRPCTokenValidator
+	// PublicMethods 无需认证的方法全名，以 /* 结尾时表示服务下的所有方法，如 /grpc.health.v1.Health/*
+	PublicMethods []string
+}
+
+// isPublic 判断方法是否无需认证
+func (c GRPCAuthConfig) isPublic(fullMethod string) bool {
+	for _, method := range c.PublicMethods {
+		if prefix, ok := strings.CutSuffix(method, "*"); ok {
+			if strings.HasPrefix(fullMethod, prefix) {
+				return true
+			}
+		} else if method == fullMethod {
+			return true
+		}
+	}
+	return false
+}
+
+// validAPIKey 判断 API Key 是否有效，使用常量时间比较避免时序攻击
+func (c GRPCAuthConfig) validAPIKey(key string) bool {
+	valid := false
+	for _, apiKey := range c.APIKeys {
+		if apiKey != "" && subtle.ConstantTimeCompare([]byte(apiKey), []byte(key)) == 1 {
+			valid = true
+		}
+	}
+	return valid
+}
+
+// grpcIdentityKey 上下文中调用方身份的键
+type grpcIdentityKey struct{}
+
+// GRPCIdentityFrom 获取认证通过的调用方身份：令牌认证为用户名，API Key 认证为 GRPCAPIKeyIdentity
+func GRPCIdentityFrom(ctx context.Context) string {
+	identity, _ := ctx.Value(grpcIdentityKey{}).(string)
+	return identity
+}
+
+// GRPCAuthInterceptor gRPC 一元服务端认证拦截器
+// 从 metadata 读取 x-api-key 或 authorization: Bearer <token>，两者都无效时返回 codes.Unauthenticated；
+// PublicMethods 中的方法不校验
+func GRPCAuthInterceptor(config GRPCAuthConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if config.isPublic(info.FullMethod) {
+			return handler(ctx, req)
+		}
+
+		identity, err := authenticateGRPC(ctx, config)
+		if err != nil {
+			return nil, err
+		}
+		return handler(context.WithValue(ctx, grpcIdentityKey{}, identity), req)
+	}
+}
+
+// authenticateGRPC 校验 metadata 中的凭证，返回调用方身份
+func authenticateGRPC(ctx context.Context, config GRPCAuthConfig) (string, error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+
+	if keys := md.Get(GRPCAPIKeyKey); len(keys) > 0 {
+		if !config.validAPIKey(keys[0]) {
+			return "", status.Error(codes.Unauthenticated, "invalid api key")
+		}
+		return GRPCAPIKeyIdentity, nil
+	}
+
+	if values := md.Get(GRPCAuthorizationKey); len(values) > 0 && config.ValidateToken != nil {
+		scheme, token, ok := strings.Cut(values[0], " ")
+		if !ok || !strings.EqualFold(scheme, "Bearer") || token == "" {
+			return "", status.Error(codes.Unauthenticated, "authorization must be a bearer token")
+		}
+		username, err := config.ValidateToken(ctx, token)
+		if err != nil {
+			return "", status.Error(codes.Unauthenticated, "invalid token")
+		}
+		return username, nil
+	}
+
+	return "", status.Error(codes.Unauthenticated, "missing credentials")
+}
+
+// UnaryClientAPIKeyInterceptor gRPC 一元客户端 API Key 拦截器
+// 将 apiKey 写入发出请求的 x-api-key metadata，apiKey 为空时不处理
+func UnaryClientAPIKeyInterceptor(apiKey string) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingAPIKey(ctx, apiKey), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientAPIKeyInterceptor gRPC 流式客户端 API Key 拦截器
+func StreamClientAPIKeyInterceptor(apiKey string) grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingAPIKey(ctx, apiKey), desc, cc, method, opts...)
+	}
+}
+
+// outgoingAPIKey 将 API Key 追加到发出的 metadata，metadata 中已有时不覆盖
+func outgoingAPIKey(ctx context.Context, apiKey string) context.Context {
+	if apiKey == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(GRPCAPIKeyKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, GRPCAPIKeyKey, apiKey)
+}
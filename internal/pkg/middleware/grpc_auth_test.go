@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// callWithMetadata 以 kv 为传入 metadata 调用拦截器，返回处理器看到的调用方身份
+func callWithMetadata(interceptor grpc.UnaryServerInterceptor, method string, kv ...string) (string, error) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(kv...))
+	resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return GRPCIdentityFrom(ctx), nil
+	})
+	identity, _ := resp.(string)
+	return identity, err
+}
+
+func TestGRPCAuthInterceptor(t *testing.T) {
+	const (
+		protected = "/medical_scale.MedicalScaleService/GetMedicalScaleByCode"
+		health    = "/grpc.health.v1.Health/Check"
+		public    = "/medical_scale.MedicalScaleService/ListMedicalScales"
+	)
+	interceptor := GRPCAuthInterceptor(GRPCAuthConfig{
+		APIKeys: []string{"secret-key"},
+		ValidateToken: func(ctx context.Context, token string) (string, error) {
+			if token != "good-token" {
+				return "", errors.New("bad token")
+			}
+			return "alice", nil
+		},
+		PublicMethods: []string{"/grpc.health.v1.Health/*", public},
+	})
+
+	tests := []struct {
+		name         string
+		method       string
+		kv           []string
+		wantCode     codes.Code
+		wantIdentity string
+	}{
+		{"api key", protected, []string{GRPCAPIKeyKey, "secret-key"}, codes.OK, GRPCAPIKeyIdentity},
+		{"bearer token", protected, []string{GRPCAuthorizationKey, "Bearer good-token"}, codes.OK, "alice"},
+		{"missing credentials", protected, nil, codes.Unauthenticated, ""},
+		{"wrong api key", protected, []string{GRPCAPIKeyKey, "other"}, codes.Unauthenticated, ""},
+		{"invalid token", protected, []string{GRPCAuthorizationKey, "Bearer bad-token"}, codes.Unauthenticated, ""},
+		{"not a bearer token", protected, []string{GRPCAuthorizationKey, "Basic Zm9vOmJhcg=="}, codes.Unauthenticated, ""},
+		{"public method", public, nil, codes.OK, ""},
+		{"public service wildcard", health, nil, codes.OK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			identity, err := callWithMetadata(interceptor, tt.method, tt.kv...)
+			if code := status.Code(err); code != tt.wantCode {
+				t.Fatalf("code = %s, want %s (err = %v)", code, tt.wantCode, err)
+			}
+			if identity != tt.wantIdentity {
+				t.Errorf("identity = %q, want %q", identity, tt.wantIdentity)
+			}
+		})
+	}
+}
+
+func TestUnaryClientAPIKeyInterceptor(t *testing.T) {
+	// sentKey 以 ctx 调用客户端拦截器，返回发出请求的 x-api-key
+	sentKey := func(interceptor grpc.UnaryClientInterceptor, ctx context.Context) []string {
+		var got []string
+		_ = interceptor(ctx, "/medical_scale.MedicalScaleService/GetMedicalScaleByCode", nil, nil, nil,
+			func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				md, _ := metadata.FromOutgoingContext(ctx)
+				got = md.Get(GRPCAPIKeyKey)
+				return nil
+			})
+		return got
+	}
+
+	if got := sentKey(UnaryClientAPIKeyInterceptor("client-api-key-123"), context.Background()); len(got) != 1 || got[0] != "client-api-key-123" {
+		t.Errorf("x-api-key = %v, want [client-api-key-123]", got)
+	}
+	if got := sentKey(UnaryClientAPIKeyInterceptor(""), context.Background()); len(got) != 0 {
+		t.Errorf("x-api-key = %v, want none for an empty key", got)
+	}
+	ctx := metadata.AppendToOutgoingContext(context.Background(), GRPCAPIKeyKey, "per-call-key")
+	if got := sentKey(UnaryClientAPIKeyInterceptor("client-api-key-123"), ctx); len(got) != 1 || got[0] != "per-call-key" {
+		t.Errorf("x-api-key = %v, want the per-call key kept", got)
+	}
+}
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// callFrom 以 ip 为客户端地址调用拦截器
+func callFrom(interceptor grpc.UnaryServerInterceptor, method, ip string) error {
+	ctx := peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP(ip), Port: 50000},
+	})
+	_, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+		return "ok", nil
+	})
+	return err
+}
+
+func TestGRPCRateLimitInterceptor(t *testing.T) {
+	const (
+		limited   = "/test.Service/List"
+		unlimited = "/test.Service/Get"
+	)
+	interceptor := GRPCRateLimitInterceptor(NewMemoryRateLimitStore(), map[string]RateLimitConfig{
+		limited: {Rate: 0.001, Burst: 2},
+	})
+
+	for i := 0; i < 2; i++ {
+		if err := callFrom(interceptor, limited, "10.0.0.1"); err != nil {
+			t.Fatalf("call %d error = %v", i+1, err)
+		}
+	}
+	if err := callFrom(interceptor, limited, "10.0.0.1"); status.Code(err) != codes.ResourceExhausted {
+		t.Errorf("call over limit error = %v, want ResourceExhausted", err)
+	}
+
+	// 其他客户端IP和其他方法使用独立的令牌桶
+	if err := callFrom(interceptor, limited, "10.0.0.2"); err != nil {
+		t.Errorf("call from another ip error = %v", err)
+	}
+	for i := 0; i < DefaultGRPCRateLimit.Burst; i++ {
+		if err := callFrom(interceptor, unlimited, "10.0.0.1"); err != nil {
+			t.Fatalf("unlisted method call %d error = %v", i+1, err)
+		}
+	}
+}
+
+func TestMemoryRateLimitStore_BoundsLimiters(t *testing.T) {
+	store := newMemoryRateLimitStore(time.Minute, 2)
+	config := RateLimitConfig{Rate: 1, Burst: 1}
+	ctx := context.Background()
+
+	// key-a 的令牌已用完
+	if ok, _ := store.Allow(ctx, "key-a", config); !ok {
+		t.Fatal("first Allow(key-a) = false, want true")
+	}
+	if ok, _ := store.Allow(ctx, "key-a", config); ok {
+		t.Fatal("second Allow(key-a) = true, want false")
+	}
+
+	// 超出容量后淘汰最久未访问的令牌桶
+	store.Allow(ctx, "key-b", config)
+	store.Allow(ctx, "key-c", config)
+	if got := store.limiters.Len(); got != 2 {
+		t.Errorf("limiters = %d, want 2", got)
+	}
+	if ok, _ := store.Allow(ctx, "key-a", config); !ok {
+		t.Error("Allow(key-a) after eviction = false, want a fresh bucket")
+	}
+}
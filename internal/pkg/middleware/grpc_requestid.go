@@ -0,0 +1,38 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// GRPCRequestIDKey 在 gRPC metadata 中传递请求 ID 的键
+const GRPCRequestIDKey = "x-request-id"
+
+// UnaryClientRequestIDInterceptor gRPC 一元客户端请求 ID 拦截器
+// 将上下文中的请求 ID 写入 metadata，下游服务据此关联同一请求的日志
+func UnaryClientRequestIDInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		return invoker(outgoingRequestID(ctx), method, req, reply, cc, opts...)
+	}
+}
+
+// StreamClientRequestIDInterceptor gRPC 流式客户端请求 ID 拦截器
+func StreamClientRequestIDInterceptor() grpc.StreamClientInterceptor {
+	return func(ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string, streamer grpc.Streamer, opts ...grpc.CallOption) (grpc.ClientStream, error) {
+		return streamer(outgoingRequestID(ctx), desc, cc, method, opts...)
+	}
+}
+
+// outgoingRequestID 将上下文中的请求 ID 追加到发出的 metadata，metadata 中已有时不覆盖
+func outgoingRequestID(ctx context.Context) context.Context {
+	requestID := RequestIDFrom(ctx)
+	if requestID == "" {
+		return ctx
+	}
+	if md, ok := metadata.FromOutgoingContext(ctx); ok && len(md.Get(GRPCRequestIDKey)) > 0 {
+		return ctx
+	}
+	return metadata.AppendToOutgoingContext(ctx, GRPCRequestIDKey, requestID)
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// GRPCValidationInterceptor gRPC 一元服务端请求校验拦截器
+// required 为必填字段的全名，如 "medical_scale.GetMedicalScaleByCodeRequest.code"：
+// 字符串去除首尾空白后不能为空，数值不能为 0，列表和映射不能为空，消息必须设置。
+// 请求缺少必填字段时返回 codes.InvalidArgument 并列出全部缺少的字段，不调用处理器。
+// 字段全名在创建时从已注册的 proto 描述中解析，不存在时 panic
+func GRPCValidationInterceptor(required ...protoreflect.FullName) grpc.UnaryServerInterceptor {
+	fields := make(map[protoreflect.FullName][]protoreflect.FieldDescriptor)
+	for _, name := range required {
+		desc, err := protoregistry.GlobalFiles.FindDescriptorByName(name)
+		if err != nil {
+			panic(fmt.Sprintf("grpc validation: unknown field %s: %v", name, err))
+		}
+		fd, ok := desc.(protoreflect.FieldDescriptor)
+		if !ok {
+			panic(fmt.Sprintf("grpc validation: %s is not a field", name))
+		}
+		message := fd.ContainingMessage().FullName()
+		fields[message] = append(fields[message], fd)
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		msg, ok := req.(proto.Message)
+		if !ok {
+			return handler(ctx, req)
+		}
+
+		m := msg.ProtoReflect()
+		var missing []string
+		for _, fd := range fields[m.Descriptor().FullName()] {
+			if isEmptyField(m, fd) {
+				missing = append(missing, string(fd.Name()))
+			}
+		}
+		if len(missing) > 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid %s: %s must not be empty",
+				m.Descriptor().FullName(), strings.Join(missing, ", "))
+		}
+
+		return handler(ctx, req)
+	}
+}
+
+// isEmptyField 判断必填字段是否为空，字符串只含空白时视为空
+func isEmptyField(m protoreflect.Message, fd protoreflect.FieldDescriptor) bool {
+	if !m.Has(fd) {
+		return true
+	}
+	if fd.Kind() == protoreflect.StringKind && !fd.IsList() && !fd.IsMap() {
+		return strings.TrimSpace(m.Get(fd).String()) == ""
+	}
+	return false
+}
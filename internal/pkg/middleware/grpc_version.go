@@ -0,0 +1,136 @@
+package middleware
+
+import (
+	"context"
+	"strings"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// APIVersionHeader gRPC 元数据中携带客户端 API 版本的键
+const APIVersionHeader = "x-api-version"
+
+// ResponseTransformer 将响应转换为旧版本客户端可识别的格式
+type ResponseTransformer func(resp interface{}) interface{}
+
+// VersionNegotiationConfig gRPC API 版本协商配置
+type VersionNegotiationConfig struct {
+	// ServicePrefix 需要版本协商的方法前缀，如 "/medical_scale.MedicalScaleService/"
+	ServicePrefix string
+	// SupportedVersions 支持的版本，从旧到新排列，最后一个为当前版本
+	SupportedVersions []string
+	// Transformers 以版本为键的响应转换规则，当前版本无需配置
+	Transformers map[string]ResponseTransformer
+}
+
+// currentVersion 当前版本，未携带版本的请求按当前版本处理
+func (c VersionNegotiationConfig) currentVersion() string {
+	if len(c.SupportedVersions) == 0 {
+		return ""
+	}
+	return c.SupportedVersions[len(c.SupportedVersions)-1]
+}
+
+// supports 判断是否支持该版本
+func (c VersionNegotiationConfig) supports(version string) bool {
+	for _, supported := range c.SupportedVersions {
+		if supported == version {
+			return true
+		}
+	}
+	return false
+}
+
+// VersionNegotiationInterceptor gRPC 一元服务端 API 版本协商拦截器
+// 从元数据 x-api-version 读取客户端版本，不支持的版本返回 codes.Unimplemented；
+// 旧版本请求记录弃用警告，并按 Transformers 中的规则转换响应
+func VersionNegotiationInterceptor(config VersionNegotiationConfig) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !strings.HasPrefix(info.FullMethod, config.ServicePrefix) {
+			return handler(ctx, req)
+		}
+
+		version := config.currentVersion()
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if values := md.Get(APIVersionHeader); len(values) > 0 && values[0] != "" {
+				version = values[0]
+			}
+		}
+		if !config.supports(version) {
+			return nil, status.Errorf(codes.Unimplemented, "api version %q is not supported, supported versions: %s",
+				version, strings.Join(config.SupportedVersions, ", "))
+		}
+		if version != config.currentVersion() {
+			log.Warnf("Deprecated gRPC API version - Method: %s, Version: %s, Current: %s",
+				info.FullMethod, version, config.currentVersion())
+		}
+
+		resp, err := handler(ctx, req)
+		if err != nil {
+			return resp, err
+		}
+		if transform, ok := config.Transformers[version]; ok {
+			resp = transform(resp)
+		}
+		return resp, nil
+	}
+}
+
+// StripProtoFields 创建去除指定字段的响应转换规则，用于向旧版本客户端隐藏新增字段
+// 字段以全名指定，如 "medical_scale.ListMedicalScalesResponse.next_page_token"，嵌套消息中的字段同样会被去除
+func StripProtoFields(fields ...protoreflect.FullName) ResponseTransformer {
+	strip := make(map[protoreflect.FullName]bool, len(fields))
+	for _, field := range fields {
+		strip[field] = true
+	}
+
+	return func(resp interface{}) interface{} {
+		msg, ok := resp.(proto.Message)
+		if !ok {
+			return resp
+		}
+
+		// 复制响应，避免修改处理器可能缓存的消息
+		cloned := proto.Clone(msg)
+		stripFields(cloned.ProtoReflect(), strip)
+		return cloned
+	}
+}
+
+// stripFields 递归清除消息中需要去除的字段
+func stripFields(msg protoreflect.Message, strip map[protoreflect.FullName]bool) {
+	msg.Range(func(fd protoreflect.FieldDescriptor, value protoreflect.Value) bool {
+		if strip[fd.FullName()] {
+			msg.Clear(fd)
+			return true
+		}
+		if fd.Message() == nil {
+			return true
+		}
+
+		switch {
+		case fd.IsList():
+			list := value.List()
+			for i := 0; i < list.Len(); i++ {
+				stripFields(list.Get(i).Message(), strip)
+			}
+		case fd.IsMap():
+			if fd.MapValue().Message() != nil {
+				value.Map().Range(func(_ protoreflect.MapKey, v protoreflect.Value) bool {
+					stripFields(v.Message(), strip)
+					return true
+				})
+			}
+		default:
+			stripFields(value.Message(), strip)
+		}
+		return true
+	})
+}
@@ -0,0 +1,76 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/typepb"
+)
+
+func TestVersionNegotiationInterceptor(t *testing.T) {
+	interceptor := VersionNegotiationInterceptor(VersionNegotiationConfig{
+		ServicePrefix:     "/test.Service/",
+		SupportedVersions: []string{"v1", "v2"},
+		Transformers: map[string]ResponseTransformer{
+			"v1": StripProtoFields("google.protobuf.Type.edition", "google.protobuf.Field.json_name"),
+		},
+	})
+
+	newResponse := func() *typepb.Type {
+		return &typepb.Type{
+			Name:    "Scale",
+			Edition: "2023",
+			Fields:  []*typepb.Field{{Name: "code", JsonName: "code"}},
+		}
+	}
+	call := func(method, version string) (*typepb.Type, error) {
+		ctx := context.Background()
+		if version != "" {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(APIVersionHeader, version))
+		}
+		resp, err := interceptor(ctx, nil, &grpc.UnaryServerInfo{FullMethod: method}, func(ctx context.Context, req interface{}) (interface{}, error) {
+			return newResponse(), nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		return resp.(*typepb.Type), nil
+	}
+
+	// 当前版本和未携带版本的请求返回完整响应
+	for _, version := range []string{"v2", ""} {
+		resp, err := call("/test.Service/Get", version)
+		if err != nil {
+			t.Fatalf("call(%q) error = %v", version, err)
+		}
+		if resp.GetEdition() != "2023" || resp.GetFields()[0].GetJsonName() != "code" {
+			t.Errorf("call(%q) response = %v, want all fields", version, resp)
+		}
+	}
+
+	// v1 客户端的响应去除新增字段，包括嵌套消息中的字段
+	resp, err := call("/test.Service/Get", "v1")
+	if err != nil {
+		t.Fatalf("call(v1) error = %v", err)
+	}
+	if resp.GetEdition() != "" || resp.GetFields()[0].GetJsonName() != "" {
+		t.Errorf("call(v1) response = %v, want new fields stripped", resp)
+	}
+	if resp.GetName() != "Scale" || resp.GetFields()[0].GetName() != "code" {
+		t.Errorf("call(v1) response = %v, want old fields kept", resp)
+	}
+
+	// 不支持的版本
+	if _, err := call("/test.Service/Get", "v3"); status.Code(err) != codes.Unimplemented {
+		t.Errorf("call(v3) error = %v, want Unimplemented", err)
+	}
+
+	// 其他服务不做版本协商
+	if _, err := call("/other.Service/Get", "v3"); err != nil {
+		t.Errorf("call other service error = %v", err)
+	}
+}
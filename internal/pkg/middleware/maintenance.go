@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"net/http"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// DefaultMaintenanceRetryAfter 维护模式下建议客户端重试的默认间隔
+const DefaultMaintenanceRetryAfter = 5 * time.Minute
+
+// MaintenanceMode 维护模式开关，可在运行时切换
+// 开关状态仅在当前实例内生效
+type MaintenanceMode struct {
+	enabled    atomic.Bool
+	retryAfter time.Duration
+}
+
+// NewMaintenanceMode 创建维护模式开关，retryAfter 不大于 0 时使用默认值
+func NewMaintenanceMode(enabled bool, retryAfter time.Duration) *MaintenanceMode {
+	if retryAfter <= 0 {
+		retryAfter = DefaultMaintenanceRetryAfter
+	}
+
+	m := &MaintenanceMode{retryAfter: retryAfter}
+	m.enabled.Store(enabled)
+	return m
+}
+
+// Enabled 是否处于维护模式
+func (m *MaintenanceMode) Enabled() bool {
+	return m.enabled.Load()
+}
+
+// SetEnabled 开启或关闭维护模式
+func (m *MaintenanceMode) SetEnabled(enabled bool) {
+	m.enabled.Store(enabled)
+}
+
+// RetryAfter 维护模式下建议客户端重试的间隔
+func (m *MaintenanceMode) RetryAfter() time.Duration {
+	return m.retryAfter
+}
+
+// Maintenance 维护模式中间件
+// 开启维护模式时，除 exemptPaths 外的修改类请求返回 503 并带上 Retry-After 头，读请求不受影响
+func Maintenance(mode *MaintenanceMode, exemptPaths ...string) gin.HandlerFunc {
+	exempt := make(map[string]struct{}, len(exemptPaths))
+	for _, p := range exemptPaths {
+		exempt[p] = struct{}{}
+	}
+
+	return func(c *gin.Context) {
+		if !mode.Enabled() || isReadMethod(c.Request.Method) {
+			c.Next()
+
+			return
+		}
+		if _, ok := exempt[c.Request.URL.Path]; ok {
+			c.Next()
+
+			return
+		}
+
+		c.Header("Retry-After", strconv.Itoa(int(mode.RetryAfter().Seconds())))
+		envelope.AbortWithStatus(c, http.StatusServiceUnavailable,
+			errors.WithCode(code.ErrMaintenance, "service is under maintenance, please retry later"))
+	}
+}
+
+// isReadMethod 判断是否为不修改数据的请求方法
+func isReadMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestMaintenance(t *testing.T) {
+	mode := NewMaintenanceMode(true, 2*time.Minute)
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(Maintenance(mode, "/admin/maintenance"))
+	ok := func(c *gin.Context) { c.Status(http.StatusOK) }
+	engine.GET("/questionnaires", ok)
+	engine.POST("/questionnaires", ok)
+	engine.PUT("/questionnaires/:code", ok)
+	engine.DELETE("/questionnaires/:code", ok)
+	engine.PUT("/admin/maintenance", ok)
+
+	tests := []struct {
+		name       string
+		method     string
+		path       string
+		wantStatus int
+	}{
+		{name: "read", method: http.MethodGet, path: "/questionnaires", wantStatus: http.StatusOK},
+		{name: "create", method: http.MethodPost, path: "/questionnaires", wantStatus: http.StatusServiceUnavailable},
+		{name: "update", method: http.MethodPut, path: "/questionnaires/phq9", wantStatus: http.StatusServiceUnavailable},
+		{name: "delete", method: http.MethodDelete, path: "/questionnaires/phq9", wantStatus: http.StatusServiceUnavailable},
+		{name: "exempt path", method: http.MethodPut, path: "/admin/maintenance", wantStatus: http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if tt.wantStatus == http.StatusServiceUnavailable && rec.Header().Get("Retry-After") != "120" {
+				t.Errorf("Retry-After = %q, want 120", rec.Header().Get("Retry-After"))
+			}
+		})
+	}
+
+	// 关闭维护模式后写请求恢复
+	mode.SetEnabled(false)
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/questionnaires", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("status after disabling maintenance = %d, want %d", rec.Code, http.StatusOK)
+	}
+}
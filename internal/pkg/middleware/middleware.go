@@ -49,7 +49,7 @@ func Secure(c *gin.Context) {
 // defaultMiddlewares 返回默认的中间件
 func defaultMiddlewares() map[string]gin.HandlerFunc {
 	return map[string]gin.HandlerFunc{
-		"recovery":        gin.Recovery(),
+		"recovery":        Recovery(),
 		"secure":          Secure,
 		"options":         Options,
 		"nocache":         NoCache,
@@ -58,5 +58,6 @@ func defaultMiddlewares() map[string]gin.HandlerFunc {
 		"logger":          Logger(),
 		"enhanced_logger": EnhancedLogger(), // 增强日志中间件
 		"dump":            gindump.Dump(),
+		"request_body":    RequestBodyLoggingMiddleware([]string{"password", "token", "refresh_token", "secret"}, 10*1024), // 请求体日志（DEBUG）
 	}
 }
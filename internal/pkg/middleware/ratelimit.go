@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+const (
+	// rateLimiterIdleTTL 令牌桶的闲置时间，超过该时间未被访问的令牌桶被淘汰
+	// 闲置足够久的令牌桶已经补满，淘汰后重新创建不影响限流效果
+	rateLimiterIdleTTL = 10 * time.Minute
+	// rateLimiterMaxEntries 保留的令牌桶数量上限，超出时淘汰最久未访问的令牌桶
+	rateLimiterMaxEntries = 100000
+)
+
+// RateLimitConfig 令牌桶限流配置
+type RateLimitConfig struct {
+	// Rate 每秒补充的令牌数
+	Rate float64
+	// Burst 令牌桶容量，小于 1 时取 Rate 向上取整
+	Burst int
+}
+
+// BurstSize 返回令牌桶容量
+func (c RateLimitConfig) BurstSize() int {
+	if c.Burst > 0 {
+		return c.Burst
+	}
+	if burst := int(math.Ceil(c.Rate)); burst > 0 {
+		return burst
+	}
+	return 1
+}
+
+// RateLimitStore 令牌桶存储，按 key 维护独立的令牌桶
+type RateLimitStore interface {
+	// Allow 从 key 对应的令牌桶中取出一个令牌，令牌不足时返回 false
+	Allow(ctx context.Context, key string, config RateLimitConfig) (bool, error)
+}
+
+// memoryRateLimitStore 进程内的令牌桶存储，多实例部署时各实例分别计数
+// 令牌桶保存在 boundedCache 中，闲置超过 rateLimiterIdleTTL 或超出容量上限时被淘汰
+type memoryRateLimitStore struct {
+	mu       sync.Mutex
+	limiters *boundedCache[*rate.Limiter]
+}
+
+// NewMemoryRateLimitStore 创建进程内的令牌桶存储
+func NewMemoryRateLimitStore() RateLimitStore {
+	return newMemoryRateLimitStore(rateLimiterIdleTTL, rateLimiterMaxEntries)
+}
+
+// newMemoryRateLimitStore 创建指定闲置时间和容量上限的令牌桶存储
+func newMemoryRateLimitStore(idleTTL time.Duration, maxEntries int) *memoryRateLimitStore {
+	return &memoryRateLimitStore{limiters: newBoundedCache[*rate.Limiter](idleTTL, maxEntries)}
+}
+
+// Allow 从 key 对应的令牌桶中取出一个令牌，每次访问都会重置令牌桶的闲置时间
+func (s *memoryRateLimitStore) Allow(ctx context.Context, key string, config RateLimitConfig) (bool, error) {
+	s.mu.Lock()
+	limiter, ok := s.limiters.Get(key)
+	if !ok {
+		limiter = rate.NewLimiter(rate.Limit(config.Rate), config.BurstSize())
+	}
+	s.limiters.Set(key, limiter)
+	s.mu.Unlock()
+
+	return limiter.Allow(), nil
+}
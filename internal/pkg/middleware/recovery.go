@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"fmt"
+	"runtime/debug"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/envelope"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// Recovery 是一个中间件函数，捕获处理器中的 panic，避免服务崩溃
+// 堆栈只写入日志（带请求ID），客户端收到标准响应结构的 500 错误，不会看到堆栈信息
+func Recovery() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		defer func() {
+			rec := recover()
+			if rec == nil {
+				return
+			}
+
+			requestID := GetRequestIDFromContext(c)
+			if requestID == "" {
+				requestID = c.Writer.Header().Get(XRequestIDKey)
+			}
+
+			log.Errorw("Panic recovered",
+				log.KeyRequestID, requestID,
+				"method", c.Request.Method,
+				"path", c.Request.URL.Path,
+				"panic", fmt.Sprint(rec),
+				"stack", string(debug.Stack()),
+			)
+
+			// 响应已开始写入时无法再修改状态码
+			if c.Writer.Written() {
+				c.Abort()
+				return
+			}
+
+			// panic 详情已写入日志，响应中只包含错误码对应的通用信息
+			envelope.AbortWithError(c, errors.WithCode(code.ErrInternalServerError, "panic recovered"))
+		}()
+
+		c.Next()
+	}
+}
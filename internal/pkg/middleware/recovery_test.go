@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+func TestRecovery(t *testing.T) {
+	logFile := filepath.Join(t.TempDir(), "recovery.log")
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{logFile}
+	opts.Format = "json"
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(RequestID(), Recovery())
+	engine.GET("/panic", func(c *gin.Context) {
+		panic("questionnaire not loaded")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/panic", nil)
+	req.Header.Set(XRequestIDKey, "req-panic")
+	rec := httptest.NewRecorder()
+	engine.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want %d", rec.Code, http.StatusInternalServerError)
+	}
+	if strings.Contains(rec.Body.String(), "goroutine") || strings.Contains(rec.Body.String(), "questionnaire not loaded") {
+		t.Errorf("response %s leaks panic details", rec.Body.String())
+	}
+
+	var body map[string]interface{}
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("decode response %q: %v", rec.Body.String(), err)
+	}
+	if int(body["code"].(float64)) != code.ErrInternalServerError || body["request_id"] != "req-panic" {
+		t.Errorf("response = %v, want ErrInternalServerError envelope with request_id", body)
+	}
+	for _, key := range []string{"message", "data"} {
+		if _, ok := body[key]; !ok {
+			t.Errorf("response %v has no %q field", body, key)
+		}
+	}
+
+	log.Flush()
+	logged, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	for _, want := range []string{"Panic recovered", "req-panic", "questionnaire not loaded", "runtime/debug.Stack"} {
+		if !strings.Contains(string(logged), want) {
+			t.Errorf("log does not contain %q:\n%s", want, logged)
+		}
+	}
+}
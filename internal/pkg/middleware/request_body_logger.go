@@ -0,0 +1,146 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// redactedValue 敏感字段脱敏后的值
+const redactedValue = "[REDACTED]"
+
+// RequestBodyLoggingMiddleware 在 DEBUG 级别记录请求体，用于排查线上问题
+// 请求体经 io.TeeReader 在处理器读取时同步捕获，处理器仍能完整读取；最多捕获 maxBodySize 字节，超出部分截断
+// Content-Type 为 application/json 时，键名与 sensitiveFields 匹配（不区分大小写）的值替换为 "[REDACTED]"；其他类型记录原始内容
+func RequestBodyLoggingMiddleware(sensitiveFields []string, maxBodySize int) gin.HandlerFunc {
+	sensitive := make(map[string]bool, len(sensitiveFields))
+	for _, field := range sensitiveFields {
+		sensitive[strings.ToLower(field)] = true
+	}
+	truncatedPattern := sensitiveValuePattern(sensitiveFields)
+
+	return func(c *gin.Context) {
+		if c.Request.Body == nil || c.Request.Body == http.NoBody {
+			c.Next()
+			return
+		}
+
+		captured := &limitedBuffer{limit: maxBodySize}
+		c.Request.Body = &teeReadCloser{
+			Reader: io.TeeReader(c.Request.Body, captured),
+			Closer: c.Request.Body,
+		}
+
+		c.Next()
+
+		if captured.total == 0 {
+			return
+		}
+		body := captured.buf.Bytes()
+		if isJSONContentType(c.ContentType()) {
+			body = redactJSONBody(body, captured.truncated(), sensitive, truncatedPattern)
+		}
+
+		log.Debugw("Request body",
+			log.KeyRequestID, GetRequestIDFromContext(c),
+			"method", c.Request.Method,
+			"path", c.Request.URL.Path,
+			"body", string(body),
+			"size", captured.total,
+			"truncated", captured.truncated(),
+		)
+	}
+}
+
+// teeReadCloser 读取时将请求体写入捕获缓冲，关闭时关闭原请求体
+type teeReadCloser struct {
+	io.Reader
+	io.Closer
+}
+
+// limitedBuffer 只保留前 limit 字节，同时统计写入的总字节数
+type limitedBuffer struct {
+	buf   bytes.Buffer
+	limit int
+	total int
+}
+
+// Write 写入数据，超出 limit 的部分丢弃，始终返回完整写入以免中断 TeeReader
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	b.total += len(p)
+	if remaining := b.limit - b.buf.Len(); remaining > 0 {
+		if len(p) > remaining {
+			b.buf.Write(p[:remaining])
+		} else {
+			b.buf.Write(p)
+		}
+	}
+	return len(p), nil
+}
+
+// truncated 判断捕获的内容是否被截断
+func (b *limitedBuffer) truncated() bool {
+	return b.total > b.buf.Len()
+}
+
+// isJSONContentType 判断是否为 JSON 请求
+func isJSONContentType(contentType string) bool {
+	return contentType == gin.MIMEJSON
+}
+
+// redactJSONBody 对 JSON 请求体中的敏感字段脱敏
+// 完整的 JSON 解析后逐层替换；截断或无法解析的内容按正则替换敏感字段的字符串和数字值
+func redactJSONBody(body []byte, truncated bool, sensitive map[string]bool, pattern *regexp.Regexp) []byte {
+	if !truncated {
+		var value interface{}
+		decoder := json.NewDecoder(bytes.NewReader(body))
+		decoder.UseNumber()
+		if err := decoder.Decode(&value); err == nil {
+			if redacted, err := json.Marshal(redactJSONValue(value, sensitive)); err == nil {
+				return redacted
+			}
+		}
+	}
+	if pattern == nil {
+		return body
+	}
+	return pattern.ReplaceAll(body, []byte(`${1}"`+redactedValue+`"`))
+}
+
+// redactJSONValue 递归替换对象中敏感字段的值
+func redactJSONValue(value interface{}, sensitive map[string]bool) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, item := range v {
+			if sensitive[strings.ToLower(key)] {
+				v[key] = redactedValue
+				continue
+			}
+			v[key] = redactJSONValue(item, sensitive)
+		}
+	case []interface{}:
+		for i, item := range v {
+			v[i] = redactJSONValue(item, sensitive)
+		}
+	}
+	return value
+}
+
+// sensitiveValuePattern 构造匹配敏感字段标量值的正则，值被截断时也能匹配
+func sensitiveValuePattern(fields []string) *regexp.Regexp {
+	if len(fields) == 0 {
+		return nil
+	}
+	quoted := make([]string, len(fields))
+	for i, field := range fields {
+		quoted[i] = regexp.QuoteMeta(field)
+	}
+	return regexp.MustCompile(`(?i)("(?:` + strings.Join(quoted, "|") + `)"\s*:\s*)(?:"(?:[^"\\]|\\.)*"?|[^,}\]\s]+)`)
+}
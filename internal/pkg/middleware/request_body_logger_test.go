@@ -0,0 +1,93 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/yshujie/questionnaire-scale/pkg/log"
+)
+
+// serveWithBodyLogging 以 DEBUG 级别记录日志处理请求，返回处理器读取到的请求体和日志内容
+func serveWithBodyLogging(t *testing.T, maxBodySize int, contentType, body string) (string, string) {
+	t.Helper()
+
+	logFile := filepath.Join(t.TempDir(), "request-body.log")
+	opts := log.NewOptions()
+	opts.OutputPaths = []string{logFile}
+	opts.Format = "json"
+	opts.Level = "debug"
+	log.Init(opts)
+	t.Cleanup(func() { log.Init(log.NewOptions()) })
+
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(RequestBodyLoggingMiddleware([]string{"password", "token"}, maxBodySize))
+	var received string
+	engine.POST("/api/v1/public/login", func(c *gin.Context) {
+		data, err := c.GetRawData()
+		if err != nil {
+			t.Errorf("read body: %v", err)
+		}
+		received = string(data)
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/public/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+
+	log.Flush()
+	logged, err := os.ReadFile(logFile)
+	if err != nil {
+		t.Fatalf("read log: %v", err)
+	}
+	return received, string(logged)
+}
+
+func TestRequestBodyLoggingMiddleware_RedactsLoginPassword(t *testing.T) {
+	body := `{"username":"zhangsan","password":"P@ssw0rd!"}`
+	received, logged := serveWithBodyLogging(t, 1024, "application/json; charset=utf-8", body)
+
+	if received != body {
+		t.Errorf("handler received %q, want %q", received, body)
+	}
+	if !strings.Contains(logged, "zhangsan") {
+		t.Errorf("log does not contain username:\n%s", logged)
+	}
+	if strings.Contains(logged, "P@ssw0rd!") {
+		t.Errorf("log leaks password:\n%s", logged)
+	}
+	if !strings.Contains(logged, redactedValue) {
+		t.Errorf("log does not contain %s:\n%s", redactedValue, logged)
+	}
+}
+
+func TestRequestBodyLoggingMiddleware_TruncatesLargeBody(t *testing.T) {
+	body := `{"token":"` + strings.Repeat("a", 64) + `","note":"` + strings.Repeat("b", 64) + `"}`
+	received, logged := serveWithBodyLogging(t, 32, "application/json", body)
+
+	if received != body {
+		t.Errorf("handler received %d bytes, want the full %d-byte body", len(received), len(body))
+	}
+	if strings.Contains(logged, "aaaa") || strings.Contains(logged, "bbbb") {
+		t.Errorf("log contains the redacted token or the truncated tail:\n%s", logged)
+	}
+	if !strings.Contains(logged, `"truncated":true`) {
+		t.Errorf("log does not mark the body as truncated:\n%s", logged)
+	}
+}
+
+func TestRequestBodyLoggingMiddleware_LogsRawNonJSONBody(t *testing.T) {
+	body := "username=zhangsan&password=P@ssw0rd!"
+	_, logged := serveWithBodyLogging(t, 1024, "application/x-www-form-urlencoded", body)
+
+	if !strings.Contains(logged, body) {
+		t.Errorf("log does not contain the raw body %q:\n%s", body, logged)
+	}
+}
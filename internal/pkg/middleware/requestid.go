@@ -1,12 +1,15 @@
 package middleware
 
 import (
+	"context"
 	"fmt"
 	"io"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	uuid "github.com/satori/go.uuid"
+
+	"github.com/yshujie/questionnaire-scale/pkg/log"
 )
 
 const (
@@ -15,6 +18,7 @@ const (
 )
 
 // 请求 ID 中间件，将 'X-Request-ID' 注入到每个请求的上下文和请求/响应头中
+// 请求 ID 同时写入请求的 context.Context，log.L(ctx) 输出的日志和发往下游的 gRPC 调用都会带上它
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		// 检查传入的请求头，如果存在则使用它
@@ -23,8 +27,10 @@ func RequestID() gin.HandlerFunc {
 		if rid == "" {
 			rid = uuid.Must(uuid.NewV4(), nil).String()
 			c.Request.Header.Set(XRequestIDKey, rid)
-			c.Set(XRequestIDKey, rid)
 		}
+		c.Set(XRequestIDKey, rid)
+		c.Set(log.KeyRequestID, rid)
+		c.Request = c.Request.WithContext(WithRequestID(c.Request.Context(), rid))
 
 		// 设置 XRequestIDKey 头
 		c.Writer.Header().Set(XRequestIDKey, rid)
@@ -32,6 +38,17 @@ func RequestID() gin.HandlerFunc {
 	}
 }
 
+// WithRequestID 返回携带请求 ID 的上下文，键与 log.L 读取的键一致
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, log.KeyRequestID, requestID)
+}
+
+// RequestIDFrom 从上下文中获取请求 ID，没有时返回空字符串
+func RequestIDFrom(ctx context.Context) string {
+	requestID, _ := ctx.Value(log.KeyRequestID).(string)
+	return requestID
+}
+
 // GetLoggerConfig return gin.LoggerConfig which will write the logs to specified io.Writer with given gin.LogFormatter.
 // By default gin.DefaultWriter = os.Stdout
 // reference: https://github.com/gin-gonic/gin#custom-log-format
@@ -0,0 +1,118 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestScopeGuard(t *testing.T) {
+	tests := []struct {
+		name       string
+		scope      interface{}
+		code       string
+		wantStatus int
+	}{
+		{name: "exact code", scope: []string{"phq9"}, code: "phq9", wantStatus: http.StatusOK},
+		{name: "glob matches phq9", scope: []string{"phq*"}, code: "phq9", wantStatus: http.StatusOK},
+		{name: "glob matches phq2", scope: []string{"phq*"}, code: "phq2", wantStatus: http.StatusOK},
+		{name: "wildcard", scope: []string{"*"}, code: "sds", wantStatus: http.StatusOK},
+		{name: "decoded jwt claims", scope: []interface{}{"gad*"}, code: "gad7", wantStatus: http.StatusOK},
+		{name: "out of scope", scope: []string{"phq*"}, code: "gad7", wantStatus: http.StatusForbidden},
+		{name: "no scope", scope: nil, code: "phq9", wantStatus: http.StatusForbidden},
+		{name: "role does not grant resource", scope: []string{"role:*"}, code: "role:x", wantStatus: http.StatusForbidden},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.PUT("/questionnaires/:code", func(c *gin.Context) {
+				if tt.scope != nil {
+					c.Set(ScopeKey, tt.scope)
+				}
+			}, ScopeGuard("code"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPut, "/questionnaires/"+tt.code, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRequireAdmin(t *testing.T) {
+	tests := []struct {
+		name       string
+		scope      interface{}
+		wantStatus int
+	}{
+		{name: "admin", scope: []string{"phq*", "*"}, wantStatus: http.StatusOK},
+		{name: "decoded jwt claims", scope: []interface{}{"*"}, wantStatus: http.StatusOK},
+		{name: "scoped user", scope: []string{"phq*"}, wantStatus: http.StatusForbidden},
+		{name: "no scope", scope: nil, wantStatus: http.StatusForbidden},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.GET("/admin/summary", func(c *gin.Context) {
+				if tt.scope != nil {
+					c.Set(ScopeKey, tt.scope)
+				}
+			}, RequireAdmin(), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/admin/summary", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestRoleGuard(t *testing.T) {
+	tests := []struct {
+		name       string
+		scope      interface{}
+		wantStatus int
+	}{
+		{name: "role granted", scope: []string{"phq*", "role:translation-admin"}, wantStatus: http.StatusOK},
+		{name: "decoded jwt claims", scope: []interface{}{"role:translation-admin"}, wantStatus: http.StatusOK},
+		{name: "admin", scope: []string{"*"}, wantStatus: http.StatusOK},
+		{name: "glob does not grant role", scope: []string{"r*"}, wantStatus: http.StatusForbidden},
+		{name: "other role", scope: []string{"role:translator"}, wantStatus: http.StatusForbidden},
+		{name: "no scope", scope: nil, wantStatus: http.StatusForbidden},
+	}
+
+	gin.SetMode(gin.TestMode)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			engine := gin.New()
+			engine.POST("/translations/approve", func(c *gin.Context) {
+				if tt.scope != nil {
+					c.Set(ScopeKey, tt.scope)
+				}
+			}, RoleGuard("translation-admin"), func(c *gin.Context) {
+				c.Status(http.StatusOK)
+			})
+
+			rec := httptest.NewRecorder()
+			engine.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/translations/approve", nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package options
+
+import (
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/spf13/pflag"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+)
+
+// AnswerSheetPurgeOptions defines options for purging soft-deleted answer sheets.
+type AnswerSheetPurgeOptions struct {
+	Enabled   bool          `json:"enabled"   mapstructure:"enabled"`
+	Schedule  string        `json:"schedule"  mapstructure:"schedule"`
+	Retention time.Duration `json:"retention" mapstructure:"retention"`
+}
+
+// NewAnswerSheetPurgeOptions create a `zero` value instance.
+// Purging is disabled by default because it permanently deletes data.
+func NewAnswerSheetPurgeOptions() *AnswerSheetPurgeOptions {
+	return &AnswerSheetPurgeOptions{
+		Enabled:   false,
+		Schedule:  "0 3 * * *",
+		Retention: 30 * 24 * time.Hour,
+	}
+}
+
+// Validate verifies flags passed to AnswerSheetPurgeOptions.
+func (o *AnswerSheetPurgeOptions) Validate() []error {
+	errs := []error{}
+	if !o.Enabled {
+		return errs
+	}
+
+	if _, err := cron.ParseStandard(o.Schedule); err != nil {
+		errs = append(errs, app.NewOptionsValidationError("answersheet-purge.schedule",
+			"is not a valid cron expression: %v", err))
+	}
+	if o.Retention <= 0 {
+		errs = append(errs, app.NewOptionsValidationError("answersheet-purge.retention",
+			"must be greater than 0, got %s", o.Retention))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to purging soft-deleted answer sheets to the specified FlagSet.
+func (o *AnswerSheetPurgeOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.BoolVar(&o.Enabled, "answersheet-purge.enabled", o.Enabled, ""+
+		"Permanently delete answer sheets that were soft-deleted longer than the retention period.")
+
+	fs.StringVar(&o.Schedule, "answersheet-purge.schedule", o.Schedule, ""+
+		"Cron expression (minute hour day month weekday) of the purge job.")
+
+	fs.DurationVar(&o.Retention, "answersheet-purge.retention", o.Retention, ""+
+		"How long soft-deleted answer sheets are kept before being purged.")
+}
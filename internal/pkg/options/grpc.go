@@ -3,23 +3,87 @@ package options
 import (
 	"fmt"
 	"net"
+	"strings"
+	"time"
 
 	"github.com/spf13/pflag"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+)
+
+// GRPC 消息大小限制（字节）
+const (
+	DefaultGRPCMaxMsgSize = 4 * 1024 * 1024  // 默认 4MB，与 grpc-go 的默认接收上限一致
+	MaxGRPCMsgSize        = 64 * 1024 * 1024 // 可配置的上限 64MB
 )
 
+// ValidateGRPCMsgSize 校验消息大小限制在 (0, MaxGRPCMsgSize] 范围内
+func ValidateGRPCMsgSize(field string, size int) error {
+	if size <= 0 || size > MaxGRPCMsgSize {
+		return app.NewOptionsValidationError(field, "%d must be between 1 and %d bytes, inclusive", size, MaxGRPCMsgSize)
+	}
+	return nil
+}
+
 // GRPCOptions GRPC 服务器配置选项
 type GRPCOptions struct {
-	BindAddress string `json:"bind_address" mapstructure:"bind-address"` // 绑定地址
-	BindPort    int    `json:"bind_port"    mapstructure:"bind-port"`    // 绑定端口
-	HealthzPort int    `json:"healthz_port" mapstructure:"healthz-port"` // 健康检查端口
+	BindAddress     string        `json:"bind_address" mapstructure:"bind-address"`                       // 绑定地址
+	BindPort        int           `json:"bind_port"    mapstructure:"bind-port"`                          // 绑定端口
+	HealthzPort     int           `json:"healthz_port" mapstructure:"healthz-port"`                       // 健康检查端口
+	ShutdownTimeout time.Duration `json:"shutdown_timeout" mapstructure:"shutdown-timeout"`               // 优雅关闭的最长等待时间
+	RequireAuth     bool          `json:"require_auth" mapstructure:"require-auth"`                       // 是否要求调用方认证
+	APIKeys         []string      `json:"-"            mapstructure:"api-keys"`                           // 允许访问的 API Key
+	PublicMethods   []string      `json:"public_methods" mapstructure:"public-methods"`                   // 无需认证的方法全名
+	Insecure        bool          `json:"insecure"       mapstructure:"insecure"`                         // 是否使用明文连接，为 false 时使用 secure.tls 的证书
+	ClientCAFile    string        `json:"client_ca_file" mapstructure:"client-ca-file"`                   // 校验客户端证书的 CA 文件，配置后启用双向 TLS
+	MaxRecvMsgSize  int           `json:"max_recv_msg_size_bytes" mapstructure:"max-recv-msg-size-bytes"` // 可接收的最大消息字节数
+	MaxSendMsgSize  int           `json:"max_send_msg_size_bytes" mapstructure:"max-send-msg-size-bytes"` // 可发送的最大消息字节数
+
+	// Keepalive 连接保活和客户端 ping 频率限制
+	Keepalive GRPCKeepaliveOptions `json:"keepalive" mapstructure:"keepalive"`
+
+	// RateLimits 按方法单独配置的令牌桶限流，覆盖内置的方法限流
+	// 方法名包含 “.” 且区分大小写，无法作为配置键，因此以列表配置
+	RateLimits []GRPCRateLimitOptions `json:"rate_limits" mapstructure:"rate-limits"`
+}
+
+// GRPCRateLimitOptions gRPC 方法限流配置
+type GRPCRateLimitOptions struct {
+	Method string  `json:"method" mapstructure:"method"` // 方法全名，如 /answersheet.AnswerSheetService/ListAnswerSheets
+	Rate   float64 `json:"rate"   mapstructure:"rate"`   // 每秒补充的令牌数
+	Burst  int     `json:"burst"  mapstructure:"burst"`  // 令牌桶容量，为 0 时取 Rate 向上取整
+}
+
+// GRPCKeepaliveOptions gRPC 服务端 keepalive 配置
+type GRPCKeepaliveOptions struct {
+	MaxConnectionIdle     time.Duration `json:"max_connection_idle"      mapstructure:"max-connection-idle"`      // 空闲连接的最长保留时间，0 表示不限制
+	MaxConnectionAge      time.Duration `json:"max_connection_age"       mapstructure:"max-connection-age"`       // 连接的最长存活时间，到期后通知客户端重连
+	MaxConnectionAgeGrace time.Duration `json:"max_connection_age_grace" mapstructure:"max-connection-age-grace"` // 连接到期后等待进行中请求完成的时间
+	Time                  time.Duration `json:"time"                     mapstructure:"time"`                     // 连接空闲多久后服务端发送 ping
+	Timeout               time.Duration `json:"timeout"                  mapstructure:"timeout"`                  // 等待 ping 响应的时间
+	MinTime               time.Duration `json:"min_time"                 mapstructure:"min-time"`                 // 允许客户端发送 ping 的最小间隔
+	PermitWithoutStream   bool          `json:"permit_without_stream"    mapstructure:"permit-without-stream"`    // 是否允许客户端在没有请求时发送 ping
 }
 
 // NewGRPCOptions 创建默认的 GRPC 配置选项
 func NewGRPCOptions() *GRPCOptions {
 	return &GRPCOptions{
-		BindAddress: "127.0.0.1",
-		BindPort:    9090,
-		HealthzPort: 9091,
+		BindAddress:     "127.0.0.1",
+		BindPort:        9090,
+		HealthzPort:     9091,
+		ShutdownTimeout: 5 * time.Second,
+		MaxRecvMsgSize:  DefaultGRPCMaxMsgSize,
+		MaxSendMsgSize:  DefaultGRPCMaxMsgSize,
+		PublicMethods:   []string{"/grpc.health.v1.Health/*"},
+		// 客户端 ping 的最小间隔需小于内部客户端的 30 秒 ping 间隔，否则其连接会被断开
+		Keepalive: GRPCKeepaliveOptions{
+			MaxConnectionIdle:     15 * time.Minute,
+			MaxConnectionAge:      2 * time.Hour,
+			MaxConnectionAgeGrace: 10 * time.Second,
+			Time:                  time.Minute,
+			Timeout:               20 * time.Second,
+			MinTime:               20 * time.Second,
+		},
 	}
 }
 
@@ -30,13 +94,85 @@ func (s *GRPCOptions) Validate() []error {
 	if s.BindPort < 0 || s.BindPort > 65535 {
 		errors = append(
 			errors,
-			fmt.Errorf(
-				"--grpc.bind-port %v must be between 0 and 65535, inclusive. 0 for turning off insecure (HTTP) port",
+			app.NewOptionsValidationError(
+				"grpc.bind-port",
+				"%v must be between 0 and 65535, inclusive. 0 for turning off insecure (HTTP) port",
 				s.BindPort,
 			),
 		)
 	}
 
+	if s.ShutdownTimeout < 0 {
+		errors = append(errors, app.NewOptionsValidationError("grpc.shutdown-timeout", "%v must not be negative", s.ShutdownTimeout))
+	}
+
+	if err := ValidateGRPCMsgSize("grpc.max-recv-msg-size-bytes", s.MaxRecvMsgSize); err != nil {
+		errors = append(errors, err)
+	}
+	if err := ValidateGRPCMsgSize("grpc.max-send-msg-size-bytes", s.MaxSendMsgSize); err != nil {
+		errors = append(errors, err)
+	}
+
+	if s.RequireAuth {
+		for _, key := range s.APIKeys {
+			if len(key) < 16 {
+				errors = append(errors, app.NewOptionsValidationError("grpc.api-keys", "api keys must be at least 16 characters"))
+				break
+			}
+		}
+	}
+
+	errors = append(errors, s.Keepalive.validate()...)
+
+	if s.Insecure && s.ClientCAFile != "" {
+		errors = append(errors, app.NewOptionsValidationError("grpc.client-ca-file", "mutual TLS can not be enabled when grpc.insecure is true"))
+	}
+
+	methods := make(map[string]struct{}, len(s.RateLimits))
+	for _, limit := range s.RateLimits {
+		if !strings.HasPrefix(limit.Method, "/") {
+			errors = append(errors, app.NewOptionsValidationError("grpc.rate-limits", "method %q must be a full method name like /package.Service/Method", limit.Method))
+			continue
+		}
+		if _, exists := methods[limit.Method]; exists {
+			errors = append(errors, app.NewOptionsValidationError("grpc.rate-limits", "method %s is configured more than once", limit.Method))
+		}
+		methods[limit.Method] = struct{}{}
+		if limit.Rate <= 0 || limit.Burst < 0 {
+			errors = append(errors, app.NewOptionsValidationError("grpc.rate-limits", "method %s must have a positive rate and a non-negative burst", limit.Method))
+		}
+	}
+
+	return errors
+}
+
+// validate 校验 keepalive 配置
+func (k GRPCKeepaliveOptions) validate() []error {
+	var errors []error
+
+	durations := []struct {
+		field string
+		value time.Duration
+	}{
+		{"grpc.keepalive.max-connection-idle", k.MaxConnectionIdle},
+		{"grpc.keepalive.max-connection-age", k.MaxConnectionAge},
+		{"grpc.keepalive.max-connection-age-grace", k.MaxConnectionAgeGrace},
+		{"grpc.keepalive.min-time", k.MinTime},
+	}
+	for _, d := range durations {
+		if d.value < 0 {
+			errors = append(errors, app.NewOptionsValidationError(d.field, "%v must not be negative", d.value))
+		}
+	}
+
+	// grpc-go 会把小于 1 秒的 ping 间隔提升为 1 秒
+	if k.Time < time.Second {
+		errors = append(errors, app.NewOptionsValidationError("grpc.keepalive.time", "%v must be at least 1s", k.Time))
+	}
+	if k.Timeout <= 0 {
+		errors = append(errors, app.NewOptionsValidationError("grpc.keepalive.timeout", "%v must be positive", k.Timeout))
+	}
+
 	return errors
 }
 
@@ -53,6 +189,56 @@ func (s *GRPCOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.IntVar(&s.HealthzPort, "grpc.healthz-port", s.HealthzPort, ""+
 		"The port on which to serve grpc health check.")
+
+	fs.DurationVar(&s.ShutdownTimeout, "grpc.shutdown-timeout", s.ShutdownTimeout, ""+
+		"Maximum time to wait for in-flight grpc requests to finish on shutdown before forcing the server to stop.")
+
+	fs.IntVar(&s.MaxRecvMsgSize, "grpc.max-recv-msg-size-bytes", s.MaxRecvMsgSize, ""+
+		"Maximum size in bytes of a grpc message the server can receive, up to 64MB. Larger requests "+
+		"are rejected with ResourceExhausted before being decoded, so keep it small to bound memory use.")
+
+	fs.IntVar(&s.MaxSendMsgSize, "grpc.max-send-msg-size-bytes", s.MaxSendMsgSize, ""+
+		"Maximum size in bytes of a grpc message the server can send, up to 64MB.")
+
+	fs.BoolVar(&s.Insecure, "grpc.insecure", s.Insecure, ""+
+		"Serve grpc over plaintext TCP. When false, the server uses the certificate configured by "+
+		"--secure.tls.cert-file and --secure.tls.private-key-file.")
+
+	fs.StringVar(&s.ClientCAFile, "grpc.client-ca-file", s.ClientCAFile, ""+
+		"File containing the CA certificates used to verify grpc client certificates. "+
+		"When set, clients must present a certificate signed by one of these CAs (mutual TLS).")
+
+	fs.DurationVar(&s.Keepalive.MaxConnectionIdle, "grpc.keepalive.max-connection-idle", s.Keepalive.MaxConnectionIdle, ""+
+		"Close grpc connections that have had no requests for this long. 0 keeps idle connections open.")
+
+	fs.DurationVar(&s.Keepalive.MaxConnectionAge, "grpc.keepalive.max-connection-age", s.Keepalive.MaxConnectionAge, ""+
+		"Maximum lifetime of a grpc connection before the client is asked to reconnect. 0 means no limit.")
+
+	fs.DurationVar(&s.Keepalive.MaxConnectionAgeGrace, "grpc.keepalive.max-connection-age-grace", s.Keepalive.MaxConnectionAgeGrace, ""+
+		"Time allowed for in-flight requests to finish after a connection reaches its maximum age.")
+
+	fs.DurationVar(&s.Keepalive.Time, "grpc.keepalive.time", s.Keepalive.Time, ""+
+		"Idle time after which the grpc server pings the client to check the connection is alive.")
+
+	fs.DurationVar(&s.Keepalive.Timeout, "grpc.keepalive.timeout", s.Keepalive.Timeout, ""+
+		"Time the grpc server waits for a ping ack before closing the connection.")
+
+	fs.DurationVar(&s.Keepalive.MinTime, "grpc.keepalive.min-time", s.Keepalive.MinTime, ""+
+		"Minimum interval between client pings. Clients pinging more often are disconnected with "+
+		"GOAWAY (too_many_pings), so keep it below the keepalive time of every grpc client.")
+
+	fs.BoolVar(&s.Keepalive.PermitWithoutStream, "grpc.keepalive.permit-without-stream", s.Keepalive.PermitWithoutStream, ""+
+		"Allow clients to send pings when they have no active requests.")
+
+	fs.BoolVar(&s.RequireAuth, "grpc.require-auth", s.RequireAuth, ""+
+		"Require grpc callers to authenticate with an api key (x-api-key metadata) or a JWT "+
+		"(authorization: Bearer metadata). Configure grpc clients with a key before enabling.")
+
+	fs.StringSliceVar(&s.APIKeys, "grpc.api-keys", s.APIKeys, ""+
+		"API keys accepted from grpc callers when --grpc.require-auth is enabled.")
+
+	fs.StringSliceVar(&s.PublicMethods, "grpc.public-methods", s.PublicMethods, ""+
+		"Full grpc method names that do not require authentication. A trailing /* matches every method of a service.")
 }
 
 // ApplyTo 应用配置到服务器
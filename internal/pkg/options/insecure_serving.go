@@ -5,7 +5,9 @@ import (
 	"net"
 
 	"github.com/spf13/pflag"
+
 	"github.com/yshujie/questionnaire-scale/internal/pkg/server"
+	"github.com/yshujie/questionnaire-scale/pkg/app"
 )
 
 // InsecureServingOptions 不安全的服务器配置选项
@@ -29,8 +31,9 @@ func (s *InsecureServingOptions) Validate() []error {
 	if s.BindPort < 0 || s.BindPort > 65535 {
 		errors = append(
 			errors,
-			fmt.Errorf(
-				"--insecure.bind-port %v must be between 0 and 65535, inclusive. 0 for turning off insecure (HTTP) port",
+			app.NewOptionsValidationError(
+				"insecure.bind-port",
+				"%v must be between 0 and 65535, inclusive. 0 for turning off insecure (HTTP) port",
 				s.BindPort,
 			),
 		)
@@ -0,0 +1,67 @@
+package options
+
+import (
+	"time"
+
+	"github.com/spf13/pflag"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+)
+
+// JwtOptions defines options for signing and refreshing JWT tokens.
+type JwtOptions struct {
+	Realm           string        `json:"realm"            mapstructure:"realm"`
+	Key             string        `json:"-"                mapstructure:"key"`
+	Timeout         time.Duration `json:"timeout"          mapstructure:"timeout"`
+	MaxRefresh      time.Duration `json:"max-refresh"      mapstructure:"max-refresh"`
+	RememberRefresh time.Duration `json:"remember-refresh" mapstructure:"remember-refresh"`
+}
+
+// NewJwtOptions create a `zero` value instance.
+func NewJwtOptions() *JwtOptions {
+	return &JwtOptions{
+		Realm:           "qs jwt",
+		Key:             "",
+		Timeout:         24 * time.Hour,
+		MaxRefresh:      7 * 24 * time.Hour,
+		RememberRefresh: 30 * 24 * time.Hour,
+	}
+}
+
+// Validate verifies flags passed to JwtOptions.
+func (o *JwtOptions) Validate() []error {
+	errs := []error{}
+
+	if o.Key == "" {
+		errs = append(errs, app.NewOptionsValidationError("jwt.key", "is required"))
+	}
+	if o.Timeout <= 0 {
+		errs = append(errs, app.NewOptionsValidationError("jwt.timeout",
+			"must be greater than 0, got %s", o.Timeout))
+	}
+	if o.MaxRefresh < 0 {
+		errs = append(errs, app.NewOptionsValidationError("jwt.max-refresh",
+			"must not be negative, got %s", o.MaxRefresh))
+	}
+	if o.RememberRefresh <= 0 {
+		errs = append(errs, app.NewOptionsValidationError("jwt.remember-refresh",
+			"must be greater than 0, got %s", o.RememberRefresh))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to JWT tokens to the specified FlagSet.
+func (o *JwtOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&o.Realm, "jwt.realm", o.Realm, "Realm name to display to the user.")
+
+	fs.StringVar(&o.Key, "jwt.key", o.Key, "Private key used to sign jwt token.")
+
+	fs.DurationVar(&o.Timeout, "jwt.timeout", o.Timeout, "JWT token timeout.")
+
+	fs.DurationVar(&o.MaxRefresh, "jwt.max-refresh", o.MaxRefresh, ""+
+		"This field allows clients to refresh their token until MaxRefresh has passed.")
+
+	fs.DurationVar(&o.RememberRefresh, "jwt.remember-refresh", o.RememberRefresh, ""+
+		"Lifetime of the long-lived refresh token issued to remember-me logins.")
+}
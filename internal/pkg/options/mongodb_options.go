@@ -1,17 +1,24 @@
 package options
 
 import (
+	"time"
+
 	"github.com/spf13/pflag"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
 )
 
 // MongoDBOptions defines options for mongodb database.
 type MongoDBOptions struct {
-	URL                      string `json:"url,omitempty"                                mapstructure:"url"`
-	UseSSL                   bool   `json:"use-ssl,omitempty"                            mapstructure:"use-ssl"`
-	SSLInsecureSkipVerify    bool   `json:"ssl-insecure-skip-verify,omitempty"           mapstructure:"ssl-insecure-skip-verify"`
-	SSLAllowInvalidHostnames bool   `json:"ssl-allow-invalid-hostnames,omitempty"        mapstructure:"ssl-allow-invalid-hostnames"`
-	SSLCAFile                string `json:"ssl-ca-file,omitempty"                        mapstructure:"ssl-ca-file"`
-	SSLPEMKeyfile            string `json:"ssl-pem-keyfile,omitempty"                    mapstructure:"ssl-pem-keyfile"`
+	URL                      string        `json:"url,omitempty"                                mapstructure:"url"`
+	UseSSL                   bool          `json:"use-ssl,omitempty"                            mapstructure:"use-ssl"`
+	SSLInsecureSkipVerify    bool          `json:"ssl-insecure-skip-verify,omitempty"           mapstructure:"ssl-insecure-skip-verify"`
+	SSLAllowInvalidHostnames bool          `json:"ssl-allow-invalid-hostnames,omitempty"        mapstructure:"ssl-allow-invalid-hostnames"`
+	SSLCAFile                string        `json:"ssl-ca-file,omitempty"                        mapstructure:"ssl-ca-file"`
+	SSLPEMKeyfile            string        `json:"ssl-pem-keyfile,omitempty"                    mapstructure:"ssl-pem-keyfile"`
+	MaxPoolSize              uint64        `json:"max-pool-size,omitempty"                      mapstructure:"max-pool-size"`
+	MinPoolSize              uint64        `json:"min-pool-size,omitempty"                      mapstructure:"min-pool-size"`
+	MaxConnIdleTime          time.Duration `json:"max-conn-idle-time,omitempty"                 mapstructure:"max-conn-idle-time"`
 }
 
 // NewMongoDBOptions create a `zero` value instance.
@@ -23,6 +30,9 @@ func NewMongoDBOptions() *MongoDBOptions {
 		SSLAllowInvalidHostnames: false,
 		SSLCAFile:                "",
 		SSLPEMKeyfile:            "",
+		MaxPoolSize:              100,
+		MinPoolSize:              0,
+		MaxConnIdleTime:          0, // 不限制
 	}
 }
 
@@ -30,6 +40,18 @@ func NewMongoDBOptions() *MongoDBOptions {
 func (o *MongoDBOptions) Validate() []error {
 	errs := []error{}
 
+	if o.URL == "" {
+		errs = append(errs, app.NewOptionsValidationError("mongodb.url", "is required"))
+	}
+	if o.MaxPoolSize > 0 && o.MinPoolSize > o.MaxPoolSize {
+		errs = append(errs, app.NewOptionsValidationError("mongodb.min-pool-size",
+			"%d must not exceed mongodb.max-pool-size (%d)", o.MinPoolSize, o.MaxPoolSize))
+	}
+	if o.MaxConnIdleTime < 0 {
+		errs = append(errs, app.NewOptionsValidationError("mongodb.max-conn-idle-time",
+			"must not be negative, got %s", o.MaxConnIdleTime))
+	}
+
 	return errs
 }
 
@@ -52,4 +74,13 @@ func (o *MongoDBOptions) AddFlags(fs *pflag.FlagSet) {
 
 	fs.StringVar(&o.SSLPEMKeyfile, "mongodb.ssl-pem-keyfile", o.SSLPEMKeyfile, ""+
 		"Path to SSL PEM key file for mongodb.")
+
+	fs.Uint64Var(&o.MaxPoolSize, "mongodb.max-pool-size", o.MaxPoolSize, ""+
+		"Maximum number of connections in the mongodb connection pool, 0 means the driver default (100).")
+
+	fs.Uint64Var(&o.MinPoolSize, "mongodb.min-pool-size", o.MinPoolSize, ""+
+		"Minimum number of connections kept in the mongodb connection pool.")
+
+	fs.DurationVar(&o.MaxConnIdleTime, "mongodb.max-conn-idle-time", o.MaxConnIdleTime, ""+
+		"Maximum amount of time a connection to mongodb may be idle before being closed, 0 means no limit.")
 }
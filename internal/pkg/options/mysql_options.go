@@ -8,6 +8,8 @@ import (
 	"time"
 
 	"github.com/spf13/pflag"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
 )
 
 // MySQLOptions defines options for mysql database.
@@ -19,6 +21,7 @@ type MySQLOptions struct {
 	MaxIdleConnections    int           `json:"max-idle-connections,omitempty"     mapstructure:"max-idle-connections"`
 	MaxOpenConnections    int           `json:"max-open-connections,omitempty"     mapstructure:"max-open-connections"`
 	MaxConnectionLifeTime time.Duration `json:"max-connection-life-time,omitempty" mapstructure:"max-connection-life-time"`
+	MaxConnectionIdleTime time.Duration `json:"max-connection-idle-time,omitempty" mapstructure:"max-connection-idle-time"`
 	LogLevel              int           `json:"log-level"                          mapstructure:"log-level"`
 }
 
@@ -32,6 +35,7 @@ func NewMySQLOptions() *MySQLOptions {
 		MaxIdleConnections:    100,
 		MaxOpenConnections:    100,
 		MaxConnectionLifeTime: time.Duration(10) * time.Second,
+		MaxConnectionIdleTime: 0, // 不限制
 		LogLevel:              1, // Silent
 	}
 }
@@ -40,6 +44,33 @@ func NewMySQLOptions() *MySQLOptions {
 func (o *MySQLOptions) Validate() []error {
 	errs := []error{}
 
+	if o.Host == "" {
+		errs = append(errs, app.NewOptionsValidationError("mysql.host", "is required"))
+	}
+	if o.Database == "" {
+		errs = append(errs, app.NewOptionsValidationError("mysql.database", "is required"))
+	}
+	if o.MaxOpenConnections < 0 {
+		errs = append(errs, app.NewOptionsValidationError("mysql.max-open-connections",
+			"must not be negative, got %d", o.MaxOpenConnections))
+	}
+	if o.MaxIdleConnections < 0 {
+		errs = append(errs, app.NewOptionsValidationError("mysql.max-idle-connections",
+			"must not be negative, got %d", o.MaxIdleConnections))
+	}
+	if o.MaxOpenConnections > 0 && o.MaxIdleConnections > o.MaxOpenConnections {
+		errs = append(errs, app.NewOptionsValidationError("mysql.max-idle-connections",
+			"%d must not exceed mysql.max-open-connections (%d)", o.MaxIdleConnections, o.MaxOpenConnections))
+	}
+	if o.MaxConnectionLifeTime < 0 {
+		errs = append(errs, app.NewOptionsValidationError("mysql.max-connection-life-time",
+			"must not be negative, got %s", o.MaxConnectionLifeTime))
+	}
+	if o.MaxConnectionIdleTime < 0 {
+		errs = append(errs, app.NewOptionsValidationError("mysql.max-connection-idle-time",
+			"must not be negative, got %s", o.MaxConnectionIdleTime))
+	}
+
 	return errs
 }
 
@@ -57,7 +88,7 @@ func (o *MySQLOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.StringVar(&o.Database, "mysql.database", o.Database, ""+
 		"Database name for the server to use.")
 
-	fs.IntVar(&o.MaxIdleConnections, "mysql.max-idle-connections", o.MaxOpenConnections, ""+
+	fs.IntVar(&o.MaxIdleConnections, "mysql.max-idle-connections", o.MaxIdleConnections, ""+
 		"Maximum idle connections allowed to connect to mysql.")
 
 	fs.IntVar(&o.MaxOpenConnections, "mysql.max-open-connections", o.MaxOpenConnections, ""+
@@ -66,6 +97,9 @@ func (o *MySQLOptions) AddFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&o.MaxConnectionLifeTime, "mysql.max-connection-life-time", o.MaxConnectionLifeTime, ""+
 		"Maximum connection life time allowed to connect to mysql.")
 
+	fs.DurationVar(&o.MaxConnectionIdleTime, "mysql.max-connection-idle-time", o.MaxConnectionIdleTime, ""+
+		"Maximum amount of time a connection to mysql may be idle before being closed, 0 means no limit.")
+
 	fs.IntVar(&o.LogLevel, "mysql.log-mode", o.LogLevel, ""+
 		"Specify gorm log level.")
 }
@@ -0,0 +1,52 @@
+package options
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/pkg/app"
+)
+
+func TestValidate_RequiredFields(t *testing.T) {
+	tests := []struct {
+		field    string
+		validate func() []error
+	}{
+		{"mysql.host", func() []error {
+			o := NewMySQLOptions()
+			o.Database = "questionnaire_scale"
+			o.Host = ""
+			return o.Validate()
+		}},
+		{"mysql.database", func() []error {
+			return NewMySQLOptions().Validate()
+		}},
+		{"answersheet-purge.schedule", func() []error {
+			o := NewAnswerSheetPurgeOptions()
+			o.Enabled = true
+			o.Schedule = "every night"
+			return o.Validate()
+		}},
+		{"mongodb.url", func() []error {
+			o := NewMongoDBOptions()
+			o.URL = ""
+			return o.Validate()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.field, func(t *testing.T) {
+			errs := tt.validate()
+			if len(errs) != 1 {
+				t.Fatalf("Validate() = %v, want exactly one error", errs)
+			}
+
+			var buf bytes.Buffer
+			app.PrintOptionsValidationErrors(&buf, errs)
+			if !strings.Contains(buf.String(), tt.field) || !strings.Contains(errs[0].Error(), "field "+tt.field+":") {
+				t.Errorf("output %q / error %q does not name field %s", buf.String(), errs[0], tt.field)
+			}
+		})
+	}
+}
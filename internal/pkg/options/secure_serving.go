@@ -5,7 +5,9 @@ import (
 	"os"
 
 	"github.com/spf13/pflag"
+
 	"github.com/yshujie/questionnaire-scale/internal/pkg/server"
+	"github.com/yshujie/questionnaire-scale/pkg/app"
 )
 
 // SecureServingOptions 安全的服务器配置选项
@@ -33,8 +35,9 @@ func (s *SecureServingOptions) Validate() []error {
 	if s.BindPort < 0 || s.BindPort > 65535 {
 		errors = append(
 			errors,
-			fmt.Errorf(
-				"--secure.bind-port %v must be between 0 and 65535, inclusive. 0 for turning off secure (HTTPS) port",
+			app.NewOptionsValidationError(
+				"secure.bind-port",
+				"%v must be between 0 and 65535, inclusive. 0 for turning off secure (HTTPS) port",
 				s.BindPort,
 			),
 		)
@@ -0,0 +1,48 @@
+package options
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+// SessionOptions defines options for user login sessions.
+type SessionOptions struct {
+	Timeout           time.Duration  `json:"timeout"             mapstructure:"timeout"`
+	MaxConcurrent     int            `json:"max-concurrent"      mapstructure:"max-concurrent"`
+	RoleMaxConcurrent map[string]int `json:"role-max-concurrent" mapstructure:"role-max-concurrent"`
+}
+
+// NewSessionOptions create a `zero` value instance.
+func NewSessionOptions() *SessionOptions {
+	return &SessionOptions{
+		Timeout:           24 * time.Hour,
+		MaxConcurrent:     5,
+		RoleMaxConcurrent: map[string]int{},
+	}
+}
+
+// Validate verifies flags passed to SessionOptions.
+func (o *SessionOptions) Validate() []error {
+	errs := []error{}
+
+	if o.Timeout <= 0 {
+		errs = append(errs, fmt.Errorf("--session.timeout must be greater than 0"))
+	}
+
+	return errs
+}
+
+// AddFlags adds flags related to user login sessions to the specified FlagSet.
+func (o *SessionOptions) AddFlags(fs *pflag.FlagSet) {
+	fs.DurationVar(&o.Timeout, "session.timeout", o.Timeout, ""+
+		"Idle timeout of a login session, should match jwt.timeout.")
+
+	fs.IntVar(&o.MaxConcurrent, "session.max-concurrent", o.MaxConcurrent, ""+
+		"Maximum concurrent login sessions per user, the oldest session is revoked when exceeded. "+
+		"Zero means unlimited.")
+
+	fs.StringToIntVar(&o.RoleMaxConcurrent, "session.role-max-concurrent", o.RoleMaxConcurrent, ""+
+		"Per-role override of session.max-concurrent, e.g. admin=1.")
+}
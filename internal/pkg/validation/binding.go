@@ -0,0 +1,158 @@
+package validation
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+	"github.com/yshujie/questionnaire-scale/internal/pkg/code"
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+// 自定义绑定校验标签
+const (
+	TagUsername = "username"
+	TagPassword = "password"
+)
+
+// 用户名、密码约束
+const (
+	usernameMinLength = 3
+	usernameMaxLength = 50
+	passwordMinLength = 8
+)
+
+// PasswordWeakMessage 密码强度不足的提示
+var PasswordWeakMessage = fmt.Sprintf("密码强度不足，长度至少为%d位且必须同时包含字母和数字", passwordMinLength)
+
+var (
+	usernamePattern = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+	registerOnce sync.Once
+	registerErr  error
+)
+
+// FieldError 字段级校验错误
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorResponse 参数校验失败的响应体
+type ErrorResponse struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors,omitempty"`
+}
+
+// RegisterBindingValidators 向 gin 的校验引擎注册自定义校验标签
+// 字段名取 json 标签，使校验错误中的字段与请求体一致，重复调用只注册一次
+func RegisterBindingValidators() error {
+	registerOnce.Do(func() {
+		v, ok := binding.Validator.Engine().(*validator.Validate)
+		if !ok {
+			registerErr = errors.WithCode(code.ErrUnknown, "unsupported binding validator engine %T", binding.Validator.Engine())
+			return
+		}
+
+		v.RegisterTagNameFunc(jsonFieldName)
+		if err := v.RegisterValidation(TagUsername, validateUsername); err != nil {
+			registerErr = errors.WrapC(err, code.ErrUnknown, "register %s validator failed", TagUsername)
+			return
+		}
+		if err := v.RegisterValidation(TagPassword, validatePassword); err != nil {
+			registerErr = errors.WrapC(err, code.ErrUnknown, "register %s validator failed", TagPassword)
+		}
+	})
+	return registerErr
+}
+
+// TranslateBindingError 将请求绑定错误转换为字段级错误响应
+// 非校验错误（如 JSON 格式错误）转换为 ErrBind 响应
+func TranslateBindingError(err error) *ErrorResponse {
+	var validationErrors validator.ValidationErrors
+	if !errors.As(err, &validationErrors) {
+		return &ErrorResponse{
+			Code:    code.ErrBind,
+			Message: "请求参数格式错误",
+		}
+	}
+
+	resp := &ErrorResponse{
+		Code:    code.ErrValidation,
+		Message: "参数验证失败",
+		Errors:  make([]FieldError, 0, len(validationErrors)),
+	}
+	for _, fe := range validationErrors {
+		resp.Errors = append(resp.Errors, translateFieldError(fe))
+	}
+	return resp
+}
+
+// translateFieldError 按校验标签生成字段错误信息
+func translateFieldError(fe validator.FieldError) FieldError {
+	field := fe.Field()
+	switch fe.Tag() {
+	case "required":
+		return FieldError{Field: field, Code: code.ErrFieldRequired, Message: fmt.Sprintf("%s不能为空", field)}
+	case TagUsername:
+		return FieldError{Field: field, Code: code.ErrFieldInvalid, Message: fmt.Sprintf(
+			"用户名只能包含字母、数字和下划线，长度为%d-%d个字符", usernameMinLength, usernameMaxLength)}
+	case TagPassword:
+		return FieldError{Field: field, Code: code.ErrUserPasswordWeak, Message: PasswordWeakMessage}
+	case "min":
+		return FieldError{Field: field, Code: code.ErrFieldInvalid, Message: fmt.Sprintf("%s不能小于%s", field, fe.Param())}
+	case "max":
+		return FieldError{Field: field, Code: code.ErrFieldInvalid, Message: fmt.Sprintf("%s不能大于%s", field, fe.Param())}
+	case "email":
+		return FieldError{Field: field, Code: code.ErrFieldInvalid, Message: fmt.Sprintf("%s不是有效的邮箱地址", field)}
+	default:
+		return FieldError{Field: field, Code: code.ErrFieldInvalid, Message: fmt.Sprintf("%s格式不正确", field)}
+	}
+}
+
+// jsonFieldName 使用 json 标签作为字段名
+func jsonFieldName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "" || name == "-" {
+		return field.Name
+	}
+	return name
+}
+
+// validateUsername 用户名只能包含字母、数字和下划线
+func validateUsername(fl validator.FieldLevel) bool {
+	username := fl.Field().String()
+	return len(username) >= usernameMinLength &&
+		len(username) <= usernameMaxLength &&
+		usernamePattern.MatchString(username)
+}
+
+// validatePassword 校验密码强度
+func validatePassword(fl validator.FieldLevel) bool {
+	return IsStrongPassword(fl.Field().String())
+}
+
+// IsStrongPassword 密码长度不少于下限且同时包含字母和数字
+func IsStrongPassword(password string) bool {
+	if len(password) < passwordMinLength {
+		return false
+	}
+
+	var hasLetter, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLetter(r):
+			hasLetter = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+	return hasLetter && hasDigit
+}
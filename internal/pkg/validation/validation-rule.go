@@ -12,10 +12,21 @@ const (
 	RuleTypeMaxSelections RuleType = "max_selections"
 )
 
+// Severity 校验规则未通过时的严重级别
+type Severity string
+
+const (
+	// SeverityError 错误，校验不通过
+	SeverityError Severity = "error"
+	// SeverityWarning 警告，只提示不阻断提交
+	SeverityWarning Severity = "warning"
+)
+
 // ValidationRule 校验规则接口
 type ValidationRule struct {
 	ruleType    RuleType
 	targetValue string
+	severity    Severity
 }
 
 // NewValidationRule 创建校验规则
@@ -26,6 +37,12 @@ func NewValidationRule(ruleType RuleType, targetValue string) ValidationRule {
 	}
 }
 
+// WithSeverity 设置规则的严重级别，为空时按错误处理
+func (r ValidationRule) WithSeverity(severity Severity) ValidationRule {
+	r.severity = severity
+	return r
+}
+
 // GetRuleType 获取规则类型
 func (r *ValidationRule) GetRuleType() RuleType {
 	return r.ruleType
@@ -35,3 +52,11 @@ func (r *ValidationRule) GetRuleType() RuleType {
 func (r *ValidationRule) GetTargetValue() string {
 	return r.targetValue
 }
+
+// GetSeverity 获取严重级别，未设置时为错误
+func (r *ValidationRule) GetSeverity() Severity {
+	if r.severity == "" {
+		return SeverityError
+	}
+	return r.severity
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/fatih/color"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 
 	"github.com/yshujie/questionnaire-scale/pkg/errors"
@@ -69,6 +70,13 @@ func WithValidArgs(args cobra.PositionalArgs) Option {
 	}
 }
 
+// WithCommands 添加子命令
+func WithCommands(commands ...*Command) Option {
+	return func(a *App) {
+		a.commands = append(a.commands, commands...)
+	}
+}
+
 // WithDefaultValidArgs 设置默认的 args
 func WithDefaultValidArgs() Option {
 	return func(a *App) {
@@ -133,6 +141,10 @@ func (a *App) buildCommand() {
 		}
 		// 设置帮助命令
 		cmd.SetHelpCommand(helpCommand(FormatBaseName(a.basename)))
+		// 子命令与主命令共用配置文件
+		if !a.noConfig {
+			cmd.PersistentFlags().AddFlag(pflag.Lookup(configFlagName))
+		}
 	}
 
 	// 如果启动回调函数不为空，则设置启动回调函数
@@ -160,6 +172,9 @@ func (a *App) buildCommand() {
 		addConfigFlag(a.basename, namedFlagSets.FlagSet("global"))
 	}
 
+	// 添加 PID 文件标志
+	addPIDFileFlag(namedFlagSets.FlagSet("global"))
+
 	// 添加全局标志到命令标志集
 	cmd.Flags().AddFlagSet(namedFlagSets.FlagSet("global"))
 
@@ -233,6 +248,19 @@ func (a *App) runCommand(cmd *cobra.Command, args []string) error {
 		}
 	}
 
+	// 如果指定了 PID 文件，则写入 PID 文件，退出时删除
+	if pidFilePath != "" {
+		pidFile := &PIDFile{}
+		if err := pidFile.Write(pidFilePath); err != nil {
+			return err
+		}
+		defer func() {
+			if err := pidFile.Remove(); err != nil {
+				log.Warnf("%v", err)
+			}
+		}()
+	}
+
 	// 运行应用程序
 	if a.runFunc != nil {
 		return a.runFunc(a.basename)
@@ -250,6 +278,7 @@ func (a *App) applyOptionRules() error {
 	}
 
 	if errs := a.options.Validate(); len(errs) != 0 {
+		PrintOptionsValidationErrors(os.Stderr, errs)
 		return errors.NewAggregate(errs)
 	}
 
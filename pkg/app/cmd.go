@@ -39,6 +39,25 @@ func NewCommand(usage string, desc string, opts ...CommandOption) *Command {
 	return c
 }
 
+// WithCommandOptions 设置命令的命令行选项
+func WithCommandOptions(opt CliOptions) CommandOption {
+	return func(c *Command) {
+		c.options = opt
+	}
+}
+
+// WithCommandRunFunc 设置命令的启动回调函数
+func WithCommandRunFunc(run RunCommandFunc) CommandOption {
+	return func(c *Command) {
+		c.runFunc = run
+	}
+}
+
+// AddCommand 添加子命令
+func (c *Command) AddCommand(cmd *Command) {
+	c.commands = append(c.commands, cmd)
+}
+
 // RunCommandFunc 定义应用程序的命令启动回调函数
 type RunCommandFunc func(args []string) error
 
@@ -70,10 +89,12 @@ func (c *Command) cobraCommand() *cobra.Command {
 		cmd.Run = c.runCommand
 	}
 	if c.options != nil {
-		for _, f := range c.options.Flags().FlagSets {
+		namedFlagSets := c.options.Flags()
+		for _, f := range namedFlagSets.FlagSets {
 			cmd.Flags().AddFlagSet(f)
 		}
-		// c.options.AddFlags(cmd.Flags())
+		// 使用子命令自身的参数分组输出帮助信息，避免继承主命令的帮助模板
+		addCmdTemplate(cmd, namedFlagSets)
 	}
 	addHelpCommandFlag(c.usage, cmd.Flags())
 
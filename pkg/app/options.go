@@ -1,6 +1,11 @@
 package app
 
 import (
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
 	cliflag "github.com/yshujie/questionnaire-scale/pkg/flag"
 )
 
@@ -21,3 +26,37 @@ type CompleteableOptions interface {
 type PrintableOptions interface {
 	String() string
 }
+
+// OptionsValidationError 字段级的配置校验错误
+type OptionsValidationError struct {
+	Field  string
+	Reason string
+}
+
+// Error 实现 error 接口，字段名由 NewOptionsValidationError 的包装附加
+func (e *OptionsValidationError) Error() string {
+	return e.Reason
+}
+
+// NewOptionsValidationError 创建字段级的配置校验错误，错误信息格式为 "field <字段名>: <原因>"
+func NewOptionsValidationError(field, format string, args ...interface{}) error {
+	return fmt.Errorf("field %s: %w", field, &OptionsValidationError{
+		Field:  field,
+		Reason: fmt.Sprintf(format, args...),
+	})
+}
+
+// PrintOptionsValidationErrors 以表格形式输出配置校验错误，非字段级错误的字段列显示为 "-"
+func PrintOptionsValidationErrors(w io.Writer, errs []error) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "FIELD\tREASON")
+	for _, err := range errs {
+		var validationErr *OptionsValidationError
+		if errors.As(err, &validationErr) {
+			fmt.Fprintf(tw, "%s\t%s\n", validationErr.Field, validationErr.Reason)
+			continue
+		}
+		fmt.Fprintf(tw, "-\t%s\n", err.Error())
+	}
+	tw.Flush()
+}
@@ -0,0 +1,49 @@
+package app
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/yshujie/questionnaire-scale/pkg/errors"
+)
+
+func TestNewOptionsValidationError(t *testing.T) {
+	err := NewOptionsValidationError("mysql.host", "is required")
+	if err.Error() != "field mysql.host: is required" {
+		t.Errorf("Error() = %q, want %q", err.Error(), "field mysql.host: is required")
+	}
+
+	var validationErr *OptionsValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "mysql.host" || validationErr.Reason != "is required" {
+		t.Errorf("errors.As() = %+v, want field mysql.host with reason", validationErr)
+	}
+}
+
+func TestPrintOptionsValidationErrors(t *testing.T) {
+	var buf bytes.Buffer
+	PrintOptionsValidationErrors(&buf, []error{
+		NewOptionsValidationError("mysql.database", "is required"),
+		NewOptionsValidationError("grpc.bind-port", "%d must be between 0 and 65535", 70000),
+		fmt.Errorf("field %s: cannot be negative", "log.max-age"),
+	})
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	want := [][]string{
+		{"FIELD", "REASON"},
+		{"mysql.database", "is required"},
+		{"grpc.bind-port", "70000 must be between 0 and 65535"},
+		{"-", "field log.max-age: cannot be negative"},
+	}
+	if len(lines) != len(want) {
+		t.Fatalf("output has %d lines, want %d:\n%s", len(lines), len(want), buf.String())
+	}
+	// 各行的原因列对齐
+	column := strings.Index(lines[0], "REASON")
+	for i, line := range lines {
+		if !strings.HasPrefix(line, want[i][0]) || line[column:] != want[i][1] {
+			t.Errorf("line %d = %q, want %q aligned with %q", i, line, want[i][0], want[i][1])
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/pflag"
+)
+
+// pidFileFlagName PID 文件标志名称
+const pidFileFlagName = "pidfile"
+
+// pidFilePath PID 文件路径
+var pidFilePath string
+
+// init 初始化 PID 文件标志
+func init() {
+	pflag.StringVar(&pidFilePath, pidFileFlagName, pidFilePath, "Write the process ID to the specified `FILE` "+
+		"and refuse to start if another process holding it is still running.")
+}
+
+// addPIDFileFlag 添加 PID 文件标志
+func addPIDFileFlag(fs *pflag.FlagSet) {
+	fs.AddFlag(pflag.Lookup(pidFileFlagName))
+}
+
+// PIDFile PID 文件，用于防止同一主机上重复启动多个实例
+type PIDFile struct {
+	path string
+}
+
+// Write 将当前进程 PID 写入指定文件
+// 若文件中记录的进程仍在运行则拒绝写入，记录的进程已退出时视为过期文件并覆盖
+func (p *PIDFile) Write(path string) error {
+	if pid, err := readPID(path); err == nil && processAlive(pid) {
+		return fmt.Errorf("process already running (pid %d, pidfile %s)", pid, path)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create pidfile directory: %w", err)
+	}
+	if err := os.WriteFile(path, []byte(strconv.Itoa(os.Getpid())+"\n"), 0o644); err != nil {
+		return fmt.Errorf("failed to write pidfile: %w", err)
+	}
+
+	p.path = path
+	return nil
+}
+
+// Remove 删除由 Write 写入的 PID 文件
+func (p *PIDFile) Remove() error {
+	if p.path == "" {
+		return nil
+	}
+	if err := os.Remove(p.path); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove pidfile: %w", err)
+	}
+	p.path = ""
+	return nil
+}
+
+// readPID 读取 PID 文件中记录的进程 ID
+func readPID(path string) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+// processAlive 通过发送 0 信号判断进程是否存活
+func processAlive(pid int) bool {
+	if pid <= 0 {
+		return false
+	}
+	process, err := os.FindProcess(pid)
+	if err != nil {
+		return false
+	}
+	return process.Signal(syscall.Signal(0)) == nil
+}
@@ -0,0 +1,51 @@
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestPIDFile_WriteRefusesWhenProcessRunning(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apiserver.pid")
+
+	first := &PIDFile{}
+	if err := first.Write(path); err != nil {
+		t.Fatalf("Write() error = %v", err)
+	}
+	defer first.Remove()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("ReadFile() error = %v", err)
+	}
+	if strings.TrimSpace(string(data)) != strconv.Itoa(os.Getpid()) {
+		t.Errorf("pidfile content = %q, want %d", data, os.Getpid())
+	}
+
+	second := &PIDFile{}
+	err = second.Write(path)
+	if err == nil || !strings.Contains(err.Error(), "process already running") {
+		t.Fatalf("Write() error = %v, want process already running", err)
+	}
+}
+
+func TestPIDFile_OverwritesStaleFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "apiserver.pid")
+	if err := os.WriteFile(path, []byte("not-a-pid\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile() error = %v", err)
+	}
+
+	pidFile := &PIDFile{}
+	if err := pidFile.Write(path); err != nil {
+		t.Fatalf("Write() error = %v, want stale pidfile overwritten", err)
+	}
+	if err := pidFile.Remove(); err != nil {
+		t.Fatalf("Remove() error = %v", err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("pidfile still exists after Remove(), stat error = %v", err)
+	}
+}
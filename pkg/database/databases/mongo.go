@@ -13,12 +13,15 @@ import (
 
 // MongoConfig MongoDB 数据库配置
 type MongoConfig struct {
-	URL                      string `json:"url" mapstructure:"url"`
-	UseSSL                   bool   `json:"use-ssl" mapstructure:"use-ssl"`
-	SSLInsecureSkipVerify    bool   `json:"ssl-insecure-skip-verify" mapstructure:"ssl-insecure-skip-verify"`
-	SSLAllowInvalidHostnames bool   `json:"ssl-allow-invalid-hostnames" mapstructure:"ssl-allow-invalid-hostnames"`
-	SSLCAFile                string `json:"ssl-ca-file" mapstructure:"ssl-ca-file"`
-	SSLPEMKeyfile            string `json:"ssl-pem-keyfile" mapstructure:"ssl-pem-keyfile"`
+	URL                      string        `json:"url" mapstructure:"url"`
+	UseSSL                   bool          `json:"use-ssl" mapstructure:"use-ssl"`
+	SSLInsecureSkipVerify    bool          `json:"ssl-insecure-skip-verify" mapstructure:"ssl-insecure-skip-verify"`
+	SSLAllowInvalidHostnames bool          `json:"ssl-allow-invalid-hostnames" mapstructure:"ssl-allow-invalid-hostnames"`
+	SSLCAFile                string        `json:"ssl-ca-file" mapstructure:"ssl-ca-file"`
+	SSLPEMKeyfile            string        `json:"ssl-pem-keyfile" mapstructure:"ssl-pem-keyfile"`
+	MaxPoolSize              uint64        `json:"max-pool-size" mapstructure:"max-pool-size"`
+	MinPoolSize              uint64        `json:"min-pool-size" mapstructure:"min-pool-size"`
+	MaxConnIdleTime          time.Duration `json:"max-conn-idle-time" mapstructure:"max-conn-idle-time"`
 }
 
 // MongoDBConnection MongoDB 连接实现
@@ -44,15 +47,8 @@ func (m *MongoDBConnection) Connect() error {
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
 
-	// 创建连接选项
-	clientOptions := options.Client().ApplyURI(m.config.URL)
-
-	// 设置连接超时
-	clientOptions.SetConnectTimeout(5 * time.Second)
-	clientOptions.SetServerSelectionTimeout(5 * time.Second)
-
 	// 连接到MongoDB
-	client, err := mongo.Connect(ctx, clientOptions)
+	client, err := mongo.Connect(ctx, m.clientOptions())
 	if err != nil {
 		return fmt.Errorf("failed to connect to MongoDB: %w", err)
 	}
@@ -68,6 +64,28 @@ func (m *MongoDBConnection) Connect() error {
 	return nil
 }
 
+// clientOptions 创建连接选项
+func (m *MongoDBConnection) clientOptions() *options.ClientOptions {
+	clientOptions := options.Client().ApplyURI(m.config.URL)
+
+	// 设置连接超时
+	clientOptions.SetConnectTimeout(5 * time.Second)
+	clientOptions.SetServerSelectionTimeout(5 * time.Second)
+
+	// 设置连接池参数，未配置时使用驱动默认值
+	if m.config.MaxPoolSize > 0 {
+		clientOptions.SetMaxPoolSize(m.config.MaxPoolSize)
+	}
+	if m.config.MinPoolSize > 0 {
+		clientOptions.SetMinPoolSize(m.config.MinPoolSize)
+	}
+	if m.config.MaxConnIdleTime > 0 {
+		clientOptions.SetMaxConnIdleTime(m.config.MaxConnIdleTime)
+	}
+
+	return clientOptions
+}
+
 // Close 关闭 MongoDB 连接
 func (m *MongoDBConnection) Close() error {
 	if m.client != nil {
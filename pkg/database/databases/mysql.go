@@ -2,6 +2,7 @@ package databases
 
 import (
 	"context"
+	"database/sql"
 	"fmt"
 	"log"
 	"time"
@@ -20,6 +21,7 @@ type MySQLConfig struct {
 	MaxIdleConnections    int           `json:"max-idle-connections" mapstructure:"max-idle-connections"`
 	MaxOpenConnections    int           `json:"max-open-connections" mapstructure:"max-open-connections"`
 	MaxConnectionLifeTime time.Duration `json:"max-connection-life-time" mapstructure:"max-connection-life-time"`
+	MaxConnectionIdleTime time.Duration `json:"max-connection-idle-time" mapstructure:"max-connection-idle-time"`
 	LogLevel              int           `json:"log-level" mapstructure:"log-level"`
 	Logger                logger.Interface
 }
@@ -65,15 +67,21 @@ func (m *MySQLConnection) Connect() error {
 	}
 
 	// 设置连接池参数
-	sqlDB.SetMaxOpenConns(m.config.MaxOpenConnections)
-	sqlDB.SetConnMaxLifetime(m.config.MaxConnectionLifeTime)
-	sqlDB.SetMaxIdleConns(m.config.MaxIdleConnections)
+	m.applyPoolSettings(sqlDB)
 
 	m.client = db
 	log.Printf("MySQL connected successfully to %s/%s", m.config.Host, m.config.Database)
 	return nil
 }
 
+// applyPoolSettings 设置连接池参数
+func (m *MySQLConnection) applyPoolSettings(sqlDB *sql.DB) {
+	sqlDB.SetMaxOpenConns(m.config.MaxOpenConnections)
+	sqlDB.SetMaxIdleConns(m.config.MaxIdleConnections)
+	sqlDB.SetConnMaxLifetime(m.config.MaxConnectionLifeTime)
+	sqlDB.SetConnMaxIdleTime(m.config.MaxConnectionIdleTime)
+}
+
 // Close 关闭 MySQL 连接
 func (m *MySQLConnection) Close() error {
 	if m.client != nil {
@@ -0,0 +1,55 @@
+package databases
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+)
+
+func TestMongoDBConnection_ClientOptionsApplyPoolSettings(t *testing.T) {
+	conn := NewMongoDBConnection(&MongoConfig{
+		URL:             "mongodb://127.0.0.1:27017",
+		MaxPoolSize:     50,
+		MinPoolSize:     5,
+		MaxConnIdleTime: 3 * time.Minute,
+	})
+
+	opts := conn.clientOptions()
+	if opts.MaxPoolSize == nil || *opts.MaxPoolSize != 50 {
+		t.Errorf("MaxPoolSize = %v, want 50", opts.MaxPoolSize)
+	}
+	if opts.MinPoolSize == nil || *opts.MinPoolSize != 5 {
+		t.Errorf("MinPoolSize = %v, want 5", opts.MinPoolSize)
+	}
+	if opts.MaxConnIdleTime == nil || *opts.MaxConnIdleTime != 3*time.Minute {
+		t.Errorf("MaxConnIdleTime = %v, want 3m", opts.MaxConnIdleTime)
+	}
+}
+
+func TestMongoDBConnection_ClientOptionsKeepDriverDefaults(t *testing.T) {
+	opts := NewMongoDBConnection(&MongoConfig{URL: "mongodb://127.0.0.1:27017"}).clientOptions()
+	if opts.MaxPoolSize != nil || opts.MinPoolSize != nil || opts.MaxConnIdleTime != nil {
+		t.Errorf("pool options = %v/%v/%v, want driver defaults", opts.MaxPoolSize, opts.MinPoolSize, opts.MaxConnIdleTime)
+	}
+}
+
+func TestMySQLConnection_ApplyPoolSettings(t *testing.T) {
+	// sql.Open 不会建立连接，可在没有 MySQL 的环境中校验连接池参数
+	sqlDB, err := sql.Open("mysql", "user:password@tcp(127.0.0.1:3306)/test")
+	if err != nil {
+		t.Fatalf("sql.Open() error = %v", err)
+	}
+	defer sqlDB.Close()
+
+	conn := NewMySQLConnection(&MySQLConfig{
+		MaxOpenConnections:    20,
+		MaxIdleConnections:    5,
+		MaxConnectionLifeTime: time.Hour,
+		MaxConnectionIdleTime: 10 * time.Minute,
+	})
+	conn.applyPoolSettings(sqlDB)
+
+	if got := sqlDB.Stats().MaxOpenConnections; got != 20 {
+		t.Errorf("MaxOpenConnections = %d, want 20", got)
+	}
+}
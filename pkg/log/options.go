@@ -86,25 +86,25 @@ func (o *Options) Validate() []error {
 
 	var zapLevel zapcore.Level
 	if err := zapLevel.UnmarshalText([]byte(o.Level)); err != nil {
-		errs = append(errs, err)
+		errs = append(errs, fmt.Errorf("field %s: %w", "log.level", err))
 	}
 
 	format := strings.ToLower(o.Format)
 	if format != consoleFormat && format != jsonFormat {
-		errs = append(errs, fmt.Errorf("not a valid log format: %q", o.Format))
+		errs = append(errs, fmt.Errorf("field %s: not a valid log format: %q", "log.format", o.Format))
 	}
 
 	// 验证日志轮转配置
 	if o.MaxSize <= 0 {
-		errs = append(errs, fmt.Errorf("max-size must be greater than 0"))
+		errs = append(errs, fmt.Errorf("field %s: must be greater than 0", "log.max-size"))
 	}
 
 	if o.MaxAge < 0 {
-		errs = append(errs, fmt.Errorf("max-age cannot be negative"))
+		errs = append(errs, fmt.Errorf("field %s: cannot be negative", "log.max-age"))
 	}
 
 	if o.MaxBackups < 0 {
-		errs = append(errs, fmt.Errorf("max-backups cannot be negative"))
+		errs = append(errs, fmt.Errorf("field %s: cannot be negative", "log.max-backups"))
 	}
 
 	return errs
@@ -0,0 +1,113 @@
+// Package questiontype 是自定义题型的公开扩展点，只依赖标准库
+// 第三方实现 QuestionTypePlugin 并调用 RegisterQuestionType 注册后，
+// 服务端即可像内置题型一样创建、持久化和返回该题型的题目，无需 fork 仓库
+package questiontype
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+var (
+	// ErrTypeRequired 插件未声明题型
+	ErrTypeRequired = errors.New("question type plugin has no type")
+	// ErrTypeAlreadyRegistered 题型已注册，内置题型和自定义题型共用同一个命名空间
+	ErrTypeAlreadyRegistered = errors.New("question type is already registered")
+)
+
+// Definition 题目配置，创建自定义题型的题目前交给插件校验
+type Definition struct {
+	Code            string
+	Title           string
+	Type            string
+	Tips            string
+	Placeholder     string
+	Options         []Option
+	ValidationRules []ValidationRule
+}
+
+// Option 选项配置
+type Option struct {
+	Code    string
+	Content string
+	Score   int
+}
+
+// ValidationRule 校验规则配置
+type ValidationRule struct {
+	RuleType    string
+	TargetValue string
+	Severity    string
+}
+
+// Capabilities 题型支持的配置能力，由插件显式声明，供前端表单构建器和校验器使用
+// 服务端创建题目时只保留题型声明支持的配置
+type Capabilities struct {
+	Options     bool `json:"options"`     // 支持选项
+	Scoring     bool `json:"scoring"`     // 支持计分规则
+	Validation  bool `json:"validation"`  // 支持校验规则
+	Placeholder bool `json:"placeholder"` // 支持占位符
+	FileUpload  bool `json:"file_upload"` // 支持文件上传约束
+}
+
+// Info 题型元数据
+type Info struct {
+	Type         string       `json:"type"`
+	Capabilities Capabilities `json:"capabilities"`
+}
+
+// QuestionTypePlugin 题型插件
+type QuestionTypePlugin interface {
+	// Type 题型，不能与已注册的题型重复
+	Type() string
+	// Capabilities 题型支持的配置能力
+	Capabilities() Capabilities
+	// Validate 校验题目配置，返回错误时题目不会被创建
+	Validate(def Definition) error
+}
+
+// registry 题型到插件的注册表
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]QuestionTypePlugin)
+)
+
+// RegisterQuestionType 注册题型插件，题型为空或已注册时返回错误
+func RegisterQuestionType(plugin QuestionTypePlugin) error {
+	if plugin == nil || plugin.Type() == "" {
+		return ErrTypeRequired
+	}
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, exists := registry[plugin.Type()]; exists {
+		return fmt.Errorf("%w: %s", ErrTypeAlreadyRegistered, plugin.Type())
+	}
+	registry[plugin.Type()] = plugin
+	return nil
+}
+
+// Lookup 按题型查找已注册的插件
+func Lookup(typ string) (QuestionTypePlugin, bool) {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	plugin, ok := registry[typ]
+	return plugin, ok
+}
+
+// List 返回所有已注册题型的元数据，按题型名称排序
+func List() []Info {
+	registryMu.RLock()
+	infos := make([]Info, 0, len(registry))
+	for typ, plugin := range registry {
+		infos = append(infos, Info{Type: typ, Capabilities: plugin.Capabilities()})
+	}
+	registryMu.RUnlock()
+
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Type < infos[j].Type })
+	return infos
+}
@@ -0,0 +1,74 @@
+package scoring
+
+import "fmt"
+
+// 聚合公式
+const (
+	FormulaScore               = "score"                 // 选项分值，按求和计算
+	FormulaSum                 = "sum"                   // 求和
+	FormulaAvg                 = "avg"                   // 平均值
+	FormulaMax                 = "max"                   // 最大值
+	FormulaMin                 = "min"                   // 最小值
+	FormulaCountAboveThreshold = "count_above_threshold" // 严格大于阈值的条目数
+)
+
+// IsSupportedFormula 判断是否为支持的聚合公式
+func IsSupportedFormula(formula string) bool {
+	switch formula {
+	case FormulaScore, FormulaSum, FormulaAvg, FormulaMax, FormulaMin, FormulaCountAboveThreshold:
+		return true
+	default:
+		return false
+	}
+}
+
+// Aggregate 按聚合公式由一组得分计算得分，没有得分时结果为 0
+// threshold 只用于计数公式
+func Aggregate(formula string, operands []float64, threshold float64) (float64, error) {
+	if !IsSupportedFormula(formula) {
+		return 0, fmt.Errorf("unsupported formula: %s", formula)
+	}
+	if len(operands) == 0 {
+		return 0, nil
+	}
+
+	switch formula {
+	case FormulaAvg:
+		return sum(operands) / float64(len(operands)), nil
+	case FormulaMax:
+		max := operands[0]
+		for _, operand := range operands[1:] {
+			if operand > max {
+				max = operand
+			}
+		}
+		return max, nil
+	case FormulaMin:
+		min := operands[0]
+		for _, operand := range operands[1:] {
+			if operand < min {
+				min = operand
+			}
+		}
+		return min, nil
+	case FormulaCountAboveThreshold:
+		var count float64
+		for _, operand := range operands {
+			if operand > threshold {
+				count++
+			}
+		}
+		return count, nil
+	default:
+		return sum(operands), nil
+	}
+}
+
+// sum 求和
+func sum(operands []float64) float64 {
+	var total float64
+	for _, operand := range operands {
+		total += operand
+	}
+	return total
+}
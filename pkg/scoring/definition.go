@@ -0,0 +1,170 @@
+package scoring
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// 题型，与问卷定义中的题型一致
+const (
+	QuestionTypeRadio    = "Radio"
+	QuestionTypeCheckbox = "Checkbox"
+)
+
+// 因子类型
+const (
+	FactorTypePrimary    = "primary"    // 一级因子，引用题目得分
+	FactorTypeMultilevel = "multilevel" // 多级因子，引用其他因子得分
+)
+
+// Questionnaire 问卷定义，只包含计分所需的字段
+type Questionnaire struct {
+	Code      string     `json:"code"`
+	Version   string     `json:"version"`
+	Questions []Question `json:"questions"`
+}
+
+// Question 题目定义
+type Question struct {
+	Code        string       `json:"code"`
+	Type        string       `json:"type"`
+	Options     []Option     `json:"options"`
+	Conditional *Conditional `json:"conditional,omitempty"`
+}
+
+// Option 选项定义
+type Option struct {
+	Code  string  `json:"code"`
+	Score float64 `json:"score"`
+}
+
+// Conditional 条件计分规则
+// 引用题目 DependsOn 的答案等于某个映射的 WhenValue 时，本题选项按该映射的分值计分
+type Conditional struct {
+	DependsOn string         `json:"depends_on"`
+	Mappings  []ScoreMapping `json:"mappings"`
+}
+
+// ScoreMapping 条件计分映射，未列出的选项按选项分值计分
+type ScoreMapping struct {
+	WhenValue string             `json:"when_value"`
+	Scores    map[string]float64 `json:"scores"`
+}
+
+// Scale 医学量表定义
+type Scale struct {
+	Code                  string    `json:"code"`
+	Factors               []Factor  `json:"factors"`
+	Bands                 []Band    `json:"bands"`                   // 总分的临床切分分数段
+	NormalizeToPercentage bool      `json:"normalize_to_percentage"` // 是否计算百分制得分
+	Strategy              *Strategy `json:"strategy,omitempty"`      // 计分策略，为空时总分为各题得分之和
+}
+
+// Factor 因子定义
+type Factor struct {
+	Code         string   `json:"code"`
+	Title        string   `json:"title"`
+	Type         string   `json:"type"`
+	IsTotalScore bool     `json:"is_total_score"`
+	Formula      string   `json:"formula"`
+	SourceCodes  []string `json:"source_codes"`
+	Threshold    float64  `json:"threshold"` // 计数公式的阈值
+	Bands        []Band   `json:"bands"`     // 因子得分的解读分数段
+}
+
+// Band 分数段，采用左闭右开区间 [Min, Max)
+type Band struct {
+	Min         float64 `json:"min"`
+	Max         float64 `json:"max"`
+	Label       string  `json:"label"`
+	Description string  `json:"description,omitempty"`
+}
+
+// Contains 判断分数是否在分数段内
+func (b Band) Contains(score float64) bool {
+	return score >= b.Min && score < b.Max
+}
+
+// LoadQuestionnaire 从 JSON 加载问卷定义并校验
+func LoadQuestionnaire(data []byte) (*Questionnaire, error) {
+	var q Questionnaire
+	if err := json.Unmarshal(data, &q); err != nil {
+		return nil, fmt.Errorf("decode questionnaire: %w", err)
+	}
+	if err := q.Validate(); err != nil {
+		return nil, err
+	}
+	return &q, nil
+}
+
+// LoadScale 从 JSON 加载医学量表定义并校验
+func LoadScale(data []byte) (*Scale, error) {
+	var s Scale
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("decode scale: %w", err)
+	}
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// Validate 校验问卷定义，题目编码不能为空且不能重复，条件计分引用的题目必须存在
+func (q *Questionnaire) Validate() error {
+	codes := make(map[string]bool, len(q.Questions))
+	for i, qu := range q.Questions {
+		if qu.Code == "" {
+			return fmt.Errorf("question at index %d has no code", i)
+		}
+		if codes[qu.Code] {
+			return fmt.Errorf("duplicate question code %q", qu.Code)
+		}
+		codes[qu.Code] = true
+	}
+	for _, qu := range q.Questions {
+		if qu.Conditional != nil && !codes[qu.Conditional.DependsOn] {
+			return fmt.Errorf("question %q depends on unknown question %q", qu.Code, qu.Conditional.DependsOn)
+		}
+	}
+	return nil
+}
+
+// Validate 校验医学量表定义，因子编码不能重复，公式必须受支持，分数段必须有效
+func (s *Scale) Validate() error {
+	codes := make(map[string]bool, len(s.Factors))
+	for i, f := range s.Factors {
+		if f.Code == "" {
+			return fmt.Errorf("factor at index %d has no code", i)
+		}
+		if codes[f.Code] {
+			return fmt.Errorf("duplicate factor code %q", f.Code)
+		}
+		codes[f.Code] = true
+
+		if f.Type != FactorTypePrimary && f.Type != FactorTypeMultilevel {
+			return fmt.Errorf("factor %q has unknown type %q", f.Code, f.Type)
+		}
+		if !IsSupportedFormula(f.Formula) {
+			return fmt.Errorf("factor %q has unsupported formula %q", f.Code, f.Formula)
+		}
+		if err := validateBands(f.Bands); err != nil {
+			return fmt.Errorf("factor %q: %w", f.Code, err)
+		}
+	}
+	if s.Strategy != nil {
+		if err := s.Strategy.Validate(); err != nil {
+			return fmt.Errorf("strategy: %w", err)
+		}
+	}
+	return validateBands(s.Bands)
+}
+
+// validateBands 校验分数段的区间有效
+func validateBands(bands []Band) error {
+	for _, b := range bands {
+		if b.Min >= b.Max {
+			return fmt.Errorf("band %q has invalid range [%g, %g)", b.Label, b.Min, b.Max)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,303 @@
+// Package scoring 是独立的问卷计分 SDK，只依赖标准库
+// 根据问卷和医学量表定义计算题目得分、总分、因子得分，并解析得分所在的分数段，
+// 计分规则与服务端一致，第三方可在客户端离线计分
+package scoring
+
+import (
+	"fmt"
+)
+
+// Answers 答案，以题目编码为键
+// 单选题的值为选项编码，多选题的值为选项编码列表，其余题型不计分
+type Answers map[string]any
+
+// Result 计分结果
+type Result struct {
+	QuestionScores  map[string]float64 `json:"question_scores"`            // 各题得分
+	TotalScore      float64            `json:"total_score"`                // 总分，策略计算出总分时以其为准，否则为各题得分之和
+	Scores          map[string]float64 `json:"scores,omitempty"`           // 计分策略计算的各项得分，以得分编码为键
+	PercentageScore float64            `json:"percentage_score,omitempty"` // 百分制得分，量表开启百分制换算时有效
+	Band            *Band              `json:"band,omitempty"`             // 总分所在的分数段
+	Factors         []FactorScore      `json:"factors,omitempty"`          // 因子得分，按量表中的因子顺序排列
+}
+
+// FactorScore 因子得分
+type FactorScore struct {
+	Code  string  `json:"code"`
+	Title string  `json:"title"`
+	Score float64 `json:"score"`
+	Band  *Band   `json:"band,omitempty"` // 因子得分所在的解读分数段
+}
+
+// Score 按问卷定义计算答案得分，scale 不为空时计算因子得分并解析分数段
+func Score(q *Questionnaire, scale *Scale, answers Answers) (*Result, error) {
+	result := &Result{QuestionScores: q.ScoreQuestions(answers)}
+	for _, score := range result.QuestionScores {
+		result.TotalScore += score
+	}
+	if scale == nil {
+		return result, nil
+	}
+
+	if scale.Strategy != nil {
+		scores, err := scale.Strategy.Apply(q.questionCodes(), result.QuestionScores)
+		if err != nil {
+			return nil, err
+		}
+		result.Scores = scores
+		if total, ok := scores[TotalScoreCode]; ok {
+			result.TotalScore = total
+		}
+	}
+
+	result.Band = ResolveBand(scale.Bands, result.TotalScore)
+	if scale.NormalizeToPercentage {
+		maxScore := q.MaxScore()
+		if maxScore == 0 {
+			return nil, fmt.Errorf("questionnaire %s has max score 0, percentage score is undefined", q.Code)
+		}
+		result.PercentageScore = result.TotalScore / maxScore * 100
+	}
+
+	factorScores, err := scale.ScoreFactors(result.QuestionScores)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range scale.Factors {
+		score, ok := factorScores[f.Code]
+		if !ok {
+			continue
+		}
+		result.Factors = append(result.Factors, FactorScore{
+			Code:  f.Code,
+			Title: f.Title,
+			Score: score,
+			Band:  ResolveBand(f.Bands, score),
+		})
+	}
+	return result, nil
+}
+
+// ScoreQuestions 计算已作答题目的得分
+// 单选题取所选选项的分值，多选题取所选选项分值之和；
+// 设置了条件计分规则的题目，引用题目的答案与映射匹配时按映射分值计分
+func (q *Questionnaire) ScoreQuestions(answers Answers) map[string]float64 {
+	scores := make(map[string]float64, len(answers))
+	for _, qu := range q.Questions {
+		value, ok := answers[qu.Code]
+		if !ok {
+			continue
+		}
+
+		optionScores := qu.optionScores(answers)
+		switch qu.Type {
+		case QuestionTypeRadio:
+			if code, ok := value.(string); ok {
+				scores[qu.Code] = optionScores[code]
+			}
+		case QuestionTypeCheckbox:
+			var score float64
+			for _, code := range optionCodes(value) {
+				score += optionScores[code]
+			}
+			scores[qu.Code] = score
+		}
+	}
+	return scores
+}
+
+// MaxScore 计算问卷的最高可得分
+// 单选题取选项最高分，多选题取正分选项之和，条件计分的题目取各映射下的最高分
+func (q *Questionnaire) MaxScore() float64 {
+	var total float64
+	for _, qu := range q.Questions {
+		scores := qu.baseOptionScores()
+		maxScore := optionsMaxScore(qu.Type, scores)
+		if qu.Conditional != nil {
+			for _, mapping := range qu.Conditional.Mappings {
+				if score := optionsMaxScore(qu.Type, mergeScores(scores, mapping.Scores)); score > maxScore {
+					maxScore = score
+				}
+			}
+		}
+		total += maxScore
+	}
+	return total
+}
+
+// MinScore 计算问卷的最低可得分
+// 单选题取选项最低分，多选题取负分选项之和，条件计分的题目取各映射下的最低分；未作答的题目得 0 分，最低分不高于 0
+func (q *Questionnaire) MinScore() float64 {
+	var total float64
+	for _, qu := range q.Questions {
+		scores := qu.baseOptionScores()
+		minScore := optionsMinScore(qu.Type, scores)
+		if qu.Conditional != nil {
+			for _, mapping := range qu.Conditional.Mappings {
+				if score := optionsMinScore(qu.Type, mergeScores(scores, mapping.Scores)); score < minScore {
+					minScore = score
+				}
+			}
+		}
+		total += minScore
+	}
+	return total
+}
+
+// ScoreFactors 按因子公式计算因子得分，先计算一级因子，再计算多级因子
+// 一级因子引用题目得分，多级因子引用其他因子得分，未作答的源不参与计算
+func (s *Scale) ScoreFactors(questionScores map[string]float64) (map[string]float64, error) {
+	factorScores := make(map[string]float64, len(s.Factors))
+	for _, factorType := range []string{FactorTypePrimary, FactorTypeMultilevel} {
+		sources := questionScores
+		if factorType == FactorTypeMultilevel {
+			sources = factorScores
+		}
+
+		for _, f := range s.Factors {
+			if f.Type != factorType {
+				continue
+			}
+			operands := make([]float64, 0, len(f.SourceCodes))
+			for _, code := range f.SourceCodes {
+				if score, ok := sources[code]; ok {
+					operands = append(operands, score)
+				}
+			}
+			score, err := Aggregate(f.Formula, operands, f.Threshold)
+			if err != nil {
+				return nil, fmt.Errorf("factor %s: %w", f.Code, err)
+			}
+			factorScores[f.Code] = score
+		}
+	}
+	return factorScores, nil
+}
+
+// ResolveBand 获取分数所在的分数段，没有分数段包含该分数时返回 nil
+func ResolveBand(bands []Band, score float64) *Band {
+	for i := range bands {
+		if bands[i].Contains(score) {
+			return &bands[i]
+		}
+	}
+	return nil
+}
+
+// questionCodes 按问卷顺序返回题目编码
+func (q *Questionnaire) questionCodes() []string {
+	codes := make([]string, 0, len(q.Questions))
+	for _, qu := range q.Questions {
+		codes = append(codes, qu.Code)
+	}
+	return codes
+}
+
+// baseOptionScores 选项编码到选项分值的映射
+func (qu Question) baseOptionScores() map[string]float64 {
+	scores := make(map[string]float64, len(qu.Options))
+	for _, opt := range qu.Options {
+		scores[opt.Code] = opt.Score
+	}
+	return scores
+}
+
+// optionScores 获取本次作答适用的选项分值，引用题目的答案匹配条件计分映射时使用映射分值
+func (qu Question) optionScores(answers Answers) map[string]float64 {
+	scores := qu.baseOptionScores()
+	if qu.Conditional == nil {
+		return scores
+	}
+
+	referenced, ok := answers[qu.Conditional.DependsOn]
+	if !ok {
+		return scores
+	}
+	for _, mapping := range qu.Conditional.Mappings {
+		if answerMatches(referenced, mapping.WhenValue) {
+			return mergeScores(scores, mapping.Scores)
+		}
+	}
+	return scores
+}
+
+// answerMatches 判断答案是否等于给定值，多选题答案包含该选项即匹配
+func answerMatches(value any, want string) bool {
+	if codes := optionCodes(value); codes != nil {
+		for _, code := range codes {
+			if code == want {
+				return true
+			}
+		}
+		return false
+	}
+	return fmt.Sprint(value) == want
+}
+
+// optionCodes 将多选题答案转换为选项编码列表，答案不是列表时返回 nil
+func optionCodes(value any) []string {
+	switch v := value.(type) {
+	case []string:
+		return v
+	case []any:
+		codes := make([]string, 0, len(v))
+		for _, item := range v {
+			codes = append(codes, fmt.Sprint(item))
+		}
+		return codes
+	default:
+		return nil
+	}
+}
+
+// mergeScores 以 overrides 覆盖 base 中的分值，返回新的映射
+func mergeScores(base, overrides map[string]float64) map[string]float64 {
+	merged := make(map[string]float64, len(base)+len(overrides))
+	for code, score := range base {
+		merged[code] = score
+	}
+	for code, score := range overrides {
+		merged[code] = score
+	}
+	return merged
+}
+
+// optionsMaxScore 按题型计算最高可得分，单选题取最高分，多选题取正分之和，其余题型不计分
+func optionsMaxScore(questionType string, optionScores map[string]float64) float64 {
+	var maxScore float64
+	switch questionType {
+	case QuestionTypeRadio:
+		for _, score := range optionScores {
+			if score > maxScore {
+				maxScore = score
+			}
+		}
+	case QuestionTypeCheckbox:
+		for _, score := range optionScores {
+			if score > 0 {
+				maxScore += score
+			}
+		}
+	}
+	return maxScore
+}
+
+// optionsMinScore 按题型计算最低可得分，单选题取最低分，多选题取负分之和，其余题型不计分
+func optionsMinScore(questionType string, optionScores map[string]float64) float64 {
+	var minScore float64
+	switch questionType {
+	case QuestionTypeRadio:
+		for _, score := range optionScores {
+			if score < minScore {
+				minScore = score
+			}
+		}
+	case QuestionTypeCheckbox:
+		for _, score := range optionScores {
+			if score < 0 {
+				minScore += score
+			}
+		}
+	}
+	return minScore
+}
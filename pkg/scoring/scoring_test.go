@@ -0,0 +1,163 @@
+package scoring
+
+import (
+	"encoding/json"
+	"math"
+	"os"
+	"testing"
+)
+
+// loadDefinitions 从 testdata 加载问卷和医学量表定义
+func loadDefinitions(t *testing.T) (*Questionnaire, *Scale) {
+	t.Helper()
+	qData, err := os.ReadFile("testdata/questionnaire.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	q, err := LoadQuestionnaire(qData)
+	if err != nil {
+		t.Fatalf("LoadQuestionnaire() error = %v", err)
+	}
+
+	sData, err := os.ReadFile("testdata/scale.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	scale, err := LoadScale(sData)
+	if err != nil {
+		t.Fatalf("LoadScale() error = %v", err)
+	}
+	return q, scale
+}
+
+func TestScore_FromJSONDefinitions(t *testing.T) {
+	q, scale := loadDefinitions(t)
+
+	var answers Answers
+	if err := json.Unmarshal([]byte(`{"q1": "d", "q2": "b", "q3": ["sleep", "appetite"], "q4": "often", "q5": "severe", "note": "最近睡不好"}`), &answers); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := Score(q, scale, answers)
+	if err != nil {
+		t.Fatalf("Score() error = %v", err)
+	}
+
+	// q4 按 q5=severe 的条件映射计分
+	wantQuestions := map[string]float64{"q1": 3, "q2": 1, "q3": 2, "q4": 4, "q5": 0}
+	for code, want := range wantQuestions {
+		if got := result.QuestionScores[code]; got != want {
+			t.Errorf("QuestionScores[%s] = %v, want %v", code, got, want)
+		}
+	}
+	if _, ok := result.QuestionScores["note"]; ok {
+		t.Error("QuestionScores contains unscored question note")
+	}
+
+	if result.TotalScore != 10 {
+		t.Errorf("TotalScore = %v, want 10", result.TotalScore)
+	}
+	if result.Band == nil || result.Band.Label != "中重度" {
+		t.Errorf("Band = %+v, want 中重度", result.Band)
+	}
+	// 最高可得分 3 + 3 + 2 + 4 + 0 = 12
+	if want := 10.0 / 12 * 100; math.Abs(result.PercentageScore-want) > 1e-9 {
+		t.Errorf("PercentageScore = %v, want %v", result.PercentageScore, want)
+	}
+
+	wantFactors := []FactorScore{
+		{Code: "mood", Score: 4},
+		{Code: "somatic", Score: 3},
+		{Code: "severe_items", Score: 2},
+		{Code: "total", Score: 7},
+	}
+	if len(result.Factors) != len(wantFactors) {
+		t.Fatalf("Factors = %+v, want %d factors", result.Factors, len(wantFactors))
+	}
+	for i, want := range wantFactors {
+		if got := result.Factors[i]; got.Code != want.Code || got.Score != want.Score {
+			t.Errorf("Factors[%d] = %s:%v, want %s:%v", i, got.Code, got.Score, want.Code, want.Score)
+		}
+	}
+	if band := result.Factors[0].Band; band == nil || band.Label != "情绪低落" {
+		t.Errorf("Factors[mood].Band = %+v, want 情绪低落", band)
+	}
+}
+
+func TestScore_WithoutScale(t *testing.T) {
+	q, _ := loadDefinitions(t)
+
+	result, err := Score(q, nil, Answers{"q1": "c", "q4": "rarely"})
+	if err != nil {
+		t.Fatalf("Score() error = %v", err)
+	}
+	// q5 未作答，q4 按选项分值计分
+	if result.TotalScore != 3 || result.Band != nil || len(result.Factors) != 0 {
+		t.Errorf("Score() = %+v, want total 3 without bands and factors", result)
+	}
+}
+
+func TestLoadScale_Invalid(t *testing.T) {
+	for _, data := range []string{
+		`{"factors": [{"code": "a", "type": "primary", "formula": "median"}]}`,
+		`{"factors": [{"code": "a", "type": "primary", "formula": "sum"}, {"code": "a", "type": "primary", "formula": "sum"}]}`,
+		`{"bands": [{"min": 5, "max": 5, "label": "x"}]}`,
+		`not json`,
+	} {
+		if _, err := LoadScale([]byte(data)); err == nil {
+			t.Errorf("LoadScale(%s) error = nil", data)
+		}
+	}
+}
+
+func TestScore_WithStrategy(t *testing.T) {
+	q, _ := loadDefinitions(t)
+	scale, err := LoadScale([]byte(`{"code": "PHQ4-SCALE", "strategy": {"type": "composite", "strategies": [
+		{"type": "weighted_sum", "weights": {"q1": 2}},
+		{"type": "subscale_sum", "subscales": {"mood": ["q1", "q2"]}},
+		{"type": "formula", "code": "severe_items", "formula": "count_above_threshold", "source_codes": ["q1", "q2"], "threshold": 2}
+	]}}`))
+	if err != nil {
+		t.Fatalf("LoadScale() error = %v", err)
+	}
+
+	result, err := Score(q, scale, Answers{"q1": "d", "q2": "b"})
+	if err != nil {
+		t.Fatalf("Score() error = %v", err)
+	}
+	// 加权总分 3*2 + 1 = 7，以策略计算的总分为准
+	want := map[string]float64{TotalScoreCode: 7, "mood": 4, "severe_items": 1}
+	for code, score := range want {
+		if got, ok := result.Scores[code]; !ok || got != score {
+			t.Errorf("Scores[%s] = %v, want %v", code, got, score)
+		}
+	}
+	if result.TotalScore != 7 {
+		t.Errorf("TotalScore = %v, want 7", result.TotalScore)
+	}
+
+	if _, err := LoadScale([]byte(`{"strategy": {"type": "formula", "code": "x", "formula": "median"}}`)); err == nil {
+		t.Error("LoadScale() with unsupported strategy formula error = nil")
+	}
+}
+
+func TestQuestionnaire_ScoreBounds(t *testing.T) {
+	q := &Questionnaire{Questions: []Question{
+		{Code: "q1", Type: QuestionTypeRadio, Options: []Option{{Code: "a", Score: 0}, {Code: "b", Score: 3}}},
+		{Code: "q2", Type: QuestionTypeCheckbox, Options: []Option{{Code: "a", Score: -1}, {Code: "b", Score: -2}, {Code: "c", Score: 2}}},
+		{
+			Code: "q3", Type: QuestionTypeRadio,
+			Options: []Option{{Code: "a", Score: 1}, {Code: "b", Score: 2}},
+			Conditional: &Conditional{DependsOn: "q1", Mappings: []ScoreMapping{
+				{WhenValue: "b", Scores: map[string]float64{"a": -3}},
+			}},
+		},
+	}}
+
+	if got := q.MinScore(); got != -6 {
+		t.Errorf("MinScore() = %v, want -6", got)
+	}
+	if got := q.MaxScore(); got != 7 {
+		t.Errorf("MaxScore() = %v, want 7", got)
+	}
+}
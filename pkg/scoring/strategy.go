@@ -0,0 +1,127 @@
+package scoring
+
+import "fmt"
+
+// TotalScoreCode 总分的得分编码，策略计算出该编码的得分时以其作为总分
+const TotalScoreCode = "total"
+
+// 计分策略类型，与服务端量表的计分策略配置一致
+const (
+	StrategyTypeSimpleSum   = "simple_sum"   // 简单求和，总分为各题得分之和
+	StrategyTypeSubscaleSum = "subscale_sum" // 分量表求和，各分量表得分为其题目得分之和
+	StrategyTypeWeightedSum = "weighted_sum" // 加权求和，未配置权重的题目权重为 1
+	StrategyTypeFormula     = "formula"      // 按聚合公式由源题目得分计算一项得分
+	StrategyTypeComposite   = "composite"    // 依次执行子策略，编码相同的得分以后执行的为准
+)
+
+// Strategy 计分策略配置，例如：
+//
+//	{"type": "composite", "strategies": [
+//	    {"type": "simple_sum"},
+//	    {"type": "subscale_sum", "subscales": {"anxiety": ["q1", "q2"]}},
+//	    {"type": "formula", "code": "severe_items", "formula": "count_above_threshold", "source_codes": ["q1", "q2"], "threshold": 2}
+//	]}
+type Strategy struct {
+	Type        string              `json:"type"`
+	Subscales   map[string][]string `json:"subscales,omitempty"`    // 分量表编码到题目编码的映射
+	Weights     map[string]float64  `json:"weights,omitempty"`      // 题目编码到权重的映射
+	Code        string              `json:"code,omitempty"`         // 公式策略的得分编码
+	Formula     string              `json:"formula,omitempty"`      // 公式策略的聚合公式
+	SourceCodes []string            `json:"source_codes,omitempty"` // 公式策略的源题目编码
+	Threshold   float64             `json:"threshold,omitempty"`    // 计数公式的阈值
+	Strategies  []Strategy          `json:"strategies,omitempty"`   // 组合策略的子策略
+}
+
+// Validate 校验计分策略配置
+func (s Strategy) Validate() error {
+	switch s.Type {
+	case StrategyTypeSimpleSum, StrategyTypeWeightedSum:
+		return nil
+	case StrategyTypeSubscaleSum:
+		if len(s.Subscales) == 0 {
+			return fmt.Errorf("subscale_sum strategy has no subscales")
+		}
+		return nil
+	case StrategyTypeFormula:
+		if s.Code == "" {
+			return fmt.Errorf("formula strategy has no code")
+		}
+		if !IsSupportedFormula(s.Formula) {
+			return fmt.Errorf("formula strategy %q has unsupported formula %q", s.Code, s.Formula)
+		}
+		return nil
+	case StrategyTypeComposite:
+		if len(s.Strategies) == 0 {
+			return fmt.Errorf("composite strategy has no strategies")
+		}
+		for i, sub := range s.Strategies {
+			if err := sub.Validate(); err != nil {
+				return fmt.Errorf("strategy at index %d: %w", i, err)
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("unknown strategy type %q", s.Type)
+	}
+}
+
+// Apply 按策略由题目得分计算各项得分，以得分编码为键
+// questionCodes 为参与计分的题目编码，questionScores 为已作答题目的得分，未作答的题目不参与计算
+func (s Strategy) Apply(questionCodes []string, questionScores map[string]float64) (map[string]float64, error) {
+	scores := make(map[string]float64)
+	switch s.Type {
+	case StrategyTypeSimpleSum:
+		var total float64
+		for _, code := range questionCodes {
+			total += questionScores[code]
+		}
+		scores[TotalScoreCode] = total
+	case StrategyTypeSubscaleSum:
+		for subscale, codes := range s.Subscales {
+			var total float64
+			for _, code := range codes {
+				total += questionScores[code]
+			}
+			scores[subscale] = total
+		}
+	case StrategyTypeWeightedSum:
+		var total float64
+		for _, code := range questionCodes {
+			score, ok := questionScores[code]
+			if !ok {
+				continue
+			}
+			weight, ok := s.Weights[code]
+			if !ok {
+				weight = 1
+			}
+			total += score * weight
+		}
+		scores[TotalScoreCode] = total
+	case StrategyTypeFormula:
+		operands := make([]float64, 0, len(s.SourceCodes))
+		for _, code := range s.SourceCodes {
+			if score, ok := questionScores[code]; ok {
+				operands = append(operands, score)
+			}
+		}
+		score, err := Aggregate(s.Formula, operands, s.Threshold)
+		if err != nil {
+			return nil, fmt.Errorf("formula strategy %s: %w", s.Code, err)
+		}
+		scores[s.Code] = score
+	case StrategyTypeComposite:
+		for _, sub := range s.Strategies {
+			subScores, err := sub.Apply(questionCodes, questionScores)
+			if err != nil {
+				return nil, err
+			}
+			for code, score := range subScores {
+				scores[code] = score
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown strategy type %q", s.Type)
+	}
+	return scores, nil
+}
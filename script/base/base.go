@@ -188,6 +188,7 @@ func (env *ScriptEnv) initMySQL() error {
 		MaxIdleConnections:    env.Config.MySQLOptions.MaxIdleConnections,
 		MaxOpenConnections:    env.Config.MySQLOptions.MaxOpenConnections,
 		MaxConnectionLifeTime: env.Config.MySQLOptions.MaxConnectionLifeTime,
+		MaxConnectionIdleTime: env.Config.MySQLOptions.MaxConnectionIdleTime,
 		LogLevel:              env.Config.MySQLOptions.LogLevel,
 	}
 
@@ -244,6 +245,9 @@ func (env *ScriptEnv) initMongoDB() error {
 		SSLAllowInvalidHostnames: env.Config.MongoDBOptions.SSLAllowInvalidHostnames,
 		SSLCAFile:                env.Config.MongoDBOptions.SSLCAFile,
 		SSLPEMKeyfile:            env.Config.MongoDBOptions.SSLPEMKeyfile,
+		MaxPoolSize:              env.Config.MongoDBOptions.MaxPoolSize,
+		MinPoolSize:              env.Config.MongoDBOptions.MinPoolSize,
+		MaxConnIdleTime:          env.Config.MongoDBOptions.MaxConnIdleTime,
 	}
 
 	if mongoConfig.URL == "" {
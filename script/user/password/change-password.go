@@ -45,7 +45,7 @@ func (s *PasswordChangeScript) Initialize() error {
 		return err
 	}
 	userRepo := userInfra.NewRepository(db)
-	s.passwordChanger = userApp.NewPasswordChanger(userRepo)
+	s.passwordChanger = userApp.NewPasswordChanger(userRepo, nil, nil)
 	s.query = userApp.NewUserQueryer(userRepo)
 
 	return nil
@@ -50,6 +50,11 @@ db.system_configs.createIndex({ "key": 1 }, { unique: true }); // 唯一索引
 db.system_configs.createIndex({ "updated_at": 1 });
 db.system_configs.createIndex({ "updated_by": 1 });
 
+// 为user_activity_logs集合创建索引
+print('创建user_activity_logs索引...');
+db.user_activity_logs.createIndex({ "user_id": 1, "occurred_at": -1 }); // 复合索引，按用户和时间范围查询
+db.user_activity_logs.createIndex({ "occurred_at": 1 }, { expireAfterSeconds: 7776000 }); // TTL索引，90天后过期
+
 print('基础索引创建完成');
 
 // 创建复合索引用于复杂查询
@@ -85,6 +90,24 @@ db.submission_logs.createIndex({
   background: true
 });
 
+// 问卷标签的复合索引 - 用于按标签查询未删除的问卷
+db.questionnaires.createIndex({
+  "tags": 1,
+  "deleted_at": 1
+}, {
+  name: "questionnaire_tags_idx",
+  background: true
+});
+
+// 答卷按问卷分批遍历的复合索引 - 用于重新计分
+db.answersheets.createIndex({
+  "questionnaire_code": 1,
+  "domain_id": 1
+}, {
+  name: "answersheet_questionnaire_domain_idx",
+  background: true
+});
+
 print('复合索引创建完成');
 
 // 创建文本索引（用于全文搜索）
@@ -141,6 +164,11 @@ db.system_configs.getIndexes().forEach(function(index) {
   print('  - ' + index.name + ': ' + JSON.stringify(index.key));
 });
 
+print('user_activity_logs集合索引:');
+db.user_activity_logs.getIndexes().forEach(function(index) {
+  print('  - ' + index.name + ': ' + JSON.stringify(index.key));
+});
+
 print('');
 print('所有索引创建完成！');
 
@@ -23,4 +23,12 @@ protoc --proto_path=${PROTO_PATH} \
        --go-grpc_opt=paths=source_relative \
        ${PROTO_PATH}/questionnaire/questionnaire.proto
 
+# 生成 scoring 服务代码
+protoc --proto_path=${PROTO_PATH} \
+       --go_out=${GO_OUT_PATH} \
+       --go_opt=paths=source_relative \
+       --go-grpc_out=${GO_OUT_PATH} \
+       --go-grpc_opt=paths=source_relative \
+       ${PROTO_PATH}/scoring/scoring.proto
+
 echo "Proto files generated successfully!" 
\ No newline at end of file
@@ -57,7 +57,7 @@ RESPONSE_BODY=$(echo "$LOGIN_RESPONSE" | sed '/HTTP_STATUS/d')
 
 echo "   登录状态码: $HTTP_STATUS"
 if [ "$HTTP_STATUS" = "200" ]; then
-    TOKEN=$(echo "$RESPONSE_BODY" | jq -r '.token // "none"')
+    TOKEN=$(echo "$RESPONSE_BODY" | jq -r '.data.token // "none"')
     echo "   Token: ${TOKEN:0:30}..."
     
     # 使用token测试受保护路由